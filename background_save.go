@@ -0,0 +1,120 @@
+// This file writes auto-saves in the background while the REPL runs. Most of
+// the time spent saving a large Pokédex goes to encoding it, and doing that
+// between commands would hold up the next prompt. Instead, the Pokédex and
+// settings are copied while the REPL waits, which is quick (Export copies every
+// entry, so later changes don't reach the copy), and the copy is encoded and
+// written on another goroutine while the user carries on.
+//
+// One save is written at a time. If more are taken while one is being
+// written, only the newest is written next, since it includes the others'
+// changes. Saves the user asks for, and the save when exiting, are still
+// written before the command finishes, after waiting for the background save,
+// so that an older save never replaces a newer one.
+package main
+
+import (
+	"sync"
+)
+
+// backgroundSaves writes the auto-saves taken while the REPL runs.
+type backgroundSaves struct {
+	mutex sync.Mutex    // Protects next and idle
+	next  *pendingSave  // The newest save waiting to be written, if any
+	idle  chan struct{} // Closed once no save is being written (nil when none is)
+}
+
+// add queues a save to be written, starting to write if nothing is being
+// written. A save that was already waiting is replaced.
+//
+// Parameters:
+//   - cfg: The application configuration, for writing the save and reporting errors
+//   - save: The save to write
+func (b *backgroundSaves) add(cfg *config, save pendingSave) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.next != nil {
+		// The replaced save's changes are only saved once this one is
+		save.changes += b.next.changes
+	}
+	b.next = &save
+	if b.idle == nil {
+		b.idle = make(chan struct{})
+		go b.run(cfg)
+	}
+}
+
+// run writes the waiting saves until there are none. A save that can't be
+// written is reported before the next prompt, and its changes count as
+// unsaved again, so the next auto-save or exit tries again.
+func (b *backgroundSaves) run(cfg *config) {
+	for {
+		b.mutex.Lock()
+		save := b.next
+		b.next = nil
+		if save == nil {
+			close(b.idle)
+			b.idle = nil
+			b.mutex.Unlock()
+			return
+		}
+		b.mutex.Unlock()
+
+		if err := writeSave(cfg, *save); err != nil {
+			cfg.mutex.Lock()
+			cfg.changesSinceSync += save.changes
+			cfg.mutex.Unlock()
+			notify(cfg, "Warning: Could not auto-save: %v\n", err)
+		}
+	}
+}
+
+// wait waits until every queued save has been written.
+func (b *backgroundSaves) wait() {
+	b.mutex.Lock()
+	idle := b.idle
+	b.mutex.Unlock()
+	if idle != nil {
+		<-idle
+	}
+}
+
+// saveInBackground saves the Pokédex like savePokedexData, but while the REPL
+// runs, only the copy of the data is taken before returning, and it's written
+// in the background. Failures are reported with notify rather than returned.
+// Without a REPL, and while the sandbox is on, the save is written right away.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex to save
+//
+// Returns:
+//   - An error if the save was written right away and failed
+func saveInBackground(cfg *config) error {
+	// The sandbox's saved state belongs to the REPL, so it's not written in the background
+	if cfg.events == nil || cfg.sandbox != nil || cfg.dryRun || cfg.batch != nil {
+		return savePokedexData(cfg)
+	}
+
+	save, err := takeSave(cfg)
+	if err != nil {
+		return err
+	}
+	// The changes count as saved once the copy is taken, so the prompt
+	// doesn't show unsaved changes while it's being written
+	cfg.mutex.Lock()
+	save.changes = cfg.changesSinceSync
+	cfg.changesSinceSync = 0
+	cfg.mutex.Unlock()
+	cfg.events.saves.add(cfg, save)
+	return nil
+}
+
+// waitForBackgroundSaves waits for the auto-saves being written in the
+// background, if any. Anything that writes or reads the save file waits first.
+//
+// Parameters:
+//   - cfg: The application configuration
+func waitForBackgroundSaves(cfg *config) {
+	if cfg.events != nil {
+		cfg.events.saves.wait()
+	}
+}
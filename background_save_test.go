@@ -0,0 +1,62 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestSaveInBackground tests that an auto-save in the REPL saves the Pokédex
+// as it was when the save was taken, even if it changes while being written
+func TestSaveInBackground(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings(), events: &eventLoop{}}
+	pikachu := pokedex.NewEntry(pokeapi.PokemonDataResp{Name: "pikachu"})
+	pikachu.EVs = map[string]int{"speed": 4}
+	cfg.pokedex.Add("pikachu", pikachu)
+	cfg.changesSinceSync = 1
+
+	if err := saveInBackground(cfg); err != nil {
+		t.Fatalf("saveInBackground returned an error: %v", err)
+	}
+	if hasUnsavedChanges(cfg) {
+		t.Error("Expected the changes to count as saved once the save was taken")
+	}
+
+	// Change the Pokédex while the save may still be being written
+	entry, _ := cfg.pokedex.Get("pikachu")
+	entry.EVs["speed"] = 8
+	cfg.pokedex.Add("eevee", pokedex.NewEntry(pokeapi.PokemonDataResp{Name: "eevee"}))
+
+	waitForBackgroundSaves(cfg)
+	saved, found, err := pokedex.ReadFile(saveFile.path())
+	if err != nil || !found {
+		t.Fatalf("Failed to read the save: found=%v, err=%v", found, err)
+	}
+	if len(saved.Pokedex) != 1 || saved.Pokedex["pikachu"].EVs["speed"] != 4 {
+		t.Errorf("Expected the Pokédex from when the save was taken, got %+v", saved.Pokedex)
+	}
+}
+
+// TestSaveInBackgroundFailure tests that an auto-save that can't be written is
+// reported before the next prompt, and its changes count as unsaved again
+func TestSaveInBackgroundFailure(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings(), events: &eventLoop{}}
+	cfg.saveFilePath = filepath.Join(t.TempDir(), "missing", "pokedex.json")
+	cfg.changesSinceSync = 2
+
+	if err := saveInBackground(cfg); err != nil {
+		t.Fatalf("saveInBackground returned an error: %v", err)
+	}
+	waitForBackgroundSaves(cfg)
+	if cfg.changesSinceSync != 2 {
+		t.Errorf("Expected 2 unsaved changes, got %d", cfg.changesSinceSync)
+	}
+	if len(cfg.events.messages) != 1 || !strings.HasPrefix(cfg.events.messages[0], "Warning: Could not auto-save") {
+		t.Errorf("Expected the failure to be reported, got %q", cfg.events.messages)
+	}
+}
@@ -0,0 +1,112 @@
+// This file keeps a versioned history of the save file in a local git
+// repository next to it, and pushes that history to a git remote. Once a
+// remote is set with 'backup git', every save is committed, and the history
+// is pushed on demand or after a chosen number of saves.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/git"
+)
+
+// backupFileName is the save file's name in the backup repository.
+const backupFileName = "pokedex.json"
+
+// backupEmail is the email address of backup commits, which are made by the app.
+const backupEmail = "pokedexcli@localhost"
+
+// backupPushTimeout is how long pushing to a remote may take.
+const backupPushTimeout = 30 * time.Second
+
+// getBackupRepoPath returns the full path to the local git repository the save
+// file is committed to. Like the save file, it's in the data directory.
+func getBackupRepoPath() string {
+	return backupDir.path()
+}
+
+// backupSaveFile commits the save file to the backup repository if a backup
+// remote is set, and pushes once the chosen number of saves haven't been
+// pushed. A backup that fails is reported as a warning, since the save
+// itself succeeded.
+//
+// Parameters:
+//   - cfg: The application configuration containing the backup settings
+//   - saveFilePath: The path of the save file that was just written
+func backupSaveFile(cfg *config, saveFilePath string) {
+	current := cfg.Settings()
+	if current.backupRemote == "" {
+		return
+	}
+	repo, err := git.Init(getBackupRepoPath())
+	if err == nil {
+		_, err = commitBackup(cfg, repo, saveFilePath)
+	}
+	if err == nil && current.backupEvery > 0 {
+		var unpushed int
+		if unpushed, err = repo.Unpushed(); err == nil && unpushed >= current.backupEvery {
+			err = pushBackup(repo, current.backupRemote)
+		}
+	}
+	if err != nil {
+		notify(cfg, "Warning: Could not back up the save file: %v\n", err)
+	}
+}
+
+// commitBackup commits the save file to the backup repository.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex, for the commit message
+//   - repo: The backup repository
+//   - saveFilePath: The path of the save file
+//
+// Returns:
+//   - Whether a commit was made; nothing is committed if the file hasn't changed
+//   - An error if the save file can't be read or the commit can't be made
+func commitBackup(cfg *config, repo *git.Repo, saveFilePath string) (bool, error) {
+	data, err := os.ReadFile(saveFilePath)
+	if err != nil {
+		return false, err
+	}
+	stats := cfg.pokedex.Stats()
+	message := fmt.Sprintf("Save the Pokédex: %d caught, %d seen", stats.Total, stats.Seen)
+	author := git.Signature{Name: defaultTrainerName(), Email: backupEmail, When: time.Now()}
+	_, changed, err := repo.CommitFile(backupFileName, data, message, author)
+	return changed, err
+}
+
+// pushBackup pushes the backup repository to a remote.
+func pushBackup(repo *git.Repo, remote string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), backupPushTimeout)
+	defer cancel()
+	return repo.Push(ctx, remote)
+}
+
+// displayRemote returns a remote for display, or for a crash report, without
+// the user name, password, or token in its URL. Tokens are often given as the
+// user name (https://<token>@github.com/...), so the whole user part is left
+// out, even if the URL doesn't parse.
+func displayRemote(remote string) string {
+	if !git.IsHTTP(remote) {
+		return remote
+	}
+	u, err := url.Parse(remote)
+	if err == nil {
+		u.User = nil
+		return u.String()
+	}
+	scheme, rest, _ := strings.Cut(remote, "://")
+	authority, path, hasPath := strings.Cut(rest, "/")
+	if at := strings.LastIndex(authority, "@"); at >= 0 {
+		authority = authority[at+1:]
+	}
+	if hasPath {
+		authority += "/" + path
+	}
+	return scheme + "://" + authority
+}
@@ -0,0 +1,121 @@
+// This file implements batch mode for the Pokédex CLI application.
+// When commands are piped in rather than typed at a terminal, there is nobody
+// to answer prompts, so prompts are answered "no" without reading input. The
+// outcome of every command is recorded, and a summary is printed at the end with
+// a non-zero exit status if any command failed, so scripts can check the result.
+// Saves are deferred until the batch ends and then written at once, or rolled
+// back if a command crashed.
+package main
+
+import (
+	"os"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// batchResults collects the outcome of each command run in batch mode.
+type batchResults struct {
+	commands    int            // Number of commands run
+	failures    []batchFailure // Commands that failed, in the order they ran
+	pendingSave bool           // Whether a save was deferred until the batch ends
+	crashed     bool           // Whether a command crashed, so the deferred save is rolled back
+}
+
+// batchFailure describes a command that failed in batch mode.
+type batchFailure struct {
+	line    int    // The input line the command was read from
+	input   string // The command as it was entered
+	message string // The user-friendly error message
+}
+
+// stdinIsTerminal reports whether standard input is an interactive terminal
+// rather than a pipe or file.
+func stdinIsTerminal() bool {
+	stat, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// record adds the outcome of a command to the results.
+//
+// Parameters:
+//   - line: The input line number of the command
+//   - input: The command as it was entered
+//   - err: The error the command failed with, or nil if it succeeded
+func (b *batchResults) record(line int, input string, err error) {
+	b.commands++
+	if err != nil {
+		b.failures = append(b.failures, batchFailure{
+			line:    line,
+			input:   input,
+			message: errorhandling.FormatUserMessage(err),
+		})
+	}
+}
+
+// printSummary displays how many commands succeeded and lists those that failed.
+func (b *batchResults) printSummary() {
+	noun := i18n.T("commands")
+	if b.commands == 1 {
+		noun = i18n.T("command")
+	}
+	i18n.Printf("Batch summary: %d %s run, %d succeeded, %d failed\n",
+		b.commands, noun, b.commands-len(b.failures), len(b.failures))
+	if len(b.failures) > 0 {
+		i18n.Println("Failed commands:")
+		for _, f := range b.failures {
+			i18n.Printf(" - line %d: %s (%s)\n", f.line, f.input, f.message)
+		}
+	}
+	printSeparator()
+}
+
+// commitBatch writes the changes made during the batch to the save file in a
+// single atomic write. If a command crashed, the state may be inconsistent, so the
+// changes are rolled back instead and the save file is left as it was before the batch.
+//
+// Parameters:
+//   - cfg: The application configuration containing the batch results and the Pokédex
+//
+// Returns:
+//   - An error if the save file couldn't be written
+func commitBatch(cfg *config) error {
+	if cfg.batch == nil || !cfg.batch.pendingSave {
+		return nil
+	}
+	cfg.batch.pendingSave = false
+	if cfg.batch.crashed {
+		i18n.Println("A command crashed, so the changes made by this batch were not saved.")
+		return nil
+	}
+	defer setSaveTrigger(cfg, saveTriggerBatch)()
+	return writeSaveFile(cfg)
+}
+
+// finishBatch saves the changes made during the batch and prints the batch
+// summary, if running in batch mode, and returns the exit status for the
+// program: 1 if any command failed or the changes couldn't be saved, 0 otherwise.
+//
+// Parameters:
+//   - cfg: The application configuration containing the batch results
+//
+// Returns:
+//   - The exit status to use
+func finishBatch(cfg *config) int {
+	if cfg.batch == nil {
+		return 0
+	}
+	status := 0
+	if err := commitBatch(cfg); err != nil {
+		i18n.Printf("Warning: Could not save Pokédex data: %v\n", err)
+		status = 1
+	}
+	cfg.batch.printSummary()
+	if len(cfg.batch.failures) > 0 {
+		status = 1
+	}
+	return status
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestBatchResults tests that batch mode counts failed commands and sets the exit status
+func TestBatchResults(t *testing.T) {
+	cfg := &config{}
+	if status := finishBatch(cfg); status != 0 {
+		t.Errorf("Expected exit status 0 outside batch mode, got %d", status)
+	}
+
+	cfg.batch = &batchResults{}
+	cfg.batch.record(1, "pokedex", nil)
+	if status := finishBatch(cfg); status != 0 {
+		t.Errorf("Expected exit status 0 when every command succeeded, got %d", status)
+	}
+
+	cfg.batch.record(2, "catch qwerty", errors.New("no such Pokémon"))
+	if cfg.batch.commands != 2 || len(cfg.batch.failures) != 1 || cfg.batch.failures[0].line != 2 {
+		t.Errorf("Unexpected batch results: %+v", cfg.batch)
+	}
+	if status := finishBatch(cfg); status != 1 {
+		t.Errorf("Expected exit status 1 after a failed command, got %d", status)
+	}
+
+	// Prompts are answered no without reading input
+	if confirm(cfg, "Proceed?") {
+		t.Error("Expected confirmation to be declined in batch mode")
+	}
+}
+
+// TestBatchDefersSaves tests that saves in batch mode are written once when the
+// batch ends, and rolled back if a command crashed
+func TestBatchDefersSaves(t *testing.T) {
+	useTempHome(t)
+	savePath := saveFile.path()
+
+	cfg := &config{pokedex: pokedex.New(), batch: &batchResults{}}
+	cfg.pokedex.Add("pikachu", pokedex.Entry{})
+	if err := savePokedexData(cfg); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	if _, err := os.Stat(savePath); !os.IsNotExist(err) {
+		t.Fatalf("Expected the save to be deferred, got %v", err)
+	}
+
+	if status := finishBatch(cfg); status != 0 {
+		t.Errorf("Expected exit status 0, got %d", status)
+	}
+	saved, found, err := pokedex.ReadFile(savePath)
+	if err != nil || !found || len(saved.Pokedex) != 1 {
+		t.Fatalf("Expected the batch to be saved when it finished, got found=%v, err=%v, %v", found, err, saved.Pokedex)
+	}
+
+	// A crash rolls back the changes made during the batch
+	cfg.batch = &batchResults{}
+	crash := cliCommand{name: "crash", callback: func(cfg *config, params []string) error {
+		cfg.pokedex.Remove("pikachu")
+		savePokedexData(cfg)
+		panic("something went wrong")
+	}}
+	err = executeCommand(cfg, crash, nil)
+	cfg.batch.record(1, "crash", err)
+	if status := finishBatch(cfg); status != 1 {
+		t.Errorf("Expected exit status 1 after a crash, got %d", status)
+	}
+	saved, _, _ = pokedex.ReadFile(savePath)
+	if len(saved.Pokedex) != 1 {
+		t.Errorf("Expected the save file to be left as it was before the batch, got %v", saved.Pokedex)
+	}
+}
@@ -0,0 +1,140 @@
+// This file contains the strategies the computer uses to play one side of a
+// battle. Each difficulty plays a little better than the one before it: easy
+// opponents pick moves at random, normal opponents pick the move that does the
+// most damage, and hard opponents also switch out of bad type matchups.
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// battleDifficulty is how well a computer-controlled side plays.
+type battleDifficulty string
+
+const (
+	difficultyEasy   battleDifficulty = "easy"   // Picks moves at random and never switches
+	difficultyNormal battleDifficulty = "normal" // Picks the move expected to do the most damage
+	difficultyHard   battleDifficulty = "hard"   // Like normal, but switches out of bad type matchups
+)
+
+// battleDifficulties lists the difficulties from easiest to hardest.
+var battleDifficulties = []battleDifficulty{difficultyEasy, difficultyNormal, difficultyHard}
+
+// switchMargin is how much better a reserve's matchup must be than the matchup
+// of the Pokémon in battle before a hard opponent switches to it. Switching
+// gives up a turn, so it's only worth it for a clearly better matchup.
+const switchMargin = 0.5
+
+// parseDifficulty parses the name of a difficulty.
+func parseDifficulty(name string) (battleDifficulty, error) {
+	difficulty := battleDifficulty(strings.ToLower(name))
+	if !slices.Contains(battleDifficulties, difficulty) {
+		names := make([]string, 0, len(battleDifficulties))
+		for _, d := range battleDifficulties {
+			names = append(names, string(d))
+		}
+		return "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown difficulty '%s'. Choose one of: %s", name, strings.Join(names, ", ")), nil)
+	}
+	return difficulty, nil
+}
+
+// aiSide is a side of a battle played by the computer.
+type aiSide struct {
+	chart      typeChart        // A type chart covering every type in the battle
+	difficulty battleDifficulty // How well the side plays
+	intn       func(n int) int  // Returns a random number from 0 to n-1, such as rand.Intn
+}
+
+// chooseAction picks the side's action for the turn.
+func (a aiSide) chooseAction(teams [2]*battleTeam, side int) (battleAction, error) {
+	team, opponent := teams[side], teams[1-side].current()
+	mine := team.current()
+	if a.difficulty == difficultyEasy {
+		return battleAction{move: a.intn(len(mine.moves)), switchTo: -1}, nil
+	}
+
+	if a.difficulty == difficultyHard {
+		if best, ok := a.bestMatchup(team.reserves(), team, opponent); ok &&
+			matchupScore(a.chart, team.members[best], opponent) > matchupScore(a.chart, mine, opponent)+switchMargin {
+			return battleAction{switchTo: best}, nil
+		}
+	}
+	move, _ := bestMove(a.chart, mine, opponent)
+	return battleAction{move: move, switchTo: -1}, nil
+}
+
+// chooseReplacement picks the Pokémon to send out after the side's Pokémon fainted.
+func (a aiSide) chooseReplacement(teams [2]*battleTeam, side int) (int, error) {
+	team, opponent := teams[side], teams[1-side].current()
+	reserves := team.reserves()
+	switch a.difficulty {
+	case difficultyEasy:
+		return reserves[0], nil
+	case difficultyHard:
+		best, _ := a.bestMatchup(reserves, team, opponent)
+		return best, nil
+	}
+
+	best, bestDamage := reserves[0], -1.0
+	for _, i := range reserves {
+		if _, damage := bestMove(a.chart, team.members[i], opponent); damage > bestDamage {
+			best, bestDamage = i, damage
+		}
+	}
+	return best, nil
+}
+
+// bestMatchup returns the team member, of those given, with the best matchup
+// against the opponent, and false if none were given.
+func (a aiSide) bestMatchup(candidates []int, team *battleTeam, opponent *battler) (int, bool) {
+	if len(candidates) == 0 {
+		return 0, false
+	}
+	best, bestScore := candidates[0], matchupScore(a.chart, team.members[candidates[0]], opponent)
+	for _, i := range candidates[1:] {
+		if score := matchupScore(a.chart, team.members[i], opponent); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best, true
+}
+
+// matchupScore scores how well one Pokémon fares against another: the share of the
+// opponent's remaining HP its best move is expected to take, less the share of
+// its own HP the opponent's best move is expected to take. Scores range from -1
+// to 1, and higher is better.
+func matchupScore(chart typeChart, mine, theirs *battler) float64 {
+	_, dealt := bestMove(chart, mine, theirs)
+	_, taken := bestMove(chart, theirs, mine)
+	return min(dealt/float64(max(theirs.hp, 1)), 1) - min(taken/float64(max(mine.hp, 1)), 1)
+}
+
+// bestMove returns the index of the attacker's move expected to do the most
+// damage to the defender, and the damage expected. Ties go to the earlier move.
+func bestMove(chart typeChart, attacker, defender *battler) (int, float64) {
+	best, bestDamage := 0, -1.0
+	for i, move := range attacker.moves {
+		if damage := expectedDamage(chart, attacker, defender, move); damage > bestDamage {
+			best, bestDamage = i, damage
+		}
+	}
+	return best, max(bestDamage, 0)
+}
+
+// expectedDamage returns the average damage a move does, allowing for the
+// chance that it misses. The weather and terrain aren't allowed for.
+func expectedDamage(chart typeChart, attacker, defender *battler, move battleMove) float64 {
+	if move.power == 0 {
+		return 0
+	}
+	damage, _ := battleDamage(chart, nil, attacker, defender, move, 0.5)
+	if move.accuracy > 0 {
+		return float64(damage) * float64(move.accuracy) / 100
+	}
+	return float64(damage)
+}
@@ -0,0 +1,91 @@
+package main
+
+import "testing"
+
+// TestAIChooseAction tests the moves and switches each difficulty picks
+func TestAIChooseAction(t *testing.T) {
+	shock := battleMove{typeName: "electric", power: 90, special: true}
+	surf := battleMove{typeName: "water", power: 90, special: true}
+	quake := battleMove{typeName: "ground", power: 100}
+	first := func(int) int { return 0 }
+
+	// Against Diglett, Pikachu's Electric move does nothing and Squirtle's Water move is super effective
+	newTeams := func() [2]*battleTeam {
+		return [2]*battleTeam{
+			{player: "Player", members: []*battler{testBattler("diglett", 100, []string{"ground"}, quake)}},
+			{player: "Gym Leader", members: []*battler{
+				testBattler("pikachu", 100, []string{"electric"}, shock),
+				testBattler("squirtle", 100, []string{"water"}, shock, surf),
+			}},
+		}
+	}
+
+	cases := []struct {
+		difficulty battleDifficulty
+		active     int
+		want       battleAction
+	}{
+		{difficultyEasy, 1, battleAction{move: 0, switchTo: -1}},   // Whatever the roll picks
+		{difficultyNormal, 1, battleAction{move: 1, switchTo: -1}}, // The super-effective move
+		{difficultyNormal, 0, battleAction{move: 0, switchTo: -1}}, // Normal opponents never switch
+		{difficultyHard, 0, battleAction{switchTo: 1}},             // Out of a hopeless matchup
+		{difficultyHard, 1, battleAction{move: 1, switchTo: -1}},   // Already in a good matchup
+	}
+	for _, c := range cases {
+		teams := newTeams()
+		teams[1].active = c.active
+		ai := aiSide{chart: testTypeChart(), difficulty: c.difficulty, intn: first}
+		got, err := ai.chooseAction(teams, 1)
+		if err != nil || got != c.want {
+			t.Errorf("%s with %s in battle: got %+v (%v), expected %+v",
+				c.difficulty, teams[1].current().name, got, err, c.want)
+		}
+	}
+}
+
+// TestAIChooseReplacement tests the Pokémon each difficulty sends out next
+func TestAIChooseReplacement(t *testing.T) {
+	shock := battleMove{typeName: "electric", power: 90, special: true}
+	surf := battleMove{typeName: "water", power: 90, special: true}
+	teams := [2]*battleTeam{
+		{members: []*battler{testBattler("diglett", 100, []string{"ground"}, battleMove{typeName: "ground", power: 100})}},
+		{members: []*battler{
+			testBattler("magnemite", 0, []string{"electric", "steel"}, shock),
+			testBattler("pikachu", 100, []string{"electric"}, shock),
+			testBattler("squirtle", 100, []string{"water"}, surf),
+		}},
+	}
+
+	want := map[battleDifficulty]int{difficultyEasy: 1, difficultyNormal: 2, difficultyHard: 2}
+	for difficulty, expected := range want {
+		ai := aiSide{chart: testTypeChart(), difficulty: difficulty}
+		if got, err := ai.chooseReplacement(teams, 1); err != nil || got != expected {
+			t.Errorf("%s: got %d (%v), expected %d", difficulty, got, err, expected)
+		}
+	}
+}
+
+// TestPlayBattleAgainstAI tests a whole battle between two computer-controlled sides
+func TestPlayBattleAgainstAI(t *testing.T) {
+	quake := battleMove{typeName: "ground", power: 100}
+	teams := [2]*battleTeam{
+		{player: "Player", members: []*battler{testBattler("pikachu", 100, []string{"electric"}, quake)}},
+		{members: []*battler{testBattler("diglett", 100, []string{"ground"}, quake)}},
+	}
+	chart := testTypeChart()
+	sides := [2]battleSide{
+		aiSide{chart: chart, difficulty: difficultyNormal},
+		aiSide{chart: chart, difficulty: difficultyNormal},
+	}
+
+	battle, err := playBattle(chart, teams, sides, func() float64 { return 0.9 })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if battle.Winner != 1 {
+		t.Errorf("Expected the wild Diglett to win, got winner %d", battle.Winner)
+	}
+	if opening := battle.Turns[0][1]; opening.Player != "" || opening.Pokemon != "diglett" {
+		t.Errorf("Expected the wild Diglett to appear, got %+v", opening)
+	}
+}
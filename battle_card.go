@@ -0,0 +1,343 @@
+// This file builds the battle card shown by 'card <pokemon>': a one-screen
+// summary of how a caught Pokémon fares in battle. It brings together the
+// Pokémon's stored data with its types' damage relations, its learnable moves,
+// and its abilities, each looked up from the API client's cache. The suggested
+// moves are scored as battle_utils.go deals damage, by power, accuracy, the
+// same-type attack bonus (STAB), and the attacking stat the move uses, and are
+// picked to cover as many types as they can.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// battleCardMoves is the number of moves the battle card suggests, as many as
+// a Pokémon can know at once.
+const battleCardMoves = pokedex.MaxMovesetSize
+
+// typeMultiplier is the damage an attacking type does to a Pokémon.
+type typeMultiplier struct {
+	typeName   string  // The attacking type
+	multiplier float64 // The damage multiplier (e.g. 4 for Ground against Electric/Steel)
+}
+
+// String formats the multiplier with its type (e.g. "Ground (4x)").
+func (t typeMultiplier) String() string {
+	return i18n.Sprintf("%s (%gx)", FormatTypeName(t.typeName), t.multiplier)
+}
+
+// defensiveMatchups sorts the standard types by the damage they do to a
+// Pokémon with the given types.
+//
+// Parameters:
+//   - chart: A type chart covering all standard types
+//   - types: The Pokémon's types
+//
+// Returns:
+//   - The types it's weak to, most damaging first
+//   - The types it resists, least damaging first
+//   - The types it's immune to, in standard order
+func defensiveMatchups(chart typeChart, types []string) (weak, resists []typeMultiplier, immune []string) {
+	for _, attacking := range standardTypes {
+		m := chart.effectiveness(attacking, types)
+		switch {
+		case m == 0:
+			immune = append(immune, attacking)
+		case m > 1:
+			weak = append(weak, typeMultiplier{attacking, m})
+		case m < 1:
+			resists = append(resists, typeMultiplier{attacking, m})
+		}
+	}
+	// Stable sorts keep types with the same multiplier in standard order
+	slices.SortStableFunc(weak, func(a, b typeMultiplier) int { return compareFloats(b.multiplier, a.multiplier) })
+	slices.SortStableFunc(resists, func(a, b typeMultiplier) int { return compareFloats(a.multiplier, b.multiplier) })
+	return weak, resists, immune
+}
+
+// compareFloats compares two numbers for sorting.
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// cardStats works out a Pokémon's stats at its level, counting the effort
+// values it has gained but no individual values or nature.
+//
+// Returns:
+//   - The stats' values by API stat name
+func cardStats(entry pokedex.Entry) map[string]int {
+	stats := make(map[string]int, len(entry.Stats))
+	for _, stat := range entry.Stats {
+		name := stat.Stat.Name
+		stats[name] = calcStat(name, stat.BaseStat, 0, entry.EVs[name], entry.CurrentLevel(), natureNeutral)
+	}
+	return stats
+}
+
+// moveScore rates how much damage a move does when a Pokémon uses it: its
+// power, times its chance to hit, times STAB if it's one of the Pokémon's
+// types, times the attacking stat the move uses.
+//
+// Parameters:
+//   - move: The move
+//   - types: The types of the Pokémon using it
+//   - stats: The Pokémon's stats by API stat name
+//
+// Returns:
+//   - The move's score, or 0 if it deals no damage
+func moveScore(move battleMove, types []string, stats map[string]int) float64 {
+	score := float64(move.power)
+	if move.accuracy > 0 {
+		score *= float64(move.accuracy) / 100
+	}
+	if slices.Contains(types, move.typeName) {
+		score *= stabMultiplier
+	}
+	if move.special {
+		return score * float64(stats["special-attack"])
+	}
+	return score * float64(stats["attack"])
+}
+
+// superEffectiveAgainst returns the standard types an attacking type hits
+// for more than normal damage, in standard order.
+func superEffectiveAgainst(chart typeChart, attacking string) []string {
+	var types []string
+	for _, defending := range standardTypes {
+		if chart.effectiveness(attacking, []string{defending}) > 1 {
+			types = append(types, defending)
+		}
+	}
+	return types
+}
+
+// suggestMoves picks the moves a Pokémon should battle with. Moves are picked
+// one at a time, each time taking the move whose score, times one more than
+// the number of types it hits super-effectively that no earlier pick does, is
+// highest. A move that widens the coverage can so beat a stronger one that
+// doesn't. No two picks share a type, and moves that deal no damage are left out.
+//
+// Parameters:
+//   - chart: A type chart covering all standard types
+//   - moves: The moves the Pokémon can learn
+//   - types: The Pokémon's types
+//   - stats: The Pokémon's stats by API stat name
+//
+// Returns:
+//   - Up to battleCardMoves moves, in the order they were picked
+func suggestMoves(chart typeChart, moves []battleMove, types []string, stats map[string]int) []battleMove {
+	// Ties go to the move that comes first alphabetically, so picks don't depend on the API's order
+	candidates := slices.Clone(moves)
+	slices.SortFunc(candidates, func(a, b battleMove) int { return strings.Compare(a.name, b.name) })
+
+	var picked []battleMove
+	covered := make(map[string]bool)
+	for len(picked) < battleCardMoves {
+		best, bestValue := -1, 0.0
+		for i, move := range candidates {
+			score := moveScore(move, types, stats)
+			taken := slices.ContainsFunc(picked, func(p battleMove) bool { return p.typeName == move.typeName })
+			if score <= 0 || taken {
+				continue
+			}
+			added := 0
+			for _, t := range superEffectiveAgainst(chart, move.typeName) {
+				if !covered[t] {
+					added++
+				}
+			}
+			if value := score * float64(1+added); value > bestValue {
+				best, bestValue = i, value
+			}
+		}
+		if best < 0 {
+			break
+		}
+		picked = append(picked, candidates[best])
+		for _, t := range superEffectiveAgainst(chart, candidates[best].typeName) {
+			covered[t] = true
+		}
+	}
+	return picked
+}
+
+// loadLearnableMoves looks up the moves a Pokémon can learn in the chosen
+// version group. Moves that can't be looked up are left out, so that one
+// missing move doesn't stop the card from being shown.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client and version group
+//   - entry: The Pokémon's Pokédex entry
+//
+// Returns:
+//   - The moves that were looked up
+//   - The number of moves that couldn't be
+//   - An error if the command was cancelled
+func loadLearnableMoves(cfg *config, entry pokedex.Entry) ([]battleMove, int, error) {
+	ctx := commandContext(cfg)
+	client := cfg.pokeapiClient.WithContext(ctx)
+	names := entry.LearnableMoves(cfg.Settings().versionGroup)
+
+	bar := NewProgressBar(os.Stdout, len(names))
+	defer bar.Finish()
+	moves := make([]battleMove, 0, len(names))
+	failed := 0
+	for _, name := range names {
+		move, err := client.GetMove(name)
+		bar.Advance()
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+		if err != nil {
+			if cfg.Settings().debugMode {
+				log.Printf("Could not load the move %s: %v", name, err)
+			}
+			failed++
+			continue
+		}
+		moves = append(moves, newBattleMove(move))
+	}
+	return moves, failed, nil
+}
+
+// showBattleCard prints the battle card of a caught Pokémon: its types with
+// their weaknesses, its abilities, its stats at its level, and the moves
+// suggested for it.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters forming the Pokémon's name
+//
+// Returns:
+//   - An error if the Pokémon isn't in the Pokédex, the type chart can't be
+//     loaded, or the command was cancelled
+func showBattleCard(cfg *config, params []string) error {
+	// card also takes the export subcommand, so the words of a name like
+	// "mr mime" aren't joined before they get here
+	_, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, []string{strings.Join(params, " ")})
+	if err != nil {
+		return err
+	}
+	entry, err := GetTypedPokemonData(pokemonData, nameInfo.Formatted)
+	if err != nil {
+		return err
+	}
+	chart, err := loadTypeChart(cfg, standardTypes)
+	if err != nil {
+		return err
+	}
+	i18n.Printf("Looking up the moves %s can learn...\n", nameInfo.Formatted)
+	moves, failed, err := loadLearnableMoves(cfg, entry)
+	if err != nil {
+		return err
+	}
+
+	types := pokemonTypes(entry.PokemonDataResp)
+	stats := cardStats(entry)
+	weak, resists, immune := defensiveMatchups(chart, types)
+
+	fmt.Println()
+	i18n.Printf("%s — level %d\n", nameInfo.Formatted, entry.CurrentLevel())
+	i18n.Printf("Types: %s\n", FormatTypeList(types))
+	i18n.Printf("Weak to: %s\n", formatMultipliers(weak))
+	i18n.Printf("Resists: %s\n", formatMultipliers(resists))
+	if len(immune) > 0 {
+		i18n.Printf("Immune to: %s\n", strings.Join(formatTypeNames(immune), ", "))
+	}
+	printCardAbilities(cfg, entry)
+
+	i18n.Printf("Stats at level %d:\n", entry.CurrentLevel())
+	headers := make([]string, 0, len(entry.Stats))
+	values := make([]string, 0, len(entry.Stats))
+	for _, stat := range entry.Stats {
+		headers = append(headers, FormatStatName(stat.Stat.Name))
+		values = append(values, strconv.Itoa(stats[stat.Stat.Name]))
+	}
+	table := NewTable(headers...)
+	table.AddRow(values...)
+	table.Print()
+
+	i18n.Println("Suggested moves:")
+	suggested := suggestMoves(chart, moves, types, stats)
+	if len(suggested) == 0 {
+		i18n.Println(" - none of the moves it can learn deal damage")
+	} else {
+		table := NewTable("Move", "Type", "Category", "Power", "Accuracy", "Super effective against")
+		for _, move := range suggested {
+			accuracy := "—"
+			if move.accuracy > 0 {
+				accuracy = fmt.Sprintf("%d%%", move.accuracy)
+			}
+			against := strings.Join(formatTypeNames(superEffectiveAgainst(chart, move.typeName)), ", ")
+			if against == "" {
+				against = "—"
+			}
+			category := "physical"
+			if move.special {
+				category = "special"
+			}
+			table.AddRow(move.displayName(), FormatTypeName(move.typeName), damageClassName(category),
+				strconv.Itoa(move.power), accuracy, against)
+		}
+		table.Print()
+	}
+	if failed > 0 {
+		i18n.Printf("%d of the moves it can learn couldn't be looked up, so they weren't considered.\n", failed)
+	}
+	return nil
+}
+
+// formatMultipliers lists types with their damage multipliers, or "none".
+func formatMultipliers(multipliers []typeMultiplier) string {
+	if len(multipliers) == 0 {
+		return i18n.T("none")
+	}
+	formatted := make([]string, len(multipliers))
+	for i, m := range multipliers {
+		formatted[i] = m.String()
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// printCardAbilities lists a Pokémon's abilities with their effects. The
+// effects come from the ability data, which isn't stored in the Pokédex; the
+// card should still be shown without it, so a failed request is only logged.
+func printCardAbilities(cfg *config, entry pokedex.Entry) {
+	if len(entry.Abilities) == 0 {
+		return
+	}
+	i18n.Println("Abilities:")
+	for _, a := range entry.Abilities {
+		name := FormatAbilityName(a.Ability.Name)
+		if a.IsHidden {
+			name = i18n.Sprintf("%s (hidden)", name)
+		}
+		ability, err := cfg.pokeapiClient.GetAbility(a.Ability.Name)
+		if err != nil {
+			if cfg.Settings().debugMode {
+				log.Printf("Could not load the ability %s: %v", a.Ability.Name, err)
+			}
+			fmt.Printf(" - %s\n", name)
+			continue
+		}
+		if effect := ability.EnglishEffect(); effect != "" {
+			fmt.Printf(" - %s: %s\n", name, cleanFlavorText(effect))
+		} else {
+			fmt.Printf(" - %s\n", name)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestDefensiveMatchups tests that weaknesses are sorted most damaging first,
+// resistances least damaging first, and immunities are listed apart
+func TestDefensiveMatchups(t *testing.T) {
+	chart := testTypeChart()
+
+	weak, resists, immune := defensiveMatchups(chart, []string{"electric", "steel"})
+	if want := []typeMultiplier{{"ground", 4}}; !reflect.DeepEqual(weak, want) {
+		t.Errorf("Electric/Steel weaknesses: got %v, expected %v", weak, want)
+	}
+	if want := []typeMultiplier{{"steel", 0.25}, {"electric", 0.5}}; !reflect.DeepEqual(resists, want) {
+		t.Errorf("Electric/Steel resistances: got %v, expected %v", resists, want)
+	}
+	if len(immune) != 0 {
+		t.Errorf("Electric/Steel immunities: got %v, expected none", immune)
+	}
+
+	// Ground's immunity cancels Water's weakness to Electric
+	weak, _, immune = defensiveMatchups(chart, []string{"water", "ground"})
+	if len(weak) != 0 || !reflect.DeepEqual(immune, []string{"electric"}) {
+		t.Errorf("Water/Ground: got weaknesses %v and immunities %v, expected only an immunity to Electric", weak, immune)
+	}
+	if got := formatMultipliers(nil); got != "none" {
+		t.Errorf("Expected no multipliers to be shown as none, got %q", got)
+	}
+}
+
+// TestSuggestMoves tests that moves are picked by score and new coverage,
+// that no two share a type, and that moves dealing no damage are left out
+func TestSuggestMoves(t *testing.T) {
+	chart := testTypeChart()
+	stats := map[string]int{"attack": 100, "special-attack": 100}
+	moves := []battleMove{
+		{name: "growl", typeName: "normal"},
+		{name: "hydro-pump", typeName: "water", power: 110, accuracy: 80, special: true},
+		{name: "iron-tail", typeName: "steel", power: 100, accuracy: 75},
+		{name: "surf", typeName: "water", power: 90, accuracy: 100, special: true},
+		{name: "tackle", typeName: "normal", power: 40, accuracy: 100},
+		{name: "thunderbolt", typeName: "electric", power: 90, accuracy: 100, special: true},
+		{name: "earthquake", typeName: "ground", power: 100, accuracy: 100},
+	}
+
+	// Earthquake covers two types, so it beats the stronger Surf; Surf then
+	// beats Hydro Pump, which is stronger but less accurate, and Tackle misses
+	// out once four moves are picked
+	var names []string
+	for _, move := range suggestMoves(chart, moves, []string{"water"}, stats) {
+		names = append(names, move.name)
+	}
+	if want := []string{"earthquake", "surf", "thunderbolt", "iron-tail"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Got suggestions %v, expected %v", names, want)
+	}
+
+	if suggested := suggestMoves(chart, moves[:1], []string{"water"}, stats); len(suggested) != 0 {
+		t.Errorf("Expected no suggestions from moves that deal no damage, got %v", suggested)
+	}
+}
+
+// TestCardStats tests that stats are worked out at the Pokémon's level with its EVs
+func TestCardStats(t *testing.T) {
+	var entry pokedex.Entry
+	err := json.Unmarshal([]byte(`{"name": "pikachu", "level": 50, "evs": {"attack": 252}, "stats": [
+		{"base_stat": 35, "stat": {"name": "hp"}}, {"base_stat": 55, "stat": {"name": "attack"}}]}`), &entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cardStats(entry), map[string]int{"hp": 95, "attack": 91}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got stats %v, expected %v", got, want)
+	}
+}
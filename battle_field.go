@@ -0,0 +1,245 @@
+// This file contains the field state of the battle engine: the weather and the
+// terrain, which affect both sides. Each is set by a move (such as Rain Dance)
+// or by a Pokémon's ability when it's sent out (such as Drizzle), and lasts
+// fieldTurns turns, as in the games:
+//   - Rain: Water moves do 50% more damage and Fire moves half damage
+//   - Harsh sunlight: Fire moves do 50% more damage and Water moves half damage
+//   - Sandstorm: Pokémon that aren't Rock, Ground, or Steel lose 1/16 of their HP each turn
+//   - Electric, Grassy, and Psychic Terrain: moves of that type do 30% more damage
+//   - Grassy Terrain also restores 1/16 of each Pokémon's HP each turn
+//   - Misty Terrain: Dragon moves do half damage
+//
+// Terrain only affects Pokémon on the ground, so Flying types are unaffected.
+package main
+
+import (
+	"slices"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// fieldEffect is a weather or terrain. Terrains are named after the moves that set them.
+type fieldEffect string
+
+const (
+	weatherRain      fieldEffect = "rain"
+	weatherSun       fieldEffect = "sun"
+	weatherSandstorm fieldEffect = "sandstorm"
+	terrainElectric  fieldEffect = "electric-terrain"
+	terrainGrassy    fieldEffect = "grassy-terrain"
+	terrainPsychic   fieldEffect = "psychic-terrain"
+	terrainMisty     fieldEffect = "misty-terrain"
+)
+
+// terrains lists the field effects that are terrains rather than weather.
+var terrains = []fieldEffect{terrainElectric, terrainGrassy, terrainPsychic, terrainMisty}
+
+// fieldMoves are the moves that set each field effect, by API name.
+var fieldMoves = map[string]fieldEffect{
+	"rain-dance":       weatherRain,
+	"sunny-day":        weatherSun,
+	"sandstorm":        weatherSandstorm,
+	"electric-terrain": terrainElectric,
+	"grassy-terrain":   terrainGrassy,
+	"psychic-terrain":  terrainPsychic,
+	"misty-terrain":    terrainMisty,
+}
+
+// fieldAbilities are the abilities that set each field effect when their
+// Pokémon is sent out, by API name.
+var fieldAbilities = map[string]fieldEffect{
+	"drizzle":        weatherRain,
+	"drought":        weatherSun,
+	"sand-stream":    weatherSandstorm,
+	"electric-surge": terrainElectric,
+	"grassy-surge":   terrainGrassy,
+	"psychic-surge":  terrainPsychic,
+	"misty-surge":    terrainMisty,
+}
+
+// fieldStartMessages announce each field effect when it starts.
+var fieldStartMessages = map[fieldEffect]string{
+	weatherRain:      "It started to rain!",
+	weatherSun:       "The sunlight turned harsh!",
+	weatherSandstorm: "A sandstorm kicked up!",
+	terrainElectric:  "An electric current ran across the battlefield!",
+	terrainGrassy:    "Grass grew to cover the battlefield!",
+	terrainPsychic:   "The battlefield got weird!",
+	terrainMisty:     "Mist swirled around the battlefield!",
+}
+
+// fieldEndMessages announce each field effect when it ends.
+var fieldEndMessages = map[fieldEffect]string{
+	weatherRain:      "The rain stopped.",
+	weatherSun:       "The harsh sunlight faded.",
+	weatherSandstorm: "The sandstorm subsided.",
+	terrainElectric:  "The electricity disappeared from the battlefield.",
+	terrainGrassy:    "The grass disappeared from the battlefield.",
+	terrainPsychic:   "The weirdness disappeared from the battlefield.",
+	terrainMisty:     "The mist disappeared from the battlefield.",
+}
+
+// sandstormImmunities lists the types that aren't hurt by a sandstorm.
+var sandstormImmunities = []string{"rock", "ground", "steel"}
+
+const (
+	fieldTurns           = 5   // The turns a weather or terrain lasts, including the one it starts in
+	weatherBoost         = 1.5 // The damage multiplier for moves the weather favors
+	weatherPenalty       = 0.5 // The damage multiplier for moves the weather hinders
+	terrainBoost         = 1.3 // The damage multiplier for moves of a terrain's type
+	mistyTerrainPenalty  = 0.5 // The damage multiplier for Dragon moves on Misty Terrain
+	sandstormDivisor     = 16  // A Pokémon in a sandstorm loses 1/16 of its HP each turn
+	grassyTerrainDivisor = 16  // A Pokémon on Grassy Terrain restores 1/16 of its HP each turn
+)
+
+// battleField is the weather and terrain of a battle.
+type battleField struct {
+	weather      fieldEffect // The current weather, or "" for clear skies
+	weatherTurns int         // The turns left before the weather ends
+	terrain      fieldEffect // The current terrain, or "" for none
+	terrainTurns int         // The turns left before the terrain ends
+}
+
+// fieldAbility returns the Pokémon's ability that sets a field effect, or ""
+// if it has none. Hidden abilities aren't counted, since a Pokémon usually
+// doesn't have its hidden ability.
+func fieldAbility(data pokeapi.PokemonDataResp) string {
+	for _, a := range data.Abilities {
+		if !a.IsHidden && fieldAbilities[a.Ability.Name] != "" {
+			return a.Ability.Name
+		}
+	}
+	return ""
+}
+
+// isTerrain reports whether a field effect is a terrain rather than weather.
+func isTerrain(effect fieldEffect) bool {
+	return slices.Contains(terrains, effect)
+}
+
+// set starts a weather or terrain, replacing the one in place.
+//
+// Returns:
+//   - Whether it started, which it doesn't if it's already in place
+func (f *battleField) set(effect fieldEffect) bool {
+	if isTerrain(effect) {
+		if f.terrain == effect {
+			return false
+		}
+		f.terrain, f.terrainTurns = effect, fieldTurns
+		return true
+	}
+	if f.weather == effect {
+		return false
+	}
+	f.weather, f.weatherTurns = effect, fieldTurns
+	return true
+}
+
+// sendOut sets the weather or terrain of the Pokémon a team has just sent
+// out, if it has an ability that sets one.
+//
+// Returns:
+//   - What happened, if anything
+func (f *battleField) sendOut(team *battleTeam) []battleEvent {
+	b := team.current()
+	effect := fieldAbilities[b.ability]
+	if effect == "" || !f.set(effect) {
+		return nil
+	}
+	return []battleEvent{{kind: eventFieldStart, player: team.player, pokemon: b.name, ability: b.ability, field: effect}}
+}
+
+// damageMultiplier returns the multiplier the weather and terrain apply to a
+// move's damage. A nil field has no effect, for working out damage without
+// allowing for the weather.
+//
+// Parameters:
+//   - attacker: The Pokémon using the move
+//   - defender: The Pokémon the move is used on
+//   - move: The move
+func (f *battleField) damageMultiplier(attacker, defender *battler, move battleMove) float64 {
+	multiplier := 1.0
+	if f == nil {
+		return multiplier
+	}
+	switch {
+	case f.weather == weatherRain && move.typeName == "water", f.weather == weatherSun && move.typeName == "fire":
+		multiplier *= weatherBoost
+	case f.weather == weatherRain && move.typeName == "fire", f.weather == weatherSun && move.typeName == "water":
+		multiplier *= weatherPenalty
+	}
+	switch {
+	case f.terrain == terrainMisty && move.typeName == "dragon" && defender.grounded():
+		multiplier *= mistyTerrainPenalty
+	case move.typeName == terrainType(f.terrain) && attacker.grounded():
+		multiplier *= terrainBoost
+	}
+	return multiplier
+}
+
+// terrainType returns the type of move a terrain boosts (e.g. "electric" for Electric Terrain).
+func terrainType(terrain fieldEffect) string {
+	switch terrain {
+	case terrainElectric:
+		return "electric"
+	case terrainGrassy:
+		return "grass"
+	case terrainPsychic:
+		return "psychic"
+	}
+	return ""
+}
+
+// grounded reports whether the Pokémon is on the ground and affected by terrain.
+func (b *battler) grounded() bool {
+	return !slices.Contains(b.types, "flying")
+}
+
+// endOfTurn applies the weather and terrain to each Pokémon in battle at the
+// end of a turn, then counts down the turns they have left.
+//
+// Parameters:
+//   - teams: The two sides of the battle
+//
+// Returns:
+//   - What happened, in order, including any Pokémon that fainted and any weather or terrain that ended
+func (f *battleField) endOfTurn(teams [2]*battleTeam) []battleEvent {
+	var events []battleEvent
+	for _, team := range teams {
+		b := team.current()
+		if b.fainted() {
+			continue
+		}
+		if f.weather == weatherSandstorm &&
+			!slices.ContainsFunc(b.types, func(t string) bool { return slices.Contains(sandstormImmunities, t) }) {
+			damage := max(b.maxHP/sandstormDivisor, 1)
+			b.hp = max(b.hp-damage, 0)
+			events = append(events, battleEvent{kind: eventWeatherDamage, player: team.player, pokemon: b.name,
+				field: f.weather, damage: damage, targetHP: b.hp, targetMaxHP: b.maxHP})
+			if b.fainted() {
+				events = append(events, battleEvent{kind: eventFaint, player: team.player, pokemon: b.name})
+			}
+		}
+		if f.terrain == terrainGrassy && b.grounded() && !b.fainted() && b.hp < b.maxHP {
+			healed := min(max(b.maxHP/grassyTerrainDivisor, 1), b.maxHP-b.hp)
+			b.hp += healed
+			events = append(events, battleEvent{kind: eventFieldHeal, player: team.player, pokemon: b.name,
+				field: f.terrain, damage: healed, targetHP: b.hp, targetMaxHP: b.maxHP})
+		}
+	}
+
+	if f.weather != "" {
+		if f.weatherTurns--; f.weatherTurns == 0 {
+			events = append(events, battleEvent{kind: eventFieldEnd, field: f.weather})
+			f.weather = ""
+		}
+	}
+	if f.terrain != "" {
+		if f.terrainTurns--; f.terrainTurns == 0 {
+			events = append(events, battleEvent{kind: eventFieldEnd, field: f.terrain})
+			f.terrain = ""
+		}
+	}
+	return events
+}
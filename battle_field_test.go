@@ -0,0 +1,111 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestFieldAbility tests that only a regular ability that sets the weather or terrain is used
+func TestFieldAbility(t *testing.T) {
+	ability := func(name string, hidden bool) pokeapi.PokemonDataResp {
+		return pokeapi.PokemonDataResp{Abilities: []pokeapi.PokemonAbility{
+			{Ability: pokeapi.NamedAPIResource{Name: name}, IsHidden: hidden, Slot: 1}}}
+	}
+	if got := fieldAbility(ability("drizzle", false)); got != "drizzle" {
+		t.Errorf("Expected drizzle, got %q", got)
+	}
+	if got := fieldAbility(ability("drizzle", true)); got != "" {
+		t.Errorf("Expected a hidden ability to be left out, got %q", got)
+	}
+	if got := fieldAbility(ability("static", false)); got != "" {
+		t.Errorf("Expected an ability that doesn't set the field to be left out, got %q", got)
+	}
+}
+
+// TestDamageMultiplier tests how the weather and terrain scale each kind of move
+func TestDamageMultiplier(t *testing.T) {
+	pikachu := testBattler("pikachu", 100, []string{"electric"})
+	pidgey := testBattler("pidgey", 100, []string{"normal", "flying"})
+	move := func(typeName string) battleMove { return battleMove{typeName: typeName, power: 80} }
+
+	cases := []struct {
+		name     string
+		field    *battleField
+		attacker *battler
+		move     battleMove
+		want     float64
+	}{
+		{"no field", nil, pikachu, move("water"), 1},
+		{"rain boosts water", &battleField{weather: weatherRain}, pikachu, move("water"), weatherBoost},
+		{"rain weakens fire", &battleField{weather: weatherRain}, pikachu, move("fire"), weatherPenalty},
+		{"sun boosts fire", &battleField{weather: weatherSun}, pikachu, move("fire"), weatherBoost},
+		{"sandstorm doesn't change damage", &battleField{weather: weatherSandstorm}, pikachu, move("rock"), 1},
+		{"electric terrain boosts electric", &battleField{terrain: terrainElectric}, pikachu, move("electric"), terrainBoost},
+		{"terrain doesn't reach flying types", &battleField{terrain: terrainElectric}, pidgey, move("electric"), 1},
+		{"misty terrain weakens dragon", &battleField{terrain: terrainMisty}, pidgey, move("dragon"), mistyTerrainPenalty},
+		{"rain and grassy terrain together", &battleField{weather: weatherRain, terrain: terrainGrassy}, pikachu, move("grass"), terrainBoost},
+	}
+	for _, c := range cases {
+		if got := c.field.damageMultiplier(c.attacker, pikachu, c.move); got != c.want {
+			t.Errorf("%s: got %v, expected %v", c.name, got, c.want)
+		}
+	}
+}
+
+// TestFieldMoveAndAbility tests that weather moves and abilities start the
+// weather, and that a move fails if the weather is already in place
+func TestFieldMoveAndAbility(t *testing.T) {
+	chart := testTypeChart()
+	dance := newBattleMove(pokeapi.MoveResp{Name: "rain-dance", Type: pokeapi.NamedAPIResource{Name: "water"}})
+	if dance.field != weatherRain {
+		t.Fatalf("Expected Rain Dance to set the rain, got %q", dance.field)
+	}
+	user := &battleTeam{player: "Player 1", members: []*battler{testBattler("squirtle", 60, []string{"water"}, dance)}}
+	opponent := &battleTeam{player: "Player 2", members: []*battler{testBattler("pikachu", 60, []string{"electric"})}}
+
+	var field battleField
+	events := useMove(chart, &field, user, opponent, dance, func() float64 { return 0 })
+	if len(events) != 2 || events[0].kind != eventUse || events[1].kind != eventFieldStart || field.weather != weatherRain {
+		t.Fatalf("Expected Rain Dance to start the rain, got %+v", events)
+	}
+	if events = useMove(chart, &field, user, opponent, dance, func() float64 { return 0 }); events[0].kind != eventNoDamage {
+		t.Errorf("Expected Rain Dance to fail in the rain, got %+v", events)
+	}
+
+	opponent.members[0].ability = "drought"
+	events = field.sendOut(opponent)
+	if len(events) != 1 || events[0].ability != "drought" || field.weather != weatherSun || field.weatherTurns != fieldTurns {
+		t.Errorf("Expected Drought to replace the rain with sunlight, got %+v", events)
+	}
+}
+
+// TestFieldEndOfTurn tests sandstorm damage, grassy terrain healing, and the
+// weather and terrain running out
+func TestFieldEndOfTurn(t *testing.T) {
+	pikachu := testBattler("pikachu", 160, []string{"electric"})
+	onix := testBattler("onix", 160, []string{"rock", "ground"})
+	onix.hp = 100
+	teams := [2]*battleTeam{
+		{player: "Player 1", members: []*battler{pikachu}},
+		{player: "Player 2", members: []*battler{onix}},
+	}
+	field := battleField{weather: weatherSandstorm, weatherTurns: 1, terrain: terrainGrassy, terrainTurns: 2}
+
+	events := field.endOfTurn(teams)
+	wantKinds := []battleEventKind{eventWeatherDamage, eventFieldHeal, eventFieldHeal, eventFieldEnd}
+	if len(events) != len(wantKinds) {
+		t.Fatalf("Expected %d events, got %+v", len(wantKinds), events)
+	}
+	for i, kind := range wantKinds {
+		if events[i].kind != kind {
+			t.Errorf("Event %d: expected kind %d, got %+v", i, kind, events[i])
+		}
+	}
+	if pikachu.hp != 160 || onix.hp != 110 {
+		t.Errorf("Expected Pikachu back at 160 HP and Onix at 110, got %d and %d", pikachu.hp, onix.hp)
+	}
+	if field.weather != "" || field.terrain != terrainGrassy || field.terrainTurns != 1 {
+		t.Errorf("Expected the sandstorm to end and the terrain to last another turn, got %+v", field)
+	}
+}
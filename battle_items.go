@@ -0,0 +1,103 @@
+// This file contains the held items of the battle engine. A Pokémon can be
+// given an item from the bag to hold (see command_hold.go), which takes effect
+// in battles against the computer and in hotseat battles, as in the games:
+//   - Leftovers: the Pokémon restores 1/16 of its HP at the end of each turn
+//   - Choice Band: the Pokémon's Attack is raised by 50%
+//   - Choice Specs: the Pokémon's Special Attack is raised by 50%
+//   - Choice Scarf: the Pokémon's Speed is raised by 50%
+//
+// A Pokémon holding a choice item is locked into the first move it uses until
+// it's switched out. Held items are never used up.
+package main
+
+import "strings"
+
+const (
+	leftoversItem   = "leftovers"
+	choiceBandItem  = "choice-band"
+	choiceSpecsItem = "choice-specs"
+	choiceScarfItem = "choice-scarf"
+)
+
+// heldItems lists the items that have an effect when held in battle, in the
+// order they are shown.
+var heldItems = []string{leftoversItem, choiceBandItem, choiceSpecsItem, choiceScarfItem}
+
+// choiceStats maps each choice item to the stat it raises.
+var choiceStats = map[string]string{
+	choiceBandItem:  "attack",
+	choiceSpecsItem: "special-attack",
+	choiceScarfItem: "speed",
+}
+
+const (
+	leftoversDivisor = 16  // A Pokémon holding Leftovers restores 1/16 of its HP each turn
+	choiceBoost      = 1.5 // The multiplier for the stat a choice item raises
+)
+
+// itemStat returns one of the Pokémon's stats, raised by the choice item it
+// holds if the item raises that stat.
+//
+// Parameters:
+//   - stat: The API name of the stat (e.g. "attack")
+//   - value: The Pokémon's stat before its item is taken into account
+//
+// Returns:
+//   - The stat after its item is taken into account
+func (b *battler) itemStat(stat string, value int) int {
+	if choiceStats[b.heldItem] != stat {
+		return value
+	}
+	return int(float64(value) * choiceBoost)
+}
+
+// choiceLocked reports whether the Pokémon holds a choice item and has used a
+// move since it was sent out, so that it can only use that move.
+func (b *battler) choiceLocked() bool {
+	return choiceStats[b.heldItem] != "" && b.lockedMove > 0
+}
+
+// chooseMove returns the index of the move the Pokémon uses when the given
+// move is chosen: the chosen one, unless a choice item has locked it into
+// another. Using a move while holding a choice item locks the Pokémon into it.
+func (b *battler) chooseMove(move int) int {
+	if choiceStats[b.heldItem] == "" {
+		return move
+	}
+	if b.lockedMove == 0 {
+		b.lockedMove = move + 1
+	}
+	return b.lockedMove - 1
+}
+
+// itemHealing restores the HP of each Pokémon in battle that holds Leftovers,
+// at the end of a turn.
+//
+// Parameters:
+//   - teams: The two sides of the battle
+//
+// Returns:
+//   - What happened, in order
+func itemHealing(teams [2]*battleTeam) []battleEvent {
+	var events []battleEvent
+	for _, team := range teams {
+		b := team.current()
+		if b.heldItem != leftoversItem || b.fainted() || b.hp == b.maxHP {
+			continue
+		}
+		healed := min(max(b.maxHP/leftoversDivisor, 1), b.maxHP-b.hp)
+		b.hp += healed
+		events = append(events, battleEvent{kind: eventItemHeal, player: team.player, pokemon: b.name,
+			item: b.heldItem, damage: healed, targetHP: b.hp, targetMaxHP: b.maxHP})
+	}
+	return events
+}
+
+// formatHeldItems lists the items that have an effect when held, for display.
+func formatHeldItems() string {
+	names := make([]string, len(heldItems))
+	for i, item := range heldItems {
+		names[i] = FormatItemName(item)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,89 @@
+package main
+
+import "testing"
+
+// TestChoiceItems tests that each choice item raises only its own stat
+func TestChoiceItems(t *testing.T) {
+	chart := testTypeChart()
+	ground := testBattler("diglett", 100, []string{"ground"})
+	punch := battleMove{typeName: "water", power: 90}
+	surf := battleMove{typeName: "water", power: 90, special: true}
+
+	plain, _ := battleDamage(chart, nil, testBattler("squirtle", 100, []string{"water"}), ground, punch, 1)
+	for _, c := range []struct {
+		item           string
+		physical, spec bool
+	}{
+		{item: choiceBandItem, physical: true},
+		{item: choiceSpecsItem, spec: true},
+		{item: choiceScarfItem},
+	} {
+		holder := testBattler("squirtle", 100, []string{"water"})
+		holder.heldItem = c.item
+		physical, _ := battleDamage(chart, nil, holder, ground, punch, 1)
+		special, _ := battleDamage(chart, nil, holder, ground, surf, 1)
+		if (physical > plain) != c.physical || (special > plain) != c.spec {
+			t.Errorf("%s: got %d physical and %d special damage, against %d without an item", c.item, physical, special, plain)
+		}
+		if fast := holder.effectiveSpeed() > 100; fast != (c.item == choiceScarfItem) {
+			t.Errorf("%s: got a Speed of %d", c.item, holder.effectiveSpeed())
+		}
+	}
+}
+
+// TestChoiceLock tests that a Pokémon holding a choice item keeps using its
+// first move until it's switched out
+func TestChoiceLock(t *testing.T) {
+	tackle := battleMove{name: "tackle", typeName: "normal", power: 40}
+	growl := battleMove{name: "growl", typeName: "normal"}
+	holder := testBattler("eevee", 100, []string{"normal"}, tackle, growl)
+	holder.heldItem = choiceBandItem
+	teams := [2]*battleTeam{
+		{player: "Player 1", members: []*battler{holder, testBattler("pikachu", 100, []string{"electric"}, tackle)}},
+		{player: "Player 2", members: []*battler{testBattler("snorlax", 1000, []string{"normal"}, growl)}},
+	}
+	roll := func() float64 { return 0.5 }
+	useFirst := [2]battleAction{{move: 0, switchTo: -1}, {move: 0, switchTo: -1}}
+	useSecond := [2]battleAction{{move: 1, switchTo: -1}, {move: 0, switchTo: -1}}
+
+	runTurn(testTypeChart(), &battleField{}, teams, useFirst, roll)
+	if _, err := parseBattleAction("move 2", teams[0]); err == nil {
+		t.Error("Expected choosing another move to be refused while locked")
+	}
+	used := ""
+	for _, event := range runTurn(testTypeChart(), &battleField{}, teams, useSecond, roll) {
+		if event.pokemon == "eevee" {
+			used = event.move.name
+		}
+	}
+	if used != "tackle" {
+		t.Errorf("Expected the locked Pokémon to use Tackle again, got %q", used)
+	}
+
+	runTurn(testTypeChart(), &battleField{}, teams, [2]battleAction{{switchTo: 1}, {move: 0, switchTo: -1}}, roll)
+	if holder.choiceLocked() {
+		t.Error("Expected switching out to end the lock")
+	}
+}
+
+// TestItemHealing tests that Leftovers restore 1/16 of a Pokémon's HP, but
+// not past full health or after fainting
+func TestItemHealing(t *testing.T) {
+	hurt := testBattler("snorlax", 160, []string{"normal"})
+	hurt.heldItem, hurt.hp = leftoversItem, 100
+	full := testBattler("munchlax", 160, []string{"normal"})
+	full.heldItem = leftoversItem
+	teams := [2]*battleTeam{
+		{player: "Player 1", members: []*battler{hurt}},
+		{player: "Player 2", members: []*battler{full}},
+	}
+
+	events := itemHealing(teams)
+	if len(events) != 1 || events[0].kind != eventItemHeal || events[0].damage != 10 || hurt.hp != 110 {
+		t.Errorf("Expected Snorlax alone to restore 10 HP, got %+v", events)
+	}
+	hurt.hp = 0
+	if events := itemHealing(teams); len(events) != 0 || hurt.hp != 0 {
+		t.Errorf("Expected a fainted Pokémon not to be healed, got %+v", events)
+	}
+}
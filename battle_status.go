@@ -0,0 +1,176 @@
+// This file contains the status conditions of the battle engine. Moves can
+// inflict a status condition on the Pokémon they hit, which lasts until the
+// battle ends (or, for sleep and freeze, until the Pokémon wakes up or thaws):
+//   - Burn: the Pokémon's physical moves do half damage, and it loses 1/16 of its HP each turn
+//   - Poison: the Pokémon loses 1/8 of its HP each turn
+//   - Paralysis: the Pokémon's Speed is halved, and it can't move a quarter of the time
+//   - Sleep: the Pokémon can't move for 1 to 3 turns
+//   - Freeze: the Pokémon can't move, with a 20% chance to thaw out each turn
+//
+// A Pokémon can only have one status condition at a time, and some types are
+// immune to some conditions, as in the games.
+package main
+
+import (
+	"slices"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// battleStatus is a status condition, named as the PokeAPI names move ailments.
+type battleStatus string
+
+const (
+	statusBurn      battleStatus = "burn"
+	statusPoison    battleStatus = "poison"
+	statusParalysis battleStatus = "paralysis"
+	statusSleep     battleStatus = "sleep"
+	statusFreeze    battleStatus = "freeze"
+)
+
+// statusAdjectives describe a Pokémon with each status condition (e.g. "Pikachu is paralyzed").
+var statusAdjectives = map[battleStatus]string{
+	statusBurn:      "burned",
+	statusPoison:    "poisoned",
+	statusParalysis: "paralyzed",
+	statusSleep:     "asleep",
+	statusFreeze:    "frozen",
+}
+
+// statusImmunities lists the types that can't get each status condition.
+var statusImmunities = map[battleStatus][]string{
+	statusBurn:      {"fire"},
+	statusPoison:    {"poison", "steel"},
+	statusParalysis: {"electric"},
+	statusFreeze:    {"ice"},
+}
+
+const (
+	burnDamageDivisor   = 16   // A burned Pokémon loses 1/16 of its HP each turn
+	poisonDamageDivisor = 8    // A poisoned Pokémon loses 1/8 of its HP each turn
+	paralysisSkipChance = 0.25 // The chance a paralyzed Pokémon can't move
+	thawChance          = 0.2  // The chance a frozen Pokémon thaws out each turn
+	maxSleepTurns       = 3    // The most turns a Pokémon sleeps for
+)
+
+// formatStatus returns the adjective for a status condition, for display.
+func formatStatus(status battleStatus) string {
+	return i18n.T(statusAdjectives[status])
+}
+
+// effectiveSpeed returns the Pokémon's Speed, raised by a Choice Scarf and
+// halved if it's paralyzed.
+func (b *battler) effectiveSpeed() int {
+	speed := b.itemStat("speed", b.speed)
+	if b.status == statusParalysis {
+		return speed / 2
+	}
+	return speed
+}
+
+// inflictStatus gives a Pokémon the status condition a move inflicts, if the
+// move has one and its chance comes up. Pokémon that already have a status
+// condition, are immune to it by type, or weren't affected by the move's type
+// aren't affected.
+//
+// Parameters:
+//   - chart: A type chart covering the move's type
+//   - target: The Pokémon the move hit
+//   - move: The move
+//   - roll: Returns a random number in [0, 1), such as rand.Float64
+//
+// Returns:
+//   - Whether the status condition was inflicted
+func inflictStatus(chart typeChart, target *battler, move battleMove, roll func() float64) bool {
+	status := move.ailment
+	if status == "" || target.status != "" || target.fainted() ||
+		slices.ContainsFunc(target.types, func(t string) bool { return slices.Contains(statusImmunities[status], t) }) ||
+		chart.effectiveness(move.typeName, target.types) == 0 {
+		return false
+	}
+	chance := move.ailmentChance
+	if chance == 0 {
+		chance = 100
+	}
+	if roll()*100 >= float64(chance) {
+		return false
+	}
+	target.status = status
+	if status == statusSleep {
+		target.sleepTurns = 1 + int(roll()*maxSleepTurns)
+	}
+	return true
+}
+
+// checkCanMove works out whether a Pokémon's status condition stops it from
+// moving this turn. A sleeping Pokémon may wake up and a frozen one may thaw
+// out, in which case it moves as usual.
+//
+// Parameters:
+//   - player: The player whose Pokémon is about to move
+//   - b: The Pokémon about to move
+//   - roll: Returns a random number in [0, 1), such as rand.Float64
+//
+// Returns:
+//   - What happened because of the Pokémon's status condition, if anything
+//   - Whether the Pokémon can move
+func checkCanMove(player string, b *battler, roll func() float64) ([]battleEvent, bool) {
+	event := battleEvent{player: player, pokemon: b.name, status: b.status}
+	switch b.status {
+	case statusSleep:
+		if b.sleepTurns > 0 {
+			b.sleepTurns--
+			event.kind = eventImmobile
+			return []battleEvent{event}, false
+		}
+	case statusFreeze:
+		if roll() >= thawChance {
+			event.kind = eventImmobile
+			return []battleEvent{event}, false
+		}
+	case statusParalysis:
+		if roll() < paralysisSkipChance {
+			event.kind = eventImmobile
+			return []battleEvent{event}, false
+		}
+		return nil, true
+	default:
+		return nil, true
+	}
+	b.status = ""
+	event.kind = eventRecover
+	return []battleEvent{event}, true
+}
+
+// statusDamage hurts each Pokémon in battle that's burned or poisoned, at the
+// end of a turn.
+//
+// Parameters:
+//   - teams: The two sides of the battle
+//
+// Returns:
+//   - What happened, in order, including any Pokémon that fainted
+func statusDamage(teams [2]*battleTeam) []battleEvent {
+	var events []battleEvent
+	for _, team := range teams {
+		b := team.current()
+		divisor := 0
+		switch b.status {
+		case statusBurn:
+			divisor = burnDamageDivisor
+		case statusPoison:
+			divisor = poisonDamageDivisor
+		}
+		if divisor == 0 || b.fainted() {
+			continue
+		}
+		damage := max(b.maxHP/divisor, 1)
+		b.hp = max(b.hp-damage, 0)
+		events = append(events, battleEvent{kind: eventStatusDamage, player: team.player, pokemon: b.name,
+			status: b.status, damage: damage, targetHP: b.hp, targetMaxHP: b.maxHP})
+		if b.fainted() {
+			events = append(events, battleEvent{kind: eventFaint, player: team.player, pokemon: b.name})
+		}
+	}
+	return events
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestNewBattleMoveAilment tests that only the status conditions the engine models are kept
+func TestNewBattleMoveAilment(t *testing.T) {
+	burn := newBattleMove(pokeapi.MoveResp{Meta: &pokeapi.MoveMeta{
+		Ailment: pokeapi.NamedAPIResource{Name: "burn"}, AilmentChance: 10}})
+	if burn.ailment != statusBurn || burn.ailmentChance != 10 {
+		t.Errorf("Expected a 10%% chance to burn, got %q (%d%%)", burn.ailment, burn.ailmentChance)
+	}
+	for _, meta := range []*pokeapi.MoveMeta{nil, {Ailment: pokeapi.NamedAPIResource{Name: "confusion"}}} {
+		if move := newBattleMove(pokeapi.MoveResp{Meta: meta}); move.ailment != "" {
+			t.Errorf("Expected no ailment for %+v, got %q", meta, move.ailment)
+		}
+	}
+}
+
+// TestInflictStatus tests which Pokémon a status move affects
+func TestInflictStatus(t *testing.T) {
+	chart := testTypeChart()
+	wave := battleMove{name: "thunder-wave", typeName: "electric", ailment: statusParalysis}
+	always := func() float64 { return 0 }
+
+	cases := []struct {
+		name   string
+		target *battler
+		move   battleMove
+		want   bool
+	}{
+		{"water type", testBattler("squirtle", 60, []string{"water"}), wave, true},
+		{"immune to the move's type", testBattler("diglett", 60, []string{"ground"}), wave, false},
+		{"immune to the status", testBattler("pikachu", 60, []string{"electric"}), wave, false},
+		{"already asleep", &battler{hp: 60, types: []string{"water"}, status: statusSleep}, wave, false},
+		{"chance didn't come up", testBattler("squirtle", 60, []string{"water"}),
+			battleMove{typeName: "fire", ailment: statusBurn, ailmentChance: 10}, false},
+	}
+	for _, c := range cases {
+		roll := always
+		if c.move.ailmentChance > 0 {
+			roll = func() float64 { return 0.5 }
+		}
+		if got := inflictStatus(chart, c.target, c.move, roll); got != c.want {
+			t.Errorf("%s: got %v, expected %v", c.name, got, c.want)
+		}
+	}
+}
+
+// TestCheckCanMove tests when status conditions stop a Pokémon from moving
+func TestCheckCanMove(t *testing.T) {
+	sleeper := &battler{name: "snorlax", status: statusSleep, sleepTurns: 1}
+	if events, ok := checkCanMove("Player 1", sleeper, nil); ok || events[0].kind != eventImmobile {
+		t.Errorf("Expected Snorlax to stay asleep, got %+v", events)
+	}
+	if events, ok := checkCanMove("Player 1", sleeper, nil); !ok || events[0].kind != eventRecover || sleeper.status != "" {
+		t.Errorf("Expected Snorlax to wake up and move, got %+v", events)
+	}
+
+	frozen := &battler{status: statusFreeze}
+	if _, ok := checkCanMove("Player 1", frozen, func() float64 { return 0.5 }); ok {
+		t.Error("Expected the frozen Pokémon to stay frozen")
+	}
+	if _, ok := checkCanMove("Player 1", frozen, func() float64 { return 0.1 }); !ok || frozen.status != "" {
+		t.Error("Expected the frozen Pokémon to thaw out")
+	}
+
+	paralyzed := &battler{status: statusParalysis, speed: 100}
+	if _, ok := checkCanMove("Player 1", paralyzed, func() float64 { return 0.1 }); ok {
+		t.Error("Expected the paralyzed Pokémon to be unable to move")
+	}
+	if events, ok := checkCanMove("Player 1", paralyzed, func() float64 { return 0.9 }); !ok || len(events) != 0 {
+		t.Errorf("Expected the paralyzed Pokémon to move, got %+v", events)
+	}
+	if paralyzed.effectiveSpeed() != 50 {
+		t.Errorf("Expected paralysis to halve Speed, got %d", paralyzed.effectiveSpeed())
+	}
+}
+
+// TestStatusDamage tests the damage burned and poisoned Pokémon take each turn
+func TestStatusDamage(t *testing.T) {
+	burned := &battler{name: "growlithe", hp: 160, maxHP: 160, status: statusBurn}
+	poisoned := &battler{name: "oddish", hp: 10, maxHP: 160, status: statusPoison}
+	teams := [2]*battleTeam{{members: []*battler{burned}}, {members: []*battler{poisoned}}}
+
+	events := statusDamage(teams)
+	if len(events) != 3 || events[0].damage != 10 || burned.hp != 150 {
+		t.Fatalf("Expected Growlithe to lose 1/16 of its HP, got %+v", events)
+	}
+	if events[1].damage != 20 || events[2].kind != eventFaint || !poisoned.fainted() {
+		t.Errorf("Expected Oddish to lose 1/8 of its HP and faint, got %+v", events[1:])
+	}
+}
+
+// TestRunTurnStatusMove tests a status move used in a turn, and burn halving physical damage
+func TestRunTurnStatusMove(t *testing.T) {
+	chart := testTypeChart()
+	wave := battleMove{name: "thunder-wave", typeName: "electric", ailment: statusParalysis}
+	pikachu := testBattler("pikachu", 100, []string{"electric"}, wave)
+	squirtle := testBattler("squirtle", 60, []string{"water"}, battleMove{name: "tail-whip", typeName: "water"})
+	teams := [2]*battleTeam{
+		{player: "Player 1", members: []*battler{pikachu}},
+		{player: "Player 2", members: []*battler{squirtle}},
+	}
+
+	events := runTurn(chart, &battleField{}, teams, [2]battleAction{{switchTo: -1}, {switchTo: -1}}, func() float64 { return 0.9 })
+	if len(events) < 2 || events[0].kind != eventUse || events[1].kind != eventStatus || squirtle.status != statusParalysis {
+		t.Fatalf("Expected Thunder Wave to paralyze Squirtle, got %+v", events)
+	}
+
+	strike := battleMove{typeName: "ground", power: 90}
+	healthy, _ := battleDamage(chart, nil, pikachu, squirtle, strike, 1)
+	pikachu.status = statusBurn
+	if burned, _ := battleDamage(chart, nil, pikachu, squirtle, strike, 1); burned != healthy/2 {
+		t.Errorf("Expected a burn to halve physical damage: %d healthy, %d burned", healthy, burned)
+	}
+}
@@ -0,0 +1,423 @@
+// This file contains the turn-based battle engine used by the battle command.
+// Two teams each send out one Pokémon at a time. Every turn, each side either
+// uses one of its Pokémon's moves or switches to another team member; switches
+// happen first, and moves go in order of Speed. Damage follows the formula from
+// the games at a fixed level, with the same-type attack bonus (STAB) and type
+// effectiveness from the type chart. Moves can also inflict status conditions
+// (see battle_status.go), moves and abilities can change the weather and
+// terrain (see battle_field.go), and Pokémon can hold items that take effect
+// in battle (see battle_items.go). A side loses when all its Pokémon faint.
+package main
+
+import (
+	"slices"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// battleLevel is the level every Pokémon battles at, so that battles are
+// decided by species, moves, and the players' choices.
+const battleLevel = 50
+
+// battleTeamSize is the largest number of Pokémon on a battle team.
+const battleTeamSize = 3
+
+// basicAttackPower is the power of the basic attacks used by Pokémon that
+// haven't been taught any moves.
+const basicAttackPower = 50
+
+// stabMultiplier is the damage bonus for a move of one of the user's own types.
+const stabMultiplier = 1.5
+
+// battleMove is a move a Pokémon can use in battle.
+type battleMove struct {
+	name     string // The move's API name, or "" for a basic attack
+	typeName string // The move's type (e.g. "fire")
+	power    int    // The move's base power, or 0 if it deals no damage
+	accuracy int    // The percent chance the move hits, or 0 if it never misses
+	special  bool   // Whether the move uses Special Attack and Special Defense
+
+	ailment       battleStatus // The status condition the move can inflict, or "" for none
+	ailmentChance int          // The percent chance of inflicting it, or 0 if it always does
+	field         fieldEffect  // The weather or terrain the move sets, or "" for none
+}
+
+// newBattleMove converts move data from the PokeAPI into a battle move.
+func newBattleMove(move pokeapi.MoveResp) battleMove {
+	m := battleMove{
+		name:     move.Name,
+		typeName: move.Type.Name,
+		special:  move.DamageClass.Name == "special",
+		field:    fieldMoves[move.Name],
+	}
+	if move.Power != nil {
+		m.power = *move.Power
+	}
+	if move.Accuracy != nil {
+		m.accuracy = *move.Accuracy
+	}
+	if move.Meta != nil {
+		if ailment := battleStatus(move.Meta.Ailment.Name); statusAdjectives[ailment] != "" {
+			m.ailment, m.ailmentChance = ailment, move.Meta.AilmentChance
+		}
+	}
+	return m
+}
+
+// basicAttacks returns a basic attack of each of a Pokémon's types, for
+// Pokémon that haven't been taught any moves. The attacks use whichever of
+// Attack and Special Attack is higher.
+func basicAttacks(data pokeapi.PokemonDataResp) []battleMove {
+	special := baseStat(data, "special-attack") > baseStat(data, "attack")
+	var moves []battleMove
+	for _, t := range pokemonTypes(data) {
+		moves = append(moves, battleMove{typeName: t, power: basicAttackPower, special: special})
+	}
+	return moves
+}
+
+// displayName returns the move's name for display (e.g. "Flamethrower" or "Fire attack").
+func (m battleMove) displayName() string {
+	if m.name == "" {
+		return i18n.Sprintf("%s attack", FormatTypeName(m.typeName))
+	}
+	return FormatMoveName(m.name)
+}
+
+// battler is a Pokémon taking part in a battle, with its stats at battleLevel.
+type battler struct {
+	name           string       // The Pokémon's name in API format
+	types          []string     // The Pokémon's types in slot order
+	moves          []battleMove // The moves the Pokémon can use
+	hp             int          // The Pokémon's remaining HP
+	maxHP          int          // The Pokémon's HP when it's at full health
+	attack         int          // The Pokémon's Attack stat
+	defense        int          // The Pokémon's Defense stat
+	specialAttack  int          // The Pokémon's Special Attack stat
+	specialDefense int          // The Pokémon's Special Defense stat
+	speed          int          // The Pokémon's Speed stat
+	status         battleStatus // The Pokémon's status condition, or "" for none
+	sleepTurns     int          // The turns left before the Pokémon wakes up, if it's asleep
+	ability        string       // The Pokémon's ability that sets the weather or terrain, or "" for none
+	heldItem       string       // The API name of the item the Pokémon holds, or "" for none
+	lockedMove     int          // The move its choice item locks it into, numbered from 1, or 0 if it isn't locked
+}
+
+// newBattler prepares a Pokémon for battle at full health. Stats are worked
+// out from base stats at battleLevel, as for a Pokémon with no training.
+//
+// Parameters:
+//   - data: The Pokémon's data, including its base stats and types
+//   - moves: The moves it can use
+//
+// Returns:
+//   - The Pokémon, ready to battle
+func newBattler(data pokeapi.PokemonDataResp, moves []battleMove) *battler {
+	stat := func(name string) int {
+		return baseStat(data, name)*2*battleLevel/100 + 5
+	}
+	maxHP := battleMaxHP(data)
+	return &battler{
+		name:           data.Name,
+		types:          pokemonTypes(data),
+		moves:          moves,
+		hp:             maxHP,
+		maxHP:          maxHP,
+		attack:         stat("attack"),
+		defense:        stat("defense"),
+		specialAttack:  stat("special-attack"),
+		specialDefense: stat("special-defense"),
+		speed:          stat("speed"),
+		ability:        fieldAbility(data),
+	}
+}
+
+// battleMaxHP works out a Pokémon's HP at full health in battle, at battleLevel.
+func battleMaxHP(data pokeapi.PokemonDataResp) int {
+	return baseStat(data, "hp")*2*battleLevel/100 + battleLevel + 10
+}
+
+// fainted reports whether the Pokémon has no HP left.
+func (b *battler) fainted() bool {
+	return b.hp <= 0
+}
+
+// battleTeam is one side of a battle.
+type battleTeam struct {
+	player  string     // The name of the side's player (e.g. "Player 1"), or "" for a wild Pokémon
+	members []*battler // The Pokémon on the team, in the order they were chosen
+	active  int        // The index of the Pokémon in battle
+}
+
+// current returns the Pokémon in battle.
+func (t *battleTeam) current() *battler {
+	return t.members[t.active]
+}
+
+// reserves returns the indexes of the team members that can be switched in.
+func (t *battleTeam) reserves() []int {
+	var reserves []int
+	for i, member := range t.members {
+		if i != t.active && !member.fainted() {
+			reserves = append(reserves, i)
+		}
+	}
+	return reserves
+}
+
+// defeated reports whether every Pokémon on the team has fainted.
+func (t *battleTeam) defeated() bool {
+	return !slices.ContainsFunc(t.members, func(b *battler) bool { return !b.fainted() })
+}
+
+// heal restores every team member to full health, cures their status
+// conditions, and sends out the first one, ready for another battle.
+func (t *battleTeam) heal() {
+	for _, member := range t.members {
+		member.hp = member.maxHP
+		member.status = ""
+		member.lockedMove = 0
+	}
+	t.active = 0
+}
+
+// battleAction is what a side does in a turn.
+type battleAction struct {
+	move     int  // The index of the move to use, if not switching
+	switchTo int  // The index of the team member to switch to, or -1 to use a move
+	forfeit  bool // Whether the side gives up the battle instead
+}
+
+// battleEventKind identifies what happened in a battle event.
+type battleEventKind int
+
+const (
+	eventSwitch        battleEventKind = iota // A player sent out a Pokémon
+	eventHit                                  // A move hit and dealt damage (possibly none, if the target is immune)
+	eventMiss                                 // A move missed
+	eventNoDamage                             // A move that deals no damage was used, with no effect
+	eventFaint                                // A Pokémon fainted
+	eventUse                                  // A move that deals no damage was used, and its effect follows
+	eventStatus                               // A Pokémon was given a status condition
+	eventStatusDamage                         // A Pokémon was hurt by its status condition
+	eventImmobile                             // A Pokémon couldn't move because of its status condition
+	eventRecover                              // A Pokémon woke up or thawed out
+	eventFieldStart                           // A weather or terrain started, from a move or an ability
+	eventFieldEnd                             // A weather or terrain ended
+	eventWeatherDamage                        // A Pokémon was hurt by the weather
+	eventFieldHeal                            // A Pokémon restored HP from the terrain
+	eventForfeit                              // A player forfeited the battle
+	eventItemHeal                             // A Pokémon restored HP from its held item
+)
+
+// battleEvent is something that happened in a turn, in enough detail to describe it.
+type battleEvent struct {
+	kind          battleEventKind
+	player        string       // The player whose Pokémon acted or fainted, or "" for a wild Pokémon
+	pokemon       string       // The Pokémon that acted or fainted, in API format
+	move          battleMove   // The move used
+	target        string       // The Pokémon the move was used on, in API format
+	damage        int          // The damage the move or status condition dealt
+	effectiveness float64      // The move's type effectiveness against the target
+	targetHP      int          // The target's HP after the move (the Pokémon's own, for status damage)
+	targetMaxHP   int          // The target's HP at full health (likewise)
+	status        battleStatus // The status condition involved, for status events
+	field         fieldEffect  // The weather or terrain involved, for field events
+	ability       string       // The ability that started the weather or terrain, or "" if a move did
+	item          string       // The held item involved, for item events
+}
+
+// runTurn carries out both sides' actions for one turn. Switches happen before
+// moves, and the faster Pokémon moves first, with ties decided at random. A
+// Pokémon that faints before its move doesn't get to use it, and one whose
+// status condition stops it from moving doesn't either. Burned and poisoned
+// Pokémon are hurt at the end of the turn, Pokémon holding Leftovers restore
+// HP, and then the weather and terrain take effect.
+//
+// Parameters:
+//   - chart: A type chart covering the types of every move in the battle
+//   - field: The weather and terrain, which the turn may change
+//   - teams: The two sides of the battle
+//   - actions: What each side does, in the same order as teams
+//   - roll: Returns a random number in [0, 1), such as rand.Float64
+//
+// Returns:
+//   - What happened, in order
+func runTurn(chart typeChart, field *battleField, teams [2]*battleTeam, actions [2]battleAction, roll func() float64) []battleEvent {
+	var events []battleEvent
+	for side, action := range actions {
+		if action.switchTo >= 0 {
+			// Switching out frees a Pokémon from its choice item's lock
+			teams[side].current().lockedMove = 0
+			teams[side].active = action.switchTo
+			events = append(events, battleEvent{kind: eventSwitch, player: teams[side].player, pokemon: teams[side].current().name})
+			events = append(events, field.sendOut(teams[side])...)
+		}
+	}
+
+	order := []int{0, 1}
+	speed0, speed1 := teams[0].current().effectiveSpeed(), teams[1].current().effectiveSpeed()
+	if speed1 > speed0 || (speed1 == speed0 && roll() < 0.5) {
+		order = []int{1, 0}
+	}
+	for _, side := range order {
+		attacker, defender := teams[side].current(), teams[1-side].current()
+		if actions[side].switchTo >= 0 || attacker.fainted() {
+			continue
+		}
+		checked, canMove := checkCanMove(teams[side].player, attacker, roll)
+		events = append(events, checked...)
+		if !canMove {
+			continue
+		}
+		move := attacker.moves[attacker.chooseMove(actions[side].move)]
+		events = append(events, useMove(chart, field, teams[side], teams[1-side], move, roll)...)
+		if defender.fainted() {
+			events = append(events, battleEvent{kind: eventFaint, player: teams[1-side].player, pokemon: defender.name})
+		}
+	}
+	events = append(events, statusDamage(teams)...)
+	events = append(events, itemHealing(teams)...)
+	return append(events, field.endOfTurn(teams)...)
+}
+
+// useMove has a team's Pokémon in battle use a move on the opponent's, and
+// inflicts the move's status condition or sets its weather or terrain if it
+// has one.
+func useMove(chart typeChart, field *battleField, user, opponent *battleTeam, move battleMove, roll func() float64) []battleEvent {
+	attacker, defender := user.current(), opponent.current()
+	event := battleEvent{
+		kind:        eventHit,
+		player:      user.player,
+		pokemon:     attacker.name,
+		move:        move,
+		target:      defender.name,
+		targetMaxHP: defender.maxHP,
+	}
+	switch {
+	case move.accuracy > 0 && roll()*100 >= float64(move.accuracy):
+		event.kind = eventMiss
+	case move.field != "" && field.set(move.field):
+		event.kind = eventUse
+		return []battleEvent{event, {kind: eventFieldStart, field: move.field}}
+	case move.power == 0:
+		event.kind = eventNoDamage
+	default:
+		event.damage, event.effectiveness = battleDamage(chart, field, attacker, defender, move, roll())
+		defender.hp = max(defender.hp-event.damage, 0)
+	}
+	event.targetHP = defender.hp
+	if event.kind == eventMiss || (event.kind == eventHit && event.effectiveness == 0) ||
+		!inflictStatus(chart, defender, move, roll) {
+		return []battleEvent{event}
+	}
+	if event.kind == eventNoDamage {
+		event.kind = eventUse
+	}
+	return []battleEvent{event, {kind: eventStatus, player: opponent.player, pokemon: defender.name, status: defender.status}}
+}
+
+// battleDamage works out the damage a move does, using the formula from the
+// games: the move's power scaled by the attacker's attacking stat against the
+// defender's defending stat, then by STAB, type effectiveness, the weather and
+// terrain, and a random factor from 85% to 100%. A burned attacker's physical
+// moves do half damage, and a choice item raises the attacking stat.
+//
+// Parameters:
+//   - chart: A type chart covering the move's type
+//   - field: The weather and terrain, or nil to leave them out
+//   - attacker: The Pokémon using the move
+//   - defender: The Pokémon the move is used on
+//   - move: The move, which must deal damage
+//   - roll: A random number in [0, 1) for the random factor
+//
+// Returns:
+//   - The damage, at least 1 unless the defender is immune
+//   - The move's type effectiveness against the defender
+func battleDamage(chart typeChart, field *battleField, attacker, defender *battler, move battleMove, roll float64) (int, float64) {
+	effectiveness := chart.effectiveness(move.typeName, defender.types)
+	if effectiveness == 0 {
+		return 0, 0
+	}
+	attack, defense := attacker.itemStat("attack", attacker.attack), defender.defense
+	if move.special {
+		attack, defense = attacker.itemStat("special-attack", attacker.specialAttack), defender.specialDefense
+	}
+
+	base := (2*battleLevel/5+2)*move.power*attack/max(defense, 1)/50 + 2
+	damage := float64(base) * effectiveness * field.damageMultiplier(attacker, defender, move) * (0.85 + 0.15*roll)
+	if slices.Contains(attacker.types, move.typeName) {
+		damage *= stabMultiplier
+	}
+	if attacker.status == statusBurn && !move.special {
+		damage /= 2
+	}
+	return max(int(damage), 1), effectiveness
+}
+
+// describe returns the lines that tell the players what happened in an event.
+func (e battleEvent) describe() []string {
+	switch e.kind {
+	case eventSwitch:
+		return []string{e.narrate("%s sent out %s!", "A wild %s appeared!")}
+	case eventMiss:
+		return []string{e.narrate("%s's %s used %s, but it missed!", "The wild %s used %s, but it missed!", e.move.displayName())}
+	case eventNoDamage:
+		return []string{e.narrate("%s's %s used %s, but nothing happened.", "The wild %s used %s, but nothing happened.", e.move.displayName())}
+	case eventFaint:
+		return []string{e.narrate("%s's %s fainted!", "The wild %s fainted!")}
+	case eventUse:
+		return []string{e.narrate("%s's %s used %s!", "The wild %s used %s!", e.move.displayName())}
+	case eventStatus:
+		return []string{e.narrate("%s's %s is now %s!", "The wild %s is now %s!", formatStatus(e.status))}
+	case eventStatusDamage:
+		return []string{e.narrate("%s's %s is %s and took %d damage (%d/%d HP left).",
+			"The wild %s is %s and took %d damage (%d/%d HP left).", formatStatus(e.status), e.damage, e.targetHP, e.targetMaxHP)}
+	case eventImmobile:
+		return []string{e.narrate("%s's %s is %s and can't move!", "The wild %s is %s and can't move!", formatStatus(e.status))}
+	case eventRecover:
+		return []string{e.narrate("%s's %s is no longer %s!", "The wild %s is no longer %s!", formatStatus(e.status))}
+	case eventFieldStart:
+		if e.ability == "" {
+			return []string{i18n.T(fieldStartMessages[e.field])}
+		}
+		return []string{e.narrate("%s's %s's %s!", "The wild %s's %s!", FormatMoveName(e.ability)), i18n.T(fieldStartMessages[e.field])}
+	case eventFieldEnd:
+		return []string{i18n.T(fieldEndMessages[e.field])}
+	case eventForfeit:
+		return []string{i18n.Sprintf("%s forfeited the battle.", e.player)}
+	case eventWeatherDamage:
+		return []string{e.narrate("%s's %s is buffeted by the sandstorm and took %d damage (%d/%d HP left).",
+			"The wild %s is buffeted by the sandstorm and took %d damage (%d/%d HP left).", e.damage, e.targetHP, e.targetMaxHP)}
+	case eventFieldHeal:
+		return []string{e.narrate("%s's %s restored %d HP on the grassy terrain (%d/%d HP left).",
+			"The wild %s restored %d HP on the grassy terrain (%d/%d HP left).", e.damage, e.targetHP, e.targetMaxHP)}
+	case eventItemHeal:
+		return []string{e.narrate("%s's %s restored %d HP with its %s (%d/%d HP left).",
+			"The wild %s restored %d HP with its %s (%d/%d HP left).", e.damage, FormatItemName(e.item), e.targetHP, e.targetMaxHP)}
+	}
+
+	lines := []string{e.narrate("%s's %s used %s!", "The wild %s used %s!", e.move.displayName())}
+	switch {
+	case e.effectiveness == 0:
+		return append(lines, i18n.Sprintf("It doesn't affect %s...", FormatPokemonName(e.target)))
+	case e.effectiveness > 1:
+		lines = append(lines, i18n.T("It's super effective!"))
+	case e.effectiveness < 1:
+		lines = append(lines, i18n.T("It's not very effective..."))
+	}
+	return append(lines, i18n.Sprintf("%s took %d damage (%d/%d HP left).",
+		FormatPokemonName(e.target), e.damage, e.targetHP, e.targetMaxHP))
+}
+
+// narrate formats a line about the Pokémon in an event. The first format is
+// given the player and the Pokémon; the second is for a wild Pokémon, which has
+// no player, and is given just the Pokémon. Both are followed by args.
+func (e battleEvent) narrate(format, wildFormat string, args ...any) string {
+	pokemon := FormatPokemonName(e.pokemon)
+	if e.player == "" {
+		return i18n.Sprintf(wildFormat, append([]any{pokemon}, args...)...)
+	}
+	return i18n.Sprintf(format, append([]any{e.player, pokemon}, args...)...)
+}
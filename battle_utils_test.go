@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+)
+
+// testBattler creates a battler with the same value for every stat
+func testBattler(name string, stat int, types []string, moves ...battleMove) *battler {
+	return &battler{
+		name: name, types: types, moves: moves,
+		hp: stat, maxHP: stat,
+		attack: stat, defense: stat, specialAttack: stat, specialDefense: stat, speed: stat,
+	}
+}
+
+// TestBattleDamage tests that damage follows type effectiveness and STAB
+func TestBattleDamage(t *testing.T) {
+	chart := testTypeChart()
+	water := testBattler("squirtle", 100, []string{"water"})
+	ground := testBattler("diglett", 100, []string{"ground"})
+	surf := battleMove{typeName: "water", power: 90, special: true}
+	shock := battleMove{typeName: "electric", power: 90, special: true}
+
+	// (22 * 90 * 100/100) / 50 + 2 = 41, then 2x effective and 1.5x STAB at the top roll
+	damage, effectiveness := battleDamage(chart, nil, water, ground, surf, 1)
+	if damage != 123 || effectiveness != 2 {
+		t.Errorf("Super effective STAB: got %d damage (%gx), expected 123 (2x)", damage, effectiveness)
+	}
+	// Without STAB, at the lowest roll
+	if damage, _ := battleDamage(chart, nil, ground, water, shock, 0); damage != 69 {
+		t.Errorf("Super effective without STAB: got %d damage, expected 69", damage)
+	}
+	if damage, effectiveness := battleDamage(chart, nil, water, ground, shock, 1); damage != 0 || effectiveness != 0 {
+		t.Errorf("Immune target: got %d damage (%gx), expected none", damage, effectiveness)
+	}
+}
+
+// TestRunTurn tests that switches go first, the faster Pokémon moves first,
+// and a Pokémon that faints doesn't get to move
+func TestRunTurn(t *testing.T) {
+	chart := testTypeChart()
+	strike := battleMove{typeName: "ground", power: 250}
+	fast := testBattler("dugtrio", 120, []string{"ground"}, strike)
+	slow := testBattler("pikachu", 60, []string{"electric"}, strike)
+	reserve := testBattler("squirtle", 60, []string{"water"}, strike)
+	reserve.hp, reserve.maxHP = 1000, 1000 // Survives Dugtrio's attack
+	teams := [2]*battleTeam{
+		{player: "Player 1", members: []*battler{slow, reserve}},
+		{player: "Player 2", members: []*battler{fast}},
+	}
+
+	events := runTurn(chart, &battleField{}, teams, [2]battleAction{{switchTo: -1}, {switchTo: -1}}, func() float64 { return 0.9 })
+	if len(events) != 2 || events[0].pokemon != "dugtrio" || events[1].kind != eventFaint || events[1].pokemon != "pikachu" {
+		t.Fatalf("Expected Dugtrio to knock out Pikachu before it moved, got %+v", events)
+	}
+	if teams[0].defeated() || len(teams[0].reserves()) != 1 {
+		t.Errorf("Expected Player 1 to have Squirtle left")
+	}
+
+	teams[0].active = 1
+	events = runTurn(chart, &battleField{}, teams, [2]battleAction{{switchTo: -1}, {switchTo: -1}}, func() float64 { return 0.9 })
+	if len(events) < 2 || events[0].pokemon != "dugtrio" || events[1].pokemon != "squirtle" || events[1].kind != eventHit {
+		t.Fatalf("Expected both Pokémon to move, got %+v", events)
+	}
+
+	teams[0].heal()
+	if teams[0].active != 0 || slow.hp != slow.maxHP || reserve.hp != reserve.maxHP {
+		t.Errorf("Expected heal to restore the team and send out the first Pokémon")
+	}
+	events = runTurn(chart, &battleField{}, teams, [2]battleAction{{switchTo: 1}, {switchTo: -1}}, func() float64 { return 0.9 })
+	if events[0].kind != eventSwitch || events[0].pokemon != "squirtle" || events[1].target != "squirtle" {
+		t.Errorf("Expected Player 1 to switch to Squirtle before Dugtrio's move, got %+v", events)
+	}
+}
+
+// TestUseMoveMissAndNoDamage tests moves that miss or deal no damage
+func TestUseMoveMissAndNoDamage(t *testing.T) {
+	chart := testTypeChart()
+	defender := testBattler("squirtle", 60, []string{"water"})
+	user := &battleTeam{player: "Player 1", members: []*battler{testBattler("pikachu", 60, []string{"electric"})}}
+	opponent := &battleTeam{player: "Player 2", members: []*battler{defender}}
+
+	events := useMove(chart, &battleField{}, user, opponent, battleMove{typeName: "electric", power: 90, accuracy: 70}, func() float64 { return 0.7 })
+	if events[0].kind != eventMiss || defender.hp != defender.maxHP {
+		t.Errorf("Expected the move to miss, got %+v", events[0])
+	}
+	events = useMove(chart, &battleField{}, user, opponent, battleMove{name: "growl", typeName: "normal"}, func() float64 { return 0 })
+	if events[0].kind != eventNoDamage || defender.hp != defender.maxHP {
+		t.Errorf("Expected the move to deal no damage, got %+v", events[0])
+	}
+}
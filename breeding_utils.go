@@ -0,0 +1,88 @@
+// This file contains the breeding rules used by the egggroups command to work out
+// which Pokémon can produce an egg together.
+package main
+
+import (
+	"slices"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// Egg groups with special breeding rules
+const (
+	eggGroupUndiscovered = "no-eggs" // Pokémon that can't breed at all
+	eggGroupDitto        = "ditto"   // Ditto, which can breed with any Pokémon that can breed
+)
+
+// breeder describes the properties of a Pokémon species that decide who it can breed with.
+type breeder struct {
+	species    string   // The species name in API format
+	eggGroups  []string // The egg groups the species belongs to
+	genderless bool     // Whether the species has no gender
+}
+
+// newBreeder builds a breeder from a species' API data.
+func newBreeder(species pokeapi.PokemonSpeciesResp) breeder {
+	groups := make([]string, 0, len(species.EggGroups))
+	for _, group := range species.EggGroups {
+		groups = append(groups, group.Name)
+	}
+	return breeder{
+		species:    species.Name,
+		eggGroups:  groups,
+		genderless: species.GenderRate == -1,
+	}
+}
+
+// inGroup reports whether the breeder belongs to the given egg group.
+func (b breeder) inGroup(group string) bool {
+	return slices.Contains(b.eggGroups, group)
+}
+
+// breedingCompatibility works out whether two Pokémon can produce an egg together,
+// following the rules of the main series games:
+//   - Pokémon in the Undiscovered group can't breed at all
+//   - Ditto can breed with any other Pokémon that can breed, except another Ditto
+//   - Otherwise the two must share an egg group, and neither can be genderless
+//
+// Gender is not checked beyond genderlessness, since the Pokédex doesn't record
+// the gender of caught Pokémon.
+//
+// Parameters:
+//   - a, b: The two Pokémon to check
+//
+// Returns:
+//   - The egg groups the two share (empty when one of them is Ditto)
+//   - Whether the two can breed
+func breedingCompatibility(a, b breeder) ([]string, bool) {
+	if a.inGroup(eggGroupUndiscovered) || b.inGroup(eggGroupUndiscovered) {
+		return nil, false
+	}
+
+	aDitto, bDitto := a.inGroup(eggGroupDitto), b.inGroup(eggGroupDitto)
+	if aDitto || bDitto {
+		return nil, aDitto != bDitto
+	}
+	if a.genderless || b.genderless {
+		return nil, false
+	}
+
+	var shared []string
+	for _, group := range a.eggGroups {
+		if b.inGroup(group) {
+			shared = append(shared, group)
+		}
+	}
+	return shared, len(shared) > 0
+}
+
+// eggGroupDisplayName returns the English name of an egg group, falling back
+// to a formatted version of its API name.
+func eggGroupDisplayName(group pokeapi.EggGroupResp) string {
+	for _, name := range group.Names {
+		if name.Language.Name == "en" && name.Name != "" {
+			return name.Name
+		}
+	}
+	return FormatLocationName(group.Name)
+}
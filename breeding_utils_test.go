@@ -0,0 +1,68 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestBreedingCompatibility tests the breeding rules for shared groups, Ditto,
+// genderless Pokémon, and the Undiscovered group
+func TestBreedingCompatibility(t *testing.T) {
+	eevee := breeder{species: "eevee", eggGroups: []string{"ground"}}
+	vaporeon := breeder{species: "vaporeon", eggGroups: []string{"ground"}}
+	lapras := breeder{species: "lapras", eggGroups: []string{"monster", "water1"}}
+	squirtle := breeder{species: "squirtle", eggGroups: []string{"monster", "water1"}}
+	ditto := breeder{species: "ditto", eggGroups: []string{"ditto"}}
+	magnemite := breeder{species: "magnemite", eggGroups: []string{"mineral"}, genderless: true}
+	bronzor := breeder{species: "bronzor", eggGroups: []string{"mineral"}, genderless: true}
+	pichu := breeder{species: "pichu", eggGroups: []string{"no-eggs"}}
+
+	tests := []struct {
+		name       string
+		a, b       breeder
+		wantOK     bool
+		wantShared []string
+	}{
+		{"shared group", eevee, vaporeon, true, []string{"ground"}},
+		{"two shared groups", lapras, squirtle, true, []string{"monster", "water1"}},
+		{"no shared group", eevee, lapras, false, nil},
+		{"ditto", eevee, ditto, true, nil},
+		{"ditto either way", ditto, lapras, true, nil},
+		{"two dittos", ditto, ditto, false, nil},
+		{"genderless with ditto", magnemite, ditto, true, nil},
+		{"two genderless", magnemite, bronzor, false, nil},
+		{"undiscovered", pichu, pichu, false, nil},
+		{"undiscovered with ditto", pichu, ditto, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shared, ok := breedingCompatibility(tt.a, tt.b)
+			if ok != tt.wantOK {
+				t.Errorf("Expected compatible=%v, got %v", tt.wantOK, ok)
+			}
+			if tt.wantOK && !slices.Equal(shared, tt.wantShared) {
+				t.Errorf("Expected shared groups %v, got %v", tt.wantShared, shared)
+			}
+		})
+	}
+}
+
+// TestNewBreeder tests that species data is converted to breeding properties
+func TestNewBreeder(t *testing.T) {
+	b := newBreeder(pokeapi.PokemonSpeciesResp{
+		Name:       "staryu",
+		EggGroups:  []pokeapi.NamedAPIResource{{Name: "water3"}},
+		GenderRate: -1,
+	})
+	if b.species != "staryu" || !b.inGroup("water3") || !b.genderless {
+		t.Errorf("Unexpected breeder: %+v", b)
+	}
+
+	b = newBreeder(pokeapi.PokemonSpeciesResp{Name: "eevee", GenderRate: 1})
+	if b.genderless {
+		t.Error("Expected a species with a gender rate of 1 to have a gender")
+	}
+}
@@ -0,0 +1,332 @@
+// This file builds the trainer cards written by the card command: a shareable
+// summary of the user with their favorite Pokémon, the ribbons they've earned,
+// and how much of the Pokédex they've completed. Cards are written either as
+// a self-contained HTML page or as a PNG image drawn with the standard library.
+package main
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// cardFiles holds the trainer card's HTML template.
+//
+//go:embed card
+var cardFiles embed.FS
+
+// cardTemplate is the trainer card's HTML page, parsed once at startup.
+var cardTemplate = template.Must(template.ParseFS(cardFiles, "card/card.html"))
+
+// cardSlots is the most favorite Pokémon a card shows, a full party.
+const cardSlots = 6
+
+// cardSpriteTimeout is how long downloading each sprite for a PNG card may take
+// before the card is drawn without it.
+const cardSpriteTimeout = 5 * time.Second
+
+// ribbonColors is the color a ribbon is drawn in on a card once it's earned.
+var ribbonColors = map[string]string{
+	ribbonVictory:  "#e6b800",
+	ribbonVeteran:  "#a0a0a8",
+	ribbonChampion: "#cc3333",
+	ribbonFlawless: "#3377cc",
+	ribbonClassic:  "#33aa55",
+}
+
+// cardPokemon is one favorite Pokémon on a card.
+type cardPokemon struct {
+	Name   string // The Pokémon's name for display
+	Sprite string // The URL of the Pokémon's sprite, or "" if it isn't known
+	Level  int    // The Pokémon's level
+}
+
+// cardBadge is one ribbon on a card.
+type cardBadge struct {
+	Name   string // The ribbon's name for display
+	Earned bool   // Whether any of the user's Pokémon has earned the ribbon
+	Color  string // The ribbon's color, as a CSS hex color
+}
+
+// trainerCard is everything a card shows.
+type trainerCard struct {
+	Name      string            // The trainer's name
+	Level     int               // The trainer level (see trainer_level.go)
+	Lang      string            // The language code of the card
+	Text      map[string]string // The card's headings and labels, translated
+	Favorites []cardPokemon     // Up to cardSlots favorite Pokémon
+	Badges    []cardBadge       // Every ribbon, in the order ribbons are listed
+	Caught    int               // How many species were caught
+	Seen      int               // How many species were seen
+	Total     int               // How many species there are
+	Percent   int               // Caught as a percentage of Total
+}
+
+// buildTrainerCard gathers what a card shows from the Pokédex. The favorites
+// are the Pokémon in the favorites box, or the party if that box is empty, and
+// completion is measured against the species in the dataset.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and species dataset
+//   - name: The trainer's name
+//
+// Returns:
+//   - The card
+func buildTrainerCard(cfg *config, name string) trainerCard {
+	card := trainerCard{Name: name, Level: currentTrainerProgress(cfg).Level, Lang: i18n.Current(), Text: cardText()}
+
+	entries := cfg.pokedex.List()
+	favorites := cardFavorites(entries, func(e pokedex.Entry) bool { return e.Box == favoritesBox })
+	if len(favorites) == 0 {
+		favorites = cardFavorites(entries, pokedex.Entry.InParty)
+	}
+	earned := make(map[string]bool)
+	for _, named := range entries {
+		for _, id := range named.Entry.Ribbons {
+			earned[id] = true
+		}
+	}
+	for _, named := range favorites {
+		card.Favorites = append(card.Favorites, cardPokemon{
+			Name:   FormatPokemonName(named.Name),
+			Sprite: pokemonSprite(cfg, named.Name, named.Entry),
+			Level:  named.Entry.CurrentLevel(),
+		})
+	}
+	for _, r := range ribbons {
+		card.Badges = append(card.Badges, cardBadge{Name: ribbonName(r.id), Earned: earned[r.id], Color: ribbonColors[r.id]})
+	}
+
+	caught := caughtSpecies(entries)
+	species := cfg.Dataset().Species
+	card.Total = len(species)
+	for _, s := range species {
+		if caught[s.Name] {
+			card.Caught++
+		}
+		if caught[s.Name] || cfg.pokedex.HasSeen(s.Name) {
+			card.Seen++
+		}
+	}
+	if card.Total > 0 {
+		card.Percent = card.Caught * 100 / card.Total
+	}
+	return card
+}
+
+// cardFavorites returns up to cardSlots of the entries that match.
+func cardFavorites(entries []pokedex.NamedEntry, match func(pokedex.Entry) bool) []pokedex.NamedEntry {
+	var favorites []pokedex.NamedEntry
+	for _, named := range entries {
+		if len(favorites) < cardSlots && match(named.Entry) {
+			favorites = append(favorites, named)
+		}
+	}
+	return favorites
+}
+
+// cardText returns the card's headings and labels in the current language.
+func cardText() map[string]string {
+	return map[string]string{
+		"title":       i18n.T("Trainer card"),
+		"trainer":     i18n.T("Trainer level"),
+		"favorites":   i18n.T("Favorites"),
+		"ribbons":     i18n.T("Ribbons"),
+		"completion":  i18n.T("Pokédex completion"),
+		"caught":      i18n.T("Caught"),
+		"seen":        i18n.T("Seen"),
+		"level":       i18n.T("Lv."),
+		"noFavorites": i18n.Sprintf("Move Pokémon into a box named '%s' to show them here.", favoritesBox),
+	}
+}
+
+// writeCardHTML writes a card as a self-contained HTML page. Sprites are
+// linked rather than embedded, so they're loaded when the page is viewed.
+func writeCardHTML(w io.Writer, card trainerCard) error {
+	return cardTemplate.Execute(w, card)
+}
+
+// fetchCardSprites downloads the sprites of a card's favorites for drawing
+// them on a PNG card. A sprite that can't be downloaded is left nil, so the
+// card is still drawn with an empty slot.
+func fetchCardSprites(card trainerCard) []image.Image {
+	client := &http.Client{Timeout: cardSpriteTimeout}
+	sprites := make([]image.Image, len(card.Favorites))
+	for i, p := range card.Favorites {
+		if p.Sprite == "" {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), cardSpriteTimeout)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.Sprite, nil)
+		if err == nil {
+			var resp *http.Response
+			if resp, err = client.Do(req); err == nil {
+				if resp.StatusCode == http.StatusOK {
+					sprites[i], _ = png.Decode(resp.Body)
+				}
+				resp.Body.Close()
+			}
+		}
+		cancel()
+	}
+	return sprites
+}
+
+// PNG card layout, in pixels
+const (
+	cardWidth      = 640
+	cardHeight     = 360
+	cardHeaderSize = 72
+	cardSpriteSize = 96 // The size of PokeAPI's sprites
+	cardMargin     = 24
+)
+
+// Colors of a PNG card
+var (
+	cardBackground = color.RGBA{0xfa, 0xfa, 0xfc, 0xff}
+	cardRed        = color.RGBA{0xcc, 0x33, 0x33, 0xff}
+	cardWhite      = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	cardDark       = color.RGBA{0x22, 0x22, 0x22, 0xff}
+	cardMuted      = color.RGBA{0x88, 0x88, 0x88, 0xff}
+	cardSlot       = color.RGBA{0xf0, 0xf0, 0xf3, 0xff}
+	cardUnearned   = color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+)
+
+// renderCardPNG draws a card as a PNG image. Text is written in a small
+// built-in font, in capitals and without accents.
+//
+// Parameters:
+//   - w: Where to write the image
+//   - card: The card to draw
+//   - sprites: The sprites of the card's favorites, in order; nil sprites are
+//     drawn as empty slots
+//
+// Returns:
+//   - An error if encoding or writing the image fails
+func renderCardPNG(w io.Writer, card trainerCard, sprites []image.Image) error {
+	img := image.NewRGBA(image.Rect(0, 0, cardWidth, cardHeight))
+	fillRect(img, img.Bounds(), cardBackground)
+
+	// Header with the trainer's name
+	fillRect(img, image.Rect(0, 0, cardWidth, cardHeaderSize), cardRed)
+	drawText(img, cardMargin, 14, 2, cardWhite, card.Text["title"])
+	drawText(img, cardMargin, 36, 3, cardWhite, card.Name)
+	level := fmt.Sprintf("%s %d", card.Text["trainer"], card.Level)
+	drawText(img, cardWidth-cardMargin-textWidth(level, 2), 14, 2, cardWhite, level)
+
+	// Favorites, evenly spaced across the card
+	gap := (cardWidth - 2*cardMargin - cardSlots*cardSpriteSize) / (cardSlots - 1)
+	top := cardHeaderSize + 16
+	for i := range cardSlots {
+		x := cardMargin + i*(cardSpriteSize+gap)
+		slot := image.Rect(x, top, x+cardSpriteSize, top+cardSpriteSize)
+		fillRect(img, slot, cardSlot)
+		if i >= len(card.Favorites) {
+			continue
+		}
+		if i < len(sprites) && sprites[i] != nil {
+			draw.Draw(img, slot, scaleSprite(sprites[i], cardSpriteSize), image.Point{}, draw.Over)
+		}
+		p := card.Favorites[i]
+		drawCentered(img, x+cardSpriteSize/2, slot.Max.Y+6, 1, cardDark, fitText(p.Name, cardSpriteSize, 1))
+		drawCentered(img, x+cardSpriteSize/2, slot.Max.Y+18, 1, cardMuted, fmt.Sprintf("%s %d", card.Text["level"], p.Level))
+	}
+
+	// Completion bar
+	top += cardSpriteSize + 44
+	drawText(img, cardMargin, top, 2, cardDark, fmt.Sprintf("%s: %d%%", card.Text["completion"], card.Percent))
+	bar := image.Rect(cardMargin, top+22, cardWidth-cardMargin, top+36)
+	fillRect(img, bar, cardSlot)
+	fillRect(img, image.Rect(bar.Min.X, bar.Min.Y, bar.Min.X+bar.Dx()*card.Percent/100, bar.Max.Y), cardRed)
+	drawText(img, cardMargin, bar.Max.Y+6, 1, cardMuted, fmt.Sprintf("%s %d/%d - %s %d/%d",
+		card.Text["caught"], card.Caught, card.Total, card.Text["seen"], card.Seen, card.Total))
+
+	// Ribbons, as circles under the completion bar
+	top = bar.Max.Y + 32
+	spacing := (cardWidth - 2*cardMargin) / max(len(card.Badges), 1)
+	for i, badge := range card.Badges {
+		center := image.Pt(cardMargin+spacing*i+spacing/2, top+14)
+		fill := color.Color(cardUnearned)
+		text := color.Color(cardMuted)
+		if badge.Earned {
+			fill = parseHexColor(badge.Color)
+			text = cardDark
+		}
+		fillCircle(img, center, 14, fill)
+		drawCentered(img, center.X, center.Y+20, 1, text, badge.Name)
+	}
+
+	return png.Encode(w, img)
+}
+
+// drawCentered draws text centered horizontally on x.
+func drawCentered(img *image.RGBA, x, y, scale int, c color.Color, text string) {
+	drawText(img, x-textWidth(text, scale)/2, y, scale, c, text)
+}
+
+// fitText shortens text that's wider than width pixels at a scale, ending it
+// with a period.
+func fitText(text string, width, scale int) string {
+	runes := []rune(text)
+	if textWidth(text, scale) <= width {
+		return text
+	}
+	for len(runes) > 0 && textWidth(string(runes)+".", scale) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "."
+}
+
+// fillRect fills a rectangle of an image with a color.
+func fillRect(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// fillCircle fills a circle of an image with a color.
+func fillCircle(img *image.RGBA, center image.Point, radius int, c color.Color) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				img.Set(center.X+x, center.Y+y, c)
+			}
+		}
+	}
+}
+
+// scaleSprite returns a sprite scaled to a square of the given size, using the
+// nearest pixel so pixel art stays sharp. Sprites of that size are returned as
+// they are.
+func scaleSprite(sprite image.Image, size int) image.Image {
+	b := sprite.Bounds()
+	if b.Dx() == size && b.Dy() == size && b.Min == (image.Point{}) {
+		return sprite
+	}
+	scaled := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := range size {
+		for x := range size {
+			scaled.Set(x, y, sprite.At(b.Min.X+x*b.Dx()/size, b.Min.Y+y*b.Dy()/size))
+		}
+	}
+	return scaled
+}
+
+// parseHexColor parses a CSS hex color of the form "#rrggbb", returning
+// black for anything else.
+func parseHexColor(hex string) color.RGBA {
+	c := color.RGBA{A: 0xff}
+	if _, err := fmt.Sscanf(hex, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+		return color.RGBA{A: 0xff}
+	}
+	return c
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestBuildTrainerCard tests choosing favorites, lighting earned ribbons, and
+// measuring completion
+func TestBuildTrainerCard(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	pikachu := pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric"))
+	pikachu.Ribbons = []string{ribbonVeteran}
+	cfg.pokedex.Add("pikachu", pikachu)
+	squirtle := pokedex.NewEntry(testMatchupPokemon(t, "squirtle", 314, "water"))
+	squirtle.Box = "storage"
+	cfg.pokedex.Add("squirtle", squirtle)
+
+	// With no favorites box, the party is shown
+	card := buildTrainerCard(cfg, "Ash")
+	if len(card.Favorites) != 1 || card.Favorites[0].Name != "Pikachu" {
+		t.Fatalf("Expected the party as favorites, got %+v", card.Favorites)
+	}
+	if !strings.HasSuffix(card.Favorites[0].Sprite, "/25.png") {
+		t.Errorf("Expected the sprite from Pikachu's dataset number, got %q", card.Favorites[0].Sprite)
+	}
+	if card.Caught != 2 || card.Total == 0 || card.Percent != 2*100/card.Total {
+		t.Errorf("Unexpected completion %d/%d (%d%%)", card.Caught, card.Total, card.Percent)
+	}
+	if len(card.Badges) != len(ribbons) || card.Badges[0].Earned || !card.Badges[1].Earned {
+		t.Errorf("Expected only the Veteran Ribbon to be earned, got %+v", card.Badges)
+	}
+
+	cfg.pokedex.Update("squirtle", func(entry *pokedex.Entry) error {
+		entry.Box = favoritesBox
+		return nil
+	})
+	if card := buildTrainerCard(cfg, "Ash"); len(card.Favorites) != 1 || card.Favorites[0].Name != "Squirtle" {
+		t.Errorf("Expected the favorites box to be shown, got %+v", card.Favorites)
+	}
+}
+
+// TestWriteCardHTML tests that the page shows the card's contents
+func TestWriteCardHTML(t *testing.T) {
+	card := trainerCard{
+		Name:      "Ash <Ketchum>",
+		Level:     7,
+		Text:      cardText(),
+		Favorites: []cardPokemon{{Name: "Pikachu", Sprite: "https://example.com/25.png", Level: 25}},
+		Badges:    []cardBadge{{Name: "Victory Ribbon", Earned: true, Color: "#e6b800"}},
+		Caught:    2, Seen: 3, Total: 151, Percent: 1,
+	}
+	var out strings.Builder
+	if err := writeCardHTML(&out, card); err != nil {
+		t.Fatalf("writeCardHTML returned an error: %v", err)
+	}
+	page := out.String()
+	for _, want := range []string{"Ash &lt;Ketchum&gt;", `<img src="https://example.com/25.png" alt="Pikachu">`, "Lv. 25", "Trainer level 7", "background: #e6b800", "Caught 2/151"} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected the page to contain %q", want)
+		}
+	}
+}
+
+// TestRenderCardPNG tests the image's size and that sprites and earned
+// ribbons are drawn
+func TestRenderCardPNG(t *testing.T) {
+	sprite := image.NewRGBA(image.Rect(0, 0, 48, 48))
+	fillRect(sprite, sprite.Bounds(), color.RGBA{0, 0xff, 0, 0xff})
+	card := trainerCard{
+		Name:      "Ash",
+		Text:      cardText(),
+		Favorites: []cardPokemon{{Name: "Pikachu", Level: 25}},
+		Badges:    []cardBadge{{Name: "Victory Ribbon", Earned: true, Color: "#e6b800"}},
+		Percent:   50,
+	}
+	var out bytes.Buffer
+	if err := renderCardPNG(&out, card, []image.Image{sprite}); err != nil {
+		t.Fatalf("renderCardPNG returned an error: %v", err)
+	}
+	img, err := png.Decode(&out)
+	if err != nil {
+		t.Fatalf("The card is not a valid PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != cardWidth || b.Dy() != cardHeight {
+		t.Errorf("Expected a %dx%d card, got %v", cardWidth, cardHeight, b)
+	}
+	checks := []struct {
+		what string
+		at   image.Point
+		want color.RGBA
+	}{
+		{"header", image.Pt(cardWidth-2, 2), cardRed},
+		{"scaled sprite", image.Pt(cardMargin+cardSpriteSize-1, cardHeaderSize+16+cardSpriteSize-1), color.RGBA{0, 0xff, 0, 0xff}},
+		{"empty slot", image.Pt(cardWidth-cardMargin-cardSpriteSize/2, cardHeaderSize+16), cardSlot},
+		{"ribbon", image.Pt(cardWidth/2, 310), parseHexColor("#e6b800")},
+	}
+	for _, c := range checks {
+		if got := color.RGBAModel.Convert(img.At(c.at.X, c.at.Y)); got != c.want {
+			t.Errorf("Expected the %s at %v to be %v, got %v", c.what, c.at, c.want, got)
+		}
+	}
+}
+
+// TestCardFontText tests folding text into what the font can draw
+func TestCardFontText(t *testing.T) {
+	if got := cardFontText("Pokédex: Flabébé ♀"); got != "POKEDEX: FLABEBE ?" {
+		t.Errorf("Unexpected text %q", got)
+	}
+	if got := fitText("Crabominable", 48, 1); got != "Crabomi." {
+		t.Errorf("Unexpected shortened text %q", got)
+	}
+}
@@ -0,0 +1,80 @@
+// This file provides a horizontal bar chart renderer for the PokédexCLI application.
+// Each bar is drawn with '#' characters scaled to the largest value, with the
+// value printed after it, so charts fit in the terminal and read well as text.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxBarWidth is the length of the bar drawn for the largest value in a chart.
+const maxBarWidth = 40
+
+// BarChart holds labelled values to be rendered as horizontal bars.
+// Build a chart with NewBarChart, add bars with Add, and then call Print or Render.
+type BarChart struct {
+	labels []string // The label of each bar, in display order
+	values []int    // The value of each bar
+}
+
+// NewBarChart creates an empty bar chart.
+func NewBarChart() *BarChart {
+	return &BarChart{}
+}
+
+// Add appends a bar to the chart.
+//
+// Parameters:
+//   - label: The text shown before the bar
+//   - value: The value the bar represents (negative values are drawn as zero)
+func (c *BarChart) Add(label string, value int) {
+	c.labels = append(c.labels, label)
+	c.values = append(c.values, max(value, 0))
+}
+
+// Len returns the number of bars that have been added to the chart.
+func (c *BarChart) Len() int {
+	return len(c.labels)
+}
+
+// Print renders the chart to standard output.
+func (c *BarChart) Print() {
+	c.Render(os.Stdout)
+}
+
+// Render writes the chart to the provided writer.
+// Labels are padded to the same width and bars are scaled so the largest value
+// fills maxBarWidth; any non-zero value gets at least one character. In
+// accessible mode, each bar is written as "label: value" instead.
+//
+// Parameters:
+//   - w: The writer to render the chart to
+func (c *BarChart) Render(w io.Writer) {
+	if isAccessibleOutput() {
+		for i, label := range c.labels {
+			fmt.Fprintf(w, "%s: %d\n", label, c.values[i])
+		}
+		return
+	}
+
+	labelWidth, largest := 0, 0
+	for i, label := range c.labels {
+		labelWidth = max(labelWidth, displayWidth(label))
+		largest = max(largest, c.values[i])
+	}
+
+	for i, label := range c.labels {
+		length := 0
+		if largest > 0 {
+			length = c.values[i] * maxBarWidth / largest
+			if c.values[i] > 0 {
+				length = max(length, 1)
+			}
+		}
+		padding := strings.Repeat(" ", labelWidth-displayWidth(label))
+		fmt.Fprintf(w, "%s%s  %s %d\n", label, padding, strings.Repeat("#", length), c.values[i])
+	}
+}
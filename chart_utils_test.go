@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestBarChartRender verifies that bars are scaled to the largest value and
+// that small non-zero values still get a bar
+func TestBarChartRender(t *testing.T) {
+	chart := NewBarChart()
+	chart.Add("Fire", 40)
+	chart.Add("Flabébé", 1)
+	chart.Add("Ice", 0)
+
+	var buf bytes.Buffer
+	chart.Render(&buf)
+
+	expected := strings.Join([]string{
+		"Fire     " + strings.Repeat("#", maxBarWidth) + " 40",
+		"Flabébé  # 1",
+		"Ice       0",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("Unexpected chart output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+// TestBarChartRenderAccessible verifies that in accessible mode bars are written as labelled values
+func TestBarChartRenderAccessible(t *testing.T) {
+	configureOutput(true)
+	defer configureOutput(false)
+
+	chart := NewBarChart()
+	chart.Add("Fire", 3)
+
+	var buf bytes.Buffer
+	chart.Render(&buf)
+	if buf.String() != "Fire: 3\n" {
+		t.Errorf("Unexpected accessible chart output: %q", buf.String())
+	}
+}
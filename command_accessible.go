@@ -0,0 +1,59 @@
+// This file implements the accessible mode setting for the Pokédex CLI application.
+// The output renderer in output_utils.go does the actual work of changing the output.
+package main
+
+import (
+	"fmt"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// commandAccessible turns accessible mode on or off. Accessible mode makes the
+// output friendlier to screen readers: tables are read out as labelled lines,
+// separators and symbols are left out, and commands like 'describe' and
+// 'showoff' make the same choice every time instead of a random one.
+// The setting is saved with the Pokédex so it persists between sessions.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters, where params[0] is either "on", "off", or omitted
+//
+// Returns:
+//   - An error if the parameter is invalid or the setting can't be saved
+func commandAccessible(cfg *config, params []string) error {
+	// If no parameter is provided, display the current status
+	if len(params) == 0 {
+		status := i18n.T("disabled")
+		if cfg.Settings().accessible {
+			status = i18n.T("enabled")
+		}
+		i18n.Printf("Accessible mode is currently %s. Use 'accessible on' or 'accessible off' to change it.\n", status)
+		printSeparator()
+		return nil
+	}
+
+	var accessible bool
+	switch params[0] {
+	case "on", "true", "1", "enable", "enabled":
+		accessible = true
+	case "off", "false", "0", "disable", "disabled":
+		accessible = false
+	default:
+		return fmt.Errorf(i18n.T("invalid parameter: %s (use 'on' or 'off')"), params[0])
+	}
+
+	cfg.UpdateSettings(func(s *settings) {
+		s.accessible = accessible
+	})
+	configureOutput(accessible)
+
+	if accessible {
+		i18n.Println("Accessible mode enabled. Output is plain text without tables or separators, and the same every time.")
+	} else {
+		i18n.Println("Accessible mode disabled.")
+	}
+	printSeparator()
+
+	// Save the configuration itself, including the new accessible mode setting
+	return savePokedexData(cfg)
+}
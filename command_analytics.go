@@ -0,0 +1,138 @@
+// This file implements the analytics command for the Pokédex CLI application.
+// It charts how the user's collection is spread across types, generations,
+// and stat totals, computed from the Pokédex entries and the species data
+// cached by the API client.
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// statTotalBucketSize is the width of each range in the stat total chart.
+const statTotalBucketSize = 100
+
+// commandAnalytics charts the distribution of types, generations, and base
+// stat totals in the user's Pokédex. Dual-type Pokémon count toward both of
+// their types. Generations come from each Pokémon's species data; Pokémon
+// whose species can't be loaded are counted as unknown.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters (not used in this command)
+//
+// Returns:
+//   - Always nil; species data that can't be loaded is left out of the generation chart
+func commandAnalytics(cfg *config, params []string) error {
+	entries := cfg.pokedex.All()
+	if len(entries) == 0 {
+		i18n.Println("You have not caught any Pokémon yet")
+		printSeparator()
+		return nil
+	}
+
+	i18n.Printf("Your Pokédex has %d Pokémon.\n", len(entries))
+
+	fmt.Println()
+	i18n.Println("Types:")
+	typeDistributionChart(cfg.pokedex.Stats().Types).Print()
+
+	fmt.Println()
+	i18n.Println("Generations:")
+	generationDistributionChart(cfg, entries).Print()
+
+	fmt.Println()
+	i18n.Println("Base stat totals:")
+	statTotalDistributionChart(entries).Print()
+
+	printSeparator()
+	return nil
+}
+
+// typeDistributionChart charts the number of Pokémon of each type, most common first.
+func typeDistributionChart(types map[string]int) *BarChart {
+	names := slices.SortedFunc(maps.Keys(types), func(a, b string) int {
+		return cmp.Or(cmp.Compare(types[b], types[a]), cmp.Compare(a, b))
+	})
+	chart := NewBarChart()
+	for _, name := range names {
+		chart.Add(FormatTypeName(name), types[name])
+	}
+	return chart
+}
+
+// generationDistributionChart charts the number of Pokémon introduced in each generation,
+// in generation order, looking up each Pokémon's species.
+func generationDistributionChart(cfg *config, entries map[string]pokedex.Entry) *BarChart {
+	counts := make(map[int]int)
+	names := make(map[int]string)
+	unknown := 0
+	for name, entry := range entries {
+		id, generation, ok := pokemonGeneration(cfg, name, entry)
+		if !ok {
+			unknown++
+			continue
+		}
+		counts[id]++
+		names[id] = generation
+	}
+
+	chart := NewBarChart()
+	for _, id := range slices.Sorted(maps.Keys(counts)) {
+		chart.Add(formatGenerationName(names[id]), counts[id])
+	}
+	if unknown > 0 {
+		chart.Add(i18n.T("Unknown"), unknown)
+	}
+	return chart
+}
+
+// pokemonGeneration looks up the generation a Pokémon's species was introduced in.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - name: The Pokémon's name in the Pokédex, for debug logging
+//   - entry: The Pokémon's Pokédex entry
+//
+// Returns:
+//   - The generation's number
+//   - The generation's name in API format (e.g. "generation-i")
+//   - Whether the generation is known; it isn't if the species can't be loaded
+func pokemonGeneration(cfg *config, name string, entry pokedex.Entry) (int, string, bool) {
+	species, err := cfg.pokeapiClient.GetPokemonSpecies(entry.Species.Name)
+	if err != nil {
+		if cfg.Settings().debugMode {
+			log.Printf("Could not load the species data of %s: %v", name, err)
+		}
+		return 0, "", false
+	}
+	id, err := species.Generation.ID()
+	if err != nil {
+		return 0, "", false
+	}
+	return id, species.Generation.Name, true
+}
+
+// statTotalDistributionChart charts the number of Pokémon in each range of base stat
+// totals (e.g. "400-499"), from the lowest range to the highest, including
+// empty ranges in between.
+func statTotalDistributionChart(entries map[string]pokedex.Entry) *BarChart {
+	counts := make(map[int]int)
+	for _, entry := range entries {
+		counts[baseStatTotal(entry.PokemonDataResp)/statTotalBucketSize]++
+	}
+
+	buckets := slices.Sorted(maps.Keys(counts))
+	chart := NewBarChart()
+	for bucket := buckets[0]; bucket <= buckets[len(buckets)-1]; bucket++ {
+		low := bucket * statTotalBucketSize
+		chart.Add(fmt.Sprintf("%d-%d", low, low+statTotalBucketSize-1), counts[bucket])
+	}
+	return chart
+}
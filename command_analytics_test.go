@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestStatTotalDistributionChart tests that stat totals are grouped into ranges,
+// including empty ranges between the lowest and highest
+func TestStatTotalDistributionChart(t *testing.T) {
+	entries := map[string]pokedex.Entry{
+		"caterpie":  pokedex.NewEntry(testMatchupPokemon(t, "caterpie", 195, "bug")),
+		"pidgey":    pokedex.NewEntry(testMatchupPokemon(t, "pidgey", 251, "normal")),
+		"dragonite": pokedex.NewEntry(testMatchupPokemon(t, "dragonite", 600, "dragon")),
+	}
+
+	configureOutput(true)
+	defer configureOutput(false)
+	var buf bytes.Buffer
+	statTotalDistributionChart(entries).Render(&buf)
+
+	expected := strings.Join([]string{
+		"100-199: 1", "200-299: 1", "300-399: 0", "400-499: 0", "500-599: 0", "600-699: 1", "",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("Unexpected stat total chart:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+// TestTypeDistributionChart tests that the most common types are charted first
+func TestTypeDistributionChart(t *testing.T) {
+	configureOutput(true)
+	defer configureOutput(false)
+	var buf bytes.Buffer
+	typeDistributionChart(map[string]int{"fire": 1, "water": 3, "bug": 1}).Render(&buf)
+
+	if expected := "Water: 3\nBug: 1\nFire: 1\n"; buf.String() != expected {
+		t.Errorf("Unexpected type chart:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
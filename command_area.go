@@ -0,0 +1,183 @@
+// This file implements the area command, which shows how many of the Pokémon
+// found in a location area have been caught there, and awards a completion
+// badge once every one of them has. Catches remember where they happened (see
+// catchPokemon), so the progress is worked out from the Pokédex each time and
+// goes back down if a Pokémon caught there is released.
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// areaUsage describes the forms of the area command.
+const areaUsage = "Usage: area progress [location number or name] (the area explored last if none is given)"
+
+// areaProgress is how many of the Pokémon found in a location area have been caught there.
+type areaProgress struct {
+	Location string   `json:"location"` // The location area's name in API format
+	Caught   []string `json:"caught"`   // The API names of the Pokémon caught there, in alphabetical order
+	Missing  []string `json:"missing"`  // The API names of those still to catch there, in alphabetical order
+}
+
+// Total returns the number of different Pokémon found in the area.
+func (p areaProgress) Total() int {
+	return len(p.Caught) + len(p.Missing)
+}
+
+// Complete reports whether every Pokémon found in the area has been caught there.
+func (p areaProgress) Complete() bool {
+	return len(p.Missing) == 0 && len(p.Caught) > 0
+}
+
+// commandArea implements the "area" command.
+// Supported forms:
+//   - area progress: Show the progress in the area explored last
+//   - area progress <location>: Show the progress in a location area, given by
+//     its number on the map page, its bookmark, or its name
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is "progress"
+//
+// Returns:
+//   - An error if the parameters are invalid, no area has been explored, or
+//     the area can't be looked up
+func commandArea(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) >= 1 && params[0] == "progress":
+		var location string
+		if location, err = areaFromParams(cfg, params[1:]); err == nil {
+			err = showAreaProgress(cfg, location)
+		}
+	default:
+		err = errorhandling.NewInvalidInputError(areaUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "area", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// areaFromParams returns the location area chosen for the area command: the
+// area explored last if none is given, or else one given the way explore takes
+// it, or by its name.
+//
+// Parameters:
+//   - cfg: The application configuration containing the map page and bookmarks
+//   - params: The location parameters, if any
+//
+// Returns:
+//   - The location area's name in API format
+//   - An error if no area is given and none has been explored, or the number is invalid
+func areaFromParams(cfg *config, params []string) (string, error) {
+	if len(params) == 0 {
+		location := cfg.ExploredArea()
+		if location == "" {
+			return "", errorhandling.NewInvalidInputError(
+				"No area explored yet. Explore one first, or give its number on the map page.", nil)
+		}
+		return location, nil
+	}
+	location := ConvertToAPIFormat(strings.Join(params, " "))
+	if isNumber(params[0]) || slices.Contains(cfg.Bookmarks(), location) {
+		return locationFromParams(cfg, params)
+	}
+	// Anything else is taken to be the area's name, which the API checks
+	return location, nil
+}
+
+// showAreaProgress looks up the Pokémon found in a location area and shows
+// which of them have been caught there.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - location: The location area's name in API format
+//
+// Returns:
+//   - An error if the area doesn't exist or the API request fails
+func showAreaProgress(cfg *config, location string) error {
+	resp, err := cfg.pokeapiClient.ExploreLocation(location)
+	if err != nil {
+		return err
+	}
+	found := make([]string, 0, len(resp.PokemonEncounters))
+	for _, encounter := range resp.PokemonEncounters {
+		found = append(found, encounter.Pokemon.Name)
+	}
+	progress := areaProgressOf(cfg, location, found)
+
+	i18n.Printf("%s: %d/%d caught there\n", FormatLocationName(location), len(progress.Caught), progress.Total())
+	if progress.Total() == 0 {
+		i18n.Println("No Pokémon are found here.")
+		return nil
+	}
+	table := NewTable("Pokémon", "Caught")
+	for _, name := range progress.Caught {
+		table.AddRow(FormatPokemonName(name), i18n.T("yes"))
+	}
+	for _, name := range progress.Missing {
+		table.AddRow(FormatPokemonName(name), i18n.T("not yet"))
+	}
+	table.Print()
+	if progress.Complete() {
+		i18n.Printf("★ Area complete! You've caught every Pokémon found in %s.\n", FormatLocationName(location))
+	}
+	return nil
+}
+
+// areaProgressOf works out which of the Pokémon found in a location area have
+// been caught there, according to where each Pokémon in the Pokédex was caught.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - location: The location area's name in API format
+//   - found: The API names of the Pokémon found there, which may repeat
+//
+// Returns:
+//   - The progress in the area
+func areaProgressOf(cfg *config, location string, found []string) areaProgress {
+	progress := areaProgress{Location: location}
+	names := slices.Clone(found)
+	slices.Sort(names)
+	for _, name := range slices.Compact(names) {
+		if entry, ok := cfg.pokedex.Get(name); ok && entry.CaughtAt == location {
+			progress.Caught = append(progress.Caught, name)
+		} else {
+			progress.Missing = append(progress.Missing, name)
+		}
+	}
+	return progress
+}
+
+// completedAreaBy returns the location area a catch has just completed: the
+// area explored last, if the Pokémon is found there, hadn't already been
+// caught there, and was the last one left to catch there.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and explored area
+//   - apiName: The API name of the Pokémon just caught
+//   - caughtHereBefore: Whether it had already been caught in the area before this catch
+//
+// Returns:
+//   - The completed area's name in API format, or "" if the catch didn't complete one
+func completedAreaBy(cfg *config, apiName string, caughtHereBefore bool) string {
+	location := cfg.ExploredLocationOf(apiName)
+	if location == "" || caughtHereBefore {
+		return ""
+	}
+	if !areaProgressOf(cfg, location, cfg.ExploredPokemon()).Complete() {
+		return ""
+	}
+	return location
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestAreaProgressOf tests counting the Pokémon caught in a location area
+func TestAreaProgressOf(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	caughtAt := func(name, location string) {
+		entry := pokedex.NewEntry(pokeapi.PokemonDataResp{Name: name})
+		entry.CaughtAt = location
+		cfg.pokedex.Add(name, entry)
+	}
+	caughtAt("shellos", "canalave-city-area")
+	caughtAt("tentacool", "route-218-area")
+	caughtAt("wingull", "")
+
+	found := []string{"wingull", "shellos", "tentacool", "shellos"}
+	progress := areaProgressOf(cfg, "canalave-city-area", found)
+	if !slices.Equal(progress.Caught, []string{"shellos"}) || !slices.Equal(progress.Missing, []string{"tentacool", "wingull"}) {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+	if progress.Total() != 3 || progress.Complete() {
+		t.Errorf("Expected 1/3 caught, got %d/%d (complete: %v)", len(progress.Caught), progress.Total(), progress.Complete())
+	}
+
+	caughtAt("tentacool", "canalave-city-area")
+	caughtAt("wingull", "canalave-city-area")
+	if progress := areaProgressOf(cfg, "canalave-city-area", found); !progress.Complete() {
+		t.Errorf("Expected the area to be complete, got %+v", progress)
+	}
+	if areaProgressOf(cfg, "canalave-city-area", nil).Complete() {
+		t.Error("Expected an area with no Pokémon never to be complete")
+	}
+}
+
+// TestCompletedAreaBy tests that the completion badge is awarded once, by the last catch
+func TestCompletedAreaBy(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	cfg.SetExploredArea("canalave-city-area", []string{"shellos", "wingull"})
+	catch := func(name string) {
+		entry := pokedex.NewEntry(pokeapi.PokemonDataResp{Name: name})
+		entry.CaughtAt = cfg.ExploredLocationOf(name)
+		cfg.pokedex.Add(name, entry)
+	}
+
+	catch("shellos")
+	if area := completedAreaBy(cfg, "shellos", false); area != "" {
+		t.Errorf("Expected no area completed yet, got %q", area)
+	}
+	catch("wingull")
+	if area := completedAreaBy(cfg, "wingull", false); area != "canalave-city-area" {
+		t.Errorf("Expected the last catch to complete the area, got %q", area)
+	}
+	if area := completedAreaBy(cfg, "wingull", true); area != "" {
+		t.Errorf("Expected catching it there again not to complete the area again, got %q", area)
+	}
+	if area := completedAreaBy(cfg, "pikachu", false); area != "" {
+		t.Errorf("Expected a Pokémon not found there not to complete the area, got %q", area)
+	}
+}
@@ -6,6 +6,9 @@ package main
 import (
 	"fmt"
 	"strconv"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 )
 
 // commandAutoSave controls the auto-save feature of the application.
@@ -21,24 +24,28 @@ import (
 func commandAutoSave(cfg *config, params []string) error {
 	// If no parameter is provided, display the current status
 	if len(params) == 0 {
-		status := "enabled"
-		if !cfg.autoSaveEnabled {
-			status = "disabled"
+		status := i18n.T("enabled")
+		if !cfg.Settings().autoSaveEnabled {
+			status = i18n.T("disabled")
 		}
-		fmt.Printf("Auto-save is currently %s\n", status)
+		i18n.Printf("Auto-save is currently %s\n", status)
 		return nil
 	}
 
 	// Otherwise, update the setting based on the provided parameter
 	switch params[0] {
 	case "on", "true", "1", "enable", "enabled":
-		cfg.autoSaveEnabled = true
-		fmt.Println("Auto-save enabled. Your Pokédex will be saved automatically after changes.")
+		cfg.UpdateSettings(func(s *settings) {
+			s.autoSaveEnabled = true
+		})
+		i18n.Println("Auto-save enabled. Your Pokédex will be saved automatically after changes.")
 	case "off", "false", "0", "disable", "disabled":
-		cfg.autoSaveEnabled = false
-		fmt.Println("Auto-save disabled. Use 'save' command to manually save your Pokédex.")
+		cfg.UpdateSettings(func(s *settings) {
+			s.autoSaveEnabled = false
+		})
+		i18n.Println("Auto-save disabled. Use 'save' command to manually save your Pokédex.")
 	default:
-		return fmt.Errorf("invalid parameter: %s (use 'on' or 'off')", params[0])
+		return fmt.Errorf(i18n.T("invalid parameter: %s (use 'on' or 'off')"), params[0])
 	}
 
 	// Save the configuration itself, including the new autosave setting
@@ -47,7 +54,8 @@ func commandAutoSave(cfg *config, params []string) error {
 
 // autoSaveIfEnabled saves the Pokédex data if auto-save is enabled.
 // This function is called after operations that modify the Pokédex,
-// such as catching or releasing Pokémon.
+// such as catching or releasing Pokémon. In the REPL, the save is written in
+// the background (see background_save.go).
 //
 // Parameters:
 //   - cfg: The application configuration containing auto-save settings
@@ -55,49 +63,145 @@ func commandAutoSave(cfg *config, params []string) error {
 // Returns:
 //   - An error if auto-save is enabled but the save operation fails
 func autoSaveIfEnabled(cfg *config) error {
-	if cfg.autoSaveEnabled {
-		return savePokedexData(cfg)
+	if cfg.Settings().autoSaveEnabled {
+		return saveInBackground(cfg)
 	}
 	return nil
 }
 
-// commandSaveInterval sets how many changes should occur before auto-saving.
-// This allows users to control the frequency of auto-saves, balancing between
-// data safety and performance.
+// minAutoSaveEvery is the shortest time allowed between timed auto-saves.
+const minAutoSaveEvery = 10 * time.Second
+
+// commandSaveInterval sets how often auto-saves occur: after a number of
+// changes, or on a timer that saves unsaved changes in the background. This
+// allows users to control the frequency of auto-saves, balancing between
+// data safety and performance. Supported forms:
+//   - saveinterval 5: Save after every 5 changes
+//   - saveinterval 5m: Also save every 5 minutes if there are unsaved changes
+//   - saveinterval off: Stop saving on a timer
 //
 // Parameters:
 //   - cfg: The application configuration containing auto-save settings
-//   - params: Command parameters, where params[0] is the number of changes before saving
+//   - params: Command parameters, where params[0] is the number of changes
+//     before saving, a duration, or "off"
 //
 // Returns:
-//   - An error if the parameter is invalid or missing
+//   - An error if the parameter is invalid
 func commandSaveInterval(cfg *config, params []string) error {
 	// If no parameter is provided, display the current interval
 	if len(params) == 0 {
-		if cfg.autoSaveInterval == 1 {
-			fmt.Println("Auto-save occurs after every change to your Pokédex.")
+		current := cfg.Settings()
+		if current.autoSaveInterval == 1 {
+			i18n.Println("Auto-save occurs after every change to your Pokédex.")
 		} else {
-			fmt.Printf("Auto-save occurs after every %d changes to your Pokédex.\n", cfg.autoSaveInterval)
+			i18n.Printf("Auto-save occurs after every %d changes to your Pokédex.\n", current.autoSaveInterval)
+		}
+		if current.autoSaveEvery > 0 {
+			i18n.Printf("Unsaved changes are also saved every %s.\n", current.autoSaveEvery)
 		}
 		return nil
 	}
 
+	if params[0] == "off" {
+		cfg.UpdateSettings(func(s *settings) {
+			s.autoSaveEvery = 0
+		})
+		startAutoSaveTimer(cfg)
+		i18n.Println("Auto-save will no longer run on a timer.")
+		return nil
+	}
+
+	// A duration like 5m turns on the timer; a plain number sets the change count
+	if every, err := time.ParseDuration(params[0]); err == nil {
+		if every < minAutoSaveEvery {
+			return fmt.Errorf(i18n.T("invalid interval: %s (must be at least %s)"), params[0], minAutoSaveEvery)
+		}
+		cfg.UpdateSettings(func(s *settings) {
+			s.autoSaveEvery = every
+		})
+		startAutoSaveTimer(cfg)
+		i18n.Printf("Unsaved changes will be saved every %s.\n", every)
+		return nil
+	}
+
 	// Parse the provided interval
 	interval, err := strconv.Atoi(params[0])
 	if err != nil || interval < 1 {
-		return fmt.Errorf("invalid interval: %s (must be a positive number)", params[0])
+		return fmt.Errorf(i18n.T("invalid interval: %s (must be a positive number or a duration like 5m)"), params[0])
 	}
 
 	// Update the interval
-	cfg.autoSaveInterval = interval
+	cfg.UpdateSettings(func(s *settings) {
+		s.autoSaveInterval = interval
+	})
 
 	// Provide feedback
 	if interval == 1 {
-		fmt.Println("Auto-save will occur after every change to your Pokédex.")
+		i18n.Println("Auto-save will occur after every change to your Pokédex.")
 	} else {
-		fmt.Printf("Auto-save will occur after every %d changes to your Pokédex.\n", interval)
+		i18n.Printf("Auto-save will occur after every %d changes to your Pokédex.\n", interval)
 	}
 
 	// Save the configuration itself, including the new interval setting
 	return savePokedexData(cfg)
 }
+
+// startAutoSaveTimer starts saving unsaved changes in the background at the
+// interval in the settings, replacing the timer that's running, if any. No
+// timer runs when the interval is 0, or in batch mode, where the batch is
+// saved when it ends.
+//
+// Parameters:
+//   - cfg: The application configuration containing auto-save settings
+func startAutoSaveTimer(cfg *config) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.autoSaveStop != nil {
+		close(cfg.autoSaveStop)
+		cfg.autoSaveStop = nil
+	}
+	if cfg.settings.autoSaveEvery <= 0 || cfg.batch != nil {
+		return
+	}
+	cfg.autoSaveStop = make(chan struct{})
+	go runAutoSaveTimer(cfg, cfg.settings.autoSaveEvery, cfg.autoSaveStop)
+}
+
+// runAutoSaveTimer saves unsaved changes every interval until stop is closed.
+// The saves run on the REPL's goroutine between commands (see postJob). A
+// failed save is reported as a warning and tried again at the next tick.
+func runAutoSaveTimer(cfg *config, every time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			postJob(cfg, func() {
+				defer setSaveTrigger(cfg, saveTriggerTimer)()
+				if err := saveIfUnsaved(cfg); err != nil {
+					notify(cfg, "Warning: Could not auto-save: %v\n", err)
+				}
+			})
+		}
+	}
+}
+
+// saveIfUnsaved saves the Pokédex if auto-save is enabled and there are
+// changes since the last save.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex to save
+//
+// Returns:
+//   - An error if the save fails
+func saveIfUnsaved(cfg *config) error {
+	cfg.mutex.RLock()
+	unsaved := cfg.settings.autoSaveEnabled && cfg.changesSinceSync > 0
+	cfg.mutex.RUnlock()
+	if !unsaved {
+		return nil
+	}
+	return saveInBackground(cfg)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestSaveIntervalDuration tests turning the auto-save timer on and off
+func TestSaveIntervalDuration(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := commandSaveInterval(cfg, []string{"5m"}); err != nil {
+		t.Fatalf("commandSaveInterval returned an error: %v", err)
+	}
+	if cfg.Settings().autoSaveEvery != 5*time.Minute || cfg.autoSaveStop == nil {
+		t.Fatalf("Expected a timer every 5 minutes, got %v", cfg.Settings().autoSaveEvery)
+	}
+	if err := commandSaveInterval(cfg, []string{"off"}); err != nil || cfg.autoSaveStop != nil {
+		t.Errorf("Expected the timer to stop, got %v", err)
+	}
+	for _, invalid := range []string{"5s", "-1m", "often"} {
+		if err := commandSaveInterval(cfg, []string{invalid}); err == nil {
+			t.Errorf("Expected %q to be refused", invalid)
+		}
+	}
+	if cfg.Settings().autoSaveInterval != 1 {
+		t.Errorf("Expected the change count to be unchanged, got %d", cfg.Settings().autoSaveInterval)
+	}
+}
+
+// TestSaveIfUnsaved tests that timed auto-saves only save unsaved changes
+func TestSaveIfUnsaved(t *testing.T) {
+	useTempHome(t)
+	savePath := saveFile.path()
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+
+	if err := saveIfUnsaved(cfg); err != nil {
+		t.Fatalf("saveIfUnsaved returned an error: %v", err)
+	}
+	if _, err := os.Stat(savePath); !os.IsNotExist(err) {
+		t.Fatalf("Expected nothing to be saved without changes, got %v", err)
+	}
+
+	cfg.changesSinceSync = 1
+	if err := saveIfUnsaved(cfg); err != nil {
+		t.Fatalf("saveIfUnsaved returned an error: %v", err)
+	}
+	if _, err := os.Stat(savePath); err != nil || cfg.changesSinceSync != 0 {
+		t.Errorf("Expected the changes to be saved, got %v with %d changes left", err, cfg.changesSinceSync)
+	}
+}
@@ -0,0 +1,208 @@
+// This file implements the backup command, which keeps a versioned history of
+// the save file in a git repository and pushes it to a remote.
+package main
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/git"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// backupUsage describes the forms of the backup command.
+const backupUsage = "Usage: backup [git <remote> [--every <n>] | push | off]"
+
+// commandBackup shows or changes how the save file is backed up. The settings
+// are saved with the Pokédex so they persist between sessions.
+// Supported forms:
+//   - backup: Show where the save file is backed up and how many saves haven't been pushed
+//   - backup git <remote> [--every <n>]: Commit every save to the local backup
+//     repository and push it to a remote, after every n saves or only on demand
+//   - backup push: Push the backups to the remote now
+//   - backup off: Stop backing up; the local history is kept
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters as described above
+//
+// Returns:
+//   - An error if the parameters are invalid, the backup can't be pushed, or
+//     the settings can't be saved
+func commandBackup(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		err = printBackupStatus(cfg.Settings())
+	case strings.EqualFold(params[0], "git"):
+		err = setBackupRemote(cfg, params[1:])
+	case len(params) == 1 && strings.EqualFold(params[0], "push"):
+		err = pushBackupNow(cfg)
+	case len(params) == 1 && strings.EqualFold(params[0], "off"):
+		cfg.UpdateSettings(func(s *settings) {
+			s.backupRemote, s.backupEvery = "", 0
+		})
+		i18n.Printf("Saves will no longer be backed up. The history so far is kept in %s.\n", getBackupRepoPath())
+		err = savePokedexData(cfg)
+	default:
+		err = errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown backup command '%s'. %s", params[0], i18n.T(backupUsage)), nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "backup", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// setBackupRemote sets the remote the save file is backed up to, and how often
+// it's pushed, then backs up and pushes the current save.
+func setBackupRemote(cfg *config, params []string) error {
+	every := 0
+	if len(params) == 3 && params[1] == "--every" {
+		n, err := strconv.Atoi(params[2])
+		if err != nil || n < 1 {
+			return errorhandling.NewInvalidInputError("The number of saves between pushes must be a positive number", nil)
+		}
+		every = n
+		params = params[:1]
+	}
+	if len(params) != 1 {
+		return errorhandling.NewInvalidInputError(i18n.T(backupUsage), nil)
+	}
+	remote, err := normalizeBackupRemote(params[0])
+	if err != nil {
+		return err
+	}
+
+	cfg.UpdateSettings(func(s *settings) {
+		s.backupRemote, s.backupEvery = remote, every
+	})
+	// Saving commits the save file, so the first push has the current Pokédex
+	if err := savePokedexData(cfg); err != nil {
+		return err
+	}
+	if every == 0 {
+		i18n.Printf("Every save will be backed up to %s. Use 'backup push' to push the backups to %s.\n",
+			getBackupRepoPath(), displayRemote(remote))
+	} else {
+		i18n.Printf("Every save will be backed up to %s and pushed to %s after every %d saves.\n",
+			getBackupRepoPath(), displayRemote(remote), every)
+	}
+	// In batch mode the save, and so the first backup, is made when the batch ends
+	if cfg.batch != nil {
+		return nil
+	}
+	return pushBackupNow(cfg)
+}
+
+// normalizeBackupRemote checks a remote, returning it with relative paths to
+// repositories on disk made absolute, so the remote doesn't depend on the
+// directory the app is started from.
+func normalizeBackupRemote(remote string) (string, error) {
+	if git.IsHTTP(remote) {
+		if u, err := url.Parse(remote); err != nil || u.Host == "" {
+			return "", errorhandling.NewInvalidInputError(i18n.Sprintf("Invalid git remote '%s'", displayRemote(remote)), err)
+		}
+		return remote, nil
+	}
+	if strings.Contains(remote, "://") || strings.HasPrefix(remote, "git@") {
+		return "", errorhandling.NewInvalidInputError(
+			"Only http:// and https:// remotes and repositories on disk are supported", nil)
+	}
+	abs, err := filepath.Abs(remote)
+	if err != nil {
+		return "", errorhandling.NewInvalidInputError(i18n.Sprintf("Invalid git remote '%s'", remote), err)
+	}
+	return abs, nil
+}
+
+// pushBackupNow commits the save file if it changed and pushes the backups
+// to the remote.
+func pushBackupNow(cfg *config) error {
+	remote := cfg.Settings().backupRemote
+	if remote == "" {
+		return errorhandling.NewInvalidInputError("No backup remote is set. Use 'backup git <remote>' to set one.", nil)
+	}
+	saveFilePath, err := getSaveFilePath(cfg)
+	if err != nil {
+		return errorhandling.NewInternalError("Could not find the save file", err)
+	}
+	waitForBackgroundSaves(cfg)
+	repo, err := git.Init(getBackupRepoPath())
+	if err == nil {
+		// There's nothing to commit if the Pokédex was never saved
+		if _, err = commitBackup(cfg, repo, saveFilePath); errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+	}
+	var head string
+	if err == nil {
+		head, err = repo.Head()
+	}
+	if err != nil {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not back up the save file: %v", err), err)
+	}
+	if head == "" {
+		return errorhandling.NewInvalidInputError("Nothing has been backed up yet. Saves are backed up once the Pokédex is saved.", nil)
+	}
+	unpushed, err := repo.Unpushed()
+	if err == nil {
+		err = pushBackup(repo, remote)
+	}
+	switch {
+	case errors.Is(err, git.ErrNotFastForward):
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The remote %s has history that isn't in the local backup. Push to an empty repository instead.", displayRemote(remote)), err)
+	case err != nil:
+		return errorhandling.NewNetworkError(i18n.Sprintf("Could not push the backups to %s: %v", displayRemote(remote), err), err)
+	}
+	if unpushed == 0 {
+		i18n.Printf("The backups at %s are up to date.\n", displayRemote(remote))
+	} else {
+		i18n.Printf("Backups pushed to %s (%d new).\n", displayRemote(remote), unpushed)
+	}
+	return nil
+}
+
+// printBackupStatus shows where the save file is backed up, if anywhere.
+func printBackupStatus(current settings) error {
+	if current.backupRemote == "" {
+		i18n.Println("Saves aren't backed up. Use 'backup git <remote>' to keep a history of them in git.")
+		return nil
+	}
+	repo, err := git.Init(getBackupRepoPath())
+	var commits []git.Commit
+	var unpushed int
+	if err == nil {
+		commits, err = repo.Log(0)
+	}
+	if err == nil {
+		unpushed, err = repo.Unpushed()
+	}
+	if err != nil {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not read the backups: %v", err), err)
+	}
+
+	i18n.Printf("Saves are backed up to %s and pushed to %s.\n", repo.Dir(), displayRemote(current.backupRemote))
+	if current.backupEvery == 0 {
+		i18n.Println("Backups are pushed only with 'backup push'.")
+	} else {
+		i18n.Printf("Backups are pushed after every %d saves.\n", current.backupEvery)
+	}
+	if len(commits) > 0 {
+		i18n.Printf("%d saves in the history, the latest on %s. %d not pushed yet.\n",
+			len(commits), commits[0].When.Local().Format("2006-01-02 15:04"), unpushed)
+	}
+	return nil
+}
@@ -0,0 +1,797 @@
+// This file implements the battle command. In a hotseat battle, two players
+// at the same terminal each pick a team and take turns choosing moves in
+// secret; each choice is scrolled out of view before the other player looks.
+// In wild and gym battles, the player battles an opponent played by the
+// computer at a chosen difficulty. A battle can be recorded to a replay file
+// and watched with the replay command.
+package main
+
+import (
+	"fmt"
+	"maps"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/events"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// battleUsage describes the parameters of the battle command.
+const battleUsage = "Usage: battle hotseat [--best-of <n>] [--p1 <save file>] [--p2 <save file>] [--record <file>], battle wild [--difficulty <level>] [--record <file>], or battle gym <type> [--difficulty <level>] [--record <file>]"
+
+// battleCommandsHelp lists the commands a player can give on their turn in battle.
+const battleCommandsHelp = "Enter 'move <n>' to use a move, 'switch <n>' to send out another Pokémon, or 'run' to forfeit:"
+
+// battleOptions holds the parsed options of a battle.
+type battleOptions struct {
+	bestOf     int              // The number of battles in the series (odd)
+	saveFiles  [2]string        // The save file each player's team comes from ("" for the current Pokédex)
+	record     string           // The file to save a replay of the series to, or "" not to record it
+	difficulty battleDifficulty // How well a computer-controlled opponent plays
+}
+
+// battleSide decides what one side of a battle does: a player at the
+// terminal, or the computer.
+type battleSide interface {
+	// chooseAction picks the side's action for the turn.
+	chooseAction(teams [2]*battleTeam, side int) (battleAction, error)
+	// chooseReplacement picks the Pokémon to send out after the side's Pokémon fainted.
+	chooseReplacement(teams [2]*battleTeam, side int) (int, error)
+}
+
+// commandBattle implements the "battle" command.
+// Supported forms:
+//   - battle hotseat: Two players battle each other with teams from the Pokédex
+//   - battle hotseat --best-of 3: Play a series, won by the first to win 2 battles
+//   - battle hotseat --p2 <save file>: Draw the second player's team from another save file
+//   - battle wild: Battle a wild Pokémon from the area explored last
+//   - battle gym <type>: Battle a gym leader who uses Pokémon of a type (e.g. water)
+//   - battle wild --difficulty hard: Choose how well the opponent plays (easy, normal, or hard)
+//   - battle <mode> --record <file>: Save a replay of the battles to a file
+//
+// Battles read the players' choices as they're made, so they can't be played
+// in batch mode. Battles are just for fun and don't change the Pokédex, apart
+// from recording a wild Pokémon as seen. While a rental team is rented (see
+// the rental command), the player battles with it instead of the Pokédex.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the mode, followed by options
+//
+// Returns:
+//   - An error if the parameters are invalid, the input isn't interactive,
+//     a save file can't be read, or there's an issue with the API requests
+func commandBattle(cfg *config, params []string) error {
+	var err error
+	mode := ""
+	if len(params) > 0 {
+		mode = strings.ToLower(params[0])
+	}
+	switch mode {
+	case "hotseat":
+		err = battleHotseat(cfg, params[1:])
+	case "wild":
+		err = battleWild(cfg, params[1:])
+	case "gym":
+		err = battleGym(cfg, params[1:])
+	default:
+		err = errorhandling.NewInvalidInputError(battleUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "battle", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseBattleParams parses the options of a battle.
+//
+// Parameters:
+//   - params: The command parameters after the mode
+//   - allowed: The options the mode accepts (e.g. "--best-of")
+//
+// Returns:
+//   - The parsed options
+//   - An error if an option is unknown, is missing its value, or has an invalid value
+func parseBattleParams(params []string, allowed ...string) (battleOptions, error) {
+	opts := battleOptions{bestOf: 1, difficulty: difficultyNormal}
+	usageErr := errorhandling.NewInvalidInputError(battleUsage, nil)
+	if len(params)%2 != 0 {
+		return opts, usageErr
+	}
+
+	for i := 0; i < len(params); i += 2 {
+		option, value := strings.ToLower(params[i]), params[i+1]
+		if !slices.Contains(allowed, option) {
+			return opts, usageErr
+		}
+		switch option {
+		case "--best-of":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n%2 == 0 {
+				return opts, errorhandling.NewInvalidInputError(
+					"The number of battles must be an odd number, like 3 or 5", err)
+			}
+			opts.bestOf = n
+		case "--p1":
+			opts.saveFiles[0] = value
+		case "--p2":
+			opts.saveFiles[1] = value
+		case "--record":
+			opts.record = value
+		case "--difficulty":
+			difficulty, err := parseDifficulty(value)
+			if err != nil {
+				return opts, err
+			}
+			opts.difficulty = difficulty
+		}
+	}
+	return opts, nil
+}
+
+// requireInteractive returns an error in batch mode, where there's no one to
+// make the choices a battle needs.
+func requireInteractive(cfg *config) error {
+	if cfg.batch != nil {
+		return errorhandling.NewInvalidInputError("Battles need an interactive terminal and can't be played in batch mode", nil)
+	}
+	return nil
+}
+
+// battleHotseat sets up a hotseat battle, or a series of them, and plays it.
+func battleHotseat(cfg *config, params []string) error {
+	opts, err := parseBattleParams(params, "--best-of", "--p1", "--p2", "--record")
+	if err != nil {
+		return err
+	}
+	if err := requireInteractive(cfg); err != nil {
+		return err
+	}
+
+	chart, err := loadTypeChart(cfg, standardTypes)
+	if err != nil {
+		return err
+	}
+
+	var teams [2]*battleTeam
+	for i := range teams {
+		player := i18n.Sprintf("Player %d", i+1)
+		entries, err := battleEntries(cfg, opts.saveFiles[i])
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s has no Pokémon to battle with. Catch some, or withdraw them from the day care.", player), nil)
+		}
+		if teams[i], err = chooseBattleTeam(cfg, player, entries); err != nil {
+			return err
+		}
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	sides := [2]battleSide{humanSide{cfg: cfg, secret: true}, humanSide{cfg: cfg, secret: true}}
+	replay := newBattleReplay(teams, opts.bestOf, time.Now())
+	var wins [2]int
+	needed := opts.bestOf/2 + 1
+	for game := 1; wins[0] < needed && wins[1] < needed; game++ {
+		if opts.bestOf > 1 {
+			i18n.Printf("Battle %d (best of %d)\n", game, opts.bestOf)
+		}
+		for _, team := range teams {
+			team.heal()
+		}
+		battle, err := playBattle(chart, teams, sides, rng.Float64)
+		if err != nil {
+			return err
+		}
+		replay.Battles = append(replay.Battles, battle)
+		winner := battle.Winner
+		wins[winner]++
+		i18n.Printf("%s wins the battle! Score: %s %d, %s %d\n",
+			teams[winner].player, teams[0].player, wins[0], teams[1].player, wins[1])
+	}
+	if opts.bestOf > 1 {
+		winner := 0
+		if wins[1] > wins[0] {
+			winner = 1
+		}
+		i18n.Printf("%s wins the series %d to %d!\n", teams[winner].player, wins[winner], wins[1-winner])
+	}
+	if err := saveReplay(opts.record, replay); err != nil {
+		return err
+	}
+	printSeparator()
+	return nil
+}
+
+// battleWild has the player battle a wild Pokémon from the area explored last,
+// rolled as the encounter command would roll it.
+func battleWild(cfg *config, params []string) error {
+	opts, err := parseBattleParams(params, "--difficulty", "--record")
+	if err != nil {
+		return err
+	}
+	if err := requireInteractive(cfg); err != nil {
+		return err
+	}
+	location := cfg.ExploredArea()
+	if location == "" {
+		return errorhandling.NewInvalidInputError("Explore an area with 'explore <number>' before looking for a wild Pokémon", nil)
+	}
+
+	resp, err := cfg.pokeapiClient.ExploreLocation(location)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	weights, err := encounterWeights(cfg, resp.PokemonEncounters, poolLand, currentConditions(now), "", events.Default().Boosts(now))
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(weights, func(w int) bool { return w > 0 }) {
+		i18n.Printf("No wild Pokémon can be found in %s that way.\n", FormatLocationName(location))
+		printSeparator()
+		return nil
+	}
+	name := resp.PokemonEncounters[weightedPick(weights, rand.Intn)].Pokemon.Name
+	data, err := cfg.pokeapiClient.GetPokemonData(name)
+	if err != nil {
+		return err
+	}
+	recordVisit(cfg, "battle", location, true)
+	recordSeen(cfg, "battle", location, name)
+	i18n.Printf("A wild %s appeared in %s!\n", FormatPokemonName(name), FormatLocationName(location))
+
+	chart, err := loadTypeChart(cfg, standardTypes)
+	if err != nil {
+		return err
+	}
+	player, _, err := choosePlayerTeam(cfg)
+	if err != nil {
+		return err
+	}
+	wild := &battleTeam{members: []*battler{newBattler(data, basicAttacks(data))}}
+	return playComputerBattle(cfg, chart, [2]*battleTeam{player, wild}, []pokeapi.PokemonDataResp{data}, opts)
+}
+
+// battleGym has the player battle a gym leader whose team is drawn from the
+// Pokémon of one type, choosing those closest in base stats to the player's team.
+// Gyms only take challengers whose trainer level has unlocked them.
+func battleGym(cfg *config, params []string) error {
+	if len(params) == 0 || !slices.Contains(standardTypes, strings.ToLower(params[0])) {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Choose the type of gym to battle, one of: %s", strings.Join(standardTypes, ", ")), nil)
+	}
+	typeName := strings.ToLower(params[0])
+	opts, err := parseBattleParams(params[1:], "--difficulty", "--record")
+	if err != nil {
+		return err
+	}
+	if err := requireTrainerLevel(cfg, gymGate); err != nil {
+		return err
+	}
+	if err := requireInteractive(cfg); err != nil {
+		return err
+	}
+
+	chart, err := loadTypeChart(cfg, standardTypes)
+	if err != nil {
+		return err
+	}
+	player, entries, err := choosePlayerTeam(cfg)
+	if err != nil {
+		return err
+	}
+
+	teamTotal := 0
+	for _, member := range player.members {
+		teamTotal += baseStatTotal(entries[member.name].PokemonDataResp)
+	}
+	leader := i18n.Sprintf("%s Gym Leader", FormatTypeName(typeName))
+	opponent, err := generateGymTeam(cfg, typeName, teamTotal/len(player.members))
+	if err != nil {
+		return err
+	}
+	if len(opponent) == 0 {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not find any Pokémon for the %s", leader), nil)
+	}
+
+	gym := &battleTeam{player: leader}
+	names := make([]string, 0, len(opponent))
+	for _, data := range opponent {
+		gym.members = append(gym.members, newBattler(data, basicAttacks(data)))
+		names = append(names, FormatPokemonName(data.Name))
+	}
+	i18n.Printf("A %s wants to battle!\n", leader)
+	i18n.Printf("The %s's team: %s\n", leader, strings.Join(names, ", "))
+	return playComputerBattle(cfg, chart, [2]*battleTeam{player, gym}, opponent, opts)
+}
+
+// generateGymTeam picks a gym leader's team from the Pokémon of a type, as
+// generateTrainerTeam does for trainers.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - typeName: The gym's type
+//   - targetTotal: The average base stat total of the player's team
+//
+// Returns:
+//   - The gym leader's team, in the order it is sent out
+//   - An error if the type or Pokémon data can't be retrieved
+func generateGymTeam(cfg *config, typeName string, targetTotal int) ([]pokeapi.PokemonDataResp, error) {
+	typeData, err := cfg.pokeapiClient.GetType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	pool := trainerPool([]pokeapi.TypeResp{typeData})
+	rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+	candidates := make([]pokeapi.PokemonDataResp, 0, battleTeamSize*trainerCandidatesPerSlot)
+	for _, name := range pool[:min(len(pool), battleTeamSize*trainerCandidatesPerSlot)] {
+		data, err := cfg.pokeapiClient.GetPokemonData(name)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, data)
+	}
+	return selectTrainerTeam(candidates, targetTotal, battleTeamSize), nil
+}
+
+// choosePlayerTeam asks the player to pick a team from the Pokédex for a
+// battle against the computer. Pokémon that fainted in an earlier battle are
+// left out, and the rest start with the HP and status condition they were left with.
+//
+// Returns:
+//   - The player's team
+//   - The Pokémon the team was chosen from, by name
+//   - An error if there are no Pokémon to choose from, the input closes, or the moves can't be looked up
+func choosePlayerTeam(cfg *config) (*battleTeam, map[string]pokedex.Entry, error) {
+	entries, err := battleEntries(cfg, "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Pokémon that fainted in an earlier battle can't battle until they're healed
+	var faintedNames []string
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		if hasFainted(entries[name]) {
+			faintedNames = append(faintedNames, FormatPokemonName(name))
+			delete(entries, name)
+		}
+	}
+	if len(entries) == 0 && len(faintedNames) > 0 {
+		return nil, nil, errorhandling.NewInvalidInputError(
+			"All your Pokémon have fainted. Heal them with 'party heal' first.", nil)
+	}
+	if len(entries) == 0 {
+		return nil, nil, errorhandling.NewInvalidInputError(
+			"You have no Pokémon to battle with. Catch some, or withdraw them from the day care.", nil)
+	}
+	if len(faintedNames) > 0 {
+		i18n.Printf("These Pokémon have fainted and can't battle until they're healed with 'party heal': %s\n",
+			strings.Join(faintedNames, ", "))
+	}
+
+	team, err := chooseBattleTeam(cfg, i18n.T("Player"), entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, member := range team.members {
+		applyBattleCondition(member, entries[member.name], rand.Intn)
+	}
+	return team, entries, nil
+}
+
+// playComputerBattle plays a battle between the player and a side played by
+// the computer, announces the result, and gives the player's Pokémon the
+// experience they earned. The player's Pokémon keep the HP they lost and their
+// status conditions until they're healed.
+//
+// Parameters:
+//   - cfg: The application configuration containing the input reader and Pokédex
+//   - chart: A type chart covering every type
+//   - teams: The player's team, followed by the computer's
+//   - opponents: The data of the computer's Pokémon, for the experience they're worth
+//   - opts: The battle's options, including the computer's difficulty
+//
+// Returns:
+//   - An error if the input closes or the replay can't be saved
+func playComputerBattle(cfg *config, chart typeChart, teams [2]*battleTeam, opponents []pokeapi.PokemonDataResp, opts battleOptions) error {
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	sides := [2]battleSide{humanSide{cfg: cfg}, aiSide{chart: chart, difficulty: opts.difficulty, intn: rng.Intn}}
+	replay := newBattleReplay(teams, 1, time.Now())
+	battle, err := playBattle(chart, teams, sides, rng.Float64)
+	if err != nil {
+		return err
+	}
+	replay.Battles = append(replay.Battles, battle)
+
+	switch {
+	case battle.Winner == 0:
+		i18n.Println("You won the battle!")
+	case battle.forfeited() && teams[1].player == "":
+		i18n.Println("You got away safely!")
+	default:
+		i18n.Println("You lost the battle.")
+	}
+	if _, rented := cfg.Rental(); rented {
+		i18n.Println("Rental Pokémon don't gain experience.")
+	} else {
+		awardExperience(cfg, battleExperience(battle, teams, opponents))
+		awardEVs(cfg, battleEVs(battle, teams, opponents))
+		recordBattleCondition(cfg, teams[0])
+
+		// Auto-save the experience and EVs gained, and the team's condition
+		if err := UpdatePokedexAndSave(cfg); err != nil {
+			// Use standardized error handling but don't return the error
+			// since we still want to save the replay
+			HandleCommandError(cfg, "battle", err)
+		}
+	}
+	if err := saveReplay(opts.record, replay); err != nil {
+		return err
+	}
+	printSeparator()
+	return nil
+}
+
+// saveReplay saves a replay to a file, if one was given, and says how to watch it.
+func saveReplay(path string, replay battleReplay) error {
+	if path == "" {
+		return nil
+	}
+	if err := writeReplay(path, replay); err != nil {
+		return err
+	}
+	i18n.Printf("Replay saved to %s. Watch it with 'replay %s'.\n", path, path)
+	return nil
+}
+
+// battleEntries returns the Pokémon a player can choose their team from:
+// those in the current Pokédex (or the rental team, if one is rented), or in
+// another save file. Pokémon at the day care can't battle.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - saveFile: The save file to draw the team from, or "" for the current Pokédex
+//
+// Returns:
+//   - The Pokémon that can battle, by name
+//   - An error if the save file doesn't exist or can't be read
+func battleEntries(cfg *config, saveFile string) (map[string]pokedex.Entry, error) {
+	if rental, ok := cfg.Rental(); ok && saveFile == "" {
+		i18n.Printf("Battling with the rental team '%s'.\n", rental.team)
+		return maps.Clone(rental.entries), nil
+	}
+	entries := cfg.pokedex.All()
+	if saveFile != "" {
+		data, found, err := pokedex.ReadFile(saveFile)
+		if err == nil && !found {
+			err = fmt.Errorf("file not found")
+		}
+		if err != nil {
+			return nil, errorhandling.NewInvalidInputError(i18n.Sprintf("Could not read save file '%s'", saveFile), err)
+		}
+		entries = data.Pokedex
+	}
+	maps.DeleteFunc(entries, func(_ string, entry pokedex.Entry) bool { return entry.InDaycare() })
+	return entries, nil
+}
+
+// chooseBattleTeam asks a player to pick their team, until they pick a valid one.
+// Each Pokémon battles with the moves it has been taught, or with a basic attack
+// of each of its types if it hasn't been taught any.
+//
+// Parameters:
+//   - cfg: The application configuration containing the input reader and API client
+//   - player: The name of the player choosing
+//   - entries: The Pokémon the player can choose from, by name
+//
+// Returns:
+//   - The player's team
+//   - An error if the input closes or the moves can't be looked up
+func chooseBattleTeam(cfg *config, player string, entries map[string]pokedex.Entry) (*battleTeam, error) {
+	var names []string
+	for names == nil {
+		i18n.Printf("%s, choose up to %d Pokémon for your team, separated by commas:\n", player, battleTeamSize)
+		line, err := readBattleLine(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if names, err = parseBattleTeam(line, entries); err != nil {
+			fmt.Println(errorhandling.FormatUserMessage(err))
+		}
+	}
+
+	team := &battleTeam{player: player}
+	formatted := make([]string, 0, len(names))
+	for _, name := range names {
+		entry := entries[name]
+		moves, err := battleMoves(cfg, entry)
+		if err != nil {
+			return nil, err
+		}
+		member := newBattler(entry.PokemonDataResp, moves)
+		member.heldItem = entry.HeldItem
+		team.members = append(team.members, member)
+		if entry.HeldItem != "" {
+			formatted = append(formatted, i18n.Sprintf("%s (holding %s)", FormatPokemonName(name), FormatItemName(entry.HeldItem)))
+		} else {
+			formatted = append(formatted, FormatPokemonName(name))
+		}
+	}
+	i18n.Printf("%s's team: %s\n", player, strings.Join(formatted, ", "))
+	return team, nil
+}
+
+// parseBattleTeam parses a player's choice of team.
+//
+// Parameters:
+//   - line: The Pokémon names, separated by commas
+//   - entries: The Pokémon the player can choose from, by name
+//
+// Returns:
+//   - The chosen Pokémon's names in API format, in the order they were given
+//   - An error if a name isn't one of the entries, or there are too few or too many
+func parseBattleTeam(line string, entries map[string]pokedex.Entry) ([]string, error) {
+	var names []string
+	for _, part := range strings.Split(line, ",") {
+		name := ConvertToAPIFormat(strings.TrimSpace(part))
+		if name == "" || slices.Contains(names, name) {
+			continue
+		}
+		if _, ok := entries[name]; !ok {
+			return nil, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s can't battle: it isn't in the Pokédex, or it's at the day care", FormatPokemonName(name)), nil)
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 || len(names) > battleTeamSize {
+		return nil, errorhandling.NewInvalidInputError(i18n.Sprintf("Choose from 1 to %d Pokémon", battleTeamSize), nil)
+	}
+	return names, nil
+}
+
+// battleMoves returns the moves a Pokémon uses in battle: the moves it has been
+// taught, or a basic attack of each of its types if it hasn't been taught any.
+func battleMoves(cfg *config, entry pokedex.Entry) ([]battleMove, error) {
+	if len(entry.Moveset) == 0 {
+		return basicAttacks(entry.PokemonDataResp), nil
+	}
+	moves := make([]battleMove, 0, len(entry.Moveset))
+	for _, name := range entry.Moveset {
+		move, err := cfg.pokeapiClient.GetMove(name)
+		if err != nil {
+			return nil, err
+		}
+		moves = append(moves, newBattleMove(move))
+	}
+	return moves, nil
+}
+
+// playBattle plays one battle between the two teams, turn by turn, until one
+// team has no Pokémon left or a side forfeits, and records what happened.
+//
+// Parameters:
+//   - chart: A type chart covering every type
+//   - teams: The two teams, at full health
+//   - sides: What decides each team's actions, in the same order as teams
+//   - roll: Returns a random number in [0, 1), such as rand.Float64
+//
+// Returns:
+//   - The record of the battle, including the index of the winning team
+//   - An error if a side can't choose, such as when the input closes
+func playBattle(chart typeChart, teams [2]*battleTeam, sides [2]battleSide, roll func() float64) (replayBattle, error) {
+	var battle replayBattle
+	var field battleField
+	var opening []battleEvent
+	for _, team := range teams {
+		opening = append(opening, sentOut(team))
+	}
+	for _, team := range teams {
+		opening = append(opening, field.sendOut(team)...)
+	}
+	printEvents(opening)
+	battle.Turns = append(battle.Turns, recordEvents(opening))
+
+	for !teams[0].defeated() && !teams[1].defeated() {
+		var actions [2]battleAction
+		for side := range teams {
+			action, err := sides[side].chooseAction(teams, side)
+			if err != nil {
+				return battle, err
+			}
+			if action.forfeit {
+				forfeit := []battleEvent{{kind: eventForfeit, player: teams[side].player}}
+				printEvents(forfeit)
+				battle.Turns = append(battle.Turns, recordEvents(forfeit))
+				battle.Winner = 1 - side
+				return battle, nil
+			}
+			actions[side] = action
+		}
+
+		events := runTurn(chart, &field, teams, actions, roll)
+		printEvents(events)
+
+		for side, team := range teams {
+			if team.current().fainted() && !team.defeated() {
+				next, err := sides[side].chooseReplacement(teams, side)
+				if err != nil {
+					return battle, err
+				}
+				team.active = next
+				replaced := append([]battleEvent{sentOut(team)}, field.sendOut(team)...)
+				printEvents(replaced)
+				events = append(events, replaced...)
+			}
+		}
+		battle.Turns = append(battle.Turns, recordEvents(events))
+	}
+
+	if teams[0].defeated() {
+		battle.Winner = 1
+	}
+	return battle, nil
+}
+
+// sentOut returns the event of a team sending out the Pokémon it has in battle.
+func sentOut(team *battleTeam) battleEvent {
+	return battleEvent{kind: eventSwitch, player: team.player, pokemon: team.current().name}
+}
+
+// printEvents prints the description of each event, in order.
+func printEvents(events []battleEvent) {
+	for _, event := range events {
+		for _, line := range event.describe() {
+			fmt.Println(line)
+		}
+	}
+}
+
+// humanSide is a side of a battle played by someone at the terminal.
+type humanSide struct {
+	cfg    *config // The application configuration containing the input reader
+	secret bool    // Whether choices are hidden from another player at the same terminal
+}
+
+// chooseAction asks the player for their action this turn. In secret, the
+// other player is asked to look away, and the choice is scrolled out of view
+// once it's made.
+func (h humanSide) chooseAction(teams [2]*battleTeam, side int) (battleAction, error) {
+	team, opponent := teams[side], teams[1-side]
+	if h.secret {
+		i18n.Printf("%s, it's your turn. Make sure %s isn't looking, then press Enter.\n", team.player, opponent.player)
+		if _, err := readBattleLine(h.cfg); err != nil {
+			return battleAction{}, err
+		}
+	}
+
+	mine, theirs := team.current(), opponent.current()
+	if opponent.player == "" {
+		i18n.Printf("Your %s (%d/%d HP) is facing the wild %s (%d/%d HP).\n",
+			FormatPokemonName(mine.name), mine.hp, mine.maxHP, FormatPokemonName(theirs.name), theirs.hp, theirs.maxHP)
+	} else {
+		i18n.Printf("Your %s (%d/%d HP) is facing %s's %s (%d/%d HP).\n",
+			FormatPokemonName(mine.name), mine.hp, mine.maxHP,
+			opponent.player, FormatPokemonName(theirs.name), theirs.hp, theirs.maxHP)
+	}
+	for i, move := range mine.moves {
+		power := i18n.T("no damage")
+		if move.power > 0 {
+			power = i18n.Sprintf("power %d", move.power)
+		}
+		fmt.Printf("  move %d: %s (%s, %s)\n", i+1, move.displayName(), FormatTypeName(move.typeName), power)
+	}
+	for _, i := range team.reserves() {
+		member := team.members[i]
+		fmt.Printf("  switch %d: %s (%d/%d HP)\n", i+1, FormatPokemonName(member.name), member.hp, member.maxHP)
+	}
+
+	for {
+		i18n.Println(battleCommandsHelp)
+		line, err := readBattleLine(h.cfg)
+		if err != nil {
+			return battleAction{}, err
+		}
+		action, err := parseBattleAction(line, team)
+		if err != nil {
+			fmt.Println(errorhandling.FormatUserMessage(err))
+			continue
+		}
+		if h.secret {
+			fmt.Print(strings.Repeat("\n", hideLines))
+			i18n.Printf("%s has chosen.\n", team.player)
+		}
+		return action, nil
+	}
+}
+
+// chooseReplacement asks the player which Pokémon to send out after theirs fainted.
+func (h humanSide) chooseReplacement(teams [2]*battleTeam, side int) (int, error) {
+	team := teams[side]
+	reserves := team.reserves()
+	if len(reserves) == 1 {
+		return reserves[0], nil
+	}
+	for {
+		i18n.Printf("%s, choose your next Pokémon:\n", team.player)
+		for _, i := range reserves {
+			member := team.members[i]
+			fmt.Printf("  %d. %s (%d/%d HP)\n", i+1, FormatPokemonName(member.name), member.hp, member.maxHP)
+		}
+		line, err := readBattleLine(h.cfg)
+		if err != nil {
+			return 0, err
+		}
+		if n, err := strconv.Atoi(line); err == nil && slices.Contains(reserves, n-1) {
+			return n - 1, nil
+		}
+	}
+}
+
+// parseBattleAction parses a player's command for the turn:
+//   - move <n>: Use the Pokémon's move numbered n (or just <n>)
+//   - switch <n>: Send out the team member numbered n instead, which takes the turn
+//   - run: Forfeit the battle
+//
+// Parameters:
+//   - input: The player's command
+//   - team: The player's team
+//
+// Returns:
+//   - The chosen action
+//   - An error if the command isn't recognized, or names a move or Pokémon that can't be chosen
+func parseBattleAction(input string, team *battleTeam) (battleAction, error) {
+	words := strings.Fields(strings.ToLower(input))
+	if len(words) == 1 {
+		if _, err := strconv.Atoi(words[0]); err == nil {
+			words = []string{"move", words[0]}
+		}
+	}
+
+	switch {
+	case len(words) == 1 && (words[0] == "run" || words[0] == "forfeit"):
+		return battleAction{switchTo: -1, forfeit: true}, nil
+	case len(words) == 2 && words[0] == "switch":
+		n, err := strconv.Atoi(words[1])
+		if err != nil || !slices.Contains(team.reserves(), n-1) {
+			return battleAction{}, errorhandling.NewInvalidInputError("That Pokémon can't be switched in", err)
+		}
+		return battleAction{switchTo: n - 1}, nil
+	case len(words) == 2 && words[0] == "move":
+		mine := team.current()
+		n, err := strconv.Atoi(words[1])
+		if err != nil || n < 1 || n > len(mine.moves) {
+			return battleAction{}, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Choose a move from 1 to %d", len(mine.moves)), err)
+		}
+		if mine.choiceLocked() && n != mine.lockedMove {
+			return battleAction{}, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s's %s only lets it use %s until it's switched out",
+					FormatPokemonName(mine.name), FormatItemName(mine.heldItem), mine.moves[mine.lockedMove-1].displayName()), nil)
+		}
+		return battleAction{move: n - 1, switchTo: -1}, nil
+	}
+	return battleAction{}, errorhandling.NewInvalidInputError(battleCommandsHelp, nil)
+}
+
+// readBattleLine reads one line of input during a battle.
+func readBattleLine(cfg *config) (string, error) {
+	line, err := readLine(cfg)
+	if err != nil && line == "" {
+		return "", errorhandling.NewInvalidInputError("The battle ended because the input closed", err)
+	}
+	return strings.TrimSpace(line), nil
+}
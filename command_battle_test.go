@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestParseBattleParams tests parsing the options of a battle
+func TestParseBattleParams(t *testing.T) {
+	hotseat := []string{"--best-of", "--p1", "--p2", "--record"}
+	opts, err := parseBattleParams([]string{"--best-of", "3", "--p2", "Friend.json", "--record", "Final.json"}, hotseat...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.bestOf != 3 || opts.saveFiles != [2]string{"", "Friend.json"} || opts.record != "Final.json" {
+		t.Errorf("Unexpected options: %+v", opts)
+	}
+	if opts.difficulty != difficultyNormal {
+		t.Errorf("Expected normal difficulty by default, got %q", opts.difficulty)
+	}
+
+	for _, params := range [][]string{{"--best-of"}, {"--best-of", "2"}, {"--best-of", "0"}, {"--rounds", "3"}, {"--difficulty", "hard"}} {
+		if _, err := parseBattleParams(params, hotseat...); err == nil {
+			t.Errorf("parseBattleParams(%v): expected an error", params)
+		}
+	}
+
+	opts, err = parseBattleParams([]string{"--difficulty", "Hard"}, "--difficulty", "--record")
+	if err != nil || opts.difficulty != difficultyHard {
+		t.Errorf("Expected hard difficulty, got %q (%v)", opts.difficulty, err)
+	}
+	if _, err := parseBattleParams([]string{"--difficulty", "expert"}, "--difficulty"); err == nil {
+		t.Error("Expected an error for an unknown difficulty")
+	}
+}
+
+// TestParseBattleTeam tests choosing a team from the Pokédex
+func TestParseBattleTeam(t *testing.T) {
+	entries := map[string]pokedex.Entry{"pikachu": {}, "mr-mime": {}, "onix": {}, "geodude": {}}
+
+	names, err := parseBattleTeam("Pikachu, mr. mime,pikachu", entries)
+	if err != nil || !slices.Equal(names, []string{"pikachu", "mr-mime"}) {
+		t.Errorf("Expected Pikachu and Mr. Mime, got %v (%v)", names, err)
+	}
+	for _, line := range []string{"", "pikachu, mew", "pikachu, mr-mime, onix, geodude"} {
+		if _, err := parseBattleTeam(line, entries); err == nil {
+			t.Errorf("parseBattleTeam(%q): expected an error", line)
+		}
+	}
+}
+
+// TestParseBattleAction tests choosing a move or a Pokémon to switch to
+func TestParseBattleAction(t *testing.T) {
+	strike := battleMove{typeName: "normal", power: 50}
+	team := &battleTeam{members: []*battler{
+		testBattler("pikachu", 50, []string{"electric"}, strike, strike),
+		testBattler("onix", 50, []string{"rock"}, strike),
+		testBattler("geodude", 0, []string{"rock"}, strike),
+	}}
+
+	cases := []struct {
+		input string
+		want  battleAction
+		ok    bool
+	}{
+		{"move 2", battleAction{move: 1, switchTo: -1}, true},
+		{"2", battleAction{move: 1, switchTo: -1}, true},
+		{"Switch 2", battleAction{switchTo: 1}, true},
+		{"run", battleAction{switchTo: -1, forfeit: true}, true},
+		{"move 3", battleAction{}, false},   // Pikachu only knows 2 moves
+		{"switch 1", battleAction{}, false}, // Already in battle
+		{"switch 3", battleAction{}, false}, // Fainted
+		{"switch", battleAction{}, false},
+		{"s2", battleAction{}, false},
+	}
+	for _, c := range cases {
+		got, err := parseBattleAction(c.input, team)
+		if (err == nil) != c.ok || (c.ok && got != c.want) {
+			t.Errorf("parseBattleAction(%q) = %+v, %v; expected %+v (ok %v)", c.input, got, err, c.want, c.ok)
+		}
+	}
+}
+
+// TestPlayHotseatBattle tests a whole battle played from scripted input
+func TestPlayHotseatBattle(t *testing.T) {
+	strike := battleMove{typeName: "ground", power: 250}
+	teams := [2]*battleTeam{
+		{player: "Player 1", members: []*battler{
+			testBattler("pikachu", 60, []string{"electric"}, strike),
+			testBattler("squirtle", 60, []string{"water"}, strike),
+		}},
+		{player: "Player 2", members: []*battler{testBattler("dugtrio", 120, []string{"ground"}, strike)}},
+	}
+	// Each turn, both players press Enter and then choose their first move
+	cfg := &config{input: bufio.NewReader(strings.NewReader(strings.Repeat("\n1\n\n1\n", 2)))}
+
+	sides := [2]battleSide{humanSide{cfg: cfg, secret: true}, humanSide{cfg: cfg, secret: true}}
+	battle, err := playBattle(testTypeChart(), teams, sides, func() float64 { return 0.9 })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if battle.Winner != 1 || !teams[0].defeated() {
+		t.Errorf("Expected Player 2 to win, got winner %d", battle.Winner)
+	}
+
+	// The record has the opening turn and both turns played, and Squirtle
+	// being sent out is recorded in the turn Pikachu fainted
+	if len(battle.Turns) != 3 || len(battle.Turns[0]) != 2 {
+		t.Fatalf("Unexpected turns: %+v", battle.Turns)
+	}
+	if last := battle.Turns[1][len(battle.Turns[1])-1]; last.Kind != "switch" || last.Pokemon != "squirtle" {
+		t.Errorf("Expected the first turn to end with Squirtle sent out, got %+v", last)
+	}
+
+	// A player who runs forfeits, and the other player wins without choosing
+	teams[0].heal()
+	cfg.input = bufio.NewReader(strings.NewReader("\nrun\n"))
+	battle, err = playBattle(testTypeChart(), teams, sides, func() float64 { return 0.9 })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if battle.Winner != 1 || !battle.forfeited() || len(battle.Turns) != 2 {
+		t.Errorf("Expected Player 1 to forfeit in the first turn, got %+v", battle)
+	}
+
+	// Running out of input ends the battle with an error
+	teams[0].heal()
+	cfg.input = bufio.NewReader(strings.NewReader(""))
+	if _, err := playBattle(testTypeChart(), teams, sides, func() float64 { return 0.9 }); err == nil {
+		t.Error("Expected an error when the input closes")
+	}
+}
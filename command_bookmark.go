@@ -0,0 +1,105 @@
+// This file implements bookmarks of location areas, so that favorite places
+// to explore can be found again without paging through the map. Bookmarks are
+// saved with the Pokédex, and 'explore' accepts a bookmarked area's name as
+// well as its number on the current map page.
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// bookmarkUsage describes the forms of the bookmark command.
+const bookmarkUsage = "Usage: bookmark, bookmark add [location number], or bookmark remove <location>"
+
+// commandBookmark implements the "bookmark" command.
+// Supported forms:
+//   - bookmark: List the bookmarked location areas
+//   - bookmark add: Bookmark the location area explored last
+//   - bookmark add <number>: Bookmark a location area on the current map page
+//   - bookmark remove <location>: Remove a bookmark, by name or by its number in the list
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters where params[0] is the optional subcommand
+//
+// Returns:
+//   - An error if the parameters are invalid or the bookmarks can't be saved
+func commandBookmark(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		listBookmarks(cfg)
+	case params[0] == "add":
+		err = addBookmark(cfg, params[1:])
+	case params[0] == "remove" && len(params) >= 2:
+		err = removeBookmark(cfg, strings.Join(params[1:], " "))
+	default:
+		err = errorhandling.NewInvalidInputError(bookmarkUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "bookmark", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// listBookmarks shows the bookmarked location areas.
+func listBookmarks(cfg *config) {
+	bookmarks := cfg.Bookmarks()
+	if len(bookmarks) == 0 {
+		i18n.Println("You haven't bookmarked any locations. Explore one, then use 'bookmark add'.")
+		return
+	}
+	i18n.Println("Bookmarked locations:")
+	for i, location := range bookmarks {
+		i18n.Printf("%d. %s\n", i+1, FormatLocationName(location))
+	}
+	i18n.Println("Explore one with 'explore <location>'.")
+}
+
+// addBookmark bookmarks the location area explored last, or the one with the
+// given number on the current map page.
+func addBookmark(cfg *config, params []string) error {
+	location := cfg.ExploredArea()
+	if len(params) > 0 {
+		var err error
+		if location, err = locationFromParams(cfg, params); err != nil {
+			return err
+		}
+	}
+	if location == "" {
+		return errorhandling.NewInvalidInputError("Explore a location first, or give its number on the map", nil)
+	}
+
+	if !cfg.AddBookmark(location) {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s is already bookmarked", FormatLocationName(location)), nil)
+	}
+	i18n.Printf("Bookmarked %s.\n", FormatLocationName(location))
+	return savePokedexData(cfg)
+}
+
+// removeBookmark removes a bookmark, given by name or by its number in the list.
+func removeBookmark(cfg *config, param string) error {
+	location := ConvertToAPIFormat(param)
+	bookmarks := cfg.Bookmarks()
+	if n, err := strconv.Atoi(param); err == nil && n >= 1 && n <= len(bookmarks) {
+		location = bookmarks[n-1]
+	}
+
+	if !cfg.RemoveBookmark(location) {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s isn't bookmarked", FormatLocationName(location)), nil)
+	}
+	i18n.Printf("Removed the bookmark for %s.\n", FormatLocationName(location))
+	return savePokedexData(cfg)
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// boxUsage describes the forms of the box command.
+const boxUsage = "Usage: box create <name>, box move <pokemon> <box>, box move --select <box>, box remove <pokemon>, box delete <name>, or box list"
+
+// commandBox organizes the Pokémon in the user's Pokédex into named boxes.
+// Each Pokémon can be stored in at most one box, and box membership is saved
+// with the Pokédex. The command supports several subcommands:
+//   - box create <name>: Create a new, empty box
+//   - box move <pokemon> <box>: Move a caught Pokémon into a box
+//   - box move --select <box>: Choose several Pokémon to move into a box
+//   - box remove <pokemon>: Take a Pokémon out of its box
+//   - box delete <name>: Delete a box, leaving its Pokémon unboxed
+//   - box list: List all boxes and the Pokémon they contain
+//
+// Use 'pokedex --box <name>' to list only the Pokémon in a particular box.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and boxes
+//   - params: Command parameters where params[0] is the subcommand
+//
+// Returns:
+//   - An error if the subcommand or its parameters are invalid
+func commandBox(cfg *config, params []string) error {
+	var err error
+	if len(params) == 0 {
+		err = errorhandling.NewInvalidInputError(boxUsage, nil)
+	} else {
+		switch params[0] {
+		case "create":
+			err = createBox(cfg, params[1:])
+		case "move":
+			err = moveToBox(cfg, params[1:])
+		case "remove":
+			err = removeFromBox(cfg, params[1:])
+		case "delete":
+			err = deleteBox(cfg, params[1:])
+		case "list":
+			listBoxes(cfg)
+		default:
+			err = errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Unknown box command '%s'. %s", params[0], boxUsage), nil)
+		}
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "box", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseBoxName validates a box name parameter and normalizes it.
+// Box names are lowercase words separated by hyphens (e.g. "gym-team").
+//
+// Parameters:
+//   - params: The parameters forming the box name
+//
+// Returns:
+//   - The normalized box name
+//   - An error if no valid name was provided
+func parseBoxName(params []string) (string, error) {
+	name := ConvertToAPIFormat(strings.Join(params, " "))
+	if name == "" {
+		return "", errorhandling.NewInvalidInputError("No box name provided", nil)
+	}
+	return name, nil
+}
+
+// createBox creates a new, empty box.
+func createBox(cfg *config, params []string) error {
+	name, err := parseBoxName(params)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.pokedex.AddBox(name) {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("A box named '%s' already exists", name), nil)
+	}
+
+	i18n.Printf("Created box '%s'.\n", name)
+	printSeparator()
+	return UpdatePokedexAndSave(cfg)
+}
+
+// moveToBox moves a caught Pokémon into an existing box.
+// The last parameter is the box name; the parameters before it form the Pokémon name.
+func moveToBox(cfg *config, params []string) error {
+	if params, selecting := takeFlag(params, selectFlag); selecting {
+		return moveSelectedToBox(cfg, params)
+	}
+	if len(params) < 2 {
+		return errorhandling.NewInvalidInputError("Usage: box move <pokemon> <box>", nil)
+	}
+	boxName, err := parseBoxName(params[len(params)-1:])
+	if err != nil {
+		return err
+	}
+	if !cfg.pokedex.HasBox(boxName) {
+		return boxNotFoundError(boxName)
+	}
+
+	pokemonName := strings.Join(params[:len(params)-1], " ")
+	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, []string{pokemonName})
+	if err != nil {
+		return err
+	}
+
+	err = cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		entry.Box = boxName
+		return nil
+	})
+	if err != nil {
+		return pokedexError(err, apiName)
+	}
+
+	i18n.Printf("Moved %s to box '%s'.\n", nameInfo.Formatted, boxName)
+	printSeparator()
+	return UpdatePokedexAndSave(cfg)
+}
+
+// moveSelectedToBox moves the Pokémon the user chooses with the picker (see
+// multiselect_utils.go) into an existing box. The Pokémon already in the box
+// aren't offered.
+func moveSelectedToBox(cfg *config, params []string) error {
+	if len(params) != 1 {
+		return errorhandling.NewInvalidInputError("Usage: box move --select <box>", nil)
+	}
+	boxName, err := parseBoxName(params)
+	if err != nil {
+		return err
+	}
+	if !cfg.pokedex.HasBox(boxName) {
+		return boxNotFoundError(boxName)
+	}
+
+	var elsewhere []pokedex.NamedEntry
+	for _, named := range cfg.pokedex.List() {
+		if named.Entry.Box != boxName {
+			elsewhere = append(elsewhere, named)
+		}
+	}
+	chosen, err := pickPokemon(cfg, i18n.Sprintf("Choose the Pokémon to move to box '%s':", boxName), elsewhere)
+	if err != nil {
+		return err
+	}
+	if len(chosen) == 0 {
+		i18n.Println("Nothing was chosen, so nothing was moved.")
+		printSeparator()
+		return nil
+	}
+
+	for _, named := range chosen {
+		err := cfg.pokedex.Update(named.Name, func(entry *pokedex.Entry) error {
+			entry.Box = boxName
+			return nil
+		})
+		if err != nil {
+			return pokedexError(err, named.Name)
+		}
+		i18n.Printf("Moved %s to box '%s'.\n", FormatPokemonName(named.Name), boxName)
+	}
+	printSeparator()
+	return UpdatePokedexAndSave(cfg)
+}
+
+// removeFromBox takes a caught Pokémon out of its box.
+func removeFromBox(cfg *config, params []string) error {
+	if len(params) > 0 {
+		params = []string{strings.Join(params, " ")}
+	}
+	apiName, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, params)
+	if err != nil {
+		return err
+	}
+
+	// A Pokémon taken out of a box joins the party, which must have room for it
+	if entry, ok := pokemonData.(pokedex.Entry); ok && entry.Box != "" && !entry.InDaycare() && !partyHasRoom(cfg, "") {
+		return partyFullError(cfg)
+	}
+
+	var previousBox string
+	err = cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		previousBox, entry.Box = entry.Box, ""
+		return nil
+	})
+	if err != nil {
+		return pokedexError(err, apiName)
+	}
+
+	if previousBox == "" {
+		i18n.Printf("%s isn't in a box.\n", nameInfo.Formatted)
+		printSeparator()
+		return nil
+	}
+
+	i18n.Printf("Took %s out of box '%s'.\n", nameInfo.Formatted, previousBox)
+	printSeparator()
+	return UpdatePokedexAndSave(cfg)
+}
+
+// deleteBox deletes a box. Any Pokémon in the box are kept but become unboxed,
+// joining the party, so the box can only be deleted if the party has room for them.
+func deleteBox(cfg *config, params []string) error {
+	name, err := parseBoxName(params)
+	if err != nil {
+		return err
+	}
+	if !cfg.pokedex.HasBox(name) {
+		return boxNotFoundError(name)
+	}
+
+	joining := 0
+	for _, caught := range cfg.pokedex.List() {
+		if caught.Entry.Box == name && !caught.Entry.InDaycare() {
+			joining++
+		}
+	}
+	if room := cfg.Settings().partySize - cfg.pokedex.PartyCount(""); joining > room {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Box '%s' has %d Pokémon, but your party only has room for %d. Move them to another box first",
+				name, joining, max(room, 0)), nil)
+	}
+	unboxed := cfg.pokedex.DeleteBox(name)
+
+	i18n.Printf("Deleted box '%s'. %d Pokémon were taken out of it.\n", name, unboxed)
+	printSeparator()
+	return UpdatePokedexAndSave(cfg)
+}
+
+// listBoxes displays every box along with the Pokémon it contains.
+func listBoxes(cfg *config) {
+	members := make(map[string][]string)
+	for _, caught := range cfg.pokedex.List() {
+		box := caught.Entry.Box
+		members[box] = append(members[box], FormatPokemonName(caught.Name))
+	}
+	names := cfg.pokedex.Boxes()
+
+	if len(names) == 0 {
+		i18n.Println("You don't have any boxes yet. Create one with 'box create <name>'.")
+		printSeparator()
+		return
+	}
+
+	table := NewTable("Box", "Count", "Pokémon")
+	for _, name := range names {
+		table.AddRow(name, fmt.Sprint(len(members[name])), strings.Join(members[name], ", "))
+	}
+	if unboxed := members[""]; len(unboxed) > 0 {
+		table.AddRow("(unboxed)", fmt.Sprint(len(unboxed)), strings.Join(unboxed, ", "))
+	}
+	table.Print()
+	printSeparator()
+}
+
+// boxNotFoundError returns the error for a box that doesn't exist.
+func boxNotFoundError(name string) error {
+	return errorhandling.NewInvalidInputError(
+		i18n.Sprintf("There is no box named '%s'. Create it with 'box create %s'", name, name), nil)
+}
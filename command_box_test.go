@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestBoxMoves tests moving Pokémon between the party and a box, that a full
+// party can't take Pokémon out of a box, and that boxes are saved and restored
+func TestBoxMoves(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	cfg.settings.partySize = 2
+	cfg.pokedex.Add("pikachu", releaseTestEntry(t, "pikachu", "electric"))
+	cfg.pokedex.Add("bulbasaur", releaseTestEntry(t, "bulbasaur", "grass"))
+
+	box := func(params ...string) error {
+		t.Helper()
+		return commandBox(cfg, params)
+	}
+	boxOf := func(name string) string {
+		t.Helper()
+		entry, _ := cfg.pokedex.Get(name)
+		return entry.Box
+	}
+
+	if err := box("move", "pikachu", "team"); !errorhandling.IsInvalidInputError(err) {
+		t.Errorf("Expected moving to a box that doesn't exist to fail, got %v", err)
+	}
+	if err := box("create", "team"); err != nil {
+		t.Fatalf("box create returned an error: %v", err)
+	}
+	if err := box("move", "pikachu", "team"); err != nil || boxOf("pikachu") != "team" {
+		t.Fatalf("Expected Pikachu to move to the box, got %q, %v", boxOf("pikachu"), err)
+	}
+	if got := cfg.pokedex.PartyCount(""); got != 1 {
+		t.Errorf("Expected Pikachu to leave the party, got %d in it", got)
+	}
+
+	// With the party full, Pikachu has nowhere to go
+	cfg.pokedex.Add("charmander", releaseTestEntry(t, "charmander", "fire"))
+	if err := box("remove", "pikachu"); !errorhandling.IsInvalidInputError(err) || boxOf("pikachu") != "team" {
+		t.Errorf("Expected taking Pikachu out of the box into a full party to fail, got %q, %v", boxOf("pikachu"), err)
+	}
+	if err := box("delete", "team"); !errorhandling.IsInvalidInputError(err) || !cfg.pokedex.HasBox("team") {
+		t.Errorf("Expected deleting a box into a full party to fail, got %v", err)
+	}
+
+	// The box and what's in it are saved
+	restored := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := loadPokedexData(restored); err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if entry, _ := restored.pokedex.Get("pikachu"); !restored.pokedex.HasBox("team") || entry.Box != "team" {
+		t.Errorf("Expected Pikachu to be restored in box 'team', got %q", entry.Box)
+	}
+
+	// With room in the party, Pikachu rejoins it
+	if err := box("move", "charmander", "team"); err != nil {
+		t.Fatalf("box move returned an error: %v", err)
+	}
+	if err := box("remove", "pikachu"); err != nil || boxOf("pikachu") != "" {
+		t.Errorf("Expected Pikachu to rejoin the party, got %q, %v", boxOf("pikachu"), err)
+	}
+	restored = &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := loadPokedexData(restored); err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if entry, _ := restored.pokedex.Get("pikachu"); entry.Box != "" {
+		t.Errorf("Expected Pikachu to be restored in the party, got box %q", entry.Box)
+	}
+}
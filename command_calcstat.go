@@ -0,0 +1,274 @@
+// This file implements the calcstat command, a calculator for the formula the
+// games use to work out a Pokémon's stats from its base stat, individual values
+// (IVs), effort values (EVs), level, and nature, for planning how to train a
+// Pokémon before leveling it up.
+package main
+
+import (
+	"strconv"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// calcStatUsage describes the two forms of the calcstat command.
+const calcStatUsage = "Usage: calcstat <base> <iv> <ev> <level> [+|-] or calcstat <pokemon> <stat> <level> [iv] [ev] [nature] (e.g. calcstat pikachu attack 50)"
+
+const (
+	maxBaseStat = 255 // The highest base stat a Pokémon can have
+	maxIV       = 31  // The highest individual value a stat can have
+)
+
+// natureEffect is how a nature changes a stat.
+type natureEffect int
+
+const (
+	natureNeutral natureEffect = iota // The stat is unchanged
+	natureRaises                      // The stat is raised by 10%
+	natureLowers                      // The stat is lowered by 10%
+)
+
+// String describes the effect for display.
+func (e natureEffect) String() string {
+	switch e {
+	case natureRaises:
+		return "+10%"
+	case natureLowers:
+		return "-10%"
+	}
+	return i18n.T("Neutral")
+}
+
+// commandCalcStat works out the value of a stat with the formula from the games.
+// The command supports two forms:
+//   - calcstat <base> <iv> <ev> <level> [+|-]: Calculate from a base stat, showing
+//     the value both as HP and as any other stat, raised (+) or lowered (-) by a nature
+//   - calcstat <pokemon> <stat> <level> [iv] [ev] [nature]: Calculate one of a
+//     Pokémon's stats. IVs default to 31, EVs to 0, and the nature to a neutral
+//     one; if none of them are given, the range the stat can have is shown instead
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - params: Command parameters in one of the forms above
+//
+// Returns:
+//   - An error if the parameters are invalid, or the Pokémon or nature can't be found
+func commandCalcStat(cfg *config, params []string) error {
+	var err error
+	if len(params) > 0 && isNumber(params[0]) {
+		err = calcStatFromBase(params)
+	} else {
+		err = calcStatForPokemon(cfg, params)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "calcstat", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// calcStatFromBase calculates a stat from a base stat given as a number.
+func calcStatFromBase(params []string) error {
+	if len(params) < 4 || len(params) > 5 {
+		return errorhandling.NewInvalidInputError(calcStatUsage, nil)
+	}
+	base, err := parseStatInput(params[0], "base stat", 1, maxBaseStat)
+	if err != nil {
+		return err
+	}
+	iv, ev, level, err := parseTrainingInputs(params[1], params[2], params[3])
+	if err != nil {
+		return err
+	}
+	effect := natureNeutral
+	if len(params) == 5 {
+		switch params[4] {
+		case "+":
+			effect = natureRaises
+		case "-":
+			effect = natureLowers
+		default:
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Without a stat, the nature must be + (raises the stat) or - (lowers it), not '%s'", params[4]), nil)
+		}
+	}
+
+	i18n.Printf("Base %d with %d IVs and %d EVs at level %d:\n", base, iv, ev, level)
+	table := NewTable("Stat", "Nature", "Value")
+	table.AddRow(FormatStatName("hp"), "—", strconv.Itoa(calcStat("hp", base, iv, ev, level, natureNeutral)))
+	table.AddRow(i18n.T("Other stats"), effect.String(), strconv.Itoa(calcStat("attack", base, iv, ev, level, effect)))
+	table.Print()
+	return nil
+}
+
+// calcStatForPokemon calculates one of a Pokémon's stats from its base stat in the API.
+func calcStatForPokemon(cfg *config, params []string) error {
+	if len(params) < 3 || len(params) > 6 {
+		return errorhandling.NewInvalidInputError(calcStatUsage, nil)
+	}
+	stat, ok := statAliases[params[1]]
+	if !ok || stat == statTotal {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown stat '%s' (use hp, attack, defense, special-attack, special-defense, or speed)", params[1]), nil)
+	}
+
+	// The IVs, EVs, and nature are optional, so read what's given after the level
+	iv, ev := strconv.Itoa(maxIV), "0"
+	var natureName string
+	extra := params[3:]
+	if len(extra) > 0 && !isNumber(extra[len(extra)-1]) {
+		natureName, extra = extra[len(extra)-1], extra[:len(extra)-1]
+	}
+	if len(extra) > 2 {
+		return errorhandling.NewInvalidInputError(calcStatUsage, nil)
+	}
+	if len(extra) > 0 {
+		iv = extra[0]
+	}
+	if len(extra) > 1 {
+		ev = extra[1]
+	}
+	ivValue, evValue, level, err := parseTrainingInputs(iv, ev, params[2])
+	if err != nil {
+		return err
+	}
+
+	nameInfo := FormatPokemonInput(params[0])
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		return err
+	}
+	data, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
+	if err != nil {
+		return err
+	}
+	base := baseStat(data, stat)
+	statName := FormatStatName(stat)
+
+	// Without IVs, EVs, or a nature, show the lowest and highest the stat can be
+	if len(params) == 3 {
+		i18n.Printf("%s's %s (base %d) at level %d:\n", nameInfo.Formatted, statName, base, level)
+		printStatRange(stat, base, level)
+		return nil
+	}
+
+	effect := natureNeutral
+	if natureName != "" {
+		nature, err := cfg.pokeapiClient.GetNature(natureName)
+		if err != nil {
+			return err
+		}
+		effect = natureEffectOn(nature, stat)
+		natureName = FormatTypeName(nature.Name)
+	}
+	value := calcStat(stat, base, ivValue, evValue, level, effect)
+	if natureName == "" {
+		i18n.Printf("%s's %s (base %d) at level %d with %d IVs and %d EVs: %d\n",
+			nameInfo.Formatted, statName, base, level, ivValue, evValue, value)
+	} else {
+		i18n.Printf("%s's %s (base %d) at level %d with %d IVs and %d EVs, %s nature (%s): %d\n",
+			nameInfo.Formatted, statName, base, level, ivValue, evValue, natureName, effect, value)
+	}
+	return nil
+}
+
+// printStatRange shows the values a stat can have at a level, from no training
+// and a hindering nature to full training and a beneficial one.
+func printStatRange(stat string, base, level int) {
+	table := NewTable("IVs", "EVs", "Nature", "Value")
+	addRow := func(iv, ev int, effect natureEffect) {
+		table.AddRow(strconv.Itoa(iv), strconv.Itoa(ev), effect.String(),
+			strconv.Itoa(calcStat(stat, base, iv, ev, level, effect)))
+	}
+	if stat != "hp" {
+		addRow(0, 0, natureLowers)
+	}
+	addRow(0, 0, natureNeutral)
+	addRow(maxIV, 0, natureNeutral)
+	addRow(maxIV, pokedex.MaxStatEVs, natureNeutral)
+	if stat != "hp" {
+		addRow(maxIV, pokedex.MaxStatEVs, natureRaises)
+	}
+	table.Print()
+}
+
+// parseTrainingInputs reads and checks the IVs, EVs, and level for a calculation.
+func parseTrainingInputs(ivParam, evParam, levelParam string) (iv, ev, level int, err error) {
+	if iv, err = parseStatInput(ivParam, "IVs", 0, maxIV); err != nil {
+		return 0, 0, 0, err
+	}
+	if ev, err = parseStatInput(evParam, "EVs", 0, pokedex.MaxStatEVs); err != nil {
+		return 0, 0, 0, err
+	}
+	if level, err = parseStatInput(levelParam, "level", 1, pokedex.MaxLevel); err != nil {
+		return 0, 0, 0, err
+	}
+	return iv, ev, level, nil
+}
+
+// parseStatInput reads a number for a calculation, which must be from low to high.
+func parseStatInput(param, label string, low, high int) (int, error) {
+	value, err := strconv.Atoi(param)
+	if err != nil || value < low || value > high {
+		return 0, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The %s must be a number from %d to %d, not '%s'", i18n.T(label), low, high, param), nil)
+	}
+	return value, nil
+}
+
+// isNumber reports whether a parameter is a whole number.
+func isNumber(param string) bool {
+	_, err := strconv.Atoi(param)
+	return err == nil
+}
+
+// natureEffectOn works out how a nature changes a stat.
+func natureEffectOn(nature pokeapi.NatureResp, stat string) natureEffect {
+	switch {
+	case nature.Neutral():
+		return natureNeutral
+	case nature.IncreasedStat.Name == stat:
+		return natureRaises
+	case nature.DecreasedStat.Name == stat:
+		return natureLowers
+	}
+	return natureNeutral
+}
+
+// calcStat works out the value of a stat with the formula from the games. HP
+// isn't changed by natures, and a Pokémon with a base HP of 1 (Shedinja)
+// always has 1 HP. Each step rounds down, as in the games.
+//
+// Parameters:
+//   - stat: The API stat name
+//   - base: The base stat
+//   - iv: The stat's individual value, from 0 to 31
+//   - ev: The stat's effort values, from 0 to 252
+//   - level: The Pokémon's level
+//   - effect: How the Pokémon's nature changes the stat
+//
+// Returns:
+//   - The stat's value
+func calcStat(stat string, base, iv, ev, level int, effect natureEffect) int {
+	value := (2*base + iv + ev/4) * level / 100
+	if stat == "hp" {
+		if base == 1 {
+			return 1
+		}
+		return value + level + 10
+	}
+	value += 5
+	switch effect {
+	case natureRaises:
+		return value * 110 / 100
+	case natureLowers:
+		return value * 90 / 100
+	}
+	return value
+}
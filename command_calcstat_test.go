@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestCalcStat tests the stat formula against values from the games
+func TestCalcStat(t *testing.T) {
+	cases := []struct {
+		name                string
+		stat                string
+		base, iv, ev, level int
+		effect              natureEffect
+		expected            int
+	}{
+		{"Adamant Garchomp's attack", "attack", 130, 31, 252, 100, natureRaises, 394},
+		{"Garchomp's HP", "hp", 108, 24, 74, 78, natureNeutral, 289},
+		{"Untrained attack at level 50", "attack", 55, 0, 0, 50, natureNeutral, 60},
+		{"Hindered attack", "attack", 55, 0, 0, 50, natureLowers, 54},
+		{"EVs count in fours", "speed", 90, 31, 3, 100, natureNeutral, 216},
+		{"Natures don't change HP", "hp", 35, 31, 0, 50, natureRaises, 110},
+		{"Shedinja always has 1 HP", "hp", 1, 31, 252, 100, natureNeutral, 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual := calcStat(c.stat, c.base, c.iv, c.ev, c.level, c.effect)
+			if actual != c.expected {
+				t.Errorf("Expected %d, got %d", c.expected, actual)
+			}
+		})
+	}
+}
+
+// TestNatureEffectOn tests how natures change each stat
+func TestNatureEffectOn(t *testing.T) {
+	adamant := pokeapi.NatureResp{
+		Name:          "adamant",
+		IncreasedStat: &pokeapi.NamedAPIResource{Name: "attack"},
+		DecreasedStat: &pokeapi.NamedAPIResource{Name: "special-attack"},
+	}
+	hardy := pokeapi.NatureResp{
+		Name:          "hardy",
+		IncreasedStat: &pokeapi.NamedAPIResource{Name: "attack"},
+		DecreasedStat: &pokeapi.NamedAPIResource{Name: "attack"},
+	}
+
+	cases := []struct {
+		nature   pokeapi.NatureResp
+		stat     string
+		expected natureEffect
+	}{
+		{adamant, "attack", natureRaises},
+		{adamant, "special-attack", natureLowers},
+		{adamant, "speed", natureNeutral},
+		{hardy, "attack", natureNeutral},
+	}
+	for _, c := range cases {
+		if actual := natureEffectOn(c.nature, c.stat); actual != c.expected {
+			t.Errorf("%s on %s: expected %v, got %v", c.nature.Name, c.stat, c.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// This file implements the card command, which shows a caught Pokémon's battle
+// card (see battle_card.go), or exports a shareable trainer card with the
+// user's favorite Pokémon, ribbons, and Pokédex completion.
+package main
+
+import (
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// cardUsage describes the forms of the card command.
+const cardUsage = "Usage: card <pokemon> | card export <file.html|file.png> [--name <name>]"
+
+// commandCard shows a battle card or exports a trainer card. Supported forms:
+//   - card <pokemon>: Show a caught Pokémon's battle card, with its types and
+//     weaknesses, abilities, stats at its level, and suggested moves
+//   - card export <file> [--name <name>]: Write the trainer card as an HTML
+//     page, or as a PNG image if the file name ends in .png. The trainer's name
+//     defaults to the user's login name.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex, API client, and species dataset
+//   - params: Command parameters where params[0] is the Pokémon's name, or
+//     "export" followed by the file path and optionally --name and the trainer's name
+//
+// Returns:
+//   - An error if the parameters are invalid, the Pokémon isn't in the Pokédex,
+//     its data can't be looked up, or the file can't be written
+func commandCard(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		err = errorhandling.NewInvalidInputError(i18n.T(cardUsage), nil)
+	case strings.ToLower(params[0]) == "export":
+		err = exportCard(cfg, params[1:])
+	default:
+		err = showBattleCard(cfg, params)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "card", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// exportCard writes a trainer card to the file in the parameters, choosing
+// the format from the file's extension.
+func exportCard(cfg *config, params []string) error {
+	name := defaultTrainerName()
+	if i := slices.Index(params, "--name"); i >= 0 {
+		name = strings.Join(params[i+1:], " ")
+		params = params[:i]
+	}
+	path := strings.Join(params, " ")
+	if path == "" || name == "" {
+		return errorhandling.NewInvalidInputError(i18n.T(cardUsage), nil)
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".png" && ext != ".html" && ext != ".htm" {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Cards can be written as .html or .png files, not '%s'", path), nil)
+	}
+
+	card := buildTrainerCard(cfg, name)
+	file, err := os.Create(path)
+	if err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Could not create file '%s'", path), err)
+	}
+	defer file.Close()
+
+	if ext == ".png" {
+		i18n.Println("Downloading sprites...")
+		err = renderCardPNG(file, card, fetchCardSprites(card))
+	} else {
+		err = writeCardHTML(file, card)
+	}
+	if err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not write file '%s'", path), err)
+	}
+	i18n.Printf("Trainer card for %s written to %s\n", name, path)
+	return nil
+}
+
+// defaultTrainerName returns the user's login name, or a generic name if it
+// isn't known.
+func defaultTrainerName() string {
+	if current, err := user.Current(); err == nil && current.Username != "" {
+		return current.Username
+	}
+	return i18n.T("Trainer")
+}
@@ -1,113 +1,283 @@
 package main
 
 import (
-	"fmt"
+	"cmp"
+	"maps"
 	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
-// commandCatch attempts to catch a specified Pokémon and add it to the user's Pokédex.
+// caughtPokemon is the data behind the catch command's result.
+type caughtPokemon struct {
+	Pokemon       string `json:"pokemon"`                  // The Pokémon's API name
+	Ball          string `json:"ball"`                     // The API name of the ball thrown
+	Caught        bool   `json:"caught"`                   // Whether the Pokémon was caught
+	Location      string `json:"location,omitempty"`       // The location area it was caught in, if it was found there
+	Box           string `json:"box,omitempty"`            // The box it was sent to because the party was full
+	CompletedArea string `json:"completed_area,omitempty"` // The location area the catch completed, if any
+}
+
+// catchResult attempts to catch a specified Pokémon and add it to the user's Pokédex.
 // The function simulates the catching mechanic from the Pokémon games by using
 // the Pokémon's capture rate from the API to determine catch probability.
 //
 // The catch probability is calculated by comparing a random number (0-255)
 // against the Pokémon's capture rate. If the random number is less than the
-// capture rate, the catch is successful.
+// capture rate, the catch is successful. The date of the catch is recorded, as is
+// the location if the Pokémon was found in the most recently explored area.
+// If the user's party is full, the Pokémon is sent to the storage box.
+//
+// The start of a name is enough for a Pokémon found in the area explored
+// last, if no other Pokémon found there starts the same way.
+//
+// With --ball <ball>, a ball from the user's bag (bought in the shop) is thrown
+// instead of the standard Poké Ball, multiplying the capture rate.
 //
 // Parameters:
 //   - cfg: The application configuration containing the Pokédex and API client
-//   - params: Command parameters where params[0] is the Pokémon name to catch
+//   - params: Command parameters where params[0] is the Pokémon name to catch,
+//     optionally followed by --ball <ball> (e.g. "pikachu --ball great-ball")
 //
 // Returns:
+//   - The outcome of the throw, whether or not the Pokémon was caught
 //   - An error if:
 //   - No Pokémon name is provided (InvalidParameterError)
 //   - The specified Pokémon doesn't exist (InvalidPokemonNameError)
 //   - There's an API connection issue (NetworkError)
 //   - The API response cannot be processed (InternalError)
-//   - Returns nil on successful execution, even if the catch attempt fails
-func commandCatch(cfg *config, params []string) error {
-	// Validate the Pokemon parameter
-	pokemonName, err := ValidatePokemonParam(params)
+func catchResult(cfg *config, params []string) (commandResult, error) {
+	// Validate the Pokemon parameter and the ball to throw
+	pokemonName, ball, err := parseCatchParams("catch", params)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "catch", err) {
-			return err
+		return commandResult{}, err
+	}
+
+	// A number chooses a Pokémon from the last list shown, such as by explore
+	if number, convErr := strconv.Atoi(pokemonName); convErr == nil {
+		if pokemonName, err = selectedItem(cfg, selectPokemon, number); err != nil {
+			return commandResult{}, err
 		}
-		return nil
 	}
 
-	// Process the Pokémon name input
+	// Process the Pokémon name input, completing the start of the name of a
+	// Pokémon found in the area explored last
+	var message strings.Builder
 	nameInfo := FormatPokemonInput(pokemonName)
+	if completed, ok := completeExploredName(cfg, nameInfo); ok {
+		nameInfo = completed
+		message.WriteString(i18n.Sprintf("Completed '%s' to %s, found in %s.\n", pokemonName, nameInfo.Formatted, FormatLocationName(cfg.ExploredArea())))
+	}
+
+	// Check the name locally before making any API requests
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		return commandResult{}, err
+	}
+
+	// Remember whether it was already caught here, so that catching it again
+	// doesn't award the area's completion badge a second time
+	previous, had := cfg.pokedex.Get(nameInfo.APIFormat)
+	caughtHereBefore := had && previous.CaughtAt != "" && previous.CaughtAt == cfg.ExploredLocationOf(nameInfo.APIFormat)
+
+	outcome, err := catchPokemon(cfg, nameInfo.APIFormat, ball)
+	if err != nil {
+		return commandResult{}, err
+	}
+
+	// Determine what ball to use in the message
+	caught := caughtPokemon{Pokemon: nameInfo.APIFormat, Ball: cmp.Or(ball, "poke-ball"), Caught: outcome.caught}
+	if outcome.masterball {
+		caught.Ball = "master-ball"
+		message.WriteString(i18n.T("You found a Masterball lying nearby...!") + "\n")
+		message.WriteString(i18n.Sprintf("Throwing a Masterball at %s...\n", nameInfo.Formatted))
+	} else if ball != "" {
+		message.WriteString(i18n.Sprintf("Throwing a %s at %s...\n", FormatItemName(ball), nameInfo.Formatted))
+	} else {
+		message.WriteString(i18n.Sprintf("Throwing a Pokéball at %s...\n", nameInfo.Formatted))
+	}
+
+	if !outcome.caught {
+		message.WriteString(i18n.Sprintf("%s escaped!\n", nameInfo.Formatted))
+		return commandResult{Message: message.String(), Data: caught}, nil
+	}
+
+	caught.Location, caught.Box = outcome.entry.CaughtAt, outcome.entry.Box
+	if caught.Location != "" {
+		message.WriteString(i18n.Sprintf("%s was caught in %s!\n", nameInfo.Formatted, FormatLocationName(caught.Location)))
+	} else {
+		message.WriteString(i18n.Sprintf("%s was caught!\n", nameInfo.Formatted))
+	}
+	if caught.Box != "" {
+		message.WriteString(i18n.Sprintf("Your party is full, so %s was sent to box '%s'.\n", nameInfo.Formatted, caught.Box))
+	}
+	if caught.CompletedArea = completedAreaBy(cfg, nameInfo.APIFormat, caughtHereBefore); caught.CompletedArea != "" {
+		message.WriteString(i18n.Sprintf("★ Area complete! You've caught every Pokémon found in %s.\n", FormatLocationName(caught.CompletedArea)))
+	}
+	updateChallenges(cfg)
+	return commandResult{Message: message.String(), Data: caught}, nil
+}
+
+// throwOutcome is the outcome of a throw at a Pokémon.
+type throwOutcome struct {
+	caught     bool          // Whether the Pokémon was caught
+	masterball bool          // Whether a Masterball found nearby was thrown instead of the chosen ball (see command_catchrate.go)
+	entry      pokedex.Entry // The Pokémon's new Pokédex entry, if it was caught
+}
 
+// catchPokemon throws a ball at a Pokémon, adding it to the Pokédex if it's
+// caught. This is the catch mechanic shared by the catch command and the serve
+// mode's Catch method; it prints nothing, leaving the messages to its callers.
+//
+// Encountering the Pokémon registers it as seen, and the ball is taken out of
+// the bag whether or not the Pokémon is caught. The changes are auto-saved; a
+// failed auto-save is reported but doesn't fail the catch. A catch is
+// published to MQTT if a broker is set.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - apiName: The Pokémon's name in API format
+//   - ball: The API name of the ball to throw, or "" for a standard Poké Ball
+//
+// Returns:
+//   - The outcome of the throw
+//   - An error if the Pokémon doesn't exist, is legendary and the trainer's
+//     level hasn't unlocked legendary Pokémon, the bag has none of the ball,
+//     or the API request fails
+func catchPokemon(cfg *config, apiName, ball string) (throwOutcome, error) {
 	// Fetch pokemon capture rate
-	resp, err := cfg.pokeapiClient.GetPokemonCaptureRate(nameInfo.APIFormat)
+	resp, err := cfg.pokeapiClient.GetPokemonCaptureRate(apiName)
 	if err != nil {
 		// Check if this is an invalid Pokémon name (doesn't exist) error
 		if errorhandling.IsNotFoundError(err) {
 			// Convert to our standard invalid Pokémon name error
-			invalidNameErr := errorhandling.InvalidPokemonNameError(nameInfo.Formatted)
-			return invalidNameErr
+			return throwOutcome{}, errorhandling.InvalidPokemonNameError(FormatPokemonName(apiName))
 		}
+		return throwOutcome{}, err
+	}
 
-		// Use standardized error handling for other errors
-		if HandleCommandError(cfg, "catch", err) {
-			return err
+	// Legendary Pokémon don't appear to trainers below the level that unlocks them
+	if resp.Legendary {
+		if err := requireTrainerLevel(cfg, legendaryGate); err != nil {
+			return throwOutcome{}, err
 		}
-		return nil
 	}
 
-	captureRate := resp.CaptureRate
-
-	// Scale the capture rate for rare Pokémon
-	// Pokémon with capture rates below 50 are considered rare
-	// The scaling ensures rare Pokémon (capture rate < 50) have at least a 10-20% catch rate
-	// This means they should be caught within 5-10 attempts on average
-	effectiveCaptureRate := captureRate
-	isRare := captureRate < 50
-	if isRare {
-		// For rare Pokémon, boost the capture rate to be between 25-50
-		// This gives approximately a 10-20% chance per throw
-		effectiveCaptureRate = captureRate + (50-captureRate)/2
+	// Take the ball out of the bag now that the Pokémon is known to exist
+	if ball != "" && !cfg.UseItem(ball) {
+		return throwOutcome{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("You don't have any %s. Buy some with 'shop buy %s'.", FormatItemName(ball), ball), nil)
 	}
 
-	randNum := rand.Intn(256)
-	caught := randNum < effectiveCaptureRate
+	// Encountering a Pokémon registers it as seen, whether or not it's caught
+	recordSeen(cfg, "catch", cfg.ExploredLocationOf(apiName), apiName)
 
-	// Determine what ball to use in the message
-	if isRare && caught {
-		fmt.Println("You found a Masterball lying nearby...!")
-		fmt.Printf("Throwing a Masterball at %s...\n", nameInfo.Formatted)
-	} else {
-		fmt.Printf("Throwing a Pokéball at %s...\n", nameInfo.Formatted)
-	}
+	tuning := catchTuning(cfg.Settings())
+	effectiveCaptureRate, isRare := catchRate(tuning, resp.CaptureRate, ball)
+	result := throwOutcome{caught: rand.Intn(catchRollRange) < effectiveCaptureRate}
+	result.masterball = isRare && result.caught && tuning.Masterball
 
-	if caught {
-		pokeData, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
+	if result.caught {
+		pokeData, err := cfg.pokeapiClient.GetPokemonData(apiName)
 		if err != nil {
-			// Use standardized error handling
-			if HandleCommandError(cfg, "catch", err) {
-				return err
-			}
-			return nil
+			return throwOutcome{}, err
 		}
 
-		// Lock the config before modifying the pokedex
-		cfg.mutex.Lock()
-		cfg.pokedex[nameInfo.APIFormat] = pokeData
-		cfg.mutex.Unlock()
-
-		fmt.Printf("%s was caught!\n", nameInfo.Formatted)
+		result.entry = pokedex.NewEntry(pokeData)
+		result.entry.CaughtOn = time.Now()
+		// The catch happened in the explored area if the Pokémon can be found there
+		result.entry.CaughtAt = cfg.ExploredLocationOf(apiName)
+		// Send the Pokémon to storage if there's no room for it in the party
+		if !partyHasRoom(cfg, apiName) {
+			result.entry.Box = storageBox
+		}
+		cfg.pokedex.Add(apiName, result.entry)
+	}
 
-		// Auto-save after catching a Pokémon
+	// Auto-save the new Pokémon, or the bag if a ball was used up
+	if result.caught || ball != "" {
 		if err := UpdatePokedexAndSave(cfg); err != nil {
-			// Use standardized error handling but don't return the error
-			// since we still want to show the success message
+			// Report the error without failing, since the throw still happened
 			HandleCommandError(cfg, "catch", err)
 		}
-	} else {
-		fmt.Printf("%s escaped!\n", nameInfo.Formatted)
 	}
-	fmt.Println("-----")
-	return nil
+	if result.caught {
+		publishEvent(cfg, mqttEvent{Event: mqttEventCaught, Pokemon: apiName, Location: result.entry.CaughtAt, Rare: isRare})
+	}
+	return result, nil
+}
+
+// catchRollRange is the number of values a catch roll can take. A throw succeeds
+// when a random number from 0 to catchRollRange-1 is below the capture rate.
+const catchRollRange = 256
+
+// catchRate returns the capture rate a throw is rolled against, after scaling
+// for rare Pokémon and the ball being thrown.
+//
+// Pokémon with capture rates below the preset's rare threshold are considered
+// rare, and have their capture rate raised by the preset's boost: a percentage
+// of the gap up to the threshold. Under the casual preset, this raises rare
+// Pokémon halfway to 50, so they should be caught within 5-10 attempts on average.
+//
+// Parameters:
+//   - tuning: The values of the catch rate preset in use
+//   - captureRate: The species' capture rate from the API (0-255)
+//   - ball: The API name of the ball, or "" for a standard Poké Ball
+//
+// Returns:
+//   - The effective capture rate
+//   - Whether the Pokémon is rare
+func catchRate(tuning pokedex.CatchTuning, captureRate int, ball string) (int, bool) {
+	effectiveCaptureRate := captureRate
+	isRare := captureRate < tuning.RareThreshold
+	if isRare {
+		effectiveCaptureRate = captureRate + (tuning.RareThreshold-captureRate)*tuning.RareBoost/100
+	}
+	return applyBall(effectiveCaptureRate, ball), isRare
+}
+
+// catchProbability returns the chance that a single throw succeeds against
+// an effective capture rate, from 0 to 1.
+func catchProbability(effectiveCaptureRate int) float64 {
+	return min(1, float64(effectiveCaptureRate)/catchRollRange)
+}
+
+// parseCatchParams splits the parameters of the catch and odds commands into
+// the Pokémon name and the ball to throw. Everything before --ball is the Pokémon name.
+//
+// Parameters:
+//   - commandName: The command the parameters are for, shown in its usage
+//   - params: The command parameters
+//
+// Returns:
+//   - The Pokémon name
+//   - The API name of the ball, or "" for a standard Poké Ball
+//   - An error if no Pokémon name is given or the ball is missing or unknown
+func parseCatchParams(commandName string, params []string) (string, string, error) {
+	name, err := ValidatePokemonParam(params)
+	if err != nil {
+		return "", "", err
+	}
+
+	name, ballName, hasBall := strings.Cut(name, "--ball")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", ErrNoPokemonName
+	}
+	if !hasBall {
+		return name, "", nil
+	}
+
+	ball := ConvertToAPIFormat(strings.TrimPrefix(strings.TrimSpace(ballName), "="))
+	if _, ok := ballModifiers[ball]; !ok {
+		balls := slices.Sorted(maps.Keys(ballModifiers))
+		return "", "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown ball '%s'. Usage: %s <pokemon> [--ball %s]", strings.TrimSpace(ballName), commandName, strings.Join(balls, " | ")), nil)
+	}
+	return name, ball, nil
 }
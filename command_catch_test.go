@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestParseCatchParams tests splitting the catch parameters into a name and a ball
+func TestParseCatchParams(t *testing.T) {
+	cases := []struct {
+		params []string
+		name   string
+		ball   string
+	}{
+		{[]string{"pikachu"}, "pikachu", ""},
+		{[]string{"mr mime"}, "mr mime", ""},
+		{[]string{"pikachu --ball great-ball"}, "pikachu", "great-ball"},
+		{[]string{"pikachu --ball ultra ball"}, "pikachu", "ultra-ball"},
+		{[]string{"pikachu --ball=poke-ball"}, "pikachu", "poke-ball"},
+	}
+	for _, c := range cases {
+		name, ball, err := parseCatchParams("catch", c.params)
+		if err != nil {
+			t.Errorf("parseCatchParams(%v) returned an error: %v", c.params, err)
+			continue
+		}
+		if name != c.name || ball != c.ball {
+			t.Errorf("parseCatchParams(%v) = %q, %q, expected %q, %q", c.params, name, ball, c.name, c.ball)
+		}
+	}
+
+	if _, _, err := parseCatchParams("catch", nil); !errors.Is(err, ErrNoPokemonName) {
+		t.Errorf("Expected ErrNoPokemonName without parameters, got %v", err)
+	}
+	if _, _, err := parseCatchParams("catch", []string{"--ball great-ball"}); !errors.Is(err, ErrNoPokemonName) {
+		t.Errorf("Expected ErrNoPokemonName without a name, got %v", err)
+	}
+	if _, _, err := parseCatchParams("catch", []string{"pikachu --ball master-ball"}); !errorhandling.IsInvalidInputError(err) {
+		t.Errorf("Expected an invalid input error for an unknown ball, got %v", err)
+	}
+}
+
+// TestCatchRate tests that rare Pokémon have their capture rate raised as the
+// preset says, that balls multiply it, and that the chance of a throw never
+// exceeds certainty
+func TestCatchRate(t *testing.T) {
+	casual, authentic := catchPresets[catchPresetCasual], catchPresets[catchPresetAuthentic]
+	custom := pokedex.CatchTuning{RareThreshold: 100, RareBoost: 100}
+	cases := []struct {
+		tuning      pokedex.CatchTuning
+		captureRate int
+		ball        string
+		rate        int
+		rare        bool
+	}{
+		{casual, 190, "", 190, false},
+		{casual, 190, "ultra-ball", 255, false},
+		{casual, 45, "", 47, true},
+		{casual, 3, "", 26, true},
+		{casual, 3, "great-ball", 39, true},
+		{authentic, 3, "", 3, true},
+		{authentic, 3, "great-ball", 4, true},
+		{custom, 45, "", 100, true},
+		{custom, 120, "", 120, false},
+	}
+
+	for _, c := range cases {
+		rate, rare := catchRate(c.tuning, c.captureRate, c.ball)
+		if rate != c.rate || rare != c.rare {
+			t.Errorf("catchRate(%+v, %d, %q) = %d, %v, expected %d, %v", c.tuning, c.captureRate, c.ball, rate, rare, c.rate, c.rare)
+		}
+	}
+
+	if chance := catchProbability(128); chance != 0.5 {
+		t.Errorf("Expected a capture rate of 128 to give a 50%% chance, got %v", chance)
+	}
+	if chance := catchProbability(maxCaptureRate); chance >= 1 {
+		t.Errorf("Expected the highest capture rate to still miss occasionally, got %v", chance)
+	}
+}
+
+// BenchmarkCatchBatch measures throwing balls at a Pokémon in batch mode,
+// where saves wait until the batch ends, with the API responses served from
+// fixtures and then the client's cache.
+func BenchmarkCatchBatch(b *testing.B) {
+	useTempHome(b)
+	dir := b.TempDir()
+	fixtures := map[string][]byte{
+		"pokemon/pikachu.json":         benchmarkPokemonJSON("pikachu"),
+		"pokemon-species/pikachu.json": []byte(`{"name": "pikachu", "capture_rate": 190}`),
+	}
+	for path, data := range fixtures {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			b.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			b.Fatal(err)
+		}
+	}
+	client := pokeapi.NewClient(time.Hour)
+	client.UseFixtures(dir, false)
+	cfg := &config{pokeapiClient: client, pokedex: pokedex.New(), settings: defaultSettings(), batch: &batchResults{}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cfg.pokedex.Remove("pikachu")
+		if _, err := catchPokemon(cfg, "pikachu", ""); err != nil {
+			b.Fatalf("Catch failed: %v", err)
+		}
+	}
+}
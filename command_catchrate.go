@@ -0,0 +1,175 @@
+// This file implements the catchrate command, which chooses how forgiving
+// catching is with rare Pokémon. The choices are presets: casual (the
+// default), which raises the capture rate of rare Pokémon and lets the user
+// find a Masterball when they catch one; authentic, which uses the species'
+// capture rates as they are; and custom, whose values the user sets.
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// Catch rate presets
+const (
+	catchPresetCasual    = "casual"    // Rare Pokémon are easier to catch (the default)
+	catchPresetAuthentic = "authentic" // Capture rates are used as they are, as in the main series games
+	catchPresetCustom    = "custom"    // The values are set by the user
+)
+
+// catchPresets are the values of the built-in presets.
+var catchPresets = map[string]pokedex.CatchTuning{
+	// Rare Pokémon have their capture rate raised halfway to the threshold,
+	// which gives them about a 10-20% chance per throw
+	catchPresetCasual:    {RareThreshold: rareCaptureRate, RareBoost: 50, Masterball: true},
+	catchPresetAuthentic: {RareThreshold: rareCaptureRate},
+}
+
+// catchrateUsage describes the parameters of the catchrate command.
+const catchrateUsage = "Usage: catchrate [casual | authentic | custom [--rare <0-255>] [--boost <0-100>] [--masterball on|off]]"
+
+// commandCatchRate shows or changes the catch rate preset. The setting is
+// saved with the Pokédex so it persists between sessions.
+// Supported forms:
+//   - catchrate: Show the preset in use and its values
+//   - catchrate casual / catchrate authentic: Use a built-in preset
+//   - catchrate custom --rare 80 --boost 25 --masterball off: Use the custom
+//     preset, changing the values given (the rest keep their custom values,
+//     which start as those of the casual preset)
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters as described above
+//
+// Returns:
+//   - An error if the preset or a value is invalid, or the setting can't be saved
+func commandCatchRate(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		printCatchPreset(cfg.Settings())
+	case len(params) == 1 && (params[0] == catchPresetCasual || params[0] == catchPresetAuthentic):
+		current := cfg.UpdateSettings(func(s *settings) {
+			s.catchPreset = params[0]
+		})
+		printCatchPreset(current)
+		err = savePokedexData(cfg)
+	case params[0] == catchPresetCustom:
+		err = setCustomCatchPreset(cfg, params[1:])
+	default:
+		err = errorhandling.NewInvalidInputError(catchrateUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "catchrate", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// setCustomCatchPreset switches to the custom preset, changing the values
+// given in the flags, and saves it.
+func setCustomCatchPreset(cfg *config, flags []string) error {
+	current := cfg.Settings()
+	tuning := catchPresets[catchPresetCasual]
+	if current.catchCustom != nil {
+		tuning = *current.catchCustom
+	}
+
+	if len(flags)%2 != 0 {
+		return errorhandling.NewInvalidInputError(catchrateUsage, nil)
+	}
+	for i := 0; i < len(flags); i += 2 {
+		flag, value := flags[i], flags[i+1]
+		var err error
+		switch flag {
+		case "--rare":
+			tuning.RareThreshold, err = parseCatchValue(flag, value, maxCaptureRate)
+		case "--boost":
+			tuning.RareBoost, err = parseCatchValue(flag, value, 100)
+		case "--masterball":
+			switch strings.ToLower(value) {
+			case "on":
+				tuning.Masterball = true
+			case "off":
+				tuning.Masterball = false
+			default:
+				err = errorhandling.NewInvalidInputError(catchrateUsage, nil)
+			}
+		default:
+			err = errorhandling.NewInvalidInputError(catchrateUsage, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	current = cfg.UpdateSettings(func(s *settings) {
+		s.catchPreset, s.catchCustom = catchPresetCustom, &tuning
+	})
+	printCatchPreset(current)
+	return savePokedexData(cfg)
+}
+
+// parseCatchValue parses the value of a custom preset flag, which must be a
+// whole number from 0 to max.
+func parseCatchValue(flag, value string, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 || n > max {
+		return 0, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The value of %s must be a whole number from 0 to %d", flag, max), err)
+	}
+	return n, nil
+}
+
+// catchPresetName returns the name of the catch rate preset in use.
+func catchPresetName(current settings) string {
+	if current.catchPreset == "" {
+		return catchPresetCasual
+	}
+	return current.catchPreset
+}
+
+// catchTuning returns the values of the catch rate preset in use. The custom
+// preset falls back to the casual values if none have been set.
+//
+// Parameters:
+//   - current: The settings with the preset
+//
+// Returns:
+//   - How catching treats rare Pokémon
+func catchTuning(current settings) pokedex.CatchTuning {
+	name := catchPresetName(current)
+	if name == catchPresetCustom && current.catchCustom != nil {
+		return *current.catchCustom
+	}
+	if tuning, ok := catchPresets[name]; ok {
+		return tuning
+	}
+	return catchPresets[catchPresetCasual]
+}
+
+// printCatchPreset shows the catch rate preset in use and its values.
+func printCatchPreset(current settings) {
+	tuning := catchTuning(current)
+	i18n.Printf("Catch rate preset: %s\n", catchPresetName(current))
+	i18n.Printf(" - Pokémon with a capture rate below %d are rare\n", tuning.RareThreshold)
+	if tuning.RareBoost == 0 {
+		i18n.Println(" - Rare Pokémon are caught at their own capture rate")
+	} else {
+		i18n.Printf(" - Rare Pokémon have their capture rate raised %d%% of the way to %d\n", tuning.RareBoost, tuning.RareThreshold)
+	}
+	if tuning.Masterball {
+		i18n.Println(" - A Masterball turns up when you catch a rare Pokémon")
+	} else {
+		i18n.Println(" - No Masterball turns up when you catch a rare Pokémon")
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestCommandCatchRate tests switching presets and setting custom values,
+// which are kept when switching away and back
+func TestCommandCatchRate(t *testing.T) {
+	// Batch mode defers saving, so the tests don't write a save file
+	cfg := &config{settings: defaultSettings(), batch: &batchResults{}}
+	if got := catchTuning(cfg.Settings()); got != catchPresets[catchPresetCasual] {
+		t.Errorf("Expected the casual preset by default, got %+v", got)
+	}
+
+	steps := []struct {
+		params []string
+		want   pokedex.CatchTuning
+	}{
+		{[]string{"authentic"}, catchPresets[catchPresetAuthentic]},
+		{[]string{"custom", "--boost", "75"}, pokedex.CatchTuning{RareThreshold: rareCaptureRate, RareBoost: 75, Masterball: true}},
+		{[]string{"custom", "--rare", "80", "--masterball", "off"}, pokedex.CatchTuning{RareThreshold: 80, RareBoost: 75}},
+		{[]string{"casual"}, catchPresets[catchPresetCasual]},
+		{[]string{"custom"}, pokedex.CatchTuning{RareThreshold: 80, RareBoost: 75}},
+	}
+	for _, step := range steps {
+		if err := commandCatchRate(cfg, step.params); err != nil {
+			t.Fatalf("catchrate %v returned an error: %v", step.params, err)
+		}
+		if got := catchTuning(cfg.Settings()); got != step.want {
+			t.Errorf("After catchrate %v, got %+v, expected %+v", step.params, got, step.want)
+		}
+	}
+
+	for _, params := range [][]string{
+		{"easy"},
+		{"custom", "--boost", "101"},
+		{"custom", "--rare", "-1"},
+		{"custom", "--masterball", "maybe"},
+		{"custom", "--boost"},
+	} {
+		if err := commandCatchRate(cfg, params); !errorhandling.IsInvalidInputError(err) {
+			t.Errorf("catchrate %v: expected an invalid input error, got %v", params, err)
+		}
+	}
+}
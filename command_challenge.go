@@ -0,0 +1,276 @@
+// This file implements the challenge command, which lets the user take on
+// challenges they define themselves as JSON files in the challenge directory
+// (see internal/challenge for the format), and tracks their progress as they
+// catch and see Pokémon.
+package main
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/challenge"
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// challengeUsage describes the forms of the challenge command.
+const challengeUsage = "Usage: challenge [list | start <id> | abandon <id> | check]"
+
+// commandChallenge lists, starts, abandons, or checks user-defined challenges.
+// Supported forms:
+//   - challenge / challenge list: List the challenges with their goals and progress
+//   - challenge start <id>: Start a challenge (or start a failed one again)
+//   - challenge abandon <id>: Stop a challenge and forget its progress
+//   - challenge check: Check the challenge files and report any problems
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters as described above
+//
+// Returns:
+//   - An error if the parameters are invalid, the challenge doesn't exist or
+//     can't be started or abandoned, or the challenge directory can't be read
+func commandChallenge(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0 || (len(params) == 1 && params[0] == "list"):
+		err = listChallenges(cfg, time.Now())
+	case len(params) == 2 && params[0] == "start":
+		err = startChallenge(cfg, params[1], time.Now())
+	case len(params) == 2 && params[0] == "abandon":
+		err = abandonChallenge(cfg, params[1])
+	case len(params) == 1 && params[0] == "check":
+		err = checkChallengeFiles()
+	default:
+		err = errorhandling.NewInvalidInputError(challengeUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "challenge", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// loadChallenges reads the challenges in the challenge directory. Files with
+// problems are skipped; 'challenge check' reports them.
+func loadChallenges() ([]challenge.Challenge, []challenge.FileError, error) {
+	challenges, problems, err := challenge.LoadDir(challengesDir.path(), standardTypes)
+	if err != nil {
+		return nil, nil, errorhandling.NewInternalError(
+			i18n.Sprintf("Could not read the challenge directory %s: %v", challengesDir.path(), err), err)
+	}
+	return challenges, problems, nil
+}
+
+// listChallenges shows every challenge with its goal and progress.
+func listChallenges(cfg *config, now time.Time) error {
+	challenges, problems, err := loadChallenges()
+	if err != nil {
+		return err
+	}
+	if len(challenges) == 0 {
+		i18n.Printf("You have no challenges yet. Add challenge files (%s) to %s.\n", challenge.FileExtension, challengesDir.path())
+		i18n.Println("See the README for the format, and use 'challenge check' to check them.")
+		return nil
+	}
+
+	started := cfg.Challenges()
+	events := challengeEvents(cfg)
+	table := NewTable("ID", "Challenge", "Goal", "Progress")
+	for _, c := range challenges {
+		table.AddRow(c.ID, c.Name, describeGoal(c.Goal), challengeStatus(c, started, events, now))
+	}
+	table.Print()
+	if len(problems) > 0 {
+		i18n.Printf("%d challenge files have problems and were skipped. Use 'challenge check' to see them.\n", len(problems))
+	}
+	return nil
+}
+
+// challengeStatus describes how far along a challenge is.
+func challengeStatus(c challenge.Challenge, started map[string]pokedex.ChallengeState, events []challenge.Event, now time.Time) string {
+	state, ok := started[c.ID]
+	switch {
+	case !ok:
+		return i18n.T("Not started")
+	case !state.Completed.IsZero():
+		return i18n.Sprintf("Completed on %s", state.Completed.Local().Format("2006-01-02"))
+	}
+	progress := c.Progress(state.Started, events, now)
+	switch {
+	case !progress.Completed.IsZero():
+		return i18n.Sprintf("Completed on %s", progress.Completed.Local().Format("2006-01-02"))
+	case progress.Failed:
+		return i18n.Sprintf("Failed (%d/%d)", progress.Count, c.Goal.Count)
+	case progress.Deadline.IsZero():
+		return fmt.Sprintf("%d/%d", progress.Count, c.Goal.Count)
+	}
+	return i18n.Sprintf("%d/%d, until %s", progress.Count, c.Goal.Count, progress.Deadline.Local().Format("2006-01-02 15:04"))
+}
+
+// findChallenge looks up a challenge in the challenge directory by its ID.
+func findChallenge(id string) (challenge.Challenge, error) {
+	challenges, _, err := loadChallenges()
+	if err != nil {
+		return challenge.Challenge{}, err
+	}
+	index := slices.IndexFunc(challenges, func(c challenge.Challenge) bool { return c.ID == id })
+	if index < 0 {
+		return challenge.Challenge{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("There's no challenge '%s'. Use 'challenge list' to see them", id), nil)
+	}
+	return challenges[index], nil
+}
+
+// startChallenge starts a challenge. Only what the user does from now on
+// counts toward it. A challenge that failed can be started again; one that's
+// under way or completed can't.
+func startChallenge(cfg *config, id string, now time.Time) error {
+	c, err := findChallenge(id)
+	if err != nil {
+		return err
+	}
+	if state, ok := cfg.Challenges()[c.ID]; ok {
+		progress := c.Progress(state.Started, challengeEvents(cfg), now)
+		switch {
+		case !state.Completed.IsZero() || !progress.Completed.IsZero():
+			return errorhandling.NewInvalidInputError(i18n.Sprintf("You've already completed %s", c.Name), nil)
+		case !progress.Failed:
+			return errorhandling.NewInvalidInputError(i18n.Sprintf("You've already started %s", c.Name), nil)
+		}
+	}
+
+	cfg.SetChallenge(c.ID, pokedex.ChallengeState{Started: now})
+	i18n.Printf("Challenge started: %s\n", c.Name)
+	i18n.Printf("Goal: %s\n", describeGoal(c.Goal))
+	if deadline := c.Deadline(now); !deadline.IsZero() {
+		i18n.Printf("You have until %s.\n", deadline.Local().Format("2006-01-02 15:04"))
+	}
+	return savePokedexData(cfg)
+}
+
+// abandonChallenge stops a challenge and forgets its progress.
+func abandonChallenge(cfg *config, id string) error {
+	if !cfg.RemoveChallenge(id) {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("You haven't started a challenge '%s'", id), nil)
+	}
+	i18n.Printf("Abandoned the challenge '%s'.\n", id)
+	return savePokedexData(cfg)
+}
+
+// checkChallengeFiles checks every file in the challenge directory and reports
+// the problems with any that can't be used.
+func checkChallengeFiles() error {
+	challenges, problems, err := loadChallenges()
+	if err != nil {
+		return err
+	}
+	if len(challenges) == 0 && len(problems) == 0 {
+		i18n.Printf("There are no challenge files (%s) in %s.\n", challenge.FileExtension, challengesDir.path())
+		return nil
+	}
+	i18n.Printf("%d challenges are valid.\n", len(challenges))
+	if len(problems) == 0 {
+		return nil
+	}
+	i18n.Printf("%d challenge files have problems:\n", len(problems))
+	for _, problem := range problems {
+		i18n.Printf(" - %s\n", problem.Error())
+	}
+	return errorhandling.NewInvalidInputError(i18n.Sprintf("Fix the problems in %s", challengesDir.path()), nil)
+}
+
+// describeGoal describes a challenge's goal (e.g. "Catch 5 Ghost-type Pokémon
+// within 7 days").
+func describeGoal(goal challenge.Goal) string {
+	var text string
+	switch {
+	case goal.Action == challenge.ActionSee:
+		text = i18n.Sprintf("See %d new Pokémon", goal.Count)
+	case goal.Type != "":
+		text = i18n.Sprintf("Catch %d %s-type Pokémon", goal.Count, FormatTypeName(goal.Type))
+	default:
+		text = i18n.Sprintf("Catch %d Pokémon", goal.Count)
+	}
+	if goal.Location != "" {
+		text = i18n.Sprintf("%s in %s", text, FormatLocationName(goal.Location))
+	}
+	if goal.Days > 0 {
+		text = i18n.Sprintf("%s within %d days", text, goal.Days)
+	}
+	return text
+}
+
+// challengeEvents lists what the user has done that challenges can count:
+// every Pokémon in the Pokédex with a catch date, and every sighting with a
+// date. Pokémon that were released no longer count.
+func challengeEvents(cfg *config) []challenge.Event {
+	var events []challenge.Event
+	for _, entry := range cfg.pokedex.All() {
+		if !entry.CaughtOn.IsZero() {
+			events = append(events, challenge.Event{
+				Action: challenge.ActionCatch, At: entry.CaughtOn,
+				Types: pokemonTypes(entry.PokemonDataResp), Location: entry.CaughtAt,
+			})
+		}
+	}
+	for _, sighting := range cfg.pokedex.Seen() {
+		if !sighting.SeenOn.IsZero() {
+			events = append(events, challenge.Event{Action: challenge.ActionSee, At: sighting.SeenOn, Location: sighting.Location})
+		}
+	}
+	return events
+}
+
+// updateChallenges checks the challenges under way after the user catches or
+// sees Pokémon, and announces and records any that are now complete. The
+// challenge files are only read if a challenge is under way, and problems
+// reading them are left for the challenge command to report.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+func updateChallenges(cfg *config) {
+	started := cfg.Challenges()
+	underway := false
+	for _, state := range started {
+		underway = underway || state.Completed.IsZero()
+	}
+	if !underway {
+		return
+	}
+	challenges, _, err := loadChallenges()
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	events := challengeEvents(cfg)
+	var completed []string
+	for _, c := range challenges {
+		state, ok := started[c.ID]
+		if !ok || !state.Completed.IsZero() {
+			continue
+		}
+		if progress := c.Progress(state.Started, events, now); !progress.Completed.IsZero() {
+			state.Completed = progress.Completed
+			cfg.SetChallenge(c.ID, state)
+			completed = append(completed, c.Name)
+		}
+	}
+	for _, name := range completed {
+		i18n.Printf("Challenge complete: %s!\n", name)
+	}
+	if len(completed) > 0 {
+		// Count the completed challenges as a change for auto-saving
+		if err := UpdatePokedexAndSave(cfg); err != nil {
+			HandleCommandError(cfg, "challenge", err)
+		}
+	}
+}
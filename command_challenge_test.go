@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/challenge"
+)
+
+// TestDescribeGoal tests the descriptions of challenge goals
+func TestDescribeGoal(t *testing.T) {
+	tests := []struct {
+		goal challenge.Goal
+		want string
+	}{
+		{challenge.Goal{Action: challenge.ActionCatch, Count: 3}, "Catch 3 Pokémon"},
+		{challenge.Goal{Action: challenge.ActionCatch, Count: 5, Type: "ghost", Days: 7}, "Catch 5 Ghost-type Pokémon within 7 days"},
+		{challenge.Goal{Action: challenge.ActionSee, Count: 10, Location: "viridian-forest-area"}, "See 10 new Pokémon in Viridian Forest Area"},
+	}
+	for _, tt := range tests {
+		if got := describeGoal(tt.goal); got != tt.want {
+			t.Errorf("describeGoal(%+v) = %q, want %q", tt.goal, got, tt.want)
+		}
+	}
+}
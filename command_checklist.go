@@ -0,0 +1,275 @@
+// This file implements the checklist command for the Pokédex CLI application.
+// It shows every species introduced in a generation in National Pokédex order,
+// marking which ones the user has seen and caught, so they can track their
+// progress toward completing the Pokédex.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// checklistCellWidth is the width of one species cell in the checklist grid,
+// including the marker and dex number (e.g. "[x] 025 Pikachu").
+const checklistCellWidth = 24
+
+// checklistItem is a single species in a checklist.
+type checklistItem struct {
+	number int    // National Pokédex number
+	name   string // Species name in API format
+	caught bool   // Whether the user has caught this species
+	seen   bool   // Whether the user has seen this species (always true if caught)
+}
+
+// commandChecklist displays every species in a generation with caught/uncaught markers.
+// Species are listed in National Pokédex order in a compact multi-column grid,
+// followed by a summary of how many have been caught. The checklist can also be
+// written to a text file.
+//
+// Usage:
+//   - checklist gen1: Show the checklist for Generation I (also accepts "1")
+//   - checklist gen1 --out <file>: Write the checklist to a file instead
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the generation, optionally
+//     followed by "--out" and a file path
+//
+// Returns:
+//   - An error if the parameters are invalid, the API request fails,
+//     or the file can't be written
+func commandChecklist(cfg *config, params []string) error {
+	generation, outPath, err := parseChecklistParams(params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "checklist", err) {
+			return err
+		}
+		return nil
+	}
+
+	genData, err := cfg.pokeapiClient.GetGeneration(generation)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "checklist", err) {
+			return err
+		}
+		return nil
+	}
+
+	items := buildChecklist(cfg, genData.PokemonSpecies)
+	title := formatGenerationName(genData.Name)
+
+	// Write to a file if requested, otherwise print to the terminal
+	if outPath != "" {
+		file, err := os.Create(outPath)
+		if err != nil {
+			fileErr := errorhandling.NewInvalidInputError(i18n.Sprintf("Could not create file '%s'", outPath), err)
+			if HandleCommandError(cfg, "checklist", fileErr) {
+				return fileErr
+			}
+			return nil
+		}
+		defer file.Close()
+
+		// Files aren't limited by the terminal width, so use a fixed layout
+		renderChecklist(file, title, items, defaultTerminalWidth)
+		i18n.Printf("Checklist for %s written to %s\n", title, outPath)
+		printSeparator()
+		return nil
+	}
+
+	renderChecklist(os.Stdout, title, items, terminalWidth())
+	printSeparator()
+	return nil
+}
+
+// parseChecklistParams parses the generation and optional output file of the checklist command.
+//
+// Parameters:
+//   - params: The command parameters
+//
+// Returns:
+//   - The generation number
+//   - The output file path, or an empty string to print to the terminal
+//   - An error if the parameters are invalid
+func parseChecklistParams(params []string) (int, string, error) {
+	usageErr := errorhandling.NewInvalidInputError("Usage: checklist <generation> [--out <file>] (e.g., 'checklist gen1')", nil)
+	if len(params) != 1 && len(params) != 3 {
+		return 0, "", usageErr
+	}
+
+	generation, err := parseGeneration(params[0])
+	if err != nil {
+		return 0, "", err
+	}
+
+	outPath := ""
+	if len(params) == 3 {
+		if strings.ToLower(params[1]) != "--out" {
+			return 0, "", usageErr
+		}
+		outPath = params[2]
+	}
+	return generation, outPath, nil
+}
+
+// parseGeneration parses a generation given as a number, with or without a
+// "gen" prefix (e.g. "gen1" or "3").
+//
+// Parameters:
+//   - param: The generation as typed by the user
+//
+// Returns:
+//   - The generation number
+//   - An error if the parameter isn't a generation number
+func parseGeneration(param string) (int, error) {
+	generation, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(param), "gen"))
+	if err != nil || generation < 1 {
+		return 0, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Invalid generation '%s': use a number like 'gen1' or '3'", param), err)
+	}
+	return generation, nil
+}
+
+// buildChecklist creates a checklist entry for each species, in National Pokédex order.
+// A species counts as caught if any Pokémon of that species is in the Pokédex,
+// and as seen if it's caught or has been recorded as seen.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - species: The species to include in the checklist
+//
+// Returns:
+//   - The checklist items, sorted by National Pokédex number
+func buildChecklist(cfg *config, species []pokeapi.NamedAPIResource) []checklistItem {
+	// Collect the species of every caught Pokémon. The Pokédex key is checked
+	// as well, since entries saved by older versions may lack species data.
+	entries := cfg.pokedex.All()
+	caught := make(map[string]bool, len(entries)*2)
+	for key, entry := range entries {
+		caught[key] = true
+		caught[entry.Species.Name] = true
+	}
+
+	items := make([]checklistItem, 0, len(species))
+	for _, s := range species {
+		number, err := s.ID()
+		if err != nil {
+			continue // Validated when the generation is fetched, so this shouldn't happen
+		}
+		items = append(items, checklistItem{
+			number: number,
+			name:   s.Name,
+			caught: caught[s.Name],
+			seen:   caught[s.Name] || cfg.pokedex.HasSeen(s.Name),
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].number < items[j].number
+	})
+	return items
+}
+
+// renderChecklist writes a checklist as a grid of species followed by a summary.
+// In accessible mode, the species are listed one per line instead.
+//
+// Parameters:
+//   - w: The writer to render the checklist to
+//   - title: The display name of the generation
+//   - items: The checklist items, in display order
+//   - width: The maximum width of each line
+func renderChecklist(w io.Writer, title string, items []checklistItem, width int) {
+	i18n.Fprintf(w, "%s checklist:\n", title)
+
+	// A grid is hard to follow with a screen reader, so list one species per line
+	if isAccessibleOutput() {
+		for _, item := range items {
+			fmt.Fprintf(w, "%03d %s: %s\n", item.number, FormatPokemonName(item.name), caughtStatus(item.caught, item.seen))
+		}
+	} else {
+		renderChecklistGrid(w, items, width)
+	}
+
+	seenCount, caughtCount := 0, 0
+	for _, item := range items {
+		if item.seen {
+			seenCount++
+		}
+		if item.caught {
+			caughtCount++
+		}
+	}
+	percent := 0
+	if len(items) > 0 {
+		percent = caughtCount * 100 / len(items)
+	}
+	i18n.Fprintf(w, "Seen %d, caught %d of %d (%d%%)\n", seenCount, caughtCount, len(items), percent)
+}
+
+// renderChecklistGrid writes checklist items as a grid that fits within width.
+// Species fill the grid column by column, so numbers read top to bottom.
+func renderChecklistGrid(w io.Writer, items []checklistItem, width int) {
+	columns := max(width/checklistCellWidth, 1)
+	rows := (len(items) + columns - 1) / columns
+	for row := 0; row < rows; row++ {
+		var line strings.Builder
+		for col := 0; col < columns; col++ {
+			i := col*rows + row
+			if i >= len(items) {
+				break
+			}
+			// Leave room for the gap between columns
+			cell := truncateText(formatChecklistItem(items[i]), checklistCellWidth-len(columnGap))
+			line.WriteString(cell + strings.Repeat(" ", checklistCellWidth-displayWidth(cell)))
+		}
+		fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+	}
+}
+
+// formatChecklistItem formats a checklist item as "[x] 025 Pikachu", marking
+// caught species with "x" and species that have only been seen with "o".
+func formatChecklistItem(item checklistItem) string {
+	return fmt.Sprintf("%s %03d %s", caughtMarker(item.caught, item.seen), item.number, FormatPokemonName(item.name))
+}
+
+// caughtMarker returns the marker for a species: "[x]" if it has been caught,
+// "[o]" if it has only been seen, and "[ ]" otherwise.
+func caughtMarker(caught, seen bool) string {
+	switch {
+	case caught:
+		return "[x]"
+	case seen:
+		return "[o]"
+	}
+	return "[ ]"
+}
+
+// caughtStatus describes in words whether a species has been caught or seen,
+// for where caughtMarker's markers would need a legend.
+func caughtStatus(caught, seen bool) string {
+	switch {
+	case caught:
+		return i18n.T("caught")
+	case seen:
+		return i18n.T("seen")
+	}
+	return i18n.T("not seen")
+}
+
+// formatGenerationName converts an API generation name (like "generation-iv")
+// to a display name with an upper-case numeral (like "Generation IV").
+func formatGenerationName(name string) string {
+	if numeral, ok := strings.CutPrefix(name, "generation-"); ok {
+		return i18n.Sprintf("Generation %s", strings.ToUpper(numeral))
+	}
+	return FormatLocationName(name)
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRenderChecklist tests that checklists fill columns top to bottom
+// and summarize the number of seen and caught species
+func TestRenderChecklist(t *testing.T) {
+	items := []checklistItem{
+		{number: 1, name: "bulbasaur", caught: true, seen: true},
+		{number: 2, name: "ivysaur", seen: true},
+		{number: 3, name: "venusaur"},
+		{number: 4, name: "charmander", caught: true, seen: true},
+		{number: 5, name: "charmeleon"},
+	}
+
+	var out strings.Builder
+	renderChecklist(&out, "Generation I", items, 2*checklistCellWidth)
+
+	expected := "Generation I checklist:\n" +
+		"[x] 001 Bulbasaur       [x] 004 Charmander\n" +
+		"[o] 002 Ivysaur         [ ] 005 Charmeleon\n" +
+		"[ ] 003 Venusaur\n" +
+		"Seen 3, caught 2 of 5 (40%)\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected checklist:\n%s\nExpected:\n%s", out.String(), expected)
+	}
+}
+
+// TestRenderChecklistAccessible tests that accessible mode lists one species
+// per line with its status in words
+func TestRenderChecklistAccessible(t *testing.T) {
+	configureOutput(true)
+	defer configureOutput(false)
+
+	items := []checklistItem{
+		{number: 1, name: "bulbasaur", caught: true, seen: true},
+		{number: 2, name: "ivysaur", seen: true},
+		{number: 3, name: "venusaur"},
+	}
+
+	var out strings.Builder
+	renderChecklist(&out, "Generation I", items, 2*checklistCellWidth)
+
+	expected := "Generation I checklist:\n" +
+		"001 Bulbasaur: caught\n" +
+		"002 Ivysaur: seen\n" +
+		"003 Venusaur: not seen\n" +
+		"Seen 2, caught 1 of 3 (33%)\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected checklist:\n%s\nExpected:\n%s", out.String(), expected)
+	}
+}
+
+// TestParseChecklistParams tests parsing of generation numbers and output files
+func TestParseChecklistParams(t *testing.T) {
+	cases := []struct {
+		params     []string
+		generation int
+		outPath    string
+		wantErr    bool
+	}{
+		{params: []string{"gen1"}, generation: 1},
+		{params: []string{"Gen4"}, generation: 4},
+		{params: []string{"3"}, generation: 3},
+		{params: []string{"gen2", "--out", "My List.txt"}, generation: 2, outPath: "My List.txt"},
+		{params: []string{}, wantErr: true},
+		{params: []string{"kanto"}, wantErr: true},
+		{params: []string{"gen0"}, wantErr: true},
+		{params: []string{"gen1", "--file", "list.txt"}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		generation, outPath, err := parseChecklistParams(c.params)
+		if (err != nil) != c.wantErr {
+			t.Errorf("parseChecklistParams(%v) error = %v, wantErr %v", c.params, err, c.wantErr)
+			continue
+		}
+		if generation != c.generation || outPath != c.outPath {
+			t.Errorf("parseChecklistParams(%v) = %d, %q; want %d, %q", c.params, generation, outPath, c.generation, c.outPath)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// This file implements the commands command, which describes the command registry
+// for people and for tools. With --json it prints a manifest of every command, its
+// arguments, and its description, so that shell completion generators, GUIs, and
+// other external tools can find out what the CLI can do.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// commandManifest is the machine-readable description of the CLI printed by 'commands --json'.
+type commandManifest struct {
+	Program  string                 `json:"program"`  // Name of the program
+	Version  string                 `json:"version"`  // Version of the program
+	Commands []commandManifestEntry `json:"commands"` // The commands, sorted by name
+}
+
+// commandManifestEntry describes one command in the manifest.
+type commandManifestEntry struct {
+	Name        string   `json:"name"`         // Name of the command
+	Args        string   `json:"args"`         // Arguments in usage notation ("" if there are none)
+	Description string   `json:"description"`  // Description shown in help, in English
+	Flags       []string `json:"flags"`        // Flags the command accepts, such as "--dry-run"
+	PokemonName bool     `json:"pokemon_name"` // Whether the arguments are a single Pokémon name
+	DryRun      bool     `json:"dry_run"`      // Whether the command supports --dry-run
+}
+
+// flagPattern matches the flags in a command's usage notation.
+var flagPattern = regexp.MustCompile(`--[a-z][a-z-]*`)
+
+// buildCommandManifest describes every registered command.
+//
+// Parameters:
+//   - commands: The registry of available commands
+//
+// Returns:
+//   - The manifest, with the commands sorted by name
+func buildCommandManifest(commands map[string]cliCommand) commandManifest {
+	manifest := commandManifest{Program: "pokedexcli", Version: appVersion()}
+	for _, name := range slices.Sorted(maps.Keys(commands)) {
+		command := commands[name]
+		flags := []string{}
+		for _, flag := range flagPattern.FindAllString(command.args, -1) {
+			if !slices.Contains(flags, flag) {
+				flags = append(flags, flag)
+			}
+		}
+		manifest.Commands = append(manifest.Commands, commandManifestEntry{
+			Name:        command.name,
+			Args:        command.args,
+			Description: command.description,
+			Flags:       flags,
+			PokemonName: pokemonNameCommands[command.name],
+			DryRun:      command.dryRun,
+		})
+	}
+	return manifest
+}
+
+// commandCommands lists the usage of every command, or with --json prints the
+// full command registry as JSON for external tools. The JSON is printed on its
+// own, without the separator line, so that it can be piped straight into a parser.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters, where params[0] may be "--json"
+//
+// Returns:
+//   - An error if an unknown parameter is given or the manifest can't be encoded
+func commandCommands(cfg *config, params []string) error {
+	asJSON := false
+	for _, param := range params {
+		if param != "--json" {
+			err := errorhandling.NewInvalidInputError("Usage: commands [--json]", nil)
+			if HandleCommandError(cfg, "commands", err) {
+				return err
+			}
+			return nil
+		}
+		asJSON = true
+	}
+
+	manifest := buildCommandManifest(getCommands())
+	if asJSON {
+		encoded, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding command manifest: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return nil
+	}
+
+	for _, command := range manifest.Commands {
+		fmt.Println(strings.TrimSpace(command.Name + " " + command.Args))
+	}
+	printSeparator()
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestBuildCommandManifest tests that every registered command is described,
+// in order, with its flags and capabilities
+func TestBuildCommandManifest(t *testing.T) {
+	commands := getCommands()
+	manifest := buildCommandManifest(commands)
+
+	if len(manifest.Commands) != len(commands) {
+		t.Fatalf("Expected %d commands in the manifest, got %d", len(commands), len(manifest.Commands))
+	}
+	if !slices.IsSortedFunc(manifest.Commands, func(a, b commandManifestEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	}) {
+		t.Error("Expected the commands to be sorted by name")
+	}
+
+	byName := make(map[string]commandManifestEntry)
+	for _, command := range manifest.Commands {
+		if command.Description == "" {
+			t.Errorf("Command %q has no description", command.Name)
+		}
+		byName[command.Name] = command
+	}
+
+	evolve := byName["evolve"]
+	if !reflect.DeepEqual(evolve.Flags, []string{"--yes", "--dry-run", "--json"}) {
+		t.Errorf("Expected evolve to accept --yes, --dry-run, and --json, got %v", evolve.Flags)
+	}
+	if !evolve.PokemonName || !evolve.DryRun {
+		t.Errorf("Expected evolve to take a Pokémon name and support dry runs, got %+v", evolve)
+	}
+	if help := byName["help"]; help.Args != "" || len(help.Flags) != 0 || help.DryRun {
+		t.Errorf("Expected help to take no arguments, got %+v", help)
+	}
+
+	// Commands without flags are encoded with an empty list rather than null
+	encoded, err := json.Marshal(byName["help"])
+	if err != nil {
+		t.Fatalf("Failed to encode manifest entry: %v", err)
+	}
+	var decoded map[string]any
+	json.Unmarshal(encoded, &decoded)
+	if flags, ok := decoded["flags"].([]any); !ok || len(flags) != 0 {
+		t.Errorf("Expected an empty flags list, got %s", encoded)
+	}
+}
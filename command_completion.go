@@ -0,0 +1,261 @@
+// This file implements the completion command, which prints a shell completion
+// script for running pokedexcli with a command on its command line (for example
+// "pokedexcli catch pikachu"). The scripts complete the program's flags, the
+// command names, and each command's flags, and are generated from the same
+// metadata as 'commands --json', so they stay in step with the command registry.
+// Commands that take a wild Pokémon also complete the names of those found in
+// the area explored last, which the scripts get from 'completion names'.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// completionUsage describes the forms of the completion command.
+const completionUsage = "Usage: completion bash|zsh|fish|names"
+
+// completionNamesCommand is the command the scripts run to list the Pokémon
+// found in the area explored last.
+const completionNamesCommand = "pokedexcli completion names 2>/dev/null"
+
+// exploredNameCommands are the commands whose Pokémon parameter is completed
+// with the Pokémon found in the area explored last.
+var exploredNameCommands = []string{"catch", "odds", "lookup"}
+
+// programFlag describes one of the program's own flags, such as --fixtures.
+type programFlag struct {
+	name       string // Name of the flag, without dashes
+	usage      string // Help text for the flag
+	takesValue bool   // Whether the flag is followed by a value
+}
+
+// programFlags returns the program's own flags, as defined in main.
+func programFlags() []programFlag {
+	var flags []programFlag
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		isBool := false
+		if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); ok {
+			isBool = boolFlag.IsBoolFlag()
+		}
+		flags = append(flags, programFlag{name: f.Name, usage: f.Usage, takesValue: !isBool})
+	})
+	return flags
+}
+
+// commandCompletion prints a completion script for bash, zsh, or fish. The script
+// is printed on its own, without the separator line, so that it can be redirected
+// straight into a file or sourced (e.g. "source <(pokedexcli completion bash)").
+// 'completion names' prints the Pokémon found in the area explored last, one
+// per line, for the scripts to complete.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters, where params[0] is the shell, or "names"
+//
+// Returns:
+//   - An error if the shell is missing or not supported
+func commandCompletion(cfg *config, params []string) error {
+	if len(params) == 1 && params[0] == "names" {
+		for _, name := range cfg.ExploredPokemon() {
+			fmt.Println(name)
+		}
+		return nil
+	}
+
+	generators := map[string]func(commandManifest, []programFlag) string{
+		"bash": bashCompletion,
+		"zsh":  zshCompletion,
+		"fish": fishCompletion,
+	}
+
+	var generate func(commandManifest, []programFlag) string
+	if len(params) == 1 {
+		generate = generators[params[0]]
+	}
+	if generate == nil {
+		err := errorhandling.NewInvalidInputError(completionUsage, nil)
+		if HandleCommandError(cfg, "completion", err) {
+			return err
+		}
+		return nil
+	}
+
+	fmt.Print(generate(buildCommandManifest(getCommands()), programFlags()))
+	return nil
+}
+
+// bashCompletion generates a completion script for bash. The first word that
+// isn't a flag (or a flag's value) is taken to be the command.
+//
+// Parameters:
+//   - manifest: The description of the commands
+//   - flags: The program's own flags
+//
+// Returns:
+//   - The completion script
+func bashCompletion(manifest commandManifest, flags []programFlag) string {
+	var topLevel, valueFlags []string
+	for _, f := range flags {
+		topLevel = append(topLevel, "--"+f.name)
+		if f.takesValue {
+			valueFlags = append(valueFlags, "--"+f.name)
+		}
+	}
+	for _, command := range manifest.Commands {
+		topLevel = append(topLevel, command.Name)
+	}
+
+	var b strings.Builder
+	b.WriteString("# bash completion for pokedexcli\n")
+	b.WriteString("_pokedexcli() {\n")
+	b.WriteString("    local cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    local command=\"\" i\n")
+	b.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("        case \"${COMP_WORDS[i]}\" in\n")
+	if len(valueFlags) > 0 {
+		fmt.Fprintf(&b, "            %s) ((i++)) ;;\n", strings.Join(valueFlags, "|"))
+	}
+	b.WriteString("            -*) ;;\n")
+	b.WriteString("            *) command=\"${COMP_WORDS[i]}\"; break ;;\n")
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n\n")
+	b.WriteString("    local words=\"\"\n")
+	b.WriteString("    case \"$command\" in\n")
+	fmt.Fprintf(&b, "        \"\") words=\"%s\" ;;\n", strings.Join(topLevel, " "))
+	for _, command := range manifest.Commands {
+		if len(command.Flags) > 0 {
+			fmt.Fprintf(&b, "        %s) words=\"%s\" ;;\n", command.Name, strings.Join(command.Flags, " "))
+		}
+	}
+	b.WriteString("    esac\n")
+	if names := exploredNamePattern(manifest, "|"); names != "" {
+		fmt.Fprintf(&b, "    case \"$command\" in\n        %s) words=\"$words $(%s)\" ;;\n    esac\n", names, completionNamesCommand)
+	}
+	b.WriteString("    COMPREPLY=($(compgen -W \"$words\" -- \"$cur\"))\n")
+	b.WriteString("}\n")
+	b.WriteString("complete -o default -F _pokedexcli pokedexcli\n")
+	return b.String()
+}
+
+// zshCompletion generates a completion script for zsh, which also shows the
+// description of each command and flag.
+//
+// Parameters:
+//   - manifest: The description of the commands
+//   - flags: The program's own flags
+//
+// Returns:
+//   - The completion script
+func zshCompletion(manifest commandManifest, flags []programFlag) string {
+	var b strings.Builder
+	b.WriteString("#compdef pokedexcli\n\n")
+	b.WriteString("_pokedexcli() {\n")
+	b.WriteString("    local -a commands\n")
+	b.WriteString("    commands=(\n")
+	for _, command := range manifest.Commands {
+		fmt.Fprintf(&b, "        %s\n", shellQuote(command.Name+":"+command.Description))
+	}
+	b.WriteString("    )\n\n")
+	b.WriteString("    local state\n")
+	b.WriteString("    _arguments -C \\\n")
+	for _, f := range flags {
+		spec := "--" + f.name + "[" + zshEscapeBrackets(f.usage) + "]"
+		if f.takesValue {
+			spec += ":" + f.name + ":_files"
+		}
+		fmt.Fprintf(&b, "        %s \\\n", shellQuote(spec))
+	}
+	b.WriteString("        '1:command:->command' \\\n")
+	b.WriteString("        '*::argument:->argument'\n\n")
+	b.WriteString("    case $state in\n")
+	b.WriteString("        command) _describe -t commands 'pokedexcli command' commands ;;\n")
+	b.WriteString("        argument)\n")
+	if names := exploredNamePattern(manifest, "|"); names != "" {
+		fmt.Fprintf(&b, "            case $words[1] in\n                %s) compadd -- $(%s) ;;\n            esac\n", names, completionNamesCommand)
+	}
+	b.WriteString("            case $words[1] in\n")
+	for _, command := range manifest.Commands {
+		if len(command.Flags) > 0 {
+			fmt.Fprintf(&b, "                %s) compadd -- %s ;;\n", command.Name, strings.Join(command.Flags, " "))
+		}
+	}
+	b.WriteString("                *) _files ;;\n")
+	b.WriteString("            esac\n")
+	b.WriteString("            ;;\n")
+	b.WriteString("    esac\n")
+	b.WriteString("}\n\n")
+	b.WriteString("_pokedexcli \"$@\"\n")
+	return b.String()
+}
+
+// fishCompletion generates a completion script for fish, which also shows the
+// description of each command and flag.
+//
+// Parameters:
+//   - manifest: The description of the commands
+//   - flags: The program's own flags
+//
+// Returns:
+//   - The completion script
+func fishCompletion(manifest commandManifest, flags []programFlag) string {
+	var b strings.Builder
+	b.WriteString("# fish completion for pokedexcli\n")
+	for _, f := range flags {
+		valueOption := ""
+		if f.takesValue {
+			valueOption = " -r"
+		}
+		fmt.Fprintf(&b, "complete -c pokedexcli -n __fish_use_subcommand -l %s%s -d %s\n",
+			f.name, valueOption, shellQuote(f.usage))
+	}
+	for _, command := range manifest.Commands {
+		fmt.Fprintf(&b, "complete -c pokedexcli -n __fish_use_subcommand -f -a %s -d %s\n",
+			command.Name, shellQuote(command.Description))
+	}
+	for _, command := range manifest.Commands {
+		for _, commandFlag := range command.Flags {
+			fmt.Fprintf(&b, "complete -c pokedexcli -n '__fish_seen_subcommand_from %s' -l %s\n",
+				command.Name, strings.TrimPrefix(commandFlag, "--"))
+		}
+	}
+	if names := exploredNamePattern(manifest, " "); names != "" {
+		fmt.Fprintf(&b, "complete -c pokedexcli -n '__fish_seen_subcommand_from %s' -f -a '(%s)'\n", names, completionNamesCommand)
+	}
+	return b.String()
+}
+
+// shellQuote quotes a string for bash, zsh, and fish with single quotes.
+// Single quotes in the string end the quoted text, are escaped, and start it again.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// zshEscapeBrackets escapes square brackets, which end a flag's description in
+// a zsh _arguments specification.
+func zshEscapeBrackets(s string) string {
+	return strings.NewReplacer("[", `\[`, "]", `\]`).Replace(s)
+}
+
+// exploredNamePattern joins the names of the commands in exploredNameCommands
+// that are in the manifest, for matching them in a script.
+//
+// Parameters:
+//   - manifest: The description of the commands
+//   - sep: What to join the names with, such as "|" for a case pattern
+//
+// Returns:
+//   - The joined names, or "" if none of the commands are in the manifest
+func exploredNamePattern(manifest commandManifest, sep string) string {
+	var names []string
+	for _, command := range manifest.Commands {
+		if slices.Contains(exploredNameCommands, command.Name) {
+			names = append(names, command.Name)
+		}
+	}
+	return strings.Join(names, sep)
+}
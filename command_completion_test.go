@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCompletionScripts tests that each shell's script covers the program's
+// flags, the command names, and the flags of each command
+func TestCompletionScripts(t *testing.T) {
+	manifest := commandManifest{Commands: []commandManifestEntry{
+		{Name: "catch", Description: "Attempt to catch the specified pokemon", Flags: []string{"--ball"}},
+		{Name: "egggroups", Description: "Show a pokemon's egg groups", Flags: []string{}},
+	}}
+	flags := []programFlag{
+		{name: "fixtures", usage: "serve API responses from JSON fixtures", takesValue: true},
+		{name: "yes", usage: "answer yes to every confirmation prompt"},
+	}
+
+	tests := []struct {
+		shell    string
+		generate func(commandManifest, []programFlag) string
+		want     []string
+	}{
+		{"bash", bashCompletion, []string{
+			`--fixtures) ((i++)) ;;`,
+			`"") words="--fixtures --yes catch egggroups" ;;`,
+			`catch) words="--ball" ;;`,
+			"complete -o default -F _pokedexcli pokedexcli",
+		}},
+		{"zsh", zshCompletion, []string{
+			"#compdef pokedexcli",
+			`'egggroups:Show a pokemon'\''s egg groups'`,
+			`'--fixtures[serve API responses from JSON fixtures]:fixtures:_files'`,
+			`'--yes[answer yes to every confirmation prompt]'`,
+			"catch) compadd -- --ball ;;",
+		}},
+		{"fish", fishCompletion, []string{
+			"complete -c pokedexcli -n __fish_use_subcommand -l fixtures -r -d 'serve API responses from JSON fixtures'",
+			"complete -c pokedexcli -n __fish_use_subcommand -l yes -d 'answer yes to every confirmation prompt'",
+			"complete -c pokedexcli -n __fish_use_subcommand -f -a catch -d 'Attempt to catch the specified pokemon'",
+			"complete -c pokedexcli -n '__fish_seen_subcommand_from catch' -l ball",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			script := tt.generate(manifest, flags)
+			for _, want := range tt.want {
+				if !strings.Contains(script, want) {
+					t.Errorf("Expected the %s script to contain %q, got:\n%s", tt.shell, want, script)
+				}
+			}
+			if strings.Contains(script, "egggroups)") {
+				t.Errorf("Expected no flag completion for a command without flags, got:\n%s", script)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// This file implements the copy command, which places a summary of a caught
+// Pokémon on the system clipboard (see internal/clipboard), ready to paste
+// into a chat.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/clipboard"
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// copyJSONFlag copies the entry as JSON instead of as text.
+const copyJSONFlag = "--json"
+
+// copiedEntry is the JSON copied by 'copy --json': the parts of a Pokédex
+// entry worth sharing, without the full API data.
+type copiedEntry struct {
+	Name      string         `json:"name"`                // The Pokémon's name in API format
+	Level     int            `json:"level"`               // Its level
+	Types     []string       `json:"types"`               // Its types, in API format
+	BaseStats map[string]int `json:"base_stats"`          // Its base stats, by API stat name
+	Moves     []string       `json:"moves,omitempty"`     // The moves it was taught
+	CaughtOn  time.Time      `json:"caught_on,omitzero"`  // When it was caught, if known
+	CaughtAt  string         `json:"caught_at,omitempty"` // Where it was caught, if known
+	Ribbons   []string       `json:"ribbons,omitempty"`   // The ribbons it has earned
+	Notes     []string       `json:"notes,omitempty"`     // The user's notes on it
+}
+
+// commandCopy copies a summary of a caught Pokémon to the clipboard, as text
+// or, with --json, as JSON. If there's no clipboard tool, the summary is
+// printed instead, so it can be copied by hand.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: The Pokémon's name, optionally with --json
+//
+// Returns:
+//   - An error if the Pokémon isn't in the Pokédex or the clipboard tool fails
+func commandCopy(cfg *config, params []string) error {
+	asJSON := slices.Contains(params, copyJSONFlag)
+	params = slices.DeleteFunc(slices.Clone(params), func(p string) bool { return p == copyJSONFlag })
+
+	err := copyEntry(cfg, params, asJSON)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "copy", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// copyEntry copies the summary of the Pokémon named in params to the clipboard.
+func copyEntry(cfg *config, params []string, asJSON bool) error {
+	_, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, params)
+	if err != nil {
+		return err
+	}
+	entry, err := GetTypedPokemonData(pokemonData, nameInfo.Formatted)
+	if err != nil {
+		return err
+	}
+
+	text := entrySummary(nameInfo.Formatted, entry)
+	if asJSON {
+		if text, err = entryJSON(entry); err != nil {
+			return errorhandling.NewInternalError("Could not encode the Pokémon as JSON", err)
+		}
+	}
+
+	err = clipboard.Copy(text)
+	switch {
+	case errors.Is(err, clipboard.ErrUnavailable):
+		i18n.Println("No clipboard tool was found (such as xclip, xsel, or wl-copy), so here it is to copy by hand:")
+		fmt.Println(text)
+		return nil
+	case err != nil:
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not copy %s to the clipboard", nameInfo.Formatted), err)
+	}
+	i18n.Printf("Copied %s to the clipboard.\n", nameInfo.Formatted)
+	return nil
+}
+
+// entrySummary describes a caught Pokémon in a few lines of text, in the
+// user's language.
+//
+// Parameters:
+//   - name: The Pokémon's display name
+//   - entry: The Pokémon's Pokédex entry
+//
+// Returns:
+//   - The summary, without a trailing newline
+func entrySummary(name string, entry pokedex.Entry) string {
+	var b strings.Builder
+	b.WriteString(name + "\n")
+	b.WriteString(i18n.Sprintf("Level: %d\n", entry.CurrentLevel()))
+	b.WriteString(i18n.Sprintf("Types: %s\n", FormatTypeList(pokemonTypes(entry.PokemonDataResp))))
+
+	stats := make([]string, len(entry.Stats))
+	for i, stat := range entry.Stats {
+		stats[i] = fmt.Sprintf("%s %d", FormatStatName(stat.Stat.Name), stat.BaseStat)
+	}
+	b.WriteString(i18n.Sprintf("Base stats: %s (total %d)\n", strings.Join(stats, ", "), baseStatTotal(entry.PokemonDataResp)))
+
+	if len(entry.Moveset) > 0 {
+		moves := make([]string, len(entry.Moveset))
+		for i, move := range entry.Moveset {
+			moves[i] = FormatMoveName(move)
+		}
+		b.WriteString(i18n.Sprintf("Moves: %s\n", strings.Join(moves, ", ")))
+	}
+	if caught := formatCaughtDetails(entry); caught != "" {
+		b.WriteString(i18n.Sprintf("Caught: %s\n", caught))
+	}
+	if len(entry.Ribbons) > 0 {
+		ribbons := make([]string, len(entry.Ribbons))
+		for i, id := range entry.Ribbons {
+			ribbons[i] = ribbonName(id)
+		}
+		b.WriteString(i18n.Sprintf("Ribbons: %s\n", strings.Join(ribbons, ", ")))
+	}
+	for _, note := range entry.Notes {
+		b.WriteString(i18n.Sprintf("Note: %s\n", note))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// entryJSON encodes the shareable parts of a caught Pokémon's entry as
+// indented JSON.
+func entryJSON(entry pokedex.Entry) (string, error) {
+	copied := copiedEntry{
+		Name:      entry.Name,
+		Level:     entry.CurrentLevel(),
+		Types:     pokemonTypes(entry.PokemonDataResp),
+		BaseStats: make(map[string]int, len(entry.Stats)),
+		Moves:     entry.Moveset,
+		CaughtOn:  entry.CaughtOn,
+		CaughtAt:  entry.CaughtAt,
+		Ribbons:   entry.Ribbons,
+		Notes:     entry.Notes,
+	}
+	for _, stat := range entry.Stats {
+		copied.BaseStats[stat.Stat.Name] = stat.BaseStat
+	}
+	data, err := json.MarshalIndent(copied, "", "  ")
+	return string(data), err
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// maxCounterSuggestions is the number of counters listed by the counter command.
+const maxCounterSuggestions = 5
+
+// commandCounter ranks the Pokémon in the user's Pokédex by how well they would
+// fare against a target Pokémon. Each candidate is rated on how hard its STAB
+// moves hit the target, how well it resists the target's STAB moves, and its
+// base stat total relative to the target's. The best counters are listed with
+// the reasons for their ranking.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the target Pokémon (caught or not)
+//
+// Returns:
+//   - An error if no Pokémon name is provided, the name is invalid,
+//     or there's an issue with the API requests
+func commandCounter(cfg *config, params []string) error {
+	// Check if Pokemon name parameter was provided
+	pokemonParam, err := ValidatePokemonParam(params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "counter", err) {
+			return err
+		}
+		return nil
+	}
+
+	nameInfo := FormatPokemonInput(pokemonParam)
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "counter", err) {
+			return err
+		}
+		return nil
+	}
+
+	// Take a snapshot of the Pokédex so the API requests below don't hold the lock
+	candidates := cfg.pokedex.All()
+
+	if len(candidates) == 0 {
+		i18n.Println("You have not caught any Pokémon yet, so there's nothing to counter with.")
+		printSeparator()
+		return nil
+	}
+
+	target, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "counter", err) {
+			return err
+		}
+		return nil
+	}
+	recordSeen(cfg, "counter", "", nameInfo.APIFormat)
+
+	// Load the type chart for every type involved in the matchups
+	typeNames := pokemonTypes(target)
+	for _, entry := range candidates {
+		typeNames = append(typeNames, pokemonTypes(entry.PokemonDataResp)...)
+	}
+	chart, err := loadTypeChart(cfg, typeNames)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "counter", err) {
+			return err
+		}
+		return nil
+	}
+
+	// Rate every candidate and rank them, best first
+	type rankedCounter struct {
+		name    string
+		types   []string
+		matchup matchup
+	}
+	ranked := make([]rankedCounter, 0, len(candidates))
+	for name, entry := range candidates {
+		ranked = append(ranked, rankedCounter{
+			name:    name,
+			types:   pokemonTypes(entry.PokemonDataResp),
+			matchup: evaluateMatchup(chart, entry.PokemonDataResp, target),
+		})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].matchup.score != ranked[j].matchup.score {
+			return ranked[i].matchup.score > ranked[j].matchup.score
+		}
+		return ranked[i].name < ranked[j].name
+	})
+
+	i18n.Printf("Best counters to %s (%s):\n", nameInfo.Formatted, FormatTypeList(pokemonTypes(target)))
+	for i, counter := range ranked[:min(len(ranked), maxCounterSuggestions)] {
+		i18n.Printf("%d. %s (%s) - score %.1f\n", i+1, FormatPokemonName(counter.name),
+			FormatTypeList(counter.types), counter.matchup.score)
+		if reasons := counter.matchup.reasons(); len(reasons) > 0 {
+			i18n.Printf("   %s\n", strings.Join(reasons, ", "))
+		}
+	}
+	printSeparator()
+	return nil
+}
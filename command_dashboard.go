@@ -0,0 +1,130 @@
+// This file implements the dashboard command, which serves a read-only web
+// page showing the user's collection in the background while the app runs.
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// dashboardUsage describes the parameters of the dashboard command.
+const dashboardUsage = "Usage: dashboard [--port <n>], or dashboard stop"
+
+// defaultDashboardPort is the port the dashboard is served on unless another is chosen.
+const defaultDashboardPort = 8025
+
+// dashboardShutdownTimeout is how long the dashboard is given to finish the
+// requests it's serving when it's stopped.
+const dashboardShutdownTimeout = 5 * time.Second
+
+// commandDashboard starts or stops the web dashboard. The dashboard is only
+// served on this computer (127.0.0.1), and stops when the app exits.
+// Supported forms:
+//   - dashboard: Serve the dashboard on the default port
+//   - dashboard --port 9000: Serve it on another port
+//   - dashboard stop: Stop serving it
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters: nothing, --port and a port number, or "stop"
+//
+// Returns:
+//   - An error if the parameters are invalid or the port can't be used
+func commandDashboard(cfg *config, params []string) error {
+	var err error
+	if len(params) == 1 && strings.ToLower(params[0]) == "stop" {
+		err = stopDashboard(cfg)
+	} else {
+		var port int
+		if port, err = parsePortParams(params, defaultDashboardPort, dashboardUsage); err == nil {
+			err = startDashboard(cfg, port)
+		}
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "dashboard", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// parsePortParams parses the port option of the dashboard and serve commands.
+//
+// Parameters:
+//   - params: The command parameters: nothing, or --port and a port number
+//   - defaultPort: The port to use if none is given
+//   - usage: The command's usage, shown if the parameters are invalid
+//
+// Returns:
+//   - The port
+//   - An error if an option is unknown or the port isn't a valid port number
+func parsePortParams(params []string, defaultPort int, usage string) (int, error) {
+	switch {
+	case len(params) == 0:
+		return defaultPort, nil
+	case len(params) != 2 || strings.ToLower(params[0]) != "--port":
+		return 0, errorhandling.NewInvalidInputError(usage, nil)
+	}
+	port, err := strconv.Atoi(params[1])
+	if err != nil || port < 1 || port > 65535 {
+		return 0, errorhandling.NewInvalidInputError("The port must be a number from 1 to 65535", err)
+	}
+	return port, nil
+}
+
+// startDashboard serves the dashboard in the background, unless it's already running.
+func startDashboard(cfg *config, port int) error {
+	if cfg.dashboard != nil {
+		i18n.Printf("The dashboard is already running at http://%s/\n", cfg.dashboard.Addr)
+		return nil
+	}
+	if cfg.batch != nil {
+		return errorhandling.NewInvalidInputError("The dashboard stops when the app exits, so it can't be started in batch mode", nil)
+	}
+
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Could not serve the dashboard on port %d", port), err)
+	}
+	server := &http.Server{Addr: addr, Handler: newDashboardHandler(cfg), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) && cfg.Settings().debugMode {
+			notify(cfg, "The dashboard stopped: %v\n", err)
+		}
+	}()
+	cfg.dashboard = server
+
+	i18n.Printf("The dashboard is running at http://%s/\n", addr)
+	i18n.Println("It shows your Pokédex as it changes while the app is open. Use 'dashboard stop' to stop it.")
+	return nil
+}
+
+// stopDashboard stops serving the dashboard, if it's running.
+func stopDashboard(cfg *config) error {
+	if cfg.dashboard == nil {
+		i18n.Println("The dashboard isn't running.")
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dashboardShutdownTimeout)
+	defer cancel()
+	err := cfg.dashboard.Shutdown(ctx)
+	cfg.dashboard = nil
+	if err != nil {
+		return errorhandling.NewInternalError("Could not stop the dashboard cleanly", err)
+	}
+	i18n.Println("Stopped the dashboard.")
+	return nil
+}
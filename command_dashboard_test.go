@@ -0,0 +1,86 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestParsePortParams tests the port option of the dashboard and serve commands
+func TestParsePortParams(t *testing.T) {
+	cases := []struct {
+		params []string
+		want   int
+		ok     bool
+	}{
+		{nil, defaultDashboardPort, true},
+		{[]string{"--port", "9000"}, 9000, true},
+		{[]string{"--port", "0"}, 0, false},
+		{[]string{"--port", "http"}, 0, false},
+		{[]string{"--port"}, 0, false},
+		{[]string{"9000"}, 0, false},
+	}
+	for _, c := range cases {
+		got, err := parsePortParams(c.params, defaultDashboardPort, dashboardUsage)
+		if (err == nil) != c.ok || got != c.want {
+			t.Errorf("parsePortParams(%q) = %d, %v; expected %d (ok %v)", c.params, got, err, c.want, c.ok)
+		}
+	}
+}
+
+// TestBuildDashboardPage tests the collection and completion the dashboard shows
+func TestBuildDashboardPage(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.AddBox("team")
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric")))
+	squirtle := pokedex.NewEntry(testMatchupPokemon(t, "squirtle", 314, "water"))
+	squirtle.Box = "team"
+	squirtle.Sprites.FrontDefault = "https://example.com/squirtle.png"
+	cfg.pokedex.Add("squirtle", squirtle)
+	cfg.pokedex.MarkSeen("charmander", pokedex.Sighting{})
+
+	page := buildDashboardPage(cfg)
+	if len(page.Pokemon) != 2 || page.Pokemon[0].Key != "pikachu" || page.Pokemon[1].Place != "team" {
+		t.Fatalf("Unexpected collection: %+v", page.Pokemon)
+	}
+	if !strings.HasSuffix(page.Pokemon[0].Sprite, "/25.png") || page.Pokemon[1].Sprite != squirtle.Sprites.FrontDefault {
+		t.Errorf("Expected sprites from the dataset and the entry, got %q and %q", page.Pokemon[0].Sprite, page.Pokemon[1].Sprite)
+	}
+	total := len(cfg.Dataset().Species)
+	if caught, seen := page.Completion[0], page.Completion[1]; caught.Count != 2 || seen.Count != 3 || caught.Total != total {
+		t.Errorf("Expected 2 caught and 3 seen out of %d, got %+v", total, page.Completion)
+	}
+	if len(page.Types) != 2 || page.Types[0].Value != "water" || page.Types[1].Value != "electric" {
+		t.Errorf("Expected the type filter to offer Water and Electric, got %+v", page.Types)
+	}
+}
+
+// TestDashboardHandler tests that the dashboard serves its page and assets,
+// and refuses requests that would change anything
+func TestDashboardHandler(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric")))
+	handler := newDashboardHandler(cfg)
+
+	cases := []struct {
+		method, path string
+		status       int
+		contains     string
+	}{
+		{http.MethodGet, "/", http.StatusOK, "Pikachu"},
+		{http.MethodGet, "/assets/style.css", http.StatusOK, ".card"},
+		{http.MethodGet, "/assets/app.js", http.StatusOK, "applyFilters"},
+		{http.MethodGet, "/missing", http.StatusNotFound, ""},
+		{http.MethodPost, "/", http.StatusMethodNotAllowed, ""},
+	}
+	for _, c := range cases {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(c.method, c.path, nil))
+		if recorder.Code != c.status || !strings.Contains(recorder.Body.String(), c.contains) {
+			t.Errorf("%s %s: got status %d, expected %d containing %q", c.method, c.path, recorder.Code, c.status, c.contains)
+		}
+	}
+}
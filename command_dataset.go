@@ -0,0 +1,193 @@
+// This file implements the dataset command, which shows which species dataset is
+// in use and downloads a complete one from the PokeAPI. Once downloaded, names
+// are validated, suggested, and completed without the network.
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/bmlevitt/pokedexcli/internal/dataset"
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// datasetUsage describes the forms of the dataset command.
+const datasetUsage = "Usage: dataset or dataset update"
+
+// datasetWorkers is the number of Pokémon downloaded at the same time by 'dataset update'.
+const datasetWorkers = 8
+
+// commandDataset shows or updates the species dataset. The command supports
+// two forms:
+//   - dataset: Show where the dataset comes from and how many species it holds
+//   - dataset update: Download every Pokémon from the PokeAPI and save the dataset locally
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters where params[0] is the optional subcommand
+//
+// Returns:
+//   - An error if the parameters are invalid or the dataset can't be downloaded or saved
+func commandDataset(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		printDatasetInfo(cfg.Dataset())
+	case len(params) == 1 && params[0] == "update":
+		err = updateDataset(cfg)
+	default:
+		err = errorhandling.NewInvalidInputError(datasetUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "dataset", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// printDatasetInfo describes the dataset in use.
+func printDatasetInfo(d *dataset.Dataset) {
+	if d.Updated.IsZero() {
+		i18n.Printf("Using the built-in dataset of %d species.\n", d.Len())
+	} else {
+		i18n.Printf("Using a dataset of %d species downloaded %s.\n", d.Len(), d.Updated.Local().Format("2006-01-02 15:04"))
+	}
+	if !d.Complete {
+		i18n.Println("It doesn't cover every Pokémon, so other names are checked with the PokeAPI.")
+		i18n.Println("Run 'dataset update' to download the complete dataset.")
+	}
+	printSeparator()
+}
+
+// updateDataset downloads every Pokémon from the PokeAPI, saves the dataset
+// next to the save file, and starts using it. Nothing is saved if any
+// Pokémon can't be downloaded, or if the download is cancelled with Ctrl+C.
+func updateDataset(cfg *config) error {
+	ctx := commandContext(cfg)
+	client := cfg.pokeapiClient.WithContext(ctx)
+	listResp, err := client.ListAllPokemon()
+	if err != nil {
+		return err
+	}
+	i18n.Printf("Downloading data for %d Pokémon. This may take a few minutes...\n", len(listResp.Results))
+	i18n.Println("Press Ctrl+C to cancel.")
+
+	// The Pokémon's data isn't needed again, so it isn't cached, keeping memory flat
+	species, err := fetchDatasetSpecies(ctx, client.WithoutCaching(), listResp.Results)
+	if err != nil {
+		return err
+	}
+
+	path, err := getDatasetPath()
+	if err != nil {
+		return fmt.Errorf("error determining dataset path: %w", err)
+	}
+	d := dataset.New(species, true)
+	if err := dataset.Write(path, d); err != nil {
+		return err
+	}
+	cfg.setDataset(d)
+
+	i18n.Printf("Saved a dataset of %d species to %s\n", d.Len(), path)
+	printSeparator()
+	return nil
+}
+
+// fetchDatasetSpecies downloads the data for a list of Pokémon, several at a
+// time. No more downloads are started once ctx is cancelled.
+//
+// Parameters:
+//   - ctx: Cancels the download
+//   - client: The API client to download with
+//   - pokemon: The Pokémon to download, as listed by the API
+//
+// Returns:
+//   - The species data, in the order of the list
+//   - The first error encountered, if any Pokémon couldn't be downloaded, or
+//     ctx's error if the download was cancelled
+func fetchDatasetSpecies(ctx context.Context, client *pokeapi.Client, pokemon []pokeapi.NamedAPIResource) ([]dataset.Species, error) {
+	species := make([]dataset.Species, len(pokemon))
+	errs := make([]error, len(pokemon))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range datasetWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				species[i], errs[i] = fetchSpecies(client, pokemon[i])
+			}
+		}()
+	}
+queue:
+	for i := range pokemon {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return species, nil
+}
+
+// fetchSpecies downloads one Pokémon and converts it into dataset form.
+func fetchSpecies(client *pokeapi.Client, pokemon pokeapi.NamedAPIResource) (dataset.Species, error) {
+	id, err := pokemon.ID()
+	if err != nil {
+		return dataset.Species{}, err
+	}
+	data, err := client.GetPokemonData(pokemon.Name)
+	if err != nil {
+		return dataset.Species{}, err
+	}
+	return speciesFromPokemon(id, data), nil
+}
+
+// speciesFromPokemon converts the API data for a Pokémon into dataset form.
+//
+// Parameters:
+//   - id: The Pokémon's ID
+//   - data: The Pokémon's data from the API
+//
+// Returns:
+//   - The Pokémon's name, ID, types in slot order, and base stats
+func speciesFromPokemon(id int, data pokeapi.PokemonDataResp) dataset.Species {
+	species := dataset.Species{ID: id, Name: data.Name, Types: pokemonTypes(data)}
+
+	for _, stat := range data.Stats {
+		switch stat.Stat.Name {
+		case "hp":
+			species.Stats.HP = stat.BaseStat
+		case "attack":
+			species.Stats.Attack = stat.BaseStat
+		case "defense":
+			species.Stats.Defense = stat.BaseStat
+		case "special-attack":
+			species.Stats.SpecialAttack = stat.BaseStat
+		case "special-defense":
+			species.Stats.SpecialDefense = stat.BaseStat
+		case "speed":
+			species.Stats.Speed = stat.BaseStat
+		}
+	}
+	return species
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// daycareUsage describes the subcommands of the daycare command.
+const daycareUsage = "Usage: daycare, daycare deposit <pokemon>, or daycare withdraw <pokemon>"
+
+// maxDaycareSlots is the number of Pokémon the day care can look after at once.
+const maxDaycareSlots = 2
+
+// daycareLevelInterval is how long a Pokémon has to stay at the day care to gain a level.
+const daycareLevelInterval = 10 * time.Minute
+
+// commandDaycare implements the "daycare" command, where Pokémon can be left to
+// gain levels while the user is away. Pokémon at the day care gain a level for
+// every daycareLevelInterval that passes, even while the app isn't running.
+// Supported forms:
+//   - daycare: List the Pokémon at the day care and the levels they have gained
+//   - daycare deposit <pokemon>: Leave a Pokémon at the day care
+//   - daycare withdraw <pokemon>: Pick a Pokémon up, applying the levels it gained
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters where params[0] is the optional subcommand
+//
+// Returns:
+//   - An error if the subcommand or its parameters are invalid
+func commandDaycare(cfg *config, params []string) error {
+	var err error
+	if len(params) == 0 {
+		listDaycare(cfg, time.Now())
+	} else {
+		switch params[0] {
+		case "deposit":
+			err = depositInDaycare(cfg, params[1:])
+		case "withdraw":
+			err = withdrawFromDaycare(cfg, params[1:])
+		default:
+			err = errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Unknown daycare command '%s'. %s", params[0], i18n.T(daycareUsage)), nil)
+		}
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "daycare", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// daycareLevelsGained works out how many levels a Pokémon has gained at the day care.
+//
+// Parameters:
+//   - entry: The Pokémon's Pokédex entry
+//   - now: The current time
+//
+// Returns:
+//   - The levels gained, never taking the Pokémon past pokedex.MaxLevel
+func daycareLevelsGained(entry pokedex.Entry, now time.Time) int {
+	if !entry.InDaycare() {
+		return 0
+	}
+	gained := int(now.Sub(entry.DaycareSince) / daycareLevelInterval)
+	return max(0, min(gained, pokedex.MaxLevel-entry.CurrentLevel()))
+}
+
+// daycareResidents returns the Pokémon at the day care, ordered by name.
+func daycareResidents(cfg *config) []pokedex.NamedEntry {
+	var residents []pokedex.NamedEntry
+	for _, named := range cfg.pokedex.List() {
+		if named.Entry.InDaycare() {
+			residents = append(residents, named)
+		}
+	}
+	return residents
+}
+
+// listDaycare shows the Pokémon at the day care and how they're doing.
+func listDaycare(cfg *config, now time.Time) {
+	residents := daycareResidents(cfg)
+	if len(residents) == 0 {
+		i18n.Printf("The day care is empty. It can look after %d Pokémon; use 'daycare deposit <pokemon>' to leave one.\n", maxDaycareSlots)
+		printSeparator()
+		return
+	}
+
+	i18n.Printf("Pokémon at the day care (%d of %d):\n", len(residents), maxDaycareSlots)
+	table := NewTable("Pokémon", "Level", "Left on", "Levels gained")
+	for _, resident := range residents {
+		entry := resident.Entry
+		table.AddRow(FormatPokemonName(resident.Name), fmt.Sprint(entry.CurrentLevel()),
+			entry.DaycareSince.Local().Format("2006-01-02 15:04"), fmt.Sprint(daycareLevelsGained(entry, now)))
+	}
+	table.Print()
+	printSeparator()
+}
+
+// depositInDaycare leaves a Pokémon at the day care, if there is room.
+func depositInDaycare(cfg *config, params []string) error {
+	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, daycarePokemonParam(params))
+	if err != nil {
+		return err
+	}
+	if len(daycareResidents(cfg)) >= maxDaycareSlots {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The day care can only look after %d Pokémon at a time. Withdraw one first", maxDaycareSlots), nil)
+	}
+
+	err = cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		if entry.InDaycare() {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s is already at the day care", nameInfo.Formatted), nil)
+		}
+		if entry.CurrentLevel() >= pokedex.MaxLevel {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s is already level %d and can't gain any more levels", nameInfo.Formatted, pokedex.MaxLevel), nil)
+		}
+		entry.DaycareSince = time.Now()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	i18n.Printf("%s was left at the day care. It will gain a level every %d minutes.\n",
+		nameInfo.Formatted, int(daycareLevelInterval.Minutes()))
+	saveDaycareChange(cfg)
+	return nil
+}
+
+// withdrawFromDaycare picks a Pokémon up from the day care and applies the levels it gained.
+func withdrawFromDaycare(cfg *config, params []string) error {
+	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, daycarePokemonParam(params))
+	if err != nil {
+		return err
+	}
+
+	// The Pokémon goes to the storage box if there's no room for it in the party
+	hasRoom := partyHasRoom(cfg, "")
+	if !hasRoom {
+		cfg.pokedex.AddBox(storageBox)
+	}
+
+	var gained, level int
+	var sentToStorage bool
+	err = cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		if !entry.InDaycare() {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s isn't at the day care", nameInfo.Formatted), nil)
+		}
+		gained = daycareLevelsGained(*entry, time.Now())
+		entry.Level = entry.CurrentLevel() + gained
+		entry.DaycareSince = time.Time{}
+		if entry.Box == "" && !hasRoom {
+			entry.Box = storageBox
+			sentToStorage = true
+		}
+		level = entry.Level
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	switch gained {
+	case 0:
+		i18n.Printf("%s is back from the day care, still at level %d.\n", nameInfo.Formatted, level)
+	case 1:
+		i18n.Printf("%s is back from the day care and grew 1 level to level %d!\n", nameInfo.Formatted, level)
+	default:
+		i18n.Printf("%s is back from the day care and grew %d levels to level %d!\n", nameInfo.Formatted, gained, level)
+	}
+	if sentToStorage {
+		i18n.Printf("Your party is full, so %s was sent to box '%s'.\n", nameInfo.Formatted, storageBox)
+	}
+	saveDaycareChange(cfg)
+	return nil
+}
+
+// daycarePokemonParam joins the words after a daycare subcommand into one
+// Pokémon name, so that names with spaces (e.g. "mr mime") work.
+func daycarePokemonParam(params []string) []string {
+	if len(params) == 0 {
+		return nil
+	}
+	return []string{strings.Join(params, " ")}
+}
+
+// saveDaycareChange auto-saves after a Pokémon is deposited or withdrawn.
+func saveDaycareChange(cfg *config) {
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the change was still made
+		HandleCommandError(cfg, "daycare", err)
+	}
+	printSeparator()
+}
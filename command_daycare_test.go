@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestDaycareLevelsGained tests that levels are gained over time up to the maximum level
+func TestDaycareLevelsGained(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		entry    pokedex.Entry
+		expected int
+	}{
+		{"not at the day care", pokedex.Entry{}, 0},
+		{"just deposited", pokedex.Entry{DaycareSince: now.Add(-time.Minute)}, 0},
+		{"one interval", pokedex.Entry{DaycareSince: now.Add(-daycareLevelInterval)}, 1},
+		{"several intervals", pokedex.Entry{DaycareSince: now.Add(-7*daycareLevelInterval - time.Minute)}, 7},
+		{"capped at the maximum level", pokedex.Entry{Level: 98, DaycareSince: now.Add(-24 * time.Hour)}, 2},
+		{"deposited in the future", pokedex.Entry{DaycareSince: now.Add(time.Hour)}, 0},
+	}
+	for _, c := range cases {
+		if got := daycareLevelsGained(c.entry, now); got != c.expected {
+			t.Errorf("%s: expected %d levels, got %d", c.name, c.expected, got)
+		}
+	}
+}
@@ -1,10 +1,12 @@
 package main
 
-import "fmt"
+import (
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
 
 // commandToggleDebug toggles the debug mode setting in the application.
-// When debug mode is enabled, detailed error information is logged to stderr,
-// which can be helpful for troubleshooting issues.
+// When debug mode is enabled, detailed error information and command timings
+// are logged to stderr, which can be helpful for troubleshooting issues.
 //
 // Parameters:
 //   - cfg: The application configuration containing the debug mode setting
@@ -15,18 +17,22 @@ import "fmt"
 //
 // Side Effects:
 //   - Modifies the debugMode flag in the application configuration
+//   - Enables or disables log output to stderr
 //   - Prints the current debug mode status to stdout
 func commandToggleDebug(cfg *config, params []string) error {
-	// Toggle the debug mode setting
-	cfg.debugMode = !cfg.debugMode
+	// Toggle the debug mode setting and route log output to match
+	updated := cfg.UpdateSettings(func(s *settings) {
+		s.debugMode = !s.debugMode
+	})
+	configureDebugLogging(updated.debugMode)
 
 	// Display the new debug mode status
-	if cfg.debugMode {
-		fmt.Println("Debug mode is now enabled. Detailed error information will be logged.")
+	if updated.debugMode {
+		i18n.Println("Debug mode is now enabled. Detailed error information and command timings will be logged.")
 	} else {
-		fmt.Println("Debug mode is now disabled. Only user-friendly error messages will be shown.")
+		i18n.Println("Debug mode is now disabled. Only user-friendly error messages will be shown.")
 	}
-	fmt.Println("-----")
+	printSeparator()
 
 	return nil
 }
@@ -1,99 +1,463 @@
 package main
 
 import (
-	"fmt"
+	"io"
 	"math/rand"
+	"slices"
+	"sort"
 	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
 )
 
-// commandDescribe displays detailed Pokédex information about a Pokémon.
+// describeOptions holds the parsed parameters of the describe command.
+type describeOptions struct {
+	name         string // The Pokémon name as typed by the user
+	version      string // The game version to show the entry from, in API format ("" for random)
+	listVersions bool   // Whether to list the versions with entries instead of showing one
+	all          bool   // Whether to show every distinct entry, grouped by generation
+}
+
+// description is the data behind the describe command's result.
+type description struct {
+	Pokemon         string          `json:"pokemon"`                    // The Pokémon's API name
+	Genus           string          `json:"genus,omitempty"`            // Its genus in English (e.g. "Mouse Pokémon")
+	Entries         []describedText `json:"entries,omitempty"`          // The Pokédex entries shown
+	FormDescription string          `json:"form_description,omitempty"` // The description of its forms, shown when it has no entries
+	Biology         *speciesBiology `json:"biology,omitempty"`          // Where and how the species lives, if its species data is available
+	Catching        *catchInfo      `json:"catching,omitempty"`         // How hard it is to catch, if its species data is available
+	Notes           []string        `json:"notes,omitempty"`            // The user's notes
+}
+
+// describedText is a Pokédex entry in the describe command's result.
+type describedText struct {
+	Text       string   `json:"text"`                 // The cleaned-up flavor text
+	Versions   []string `json:"versions"`             // The API names of the games that use it
+	Generation int      `json:"generation,omitempty"` // The generation it first appeared in, with --all (0 for unknown games)
+}
+
+// describedVersions is the data behind 'describe <pokemon> --versions'.
+type describedVersions struct {
+	Pokemon  string   `json:"pokemon"`  // The Pokémon's API name
+	Versions []string `json:"versions"` // The API names of the games with an entry, oldest first
+}
+
+// describeResult describes a Pokémon from the user's Pokédex.
 // This command shows flavor text entries (Pokédex descriptions) for a Pokémon,
-// including its genus (e.g., "Mouse Pokémon") and a randomly selected
-// description from the games.
+// including its genus (e.g., "Mouse Pokémon") and a description from the games,
+// followed by the species' biology, how hard it is to catch, and any notes the
+// user has added.
+//
+// By default a random description is shown. With --version <game>, the
+// description from that game is shown instead, and --versions (or --version
+// without a game) lists the games that have a description. With --all, every
+// distinct description is shown, grouped by the generation it first appeared in.
+//
+// Species without a genus or descriptions, such as some special forms and
+// newer Pokémon, are described with what's available; if the API has no
+// species data at all, only the Pokédex's own details are shown.
 //
 // The command can only be used with Pokémon that are currently in the user's Pokédex.
 //
 // Parameters:
 //   - cfg: The application configuration containing the Pokédex and API client
-//   - params: Command parameters where params[0] is the Pokémon name to describe
+//   - params: Command parameters forming the Pokémon name, optionally followed by
+//     --version <game>, --versions, or --all (e.g. "pikachu --version red")
 //
 // Returns:
+//   - The description, or the games with one if --versions is given
 //   - An error if no Pokémon name is provided, if the Pokémon is not in the Pokédex,
-//     or if there's an issue with the API request
-func commandDescribe(cfg *config, params []string) error {
+//     if the requested version has no description, or if there's an issue with the API request
+func describeResult(cfg *config, params []string) (commandResult, error) {
+	opts, err := parseDescribeParams(params)
+	if err != nil {
+		return commandResult{}, err
+	}
+
 	// Use the utility function to validate the Pokemon parameter and check if it exists
-	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, params)
+	apiName, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, []string{opts.name})
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "describe", err) {
-			return err
-		}
-		return nil
+		return commandResult{}, err
 	}
 
-	// Fetch species data for the pokemon
-	speciesData, err := cfg.pokeapiClient.GetPokemonSpecies(apiName)
+	entry, err := GetTypedPokemonData(pokemonData, nameInfo.Formatted)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "describe", err) {
-			return err
+		return commandResult{}, err
+	}
+
+	// Fetch species data for the pokemon, which forms share with their species.
+	// Without it, the parts of the description that come from it are left out.
+	speciesData, err := cfg.pokeapiClient.GetPokemonSpecies(speciesName(apiName, entry.PokemonDataResp))
+	if err != nil && !isMissingData(err) {
+		return commandResult{}, err
+	}
+	hasSpecies := err == nil
+
+	// Find English flavor text entries
+	var englishEntries []pokeapi.FlavorTextEntry
+	for _, entry := range speciesData.FlavorTextEntries {
+		if entry.Language.Name == "en" {
+			englishEntries = append(englishEntries, entry)
 		}
-		return nil
 	}
 
+	if opts.listVersions {
+		return flavorTextVersionsResult(apiName, nameInfo.Formatted, englishEntries), nil
+	}
+
+	// Find the entry from the requested game before describing anything
+	var selectedEntry pokeapi.FlavorTextEntry
+	if opts.version != "" && len(englishEntries) > 0 {
+		found := false
+		for _, e := range englishEntries {
+			if e.Version.Name == opts.version {
+				selectedEntry, found = e, true
+				break
+			}
+		}
+		if !found {
+			return commandResult{}, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("No Pokédex entry for %s in Pokémon %s. Available versions: %s",
+					nameInfo.Formatted, FormatLocationName(opts.version),
+					strings.Join(formatVersionNames(flavorTextVersions(englishEntries)), ", ")), nil)
+		}
+	}
+
+	described := description{Pokemon: apiName, Notes: entry.Notes}
+
 	// Find the English genus
-	var genus string
 	for _, genusEntry := range speciesData.Genera {
 		if genusEntry.Language.Name == "en" {
-			genus = genusEntry.Genus
+			described.Genus = genusEntry.Genus
 			break
 		}
 	}
 
-	// Find English flavor text entries
-	var englishEntries []int
-	for i, entry := range speciesData.FlavorTextEntries {
-		if entry.Language.Name == "en" {
-			englishEntries = append(englishEntries, i)
-		}
+	// Describe the Pokémon name and genus
+	var message strings.Builder
+	if described.Genus != "" {
+		message.WriteString(i18n.Sprintf("%s, the %s\n", nameInfo.Formatted, described.Genus))
+	} else {
+		message.WriteString(i18n.Sprintf("%s\n", nameInfo.Formatted))
 	}
 
-	// Display the information
-	if len(englishEntries) > 0 {
-		// Select a random entry index
-		randomIndex := englishEntries[rand.Intn(len(englishEntries))]
-		selectedEntry := speciesData.FlavorTextEntries[randomIndex]
-
-		// Clean up the flavor text (remove newlines and extra spaces)
-		flavorText := strings.ReplaceAll(selectedEntry.FlavorText, "\n", " ")
-		flavorText = strings.ReplaceAll(flavorText, "\f", " ")
-		flavorText = strings.Join(strings.Fields(flavorText), " ")
-
-		// Display the Pokémon name and genus
-		if genus != "" {
-			fmt.Printf("%s, the %s\n", nameInfo.Formatted, genus)
+	// Describe the information
+	switch {
+	case !hasSpecies:
+		message.WriteString(i18n.Sprintf("No species data is available for %s, so only what your Pokédex holds is shown.\n", nameInfo.Formatted))
+	case len(englishEntries) == 0:
+		// Special forms often have a description of the form instead
+		if described.FormDescription = formDescription(speciesData); described.FormDescription != "" {
+			message.WriteString(i18n.Sprintf("- %s\n", described.FormDescription))
 		} else {
-			fmt.Printf("%s\n", nameInfo.Formatted)
+			message.WriteString(i18n.Sprintf("No Pokédex entries found for %s\n", nameInfo.Formatted))
 		}
+	case opts.all:
+		groups := groupFlavorTexts(englishEntries)
+		for _, group := range groups {
+			for _, t := range group.texts {
+				described.Entries = append(described.Entries, describedText{Text: t.text, Versions: t.versions, Generation: group.generation})
+			}
+		}
+		writeFlavorTextGroups(&message, groups)
+	default:
+		if opts.version == "" {
+			// Select a random entry, or the latest one in accessible mode so the output is always the same
+			if cfg.Settings().accessible {
+				selectedEntry = englishEntries[len(englishEntries)-1]
+			} else {
+				selectedEntry = englishEntries[rand.Intn(len(englishEntries))]
+			}
+		}
+		text := cleanFlavorText(selectedEntry.FlavorText)
+		described.Entries = []describedText{{Text: text, Versions: []string{selectedEntry.Version.Name}}}
 
-		// Display the flavor text
-		fmt.Printf("- %s", flavorText)
+		// Describe the flavor text
+		message.WriteString(i18n.Sprintf("- %s", text))
 
 		// Format the game name
 		formattedGameName := FormatLocationName(selectedEntry.Version.Name)
 
-		// Display the source game
+		// Describe the source game
 		if formattedGameName != "" {
-			fmt.Printf(" (From Pokémon %s)\n", formattedGameName)
+			message.WriteString(i18n.Sprintf(" (From Pokémon %s)\n", formattedGameName))
 		} else {
-			fmt.Println()
+			message.WriteString("\n")
 		}
+	}
 
-		fmt.Println("-----")
+	// Describe where and how the species lives, and how hard it is to catch
+	if hasSpecies {
+		biology := newSpeciesBiology(speciesData)
+		catching := newCatchInfo(speciesData, entry.BaseExperience)
+		described.Biology, described.Catching = &biology, &catching
+		writeBiology(&message, biology)
+		writeCatchInfo(&message, catching)
+	}
+
+	// Describe the user's notes
+	if len(entry.Notes) > 0 {
+		i18n.Fprintln(&message, "Your notes:")
+		writeNotes(&message, entry.Notes)
+	}
+
+	return commandResult{Message: message.String(), Data: described}, nil
+}
+
+// parseDescribeParams separates the Pokémon name from the describe command's flags.
+// The REPL joins the parameters into one name, so they are split back into words;
+// everything before the first flag is the Pokémon name.
+//
+// Parameters:
+//   - params: The command parameters
+//
+// Returns:
+//   - The parsed options
+//   - An error if no Pokémon name is given or a flag is not recognized
+func parseDescribeParams(params []string) (describeOptions, error) {
+	var opts describeOptions
+	words := strings.Fields(strings.Join(params, " "))
+
+	flagStart := len(words)
+	for i, word := range words {
+		if strings.HasPrefix(word, "--") {
+			flagStart = i
+			break
+		}
+	}
+	opts.name = strings.Join(words[:flagStart], " ")
+	if opts.name == "" {
+		return opts, ErrNoPokemonName
+	}
+	if flagStart == len(words) {
+		return opts, nil
+	}
+
+	flag, value, _ := strings.Cut(words[flagStart], "=")
+	rest := words[flagStart+1:]
+	if value != "" {
+		rest = append([]string{value}, rest...)
+	}
+
+	switch flag {
+	case "--all":
+		opts.all = true
+	case "--version":
+		// Game names can have several words (e.g. "alpha sapphire")
+		opts.version = ConvertToAPIFormat(strings.Join(rest, " "))
+		opts.listVersions = opts.version == ""
+	case "--versions":
+		opts.listVersions = true
+	default:
+		return opts, describeUsageError(i18n.Sprintf("Unknown option '%s'", flag))
+	}
+	if (opts.all || flag == "--versions") && len(rest) > 0 {
+		return opts, describeUsageError(i18n.Sprintf("Option '%s' doesn't take a value", flag))
+	}
+	return opts, nil
+}
+
+// describeUsageError returns an invalid input error explaining the describe command's usage.
+func describeUsageError(problem string) error {
+	return errorhandling.NewInvalidInputError(
+		i18n.Sprintf("%s. Usage: describe <pokemon> [--version <game> | --versions | --all]", problem), nil)
+}
+
+// flavorTextVersionsResult lists the games that have a Pokédex entry for a Pokémon.
+//
+// Parameters:
+//   - apiName: The Pokémon's API name
+//   - pokemonName: The formatted name of the Pokémon
+//   - entries: The Pokémon's flavor text entries
+//
+// Returns:
+//   - The games with an entry
+func flavorTextVersionsResult(apiName, pokemonName string, entries []pokeapi.FlavorTextEntry) commandResult {
+	versions := flavorTextVersions(entries)
+	var message strings.Builder
+	if len(versions) == 0 {
+		message.WriteString(i18n.Sprintf("No Pokédex entries found for %s\n", pokemonName))
 	} else {
-		fmt.Printf("No Pokédex entries found for %s\n", nameInfo.Formatted)
-		fmt.Println("-----")
+		message.WriteString(i18n.Sprintf("Pokédex entries for %s are available from %d versions:\n", pokemonName, len(versions)))
+		message.WriteString(strings.Join(formatVersionNames(versions), ", ") + "\n")
+		message.WriteString(i18n.Sprintf("Use 'describe %s --version <game>' to read one.\n", apiName))
+	}
+	return commandResult{Message: message.String(), Data: describedVersions{Pokemon: apiName, Versions: versions}}
+}
+
+// formDescription returns the English description of a species' forms, or ""
+// if it has none.
+func formDescription(species pokeapi.PokemonSpeciesResp) string {
+	for _, description := range species.FormDescriptions {
+		if description.Language.Name == "en" {
+			return cleanFlavorText(description.Description)
+		}
+	}
+	return ""
+}
+
+// flavorTextVersions returns the API names of the games with flavor text
+// entries, in the order the API lists them (oldest games first).
+func flavorTextVersions(entries []pokeapi.FlavorTextEntry) []string {
+	versions := []string{}
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if !seen[e.Version.Name] {
+			seen[e.Version.Name] = true
+			versions = append(versions, e.Version.Name)
+		}
+	}
+	return versions
+}
+
+// formatVersionNames formats the API names of games for display.
+func formatVersionNames(versions []string) []string {
+	formatted := make([]string, len(versions))
+	for i, version := range versions {
+		formatted[i] = FormatLocationName(version)
+	}
+	return formatted
+}
+
+// cleanFlavorText removes the line breaks and extra spaces that the games use
+// to lay out flavor text, returning it as a single line. Words split across
+// lines by a hyphen or soft hyphen are joined back together.
+func cleanFlavorText(text string) string {
+	text = strings.ReplaceAll(text, "\u00ad\n", "")
+	text = strings.ReplaceAll(text, "-\n", "-")
+	text = strings.ReplaceAll(text, "\n", " ")
+	text = strings.ReplaceAll(text, "\f", " ")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// flavorTextGroup holds the distinct flavor texts that first appeared in one generation.
+type flavorTextGroup struct {
+	generation int          // The generation number, or 0 for unknown games
+	texts      []flavorText // The distinct texts, in the order the API lists them
+}
+
+// flavorText is a distinct flavor text and the games that use it.
+type flavorText struct {
+	text     string   // The cleaned-up flavor text
+	versions []string // The API names of the games that use it
+}
+
+// groupFlavorTexts removes near-identical flavor texts and groups the rest by generation.
+// Texts are compared ignoring case, accents, punctuation, and spacing, since the
+// games often reprint an entry with only its capitalization or line breaks changed.
+// A reprinted text is listed once, under the generation it first appeared in,
+// with every game that uses it.
+//
+// Parameters:
+//   - entries: The flavor text entries, in one language
+//
+// Returns:
+//   - The groups ordered by generation, with unknown games last
+func groupFlavorTexts(entries []pokeapi.FlavorTextEntry) []flavorTextGroup {
+	type location struct{ group, text int }
+	var groups []flavorTextGroup
+	groupIndex := make(map[int]int)
+	seen := make(map[string]location)
+
+	for _, e := range entries {
+		text := cleanFlavorText(e.FlavorText)
+		version := e.Version.Name
+		key := strings.ReplaceAll(ConvertToAPIFormat(text), "-", "")
+
+		if loc, ok := seen[key]; ok {
+			existing := &groups[loc.group].texts[loc.text]
+			if !slices.Contains(existing.versions, version) {
+				existing.versions = append(existing.versions, version)
+			}
+			continue
+		}
+
+		generation := versionGeneration(e.Version.Name)
+		gi, ok := groupIndex[generation]
+		if !ok {
+			gi = len(groups)
+			groupIndex[generation] = gi
+			groups = append(groups, flavorTextGroup{generation: generation})
+		}
+		groups[gi].texts = append(groups[gi].texts, flavorText{text: text, versions: []string{version}})
+		seen[key] = location{group: gi, text: len(groups[gi].texts) - 1}
 	}
 
-	return nil
+	// Order by generation; the API mostly lists older games first, but not always
+	sort.SliceStable(groups, func(i, j int) bool {
+		gi, gj := groups[i].generation, groups[j].generation
+		if gi == 0 || gj == 0 {
+			return gj == 0 && gi != 0
+		}
+		return gi < gj
+	})
+	return groups
+}
+
+// writeFlavorTextGroups writes flavor texts grouped by generation with the games that use them.
+func writeFlavorTextGroups(w io.Writer, groups []flavorTextGroup) {
+	for _, group := range groups {
+		i18n.Fprintf(w, "%s:\n", generationDisplayName(group.generation))
+		for _, t := range group.texts {
+			i18n.Fprintf(w, "- %s (%s)\n", t.text, strings.Join(formatVersionNames(t.versions), ", "))
+		}
+	}
+}
+
+// speciesBiology is where and how a species lives. Details the API doesn't
+// provide for the species are empty.
+type speciesBiology struct {
+	Habitat       string `json:"habitat,omitempty"`        // The API name of its habitat
+	Color         string `json:"color,omitempty"`          // The API name of its color
+	Shape         string `json:"shape,omitempty"`          // The API name of its shape
+	GrowthRate    string `json:"growth_rate,omitempty"`    // The API name of its growth rate
+	BaseHappiness *int   `json:"base_happiness,omitempty"` // Its base happiness
+}
+
+// newSpeciesBiology returns a species' habitat, color, shape, growth rate, and
+// base happiness.
+func newSpeciesBiology(species pokeapi.PokemonSpeciesResp) speciesBiology {
+	biology := speciesBiology{
+		Color:         species.Color.Name,
+		GrowthRate:    species.GrowthRate.Name,
+		BaseHappiness: species.BaseHappiness,
+	}
+	if species.Habitat != nil {
+		biology.Habitat = species.Habitat.Name
+	}
+	if species.Shape != nil {
+		biology.Shape = species.Shape.Name
+	}
+	return biology
+}
+
+// writeBiology writes a species' biology. Details the API doesn't provide for
+// the species are left out, and nothing is written if it provides none.
+func writeBiology(w io.Writer, biology speciesBiology) {
+	var lines []string
+	if biology.Habitat != "" {
+		lines = append(lines, i18n.Sprintf("Habitat: %s", FormatLocationName(biology.Habitat)))
+	}
+	if biology.Color != "" {
+		lines = append(lines, i18n.Sprintf("Color: %s", CapitalizeFirstLetter(biology.Color)))
+	}
+	if biology.Shape != "" {
+		lines = append(lines, i18n.Sprintf("Shape: %s", FormatLocationName(biology.Shape)))
+	}
+	if biology.GrowthRate != "" {
+		lines = append(lines, i18n.Sprintf("Growth rate: %s", FormatLocationName(biology.GrowthRate)))
+	}
+	if biology.BaseHappiness != nil {
+		lines = append(lines, i18n.Sprintf("Base happiness: %d", *biology.BaseHappiness))
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	i18n.Fprintln(w, "Biology:")
+	for _, line := range lines {
+		i18n.Fprintf(w, " - %s\n", line)
+	}
 }
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestParseDescribeParams tests parsing of Pokémon names and version options
+func TestParseDescribeParams(t *testing.T) {
+	cases := []struct {
+		params  []string
+		want    describeOptions
+		wantErr bool
+	}{
+		{params: []string{"pikachu"}, want: describeOptions{name: "pikachu"}},
+		{params: []string{"mr mime"}, want: describeOptions{name: "mr mime"}},
+		{params: []string{"pikachu --version red"}, want: describeOptions{name: "pikachu", version: "red"}},
+		{params: []string{"pikachu --version=alpha-sapphire"}, want: describeOptions{name: "pikachu", version: "alpha-sapphire"}},
+		{params: []string{"mr mime --version alpha sapphire"}, want: describeOptions{name: "mr mime", version: "alpha-sapphire"}},
+		{params: []string{"pikachu --version"}, want: describeOptions{name: "pikachu", listVersions: true}},
+		{params: []string{"pikachu --versions"}, want: describeOptions{name: "pikachu", listVersions: true}},
+		{params: []string{"pikachu --colour"}, wantErr: true},
+		{params: []string{"--version red"}, wantErr: true},
+		{params: []string{}, wantErr: true},
+	}
+
+	for _, c := range cases {
+		got, err := parseDescribeParams(c.params)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseDescribeParams(%v): expected an error", c.params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDescribeParams(%v): unexpected error: %v", c.params, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("parseDescribeParams(%v) = %+v, expected %+v", c.params, got, c.want)
+		}
+	}
+}
+
+// TestGroupFlavorTexts tests that reprinted flavor texts are listed once under
+// the generation they first appeared in, with every game that uses them
+func TestGroupFlavorTexts(t *testing.T) {
+	entry := func(text, version string) pokeapi.FlavorTextEntry {
+		return pokeapi.FlavorTextEntry{
+			FlavorText: text,
+			Language:   pokeapi.NamedAPIResource{Name: "en"},
+			Version:    pokeapi.NamedAPIResource{Name: version},
+		}
+	}
+	entries := []pokeapi.FlavorTextEntry{
+		entry("It has the ability to alter\fits body.", "alpha-sapphire"),
+		entry("Its genetic code is\nirregular.", "red"),
+		entry("ITS GENETIC CODE IS IRREGULAR", "blue"),
+		entry("Its genetic code is irregular.", "firered"),
+		entry("A brand new entry.", "future-game"),
+		entry("Thanks to its un-\nstable gen\u00ad\netic makeup.", "sword"),
+	}
+
+	groups := groupFlavorTexts(entries)
+	expected := []flavorTextGroup{
+		{generation: 1, texts: []flavorText{{text: "Its genetic code is irregular.", versions: []string{"red", "blue", "firered"}}}},
+		{generation: 6, texts: []flavorText{{text: "It has the ability to alter its body.", versions: []string{"alpha-sapphire"}}}},
+		{generation: 8, texts: []flavorText{{text: "Thanks to its un-stable genetic makeup.", versions: []string{"sword"}}}},
+		{generation: 0, texts: []flavorText{{text: "A brand new entry.", versions: []string{"future-game"}}}},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("Unexpected groups:\n%+v\nExpected:\n%+v", groups, expected)
+	}
+}
+
+// TestFormDescription tests that species without flavor text fall back to
+// the English description of their forms
+func TestFormDescription(t *testing.T) {
+	var species pokeapi.PokemonSpeciesResp
+	err := json.Unmarshal([]byte(`{"name": "unown", "form_descriptions": [
+		{"description": "Unown come in 28\nshapes.", "language": {"name": "fr"}},
+		{"description": "There are 28 shapes\nof Unown.", "language": {"name": "en"}}]}`), &species)
+	if err != nil {
+		t.Fatalf("Could not decode the species: %v", err)
+	}
+	if got := formDescription(species); got != "There are 28 shapes of Unown." {
+		t.Errorf("Expected the English form description, got %q", got)
+	}
+	if got := formDescription(pokeapi.PokemonSpeciesResp{Name: "pikachu"}); got != "" {
+		t.Errorf("Expected no description for a species without one, got %q", got)
+	}
+}
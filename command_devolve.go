@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// commandDevolve undoes the most recent evolution of a Pokémon in the user's Pokédex.
+// When a Pokémon evolves, its previous form is kept with the evolved entry. This
+// command restores that previous form exactly as it was before the evolution,
+// including its data, notes, box, and moveset.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters where params[0] is the Pokémon to devolve
+//
+// Returns:
+//   - An error if no Pokémon name is provided, if the Pokémon is not in the Pokédex,
+//     if it has no recorded previous form, or if the previous form is already in the Pokédex
+func commandDevolve(cfg *config, params []string) error {
+	// Use the utility function to validate the Pokemon parameter and check if it exists
+	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "devolve", err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := devolvePokemon(cfg, apiName, nameInfo); err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "devolve", err) {
+			return err
+		}
+		return nil
+	}
+
+	// Auto-save after devolving
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the Pokédex has already been updated
+		HandleCommandError(cfg, "devolve", err)
+	}
+	return nil
+}
+
+// devolvePokemon replaces an evolved Pokémon with its recorded previous form.
+func devolvePokemon(cfg *config, apiName string, nameInfo PokemonNameInfo) error {
+	entry, _ := cfg.pokedex.Get(apiName)
+	snapshot := entry.PreEvolution
+	if snapshot == nil {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s has no earlier form to return to (only Pokémon evolved with 'evolve' can be devolved)",
+				nameInfo.Formatted), nil)
+	}
+
+	previousName := FormatPokemonName(snapshot.Name)
+	if _, exists := cfg.pokedex.Get(snapshot.Name); exists {
+		return alreadyInPokedexError(snapshot.Name, i18n.Sprintf("devolving %s", nameInfo.Formatted))
+	}
+
+	err := cfg.pokedex.Replace(apiName, snapshot.Name, func(current pokedex.Entry) (pokedex.Entry, error) {
+		// The Pokémon keeps the item it holds now, which may not be the one it held before evolving
+		previous := snapshot.Entry
+		previous.HeldItem = current.HeldItem
+		return previous, nil
+	})
+	if err != nil {
+		return pokedexError(err, apiName)
+	}
+
+	i18n.Printf("%s returned to its previous form. Welcome back, %s!\n", nameInfo.Formatted, previousName)
+	writeDataDiff(os.Stdout, cfg, nameInfo.Formatted, entry.PokemonDataResp, previousName, snapshot.Entry.PokemonDataResp)
+	printSeparator()
+	return nil
+}
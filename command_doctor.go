@@ -0,0 +1,318 @@
+// This file implements the doctor command, which checks the Pokédex for entries
+// that are inconsistent or incomplete, such as entries saved by older versions
+// before a field was added, or edited by hand, and offers to fix them.
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// doctorFix is the kind of automatic fix for a problem found by 'doctor'.
+type doctorFix int
+
+const (
+	fixRefetch doctorFix = iota // Download the Pokémon's data again, keeping what the user added
+	fixRename                   // Store the entry under the name in its data
+	fixRemove                   // Remove the entry, which can't be identified
+	fixRepair                   // Correct the entry without the API
+)
+
+// doctorProblem is a problem with a Pokédex entry found by 'doctor'.
+type doctorProblem struct {
+	name    string                     // The entry's name in the Pokédex
+	problem string                     // What's wrong, for display
+	fix     doctorFix                  // How the problem is fixed
+	rename  string                     // The name to store the entry under, for fixRename
+	repair  func(entry *pokedex.Entry) // Corrects the entry, for fixRepair
+}
+
+// describeFix describes the automatic fix for a problem, for display.
+func (p doctorProblem) describeFix() string {
+	switch p.fix {
+	case fixRefetch:
+		return i18n.T("Download its data again")
+	case fixRename:
+		return i18n.Sprintf("Store it as %s", FormatPokemonName(p.rename))
+	case fixRemove:
+		return i18n.T("Remove the entry")
+	}
+	return i18n.T("Correct the entry")
+}
+
+// commandDoctor checks every entry in the Pokédex and offers to fix the problems
+// it finds. It looks for:
+//   - Entries stored without a name
+//   - Entries whose data is for a different Pokémon than the name they're stored under
+//   - Entries missing data that's required, such as stats, types, or the species
+//   - Moves in a moveset that the Pokémon can't learn, or more moves than a moveset holds
+//   - Levels and experience outside the range the game allows
+//   - Effort values over the limits the game allows
+//   - Earlier forms recorded by 'evolve' that have no name, so can't be restored
+//
+// Fields in the save file that don't match the save format are listed first,
+// so that they can be corrected by hand; the next save leaves them out.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters (none are accepted)
+//
+// Returns:
+//   - An error if parameters are given, or the fixed Pokédex can't be saved
+func commandDoctor(cfg *config, params []string) error {
+	if len(params) > 0 {
+		err := errorhandling.NewInvalidInputError("Usage: doctor", nil)
+		if HandleCommandError(cfg, "doctor", err) {
+			return err
+		}
+		return nil
+	}
+
+	checkSaveFile(cfg)
+
+	problems := checkPokedex(cfg.pokedex.All())
+	if len(problems) == 0 {
+		i18n.Printf("No problems found in %d Pokédex entries.\n", cfg.pokedex.Len())
+		printSeparator()
+		return nil
+	}
+
+	table := NewTable("Pokémon", "Problem", "Fix")
+	for _, problem := range problems {
+		table.AddRow(doctorDisplayName(problem.name), problem.problem, problem.describeFix())
+	}
+	table.Print()
+
+	if !confirm(cfg, i18n.Sprintf("Fix %d problems?", len(problems))) {
+		i18n.Println("Nothing was changed.")
+		printSeparator()
+		return nil
+	}
+
+	var fixed int
+	var failures []string
+	for _, problem := range problems {
+		if err := fixProblem(cfg, problem); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", doctorDisplayName(problem.name), errorhandling.FormatUserMessage(err)))
+			continue
+		}
+		fixed++
+	}
+
+	i18n.Printf("Fixed %d of %d problems.\n", fixed, len(problems))
+	if len(failures) > 0 {
+		i18n.Println("These problems couldn't be fixed:")
+		for _, failure := range failures {
+			i18n.Printf(" - %s\n", failure)
+		}
+	}
+	printSeparator()
+
+	if fixed == 0 {
+		return nil
+	}
+	// Auto-save after fixing
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the Pokédex has already been fixed
+		HandleCommandError(cfg, "doctor", err)
+	}
+	return nil
+}
+
+// checkSaveFile lists the fields in the save file that don't match the save
+// format (see internal/pokedex/schema.go). A save file that can't be checked
+// is reported without stopping the check of the entries.
+func checkSaveFile(cfg *config) {
+	path, err := getSaveFilePath(cfg)
+	if err != nil {
+		return
+	}
+	problems, err := pokedex.CheckFile(path)
+	if err != nil {
+		i18n.Printf("Warning: Could not check the save file: %v\n", err)
+		return
+	}
+	if len(problems) == 0 {
+		return
+	}
+	i18n.Printf("%d fields in the save file don't match the save format, so they couldn't be loaded:\n", len(problems))
+	for _, problem := range problems {
+		i18n.Printf(" - %s: expected %s, found %s\n", problem.Path, problem.Expected, problem.Found)
+	}
+}
+
+// checkPokedex finds the problems with Pokédex entries. Each entry has at most
+// one problem that needs its data downloaded again, since that fixes every
+// problem with the data at once; moveset and level problems are only looked
+// for in entries whose data is sound.
+//
+// Parameters:
+//   - entries: The Pokédex entries, indexed by name
+//
+// Returns:
+//   - The problems found, ordered by the name of the entry
+func checkPokedex(entries map[string]pokedex.Entry) []doctorProblem {
+	var problems []doctorProblem
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		entry := entries[name]
+
+		if strings.TrimSpace(name) == "" {
+			problem := doctorProblem{name: name, problem: i18n.T("Stored without a name"), fix: fixRemove}
+			if _, taken := entries[entry.Name]; entry.Name != "" && !taken {
+				problem.fix, problem.rename = fixRename, entry.Name
+			}
+			problems = append(problems, problem)
+			continue
+		}
+
+		if problem, ok := checkEntryData(name, entry); ok {
+			problems = append(problems, problem)
+			continue
+		}
+		problems = append(problems, checkEntryProgress(name, entry)...)
+	}
+	return problems
+}
+
+// checkEntryData looks for a problem with the API data in an entry.
+func checkEntryData(name string, entry pokedex.Entry) (doctorProblem, bool) {
+	var missing []string
+	switch {
+	case entry.Name == "":
+		missing = append(missing, "name")
+	case entry.Name != name:
+		return doctorProblem{
+			name:    name,
+			problem: i18n.Sprintf("Its data is for %s", FormatPokemonName(entry.Name)),
+			fix:     fixRefetch,
+		}, true
+	}
+	if len(entry.Stats) == 0 {
+		missing = append(missing, "stats")
+	}
+	if len(entry.Types) == 0 {
+		missing = append(missing, "types")
+	}
+	if entry.Species.Name == "" {
+		missing = append(missing, "species")
+	}
+	if len(missing) == 0 {
+		return doctorProblem{}, false
+	}
+	return doctorProblem{
+		name:    name,
+		problem: i18n.Sprintf("Missing %s", strings.Join(missing, ", ")),
+		fix:     fixRefetch,
+	}, true
+}
+
+// checkEntryProgress looks for problems with what the user has added to an
+// entry whose data is sound.
+func checkEntryProgress(name string, entry pokedex.Entry) []doctorProblem {
+	var problems []doctorProblem
+	repair := func(problem string, repair func(entry *pokedex.Entry)) {
+		problems = append(problems, doctorProblem{name: name, problem: problem, fix: fixRepair, repair: repair})
+	}
+
+	// Moves in the moveset the Pokémon can't learn at all, in any game
+	var unknown, unknownNames []string
+	for _, move := range entry.Moveset {
+		if len(entry.Moves) > 0 && !entry.CanLearn(move, "") {
+			unknown = append(unknown, move)
+			unknownNames = append(unknownNames, FormatMoveName(move))
+		}
+	}
+	if len(unknown) > 0 {
+		repair(i18n.Sprintf("Its moveset has moves it can't learn: %s", strings.Join(unknownNames, ", ")),
+			func(entry *pokedex.Entry) {
+				entry.Moveset = slices.DeleteFunc(entry.Moveset, func(move string) bool {
+					return slices.Contains(unknown, move)
+				})
+			})
+	} else if len(entry.Moveset) > pokedex.MaxMovesetSize {
+		repair(i18n.Sprintf("Its moveset has %d moves (at most %d)", len(entry.Moveset), pokedex.MaxMovesetSize),
+			func(entry *pokedex.Entry) {
+				entry.Moveset = entry.Moveset[:pokedex.MaxMovesetSize]
+			})
+	}
+
+	if entry.Level < 0 || entry.Level > pokedex.MaxLevel || entry.Experience < 0 {
+		repair(i18n.Sprintf("Level %d with %d experience is out of range", entry.Level, entry.Experience),
+			func(entry *pokedex.Entry) {
+				entry.Level = min(max(entry.Level, 0), pokedex.MaxLevel)
+				entry.Experience = max(entry.Experience, 0)
+			})
+	}
+
+	if overEVLimits(entry.EVs) {
+		repair(i18n.Sprintf("Its EVs are over the limits (%d in a stat, %d in all)", pokedex.MaxStatEVs, pokedex.MaxTotalEVs),
+			func(entry *pokedex.Entry) {
+				evs := entry.EVs
+				entry.EVs = nil
+				for stat, points := range evs {
+					evs[stat] = max(points, 0)
+				}
+				entry.GainEVs(evs)
+			})
+	}
+
+	if entry.PreEvolution != nil && entry.PreEvolution.Name == "" {
+		repair(i18n.T("Its earlier form has no name, so it can't be devolved"),
+			func(entry *pokedex.Entry) {
+				entry.PreEvolution = nil
+			})
+	}
+	return problems
+}
+
+// overEVLimits reports whether a Pokémon's effort values are negative, or over
+// the limit for a stat or in all.
+func overEVLimits(evs map[string]int) bool {
+	total := 0
+	for _, points := range evs {
+		if points < 0 || points > pokedex.MaxStatEVs {
+			return true
+		}
+		total += points
+	}
+	return total > pokedex.MaxTotalEVs
+}
+
+// fixProblem applies the automatic fix for a problem.
+func fixProblem(cfg *config, problem doctorProblem) error {
+	switch problem.fix {
+	case fixRefetch:
+		data, err := cfg.pokeapiClient.RefreshPokemonData(problem.name)
+		if err != nil {
+			return err
+		}
+		_, err = applyRefreshedData(cfg, problem.name, data)
+		return err
+	case fixRename:
+		return cfg.pokedex.Replace(problem.name, problem.rename, func(entry pokedex.Entry) (pokedex.Entry, error) {
+			return entry, nil
+		})
+	case fixRemove:
+		cfg.pokedex.Remove(problem.name)
+		return nil
+	}
+	return cfg.pokedex.Update(problem.name, func(entry *pokedex.Entry) error {
+		problem.repair(entry)
+		return nil
+	})
+}
+
+// doctorDisplayName formats the name an entry is stored under, for display.
+func doctorDisplayName(name string) string {
+	if strings.TrimSpace(name) == "" {
+		return i18n.T("(no name)")
+	}
+	return FormatPokemonName(name)
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestCheckPokedex tests the problems found in inconsistent entries, and that
+// sound entries have none
+func TestCheckPokedex(t *testing.T) {
+	sound := func(name string) pokedex.Entry {
+		data := testMatchupPokemon(t, name, 50, "electric")
+		data.Species = pokeapi.NamedAPIResource{Name: name}
+		data.Moves = []pokeapi.PokemonMove{{Move: pokeapi.NamedAPIResource{Name: "thunderbolt"}}}
+		return pokedex.NewEntry(data)
+	}
+	unlearnable := sound("pichu")
+	unlearnable.Moveset = []string{"thunderbolt", "surf"}
+	overLevel := sound("raichu")
+	overLevel.Level = 150
+	overEVs := sound("zapdos")
+	overEVs.EVs = map[string]int{"attack": 300, "speed": 252, "hp": -4}
+	noSpecies := sound("eevee")
+	noSpecies.Species = pokeapi.NamedAPIResource{}
+
+	entries := map[string]pokedex.Entry{
+		"":        sound("jolteon"),
+		"pikachu": sound("pikachu"),
+		"plusle":  sound("minun"),
+		"eevee":   noSpecies,
+		"pichu":   unlearnable,
+		"raichu":  overLevel,
+		"zapdos":  overEVs,
+	}
+	problems := checkPokedex(entries)
+
+	type found struct {
+		name string
+		fix  doctorFix
+	}
+	var got []found
+	for _, problem := range problems {
+		got = append(got, found{problem.name, problem.fix})
+	}
+	expected := []found{{"", fixRename}, {"eevee", fixRefetch}, {"pichu", fixRepair}, {"plusle", fixRefetch}, {"raichu", fixRepair}, {"zapdos", fixRepair}}
+	if !slices.Equal(got, expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+
+	problems[2].repair(&unlearnable)
+	if !slices.Equal(unlearnable.Moveset, []string{"thunderbolt"}) {
+		t.Errorf("Expected the unlearnable move to be removed, got %v", unlearnable.Moveset)
+	}
+	problems[4].repair(&overLevel)
+	if overLevel.Level != pokedex.MaxLevel {
+		t.Errorf("Expected the level to be lowered to %d, got %d", pokedex.MaxLevel, overLevel.Level)
+	}
+	problems[5].repair(&overEVs)
+	if !maps.Equal(overEVs.EVs, map[string]int{"attack": pokedex.MaxStatEVs, "speed": pokedex.MaxStatEVs}) {
+		t.Errorf("Expected the EVs to be brought within the limits, got %v", overEVs.EVs)
+	}
+
+	// An entry without a name is removed if its data's name is already taken
+	entries[""] = sound("pikachu")
+	if problems := checkPokedex(entries); problems[0].fix != fixRemove {
+		t.Errorf("Expected the entry to be removed, got fix %d", problems[0].fix)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// maxBreedingPartners is the number of compatible partners listed by the egggroups command.
+const maxBreedingPartners = 10
+
+// commandEggGroups lists the egg groups of a Pokémon and the Pokémon in the
+// user's Pokédex that it could breed with. The target doesn't need to be caught.
+// Only caught Pokémon that belong to one of the target's egg groups (or Ditto)
+// have their species data fetched, so large Pokédexes stay cheap to check.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the Pokémon name (caught or not)
+//
+// Returns:
+//   - An error if no Pokémon name is provided, the name is invalid,
+//     or there's an issue with the API requests
+func commandEggGroups(cfg *config, params []string) error {
+	// Check if Pokemon name parameter was provided
+	pokemonParam, err := ValidatePokemonParam(params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "egggroups", err) {
+			return err
+		}
+		return nil
+	}
+
+	nameInfo := FormatPokemonInput(pokemonParam)
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "egggroups", err) {
+			return err
+		}
+		return nil
+	}
+
+	// Egg groups belong to the species, so look up the species of the given form
+	pokemonData, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "egggroups", err) {
+			return err
+		}
+		return nil
+	}
+	recordSeen(cfg, "egggroups", "", nameInfo.APIFormat)
+
+	speciesData, err := cfg.pokeapiClient.GetPokemonSpecies(pokemonData.Species.Name)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "egggroups", err) {
+			return err
+		}
+		return nil
+	}
+	target := newBreeder(speciesData)
+
+	// Fetch each egg group for its display name and member species
+	groupNames := make(map[string]string, len(target.eggGroups))
+	members := make(map[string]bool)
+	displayNames := make([]string, 0, len(target.eggGroups))
+	for _, groupName := range target.eggGroups {
+		group, err := cfg.pokeapiClient.GetEggGroup(groupName)
+		if err != nil {
+			// Use standardized error handling
+			if HandleCommandError(cfg, "egggroups", err) {
+				return err
+			}
+			return nil
+		}
+		groupNames[groupName] = eggGroupDisplayName(group)
+		displayNames = append(displayNames, groupNames[groupName])
+		for _, species := range group.PokemonSpecies {
+			members[species.Name] = true
+		}
+	}
+
+	if len(displayNames) == 0 {
+		i18n.Printf("No egg groups are recorded for %s.\n", nameInfo.Formatted)
+		printSeparator()
+		return nil
+	}
+	i18n.Printf("Egg groups of %s: %s\n", nameInfo.Formatted, strings.Join(displayNames, ", "))
+
+	switch {
+	case target.inGroup(eggGroupUndiscovered):
+		i18n.Printf("%s is in the Undiscovered egg group, so it can't breed.\n", nameInfo.Formatted)
+		printSeparator()
+		return nil
+	case target.inGroup(eggGroupDitto):
+		i18n.Printf("%s can breed with any Pokémon that isn't in the Undiscovered egg group, except another Ditto.\n", nameInfo.Formatted)
+	case target.genderless:
+		i18n.Printf("%s is genderless, so it can only breed with Ditto.\n", nameInfo.Formatted)
+	}
+
+	// Take a snapshot of the Pokédex so the API requests below don't hold the lock
+	candidates := cfg.pokedex.All()
+
+	type partner struct {
+		name   string
+		shared []string
+	}
+	var partners []partner
+	for name, entry := range candidates {
+		species := entry.Species.Name
+		// Skip species that can't share a group with the target; Ditto partners
+		// with nearly anything, so every candidate is checked when it's the target
+		if !target.inGroup(eggGroupDitto) && species != eggGroupDitto && !members[species] {
+			continue
+		}
+
+		candidateSpecies, err := cfg.pokeapiClient.GetPokemonSpecies(species)
+		if err != nil {
+			// Use standardized error handling
+			if HandleCommandError(cfg, "egggroups", err) {
+				return err
+			}
+			return nil
+		}
+		shared, ok := breedingCompatibility(target, newBreeder(candidateSpecies))
+		if !ok {
+			continue
+		}
+
+		sharedNames := make([]string, 0, len(shared))
+		for _, group := range shared {
+			sharedNames = append(sharedNames, groupNames[group])
+		}
+		partners = append(partners, partner{name: name, shared: sharedNames})
+	}
+
+	if len(partners) == 0 {
+		i18n.Printf("None of the Pokémon in your Pokédex can breed with %s.\n", nameInfo.Formatted)
+		printSeparator()
+		return nil
+	}
+
+	sort.Slice(partners, func(i, j int) bool {
+		return partners[i].name < partners[j].name
+	})
+
+	i18n.Printf("Compatible partners in your Pokédex (%d):\n", len(partners))
+	table := NewTable("Pokémon", "Shared egg groups")
+	for _, p := range partners[:min(len(partners), maxBreedingPartners)] {
+		shared := i18n.T("Any (Ditto)")
+		if len(p.shared) > 0 {
+			shared = strings.Join(p.shared, ", ")
+		}
+		table.AddRow(FormatPokemonName(p.name), shared)
+	}
+	table.Print()
+	if len(partners) > maxBreedingPartners {
+		i18n.Printf("...and %d more.\n", len(partners)-maxBreedingPartners)
+	}
+	printSeparator()
+	return nil
+}
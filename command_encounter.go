@@ -0,0 +1,328 @@
+// This file implements the encounter, surf, and fish commands, which look for a
+// wild Pokémon in a location area. Each command rolls from the Pokémon found by
+// its own encounter methods (on land, surfing, or fishing), and Pokémon turn up
+// as often as they do in the games. Pokémon that only turn up at some times of
+// day or in some seasons only turn up then (see encounter_conditions.go). A
+// lure in use in the area, and seasonal events (see internal/events), make the
+// Pokémon they target turn up more often.
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/events"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// encounterPool is a group of encounter methods whose Pokémon are rolled together.
+type encounterPool string
+
+const (
+	poolLand encounterPool = "land" // Walking in grass or caves, and every other method on land
+	poolSurf encounterPool = "surf" // Surfing on water
+	poolFish encounterPool = "fish" // Fishing with any rod
+)
+
+// waterMethods maps the PokeAPI encounter methods on water to their pool.
+// Every other method is on land.
+var waterMethods = map[string]encounterPool{
+	"surf":            poolSurf,
+	"surf-spots":      poolSurf,
+	"old-rod":         poolFish,
+	"good-rod":        poolFish,
+	"super-rod":       poolFish,
+	"super-rod-spots": poolFish,
+}
+
+// poolNames are the display names of the pools, in the order they are listed.
+var poolNames = []struct {
+	pool encounterPool
+	name string
+}{
+	{poolLand, "On land"},
+	{poolSurf, "Surfing"},
+	{poolFish, "Fishing"},
+}
+
+// formatPools lists the ways a Pokémon can be encountered (e.g. "Surfing, Fishing").
+func formatPools(encounter pokeapi.PokemonEncounter) string {
+	var names []string
+	for _, p := range poolNames {
+		if poolChance(encounter, p.pool, nil) > 0 {
+			names = append(names, i18n.T(p.name))
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// methodPool returns the pool an encounter method belongs to.
+func methodPool(method string) encounterPool {
+	if pool, ok := waterMethods[method]; ok {
+		return pool
+	}
+	return poolLand
+}
+
+// poolChance returns the chance of encountering a Pokémon by the methods in
+// a pool, as a percentage. Encounters without method details are on land.
+//
+// Parameters:
+//   - encounter: The Pokémon's encounter details
+//   - pool: The encounter methods being used
+//   - allows: Reports whether a detail's conditions hold, or nil to count every detail
+//
+// Returns:
+//   - The chance of encountering the Pokémon, or zero if it can't be found that way
+func poolChance(encounter pokeapi.PokemonEncounter, pool encounterPool, allows func(pokeapi.EncounterDetail) bool) int {
+	if len(encounter.Methods()) == 0 {
+		if pool == poolLand {
+			return max(1, encounter.MaxChance())
+		}
+		return 0
+	}
+	return encounter.DetailChance(func(detail pokeapi.EncounterDetail) bool {
+		return methodPool(detail.Method.Name) == pool && (allows == nil || allows(detail))
+	})
+}
+
+// commandEncounter looks for a wild Pokémon on land in the area explored last.
+//
+// Parameters:
+//   - cfg: The application configuration containing the explored area and API client
+//   - params: Command parameters (not used in this command)
+//
+// Returns:
+//   - An error if no area has been explored or there's an issue with the API requests
+func commandEncounter(cfg *config, params []string) error {
+	location := cfg.ExploredArea()
+	if location == "" {
+		err := errorhandling.NewInvalidInputError("Explore an area with 'explore <number>' before looking for a wild Pokémon", nil)
+		if HandleCommandError(cfg, "encounter", err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := rollEncounter(cfg, "encounter", location, poolLand); err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "encounter", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// commandSurf looks for a wild Pokémon by surfing in a location area.
+//
+// Parameters:
+//   - cfg: The application configuration containing the explored area and API client
+//   - params: Command parameters where params[0] is an optional location number from the map
+//
+// Returns:
+//   - An error if the location is invalid or there's an issue with the API requests
+func commandSurf(cfg *config, params []string) error {
+	return waterEncounter(cfg, "surf", params, poolSurf)
+}
+
+// commandFish looks for a wild Pokémon by fishing in a location area.
+//
+// Parameters:
+//   - cfg: The application configuration containing the explored area and API client
+//   - params: Command parameters where params[0] is an optional location number from the map
+//
+// Returns:
+//   - An error if the location is invalid or there's an issue with the API requests
+func commandFish(cfg *config, params []string) error {
+	return waterEncounter(cfg, "fish", params, poolFish)
+}
+
+// waterEncounter looks for a wild Pokémon on water, in the location area with the
+// given number on the map, or in the area explored last if no number is given.
+// An area chosen by number becomes the explored area, so that a Pokémon caught
+// there records where it was caught.
+//
+// Parameters:
+//   - cfg: The application configuration containing the explored area and API client
+//   - commandName: The command being run, for error reporting
+//   - params: Command parameters where params[0] is an optional location number
+//   - pool: The encounter methods to roll from
+//
+// Returns:
+//   - An error if the location is invalid or there's an issue with the API requests
+func waterEncounter(cfg *config, commandName string, params []string, pool encounterPool) error {
+	location := cfg.ExploredArea()
+	var err error
+	if len(params) > 0 {
+		location, err = locationFromParams(cfg, params)
+	} else if location == "" {
+		err = errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Choose a location with '%s <location number>', or explore an area first", commandName), nil)
+	}
+	if err == nil {
+		err = rollEncounter(cfg, commandName, location, pool)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, commandName, err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// rollEncounter rolls a wild Pokémon found by the methods in a pool in a location
+// area, weighted by how likely each Pokémon is to turn up. If a lure is in use in
+// the area, the encounter counts against it and its target is favored.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - commandName: The command being run, for error reporting
+//   - location: The API name of the location area
+//   - pool: The encounter methods to roll from
+//
+// Returns:
+//   - An error if there's an issue with the API requests
+func rollEncounter(cfg *config, commandName, location string, pool encounterPool) error {
+	resp, err := cfg.pokeapiClient.ExploreLocation(location)
+	if err != nil {
+		return err
+	}
+	if location != cfg.ExploredArea() {
+		found := make([]string, 0, len(resp.PokemonEncounters))
+		for _, encounter := range resp.PokemonEncounters {
+			found = append(found, encounter.Pokemon.Name)
+		}
+		cfg.SetExploredArea(location, found)
+	}
+
+	lure, lured := cfg.ActiveLure()
+	lured = lured && lure.Location == location
+	lureTarget := ""
+	if lured {
+		lureTarget = lure.Target
+	}
+	now := time.Now()
+	conditions := currentConditions(now)
+	weights, err := encounterWeights(cfg, resp.PokemonEncounters, pool, conditions, lureTarget, events.Default().Boosts(now))
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(weights, func(w int) bool { return w > 0 }) {
+		if slices.ContainsFunc(resp.PokemonEncounters, func(e pokeapi.PokemonEncounter) bool { return poolChance(e, pool, nil) > 0 }) {
+			i18n.Printf("No wild Pokémon can be found in %s that way right now (%s). Try again at another time of day or in another season.\n",
+				FormatLocationName(location), conditions)
+		} else {
+			i18n.Printf("No wild Pokémon can be found in %s that way.\n", FormatLocationName(location))
+		}
+		printSeparator()
+		return nil
+	}
+
+	name := resp.PokemonEncounters[weightedPick(weights, rand.Intn)].Pokemon.Name
+	recordVisit(cfg, commandName, location, true)
+	recordSeen(cfg, commandName, location, name)
+	i18n.Printf("A wild %s appeared in %s!\n", FormatPokemonName(name), FormatLocationName(location))
+
+	if lured {
+		lure, _ = cfg.ConsumeLure(location)
+		if lure.Remaining > 0 {
+			i18n.Printf("The %s will last for %d more encounters here.\n", FormatItemName(lure.Item), lure.Remaining)
+		} else {
+			i18n.Printf("The %s has worn off.\n", FormatItemName(lure.Item))
+		}
+		if err := UpdatePokedexAndSave(cfg); err != nil {
+			HandleCommandError(cfg, commandName, err)
+		}
+	}
+	i18n.Printf("Use 'catch %s' to try to catch it.\n", name)
+	printSeparator()
+	return nil
+}
+
+// encounterWeights returns how likely each Pokémon is to be encountered by the
+// methods in a pool, relative to the others. Each Pokémon is weighted by its
+// highest chance of turning up in any game under the current conditions, or
+// zero if the pool's methods can't find it then. The target of a lure is weighted lureBias times more, and the
+// Pokémon boosted by a seasonal event by the boost's multiplier.
+//
+// Parameters:
+//   - cfg: The application configuration, used to look up the Pokémon's types
+//   - encounters: The Pokémon that can be encountered
+//   - pool: The encounter methods being used
+//   - conditions: The time of day and season the encounter happens in
+//   - lureTarget: The target of the lure in use in the area, or "" if there isn't one
+//   - boosts: The boosts of the seasonal events that are on (see internal/events)
+//
+// Returns:
+//   - The weight of each Pokémon, in the order of encounters
+//   - An error if a Pokémon's types are needed and can't be looked up
+func encounterWeights(cfg *config, encounters []pokeapi.PokemonEncounter, pool encounterPool, conditions encounterConditions, lureTarget string, boosts []events.Boost) ([]int, error) {
+	weights := make([]int, len(encounters))
+	for i, encounter := range encounters {
+		weights[i] = poolChance(encounter, pool, conditions.allows)
+		if weights[i] == 0 {
+			continue
+		}
+		if lureTarget != "" {
+			targeted, err := lureTargets(cfg, lureTarget, encounter.Pokemon.Name)
+			if err != nil {
+				return nil, err
+			}
+			if targeted {
+				weights[i] *= lureBias
+			}
+		}
+		for _, boost := range boosts {
+			targeted, err := lureTargets(cfg, boost.Target, encounter.Pokemon.Name)
+			if err != nil {
+				return nil, err
+			}
+			if targeted {
+				weights[i] *= boost.Multiplier
+			}
+		}
+	}
+	return weights, nil
+}
+
+// lureTargets reports whether the target of a lure or an event boost, a type or
+// a Pokémon, covers a Pokémon.
+func lureTargets(cfg *config, target, pokemon string) (bool, error) {
+	if !slices.Contains(standardTypes, target) {
+		return target == pokemon, nil
+	}
+	types, err := pokemonTypesOf(cfg, pokemon)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(types, target), nil
+}
+
+// weightedPick picks an index at random, with each index chosen in proportion to its weight.
+//
+// Parameters:
+//   - weights: The weight of each index, at least one of them positive
+//   - intn: Returns a random number from 0 to n-1, such as rand.Intn
+//
+// Returns:
+//   - The chosen index
+func weightedPick(weights []int, intn func(n int) int) int {
+	total := 0
+	for _, w := range weights {
+		total += w
+	}
+	roll := intn(total)
+	for i, w := range weights {
+		if roll < w {
+			return i
+		}
+		roll -= w
+	}
+	return len(weights) - 1
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/dataset"
+	"github.com/bmlevitt/pokedexcli/internal/events"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestEncounterWeights tests that Pokémon are weighted by their chance of being
+// encountered by the methods in a pool, and that lures and event boosts favor their targets
+func TestEncounterWeights(t *testing.T) {
+	cfg := &config{dataset: dataset.New([]dataset.Species{
+		{ID: 10, Name: "caterpie", Types: []string{"bug"}},
+		{ID: 13, Name: "weedle", Types: []string{"bug", "poison"}},
+		{ID: 25, Name: "pikachu", Types: []string{"electric"}},
+		{ID: 43, Name: "oddish", Types: []string{"grass", "poison"}},
+		{ID: 129, Name: "magikarp", Types: []string{"water"}},
+	}, true)}
+
+	// encounter builds an encounter with one detail per method, and the same
+	// details in two games, with the second game's chances halved
+	encounter := func(name string, chances map[string]int) pokeapi.PokemonEncounter {
+		e := pokeapi.PokemonEncounter{Pokemon: pokeapi.NamedAPIResource{Name: name}}
+		for _, divisor := range []int{1, 2} {
+			version := pokeapi.EncounterVersionDetails{}
+			for method, chance := range chances {
+				version.EncounterDetails = append(version.EncounterDetails, pokeapi.EncounterDetail{
+					Chance: chance / divisor,
+					Method: pokeapi.NamedAPIResource{Name: method},
+				})
+				version.MaxChance += chance / divisor
+			}
+			e.VersionDetails = append(e.VersionDetails, version)
+		}
+		return e
+	}
+	encounters := []pokeapi.PokemonEncounter{
+		encounter("caterpie", map[string]int{"walk": 50}),
+		encounter("weedle", map[string]int{"walk": 20, "headbutt": 10}),
+		encounter("pikachu", map[string]int{"walk": 5}),
+		encounter("magikarp", map[string]int{"surf": 30, "old-rod": 60, "good-rod": 20}),
+		{Pokemon: pokeapi.NamedAPIResource{Name: "oddish"}}, // No details, so found on land
+	}
+
+	tests := []struct {
+		pool       encounterPool
+		lureTarget string
+		boosts     []events.Boost
+		want       []int
+	}{
+		{poolLand, "", nil, []int{50, 30, 5, 0, 1}},
+		{poolLand, "bug", nil, []int{50 * lureBias, 30 * lureBias, 5, 0, 1}},
+		{poolLand, "pikachu", nil, []int{50, 30, 5 * lureBias, 0, 1}},
+		{poolSurf, "", nil, []int{0, 0, 0, 30, 0}},
+		{poolFish, "water", nil, []int{0, 0, 0, 80 * lureBias, 0}},
+		{poolLand, "", []events.Boost{{Target: "poison", Multiplier: 3}}, []int{50, 90, 5, 0, 3}},
+		{poolLand, "bug", []events.Boost{{Target: "pikachu", Multiplier: 2}}, []int{50 * lureBias, 30 * lureBias, 10, 0, 1}},
+	}
+	for _, tt := range tests {
+		got, err := encounterWeights(cfg, encounters, tt.pool, encounterConditions{}, tt.lureTarget, tt.boosts)
+		if err != nil {
+			t.Fatalf("encounterWeights(%s, %q) returned an error: %v", tt.pool, tt.lureTarget, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("encounterWeights(%s, %q) = %v, want %v", tt.pool, tt.lureTarget, got, tt.want)
+		}
+	}
+
+	if got := formatPools(encounters[3]); got != "Surfing, Fishing" {
+		t.Errorf("formatPools(magikarp) = %q, want \"Surfing, Fishing\"", got)
+	}
+}
+
+// TestWeightedPick tests that each index is picked for its share of the rolls
+func TestWeightedPick(t *testing.T) {
+	weights := []int{2, 0, 3}
+	want := []int{0, 0, 2, 2, 2}
+	for roll, index := range want {
+		if got := weightedPick(weights, func(n int) int { return roll }); got != index {
+			t.Errorf("weightedPick with roll %d = %d, want %d", roll, got, index)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// This file implements the events command, which lists the seasonal events
+// that are on (see internal/events), and shows their messages when the app starts.
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/events"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// commandEvents lists the seasonal events that are on, with when each ends,
+// its message, and the Pokémon that turn up more often during it, followed
+// by the next event to start.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Not used
+//
+// Returns:
+//   - Always nil
+func commandEvents(cfg *config, params []string) error {
+	printSeasonalEvents(events.Default(), time.Now())
+	printSeparator()
+	return nil
+}
+
+// printSeasonalEvents lists the events of a schedule that are on at a time, and the
+// next one to start.
+func printSeasonalEvents(schedule events.Schedule, now time.Time) {
+	language := i18n.Current()
+	active := schedule.Active(now)
+	if len(active) == 0 {
+		i18n.Println("No events are on right now.")
+	} else {
+		i18n.Println("Events on now:")
+	}
+	for _, e := range active {
+		i18n.Printf("%s (until %s)\n", e.Name(language), e.End(now).Format("2006-01-02"))
+		fmt.Printf("  %s\n", e.Message(language))
+		if len(e.Boosts) > 0 {
+			i18n.Printf("  More common in the wild: %s\n", formatBoosts(e.Boosts))
+		}
+	}
+
+	if next, start, err := schedule.Next(now); err == nil {
+		i18n.Printf("Next event: %s, from %s.\n", next.Name(language), start.Format("2006-01-02"))
+	}
+}
+
+// formatBoosts describes the Pokémon boosted by an event and by how much
+// (e.g. "Ghost-type Pokémon (x3), Pikachu (x5)").
+func formatBoosts(boosts []events.Boost) string {
+	parts := make([]string, len(boosts))
+	for i, boost := range boosts {
+		if slices.Contains(standardTypes, boost.Target) {
+			parts[i] = i18n.Sprintf("%s-type Pokémon (x%d)", FormatTypeName(boost.Target), boost.Multiplier)
+		} else {
+			parts[i] = fmt.Sprintf("%s (x%d)", FormatPokemonName(boost.Target), boost.Multiplier)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printEventGreetings shows the message of every event that's on, as the app starts.
+func printEventGreetings() {
+	language := i18n.Current()
+	for _, e := range events.Default().Active(time.Now()) {
+		fmt.Printf("[%s] %s\n", e.Name(language), e.Message(language))
+	}
+}
@@ -0,0 +1,15 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/events"
+)
+
+// TestFormatBoosts tests that type and Pokémon boosts are described differently
+func TestFormatBoosts(t *testing.T) {
+	got := formatBoosts([]events.Boost{{Target: "ghost", Multiplier: 3}, {Target: "pikachu", Multiplier: 5}})
+	if want := "Ghost-type Pokémon (x3), Pikachu (x5)"; got != want {
+		t.Errorf("formatBoosts() = %q, want %q", got, want)
+	}
+}
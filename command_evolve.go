@@ -2,14 +2,26 @@ package main
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
-// commandEvolve evolves a Pokémon in the user's Pokédex into its next evolution.
+// evolution is the data behind the evolve command's result.
+type evolution struct {
+	Pokemon     string `json:"pokemon"`                // The evolving Pokémon's API name
+	EvolvedInto string `json:"evolved_into,omitempty"` // The API name of the form it evolved into, if it evolved
+	Evolved     bool   `json:"evolved"`                // Whether it evolved (false if cancelled or there's no evolution data)
+}
+
+// evolveResult evolves a Pokémon in the user's Pokédex into its next evolution.
 // This command simulates the evolution mechanic from the Pokémon games by replacing
 // the original Pokémon in the Pokédex with its evolved form.
 //
@@ -17,107 +29,73 @@ import (
 // which evolution they want. They can specify an evolution either by number or name
 // as an additional parameter, or select from a menu if no choice is provided.
 //
+// Before evolving, a preview shows what the Pokémon will evolve into, how it
+// evolves in the games, and how its types and base stats will change. The user
+// must confirm the evolution unless the --yes flag is given, in which case the
+// preview is part of the result.
+//
+// Forms evolve along their species' evolution chain. If the API has no
+// evolution data for the species, the user is told so rather than shown an error.
+//
 // Parameters:
 //   - cfg: The application configuration containing the Pokédex and API client
-//   - params: Command parameters where params[0] is the Pokémon to evolve and
-//     params[1] (optional) is the evolution selection
+//   - params: Command parameters forming the Pokémon to evolve, optionally followed
+//     by the evolution selection and the --yes flag (e.g. "eevee 2 --yes")
 //
 // Returns:
+//   - What the Pokémon evolved into, if it did
 //   - An error if no Pokémon name is provided, if the Pokémon is not in the Pokédex,
 //     if the Pokémon cannot evolve further, if an invalid selection is made,
 //     or if there's an issue with the API request
-func commandEvolve(cfg *config, params []string) error {
-	// Use the utility function to validate the Pokemon parameter and check if it exists
-	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, params)
-	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "evolve", err) {
-			return err
+func evolveResult(cfg *config, params []string) (commandResult, error) {
+	// The REPL joins the parameters into one name, so split them back into words
+	var words []string
+	skipConfirm := false
+	for _, word := range strings.Fields(strings.Join(params, " ")) {
+		if word == "--yes" || word == "-y" {
+			skipConfirm = true
+			continue
 		}
-		return nil
+		words = append(words, word)
 	}
 
-	// Get the evolution chain for the Pokemon
-	evolutionChain, err := cfg.pokeapiClient.GetEvolutionChainBySpecies(apiName)
+	// Separate the Pokémon name from the evolution selection and check it's in the Pokédex
+	apiName, nameInfo, selection, err := splitPokemonParams(cfg, words)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "evolve", err) {
-			return err
-		}
-		return nil
+		return commandResult{}, err
 	}
+	evolved := evolution{Pokemon: apiName}
 
-	// Find the Pokemon in the evolution chain and its possible evolutions
-	evolutions, err := findEvolutionsFor(apiName, evolutionChain.Chain)
+	// Get the evolution chain for the Pokemon's species, which forms share
+	current, _ := cfg.pokedex.Get(apiName)
+	species := speciesName(apiName, current.PokemonDataResp)
+	evolutionChain, err := cfg.pokeapiClient.GetEvolutionChainBySpecies(species)
 	if err != nil {
-		// Create a specific error for this case
-		evolveErr := errorhandling.NewInvalidInputError(
-			fmt.Sprintf("%s cannot evolve (not found in evolution chain)", nameInfo.Formatted), err)
-
-		// Use standardized error handling
-		if HandleCommandError(cfg, "evolve", evolveErr) {
-			return evolveErr
+		if isMissingData(err) {
+			// Newer species and some forms have no evolution data yet
+			return commandResult{
+				Message: i18n.Sprintf("No evolution data is available for %s, so it can't be evolved for now.\n", nameInfo.Formatted),
+				Data:    evolved,
+			}, nil
 		}
-		return nil
+		return commandResult{}, err
 	}
 
+	// Find the Pokemon in the evolution chain and its possible evolutions
+	evolutions, err := findEvolutionsFor(species, evolutionChain.Chain)
+	if err != nil {
+		return commandResult{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s cannot evolve (not found in evolution chain)", nameInfo.Formatted), err)
+	}
 	if len(evolutions) == 0 {
-		// Create a specific error for this case
-		evolveErr := errorhandling.NewInvalidInputError(
-			fmt.Sprintf("%s cannot evolve any further", nameInfo.Formatted), nil)
-
-		// Use standardized error handling
-		if HandleCommandError(cfg, "evolve", evolveErr) {
-			return evolveErr
-		}
-		return nil
+		return commandResult{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s cannot evolve any further", nameInfo.Formatted), nil)
 	}
 
 	// Handle evolution choice
-	var selectedEvolution pokeapi.ChainLink
-	if len(evolutions) == 1 {
-		// Only one possible evolution
-		selectedEvolution = evolutions[0]
-	} else {
-		// Multiple possible evolutions, need to choose
-		if len(params) > 1 {
-			// User provided a selection parameter
-			selection := params[1]
-			selectionNum, err := strconv.Atoi(selection)
-			if err == nil && selectionNum > 0 && selectionNum <= len(evolutions) {
-				// Valid numeric selection
-				selectedEvolution = evolutions[selectionNum-1]
-			} else {
-				// Try to match by name
-				found := false
-				for _, evo := range evolutions {
-					if strings.EqualFold(ConvertToAPIFormat(selection), evo.Species.Name) {
-						selectedEvolution = evo
-						found = true
-						break
-					}
-				}
-				if !found {
-					// Show evolution options
-					fmt.Printf("%s can evolve into multiple forms. Choose one:\n", nameInfo.Formatted)
-					for i, evolution := range evolutions {
-						formattedEvolution := FormatPokemonName(evolution.Species.Name)
-						fmt.Printf("%d. %s\n", i+1, formattedEvolution)
-					}
-					return errorhandling.NewInvalidInputError(
-						fmt.Sprintf("Invalid evolution selection: '%s'", selection), nil)
-				}
-			}
-		} else {
-			// No selection provided, show options
-			fmt.Printf("%s can evolve into multiple forms. Choose one:\n", nameInfo.Formatted)
-			for i, evolution := range evolutions {
-				formattedEvolution := FormatPokemonName(evolution.Species.Name)
-				fmt.Printf("%d. %s\n", i+1, formattedEvolution)
-			}
-			return errorhandling.NewInvalidInputError(
-				fmt.Sprintf("Please specify which evolution to use (e.g., 'evolve %s 1')", nameInfo.APIFormat), nil)
-		}
+	selectedEvolution, err := selectEvolution(nameInfo, evolutions, selection)
+	if err != nil {
+		return commandResult{}, err
 	}
 
 	// Get data for the evolved form
@@ -125,24 +103,44 @@ func commandEvolve(cfg *config, params []string) error {
 	evolvedFormattedName := FormatPokemonName(evolvedName)
 	evolvedData, err := cfg.pokeapiClient.GetPokemonData(evolvedName)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "evolve", err) {
-			return err
-		}
-		return nil
+		return commandResult{}, err
+	}
+
+	if _, exists := cfg.pokedex.Get(evolvedName); exists {
+		return commandResult{}, alreadyInPokedexError(evolvedName, i18n.Sprintf("evolving %s", nameInfo.Formatted))
+	}
+
+	// Show what will change and ask before replacing the entry. Without the
+	// question, the preview is shown with the rest of the result.
+	var message strings.Builder
+	var preview io.Writer = os.Stdout
+	if skipConfirm {
+		preview = &message
+	}
+	writeEvolutionPreview(preview, cfg, nameInfo.Formatted, current.PokemonDataResp, evolvedFormattedName, evolvedData,
+		selectedEvolution.EvolutionDetails)
+
+	if !skipConfirm && !confirm(cfg, i18n.Sprintf("Evolve %s into %s?", nameInfo.Formatted, evolvedFormattedName)) {
+		return commandResult{
+			Message: i18n.Sprintf("Evolution cancelled. %s was not changed.\n", nameInfo.Formatted),
+			Data:    evolved,
+		}, nil
 	}
 
-	// Add evolved form to pokedex
-	cfg.mutex.Lock()
-	// First remove the original pokemon
-	delete(cfg.pokedex, apiName)
-	// Then add the evolved form
-	cfg.pokedex[evolvedName] = evolvedData
-	cfg.mutex.Unlock()
+	// Add evolved form to pokedex, keeping the user's notes and box and
+	// remembering the previous form so the evolution can be undone
+	err = cfg.pokedex.Replace(apiName, evolvedName, func(entry pokedex.Entry) (pokedex.Entry, error) {
+		return entry.EvolveInto(apiName, evolvedData, time.Now()), nil
+	})
+	if err != nil {
+		return commandResult{}, pokedexError(err, apiName)
+	}
 
-	fmt.Printf("Evolving %s into %s...\n", nameInfo.Formatted, evolvedFormattedName)
-	fmt.Printf("Congratulations! Your %s evolved into %s!\n", nameInfo.Formatted, evolvedFormattedName)
-	fmt.Println("-----")
+	message.WriteString(i18n.Sprintf("Evolving %s into %s...\n", nameInfo.Formatted, evolvedFormattedName))
+	message.WriteString(i18n.Sprintf("Congratulations! Your %s evolved into %s!\n", nameInfo.Formatted, evolvedFormattedName))
+	message.WriteString(i18n.Sprintf("Changed your mind? Use 'devolve %s' to undo the evolution.\n", evolvedName))
+	publishEvent(cfg, mqttEvent{Event: mqttEventEvolved, Pokemon: apiName, Evolved: evolvedName})
+	evolved.EvolvedInto, evolved.Evolved = evolvedName, true
 
 	// Auto-save after evolving
 	if err := UpdatePokedexAndSave(cfg); err != nil {
@@ -150,8 +148,213 @@ func commandEvolve(cfg *config, params []string) error {
 		// since we still want to show the success message
 		HandleCommandError(cfg, "evolve", err)
 	}
+	return commandResult{Message: message.String(), Data: evolved}, nil
+}
+
+// selectEvolution picks one of a Pokémon's possible evolutions.
+// A Pokémon with a single evolution needs no selection. Otherwise the selection
+// may be the evolution's number in the list or its name; if it's missing or
+// doesn't match, the options are listed and an error is returned.
+//
+// Parameters:
+//   - nameInfo: Structured information about the evolving Pokémon's name
+//   - evolutions: The Pokémon's possible evolutions
+//   - selection: The user's choice, or an empty string if none was given
+//
+// Returns:
+//   - The chosen evolution
+//   - An error if a choice is needed and the selection doesn't identify one
+func selectEvolution(nameInfo PokemonNameInfo, evolutions []pokeapi.ChainLink, selection string) (pokeapi.ChainLink, error) {
+	if len(evolutions) == 1 {
+		// Only one possible evolution
+		return evolutions[0], nil
+	}
+
+	if selection != "" {
+		// Valid numeric selection
+		selectionNum, err := strconv.Atoi(selection)
+		if err == nil && selectionNum > 0 && selectionNum <= len(evolutions) {
+			return evolutions[selectionNum-1], nil
+		}
+
+		// Try to match by name
+		for _, evo := range evolutions {
+			if strings.EqualFold(ConvertToAPIFormat(selection), evo.Species.Name) {
+				return evo, nil
+			}
+		}
+	}
+
+	// Show evolution options
+	i18n.Printf("%s can evolve into multiple forms. Choose one:\n", nameInfo.Formatted)
+	for i, evolution := range evolutions {
+		i18n.Printf("%d. %s (%s)\n", i+1, FormatPokemonName(evolution.Species.Name),
+			strings.Join(describeEvolutionDetails(evolution.EvolutionDetails), "; "))
+	}
+	if selection != "" {
+		return pokeapi.ChainLink{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Invalid evolution selection: '%s'", selection), nil)
+	}
+	return pokeapi.ChainLink{}, errorhandling.NewInvalidInputError(
+		i18n.Sprintf("Please specify which evolution to use (e.g., 'evolve %s 1')", nameInfo.APIFormat), nil)
+}
+
+// writeEvolutionPreview writes what an evolution will change: the evolution's
+// trigger conditions, then the changes to the Pokémon's data (see writeDataDiff).
+//
+// Parameters:
+//   - w: Where to write the preview
+//   - cfg: The application configuration
+//   - fromName: The formatted name of the evolving Pokémon
+//   - from: The evolving Pokémon's data
+//   - toName: The formatted name of the evolved form
+//   - to: The evolved form's data
+//   - details: The conditions under which the evolution happens in the games
+func writeEvolutionPreview(w io.Writer, cfg *config, fromName string, from pokeapi.PokemonDataResp, toName string, to pokeapi.PokemonDataResp, details []pokeapi.EvolutionDetail) {
+	i18n.Fprintf(w, "%s can evolve into %s.\n", fromName, toName)
+	i18n.Fprintln(w, "In the games, it evolves by:")
+	for _, condition := range describeEvolutionDetails(details) {
+		i18n.Fprintf(w, " - %s\n", condition)
+	}
+	writeDataDiff(w, cfg, fromName, from, toName, to)
+}
+
+// formatStatChange formats a change in a stat with an explicit sign (e.g. "+15", "-5", "0").
+func formatStatChange(change int) string {
+	if change > 0 {
+		return fmt.Sprintf("+%d", change)
+	}
+	return fmt.Sprint(change)
+}
+
+// describeEvolutionDetails describes each way an evolution can be triggered.
+// The API often lists the same conditions once per game, so duplicates are removed.
+//
+// Parameters:
+//   - details: The evolution details from the evolution chain
+//
+// Returns:
+//   - One description per distinct set of conditions (e.g. "Reach level 16")
+func describeEvolutionDetails(details []pokeapi.EvolutionDetail) []string {
+	var descriptions []string
+	seen := make(map[string]bool)
+	for _, detail := range details {
+		description := describeEvolutionTrigger(detail)
+		if !seen[description] {
+			seen[description] = true
+			descriptions = append(descriptions, description)
+		}
+	}
+	if len(descriptions) == 0 {
+		descriptions = append(descriptions, i18n.T("Unknown conditions"))
+	}
+	return descriptions
+}
+
+// describeEvolutionTrigger describes the conditions of a single way to evolve,
+// such as "Use a Thunder Stone" or "Level up with high friendship (160+) during the day".
+//
+// Parameters:
+//   - detail: The evolution detail to describe
+//
+// Returns:
+//   - A one-line description of the trigger and its conditions
+func describeEvolutionTrigger(detail pokeapi.EvolutionDetail) string {
+	var parts []string
+
+	// The trigger itself
+	switch detail.Trigger.Name {
+	case "level-up":
+		if detail.MinLevel > 0 {
+			parts = append(parts, i18n.Sprintf("Reach level %d", detail.MinLevel))
+		} else {
+			parts = append(parts, i18n.T("Level up"))
+		}
+	case "trade":
+		if detail.TradeSpecies != nil {
+			parts = append(parts, i18n.Sprintf("Trade for %s", FormatPokemonName(detail.TradeSpecies.Name)))
+		} else {
+			parts = append(parts, "Trade")
+		}
+	case "use-item":
+		if detail.Item != nil {
+			parts = append(parts, i18n.Sprintf("Use a %s", FormatItemName(detail.Item.Name)))
+		} else {
+			parts = append(parts, i18n.T("Use an item"))
+		}
+	case "shed":
+		parts = append(parts, i18n.T("Level up with an empty party slot and a spare Poké Ball"))
+	default:
+		parts = append(parts, FormatMoveName(detail.Trigger.Name))
+	}
+
+	// Any additional conditions
+	if detail.Item != nil && detail.Trigger.Name != "use-item" {
+		parts = append(parts, i18n.Sprintf("using a %s", FormatItemName(detail.Item.Name)))
+	}
+	if detail.HeldItem != nil {
+		parts = append(parts, i18n.Sprintf("while holding a %s", FormatItemName(detail.HeldItem.Name)))
+	}
+	if detail.MinHappiness != nil {
+		parts = append(parts, i18n.Sprintf("with high friendship (%d+)", *detail.MinHappiness))
+	}
+	if detail.MinAffection != nil {
+		parts = append(parts, i18n.Sprintf("with high affection (%d+)", *detail.MinAffection))
+	}
+	if detail.MinBeauty != nil {
+		parts = append(parts, i18n.Sprintf("with high beauty (%d+)", *detail.MinBeauty))
+	}
+	if detail.KnownMove != nil {
+		parts = append(parts, i18n.Sprintf("knowing %s", FormatMoveName(detail.KnownMove.Name)))
+	}
+	if detail.KnownMoveType != nil {
+		parts = append(parts, i18n.Sprintf("knowing a %s-type move", FormatTypeName(detail.KnownMoveType.Name)))
+	}
+	if detail.PartySpecies != nil {
+		parts = append(parts, i18n.Sprintf("with %s in the party", FormatPokemonName(detail.PartySpecies.Name)))
+	}
+	if detail.PartyType != nil {
+		parts = append(parts, i18n.Sprintf("with a %s-type Pokémon in the party", FormatTypeName(detail.PartyType.Name)))
+	}
+	if detail.RelativePhysicalStats != nil {
+		switch *detail.RelativePhysicalStats {
+		case 1:
+			parts = append(parts, i18n.T("with Attack higher than Defense"))
+		case 0:
+			parts = append(parts, i18n.T("with Attack equal to Defense"))
+		case -1:
+			parts = append(parts, i18n.T("with Attack lower than Defense"))
+		}
+	}
+	if detail.Location != nil {
+		parts = append(parts, i18n.Sprintf("at %s", FormatLocationName(detail.Location.Name)))
+	}
+	switch detail.TimeOfDay {
+	case "":
+		// Any time of day
+	case "day":
+		parts = append(parts, i18n.T("during the day"))
+	case "night":
+		parts = append(parts, i18n.T("at night"))
+	default:
+		parts = append(parts, i18n.Sprintf("at %s", detail.TimeOfDay))
+	}
+	if detail.NeedsOverworldRain {
+		parts = append(parts, i18n.T("while it's raining"))
+	}
+	if detail.TurnUpsideDown {
+		parts = append(parts, i18n.T("with the console held upside down"))
+	}
+	if detail.Gender != nil {
+		switch *detail.Gender {
+		case 1:
+			parts = append(parts, "(female only)")
+		case 2:
+			parts = append(parts, "(male only)")
+		}
+	}
 
-	return nil
+	return strings.Join(parts, " ")
 }
 
 // findEvolutionsFor recursively searches an evolution chain to find and return
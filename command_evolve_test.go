@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestDescribeEvolutionTrigger tests that evolution details from the API are
+// described in plain language
+func TestDescribeEvolutionTrigger(t *testing.T) {
+	cases := []struct {
+		detail   string
+		expected string
+	}{
+		{
+			detail:   `{"trigger": {"name": "level-up"}, "min_level": 16}`,
+			expected: "Reach level 16",
+		},
+		{
+			detail:   `{"trigger": {"name": "use-item"}, "item": {"name": "thunder-stone"}, "min_level": null}`,
+			expected: "Use a Thunder Stone",
+		},
+		{
+			detail:   `{"trigger": {"name": "trade"}, "held_item": {"name": "metal-coat"}}`,
+			expected: "Trade while holding a Metal Coat",
+		},
+		{
+			detail:   `{"trigger": {"name": "level-up"}, "min_happiness": 160, "time_of_day": "night"}`,
+			expected: "Level up with high friendship (160+) at night",
+		},
+		{
+			detail:   `{"trigger": {"name": "level-up"}, "min_level": 20, "relative_physical_stats": -1}`,
+			expected: "Reach level 20 with Attack lower than Defense",
+		},
+		{
+			detail:   `{"trigger": {"name": "level-up"}, "known_move": {"name": "ancient-power"}, "gender": 1}`,
+			expected: "Level up knowing Ancient Power (female only)",
+		},
+	}
+
+	for _, c := range cases {
+		var detail pokeapi.EvolutionDetail
+		if err := json.Unmarshal([]byte(c.detail), &detail); err != nil {
+			t.Fatalf("Failed to decode %s: %v", c.detail, err)
+		}
+		if got := describeEvolutionTrigger(detail); got != c.expected {
+			t.Errorf("describeEvolutionTrigger(%s) = %q, expected %q", c.detail, got, c.expected)
+		}
+	}
+}
+
+// TestDescribeEvolutionDetails tests that conditions repeated for several games
+// are only listed once
+func TestDescribeEvolutionDetails(t *testing.T) {
+	stone := pokeapi.EvolutionDetail{
+		Trigger: pokeapi.NamedAPIResource{Name: "use-item"},
+		Item:    &pokeapi.NamedAPIResource{Name: "water-stone"},
+	}
+	level := pokeapi.EvolutionDetail{
+		Trigger:  pokeapi.NamedAPIResource{Name: "level-up"},
+		Location: &pokeapi.NamedAPIResource{Name: "mt-coronet"},
+	}
+
+	got := describeEvolutionDetails([]pokeapi.EvolutionDetail{stone, level, stone})
+	expected := []string{"Use a Water Stone", "Level up at Mt Coronet"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+
+	if got := describeEvolutionDetails(nil); !reflect.DeepEqual(got, []string{"Unknown conditions"}) {
+		t.Errorf("Expected unknown conditions for missing details, got %v", got)
+	}
+}
@@ -1,13 +1,15 @@
 package main
 
 import (
-	"fmt"
 	"os"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 )
 
 // commandExit handles the exit command, which gracefully terminates the program.
 // Before exiting, it ensures that the user's Pokédex data is saved to disk
-// to prevent data loss.
+// to prevent data loss; if auto-save is off, the user is asked first. In batch mode, the summary of the commands run is
+// printed and the exit status reports whether any of them failed.
 //
 // Parameters:
 //   - cfg: The application configuration containing the Pokédex data
@@ -16,16 +18,26 @@ import (
 // Returns:
 //   - Never returns as the program exits
 func commandExit(cfg *config, params []string) error {
-	// Save the Pokédex data before exiting
-	err := savePokedexData(cfg)
-	if err != nil {
-		fmt.Printf("Warning: Could not save Pokédex data: %v\n", err)
+	// Save the Pokédex data before exiting, unless auto-save is off and the
+	// user chooses not to. In batch mode the save is made when the batch is
+	// finished below
+	waitForBackgroundSaves(cfg)
+	if cfg.sandbox != nil {
+		i18n.Println("The changes made in the sandbox were discarded.")
+	}
+	if shouldSaveOnExit(cfg) {
+		err := savePokedexData(cfg)
+		if err != nil {
+			i18n.Printf("Warning: Could not save Pokédex data: %v\n", err)
+		} else if cfg.batch == nil {
+			i18n.Println("Pokédex data saved!")
+		}
 	} else {
-		fmt.Println("Pokédex data saved!")
+		i18n.Println("Your changes were not saved.")
 	}
 
-	fmt.Println("Thanks for using the Pokédex! See you next time!")
-	fmt.Println("-----")
-	os.Exit(0)
+	i18n.Println("Thanks for using the Pokédex! See you next time!")
+	printSeparator()
+	os.Exit(finishBatch(cfg))
 	return nil // This line is never reached but keeps the compiler happy
 }
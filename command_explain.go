@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// commandExplain describes an error code shown alongside an error message.
+// It prints the likely cause of the error and suggested steps to fix it.
+// When called without a code, it lists every error code with a short title.
+//
+// Parameters:
+//   - cfg: The application configuration (not used in this command)
+//   - params: Command parameters where params[0] (optional) is the error code
+//
+// Returns:
+//   - An error if the error code is not recognized
+func commandExplain(cfg *config, params []string) error {
+	// Without a code, list all codes so the user can find the right one
+	if len(params) == 0 {
+		i18n.Println("Error codes:")
+		table := NewTable("Code", "Description")
+		for _, info := range errorhandling.AllCodes() {
+			table.AddRow(info.Code, info.Title)
+		}
+		table.Print()
+		i18n.Println("Use 'explain <code>' for details about a specific error.")
+		printSeparator()
+		return nil
+	}
+
+	// Input is lowercased by the REPL, but codes are uppercase
+	code := strings.ToUpper(params[0])
+	info, ok := errorhandling.LookupCode(code)
+	if !ok {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown error code '%s'. Use 'explain' to list all codes.", code), nil)
+	}
+
+	i18n.Printf("%s: %s\n", info.Code, info.Title)
+	i18n.Printf("Likely cause: %s\n", info.Cause)
+	i18n.Printf("How to fix it: %s\n", info.Remedy)
+	printSeparator()
+	return nil
+}
@@ -4,10 +4,17 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
 )
 
 // ValidateLocationParam checks if a location number parameter was provided
@@ -26,96 +33,309 @@ func ValidateLocationParam(params []string) (string, error) {
 	return params[0], nil
 }
 
-// commandExplore retrieves and displays Pokémon that can be found at a specific location.
+// exploration is the data behind the explore command's result.
+type exploration struct {
+	Location  string   `json:"location"`   // The API name of the location area
+	Pokemon   []string `json:"pokemon"`    // The API names of the Pokémon found there, in the order they're numbered
+	NewlySeen int      `json:"newly_seen"` // The number of Pokémon seen for the first time
+}
+
+// exploreResult finds the Pokémon that can be found at a specific location.
 // This command is a key part of the exploration gameplay, allowing users to discover
 // which Pokémon they might encounter at a given location area before attempting to catch them.
 //
 // The function takes a location number as a parameter, which corresponds to the location
-// displayed by the map command (1-20). It then fetches a list of Pokémon that can be
-// encountered at that location and displays them to the user.
+// displayed by the map command (1-20, unless the page size was changed). It then fetches
+// a list of Pokémon that can be encountered at that location and lists them.
 //
 // Parameters:
 //   - cfg: The application configuration containing the API client and recent locations
 //   - params: Command parameters where params[0] is the location number to explore
 //
+// With "all" instead of a number, every location on the map page is explored
+// at once (see exploreAll).
+//
 // Returns:
+//   - The Pokémon found
 //   - An error if no location number is provided, if the number is invalid,
 //     if the map hasn't been viewed yet, or if there's an issue with the API request
-func commandExplore(cfg *config, params []string) error {
+func exploreResult(cfg *config, params []string) (commandResult, error) {
+	if len(params) == 1 && params[0] == "all" {
+		return exploreAll(cfg)
+	}
+
+	apiLocationName, err := locationFromParams(cfg, params)
+	if err != nil {
+		return commandResult{}, err
+	}
+
+	resp, newlySeen, err := exploreArea(cfg, apiLocationName)
+	if err != nil {
+		return commandResult{}, err
+	}
+	explored := exploration{Location: apiLocationName, Pokemon: []string{}, NewlySeen: newlySeen}
+
+	var message strings.Builder
+	message.WriteString(i18n.Sprintf("Exploring %s...\n", FormatLocationName(apiLocationName)))
+
+	// List the Pokémon found at this location
+	if len(resp.PokemonEncounters) == 0 {
+		i18n.Fprintln(&message, "No Pokémon found at this location.")
+	} else {
+		i18n.Fprintln(&message, "Found Pokémon:")
+		// Only add a column for the conditions if some Pokémon need them
+		conditional := slices.ContainsFunc(resp.PokemonEncounters, func(e pokeapi.PokemonEncounter) bool {
+			return formatConditions(e) != ""
+		})
+		table := NewTable("#", "Pokémon", "Found by")
+		if conditional {
+			table = NewTable("#", "Pokémon", "Found by", "When")
+		}
+		for i, encounter := range resp.PokemonEncounters {
+			formattedName := FormatPokemonName(encounter.Pokemon.Name)
+			if conditional {
+				table.AddRow(fmt.Sprint(i+1), formattedName, formatPools(encounter), formatConditions(encounter))
+			} else {
+				table.AddRow(fmt.Sprint(i+1), formattedName, formatPools(encounter))
+			}
+			explored.Pokemon = append(explored.Pokemon, encounter.Pokemon.Name)
+		}
+		table.Render(&message)
+		if conditional {
+			message.WriteString(i18n.Sprintf("Some Pokémon only turn up at certain times. Right now it's: %s\n", currentConditions(time.Now())))
+		}
+		// Remember the numbers, so 'catch 3' or '#3' can choose a Pokémon from the list
+		cfg.SetSelection(selectPokemon, "explore", explored.Pokemon)
+		i18n.Fprintln(&message, "Use 'catch <number>' to try to catch one of them.")
+	}
+	if newlySeen > 0 {
+		message.WriteString(i18n.Sprintf("%d new Pokémon registered as seen. Use 'seen' to browse them.\n", newlySeen))
+	}
+	return commandResult{Message: message.String(), Data: explored}, nil
+}
+
+// exploreArea looks up the Pokémon found in a location area. They're
+// remembered so that catches can record where they happened, and registered as
+// seen, and the visit is counted. This is shared by the explore command and the serve mode's Explore method.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client and Pokédex
+//   - apiLocationName: The location area's name in API format
+//
+// Returns:
+//   - The location area's encounters
+//   - The number of Pokémon seen for the first time
+//   - An error if the location area doesn't exist or the API request fails
+func exploreArea(cfg *config, apiLocationName string) (pokeapi.LocationExploreResp, int, error) {
+	resp, err := cfg.pokeapiClient.ExploreLocation(apiLocationName)
+	if err != nil {
+		return pokeapi.LocationExploreResp{}, 0, err
+	}
+
+	// Remember what was found here so that catches can record where they happened
+	found := make([]string, 0, len(resp.PokemonEncounters))
+	for _, encounter := range resp.PokemonEncounters {
+		found = append(found, encounter.Pokemon.Name)
+	}
+	cfg.SetExploredArea(apiLocationName, found)
+
+	// Count the visit, and register everything found here as seen, with where it was first spotted
+	recordVisit(cfg, "explore", apiLocationName, false)
+	return resp, recordSeen(cfg, "explore", apiLocationName, found...), nil
+}
+
+// locationFromParams looks up the location area chosen by its number in the
+// list displayed by the map command (1-20 by default), or by the name of a bookmarked area.
+//
+// Parameters:
+//   - cfg: The application configuration containing the recent locations
+//   - params: Command parameters with the location number, or the words of a bookmarked area's name
+//
+// Returns:
+//   - The API name of the location area
+//   - An error if no location is provided, if it isn't a valid number or a bookmark,
+//     or if the map hasn't been viewed yet
+func locationFromParams(cfg *config, params []string) (string, error) {
 	// Validate the location parameter
 	locNumStr, err := ValidateLocationParam(params)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "explore", err) {
-			return err
-		}
-		return nil
+		return "", err
 	}
 
 	// Parse the location number from input
 	locationNumber, err := strconv.Atoi(locNumStr)
 	if err != nil {
-		// Create a specific error for this case
-		invalidNumErr := errorhandling.NewInvalidInputError("Invalid location number: please provide a number between 1-20", err)
-
-		// Use standardized error handling
-		if HandleCommandError(cfg, "explore", invalidNumErr) {
-			return invalidNumErr
+		// A bookmarked area can be chosen by name
+		if location := ConvertToAPIFormat(strings.Join(params, " ")); slices.Contains(cfg.Bookmarks(), location) {
+			return location, nil
 		}
-		return nil
+		return "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Invalid location number: please provide a number between 1-%d", mapPageSize(cfg)), err)
 	}
 
 	// Check if the location list exists
 	if len(cfg.recentLocations) == 0 {
-		// Create a specific error for this case
-		noLocationsErr := errorhandling.NewInvalidInputError("No location list available, please run the 'map' command first", nil)
-
-		// Use standardized error handling
-		if HandleCommandError(cfg, "explore", noLocationsErr) {
-			return noLocationsErr
-		}
-		return nil
+		return "", errorhandling.NewInvalidInputError("No location list available, please run the 'map' command first", nil)
 	}
 
 	// Check if the number is in range (1-based indexing)
 	if locationNumber < 1 || locationNumber > len(cfg.recentLocations) {
-		// Create a specific error for this case
-		outOfRangeErr := errorhandling.NewInvalidInputError(
-			fmt.Sprintf("Location number %d is out of range (valid range: 1-%d)",
+		return "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Location number %d is out of range (valid range: 1-%d)",
 				locationNumber, len(cfg.recentLocations)), nil)
+	}
+
+	// Convert from 1-based user input to 0-based array index
+	return cfg.recentLocations[locationNumber-1].Name, nil
+}
+
+// exploreAllWorkers is the number of location areas looked up at the same time by 'explore all'.
+const exploreAllWorkers = 4
+
+// areaSurvey is what 'explore all' found in one location area.
+type areaSurvey struct {
+	resp     pokeapi.LocationExploreResp // The area's encounters
+	err      error                       // Why the area couldn't be looked up, if it couldn't
+	surveyed bool                        // Whether it was looked up (false if the survey was cancelled first)
+}
+
+// surveyedArea is a location area in the result of 'explore all'.
+type surveyedArea struct {
+	Number   int      `json:"number"`             // The area's number on the map page
+	Location string   `json:"location"`           // The API name of the location area
+	Pokemon  []string `json:"pokemon,omitempty"`  // The API names of the Pokémon found there
+	Uncaught []string `json:"uncaught,omitempty"` // The API names of those not in the Pokédex, without repeats
+	Error    string   `json:"error,omitempty"`    // Why the area couldn't be explored, if it couldn't
+}
+
+// areaSurveys is the data behind the result of 'explore all'.
+type areaSurveys struct {
+	Areas     []surveyedArea `json:"areas"`               // The areas on the map page, in order
+	NewlySeen int            `json:"newly_seen"`          // The number of Pokémon seen for the first time
+	Cancelled bool           `json:"cancelled,omitempty"` // Whether the survey was cancelled before every area was looked up
+}
+
+// exploreAll looks up every location area on the current map page at once,
+// and shows a table of the Pokémon in each that haven't been caught yet. The
+// encounters are registered as seen, as with 'explore', but the explored area
+// is left as it was, so catches are still recorded where the user last went.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client and recent locations
+//
+// Returns:
+//   - What was found in each area
+//   - An error if the map hasn't been viewed yet
+func exploreAll(cfg *config) (commandResult, error) {
+	if len(cfg.recentLocations) == 0 {
+		return commandResult{}, errorhandling.NewInvalidInputError("No location list available, please run the 'map' command first", nil)
+	}
+
+	var message strings.Builder
+	message.WriteString(i18n.Sprintf("Exploring %d locations...\n", len(cfg.recentLocations)))
+	ctx := commandContext(cfg)
+	surveys := surveyAreas(ctx, cfg.pokeapiClient.WithContext(ctx), cfg.recentLocations)
+
+	table := NewTable("#", "Location", "Not caught yet")
+	var failures []string
+	var surveyed areaSurveys
+	for i, survey := range surveys {
+		if !survey.surveyed {
+			continue
+		}
+		location := cfg.recentLocations[i].Name
+		area := surveyedArea{Number: i + 1, Location: location}
+		if survey.err != nil {
+			area.Error = survey.err.Error()
+			surveyed.Areas = append(surveyed.Areas, area)
+			failures = append(failures, fmt.Sprintf("%s: %v", FormatLocationName(location), survey.err))
+			continue
+		}
+		for _, encounter := range survey.resp.PokemonEncounters {
+			area.Pokemon = append(area.Pokemon, encounter.Pokemon.Name)
+			if !hasPokemon(cfg, encounter.Pokemon.Name) && !slices.Contains(area.Uncaught, encounter.Pokemon.Name) {
+				area.Uncaught = append(area.Uncaught, encounter.Pokemon.Name)
+			}
+		}
+		surveyed.NewlySeen += recordSeen(cfg, "explore", location, area.Pokemon...)
+		surveyed.Areas = append(surveyed.Areas, area)
 
-		// Use standardized error handling
-		if HandleCommandError(cfg, "explore", outOfRangeErr) {
-			return outOfRangeErr
+		notable := uncaughtNames(cfg, area.Pokemon)
+		switch {
+		case len(area.Pokemon) == 0:
+			table.AddRow(fmt.Sprint(i+1), FormatLocationName(location), i18n.T("No Pokémon"))
+		case len(notable) == 0:
+			table.AddRow(fmt.Sprint(i+1), FormatLocationName(location), i18n.T("All caught"))
+		default:
+			table.AddRow(fmt.Sprint(i+1), FormatLocationName(location), strings.Join(notable, ", "))
 		}
-		return nil
 	}
+	table.Render(&message)
 
-	// Convert from 1-based user input to 0-based array index
-	apiLocationName := cfg.recentLocations[locationNumber-1].Name
-	formattedLocation := FormatLocationName(apiLocationName)
-	fmt.Printf("Exploring %s...\n", formattedLocation)
+	if ctx.Err() != nil {
+		surveyed.Cancelled = true
+		message.WriteString(i18n.Sprintf("Exploring cancelled after %d of %d locations.\n", len(surveyed.Areas), len(surveys)))
+	}
+	if len(failures) > 0 {
+		message.WriteString(i18n.Sprintf("Couldn't explore %d locations:\n", len(failures)))
+		for _, failure := range failures {
+			message.WriteString(i18n.Sprintf(" - %s\n", failure))
+		}
+	}
+	if surveyed.NewlySeen > 0 {
+		message.WriteString(i18n.Sprintf("%d new Pokémon registered as seen. Use 'seen' to browse them.\n", surveyed.NewlySeen))
+	}
+	i18n.Fprintln(&message, "Use 'explore <location number>' to go to one of them.")
+	return commandResult{Message: message.String(), Data: surveyed}, nil
+}
 
-	// Make the API request to explore the location
-	resp, err := cfg.pokeapiClient.ExploreLocation(apiLocationName)
-	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "explore", err) {
-			return err
+// surveyAreas looks up the encounters of several location areas, a few at a
+// time. Areas already looked up are served from the cache. No more areas are
+// looked up once ctx is cancelled.
+//
+// Parameters:
+//   - ctx: Cancels the survey
+//   - client: The API client to look the areas up with
+//   - locations: The location areas to look up
+//
+// Returns:
+//   - What was found in each area, in the order of locations
+func surveyAreas(ctx context.Context, client *pokeapi.Client, locations []pokeapi.NamedAPIResource) []areaSurvey {
+	surveys := make([]areaSurvey, len(locations))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range exploreAllWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				resp, err := client.ExploreLocation(locations[i].Name)
+				surveys[i] = areaSurvey{resp: resp, err: err, surveyed: ctx.Err() == nil}
+			}
+		}()
+	}
+queue:
+	for i := range locations {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break queue
 		}
-		return nil
 	}
+	close(jobs)
+	wg.Wait()
+	return surveys
+}
 
-	// Display the Pokémon found at this location
-	if len(resp.PokemonEncounters) == 0 {
-		fmt.Println("No Pokémon found at this location.")
-	} else {
-		fmt.Println("Found Pokémon:")
-		for i, encounter := range resp.PokemonEncounters {
-			formattedName := FormatPokemonName(encounter.Pokemon.Name)
-			fmt.Printf("%d. %s\n", i+1, formattedName)
+// uncaughtNames returns the display names of the Pokémon that aren't in the
+// Pokédex, without repeats and in the order given.
+func uncaughtNames(cfg *config, names []string) []string {
+	var uncaught []string
+	for _, name := range names {
+		formatted := FormatPokemonName(name)
+		if !hasPokemon(cfg, name) && !slices.Contains(uncaught, formatted) {
+			uncaught = append(uncaught, formatted)
 		}
 	}
-	fmt.Println("-----")
-	return nil
+	return uncaught
 }
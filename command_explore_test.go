@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestUncaughtNames tests that caught Pokémon and repeats are left out
+func TestUncaughtNames(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric")))
+
+	got := uncaughtNames(cfg, []string{"tentacool", "pikachu", "mr-mime", "tentacool"})
+	if want := []string{"Tentacool", "Mr. Mime"}; !slices.Equal(got, want) {
+		t.Errorf("uncaughtNames() = %v, want %v", got, want)
+	}
+}
+
+// TestSurveyAreasCancelled tests that no area counts as looked up once the
+// survey has been cancelled
+func TestSurveyAreasCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client := pokeapi.NewClientWithOptions(pokeapi.ClientOptions{CacheInterval: time.Hour, Transport: notFoundTransport{}})
+	locations := []pokeapi.NamedAPIResource{{Name: "viridian-forest-area"}, {Name: "mt-moon-1f"}}
+
+	for i, survey := range surveyAreas(ctx, client.WithContext(ctx), locations) {
+		if survey.surveyed {
+			t.Errorf("Expected %s not to be looked up after cancelling", locations[i].Name)
+		}
+	}
+}
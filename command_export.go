@@ -0,0 +1,148 @@
+// This file implements the export command, which writes the user's
+// collection to a file in a format other programs can open.
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// exportUsage describes the forms of the export command.
+const exportUsage = "Usage: export [--select] ical <file> | export [--select] showdown <file>"
+
+// commandExport writes the collection to a file. Supported forms:
+//   - export ical <file>: Write an iCalendar file with an event for each catch
+//   - export showdown <file>: Write the party as a Pokémon Showdown team
+//
+// With --select, the Pokémon to export are chosen with the picker (see
+// multiselect_utils.go) instead: the catches to put in the calendar, or the
+// team's members, which needn't be in the party.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters where params[0] is the format and the rest is the file path
+//
+// Returns:
+//   - An error if the format is unknown, no file is given, or the file can't be written
+func commandExport(cfg *config, params []string) error {
+	var err error
+	params, selecting := takeFlag(params, selectFlag)
+	if len(params) < 2 {
+		err = errorhandling.NewInvalidInputError(exportUsage, nil)
+	} else {
+		path := strings.Join(params[1:], " ")
+		switch strings.ToLower(params[0]) {
+		case "ical", "ics":
+			entries := cfg.pokedex.List()
+			if selecting {
+				entries, err = pickPokemon(cfg, i18n.T("Choose the catches to export:"), entries)
+			}
+			if err == nil {
+				err = exportICal(entries, path)
+			}
+		case "showdown":
+			team := partyOf(cfg.pokedex.List())
+			if selecting {
+				team, err = pickPokemon(cfg, i18n.T("Choose the team to export:"), cfg.pokedex.List())
+			}
+			if err == nil {
+				err = exportShowdown(team, selecting, path)
+			}
+		default:
+			err = errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Unknown export format '%s'. %s", params[0], exportUsage), nil)
+		}
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "export", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// exportICal writes the catch history of some Pokémon to an iCalendar file.
+func exportICal(entries []pokedex.NamedEntry, path string) error {
+	if len(entries) == 0 {
+		i18n.Println("Nothing was chosen, so nothing was exported.")
+		return nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Could not create file '%s'", path), err)
+	}
+	defer file.Close()
+
+	events, err := writeICal(file, entries, time.Now())
+	if err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not write file '%s'", path), err)
+	}
+
+	i18n.Printf("Wrote %d catches to %s. Import it into a calendar app to browse your collecting history.\n", events, path)
+	if undated := len(entries) - events; undated > 0 {
+		i18n.Printf("%d Pokémon caught before catch dates were recorded were left out.\n", undated)
+	}
+	return nil
+}
+
+// partyOf returns the Pokémon in the party, in the order given.
+func partyOf(entries []pokedex.NamedEntry) []pokedex.NamedEntry {
+	var party []pokedex.NamedEntry
+	for _, caught := range entries {
+		if caught.Entry.InParty() {
+			party = append(party, caught)
+		}
+	}
+	return party
+}
+
+// exportShowdown writes a team to a file as a Pokémon Showdown team: the
+// party, or the Pokémon the user chose. Only the first six Pokémon of the
+// party fit in a team, and no more than six can be chosen.
+func exportShowdown(team []pokedex.NamedEntry, chosen bool, path string) error {
+	switch {
+	case chosen && len(team) == 0:
+		i18n.Println("Nothing was chosen, so nothing was exported.")
+		return nil
+	case chosen && len(team) > showdownTeamSize:
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("A Showdown team has at most %d Pokémon, but %d were chosen", showdownTeamSize, len(team)), nil)
+	case len(team) == 0:
+		return errorhandling.NewInvalidInputError(
+			"Your party is empty. Take Pokémon out of storage with 'box remove <pokemon>' first", nil)
+	}
+	partySize := len(team)
+	team = team[:min(partySize, showdownTeamSize)]
+
+	file, err := os.Create(path)
+	if err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Could not create file '%s'", path), err)
+	}
+	defer file.Close()
+
+	err = writeShowdownTeam(file, team)
+	if err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not write file '%s'", path), err)
+	}
+
+	i18n.Printf("Wrote a team of %d Pokémon to %s. Paste it into Pokémon Showdown's teambuilder with 'Import from text'.\n", len(team), path)
+	if partySize > len(team) {
+		i18n.Printf("Showdown teams have at most %d Pokémon, so %d from your party were left out.\n", showdownTeamSize, partySize-len(team))
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"maps"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// fightUsage describes the parameters of the fight command.
+const fightUsage = "Usage: fight trainer [class]"
+
+// trainerCandidatesPerSlot is how many Pokémon are looked up for each slot on
+// a trainer's team. Looking up a few extra lets the closest match be chosen
+// without fetching every Pokémon of the class's types.
+const trainerCandidatesPerSlot = 3
+
+// commandFight implements the "fight" command, which battles an NPC trainer.
+// Supported forms:
+//   - fight trainer: Battle a trainer of a random class
+//   - fight trainer <class>: Battle a trainer of the given class (e.g. swimmer)
+//
+// The user's team is the one the teambuild command would suggest, and the
+// trainer's team is drawn from the Pokémon of the class's types, choosing those
+// closest in base stats to the user's team. Winning awards money.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is "trainer" and params[1] is an optional class
+//
+// Returns:
+//   - An error if the parameters are invalid or there's an issue with the API requests
+func commandFight(cfg *config, params []string) error {
+	var err error
+	if len(params) == 0 || params[0] != "trainer" {
+		err = errorhandling.NewInvalidInputError(fightUsage, nil)
+	} else {
+		err = fightTrainer(cfg, params[1:])
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "fight", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// fightTrainer generates a trainer, runs the battle, and awards the prize money.
+func fightTrainer(cfg *config, params []string) error {
+	class := trainerClasses[rand.Intn(len(trainerClasses))]
+	if len(params) > 0 {
+		var ok bool
+		if class, ok = findTrainerClass(strings.ToLower(params[0])); !ok {
+			ids := make([]string, 0, len(trainerClasses))
+			for _, c := range trainerClasses {
+				ids = append(ids, c.id)
+			}
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Unknown trainer class '%s'. Choose one of: %s", params[0], strings.Join(ids, ", ")), nil)
+		}
+	}
+
+	// Take a snapshot of the Pokédex so the API requests below don't hold the lock.
+	// Pokémon at the day care, or that have fainted and haven't been healed, can't battle.
+	entries := cfg.pokedex.All()
+	maps.DeleteFunc(entries, func(_ string, entry pokedex.Entry) bool { return entry.InDaycare() || hasFainted(entry) })
+	if len(entries) == 0 {
+		i18n.Println("You have no Pokémon to battle with. Catch some, or withdraw them from the day care.")
+		printSeparator()
+		return nil
+	}
+
+	chart, err := loadTypeChart(cfg, standardTypes)
+	if err != nil {
+		return err
+	}
+
+	// Field the team the teambuild command would suggest
+	candidates := make([]teamMember, 0, len(entries))
+	for name, entry := range entries {
+		candidates = append(candidates, newTeamMember(name, entry.PokemonDataResp))
+	}
+	team := buildTeam(chart, candidates)
+	player := make(map[string]pokeapi.PokemonDataResp, len(team))
+	teamTotal := 0
+	for _, member := range team {
+		player[member.name] = entries[member.name].PokemonDataResp
+		teamTotal += member.statTotal
+	}
+
+	opponent, err := generateTrainerTeam(cfg, class, teamTotal/len(team))
+	if err != nil {
+		return err
+	}
+	if len(opponent) == 0 {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not find any Pokémon for the %s", i18n.T(class.name)), nil)
+	}
+
+	opponentNames := make([]string, 0, len(opponent))
+	for _, data := range opponent {
+		opponentNames = append(opponentNames, FormatPokemonName(data.Name))
+	}
+	i18n.Printf("A %s wants to battle!\n", i18n.T(class.name))
+	i18n.Printf("The %s's team: %s\n", i18n.T(class.name), strings.Join(opponentNames, ", "))
+
+	result := simulateBattle(chart, player, opponent, rand.Float64)
+	for i, round := range result.rounds {
+		chance := int(round.chance*100 + 0.5)
+		if round.won {
+			i18n.Printf("Round %d: Your %s defeated %s (%d%% chance)\n", i+1,
+				FormatPokemonName(round.player), FormatPokemonName(round.opponent), chance)
+		} else {
+			i18n.Printf("Round %d: Your %s was defeated by %s (%d%% chance)\n", i+1,
+				FormatPokemonName(round.player), FormatPokemonName(round.opponent), chance)
+		}
+	}
+
+	if result.won {
+		prize := class.prize * len(opponent)
+		balance := cfg.AddMoney(prize)
+		i18n.Printf("You defeated the %s and earned ₽%d! You now have ₽%d.\n", i18n.T(class.name), prize, balance)
+	} else {
+		i18n.Printf("You lost to the %s.\n", i18n.T(class.name))
+	}
+	recordBattle(cfg, result)
+	awardExperience(cfg, roundsExperience(result, opponent))
+	awardEVs(cfg, roundsEVs(result, opponent))
+
+	// Auto-save the new balance, battle records, experience, and EVs
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since we still want to show the result
+		HandleCommandError(cfg, "fight", err)
+	}
+	printSeparator()
+	return nil
+}
+
+// recordBattle adds the rounds each of the user's Pokémon won to its entry
+// and awards any ribbons it has earned.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - result: The outcome of the battle
+func recordBattle(cfg *config, result battleResult) {
+	flawless := flawlessVictory(result)
+	wins := roundsWon(result)
+	names := make([]string, 0, len(wins))
+	for name := range wins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var earned []string
+		err := cfg.pokedex.Update(name, func(entry *pokedex.Entry) error {
+			entry.BattlesWon += wins[name]
+			for _, id := range battleRibbons(entry.BattlesWon, flawless) {
+				if entry.AwardRibbon(id) {
+					earned = append(earned, id)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			// Every fighter came from the Pokédex, so only a Pokémon removed since then is skipped
+			continue
+		}
+		for _, id := range earned {
+			i18n.Printf("%s earned the %s!\n", FormatPokemonName(name), ribbonName(id))
+			publishEvent(cfg, mqttEvent{Event: mqttEventRibbon, Pokemon: name, Ribbon: id})
+		}
+	}
+}
+
+// generateTrainerTeam picks a team for a trainer from the Pokémon of its class's types.
+// A random sample of those Pokémon is looked up, and the ones closest in base
+// stats to the user's team are chosen.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - class: The trainer's class
+//   - targetTotal: The average base stat total of the user's team
+//
+// Returns:
+//   - The trainer's team, in the order it is sent out
+//   - An error if the type or Pokémon data can't be retrieved
+func generateTrainerTeam(cfg *config, class trainerClass, targetTotal int) ([]pokeapi.PokemonDataResp, error) {
+	types := make([]pokeapi.TypeResp, 0, len(class.types))
+	for _, name := range class.types {
+		typeData, err := cfg.pokeapiClient.GetType(name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, typeData)
+	}
+
+	pool := trainerPool(types)
+	rand.Shuffle(len(pool), func(i, j int) { pool[i], pool[j] = pool[j], pool[i] })
+
+	candidates := make([]pokeapi.PokemonDataResp, 0, class.teamSize*trainerCandidatesPerSlot)
+	for _, name := range pool[:min(len(pool), class.teamSize*trainerCandidatesPerSlot)] {
+		data, err := cfg.pokeapiClient.GetPokemonData(name)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, data)
+	}
+	return selectTrainerTeam(candidates, targetTotal, class.teamSize), nil
+}
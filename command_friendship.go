@@ -0,0 +1,259 @@
+// This file implements the pet and play commands, which let the user spend time
+// with a caught Pokémon once a day to raise its happiness. The Pokémon reacts
+// in a way that suits its species, and the user is told when it becomes
+// friendly enough to reach an evolution that needs high friendship.
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// interaction is a way of spending time with a Pokémon that raises its happiness.
+type interaction struct {
+	id        string // Identifier stored with the Pokémon's interactions and typed as the command
+	happiness int    // The happiness it earns
+	already   string // The message shown when it has already been done today
+}
+
+// The interactions, each of which can be done once a day with each Pokémon
+var (
+	interactionPet  = interaction{id: "pet", happiness: 3, already: "You've already petted %s today. Try again tomorrow"}
+	interactionPlay = interaction{id: "play", happiness: 5, already: "You've already played with %s today. Try again tomorrow"}
+)
+
+// reaction is how a Pokémon reacts to each interaction. Each message has a %s
+// for the Pokémon's name.
+type reaction struct {
+	pet  string // The reaction to being petted
+	play string // The reaction to being played with
+}
+
+// genusReactions are the reactions of Pokémon whose English genus contains a
+// word (e.g. "Mouse" in Pikachu's "Mouse Pokémon"). They're checked in order,
+// before the reactions by type.
+var genusReactions = []struct {
+	word     string
+	reaction reaction
+}{
+	{"Mouse", reaction{"%s squeaks and nuzzles your hand.", "%s scurries around you in circles."}},
+	{"Puppy", reaction{"%s wags its tail and licks your hand.", "%s chases the stick you throw and brings it right back."}},
+	{"Cat", reaction{"%s purrs and curls up in your lap.", "%s pounces on the string you dangle."}},
+	{"Bird", reaction{"%s ruffles its feathers and coos.", "%s flutters around your head."}},
+	{"Turtle", reaction{"%s pokes its head out of its shell to be patted.", "%s squirts water at you from its shell."}},
+}
+
+// typeReactions are the reactions of Pokémon by their first type, for
+// Pokémon without a reaction for their genus.
+var typeReactions = map[string]reaction{
+	"fire":     {"%s's flame flickers warmly as you pet it.", "%s puffs little embers into the air as it plays."},
+	"water":    {"%s splashes you happily.", "%s sprays a fountain of water into the air."},
+	"electric": {"%s's fur crackles with static as you pet it.", "%s sends out happy little sparks as it runs around."},
+	"grass":    {"%s sways contentedly, smelling faintly of fresh leaves.", "%s rolls around in the grass."},
+	"ghost":    {"Your hand passes right through %s, but it seems to like the thought.", "%s plays hide-and-seek, vanishing and reappearing behind you."},
+	"psychic":  {"%s closes its eyes and hums contentedly.", "%s floats your things around in the air to amuse you."},
+	"rock":     {"%s is hard to the touch, but it leans into your hand.", "%s rolls around you, rumbling happily."},
+	"dragon":   {"%s lowers its head so you can scratch it.", "%s lets out a happy roar as it chases you."},
+}
+
+// defaultReaction is the reaction of Pokémon without a reaction for their genus or type.
+var defaultReaction = reaction{"%s looks happy to be petted.", "%s plays with you happily."}
+
+// commandPet pets a caught Pokémon, raising its happiness. Each Pokémon can
+// be petted once a day.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the Pokémon to pet
+//
+// Returns:
+//   - An error if the Pokémon isn't in the Pokédex, is at the day care, or has
+//     already been petted today
+func commandPet(cfg *config, params []string) error {
+	return interact(cfg, interactionPet, params)
+}
+
+// commandPlay plays with a caught Pokémon, raising its happiness more than
+// petting it. Each Pokémon can be played with once a day.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the Pokémon to play with
+//
+// Returns:
+//   - An error if the Pokémon isn't in the Pokédex, is at the day care, or has
+//     already been played with today
+func commandPlay(cfg *config, params []string) error {
+	return interact(cfg, interactionPlay, params)
+}
+
+// interact has an interaction with a Pokémon, shows its reaction, and saves
+// the happiness it earned.
+func interact(cfg *config, action interaction, params []string) error {
+	if err := interactWith(cfg, action, params, time.Now()); err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, action.id, err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+
+	// Auto-save the happiness earned
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the Pokédex has already been updated
+		HandleCommandError(cfg, action.id, err)
+	}
+	return nil
+}
+
+// interactWith has an interaction with a Pokémon at a time, and reports its
+// reaction, the happiness it earned, and any evolutions it's now friendly
+// enough to reach.
+func interactWith(cfg *config, action interaction, params []string, now time.Time) error {
+	apiName, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, params)
+	if err != nil {
+		return err
+	}
+	entry, err := GetTypedPokemonData(pokemonData, nameInfo.Formatted)
+	if err != nil {
+		return err
+	}
+	if entry.InDaycare() {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s is at the day care. Withdraw it first", nameInfo.Formatted), nil)
+	}
+	if entry.InteractedOn(action.id, now) {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf(action.already, nameInfo.Formatted), nil)
+	}
+
+	// The species gives the genus for the reaction and the base happiness that
+	// friendship starts from. Interacting still works without it.
+	var species *pokeapi.PokemonSpeciesResp
+	if data, err := cfg.pokeapiClient.GetPokemonSpecies(apiName); err == nil {
+		species = &data
+	} else if cfg.Settings().debugMode {
+		log.Printf("Could not load the species data of %s: %v", apiName, err)
+	}
+
+	var gained int
+	var before, after pokedex.Entry
+	err = cfg.pokedex.Update(apiName, func(e *pokedex.Entry) error {
+		before = *e
+		gained = min(e.Happiness+action.happiness, maxHappiness) - e.Happiness
+		e.Happiness += gained
+		e.RecordInteraction(action.id, now)
+		after = *e
+		return nil
+	})
+	if err != nil {
+		return pokedexError(err, apiName)
+	}
+
+	fmt.Println(i18n.Sprintf(pokemonReaction(entry.PokemonDataResp, species).message(action), nameInfo.Formatted))
+	if gained > 0 {
+		i18n.Printf("%s gained %d happiness.\n", nameInfo.Formatted, gained)
+	} else {
+		i18n.Printf("%s couldn't be any happier.\n", nameInfo.Formatted)
+	}
+	if species == nil {
+		return nil
+	}
+
+	from, to := friendship(before, *species), friendship(after, *species)
+	i18n.Printf("Friendship: %d/%d\n", to, maxHappiness)
+
+	// The evolution chain is only a hint here, so a failed lookup isn't reported
+	chain, err := cfg.pokeapiClient.GetEvolutionChainBySpecies(apiName)
+	if err != nil {
+		return nil
+	}
+	evolutions, err := findEvolutionsFor(apiName, chain.Chain)
+	if err != nil {
+		return nil
+	}
+	for _, evolution := range friendshipEvolutions(evolutions, from, to) {
+		i18n.Printf("%s is now friendly enough to evolve into %s! Use 'evolve %s' to evolve it.\n",
+			nameInfo.Formatted, FormatPokemonName(evolution), apiName)
+	}
+	return nil
+}
+
+// message returns the reaction to an interaction, as a format for the Pokémon's name.
+func (r reaction) message(action interaction) string {
+	if action.id == interactionPlay.id {
+		return r.play
+	}
+	return r.pet
+}
+
+// pokemonReaction chooses how a Pokémon reacts to interactions: by its genus if
+// there's a reaction for it, then by its first type.
+//
+// Parameters:
+//   - data: The Pokémon's data, including its types
+//   - species: The Pokémon's species data, including its genus, or nil if it isn't known
+//
+// Returns:
+//   - The Pokémon's reaction
+func pokemonReaction(data pokeapi.PokemonDataResp, species *pokeapi.PokemonSpeciesResp) reaction {
+	if species != nil {
+		for _, genus := range species.Genera {
+			if genus.Language.Name != "en" {
+				continue
+			}
+			for _, r := range genusReactions {
+				if strings.Contains(genus.Genus, r.word) {
+					return r.reaction
+				}
+			}
+		}
+	}
+	if types := pokemonTypes(data); len(types) > 0 {
+		if r, ok := typeReactions[types[0]]; ok {
+			return r
+		}
+	}
+	return defaultReaction
+}
+
+// friendship returns a Pokémon's friendship: the base happiness of its species
+// and the happiness it has gained, up to maxHappiness.
+func friendship(entry pokedex.Entry, species pokeapi.PokemonSpeciesResp) int {
+	base := 0
+	if species.BaseHappiness != nil {
+		base = *species.BaseHappiness
+	}
+	return min(base+entry.Happiness, maxHappiness)
+}
+
+// friendshipEvolutions returns the evolutions that need high friendship whose
+// requirement was reached as a Pokémon's friendship grew from one value to another.
+//
+// Parameters:
+//   - evolutions: The Pokémon's possible evolutions
+//   - from: The friendship it had
+//   - to: The friendship it has now
+//
+// Returns:
+//   - The names of the evolutions in API format
+func friendshipEvolutions(evolutions []pokeapi.ChainLink, from, to int) []string {
+	var names []string
+	for _, evolution := range evolutions {
+		for _, detail := range evolution.EvolutionDetails {
+			if detail.MinHappiness != nil && *detail.MinHappiness > from && *detail.MinHappiness <= to {
+				names = append(names, evolution.Species.Name)
+				break
+			}
+		}
+	}
+	return names
+}
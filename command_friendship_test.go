@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestPokemonReaction tests that reactions are chosen by genus first, then by
+// first type, with a default for everything else
+func TestPokemonReaction(t *testing.T) {
+	species := func(genus string) *pokeapi.PokemonSpeciesResp {
+		var data pokeapi.PokemonSpeciesResp
+		body := `{"genera": [{"genus": "` + genus + `", "language": {"name": "en"}}]}`
+		if err := json.Unmarshal([]byte(body), &data); err != nil {
+			t.Fatalf("Failed to build test species: %v", err)
+		}
+		return &data
+	}
+
+	tests := []struct {
+		name    string
+		data    pokeapi.PokemonDataResp
+		species *pokeapi.PokemonSpeciesResp
+		want    reaction
+	}{
+		{"genus", testMatchupPokemon(t, "pikachu", 320, "electric"), species("Mouse Pokémon"), genusReactions[0].reaction},
+		{"first type", testMatchupPokemon(t, "charmander", 309, "fire"), species("Lizard Pokémon"), typeReactions["fire"]},
+		{"no species", testMatchupPokemon(t, "gastly", 310, "ghost", "poison"), nil, typeReactions["ghost"]},
+		{"default", testMatchupPokemon(t, "eevee", 325, "normal"), species("Evolution Pokémon"), defaultReaction},
+	}
+	for _, tt := range tests {
+		if got := pokemonReaction(tt.data, tt.species); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+// TestFriendshipEvolutions tests that only evolutions whose friendship
+// requirement was just reached are reported
+func TestFriendshipEvolutions(t *testing.T) {
+	happiness := 160
+	evolutions := []pokeapi.ChainLink{
+		{
+			Species:          pokeapi.NamedAPIResource{Name: "espeon"},
+			EvolutionDetails: []pokeapi.EvolutionDetail{{Trigger: pokeapi.NamedAPIResource{Name: "level-up"}, MinHappiness: &happiness}},
+		},
+		{
+			Species:          pokeapi.NamedAPIResource{Name: "vaporeon"},
+			EvolutionDetails: []pokeapi.EvolutionDetail{{Trigger: pokeapi.NamedAPIResource{Name: "use-item"}}},
+		},
+	}
+
+	tests := []struct {
+		from, to int
+		want     []string
+	}{
+		{155, 160, []string{"espeon"}},
+		{150, 155, nil},
+		{160, 165, nil},
+	}
+	for _, tt := range tests {
+		if got := friendshipEvolutions(evolutions, tt.from, tt.to); !slices.Equal(got, tt.want) {
+			t.Errorf("friendshipEvolutions(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
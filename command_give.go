@@ -0,0 +1,84 @@
+// This file implements the give command, a developer command that adds a
+// Pokémon to the Pokédex without the catch roll, so that features such as
+// evolution, battles, and storage can be tried out quickly. It only works in
+// debug mode.
+package main
+
+import (
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// commandGive adds a Pokémon to the Pokédex as if it had just been caught,
+// without throwing a ball. Like a caught Pokémon, it goes to storage if the
+// party is full. The command is refused unless debug mode is on.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the Pokémon name
+//
+// Returns:
+//   - An error if debug mode is off, no Pokémon name is given, the Pokémon
+//     doesn't exist or is already in the Pokédex, or the API request fails
+func commandGive(cfg *config, params []string) error {
+	err := givePokemon(cfg, params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "give", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// givePokemon checks that debug mode is on and adds the Pokémon named in the
+// parameters to the Pokédex.
+func givePokemon(cfg *config, params []string) error {
+	if !cfg.Settings().debugMode {
+		return errorhandling.NewInvalidInputError(
+			"give is a developer command. Turn on debug mode with 'debug' to use it.", nil)
+	}
+	pokemonName, err := ValidatePokemonParam(params)
+	if err != nil {
+		return err
+	}
+	nameInfo := FormatPokemonInput(pokemonName)
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		return err
+	}
+	if hasPokemon(cfg, nameInfo.APIFormat) {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("You already have %s. Release it first to receive this one.", nameInfo.Formatted), nil)
+	}
+
+	data, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
+	if err != nil {
+		if errorhandling.IsNotFoundError(err) {
+			return errorhandling.InvalidPokemonNameError(nameInfo.Formatted)
+		}
+		return err
+	}
+	entry := pokedex.NewEntry(data)
+	entry.CaughtOn = time.Now()
+	// Send the Pokémon to storage if there's no room for it in the party
+	if !partyHasRoom(cfg, nameInfo.APIFormat) {
+		entry.Box = storageBox
+	}
+	cfg.pokedex.Add(nameInfo.APIFormat, entry)
+
+	i18n.Printf("Gave you %s.\n", nameInfo.Formatted)
+	if entry.Box != "" {
+		i18n.Printf("Your party is full, so %s was sent to box '%s'.\n", nameInfo.Formatted, entry.Box)
+	}
+	// Auto-save the new Pokémon
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Report the error without failing, since the Pokémon was still added
+		HandleCommandError(cfg, "give", err)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestGiveNeedsDebugMode tests that give is refused unless debug mode is on
+func TestGiveNeedsDebugMode(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := givePokemon(cfg, []string{"pikachu"}); !errorhandling.IsInvalidInputError(err) {
+		t.Errorf("Expected an invalid input error without debug mode, got %v", err)
+	}
+	if hasPokemon(cfg, "pikachu") {
+		t.Error("Expected no Pokémon to be added without debug mode")
+	}
+
+	cfg.UpdateSettings(func(s *settings) { s.debugMode = true })
+	if err := givePokemon(cfg, nil); err == nil {
+		t.Errorf("Expected an error for a missing Pokémon name in debug mode, got %v", err)
+	}
+}
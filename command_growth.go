@@ -0,0 +1,239 @@
+// This file implements the growth command, which charts the size of the Pokédex
+// over time as a sparkline, from the sizes recorded in the save log (see
+// savelog_utils.go), with the days it first reached each milestone marked.
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// growthWidth is the most columns the sparkline takes; longer histories are
+// shown with several days to a column.
+const growthWidth = 60
+
+// sparkLevels are the characters of the sparkline, from lowest to highest.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// growthMilestones are the Pokédex sizes marked on the chart. There are at
+// most nine, so each can be marked with a single digit.
+var growthMilestones = []int{1, 10, 25, 50, 100, 151, 250, 500, 1000}
+
+// growthSample is the size of the Pokédex at a point in time.
+type growthSample struct {
+	at   time.Time // When the Pokédex had this size
+	size int       // The number of Pokémon in it
+}
+
+// growthMilestone is the first day the Pokédex reached a milestone size.
+type growthMilestone struct {
+	size int // The milestone size
+	day  int // The day it was reached, counted from the first day of the chart
+}
+
+// commandGrowth charts the size of the Pokédex over time. The sizes come from
+// the save log, which records the size of every save; if nothing has been
+// logged yet, they're worked out from when each Pokémon in the Pokédex was
+// caught instead.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Not used
+//
+// Returns:
+//   - An error if the save log can't be read
+func commandGrowth(cfg *config, params []string) error {
+	samples, err := growthSamples(cfg)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "growth", err) {
+			return err
+		}
+		return nil
+	}
+
+	days := dailySizes(samples)
+	if len(days) == 0 || slices.Max(days) == 0 {
+		i18n.Println("There's no growth to chart yet. Catch some Pokémon first!")
+		printSeparator()
+		return nil
+	}
+	first := startOfDay(samples[0].at)
+	last := first.AddDate(0, 0, len(days)-1)
+	milestones := reachedMilestones(days)
+
+	peak, peakDay := 0, 0
+	for i, size := range days {
+		if size > peak {
+			peak, peakDay = size, i
+		}
+	}
+	i18n.Printf("Pokédex size from %s to %s:\n", first.Format("2006-01-02"), last.Format("2006-01-02"))
+	if !isAccessibleOutput() {
+		columns := growthColumns(days, growthWidth)
+		fmt.Printf("%s %d\n", sparkline(columns), days[len(days)-1])
+		fmt.Println(milestoneMarkers(milestones, len(days), len(columns)))
+	}
+	i18n.Printf("Started with %d, now %d. Peak: %d on %s.\n",
+		days[0], days[len(days)-1], peak, first.AddDate(0, 0, peakDay).Format("2006-01-02"))
+
+	if len(milestones) > 0 {
+		i18n.Println("Milestones:")
+		for i, m := range milestones {
+			i18n.Printf("%d. %s: %d Pokémon\n", i+1, first.AddDate(0, 0, m.day).Format("2006-01-02"), m.size)
+		}
+	}
+	printSeparator()
+	return nil
+}
+
+// growthSamples returns the sizes of the Pokédex over time, oldest first,
+// ending with its current size.
+func growthSamples(cfg *config) ([]growthSample, error) {
+	path, err := getSaveLogPath()
+	if err != nil {
+		return nil, errorhandling.NewInternalError("Could not find the save log", err)
+	}
+	records, err := readSaveLog(path)
+	if err != nil {
+		return nil, errorhandling.NewInternalError("Could not read the save log", err)
+	}
+
+	var samples []growthSample
+	for _, record := range records {
+		if record.Error == "" {
+			samples = append(samples, growthSample{at: record.Time, size: record.Entries})
+		}
+	}
+	if len(samples) == 0 {
+		samples = catchSamples(cfg)
+	}
+	samples = append(samples, growthSample{at: time.Now(), size: cfg.pokedex.Len()})
+
+	// The log is in the order of the saves, which a change of the clock can put out of order
+	slices.SortStableFunc(samples, func(a, b growthSample) int { return a.at.Compare(b.at) })
+	return samples, nil
+}
+
+// catchSamples works out the sizes of the Pokédex from when each Pokémon in it
+// was caught, for when there's no save log. Pokémon caught before catch dates
+// were recorded are left out, as are those that have been released.
+func catchSamples(cfg *config) []growthSample {
+	var caught []time.Time
+	for _, entry := range cfg.pokedex.All() {
+		if !entry.CaughtOn.IsZero() {
+			caught = append(caught, entry.CaughtOn)
+		}
+	}
+	slices.SortFunc(caught, time.Time.Compare)
+
+	samples := make([]growthSample, len(caught))
+	for i, at := range caught {
+		samples[i] = growthSample{at: at, size: i + 1}
+	}
+	return samples
+}
+
+// dailySizes returns the size of the Pokédex at the end of each day, from the
+// day of the first sample to the day of the last. Days without a sample keep
+// the size of the day before.
+//
+// Parameters:
+//   - samples: The sizes of the Pokédex over time, oldest first
+//
+// Returns:
+//   - The size at the end of each day, starting with the first day
+func dailySizes(samples []growthSample) []int {
+	if len(samples) == 0 {
+		return nil
+	}
+	first := startOfDay(samples[0].at)
+	var days []int
+	for _, sample := range samples {
+		day := daysBetween(first, startOfDay(sample.at))
+		for len(days) <= day {
+			size := 0
+			if len(days) > 0 {
+				size = days[len(days)-1]
+			}
+			days = append(days, size)
+		}
+		days[day] = sample.size
+	}
+	return days
+}
+
+// startOfDay returns midnight at the start of a time's day, in local time.
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Local().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+// daysBetween counts the calendar days from one midnight to another, which
+// aren't always 24 hours apart when the clocks change.
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Round(24*time.Hour) / (24 * time.Hour))
+}
+
+// reachedMilestones returns the milestones the Pokédex has reached, with the
+// first day it reached each one.
+func reachedMilestones(days []int) []growthMilestone {
+	var milestones []growthMilestone
+	next := 0
+	for day, size := range days {
+		for next < len(growthMilestones) && size >= growthMilestones[next] {
+			milestones = append(milestones, growthMilestone{size: growthMilestones[next], day: day})
+			next++
+		}
+	}
+	return milestones
+}
+
+// growthColumns fits the daily sizes into at most width columns. Each column
+// shows the size at the end of the last day it covers.
+func growthColumns(days []int, width int) []int {
+	if len(days) <= width {
+		return days
+	}
+	columns := make([]int, width)
+	for i := range columns {
+		columns[i] = days[(i+1)*len(days)/width-1]
+	}
+	return columns
+}
+
+// columnOfDay returns the column of growthColumns that covers a day.
+func columnOfDay(day, days, columns int) int {
+	return day * columns / days
+}
+
+// sparkline draws values as a line of block characters, scaled so the highest
+// value is the tallest.
+func sparkline(values []int) string {
+	highest := slices.Max(values)
+	var line strings.Builder
+	for _, value := range values {
+		level := 0
+		if highest > 0 {
+			level = value * (len(sparkLevels) - 1) / highest
+		}
+		line.WriteRune(sparkLevels[level])
+	}
+	return line.String()
+}
+
+// milestoneMarkers draws the line under the sparkline that marks the column
+// where each milestone was reached with its number in the list of milestones.
+// When several milestones fall in the same column, the first is marked.
+func milestoneMarkers(milestones []growthMilestone, days, columns int) string {
+	markers := []rune(strings.Repeat(" ", columns))
+	for i := len(milestones) - 1; i >= 0; i-- {
+		markers[columnOfDay(milestones[i].day, days, columns)] = rune('1' + i)
+	}
+	return strings.TrimRight(string(markers), " ")
+}
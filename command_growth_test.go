@@ -0,0 +1,54 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestDailySizes tests that sizes are taken from the end of each day, and that
+// days without a sample keep the size of the day before
+func TestDailySizes(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2026, 3, d, hour, 0, 0, 0, time.Local) }
+	samples := []growthSample{
+		{at: day(1, 9), size: 1},
+		{at: day(1, 18), size: 3},
+		{at: day(4, 12), size: 2},
+		{at: day(5, 8), size: 12},
+	}
+	want := []int{3, 3, 3, 2, 12}
+	if got := dailySizes(samples); !slices.Equal(got, want) {
+		t.Errorf("Expected daily sizes %v, got %v", want, got)
+	}
+}
+
+// TestReachedMilestones tests that each milestone is marked on the first day
+// it was reached, even when several are reached at once
+func TestReachedMilestones(t *testing.T) {
+	got := reachedMilestones([]int{0, 1, 0, 5, 30, 9})
+	want := []growthMilestone{{size: 1, day: 1}, {size: 10, day: 4}, {size: 25, day: 4}}
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected milestones %v, got %v", want, got)
+	}
+}
+
+// TestSparkline tests that values are scaled to the highest, and that long
+// histories are fitted into the width with milestones marked in their column
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{0, 7, 14}); got != "▁▄█" {
+		t.Errorf("Expected ▁▄█, got %s", got)
+	}
+
+	days := make([]int, 120)
+	for i := range days {
+		days[i] = i
+	}
+	columns := growthColumns(days, 60)
+	if len(columns) != 60 || columns[0] != 1 || columns[59] != 119 {
+		t.Errorf("Expected 60 columns from 1 to 119, got %d from %d to %d", len(columns), columns[0], columns[len(columns)-1])
+	}
+	markers := milestoneMarkers(reachedMilestones(days), len(days), len(columns))
+	if want := "1    2      3            4                        5"; markers != want {
+		t.Errorf("Expected markers %q, got %q", want, markers)
+	}
+}
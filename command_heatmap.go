@@ -0,0 +1,148 @@
+// This file implements the heatmap command, which shows the location areas the
+// user has visited most, with how many times they explored each one, how many
+// wild Pokémon they encountered there, and how many of their Pokémon were
+// caught there.
+package main
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// heatmapUsage describes the parameters of the heatmap command.
+const heatmapUsage = "Usage: heatmap [count]"
+
+// defaultHeatmapCount is the number of location areas listed when no count is given.
+const defaultHeatmapCount = 10
+
+// heatWidth is the length of the heat bar of the most visited location area.
+const heatWidth = 10
+
+// locationHeat is the activity in one location area.
+type locationHeat struct {
+	location string         // The location area's name in API format
+	visits   pokedex.Visits // How often it was explored and encountered in
+	caught   int            // The number of Pokémon in the Pokédex caught there
+}
+
+// activity returns the number of times anything happened in the location area,
+// which is what the heatmap ranks areas by.
+func (h locationHeat) activity() int {
+	return h.visits.Total() + h.caught
+}
+
+// commandHeatmap lists the location areas the user has been most active in,
+// with a bar showing how they compare. Explores, wild encounters (including
+// wild battles), and catches each count once.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters, optionally the number of location areas to list
+//
+// Returns:
+//   - An error if the count is invalid
+func commandHeatmap(cfg *config, params []string) error {
+	count := defaultHeatmapCount
+	var err error
+	switch {
+	case len(params) > 1:
+		err = errorhandling.NewInvalidInputError(heatmapUsage, nil)
+	case len(params) == 1:
+		count, err = strconv.Atoi(params[0])
+		if err != nil || count < 1 {
+			err = errorhandling.NewInvalidInputError(
+				i18n.Sprintf("invalid count: %s (must be a positive number)", params[0]), nil)
+		}
+	}
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "heatmap", err) {
+			return err
+		}
+		return nil
+	}
+
+	heat := locationHeatmap(cfg.pokedex.Visits(), cfg.pokedex.All())
+	if len(heat) == 0 {
+		i18n.Println("You haven't visited any locations yet. Use 'explore' or 'encounter' to look around.")
+		printSeparator()
+		return nil
+	}
+	heat = heat[:min(count, len(heat))]
+
+	i18n.Println("Your most active locations:")
+	headers := []string{"#", "Location", "Explored", "Encounters", "Catches"}
+	if !isAccessibleOutput() {
+		headers = append(headers, "Heat")
+	}
+	table := NewTable(headers...)
+	for i, h := range heat {
+		row := []string{strconv.Itoa(i + 1), FormatLocationName(h.location), strconv.Itoa(h.visits.Explored),
+			strconv.Itoa(h.visits.Encounters), strconv.Itoa(h.caught)}
+		if !isAccessibleOutput() {
+			row = append(row, heatBar(h.activity(), heat[0].activity()))
+		}
+		table.AddRow(row...)
+	}
+	table.Print()
+	printSeparator()
+	return nil
+}
+
+// locationHeatmap combines the visit counts with where the Pokémon in the
+// Pokédex were caught, and ranks the location areas by their activity, most
+// first. Ties are broken by name, so the order is always the same.
+//
+// Parameters:
+//   - visits: The visit counts, indexed by location area
+//   - entries: The Pokémon in the Pokédex, indexed by name
+//
+// Returns:
+//   - Every location area that was visited or had a Pokémon caught in it, in ranked order
+func locationHeatmap(visits map[string]pokedex.Visits, entries map[string]pokedex.Entry) []locationHeat {
+	byLocation := make(map[string]*locationHeat, len(visits))
+	heatOf := func(location string) *locationHeat {
+		h, ok := byLocation[location]
+		if !ok {
+			h = &locationHeat{location: location}
+			byLocation[location] = h
+		}
+		return h
+	}
+	for location, v := range visits {
+		heatOf(location).visits = v
+	}
+	for _, entry := range entries {
+		if entry.CaughtAt != "" {
+			heatOf(entry.CaughtAt).caught++
+		}
+	}
+
+	heat := make([]locationHeat, 0, len(byLocation))
+	for _, h := range byLocation {
+		heat = append(heat, *h)
+	}
+	slices.SortFunc(heat, func(a, b locationHeat) int {
+		return cmp.Or(cmp.Compare(b.activity(), a.activity()), cmp.Compare(a.location, b.location))
+	})
+	return heat
+}
+
+// heatBar draws a bar for a location area's activity, scaled so the most
+// active area fills heatWidth. Any activity gets at least one character.
+func heatBar(activity, most int) string {
+	length := 0
+	if most > 0 {
+		length = activity * heatWidth / most
+		if activity > 0 {
+			length = max(length, 1)
+		}
+	}
+	return strings.Repeat("#", length)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestLocationHeatmap tests that catches are counted with the visits, and that
+// locations are ranked by their activity and then by name
+func TestLocationHeatmap(t *testing.T) {
+	visits := map[string]pokedex.Visits{
+		"viridian-forest-area": {Explored: 2, Encounters: 3},
+		"mt-moon-1f":           {Explored: 1},
+		"route-1-area":         {Explored: 1, Encounters: 1},
+	}
+	entries := map[string]pokedex.Entry{
+		"pikachu":  {CaughtAt: "viridian-forest-area"},
+		"clefairy": {CaughtAt: "mt-moon-1f"},
+		"pidgey":   {CaughtAt: "route-2-area"},
+		"mew":      {},
+	}
+
+	heat := locationHeatmap(visits, entries)
+	want := []struct {
+		location string
+		activity int
+		caught   int
+	}{
+		{"viridian-forest-area", 6, 1},
+		{"mt-moon-1f", 2, 1},
+		{"route-1-area", 2, 0},
+		{"route-2-area", 1, 1},
+	}
+	if len(heat) != len(want) {
+		t.Fatalf("Expected %d locations, got %d: %+v", len(want), len(heat), heat)
+	}
+	for i, w := range want {
+		if heat[i].location != w.location || heat[i].activity() != w.activity || heat[i].caught != w.caught {
+			t.Errorf("Expected #%d to be %s with activity %d and %d caught, got %+v",
+				i+1, w.location, w.activity, w.caught, heat[i])
+		}
+	}
+}
+
+// TestHeatBar tests that heat bars are scaled to the most active location
+func TestHeatBar(t *testing.T) {
+	tests := []struct {
+		activity, most int
+		want           string
+	}{
+		{10, 10, "##########"},
+		{5, 10, "#####"},
+		{1, 100, "#"},
+		{0, 10, ""},
+	}
+	for _, tt := range tests {
+		if got := heatBar(tt.activity, tt.most); got != tt.want {
+			t.Errorf("heatBar(%d, %d) = %q, want %q", tt.activity, tt.most, got, tt.want)
+		}
+	}
+}
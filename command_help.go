@@ -1,7 +1,10 @@
 package main
 
 import (
-	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 )
 
 // commandHelp displays a list of all available commands with their descriptions.
@@ -9,7 +12,7 @@ import (
 // about what commands are available and what they do.
 //
 // The function iterates through all registered commands from getCommands() and
-// prints each command name alongside its description in a formatted list.
+// prints each command name alongside its description in an alphabetical list.
 //
 // Parameters:
 //   - cfg: The application configuration (not used in this command)
@@ -21,11 +24,18 @@ import (
 // Side Effects:
 //   - Prints the welcome message and list of available commands to stdout
 func commandHelp(cfg *config, params []string) error {
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Println("-----")
-	for _, cmd := range getCommands() {
-		fmt.Printf("%s | %s \n", cmd.name, cmd.description)
+	i18n.Println("Welcome to the Pokedex!")
+	printSeparator()
+	// List the commands in alphabetical order so the output is the same every time
+	commands := getCommands()
+	for _, name := range slices.Sorted(maps.Keys(commands)) {
+		cmd := commands[name]
+		if isAccessibleOutput() {
+			i18n.Printf("%s: %s\n", cmd.name, i18n.T(cmd.description))
+		} else {
+			i18n.Printf("%s | %s \n", cmd.name, i18n.T(cmd.description))
+		}
 	}
-	fmt.Println("-----")
+	printSeparator()
 	return nil
 }
@@ -0,0 +1,164 @@
+// This file implements the hold command, which gives a caught Pokémon an item
+// from the bag to hold in battle. Only the items in heldItems have an effect
+// (see battle_items.go). A Pokémon holds one item at a time, and the item goes
+// back in the bag when it's taken away, swapped for another, or its Pokémon is
+// released.
+package main
+
+import (
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// heldItemInfo is the data behind the hold command's result.
+type heldItemInfo struct {
+	Pokemon string `json:"pokemon"`          // The Pokémon's API name
+	Item    string `json:"item,omitempty"`   // The API name of the item it holds now, if any
+	Effect  string `json:"effect,omitempty"` // What the item does, from the PokeAPI, if it could be looked up
+}
+
+// holdResult shows the item a caught Pokémon holds, gives it an item from the
+// bag, or takes its item back.
+// Supported forms:
+//   - hold <pokemon>: Show the item the Pokémon holds
+//   - hold <pokemon> <item>: Give the Pokémon an item from the bag, putting back the one it held
+//   - hold <pokemon> none: Put the Pokémon's item back in the bag
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex, bag, and API client
+//   - params: Command parameters forming the Pokémon name, optionally followed
+//     by the item name (e.g. "pikachu choice band")
+//
+// Returns:
+//   - The item the Pokémon holds afterwards
+//   - An error if the Pokémon is not in the Pokédex, the item has no effect
+//     when held, or the bag doesn't have it
+func holdResult(cfg *config, params []string) (commandResult, error) {
+	apiName, nameInfo, itemInput, err := splitPokemonParams(cfg, params)
+	if err != nil {
+		return commandResult{}, err
+	}
+
+	switch item := ConvertToAPIFormat(itemInput); item {
+	case "":
+		return showHeldItem(cfg, apiName, nameInfo), nil
+	case "none":
+		return takeHeldItem(cfg, apiName, nameInfo)
+	default:
+		return giveHeldItem(cfg, apiName, nameInfo, item)
+	}
+}
+
+// showHeldItem describes the item a Pokémon holds.
+func showHeldItem(cfg *config, apiName string, nameInfo PokemonNameInfo) commandResult {
+	entry, _ := cfg.pokedex.Get(apiName)
+	held := heldItemInfo{Pokemon: apiName, Item: entry.HeldItem}
+	if entry.HeldItem == "" {
+		return commandResult{
+			Message: i18n.Sprintf("%s isn't holding an item. Give it one from your bag with 'hold %s <item>'. Items that work in battle: %s\n",
+				nameInfo.Formatted, apiName, formatHeldItems()),
+			Data: held,
+		}
+	}
+
+	var message strings.Builder
+	message.WriteString(i18n.Sprintf("%s is holding %s.\n", nameInfo.Formatted, FormatItemName(entry.HeldItem)))
+	if held.Effect = heldItemEffect(cfg, entry.HeldItem); held.Effect != "" {
+		message.WriteString(held.Effect + "\n")
+	}
+	return commandResult{Message: message.String(), Data: held}
+}
+
+// giveHeldItem takes an item out of the bag for a Pokémon to hold. The item
+// the Pokémon held before goes back in the bag.
+func giveHeldItem(cfg *config, apiName string, nameInfo PokemonNameInfo, item string) (commandResult, error) {
+	formattedItem := FormatItemName(item)
+	if !slices.Contains(heldItems, item) {
+		return commandResult{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s has no effect when held. Items that work in battle: %s", formattedItem, formatHeldItems()), nil)
+	}
+	if entry, _ := cfg.pokedex.Get(apiName); entry.HeldItem == item {
+		return commandResult{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s is already holding %s", nameInfo.Formatted, formattedItem), nil)
+	}
+	if !cfg.UseItem(item) {
+		return commandResult{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("You don't have any %s. Buy one with 'shop buy %s'", formattedItem, item), nil)
+	}
+
+	var previous string
+	err := cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		previous, entry.HeldItem = entry.HeldItem, item
+		return nil
+	})
+	if err != nil {
+		cfg.AddItem(item, 1)
+		return commandResult{}, pokedexError(err, apiName)
+	}
+
+	var message strings.Builder
+	message.WriteString(i18n.Sprintf("%s is now holding %s.\n", nameInfo.Formatted, formattedItem))
+	if previous != "" {
+		cfg.AddItem(previous, 1)
+		message.WriteString(i18n.Sprintf("%s went back in your bag.\n", FormatItemName(previous)))
+	}
+	held := heldItemInfo{Pokemon: apiName, Item: item, Effect: heldItemEffect(cfg, item)}
+	if held.Effect != "" {
+		message.WriteString(held.Effect + "\n")
+	}
+
+	// Auto-save after changing the held item and the bag
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the item has already been given
+		HandleCommandError(cfg, "hold", err)
+	}
+	return commandResult{Message: message.String(), Data: held}, nil
+}
+
+// takeHeldItem puts the item a Pokémon holds back in the bag.
+func takeHeldItem(cfg *config, apiName string, nameInfo PokemonNameInfo) (commandResult, error) {
+	var previous string
+	err := cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		if entry.HeldItem == "" {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s isn't holding an item", nameInfo.Formatted), nil)
+		}
+		previous, entry.HeldItem = entry.HeldItem, ""
+		return nil
+	})
+	if err != nil {
+		return commandResult{}, pokedexError(err, apiName)
+	}
+	cfg.AddItem(previous, 1)
+
+	// Auto-save after changing the held item and the bag
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the item has already been put back
+		HandleCommandError(cfg, "hold", err)
+	}
+	return commandResult{
+		Message: i18n.Sprintf("You took %s from %s. It went back in your bag.\n", FormatItemName(previous), nameInfo.Formatted),
+		Data:    heldItemInfo{Pokemon: apiName},
+	}, nil
+}
+
+// heldItemEffect returns the short English description of what an item does,
+// from the PokeAPI. Holding an item works without it, so a failed request is
+// only logged and an empty string is returned.
+func heldItemEffect(cfg *config, item string) string {
+	resp, err := cfg.pokeapiClient.GetItem(item)
+	if err != nil {
+		if cfg.Settings().debugMode {
+			log.Printf("Could not load the item data of %s: %v", item, err)
+		}
+		return ""
+	}
+	return resp.EnglishEffect()
+}
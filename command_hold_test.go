@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestHoldItem tests that giving, swapping, and taking back a held item moves
+// it between the bag and the Pokémon, and that releasing the Pokémon puts it back
+func TestHoldItem(t *testing.T) {
+	cfg := &config{
+		pokedex:       pokedex.New(),
+		settings:      defaultSettings(),
+		pokeapiClient: pokeapi.NewClientWithOptions(pokeapi.ClientOptions{CacheInterval: time.Hour, Transport: notFoundTransport{}}),
+	}
+	cfg.pokedex.Add("snorlax", pokedex.NewEntry(testMatchupPokemon(t, "snorlax", 540, "normal")))
+	cfg.AddItem(leftoversItem, 1)
+	cfg.AddItem(choiceBandItem, 1)
+	cfg.AddItem("fire-stone", 1)
+	held := func() string {
+		entry, _ := cfg.pokedex.Get("snorlax")
+		return entry.HeldItem
+	}
+
+	for _, params := range [][]string{{"snorlax", "fire", "stone"}, {"snorlax", "choice", "specs"}, {"snorlax", "none"}} {
+		if _, err := holdResult(cfg, params); err == nil {
+			t.Errorf("holdResult(%v): expected an error", params)
+		}
+	}
+
+	if _, err := holdResult(cfg, []string{"snorlax", "leftovers"}); err != nil || held() != leftoversItem || cfg.Items()[leftoversItem] != 0 {
+		t.Fatalf("Expected Snorlax to hold the Leftovers from the bag, got %q (%v)", held(), err)
+	}
+	if _, err := holdResult(cfg, []string{"snorlax", "choice", "band"}); err != nil || held() != choiceBandItem || cfg.Items()[leftoversItem] != 1 {
+		t.Fatalf("Expected the Choice Band to replace the Leftovers, got %q (%v)", held(), err)
+	}
+	if result, err := holdResult(cfg, []string{"snorlax"}); err != nil || result.Data.(heldItemInfo).Item != choiceBandItem {
+		t.Errorf("Expected the Choice Band to be shown, got %+v (%v)", result, err)
+	}
+	if _, err := holdResult(cfg, []string{"snorlax", "none"}); err != nil || held() != "" || cfg.Items()[choiceBandItem] != 1 {
+		t.Fatalf("Expected the Choice Band back in the bag, got %q (%v)", held(), err)
+	}
+
+	if _, err := holdResult(cfg, []string{"snorlax", "choice", "band"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	letGo(cfg, "snorlax")
+	if cfg.Items()[choiceBandItem] != 1 {
+		t.Errorf("Expected releasing Snorlax to put its item back in the bag, got %v", cfg.Items())
+	}
+}
@@ -0,0 +1,163 @@
+// This file implements the import command, which adds Pokémon to the Pokédex
+// from other programs' formats (see import_utils.go).
+package main
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// importUsage describes the forms of the import command.
+const importUsage = "Usage: import showdown <file> | import csv <file> --mapping <spec>"
+
+// commandImport adds the Pokémon in a file to the Pokédex. Supported forms:
+//   - import showdown <file>: Import a team exported from Pokémon Showdown
+//   - import csv <file> --mapping <spec>: Import the rows of a CSV file, with
+//     its columns mapped to Pokédex fields (e.g. "name=Species,level=Lvl")
+//
+// Pokémon that are already in the Pokédex, or that don't exist, are skipped,
+// as are moves they can't learn.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the format and the rest is
+//     the file path, followed by the mapping for CSV files
+//
+// Returns:
+//   - An error if the format is unknown, or the file can't be read or parsed
+func commandImport(cfg *config, params []string) error {
+	imported, path, err := readImportFile(params)
+	if err == nil {
+		err = importAll(cfg, imported, path)
+	}
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "import", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// readImportFile reads the Pokémon in the file given to the import command.
+//
+// Returns:
+//   - The Pokémon in the file
+//   - The file's path
+//   - An error if the parameters are invalid, or the file can't be read or parsed
+func readImportFile(params []string) ([]importedPokemon, string, error) {
+	if len(params) < 2 {
+		return nil, "", errorhandling.NewInvalidInputError(importUsage, nil)
+	}
+	format := strings.ToLower(params[0])
+	args := params[1:]
+	spec := ""
+	if i := slices.Index(args, "--mapping"); i >= 0 {
+		spec = strings.Join(args[i+1:], " ")
+		args = args[:i]
+	}
+	path := strings.Join(args, " ")
+	if path == "" {
+		return nil, "", errorhandling.NewInvalidInputError(importUsage, nil)
+	}
+
+	var parse func(*os.File) ([]importedPokemon, error)
+	switch format {
+	case "showdown":
+		parse = func(file *os.File) ([]importedPokemon, error) { return parseShowdown(file) }
+	case "csv":
+		if spec == "" {
+			return nil, "", errorhandling.NewInvalidInputError(
+				"CSV imports need a mapping, e.g. import csv pokemon.csv --mapping name=Species,level=Level", nil)
+		}
+		parse = func(file *os.File) ([]importedPokemon, error) { return parseImportCSV(file, spec) }
+	default:
+		return nil, "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown import format '%s'. %s", params[0], importUsage), nil)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, "", errorhandling.NewInvalidInputError(i18n.Sprintf("Could not open file '%s'", path), err)
+	}
+	defer file.Close()
+	imported, err := parse(file)
+	if err != nil {
+		return nil, "", err
+	}
+	return imported, path, nil
+}
+
+// importAll adds imported Pokémon to the Pokédex, reporting each one, and
+// auto-saves the changes. A Pokémon that can't be imported is reported and
+// skipped, while the rest are still imported. If the import is cancelled with
+// Ctrl+C, the Pokémon imported so far are kept.
+func importAll(cfg *config, imported []importedPokemon, path string) error {
+	ctx := commandContext(cfg)
+	added := 0
+	for _, pokemon := range imported {
+		if ctx.Err() != nil {
+			break
+		}
+		nameInfo := FormatPokemonInput(pokemon.Name)
+		if err := importPokemon(cfg, pokemon, nameInfo); err != nil {
+			i18n.Printf("Line %d: Skipped %s: %s\n", pokemon.Line, nameInfo.Formatted, errorhandling.FormatUserMessage(err))
+			continue
+		}
+		added++
+	}
+
+	i18n.Printf("Imported %d of %d Pokémon from %s.\n", added, len(imported), path)
+	if added > 0 {
+		if err := UpdatePokedexAndSave(cfg); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// importPokemon looks up an imported Pokémon's data and adds it to the Pokédex,
+// creating its box if needed. Without a box, it joins the party if there's room.
+func importPokemon(cfg *config, pokemon importedPokemon, nameInfo PokemonNameInfo) error {
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		return err
+	}
+	if _, exists, _ := CheckPokemonExists(cfg, nameInfo.APIFormat); exists {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s is already in your Pokédex", nameInfo.Formatted), nil)
+	}
+	data, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
+	if err != nil {
+		if errorhandling.IsNotFoundError(err) {
+			return errorhandling.InvalidPokemonNameError(nameInfo.Formatted)
+		}
+		return err
+	}
+
+	entry, leftOut := importedEntry(pokemon, pokedex.NewEntry(data))
+	if entry.Box != "" {
+		cfg.pokedex.AddBox(entry.Box)
+	} else if !partyHasRoom(cfg, nameInfo.APIFormat) {
+		entry.Box = storageBox
+	}
+	cfg.pokedex.Add(nameInfo.APIFormat, entry)
+	cfg.pokedex.MarkSeen(nameInfo.APIFormat, pokedex.Sighting{SeenOn: time.Now(), Location: entry.CaughtAt})
+
+	i18n.Printf("Imported %s (level %d).\n", nameInfo.Formatted, entry.CurrentLevel())
+	if len(leftOut) > 0 {
+		moves := make([]string, len(leftOut))
+		for i, move := range leftOut {
+			moves[i] = FormatMoveName(move)
+		}
+		i18n.Printf("  Left out moves it can't learn or that don't fit its moveset: %s\n", strings.Join(moves, ", "))
+	}
+	return nil
+}
@@ -2,59 +2,150 @@ package main
 
 import (
 	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
-// commandInspect displays detailed information about a Pokémon in the user's Pokédex.
+// inspection is the data behind the inspect command's result.
+type inspection struct {
+	Pokemon  string          `json:"pokemon"`            // The Pokémon's API name
+	Entry    pokedex.Entry   `json:"entry"`              // Its Pokédex entry, in the same format as the save file
+	Biology  *speciesBiology `json:"biology,omitempty"`  // Where and how its species lives, if the species data could be loaded
+	Catching *catchInfo      `json:"catching,omitempty"` // How hard it is to catch, if the species data could be loaded
+}
+
+// inspectResult shows detailed information about a Pokémon in the user's Pokédex.
 // This command shows various attributes of a caught Pokémon, including:
 //   - Base stats (HP, Attack, Defense, etc.)
 //   - Physical attributes (Height and Weight)
 //   - Types (Fire, Water, etc.)
+//   - Biology of the species (habitat, color, shape, growth rate, and base happiness)
+//   - Its capture rate, base experience, and rarity tier
+//   - Its level, the effort values (EVs) it has gained, and whether it's at the day care
+//   - The item it holds in battle, if any
+//   - When and where it was caught, if known
+//   - The active moveset and any notes the user has added
 //
 // The information is only available for Pokémon that have been caught and are
 // currently in the user's Pokédex.
 //
 // Parameters:
-//   - cfg: The application configuration containing the Pokédex
+//   - cfg: The application configuration containing the Pokédex and API client
 //   - params: Command parameters where params[0] is the Pokémon name to inspect
 //
 // Returns:
+//   - The Pokémon's details
 //   - An error if no Pokémon name is provided or if the Pokémon is not in the Pokédex
-func commandInspect(cfg *config, params []string) error {
+func inspectResult(cfg *config, params []string) (commandResult, error) {
 	// Use the utility function to validate the Pokemon parameter and check if it exists
-	_, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, params)
+	apiName, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, params)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "inspect", err) {
-			return err
-		}
-		return nil
+		return commandResult{}, err
 	}
 
 	// The Pokemon exists, so convert to the typed data structure
 	data, err := GetTypedPokemonData(pokemonData, nameInfo.Formatted)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "inspect", err) {
-			return err
-		}
-		return nil
+		return commandResult{}, err
 	}
+	inspected := inspection{Pokemon: apiName, Entry: data}
 
-	// Display Pokemon information
-	fmt.Printf("Name: %s\n", nameInfo.Formatted)
-	fmt.Printf("Height: %v\n", data.Height)
-	fmt.Printf("Weight: %v\n", data.Weight)
-	fmt.Printf("Stats:\n")
+	// Describe the Pokemon
+	var message strings.Builder
+	message.WriteString(i18n.Sprintf("Name: %s\n", nameInfo.Formatted))
+	message.WriteString(i18n.Sprintf("Level: %d\n", data.CurrentLevel()))
+	if data.CurrentLevel() < pokedex.MaxLevel {
+		message.WriteString(i18n.Sprintf("Experience: %d/%d to the next level\n", data.Experience, pokedex.ExperienceToNextLevel(data.CurrentLevel())))
+	}
+	if data.TotalEVs() > 0 {
+		message.WriteString(i18n.Sprintf("EVs: %s (%d/%d)\n", formatEVSpread(data.EVs), data.TotalEVs(), pokedex.MaxTotalEVs))
+	}
+	units := displayUnits(cfg)
+	message.WriteString(i18n.Sprintf("Height: %s\n", FormatHeight(data.Height, units)))
+	message.WriteString(i18n.Sprintf("Weight: %s\n", FormatWeight(data.Weight, units)))
+	message.WriteString(i18n.Sprintf("Stats:\n"))
 	for _, stat := range data.Stats {
 		formattedStat := FormatStatName(stat.Stat.Name)
-		fmt.Printf(" - %s: %v\n", formattedStat, stat.BaseStat)
+		message.WriteString(i18n.Sprintf(" - %s: %v\n", formattedStat, stat.BaseStat))
 	}
-	fmt.Printf("Types:\n")
+	message.WriteString(i18n.Sprintf("Types:\n"))
 	for _, typ := range data.Types {
 		formattedType := FormatTypeName(typ.Type.Name)
-		fmt.Printf(" - %s\n", formattedType)
+		message.WriteString(i18n.Sprintf(" - %s\n", formattedType))
 	}
-	fmt.Println("-----")
 
-	return nil
+	// The biology comes from the species data, which isn't stored in the Pokédex.
+	// Inspecting should still work without it, so a failed request is only logged.
+	speciesData, err := cfg.pokeapiClient.GetPokemonSpecies(apiName)
+	if err == nil {
+		biology := newSpeciesBiology(speciesData)
+		catching := newCatchInfo(speciesData, data.BaseExperience)
+		inspected.Biology, inspected.Catching = &biology, &catching
+		writeBiology(&message, biology)
+		writeCatchInfo(&message, catching)
+	} else if cfg.Settings().debugMode {
+		log.Printf("Could not load the species data of %s: %v", apiName, err)
+	}
+
+	if caught := formatCaughtDetails(data); caught != "" {
+		message.WriteString(i18n.Sprintf("Caught: %s\n", caught))
+	}
+	if data.InDaycare() {
+		message.WriteString(i18n.Sprintf("At the day care since %s\n", data.DaycareSince.Local().Format("2006-01-02 15:04")))
+	}
+	if data.HeldItem != "" {
+		message.WriteString(i18n.Sprintf("Held item: %s\n", FormatItemName(data.HeldItem)))
+	}
+	if len(data.Ribbons) > 0 {
+		names := make([]string, 0, len(data.Ribbons))
+		for _, id := range data.Ribbons {
+			names = append(names, ribbonName(id))
+		}
+		message.WriteString(i18n.Sprintf("Ribbons: %s\n", strings.Join(names, ", ")))
+	}
+	if scores := formatMinigameScores(data); scores != "" {
+		message.WriteString(i18n.Sprintf("Minigame bests: %s\n", scores))
+	}
+	if data.Happiness > 0 {
+		message.WriteString(i18n.Sprintf("Happiness gained from minigames and care: +%d\n", data.Happiness))
+	}
+	if len(data.Moveset) > 0 {
+		message.WriteString(i18n.Sprintf("Moves:\n"))
+		writeMovesetTable(&message, data.Moveset)
+	}
+	if len(data.Notes) > 0 {
+		message.WriteString(i18n.Sprintf("Notes:\n"))
+		writeNotes(&message, data.Notes)
+	}
+
+	return commandResult{Message: message.String(), Data: inspected}, nil
+}
+
+// formatCaughtDetails describes when and where a Pokémon was caught
+// (e.g. "2024-05-01 in Viridian Forest"), or returns an empty string if
+// neither is known, as for Pokémon caught before this was recorded.
+func formatCaughtDetails(entry pokedex.Entry) string {
+	var parts []string
+	if !entry.CaughtOn.IsZero() {
+		parts = append(parts, entry.CaughtOn.Local().Format("2006-01-02"))
+	}
+	if entry.CaughtAt != "" {
+		parts = append(parts, i18n.Sprintf("in %s", FormatLocationName(entry.CaughtAt)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatEVSpread formats a Pokémon's effort values for display, in the order
+// the games show stats and leaving out stats without any (e.g. "4 Hp / 252 Attack").
+func formatEVSpread(evs map[string]int) string {
+	var parts []string
+	for _, stat := range statOrder {
+		if evs[stat] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", evs[stat], FormatStatName(stat)))
+		}
+	}
+	return strings.Join(parts, " / ")
 }
@@ -0,0 +1,60 @@
+// This file implements the language setting for the Pokédex CLI application.
+// The messages themselves are translated by the internal/i18n package, which
+// also keeps track of the selected language.
+package main
+
+import (
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// commandLang shows or changes the language the interface is shown in.
+// The setting is saved with the Pokédex so it persists between sessions.
+// Messages without a translation are shown in English, and text from the
+// Pokémon API, such as Pokédex entries, isn't affected.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters, where params[0] is a language code or omitted
+//
+// Returns:
+//   - An error if the language is not supported or the setting can't be saved
+func commandLang(cfg *config, params []string) error {
+	// If no parameter is provided, display the current and available languages
+	if len(params) == 0 {
+		current, _ := i18n.Lookup(i18n.Current())
+		i18n.Printf("The interface is shown in %s. Available languages: %s\n", current.Name, languageList())
+		i18n.Println("Use 'lang <code>' to change it (e.g. 'lang es').")
+		printSeparator()
+		return nil
+	}
+
+	if err := i18n.SetLanguage(params[0]); err != nil {
+		err := errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown language '%s'. Available languages: %s", params[0], languageList()), err)
+
+		// Use standardized error handling
+		if HandleCommandError(cfg, "lang", err) {
+			return err
+		}
+		return nil
+	}
+
+	selected, _ := i18n.Lookup(i18n.Current())
+	i18n.Printf("The interface will be shown in %s.\n", selected.Name)
+	printSeparator()
+
+	// Save the configuration itself, including the new language
+	return savePokedexData(cfg)
+}
+
+// languageList returns the supported languages formatted like "en (English), es (Español)".
+func languageList() string {
+	var parts []string
+	for _, lang := range i18n.Languages() {
+		parts = append(parts, lang.Code+" ("+lang.Name+")")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// commandLookup displays the types, base stats, and catch information of any
+// Pokémon, caught or not, so that players can judge how hard a catch will be
+// before throwing. Looking a Pokémon up registers it as seen.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the Pokémon name (caught or not)
+//
+// Returns:
+//   - An error if no Pokémon name is provided, the name is invalid,
+//     or there's an issue with the API requests
+func commandLookup(cfg *config, params []string) error {
+	// Check if Pokemon name parameter was provided
+	pokemonParam, err := ValidatePokemonParam(params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "lookup", err) {
+			return err
+		}
+		return nil
+	}
+
+	nameInfo := FormatPokemonInput(pokemonParam)
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "lookup", err) {
+			return err
+		}
+		return nil
+	}
+
+	pokemonData, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "lookup", err) {
+			return err
+		}
+		return nil
+	}
+	recordSeen(cfg, "lookup", "", nameInfo.APIFormat)
+
+	// The capture rate belongs to the species, so look up the species of the given form
+	speciesData, err := cfg.pokeapiClient.GetPokemonSpecies(pokemonData.Species.Name)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "lookup", err) {
+			return err
+		}
+		return nil
+	}
+
+	i18n.Printf("Name: %s (#%d)\n", nameInfo.Formatted, speciesData.ID)
+	i18n.Printf("Types: %s\n", FormatTypeList(pokemonTypes(pokemonData)))
+	i18n.Printf("Stats:\n")
+	for _, stat := range pokemonData.Stats {
+		i18n.Printf(" - %s: %v\n", FormatStatName(stat.Stat.Name), stat.BaseStat)
+	}
+	i18n.Printf(" - Total: %d\n", baseStatTotal(pokemonData))
+	writeCatchInfo(os.Stdout, newCatchInfo(speciesData, pokemonData.BaseExperience))
+	if _, caught := cfg.pokedex.Get(nameInfo.APIFormat); caught {
+		i18n.Printf("%s is in your Pokédex.\n", nameInfo.Formatted)
+	}
+	printSeparator()
+
+	return nil
+}
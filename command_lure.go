@@ -0,0 +1,126 @@
+// This file implements the lure command, which uses a lure item from the bag in
+// the area explored last so that a chosen type or Pokémon turns up more often in
+// the next encounters there.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// commandLure shows the lure in use, or uses a lure item from the bag in the
+// area explored last. The command supports two forms:
+//   - lure: Show the lure in use and how many encounters it has left
+//   - lure <type or pokemon>: Use a lure that draws out a type (e.g. "fire") or a Pokémon
+//
+// Only one lure can be in use at a time; using another replaces it after asking.
+//
+// Parameters:
+//   - cfg: The application configuration containing the bag and explored area
+//   - params: Command parameters forming the lure's target, if any
+//
+// Returns:
+//   - An error if no area has been explored, the target is unknown, or the bag has no lure
+func commandLure(cfg *config, params []string) error {
+	var err error
+	if len(params) == 0 {
+		printActiveLure(cfg)
+	} else {
+		err = useLure(cfg, ConvertToAPIFormat(strings.Join(params, " ")))
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "lure", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// printActiveLure describes the lure in use, if any.
+func printActiveLure(cfg *config) {
+	if lure, ok := cfg.ActiveLure(); ok {
+		fmt.Println(formatActiveLure(lure))
+	} else {
+		i18n.Println("No lure is in use. Use 'lure <type or pokemon>' after exploring an area.")
+	}
+	printSeparator()
+}
+
+// formatActiveLure describes a lure in use (e.g. "Honey in Eterna Forest Area,
+// drawing out Bug-type Pokémon (8 encounters left)").
+func formatActiveLure(lure pokedex.Lure) string {
+	return i18n.Sprintf("%s in %s, drawing out %s (%d encounters left)",
+		FormatItemName(lure.Item), FormatLocationName(lure.Location), formatLureTarget(lure.Target), lure.Remaining)
+}
+
+// formatLureTarget formats the target of a lure for display.
+func formatLureTarget(target string) string {
+	if slices.Contains(standardTypes, target) {
+		return i18n.Sprintf("%s-type Pokémon", FormatTypeName(target))
+	}
+	return FormatPokemonName(target)
+}
+
+// useLure takes a lure item out of the bag and uses it in the area explored last.
+//
+// Parameters:
+//   - cfg: The application configuration containing the bag and explored area
+//   - target: The type or Pokémon to draw out, in API format
+//
+// Returns:
+//   - An error if no area has been explored, the target is unknown, the bag
+//     has no lure, or the user decides not to replace the lure in use
+func useLure(cfg *config, target string) error {
+	location := cfg.ExploredArea()
+	if location == "" {
+		return errorhandling.NewInvalidInputError("Explore an area with 'explore <number>' before using a lure", nil)
+	}
+	if !slices.Contains(standardTypes, target) {
+		if err := ValidatePokemonName(cfg, FormatPokemonInput(target)); err != nil {
+			return err
+		}
+	}
+
+	item := lureInBag(cfg)
+	if item == "" {
+		return errorhandling.NewInvalidInputError("You don't have a lure. Buy Honey with 'shop buy honey'.", nil)
+	}
+	if active, ok := cfg.ActiveLure(); ok {
+		if !confirm(cfg, i18n.Sprintf("Replace the %s?", formatActiveLure(active))) {
+			return errors.New(i18n.T("operation cancelled"))
+		}
+	}
+
+	cfg.UseItem(item)
+	lure := pokedex.Lure{Item: item, Location: location, Target: target, Remaining: lureEncounters[item]}
+	cfg.SetLure(lure)
+	i18n.Printf("You used the %s in %s. %s will turn up more often in the next %d encounters here.\n",
+		FormatItemName(item), FormatLocationName(location), formatLureTarget(target), lure.Remaining)
+
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		HandleCommandError(cfg, "lure", err)
+	}
+	printSeparator()
+	return nil
+}
+
+// lureInBag returns the first lure item in the user's bag, in alphabetical
+// order, or "" if the bag has none.
+func lureInBag(cfg *config) string {
+	bag := cfg.Items()
+	for _, item := range slices.Sorted(maps.Keys(lureEncounters)) {
+		if bag[item] > 0 {
+			return item
+		}
+	}
+	return ""
+}
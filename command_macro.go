@@ -0,0 +1,111 @@
+// This file implements the macro command, which defines, lists, and removes
+// the user's macros (see macro_utils.go).
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// macroUsage describes the forms of the macro command.
+const macroUsage = `Usage: macro, macro define <name> "<commands>" (e.g. macro define hunt "map; explore $1; encounter"), or macro remove <name>`
+
+// commandMacro implements the "macro" command.
+// Supported forms:
+//   - macro: List the user's macros
+//   - macro define <name> "<commands>": Define a macro that runs the commands,
+//     with $1 to $9 standing for its parameters and $* for all of them
+//   - macro remove <name>: Remove a macro
+//
+// Parameters:
+//   - cfg: The application configuration containing the macros
+//   - params: Command parameters, with their capitalization kept for the commands
+//
+// Returns:
+//   - An error if the parameters are invalid or the macros can't be saved
+func commandMacro(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		listMacros(cfg)
+	case strings.EqualFold(params[0], "define") && len(params) >= 3:
+		err = defineMacro(cfg, strings.ToLower(params[1]), strings.Join(params[2:], " "))
+	case strings.EqualFold(params[0], "remove") && len(params) == 2:
+		name := strings.ToLower(params[1])
+		if !cfg.RemoveMacro(name) {
+			err = errorhandling.NewInvalidInputError(i18n.Sprintf("There's no macro named '%s'", name), nil)
+		} else {
+			i18n.Printf("Removed the macro %s.\n", name)
+			err = savePokedexData(cfg)
+		}
+	default:
+		err = errorhandling.NewInvalidInputError(macroUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "macro", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// listMacros shows the user's macros in alphabetical order, with the commands
+// each runs.
+func listMacros(cfg *config) {
+	macros := cfg.Macros()
+	if len(macros) == 0 {
+		i18n.Println(`You have no macros. Define one with 'macro define hunt "map; explore $1; encounter"'.`)
+		return
+	}
+	i18n.Println("Macros:")
+	names := make([]string, 0, len(macros))
+	for name := range macros {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		i18n.Printf(" - %s: %s\n", name, macros[name])
+	}
+	i18n.Println("Run one by typing its name and any parameters, like a command.")
+}
+
+// defineMacro defines a macro, replacing any earlier one with the same name,
+// and saves it.
+//
+// Parameters:
+//   - cfg: The application configuration containing the macros
+//   - name: The macro's name, in lowercase
+//   - body: The commands it runs, in double quotes if they contain ";" or "&&"
+//
+// Returns:
+//   - An error if the name can't be used or the macro can't be saved
+func defineMacro(cfg *config, name, body string) error {
+	if !macroNamePattern.MatchString(name) {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Invalid macro name: %s (use letters, digits, and dashes, starting with a letter)", name), nil)
+	}
+	if _, exists := getCommands()[name]; exists {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("'%s' is already a command, so it can't be a macro's name", name), nil)
+	}
+	body = unquoteText(body)
+	if len(splitChainedCommands(body)) == 0 {
+		return errorhandling.NewInvalidInputError(macroUsage, nil)
+	}
+
+	cfg.SetMacro(name, body)
+	i18n.Printf("Defined the macro %s, which runs: %s\n", name, body)
+	if count := macroParamCount(body); count == 1 {
+		i18n.Printf("Run it with '%s' followed by a parameter.\n", name)
+	} else if count > 1 {
+		i18n.Printf("Run it with '%s' followed by %d parameters.\n", name, count)
+	}
+	return savePokedexData(cfg)
+}
@@ -4,51 +4,76 @@
 package main
 
 import (
-	"fmt"
+	"log"
+	"sort"
+	"strings"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
 )
 
-// commandMap displays the first page of Pokémon location areas.
-// It retrieves data from the PokeAPI and displays a numbered list of up to 20 locations.
+// mapPage is the data behind the result of the map, next, and prev commands.
+type mapPage struct {
+	Locations   []mapLocation `json:"locations"`      // The location areas on the page, in the order shown
+	Sort        string        `json:"sort,omitempty"` // The order of the page ("" for the API order)
+	HasNext     bool          `json:"has_next"`       // Whether 'next' has a page to show
+	HasPrevious bool          `json:"has_previous"`   // Whether 'prev' has a page to show
+}
+
+// mapLocation is a location area on a page of the map.
+type mapLocation struct {
+	Number int    `json:"number"`           // Its number in the list, for 'explore'
+	Name   string `json:"name"`             // Its API name
+	Region string `json:"region,omitempty"` // Its region, when the page is grouped by region and it's known
+}
+
+// mapResult displays the first page of Pokémon location areas.
+// It retrieves data from the PokeAPI and lists the locations, numbered, as many
+// as the page size setting allows (20 unless changed with 'pagesize').
 // This command serves as the entry point for map exploration before using 'next' and 'prev'.
 //
+// Because the API returns locations in an arbitrary order, each page can be sorted:
+//   - map --sort name: Sort the locations on each page alphabetically
+//   - map --sort region: Group the locations on each page by region
+//
+// The chosen order also applies to pages shown by 'next' and 'prev', and running
+// 'map' without options restores the API order.
+//
 // The function stores the current location list and pagination URLs in the application config
 // for subsequent navigation commands.
 //
 // Parameters:
 //   - cfg: The application configuration for storing location data and pagination URLs
-//   - params: Command parameters (not used in this command)
+//   - params: Optional sort parameters ("--sort" followed by "name" or "region")
 //
 // Returns:
-//   - An error if there's an issue with the API request
-func commandMap(cfg *config, params []string) error {
-	// Get the URL to use - always use the base URL (nil) for the initial map command
-	var locationsResp, err = cfg.pokeapiClient.ListLocationAreas(nil)
+//   - The page of locations
+//   - An error if the sort parameters are invalid or there's an issue with the API request
+func mapResult(cfg *config, params []string) (commandResult, error) {
+	// Parse the optional sort order
+	sortOrder, err := parseMapSort(params)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "map", err) {
-			return err
-		}
-		return nil
+		return commandResult{}, err
 	}
+	cfg.UpdateSettings(func(s *settings) {
+		s.mapSort = sortOrder
+	})
 
-	// Update shared state with the utility function
-	UpdateLocationState(cfg, locationsResp, true)
-
-	// Display the location areas
-	for i, loc := range locationsResp.Results {
-		formattedLocation := FormatLocationName(loc.Name)
-		fmt.Printf("%d. %s\n", i+1, formattedLocation)
+	// Get the URL to use - always use the base URL (nil) for the initial map command
+	locationsResp, err := cfg.pokeapiClient.ListLocationAreas(nil, mapPageSize(cfg))
+	if err != nil {
+		return commandResult{}, err
 	}
-	fmt.Println("-----")
 
-	return nil
+	// Order, store, and list the page of locations
+	return showLocationPage(cfg, locationsResp, true), nil
 }
 
-// commandNext navigates to the next page of Pokémon location areas.
+// nextResult navigates to the next page of Pokémon location areas.
 // It uses the nextLocationURL stored in the application config to retrieve
-// the next set of up to 20 locations from the PokeAPI.
+// the next page of locations from the PokeAPI. The page starts right after the
+// current one, and holds as many locations as the current page size allows.
 //
 // If there are no more pages (nextLocationURL is nil), an error is returned.
 // This command can only be used after the 'map' command has been used at least once.
@@ -58,18 +83,12 @@ func commandMap(cfg *config, params []string) error {
 //   - params: Command parameters (not used in this command)
 //
 // Returns:
+//   - The page of locations
 //   - An error if there are no more pages or if there's an issue with the API request
-func commandNext(cfg *config, params []string) error {
+func nextResult(cfg *config, params []string) (commandResult, error) {
 	// Check if map has been viewed in this session
 	if !cfg.mapViewedThisSession {
-		// Create a specific error for this case
-		noMapViewedErr := errorhandling.NewInvalidInputError("You need to use the 'map' command first to load locations", nil)
-
-		// Use standardized error handling
-		if HandleCommandError(cfg, "next", noMapViewedErr) {
-			return noMapViewedErr
-		}
-		return nil
+		return commandResult{}, errorhandling.NewInvalidInputError("You need to use the 'map' command first to load locations", nil)
 	}
 
 	// Lock to prevent race conditions when reading/writing shared state
@@ -79,42 +98,23 @@ func commandNext(cfg *config, params []string) error {
 
 	// Check if there's a next page
 	if nextURL == nil {
-		// Create a specific error for this case
-		noNextErr := errorhandling.NewInvalidInputError("You're on the last page", nil)
-
-		// Use standardized error handling
-		if HandleCommandError(cfg, "next", noNextErr) {
-			return noNextErr
-		}
-		return nil
+		return commandResult{}, errorhandling.NewInvalidInputError("You're on the last page", nil)
 	}
 
 	// Make the API request with the next URL
-	locationsResp, err := cfg.pokeapiClient.ListLocationAreas(cfg.nextLocationURL)
+	locationsResp, err := cfg.pokeapiClient.ListLocationAreas(nextURL, mapPageSize(cfg))
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "next", err) {
-			return err
-		}
-		return nil
-	}
-
-	// Update shared state with the utility function
-	UpdateLocationState(cfg, locationsResp, false)
-
-	// Display the location areas
-	for i, loc := range locationsResp.Results {
-		formattedLocation := FormatLocationName(loc.Name)
-		fmt.Printf("%d. %s\n", i+1, formattedLocation)
+		return commandResult{}, err
 	}
-	fmt.Println("-----")
 
-	return nil
+	// Order, store, and list the page of locations
+	return showLocationPage(cfg, locationsResp, false), nil
 }
 
-// commandPrev navigates to the previous page of Pokémon location areas.
+// prevResult navigates to the previous page of Pokémon location areas.
 // It uses the prevLocationURL stored in the application config to retrieve
-// the previous set of up to 20 locations from the PokeAPI.
+// the previous page of locations from the PokeAPI, with as many locations as the
+// current page size allows.
 //
 // If there are no previous pages (prevLocationURL is nil), an error is returned.
 // This command can only be used after navigating forward at least once with 'next'.
@@ -124,18 +124,12 @@ func commandNext(cfg *config, params []string) error {
 //   - params: Command parameters (not used in this command)
 //
 // Returns:
+//   - The page of locations
 //   - An error if there are no previous pages or if there's an issue with the API request
-func commandPrev(cfg *config, params []string) error {
+func prevResult(cfg *config, params []string) (commandResult, error) {
 	// Check if map has been viewed in this session
 	if !cfg.mapViewedThisSession {
-		// Create a specific error for this case
-		noMapViewedErr := errorhandling.NewInvalidInputError("You need to use the 'map' command first to load locations", nil)
-
-		// Use standardized error handling
-		if HandleCommandError(cfg, "prev", noMapViewedErr) {
-			return noMapViewedErr
-		}
-		return nil
+		return commandResult{}, errorhandling.NewInvalidInputError("You need to use the 'map' command first to load locations", nil)
 	}
 
 	// Lock to prevent race conditions when reading/writing shared state
@@ -145,35 +139,129 @@ func commandPrev(cfg *config, params []string) error {
 
 	// Check if there's a previous page
 	if prevURL == nil {
-		// Create a specific error for this case
-		noPrevErr := errorhandling.NewInvalidInputError("You're on the first page", nil)
-
-		// Use standardized error handling
-		if HandleCommandError(cfg, "prev", noPrevErr) {
-			return noPrevErr
-		}
-		return nil
+		return commandResult{}, errorhandling.NewInvalidInputError("You're on the first page", nil)
 	}
 
 	// Make the API request with the previous URL
-	locationsResp, err := cfg.pokeapiClient.ListLocationAreas(cfg.prevLocationURL)
+	locationsResp, err := cfg.pokeapiClient.ListLocationAreas(prevURL, mapPageSize(cfg))
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "prev", err) {
-			return err
-		}
-		return nil
+		return commandResult{}, err
+	}
+
+	// Order, store, and list the page of locations
+	return showLocationPage(cfg, locationsResp, false), nil
+}
+
+// Map sort orders accepted by 'map --sort'
+const (
+	mapSortName   = "name"
+	mapSortRegion = "region"
+)
+
+// parseMapSort parses the optional "--sort <order>" parameters of the map command.
+//
+// Parameters:
+//   - params: The map command parameters
+//
+// Returns:
+//   - The sort order, or an empty string for the API order
+//   - An error if the parameters are invalid
+func parseMapSort(params []string) (string, error) {
+	if len(params) == 0 {
+		return "", nil
+	}
+	if len(params) == 2 && params[0] == "--sort" && (params[1] == mapSortName || params[1] == mapSortRegion) {
+		return params[1], nil
+	}
+	return "", errorhandling.NewInvalidInputError("Usage: map [--sort name|region]", nil)
+}
+
+// showLocationPage orders a page of locations according to the current map sort,
+// stores it as the current location list, and lists it. The stored list uses
+// the displayed order so that 'explore' numbers match what the user sees.
+//
+// Parameters:
+//   - cfg: The application configuration containing the map sort order
+//   - locationsResp: The page of locations returned by the API
+//   - markMapViewed: Whether to record that the map has been viewed this session
+//
+// Returns:
+//   - The page of locations, in the order shown
+func showLocationPage(cfg *config, locationsResp pokeapi.LocationAreasResp, markMapViewed bool) commandResult {
+	cfg.mutex.RLock()
+	sortOrder := cfg.Settings().mapSort
+	cfg.mutex.RUnlock()
+
+	// Sort a copy so the cached API response isn't modified
+	locations := append([]pokeapi.NamedAPIResource(nil), locationsResp.Results...)
+	var regions map[string]string
+	switch sortOrder {
+	case mapSortName:
+		sort.SliceStable(locations, func(i, j int) bool {
+			return locations[i].Name < locations[j].Name
+		})
+	case mapSortRegion:
+		regions = lookupLocationRegions(cfg, locations)
+		sort.SliceStable(locations, func(i, j int) bool {
+			ri, rj := regions[locations[i].Name], regions[locations[j].Name]
+			if ri != rj {
+				// Locations without a known region are listed last
+				if ri == "" || rj == "" {
+					return rj == ""
+				}
+				return ri < rj
+			}
+			return locations[i].Name < locations[j].Name
+		})
 	}
 
 	// Update shared state with the utility function
-	UpdateLocationState(cfg, locationsResp, false)
+	locationsResp.Results = locations
+	UpdateLocationState(cfg, locationsResp, markMapViewed)
 
-	// Display the location areas
-	for i, loc := range locationsResp.Results {
-		formattedLocation := FormatLocationName(loc.Name)
-		fmt.Printf("%d. %s\n", i+1, formattedLocation)
+	// List the location areas, with a heading for each region when grouping
+	page := mapPage{
+		Locations:   make([]mapLocation, 0, len(locations)),
+		Sort:        sortOrder,
+		HasNext:     locationsResp.Next != nil,
+		HasPrevious: locationsResp.Previous != nil,
+	}
+	var message strings.Builder
+	currentRegion := "-"
+	for i, loc := range locations {
+		if regions != nil && regions[loc.Name] != currentRegion {
+			currentRegion = regions[loc.Name]
+			if currentRegion == "" {
+				i18n.Fprintln(&message, "Unknown region:")
+			} else {
+				i18n.Fprintf(&message, "%s:\n", FormatLocationName(currentRegion))
+			}
+		}
+		i18n.Fprintf(&message, "%d. %s\n", i+1, FormatLocationName(loc.Name))
+		page.Locations = append(page.Locations, mapLocation{Number: i + 1, Name: loc.Name, Region: regions[loc.Name]})
 	}
-	fmt.Println("-----")
+	return commandResult{Message: message.String(), Data: page}
+}
 
-	return nil
+// lookupLocationRegions finds the region of each location area on a page.
+// Responses are cached, so only the first lookup of each area and location
+// makes API requests. Areas whose region can't be determined are mapped to
+// an empty string rather than failing the whole page.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - locations: The location areas to look up
+//
+// Returns:
+//   - A map from location area name to region name
+func lookupLocationRegions(cfg *config, locations []pokeapi.NamedAPIResource) map[string]string {
+	regions := make(map[string]string, len(locations))
+	for _, loc := range locations {
+		region, err := cfg.pokeapiClient.GetLocationAreaRegion(loc.Name)
+		if err != nil && cfg.Settings().debugMode {
+			log.Printf("Could not look up the region of %s: %v", loc.Name, err)
+		}
+		regions[loc.Name] = region
+	}
+	return regions
 }
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// mapTestConfig returns a configuration whose API responses come from
+// fixtures for location areas in Kanto and Hoenn, and one in a location
+// without a region.
+func mapTestConfig(t *testing.T) *config {
+	t.Helper()
+	dir := t.TempDir()
+	fixtures := map[string]string{
+		"location-area/viridian-forest-area.json": `{"name": "viridian-forest-area", "location": {"name": "viridian-forest"}}`,
+		"location-area/pallet-town-area.json":     `{"name": "pallet-town-area", "location": {"name": "pallet-town"}}`,
+		"location-area/petalburg-woods-area.json": `{"name": "petalburg-woods-area", "location": {"name": "petalburg-woods"}}`,
+		"location-area/mystery-zone-area.json":    `{"name": "mystery-zone-area", "location": {"name": "mystery-zone"}}`,
+		"location/viridian-forest.json":           `{"name": "viridian-forest", "region": {"name": "kanto"}}`,
+		"location/pallet-town.json":               `{"name": "pallet-town", "region": {"name": "kanto"}}`,
+		"location/petalburg-woods.json":           `{"name": "petalburg-woods", "region": {"name": "hoenn"}}`,
+		"location/mystery-zone.json":              `{"name": "mystery-zone", "region": null}`,
+	}
+	for path, data := range fixtures {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := pokeapi.NewClient(time.Hour)
+	client.UseFixtures(dir, false)
+	return &config{pokeapiClient: client, pokedex: pokedex.New(), settings: defaultSettings()}
+}
+
+// TestShowLocationPageSorted tests the order of a map page sorted by name and
+// grouped by region, and the region headings
+func TestShowLocationPageSorted(t *testing.T) {
+	page := pokeapi.LocationAreasResp{}
+	for _, name := range []string{"viridian-forest-area", "mystery-zone-area", "petalburg-woods-area", "pallet-town-area"} {
+		page.Results = append(page.Results, pokeapi.NamedAPIResource{Name: name})
+	}
+
+	cases := []struct {
+		sort     string
+		want     []string // The API names of the locations, in the order shown
+		headings []string // The region headings, in the order shown
+	}{
+		{"", []string{"viridian-forest-area", "mystery-zone-area", "petalburg-woods-area", "pallet-town-area"}, nil},
+		{mapSortName, []string{"mystery-zone-area", "pallet-town-area", "petalburg-woods-area", "viridian-forest-area"}, nil},
+		{mapSortRegion, []string{"petalburg-woods-area", "pallet-town-area", "viridian-forest-area", "mystery-zone-area"},
+			[]string{"Hoenn:", "Kanto:", "Unknown region:"}},
+	}
+	for _, c := range cases {
+		cfg := mapTestConfig(t)
+		cfg.settings.mapSort = c.sort
+		result := showLocationPage(cfg, page, true)
+
+		shown := result.Data.(mapPage).Locations
+		var names []string
+		for i, loc := range shown {
+			names = append(names, loc.Name)
+			if loc.Number != i+1 {
+				t.Errorf("sort %q: expected %s to be numbered %d, got %d", c.sort, loc.Name, i+1, loc.Number)
+			}
+		}
+		if !slices.Equal(names, c.want) {
+			t.Errorf("sort %q: expected %v, got %v", c.sort, c.want, names)
+		}
+		var recent []string
+		for _, loc := range cfg.recentLocations {
+			recent = append(recent, loc.Name)
+		}
+		if !slices.Equal(recent, c.want) {
+			t.Errorf("sort %q: expected explore's numbers to follow the order shown, got %v", c.sort, recent)
+		}
+
+		var headings []string
+		for _, line := range strings.Split(result.Message, "\n") {
+			if strings.HasSuffix(line, ":") {
+				headings = append(headings, line)
+			}
+		}
+		if !slices.Equal(headings, c.headings) {
+			t.Errorf("sort %q: expected the headings %v, got %v", c.sort, c.headings, headings)
+		}
+		if c.sort == mapSortRegion {
+			want := "Hoenn:\n1. " + FormatLocationName("petalburg-woods-area") +
+				"\nKanto:\n2. " + FormatLocationName("pallet-town-area") + "\n3. " + FormatLocationName("viridian-forest-area") +
+				"\nUnknown region:\n4. " + FormatLocationName("mystery-zone-area") + "\n"
+			if result.Message != want {
+				t.Errorf("Expected each region's heading before its locations:\n%s\ngot:\n%s", want, result.Message)
+			}
+		}
+	}
+	if page.Results[0].Name != "viridian-forest-area" {
+		t.Error("Expected the API response not to be sorted in place")
+	}
+}
@@ -0,0 +1,213 @@
+// This file implements the middleware pipeline that wraps command execution.
+// Middleware adds behavior around every command, such as crash recovery and
+// debug timing, without each command having to implement it individually.
+package main
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// commandFunc is the signature shared by all command callbacks.
+type commandFunc func(*config, []string) error
+
+// commandMiddleware wraps a command callback with additional behavior.
+// It receives the command being run and the next function in the pipeline,
+// and returns a function that calls next at the appropriate point.
+type commandMiddleware func(command cliCommand, next commandFunc) commandFunc
+
+// commandPipeline lists the middleware applied to every command, outermost first.
+// Crash recovery is outermost so that panics in other middleware are also caught.
+var commandPipeline = []commandMiddleware{
+	recoverMiddleware,
+	interruptMiddleware,
+	usageMiddleware,
+	decodeProblemsMiddleware,
+	selectionMiddleware,
+	jsonMiddleware,
+	dryRunMiddleware,
+	timingMiddleware,
+}
+
+// executeCommand runs a command's callback through the middleware pipeline.
+//
+// Parameters:
+//   - cfg: The application configuration passed to the command
+//   - command: The command to execute
+//   - params: The parameters for the command
+//
+// Returns:
+//   - The error returned by the command or by the middleware
+func executeCommand(cfg *config, command cliCommand, params []string) error {
+	defer setSaveTrigger(cfg, command.name)()
+	callback := commandFunc(command.callback)
+	if command.result != nil {
+		callback = resultCallback(command)
+	}
+	for i := len(commandPipeline) - 1; i >= 0; i-- {
+		callback = commandPipeline[i](command, callback)
+	}
+	return callback(cfg, params)
+}
+
+// recoverMiddleware recovers from any panic raised by a command, so that one
+// failing command doesn't end the whole session. When a command panics, the
+// stack trace is written to the debug log and to a crash report (see
+// crash_report.go), an emergency save is attempted to
+// protect the user's Pokédex (in batch mode, the batch's changes are rolled back
+// instead), and an internal error is returned for the REPL to display.
+func recoverMiddleware(command cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, params []string) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				stack := debug.Stack()
+				log.Printf("PANIC in command '%s': %v\n%s", command.name, r, stack)
+				reportCrash(cfg, command.name, r, stack)
+				if cfg.batch != nil {
+					// The crash may have left the Pokédex inconsistent, so the batch isn't saved
+					cfg.batch.crashed = true
+				} else {
+					emergencySave(cfg)
+				}
+				err = errorhandling.NewInternalError(
+					i18n.Sprintf("The '%s' command crashed unexpectedly, but your session is still running", command.name),
+					fmt.Errorf("panic: %v", r))
+			}
+		}()
+
+		return next(cfg, params)
+	}
+}
+
+// interruptMiddleware lets the user cancel a running command with Ctrl+C
+// instead of ending the program. The command gets a context, through
+// commandContext, that's cancelled when Ctrl+C is pressed; long-running
+// commands stop what they're doing when it is, and prompts stop waiting for
+// input. The shared PokeAPI client uses the same context while the command
+// runs, so any request it's waiting on is abandoned too.
+func interruptMiddleware(command cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, params []string) error {
+		ctx, stop := interruptContext(cfg)
+		defer stop()
+		previous, previousClient := cfg.commandCtx, cfg.pokeapiClient
+		cfg.commandCtx = ctx
+		if previousClient != nil {
+			cfg.pokeapiClient = previousClient.WithContext(ctx)
+		}
+		defer func() { cfg.commandCtx, cfg.pokeapiClient = previous, previousClient }()
+		return next(cfg, params)
+	}
+}
+
+// usageMiddleware counts each run of a command, when the user has turned
+// counting on with 'usage on'. Only the command's name is recorded; its
+// parameters never are.
+func usageMiddleware(command cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, params []string) error {
+		recordUsage(cfg, command.name)
+		return next(cfg, params)
+	}
+}
+
+// decodeProblemsMiddleware warns about saved Pokédex entries that couldn't be
+// decoded when a command first used them (see internal/pokedex/lazy.go). Such
+// entries are kept as they were saved, so the warning says how to find and fix
+// them rather than suggesting the data was lost.
+func decodeProblemsMiddleware(command cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, params []string) error {
+		err := next(cfg, params)
+		for _, problem := range cfg.pokedex.DecodeProblems() {
+			i18n.Printf("Warning: %v\n", problem)
+			i18n.Println("The entry is kept unchanged in your save file, but can't be used until it's fixed. Run 'doctor' for details.")
+		}
+		return err
+	}
+}
+
+// jsonMiddleware rejects the --json flag for commands that can't print their
+// output as JSON: those that still print directly rather than return a result
+// (see result_utils.go) and don't handle the flag themselves. Without it, the
+// flag would be taken as one of the command's parameters.
+func jsonMiddleware(command cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, params []string) error {
+		if _, asJSON := takeFlag(params, jsonFlag); asJSON && command.result == nil && !strings.Contains(command.args, jsonFlag) {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("The '%s' command doesn't support %s", command.name, jsonFlag), nil)
+		}
+		return next(cfg, params)
+	}
+}
+
+// dryRunMiddleware handles the --dry-run flag, which may be given to any command
+// that supports it. Instead of running normally, the command's changes are
+// previewed: they are listed and then undone, and nothing is saved. Commands
+// that don't support previews reject the flag rather than making real changes.
+func dryRunMiddleware(command cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, params []string) error {
+		params, dryRun := takeDryRunFlag(params)
+		if !dryRun {
+			return next(cfg, params)
+		}
+		if !command.dryRun {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("The '%s' command doesn't support %s", command.name, dryRunFlag), nil)
+		}
+		return previewCommand(cfg, command.name, params, next)
+	}
+}
+
+// timingMiddleware reports how long each command took when debug mode is enabled,
+// along with how many API calls it made, how many requests were served from
+// the cache, and how many shared an identical request already in progress.
+// This helps identify slow commands, such as those that chain requests.
+func timingMiddleware(command cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, params []string) error {
+		if !cfg.Settings().debugMode {
+			return next(cfg, params)
+		}
+
+		statsBefore := cfg.pokeapiClient.Stats()
+		start := time.Now()
+
+		err := next(cfg, params)
+
+		elapsed := time.Since(start)
+		stats := cfg.pokeapiClient.Stats().Sub(statsBefore)
+		log.Printf("TIMING: [%s] took %v (API calls: %d, cache hits: %d, coalesced: %d)",
+			command.name, elapsed.Round(time.Microsecond), stats.APICalls, stats.CacheHits, stats.Coalesced)
+
+		return err
+	}
+}
+
+// emergencySave attempts to save the Pokédex after a command has crashed.
+// The save runs in the background with a timeout, because the crashed command
+// may have left the configuration locked; in that case the save is abandoned
+// rather than hanging the REPL.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex to save
+func emergencySave(cfg *config) {
+	done := make(chan error, 1)
+	go func() {
+		done <- savePokedexData(cfg)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			i18n.Printf("Warning: Could not save Pokédex data after the crash: %v\n", err)
+		} else {
+			i18n.Println("Your Pokédex was saved as a precaution.")
+		}
+	case <-time.After(pokedex.LockTimeout):
+		i18n.Println("Warning: Could not save Pokédex data after the crash: timed out")
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestTimingMiddleware tests that a command's timing is reported in debug
+// mode only, and that the command's error is passed on either way
+func TestTimingMiddleware(t *testing.T) {
+	var logged bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	defer log.SetFlags(log.Flags())
+	log.SetOutput(&logged)
+	log.SetFlags(0)
+
+	cfg := &config{pokeapiClient: pokeapi.NewClient(time.Hour), settings: defaultSettings()}
+	failed := errors.New("failed")
+	ran := 0
+	next := func(cfg *config, params []string) error {
+		ran++
+		return failed
+	}
+	timed := timingMiddleware(cliCommand{name: "explore"}, next)
+
+	if err := timed(cfg, nil); err != failed || ran != 1 {
+		t.Errorf("Expected the command to run and its error to be returned, got %v", err)
+	}
+	if logged.Len() != 0 {
+		t.Errorf("Expected no timing outside debug mode, got %q", logged.String())
+	}
+
+	cfg.settings.debugMode = true
+	if err := timed(cfg, nil); err != failed || ran != 2 {
+		t.Errorf("Expected the command to run and its error to be returned, got %v", err)
+	}
+	if got := logged.String(); !strings.HasPrefix(got, "TIMING: [explore] took ") || !strings.Contains(got, "API calls: 0") {
+		t.Errorf("Expected the command's timing in debug mode, got %q", got)
+	}
+}
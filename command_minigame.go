@@ -0,0 +1,198 @@
+// This file implements the minigame command for the Pokédex CLI application.
+// Minigames are short Pokéathlon-style games played in the terminal with one
+// of the user's Pokémon, whose stats make the game easier or harder. Playing
+// earns the Pokémon happiness, and its best score in each game is kept.
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// minigameUsage describes the forms of the minigame command.
+const minigameUsage = "Usage: minigame, or minigame <game> <pokemon> (e.g. minigame reaction pikachu)"
+
+// reactionRounds is the number of rounds in a game of Reaction Dash.
+const reactionRounds = 3
+
+// hideLines is the number of blank lines printed to scroll something out of
+// view, such as a Memory Match sequence before the user is asked to repeat it,
+// or a player's secret choice in a hotseat battle.
+const hideLines = 40
+
+// commandMinigame implements the "minigame" command.
+// Supported forms:
+//   - minigame: List the minigames and the stat that helps in each
+//   - minigame <game> <pokemon>: Play a minigame with a caught Pokémon
+//
+// Minigames read the user's key presses as they happen, so they can't be
+// played in batch mode.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and input reader
+//   - params: Command parameters where params[0] is the game and the rest form the Pokémon name
+//
+// Returns:
+//   - An error if the game or Pokémon is invalid, or the input isn't interactive
+func commandMinigame(cfg *config, params []string) error {
+	var err error
+	if len(params) == 0 {
+		listMinigames()
+	} else {
+		err = playMinigame(cfg, params[0], params[1:])
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "minigame", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// listMinigames displays the minigames and the stat that helps in each.
+func listMinigames() {
+	table := NewTable("Game", "Name", "Helped by")
+	for _, game := range minigames {
+		table.AddRow(game.id, i18n.T(game.name), FormatStatName(game.stat))
+	}
+	table.Print()
+	i18n.Println("Play one with 'minigame <game> <pokemon>'.")
+	printSeparator()
+}
+
+// playMinigame plays a game with a Pokémon and records the result.
+func playMinigame(cfg *config, gameID string, params []string) error {
+	game, ok := findMinigame(gameID)
+	if !ok {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown minigame '%s'. %s", gameID, i18n.T(minigameUsage)), nil)
+	}
+	if len(params) == 0 {
+		return errorhandling.NewInvalidInputError(minigameUsage, nil)
+	}
+	apiName, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, []string{strings.Join(params, " ")})
+	if err != nil {
+		return err
+	}
+	entry, err := GetTypedPokemonData(pokemonData, nameInfo.Formatted)
+	if err != nil {
+		return err
+	}
+	if entry.InDaycare() {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("%s is at the day care. Withdraw it first", nameInfo.Formatted), nil)
+	}
+	if cfg.batch != nil {
+		return errorhandling.NewInvalidInputError("Minigames need an interactive terminal and can't be played in batch mode", nil)
+	}
+
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	stat := minigameStat(game, entry.PokemonDataResp)
+	i18n.Printf("%s is playing %s! (%s %d)\n", nameInfo.Formatted, i18n.T(game.name), FormatStatName(game.stat), stat)
+
+	var score int
+	switch game.id {
+	case minigameReaction:
+		score, err = playReaction(cfg, rng, stat)
+	case minigameMemory:
+		score, err = playMemory(cfg, rng, stat)
+	}
+	if err != nil {
+		return err
+	}
+
+	var happiness, best int
+	var newBest bool
+	err = cfg.pokedex.Update(apiName, func(e *pokedex.Entry) error {
+		happiness = min(e.Happiness+happinessEarned(score), maxHappiness) - e.Happiness
+		e.Happiness += happiness
+		newBest = e.RecordMinigameScore(game.id, score)
+		best = e.MinigameScores[game.id]
+		return nil
+	})
+	if err != nil {
+		return pokedexError(err, apiName)
+	}
+
+	i18n.Printf("Score: %d/%d\n", score, maxMinigameScore)
+	if newBest {
+		i18n.Printf("That's a new best for %s!\n", nameInfo.Formatted)
+	} else {
+		i18n.Printf("%s's best is %d.\n", nameInfo.Formatted, best)
+	}
+	if happiness > 0 {
+		i18n.Printf("%s had fun and gained %d happiness.\n", nameInfo.Formatted, happiness)
+	}
+
+	// Auto-save after recording the score
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		HandleCommandError(cfg, "minigame", err)
+	}
+	printSeparator()
+	return nil
+}
+
+// playReaction plays Reaction Dash: after a random pause the user presses
+// Enter as quickly as they can. The score is the average of the rounds.
+func playReaction(cfg *config, rng *rand.Rand, speed int) (int, error) {
+	i18n.Printf("Press Enter as soon as you see GO! You have %.1f seconds each round.\n",
+		reactionWindow(speed).Seconds())
+	total := 0
+	for round := 1; round <= reactionRounds; round++ {
+		i18n.Printf("Round %d: get ready...\n", round)
+		time.Sleep(time.Second + time.Duration(rng.Int63n(int64(2*time.Second))))
+
+		i18n.Println("GO!")
+		start := time.Now()
+		if _, err := readMinigameLine(cfg); err != nil {
+			return 0, err
+		}
+		reaction := time.Since(start)
+
+		score := reactionScore(reaction, speed)
+		if reaction < falseStartThreshold {
+			i18n.Println("False start!")
+		} else {
+			i18n.Printf("%d ms: %d points\n", reaction.Milliseconds(), score)
+		}
+		total += score
+	}
+	return total / reactionRounds, nil
+}
+
+// playMemory plays Memory Match: the user studies a sequence of digits, which
+// is then scrolled out of view, and types it back from memory.
+func playMemory(cfg *config, rng *rand.Rand, specialAttack int) (int, error) {
+	sequence := newMemorySequence(rng, memorySequenceLength(specialAttack))
+	i18n.Printf("Remember these %d digits: %s\n", len(sequence), sequence)
+	i18n.Println("Press Enter when you're ready.")
+	if _, err := readMinigameLine(cfg); err != nil {
+		return 0, err
+	}
+	fmt.Print(strings.Repeat("\n", hideLines))
+
+	i18n.Println("Type the digits:")
+	answer, err := readMinigameLine(cfg)
+	if err != nil {
+		return 0, err
+	}
+	i18n.Printf("The digits were %s.\n", sequence)
+	return memoryScore(sequence, answer), nil
+}
+
+// readMinigameLine reads one line of input during a minigame.
+func readMinigameLine(cfg *config) (string, error) {
+	line, err := readLine(cfg)
+	if err != nil && line == "" {
+		return "", errorhandling.NewInvalidInputError("The minigame ended because the input closed", err)
+	}
+	return strings.TrimSpace(line), nil
+}
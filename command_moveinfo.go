@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// commandMoveInfo displays the details of a move: its type, damage class,
+// power, accuracy, PP, priority, effect, and description. The description is
+// taken from the selected version group when it has one (see 'versiongroup').
+// Moves are cached like the other game data, so looking one up again is instant.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - params: Command parameters forming the move name (e.g. "thunder punch")
+//
+// Returns:
+//   - An error if no move name is provided, the move doesn't exist,
+//     or there's an issue with the API request
+func commandMoveInfo(cfg *config, params []string) error {
+	move := ConvertToAPIFormat(strings.Join(params, " "))
+	if move == "" {
+		err := errorhandling.NewInvalidInputError("No move name provided (e.g., 'moveinfo thunderbolt')", nil)
+		if HandleCommandError(cfg, "moveinfo", err) {
+			return err
+		}
+		return nil
+	}
+
+	moveData, err := cfg.pokeapiClient.GetMove(move)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "moveinfo", err) {
+			return err
+		}
+		return nil
+	}
+
+	printMoveInfo(moveData, cfg.Settings().versionGroup)
+	printSeparator()
+	return nil
+}
+
+// printMoveInfo displays a move's details.
+//
+// Parameters:
+//   - move: The move's data from the API
+//   - versionGroup: The version group whose description is preferred, or "" for the latest
+func printMoveInfo(move pokeapi.MoveResp, versionGroup string) {
+	i18n.Printf("Name: %s (#%d)\n", FormatMoveName(move.Name), move.ID)
+	i18n.Printf("Type: %s\n", FormatTypeName(move.Type.Name))
+	i18n.Printf("Category: %s\n", damageClassName(move.DamageClass.Name))
+	i18n.Printf("Power: %s\n", optionalMoveValue(move.Power, "%d"))
+	i18n.Printf("Accuracy: %s\n", optionalMoveValue(move.Accuracy, "%d%%"))
+	i18n.Printf("PP: %d\n", move.PP)
+	i18n.Printf("Priority: %s\n", formatPriority(move.Priority))
+	if move.EffectChance != nil {
+		i18n.Printf("Effect chance: %d%%\n", *move.EffectChance)
+	}
+	if effect := move.EnglishEffect(); effect != "" {
+		i18n.Printf("Effect: %s\n", cleanFlavorText(effect))
+	}
+	if text := move.EnglishFlavorText(versionGroup); text != "" {
+		i18n.Printf("Description: %s\n", cleanFlavorText(text))
+	}
+}
+
+// damageClassName returns the display name of a move's damage class.
+func damageClassName(class string) string {
+	switch class {
+	case "physical":
+		return i18n.T("Physical")
+	case "special":
+		return i18n.T("Special")
+	case "status":
+		return i18n.T("Status")
+	}
+	return FormatTypeName(class)
+}
+
+// optionalMoveValue formats a move's power or accuracy, which the API leaves
+// out for moves that don't deal damage directly or never miss.
+func optionalMoveValue(value *int, format string) string {
+	if value == nil {
+		return "—"
+	}
+	return fmt.Sprintf(format, *value)
+}
+
+// formatPriority formats a move's priority with its sign, so that moves that
+// go first (like Quick Attack, +1) stand out from the usual 0.
+func formatPriority(priority int) string {
+	if priority > 0 {
+		return fmt.Sprintf("+%d", priority)
+	}
+	return fmt.Sprint(priority)
+}
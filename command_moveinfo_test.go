@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// TestMoveInfoFormatting tests how priorities and missing power or accuracy are shown
+func TestMoveInfoFormatting(t *testing.T) {
+	for priority, expected := range map[int]string{1: "+1", 0: "0", -6: "-6"} {
+		if actual := formatPriority(priority); actual != expected {
+			t.Errorf("formatPriority(%d) == %q, expected %q", priority, actual, expected)
+		}
+	}
+
+	accuracy := 95
+	if actual := optionalMoveValue(&accuracy, "%d%%"); actual != "95%" {
+		t.Errorf("Expected 95%%, got %q", actual)
+	}
+	if actual := optionalMoveValue(nil, "%d"); actual != "—" {
+		t.Errorf("Expected a dash for a missing value, got %q", actual)
+	}
+}
+
+// TestMoveInfoRequiresName tests that moveinfo without a move is an input error
+func TestMoveInfoRequiresName(t *testing.T) {
+	err := commandMoveInfo(&config{}, nil)
+	if !errorhandling.IsInvalidInputError(err) {
+		t.Errorf("Expected an invalid input error, got %v", err)
+	}
+}
@@ -0,0 +1,182 @@
+// This file implements the move tutor commands for the Pokédex CLI application.
+// Each caught Pokémon can have an active moveset of up to four moves chosen from
+// the moves it can learn. The moveset is saved with the Pokédex and used when
+// the Pokémon shows off.
+package main
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// commandTeach adds a move to a caught Pokémon's active moveset.
+// The move must be one the Pokémon can learn, and a Pokémon can know at most
+// four moves at a time. Without a move name, the current moveset is shown.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters forming the Pokémon name followed by the move name
+//     (e.g. "pikachu thunder punch")
+//
+// Returns:
+//   - An error if the Pokémon is not in the Pokédex, can't learn the move,
+//     already knows it, or already knows four moves
+func commandTeach(cfg *config, params []string) error {
+	apiName, nameInfo, moveInput, err := splitPokemonParams(cfg, params)
+	if err == nil && moveInput == "" {
+		printMoveset(cfg, apiName, nameInfo)
+		return nil
+	}
+
+	if err == nil {
+		err = teachMove(cfg, apiName, nameInfo, ConvertToAPIFormat(moveInput))
+	}
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "teach", err) {
+			return err
+		}
+		return nil
+	}
+
+	// Auto-save after changing the moveset
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		HandleCommandError(cfg, "teach", err)
+	}
+	return nil
+}
+
+// teachMove validates a move and adds it to a Pokémon's moveset.
+func teachMove(cfg *config, apiName string, nameInfo PokemonNameInfo, move string) error {
+	formattedMove := FormatMoveName(move)
+	versionGroup := cfg.Settings().versionGroup
+
+	err := cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		switch {
+		case !entry.CanLearn(move, ""):
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s can't learn %s", nameInfo.Formatted, formattedMove), nil)
+		case !entry.CanLearn(move, versionGroup):
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s can't learn %s in %s. Use 'versiongroup all' to allow moves from every game",
+					nameInfo.Formatted, formattedMove, FormatLocationName(versionGroup)), nil)
+		case entry.KnowsMove(move):
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s already knows %s", nameInfo.Formatted, formattedMove), nil)
+		case len(entry.Moveset) >= pokedex.MaxMovesetSize:
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s already knows %d moves. Use 'forget %s <move>' to make room first",
+					nameInfo.Formatted, pokedex.MaxMovesetSize, apiName), nil)
+		}
+		entry.Moveset = append(entry.Moveset, move)
+		return nil
+	})
+	if err != nil {
+		return pokedexError(err, apiName)
+	}
+
+	i18n.Printf("%s learned %s!\n", nameInfo.Formatted, formattedMove)
+	printSeparator()
+	return nil
+}
+
+// commandForget removes a move from a caught Pokémon's active moveset.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters forming the Pokémon name followed by the move name
+//
+// Returns:
+//   - An error if the Pokémon is not in the Pokédex or doesn't know the move
+func commandForget(cfg *config, params []string) error {
+	apiName, nameInfo, moveInput, err := splitPokemonParams(cfg, params)
+	if err == nil && moveInput == "" {
+		err = errorhandling.NewInvalidInputError("Usage: forget <pokemon> <move>", nil)
+	}
+
+	if err == nil {
+		err = forgetMove(cfg, apiName, nameInfo, ConvertToAPIFormat(moveInput))
+	}
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "forget", err) {
+			return err
+		}
+		return nil
+	}
+
+	// Auto-save after changing the moveset
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		HandleCommandError(cfg, "forget", err)
+	}
+	return nil
+}
+
+// forgetMove removes a move from a Pokémon's moveset.
+func forgetMove(cfg *config, apiName string, nameInfo PokemonNameInfo, move string) error {
+	err := cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		if !entry.KnowsMove(move) {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("%s doesn't know %s", nameInfo.Formatted, FormatMoveName(move)), nil)
+		}
+
+		moveset := make([]string, 0, len(entry.Moveset)-1)
+		for _, m := range entry.Moveset {
+			if m != move {
+				moveset = append(moveset, m)
+			}
+		}
+		entry.Moveset = moveset
+		return nil
+	})
+	if err != nil {
+		return pokedexError(err, apiName)
+	}
+
+	i18n.Printf("%s forgot %s.\n", nameInfo.Formatted, FormatMoveName(move))
+	printSeparator()
+	return nil
+}
+
+// printMoveset displays a Pokémon's active moveset.
+func printMoveset(cfg *config, apiName string, nameInfo PokemonNameInfo) {
+	entry, _ := cfg.pokedex.Get(apiName)
+	versionGroup := cfg.Settings().versionGroup
+	learnable := entry.LearnableMoves(versionGroup)
+
+	if len(entry.Moveset) == 0 && versionGroup != "" {
+		i18n.Printf("%s hasn't been taught any moves. It can learn %d moves in %s, e.g. 'teach %s %s'.\n",
+			nameInfo.Formatted, len(learnable), FormatLocationName(versionGroup), apiName, exampleMove(learnable))
+	} else if len(entry.Moveset) == 0 {
+		i18n.Printf("%s hasn't been taught any moves. It can learn %d moves, e.g. 'teach %s %s'.\n",
+			nameInfo.Formatted, len(learnable), apiName, exampleMove(learnable))
+	} else {
+		i18n.Printf("%s knows %d of %d moves:\n", nameInfo.Formatted, len(entry.Moveset), pokedex.MaxMovesetSize)
+		writeMovesetTable(os.Stdout, entry.Moveset)
+		// Number the moves, so 'forget <pokemon> #2' can choose one
+		cfg.SetSelection(selectMove, "teach", entry.Moveset)
+	}
+	printSeparator()
+}
+
+// writeMovesetTable writes a list of moves as a table, numbered from 1.
+func writeMovesetTable(w io.Writer, moves []string) {
+	table := NewTable("#", "Move")
+	for i, move := range moves {
+		table.AddRow(strconv.Itoa(i+1), FormatMoveName(move))
+	}
+	table.Render(w)
+}
+
+// exampleMove returns the first of a Pokémon's learnable moves, for use in usage hints.
+func exampleMove(learnable []string) string {
+	if len(learnable) == 0 {
+		return "<move>"
+	}
+	return learnable[0]
+}
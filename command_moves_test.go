@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// movesTestConfig returns a configuration whose API responses come from a
+// fixture for a Pikachu that can learn five moves, one of them only in Sword
+// and Shield, and a Pokédex holding that Pikachu.
+func movesTestConfig(t *testing.T) *config {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "pokemon")
+	stats := make([]string, len(statOrder))
+	for i, stat := range statOrder {
+		stats[i] = `{"base_stat": 50, "stat": {"name": "` + stat + `"}}`
+	}
+	fixture := `{"name": "pikachu", "species": {"name": "pikachu", "url": "https://pokeapi.co/api/v2/pokemon-species/25/"}, "stats": [` + strings.Join(stats, ",") + `],
+		"types": [{"slot": 1, "type": {"name": "electric"}}], "moves": [
+		{"move": {"name": "thunderbolt"}},
+		{"move": {"name": "quick-attack"}},
+		{"move": {"name": "thunder-wave"}},
+		{"move": {"name": "iron-tail"}},
+		{"move": {"name": "volt-tackle"}, "version_group_details": [{"version_group": {"name": "sword-shield"}}]}]}`
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pikachu.json"), []byte(fixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client := pokeapi.NewClient(time.Hour)
+	client.UseFixtures(filepath.Dir(dir), false)
+	cfg := &config{pokeapiClient: client, pokedex: pokedex.New(), settings: defaultSettings()}
+
+	data, err := client.GetPokemonData("pikachu")
+	if err != nil {
+		t.Fatalf("Could not load the Pikachu fixture: %v", err)
+	}
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(data))
+	return cfg
+}
+
+// TestMovesetChanges tests the moveset's four-move cap, that only moves the
+// species can learn are taught, and replacing a move by forgetting one first
+func TestMovesetChanges(t *testing.T) {
+	pikachu := PokemonNameInfo{Formatted: "Pikachu", APIFormat: "pikachu"}
+	type step struct {
+		forget bool   // Whether the move is forgotten rather than taught
+		move   string // The move taught or forgotten
+	}
+	cases := []struct {
+		name         string
+		versionGroup string   // The version group moves are limited to
+		moveset      []string // The moveset before the steps
+		steps        []step
+		wantErr      bool     // Whether the last step fails with an invalid input error
+		want         []string // The moveset after the steps
+	}{
+		{
+			name:    "teach a learnable move",
+			moveset: []string{"thunderbolt"},
+			steps:   []step{{move: "quick-attack"}},
+			want:    []string{"thunderbolt", "quick-attack"},
+		},
+		{
+			name:    "teach a fifth move",
+			moveset: []string{"thunderbolt", "quick-attack", "thunder-wave", "iron-tail"},
+			steps:   []step{{move: "volt-tackle"}},
+			wantErr: true,
+			want:    []string{"thunderbolt", "quick-attack", "thunder-wave", "iron-tail"},
+		},
+		{
+			name:    "teach a move the species can't learn",
+			moveset: []string{"thunderbolt"},
+			steps:   []step{{move: "flamethrower"}},
+			wantErr: true,
+			want:    []string{"thunderbolt"},
+		},
+		{
+			name:         "teach a move from another game",
+			versionGroup: "red-blue",
+			steps:        []step{{move: "volt-tackle"}},
+			wantErr:      true,
+		},
+		{
+			name:    "teach a move it already knows",
+			moveset: []string{"thunderbolt"},
+			steps:   []step{{move: "thunderbolt"}},
+			wantErr: true,
+			want:    []string{"thunderbolt"},
+		},
+		{
+			name:    "replace a move",
+			moveset: []string{"thunderbolt", "quick-attack", "thunder-wave", "iron-tail"},
+			steps:   []step{{forget: true, move: "quick-attack"}, {move: "volt-tackle"}},
+			want:    []string{"thunderbolt", "thunder-wave", "iron-tail", "volt-tackle"},
+		},
+		{
+			name:    "forget a move it doesn't know",
+			moveset: []string{"thunderbolt"},
+			steps:   []step{{forget: true, move: "iron-tail"}},
+			wantErr: true,
+			want:    []string{"thunderbolt"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cfg := movesTestConfig(t)
+			cfg.settings.versionGroup = c.versionGroup
+			cfg.pokedex.Update("pikachu", func(entry *pokedex.Entry) error {
+				entry.Moveset = c.moveset
+				return nil
+			})
+
+			var err error
+			for _, s := range c.steps {
+				if s.forget {
+					err = forgetMove(cfg, "pikachu", pikachu, s.move)
+				} else {
+					err = teachMove(cfg, "pikachu", pikachu, s.move)
+				}
+			}
+			if c.wantErr != errorhandling.IsInvalidInputError(err) {
+				t.Errorf("Expected an invalid input error: %v, got %v", c.wantErr, err)
+			}
+			entry, _ := cfg.pokedex.Get("pikachu")
+			if !slices.Equal(entry.Moveset, c.want) {
+				t.Errorf("Expected the moveset %v, got %v", c.want, entry.Moveset)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// This file implements the mqtt command, which chooses the MQTT broker and
+// topic that events are published to for home automation.
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/mqtt"
+)
+
+// mqttUsage describes the parameters of the mqtt command.
+const mqttUsage = "Usage: mqtt [<broker> [--topic <topic>] | off | test]"
+
+// commandMQTT shows or changes where events are published. The settings are
+// saved with the Pokédex so they persist between sessions.
+// Supported forms:
+//   - mqtt: Show where events are published
+//   - mqtt mqtt://localhost --topic home/pokedex: Publish events to a broker and topic
+//   - mqtt off: Stop publishing events
+//   - mqtt test: Publish a test event to check the setup
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters as described above
+//
+// Returns:
+//   - An error if the parameters are invalid, the test event can't be
+//     published, or the settings can't be saved
+func commandMQTT(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		printMQTTSettings(cfg.Settings())
+	case len(params) == 1 && strings.EqualFold(params[0], "off"):
+		cfg.UpdateSettings(func(s *settings) {
+			s.mqttBroker, s.mqttTopic = "", ""
+		})
+		i18n.Println("Events will no longer be published to MQTT.")
+		err = savePokedexData(cfg)
+	case len(params) == 1 && strings.EqualFold(params[0], "test"):
+		err = testMQTT(cfg)
+	default:
+		err = setMQTT(cfg, params)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "mqtt", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// setMQTT sets the broker and topic events are published to, and saves them.
+func setMQTT(cfg *config, params []string) error {
+	topic := defaultMQTTTopic
+	switch {
+	case len(params) == 3 && strings.EqualFold(params[1], "--topic"):
+		topic = params[2]
+	case len(params) != 1:
+		return errorhandling.NewInvalidInputError(mqttUsage, nil)
+	}
+	if _, err := mqtt.ParseBroker(params[0]); err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Invalid MQTT broker '%s': %v", redactURL(params[0]), err), err)
+	}
+	if strings.ContainsAny(topic, "+#") {
+		return errorhandling.NewInvalidInputError("Events can't be published to a topic with wildcards (+ or #)", nil)
+	}
+
+	current := cfg.UpdateSettings(func(s *settings) {
+		s.mqttBroker, s.mqttTopic = params[0], topic
+	})
+	printMQTTSettings(current)
+	i18n.Println("Use 'mqtt test' to check that events arrive.")
+	return savePokedexData(cfg)
+}
+
+// testMQTT publishes a test event, reporting whether it could be sent.
+func testMQTT(cfg *config) error {
+	current := cfg.Settings()
+	if current.mqttBroker == "" {
+		return errorhandling.NewInvalidInputError("No MQTT broker is set. Use 'mqtt <broker>' to set one.", nil)
+	}
+	if err := publishMQTT(current, mqttEvent{Event: mqttEventTest, Time: time.Now()}); err != nil {
+		return errorhandling.NewNetworkError(i18n.Sprintf("Could not publish to the MQTT broker: %v", err), err)
+	}
+	i18n.Printf("Published a test event to topic '%s'.\n", mqttTopic(current))
+	return nil
+}
+
+// printMQTTSettings shows where events are published, if anywhere.
+func printMQTTSettings(current settings) {
+	if current.mqttBroker == "" {
+		i18n.Println("Events aren't published to MQTT. Use 'mqtt <broker> [--topic <topic>]' to publish them.")
+		return
+	}
+	broker, err := mqtt.ParseBroker(current.mqttBroker)
+	if err != nil {
+		i18n.Printf("The MQTT broker '%s' is invalid: %v\n", redactURL(current.mqttBroker), err)
+		return
+	}
+	i18n.Printf("Catches, evolutions, and ribbons are published to topic '%s' on %s.\n", mqttTopic(current), broker)
+}
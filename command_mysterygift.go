@@ -0,0 +1,208 @@
+// This file implements the mysterygift command, which hands out a gift once a
+// week: an item or an uncommon Pokémon. The gift is chosen from the user's
+// trainer ID and the week, so it's the same however many times the user
+// looks, and it can't be rolled again. Around holidays, the gifts come from a
+// festive pool instead.
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/events"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// giftPool is the gifts that can be handed out in a week.
+type giftPool struct {
+	name    string          // The holiday the pool is for, or "" for the everyday pool
+	from    events.MonthDay // The day the holiday starts
+	to      events.MonthDay // The day it ends (before from if it spans the new year)
+	pokemon []string        // The API names of the Pokémon that can be given
+	items   []distribution  // The items that can be given
+}
+
+// everydayGifts is the pool of gifts for weeks without a holiday.
+var everydayGifts = giftPool{
+	pokemon: []string{"eevee", "dratini", "lapras", "snorlax", "porygon", "larvitar", "bagon", "beldum", "gible", "riolu"},
+	items: []distribution{
+		{kind: rewardItem, name: "great-ball", quantity: 10},
+		{kind: rewardItem, name: "ultra-ball", quantity: 5},
+		{kind: rewardItem, name: "honey", quantity: 3},
+		{kind: rewardItem, name: "fire-stone", quantity: 1},
+		{kind: rewardItem, name: "water-stone", quantity: 1},
+		{kind: rewardItem, name: "thunder-stone", quantity: 1},
+		{kind: rewardItem, name: "leaf-stone", quantity: 1},
+		{kind: rewardItem, name: "moon-stone", quantity: 1},
+	},
+}
+
+// festiveGifts are the pools of gifts for the weeks around holidays, used
+// instead of the everyday pool for any week a holiday falls in.
+var festiveGifts = []giftPool{
+	{
+		name: "Valentine's Day", from: events.MonthDay{Month: time.February, Day: 10}, to: events.MonthDay{Month: time.February, Day: 16},
+		pokemon: []string{"luvdisc", "happiny", "skitty", "smoochum", "alomomola", "flabebe"},
+		items: []distribution{
+			{kind: rewardItem, name: "honey", quantity: 5},
+			{kind: rewardItem, name: "moon-stone", quantity: 1},
+		},
+	},
+	{
+		name: "Halloween", from: events.MonthDay{Month: time.October, Day: 25}, to: events.MonthDay{Month: time.October, Day: 31},
+		pokemon: []string{"gastly", "misdreavus", "duskull", "shuppet", "litwick", "pumpkaboo"},
+		items: []distribution{
+			{kind: rewardItem, name: "moon-stone", quantity: 1},
+			{kind: rewardItem, name: "ultra-ball", quantity: 5},
+		},
+	},
+	{
+		name: "the winter holidays", from: events.MonthDay{Month: time.December, Day: 20}, to: events.MonthDay{Month: time.January, Day: 1},
+		pokemon: []string{"delibird", "snover", "swinub", "snorunt", "spheal", "cubchoo"},
+		items: []distribution{
+			{kind: rewardItem, name: "ultra-ball", quantity: 10},
+			{kind: rewardItem, name: "water-stone", quantity: 1},
+		},
+	},
+}
+
+// mysteryGift is the gift chosen for a trainer in a week.
+type mysteryGift struct {
+	pool     giftPool     // The pool it was chosen from
+	gift     distribution // The gift
+	fallback distribution // The item given instead if the gift is a Pokémon the user already has
+}
+
+// commandMysteryGift receives this week's mystery gift. Each trainer gets one
+// gift a week, starting on Monday, which is the same however many times they
+// ask for it. Pokémon received this way have the Classic Ribbon, like those
+// from distribution codes, and go to storage if the party is full.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex, bag, and API client
+//   - params: Not used
+//
+// Returns:
+//   - An error if this week's gift has already been received, or there's an
+//     issue with the API requests
+func commandMysteryGift(cfg *config, params []string) error {
+	err := receiveMysteryGift(cfg, time.Now())
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "mysterygift", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// receiveMysteryGift receives the mystery gift for the week of a time.
+func receiveMysteryGift(cfg *config, now time.Time) error {
+	trainerID, assigned := cfg.TrainerID()
+	if assigned {
+		// Save the new trainer ID right away, so that restarting without saving
+		// can't give the user a different trainer ID and gift
+		if err := savePokedexData(cfg); err != nil {
+			return err
+		}
+	}
+
+	year, week := now.ISOWeek()
+	code := fmt.Sprintf("MYSTERYGIFT-%d-W%02d", year, week)
+	if cfg.Redeemed(code) {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("You've already received this week's mystery gift. The next one arrives on %s.",
+				weekStart(now).AddDate(0, 0, 7).Format("2006-01-02")), nil)
+	}
+
+	gift := chooseMysteryGift(trainerID, now)
+	i18n.Printf("Trainer ID %05d, mystery gift for week %d of %d:\n", trainerID, week, year)
+	if gift.pool.name != "" {
+		i18n.Printf("A festive gift for %s!\n", i18n.T(gift.pool.name))
+	}
+
+	var err error
+	switch {
+	case gift.gift.kind == rewardItem:
+		err = receiveItem(cfg, gift.gift.name, gift.gift.quantity)
+	case hasPokemon(cfg, gift.gift.name):
+		i18n.Printf("The gift was %s, but you already have one, so here's something else.\n", FormatPokemonName(gift.gift.name))
+		err = receiveItem(cfg, gift.fallback.name, gift.fallback.quantity)
+	default:
+		err = receivePokemon(cfg, gift.gift.name)
+	}
+	if err != nil {
+		return err
+	}
+	cfg.MarkRedeemed(code)
+
+	// Auto-save the gift and the week it was received in
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the gift was still received
+		HandleCommandError(cfg, "mysterygift", err)
+	}
+	printSeparator()
+	return nil
+}
+
+// hasPokemon reports whether a Pokémon is in the Pokédex.
+func hasPokemon(cfg *config, name string) bool {
+	_, exists := cfg.pokedex.Get(name)
+	return exists
+}
+
+// chooseMysteryGift chooses the mystery gift for a trainer in the week of a
+// time. The choice only depends on the trainer ID and the week, so it's the
+// same every time it's made.
+//
+// Parameters:
+//   - trainerID: The user's trainer ID
+//   - now: A time in the week
+//
+// Returns:
+//   - The week's gift
+func chooseMysteryGift(trainerID int, now time.Time) mysteryGift {
+	year, week := now.ISOWeek()
+	seed := fnv.New64a()
+	fmt.Fprintf(seed, "%d:%d-W%02d", trainerID, year, week)
+	rng := rand.New(rand.NewSource(int64(seed.Sum64())))
+
+	pool := giftPoolFor(now)
+	gift := mysteryGift{pool: pool, fallback: pool.items[rng.Intn(len(pool.items))]}
+	if choice := rng.Intn(len(pool.pokemon) + len(pool.items)); choice < len(pool.pokemon) {
+		gift.gift = distribution{kind: rewardPokemon, name: pool.pokemon[choice], quantity: 1}
+	} else {
+		gift.gift = pool.items[choice-len(pool.pokemon)]
+	}
+	return gift
+}
+
+// giftPoolFor returns the pool of gifts for the week of a time: the pool of a
+// holiday that falls on any day of the week, or the everyday pool.
+func giftPoolFor(now time.Time) giftPool {
+	start := weekStart(now)
+	for _, pool := range festiveGifts {
+		for day := range 7 {
+			if pool.includes(start.AddDate(0, 0, day)) {
+				return pool
+			}
+		}
+	}
+	return everydayGifts
+}
+
+// includes reports whether a day falls in the holiday of a pool.
+func (p giftPool) includes(day time.Time) bool {
+	return events.InRange(day, p.from, p.to)
+}
+
+// weekStart returns midnight on the Monday that starts the week of a time,
+// which is when each mystery gift arrives.
+func weekStart(now time.Time) time.Time {
+	day := startOfDay(now)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
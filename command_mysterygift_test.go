@@ -0,0 +1,67 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestChooseMysteryGift tests that the gift is the same all week for a
+// trainer, and that it always comes from the week's pool
+func TestChooseMysteryGift(t *testing.T) {
+	monday := time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local)
+	gift := chooseMysteryGift(12345, monday)
+	for day := range 7 {
+		if again := chooseMysteryGift(12345, monday.AddDate(0, 0, day).Add(23*time.Hour)); again.gift != gift.gift || again.fallback != gift.fallback {
+			t.Errorf("Expected the same gift all week, got %+v on day %d instead of %+v", again.gift, day, gift.gift)
+		}
+	}
+
+	// Over many trainers, both Pokémon and items are given, always from the pool
+	kinds := map[string]bool{}
+	for id := 1; id <= 200; id++ {
+		g := chooseMysteryGift(id, monday)
+		kinds[g.gift.kind] = true
+		if g.gift.kind == rewardPokemon && !slices.Contains(everydayGifts.pokemon, g.gift.name) {
+			t.Errorf("Expected a Pokémon from the everyday pool, got %s", g.gift.name)
+		}
+		if !slices.Contains(everydayGifts.items, g.fallback) {
+			t.Errorf("Expected a fallback item from the everyday pool, got %+v", g.fallback)
+		}
+	}
+	if !kinds[rewardPokemon] || !kinds[rewardItem] {
+		t.Errorf("Expected both Pokémon and items to be given, got %v", kinds)
+	}
+}
+
+// TestGiftPoolFor tests that a holiday's pool is used for the whole week it
+// falls in, including a holiday that spans the new year
+func TestGiftPoolFor(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2026, 6, 10, 12, 0, 0, 0, time.Local), ""},
+		{time.Date(2026, 10, 26, 12, 0, 0, 0, time.Local), "Halloween"}, // Monday of the week of Halloween
+		{time.Date(2026, 11, 1, 12, 0, 0, 0, time.Local), "Halloween"},  // Sunday of the same week
+		{time.Date(2026, 11, 2, 12, 0, 0, 0, time.Local), ""},           // The Monday after
+		{time.Date(2026, 12, 31, 12, 0, 0, 0, time.Local), "the winter holidays"},
+		{time.Date(2027, 1, 3, 12, 0, 0, 0, time.Local), "the winter holidays"}, // The week of New Year's Day
+		{time.Date(2027, 2, 14, 12, 0, 0, 0, time.Local), "Valentine's Day"},
+	}
+	for _, tt := range tests {
+		if got := giftPoolFor(tt.date).name; got != tt.want {
+			t.Errorf("giftPoolFor(%s) = %q, want %q", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+// TestWeekStart tests that weeks start on Monday
+func TestWeekStart(t *testing.T) {
+	want := time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local)
+	for day := range 7 {
+		if got := weekStart(want.AddDate(0, 0, day).Add(15 * time.Hour)); !got.Equal(want) {
+			t.Errorf("Expected day %d of the week to start on %s, got %s", day, want, got)
+		}
+	}
+}
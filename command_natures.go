@@ -0,0 +1,84 @@
+package main
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// commandNatures lists every nature with the stat it raises and the stat it
+// lowers by 10%, and the berry flavors it likes and dislikes. Neutral natures,
+// which change no stats, are listed last.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - params: Command parameters (unused)
+//
+// Returns:
+//   - An error if there's an issue with the API requests
+func commandNatures(cfg *config, params []string) error {
+	natures, err := loadNatures(cfg)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "natures", err) {
+			return err
+		}
+		return nil
+	}
+
+	table := NewTable("Nature", "Raises", "Lowers", "Likes", "Dislikes")
+	for _, nature := range natures {
+		if nature.Neutral() {
+			table.AddRow(FormatTypeName(nature.Name), "—", "—", "—", "—")
+			continue
+		}
+		table.AddRow(FormatTypeName(nature.Name),
+			FormatStatName(nature.IncreasedStat.Name), FormatStatName(nature.DecreasedStat.Name),
+			natureFlavor(nature.LikesFlavor), natureFlavor(nature.HatesFlavor))
+	}
+	table.Print()
+	i18n.Printf("Raised stats are 10%% higher and lowered stats 10%% lower than usual.\n")
+	printSeparator()
+	return nil
+}
+
+// loadNatures fetches every nature, sorted by name with the neutral natures last.
+func loadNatures(cfg *config) ([]pokeapi.NatureResp, error) {
+	list, err := cfg.pokeapiClient.ListNatures()
+	if err != nil {
+		return nil, err
+	}
+	natures := make([]pokeapi.NatureResp, 0, len(list.Results))
+	for _, result := range list.Results {
+		nature, err := cfg.pokeapiClient.GetNature(result.Name)
+		if err != nil {
+			return nil, err
+		}
+		natures = append(natures, nature)
+	}
+	sortNatures(natures)
+	return natures, nil
+}
+
+// sortNatures sorts natures by name, with the neutral natures last.
+func sortNatures(natures []pokeapi.NatureResp) {
+	slices.SortFunc(natures, func(a, b pokeapi.NatureResp) int {
+		if a.Neutral() != b.Neutral() {
+			if a.Neutral() {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+}
+
+// natureFlavor formats a berry flavor for display, or a dash if there's none.
+func natureFlavor(flavor *pokeapi.NamedAPIResource) string {
+	if flavor == nil {
+		return "—"
+	}
+	return FormatTypeName(flavor.Name)
+}
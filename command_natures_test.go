@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestSortNatures tests that natures are sorted by name with the neutral ones last
+func TestSortNatures(t *testing.T) {
+	attack, speed := &pokeapi.NamedAPIResource{Name: "attack"}, &pokeapi.NamedAPIResource{Name: "speed"}
+	natures := []pokeapi.NatureResp{
+		{Name: "hardy"},
+		{Name: "modest", IncreasedStat: attack, DecreasedStat: speed},
+		{Name: "bashful"},
+		{Name: "adamant", IncreasedStat: attack, DecreasedStat: speed},
+	}
+	sortNatures(natures)
+
+	var names []string
+	for _, nature := range natures {
+		names = append(names, nature.Name)
+	}
+	expected := []string{"adamant", "modest", "bashful", "hardy"}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("Expected %v, got %v", expected, names)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// commandNote attaches free-form notes to Pokémon in the user's Pokédex.
+// Notes are saved with the Pokédex, shown by 'inspect' and 'describe', and kept
+// when a Pokémon evolves. The command supports several forms:
+//   - note <pokemon> <text>: Add a note to a caught Pokémon
+//   - note <pokemon>: List the notes for a caught Pokémon
+//   - note clear <pokemon>: Remove all notes from a caught Pokémon
+//   - note search <query>: Find notes containing the query (case-insensitive)
+//
+// Note text keeps the capitalization it was typed with. Pokémon names may
+// contain spaces (e.g. "note mr mime Loves to dance").
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters in their original case
+//
+// Returns:
+//   - An error if the parameters are missing or the Pokémon is not in the Pokédex
+func commandNote(cfg *config, params []string) error {
+	if len(params) == 0 {
+		err := errorhandling.NewInvalidInputError(
+			"Usage: note <pokemon> <text>, note clear <pokemon>, or note search <query>", nil)
+		if HandleCommandError(cfg, "note", err) {
+			return err
+		}
+		return nil
+	}
+
+	switch strings.ToLower(params[0]) {
+	case "search":
+		return searchNotes(cfg, unquoteText(strings.Join(params[1:], " ")))
+	case "clear":
+		return clearNotes(cfg, params[1:])
+	}
+
+	// Split the parameters into the Pokémon name and the note text
+	apiName, nameInfo, text, err := splitPokemonParams(cfg, params)
+	text = unquoteText(text)
+	if err != nil {
+		if HandleCommandError(cfg, "note", err) {
+			return err
+		}
+		return nil
+	}
+
+	// Without any text, list the existing notes
+	if text == "" {
+		entry, _ := cfg.pokedex.Get(apiName)
+		notes := entry.Notes
+
+		if len(notes) == 0 {
+			i18n.Printf("%s has no notes. Add one with 'note %s <text>'.\n", nameInfo.Formatted, apiName)
+		} else {
+			i18n.Printf("Notes for %s:\n", nameInfo.Formatted)
+			writeNotes(os.Stdout, notes)
+		}
+		printSeparator()
+		return nil
+	}
+
+	err = cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		entry.Notes = append(entry.Notes, text)
+		return nil
+	})
+	if err != nil {
+		err = pokedexError(err, apiName)
+		if HandleCommandError(cfg, "note", err) {
+			return err
+		}
+		return nil
+	}
+
+	i18n.Printf("Added a note to %s.\n", nameInfo.Formatted)
+	printSeparator()
+
+	// Auto-save after adding a note
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the note was still added
+		HandleCommandError(cfg, "note", err)
+	}
+
+	return nil
+}
+
+// clearNotes removes all notes from a Pokémon in the Pokédex.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters forming the Pokémon name
+//
+// Returns:
+//   - An error if no Pokémon name is provided or the Pokémon is not in the Pokédex
+func clearNotes(cfg *config, params []string) error {
+	if len(params) > 0 {
+		params = []string{strings.Join(params, " ")}
+	}
+	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, params)
+	if err != nil {
+		if HandleCommandError(cfg, "note", err) {
+			return err
+		}
+		return nil
+	}
+
+	var cleared int
+	err = cfg.pokedex.Update(apiName, func(entry *pokedex.Entry) error {
+		cleared = len(entry.Notes)
+		entry.Notes = nil
+		return nil
+	})
+	if err != nil {
+		err = pokedexError(err, apiName)
+		if HandleCommandError(cfg, "note", err) {
+			return err
+		}
+		return nil
+	}
+
+	i18n.Printf("Removed %d note(s) from %s.\n", cleared, nameInfo.Formatted)
+	printSeparator()
+
+	if cleared > 0 {
+		// Auto-save after clearing notes
+		if err := UpdatePokedexAndSave(cfg); err != nil {
+			HandleCommandError(cfg, "note", err)
+		}
+	}
+
+	return nil
+}
+
+// searchNotes displays every note containing the query, grouped by Pokémon.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - query: The text to search for, ignoring case and accents
+//
+// Returns:
+//   - An error if no query is provided
+func searchNotes(cfg *config, query string) error {
+	if query == "" {
+		err := errorhandling.NewInvalidInputError("No search text provided (e.g., 'note search shiny')", nil)
+		if HandleCommandError(cfg, "note", err) {
+			return err
+		}
+		return nil
+	}
+
+	table := NewTable("Pokémon", "Note")
+
+	for _, caught := range cfg.pokedex.List() {
+		for _, note := range caught.Entry.Notes {
+			if containsFolded(note, query) {
+				table.AddRow(FormatPokemonName(caught.Name), note)
+			}
+		}
+	}
+
+	if table.Len() == 0 {
+		i18n.Printf("No notes found matching '%s'.\n", query)
+	} else {
+		table.Print()
+	}
+	printSeparator()
+	return nil
+}
+
+// writeNotes writes a list of notes, one per line.
+func writeNotes(w io.Writer, notes []string) {
+	for _, note := range notes {
+		i18n.Fprintf(w, " - %s\n", note)
+	}
+}
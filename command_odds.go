@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// ballOdds is the chance of catching a Pokémon with one ball.
+type ballOdds struct {
+	Ball           string  `json:"ball"`            // The API name of the ball
+	CaptureRate    int     `json:"capture_rate"`    // The effective capture rate a throw is rolled against
+	Chance         float64 `json:"chance"`          // The chance that one throw succeeds, from 0 to 1
+	ExpectedThrows float64 `json:"expected_throws"` // The average number of throws needed (0 if a throw can never succeed)
+}
+
+// catchOdds is the data behind the odds command's result.
+type catchOdds struct {
+	Pokemon     string     `json:"pokemon"`      // The Pokémon's API name
+	CaptureRate int        `json:"capture_rate"` // The species' capture rate from the API (0-255)
+	Rare        bool       `json:"rare"`         // Whether the Pokémon is rare under the catch rate preset
+	BoostedRate int        `json:"boosted_rate"` // The capture rate after the boost for rare Pokémon, before the ball
+	Balls       []ballOdds `json:"balls"`        // The odds with each ball
+}
+
+// oddsResult works out the chance that a throw at a Pokémon succeeds and the
+// expected number of throws needed to catch it, using the same calculation as
+// the catch command. The odds are given for every ball, or only the ball given
+// with --ball, which doesn't need to be in the user's bag.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - params: Command parameters where params[0] is the Pokémon name,
+//     optionally followed by --ball <ball> (e.g. "pikachu --ball great-ball")
+//
+// Returns:
+//   - The odds with each ball
+//   - An error if no Pokémon name is provided, the name or ball is invalid,
+//     or there's an issue with the API request
+func oddsResult(cfg *config, params []string) (commandResult, error) {
+	pokemonName, ball, err := parseCatchParams("odds", params)
+	if err != nil {
+		return commandResult{}, err
+	}
+	nameInfo := FormatPokemonInput(pokemonName)
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		return commandResult{}, err
+	}
+
+	resp, err := cfg.pokeapiClient.GetPokemonCaptureRate(nameInfo.APIFormat)
+	if err != nil {
+		if errorhandling.IsNotFoundError(err) {
+			err = errorhandling.InvalidPokemonNameError(nameInfo.Formatted)
+		}
+		return commandResult{}, err
+	}
+
+	balls := []string{ball}
+	if ball == "" {
+		balls = shopBalls()
+	}
+	odds := buildCatchOdds(catchTuning(cfg.Settings()), nameInfo.APIFormat, resp.CaptureRate, balls)
+
+	var message strings.Builder
+	message.WriteString(i18n.Sprintf("Capture rate of %s: %d/255\n", nameInfo.Formatted, odds.CaptureRate))
+	if odds.BoostedRate > odds.CaptureRate {
+		message.WriteString(i18n.Sprintf("%s is rare, so its capture rate is raised to %d.\n", nameInfo.Formatted, odds.BoostedRate))
+	}
+	table := NewTable("Ball", "Chance per throw", "Expected throws")
+	for _, b := range odds.Balls {
+		table.AddRow(FormatItemName(b.Ball), fmt.Sprintf("%.1f%%", b.Chance*100), formatExpectedThrows(b.Chance))
+	}
+	table.Render(&message)
+	message.WriteString(i18n.Sprintf("A throw succeeds when a random number from 0 to %d is below the capture rate.\n", catchRollRange-1))
+	return commandResult{Message: message.String(), Data: odds}, nil
+}
+
+// buildCatchOdds works out the odds of catching a Pokémon with each of some balls.
+//
+// Parameters:
+//   - tuning: The values of the catch rate preset in use
+//   - pokemon: The Pokémon's API name
+//   - captureRate: The species' capture rate from the API
+//   - balls: The API names of the balls, in the order to list them
+//
+// Returns:
+//   - The odds
+func buildCatchOdds(tuning pokedex.CatchTuning, pokemon string, captureRate int, balls []string) catchOdds {
+	odds := catchOdds{Pokemon: pokemon, CaptureRate: captureRate}
+	odds.BoostedRate, odds.Rare = catchRate(tuning, captureRate, "")
+	for _, ball := range balls {
+		rate, _ := catchRate(tuning, captureRate, ball)
+		chance := catchProbability(rate)
+		b := ballOdds{Ball: ball, CaptureRate: rate, Chance: chance}
+		if chance > 0 {
+			b.ExpectedThrows = 1 / chance
+		}
+		odds.Balls = append(odds.Balls, b)
+	}
+	return odds
+}
+
+// shopBalls returns the balls that can be thrown, in the order the shop lists them.
+func shopBalls() []string {
+	var balls []string
+	for _, item := range shopStock {
+		if _, ok := ballModifiers[item]; ok {
+			balls = append(balls, item)
+		}
+	}
+	return balls
+}
+
+// formatExpectedThrows formats the average number of throws needed to catch a
+// Pokémon when each throw succeeds with the given chance (e.g. "3.2").
+func formatExpectedThrows(chance float64) string {
+	if chance <= 0 {
+		return "∞"
+	}
+	return fmt.Sprintf("%.1f", 1/chance)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestOddsHelpers tests the balls odds are shown for and the expected number of throws
+func TestOddsHelpers(t *testing.T) {
+	if balls := shopBalls(); !reflect.DeepEqual(balls, []string{"poke-ball", "great-ball", "ultra-ball"}) {
+		t.Errorf("Expected the balls in shop order, got %v", balls)
+	}
+
+	cases := []struct {
+		chance float64
+		want   string
+	}{
+		{1, "1.0"},
+		{0.25, "4.0"},
+		{0.1, "10.0"},
+		{0, "∞"},
+	}
+	for _, c := range cases {
+		if got := formatExpectedThrows(c.chance); got != c.want {
+			t.Errorf("formatExpectedThrows(%v) = %q, expected %q", c.chance, got, c.want)
+		}
+	}
+}
+
+// TestBuildCatchOdds tests the odds with each ball, after the boost for rare Pokémon
+func TestBuildCatchOdds(t *testing.T) {
+	odds := buildCatchOdds(catchPresets[catchPresetCasual], "dratini", 45, shopBalls())
+	if !odds.Rare || odds.CaptureRate != 45 || odds.BoostedRate != 47 || len(odds.Balls) != 3 {
+		t.Fatalf("Unexpected odds for a rare Pokémon: %+v", odds)
+	}
+	if ultra := odds.Balls[2]; ultra.Ball != "ultra-ball" || ultra.CaptureRate != 94 || ultra.ExpectedThrows != 256.0/94 {
+		t.Errorf("Unexpected odds with an Ultra Ball: %+v", ultra)
+	}
+
+	odds = buildCatchOdds(catchPresets[catchPresetAuthentic], "dratini", 45, []string{""})
+	if !odds.Rare || odds.BoostedRate != 45 || odds.Balls[0].Chance != 45.0/256 {
+		t.Errorf("Expected no boost under the authentic preset, got %+v", odds)
+	}
+}
@@ -0,0 +1,63 @@
+// This file implements the page size setting, which chooses how many location
+// areas the map command lists on a page, so that users with tall terminals can
+// see more of them at once.
+package main
+
+import (
+	"strconv"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// maxPageSize is the most location areas a map page can list.
+const maxPageSize = 100
+
+// commandPageSize shows or changes how many location areas a map page lists.
+// The new size applies from the next page shown by 'map', 'next', or 'prev',
+// and is saved with the Pokédex so it persists between sessions.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters, where params[0] is the new page size or omitted
+//
+// Returns:
+//   - An error if the size isn't a number from 1 to 100, or the setting can't be saved
+func commandPageSize(cfg *config, params []string) error {
+	// If no parameter is provided, display the current setting
+	if len(params) == 0 {
+		i18n.Printf("Map pages list %d locations. Use 'pagesize <number>' to change this.\n", mapPageSize(cfg))
+		printSeparator()
+		return nil
+	}
+
+	size, err := strconv.Atoi(params[0])
+	if err != nil || size < 1 || size > maxPageSize {
+		err = errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The page size must be a number from 1 to %d", maxPageSize), nil)
+
+		// Use standardized error handling
+		if HandleCommandError(cfg, "pagesize", err) {
+			return err
+		}
+		return nil
+	}
+
+	cfg.UpdateSettings(func(s *settings) {
+		s.pageSize = size
+	})
+	i18n.Printf("Map pages will list %d locations.\n", size)
+	printSeparator()
+
+	// Save the configuration itself, including the new page size
+	return savePokedexData(cfg)
+}
+
+// mapPageSize returns how many location areas a map page lists.
+func mapPageSize(cfg *config) int {
+	if size := cfg.Settings().pageSize; size > 0 {
+		return size
+	}
+	return pokeapi.DefaultLocationPageSize
+}
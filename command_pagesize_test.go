@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestPageSizeSaved tests that the page size is checked, saved, and restored
+func TestPageSizeSaved(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if got := mapPageSize(cfg); got != pokeapi.DefaultLocationPageSize {
+		t.Fatalf("Expected the default page size, got %d", got)
+	}
+
+	for _, invalid := range []string{"0", "101", "many"} {
+		if err := commandPageSize(cfg, []string{invalid}); err == nil {
+			t.Errorf("Expected %q to be refused", invalid)
+		}
+	}
+	if err := commandPageSize(cfg, []string{"50"}); err != nil {
+		t.Fatalf("commandPageSize returned an error: %v", err)
+	}
+
+	restored := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := loadPokedexData(restored); err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if got := mapPageSize(restored); got != 50 {
+		t.Errorf("Expected the page size to be restored, got %d", got)
+	}
+}
@@ -0,0 +1,318 @@
+// This file implements the party, the Pokémon the user has with them. As in the
+// games, the party has a limited size, while boxes can store any number of
+// Pokémon. Pokémon that aren't in a box or at the day care are in the party.
+// Pokémon keep the HP they lost and the status conditions they got in battles
+// against the computer until they're healed with 'party heal', and the prompt
+// shows at a glance which party members have fainted.
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// Party size limits
+const (
+	defaultPartySize = 6  // The party size used in the games
+	maxPartySize     = 30 // The largest party size that can be configured
+)
+
+// storageBox is the box that Pokémon are sent to when the party is full.
+const storageBox = "pc"
+
+// partyUsage describes the subcommands of the party command.
+const partyUsage = "Usage: party, party size [number], party status, or party heal"
+
+// hpHearts is the number of hearts in the HP bar of 'party status'.
+const hpHearts = 6
+
+// statusAbbreviations are the short names the games show for each status condition.
+var statusAbbreviations = map[battleStatus]string{
+	statusBurn:      "BRN",
+	statusPoison:    "PSN",
+	statusParalysis: "PAR",
+	statusSleep:     "SLP",
+	statusFreeze:    "FRZ",
+}
+
+// partyListing is the data behind the party command's result.
+type partyListing struct {
+	Pokemon []listedPokemon `json:"pokemon"` // The Pokémon with the user, in the order they're numbered
+	Size    int             `json:"size"`    // The maximum party size
+}
+
+// partySize is the data behind the result of 'party size'.
+type partySize struct {
+	Size int `json:"size"` // The maximum party size
+}
+
+// partyMemberStatus is a party member in the result of 'party status'.
+type partyMemberStatus struct {
+	Name    string `json:"name"`             // The Pokémon's API name
+	HP      int    `json:"hp"`               // Its remaining HP, which is 0 if it has fainted
+	MaxHP   int    `json:"max_hp"`           // Its HP at full health
+	Status  string `json:"status,omitempty"` // Its status condition (e.g. "poison"), if any
+	Fainted bool   `json:"fainted"`          // Whether it has fainted
+}
+
+// partyHealing is the data behind the result of 'party heal'.
+type partyHealing struct {
+	Healed int `json:"healed"` // The number of party members that were healed
+}
+
+// partyResult implements the "party" command.
+// Supported forms:
+//   - party: List the Pokémon with the user
+//   - party size: Show the maximum party size
+//   - party size <number>: Change the maximum party size
+//   - party status: Show each party member's HP and status condition
+//   - party heal: Heal the party, as at a Pokémon Center
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and settings
+//   - params: Command parameters where params[0] is the optional subcommand
+//
+// Returns:
+//   - The result of the subcommand
+//   - An error if the subcommand or its parameters are invalid
+func partyResult(cfg *config, params []string) (commandResult, error) {
+	switch {
+	case len(params) == 0:
+		return listParty(cfg), nil
+	case params[0] == "size":
+		return setPartySize(cfg, params[1:])
+	case params[0] == "status" && len(params) == 1:
+		return showPartyStatus(cfg), nil
+	case params[0] == "heal" && len(params) == 1:
+		return healParty(cfg), nil
+	default:
+		return commandResult{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown party command '%s'. %s", params[0], i18n.T(partyUsage)), nil)
+	}
+}
+
+// listParty lists the Pokémon in the user's party.
+func listParty(cfg *config) commandResult {
+	limit := cfg.Settings().partySize
+	table := NewTable("#", "Name", "Types", "Level")
+	listing := partyListing{Pokemon: []listedPokemon{}, Size: limit}
+	var listed []string
+	for _, caught := range cfg.pokedex.Party() {
+		listed = append(listed, caught.Name)
+		listing.Pokemon = append(listing.Pokemon, newListedPokemon(len(listed), caught))
+		table.AddRow(fmt.Sprint(table.Len()+1), FormatPokemonName(caught.Name),
+			FormatTypeList(pokemonTypes(caught.Entry.PokemonDataResp)), fmt.Sprint(caught.Entry.CurrentLevel()))
+	}
+
+	if table.Len() == 0 {
+		return commandResult{
+			Message: i18n.Sprintf("Your party is empty (up to %d Pokémon). Take Pokémon out of storage with 'box remove <pokemon>'.\n", limit),
+			Data:    listing,
+		}
+	}
+	var message strings.Builder
+	message.WriteString(i18n.Sprintf("With you (%d of %d):\n", table.Len(), limit))
+	table.Render(&message)
+	cfg.SetSelection(selectPokemon, "party", listed)
+	return commandResult{Message: message.String(), Data: listing}
+}
+
+// setPartySize shows or changes the maximum party size. The size can't be
+// lowered below the number of Pokémon already in the party.
+func setPartySize(cfg *config, params []string) (commandResult, error) {
+	if len(params) == 0 {
+		size := cfg.Settings().partySize
+		return commandResult{
+			Message: i18n.Sprintf("You can have up to %d Pokémon with you. Use 'party size <number>' to change this.\n", size),
+			Data:    partySize{Size: size},
+		}, nil
+	}
+
+	size, err := strconv.Atoi(params[0])
+	if err != nil || size < 1 || size > maxPartySize {
+		return commandResult{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The party size must be a number from 1 to %d", maxPartySize), nil)
+	}
+	if count := cfg.pokedex.PartyCount(""); count > size {
+		return commandResult{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("You have %d Pokémon with you. Move some to a box before lowering the party size to %d", count, size), nil)
+	}
+
+	cfg.UpdateSettings(func(s *settings) {
+		s.partySize = size
+	})
+
+	// Save the configuration itself, including the new party size
+	if err := savePokedexData(cfg); err != nil {
+		return commandResult{}, err
+	}
+	return commandResult{
+		Message: i18n.Sprintf("You can now have up to %d Pokémon with you.\n", size),
+		Data:    partySize{Size: size},
+	}, nil
+}
+
+// partyHasRoom reports whether another Pokémon can join the party.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and settings
+//   - exclude: A Pokémon to leave out of the count, such as one being replaced ("" for none)
+func partyHasRoom(cfg *config, exclude string) bool {
+	return cfg.pokedex.PartyCount(exclude) < cfg.Settings().partySize
+}
+
+// partyFullError returns the error for moving a Pokémon into a full party.
+func partyFullError(cfg *config) error {
+	return errorhandling.NewInvalidInputError(
+		i18n.Sprintf("Your party is full (%d Pokémon). Move a Pokémon to a box with 'box move <pokemon> <box>' first",
+			cfg.Settings().partySize), nil)
+}
+
+// partyCondition works out a Pokémon's HP in battle and its status condition,
+// from the damage and status it was left with after its last battle.
+//
+// Parameters:
+//   - entry: The Pokémon's Pokédex entry
+//
+// Returns:
+//   - Its remaining HP, which is 0 if it has fainted
+//   - Its HP at full health
+//   - Its status condition, or "" for none
+func partyCondition(entry pokedex.Entry) (hp, maxHP int, status battleStatus) {
+	maxHP = battleMaxHP(entry.PokemonDataResp)
+	return max(maxHP-entry.Damage, 0), maxHP, battleStatus(entry.Status)
+}
+
+// hasFainted reports whether a Pokémon fainted in its last battle and hasn't been healed.
+func hasFainted(entry pokedex.Entry) bool {
+	hp, _, _ := partyCondition(entry)
+	return hp == 0
+}
+
+// formatHPBar draws HP as a row of hearts (e.g. "[♥♥♥♡♡♡]" at half health).
+// A Pokémon with any HP left has at least one full heart.
+func formatHPBar(hp, maxHP int) string {
+	full := 0
+	if hp > 0 {
+		full = max((hp*hpHearts+maxHP-1)/maxHP, 1)
+	}
+	return "[" + strings.Repeat("♥", full) + strings.Repeat("♡", hpHearts-full) + "]"
+}
+
+// showPartyStatus shows each party member's HP and status condition.
+func showPartyStatus(cfg *config) commandResult {
+	table := NewTable("Name", "HP", "Condition")
+	members := []partyMemberStatus{}
+	for _, caught := range cfg.pokedex.Party() {
+		hp, maxHP, status := partyCondition(caught.Entry)
+		members = append(members, partyMemberStatus{Name: caught.Name, HP: hp, MaxHP: maxHP, Status: string(status), Fainted: hp == 0})
+		condition := i18n.T("Healthy")
+		switch {
+		case hp == 0:
+			condition = i18n.T("Fainted")
+		case status != "":
+			condition = statusAbbreviations[status] + " (" + formatStatus(status) + ")"
+		}
+		bar := fmt.Sprintf("%d/%d", hp, maxHP)
+		if !isAccessibleOutput() {
+			bar = formatHPBar(hp, maxHP) + " " + bar
+		}
+		table.AddRow(FormatPokemonName(caught.Name), bar, condition)
+	}
+
+	if table.Len() == 0 {
+		return commandResult{Message: i18n.T("Your party is empty.") + "\n", Data: members}
+	}
+	var message strings.Builder
+	table.Render(&message)
+	return commandResult{Message: message.String(), Data: members}
+}
+
+// healParty restores every party member to full health and cures their status
+// conditions, as at a Pokémon Center.
+func healParty(cfg *config) commandResult {
+	var healed int
+	for _, caught := range cfg.pokedex.Party() {
+		if !caught.Entry.Hurt() {
+			continue
+		}
+		err := cfg.pokedex.Update(caught.Name, func(entry *pokedex.Entry) error {
+			entry.Heal()
+			return nil
+		})
+		if err == nil {
+			healed++
+		}
+	}
+
+	if healed == 0 {
+		return commandResult{Message: i18n.T("Your party is already at full health.") + "\n", Data: partyHealing{}}
+	}
+
+	// Auto-save after healing
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the party has already been healed
+		HandleCommandError(cfg, "party", err)
+	}
+	return commandResult{
+		Message: i18n.Sprintf("Your Pokémon are back to full health (%d healed). We hope to see you again!\n", healed),
+		Data:    partyHealing{Healed: healed},
+	}
+}
+
+// partyPrompt summarizes the party for the prompt, with a full heart for each
+// member that can battle and an empty one for each that has fainted
+// (e.g. "[♥♥♡] "). It's empty while no party member is hurt, so the prompt
+// only changes when there's something to see.
+func partyPrompt(cfg *config) string {
+	var able, faintedCount int
+	var hurt bool
+	for _, caught := range cfg.pokedex.Party() {
+		hurt = hurt || caught.Entry.Hurt()
+		if hasFainted(caught.Entry) {
+			faintedCount++
+		} else {
+			able++
+		}
+	}
+	if !hurt {
+		return ""
+	}
+	if isAccessibleOutput() {
+		return i18n.Sprintf("[%d of %d can battle] ", able, able+faintedCount)
+	}
+	return "[" + strings.Repeat("♥", able) + strings.Repeat("♡", faintedCount) + "] "
+}
+
+// recordBattleCondition keeps the HP the player's Pokémon lost and the status
+// conditions they got in a battle, so they carry over to the next battle until
+// they're healed. The caller saves the Pokédex.
+func recordBattleCondition(cfg *config, team *battleTeam) {
+	for _, member := range team.members {
+		// Every member came from the Pokédex, so only a Pokémon removed since then is skipped
+		_ = cfg.pokedex.Update(member.name, func(entry *pokedex.Entry) error {
+			entry.Damage = member.maxHP - member.hp
+			entry.Status = string(member.status)
+			if member.fainted() {
+				entry.Status = ""
+			}
+			return nil
+		})
+	}
+}
+
+// applyBattleCondition starts a battler with the HP and status condition its
+// Pokémon was left with after its last battle.
+func applyBattleCondition(b *battler, entry pokedex.Entry, intn func(int) int) {
+	b.hp = max(b.maxHP-entry.Damage, 0)
+	b.status = battleStatus(entry.Status)
+	if b.status == statusSleep {
+		b.sleepTurns = 1 + intn(maxSleepTurns)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestFormatHPBar tests that HP is drawn as hearts, with at least one full
+// heart for a Pokémon that hasn't fainted
+func TestFormatHPBar(t *testing.T) {
+	tests := []struct {
+		hp, maxHP int
+		want      string
+	}{
+		{120, 120, "[♥♥♥♥♥♥]"},
+		{60, 120, "[♥♥♥♡♡♡]"},
+		{61, 120, "[♥♥♥♥♡♡]"},
+		{1, 120, "[♥♡♡♡♡♡]"},
+		{0, 120, "[♡♡♡♡♡♡]"},
+	}
+	for _, tt := range tests {
+		if got := formatHPBar(tt.hp, tt.maxHP); got != tt.want {
+			t.Errorf("formatHPBar(%d, %d) = %s, want %s", tt.hp, tt.maxHP, got, tt.want)
+		}
+	}
+}
+
+// TestBattleConditionCarriesOver tests that the HP and status a Pokémon is
+// left with after a battle are kept, shown in the prompt, and start its next
+// battle, until the party is healed
+func TestBattleConditionCarriesOver(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	pikachu := testMatchupPokemon(t, "pikachu", 35, "electric")
+	squirtle := testMatchupPokemon(t, "squirtle", 44, "water")
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(pikachu))
+	cfg.pokedex.Add("squirtle", pokedex.NewEntry(squirtle))
+	if prompt := partyPrompt(cfg); prompt != "" {
+		t.Errorf("Expected no party status in the prompt while nobody is hurt, got %q", prompt)
+	}
+
+	hurt := newBattler(pikachu, nil)
+	hurt.hp = hurt.maxHP / 2
+	hurt.status = statusPoison
+	knockedOut := newBattler(squirtle, nil)
+	knockedOut.hp = 0
+	knockedOut.status = statusBurn
+	recordBattleCondition(cfg, &battleTeam{members: []*battler{hurt, knockedOut}})
+
+	entry, _ := cfg.pokedex.Get("pikachu")
+	next := newBattler(pikachu, nil)
+	applyBattleCondition(next, entry, func(int) int { return 0 })
+	if next.hp != hurt.hp || next.status != statusPoison {
+		t.Errorf("Expected the next battle to start with %d HP and poison, got %d HP and %q", hurt.hp, next.hp, next.status)
+	}
+	if entry, _ := cfg.pokedex.Get("squirtle"); !hasFainted(entry) || entry.Status != "" {
+		t.Errorf("Expected squirtle to have fainted with no status, got %+v", entry.Status)
+	}
+	if prompt := partyPrompt(cfg); prompt != "[♥♡] " {
+		t.Errorf("Expected the prompt to show one fainted Pokémon, got %q", prompt)
+	}
+
+	healParty(cfg)
+	for _, name := range []string{"pikachu", "squirtle"} {
+		if entry, _ := cfg.pokedex.Get(name); entry.Hurt() {
+			t.Errorf("Expected %s to be healed", name)
+		}
+	}
+	if prompt := partyPrompt(cfg); prompt != "" {
+		t.Errorf("Expected no party status in the prompt after healing, got %q", prompt)
+	}
+}
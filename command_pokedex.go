@@ -1,40 +1,414 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
 
-// commandPokedex displays a list of all Pokémon the user has caught.
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// listedPokemon is a Pokémon in the list of a pokedex or party command's result.
+type listedPokemon struct {
+	Number  int      `json:"number"`            // Its number in the list, which can be used in place of its name
+	Name    string   `json:"name"`              // Its API name
+	Types   []string `json:"types"`             // The API names of its types
+	Level   int      `json:"level"`             // Its current level
+	InParty bool     `json:"in_party"`          // Whether it's with the user rather than in storage
+	Box     string   `json:"box,omitempty"`     // The box it's stored in, if any
+	Daycare bool     `json:"daycare,omitempty"` // Whether it's at the day care
+}
+
+// newListedPokemon returns a Pokédex entry as listed at the given number.
+func newListedPokemon(number int, caught pokedex.NamedEntry) listedPokemon {
+	return listedPokemon{
+		Number:  number,
+		Name:    caught.Name,
+		Types:   pokemonTypes(caught.Entry.PokemonDataResp),
+		Level:   caught.Entry.CurrentLevel(),
+		InParty: caught.Entry.InParty(),
+		Box:     caught.Entry.Box,
+		Daycare: caught.Entry.InDaycare(),
+	}
+}
+
+// familyMember is a species in an evolution family of the pokedex command's
+// result, with the species that evolve from it.
+type familyMember struct {
+	Species   string         `json:"species"`              // The species' API name
+	Caught    bool           `json:"caught"`               // Whether the user has caught it
+	Seen      bool           `json:"seen"`                 // Whether the user has seen it, which caught species always have
+	EvolvesTo []familyMember `json:"evolves_to,omitempty"` // The species it evolves into
+}
+
+// pokedexListing is the data behind the pokedex command's result.
+type pokedexListing struct {
+	Pokemon  []listedPokemon `json:"pokemon,omitempty"`  // The Pokémon listed, in the order they're numbered
+	Families []familyMember  `json:"families,omitempty"` // With --families, the first species of each family listed
+	Seen     int             `json:"seen"`               // The number of different Pokémon seen
+	Caught   int             `json:"caught"`             // The number of Pokémon in the Pokédex
+}
+
+// pokedexResult lists all Pokémon the user has caught.
 // This command provides a simple inventory view of the user's collection,
-// listing the names of all Pokémon currently in their Pokédex.
+// listing the names and types of all Pokémon currently in their Pokédex
+// as tables sorted alphabetically by name. As in the games, the Pokémon with
+// the user (the party) are listed separately from those in storage, which
+// shows the box each one is in or whether it's at the day care.
+//
+// The list can be limited to a single box with 'pokedex --box <name>', and to
+// the Pokémon caught in a location with 'pokedex --caught-at <location>'.
+// 'pokedex --families' groups the Pokémon by evolution family instead, showing
+// each family's whole evolution line with the caught species marked.
 //
 // If the Pokédex is empty (no Pokémon have been caught), a message indicating
-// this is displayed instead of an empty list.
+// this is shown instead of an empty list.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Optional filter parameters ("--box" followed by a box name and/or
+//     "--caught-at" followed by a location name), and "--families"
+//
+// Returns:
+//   - The Pokémon or families listed
+//   - An error if the filter parameters are invalid, the box doesn't exist,
+//     or an evolution chain can't be fetched
+func pokedexResult(cfg *config, params []string) (commandResult, error) {
+	// Parse the optional filters
+	families := slices.Contains(params, "--families")
+	params = slices.DeleteFunc(slices.Clone(params), func(p string) bool { return p == "--families" })
+	filter, err := parsePokedexFilter(cfg, params)
+	if err != nil {
+		return commandResult{}, err
+	}
+
+	stats := cfg.pokedex.Stats()
+	listing := pokedexListing{Seen: stats.Seen, Caught: stats.Total}
+	entries := cfg.pokedex.List()
+	if len(entries) == 0 {
+		return commandResult{Message: i18n.T("You have not caught any Pokémon yet") + "\n", Data: listing}, nil
+	}
+
+	var message strings.Builder
+	switch {
+	case families:
+		listing.Families, err = writeFamilies(&message, cfg, entries, filter)
+		if err != nil {
+			return commandResult{}, err
+		}
+	case filter == (pokedexFilter{}):
+		listing.Pokemon = writePartyAndStorage(&message, cfg, entries)
+	default:
+		listing.Pokemon = writeFilteredPokedex(&message, cfg, entries, filter)
+	}
+	return commandResult{Message: message.String(), Data: listing}, nil
+}
+
+// writeFilteredPokedex lists the Pokémon that pass a filter as a table.
 //
 // Parameters:
+//   - w: Where to write the table
 //   - cfg: The application configuration containing the Pokédex
-//   - params: Command parameters (not used in this command)
+//   - entries: Every entry in the Pokédex, sorted by name
+//   - filter: Limits which Pokémon are listed
 //
 // Returns:
-//   - Always returns nil as this command cannot fail under normal circumstances
-func commandPokedex(cfg *config, params []string) error {
-	// Acquire a read lock before accessing the pokedex
-	cfg.mutex.RLock()
-	pokedexEmpty := len(cfg.pokedex) == 0
-	cfg.mutex.RUnlock()
-
-	if pokedexEmpty {
-		fmt.Println("You have not caught any Pokémon yet")
+//   - The Pokémon listed
+func writeFilteredPokedex(w io.Writer, cfg *config, entries []pokedex.NamedEntry, filter pokedexFilter) []listedPokemon {
+	showBoxes := len(cfg.pokedex.Boxes()) > 0 && filter.box == ""
+
+	headers := []string{"#", "Name", "Types"}
+	if showBoxes {
+		headers = append(headers, "Box")
+	}
+	table := NewTable(headers...)
+	var listed []listedPokemon
+	var names []string
+	for _, caught := range entries {
+		key, entry := caught.Name, caught.Entry
+		if !filter.matches(entry) {
+			continue
+		}
+		listed = append(listed, newListedPokemon(len(listed)+1, caught))
+		names = append(names, key)
+		row := []string{fmt.Sprint(table.Len() + 1), FormatPokemonName(key), FormatTypeList(pokemonTypes(entry.PokemonDataResp))}
+		if showBoxes {
+			row = append(row, entry.Box)
+		}
+		table.AddRow(row...)
+	}
+
+	switch {
+	case filter.box != "" && filter.caughtAt == "" && table.Len() == 0:
+		i18n.Fprintf(w, "Box '%s' is empty. Add Pokémon with 'box move <pokemon> %s'.\n", filter.box, filter.box)
+		return nil
+	case table.Len() == 0:
+		i18n.Fprintf(w, "None of your Pokémon match (%s).\n", filter.describe())
+		return nil
+	}
+
+	i18n.Fprintf(w, "Your Pokédex (%s):\n", filter.describe())
+	table.Render(w)
+	cfg.SetSelection(selectPokemon, "pokedex", names)
+	return listed
+}
+
+// writePartyAndStorage lists the Pokémon with the user and the Pokémon in
+// storage as separate tables. The Pokémon in storage are numbered after those
+// in the party, so each number refers to one Pokémon (see selection_utils.go).
+//
+// Parameters:
+//   - w: Where to write the tables
+//   - cfg: The application configuration containing the party size
+//   - entries: Every entry in the Pokédex, sorted by name
+//
+// Returns:
+//   - The Pokémon listed, party first
+func writePartyAndStorage(w io.Writer, cfg *config, entries []pokedex.NamedEntry) []listedPokemon {
+	party := NewTable("#", "Name", "Types")
+	storage := NewTable("#", "Name", "Types", "Where")
+	var inParty, inStorage []string
+	var listed []listedPokemon
+	for _, caught := range entries {
+		if caught.Entry.InParty() {
+			inParty = append(inParty, caught.Name)
+			listed = append(listed, newListedPokemon(len(listed)+1, caught))
+			party.AddRow(fmt.Sprint(len(inParty)), FormatPokemonName(caught.Name),
+				FormatTypeList(pokemonTypes(caught.Entry.PokemonDataResp)))
+		}
+	}
+	for _, caught := range entries {
+		if caught.Entry.InParty() {
+			continue
+		}
+		inStorage = append(inStorage, caught.Name)
+		listed = append(listed, newListedPokemon(len(listed)+1, caught))
+		number := fmt.Sprint(len(inParty) + len(inStorage))
+		name := FormatPokemonName(caught.Name)
+		types := FormatTypeList(pokemonTypes(caught.Entry.PokemonDataResp))
+		if caught.Entry.InDaycare() {
+			storage.AddRow(number, name, types, i18n.T("Day care"))
+		} else {
+			storage.AddRow(number, name, types, i18n.Sprintf("Box '%s'", caught.Entry.Box))
+		}
+	}
+	cfg.SetSelection(selectPokemon, "pokedex", append(inParty, inStorage...))
+
+	i18n.Fprintf(w, "With you (%d of %d):\n", party.Len(), cfg.Settings().partySize)
+	if party.Len() == 0 {
+		i18n.Fprintln(w, "No Pokémon are with you. Take some out of storage with 'box remove <pokemon>'.")
 	} else {
-		fmt.Println("Your Pokédex:")
+		party.Render(w)
+	}
+	if storage.Len() > 0 {
+		fmt.Fprintln(w)
+		i18n.Fprintf(w, "In storage (%d):\n", storage.Len())
+		storage.Render(w)
+	}
+	stats := cfg.pokedex.Stats()
+	fmt.Fprintln(w)
+	i18n.Fprintf(w, "Seen: %d  Caught: %d\n", stats.Seen, stats.Total)
+	return listed
+}
 
-		// Acquire a read lock for iteration
-		cfg.mutex.RLock()
-		for key := range cfg.pokedex {
-			formattedName := FormatPokemonName(key)
-			fmt.Printf(" - %s\n", formattedName)
+// writeFamilies lists the evolution families of the Pokémon that pass the
+// filter, one line per family in the order of their evolution chains, with
+// every species in the family marked as caught, seen, or neither. Evolution
+// chains are cached by the API client, so listing them again is quick.
+//
+// Parameters:
+//   - w: Where to write the families
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - entries: Every entry in the Pokédex, sorted by name
+//   - filter: Limits which Pokémon's families are listed
+//
+// Returns:
+//   - The families listed
+//   - An error if an evolution chain can't be fetched
+func writeFamilies(w io.Writer, cfg *config, entries []pokedex.NamedEntry, filter pokedexFilter) ([]familyMember, error) {
+	// Collect the species of every caught Pokémon, as the checklist does
+	caught := make(map[string]bool, len(entries)*2)
+	for _, e := range entries {
+		caught[e.Name] = true
+		caught[e.Entry.Species.Name] = true
+	}
+	seen := func(species string) bool {
+		return caught[species] || cfg.pokedex.HasSeen(species)
+	}
+	marker := func(species string) string {
+		return caughtMarker(caught[species], seen(species))
+	}
+
+	chains := make(map[int]pokeapi.ChainLink)
+	for _, e := range entries {
+		if !filter.matches(e.Entry) {
+			continue
+		}
+		species := e.Entry.Species.Name
+		if species == "" {
+			species = e.Name // Entries saved by older versions may lack species data
+		}
+		chain, err := cfg.pokeapiClient.GetEvolutionChainBySpecies(species)
+		if err != nil {
+			return nil, err
+		}
+		chains[chain.ID] = chain.Chain
+	}
+
+	if len(chains) == 0 {
+		i18n.Fprintf(w, "None of your Pokémon match (%s).\n", filter.describe())
+		return nil, nil
+	}
+	if filter == (pokedexFilter{}) {
+		i18n.Fprintln(w, "Your Pokédex by evolution family:")
+	} else {
+		i18n.Fprintf(w, "Your Pokédex by evolution family (%s):\n", filter.describe())
+	}
+	ids := make([]int, 0, len(chains))
+	for id := range chains {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	families := make([]familyMember, 0, len(ids))
+	for _, id := range ids {
+		fmt.Fprintln(w, formatFamily(chains[id], marker))
+		families = append(families, newFamilyMember(chains[id], caught, seen))
+	}
+	i18n.Fprintln(w, "[x] caught  [o] seen  [ ] not seen")
+	return families, nil
+}
+
+// newFamilyMember returns an evolution chain as a family, with each species
+// marked as caught or seen.
+//
+// Parameters:
+//   - link: The first link of the chain
+//   - caught: The API names of the species the user has caught
+//   - seen: Reports whether the user has seen a species, given its API name
+//
+// Returns:
+//   - The first species of the family
+func newFamilyMember(link pokeapi.ChainLink, caught map[string]bool, seen func(species string) bool) familyMember {
+	member := familyMember{
+		Species: link.Species.Name,
+		Caught:  caught[link.Species.Name],
+		Seen:    seen(link.Species.Name),
+	}
+	for _, next := range link.EvolvesTo {
+		member.EvolvesTo = append(member.EvolvesTo, newFamilyMember(next, caught, seen))
+	}
+	return member
+}
+
+// formatFamily formats an evolution chain as a single line, with each species
+// preceded by its marker (e.g. "[x] Bulbasaur → [ ] Ivysaur → [x] Venusaur").
+// Branching evolutions are separated by " / ", and branches that evolve further
+// are grouped in parentheses (e.g. "[x] Wurmple → ([ ] Silcoon → [ ] Beautifly /
+// [ ] Cascoon → [ ] Dustox)").
+//
+// Parameters:
+//   - link: The first link of the chain to format
+//   - marker: Returns the marker to show before a species, given its API name
+//
+// Returns:
+//   - The formatted chain
+func formatFamily(link pokeapi.ChainLink, marker func(species string) string) string {
+	line := marker(link.Species.Name) + " " + FormatPokemonName(link.Species.Name)
+	if len(link.EvolvesTo) == 0 {
+		return line
+	}
+
+	branches := make([]string, len(link.EvolvesTo))
+	nested := false
+	for i, next := range link.EvolvesTo {
+		branches[i] = formatFamily(next, marker)
+		nested = nested || len(next.EvolvesTo) > 0
+	}
+	evolutions := strings.Join(branches, " / ")
+	if len(branches) > 1 && nested {
+		evolutions = "(" + evolutions + ")"
+	}
+	return line + " → " + evolutions
+}
+
+// pokedexFilter limits which Pokémon the pokedex command lists.
+type pokedexFilter struct {
+	box      string // Only list Pokémon in this box ("" for any)
+	caughtAt string // Only list Pokémon caught in this location, in API format ("" for any)
+}
+
+// parsePokedexFilter parses the filter options of the pokedex command.
+// Each option is followed by a name that may have several words, running up
+// to the next option (e.g. "--caught-at viridian forest --box team").
+//
+// Parameters:
+//   - cfg: The application configuration, used to check that the box exists
+//   - params: The command parameters
+//
+// Returns:
+//   - The parsed filter
+//   - An error if an option is unknown, is missing its value, or names a box that doesn't exist
+func parsePokedexFilter(cfg *config, params []string) (pokedexFilter, error) {
+	var filter pokedexFilter
+	usageErr := errorhandling.NewInvalidInputError("Usage: pokedex [--box <name>] [--caught-at <location>] [--families]", nil)
+
+	for i := 0; i < len(params); {
+		option := params[i]
+		end := i + 1
+		for end < len(params) && !strings.HasPrefix(params[end], "--") {
+			end++
 		}
-		cfg.mutex.RUnlock()
+		value := params[i+1 : end]
+		i = end
+
+		switch option {
+		case "--box":
+			boxName, err := parseBoxName(value)
+			if err == nil && !cfg.pokedex.HasBox(boxName) {
+				err = boxNotFoundError(boxName)
+			}
+			if err != nil {
+				return filter, err
+			}
+			filter.box = boxName
+		case "--caught-at":
+			filter.caughtAt = ConvertToAPIFormat(strings.Join(value, " "))
+			if filter.caughtAt == "" {
+				return filter, usageErr
+			}
+		default:
+			return filter, usageErr
+		}
+	}
+	return filter, nil
+}
+
+// matches reports whether a Pokédex entry passes the filter.
+// A location matches the area a Pokémon was caught in either exactly or as
+// the start of its name, so "viridian-forest" matches "viridian-forest-area".
+func (f pokedexFilter) matches(entry pokedex.Entry) bool {
+	if f.box != "" && entry.Box != f.box {
+		return false
+	}
+	if f.caughtAt != "" && !locationMatches(entry.CaughtAt, f.caughtAt) {
+		return false
+	}
+	return true
+}
 
-		fmt.Println("-----")
+// describe summarizes the filter for display (e.g. "box 'team', caught at Route 1").
+func (f pokedexFilter) describe() string {
+	var parts []string
+	if f.box != "" {
+		parts = append(parts, i18n.Sprintf("box '%s'", f.box))
+	}
+	if f.caughtAt != "" {
+		parts = append(parts, i18n.Sprintf("caught at %s", FormatLocationName(f.caughtAt)))
 	}
-	return nil
+	return strings.Join(parts, ", ")
 }
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestPokedexFilter tests parsing of the pokedex filters and matching entries against them
+func TestPokedexFilter(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.AddBox("team")
+
+	filter, err := parsePokedexFilter(cfg, []string{"--caught-at", "viridian", "forest", "--box", "team"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if filter.caughtAt != "viridian-forest" || filter.box != "team" {
+		t.Fatalf("Unexpected filter: %+v", filter)
+	}
+
+	cases := []struct {
+		entry pokedex.Entry
+		want  bool
+	}{
+		{entry: pokedex.Entry{Box: "team", CaughtAt: "viridian-forest"}, want: true},
+		{entry: pokedex.Entry{Box: "team", CaughtAt: "viridian-forest-area"}, want: true},
+		{entry: pokedex.Entry{Box: "team", CaughtAt: "viridian-forestry"}, want: false},
+		{entry: pokedex.Entry{Box: "team"}, want: false},
+		{entry: pokedex.Entry{CaughtAt: "viridian-forest"}, want: false},
+	}
+	for _, c := range cases {
+		if got := filter.matches(c.entry); got != c.want {
+			t.Errorf("matches(box %q, caught at %q) = %v, expected %v", c.entry.Box, c.entry.CaughtAt, got, c.want)
+		}
+	}
+
+	for _, params := range [][]string{{"--caught-at"}, {"--box", "missing"}, {"team"}} {
+		if _, err := parsePokedexFilter(cfg, params); err == nil {
+			t.Errorf("parsePokedexFilter(%v): expected an error", params)
+		}
+	}
+}
+
+// TestFormatFamily tests formatting evolution chains as a single line with markers
+func TestFormatFamily(t *testing.T) {
+	link := func(name string, next ...pokeapi.ChainLink) pokeapi.ChainLink {
+		return pokeapi.ChainLink{Species: pokeapi.NamedAPIResource{Name: name}, EvolvesTo: next}
+	}
+	caught := map[string]bool{"bulbasaur": true, "venusaur": true, "wurmple": true, "eevee": true}
+	marker := func(species string) string { return caughtMarker(caught[species], species == "silcoon") }
+
+	cases := []struct {
+		chain pokeapi.ChainLink
+		want  string
+	}{
+		{
+			chain: link("bulbasaur", link("ivysaur", link("venusaur"))),
+			want:  "[x] Bulbasaur → [ ] Ivysaur → [x] Venusaur",
+		},
+		{
+			chain: link("eevee", link("vaporeon"), link("jolteon")),
+			want:  "[x] Eevee → [ ] Vaporeon / [ ] Jolteon",
+		},
+		{
+			chain: link("wurmple", link("silcoon", link("beautifly")), link("cascoon", link("dustox"))),
+			want:  "[x] Wurmple → ([o] Silcoon → [ ] Beautifly / [ ] Cascoon → [ ] Dustox)",
+		},
+		{
+			chain: link("tauros"),
+			want:  "[ ] Tauros",
+		},
+	}
+	for _, c := range cases {
+		if got := formatFamily(c.chain, marker); got != c.want {
+			t.Errorf("formatFamily(%s) = %q, expected %q", c.chain.Species.Name, got, c.want)
+		}
+	}
+}
+
+// TestPokedexResult tests that the pokedex command's data lists the party
+// before the Pokémon in storage, numbered as in the message
+func TestPokedexResult(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	cfg.pokedex.AddBox("spare")
+	pichu := pokedex.NewEntry(testMatchupPokemon(t, "pichu", 205, "electric"))
+	pichu.Box = "spare"
+	cfg.pokedex.Add("pichu", pichu)
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric")))
+
+	result, err := pokedexResult(cfg, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	listing, ok := result.Data.(pokedexListing)
+	if !ok {
+		t.Fatalf("Expected a pokedexListing, got %T", result.Data)
+	}
+	if len(listing.Pokemon) != 2 || listing.Caught != 2 {
+		t.Fatalf("Expected two Pokémon, got %+v", listing)
+	}
+	if got := listing.Pokemon[0]; got.Number != 1 || got.Name != "pikachu" || !got.InParty {
+		t.Errorf("Expected pikachu first, in the party, got %+v", got)
+	}
+	if got := listing.Pokemon[1]; got.Number != 2 || got.Name != "pichu" || got.InParty || got.Box != "spare" {
+		t.Errorf("Expected pichu second, in box 'spare', got %+v", got)
+	}
+	if !strings.Contains(result.Message, "In storage (1):") {
+		t.Errorf("Expected the message to list the storage, got:\n%s", result.Message)
+	}
+}
@@ -0,0 +1,175 @@
+// This file implements the poster command, which writes the whole National
+// Pokédex as a grid to print out, with the species the user has caught filled
+// in, so they can cross off the rest by hand. Posters are written either as
+// plain text or as a self-contained HTML page.
+package main
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// posterFiles holds the poster's HTML template.
+//
+//go:embed poster
+var posterFiles embed.FS
+
+// posterTemplate is the poster's HTML page, parsed once at startup.
+var posterTemplate = template.Must(template.ParseFS(posterFiles, "poster/poster.html"))
+
+// posterTextWidth is the width of a text poster's lines: five checklist cells,
+// which fits a landscape page.
+const posterTextWidth = 5 * checklistCellWidth
+
+// maxPosterGenerations bounds the generations fetched for a poster, in case the
+// API never reports one missing.
+const maxPosterGenerations = 20
+
+// posterItem is one species on a poster.
+type posterItem struct {
+	Number int    // National Pokédex number
+	Name   string // The species' name for display
+	Caught bool   // Whether the user has caught the species
+	Seen   bool   // Whether the user has seen the species (always true if caught)
+}
+
+// posterSection is the species introduced in one generation.
+type posterSection struct {
+	Title     string          // The generation's display name
+	Items     []posterItem    // Its species, in National Pokédex order
+	checklist []checklistItem // The same species, for the text poster's grid
+}
+
+// poster is everything a poster shows.
+type poster struct {
+	Lang     string            // The language code of the poster
+	Text     map[string]string // The poster's headings and labels, translated
+	Sections []posterSection   // One section per generation
+	Caught   int               // How many species were caught
+	Seen     int               // How many species were seen
+	Total    int               // How many species there are
+}
+
+// commandPoster writes a poster of the National Pokédex to a file, as HTML if
+// its name ends in .html or .htm and as plain text otherwise.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: The path of the file to write
+//
+// Returns:
+//   - An error if no path is given, the species can't be fetched, or the file
+//     can't be written
+func commandPoster(cfg *config, params []string) error {
+	err := writePoster(cfg, strings.Join(params, " "))
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "poster", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// writePoster builds the poster and writes it to path.
+func writePoster(cfg *config, path string) error {
+	if path == "" {
+		return errorhandling.NewInvalidInputError("Usage: poster <file> (e.g., 'poster pokedex.html' or 'poster pokedex.txt')", nil)
+	}
+
+	i18n.Println("Fetching the species of every generation...")
+	p, err := buildPoster(cfg)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Could not create file '%s'", path), err)
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		err = posterTemplate.Execute(file, p)
+	default:
+		renderPosterText(file, p)
+	}
+	if err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not write file '%s'", path), err)
+	}
+	i18n.Printf("Poster of %d species (%d caught) written to %s\n", p.Total, p.Caught, path)
+	return nil
+}
+
+// buildPoster gathers the species of every generation, marking those the user
+// has seen and caught. Generations are fetched until the API has no more.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//
+// Returns:
+//   - The poster
+//   - An error if a generation can't be fetched, or there are none
+func buildPoster(cfg *config) (poster, error) {
+	p := poster{Lang: i18n.Current(), Text: posterText()}
+	for generation := 1; generation <= maxPosterGenerations; generation++ {
+		genData, err := cfg.pokeapiClient.GetGeneration(generation)
+		if errorhandling.IsNotFoundError(err) && generation > 1 {
+			break
+		}
+		if err != nil {
+			return poster{}, err
+		}
+
+		section := posterSection{Title: formatGenerationName(genData.Name), checklist: buildChecklist(cfg, genData.PokemonSpecies)}
+		for _, item := range section.checklist {
+			section.Items = append(section.Items, posterItem{
+				Number: item.number, Name: FormatPokemonName(item.name), Caught: item.caught, Seen: item.seen,
+			})
+			p.Total++
+			if item.caught {
+				p.Caught++
+			}
+			if item.seen {
+				p.Seen++
+			}
+		}
+		p.Sections = append(p.Sections, section)
+	}
+	return p, nil
+}
+
+// posterText returns the poster's headings and labels in the user's language.
+func posterText() map[string]string {
+	return map[string]string{
+		"title":   i18n.T("National Pokédex"),
+		"caught":  i18n.T("Caught"),
+		"seen":    i18n.T("Seen"),
+		"missing": i18n.T("Not seen"),
+	}
+}
+
+// renderPosterText writes a poster as plain text: a checklist grid for each
+// generation, marking caught species with "[x]" and seen ones with "[o]".
+func renderPosterText(w io.Writer, p poster) {
+	fmt.Fprintln(w, p.Text["title"])
+	i18n.Fprintf(w, "Seen %d, caught %d of %d. [x] caught, [o] seen, [ ] not seen\n", p.Seen, p.Caught, p.Total)
+	for _, section := range p.Sections {
+		fmt.Fprintf(w, "\n%s\n", section.Title)
+		renderChecklistGrid(w, section.checklist, posterTextWidth)
+	}
+}
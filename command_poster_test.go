@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testPoster returns a poster with one generation of three species.
+func testPoster() poster {
+	checklist := []checklistItem{
+		{number: 1, name: "bulbasaur"},
+		{number: 4, name: "charmander", seen: true},
+		{number: 25, name: "pikachu", caught: true, seen: true},
+	}
+	section := posterSection{Title: "Generation I", checklist: checklist}
+	for _, item := range checklist {
+		section.Items = append(section.Items, posterItem{Number: item.number, Name: FormatPokemonName(item.name), Caught: item.caught, Seen: item.seen})
+	}
+	return poster{Lang: "en", Text: posterText(), Sections: []posterSection{section}, Caught: 1, Seen: 2, Total: 3}
+}
+
+// TestRenderPosterText tests that the text poster marks caught and seen species
+func TestRenderPosterText(t *testing.T) {
+	var out bytes.Buffer
+	renderPosterText(&out, testPoster())
+	text := out.String()
+	for _, want := range []string{"Seen 2, caught 1 of 3", "Generation I", "[ ] 001 Bulbasaur", "[o] 004 Charmander", "[x] 025 Pikachu"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the poster to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+// TestPosterHTML tests that caught and seen cells are filled in on the HTML poster
+func TestPosterHTML(t *testing.T) {
+	var out bytes.Buffer
+	if err := posterTemplate.Execute(&out, testPoster()); err != nil {
+		t.Fatalf("Execute returned an error: %v", err)
+	}
+	html := out.String()
+	for _, want := range []string{`<div class="cell"><b>#001</b>Bulbasaur</div>`, `<div class="cell seen"><b>#004</b>Charmander</div>`, `<div class="cell caught"><b>#025</b>Pikachu</div>`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Expected the poster to contain %s", want)
+		}
+	}
+}
+
+// TestPosterPathCase tests that the file path typed at the prompt keeps its
+// capitalization
+func TestPosterPathCase(t *testing.T) {
+	name, params := splitCommandLine("Poster ~/Posters/Dex.html")
+	if name != "poster" || !slices.Equal(params, []string{"~/Posters/Dex.html"}) {
+		t.Errorf("splitCommandLine() = %q, %q, want the path as typed", name, params)
+	}
+}
@@ -0,0 +1,189 @@
+// This file implements the random command, which picks a Pokémon species at
+// random from the whole National Pokédex, or from one generation or type, and
+// tries to catch it. It's a quick way to grow a collection without exploring.
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// randomUsage describes the forms of the random command.
+const randomUsage = "Usage: random catch [--gen <generation>] [--type <type>]"
+
+// randomFilter limits which species the random command picks from.
+type randomFilter struct {
+	generation int    // Only pick species introduced in this generation (0 for any)
+	typeName   string // Only pick species with this type ("" for any)
+}
+
+// randomResult picks a species uniformly at random and runs the normal catch
+// flow on it, as if the user had typed 'catch <pokemon>'. Each species counts
+// once, by its default form, so species with many forms aren't favored.
+//
+// Usage:
+//   - random catch: Pick from every species
+//   - random catch --gen 2 --type fire: Pick from the Fire-type species of Generation II
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is "catch", optionally followed
+//     by --gen <generation> and --type <type>
+//
+// Returns:
+//   - The outcome of the throw, as returned by catch
+//   - An error if the parameters are invalid, no species match the filter,
+//     or there's an issue with the API requests
+func randomResult(cfg *config, params []string) (commandResult, error) {
+	filter, err := parseRandomParams(params)
+	if err != nil {
+		return commandResult{}, err
+	}
+	pool, err := randomCandidates(cfg, filter)
+	if err != nil {
+		return commandResult{}, err
+	}
+	if len(pool) == 0 {
+		return commandResult{}, errorhandling.NewInvalidInputError("No species match that filter", nil)
+	}
+
+	name := pool[rand.Intn(len(pool))]
+	result, err := catchResult(cfg, []string{name})
+	if err != nil {
+		return commandResult{}, err
+	}
+	result.Message = i18n.Sprintf("A wild %s appeared!\n", FormatPokemonName(name)) + result.Message
+	return result, nil
+}
+
+// parseRandomParams parses the parameters of the random command.
+//
+// Parameters:
+//   - params: The command parameters
+//
+// Returns:
+//   - The filter to pick species with
+//   - An error if the subcommand, an option, or its value is invalid
+func parseRandomParams(params []string) (randomFilter, error) {
+	var filter randomFilter
+	usageErr := errorhandling.NewInvalidInputError(randomUsage, nil)
+	if len(params) == 0 || params[0] != "catch" || len(params)%2 == 0 {
+		return filter, usageErr
+	}
+
+	for i := 1; i < len(params); i += 2 {
+		value := params[i+1]
+		switch params[i] {
+		case "--gen":
+			generation, err := parseGeneration(value)
+			if err != nil {
+				return filter, err
+			}
+			filter.generation = generation
+		case "--type":
+			if !slices.Contains(standardTypes, value) {
+				return filter, errorhandling.NewInvalidInputError(i18n.Sprintf("Unknown type '%s'", value), nil)
+			}
+			filter.typeName = value
+		default:
+			return filter, usageErr
+		}
+	}
+	return filter, nil
+}
+
+// randomCandidates returns the default form of every species that passes the
+// filter. The species come from the dataset when it's complete, and from the
+// PokeAPI otherwise.
+//
+// Parameters:
+//   - cfg: The application configuration containing the dataset and API client
+//   - filter: Limits which species are included
+//
+// Returns:
+//   - The API names of the Pokémon, in National Pokédex order
+//   - An error if there's an issue with the API requests
+func randomCandidates(cfg *config, filter randomFilter) ([]string, error) {
+	byID := make(map[int]string)
+	if d := cfg.Dataset(); d.Complete {
+		for _, species := range d.Species {
+			byID[species.ID] = species.Name
+		}
+	} else {
+		listResp, err := cfg.pokeapiClient.ListAllPokemon()
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range listResp.Results {
+			if id, err := result.ID(); err == nil {
+				byID[id] = result.Name
+			}
+		}
+	}
+
+	var genSpecies []pokeapi.NamedAPIResource
+	if filter.generation > 0 {
+		genData, err := cfg.pokeapiClient.GetGeneration(filter.generation)
+		if err != nil {
+			return nil, err
+		}
+		genSpecies = genData.PokemonSpecies
+	}
+	var typed []string
+	if filter.typeName != "" {
+		typeData, err := cfg.pokeapiClient.GetType(filter.typeName)
+		if err != nil {
+			return nil, err
+		}
+		typed = trainerPool([]pokeapi.TypeResp{typeData})
+		if len(typed) == 0 {
+			return nil, nil
+		}
+	}
+	return randomPool(byID, genSpecies, typed), nil
+}
+
+// randomPool narrows the default forms of all species down to those in a
+// generation and with a type. A species' ID is the same as its default form's.
+//
+// Parameters:
+//   - byID: The API name of each species' default form, by ID
+//   - genSpecies: The species in the generation to pick from, or nil for any
+//   - typed: The Pokémon with the type to pick from, or nil for any
+//
+// Returns:
+//   - The API names of the matching Pokémon, in National Pokédex order
+func randomPool(byID map[int]string, genSpecies []pokeapi.NamedAPIResource, typed []string) []string {
+	var inGen map[int]bool
+	if genSpecies != nil {
+		inGen = make(map[int]bool, len(genSpecies))
+		for _, s := range genSpecies {
+			if id, err := s.ID(); err == nil {
+				inGen[id] = true
+			}
+		}
+	}
+
+	ids := make([]int, 0, len(byID))
+	for id := range byID {
+		if id > maxDefaultPokemonID || (inGen != nil && !inGen[id]) {
+			continue
+		}
+		if typed != nil && !slices.Contains(typed, byID[id]) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	pool := make([]string, len(ids))
+	for i, id := range ids {
+		pool[i] = byID[id]
+	}
+	return pool
+}
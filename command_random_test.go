@@ -0,0 +1,51 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestParseRandomParams tests parsing the random command's subcommand and filters
+func TestParseRandomParams(t *testing.T) {
+	filter, err := parseRandomParams([]string{"catch", "--gen", "gen2", "--type", "fire"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if filter != (randomFilter{generation: 2, typeName: "fire"}) {
+		t.Errorf("Unexpected filter: %+v", filter)
+	}
+
+	for _, params := range [][]string{{}, {"pikachu"}, {"catch", "--gen"}, {"catch", "--gen", "zero"}, {"catch", "--type", "sound"}, {"catch", "--box", "team"}} {
+		if _, err := parseRandomParams(params); err == nil {
+			t.Errorf("parseRandomParams(%v): expected an error", params)
+		}
+	}
+}
+
+// TestRandomPool tests narrowing species down by generation and type
+func TestRandomPool(t *testing.T) {
+	byID := map[int]string{4: "charmander", 1: "bulbasaur", 155: "cyndaquil", 152: "chikorita", 10034: "charizard-mega-x"}
+	gen2 := []pokeapi.NamedAPIResource{
+		{Name: "chikorita", URL: "https://pokeapi.co/api/v2/pokemon-species/152/"},
+		{Name: "cyndaquil", URL: "https://pokeapi.co/api/v2/pokemon-species/155/"},
+	}
+	fire := []string{"charmander", "cyndaquil"}
+
+	cases := []struct {
+		genSpecies []pokeapi.NamedAPIResource
+		typed      []string
+		want       []string
+	}{
+		{want: []string{"bulbasaur", "charmander", "chikorita", "cyndaquil"}},
+		{genSpecies: gen2, want: []string{"chikorita", "cyndaquil"}},
+		{typed: fire, want: []string{"charmander", "cyndaquil"}},
+		{genSpecies: gen2, typed: fire, want: []string{"cyndaquil"}},
+	}
+	for _, c := range cases {
+		if got := randomPool(byID, c.genSpecies, c.typed); !slices.Equal(got, c.want) {
+			t.Errorf("randomPool(%v, %v) = %v, expected %v", c.genSpecies, c.typed, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// redeemUsage describes the parameters of the redeem command.
+const redeemUsage = "Usage: redeem <code>"
+
+// commandRedeem implements the "redeem" command, which claims the Pokémon or
+// items handed out at an event with a distribution code. Codes are checked
+// offline (see distribution_utils.go) and each can be redeemed once per save file.
+// Pokémon received this way have the Classic Ribbon and go to storage if the
+// party is full.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex, bag, and API client
+//   - params: Command parameters making up the code (spaces are ignored)
+//
+// Returns:
+//   - An error if the code is missing, invalid, or already redeemed,
+//     or there's an issue with the API requests
+func commandRedeem(cfg *config, params []string) error {
+	err := redeemCode(cfg, params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "redeem", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// redeemCode checks a distribution code and grants its reward.
+func redeemCode(cfg *config, params []string) error {
+	if len(params) == 0 {
+		return errorhandling.NewInvalidInputError(redeemUsage, nil)
+	}
+
+	reward, code, err := parseDistributionCode(strings.Join(params, ""))
+	if errors.Is(err, errInvalidCode) {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("'%s' isn't a valid distribution code. Check that it was typed correctly.", strings.Join(params, " ")), err)
+	}
+	if cfg.Redeemed(code) {
+		return errorhandling.NewInvalidInputError(i18n.T("That code has already been redeemed."), nil)
+	}
+
+	switch reward.kind {
+	case rewardPokemon:
+		err = receivePokemon(cfg, reward.name)
+	case rewardItem:
+		err = receiveItem(cfg, reward.name, reward.quantity)
+	}
+	if err != nil {
+		return err
+	}
+	cfg.MarkRedeemed(code)
+
+	// Auto-save the gift and the redeemed code
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the gift was still received
+		HandleCommandError(cfg, "redeem", err)
+	}
+	printSeparator()
+	return nil
+}
+
+// receivePokemon adds a Pokémon from a distribution to the Pokédex.
+// The code isn't used up if the Pokémon is already in the Pokédex, so it can
+// be redeemed after releasing that one.
+func receivePokemon(cfg *config, name string) error {
+	formatted := FormatPokemonName(name)
+	if _, exists := cfg.pokedex.Get(name); exists {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("You already have %s. Release it first to receive this one.", formatted), nil)
+	}
+
+	data, err := cfg.pokeapiClient.GetPokemonData(name)
+	if err != nil {
+		return err
+	}
+
+	entry := pokedex.NewEntry(data)
+	entry.CaughtOn = time.Now()
+	entry.AwardRibbon(ribbonClassic)
+	// Send the Pokémon to storage if there's no room for it in the party
+	if !partyHasRoom(cfg, name) {
+		entry.Box = storageBox
+	}
+	cfg.pokedex.Add(name, entry)
+
+	i18n.Printf("You received %s! It has the %s.\n", formatted, ribbonName(ribbonClassic))
+	if entry.Box != "" {
+		i18n.Printf("Your party is full, so %s was sent to box '%s'.\n", formatted, entry.Box)
+	}
+	return nil
+}
+
+// receiveItem puts items from a distribution in the user's bag.
+func receiveItem(cfg *config, name string, quantity int) error {
+	// Look the item up so that a code for an unknown item isn't used up
+	if _, err := cfg.pokeapiClient.GetItem(name); err != nil {
+		return err
+	}
+	cfg.AddItem(name, quantity)
+	i18n.Printf("You received %s x%d! It's in your bag.\n", FormatItemName(name), quantity)
+	return nil
+}
@@ -0,0 +1,287 @@
+// This file implements the refresh command, which updates the Pokémon data kept
+// in Pokédex entries with a fresh copy from the API. 'refresh --all' refreshes
+// every entry, several at a time, with a progress bar and a summary of the
+// fields that changed.
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// refreshWorkers is the number of Pokémon downloaded at the same time by 'refresh --all'.
+const refreshWorkers = 4
+
+// refreshRequestsPerSecond is the most Pokémon 'refresh --all' starts downloading
+// each second, so that refreshing a large Pokédex doesn't flood the API.
+const refreshRequestsPerSecond = 10
+
+// commandRefresh updates a caught Pokémon's data with a fresh copy from the API.
+// Pokédex entries keep the data retrieved when the Pokémon was caught, so they
+// miss corrections to PokeAPI and fields added in later versions. The cache is
+// skipped so that the API is always asked again. Only the API data is replaced:
+// notes, box, moveset, level, ribbons, and everything else the user has added
+// are kept. The command supports two forms:
+//   - refresh <pokemon>: Refresh one Pokémon
+//   - refresh --all: Refresh every Pokémon in the Pokédex
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the Pokémon to refresh, or --all
+//
+// Returns:
+//   - An error if no Pokémon name is provided, if the Pokémon is not in the
+//     Pokédex, or if its data can't be retrieved
+func commandRefresh(cfg *config, params []string) error {
+	if len(params) == 1 && params[0] == "--all" {
+		refreshAll(cfg)
+		return nil
+	}
+
+	// Use the utility function to validate the Pokemon parameter and check if it exists
+	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "refresh", err) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := cfg.pokeapiClient.RefreshPokemonData(apiName)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "refresh", err) {
+			return err
+		}
+		return nil
+	}
+
+	previous, _ := cfg.pokedex.Get(apiName)
+	changed, err := applyRefreshedData(cfg, apiName, data)
+	if err != nil {
+		// Use standardized error handling
+		err = pokedexError(err, apiName)
+		if HandleCommandError(cfg, "refresh", err) {
+			return err
+		}
+		return nil
+	}
+
+	if len(changed) == 0 {
+		i18n.Printf("%s's data is already up to date.\n", nameInfo.Formatted)
+		printSeparator()
+		return nil
+	}
+	i18n.Printf("Updated %s's data from the API. Your notes, box, moveset, and progress were kept.\n", nameInfo.Formatted)
+	writeDataDiff(os.Stdout, cfg, i18n.T("Before"), previous.PokemonDataResp, i18n.T("After"), data)
+	printSeparator()
+
+	// Auto-save after refreshing
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the Pokédex has already been updated
+		HandleCommandError(cfg, "refresh", err)
+	}
+	return nil
+}
+
+// refreshResult is the outcome of downloading one Pokémon for 'refresh --all'.
+type refreshResult struct {
+	data    pokeapi.PokemonDataResp // The Pokémon's current data
+	err     error                   // Why it couldn't be downloaded, if it couldn't
+	fetched bool                    // Whether it was downloaded (false if the refresh was cancelled first)
+}
+
+// refreshAll refreshes every Pokémon in the Pokédex and summarizes what changed.
+// Pokémon that can't be downloaded are listed and left as they were. If the
+// refresh is cancelled with Ctrl+C, the Pokémon refreshed so far are kept.
+func refreshAll(cfg *config) {
+	entries := cfg.pokedex.List()
+	if len(entries) == 0 {
+		i18n.Println("You have not caught any Pokémon yet")
+		printSeparator()
+		return
+	}
+
+	ctx := commandContext(cfg)
+	i18n.Printf("Refreshing the data for %d Pokémon...\n", len(entries))
+	i18n.Println("Press Ctrl+C to cancel.")
+	bar := NewProgressBar(os.Stdout, len(entries))
+	results := fetchRefreshedData(ctx, cfg.pokeapiClient.WithContext(ctx), entries, bar.Advance)
+	bar.Finish()
+
+	fieldCounts := map[string]int{}
+	var updated, fetched int
+	var failures []string
+	for i, result := range results {
+		name := entries[i].Name
+		if !result.fetched {
+			continue
+		}
+		fetched++
+		err := result.err
+		var changed []string
+		if err == nil {
+			changed, err = applyRefreshedData(cfg, name, result.data)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", FormatPokemonName(name), errorhandling.FormatUserMessage(err)))
+			continue
+		}
+		if len(changed) > 0 {
+			updated++
+		}
+		for _, field := range changed {
+			fieldCounts[field]++
+		}
+	}
+
+	if ctx.Err() != nil {
+		i18n.Printf("Refresh cancelled after %d of %d Pokémon. The ones already refreshed were kept.\n", fetched, len(entries))
+	}
+	if updated == 0 {
+		i18n.Printf("No changes: %d Pokémon were already up to date.\n", fetched-len(failures))
+	} else {
+		i18n.Printf("Updated %d of %d Pokémon. Their notes, boxes, movesets, and progress were kept.\n", updated, fetched-len(failures))
+		printChangedFields(fieldCounts)
+	}
+	if len(failures) > 0 {
+		i18n.Printf("Couldn't refresh %d Pokémon; they were left as they were:\n", len(failures))
+		for _, failure := range failures {
+			i18n.Printf(" - %s\n", failure)
+		}
+	}
+	printSeparator()
+
+	if updated > 0 {
+		// Auto-save after refreshing
+		if err := UpdatePokedexAndSave(cfg); err != nil {
+			// Use standardized error handling but don't return the error
+			// since the Pokédex has already been updated
+			HandleCommandError(cfg, "refresh", err)
+		}
+	}
+}
+
+// fetchRefreshedData downloads the current data for Pokédex entries, several at
+// a time and no faster than refreshRequestsPerSecond. No more downloads are
+// started once ctx is cancelled.
+//
+// Parameters:
+//   - ctx: Cancels the download
+//   - client: The API client to download with
+//   - entries: The entries to download data for
+//   - done: Called after each download finishes, successfully or not
+//
+// Returns:
+//   - The result for each entry, in the order of entries
+func fetchRefreshedData(ctx context.Context, client *pokeapi.Client, entries []pokedex.NamedEntry, done func()) []refreshResult {
+	results := make([]refreshResult, len(entries))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range refreshWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				data, err := client.RefreshPokemonData(entries[i].Name)
+				results[i] = refreshResult{data: data, err: err, fetched: ctx.Err() == nil}
+				done()
+			}
+		}()
+	}
+
+	limiter := time.NewTicker(time.Second / refreshRequestsPerSecond)
+	defer limiter.Stop()
+queue:
+	for i := range entries {
+		if i > 0 {
+			select {
+			case <-limiter.C:
+			case <-ctx.Done():
+				break queue
+			}
+		}
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// applyRefreshedData replaces the API data in a Pokédex entry, keeping
+// everything the user has added.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - name: The Pokémon's name in the Pokédex
+//   - data: The Pokémon's current data from the API
+//
+// Returns:
+//   - The fields of the data that changed (see changedDataFields)
+//   - An error if the Pokémon is no longer in the Pokédex
+func applyRefreshedData(cfg *config, name string, data pokeapi.PokemonDataResp) ([]string, error) {
+	var changed []string
+	err := cfg.pokedex.Update(name, func(entry *pokedex.Entry) error {
+		changed = changedDataFields(entry.PokemonDataResp, data)
+		entry.PokemonDataResp = data
+		return nil
+	})
+	return changed, err
+}
+
+// changedDataFields lists the fields that differ between two copies of a
+// Pokémon's data, by the names the API gives them (such as "moves" or
+// "base_experience").
+func changedDataFields(old, current pokeapi.PokemonDataResp) []string {
+	var changed []string
+	oldValue, currentValue := reflect.ValueOf(old), reflect.ValueOf(current)
+	for i := range oldValue.NumField() {
+		if reflect.DeepEqual(oldValue.Field(i).Interface(), currentValue.Field(i).Interface()) {
+			continue
+		}
+		field := oldValue.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		changed = append(changed, name)
+	}
+	return changed
+}
+
+// printChangedFields shows how many Pokémon each field changed for, most first.
+func printChangedFields(fieldCounts map[string]int) {
+	fields := slices.Collect(maps.Keys(fieldCounts))
+	slices.SortFunc(fields, func(a, b string) int {
+		if fieldCounts[a] != fieldCounts[b] {
+			return fieldCounts[b] - fieldCounts[a]
+		}
+		return strings.Compare(a, b)
+	})
+
+	table := NewTable("Field", "Pokémon")
+	for _, field := range fields {
+		table.AddRow(field, strconv.Itoa(fieldCounts[field]))
+	}
+	table.Print()
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestChangedDataFields tests that changes to the API data are reported by the
+// names the API gives the fields
+func TestChangedDataFields(t *testing.T) {
+	old := pokeapi.PokemonDataResp{Name: "pikachu", Height: 4, BaseExperience: 112}
+	if changed := changedDataFields(old, old); len(changed) != 0 {
+		t.Errorf("Expected no changes, got %v", changed)
+	}
+
+	current := old
+	current.BaseExperience = 120
+	current.Moves = []pokeapi.PokemonMove{{Move: pokeapi.NamedAPIResource{Name: "thunderbolt"}}}
+	expected := []string{"base_experience", "moves"}
+	if changed := changedDataFields(old, current); !slices.Equal(changed, expected) {
+		t.Errorf("Expected %v, got %v", expected, changed)
+	}
+}
@@ -1,47 +1,141 @@
 package main
 
 import (
-	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
-// commandRelease removes a Pokémon from the user's Pokédex.
+// releasedPokemon is the data behind the release command's result.
+type releasedPokemon struct {
+	Released []string `json:"released"` // The API names of the Pokémon released (empty if the user changed their mind)
+}
+
+// releaseResult removes a Pokémon from the user's Pokédex.
 // This command simulates releasing a caught Pokémon back into the wild,
-// removing it from the user's collection.
+// removing it from the user's collection. Releasing a legendary, a favorite,
+// or the last Pokémon the user has from its evolution family needs confirming
+// (see release_utils.go). With --select, several Pokémon are chosen from a
+// list instead (see releaseSelected).
 //
 // Parameters:
 //   - cfg: The application configuration containing the Pokédex
 //   - params: Command parameters where params[0] is the Pokémon name to release
 //
 // Returns:
+//   - The Pokémon released, if any
 //   - An error if no Pokémon name is provided or if the Pokémon is not in the Pokédex
-func commandRelease(cfg *config, params []string) error {
+func releaseResult(cfg *config, params []string) (commandResult, error) {
+	if _, selecting := takeFlag(params, selectFlag); selecting {
+		return releaseSelected(cfg)
+	}
+
 	// Use the utility function to validate the Pokemon parameter and check if it exists
-	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, params)
+	apiName, nameInfo, pokemonData, _, err := GetPokemonIfExists(cfg, params)
 	if err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "release", err) {
-			return err
+		return commandResult{}, err
+	}
+	entry, err := GetTypedPokemonData(pokemonData, nameInfo.Formatted)
+	if err != nil {
+		return commandResult{}, err
+	}
+
+	// Pokémon that would be hard to get back are only released if the user is sure
+	if warnings := releaseWarnings(cfg, apiName, entry); len(warnings) > 0 {
+		for _, warning := range warnings {
+			i18n.Printf("Warning: %s\n", warning)
 		}
-		return nil
+		if !confirm(cfg, i18n.Sprintf("Release %s anyway?", nameInfo.Formatted)) {
+			return commandResult{
+				Message: i18n.Sprintf("%s stays with you.\n", nameInfo.Formatted),
+				Data:    releasedPokemon{Released: []string{}},
+			}, nil
+		}
+	}
+
+	// The Pokémon is known to be in the Pokédex, so only auto-saving can fail
+	if err := releasePokemon(cfg, apiName); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the Pokémon has already been released
+		HandleCommandError(cfg, "release", err)
+	}
+	return commandResult{
+		Message: releaseFarewell(nameInfo.Formatted, entry.PokemonDataResp) + "\n",
+		Data:    releasedPokemon{Released: []string{apiName}},
+	}, nil
+}
+
+// releasePokemon removes a Pokémon from the Pokédex and auto-saves. It stays
+// in the seen list, like in the games. This is shared by the release command
+// and the serve mode's Release method.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - apiName: The Pokémon's name in API format
+//
+// Returns:
+//   - An error if the Pokémon isn't in the Pokédex or auto-saving fails
+func releasePokemon(cfg *config, apiName string) error {
+	if _, ok := cfg.pokedex.Get(apiName); !ok {
+		return errorhandling.PokemonNotInPokedexError(FormatPokemonName(apiName))
 	}
+	letGo(cfg, apiName)
+	return UpdatePokedexAndSave(cfg)
+}
 
-	// Lock the config before modifying the pokedex
-	cfg.mutex.Lock()
-	// Remove the pokemon from the pokedex
-	delete(cfg.pokedex, apiName)
-	cfg.mutex.Unlock()
+// letGo removes a released Pokémon from the Pokédex, keeping it in the seen
+// list. The item it was holding goes back in the bag.
+func letGo(cfg *config, apiName string) {
+	if entry, ok := cfg.pokedex.Get(apiName); ok && entry.HeldItem != "" {
+		cfg.AddItem(entry.HeldItem, 1)
+	}
+	cfg.pokedex.MarkSeen(apiName, pokedex.Sighting{SeenOn: time.Now()})
+	cfg.pokedex.Remove(apiName)
+}
 
-	fmt.Printf("%s was released. Bye, %s!\n", nameInfo.Formatted, nameInfo.Formatted)
-	fmt.Println("-----")
+// releaseSelected releases the Pokémon the user chooses from the Pokédex with
+// the picker (see multiselect_utils.go). Releasing several Pokémon at once
+// always needs confirming, with the warnings for any that would be hard to get
+// back listed first, and the Pokédex is auto-saved once they're all gone.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//
+// Returns:
+//   - The Pokémon released, if any
+//   - An error if the Pokédex is empty or the picker can't be used
+func releaseSelected(cfg *config) (commandResult, error) {
+	chosen, err := pickPokemon(cfg, i18n.T("Choose the Pokémon to release:"), cfg.pokedex.List())
+	if err != nil {
+		return commandResult{}, err
+	}
+	released := releasedPokemon{Released: []string{}}
+	if len(chosen) == 0 {
+		return commandResult{Message: i18n.T("Nothing was chosen, so nothing was released.") + "\n", Data: released}, nil
+	}
 
-	// Auto-save after releasing a Pokémon
-	if err := UpdatePokedexAndSave(cfg); err != nil {
-		// Use standardized error handling
-		if HandleCommandError(cfg, "release", err) {
-			return err
+	for _, named := range chosen {
+		for _, warning := range releaseWarnings(cfg, named.Name, named.Entry) {
+			i18n.Printf("Warning: %s\n", warning)
 		}
-		return nil
+	}
+	if !confirm(cfg, i18n.Sprintf("Release %d Pokémon?", len(chosen))) {
+		return commandResult{Message: i18n.T("Nothing was released.") + "\n", Data: released}, nil
 	}
 
-	return nil
+	var message strings.Builder
+	for _, named := range chosen {
+		letGo(cfg, named.Name)
+		released.Released = append(released.Released, named.Name)
+		message.WriteString(releaseFarewell(FormatPokemonName(named.Name), named.Entry.PokemonDataResp) + "\n")
+	}
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the Pokémon have already been released
+		HandleCommandError(cfg, "release", err)
+	}
+	return commandResult{Message: message.String(), Data: released}, nil
 }
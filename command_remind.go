@@ -0,0 +1,89 @@
+// This file implements the remind command, which sets reminders that are shown
+// before the next prompt once their time comes (see reminder_utils.go).
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// remindUsage describes the forms of the remind command.
+const remindUsage = "Usage: remind, remind <delay> <message> (e.g. remind 10m check berries), or remind cancel <number>"
+
+// commandRemind implements the "remind" command.
+// Supported forms:
+//   - remind: List the reminders that haven't come up yet
+//   - remind <delay> <message>: Show a message after a delay such as 10m or 1h30m
+//   - remind cancel <number>: Cancel a reminder, by its number in the list
+//
+// Parameters:
+//   - cfg: The application configuration containing the reminders
+//   - params: Command parameters, with their capitalization kept for the message
+//
+// Returns:
+//   - An error if the parameters are invalid or the reminders can't be saved
+func commandRemind(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		listReminders(cfg, time.Now())
+	case strings.EqualFold(params[0], "cancel") && len(params) == 2:
+		var cancelled pokedex.Reminder
+		if cancelled, err = cancelReminder(cfg, false, params[1]); err == nil {
+			i18n.Printf("Cancelled the reminder: %s\n", cancelled.Message)
+		}
+	case len(params) >= 2:
+		err = addReminder(cfg, params[0], unquoteText(strings.Join(params[1:], " ")), time.Now())
+	default:
+		err = errorhandling.NewInvalidInputError(remindUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "remind", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// listReminders shows the reminders that haven't come up yet, soonest first.
+func listReminders(cfg *config, now time.Time) {
+	reminders := remindersOfKind(cfg, false)
+	if len(reminders) == 0 {
+		i18n.Println("You have no reminders. Set one with 'remind 10m check berries'.")
+		return
+	}
+	i18n.Println("Reminders:")
+	for i, reminder := range reminders {
+		i18n.Printf("%d. %s, %s\n", i+1, reminder.Message, formatReminderDue(reminder.Due, now))
+	}
+}
+
+// addReminder sets a reminder and saves it.
+//
+// Parameters:
+//   - cfg: The application configuration containing the reminders
+//   - delay: How long until the reminder comes up, such as "10m"
+//   - message: What to remind the user of
+//   - now: The current time
+//
+// Returns:
+//   - An error if the delay is invalid or the reminder can't be saved
+func addReminder(cfg *config, delay, message string, now time.Time) error {
+	wait, err := time.ParseDuration(strings.ToLower(delay))
+	if err != nil || wait <= 0 {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Invalid delay: %s (use a duration like 10m or 1h30m)", delay), nil)
+	}
+	reminder := pokedex.Reminder{Message: message, Due: now.Add(wait)}
+	cfg.AddReminder(reminder)
+	i18n.Printf("I'll remind you %s: %s\n", formatReminderDue(reminder.Due, now), message)
+	return savePokedexData(cfg)
+}
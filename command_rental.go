@@ -0,0 +1,197 @@
+// This file implements rental teams: preset themed teams that can be borrowed
+// for battles, as in the Battle Factory and the Stadium games. While a team is
+// rented, battles draw the player's team from it instead of the Pokédex, so the
+// Pokémon in the Pokédex don't gain experience from them. Rentals last until
+// they're returned or the app exits.
+package main
+
+import (
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// rentalUsage describes the forms of the rental command.
+const rentalUsage = "Usage: rental [team], or rental return"
+
+// rentalLevel is the level of rental Pokémon.
+const rentalLevel = 50
+
+// rentalPokemon is a Pokémon in a rental team, with the moves it battles with.
+type rentalPokemon struct {
+	name  string   // The Pokémon's API name
+	moves []string // The API names of its moves
+}
+
+// rentalTeam is a preset team that can be rented.
+type rentalTeam struct {
+	name        string          // The name the team is rented by
+	description string          // What the team is, for the list of teams
+	pokemon     []rentalPokemon // The Pokémon in the team
+}
+
+// rentalParty is a rented team, ready for battle.
+type rentalParty struct {
+	team    string                   // The name of the rented team
+	entries map[string]pokedex.Entry // The team's Pokémon, by name
+}
+
+// rentalTeams lists the teams that can be rented, in the order they're listed.
+var rentalTeams = []rentalTeam{
+	{name: "kanto-starters", description: "The fully evolved starters of Kanto", pokemon: []rentalPokemon{
+		{"venusaur", []string{"solar-beam", "sludge-bomb", "earthquake", "razor-leaf"}},
+		{"charizard", []string{"flamethrower", "air-slash", "dragon-claw", "earthquake"}},
+		{"blastoise", []string{"hydro-pump", "ice-beam", "surf", "skull-bash"}},
+	}},
+	{name: "johto-starters", description: "The fully evolved starters of Johto", pokemon: []rentalPokemon{
+		{"meganium", []string{"razor-leaf", "body-slam", "earthquake", "solar-beam"}},
+		{"typhlosion", []string{"flamethrower", "thunder-punch", "earthquake", "swift"}},
+		{"feraligatr", []string{"waterfall", "crunch", "ice-punch", "earthquake"}},
+	}},
+	{name: "hoenn-starters", description: "The fully evolved starters of Hoenn", pokemon: []rentalPokemon{
+		{"sceptile", []string{"leaf-blade", "dragon-claw", "earthquake", "quick-attack"}},
+		{"blaziken", []string{"blaze-kick", "sky-uppercut", "flamethrower", "thunder-punch"}},
+		{"swampert", []string{"earthquake", "surf", "ice-beam", "rock-slide"}},
+	}},
+	{name: "legendary-birds", description: "Articuno, Zapdos, and Moltres", pokemon: []rentalPokemon{
+		{"articuno", []string{"ice-beam", "blizzard", "wing-attack", "sky-attack"}},
+		{"zapdos", []string{"thunderbolt", "drill-peck", "thunder", "sky-attack"}},
+		{"moltres", []string{"flamethrower", "fire-blast", "wing-attack", "sky-attack"}},
+	}},
+	{name: "weather-trio", description: "The legendary Pokémon of sea, land, and sky", pokemon: []rentalPokemon{
+		{"kyogre", []string{"surf", "ice-beam", "thunder", "hydro-pump"}},
+		{"groudon", []string{"earthquake", "fire-blast", "solar-beam", "rock-slide"}},
+		{"rayquaza", []string{"dragon-claw", "fly", "extreme-speed", "earthquake"}},
+	}},
+	{name: "mono-water", description: "Water types only", pokemon: []rentalPokemon{
+		{"gyarados", []string{"waterfall", "crunch", "earthquake", "ice-fang"}},
+		{"starmie", []string{"surf", "psychic", "thunderbolt", "ice-beam"}},
+		{"lapras", []string{"surf", "ice-beam", "body-slam", "thunderbolt"}},
+		{"vaporeon", []string{"surf", "ice-beam", "shadow-ball", "quick-attack"}},
+	}},
+	{name: "mono-fire", description: "Fire types only", pokemon: []rentalPokemon{
+		{"arcanine", []string{"flamethrower", "extreme-speed", "crunch", "fire-fang"}},
+		{"ninetales", []string{"flamethrower", "fire-blast", "quick-attack", "extrasensory"}},
+		{"rapidash", []string{"flamethrower", "stomp", "quick-attack", "fire-blast"}},
+		{"magmar", []string{"fire-punch", "thunder-punch", "flamethrower", "psychic"}},
+	}},
+	{name: "mono-dragon", description: "Dragon types only", pokemon: []rentalPokemon{
+		{"dragonite", []string{"dragon-claw", "extreme-speed", "fire-punch", "thunder-punch"}},
+		{"salamence", []string{"dragon-claw", "flamethrower", "crunch", "fly"}},
+		{"garchomp", []string{"dragon-claw", "earthquake", "crunch", "stone-edge"}},
+		{"flygon", []string{"dragon-claw", "earthquake", "rock-slide", "fire-punch"}},
+	}},
+}
+
+// commandRental implements the "rental" command.
+// Supported forms:
+//   - rental: List the teams that can be rented, and the one rented, if any
+//   - rental <team>: Rent a team for battles, replacing any team already rented
+//   - rental return: Return the rented team, so battles use the Pokédex again
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - params: Command parameters where params[0] is the optional team name or "return"
+//
+// Returns:
+//   - An error if the team doesn't exist, or there's an issue with the API requests
+func commandRental(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		listRentalTeams(cfg)
+	case len(params) > 1:
+		err = errorhandling.NewInvalidInputError(rentalUsage, nil)
+	case params[0] == "return":
+		err = returnRental(cfg)
+	default:
+		err = rentTeam(cfg, params[0])
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "rental", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// listRentalTeams shows the teams that can be rented.
+func listRentalTeams(cfg *config) {
+	table := NewTable("Team", "Pokémon", "Description")
+	for _, team := range rentalTeams {
+		table.AddRow(team.name, strings.Join(rentalTeamNames(team), ", "), i18n.T(team.description))
+	}
+	table.Print()
+	if rental, ok := cfg.Rental(); ok {
+		i18n.Printf("You're renting the '%s' team. Return it with 'rental return'.\n", rental.team)
+	} else {
+		i18n.Println("Rent a team with 'rental <team>' to battle with it instead of your Pokémon.")
+	}
+}
+
+// rentTeam rents a team, looking up its Pokémon's data.
+func rentTeam(cfg *config, name string) error {
+	team, ok := findRentalTeam(name)
+	if !ok {
+		names := make([]string, len(rentalTeams))
+		for i, t := range rentalTeams {
+			names[i] = t.name
+		}
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown rental team '%s'. Teams: %s", name, strings.Join(names, ", ")), nil)
+	}
+
+	rental := rentalParty{team: team.name, entries: make(map[string]pokedex.Entry, len(team.pokemon))}
+	for _, p := range team.pokemon {
+		data, err := cfg.pokeapiClient.GetPokemonData(p.name)
+		if err != nil {
+			return err
+		}
+		entry := pokedex.NewEntry(data)
+		entry.Level = rentalLevel
+		entry.Moveset = p.moves
+		rental.entries[p.name] = entry
+	}
+	cfg.SetRental(&rental)
+
+	i18n.Printf("You rented the '%s' team: %s.\n", team.name, strings.Join(rentalTeamNames(team), ", "))
+	i18n.Println("Battles use it instead of your Pokémon until you return it with 'rental return'.")
+	return nil
+}
+
+// returnRental returns the rented team.
+func returnRental(cfg *config) error {
+	rental, ok := cfg.Rental()
+	if !ok {
+		return errorhandling.NewInvalidInputError("You aren't renting a team", nil)
+	}
+	cfg.SetRental(nil)
+	i18n.Printf("You returned the '%s' team. Battles use your Pokémon again.\n", rental.team)
+	return nil
+}
+
+// findRentalTeam returns the rental team with a name.
+func findRentalTeam(name string) (rentalTeam, bool) {
+	name = ConvertToAPIFormat(name)
+	for _, team := range rentalTeams {
+		if team.name == name {
+			return team, true
+		}
+	}
+	return rentalTeam{}, false
+}
+
+// rentalTeamNames returns the display names of a rental team's Pokémon.
+func rentalTeamNames(team rentalTeam) []string {
+	names := make([]string, len(team.pokemon))
+	for i, p := range team.pokemon {
+		names[i] = FormatPokemonName(p.name)
+	}
+	return names
+}
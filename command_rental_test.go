@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestRentalTeams tests that every rental team has a unique name, enough
+// Pokémon for a battle, and a full moveset for each of them
+func TestRentalTeams(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, team := range rentalTeams {
+		if seen[team.name] || ConvertToAPIFormat(team.name) != team.name {
+			t.Errorf("Team name %q is repeated or not in API format", team.name)
+		}
+		seen[team.name] = true
+		if len(team.pokemon) < battleTeamSize {
+			t.Errorf("Team %s has %d Pokémon, fewer than a battle team", team.name, len(team.pokemon))
+		}
+		for _, p := range team.pokemon {
+			if len(p.moves) == 0 || len(p.moves) > pokedex.MaxMovesetSize {
+				t.Errorf("%s in team %s has %d moves", p.name, team.name, len(p.moves))
+			}
+		}
+	}
+
+	if team, ok := findRentalTeam("Kanto Starters"); !ok || team.name != "kanto-starters" {
+		t.Errorf("Expected to find the Kanto starters, got %q", team.name)
+	}
+	if _, ok := findRentalTeam("johto"); ok {
+		t.Error("Expected no team named 'johto'")
+	}
+}
+
+// TestBattleEntriesRental tests that battles use the rented team instead of
+// the Pokédex until it's returned
+func TestBattleEntriesRental(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric")))
+	cfg.SetRental(&rentalParty{team: "mono-water", entries: map[string]pokedex.Entry{
+		"starmie": pokedex.NewEntry(testMatchupPokemon(t, "starmie", 520, "water", "psychic")),
+	}})
+
+	entries, err := battleEntries(cfg, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := entries["starmie"]; !ok || len(entries) != 1 {
+		t.Errorf("Expected only the rental Starmie, got %v", entries)
+	}
+
+	cfg.SetRental(nil)
+	entries, _ = battleEntries(cfg, "")
+	if _, ok := entries["pikachu"]; !ok || len(entries) != 1 {
+		t.Errorf("Expected the Pokédex after returning the team, got %v", entries)
+	}
+}
@@ -0,0 +1,147 @@
+// This file implements the replay command, which plays back a battle recorded
+// with 'battle --record', pausing between turns so it can be followed.
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// replayUsage describes the parameters of the replay command.
+const replayUsage = "Usage: replay <file> [--speed <n>]"
+
+// replayTurnDelay is the pause between turns when a replay is played at normal speed.
+const replayTurnDelay = 1500 * time.Millisecond
+
+// commandReplay plays back a recorded battle, turn by turn.
+// Supported forms:
+//   - replay <file>: Play the battle back at normal speed
+//   - replay <file> --speed 2: Play it back twice as fast (0.5 for half as fast)
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters where params[0] is the replay file, optionally followed by --speed
+//
+// Returns:
+//   - An error if the parameters are invalid or the replay file can't be read
+func commandReplay(cfg *config, params []string) error {
+	path, speed, err := parseReplayParams(params)
+	var replay battleReplay
+	if err == nil {
+		replay, err = readReplay(path)
+	}
+	if err == nil {
+		delay := time.Duration(float64(replayTurnDelay) / speed)
+		err = playReplay(replay, func() { time.Sleep(delay) })
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "replay", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// parseReplayParams parses the parameters of the replay command.
+//
+// Parameters:
+//   - params: The command parameters
+//
+// Returns:
+//   - The path of the replay file
+//   - The playback speed, where 1 is normal speed
+//   - An error if the file is missing, an option is unknown, or the speed isn't a positive number
+func parseReplayParams(params []string) (string, float64, error) {
+	usageErr := errorhandling.NewInvalidInputError(replayUsage, nil)
+	if len(params) != 1 && len(params) != 3 {
+		return "", 0, usageErr
+	}
+	speed := 1.0
+	if len(params) == 3 {
+		if strings.ToLower(params[1]) != "--speed" {
+			return "", 0, usageErr
+		}
+		n, err := strconv.ParseFloat(params[2], 64)
+		if err != nil || n <= 0 {
+			return "", 0, errorhandling.NewInvalidInputError(
+				"The speed must be a positive number, like 2 for twice as fast", err)
+		}
+		speed = n
+	}
+	return params[0], speed, nil
+}
+
+// playReplay prints a recorded battle series, turn by turn, with the results of
+// each battle and of the series.
+//
+// Parameters:
+//   - replay: The recorded battles
+//   - pause: Called before each turn after the opening one, to give time to follow along
+//
+// Returns:
+//   - An error if the replay has an event that can't be described
+func playReplay(replay battleReplay, pause func()) error {
+	var teams [2]string
+	for i, team := range replay.Teams {
+		names := make([]string, 0, len(team.Pokemon))
+		for _, name := range team.Pokemon {
+			names = append(names, FormatPokemonName(name))
+		}
+		teams[i] = fmt.Sprintf("%s (%s)", replayPlayer(team.Player), strings.Join(names, ", "))
+	}
+	i18n.Printf("Battle recorded %s: %s vs %s\n", replay.Recorded.Local().Format("2006-01-02 15:04"), teams[0], teams[1])
+
+	var wins [2]int
+	for game, battle := range replay.Battles {
+		if replay.BestOf > 1 {
+			i18n.Printf("Battle %d (best of %d)\n", game+1, replay.BestOf)
+		}
+		for turn, recorded := range battle.Turns {
+			if turn > 0 {
+				pause()
+				i18n.Printf("Turn %d\n", turn)
+			}
+			events := make([]battleEvent, 0, len(recorded))
+			for _, r := range recorded {
+				event, err := r.event()
+				if err != nil {
+					return errorhandling.NewInvalidInputError("The replay file is damaged", err)
+				}
+				events = append(events, event)
+			}
+			printEvents(events)
+		}
+
+		winner := min(max(battle.Winner, 0), 1)
+		wins[winner]++
+		i18n.Printf("%s wins the battle! Score: %s %d, %s %d\n",
+			replayPlayer(replay.Teams[winner].Player), replayPlayer(replay.Teams[0].Player), wins[0],
+			replayPlayer(replay.Teams[1].Player), wins[1])
+	}
+	if replay.BestOf > 1 {
+		winner := 0
+		if wins[1] > wins[0] {
+			winner = 1
+		}
+		i18n.Printf("%s wins the series %d to %d!\n", replayPlayer(replay.Teams[winner].Player), wins[winner], wins[1-winner])
+	}
+	return nil
+}
+
+// replayPlayer returns the name of a side in a replay for display. A wild
+// Pokémon's side has no player.
+func replayPlayer(player string) string {
+	if player == "" {
+		return i18n.T("Wild Pokémon")
+	}
+	return player
+}
@@ -0,0 +1,49 @@
+package main
+
+import "testing"
+
+// TestParseReplayParams tests parsing the file and playback speed
+func TestParseReplayParams(t *testing.T) {
+	path, speed, err := parseReplayParams([]string{"Final.json", "--speed", "2.5"})
+	if err != nil || path != "Final.json" || speed != 2.5 {
+		t.Errorf("Expected Final.json at speed 2.5, got %q at %v (%v)", path, speed, err)
+	}
+	if _, speed, _ := parseReplayParams([]string{"final.json"}); speed != 1 {
+		t.Errorf("Expected normal speed by default, got %v", speed)
+	}
+
+	for _, params := range [][]string{{}, {"final.json", "--speed"}, {"final.json", "--speed", "0"},
+		{"final.json", "--speed", "fast"}, {"final.json", "--delay", "2"}} {
+		if _, _, err := parseReplayParams(params); err == nil {
+			t.Errorf("parseReplayParams(%v): expected an error", params)
+		}
+	}
+}
+
+// TestPlayReplay tests that a replay pauses between turns and rejects damaged events
+func TestPlayReplay(t *testing.T) {
+	sentOut := replayEvent{Kind: "switch", Player: "Player 1", Pokemon: "pikachu"}
+	faint := replayEvent{Kind: "faint", Player: "Player 1", Pokemon: "pikachu"}
+	replay := battleReplay{
+		Version: replayVersion,
+		BestOf:  3,
+		Teams:   [2]replayTeam{{Player: "Player 1", Pokemon: []string{"pikachu"}}, {Player: "Player 2"}},
+		Battles: []replayBattle{
+			{Turns: [][]replayEvent{{sentOut}, {faint}}, Winner: 1},
+			{Turns: [][]replayEvent{{sentOut}, {}, {faint}}, Winner: 1},
+		},
+	}
+
+	pauses := 0
+	if err := playReplay(replay, func() { pauses++ }); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pauses != 3 {
+		t.Errorf("Expected a pause before each of the 3 turns after the openings, got %d", pauses)
+	}
+
+	replay.Battles[0].Turns[1][0].Kind = "dance"
+	if err := playReplay(replay, func() {}); err == nil {
+		t.Error("Expected an error for an unknown event kind")
+	}
+}
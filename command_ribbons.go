@@ -0,0 +1,41 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// commandRibbons summarizes the ribbons that can be earned and which of the
+// user's Pokémon hold each one. Ribbons a Pokémon has are also shown by inspect.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters (unused)
+//
+// Returns:
+//   - Always nil
+func commandRibbons(cfg *config, params []string) error {
+	holders := make(map[string][]string, len(ribbons))
+	for _, caught := range cfg.pokedex.List() {
+		for _, id := range caught.Entry.Ribbons {
+			holders[id] = append(holders[id], FormatPokemonName(caught.Name))
+		}
+	}
+
+	earned := 0
+	table := NewTable("Ribbon", "How to earn", "Held by")
+	for _, r := range ribbons {
+		heldBy := "-"
+		if len(holders[r.id]) > 0 {
+			heldBy = strings.Join(holders[r.id], ", ")
+			earned++
+		}
+		table.AddRow(i18n.T(r.name), i18n.T(r.description), heldBy)
+	}
+
+	i18n.Printf("Ribbons earned: %d of %d\n", earned, len(ribbons))
+	table.Print()
+	printSeparator()
+	return nil
+}
@@ -0,0 +1,143 @@
+// This file implements the sandbox command, a what-if mode. Turning the
+// sandbox on takes a copy of the current state; any command can then be tried
+// (release, evolve, reset, and so on) without its changes reaching the save
+// file, since saves keep writing the copy. Turning the sandbox off goes back
+// to the copy, and committing keeps the changes and saves them.
+package main
+
+import (
+	"fmt"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// sandboxUsage describes the forms of the sandbox command.
+const sandboxUsage = "Usage: sandbox [on | off | commit]"
+
+// sandboxState is the state kept while the sandbox is on.
+type sandboxState struct {
+	before           pokedex.SaveData // The state when the sandbox was turned on
+	changesSinceSync int              // The changes that hadn't been saved when it was turned on (reset when the state is saved)
+}
+
+// commandSandbox turns the sandbox on or off, or commits its changes.
+// Supported forms:
+//   - sandbox: Show whether the sandbox is on, and the changes made in it so far
+//   - sandbox on: Take a copy of the current state to try things out on
+//   - sandbox off: Discard the changes made in the sandbox
+//   - sandbox commit: Keep the changes made in the sandbox and save them
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters as described above
+//
+// Returns:
+//   - An error if the parameters are invalid, the sandbox is already on (or
+//     off), or the state can't be copied or saved
+func commandSandbox(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		printSandboxStatus(cfg)
+	case len(params) == 1 && params[0] == "on":
+		err = startSandbox(cfg)
+	case len(params) == 1 && params[0] == "off":
+		err = discardSandbox(cfg)
+	case len(params) == 1 && params[0] == "commit":
+		err = commitSandbox(cfg)
+	default:
+		err = errorhandling.NewInvalidInputError(sandboxUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "sandbox", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// errSandboxOff is returned when the sandbox is turned off or committed while it isn't on.
+var errSandboxOff = errorhandling.NewInvalidInputError("The sandbox isn't on. Use 'sandbox on' to start one.", nil)
+
+// startSandbox turns the sandbox on, taking a copy of the current state.
+func startSandbox(cfg *config) error {
+	if cfg.sandbox != nil {
+		return errorhandling.NewInvalidInputError(
+			"The sandbox is already on. Use 'sandbox off' or 'sandbox commit' to leave it.", nil)
+	}
+	before, err := snapshotSaveData(cfg)
+	if err != nil {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not copy the current state: %v", err), err)
+	}
+	// An auto-save that fails counts its changes as unsaved again, which the sandbox must see
+	waitForBackgroundSaves(cfg)
+	cfg.mutex.RLock()
+	cfg.sandbox = &sandboxState{before: before, changesSinceSync: cfg.changesSinceSync}
+	cfg.mutex.RUnlock()
+
+	i18n.Println("Sandbox on: try anything you like. Nothing you do now will be saved.")
+	i18n.Println("Use 'sandbox off' to go back to how things were, or 'sandbox commit' to keep the changes.")
+	return nil
+}
+
+// discardSandbox turns the sandbox off, going back to the state from when it
+// was turned on.
+func discardSandbox(cfg *config) error {
+	if cfg.sandbox == nil {
+		return errSandboxOff
+	}
+	sandbox := cfg.sandbox
+	changes := describeChanges(sandbox.before, currentSaveData(cfg))
+	cfg.sandbox = nil
+	applySaveData(cfg, sandbox.before)
+	cfg.mutex.Lock()
+	cfg.changesSinceSync = sandbox.changesSinceSync
+	cfg.mutex.Unlock()
+
+	i18n.Printf("Sandbox off: discarded %d changes.\n", len(changes))
+	return nil
+}
+
+// commitSandbox turns the sandbox off, keeping the changes made in it, and saves them.
+func commitSandbox(cfg *config) error {
+	if cfg.sandbox == nil {
+		return errSandboxOff
+	}
+	changes := describeChanges(cfg.sandbox.before, currentSaveData(cfg))
+	cfg.sandbox = nil
+
+	i18n.Printf("Sandbox committed: kept %d changes.\n", len(changes))
+	printChangeList(changes)
+	if len(changes) == 0 {
+		return nil
+	}
+	return savePokedexData(cfg)
+}
+
+// printSandboxStatus shows whether the sandbox is on, and the changes made in it so far.
+func printSandboxStatus(cfg *config) {
+	if cfg.sandbox == nil {
+		i18n.Println("The sandbox is off. Use 'sandbox on' to try things out without saving them.")
+		return
+	}
+	changes := describeChanges(cfg.sandbox.before, currentSaveData(cfg))
+	if len(changes) == 0 {
+		i18n.Println("The sandbox is on. Nothing has changed in it yet.")
+		return
+	}
+	i18n.Println("The sandbox is on. These changes have been made in it:")
+	printChangeList(changes)
+}
+
+// printChangeList prints changes described by describeChanges, one per line.
+func printChangeList(changes []string) {
+	for _, change := range changes {
+		fmt.Printf("  - %s\n", change)
+	}
+}
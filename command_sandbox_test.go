@@ -0,0 +1,59 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestSandbox tests that changes made in the sandbox are discarded by
+// 'sandbox off' and kept by 'sandbox commit', and that saving while it's on
+// writes the state from before it was turned on
+func TestSandbox(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings(), saveFilePath: filepath.Join(t.TempDir(), "save.json")}
+	cfg.pokedex.Add("pikachu", pokedex.Entry{})
+
+	if err := commandSandbox(cfg, []string{"off"}); !errorhandling.IsInvalidInputError(err) {
+		t.Errorf("Expected an invalid input error turning off a sandbox that isn't on, got %v", err)
+	}
+
+	// Discard the changes
+	if err := commandSandbox(cfg, []string{"on"}); err != nil {
+		t.Fatalf("sandbox on returned an error: %v", err)
+	}
+	if err := commandSandbox(cfg, []string{"on"}); !errorhandling.IsInvalidInputError(err) {
+		t.Errorf("Expected an invalid input error turning on a sandbox that's already on, got %v", err)
+	}
+	cfg.pokedex.Remove("pikachu")
+	cfg.pokedex.Add("eevee", pokedex.Entry{})
+	cfg.AddMoney(500)
+	if err := writeSaveFile(cfg); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	saved, _, err := pokedex.ReadFile(cfg.saveFilePath)
+	if _, ok := saved.Pokedex["pikachu"]; err != nil || !ok || len(saved.Pokedex) != 1 || saved.Money != 0 {
+		t.Errorf("Expected the save file to have the state from before the sandbox, got %v (%v)", saved.Pokedex, err)
+	}
+	if err := commandSandbox(cfg, []string{"off"}); err != nil {
+		t.Fatalf("sandbox off returned an error: %v", err)
+	}
+	if !hasPokemon(cfg, "pikachu") || hasPokemon(cfg, "eevee") || cfg.Money() != 0 {
+		t.Errorf("Expected the changes to be discarded, got %v and %d money", cfg.pokedex.List(), cfg.Money())
+	}
+
+	// Keep the changes
+	if err := commandSandbox(cfg, []string{"on"}); err != nil {
+		t.Fatalf("sandbox on returned an error: %v", err)
+	}
+	cfg.pokedex.Add("eevee", pokedex.Entry{})
+	if err := commandSandbox(cfg, []string{"commit"}); err != nil {
+		t.Fatalf("sandbox commit returned an error: %v", err)
+	}
+	saved, _, err = pokedex.ReadFile(cfg.saveFilePath)
+	if _, ok := saved.Pokedex["eevee"]; err != nil || !ok || cfg.sandbox != nil {
+		t.Errorf("Expected the committed changes to be saved, got %v (%v)", saved.Pokedex, err)
+	}
+}
@@ -0,0 +1,221 @@
+// This file implements the santa command, which draws a Secret Santa trade
+// between trainers from their save files. Every trainer gives one Pokémon to
+// the next in a single random circle, so each gives and receives exactly one,
+// and the Pokémon given is always one the receiver doesn't have. Each trainer
+// gets a trade file naming only whom they give to, so nobody, not even
+// whoever runs the draw, learns who their Santa is without opening the other
+// files. The draw uses a cryptographic random source so it can't be
+// reproduced or predicted.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// santaUsage describes the forms of the santa command.
+const santaUsage = "Usage: santa <save file> <save file> <save file>... [--out <directory>]"
+
+// Limits of the Secret Santa draw.
+const (
+	santaMinTrainers = 3    // With two trainers, each would know who their Santa is
+	santaAttempts    = 1000 // How many circles are drawn before giving up on finding one where everyone can give
+)
+
+// santaTrainer is a trainer taking part in the draw.
+type santaTrainer struct {
+	name    string   // The trainer's name: their save file's name without its extension
+	pokemon []string // The Pokémon in their Pokédex, in API format
+}
+
+// santaTrade is a trainer's part in the draw, written to their trade file.
+type santaTrade struct {
+	Trainer string `json:"trainer"` // The trainer giving the Pokémon
+	GiveTo  string `json:"give_to"` // The trainer receiving it
+	Pokemon string `json:"pokemon"` // The Pokémon to give, in API format
+}
+
+// commandSanta draws a Secret Santa trade between the trainers whose save
+// files are given, and writes a trade file for each of them.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters: the save files (or snapshots), optionally
+//     followed by "--out" and the directory to write the trade files to
+//
+// Returns:
+//   - An error if the parameters are invalid, a file can't be read or written,
+//     or no draw lets everyone give a Pokémon the receiver doesn't have
+func commandSanta(cfg *config, params []string) error {
+	trades, outDir, err := drawSanta(params)
+	if err == nil {
+		err = writeSantaTrades(trades, outDir)
+	}
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "santa", err) {
+			return err
+		}
+		return nil
+	}
+
+	i18n.Printf("Drew a Secret Santa trade between %d trainers and wrote a trade file for each to %s:\n", len(trades), outDir)
+	for _, trade := range trades {
+		fmt.Printf("  %s\n", santaTradePath(outDir, trade.Trainer))
+	}
+	i18n.Println("Send each trainer only their own file: it names whom they give to, but not who gives to them.")
+	printSeparator()
+	return nil
+}
+
+// drawSanta reads the save files given to the santa command and draws the trades.
+//
+// Returns:
+//   - The trades, in the order the save files were given
+//   - The directory to write the trade files to
+//   - An error if the parameters are invalid, a file can't be read, or there's no fair draw
+func drawSanta(params []string) ([]santaTrade, string, error) {
+	outDir := "."
+	if i := slices.Index(params, "--out"); i >= 0 {
+		if i != len(params)-2 {
+			return nil, "", errorhandling.NewInvalidInputError(santaUsage, nil)
+		}
+		outDir = params[i+1]
+		params = params[:i]
+	}
+	if len(params) < santaMinTrainers {
+		return nil, "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("A Secret Santa needs at least %d trainers, so nobody can tell who their Santa is. %s", santaMinTrainers, santaUsage), nil)
+	}
+
+	var trainers []santaTrainer
+	for _, path := range params {
+		trainer, err := readSantaTrainer(path)
+		if err != nil {
+			return nil, "", err
+		}
+		if slices.ContainsFunc(trainers, func(t santaTrainer) bool { return t.name == trainer.name }) {
+			return nil, "", errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Two save files are named '%s'. Rename one so each trainer gets their own trade file", trainer.name), nil)
+		}
+		trainers = append(trainers, trainer)
+	}
+
+	trades, err := assignSanta(trainers, cryptoIntn)
+	if err != nil {
+		return nil, "", err
+	}
+	return trades, outDir, nil
+}
+
+// readSantaTrainer reads a trainer's save file.
+func readSantaTrainer(path string) (santaTrainer, error) {
+	data, found, err := pokedex.ReadFile(path)
+	if err == nil && !found {
+		err = os.ErrNotExist
+	}
+	if err != nil {
+		return santaTrainer{}, errorhandling.NewInvalidInputError(i18n.Sprintf("Could not read save file '%s'", path), err)
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	pokemon := make([]string, 0, len(data.Pokedex))
+	for pokemonName := range data.Pokedex {
+		pokemon = append(pokemon, pokemonName)
+	}
+	slices.Sort(pokemon)
+	return santaTrainer{name: name, pokemon: pokemon}, nil
+}
+
+// assignSanta draws the trades: the trainers are put in a random circle, and
+// each gives the next a random Pokémon the next doesn't have. Circles are
+// drawn until one lets every trainer give something.
+//
+// Parameters:
+//   - trainers: The trainers taking part, at least two
+//   - intn: Returns a random number from 0 up to (but not including) n
+//
+// Returns:
+//   - The trades, in the order of trainers
+//   - An error if no circle was found where every trainer can give a Pokémon
+func assignSanta(trainers []santaTrainer, intn func(n int) int) ([]santaTrade, error) {
+	order := make([]int, len(trainers))
+	for i := range order {
+		order[i] = i
+	}
+	for range santaAttempts {
+		// Shuffle the circle (Fisher-Yates)
+		for i := len(order) - 1; i > 0; i-- {
+			j := intn(i + 1)
+			order[i], order[j] = order[j], order[i]
+		}
+
+		trades := make([]santaTrade, len(trainers))
+		fair := true
+		for pos, giver := range order {
+			receiver := order[(pos+1)%len(order)]
+			var gifts []string
+			for _, name := range trainers[giver].pokemon {
+				if !slices.Contains(trainers[receiver].pokemon, name) {
+					gifts = append(gifts, name)
+				}
+			}
+			if len(gifts) == 0 {
+				fair = false
+				break
+			}
+			trades[giver] = santaTrade{
+				Trainer: trainers[giver].name,
+				GiveTo:  trainers[receiver].name,
+				Pokemon: gifts[intn(len(gifts))],
+			}
+		}
+		if fair {
+			return trades, nil
+		}
+	}
+	return nil, errorhandling.NewInvalidInputError(
+		"Couldn't draw a trade where every trainer can give a Pokémon the next one doesn't have. Catch a few more and try again", nil)
+}
+
+// writeSantaTrades writes each trainer's trade file.
+func writeSantaTrades(trades []santaTrade, outDir string) error {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Could not create directory '%s'", outDir), err)
+	}
+	for _, trade := range trades {
+		path := santaTradePath(outDir, trade.Trainer)
+		encoded, err := json.MarshalIndent(trade, "", "  ")
+		if err == nil {
+			err = os.WriteFile(path, encoded, 0644)
+		}
+		if err != nil {
+			return errorhandling.NewInternalError(i18n.Sprintf("Could not write file '%s'", path), err)
+		}
+	}
+	return nil
+}
+
+// santaTradePath returns the path of a trainer's trade file.
+func santaTradePath(outDir, trainer string) string {
+	return filepath.Join(outDir, trainer+".santa.json")
+}
+
+// cryptoIntn returns a random number from 0 up to (but not including) n,
+// from the operating system's cryptographic random source.
+func cryptoIntn(n int) int {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		panic(err) // crypto/rand doesn't fail on supported platforms
+	}
+	return int(v.Int64())
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestAssignSanta tests that every trainer gives one Pokémon the receiver
+// doesn't have, and everyone receives exactly once
+func TestAssignSanta(t *testing.T) {
+	trainers := []santaTrainer{
+		{name: "ash", pokemon: []string{"pikachu", "bulbasaur"}},
+		{name: "misty", pokemon: []string{"staryu", "pikachu"}},
+		{name: "brock", pokemon: []string{"onix", "geodude"}},
+		{name: "gary", pokemon: []string{"eevee"}},
+	}
+	rng := rand.New(rand.NewSource(1))
+	for range 50 {
+		trades, err := assignSanta(trainers, rng.Intn)
+		if err != nil {
+			t.Fatalf("assignSanta returned an error: %v", err)
+		}
+		var receivers []string
+		for i, trade := range trades {
+			if trade.Trainer != trainers[i].name || trade.GiveTo == trade.Trainer {
+				t.Fatalf("Unexpected trade %+v", trade)
+			}
+			receiver := trainers[slices.IndexFunc(trainers, func(t santaTrainer) bool { return t.name == trade.GiveTo })]
+			if !slices.Contains(trainers[i].pokemon, trade.Pokemon) || slices.Contains(receiver.pokemon, trade.Pokemon) {
+				t.Errorf("%s can't give %s to %s", trade.Trainer, trade.Pokemon, trade.GiveTo)
+			}
+			receivers = append(receivers, trade.GiveTo)
+		}
+		slices.Sort(receivers)
+		if !slices.Equal(slices.Compact(receivers), receivers) || len(receivers) != len(trainers) {
+			t.Errorf("Expected everyone to receive once, got %v", receivers)
+		}
+	}
+
+	// Nobody can give a Pokémon that everyone already has
+	same := []santaTrainer{
+		{name: "a", pokemon: []string{"pikachu"}},
+		{name: "b", pokemon: []string{"pikachu"}},
+		{name: "c", pokemon: []string{"pikachu"}},
+	}
+	if _, err := assignSanta(same, rng.Intn); err == nil {
+		t.Error("Expected an error when no trainer can give anything")
+	}
+}
+
+// TestCommandSanta tests drawing from save files and writing the trade files
+func TestCommandSanta(t *testing.T) {
+	useTempHome(t)
+	dir := t.TempDir()
+	var paths []string
+	for name, pokemon := range map[string]string{"ash": "pikachu", "misty": "staryu", "brock": "onix"} {
+		path := filepath.Join(dir, name+".json")
+		data := pokedex.SaveData{Pokedex: map[string]pokedex.Entry{pokemon: {}}, LastSaved: time.Now()}
+		if err := pokedex.WriteFile(path, data); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+
+	if err := commandSanta(cfg, paths[:2]); err == nil {
+		t.Error("Expected two trainers to be refused")
+	}
+
+	out := filepath.Join(dir, "trades")
+	if err := commandSanta(cfg, append(paths, "--out", out)); err != nil {
+		t.Fatalf("commandSanta returned an error: %v", err)
+	}
+	for _, name := range []string{"ash", "misty", "brock"} {
+		data, err := os.ReadFile(filepath.Join(out, name+".santa.json"))
+		if err != nil {
+			t.Fatalf("Expected a trade file for %s: %v", name, err)
+		}
+		var trade santaTrade
+		if err := json.Unmarshal(data, &trade); err != nil || trade.Trainer != name || trade.GiveTo == "" || trade.Pokemon == "" {
+			t.Errorf("Unexpected trade file for %s: %s", name, data)
+		}
+	}
+}
+
+// TestSantaTypedPaths tests that save files and an output directory typed at
+// the prompt are found with their capitalization intact
+func TestSantaTypedPaths(t *testing.T) {
+	useTempHome(t)
+	dir := filepath.Join(t.TempDir(), "Saves")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", dir, err)
+	}
+	line := "santa"
+	for name, pokemon := range map[string]string{"Ash": "pikachu", "Misty": "staryu", "Brock": "onix"} {
+		path := filepath.Join(dir, name+".json")
+		data := pokedex.SaveData{Pokedex: map[string]pokedex.Entry{pokemon: {}}, LastSaved: time.Now()}
+		if err := pokedex.WriteFile(path, data); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+		line += " " + path
+	}
+	out := filepath.Join(dir, "Trades")
+	line += " --out " + out
+
+	name, params := splitCommandLine(line)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := getCommands()[name].callback(cfg, params); err != nil {
+		t.Fatalf("santa returned an error: %v", err)
+	}
+	for _, trainer := range []string{"Ash", "Misty", "Brock"} {
+		if _, err := os.Stat(filepath.Join(out, trainer+".santa.json")); err != nil {
+			t.Errorf("Expected a trade file for %s: %v", trainer, err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// This file implements the savelog command, which shows the save log (see
+// savelog_utils.go).
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// defaultSaveLogCount is how many of the latest writes savelog shows unless
+// told otherwise.
+const defaultSaveLogCount = 20
+
+// commandSaveLog shows the latest writes to the save file: when each happened,
+// what triggered it, how many Pokémon were saved, and the size of the file.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters where params[0] is the optional number of writes to show
+//
+// Returns:
+//   - An error if the number is invalid or the save log can't be read
+func commandSaveLog(cfg *config, params []string) error {
+	err := showSaveLog(params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "savelog", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// showSaveLog prints the latest records of the save log.
+func showSaveLog(params []string) error {
+	count := defaultSaveLogCount
+	if len(params) > 0 {
+		n, err := strconv.Atoi(params[0])
+		if err != nil || n < 1 {
+			return errorhandling.NewInvalidInputError("Usage: savelog [number of writes to show]", err)
+		}
+		count = n
+	}
+
+	path, err := getSaveLogPath()
+	if err != nil {
+		return errorhandling.NewInternalError("Could not find the save log", err)
+	}
+	records, err := readSaveLog(path)
+	if err != nil {
+		return errorhandling.NewInternalError("Could not read the save log", err)
+	}
+	if len(records) == 0 {
+		i18n.Println("The save file hasn't been written yet.")
+		return nil
+	}
+
+	table := NewTable("Time", "Trigger", "Pokémon", "Size", "Result")
+	for _, record := range records[max(0, len(records)-count):] {
+		size, result := formatByteSize(record.Bytes), i18n.T("OK")
+		if record.Error != "" {
+			size, result = "—", i18n.Sprintf("Failed: %s", record.Error)
+		}
+		table.AddRow(record.Time.Local().Format("2006-01-02 15:04:05"), i18n.T(record.Trigger),
+			fmt.Sprint(record.Entries), size, result)
+	}
+	i18n.Printf("The latest %d of %d writes to the save file, oldest first:\n", table.Len(), len(records))
+	table.Print()
+	i18n.Printf("The full log is in %s.\n", path)
+	return nil
+}
@@ -0,0 +1,91 @@
+// This file implements the schedule command, which suggests running a command
+// every hour, day, or week, before the next prompt once its time comes (see
+// reminder_utils.go).
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// scheduleUsage describes the forms of the schedule command.
+const scheduleUsage = "Usage: schedule, schedule hourly|daily|weekly <command> (e.g. schedule daily challenge), or schedule cancel <number>"
+
+// commandSchedule implements the "schedule" command.
+// Supported forms:
+//   - schedule: List the scheduled commands
+//   - schedule daily <command>: Suggest running a command every day, starting a
+//     day from now (or hourly, or weekly)
+//   - schedule cancel <number>: Stop suggesting a command, by its number in the list
+//
+// Parameters:
+//   - cfg: The application configuration containing the scheduled commands
+//   - params: Command parameters where params[0] is the period or "cancel"
+//
+// Returns:
+//   - An error if the parameters are invalid or the schedule can't be saved
+func commandSchedule(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		listScheduled(cfg, time.Now())
+	case params[0] == "cancel" && len(params) == 2:
+		var cancelled pokedex.Reminder
+		if cancelled, err = cancelReminder(cfg, true, params[1]); err == nil {
+			i18n.Printf("'%s' is no longer scheduled %s.\n", cancelled.Command, i18n.T(cancelled.Every))
+		}
+	case len(params) >= 2 && reminderPeriods[params[0]] > 0:
+		err = scheduleCommand(cfg, params[0], strings.Join(params[1:], " "), time.Now())
+	default:
+		err = errorhandling.NewInvalidInputError(scheduleUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "schedule", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// listScheduled shows the scheduled commands, soonest first.
+func listScheduled(cfg *config, now time.Time) {
+	scheduled := remindersOfKind(cfg, true)
+	if len(scheduled) == 0 {
+		i18n.Println("You have no scheduled commands. Schedule one with 'schedule daily challenge'.")
+		return
+	}
+	i18n.Println("Scheduled commands:")
+	for i, reminder := range scheduled {
+		i18n.Printf("%d. '%s' %s, next %s\n", i+1, reminder.Command, i18n.T(reminder.Every), formatReminderDue(reminder.Due, now))
+	}
+}
+
+// scheduleCommand schedules a command and saves the schedule. The command
+// first comes up one period from now.
+//
+// Parameters:
+//   - cfg: The application configuration containing the scheduled commands
+//   - every: How often the command comes up, a name in reminderPeriods
+//   - command: The command and its parameters
+//   - now: The current time
+//
+// Returns:
+//   - An error if there's no such command or the schedule can't be saved
+func scheduleCommand(cfg *config, every, command string, now time.Time) error {
+	name := strings.Fields(command)[0]
+	if _, exists := getCommands()[name]; !exists {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Unknown command: %s", name), nil)
+	}
+	reminder := pokedex.Reminder{Command: command, Every: every, Due: now.Add(reminderPeriods[every])}
+	cfg.AddReminder(reminder)
+	i18n.Printf("Scheduled '%s' %s, starting %s.\n", command, i18n.T(every), formatReminderDue(reminder.Due, now))
+	return savePokedexData(cfg)
+}
@@ -0,0 +1,177 @@
+// This file implements the search command, which finds Pokémon by the start of
+// their name (or of any word in it), their type, and the generation they were
+// introduced in, using the search index (see search_index.go).
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/search"
+)
+
+// searchUsage describes the forms of the search command.
+const searchUsage = "Usage: search [<name>] [--type <type>] [--gen <generation>]"
+
+// maxSearchResults is the number of matches listed before the rest are summarized.
+const maxSearchResults = 50
+
+// searchMatch is a Pokémon found by the search command.
+type searchMatch struct {
+	Name   string `json:"name"`   // The Pokémon's API name
+	Caught bool   `json:"caught"` // Whether the Pokémon is in the Pokédex
+	Seen   bool   `json:"seen"`   // Whether the Pokémon has been caught or seen
+}
+
+// searchResults is what the search command found.
+type searchResults struct {
+	Query   search.Query  `json:"query"`   // What was searched for
+	Matches []searchMatch `json:"matches"` // Every matching Pokémon, in alphabetical order
+}
+
+// searchResult finds the Pokémon matching a name, type, and generation. At
+// least one of them must be given.
+//
+// Usage:
+//   - search mime: Find Pokémon with a word in their name starting with "mime"
+//   - search --type dragon --gen 3: Find the Dragon-type Pokémon of Generation III
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters: words of a name, optionally with --type <type>
+//     and --gen <generation>
+//
+// Returns:
+//   - The matching Pokémon
+//   - An error if the parameters are invalid or the index can't be built
+func searchResult(cfg *config, params []string) (commandResult, error) {
+	query, err := parseSearchParams(params)
+	if err != nil {
+		return commandResult{}, err
+	}
+	idx, err := getSearchIndex(cfg)
+	if err != nil {
+		return commandResult{}, err
+	}
+	names, err := searchPokemon(cfg, idx, query)
+	if err != nil {
+		return commandResult{}, err
+	}
+
+	results := searchResults{Query: query, Matches: make([]searchMatch, len(names))}
+	for i, name := range names {
+		caught := hasPokemon(cfg, name)
+		results.Matches[i] = searchMatch{Name: name, Caught: caught, Seen: caught || cfg.pokedex.HasSeen(name)}
+	}
+
+	var message strings.Builder
+	if len(names) == 0 {
+		message.WriteString(i18n.T("No Pokémon match your search.") + "\n")
+		return commandResult{Message: message.String(), Data: results}, nil
+	}
+	message.WriteString(i18n.Sprintf("Found %d Pokémon:\n", len(names)))
+	table := NewTable("#", "No.", "Pokémon", "Types", "Status")
+	listed := names[:min(len(names), maxSearchResults)]
+	for i, match := range results.Matches[:len(listed)] {
+		// Types and numbers come from the dataset, so that listing the matches doesn't need the API
+		number, types := "", ""
+		if species, ok := cfg.Dataset().Lookup(match.Name); ok {
+			number, types = strconv.Itoa(species.ID), FormatTypeList(species.Types)
+		}
+		table.AddRow(strconv.Itoa(i+1), number, FormatPokemonName(match.Name), types, caughtStatus(match.Caught, match.Seen))
+	}
+	table.Render(&message)
+	// Remember the numbers, so '#2' can choose a Pokémon from the list
+	cfg.SetSelection(selectPokemon, "search", listed)
+	if len(names) > maxSearchResults {
+		message.WriteString(i18n.Sprintf("...and %d more.\n", len(names)-maxSearchResults))
+	}
+	return commandResult{Message: message.String(), Data: results}, nil
+}
+
+// parseSearchParams parses the parameters of the search command.
+//
+// Parameters:
+//   - params: The command parameters
+//
+// Returns:
+//   - The query to search for
+//   - An error if an option or its value is invalid, or nothing is searched for
+func parseSearchParams(params []string) (search.Query, error) {
+	var query search.Query
+	usageErr := errorhandling.NewInvalidInputError(searchUsage, nil)
+	var words []string
+	for i := 0; i < len(params); i++ {
+		switch params[i] {
+		case "--type", "--gen":
+			if i+1 >= len(params) {
+				return query, usageErr
+			}
+			value := params[i+1]
+			if params[i] == "--type" {
+				value = strings.ToLower(value)
+				if !slices.Contains(standardTypes, value) {
+					return query, errorhandling.NewInvalidInputError(i18n.Sprintf("Unknown type '%s'", value), nil)
+				}
+				query.Type = value
+			} else {
+				generation, err := parseGeneration(value)
+				if err != nil {
+					return query, err
+				}
+				query.Generation = generation
+			}
+			i++
+		default:
+			if strings.HasPrefix(params[i], "--") {
+				return query, usageErr
+			}
+			words = append(words, params[i])
+		}
+	}
+
+	query.Prefix = ConvertToAPIFormat(strings.Join(words, " "))
+	if query == (search.Query{}) {
+		return query, usageErr
+	}
+	return query, nil
+}
+
+// searchPokemon runs a query on the search index. An index built without
+// types (when the complete dataset hasn't been downloaded) can't filter by
+// type, so the Pokémon of the type are then fetched from the API instead.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - idx: The search index
+//   - query: What to search for
+//
+// Returns:
+//   - The API names of the matching Pokémon, in alphabetical order
+//   - An error if the type's Pokémon can't be fetched
+func searchPokemon(cfg *config, idx *search.Index, query search.Query) ([]string, error) {
+	if query.Type == "" || idx.HasTypes {
+		return idx.Search(query), nil
+	}
+
+	typeData, err := cfg.pokeapiClient.GetType(query.Type)
+	if err != nil {
+		return nil, err
+	}
+	typed := make(map[string]bool, len(typeData.Pokemon))
+	for _, p := range typeData.Pokemon {
+		typed[p.Pokemon.Name] = true
+	}
+	untyped := query
+	untyped.Type = ""
+	var names []string
+	for _, name := range idx.Search(untyped) {
+		if typed[name] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/dataset"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+	"github.com/bmlevitt/pokedexcli/internal/search"
+)
+
+// TestParseSearchParams tests parsing the search command's name and filters
+func TestParseSearchParams(t *testing.T) {
+	query, err := parseSearchParams([]string{"Mr.", "Mime", "--type", "Psychic", "--gen", "gen1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query != (search.Query{Prefix: "mr-mime", Type: "psychic", Generation: 1}) {
+		t.Errorf("Unexpected query: %+v", query)
+	}
+
+	for _, params := range [][]string{{}, {"--type"}, {"--type", "sound"}, {"--gen", "zero"}, {"pika", "--box", "team"}} {
+		if _, err := parseSearchParams(params); err == nil {
+			t.Errorf("parseSearchParams(%v): expected an error", params)
+		}
+	}
+}
+
+// TestSearchIndexSaved tests that the search index is built from a complete
+// dataset without the network, saved, used by later sessions, and rebuilt
+// when the dataset changes
+func TestSearchIndexSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search-index.json")
+	d := dataset.New([]dataset.Species{
+		{ID: 25, Name: "pikachu", Types: []string{"electric"}},
+		{ID: 122, Name: "mr-mime", Types: []string{"psychic", "fairy"}},
+	}, true)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings(), dataset: d, searchIndexPath: path}
+
+	result, err := searchResult(cfg, []string{"mime", "--type", "fairy"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	matches := result.Data.(searchResults).Matches
+	if len(matches) != 1 || matches[0].Name != "mr-mime" {
+		t.Errorf("Expected to find Mr. Mime, got %+v", matches)
+	}
+	saved, err := search.Load(path)
+	if err != nil || saved == nil {
+		t.Fatalf("Expected the index to be saved, got %v", err)
+	}
+
+	// A later session with the same dataset uses the saved index
+	later := &config{pokedex: pokedex.New(), settings: defaultSettings(), dataset: d, searchIndexPath: path}
+	idx, err := getSearchIndex(later)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !idx.Built.Equal(saved.Built) {
+		t.Error("Expected the saved index to be used")
+	}
+
+	// A new dataset replaces it, along with the name index built from it
+	later.setDataset(dataset.New([]dataset.Species{{ID: 26, Name: "raichu", Types: []string{"electric"}}}, true))
+	names, err := getNameIndex(later)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !names.Contains("raichu") || names.Contains("pikachu") {
+		t.Errorf("Expected the name index to be rebuilt from the new dataset, got %v", names.names)
+	}
+	if saved, _ := search.Load(path); saved == nil || !slices.Equal(saved.Names, []string{"raichu"}) {
+		t.Error("Expected the rebuilt index to be saved")
+	}
+}
+
+// TestSearchSelection tests that the matches can be referred to as #N,
+// numbered by their place in the list rather than their national dex number
+func TestSearchSelection(t *testing.T) {
+	d := dataset.New([]dataset.Species{
+		{ID: 122, Name: "mr-mime", Types: []string{"psychic", "fairy"}},
+		{ID: 439, Name: "mime-jr", Types: []string{"psychic", "fairy"}},
+		{ID: 866, Name: "mr-rime", Types: []string{"ice", "psychic"}},
+	}, true)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings(), dataset: d, searchIndexPath: filepath.Join(t.TempDir(), "search-index.json")}
+
+	if _, err := searchResult(cfg, []string{"mime"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := resolveSelections(cfg, []string{"#1"})
+	if want := []string{"mime-jr"}; err != nil || !slices.Equal(got, want) {
+		t.Errorf("resolveSelections(#1) = %v, %v, want %v", got, err, want)
+	}
+	if _, err := resolveSelections(cfg, []string{"#3"}); err == nil {
+		t.Error("Expected an error for #3, past the two matches")
+	}
+}
@@ -0,0 +1,118 @@
+// This file implements the seen command for the Pokédex CLI application.
+// It lists the Pokémon the user has seen, with when and where each one was
+// first spotted, like the "seen" entries of the Pokédex in the games.
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// commandSeen lists the Pokémon the user has seen, in the order they were
+// first seen, with the location each was first spotted in and whether the
+// user has caught one.
+// Supported forms:
+//   - seen: List every Pokémon seen
+//   - seen --at <location>: List the Pokémon first spotted in a location
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters, optionally "--at" followed by a location name
+//
+// Returns:
+//   - An error if the parameters are invalid
+func commandSeen(cfg *config, params []string) error {
+	location := ""
+	if len(params) > 0 {
+		if params[0] != "--at" || len(params) == 1 {
+			err := errorhandling.NewInvalidInputError("Usage: seen [--at <location>]", nil)
+			if HandleCommandError(cfg, "seen", err) {
+				return err
+			}
+			return nil
+		}
+		location = ConvertToAPIFormat(strings.Join(params[1:], " "))
+	}
+
+	sightings := sortedSightings(cfg.pokedex.Seen())
+	caught := cfg.pokedex.All()
+
+	table := NewTable("#", "Pokémon", "First seen", "Where", "Caught")
+	var listed []string
+	for _, s := range sightings {
+		if location != "" && !locationMatches(s.Location, location) {
+			continue
+		}
+		listed = append(listed, s.name)
+		firstSeen := ""
+		if !s.SeenOn.IsZero() {
+			firstSeen = s.SeenOn.Local().Format("2006-01-02")
+		}
+		where := ""
+		if s.Location != "" {
+			where = FormatLocationName(s.Location)
+		}
+		status := i18n.T("no")
+		if _, ok := caught[s.name]; ok {
+			status = i18n.T("yes")
+		}
+		table.AddRow(fmt.Sprint(table.Len()+1), FormatPokemonName(s.name), firstSeen, where, status)
+	}
+
+	switch {
+	case table.Len() == 0 && location != "":
+		i18n.Printf("You haven't spotted any Pokémon in %s yet.\n", FormatLocationName(location))
+	case table.Len() == 0:
+		i18n.Println("You haven't seen any Pokémon yet. Use 'explore' to look around.")
+	default:
+		if location != "" {
+			i18n.Printf("Pokémon first spotted in %s:\n", FormatLocationName(location))
+		} else {
+			i18n.Printf("You have seen %d Pokémon:\n", table.Len())
+		}
+		table.Print()
+		cfg.SetSelection(selectPokemon, "seen", listed)
+	}
+	printSeparator()
+	return nil
+}
+
+// namedSighting pairs a sighting with the name of the Pokémon that was seen.
+type namedSighting struct {
+	pokedex.Sighting
+	name string // The Pokémon's name in API format
+}
+
+// sortedSightings returns the sightings in the order the Pokémon were first
+// seen, with sightings of unknown date last and ties broken by name.
+func sortedSightings(seen map[string]pokedex.Sighting) []namedSighting {
+	sightings := make([]namedSighting, 0, len(seen))
+	for name, s := range seen {
+		sightings = append(sightings, namedSighting{Sighting: s, name: name})
+	}
+	slices.SortFunc(sightings, func(a, b namedSighting) int {
+		switch {
+		case a.SeenOn.IsZero() != b.SeenOn.IsZero():
+			if a.SeenOn.IsZero() {
+				return 1
+			}
+			return -1
+		case !a.SeenOn.Equal(b.SeenOn):
+			return a.SeenOn.Compare(b.SeenOn)
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	return sightings
+}
+
+// locationMatches reports whether a location area matches a location typed by
+// the user, either exactly or as the start of its name, so "viridian-forest"
+// matches "viridian-forest-area".
+func locationMatches(area, location string) bool {
+	return area == location || strings.HasPrefix(area, location+"-")
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestSortedSightings tests that sightings are listed in the order they were
+// first seen, with undated sightings last
+func TestSortedSightings(t *testing.T) {
+	day := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	sightings := sortedSightings(map[string]pokedex.Sighting{
+		"rattata":  {SeenOn: day.Add(time.Hour), Location: "route-1-area"},
+		"pidgey":   {SeenOn: day.Add(time.Hour), Location: "route-1-area"},
+		"caterpie": {SeenOn: day, Location: "viridian-forest-area"},
+		"mew":      {},
+	})
+
+	expected := []string{"caterpie", "pidgey", "rattata", "mew"}
+	for i, name := range expected {
+		if sightings[i].name != name {
+			t.Errorf("Position %d: expected %s, got %s", i, name, sightings[i].name)
+		}
+	}
+}
+
+// TestLocationMatches tests that locations match exactly or as the start of an area name
+func TestLocationMatches(t *testing.T) {
+	if !locationMatches("viridian-forest-area", "viridian-forest") || !locationMatches("route-1", "route-1") {
+		t.Error("Expected the location to match")
+	}
+	if locationMatches("route-10-area", "route-1") || locationMatches("", "route-1") {
+		t.Error("Expected the location not to match")
+	}
+}
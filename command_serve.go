@@ -0,0 +1,104 @@
+// This file implements the serve command, which runs the app in serve mode:
+// a gRPC service that lets other programs catch, release, list, and explore.
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/rpc"
+)
+
+// serveUsage describes the parameters of the serve command.
+const serveUsage = "Usage: serve [--port <n>]"
+
+// defaultServePort is the port the gRPC service listens on unless another is
+// chosen. It's the port gRPC examples conventionally use.
+const defaultServePort = 50051
+
+// serveShutdownTimeout is how long calls in progress are given to finish when
+// serve mode ends.
+const serveShutdownTimeout = 5 * time.Second
+
+// commandServe runs the Pokédex gRPC service until it's interrupted with
+// Ctrl+C or terminated. The service is defined in
+// proto/pokedexcli/v1/pokedex.proto and is only served on this computer
+// (127.0.0.1), without TLS. Changes made through it are saved like changes
+// made by commands, including when serve mode ends. Calls can be made for
+// other trainers, who each get a Pokédex and save file of their own (see
+// trainer_stores.go).
+// Supported forms:
+//   - serve: Serve on the default port (50051)
+//   - serve --port 9000: Serve on another port
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters: nothing, or --port and a port number
+//
+// Returns:
+//   - An error if the parameters are invalid or the port can't be used
+func commandServe(cfg *config, params []string) error {
+	port, err := parsePortParams(params, defaultServePort, serveUsage)
+	if err == nil {
+		err = serve(cfg, port)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "serve", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// serve runs the gRPC service on a port until the command is cancelled with
+// Ctrl+C or the process is terminated.
+func serve(cfg *config, port int) error {
+	addr := net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Could not serve on port %d", port), err)
+	}
+	dir, err := trainerSaveDir(cfg)
+	if err != nil {
+		return errorhandling.NewInternalError("Could not find the trainers' save folder", err)
+	}
+	server := &http.Server{
+		Handler:           newPokedexServer(newTrainerStores(cfg, dir)),
+		Protocols:         rpc.Protocols(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(commandContext(cfg), syscall.SIGTERM)
+	defer stop()
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(listener) }()
+
+	i18n.Printf("Serving the %s gRPC service at %s\n", pokedexService, addr)
+	i18n.Println("Press Ctrl+C to stop.")
+
+	select {
+	case err = <-served:
+		return errorhandling.NewInternalError("The gRPC service stopped unexpectedly", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errorhandling.NewInternalError("Could not stop the gRPC service cleanly", err)
+	}
+	i18n.Println("\nStopped serving.")
+	return nil
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+	"github.com/bmlevitt/pokedexcli/internal/rpc"
+)
+
+// TestPokedexServer tests the gRPC service's List and Release methods, and
+// that invalid requests are refused with the right status codes
+func TestPokedexServer(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric")))
+	squirtle := pokedex.NewEntry(testMatchupPokemon(t, "squirtle", 314, "water"))
+	squirtle.Box = "team"
+	cfg.pokedex.Add("squirtle", squirtle)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Could not listen: %v", err)
+	}
+	server := &http.Server{Handler: newPokedexServer(newTrainerStores(cfg, t.TempDir())), Protocols: rpc.Protocols()}
+	go server.Serve(listener)
+	defer server.Close()
+	client := rpc.NewClient(listener.Addr().String(), pokedexService)
+	ctx := context.Background()
+
+	response, err := client.Invoke(ctx, "List", nil)
+	if err != nil {
+		t.Fatalf("List returned an error: %v", err)
+	}
+	listed, _ := rpc.Fields(response)
+	if len(listed) != 2 {
+		t.Fatalf("Expected 2 Pokémon, got %d", len(listed))
+	}
+	fields, _ := rpc.Fields(listed[1].Bytes())
+	if fields[0].String() != "squirtle" || fields[1].String() != "water" || fields[3].String() != "team" {
+		t.Errorf("Unexpected Pokemon message for Squirtle: %+v", fields)
+	}
+
+	if _, err := client.Invoke(ctx, "Release", rpc.AppendString(nil, 1, "Pikachu")); err != nil {
+		t.Fatalf("Release returned an error: %v", err)
+	}
+	if _, ok := cfg.pokedex.Get("pikachu"); ok || !cfg.pokedex.HasSeen("pikachu") {
+		t.Error("Expected Pikachu to be released and stay seen")
+	}
+
+	cases := []struct {
+		name    string
+		method  string
+		request []byte
+		code    rpc.Code
+	}{
+		{"release a Pokémon that isn't caught", "Release", rpc.AppendString(nil, 1, "pikachu"), rpc.NotFound},
+		{"catch without a name", "Catch", nil, rpc.InvalidArgument},
+		{"catch with an unknown ball", "Catch", rpc.AppendString(rpc.AppendString(nil, 1, "pikachu"), 2, "net-ball"), rpc.InvalidArgument},
+		{"explore without a location", "Explore", nil, rpc.InvalidArgument},
+		{"malformed request", "List", []byte{0x0a, 0x05}, rpc.InvalidArgument},
+	}
+	for _, c := range cases {
+		_, err := client.Invoke(ctx, c.method, c.request)
+		var status *rpc.Error
+		if !errors.As(err, &status) || status.Code != c.code {
+			t.Errorf("%s: expected code %d, got %v", c.name, c.code, err)
+		}
+	}
+}
+
+// TestPokedexServerTrainers tests that calls naming a trainer use that
+// trainer's own Pokédex and save file, leaving the user's and other trainers'
+// Pokédexes alone
+func TestPokedexServerTrainers(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric")))
+	dir := t.TempDir()
+	mistyFile := filepath.Join(dir, "misty.json")
+	starmie := pokedex.NewEntry(testMatchupPokemon(t, "starmie", 520, "water", "psychic"))
+	if err := pokedex.WriteFile(mistyFile, pokedex.SaveData{Pokedex: map[string]pokedex.Entry{"starmie": starmie}}); err != nil {
+		t.Fatalf("Could not write Misty's save file: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Could not listen: %v", err)
+	}
+	server := &http.Server{Handler: newPokedexServer(newTrainerStores(cfg, dir)), Protocols: rpc.Protocols()}
+	go server.Serve(listener)
+	defer server.Close()
+	client := rpc.NewClient(listener.Addr().String(), pokedexService)
+	ctx := context.Background()
+	misty := rpc.WithMetadata(ctx, trainerMetadata, "misty")
+
+	listNames := func(ctx context.Context) []string {
+		t.Helper()
+		response, err := client.Invoke(ctx, "List", nil)
+		if err != nil {
+			t.Fatalf("List returned an error: %v", err)
+		}
+		listed, _ := rpc.Fields(response)
+		var names []string
+		for _, field := range listed {
+			fields, _ := rpc.Fields(field.Bytes())
+			names = append(names, fields[0].String())
+		}
+		return names
+	}
+	if names := listNames(misty); !slices.Equal(names, []string{"starmie"}) {
+		t.Errorf("Expected Misty's Pokédex to hold Starmie, got %v", names)
+	}
+	if names := listNames(rpc.WithMetadata(ctx, trainerMetadata, "brock")); len(names) != 0 {
+		t.Errorf("Expected a new trainer to start with an empty Pokédex, got %v", names)
+	}
+
+	if _, err := client.Invoke(misty, "Release", rpc.AppendString(nil, 1, "starmie")); err != nil {
+		t.Fatalf("Release returned an error: %v", err)
+	}
+	saved, _, err := pokedex.ReadFile(mistyFile)
+	if err != nil || len(saved.Pokedex) != 0 {
+		t.Errorf("Expected the release to be saved to Misty's save file, got %v, %v", saved.Pokedex, err)
+	}
+	if names := listNames(ctx); !slices.Equal(names, []string{"pikachu"}) {
+		t.Errorf("Expected the user's Pokédex to be unchanged, got %v", names)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "brock.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected no save file for a trainer without changes, got %v", err)
+	}
+
+	_, err = client.Invoke(rpc.WithMetadata(ctx, trainerMetadata, "../misty"), "List", nil)
+	var status *rpc.Error
+	if !errors.As(err, &status) || status.Code != rpc.InvalidArgument {
+		t.Errorf("Expected an invalid trainer ID to be refused, got %v", err)
+	}
+}
+
+// TestTrainerStoresLanguage tests that loading a trainer's save file leaves
+// the interface language alone, even when the trainer saved in another one
+func TestTrainerStoresLanguage(t *testing.T) {
+	useTempHome(t)
+	defer i18n.SetLanguage(i18n.Default)
+	dir := t.TempDir()
+	saveData := pokedex.SaveData{Pokedex: map[string]pokedex.Entry{}, Language: "es"}
+	if err := pokedex.WriteFile(filepath.Join(dir, "misty.json"), saveData); err != nil {
+		t.Fatalf("Could not write Misty's save file: %v", err)
+	}
+
+	stores := newTrainerStores(&config{pokedex: pokedex.New(), settings: defaultSettings()}, dir)
+	if _, err := stores.get("misty"); err != nil {
+		t.Fatalf("get returned an error: %v", err)
+	}
+	if language := i18n.Current(); language != i18n.Default {
+		t.Errorf("Expected the interface language to stay %q, got %q", i18n.Default, language)
+	}
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// shopUsage describes the subcommands of the shop command.
+const shopUsage = "Usage: shop, shop buy <item> [quantity], or shop bag"
+
+// commandShop implements the "shop" command, the Poké Mart where money earned
+// in battles is spent on Poké Balls and other items.
+// Supported forms:
+//   - shop: List the items for sale with their prices
+//   - shop buy <item> [quantity]: Buy one or more of an item
+//   - shop bag: List the items in the user's bag
+//
+// Prices are the item costs from the PokeAPI. Purchases and the remaining
+// balance are saved with the Pokédex.
+//
+// Parameters:
+//   - cfg: The application configuration containing the balance, bag, and API client
+//   - params: Command parameters where params[0] is the optional subcommand
+//
+// Returns:
+//   - An error if the subcommand or its parameters are invalid
+func commandShop(cfg *config, params []string) error {
+	var err error
+	if len(params) == 0 {
+		err = listShopStock(cfg)
+	} else {
+		switch params[0] {
+		case "buy":
+			err = buyItem(cfg, params[1:])
+		case "bag":
+			listBag(cfg)
+		default:
+			err = errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Unknown shop command '%s'. %s", params[0], i18n.T(shopUsage)), nil)
+		}
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "shop", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// listShopStock shows the items for sale, their prices, and how many the user has.
+func listShopStock(cfg *config) error {
+	bag := cfg.Items()
+	table := NewTable("Item", "Price", "In bag", "Effect")
+	for _, name := range shopStock {
+		item, err := cfg.pokeapiClient.GetItem(name)
+		if err != nil {
+			return err
+		}
+		table.AddRow(FormatItemName(name), fmt.Sprintf("₽%d", item.Cost), fmt.Sprint(bag[name]), item.EnglishEffect())
+	}
+
+	i18n.Printf("Welcome to the Poké Mart! You have ₽%d.\n", cfg.Money())
+	table.Print()
+	i18n.Println("Use 'shop buy <item> [quantity]' to buy an item.")
+	printSeparator()
+	return nil
+}
+
+// buyItem buys one or more of an item. The item name can have several words
+// (e.g. "great ball"), and a number at the end is the quantity.
+func buyItem(cfg *config, params []string) error {
+	if len(params) == 0 {
+		return errorhandling.NewInvalidInputError(shopUsage, nil)
+	}
+
+	quantity := 1
+	if n, err := strconv.Atoi(params[len(params)-1]); err == nil {
+		if n < 1 {
+			return errorhandling.NewInvalidInputError("The quantity must be at least 1", nil)
+		}
+		quantity = n
+		params = params[:len(params)-1]
+	}
+
+	name := ConvertToAPIFormat(strings.Join(params, " "))
+	if !slices.Contains(shopStock, name) {
+		stock := make([]string, 0, len(shopStock))
+		for _, item := range shopStock {
+			stock = append(stock, FormatItemName(item))
+		}
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The shop doesn't sell '%s'. Items for sale: %s", strings.Join(params, " "), strings.Join(stock, ", ")), nil)
+	}
+
+	item, err := cfg.pokeapiClient.GetItem(name)
+	if err != nil {
+		return err
+	}
+
+	balance, err := cfg.BuyItem(name, quantity, item.Cost)
+	if errors.Is(err, ErrNotEnoughMoney) {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("You need ₽%d for that, but you only have ₽%d. Win battles with 'fight trainer' to earn more.",
+				quantity*item.Cost, balance), err)
+	}
+
+	i18n.Printf("You bought %s x%d for ₽%d. You have ₽%d left.\n", FormatItemName(name), quantity, quantity*item.Cost, balance)
+
+	// Auto-save the purchase
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		// Use standardized error handling but don't return the error
+		// since the purchase still happened
+		HandleCommandError(cfg, "shop", err)
+	}
+	printSeparator()
+	return nil
+}
+
+// listBag shows the items in the user's bag and the user's balance.
+func listBag(cfg *config) {
+	bag := cfg.Items()
+	i18n.Printf("You have ₽%d.\n", cfg.Money())
+	if lure, ok := cfg.ActiveLure(); ok {
+		i18n.Printf("Lure in use: %s\n", formatActiveLure(lure))
+	}
+	if len(bag) == 0 {
+		i18n.Println("Your bag is empty.")
+		printSeparator()
+		return
+	}
+
+	table := NewTable("Item", "Quantity")
+	for _, name := range slices.Sorted(maps.Keys(bag)) {
+		table.AddRow(FormatItemName(name), fmt.Sprint(bag[name]))
+	}
+	table.Print()
+	printSeparator()
+}
@@ -3,12 +3,14 @@ package main
 import (
 	"fmt"
 	"math/rand"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 )
 
 // commandShowOff displays a Pokémon from the user's Pokédex performing a random move.
 // This command simulates a Pokémon using one of its moves for display purposes.
-// It randomly selects a move from the Pokémon's known moves and presents it in
-// a formatted message.
+// It randomly selects a move from the Pokémon's active moveset (or from all the
+// moves it can learn, if it hasn't been taught any) and presents it in a formatted message.
 //
 // The command can only be used with Pokémon that are currently in the user's Pokédex
 // and that know at least one move.
@@ -42,7 +44,8 @@ func commandShowOff(cfg *config, params []string) error {
 	}
 
 	// Check if the pokemon has any moves
-	if len(pokemon.Moves) == 0 {
+	moves := pokemon.ActiveMoves(cfg.Settings().versionGroup)
+	if len(moves) == 0 {
 		err := fmt.Errorf("%s doesn't know any moves", nameInfo.Formatted)
 		if HandleCommandError(cfg, "showoff", err) {
 			return err
@@ -50,16 +53,18 @@ func commandShowOff(cfg *config, params []string) error {
 		return nil
 	}
 
-	// Select a random move
-	randomIndex := rand.Intn(len(pokemon.Moves))
-	moveName := pokemon.Moves[randomIndex].Move.Name
+	// Select a random move, or the first one in accessible mode so the output is always the same
+	moveName := moves[0]
+	if !cfg.Settings().accessible {
+		moveName = moves[rand.Intn(len(moves))]
+	}
 
 	// Format the move name for better display
 	formattedMove := FormatMoveName(moveName)
 
 	// Show off the pokemon using the move
-	fmt.Printf("%s used %s!\n", nameInfo.Formatted, formattedMove)
-	fmt.Println("-----")
+	i18n.Printf("%s used %s!\n", nameInfo.Formatted, formattedMove)
+	printSeparator()
 
 	return nil
 }
@@ -0,0 +1,200 @@
+// This file implements snapshots for the Pokédex CLI application. A snapshot is a
+// named copy of the complete save state, like a save slot in a game, kept in its own
+// file next to the main save file so that progress can be restored later.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// snapshotUsage describes the forms of the snapshot command.
+const snapshotUsage = "Usage: snapshot create <name>, snapshot load <name>, or snapshot list"
+
+// getSnapshotDir returns the directory snapshots are stored in. Like the save
+// file, it's in the data directory.
+//
+// Returns:
+//   - The path to the snapshot directory
+//   - An error if there was a problem determining the path
+func getSnapshotDir() (string, error) {
+	return snapshotDir.path(), nil
+}
+
+// commandSnapshot captures and restores named snapshots of the complete save state,
+// including the Pokédex, boxes, sightings, money, items, and settings. The command
+// supports several subcommands:
+//   - snapshot create <name>: Save the current state as a snapshot
+//   - snapshot load <name>: Replace the current state with a snapshot
+//   - snapshot list: List the snapshots, newest first
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters where params[0] is the subcommand
+//
+// Returns:
+//   - An error if the subcommand or its parameters are invalid, or a snapshot can't be written or read
+func commandSnapshot(cfg *config, params []string) error {
+	var err error
+	if len(params) == 0 {
+		err = errorhandling.NewInvalidInputError(snapshotUsage, nil)
+	} else {
+		switch params[0] {
+		case "create":
+			err = createSnapshot(cfg, params[1:])
+		case "load":
+			err = loadSnapshot(cfg, params[1:])
+		case "list":
+			err = listSnapshots()
+		default:
+			err = errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Unknown snapshot command '%s'. %s", params[0], snapshotUsage), nil)
+		}
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "snapshot", err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseSnapshotName validates a snapshot name parameter and normalizes it.
+// Snapshot names are lowercase words separated by hyphens (e.g. "before-elite-four").
+//
+// Parameters:
+//   - params: The parameters forming the snapshot name
+//
+// Returns:
+//   - The normalized snapshot name
+//   - An error if no valid name was provided
+func parseSnapshotName(params []string) (string, error) {
+	name := ConvertToAPIFormat(strings.Join(params, " "))
+	if name == "" {
+		return "", errorhandling.NewInvalidInputError("No snapshot name provided", nil)
+	}
+	return name, nil
+}
+
+// snapshotError turns an invalid snapshot name into an input error and wraps
+// any other error from reading or writing snapshots.
+func snapshotError(name string, err error) error {
+	if errors.Is(err, pokedex.ErrInvalidSnapshotName) {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("'%s' can't be used as a snapshot name", name), err)
+	}
+	return fmt.Errorf(i18n.T("error accessing snapshot '%s': %w"), name, err)
+}
+
+// findSnapshot looks up the metadata of a snapshot by name.
+func findSnapshot(dir, name string) (pokedex.SnapshotInfo, bool, error) {
+	snapshots, err := pokedex.ListSnapshots(dir)
+	if err != nil {
+		return pokedex.SnapshotInfo{}, false, err
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Name == name {
+			return snapshot, true, nil
+		}
+	}
+	return pokedex.SnapshotInfo{}, false, nil
+}
+
+// createSnapshot saves the current state as a named snapshot, asking before
+// replacing an existing snapshot with the same name.
+func createSnapshot(cfg *config, params []string) error {
+	name, err := parseSnapshotName(params)
+	if err != nil {
+		return err
+	}
+	dir, err := getSnapshotDir()
+	if err != nil {
+		return fmt.Errorf("error determining snapshot directory: %w", err)
+	}
+
+	existing, exists, err := findSnapshot(dir, name)
+	if err != nil {
+		return snapshotError(name, err)
+	}
+	if exists {
+		question := i18n.Sprintf("Replace the snapshot '%s' taken %s?", name, existing.Created.Local().Format("2006-01-02 15:04"))
+		if !confirm(cfg, question) {
+			return errors.New(i18n.T("operation cancelled"))
+		}
+	}
+
+	info, err := pokedex.WriteSnapshot(dir, name, currentSaveData(cfg))
+	if err != nil {
+		return snapshotError(name, err)
+	}
+	i18n.Printf("Saved snapshot '%s' with %d Pokémon.\n", info.Name, info.Pokemon)
+	printSeparator()
+	return nil
+}
+
+// loadSnapshot replaces the current state with a named snapshot and saves it,
+// after the user confirms that their current progress will be overwritten.
+func loadSnapshot(cfg *config, params []string) error {
+	name, err := parseSnapshotName(params)
+	if err != nil {
+		return err
+	}
+	dir, err := getSnapshotDir()
+	if err != nil {
+		return fmt.Errorf("error determining snapshot directory: %w", err)
+	}
+
+	saveData, found, err := pokedex.ReadSnapshot(dir, name)
+	if err != nil {
+		return snapshotError(name, err)
+	}
+	if !found {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("No snapshot named '%s'. Use 'snapshot list' to see your snapshots", name), nil)
+	}
+
+	question := i18n.Sprintf("Load the snapshot '%s'? Your current progress will be replaced unless you save it as a snapshot first.", name)
+	if !confirm(cfg, question) {
+		return errors.New(i18n.T("operation cancelled"))
+	}
+
+	applySaveData(cfg, saveData)
+	i18n.Printf("Loaded snapshot '%s' with %d Pokémon.\n", name, len(saveData.Pokedex))
+	printSeparator()
+
+	// Save the restored state so that it becomes the current save
+	return savePokedexData(cfg)
+}
+
+// listSnapshots prints the snapshots with their metadata, newest first.
+func listSnapshots() error {
+	dir, err := getSnapshotDir()
+	if err != nil {
+		return fmt.Errorf("error determining snapshot directory: %w", err)
+	}
+	snapshots, err := pokedex.ListSnapshots(dir)
+	if err != nil {
+		return fmt.Errorf(i18n.T("error listing snapshots: %w"), err)
+	}
+
+	if len(snapshots) == 0 {
+		i18n.Println("You have no snapshots. Use 'snapshot create <name>' to save one.")
+		printSeparator()
+		return nil
+	}
+
+	table := NewTable("Name", "Taken", "Pokémon", "Money")
+	for _, snapshot := range snapshots {
+		table.AddRow(snapshot.Name, snapshot.Created.Local().Format("2006-01-02 15:04"),
+			fmt.Sprint(snapshot.Pokemon), fmt.Sprint(snapshot.Money))
+	}
+	table.Print()
+	printSeparator()
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// commandTeamBuild proposes a balanced team of six Pokémon from the user's Pokédex.
+// The team is chosen to hit as many types as possible super-effectively with
+// STAB moves, to avoid several members sharing a weakness, and to mix strong
+// physical and special attackers. The suggestion is explained with each member's
+// contribution and a summary of the team's coverage and weaknesses.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters (not used in this command)
+//
+// Returns:
+//   - An error if there's an issue retrieving type data from the API
+func commandTeamBuild(cfg *config, params []string) error {
+	// Take a snapshot of the Pokédex so the API requests below don't hold the lock
+	entries := cfg.pokedex.All()
+	candidates := make([]teamMember, 0, len(entries))
+	for name, entry := range entries {
+		candidates = append(candidates, newTeamMember(name, entry.PokemonDataResp))
+	}
+
+	if len(candidates) == 0 {
+		i18n.Println("You have not caught any Pokémon yet, so there's no team to build.")
+		printSeparator()
+		return nil
+	}
+
+	chart, err := loadTypeChart(cfg, standardTypes)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "teambuild", err) {
+			return err
+		}
+		return nil
+	}
+
+	team := buildTeam(chart, candidates)
+	analysis := analyzeTeam(chart, team)
+
+	if len(candidates) <= maxTeamSize {
+		i18n.Printf("You have %d Pokémon, so all of them are on the team.\n", len(candidates))
+	}
+	i18n.Println("Suggested team:")
+	table := NewTable("#", "Pokémon", "Types", "Stats", "Role", "Super-effective against")
+	for i, member := range team {
+		role := "Special"
+		if member.physical {
+			role = "Physical"
+		}
+		var covers []string
+		for _, defending := range standardTypes {
+			if _, m := chart.bestAttack(member.types, []string{defending}); m > 1 {
+				covers = append(covers, FormatTypeName(defending))
+			}
+		}
+		table.AddRow(fmt.Sprint(i+1), FormatPokemonName(member.name), FormatTypeList(member.types),
+			fmt.Sprint(member.statTotal), role, strings.Join(covers, ", "))
+	}
+	table.Print()
+
+	// Explain the team's strengths and weaknesses
+	i18n.Printf("Coverage: hits %d of %d types super-effectively", len(analysis.covered), len(standardTypes))
+	if len(analysis.uncovered) > 0 {
+		i18n.Printf(" (not covered: %s)", strings.Join(formatTypeNames(analysis.uncovered), ", "))
+	}
+	fmt.Println()
+
+	if len(analysis.sharedWeaknesses) == 0 {
+		i18n.Println("Weaknesses: no type is super-effective against more than one member")
+	} else {
+		weaknesses := make([]string, 0, len(analysis.sharedWeaknesses))
+		for attacking, count := range analysis.sharedWeaknesses {
+			weaknesses = append(weaknesses, i18n.Sprintf("%s (%d members)", FormatTypeName(attacking), count))
+		}
+		sortText(weaknesses)
+		i18n.Printf("Shared weaknesses: %s\n", strings.Join(weaknesses, ", "))
+	}
+
+	i18n.Printf("Balance: %d physical and %d special attackers, average base stats %.0f\n",
+		analysis.physical, analysis.special, analysis.averageStats)
+	printSeparator()
+	return nil
+}
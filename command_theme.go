@@ -0,0 +1,65 @@
+package main
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// themeScope tells the user which output a theme changes: only results
+// returned by commands (see result_utils.go), which are the commands that
+// accept --json.
+const themeScope = "Themes apply to commands that accept --json, such as catch, map, and pokedex. Battles and other commands keep their own look."
+
+// commandTheme shows or changes the theme command results are drawn in (see
+// theme_utils.go). The theme is saved with the other settings.
+//
+// Parameters:
+//   - cfg: The application configuration containing the settings
+//   - params: Command parameters, where params[0] (optional) is the theme name
+//
+// Returns:
+//   - An error if the theme is not recognized or the setting can't be saved
+func commandTheme(cfg *config, params []string) error {
+	// If no parameter is provided, display the current setting
+	if len(params) == 0 {
+		current := cfg.Settings().theme
+		if current == "" {
+			current = themeClassic
+		}
+		i18n.Printf("Results are drawn in the %s theme. Available themes: %s\n", current, strings.Join(themeNames, ", "))
+		i18n.Println(themeScope)
+		printSeparator()
+		return nil
+	}
+
+	theme := strings.ToLower(params[0])
+	if !slices.Contains(themeNames, theme) {
+		err := errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown theme '%s' (use %s)", params[0], strings.Join(themeNames, ", ")), nil)
+
+		// Use standardized error handling
+		if HandleCommandError(cfg, "theme", err) {
+			return err
+		}
+		return nil
+	}
+
+	cfg.UpdateSettings(func(s *settings) {
+		s.theme = theme
+		if theme == themeClassic {
+			s.theme = ""
+		}
+	})
+	i18n.Printf("Results will be drawn in the %s theme.\n", theme)
+	i18n.Println(themeScope)
+	if theme != themeClassic && isAccessibleOutput() {
+		i18n.Println("Accessible output is on, so results are drawn in the classic theme until it's turned off.")
+	}
+	printSeparator()
+
+	// Save the configuration itself, including the new theme
+	return savePokedexData(cfg)
+}
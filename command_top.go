@@ -0,0 +1,198 @@
+// This file implements the top command for the Pokédex CLI application, which
+// ranks the user's Pokémon by one of their stats to help choose battle teams.
+package main
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// topUsage describes the parameters of the top command.
+const topUsage = "Usage: top <stat> [count] [--effective] (e.g. top attack 10)"
+
+// defaultTopCount is the number of Pokémon listed when no count is given.
+const defaultTopCount = 10
+
+// statTotal is the name used for the sum of a Pokémon's stats.
+const statTotal = "total"
+
+// statAliases maps the stat names users can type to the API stat names.
+var statAliases = map[string]string{
+	"hp":              "hp",
+	"attack":          "attack",
+	"atk":             "attack",
+	"defense":         "defense",
+	"def":             "defense",
+	"special-attack":  "special-attack",
+	"spatk":           "special-attack",
+	"sp-atk":          "special-attack",
+	"special-defense": "special-defense",
+	"spdef":           "special-defense",
+	"sp-def":          "special-defense",
+	"speed":           "speed",
+	"spe":             "speed",
+	statTotal:         statTotal,
+	"bst":             statTotal,
+}
+
+// statOrder lists the API stat names in the order the games show them.
+var statOrder = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// rankedStat is a Pokémon's value for the stat being ranked.
+type rankedStat struct {
+	name  string // The Pokémon's name in the Pokédex
+	level int    // The Pokémon's level
+	value int    // The value of the ranked stat
+	total int    // The sum of the Pokémon's stats, base or effective to match value
+}
+
+// commandTop lists the user's Pokémon with the highest value for a stat.
+// Base stats are ranked by default; with --effective, the stats a Pokémon has
+// at its current level are ranked instead.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters: the stat (e.g. "attack", "spatk", or "total"),
+//     an optional count, and an optional --effective flag
+//
+// Returns:
+//   - An error if the stat or count is invalid
+func commandTop(cfg *config, params []string) error {
+	stat, count, effective, err := parseTopParams(params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "top", err) {
+			return err
+		}
+		return nil
+	}
+
+	entries := cfg.pokedex.All()
+	if len(entries) == 0 {
+		i18n.Println("You have not caught any Pokémon yet")
+		printSeparator()
+		return nil
+	}
+
+	ranked := rankByStat(entries, stat, effective)
+	ranked = ranked[:min(count, len(ranked))]
+
+	statName := FormatStatName(stat)
+	if stat == statTotal {
+		statName = i18n.T("Total")
+	}
+	if effective {
+		i18n.Printf("Your top %d Pokémon by %s at their current level:\n", len(ranked), statName)
+	} else {
+		i18n.Printf("Your top %d Pokémon by base %s:\n", len(ranked), statName)
+	}
+
+	table := NewTable("#", "Pokémon", "Level", statName, "Total")
+	listed := make([]string, len(ranked))
+	for i, r := range ranked {
+		table.AddRow(strconv.Itoa(i+1), FormatPokemonName(r.name), strconv.Itoa(r.level),
+			strconv.Itoa(r.value), strconv.Itoa(r.total))
+		listed[i] = r.name
+	}
+	table.Print()
+	cfg.SetSelection(selectPokemon, "top", listed)
+	printSeparator()
+	return nil
+}
+
+// parseTopParams reads the stat, count, and flags of the top command.
+func parseTopParams(params []string) (stat string, count int, effective bool, err error) {
+	count = defaultTopCount
+	var positional []string
+	for _, param := range params {
+		if param == "--effective" {
+			effective = true
+		} else {
+			positional = append(positional, param)
+		}
+	}
+
+	if len(positional) == 0 || len(positional) > 2 {
+		return "", 0, false, errorhandling.NewInvalidInputError(topUsage, nil)
+	}
+	stat, ok := statAliases[positional[0]]
+	if !ok {
+		return "", 0, false, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown stat '%s' (use hp, attack, defense, special-attack, special-defense, speed, or total)",
+				positional[0]), nil)
+	}
+	if len(positional) == 2 {
+		count, err = strconv.Atoi(positional[1])
+		if err != nil || count < 1 {
+			return "", 0, false, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("invalid count: %s (must be a positive number)", positional[1]), nil)
+		}
+	}
+	return stat, count, effective, nil
+}
+
+// rankByStat ranks Pokémon by a stat, highest first. Ties are broken by the
+// stat total and then by name, so the order is always the same.
+//
+// Parameters:
+//   - entries: The Pokémon to rank, indexed by name
+//   - stat: The API stat name, or statTotal for the sum of the stats
+//   - effective: Whether to rank the stats at each Pokémon's current level instead of base stats
+//
+// Returns:
+//   - The Pokémon in ranked order
+func rankByStat(entries map[string]pokedex.Entry, stat string, effective bool) []rankedStat {
+	ranked := make([]rankedStat, 0, len(entries))
+	for name, entry := range entries {
+		level := entry.CurrentLevel()
+		r := rankedStat{name: name, level: level}
+		for _, s := range entry.Stats {
+			value := s.BaseStat
+			if effective {
+				value = effectiveStat(s.Stat.Name, s.BaseStat, level)
+			}
+			r.total += value
+			if s.Stat.Name == stat {
+				r.value = value
+			}
+		}
+		if stat == statTotal {
+			r.value = r.total
+		}
+		ranked = append(ranked, r)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].value != ranked[j].value {
+			return ranked[i].value > ranked[j].value
+		}
+		if ranked[i].total != ranked[j].total {
+			return ranked[i].total > ranked[j].total
+		}
+		return ranked[i].name < ranked[j].name
+	})
+	return ranked
+}
+
+// effectiveStat works out the value of a stat at a level, using the formula
+// from the games for a Pokémon with no individual values, effort values, or
+// nature modifier.
+//
+// Parameters:
+//   - stat: The API stat name
+//   - base: The base stat
+//   - level: The Pokémon's level
+//
+// Returns:
+//   - The stat's value at that level
+func effectiveStat(stat string, base, level int) int {
+	value := 2 * base * level / 100
+	if stat == "hp" {
+		return value + level + 10
+	}
+	return value + 5
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// statEntry creates a Pokédex entry with the given base attack and speed at a level
+func statEntry(t *testing.T, attack, speed, level int) pokedex.Entry {
+	t.Helper()
+	data := fmt.Sprintf(`{"stats": [{"base_stat": %d, "stat": {"name": "attack"}}, {"base_stat": %d, "stat": {"name": "speed"}}]}`,
+		attack, speed)
+
+	var pokemon pokeapi.PokemonDataResp
+	if err := json.Unmarshal([]byte(data), &pokemon); err != nil {
+		t.Fatalf("Failed to build test Pokémon: %v", err)
+	}
+	entry := pokedex.NewEntry(pokemon)
+	entry.Level = level
+	return entry
+}
+
+// TestRankByStat tests ranking by a single stat, by total, and by effective stats
+func TestRankByStat(t *testing.T) {
+	entries := map[string]pokedex.Entry{
+		"machop":   statEntry(t, 80, 35, 5),
+		"pikachu":  statEntry(t, 55, 90, 50),
+		"rattata":  statEntry(t, 56, 72, 5),
+		"abra":     statEntry(t, 20, 90, 5),
+		"caterpie": statEntry(t, 30, 45, 5),
+	}
+
+	names := func(ranked []rankedStat) []string {
+		result := make([]string, len(ranked))
+		for i, r := range ranked {
+			result[i] = r.name
+		}
+		return result
+	}
+
+	if got := names(rankByStat(entries, "attack", false)); got[0] != "machop" || got[4] != "abra" {
+		t.Errorf("Unexpected ranking by attack: %v", got)
+	}
+	if got := names(rankByStat(entries, "speed", false)); got[0] != "pikachu" || got[1] != "abra" {
+		t.Errorf("Expected ties to be broken by total, got %v", got)
+	}
+	if got := names(rankByStat(entries, statTotal, false)); got[0] != "pikachu" {
+		t.Errorf("Unexpected ranking by total: %v", got)
+	}
+	if got := rankByStat(entries, "attack", true); got[0].name != "pikachu" || got[0].value != 60 {
+		t.Errorf("Expected the level 50 Pokémon to lead by effective attack, got %+v", got[0])
+	}
+}
+
+// TestEffectiveStat tests the level-scaled stat formula for HP and other stats
+func TestEffectiveStat(t *testing.T) {
+	if got := effectiveStat("hp", 35, 50); got != 95 {
+		t.Errorf("Expected HP 95, got %d", got)
+	}
+	if got := effectiveStat("speed", 90, 100); got != 185 {
+		t.Errorf("Expected Speed 185, got %d", got)
+	}
+}
@@ -0,0 +1,62 @@
+// This file implements the trainer command, the trainer's profile: their level
+// (see trainer_level.go), how close the next one is, and what it unlocks.
+package main
+
+import (
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// trainerProfile is the trainer's profile shown by the trainer command.
+type trainerProfile struct {
+	trainerProgress
+	Caught   int           `json:"caught"`   // The number of Pokémon in the Pokédex
+	Unlocked []trainerGoal `json:"unlocked"` // The features the trainer's level has unlocked
+	Locked   []trainerGoal `json:"locked"`   // The features still to unlock, in the order they unlock
+}
+
+// trainerGoal is a feature unlocked at a trainer level.
+type trainerGoal struct {
+	Feature string `json:"feature"` // The feature's display name
+	Level   int    `json:"level"`   // The trainer level it unlocks at
+}
+
+// trainerResult shows the trainer's profile.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters (not used in this command)
+//
+// Returns:
+//   - The profile
+//   - An error, never in practice
+func trainerResult(cfg *config, params []string) (commandResult, error) {
+	profile := trainerProfile{trainerProgress: currentTrainerProgress(cfg), Caught: cfg.pokedex.Len()}
+	for _, gate := range trainerGates {
+		goal := trainerGoal{Feature: i18n.T(gate.feature), Level: gate.level}
+		if profile.Level >= gate.level {
+			profile.Unlocked = append(profile.Unlocked, goal)
+		} else {
+			profile.Locked = append(profile.Locked, goal)
+		}
+	}
+	return commandResult{Message: formatTrainerProfile(profile), Data: profile}, nil
+}
+
+// formatTrainerProfile describes the trainer's profile.
+func formatTrainerProfile(profile trainerProfile) string {
+	var b strings.Builder
+	b.WriteString(i18n.Sprintf("Trainer level %d\n", profile.Level))
+	b.WriteString(i18n.Sprintf("Experience: %d from %d Pokémon\n", profile.Experience, profile.Caught))
+	if profile.NextLevel > 0 {
+		b.WriteString(i18n.Sprintf("Next level: %d more experience\n", profile.NextLevel-profile.Experience))
+	}
+	for _, goal := range profile.Unlocked {
+		b.WriteString(i18n.Sprintf(" - %s: unlocked at level %d\n", goal.Feature, goal.Level))
+	}
+	for _, goal := range profile.Locked {
+		b.WriteString(i18n.Sprintf(" - %s: unlock at level %d\n", goal.Feature, goal.Level))
+	}
+	return b.String()
+}
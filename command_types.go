@@ -0,0 +1,167 @@
+// This file implements the type dex commands: 'types' lists every type with
+// what it's strong and weak against, and 'type' shows one type's damage
+// relations and the Pokémon that have it, marking the ones the user has caught.
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// maxTypeExamples is the number of Pokémon listed by the type command.
+const maxTypeExamples = 12
+
+// commandTypes lists every type that Pokémon can have, with the types it's
+// super effective against, the types it's weak to, and how many of the
+// user's Pokémon have it. Types no Pokémon has (like "shadow") are left out.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters (unused)
+//
+// Returns:
+//   - An error if there's an issue with the API requests
+func commandTypes(cfg *config, params []string) error {
+	types, err := loadTypeDex(cfg)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "types", err) {
+			return err
+		}
+		return nil
+	}
+
+	caught := cfg.pokedex.Stats().Types
+	table := NewTable("Type", "Strong against", "Weak to", "Caught")
+	for _, typeData := range types {
+		relations := typeData.DamageRelations
+		table.AddRow(FormatTypeName(typeData.Name),
+			formatTypeResources(relations.DoubleDamageTo),
+			formatTypeResources(relations.DoubleDamageFrom),
+			fmt.Sprint(caught[typeData.Name]))
+	}
+	table.Print()
+	i18n.Println("Use 'type <name>' for a type's full damage relations and its Pokémon.")
+	printSeparator()
+	return nil
+}
+
+// loadTypeDex fetches every type that Pokémon can have, in the order of their IDs.
+func loadTypeDex(cfg *config) ([]pokeapi.TypeResp, error) {
+	list, err := cfg.pokeapiClient.ListTypes()
+	if err != nil {
+		return nil, err
+	}
+	var types []pokeapi.TypeResp
+	for _, result := range list.Results {
+		typeData, err := cfg.pokeapiClient.GetType(result.Name)
+		if err != nil {
+			return nil, err
+		}
+		if len(typeData.Pokemon) > 0 {
+			types = append(types, typeData)
+		}
+	}
+	return types, nil
+}
+
+// commandType shows a type's damage relations, both attacking and defending,
+// and the Pokémon that have it. Up to maxTypeExamples Pokémon are listed,
+// those the user has caught first, each with its caught marker.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the type name
+//
+// Returns:
+//   - An error if no type is provided, the type doesn't exist,
+//     or there's an issue with the API request
+func commandType(cfg *config, params []string) error {
+	typeName := ConvertToAPIFormat(strings.Join(params, " "))
+	if typeName == "" {
+		err := errorhandling.NewInvalidInputError("No type provided (e.g., 'type fire'). Use 'types' to list them", nil)
+		if HandleCommandError(cfg, "type", err) {
+			return err
+		}
+		return nil
+	}
+
+	typeData, err := cfg.pokeapiClient.GetType(typeName)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "type", err) {
+			return err
+		}
+		return nil
+	}
+
+	relations := typeData.DamageRelations
+	i18n.Printf("Type: %s\n", FormatTypeName(typeData.Name))
+	i18n.Println("Attacking:")
+	printTypeRelation("Super effective against", relations.DoubleDamageTo)
+	printTypeRelation("Not very effective against", relations.HalfDamageTo)
+	printTypeRelation("No effect on", relations.NoDamageTo)
+	i18n.Println("Defending:")
+	printTypeRelation("Weak to", relations.DoubleDamageFrom)
+	printTypeRelation("Resists", relations.HalfDamageFrom)
+	printTypeRelation("Immune to", relations.NoDamageFrom)
+
+	examples, caught := typeExamples(cfg, typeData.Pokemon)
+	i18n.Printf("Pokémon with this type (%d caught of %d):\n", len(caught), len(typeData.Pokemon))
+	for _, example := range examples {
+		marker := caughtMarker(caught[example], cfg.pokedex.HasSeen(example))
+		fmt.Printf(" %s %s\n", marker, FormatPokemonName(example))
+	}
+	if more := len(typeData.Pokemon) - len(examples); more > 0 {
+		i18n.Printf(" ...and %d more\n", more)
+	}
+	i18n.Println("[x] caught  [o] seen  [ ] not seen")
+	printSeparator()
+	return nil
+}
+
+// printTypeRelation prints one of a type's damage relations, or "none".
+func printTypeRelation(label string, types []pokeapi.NamedAPIResource) {
+	i18n.Printf(" - %s: %s\n", i18n.T(label), formatTypeResources(types))
+}
+
+// formatTypeResources formats a list of types for display, or "none" if it's empty.
+func formatTypeResources(types []pokeapi.NamedAPIResource) string {
+	if len(types) == 0 {
+		return i18n.T("none")
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = FormatTypeName(t.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// typeExamples picks the Pokémon to list for a type: the ones the user has
+// caught, then the rest, in the order the API lists them, up to maxTypeExamples.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - pokemon: The Pokémon that have the type
+//
+// Returns:
+//   - The names of the Pokémon to list
+//   - The names of the type's Pokémon the user has caught
+func typeExamples(cfg *config, pokemon []pokeapi.TypePokemon) ([]string, map[string]bool) {
+	caught := make(map[string]bool)
+	var examples, others []string
+	for _, p := range pokemon {
+		if _, ok := cfg.pokedex.Get(p.Pokemon.Name); ok {
+			caught[p.Pokemon.Name] = true
+			examples = append(examples, p.Pokemon.Name)
+		} else {
+			others = append(others, p.Pokemon.Name)
+		}
+	}
+	examples = append(examples, others...)
+	return examples[:min(len(examples), maxTypeExamples)], caught
+}
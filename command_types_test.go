@@ -0,0 +1,44 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestTypeExamples tests that caught Pokémon are listed first, and that the
+// list is cut off at maxTypeExamples
+func TestTypeExamples(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Add("raichu", pokedex.NewEntry(testMatchupPokemon(t, "raichu", 485, "electric")))
+
+	var pokemon []pokeapi.TypePokemon
+	for _, name := range []string{"pikachu", "raichu", "magnemite", "magneton", "voltorb", "electrode",
+		"electabuzz", "jolteon", "zapdos", "chinchou", "lanturn", "pichu", "mareep", "flaaffy"} {
+		pokemon = append(pokemon, pokeapi.TypePokemon{Slot: 1, Pokemon: pokeapi.NamedAPIResource{Name: name}})
+	}
+
+	examples, caught := typeExamples(cfg, pokemon)
+	if len(examples) != maxTypeExamples {
+		t.Fatalf("Expected %d examples, got %d", maxTypeExamples, len(examples))
+	}
+	if !slices.Equal(examples[:3], []string{"raichu", "pikachu", "magnemite"}) {
+		t.Errorf("Expected the caught Raichu first, got %v", examples[:3])
+	}
+	if len(caught) != 1 || !caught["raichu"] {
+		t.Errorf("Expected only Raichu to be caught, got %v", caught)
+	}
+}
+
+// TestFormatTypeResources tests how lists of types are shown
+func TestFormatTypeResources(t *testing.T) {
+	types := []pokeapi.NamedAPIResource{{Name: "water"}, {Name: "ground"}}
+	if actual := formatTypeResources(types); actual != "Water, Ground" {
+		t.Errorf("Expected 'Water, Ground', got %q", actual)
+	}
+	if actual := formatTypeResources(nil); actual != "none" {
+		t.Errorf("Expected 'none', got %q", actual)
+	}
+}
@@ -0,0 +1,101 @@
+// This file implements the units setting for the Pokédex CLI application.
+// The PokeAPI reports heights in decimeters and weights in hectograms; the
+// setting chooses whether they are shown in metric or imperial units.
+package main
+
+import (
+	"fmt"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// Supported unit systems for displaying heights and weights
+const (
+	unitsMetric   = "metric"   // Meters and kilograms (the default)
+	unitsImperial = "imperial" // Feet, inches, and pounds
+)
+
+// commandUnits shows or changes the units used to display heights and weights.
+// The setting is saved with the Pokédex so it persists between sessions.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters, where params[0] is "metric", "imperial", or omitted
+//
+// Returns:
+//   - An error if the unit system is not recognized or the setting can't be saved
+func commandUnits(cfg *config, params []string) error {
+	// If no parameter is provided, display the current setting
+	if len(params) == 0 {
+		i18n.Printf("Heights and weights are shown in %s units. Use 'units metric' or 'units imperial' to change.\n",
+			i18n.T(displayUnits(cfg)))
+		printSeparator()
+		return nil
+	}
+
+	var units string
+	switch params[0] {
+	case unitsMetric, "m":
+		units = unitsMetric
+	case unitsImperial, "i":
+		units = unitsImperial
+	default:
+		err := errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown units '%s' (use 'metric' or 'imperial')", params[0]), nil)
+
+		// Use standardized error handling
+		if HandleCommandError(cfg, "units", err) {
+			return err
+		}
+		return nil
+	}
+
+	cfg.UpdateSettings(func(s *settings) {
+		s.units = units
+	})
+	i18n.Printf("Heights and weights will be shown in %s units.\n", i18n.T(units))
+	printSeparator()
+
+	// Save the configuration itself, including the new units setting
+	return savePokedexData(cfg)
+}
+
+// displayUnits returns the unit system used for display, defaulting to metric.
+func displayUnits(cfg *config) string {
+	if cfg.Settings().units == unitsImperial {
+		return unitsImperial
+	}
+	return unitsMetric
+}
+
+// FormatHeight converts a height from the API (in decimeters) to a readable string.
+//
+// Parameters:
+//   - decimeters: The height in decimeters
+//   - units: unitsMetric or unitsImperial
+//
+// Returns:
+//   - The height in meters (like "0.4 m") or feet and inches (like "1'04\"")
+func FormatHeight(decimeters int, units string) string {
+	if units == unitsImperial {
+		inches := int(float64(decimeters)*3.93701 + 0.5)
+		return fmt.Sprintf("%d'%02d\"", inches/12, inches%12)
+	}
+	return fmt.Sprintf("%.1f m", float64(decimeters)/10)
+}
+
+// FormatWeight converts a weight from the API (in hectograms) to a readable string.
+//
+// Parameters:
+//   - hectograms: The weight in hectograms
+//   - units: unitsMetric or unitsImperial
+//
+// Returns:
+//   - The weight in kilograms (like "6.0 kg") or pounds (like "13.2 lbs")
+func FormatWeight(hectograms int, units string) string {
+	if units == unitsImperial {
+		return fmt.Sprintf("%.1f lbs", float64(hectograms)*0.220462)
+	}
+	return fmt.Sprintf("%.1f kg", float64(hectograms)/10)
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+// TestFormatHeightAndWeight tests conversion of API heights and weights to each unit system
+func TestFormatHeightAndWeight(t *testing.T) {
+	cases := []struct {
+		decimeters, hectograms int
+		units                  string
+		height, weight         string
+	}{
+		{decimeters: 4, hectograms: 60, units: unitsMetric, height: "0.4 m", weight: "6.0 kg"},
+		{decimeters: 4, hectograms: 60, units: unitsImperial, height: "1'04\"", weight: "13.2 lbs"},
+		{decimeters: 88, hectograms: 2100, units: unitsImperial, height: "28'10\"", weight: "463.0 lbs"},
+		{decimeters: 88, hectograms: 2100, units: "", height: "8.8 m", weight: "210.0 kg"},
+	}
+
+	for _, c := range cases {
+		if got := FormatHeight(c.decimeters, c.units); got != c.height {
+			t.Errorf("FormatHeight(%d, %q) = %q, expected %q", c.decimeters, c.units, got, c.height)
+		}
+		if got := FormatWeight(c.hectograms, c.units); got != c.weight {
+			t.Errorf("FormatWeight(%d, %q) = %q, expected %q", c.hectograms, c.units, got, c.weight)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// This file implements the unsaved command, which lists the changes made since
+// the Pokédex was last saved, and the checks the prompt and the exit command
+// use to tell whether there are any.
+package main
+
+import (
+	"fmt"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// commandUnsaved lists the changes that haven't been written to the save file,
+// found by comparing the Pokédex in memory with the one on disk.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: Command parameters (not used in this command)
+//
+// Returns:
+//   - An error if the save file can't be read
+func commandUnsaved(cfg *config, params []string) error {
+	if !hasUnsavedChanges(cfg) {
+		i18n.Println("All changes are saved.")
+		printSeparator()
+		return nil
+	}
+
+	changes, err := pendingChanges(cfg)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "unsaved", err) {
+			return err
+		}
+		return nil
+	}
+	if len(changes) == 0 {
+		i18n.Println("There are unsaved changes, but none that change your collection.")
+	} else {
+		i18n.Println("These changes haven't been saved yet:")
+		for _, change := range changes {
+			fmt.Printf("  - %s\n", change)
+		}
+	}
+	if !cfg.Settings().autoSaveEnabled {
+		i18n.Println("Auto-save is off. Use 'save' to save them.")
+	}
+	printSeparator()
+	return nil
+}
+
+// hasUnsavedChanges reports whether the Pokédex has changed since it was last
+// written to the save file.
+func hasUnsavedChanges(cfg *config) bool {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return cfg.changesSinceSync > 0
+}
+
+// pendingChanges describes the differences between the save file and the
+// Pokédex in memory, in the same terms as a dry run.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//
+// Returns:
+//   - A description of each change; everything is new if there's no save file yet
+//   - An error if the save file can't be read
+func pendingChanges(cfg *config) ([]string, error) {
+	saveFilePath, err := getSaveFilePath(cfg)
+	if err != nil {
+		return nil, errorhandling.NewInternalError("Could not find the save file", err)
+	}
+	waitForBackgroundSaves(cfg)
+	saved, _, err := pokedex.ReadFile(saveFilePath)
+	if err != nil {
+		return nil, errorhandling.NewInternalError("Could not read the save file", err)
+	}
+	return describeChanges(saved, currentSaveData(cfg)), nil
+}
+
+// shouldSaveOnExit warns about unsaved changes when auto-save is off, and asks
+// whether to save them. Nothing is asked when auto-save is on or in
+// batch mode, where the changes are always saved.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and settings
+//
+// Returns:
+//   - Whether the Pokédex should be saved before exiting
+func shouldSaveOnExit(cfg *config) bool {
+	if cfg.Settings().autoSaveEnabled || cfg.batch != nil || !hasUnsavedChanges(cfg) {
+		return true
+	}
+	if changes, err := pendingChanges(cfg); err == nil && len(changes) > 0 {
+		i18n.Printf("Auto-save is off, and %d changes haven't been saved:\n", len(changes))
+		for _, change := range changes {
+			fmt.Printf("  - %s\n", change)
+		}
+	} else {
+		i18n.Println("Auto-save is off, and there are unsaved changes.")
+	}
+	return confirm(cfg, i18n.T("Save them before exiting?"))
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestUnsavedChanges tests that changes are tracked until the Pokédex is
+// saved, and listed against the save file
+func TestUnsavedChanges(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	cfg.settings.autoSaveEnabled = false
+
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric")))
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		t.Fatalf("UpdatePokedexAndSave returned an error: %v", err)
+	}
+	if !hasUnsavedChanges(cfg) {
+		t.Fatal("Expected unsaved changes with auto-save off")
+	}
+	changes, err := pendingChanges(cfg)
+	if err != nil || !slices.Contains(changes, "Add Pikachu to your Pokédex") {
+		t.Errorf("Expected Pikachu to be listed as unsaved, got %v, %v", changes, err)
+	}
+
+	// Nobody can answer in batch mode, so the changes are saved
+	cfg.batch = &batchResults{}
+	if !shouldSaveOnExit(cfg) {
+		t.Error("Expected the changes to be saved on exit in batch mode")
+	}
+	cfg.batch = nil
+
+	if err := savePokedexData(cfg); err != nil {
+		t.Fatalf("savePokedexData returned an error: %v", err)
+	}
+	if hasUnsavedChanges(cfg) {
+		t.Error("Expected no unsaved changes after saving")
+	}
+	if changes, err := pendingChanges(cfg); err != nil || len(changes) != 0 {
+		t.Errorf("Expected nothing to differ from the save file, got %v, %v", changes, err)
+	}
+}
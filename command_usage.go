@@ -0,0 +1,156 @@
+// This file implements the usage command, which turns counting of the commands
+// the user runs on and off, and shows the counts (see usage_utils.go).
+package main
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// usageUsage describes the forms of the usage command.
+const usageUsage = "Usage: usage [on | off | clear | report | endpoint <url|off>]"
+
+// commandUsage shows or changes the counting of the commands the user runs.
+// Counting is off until the user turns it on, and the setting is saved with
+// the Pokédex. Supported forms:
+//   - usage: Show how many times each command has been run
+//   - usage on / usage off: Start or stop counting
+//   - usage clear: Delete the counts
+//   - usage endpoint <url>: Set where 'usage report' sends the counts ("off" to unset it)
+//   - usage report: Send the counts to the reporting endpoint
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters as described above
+//
+// Returns:
+//   - An error if the parameters are invalid, the counts can't be read,
+//     cleared, or sent, or the setting can't be saved
+func commandUsage(cfg *config, params []string) error {
+	var err error
+	switch {
+	case len(params) == 0:
+		err = showUsage(cfg)
+	case len(params) == 1 && (strings.EqualFold(params[0], "on") || strings.EqualFold(params[0], "off")):
+		err = setUsageTracking(cfg, strings.EqualFold(params[0], "on"))
+	case len(params) == 1 && strings.EqualFold(params[0], "clear"):
+		err = clearUsage()
+	case len(params) == 1 && strings.EqualFold(params[0], "report"):
+		err = sendUsage(cfg)
+	case len(params) == 2 && strings.EqualFold(params[0], "endpoint"):
+		err = setUsageEndpoint(cfg, params[1])
+	default:
+		err = errorhandling.NewInvalidInputError(usageUsage, nil)
+	}
+
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "usage", err) {
+			return err
+		}
+		return nil
+	}
+	printSeparator()
+	return nil
+}
+
+// showUsage shows how many times each command has been run, most used first.
+func showUsage(cfg *config) error {
+	counts, err := readUsage(usageFile.path(), time.Now())
+	if err != nil {
+		return err
+	}
+	if !cfg.Settings().usageTracking {
+		i18n.Println("Usage counting is off. Use 'usage on' to count the commands you run.")
+		i18n.Println("Only command names are counted, and the counts stay on this computer.")
+	}
+	if len(counts.Commands) == 0 {
+		if cfg.Settings().usageTracking {
+			i18n.Println("No commands have been counted yet.")
+		}
+		return nil
+	}
+
+	names := slices.SortedFunc(maps.Keys(counts.Commands), func(a, b string) int {
+		return cmp.Or(cmp.Compare(counts.Commands[b], counts.Commands[a]), cmp.Compare(a, b))
+	})
+	table := NewTable("Command", "Times run")
+	for _, name := range names {
+		table.AddRow(name, strconv.Itoa(counts.Commands[name]))
+	}
+	table.Print()
+	i18n.Printf("Counted since %s.\n", counts.Since.Local().Format("2006-01-02"))
+	return nil
+}
+
+// setUsageTracking turns counting on or off, and saves the setting.
+func setUsageTracking(cfg *config, on bool) error {
+	cfg.UpdateSettings(func(s *settings) {
+		s.usageTracking = on
+	})
+	if on {
+		i18n.Println("The commands you run will be counted. Only their names are counted, and the counts stay on this computer.")
+		i18n.Println("See them with 'usage'.")
+	} else {
+		i18n.Println("Commands will no longer be counted. Use 'usage clear' to delete the counts so far.")
+	}
+	return savePokedexData(cfg)
+}
+
+// clearUsage deletes the usage counts.
+func clearUsage() error {
+	if err := os.Remove(usageFile.path()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errorhandling.NewInternalError(i18n.Sprintf("Could not delete the usage counts: %v", err), err)
+	}
+	i18n.Println("Deleted the usage counts.")
+	return nil
+}
+
+// setUsageEndpoint sets the URL 'usage report' sends the counts to, or unsets
+// it, and saves the setting.
+func setUsageEndpoint(cfg *config, endpoint string) error {
+	if strings.EqualFold(endpoint, "off") {
+		endpoint = ""
+	} else if parsed, err := url.Parse(endpoint); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Invalid reporting endpoint '%s' (use an http or https URL)", endpoint), err)
+	}
+
+	cfg.UpdateSettings(func(s *settings) {
+		s.usageEndpoint = endpoint
+	})
+	if endpoint == "" {
+		i18n.Println("The reporting endpoint was removed. Usage counts won't be sent anywhere.")
+	} else {
+		i18n.Printf("'usage report' will send the usage counts to %s. Nothing is sent until you run it.\n", endpoint)
+	}
+	return savePokedexData(cfg)
+}
+
+// sendUsage sends the usage counts to the reporting endpoint.
+func sendUsage(cfg *config) error {
+	endpoint := cfg.Settings().usageEndpoint
+	if endpoint == "" {
+		return errorhandling.NewInvalidInputError(
+			"No reporting endpoint is set. Use 'usage endpoint <url>' to set one.", nil)
+	}
+	counts, err := readUsage(usageFile.path(), time.Now())
+	if err != nil {
+		return err
+	}
+	if err := sendUsageReport(commandContext(cfg), endpoint, counts); err != nil {
+		return errorhandling.NewNetworkError(i18n.Sprintf("Could not send the usage counts to %s: %v", endpoint, err), err)
+	}
+	i18n.Printf("Sent the counts for %d commands to %s.\n", len(counts.Commands), endpoint)
+	return nil
+}
@@ -4,12 +4,14 @@
 package main
 
 import (
+	"context"
 	"errors"
-	"fmt"
 	"log"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
 // Common error variables used across commands
@@ -66,33 +68,56 @@ func GetPokemonIfExists(cfg *config, params []string) (string, PokemonNameInfo,
 
 	// Return error if Pokemon doesn't exist in Pokedex
 	if !existsInPokedex {
-		// Before returning "not in Pokédex" error, verify if it's a valid Pokémon
-		// by checking if it exists in the API
-		_, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
-
-		if err != nil {
-			// If the API returns a NotFound error, it's not a valid Pokémon name
-			if errorhandling.IsNotFoundError(err) {
-				return apiName, nameInfo, nil, false, errorhandling.InvalidPokemonNameError(nameInfo.Formatted)
-			}
-
-			// For other API errors, still return the original not-in-pokedex error
-			// but log the API error if in debug mode
-			if cfg.debugMode {
-				log.Printf("API error while checking if %s exists: %v", nameInfo.APIFormat, err)
-			}
+		// Before returning "not in Pokédex" error, verify it's a valid Pokémon name
+		if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+			return apiName, nameInfo, nil, false, err
 		}
 
-		// If we get here, either:
-		// 1. The Pokémon exists in the API but not in the user's Pokédex
-		// 2. There was a non-404 API error and we're treating it as if the Pokémon might be valid
+		// The Pokémon exists (or couldn't be checked) but isn't in the user's Pokédex
 		return apiName, nameInfo, nil, false, errorhandling.PokemonNotInPokedexError(nameInfo.Formatted)
 	}
 
 	return apiName, nameInfo, pokemonData, true, nil
 }
 
-// GetTypedPokemonData converts a generic interface to a strongly-typed PokemonDataResp.
+// ValidatePokemonName checks a Pokémon name against the species dataset and then
+// the local name index. If the name isn't a known Pokémon, the returned error
+// suggests similar names, starting with those found in the area explored last.
+// When the index can't be loaded (for example, if the API is unreachable),
+// validation is skipped so that commands can still report their own errors.
+//
+// Parameters:
+//   - cfg: The application configuration containing the name index
+//   - nameInfo: The Pokémon name to validate
+//
+// Returns:
+//   - An InvalidPokemonNameError if the name is unknown, nil otherwise
+func ValidatePokemonName(cfg *config, nameInfo PokemonNameInfo) error {
+	// Names in the dataset, or found by the last explore, are known without loading the index
+	if _, ok := cfg.Dataset().Lookup(nameInfo.APIFormat); ok {
+		return nil
+	}
+	if cfg.ExploredLocationOf(nameInfo.APIFormat) != "" {
+		return nil
+	}
+
+	idx, err := getNameIndex(cfg)
+	if err != nil {
+		// Log the API error if in debug mode, but don't block the command
+		if cfg.Settings().debugMode {
+			log.Printf("Could not load Pokémon name index to validate %s: %v", nameInfo.APIFormat, err)
+		}
+		return nil
+	}
+
+	if idx.Contains(nameInfo.APIFormat) {
+		return nil
+	}
+	suggestions := formatSuggestions(mergeSuggestions(exploredSuggestions(cfg, nameInfo.APIFormat), idx.Suggest(nameInfo.APIFormat)))
+	return errorhandling.InvalidPokemonNameError(nameInfo.Formatted, suggestions...)
+}
+
+// GetTypedPokemonData converts a generic interface to a strongly-typed pokedex.Entry.
 // This function is used when we need to access specific fields of the Pokémon data
 // that was stored in the Pokédex as an interface{}.
 //
@@ -101,18 +126,35 @@ func GetPokemonIfExists(cfg *config, params []string) (string, PokemonNameInfo,
 //   - pokemonName: The name of the Pokémon, used for error reporting
 //
 // Returns:
-//   - A strongly-typed PokemonDataResp containing the Pokémon data
+//   - A strongly-typed pokedex.Entry containing the Pokémon data
 //   - An error if the conversion fails
-func GetTypedPokemonData(pokemonData interface{}, pokemonName string) (pokeapi.PokemonDataResp, error) {
-	data, ok := pokemonData.(pokeapi.PokemonDataResp)
+func GetTypedPokemonData(pokemonData interface{}, pokemonName string) (pokedex.Entry, error) {
+	data, ok := pokemonData.(pokedex.Entry)
 	if !ok {
-		return pokeapi.PokemonDataResp{}, errorhandling.NewInternalError(
-			fmt.Sprintf("Unexpected data type for %s", pokemonName),
+		return pokedex.Entry{}, errorhandling.NewInternalError(
+			i18n.Sprintf("Unexpected data type for %s", pokemonName),
 			errors.New("type conversion error"))
 	}
 	return data, nil
 }
 
+// speciesName returns the name of a Pokémon's species, which forms such as
+// "pikachu-rock-star" share with their base form. Entries saved without a
+// species use the Pokémon's own name.
+func speciesName(apiName string, data pokeapi.PokemonDataResp) string {
+	if data.Species.Name != "" {
+		return data.Species.Name
+	}
+	return apiName
+}
+
+// isMissingData reports whether an error means the API has no data, or only
+// incomplete data, for something, rather than that it couldn't be reached.
+// Commands showing several kinds of data can leave out the missing ones.
+func isMissingData(err error) bool {
+	return errorhandling.IsNotFoundError(err) || errorhandling.IsInvalidResponseError(err)
+}
+
 // HandleCommandError processes errors from commands and determines whether they should be returned.
 // It handles special cases like API errors, displaying appropriate messages to the user.
 // In debug mode, it logs detailed error information for debugging purposes.
@@ -131,10 +173,19 @@ func HandleCommandError(cfg *config, commandName string, err error) bool {
 	}
 
 	// Log detailed error info in debug mode
-	if cfg.debugMode {
+	if cfg.Settings().debugMode {
 		log.Printf("ERROR in command '%s': %v", commandName, err)
 	}
 
+	// A command cancelled with Ctrl+C has nothing more to report, but still
+	// counts as failed in batch mode
+	if errors.Is(err, context.Canceled) {
+		cfg.commandErr = err
+		i18n.Println("Cancelled.")
+		printSeparator()
+		return false
+	}
+
 	// For certain error types, we want to return the error for consistent handling in the REPL
 	// This includes invalid input errors and "not found" errors, which should be displayed with
 	// their specific user-friendly message
@@ -142,12 +193,28 @@ func HandleCommandError(cfg *config, commandName string, err error) bool {
 		return true
 	}
 
-	// For other errors, display the user-friendly message but don't propagate the error
-	fmt.Printf("Error: %s\n", errorhandling.FormatUserMessage(err))
-	fmt.Println("-----")
+	// For other errors, display the user-friendly message but don't propagate the error.
+	// The error is still noted so that batch mode can count the command as failed.
+	cfg.commandErr = err
+	PrintUserError(err)
 	return false
 }
 
+// PrintUserError displays an error to the user followed by the standard separator line.
+// If the error has an error code, the code is shown so the user can look it up
+// with the 'explain' command.
+//
+// Parameters:
+//   - err: The error to display
+func PrintUserError(err error) {
+	if code := errorhandling.ErrorCode(err); code != "" {
+		i18n.Printf("Error [%s]: %s\n", code, errorhandling.FormatUserMessage(err))
+	} else {
+		i18n.Printf("Error: %s\n", errorhandling.FormatUserMessage(err))
+	}
+	printSeparator()
+}
+
 // UpdateLocationState updates the shared location state with proper mutex locking.
 // It updates the pagination URLs and recent locations based on the API response.
 // If markMapViewed is true, it will also set the mapViewedThisSession flag to true.
@@ -1,7 +1,15 @@
 package main
 
 import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/search"
 )
 
 // TestValidatePokemonParam tests the validation of Pokémon parameters
@@ -69,7 +77,7 @@ func TestFormatPokemonInput(t *testing.T) {
 			input: "mr mime",
 			expected: PokemonNameInfo{
 				Input:     "mr mime",
-				Formatted: "Mr-mime",
+				Formatted: "Mr. Mime",
 				APIFormat: "mr-mime",
 			},
 		},
@@ -78,7 +86,7 @@ func TestFormatPokemonInput(t *testing.T) {
 			input: "mr. mime",
 			expected: PokemonNameInfo{
 				Input:     "mr. mime",
-				Formatted: "Mr-mime",
+				Formatted: "Mr. Mime",
 				APIFormat: "mr-mime",
 			},
 		},
@@ -87,7 +95,7 @@ func TestFormatPokemonInput(t *testing.T) {
 			input: "mime jr.",
 			expected: PokemonNameInfo{
 				Input:     "mime jr.",
-				Formatted: "Mime-jr",
+				Formatted: "Mime Jr.",
 				APIFormat: "mime-jr",
 			},
 		},
@@ -96,16 +104,16 @@ func TestFormatPokemonInput(t *testing.T) {
 			input: "type: null",
 			expected: PokemonNameInfo{
 				Input:     "type: null",
-				Formatted: "Type-null",
+				Formatted: "Type: Null",
 				APIFormat: "type-null",
 			},
 		},
 		{
-			name:  "Name with hyphen (Ho-oh)",
+			name:  "Name with hyphen (Ho-Oh)",
 			input: "ho-oh",
 			expected: PokemonNameInfo{
 				Input:     "ho-oh",
-				Formatted: "Ho-oh",
+				Formatted: "Ho-Oh",
 				APIFormat: "ho-oh",
 			},
 		},
@@ -114,7 +122,7 @@ func TestFormatPokemonInput(t *testing.T) {
 			input: "ho oh",
 			expected: PokemonNameInfo{
 				Input:     "ho oh",
-				Formatted: "Ho-oh",
+				Formatted: "Ho-Oh",
 				APIFormat: "ho-oh",
 			},
 		},
@@ -123,7 +131,7 @@ func TestFormatPokemonInput(t *testing.T) {
 			input: "tapu koko",
 			expected: PokemonNameInfo{
 				Input:     "tapu koko",
-				Formatted: "Tapu-koko",
+				Formatted: "Tapu Koko",
 				APIFormat: "tapu-koko",
 			},
 		},
@@ -132,7 +140,7 @@ func TestFormatPokemonInput(t *testing.T) {
 			input: "porygon z",
 			expected: PokemonNameInfo{
 				Input:     "porygon z",
-				Formatted: "Porygon-z",
+				Formatted: "Porygon-Z",
 				APIFormat: "porygon-z",
 			},
 		},
@@ -165,3 +173,161 @@ func TestFormatPokemonInput(t *testing.T) {
 		})
 	}
 }
+
+// TestConvertToAPIFormat verifies that display names containing accents,
+// gender symbols, and punctuation are converted to their API slugs.
+func TestConvertToAPIFormat(t *testing.T) {
+	cases := map[string]string{
+		"Pikachu":       "pikachu",
+		"Flabébé":       "flabebe",
+		"Nidoran♀":      "nidoran-f",
+		"Nidoran ♂":     "nidoran-m",
+		"Farfetch'd":    "farfetchd",
+		"Sirfetch’d":    "sirfetchd",
+		"Mr. Mime":      "mr-mime",
+		"Mime Jr.":      "mime-jr",
+		"Type: Null":    "type-null",
+		"Porygon-Z":     "porygon-z",
+		"  Tapu  Koko":  "tapu-koko",
+		"Mt Coronet 5F": "mt-coronet-5f",
+	}
+
+	for input, expected := range cases {
+		if actual := ConvertToAPIFormat(input); actual != expected {
+			t.Errorf("ConvertToAPIFormat(%q) == %q, expected %q", input, actual, expected)
+		}
+	}
+}
+
+// TestFormatPokemonNameUnicode verifies that names the API spells without
+// accents or gender symbols are displayed with them, and still convert back
+func TestFormatPokemonNameUnicode(t *testing.T) {
+	cases := map[string]string{
+		"flabebe":   "Flabébé",
+		"nidoran-f": "Nidoran♀",
+		"nidoran-m": "Nidoran♂",
+		"pikachu":   "Pikachu",
+	}
+	for name, expected := range cases {
+		formatted := FormatPokemonName(name)
+		if formatted != expected {
+			t.Errorf("FormatPokemonName(%q) == %q, expected %q", name, formatted, expected)
+		}
+		if back := ConvertToAPIFormat(formatted); back != name {
+			t.Errorf("ConvertToAPIFormat(%q) == %q, expected %q", formatted, back, name)
+		}
+	}
+	if info := FormatPokemonInput("FLABÉBÉ"); info.APIFormat != "flabebe" || info.Formatted != "Flabébé" {
+		t.Errorf("Unexpected name info for FLABÉBÉ: %+v", info)
+	}
+}
+
+// TestFormatPokemonNameGolden verifies the display name of every species
+// against testdata/pokemon_names.golden, which lists each one's national
+// Pokédex number, API name, and display name. Each display name must also
+// convert back to the API name, whether it's typed or displayed.
+func TestFormatPokemonNameGolden(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "pokemon_names.golden"))
+	if err != nil {
+		t.Fatalf("Failed to read the golden names: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i, line := range lines {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 || fields[0] != strconv.Itoa(i+1) {
+			t.Fatalf("Malformed golden line %d: %q", i+1, line)
+		}
+		name, display := fields[1], fields[2]
+		if formatted := FormatPokemonName(name); formatted != display {
+			t.Errorf("FormatPokemonName(%q) == %q, expected %q", name, formatted, display)
+		}
+		if info := FormatPokemonInput(display); info.APIFormat != name || info.Formatted != display {
+			t.Errorf("FormatPokemonInput(%q) == %+v, expected %q and %q", display, info, name, display)
+		}
+	}
+	// The list must cover every generation the search index knows of
+	if search.Generation(len(lines)) == 0 || search.Generation(len(lines)+1) != 0 {
+		t.Errorf("Expected every species up to the latest generation, got %d", len(lines))
+	}
+}
+
+// TestFormatPokemonNameForms verifies that alternate forms are displayed as
+// their species' display name followed by the form
+func TestFormatPokemonNameForms(t *testing.T) {
+	cases := map[string]string{
+		"charizard-mega-x":  "Charizard-Mega-X",
+		"deoxys-attack":     "Deoxys-Attack",
+		"pikachu-alola-cap": "Pikachu-Alola-Cap",
+		"mr-mime-galar":     "Mr. Mime-Galar",
+		"farfetchd-galar":   "Farfetch'd-Galar",
+		"kommo-o-totem":     "Kommo-o-Totem",
+		"porygon-z":         "Porygon-Z",
+		"Mr-Mime":           "Mr. Mime",
+	}
+	for name, expected := range cases {
+		formatted := FormatPokemonName(name)
+		if formatted != expected {
+			t.Errorf("FormatPokemonName(%q) == %q, expected %q", name, formatted, expected)
+		}
+		if back := ConvertToAPIFormat(formatted); back != strings.ToLower(name) {
+			t.Errorf("ConvertToAPIFormat(%q) == %q, expected %q", formatted, back, strings.ToLower(name))
+		}
+	}
+}
+
+// TestFoldedMatching verifies that searches ignore accents and case,
+// whether the text is composed or decomposed
+func TestFoldedMatching(t *testing.T) {
+	cases := []struct {
+		text, query string
+		expected    bool
+	}{
+		{"Caught a shiny Flabébé!", "flabebe", true},
+		{"caught a shiny flabebe", "FLABÉBÉ", true},
+		{"Pok\u0065\u0301mon center", "pokémon", true},
+		{"Straße", "strasse", true},
+		{"Flabébé", "flabebo", false},
+	}
+	for _, c := range cases {
+		if actual := containsFolded(c.text, c.query); actual != c.expected {
+			t.Errorf("containsFolded(%q, %q) == %v, expected %v", c.text, c.query, actual, c.expected)
+		}
+	}
+}
+
+// TestSortText verifies that display text is sorted in the order of the
+// selected language, with accents and case not separating words
+func TestSortText(t *testing.T) {
+	defer i18n.SetLanguage(i18n.Default)
+	i18n.SetLanguage("es")
+
+	texts := []string{"Zona", "equipo", "Élite", "Ñandú", "nube", "Guardería"}
+	sortText(texts)
+	expected := []string{"Élite", "equipo", "Guardería", "nube", "Ñandú", "Zona"}
+	if !slices.Equal(texts, expected) {
+		t.Errorf("Expected %q, got %q", expected, texts)
+	}
+}
+
+// benchmarkNames are names in the forms users type them, and benchmarkAPINames
+// the same names in API format, for the formatting benchmarks.
+var (
+	benchmarkNames    = []string{"pikachu", "Mr. Mime", "ho-oh", "Type: Null", "Nidoran♀", "flabébé", "tapu koko", "charizard-mega-x"}
+	benchmarkAPINames = []string{"pikachu", "mr-mime", "ho-oh", "type-null", "nidoran-f", "flabebe", "tapu-koko", "charizard-mega-x"}
+)
+
+// BenchmarkFormatPokemonInput measures turning a typed name into its API and
+// display forms, which every command that takes a Pokémon does first.
+func BenchmarkFormatPokemonInput(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		FormatPokemonInput(benchmarkNames[i%len(benchmarkNames)])
+	}
+}
+
+// BenchmarkFormatPokemonName measures turning an API name into a display
+// name, which listings do for every Pokémon they show.
+func BenchmarkFormatPokemonName(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		FormatPokemonName(benchmarkAPINames[i%len(benchmarkAPINames)])
+	}
+}
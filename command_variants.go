@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// commandVariants lists every variety of a Pokémon's species, such as its
+// regional and alternate forms, marking the ones the user owns. This helps
+// completionists see which forms they still need to collect. Any form of the
+// species can be given (e.g. "variants raichu-alola" lists Raichu's forms).
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - params: Command parameters where params[0] is the Pokémon name (caught or not)
+//
+// Returns:
+//   - An error if no Pokémon name is provided, the name is invalid,
+//     or there's an issue with the API requests
+func commandVariants(cfg *config, params []string) error {
+	// Check if Pokemon name parameter was provided
+	pokemonParam, err := ValidatePokemonParam(params)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "variants", err) {
+			return err
+		}
+		return nil
+	}
+
+	nameInfo := FormatPokemonInput(pokemonParam)
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "variants", err) {
+			return err
+		}
+		return nil
+	}
+
+	// The varieties belong to the species, so look up the species of the given form
+	pokemonData, err := cfg.pokeapiClient.GetPokemonData(nameInfo.APIFormat)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "variants", err) {
+			return err
+		}
+		return nil
+	}
+	speciesData, err := cfg.pokeapiClient.GetPokemonSpecies(pokemonData.Species.Name)
+	if err != nil {
+		// Use standardized error handling
+		if HandleCommandError(cfg, "variants", err) {
+			return err
+		}
+		return nil
+	}
+
+	owned := ownedVarieties(cfg, speciesData.Varieties)
+	speciesName := FormatPokemonName(speciesData.Name)
+	table := NewTable("#", "Form", "Owned")
+	for i, variety := range speciesData.Varieties {
+		name := FormatPokemonName(variety.Pokemon.Name)
+		if variety.IsDefault {
+			name = i18n.Sprintf("%s (default)", name)
+		}
+		status := i18n.T("no")
+		if owned[variety.Pokemon.Name] {
+			status = i18n.T("yes")
+		}
+		table.AddRow(fmt.Sprint(i+1), name, status)
+	}
+
+	i18n.Printf("Forms of %s:\n", speciesName)
+	table.Print()
+	switch {
+	case len(speciesData.Varieties) == 1:
+		i18n.Printf("%s has no alternate forms.\n", speciesName)
+	case len(owned) == len(speciesData.Varieties):
+		i18n.Printf("You own every form of %s!\n", speciesName)
+	default:
+		i18n.Printf("You own %d of %d forms of %s.\n", len(owned), len(speciesData.Varieties), speciesName)
+	}
+	printSeparator()
+
+	return nil
+}
+
+// ownedVarieties returns the names of the varieties the user owns.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - varieties: The varieties of a species
+//
+// Returns:
+//   - A set of the owned varieties' names, in API format
+func ownedVarieties(cfg *config, varieties []pokeapi.PokemonSpeciesVariety) map[string]bool {
+	owned := make(map[string]bool)
+	for _, variety := range varieties {
+		if _, ok := cfg.pokedex.Get(variety.Pokemon.Name); ok {
+			owned[variety.Pokemon.Name] = true
+		}
+	}
+	return owned
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestOwnedVarieties tests that only the varieties in the Pokédex are marked as owned
+func TestOwnedVarieties(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Add("raichu-alola", pokedex.Entry{})
+	cfg.pokedex.Add("pikachu", pokedex.Entry{})
+
+	varieties := []pokeapi.PokemonSpeciesVariety{
+		{IsDefault: true, Pokemon: pokeapi.NamedAPIResource{Name: "raichu"}},
+		{Pokemon: pokeapi.NamedAPIResource{Name: "raichu-alola"}},
+	}
+	owned := ownedVarieties(cfg, varieties)
+	if len(owned) != 1 || !owned["raichu-alola"] {
+		t.Errorf("ownedVarieties = %v, expected only raichu-alola", owned)
+	}
+}
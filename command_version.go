@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/update"
+)
+
+// versionInfo is the data behind the version command's result.
+type versionInfo struct {
+	Version         string `json:"version"`                    // The application version
+	GoVersion       string `json:"go_version"`                 // The Go version it was built with
+	Platform        string `json:"platform"`                   // The platform it was built for, as os/arch
+	Latest          string `json:"latest,omitempty"`           // The latest release, if --check found one
+	UpdateAvailable bool   `json:"update_available,omitempty"` // Whether the latest release is newer than this version
+}
+
+// versionResult describes the application version along with the Go version
+// and platform it was built for. With '--check', it also asks GitHub whether
+// a newer release is available, even if the startup check ran recently.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters where params[0] (optional) is '--check'
+//
+// Returns:
+//   - The version information, and whether a newer release is available
+//   - An error if the parameters are invalid or the release check fails
+func versionResult(cfg *config, params []string) (commandResult, error) {
+	check := false
+	switch {
+	case len(params) == 1 && params[0] == "--check":
+		check = true
+	case len(params) != 0:
+		return commandResult{}, errorhandling.NewInvalidInputError("Usage: version [--check] [--json]", nil)
+	}
+
+	info := versionInfo{
+		Version:   appVersion(),
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	var message strings.Builder
+	message.WriteString(i18n.Sprintf("Pokédex CLI %s\n", info.Version))
+	message.WriteString(i18n.Sprintf("Built with %s for %s/%s\n", info.GoVersion, runtime.GOOS, runtime.GOARCH))
+	if check {
+		if err := checkVersion(&info, &message); err != nil {
+			return commandResult{}, err
+		}
+	}
+	return commandResult{Message: message.String(), Data: info}, nil
+}
+
+// checkVersion asks GitHub for the latest release and adds whether to upgrade
+// to the version information and its message.
+func checkVersion(info *versionInfo, message *strings.Builder) error {
+	if !update.IsRelease(info.Version) {
+		message.WriteString(i18n.T("This is a development build, so there are no releases to compare it with.") + "\n")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	latest, err := checkForUpdate(ctx, getUpdateStatePath(), time.Now(), true, fetchLatestRelease)
+	if err != nil {
+		return errorhandling.NewNetworkError("Could not check for a newer version", err)
+	}
+
+	info.Latest = latest.Version
+	if hint := updateHint(info.Version, latest); hint != "" {
+		info.UpdateAvailable = true
+		message.WriteString(hint + "\n")
+	} else {
+		message.WriteString(i18n.T("You're using the latest version.") + "\n")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// This file implements the version group setting for the Pokédex CLI application.
+// A version group is a set of games that share their move data, such as Red and
+// Blue. When one is chosen, the moves listed, taught, and used by Pokémon are
+// limited to those they can learn in that version group.
+package main
+
+import (
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// commandVersionGroup shows or changes the version group that movesets are limited to.
+// The version group is checked against the PokeAPI before it is saved, and
+// "all" removes the limit. The setting is saved with the Pokédex so it persists
+// between sessions.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters, where params[0] is a version group (e.g. "red-blue"), "all", or omitted
+//
+// Returns:
+//   - An error if the version group doesn't exist or the setting can't be saved
+func commandVersionGroup(cfg *config, params []string) error {
+	// If no parameter is provided, display the current setting
+	if len(params) == 0 {
+		if group := cfg.Settings().versionGroup; group != "" {
+			i18n.Printf("Moves are limited to those learnable in %s. Use 'versiongroup all' to allow every move.\n",
+				FormatLocationName(group))
+		} else {
+			i18n.Println("Moves from every game are allowed. Use 'versiongroup <name>' (e.g. 'versiongroup red-blue') to limit them.")
+		}
+		printSeparator()
+		return nil
+	}
+
+	group := ConvertToAPIFormat(strings.Join(params, " "))
+	if group == "all" || group == "off" {
+		group = ""
+	}
+
+	var games []string
+	if group != "" {
+		versionGroup, err := cfg.pokeapiClient.GetVersionGroup(group)
+		if err != nil {
+			if errorhandling.IsNotFoundError(err) {
+				err = errorhandling.NewInvalidInputError(
+					i18n.Sprintf("Unknown version group '%s' (e.g. red-blue, gold-silver, or sword-shield)", group), err)
+			}
+
+			// Use standardized error handling
+			if HandleCommandError(cfg, "versiongroup", err) {
+				return err
+			}
+			return nil
+		}
+		for _, version := range versionGroup.Versions {
+			games = append(games, FormatLocationName(version.Name))
+		}
+	}
+
+	cfg.UpdateSettings(func(s *settings) {
+		s.versionGroup = group
+	})
+	if group == "" {
+		i18n.Println("Moves from every game are allowed.")
+	} else if len(games) > 0 {
+		i18n.Printf("Moves are now limited to those learnable in %s (%s).\n",
+			FormatLocationName(group), strings.Join(games, ", "))
+	} else {
+		i18n.Printf("Moves are now limited to those learnable in %s.\n", FormatLocationName(group))
+	}
+	printSeparator()
+
+	// Save the configuration itself, including the new version group setting
+	return savePokedexData(cfg)
+}
@@ -0,0 +1,174 @@
+// This file implements the release notes shown after an upgrade. The notes
+// are the changelog (CHANGELOG.md), embedded in the binary so they're
+// available offline and always match the version that's running. The first
+// time a newer release starts, a short "What's new" section is shown before
+// the prompt, and the version is recorded in the update state file (see
+// update_check.go) so it's only shown once. The whatsnew command shows the
+// notes again, in full.
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/update"
+)
+
+//go:embed CHANGELOG.md
+var changelog string
+
+// unreleasedHeading is the changelog heading of changes not released yet.
+const unreleasedHeading = "Unreleased"
+
+// maxStartupNotes is how many changes the startup "What's new" section lists
+// before pointing to the whatsnew command for the rest.
+const maxStartupNotes = 5
+
+// releaseNotes are the changes in one release, from the changelog.
+type releaseNotes struct {
+	Version string   `json:"version"` // The version, or "Unreleased"
+	Changes []string `json:"changes"` // The changes, in the order listed
+}
+
+// parseChangelog reads the releases in a changelog: each "## " heading starts
+// a release, and each "- " line under it is a change. Lines that continue a
+// change are joined to it, and anything else is ignored.
+//
+// Parameters:
+//   - text: The changelog in Markdown
+//
+// Returns:
+//   - The releases, in the order they're listed (newest first)
+func parseChangelog(text string) []releaseNotes {
+	var releases []releaseNotes
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "## "):
+			releases = append(releases, releaseNotes{Version: strings.TrimSpace(line[3:])})
+		case len(releases) == 0 || line == "":
+			continue
+		case strings.HasPrefix(line, "- "):
+			last := &releases[len(releases)-1]
+			last.Changes = append(last.Changes, strings.TrimSpace(line[2:]))
+		default:
+			if last := &releases[len(releases)-1]; len(last.Changes) > 0 {
+				last.Changes[len(last.Changes)-1] += " " + line
+			}
+		}
+	}
+	return releases
+}
+
+// notesFor returns the notes of a release in the changelog.
+//
+// Parameters:
+//   - releases: The releases in the changelog
+//   - version: The version to find
+//
+// Returns:
+//   - The release's notes, and whether the changelog has a section for it
+func notesFor(releases []releaseNotes, version string) (releaseNotes, bool) {
+	for _, release := range releases {
+		if release.Version == version {
+			return release, true
+		}
+	}
+	return releaseNotes{}, false
+}
+
+// formatReleaseNotes describes a release's changes under a "What's new"
+// heading, listing at most limit changes (0 for all of them).
+func formatReleaseNotes(notes releaseNotes, limit int) string {
+	var message strings.Builder
+	if notes.Version == unreleasedHeading {
+		message.WriteString(i18n.T("What's new since the last release:") + "\n")
+	} else {
+		message.WriteString(i18n.Sprintf("What's new in %s:", notes.Version) + "\n")
+	}
+	shown := notes.Changes
+	if limit > 0 && len(shown) > limit {
+		shown = shown[:limit]
+	}
+	for _, change := range shown {
+		message.WriteString("  - " + change + "\n")
+	}
+	if len(shown) < len(notes.Changes) {
+		message.WriteString(i18n.Sprintf("...and %d more. Use 'whatsnew' to see them all.", len(notes.Changes)-len(shown)) + "\n")
+	}
+	return message.String()
+}
+
+// whatsnewResult shows the release notes of the running version. Development
+// builds, and releases the changelog has no section for, show the newest
+// section instead.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters (unused)
+//
+// Returns:
+//   - The release notes
+//   - An error if the changelog lists no releases
+func whatsnewResult(cfg *config, params []string) (commandResult, error) {
+	releases := parseChangelog(changelog)
+	notes, found := notesFor(releases, appVersion())
+	if !found {
+		if len(releases) == 0 {
+			return commandResult{}, errorhandling.NewInternalError("The release notes are missing from this build", nil)
+		}
+		notes = releases[0]
+	}
+	return commandResult{Message: formatReleaseNotes(notes, 0), Data: notes}, nil
+}
+
+// releaseNotesToShow decides whether to show the notes of the running version
+// at startup: only for a release the changelog has a section for, and only the
+// first time it runs after an upgrade. A fresh install has nothing to compare
+// against, so it records the version without showing anything.
+//
+// Parameters:
+//   - releases: The releases in the changelog
+//   - current: The running version
+//   - state: The update state, or nil if there's no update state file yet
+//
+// Returns:
+//   - The notes to show, and whether to show them
+func releaseNotesToShow(releases []releaseNotes, current string, state *updateState) (releaseNotes, bool) {
+	if state == nil || !update.IsRelease(current) {
+		return releaseNotes{}, false
+	}
+	// Versions from before notes were tracked record no version, so any release is an upgrade
+	if state.NotesShown != "" && !update.Newer(current, state.NotesShown) {
+		return releaseNotes{}, false
+	}
+	return notesFor(releases, current)
+}
+
+// notifyReleaseNotes shows what's new at startup after an upgrade, and
+// records that the running version's notes have been seen. Like the update
+// check, it does nothing for development builds, and failing to record the
+// version only means the notes are shown again next time.
+func notifyReleaseNotes() {
+	current := appVersion()
+	if !update.IsRelease(current) {
+		return
+	}
+	path := getUpdateStatePath()
+	state, found := readUpdateState(path)
+	var previous *updateState
+	if found {
+		previous = &state
+	}
+	if notes, ok := releaseNotesToShow(parseChangelog(changelog), current, previous); ok {
+		fmt.Print(formatReleaseNotes(notes, maxStartupNotes))
+		printSeparator()
+	}
+	if state.NotesShown == "" || update.Newer(current, state.NotesShown) {
+		state.NotesShown = current
+		writeUpdateState(path, state)
+	}
+}
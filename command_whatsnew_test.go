@@ -0,0 +1,82 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testChangelog is a changelog with two releases, one with a change that
+// continues on a second line.
+const testChangelog = `# Changelog
+
+Intro text that isn't a change.
+
+## v1.3.0
+
+- Add a where command
+- Show names the way the games
+  spell them
+
+## v1.2.0
+
+- Add search
+`
+
+// TestParseChangelog tests reading releases and their changes from a changelog
+func TestParseChangelog(t *testing.T) {
+	releases := parseChangelog(testChangelog)
+	if len(releases) != 2 || releases[0].Version != "v1.3.0" || releases[1].Version != "v1.2.0" {
+		t.Fatalf("Unexpected releases: %+v", releases)
+	}
+	want := []string{"Add a where command", "Show names the way the games spell them"}
+	if !slices.Equal(releases[0].Changes, want) {
+		t.Errorf("Expected %q, got %q", want, releases[0].Changes)
+	}
+
+	// The embedded changelog must have notes for the whatsnew command to show
+	embedded := parseChangelog(changelog)
+	if len(embedded) == 0 || len(embedded[0].Changes) == 0 {
+		t.Errorf("Expected the embedded changelog to list changes, got %+v", embedded)
+	}
+}
+
+// TestReleaseNotesToShow tests that release notes are shown once, on the
+// first start of a newer release
+func TestReleaseNotesToShow(t *testing.T) {
+	releases := parseChangelog(testChangelog)
+	cases := []struct {
+		name    string
+		current string
+		state   *updateState
+		show    bool
+	}{
+		{"Fresh install", "v1.3.0", nil, false},
+		{"Development build", "dev", &updateState{}, false},
+		{"Upgrade from before notes were tracked", "v1.3.0", &updateState{}, true},
+		{"Upgrade", "v1.3.0", &updateState{NotesShown: "v1.2.0"}, true},
+		{"Already shown", "v1.3.0", &updateState{NotesShown: "v1.3.0"}, false},
+		{"Downgrade", "v1.2.0", &updateState{NotesShown: "v1.3.0"}, false},
+		{"Release without notes", "v1.4.0", &updateState{NotesShown: "v1.3.0"}, false},
+	}
+	for _, c := range cases {
+		notes, show := releaseNotesToShow(releases, c.current, c.state)
+		if show != c.show || (show && notes.Version != c.current) {
+			t.Errorf("%s: expected show=%v, got %v with %+v", c.name, c.show, show, notes)
+		}
+	}
+}
+
+// TestFormatReleaseNotes tests that the startup notes are cut short, pointing
+// to the whatsnew command for the rest
+func TestFormatReleaseNotes(t *testing.T) {
+	notes := releaseNotes{Version: "v1.3.0", Changes: []string{"one", "two", "three"}}
+	message := formatReleaseNotes(notes, 2)
+	if !strings.Contains(message, "What's new in v1.3.0:") || !strings.Contains(message, "- two") ||
+		strings.Contains(message, "- three") || !strings.Contains(message, "...and 1 more") {
+		t.Errorf("Unexpected notes:\n%s", message)
+	}
+	if message := formatReleaseNotes(notes, 0); !strings.Contains(message, "- three") || strings.Contains(message, "more") {
+		t.Errorf("Expected every change, got:\n%s", message)
+	}
+}
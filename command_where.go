@@ -0,0 +1,231 @@
+// This file implements the where command, an overview of the state of the
+// session for users coming back to it: where they are on the map, what limits
+// what they see, which modes are on, and whether anything is unsaved.
+package main
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// whereState is the state of the session shown by the where command.
+type whereState struct {
+	Map       whereMap     `json:"map"`                 // The map page last viewed
+	Location  string       `json:"location,omitempty"`  // The location area explored most recently, if any
+	Found     int          `json:"found"`               // The Pokémon found there
+	Bookmarks int          `json:"bookmarks"`           // The number of bookmarked location areas
+	Filters   whereFilters `json:"filters"`             // The settings that limit what commands show
+	Selection *whereList   `json:"selection,omitempty"` // The numbered list #N refers to, if any
+	Lure      string       `json:"lure,omitempty"`      // The lure in use, if any
+	Rental    string       `json:"rental,omitempty"`    // The rented team, if any
+	Modes     whereModes   `json:"modes"`               // The modes that are on or off
+	Unsaved   int          `json:"unsaved"`             // The changes made since the last save
+	AutoSave  bool         `json:"auto_save"`           // Whether changes are saved automatically
+}
+
+// whereMap is the map page last viewed.
+type whereMap struct {
+	Page      int    `json:"page,omitempty"` // The page number, from 1 (0 if no page has been viewed)
+	Locations int    `json:"locations"`      // The number of location areas on the page
+	Next      bool   `json:"next"`           // Whether there's a next page
+	Previous  bool   `json:"previous"`       // Whether there's a previous page
+	Sort      string `json:"sort,omitempty"` // How pages are ordered ("" for the API's order)
+	PageSize  int    `json:"page_size"`      // How many location areas a page lists
+}
+
+// whereFilters are the settings that limit what commands show.
+type whereFilters struct {
+	VersionGroup string `json:"version_group,omitempty"` // The game moves are limited to, if any
+	CatchPreset  string `json:"catch_preset"`            // The catch rate preset
+	Units        string `json:"units"`                   // The units heights and weights are shown in
+}
+
+// whereList is the numbered list #N refers to.
+type whereList struct {
+	Command string `json:"command"` // The command that listed the items
+	Kind    string `json:"kind"`    // What the items are
+	Items   int    `json:"items"`   // The number of items
+}
+
+// whereModes are the modes that are on or off.
+type whereModes struct {
+	Offline    bool   `json:"offline"`            // Whether API responses come from fixtures instead of the network
+	Fixtures   string `json:"fixtures,omitempty"` // The fixture directory, if offline or recording
+	Recording  bool   `json:"recording"`          // Whether API responses are being recorded to fixtures
+	Dataset    bool   `json:"dataset"`            // Whether the complete species dataset is downloaded
+	Sandbox    bool   `json:"sandbox"`            // Whether the sandbox is on
+	Debug      bool   `json:"debug"`              // Whether debug mode is on
+	Accessible bool   `json:"accessible"`         // Whether accessible output is on
+	Batch      bool   `json:"batch"`              // Whether commands are read from a script
+}
+
+// whereResult shows the state of the session.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - params: Command parameters (unused)
+//
+// Returns:
+//   - The state of the session
+//   - An error, never returned
+func whereResult(cfg *config, params []string) (commandResult, error) {
+	state := currentWhereState(cfg)
+	return commandResult{Message: formatWhereState(state), Data: state}, nil
+}
+
+// currentWhereState collects the state of the session.
+func currentWhereState(cfg *config) whereState {
+	current := cfg.Settings()
+	cfg.mutex.RLock()
+	state := whereState{
+		Map: whereMap{
+			Locations: len(cfg.recentLocations),
+			Next:      cfg.nextLocationURL != nil,
+			Previous:  cfg.prevLocationURL != nil,
+			Sort:      current.mapSort,
+			PageSize:  current.pageSize,
+		},
+		Location:  cfg.exploredLocation,
+		Found:     len(cfg.exploredPokemon),
+		Bookmarks: len(cfg.bookmarks),
+		Unsaved:   cfg.changesSinceSync,
+	}
+	if len(cfg.recentLocations) > 0 {
+		state.Map.Page = locationPageNumber(cfg.prevLocationURL)
+	}
+	cfg.mutex.RUnlock()
+
+	state.Filters = whereFilters{VersionGroup: current.versionGroup, CatchPreset: catchPresetName(current), Units: current.units}
+	if state.Filters.Units == "" {
+		state.Filters.Units = unitsMetric
+	}
+	if selected := cfg.Selection(); len(selected.items) > 0 {
+		state.Selection = &whereList{Command: selected.command, Kind: selected.kind, Items: len(selected.items)}
+	}
+	if lure, ok := cfg.ActiveLure(); ok {
+		state.Lure = formatActiveLure(lure)
+	}
+	if rental, ok := cfg.Rental(); ok {
+		state.Rental = rental.team
+	}
+	var fixtures string
+	var recording bool
+	if cfg.pokeapiClient != nil {
+		fixtures, recording = cfg.pokeapiClient.FixturesDir()
+	}
+	state.Modes = whereModes{
+		Offline:    fixtures != "" && !recording,
+		Fixtures:   fixtures,
+		Recording:  recording,
+		Dataset:    cfg.Dataset().Complete,
+		Sandbox:    cfg.sandbox != nil,
+		Debug:      current.debugMode,
+		Accessible: current.accessible,
+		Batch:      cfg.batch != nil,
+	}
+	state.AutoSave = current.autoSaveEnabled
+	return state
+}
+
+// locationPageNumber works out the number of the map page viewed last from the
+// URL of the page before it: the page after one starting at offset o with l
+// locations is page (o+l)/l+1, and without a page before it, it's page 1.
+func locationPageNumber(prevURL *string) int {
+	if prevURL == nil {
+		return 1
+	}
+	parsed, err := url.Parse(*prevURL)
+	if err != nil {
+		return 0
+	}
+	offset, _ := strconv.Atoi(parsed.Query().Get("offset"))
+	limit, err := strconv.Atoi(parsed.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		return 0
+	}
+	return (offset+limit)/limit + 1
+}
+
+// formatWhereState describes the state of the session, one line per topic.
+func formatWhereState(state whereState) string {
+	var message strings.Builder
+	line := func(format string, args ...any) {
+		message.WriteString(i18n.Sprintf(format, args...) + "\n")
+	}
+
+	switch {
+	case state.Map.Locations == 0:
+		line("Map: no page viewed yet. Use 'map' to start.")
+	case state.Map.Page > 0:
+		line("Map: page %d, with %d location areas", state.Map.Page, state.Map.Locations)
+	default:
+		line("Map: %d location areas", state.Map.Locations)
+	}
+	if state.Location != "" {
+		line("Location: %s (%d Pokémon found)", FormatLocationName(state.Location), state.Found)
+	} else {
+		line("Location: none explored yet")
+	}
+	if state.Bookmarks > 0 {
+		line("Bookmarks: %d", state.Bookmarks)
+	}
+
+	filters := []string{i18n.Sprintf("catch rates %s", state.Filters.CatchPreset), i18n.Sprintf("units %s", state.Filters.Units)}
+	if state.Filters.VersionGroup != "" {
+		filters = append([]string{i18n.Sprintf("moves from %s", FormatLocationName(state.Filters.VersionGroup))}, filters...)
+	}
+	if state.Map.Sort != "" {
+		filters = append(filters, i18n.Sprintf("map sorted by %s", state.Map.Sort))
+	}
+	line("Filters: %s", strings.Join(filters, ", "))
+	if state.Selection != nil {
+		line("Last list: %d items from '%s' (refer to them as #1 to #%d)", state.Selection.Items, state.Selection.Command, state.Selection.Items)
+	}
+	if state.Lure != "" {
+		line("Lure: %s", state.Lure)
+	}
+	if state.Rental != "" {
+		line("Rental team: %s", state.Rental)
+	}
+
+	var modes []string
+	switch {
+	case state.Modes.Recording:
+		modes = append(modes, i18n.Sprintf("recording API responses to %s", state.Modes.Fixtures))
+	case state.Modes.Offline:
+		modes = append(modes, i18n.Sprintf("offline, using fixtures from %s", state.Modes.Fixtures))
+	default:
+		modes = append(modes, i18n.T("online"))
+	}
+	if state.Modes.Dataset {
+		modes = append(modes, i18n.T("complete dataset"))
+	}
+	for _, mode := range []struct {
+		on   bool
+		name string
+	}{
+		{state.Modes.Sandbox, i18n.T("sandbox")},
+		{state.Modes.Debug, i18n.T("debug")},
+		{state.Modes.Accessible, i18n.T("accessible output")},
+		{state.Modes.Batch, i18n.T("batch mode")},
+	} {
+		if mode.on {
+			modes = append(modes, mode.name)
+		}
+	}
+	line("Modes: %s", strings.Join(modes, ", "))
+
+	autoSave := i18n.T("auto-save is on")
+	if !state.AutoSave {
+		autoSave = i18n.T("auto-save is off")
+	}
+	if state.Unsaved > 0 {
+		line("Unsaved changes: %d (%s). Use 'unsaved' to list them.", state.Unsaved, autoSave)
+	} else {
+		line("Unsaved changes: none (%s)", autoSave)
+	}
+	return message.String()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestLocationPageNumber tests working out the map page from the URL of the page before it
+func TestLocationPageNumber(t *testing.T) {
+	url := func(s string) *string { return &s }
+	cases := []struct {
+		prev     *string
+		expected int
+	}{
+		{nil, 1},
+		{url("https://pokeapi.co/api/v2/location-area?offset=0&limit=20"), 2},
+		{url("https://pokeapi.co/api/v2/location-area?offset=40&limit=20"), 4},
+		{url("https://pokeapi.co/api/v2/location-area?offset=0"), 0},
+	}
+	for _, c := range cases {
+		if got := locationPageNumber(c.prev); got != c.expected {
+			t.Errorf("locationPageNumber(%v) == %d, expected %d", c.prev, got, c.expected)
+		}
+	}
+}
+
+// TestWhere tests that the where command describes a fresh session, and one
+// with a map page, an explored location, and unsaved changes
+func TestWhere(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	state := currentWhereState(cfg)
+	if state.Map.Page != 0 || state.Location != "" || state.Unsaved != 0 || !state.AutoSave {
+		t.Errorf("Unexpected state of a fresh session: %+v", state)
+	}
+	message := formatWhereState(state)
+	for _, want := range []string{"no page viewed yet", "none explored yet", "online", "Unsaved changes: none"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected %q in:\n%s", want, message)
+		}
+	}
+
+	prev := "https://pokeapi.co/api/v2/location-area?offset=0&limit=20"
+	cfg.prevLocationURL = &prev
+	cfg.recentLocations = make([]pokeapi.NamedAPIResource, 20)
+	cfg.exploredLocation = "canalave-city-area"
+	cfg.exploredPokemon = map[string]bool{"tentacool": true, "wingull": true}
+	cfg.changesSinceSync = 3
+	cfg.sandbox = &sandboxState{}
+
+	state = currentWhereState(cfg)
+	if state.Map.Page != 2 || state.Found != 2 || state.Unsaved != 3 || !state.Modes.Sandbox {
+		t.Errorf("Unexpected state: %+v", state)
+	}
+	message = formatWhereState(state)
+	for _, want := range []string{"page 2, with 20 location areas", "Canalave City Area (2 Pokémon found)", "sandbox", "Unsaved changes: 3"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected %q in:\n%s", want, message)
+		}
+	}
+}
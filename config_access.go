@@ -0,0 +1,601 @@
+// This file contains the accessor methods for the shared state in config.
+// Commands read and change the settings, the explored area, and the user's
+// money, bag, lure, rental team, redeemed codes, trainer ID, challenges, and bookmarks only through these methods, which take the config mutex
+// themselves, so that no command can forget to lock. The Pokédex has its own lock (see internal/pokedex).
+package main
+
+import (
+	"errors"
+	"maps"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// maxTrainerID is the highest trainer ID, which has five digits as in the games.
+const maxTrainerID = 65535
+
+// ErrNotEnoughMoney is returned when the user can't afford a purchase.
+var ErrNotEnoughMoney = errors.New("not enough money")
+
+// settings holds the user's preferences, which commands can change while the app runs.
+type settings struct {
+	autoSaveEnabled  bool                 // Whether to automatically save after changes
+	autoSaveInterval int                  // How many changes before auto-saving (if enabled)
+	autoSaveEvery    time.Duration        // How often to auto-save unsaved changes in the background (if enabled), or 0 for never
+	mapSort          string               // How map pages are ordered: "" (API order), "name", or "region"
+	pageSize         int                  // How many location areas a map page lists
+	units            string               // Units for heights and weights: unitsMetric or unitsImperial
+	debugMode        bool                 // Whether to show detailed error messages
+	accessible       bool                 // Whether output is plain and deterministic for screen readers
+	theme            string               // How command results are drawn: a name in themes, or "" for the classic theme
+	partySize        int                  // Maximum number of Pokémon the user can have with them
+	versionGroup     string               // The version group moves are limited to (e.g. "red-blue"), or "" for every game
+	mqttBroker       string               // The URL of the MQTT broker events are published to, or "" to publish nothing
+	mqttTopic        string               // The MQTT topic events are published to
+	backupRemote     string               // The git remote the save file is backed up to, or "" to keep no backups
+	backupEvery      int                  // How many backups to make between pushes, or 0 to push only with 'backup push'
+	usageTracking    bool                 // Whether the commands the user runs are counted (see usage_utils.go)
+	usageEndpoint    string               // The URL 'usage report' sends the counts to, or "" for none
+	reportSMTP       string               // The URL of the SMTP server weekly reports are emailed through, or "" for none
+	reportFrom       string               // The address weekly reports are emailed from
+	reportTo         string               // The address weekly reports are emailed to
+	catchPreset      string               // The catch rate preset: "" (casual), "authentic", or "custom"
+	catchCustom      *pokedex.CatchTuning // The values of the custom preset, or nil if they haven't been set
+}
+
+// defaultSettings returns the settings used until the user changes them.
+func defaultSettings() settings {
+	return settings{
+		autoSaveEnabled:  true, // Auto-save is enabled by default
+		autoSaveInterval: 1,    // Save after every change by default
+		partySize:        defaultPartySize,
+		pageSize:         pokeapi.DefaultLocationPageSize,
+	}
+}
+
+// Settings returns a copy of the current settings.
+func (cfg *config) Settings() settings {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return cfg.settings
+}
+
+// UpdateSettings changes the settings while holding the config lock.
+//
+// Parameters:
+//   - update: A function that modifies the settings it's given
+//
+// Returns:
+//   - The settings after the update
+func (cfg *config) UpdateSettings(update func(s *settings)) settings {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	update(&cfg.settings)
+	return cfg.settings
+}
+
+// SetExploredArea remembers the location area explored last and the Pokémon found there.
+//
+// Parameters:
+//   - location: The API name of the location area
+//   - pokemon: The API names of the Pokémon that can be found there
+func (cfg *config) SetExploredArea(location string, pokemon []string) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.exploredLocation = location
+	cfg.exploredPokemon = make(map[string]bool, len(pokemon))
+	for _, name := range pokemon {
+		cfg.exploredPokemon[name] = true
+	}
+}
+
+// ExploredLocationOf returns the location area explored last if the given
+// Pokémon can be found there, or an empty string otherwise.
+func (cfg *config) ExploredLocationOf(pokemon string) string {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	if cfg.exploredPokemon[pokemon] {
+		return cfg.exploredLocation
+	}
+	return ""
+}
+
+// ExploredPokemon returns the API names of the Pokémon found in the location
+// area explored last, in alphabetical order.
+func (cfg *config) ExploredPokemon() []string {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return slices.Sorted(maps.Keys(cfg.exploredPokemon))
+}
+
+// SetSelection remembers the numbered list a command has just shown, so later
+// commands can refer to its items as #N. It replaces the previous list.
+//
+// Parameters:
+//   - kind: What the items are (selectPokemon or selectMove)
+//   - command: The command that listed them
+//   - items: The items' API names, in the order they were numbered from 1
+func (cfg *config) SetSelection(kind, command string, items []string) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.selection = selection{kind: kind, command: command, items: slices.Clone(items)}
+}
+
+// Selection returns the numbered list shown last.
+func (cfg *config) Selection() selection {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return cfg.selection
+}
+
+// ExploredArea returns the location area explored last, or "" if none has been explored.
+func (cfg *config) ExploredArea() string {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return cfg.exploredLocation
+}
+
+// Money returns the amount of money the user has earned.
+func (cfg *config) Money() int {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return cfg.money
+}
+
+// AddMoney adds to the user's money. A negative amount spends money.
+//
+// Parameters:
+//   - amount: The amount to add
+//
+// Returns:
+//   - The new balance
+func (cfg *config) AddMoney(amount int) int {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.money += amount
+	return cfg.money
+}
+
+// Items returns a copy of the items in the user's bag, with their quantities.
+func (cfg *config) Items() map[string]int {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return maps.Clone(cfg.items)
+}
+
+// BuyItem spends money on items and puts them in the user's bag.
+// Nothing is bought if the user can't afford the total price.
+//
+// Parameters:
+//   - item: The API name of the item
+//   - quantity: How many to buy
+//   - price: The price of one item
+//
+// Returns:
+//   - The new balance
+//   - ErrNotEnoughMoney if the user can't afford the items
+func (cfg *config) BuyItem(item string, quantity, price int) (int, error) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if total := quantity * price; total > cfg.money {
+		return cfg.money, ErrNotEnoughMoney
+	}
+	cfg.money -= quantity * price
+	if cfg.items == nil {
+		cfg.items = make(map[string]int)
+	}
+	cfg.items[item] += quantity
+	return cfg.money, nil
+}
+
+// AddItem puts items in the user's bag without spending money.
+//
+// Parameters:
+//   - item: The API name of the item
+//   - quantity: How many to add
+func (cfg *config) AddItem(item string, quantity int) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.items == nil {
+		cfg.items = make(map[string]int)
+	}
+	cfg.items[item] += quantity
+}
+
+// UseItem takes one of an item out of the user's bag.
+//
+// Parameters:
+//   - item: The API name of the item
+//
+// Returns:
+//   - Whether the bag had the item
+func (cfg *config) UseItem(item string) bool {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.items[item] == 0 {
+		return false
+	}
+	cfg.items[item]--
+	if cfg.items[item] == 0 {
+		delete(cfg.items, item)
+	}
+	return true
+}
+
+// ActiveLure returns the lure in use, and false if there isn't one.
+func (cfg *config) ActiveLure() (pokedex.Lure, bool) {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	if cfg.lure == nil {
+		return pokedex.Lure{}, false
+	}
+	return *cfg.lure, true
+}
+
+// SetLure starts using a lure, replacing any lure already in use.
+func (cfg *config) SetLure(lure pokedex.Lure) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.lure = &lure
+}
+
+// ConsumeLure counts an encounter in a location area against the lure in use
+// there. The lure wears off once it has no encounters left.
+//
+// Parameters:
+//   - location: The API name of the location area of the encounter
+//
+// Returns:
+//   - The lure, with the encounters it has left after this one
+//   - Whether a lure was in use in the location area
+func (cfg *config) ConsumeLure(location string) (pokedex.Lure, bool) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.lure == nil || cfg.lure.Location != location {
+		return pokedex.Lure{}, false
+	}
+	cfg.lure.Remaining--
+	lure := *cfg.lure
+	if lure.Remaining <= 0 {
+		cfg.lure = nil
+	}
+	return lure, true
+}
+
+// Rental returns the rented team, and false if no team is rented.
+func (cfg *config) Rental() (rentalParty, bool) {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	if cfg.rental == nil {
+		return rentalParty{}, false
+	}
+	return *cfg.rental, true
+}
+
+// SetRental rents a team, replacing any team already rented, or returns the
+// rented team if rental is nil.
+func (cfg *config) SetRental(rental *rentalParty) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.rental = rental
+}
+
+// Redeemed reports whether a distribution code has already been redeemed.
+//
+// Parameters:
+//   - code: The code in canonical form
+func (cfg *config) Redeemed(code string) bool {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return cfg.redeemedCodes[code]
+}
+
+// MarkRedeemed records that a distribution code has been redeemed, so it can't be used again.
+//
+// Parameters:
+//   - code: The code in canonical form
+func (cfg *config) MarkRedeemed(code string) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.redeemedCodes == nil {
+		cfg.redeemedCodes = make(map[string]bool)
+	}
+	cfg.redeemedCodes[code] = true
+}
+
+// RedeemedCodes returns the distribution codes that have been redeemed, sorted.
+func (cfg *config) RedeemedCodes() []string {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return slices.Sorted(maps.Keys(cfg.redeemedCodes))
+}
+
+// TrainerID returns the user's trainer ID, assigning a random one from 1 to
+// maxTrainerID the first time it's needed, as the games do when a new game starts.
+//
+// Returns:
+//   - The trainer ID
+//   - Whether it was assigned just now, and so hasn't been saved yet
+func (cfg *config) TrainerID() (int, bool) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.trainerID != 0 {
+		return cfg.trainerID, false
+	}
+	cfg.trainerID = rand.Intn(maxTrainerID) + 1
+	return cfg.trainerID, true
+}
+
+// trainerIDIfAssigned returns the user's trainer ID, or zero if none has been
+// assigned yet, for saving.
+func (cfg *config) trainerIDIfAssigned() int {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return cfg.trainerID
+}
+
+// Challenges returns a copy of the user-defined challenges the user has started, by ID.
+func (cfg *config) Challenges() map[string]pokedex.ChallengeState {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return maps.Clone(cfg.challenges)
+}
+
+// SetChallenge records the user's progress on a user-defined challenge,
+// replacing any earlier progress.
+//
+// Parameters:
+//   - id: The challenge's ID
+//   - state: When it was started and completed
+func (cfg *config) SetChallenge(id string, state pokedex.ChallengeState) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.challenges == nil {
+		cfg.challenges = make(map[string]pokedex.ChallengeState)
+	}
+	cfg.challenges[id] = state
+}
+
+// RemoveChallenge forgets a user-defined challenge the user started.
+//
+// Parameters:
+//   - id: The challenge's ID
+//
+// Returns:
+//   - Whether the challenge had been started
+func (cfg *config) RemoveChallenge(id string) bool {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if _, ok := cfg.challenges[id]; !ok {
+		return false
+	}
+	delete(cfg.challenges, id)
+	return true
+}
+
+// Reminders returns the reminders and scheduled commands that haven't come
+// up yet, soonest first.
+func (cfg *config) Reminders() []pokedex.Reminder {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return slices.Clone(cfg.reminders)
+}
+
+// AddReminder adds a reminder or scheduled command, keeping them soonest first.
+//
+// Parameters:
+//   - reminder: The reminder or scheduled command
+func (cfg *config) AddReminder(reminder pokedex.Reminder) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	i, _ := slices.BinarySearchFunc(cfg.reminders, reminder, compareReminders)
+	cfg.reminders = slices.Insert(cfg.reminders, i, reminder)
+}
+
+// RemoveReminder removes a reminder or scheduled command.
+//
+// Parameters:
+//   - reminder: The reminder or scheduled command, as returned by Reminders
+//
+// Returns:
+//   - Whether it was removed (false if it had already come up or been removed)
+func (cfg *config) RemoveReminder(reminder pokedex.Reminder) bool {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	i := slices.Index(cfg.reminders, reminder)
+	if i < 0 {
+		return false
+	}
+	cfg.reminders = slices.Delete(cfg.reminders, i, i+1)
+	return true
+}
+
+// TakeDueReminders returns the reminders and scheduled commands that have come
+// up. Reminders are removed, and scheduled commands are moved on to the next
+// time they come up after now (see nextReminderDue).
+//
+// Parameters:
+//   - now: The current time
+//
+// Returns:
+//   - The reminders and scheduled commands that came up, soonest first
+func (cfg *config) TakeDueReminders(now time.Time) []pokedex.Reminder {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	var due, waiting []pokedex.Reminder
+	for _, reminder := range cfg.reminders {
+		if reminder.Due.After(now) {
+			waiting = append(waiting, reminder)
+			continue
+		}
+		due = append(due, reminder)
+		if reminder.Every != "" {
+			reminder.Due = nextReminderDue(reminder, now)
+			waiting = append(waiting, reminder)
+		}
+	}
+	if len(due) > 0 {
+		slices.SortStableFunc(waiting, compareReminders)
+		cfg.reminders = waiting
+	}
+	return due
+}
+
+// Macros returns a copy of the user's macros: the commands each runs, by the
+// macro's name.
+func (cfg *config) Macros() map[string]string {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return maps.Clone(cfg.macros)
+}
+
+// Macro returns the commands a macro runs.
+//
+// Parameters:
+//   - name: The macro's name
+//
+// Returns:
+//   - The commands, with their placeholders
+//   - Whether there's a macro with that name
+func (cfg *config) Macro(name string) (string, bool) {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	body, ok := cfg.macros[name]
+	return body, ok
+}
+
+// SetMacro defines a macro, replacing any earlier one with the same name.
+//
+// Parameters:
+//   - name: The macro's name
+//   - body: The commands it runs, with their placeholders
+func (cfg *config) SetMacro(name, body string) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if cfg.macros == nil {
+		cfg.macros = make(map[string]string)
+	}
+	cfg.macros[name] = body
+}
+
+// RemoveMacro forgets a macro.
+//
+// Parameters:
+//   - name: The macro's name
+//
+// Returns:
+//   - Whether there was a macro with that name
+func (cfg *config) RemoveMacro(name string) bool {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if _, ok := cfg.macros[name]; !ok {
+		return false
+	}
+	delete(cfg.macros, name)
+	return true
+}
+
+// Bookmarks returns the location areas the user bookmarked, in the order added.
+func (cfg *config) Bookmarks() []string {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	return slices.Clone(cfg.bookmarks)
+}
+
+// AddBookmark bookmarks a location area.
+//
+// Parameters:
+//   - location: The API name of the location area
+//
+// Returns:
+//   - Whether it was added (false if it was already bookmarked)
+func (cfg *config) AddBookmark(location string) bool {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	if slices.Contains(cfg.bookmarks, location) {
+		return false
+	}
+	cfg.bookmarks = append(cfg.bookmarks, location)
+	return true
+}
+
+// RemoveBookmark removes a location area from the bookmarks.
+//
+// Parameters:
+//   - location: The API name of the location area
+//
+// Returns:
+//   - Whether it was removed (false if it wasn't bookmarked)
+func (cfg *config) RemoveBookmark(location string) bool {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	i := slices.Index(cfg.bookmarks, location)
+	if i < 0 {
+		return false
+	}
+	cfg.bookmarks = slices.Delete(cfg.bookmarks, i, i+1)
+	return true
+}
+
+// MapState returns where the user is exploring the map, to be saved, or nil
+// if they haven't viewed the map, explored, or bookmarked anything.
+func (cfg *config) MapState() *pokedex.MapState {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	state := pokedex.MapState{
+		Explored:  cfg.exploredLocation,
+		Found:     slices.Sorted(maps.Keys(cfg.exploredPokemon)),
+		Bookmarks: slices.Clone(cfg.bookmarks),
+	}
+	if cfg.mapViewedThisSession {
+		if cfg.nextLocationURL != nil {
+			state.Next = *cfg.nextLocationURL
+		}
+		if cfg.prevLocationURL != nil {
+			state.Previous = *cfg.prevLocationURL
+		}
+		for _, location := range cfg.recentLocations {
+			state.Locations = append(state.Locations, location.Name)
+		}
+	}
+	if state.Explored == "" && len(state.Bookmarks) == 0 && len(state.Locations) == 0 {
+		return nil
+	}
+	return &state
+}
+
+// RestoreMapState puts the user back where they were exploring the map, as
+// saved by MapState. The map page viewed last is restored without asking the
+// API for it again, so 'next', 'prev', and 'explore' carry on from it.
+//
+// Parameters:
+//   - state: The saved map state, or nil to start from scratch
+func (cfg *config) RestoreMapState(state *pokedex.MapState) {
+	if state == nil {
+		state = &pokedex.MapState{}
+	}
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.nextLocationURL, cfg.prevLocationURL = nil, nil
+	if state.Next != "" {
+		cfg.nextLocationURL = &state.Next
+	}
+	if state.Previous != "" {
+		cfg.prevLocationURL = &state.Previous
+	}
+	cfg.recentLocations = nil
+	for _, location := range state.Locations {
+		cfg.recentLocations = append(cfg.recentLocations, pokeapi.NamedAPIResource{Name: location})
+	}
+	cfg.mapViewedThisSession = len(state.Locations) > 0
+	cfg.exploredLocation = state.Explored
+	cfg.exploredPokemon = make(map[string]bool, len(state.Found))
+	for _, name := range state.Found {
+		cfg.exploredPokemon[name] = true
+	}
+	cfg.bookmarks = slices.Clone(state.Bookmarks)
+}
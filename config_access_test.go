@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestExploredLocationOf tests that catches are only placed in the explored area
+// if the Pokémon can be found there
+func TestExploredLocationOf(t *testing.T) {
+	cfg := &config{}
+	if got := cfg.ExploredLocationOf("pikachu"); got != "" {
+		t.Errorf("Expected no location before exploring, got %q", got)
+	}
+
+	cfg.SetExploredArea("viridian-forest-area", []string{"pikachu", "caterpie"})
+	if got := cfg.ExploredLocationOf("pikachu"); got != "viridian-forest-area" {
+		t.Errorf("ExploredLocationOf(pikachu) = %q, expected viridian-forest-area", got)
+	}
+	if got := cfg.ExploredLocationOf("onix"); got != "" {
+		t.Errorf("ExploredLocationOf(onix) = %q, expected none", got)
+	}
+}
+
+// TestUpdateSettingsConcurrentUse tests that settings can be changed from several goroutines.
+// Run with -race to check for unsynchronized access.
+func TestUpdateSettingsConcurrentUse(t *testing.T) {
+	cfg := &config{settings: defaultSettings()}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				cfg.UpdateSettings(func(s *settings) { s.autoSaveInterval++ })
+				_ = cfg.Settings()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := cfg.Settings().autoSaveInterval; got != 401 {
+		t.Errorf("autoSaveInterval = %d, expected 401", got)
+	}
+}
+
+// TestAddMoney tests that money can be earned and spent
+func TestAddMoney(t *testing.T) {
+	cfg := &config{}
+	if balance := cfg.AddMoney(120); balance != 120 {
+		t.Errorf("Expected a balance of 120, got %d", balance)
+	}
+	if balance := cfg.AddMoney(-20); balance != 100 || cfg.Money() != 100 {
+		t.Errorf("Expected a balance of 100, got %d", cfg.Money())
+	}
+}
+
+// TestBuyAndUseItem tests that items are only bought when affordable and are used up one at a time
+func TestBuyAndUseItem(t *testing.T) {
+	cfg := &config{money: 1000}
+
+	if _, err := cfg.BuyItem("ultra-ball", 2, 800); !errors.Is(err, ErrNotEnoughMoney) {
+		t.Errorf("Expected ErrNotEnoughMoney, got %v", err)
+	}
+	if cfg.Money() != 1000 || len(cfg.Items()) != 0 {
+		t.Errorf("Expected nothing to be bought, got ₽%d and %v", cfg.Money(), cfg.Items())
+	}
+
+	balance, err := cfg.BuyItem("great-ball", 1, 600)
+	if err != nil || balance != 400 {
+		t.Fatalf("Expected a balance of 400, got %d (err=%v)", balance, err)
+	}
+	if !cfg.UseItem("great-ball") {
+		t.Error("Expected the great ball to be used")
+	}
+	if cfg.UseItem("great-ball") {
+		t.Error("Expected no great balls to be left")
+	}
+	if _, ok := cfg.Items()["great-ball"]; ok {
+		t.Error("Expected used-up items to be removed from the bag")
+	}
+}
+
+// TestMarkRedeemed tests that redeemed codes are remembered
+func TestMarkRedeemed(t *testing.T) {
+	cfg := &config{}
+	if cfg.Redeemed("POKEMON-PIKACHU-451AE6F13C") {
+		t.Error("Expected the code not to be redeemed yet")
+	}
+	cfg.MarkRedeemed("POKEMON-PIKACHU-451AE6F13C")
+	if !cfg.Redeemed("POKEMON-PIKACHU-451AE6F13C") {
+		t.Error("Expected the code to be redeemed")
+	}
+	if codes := cfg.RedeemedCodes(); len(codes) != 1 {
+		t.Errorf("Expected one redeemed code, got %v", codes)
+	}
+}
+
+// TestConsumeLure tests that a lure only counts encounters in its own area and
+// wears off when it has none left
+func TestConsumeLure(t *testing.T) {
+	cfg := &config{}
+	if _, ok := cfg.ConsumeLure("viridian-forest-area"); ok {
+		t.Fatal("Expected no lure before one is used")
+	}
+
+	cfg.SetLure(pokedex.Lure{Item: "honey", Location: "viridian-forest-area", Target: "bug", Remaining: 2})
+	if _, ok := cfg.ConsumeLure("mt-moon-1f"); ok {
+		t.Error("Expected the lure not to apply in another area")
+	}
+	if lure, ok := cfg.ConsumeLure("viridian-forest-area"); !ok || lure.Remaining != 1 {
+		t.Errorf("Expected the lure to have 1 encounter left, got %+v, %v", lure, ok)
+	}
+	if lure, ok := cfg.ConsumeLure("viridian-forest-area"); !ok || lure.Remaining != 0 {
+		t.Errorf("Expected the lure to be used up, got %+v, %v", lure, ok)
+	}
+	if _, ok := cfg.ActiveLure(); ok {
+		t.Error("Expected the lure to wear off once used up")
+	}
+}
+
+// TestMapStateRoundTrip tests that the map page, the explored area, and the
+// bookmarks are restored in the next session, through the save file
+func TestMapStateRoundTrip(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New()}
+	if cfg.MapState() != nil {
+		t.Error("Expected no map state before exploring")
+	}
+
+	next := "https://pokeapi.co/api/v2/location-area?offset=40&limit=20"
+	UpdateLocationState(cfg, pokeapi.LocationAreasResp{
+		Next:    &next,
+		Results: []pokeapi.NamedAPIResource{{Name: "pallet-town-area"}, {Name: "viridian-forest-area"}},
+	}, true)
+	cfg.SetExploredArea("viridian-forest-area", []string{"pikachu", "caterpie"})
+	cfg.AddBookmark("viridian-forest-area")
+	cfg.AddBookmark("mt-moon-1f")
+	if cfg.AddBookmark("mt-moon-1f") {
+		t.Error("Expected a location to be bookmarked only once")
+	}
+	if err := writeSaveFile(cfg); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	restored := &config{pokedex: pokedex.New()}
+	if err := loadPokedexData(restored); err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if !restored.mapViewedThisSession || restored.nextLocationURL == nil || *restored.nextLocationURL != next || restored.prevLocationURL != nil {
+		t.Errorf("Expected the map page to be restored, got next=%v, prev=%v", restored.nextLocationURL, restored.prevLocationURL)
+	}
+	if location, err := locationFromParams(restored, []string{"2"}); err != nil || location != "viridian-forest-area" {
+		t.Errorf("Expected the page's locations to be restored, got %q, %v", location, err)
+	}
+	if got := restored.ExploredLocationOf("pikachu"); got != "viridian-forest-area" {
+		t.Errorf("Expected the explored area to be restored, got %q", got)
+	}
+	if got := restored.Bookmarks(); !slices.Equal(got, []string{"viridian-forest-area", "mt-moon-1f"}) {
+		t.Errorf("Expected the bookmarks to be restored in order, got %v", got)
+	}
+
+	// Bookmarks can be explored by name
+	if location, err := locationFromParams(restored, []string{"mt", "moon", "1f"}); err != nil || location != "mt-moon-1f" {
+		t.Errorf("Expected the bookmark by name, got %q, %v", location, err)
+	}
+	if !restored.RemoveBookmark("mt-moon-1f") || restored.RemoveBookmark("mt-moon-1f") {
+		t.Error("Expected a bookmark to be removed once")
+	}
+}
@@ -0,0 +1,73 @@
+// This file contains the confirmation prompts used by commands that make
+// changes which are hard to undo, such as clearing the Pokédex or evolving a
+// Pokémon. Every yes/no question goes through confirm, so that the global
+// --yes flag and batch mode apply to all of them in the same way.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// inputReader returns the reader for user input, creating one for stdin if needed.
+// The REPL and confirmation prompts share this reader so that input buffered by
+// one is not lost to the other.
+func inputReader(cfg *config) *bufio.Reader {
+	if cfg.input == nil {
+		cfg.input = bufio.NewReader(os.Stdin)
+	}
+	return cfg.input
+}
+
+// confirm asks the user a yes/no question and reports whether they answered yes.
+// The answer is decided without asking in three cases:
+//   - With --dry-run, every question is answered yes, since nothing will be changed
+//   - With the --yes flag, every question is answered yes
+//   - In batch mode, there's nobody to answer and reading would consume the next
+//     command, so the question gets the default answer of no
+//
+// Otherwise, anything other than "y" or "yes" (or their translation in the selected
+// language) counts as no, including end of input.
+//
+// Parameters:
+//   - cfg: The application configuration containing the input reader and prompt settings
+//   - question: The question to ask, without the "(y/N)" suffix
+//
+// Returns:
+//   - true if the question was answered yes
+func confirm(cfg *config, question string) bool {
+	switch {
+	case cfg.dryRun:
+		i18n.Printf("%s (y/N): yes (--dry-run)\n", question)
+		return true
+	case cfg.assumeYes:
+		i18n.Printf("%s (y/N): yes (--yes)\n", question)
+		return true
+	case cfg.batch != nil:
+		i18n.Printf("%s (y/N): no (input is not interactive; start with --yes to answer yes)\n", question)
+		return false
+	}
+
+	i18n.Printf("%s (y/N): ", question)
+	response, err := readLine(cfg)
+	if err != nil && response == "" {
+		fmt.Println()
+		return false
+	}
+	response = strings.ToLower(strings.TrimSpace(response))
+	return isYes(response)
+}
+
+// isYes reports whether a response means yes, in English or the selected language.
+func isYes(response string) bool {
+	for _, yes := range []string{"y", "yes"} {
+		if response == yes || response == i18n.T(yes) {
+			return true
+		}
+	}
+	return false
+}
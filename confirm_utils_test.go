@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// TestConfirm tests the answers to confirmation prompts in each mode
+func TestConfirm(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		assumeYes bool
+		batch     bool
+		want      bool
+	}{
+		{name: "yes", input: "y\n", want: true},
+		{name: "full yes", input: " YES \n", want: true},
+		{name: "no", input: "n\n", want: false},
+		{name: "empty answer", input: "\n", want: false},
+		{name: "end of input", input: "", want: false},
+		{name: "answer without newline", input: "y", want: true},
+		{name: "--yes flag", input: "n\n", assumeYes: true, want: true},
+		{name: "batch mode", input: "y\n", batch: true, want: false},
+		{name: "--yes in batch mode", input: "", assumeYes: true, batch: true, want: true},
+	}
+
+	for _, c := range cases {
+		cfg := &config{input: bufio.NewReader(strings.NewReader(c.input)), assumeYes: c.assumeYes}
+		if c.batch {
+			cfg.batch = &batchResults{}
+		}
+		if got := confirm(cfg, "Proceed?"); got != c.want {
+			t.Errorf("%s: confirm() = %v, expected %v", c.name, got, c.want)
+		}
+
+		// Prompts answered without asking must not consume input meant for the REPL
+		if c.assumeYes || c.batch {
+			if rest, _ := io.ReadAll(cfg.input); string(rest) != c.input {
+				t.Errorf("%s: expected the input to be left unread, %q remains", c.name, rest)
+			}
+		}
+	}
+}
+
+// TestConfirmTranslatedAnswers tests that "yes" can be answered in the selected language
+func TestConfirmTranslatedAnswers(t *testing.T) {
+	defer i18n.SetLanguage(i18n.Default)
+
+	if isYes("sí") {
+		t.Error("Expected 'sí' not to count as yes in English")
+	}
+	i18n.SetLanguage("es")
+	for _, answer := range []string{"s", "sí", "y", "yes"} {
+		if !isYes(answer) {
+			t.Errorf("Expected %q to count as yes in Spanish", answer)
+		}
+	}
+	if isYes("n") {
+		t.Error("Expected 'n' not to count as yes")
+	}
+}
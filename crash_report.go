@@ -0,0 +1,203 @@
+// This file writes crash reports. When a command panics, or the program itself
+// does, a report with the stack trace, the latest save log records, the
+// settings, and the version is written to the crash report directory, so that
+// a bug report can include everything needed to track the crash down. The
+// home directory is replaced with "~" wherever it appears in the report, and
+// passwords and email addresses are left out of the settings.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// crashSaveLogRecords is how many of the latest save log records a crash
+// report includes.
+const crashSaveLogRecords = 10
+
+// crashReport is what's known about a crash, written to a file as JSON.
+type crashReport struct {
+	Time     time.Time       `json:"time"`               // When the crash happened
+	Version  string          `json:"version"`            // The application version
+	Go       string          `json:"go"`                 // The Go version the application was built with
+	Platform string          `json:"platform"`           // The operating system and architecture (e.g. "linux/amd64")
+	Command  string          `json:"command,omitempty"`  // The command that crashed, or "" if the program itself did
+	Panic    string          `json:"panic"`              // The value the program panicked with
+	Stack    string          `json:"stack"`              // The stack trace of the panic
+	SaveFile string          `json:"save_file"`          // The save file in use
+	Settings map[string]any  `json:"settings,omitempty"` // The settings, unless the crash left them locked
+	SaveLog  []saveLogRecord `json:"save_log"`           // The latest writes to the save file, oldest first
+}
+
+// newCrashReport gathers what's known about a crash.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - command: The command that crashed, or "" if the program itself did
+//   - value: The value the program panicked with
+//   - stack: The stack trace of the panic
+//   - now: The time of the crash
+//
+// Returns:
+//   - The crash report, with the home directory replaced with "~"
+func newCrashReport(cfg *config, command string, value any, stack []byte, now time.Time) crashReport {
+	report := crashReport{
+		Time:     now,
+		Version:  appVersion(),
+		Go:       runtime.Version(),
+		Platform: runtime.GOOS + "/" + runtime.GOARCH,
+		Command:  command,
+		Panic:    redactHome(fmt.Sprint(value)),
+		Stack:    redactHome(string(stack)),
+	}
+	if path, err := getSaveFilePath(cfg); err == nil {
+		report.SaveFile = redactHome(path)
+	}
+
+	// The crash may have left the config locked, as recoverMiddleware warns, so
+	// the settings are left out rather than waited for
+	if cfg.mutex.TryRLock() {
+		report.Settings = crashSettings(cfg.settings)
+		cfg.mutex.RUnlock()
+	}
+
+	if path, err := getSaveLogPath(); err == nil {
+		records, _ := readSaveLog(path)
+		records = records[max(len(records)-crashSaveLogRecords, 0):]
+		for i := range records {
+			records[i].Error = redactHome(records[i].Error)
+		}
+		report.SaveLog = records
+	}
+	return report
+}
+
+// crashSettings returns the settings for a crash report. Passwords are taken
+// out of URLs, as are the user names of backup remotes, which may be tokens,
+// and only whether the weekly report addresses are set is kept.
+func crashSettings(s settings) map[string]any {
+	return map[string]any{
+		"auto_save":          s.autoSaveEnabled,
+		"auto_save_interval": s.autoSaveInterval,
+		"auto_save_every":    s.autoSaveEvery.String(),
+		"map_sort":           s.mapSort,
+		"page_size":          s.pageSize,
+		"units":              s.units,
+		"debug":              s.debugMode,
+		"accessible":         s.accessible,
+		"theme":              s.theme,
+		"party_size":         s.partySize,
+		"version_group":      s.versionGroup,
+		"mqtt_broker":        redactURL(s.mqttBroker),
+		"mqtt_topic":         s.mqttTopic,
+		"backup_remote":      redactHome(displayRemote(s.backupRemote)),
+		"backup_every":       s.backupEvery,
+		"usage_tracking":     s.usageTracking,
+		"usage_endpoint":     redactURL(s.usageEndpoint),
+		"report_smtp":        redactURL(s.reportSMTP),
+		"report_email":       s.reportFrom != "" || s.reportTo != "",
+		"catch_preset":       s.catchPreset,
+	}
+}
+
+// redactHome replaces the user's home directory with "~" wherever it appears
+// in text, so that the user's name isn't given away by the paths in a report.
+func redactHome(text string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" || home == string(filepath.Separator) {
+		return text
+	}
+	return strings.ReplaceAll(text, home, "~")
+}
+
+// redactURL replaces the password in a URL with "xxxxx", even in a URL that
+// doesn't parse, such as one with an invalid port, or that has no scheme.
+// Anything else, such as a path, is returned as it is.
+func redactURL(text string) string {
+	u, err := url.Parse(text)
+	if err == nil && u.Host != "" {
+		return u.Redacted()
+	}
+	prefix, rest := "", text
+	if scheme, afterScheme, ok := strings.Cut(text, "://"); ok {
+		prefix, rest = scheme+"://", afterScheme
+	}
+	at := strings.LastIndex(rest, "@")
+	if at < 0 {
+		return text
+	}
+	user, _, hasPassword := strings.Cut(rest[:at], ":")
+	if !hasPassword || strings.ContainsAny(user, "/\\") {
+		return text
+	}
+	return prefix + user + ":xxxxx" + rest[at:]
+}
+
+// writeCrashReport writes a crash report to a new file in the crash report
+// directory (see paths_utils.go).
+//
+// Parameters:
+//   - report: The crash report
+//
+// Returns:
+//   - The path of the file written
+//   - An error if the file couldn't be written
+func writeCrashReport(report crashReport) (string, error) {
+	dir := crashReportDir.path()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	name := "crash-" + report.Time.UTC().Format("20060102-150405.000000000") + ".json"
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// reportCrash writes a crash report and tells the user where it is, or that
+// it couldn't be written.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - command: The command that crashed, or "" if the program itself did
+//   - value: The value the program panicked with
+//   - stack: The stack trace of the panic
+func reportCrash(cfg *config, command string, value any, stack []byte) {
+	path, err := writeCrashReport(newCrashReport(cfg, command, value, stack, time.Now()))
+	if err != nil {
+		i18n.Printf("Warning: Could not write a crash report: %v\n", err)
+		return
+	}
+	i18n.Printf("A crash report was written to %s. Please attach it if you report this bug.\n", path)
+}
+
+// reportFatalCrash writes a crash report if the program panics outside a
+// command (recoverMiddleware reports the crashes of commands), and then ends
+// the program the way an unrecovered panic would. main defers it.
+//
+// Parameters:
+//   - cfg: The application configuration
+func reportFatalCrash(cfg *config) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	stack := debug.Stack()
+	reportCrash(cfg, "", r, stack)
+	fmt.Fprintf(os.Stderr, "panic: %v\n\n%s", r, stack)
+	os.Exit(2)
+}
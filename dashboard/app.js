@@ -0,0 +1,38 @@
+// Filters the collection on the Pokédex dashboard by name, type, and place.
+(function () {
+  const search = document.getElementById("search");
+  const type = document.getElementById("type");
+  const place = document.getElementById("place");
+  if (!search) {
+    return;
+  }
+  const cards = Array.from(document.querySelectorAll("#collection .card"));
+  const shown = document.getElementById("shown");
+  const noMatches = document.getElementById("no-matches");
+
+  // Ignores accents and case, so "flabebe" finds "Flabébé"
+  function fold(text) {
+    return text.normalize("NFD").replace(/\p{Mn}/gu, "").toLowerCase();
+  }
+
+  function applyFilters() {
+    const text = fold(search.value.trim());
+    let count = 0;
+    for (const card of cards) {
+      const visible =
+        fold(card.dataset.name).includes(text) &&
+        (type.value === "" || card.dataset.types.split(" ").includes(type.value)) &&
+        (place.value === "" || card.dataset.place === place.value);
+      card.hidden = !visible;
+      if (visible) {
+        count++;
+      }
+    }
+    shown.textContent = count;
+    noMatches.hidden = count > 0;
+  }
+
+  search.addEventListener("input", applyFilters);
+  type.addEventListener("change", applyFilters);
+  place.addEventListener("change", applyFilters);
+})();
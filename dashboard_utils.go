@@ -0,0 +1,222 @@
+// This file contains the web dashboard served by the dashboard command. The
+// page is rendered from the Pokédex in memory on every request, so it always
+// shows the collection as it is in the running app. The dashboard is read-only:
+// it only answers GET requests, and changes are made from the command line.
+package main
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// dashboardFiles holds the dashboard's page template, stylesheet, and script.
+//
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+// dashboardTemplate is the dashboard's page, parsed once at startup.
+var dashboardTemplate = template.Must(template.ParseFS(dashboardFiles, "dashboard/index.html"))
+
+// spriteURLFormat is the address of a Pokémon's sprite by its National Pokédex
+// number, for entries saved before sprites were recorded.
+const spriteURLFormat = "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/%d.png"
+
+// dashboardPokemon is one Pokémon in the dashboard's collection.
+type dashboardPokemon struct {
+	Name      string   // The Pokémon's name for display
+	Key       string   // The Pokémon's name in API format
+	Types     []string // The Pokémon's types in API format, for filtering
+	TypeNames []string // The Pokémon's types for display
+	Level     int      // The Pokémon's level
+	Place     string   // Where the Pokémon is: the party, a box, or the day care
+	Sprite    string   // The URL of the Pokémon's sprite, or "" if it isn't known
+	StatTotal int      // The Pokémon's base stat total
+}
+
+// dashboardOption is a choice in one of the dashboard's filters.
+type dashboardOption struct {
+	Value string // The value the filter matches
+	Label string // The choice for display
+}
+
+// dashboardBar is one bar of a completion chart.
+type dashboardBar struct {
+	Label   string // What the bar measures
+	Count   int    // How many species were caught or seen
+	Total   int    // How many species there are
+	Percent int    // Count as a percentage of Total
+}
+
+// dashboardPage is everything the dashboard's page shows.
+type dashboardPage struct {
+	Lang            string             // The language code of the page
+	Text            map[string]string  // The page's headings and labels, translated
+	Pokemon         []dashboardPokemon // The Pokémon in the collection, sorted by name
+	Types           []dashboardOption  // The types in the collection, for the type filter
+	Places          []string           // The places Pokémon are kept, for the place filter
+	Completion      []dashboardBar     // Species caught and seen, out of every species
+	TypeCompletion  []dashboardBar     // Species caught of each type
+	DatasetComplete bool               // Whether completion is measured against every species
+}
+
+// newDashboardHandler returns the handler that serves the dashboard.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and species dataset
+//
+// Returns:
+//   - A handler serving the page at "/" and its stylesheet and script under "/assets/"
+func newDashboardHandler(cfg *config) http.Handler {
+	assets, _ := fs.Sub(dashboardFiles, "dashboard")
+	mux := http.NewServeMux()
+	mux.Handle("GET /assets/", http.StripPrefix("/assets/", http.FileServerFS(assets)))
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := dashboardTemplate.Execute(w, buildDashboardPage(cfg)); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	return mux
+}
+
+// buildDashboardPage gathers what the dashboard shows from the Pokédex.
+func buildDashboardPage(cfg *config) dashboardPage {
+	data := cfg.Dataset()
+	page := dashboardPage{
+		Lang:            i18n.Current(),
+		Text:            dashboardText(),
+		DatasetComplete: data.Complete,
+	}
+
+	entries := cfg.pokedex.List()
+	caught := caughtSpecies(entries)
+	types := make(map[string]bool)
+	places := make(map[string]bool)
+	for _, named := range entries {
+		entry := named.Entry
+		p := dashboardPokemon{
+			Name:      FormatPokemonName(named.Name),
+			Key:       named.Name,
+			Types:     pokemonTypes(entry.PokemonDataResp),
+			Level:     entry.CurrentLevel(),
+			Place:     dashboardPlace(entry),
+			Sprite:    pokemonSprite(cfg, named.Name, entry),
+			StatTotal: baseStatTotal(entry.PokemonDataResp),
+		}
+		for _, t := range p.Types {
+			p.TypeNames = append(p.TypeNames, FormatTypeName(t))
+			types[t] = true
+		}
+		places[p.Place] = true
+		page.Pokemon = append(page.Pokemon, p)
+	}
+
+	for _, t := range standardTypes {
+		if types[t] {
+			page.Types = append(page.Types, dashboardOption{Value: t, Label: FormatTypeName(t)})
+		}
+	}
+	for place := range places {
+		page.Places = append(page.Places, place)
+	}
+	sortText(page.Places)
+
+	// Completion is measured against the species in the dataset
+	var caughtCount, seenCount int
+	typeCounts := make(map[string][2]int)
+	for _, species := range data.Species {
+		isCaught := caught[species.Name]
+		if isCaught {
+			caughtCount++
+		}
+		if isCaught || cfg.pokedex.HasSeen(species.Name) {
+			seenCount++
+		}
+		for _, t := range species.Types {
+			counts := typeCounts[t]
+			counts[1]++
+			if isCaught {
+				counts[0]++
+			}
+			typeCounts[t] = counts
+		}
+	}
+	page.Completion = []dashboardBar{
+		newDashboardBar(i18n.T("Caught"), caughtCount, len(data.Species)),
+		newDashboardBar(i18n.T("Seen"), seenCount, len(data.Species)),
+	}
+	for _, t := range standardTypes {
+		if counts, ok := typeCounts[t]; ok {
+			page.TypeCompletion = append(page.TypeCompletion, newDashboardBar(FormatTypeName(t), counts[0], counts[1]))
+		}
+	}
+	return page
+}
+
+// caughtSpecies returns the names of the caught Pokémon and of their species,
+// for measuring completion against the species dataset.
+func caughtSpecies(entries []pokedex.NamedEntry) map[string]bool {
+	caught := make(map[string]bool, 2*len(entries))
+	for _, named := range entries {
+		caught[named.Entry.Species.Name] = true
+		caught[named.Name] = true
+	}
+	return caught
+}
+
+// pokemonSprite returns the URL of a Pokémon's sprite, or "" if it isn't known.
+// Entries saved before sprites were recorded use the sprite of their species
+// number in the dataset.
+func pokemonSprite(cfg *config, name string, entry pokedex.Entry) string {
+	if entry.Sprites.FrontDefault != "" {
+		return entry.Sprites.FrontDefault
+	}
+	if species, ok := cfg.Dataset().Lookup(name); ok {
+		return fmt.Sprintf(spriteURLFormat, species.ID)
+	}
+	return ""
+}
+
+// newDashboardBar returns a completion chart bar.
+func newDashboardBar(label string, count, total int) dashboardBar {
+	percent := 0
+	if total > 0 {
+		percent = count * 100 / total
+	}
+	return dashboardBar{Label: label, Count: count, Total: total, Percent: percent}
+}
+
+// dashboardPlace returns where a Pokémon is kept, for display.
+func dashboardPlace(entry pokedex.Entry) string {
+	switch {
+	case entry.InDaycare():
+		return i18n.T("Day care")
+	case entry.Box != "":
+		return entry.Box
+	}
+	return i18n.T("Party")
+}
+
+// dashboardText returns the dashboard's headings and labels in the current language.
+func dashboardText() map[string]string {
+	return map[string]string{
+		"title":      i18n.T("Pokédex dashboard"),
+		"collection": i18n.T("Collection"),
+		"completion": i18n.T("Completion"),
+		"byType":     i18n.T("Caught by type"),
+		"search":     i18n.T("Search by name"),
+		"allTypes":   i18n.T("All types"),
+		"allPlaces":  i18n.T("Everywhere"),
+		"level":      i18n.T("Lv."),
+		"statTotal":  i18n.T("Base stat total"),
+		"noMatches":  i18n.T("No Pokémon match these filters."),
+		"empty":      i18n.T("You have not caught any Pokémon yet"),
+		"partial":    i18n.T("Completion only counts the species in the built-in dataset. Run 'dataset update' to count every species."),
+	}
+}
@@ -0,0 +1,172 @@
+// This file compares two versions of a Pokémon's data from the API, as when a
+// Pokémon evolves or its entry is refreshed, and shows what changed field by
+// field: types, base stats with their deltas, size, base experience,
+// abilities, and learnable moves.
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// maxDiffMoves is the most gained or lost moves listed by name; the rest are counted.
+const maxDiffMoves = 8
+
+// statDiff is the change in one base stat.
+type statDiff struct {
+	name          string // The stat's API name (e.g. "special-attack")
+	before, after int    // The base stat before and after
+}
+
+// dataDiff is what changed between two versions of a Pokémon's data.
+type dataDiff struct {
+	typesBefore, typesAfter        string     // The formatted type lists
+	stats                          []statDiff // Every base stat, in the order of the new data
+	statsChanged                   bool       // Whether any base stat changed
+	heightBefore, heightAfter      int        // Heights in decimeters
+	weightBefore, weightAfter      int        // Weights in hectograms
+	expBefore, expAfter            int        // Base experience
+	abilitiesGained, abilitiesLost []string   // Ability names in API format
+	movesGained, movesLost         []string   // Learnable move names in API format
+}
+
+// diffPokemonData compares two versions of a Pokémon's data.
+//
+// Parameters:
+//   - before: The data before the change
+//   - after: The data after the change
+//
+// Returns:
+//   - What changed
+func diffPokemonData(before, after pokeapi.PokemonDataResp) dataDiff {
+	diff := dataDiff{
+		typesBefore:  FormatTypeList(pokemonTypes(before)),
+		typesAfter:   FormatTypeList(pokemonTypes(after)),
+		heightBefore: before.Height, heightAfter: after.Height,
+		weightBefore: before.Weight, weightAfter: after.Weight,
+		expBefore: before.BaseExperience, expAfter: after.BaseExperience,
+	}
+
+	for _, s := range after.Stats {
+		stat := statDiff{name: s.Stat.Name, before: baseStat(before, s.Stat.Name), after: s.BaseStat}
+		diff.stats = append(diff.stats, stat)
+		diff.statsChanged = diff.statsChanged || stat.before != stat.after
+	}
+	diff.statsChanged = diff.statsChanged || len(before.Stats) != len(after.Stats)
+
+	abilityNames := func(data pokeapi.PokemonDataResp) []string {
+		names := make([]string, len(data.Abilities))
+		for i, a := range data.Abilities {
+			names[i] = a.Ability.Name
+		}
+		return names
+	}
+	moveNames := func(data pokeapi.PokemonDataResp) []string {
+		names := make([]string, len(data.Moves))
+		for i, m := range data.Moves {
+			names[i] = m.Move.Name
+		}
+		return names
+	}
+	diff.abilitiesGained, diff.abilitiesLost = namesGainedAndLost(abilityNames(before), abilityNames(after))
+	diff.movesGained, diff.movesLost = namesGainedAndLost(moveNames(before), moveNames(after))
+	return diff
+}
+
+// namesGainedAndLost lists the names only in after, and those only in before.
+func namesGainedAndLost(before, after []string) (gained, lost []string) {
+	for _, name := range after {
+		if !slices.Contains(before, name) {
+			gained = append(gained, name)
+		}
+	}
+	for _, name := range before {
+		if !slices.Contains(after, name) {
+			lost = append(lost, name)
+		}
+	}
+	return gained, lost
+}
+
+// writeDataDiff writes what changed between two versions of a Pokémon's data.
+// The type and base stats are always shown, with each stat's change; the
+// other fields are only shown if they changed.
+//
+// Parameters:
+//   - w: Where to write the changes
+//   - cfg: The application configuration, for the units heights and weights are shown in
+//   - beforeLabel: The heading of the column of stats before the change
+//   - before: The data before the change
+//   - afterLabel: The heading of the column of stats after the change
+//   - after: The data after the change
+func writeDataDiff(w io.Writer, cfg *config, beforeLabel string, before pokeapi.PokemonDataResp, afterLabel string, after pokeapi.PokemonDataResp) {
+	diff := diffPokemonData(before, after)
+
+	if diff.typesBefore == diff.typesAfter {
+		i18n.Fprintf(w, "Type: %s (unchanged)\n", diff.typesBefore)
+	} else if isAccessibleOutput() {
+		i18n.Fprintf(w, "Type: changes from %s to %s\n", diff.typesBefore, diff.typesAfter)
+	} else {
+		i18n.Fprintf(w, "Type: %s -> %s\n", diff.typesBefore, diff.typesAfter)
+	}
+
+	if diff.statsChanged {
+		table := NewTable("Stat", beforeLabel, afterLabel, "Change")
+		for _, s := range diff.stats {
+			table.AddRow(FormatStatName(s.name), fmt.Sprint(s.before), fmt.Sprint(s.after),
+				formatStatChange(s.after-s.before))
+		}
+		totalBefore, totalAfter := baseStatTotal(before), baseStatTotal(after)
+		table.AddRow("Total", fmt.Sprint(totalBefore), fmt.Sprint(totalAfter), formatStatChange(totalAfter-totalBefore))
+		table.Render(w)
+	} else {
+		i18n.Fprintf(w, "Base stats: %d in total (unchanged)\n", baseStatTotal(after))
+	}
+
+	units := displayUnits(cfg)
+	writeFieldChange(w, i18n.T("Height"), FormatHeight(diff.heightBefore, units), FormatHeight(diff.heightAfter, units))
+	writeFieldChange(w, i18n.T("Weight"), FormatWeight(diff.weightBefore, units), FormatWeight(diff.weightAfter, units))
+	writeFieldChange(w, i18n.T("Base experience"), fmt.Sprint(diff.expBefore), fmt.Sprint(diff.expAfter))
+	if len(diff.abilitiesGained) > 0 {
+		i18n.Fprintf(w, "Abilities gained: %s\n", formatNameList(diff.abilitiesGained, FormatAbilityName, len(diff.abilitiesGained)))
+	}
+	if len(diff.abilitiesLost) > 0 {
+		i18n.Fprintf(w, "Abilities lost: %s\n", formatNameList(diff.abilitiesLost, FormatAbilityName, len(diff.abilitiesLost)))
+	}
+	if len(diff.movesGained) > 0 {
+		i18n.Fprintf(w, "Moves it can now learn: %s\n", formatNameList(diff.movesGained, FormatMoveName, maxDiffMoves))
+	}
+	if len(diff.movesLost) > 0 {
+		i18n.Fprintf(w, "Moves it can no longer learn: %s\n", formatNameList(diff.movesLost, FormatMoveName, maxDiffMoves))
+	}
+}
+
+// writeFieldChange writes a field's old and new values, if they differ.
+func writeFieldChange(w io.Writer, label, before, after string) {
+	switch {
+	case before == after:
+	case isAccessibleOutput():
+		i18n.Fprintf(w, "%s: changes from %s to %s\n", label, before, after)
+	default:
+		i18n.Fprintf(w, "%s: %s -> %s\n", label, before, after)
+	}
+}
+
+// formatNameList formats API names for display, listing at most limit of them
+// and counting the rest (e.g. "Thunderbolt, Surf, and 3 more").
+func formatNameList(names []string, format func(string) string, limit int) string {
+	shown := make([]string, 0, min(len(names), limit))
+	for _, name := range names[:min(len(names), limit)] {
+		shown = append(shown, format(name))
+	}
+	list := strings.Join(shown, ", ")
+	if rest := len(names) - len(shown); rest > 0 {
+		list = i18n.Sprintf("%s, and %d more", list, rest)
+	}
+	return list
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestDiffPokemonData tests the changes found between two versions of a
+// Pokémon's data
+func TestDiffPokemonData(t *testing.T) {
+	before := testMatchupPokemon(t, "pikachu", 35, "electric")
+	before.Height = 4
+	before.Abilities = []pokeapi.PokemonAbility{{Ability: pokeapi.NamedAPIResource{Name: "static"}}}
+	before.Moves = []pokeapi.PokemonMove{{Move: pokeapi.NamedAPIResource{Name: "thunder-shock"}}}
+
+	after := testMatchupPokemon(t, "raichu", 60, "electric", "psychic")
+	after.Height = 7
+	after.Abilities = []pokeapi.PokemonAbility{{Ability: pokeapi.NamedAPIResource{Name: "static"}},
+		{Ability: pokeapi.NamedAPIResource{Name: "lightning-rod"}}}
+	after.Moves = []pokeapi.PokemonMove{{Move: pokeapi.NamedAPIResource{Name: "thunderbolt"}}}
+
+	diff := diffPokemonData(before, after)
+	if diff.typesBefore == diff.typesAfter {
+		t.Errorf("Expected the types to change, got %q", diff.typesAfter)
+	}
+	if !diff.statsChanged || len(diff.stats) != 1 || diff.stats[0].after-diff.stats[0].before != 25 {
+		t.Errorf("Expected the stat to rise by 25, got %+v", diff.stats)
+	}
+	if diff.heightBefore != 4 || diff.heightAfter != 7 {
+		t.Errorf("Expected the height to change from 4 to 7, got %d to %d", diff.heightBefore, diff.heightAfter)
+	}
+	if !slices.Equal(diff.abilitiesGained, []string{"lightning-rod"}) || len(diff.abilitiesLost) != 0 {
+		t.Errorf("Unexpected ability changes: gained %v, lost %v", diff.abilitiesGained, diff.abilitiesLost)
+	}
+	if !slices.Equal(diff.movesGained, []string{"thunderbolt"}) || !slices.Equal(diff.movesLost, []string{"thunder-shock"}) {
+		t.Errorf("Unexpected move changes: gained %v, lost %v", diff.movesGained, diff.movesLost)
+	}
+
+	if same := diffPokemonData(after, after); same.statsChanged || same.movesGained != nil || same.abilitiesLost != nil {
+		t.Errorf("Expected no changes, got %+v", same)
+	}
+}
+
+// TestFormatNameList tests that long lists are cut short with a count of the rest
+func TestFormatNameList(t *testing.T) {
+	names := []string{"thunder-shock", "quick-attack", "thunderbolt"}
+	if got := formatNameList(names, FormatMoveName, 2); got != "Thunder Shock, Quick Attack, and 1 more" {
+		t.Errorf("Unexpected list: %q", got)
+	}
+	if got := formatNameList(names[:1], FormatMoveName, 2); got != "Thunder Shock" {
+		t.Errorf("Unexpected list: %q", got)
+	}
+}
@@ -0,0 +1,81 @@
+// This file connects the static species dataset (see internal/dataset) to the
+// application: it finds and loads the downloaded copy at startup, and gives
+// commands access to whichever dataset is in use.
+package main
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/dataset"
+)
+
+// getDatasetPath returns the path of the downloaded dataset, in the cache
+// directory (see paths_utils.go), since it can always be downloaded again.
+//
+// Returns:
+//   - The path to the dataset file
+//   - An error if there was a problem determining the path
+func getDatasetPath() (string, error) {
+	return datasetFile.path(), nil
+}
+
+// loadDataset loads the downloaded dataset if there is one, and the embedded
+// dataset otherwise. The embedded dataset is kept if the download can't be read.
+//
+// Parameters:
+//   - cfg: The application configuration to load the dataset into
+//
+// Returns:
+//   - An error if the downloaded dataset exists but can't be read
+func loadDataset(cfg *config) error {
+	path, err := getDatasetPath()
+	if err != nil {
+		return err
+	}
+	d, _, err := dataset.Load(path)
+	if err != nil {
+		return err
+	}
+	cfg.setDataset(d)
+	return nil
+}
+
+// Dataset returns the species dataset in use: the downloaded dataset if one was
+// loaded, and the embedded dataset otherwise.
+func (cfg *config) Dataset() *dataset.Dataset {
+	cfg.mutex.RLock()
+	defer cfg.mutex.RUnlock()
+	if cfg.dataset == nil {
+		return dataset.Embedded()
+	}
+	return cfg.dataset
+}
+
+// setDataset replaces the species dataset in use. The search and name indexes
+// are dropped, so that they are rebuilt from the new dataset when next needed.
+func (cfg *config) setDataset(d *dataset.Dataset) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.dataset = d
+	cfg.searchIndex = nil
+	cfg.nameIndex = nil
+}
+
+// pokemonTypesOf returns the types of a Pokémon in slot order, from the species
+// dataset if it has the Pokémon, and from the API otherwise.
+//
+// Parameters:
+//   - cfg: The application configuration containing the dataset and API client
+//   - name: The Pokémon's name in API format
+//
+// Returns:
+//   - The Pokémon's type names
+//   - An error if the Pokémon isn't in the dataset and the API request fails
+func pokemonTypesOf(cfg *config, name string) ([]string, error) {
+	if species, ok := cfg.Dataset().Lookup(name); ok {
+		return species.Types, nil
+	}
+	data, err := cfg.pokeapiClient.GetPokemonData(name)
+	if err != nil {
+		return nil, err
+	}
+	return pokemonTypes(data), nil
+}
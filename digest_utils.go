@@ -0,0 +1,198 @@
+// This file builds the weekly digest written by 'report weekly': what the user
+// did in the last 7 days (the Pokémon they caught and evolved, the challenges
+// they completed, and how many new Pokémon they saw) as plain text that can be
+// shown, written to a file, or emailed through an SMTP server.
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/challenge"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/mail"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// digestDays is how many days a weekly digest covers.
+const digestDays = 7
+
+// digestSendTimeout is how long emailing a digest may take before it's given up on.
+const digestSendTimeout = 30 * time.Second
+
+// digestEvolution is an evolution in a digest.
+type digestEvolution struct {
+	From string    // The Pokédex name of the form before the evolution
+	To   string    // The Pokédex name of the form it evolved into
+	On   time.Time // When it evolved
+}
+
+// digestChallenge is a challenge completed in a digest.
+type digestChallenge struct {
+	Name string    // The challenge's display name
+	On   time.Time // When it was completed
+}
+
+// weeklyDigest is what the user did in the days a digest covers.
+type weeklyDigest struct {
+	From       time.Time            // The start of the period covered
+	To         time.Time            // The end of the period covered (when the digest was made)
+	Catches    []pokedex.NamedEntry // The Pokémon caught, oldest first
+	Evolutions []digestEvolution    // The evolutions, oldest first
+	Challenges []digestChallenge    // The challenges completed, oldest first
+	Seen       int                  // How many Pokémon were seen for the first time
+}
+
+// buildWeeklyDigest gathers what happened in the last 7 days from the
+// Pokédex, the sightings, and the challenges the user has started. Pokémon
+// that were released no longer appear.
+//
+// Parameters:
+//   - entries: Every Pokémon in the Pokédex
+//   - seen: The recorded sightings
+//   - started: The challenges the user has started, by ID
+//   - challenges: The challenges in the challenge directory, to name the completed ones
+//   - now: The end of the period covered
+//
+// Returns:
+//   - The digest
+func buildWeeklyDigest(entries []pokedex.NamedEntry, seen map[string]pokedex.Sighting,
+	started map[string]pokedex.ChallengeState, challenges []challenge.Challenge, now time.Time) weeklyDigest {
+	digest := weeklyDigest{From: now.AddDate(0, 0, -digestDays), To: now}
+	inPeriod := func(t time.Time) bool {
+		return !t.IsZero() && t.After(digest.From) && !t.After(now)
+	}
+
+	for _, named := range entries {
+		if inPeriod(named.Entry.CaughtOn) {
+			digest.Catches = append(digest.Catches, named)
+		}
+		// Walk back through the earlier forms, as a Pokémon may have evolved
+		// more than once in the period
+		name := named.Name
+		for previous := named.Entry.PreEvolution; previous != nil; previous = previous.Entry.PreEvolution {
+			if inPeriod(previous.EvolvedOn) {
+				digest.Evolutions = append(digest.Evolutions, digestEvolution{From: previous.Name, To: name, On: previous.EvolvedOn})
+			}
+			name = previous.Name
+		}
+	}
+	for _, sighting := range seen {
+		if inPeriod(sighting.SeenOn) {
+			digest.Seen++
+		}
+	}
+	for id, state := range started {
+		if !inPeriod(state.Completed) {
+			continue
+		}
+		name := id
+		if index := slices.IndexFunc(challenges, func(c challenge.Challenge) bool { return c.ID == id }); index >= 0 {
+			name = challenges[index].Name
+		}
+		digest.Challenges = append(digest.Challenges, digestChallenge{Name: name, On: state.Completed})
+	}
+
+	slices.SortFunc(digest.Catches, func(a, b pokedex.NamedEntry) int {
+		return cmp.Or(a.Entry.CaughtOn.Compare(b.Entry.CaughtOn), cmp.Compare(a.Name, b.Name))
+	})
+	slices.SortFunc(digest.Evolutions, func(a, b digestEvolution) int {
+		return cmp.Or(a.On.Compare(b.On), cmp.Compare(a.To, b.To))
+	})
+	slices.SortFunc(digest.Challenges, func(a, b digestChallenge) int {
+		return cmp.Or(a.On.Compare(b.On), cmp.Compare(a.Name, b.Name))
+	})
+	return digest
+}
+
+// weeklyDigestFor builds the digest of the last 7 days for the user. The
+// challenge files are only read to name completed challenges, so problems
+// reading them are ignored and the challenges' IDs are shown instead.
+func weeklyDigestFor(cfg *config, now time.Time) weeklyDigest {
+	challenges, _, _ := loadChallenges()
+	return buildWeeklyDigest(cfg.pokedex.List(), cfg.pokedex.Seen(), cfg.Challenges(), challenges, now)
+}
+
+// digestSubject is the subject of a digest sent by email.
+func digestSubject(digest weeklyDigest) string {
+	return i18n.Sprintf("Pokédex weekly report, %s to %s", digest.From.Format("2006-01-02"), digest.To.Format("2006-01-02"))
+}
+
+// writeWeeklyDigest writes a digest as plain text.
+//
+// Parameters:
+//   - w: Where to write the digest
+//   - digest: The digest to write
+//
+// Returns:
+//   - An error if writing fails
+func writeWeeklyDigest(w io.Writer, digest weeklyDigest) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", digestSubject(digest))
+
+	if len(digest.Catches) == 0 {
+		fmt.Fprintf(&b, "%s\n", i18n.T("No Pokémon caught."))
+	} else {
+		fmt.Fprintf(&b, "%s\n", i18n.Sprintf("Caught (%d):", len(digest.Catches)))
+	}
+	for _, named := range digest.Catches {
+		line := fmt.Sprintf("%s, %s", FormatPokemonName(named.Name), named.Entry.CaughtOn.Local().Format("2006-01-02"))
+		if named.Entry.CaughtAt != "" {
+			line = i18n.Sprintf("%s, in %s", line, FormatLocationName(named.Entry.CaughtAt))
+		}
+		fmt.Fprintf(&b, "  - %s\n", line)
+	}
+	b.WriteString("\n")
+
+	if len(digest.Evolutions) == 0 {
+		fmt.Fprintf(&b, "%s\n", i18n.T("No Pokémon evolved."))
+	} else {
+		fmt.Fprintf(&b, "%s\n", i18n.Sprintf("Evolved (%d):", len(digest.Evolutions)))
+	}
+	for _, evolution := range digest.Evolutions {
+		fmt.Fprintf(&b, "  - %s\n", i18n.Sprintf("%s into %s, %s",
+			FormatPokemonName(evolution.From), FormatPokemonName(evolution.To), evolution.On.Local().Format("2006-01-02")))
+	}
+	b.WriteString("\n")
+
+	if len(digest.Challenges) == 0 {
+		fmt.Fprintf(&b, "%s\n", i18n.T("No challenges completed."))
+	} else {
+		fmt.Fprintf(&b, "%s\n", i18n.Sprintf("Challenges completed (%d):", len(digest.Challenges)))
+	}
+	for _, completed := range digest.Challenges {
+		fmt.Fprintf(&b, "  - %s, %s\n", completed.Name, completed.On.Local().Format("2006-01-02"))
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "%s\n", i18n.Sprintf("New Pokémon seen: %d", digest.Seen))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sendWeeklyDigest emails a digest through the SMTP server in the settings.
+//
+// Parameters:
+//   - current: The settings with the server and the addresses to send from and to
+//   - digest: The digest to send
+//
+// Returns:
+//   - An error if the server is invalid or the digest can't be sent
+func sendWeeklyDigest(current settings, digest weeklyDigest) error {
+	server, err := mail.ParseServer(current.reportSMTP)
+	if err != nil {
+		return err
+	}
+	var body strings.Builder
+	if err := writeWeeklyDigest(&body, digest); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), digestSendTimeout)
+	defer cancel()
+	return server.Send(ctx, current.reportFrom, current.reportTo, digestSubject(digest), body.String())
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/challenge"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestBuildWeeklyDigest tests which catches, evolutions, challenges, and
+// sightings fall in the last 7 days
+func TestBuildWeeklyDigest(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	pikachu := pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric"))
+	pikachu.CaughtOn = daysAgo(2)
+	squirtle := pokedex.NewEntry(testMatchupPokemon(t, "squirtle", 314, "water"))
+	squirtle.CaughtOn = daysAgo(10)
+	// Caught a while ago, evolved twice this week
+	charmander := pokedex.NewEntry(testMatchupPokemon(t, "charmander", 309, "fire"))
+	charmander.CaughtOn = daysAgo(30)
+	charmeleon := charmander.EvolveInto("charmander", testMatchupPokemon(t, "charmeleon", 405, "fire"), daysAgo(5))
+	charizard := charmeleon.EvolveInto("charmeleon", testMatchupPokemon(t, "charizard", 534, "fire", "flying"), daysAgo(1))
+	entries := []pokedex.NamedEntry{
+		{Name: "charizard", Entry: charizard}, {Name: "pikachu", Entry: pikachu}, {Name: "squirtle", Entry: squirtle},
+	}
+
+	seen := map[string]pokedex.Sighting{
+		"pikachu": {SeenOn: daysAgo(2)}, "rattata": {SeenOn: daysAgo(3)}, "squirtle": {SeenOn: daysAgo(10)}, "pidgey": {},
+	}
+	started := map[string]pokedex.ChallengeState{
+		"ghost-hunter": {Started: daysAgo(9), Completed: daysAgo(4)},
+		"unnamed":      {Started: daysAgo(9), Completed: daysAgo(3)},
+		"old":          {Started: daysAgo(20), Completed: daysAgo(12)},
+		"underway":     {Started: daysAgo(1)},
+	}
+	challenges := []challenge.Challenge{{ID: "ghost-hunter", Name: "Ghost Hunter"}}
+
+	digest := buildWeeklyDigest(entries, seen, started, challenges, now)
+	if len(digest.Catches) != 1 || digest.Catches[0].Name != "pikachu" {
+		t.Errorf("Expected only Pikachu to be caught this week, got %v", digest.Catches)
+	}
+	wantEvolutions := []digestEvolution{
+		{From: "charmander", To: "charmeleon", On: daysAgo(5)},
+		{From: "charmeleon", To: "charizard", On: daysAgo(1)},
+	}
+	if len(digest.Evolutions) != 2 || digest.Evolutions[0] != wantEvolutions[0] || digest.Evolutions[1] != wantEvolutions[1] {
+		t.Errorf("Expected evolutions %v, got %v", wantEvolutions, digest.Evolutions)
+	}
+	wantChallenges := []digestChallenge{{Name: "Ghost Hunter", On: daysAgo(4)}, {Name: "unnamed", On: daysAgo(3)}}
+	if len(digest.Challenges) != 2 || digest.Challenges[0] != wantChallenges[0] || digest.Challenges[1] != wantChallenges[1] {
+		t.Errorf("Expected challenges %v, got %v", wantChallenges, digest.Challenges)
+	}
+	if digest.Seen != 2 {
+		t.Errorf("Expected 2 Pokémon seen this week, got %d", digest.Seen)
+	}
+}
+
+// TestWriteWeeklyDigest tests the sections of a digest, with and without anything in them
+func TestWriteWeeklyDigest(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.Local)
+	pikachu := pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric"))
+	pikachu.CaughtOn = time.Date(2026, 10, 13, 12, 0, 0, 0, time.Local)
+	pikachu.CaughtAt = "viridian-forest-area"
+	digest := weeklyDigest{
+		From:       now.AddDate(0, 0, -digestDays),
+		To:         now,
+		Catches:    []pokedex.NamedEntry{{Name: "pikachu", Entry: pikachu}},
+		Evolutions: []digestEvolution{{From: "charmander", To: "charmeleon", On: time.Date(2026, 10, 11, 12, 0, 0, 0, time.Local)}},
+		Seen:       4,
+	}
+
+	var out strings.Builder
+	if err := writeWeeklyDigest(&out, digest); err != nil {
+		t.Fatalf("writeWeeklyDigest returned an error: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		"Pokédex weekly report, 2026-10-08 to 2026-10-15\n\n",
+		"Caught (1):\n  - Pikachu, 2026-10-13, in Viridian Forest Area\n",
+		"Evolved (1):\n  - Charmander into Charmeleon, 2026-10-11\n",
+		"No challenges completed.\n",
+		"New Pokémon seen: 4\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected the digest to contain %q, got:\n%s", want, text)
+		}
+	}
+}
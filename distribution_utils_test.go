@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseDistributionCode tests that valid codes are accepted in any case and with spaces
+func TestParseDistributionCode(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected distribution
+	}{
+		{code: "POKEMON-PIKACHU-451AE6F13C", expected: distribution{kind: rewardPokemon, name: "pikachu", quantity: 1}},
+		{code: "item-5-ultra-ball-2e66b4616b", expected: distribution{kind: rewardItem, name: "ultra-ball", quantity: 5}},
+		{code: "ITEM-1-FIRE-STONE- 733C12040A", expected: distribution{kind: rewardItem, name: "fire-stone", quantity: 1}},
+	}
+
+	for _, tc := range tests {
+		reward, canonical, err := parseDistributionCode(tc.code)
+		if err != nil {
+			t.Errorf("parseDistributionCode(%q) returned error: %v", tc.code, err)
+			continue
+		}
+		if reward != tc.expected {
+			t.Errorf("parseDistributionCode(%q) = %+v, expected %+v", tc.code, reward, tc.expected)
+		}
+		if canonical != newDistributionCode(reward) {
+			t.Errorf("Expected canonical code %q, got %q", newDistributionCode(reward), canonical)
+		}
+	}
+}
+
+// TestParseDistributionCodeInvalid tests that tampered and malformed codes are rejected
+func TestParseDistributionCodeInvalid(t *testing.T) {
+	codes := []string{
+		"",
+		"PIKACHU",
+		"POKEMON-MEW-451AE6F13C",         // Signature of a different reward
+		"ITEM-50-ULTRA-BALL-2E66B4616B",  // Quantity changed
+		"ITEM-ULTRA-BALL-2E66B4616B",     // Quantity missing
+		"ITEM-500-ULTRA-BALL-2E66B4616B", // Quantity too large
+		"BADGE-BOULDER-451AE6F13C",       // Unknown reward kind
+	}
+
+	for _, code := range codes {
+		if _, _, err := parseDistributionCode(code); !errors.Is(err, errInvalidCode) {
+			t.Errorf("parseDistributionCode(%q) = %v, expected errInvalidCode", code, err)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+// This file implements the --dry-run flag for commands that change the Pokédex,
+// such as release, reset, and evolve. The command runs as usual, but without
+// saving, and the state from before it ran is restored afterward. The difference
+// between the two states is shown so the user can see exactly what would change.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// dryRunFlag is the flag that previews a command's changes without making them.
+const dryRunFlag = "--dry-run"
+
+// takeDryRunFlag removes the --dry-run flag from a command's parameters.
+//
+// Parameters:
+//   - params: The command parameters
+//
+// Returns:
+//   - The parameters without the flag
+//   - Whether the flag was given
+func takeDryRunFlag(params []string) ([]string, bool) {
+	return takeFlag(params, dryRunFlag)
+}
+
+// takeFlag removes a flag that can be given to many commands, such as
+// --dry-run, from a command's parameters. The REPL joins the parameters of
+// Pokémon commands into one name, so the flag is also removed from within a
+// parameter (e.g. "pikachu --dry-run").
+//
+// Parameters:
+//   - params: The command parameters
+//   - flag: The flag to remove
+//
+// Returns:
+//   - The parameters without the flag
+//   - Whether the flag was given
+func takeFlag(params []string, flag string) ([]string, bool) {
+	found := false
+	kept := make([]string, 0, len(params))
+	for _, param := range params {
+		words := strings.Fields(param)
+		remaining := slices.DeleteFunc(slices.Clone(words), func(word string) bool {
+			return word == flag
+		})
+		if len(remaining) == len(words) {
+			kept = append(kept, param)
+			continue
+		}
+		found = true
+		if len(remaining) > 0 {
+			kept = append(kept, strings.Join(remaining, " "))
+		}
+	}
+	return kept, found
+}
+
+// previewCommand runs a command without saving, reports the changes it made, and
+// then restores the state from before it ran. Confirmation prompts are answered
+// yes, so that the preview shows what would happen if the user went ahead.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - commandName: The name of the command being previewed
+//   - params: The parameters for the command, without the --dry-run flag
+//   - run: The command to preview
+//
+// Returns:
+//   - The error returned by the command
+func previewCommand(cfg *config, commandName string, params []string, run commandFunc) error {
+	before, err := snapshotSaveData(cfg)
+	if err != nil {
+		return fmt.Errorf("error recording state before dry run: %w", err)
+	}
+
+	cfg.mutex.RLock()
+	changesSinceSync := cfg.changesSinceSync
+	cfg.mutex.RUnlock()
+
+	i18n.Println("Dry run: nothing will be changed or saved.")
+	cfg.dryRun = true
+	defer func() {
+		// Restore the state even if the command fails or panics
+		applySaveData(cfg, before)
+		cfg.mutex.Lock()
+		cfg.changesSinceSync = changesSinceSync
+		cfg.mutex.Unlock()
+		cfg.dryRun = false
+	}()
+
+	if err := run(cfg, params); err != nil || cfg.commandErr != nil {
+		return err
+	}
+
+	changes := describeChanges(before, currentSaveData(cfg))
+	if len(changes) == 0 {
+		i18n.Printf("Dry run: '%s' wouldn't change anything.\n", commandName)
+	} else {
+		i18n.Printf("Dry run: '%s' would make these changes, but none were made:\n", commandName)
+		for _, change := range changes {
+			fmt.Printf("  - %s\n", change)
+		}
+	}
+	printSeparator()
+	return nil
+}
+
+// snapshotSaveData takes a copy of the current state that later changes can't
+// reach, even those that commands make in place, so that it can be restored.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//
+// Returns:
+//   - The copy of the state
+//   - An error if the state couldn't be copied
+func snapshotSaveData(cfg *config) (pokedex.SaveData, error) {
+	// Keep an encoded copy, so that nothing is shared with the current state
+	var snapshot pokedex.SaveData
+	encoded, err := json.Marshal(currentSaveData(cfg))
+	if err == nil {
+		err = json.Unmarshal(encoded, &snapshot)
+	}
+	return snapshot, err
+}
+
+// describeChanges lists the differences between two save states that a user
+// would notice: Pokémon caught, released, or changed, Pokémon seen, boxes,
+// money, and items.
+//
+// Parameters:
+//   - before: The state before a command ran
+//   - after: The state after it ran
+//
+// Returns:
+//   - A description of each change, in a stable order
+func describeChanges(before, after pokedex.SaveData) []string {
+	var changes []string
+
+	for _, name := range slices.Sorted(maps.Keys(before.Pokedex)) {
+		afterEntry, exists := after.Pokedex[name]
+		if !exists {
+			changes = append(changes, i18n.Sprintf("Remove %s from your Pokédex", FormatPokemonName(name)))
+		} else if !sameEntry(before.Pokedex[name], afterEntry) {
+			changes = append(changes, i18n.Sprintf("Update %s in your Pokédex", FormatPokemonName(name)))
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(after.Pokedex)) {
+		if _, exists := before.Pokedex[name]; !exists {
+			changes = append(changes, i18n.Sprintf("Add %s to your Pokédex", FormatPokemonName(name)))
+		}
+	}
+
+	// Caught Pokémon count as seen, so only the combined lists are compared
+	seenBefore, seenAfter := seenNames(before), seenNames(after)
+	if added := countMissing(seenAfter, seenBefore); added > 0 {
+		changes = append(changes, i18n.Sprintf("Register %d Pokémon as seen", added))
+	}
+	if removed := countMissing(seenBefore, seenAfter); removed > 0 {
+		changes = append(changes, i18n.Sprintf("Remove %d Pokémon from the seen list", removed))
+	}
+
+	for _, box := range after.Boxes {
+		if !slices.Contains(before.Boxes, box) {
+			changes = append(changes, i18n.Sprintf("Create box '%s'", box))
+		}
+	}
+	for _, box := range before.Boxes {
+		if !slices.Contains(after.Boxes, box) {
+			changes = append(changes, i18n.Sprintf("Delete box '%s'", box))
+		}
+	}
+
+	if before.Money != after.Money {
+		changes = append(changes, i18n.Sprintf("Change your money from %d to %d", before.Money, after.Money))
+	}
+	items := maps.Clone(before.Items)
+	if items == nil {
+		items = map[string]int{}
+	}
+	maps.Copy(items, after.Items)
+	for _, item := range slices.Sorted(maps.Keys(items)) {
+		if before.Items[item] != after.Items[item] {
+			changes = append(changes, i18n.Sprintf("Change the number of %s in your bag from %d to %d",
+				FormatItemName(item), before.Items[item], after.Items[item]))
+		}
+	}
+
+	return changes
+}
+
+// sameEntry reports whether two Pokédex entries would be saved identically.
+func sameEntry(a, b pokedex.Entry) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// seenNames returns the names of the Pokémon seen or caught in a save state.
+func seenNames(data pokedex.SaveData) map[string]bool {
+	names := make(map[string]bool, len(data.Seen)+len(data.Pokedex))
+	for name := range data.Seen {
+		names[name] = true
+	}
+	for name := range data.Pokedex {
+		names[name] = true
+	}
+	return names
+}
+
+// countMissing counts the names in a that aren't in b.
+func countMissing(a, b map[string]bool) int {
+	count := 0
+	for name := range a {
+		if !b[name] {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestTakeDryRunFlag tests that the flag is found and removed both as its own
+// parameter and within a joined Pokémon name
+func TestTakeDryRunFlag(t *testing.T) {
+	tests := []struct {
+		params []string
+		want   []string
+		found  bool
+	}{
+		{[]string{"pikachu"}, []string{"pikachu"}, false},
+		{[]string{"--dry-run"}, []string{}, true},
+		{[]string{"mr mime --dry-run"}, []string{"mr mime"}, true},
+		{[]string{"eevee", "--dry-run", "2"}, []string{"eevee", "2"}, true},
+	}
+
+	for _, tt := range tests {
+		got, found := takeDryRunFlag(tt.params)
+		if found != tt.found || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("takeDryRunFlag(%q) = %q, %v; want %q, %v", tt.params, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+// TestDescribeChanges tests that Pokémon, sightings, boxes, money, and items are compared
+func TestDescribeChanges(t *testing.T) {
+	pikachu := pokedex.NewEntry(pokeapi.PokemonDataResp{Name: "pikachu"})
+	eevee := pokedex.NewEntry(pokeapi.PokemonDataResp{Name: "eevee"})
+	boxedEevee := eevee
+	boxedEevee.Box = "team"
+
+	before := pokedex.SaveData{
+		Pokedex: map[string]pokedex.Entry{"pikachu": pikachu, "eevee": eevee},
+		Seen:    map[string]pokedex.Sighting{"pidgey": {}},
+		Boxes:   []string{"old"},
+		Money:   500,
+		Items:   map[string]int{"poke-ball": 5},
+	}
+	after := pokedex.SaveData{
+		Pokedex: map[string]pokedex.Entry{"raichu": pikachu, "eevee": boxedEevee},
+		Seen:    map[string]pokedex.Sighting{"pidgey": {}, "pikachu": {}},
+		Boxes:   []string{"team"},
+		Money:   300,
+		Items:   map[string]int{"poke-ball": 5, "thunder-stone": 1},
+	}
+
+	want := []string{
+		"Update Eevee in your Pokédex",
+		"Remove Pikachu from your Pokédex",
+		"Add Raichu to your Pokédex",
+		"Register 1 Pokémon as seen",
+		"Create box 'team'",
+		"Delete box 'old'",
+		"Change your money from 500 to 300",
+		"Change the number of Thunder Stone in your bag from 0 to 1",
+	}
+	if got := describeChanges(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("describeChanges() =\n%q\nwant\n%q", got, want)
+	}
+
+	if got := describeChanges(before, before); len(got) != 0 {
+		t.Errorf("Expected no changes between identical states, got %q", got)
+	}
+}
+
+// TestDryRunMiddleware tests that a previewed command leaves the state unchanged
+// and saves nothing, and that commands without previews reject the flag
+func TestDryRunMiddleware(t *testing.T) {
+	useTempHome(t)
+
+	cfg := &config{
+		pokedex:  pokedex.New(),
+		settings: settings{autoSaveEnabled: true, autoSaveInterval: 1},
+		money:    250,
+	}
+	cfg.pokedex.Add("pikachu", pokedex.NewEntry(pokeapi.PokemonDataResp{Name: "pikachu"}))
+
+	reset := getCommands()["reset"]
+	if err := executeCommand(cfg, reset, []string{dryRunFlag}); err != nil {
+		t.Fatalf("Dry run of reset failed: %v", err)
+	}
+	if _, exists := cfg.pokedex.Get("pikachu"); !exists || cfg.Money() != 250 {
+		t.Errorf("Expected the dry run to leave the Pokédex and money unchanged")
+	}
+	if cfg.dryRun {
+		t.Error("Expected dry-run mode to end with the command")
+	}
+	if _, err := os.Stat(saveFile.path()); !os.IsNotExist(err) {
+		t.Errorf("Expected no save file to be written, got %v", err)
+	}
+
+	save := getCommands()["save"]
+	err := executeCommand(cfg, save, []string{dryRunFlag})
+	if !errorhandling.IsInvalidInputError(err) {
+		t.Errorf("Expected an invalid input error for a command without previews, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+// This file handles the conditions the PokeAPI attaches to encounters, such as
+// the time of day, the season, or a swarm. Like the games, which run on the
+// console's clock, the time of day and the season are taken from the system
+// clock, and wild Pokémon only turn up when their conditions hold. Swarms
+// aren't simulated, so Pokémon that only come in a swarm don't turn up, and
+// conditions with nothing to check against (such as the weather or a radio
+// station) are taken to hold.
+package main
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// encounterConditions is the state of the world that encounter conditions are
+// checked against.
+type encounterConditions struct {
+	timeOfDay string // The time of day: "morning", "day", or "night"
+	season    string // The season: "spring", "summer", "autumn", or "winter"
+}
+
+// conditionGroups are the values of each condition the application can tell
+// apart. An encounter that needs any one of a group's values needs none of them.
+var conditionGroups = [][]string{
+	{"time-morning", "time-day", "time-night"},
+	{"season-spring", "season-summer", "season-autumn", "season-winter"},
+	{"swarm-yes", "swarm-no"},
+}
+
+// conditionNames are the display names of the condition values in conditionGroups.
+var conditionNames = map[string]string{
+	"time-morning":  "Morning",
+	"time-day":      "Day",
+	"time-night":    "Night",
+	"season-spring": "Spring",
+	"season-summer": "Summer",
+	"season-autumn": "Autumn",
+	"season-winter": "Winter",
+	"swarm-yes":     "During a swarm",
+	"swarm-no":      "Outside swarms",
+}
+
+// seasons are the seasons in the order they come round each year. As in the
+// games that have them, the season changes every month, so each one comes
+// round three times a year (spring in January, May, and September).
+var seasons = []string{"spring", "summer", "autumn", "winter"}
+
+// currentConditions returns the conditions at a time: morning from 4:00 to
+// 10:00, day until 20:00, and night until 4:00, as in the games.
+//
+// Parameters:
+//   - now: The current time
+//
+// Returns:
+//   - The time of day and season at that time
+func currentConditions(now time.Time) encounterConditions {
+	timeOfDay := "night"
+	switch hour := now.Hour(); {
+	case hour >= 4 && hour < 10:
+		timeOfDay = "morning"
+	case hour >= 10 && hour < 20:
+		timeOfDay = "day"
+	}
+	return encounterConditions{
+		timeOfDay: timeOfDay,
+		season:    seasons[(int(now.Month())-1)%len(seasons)],
+	}
+}
+
+// holds reports whether a condition value holds now.
+func (c encounterConditions) holds(value string) bool {
+	switch {
+	case strings.HasPrefix(value, "time-"):
+		return value == "time-"+c.timeOfDay
+	case strings.HasPrefix(value, "season-"):
+		return value == "season-"+c.season
+	case value == "swarm-yes":
+		return false
+	default:
+		return true
+	}
+}
+
+// allows reports whether every condition of an encounter detail holds now.
+func (c encounterConditions) allows(detail pokeapi.EncounterDetail) bool {
+	for _, value := range detail.ConditionValues {
+		if !c.holds(value.Name) {
+			return false
+		}
+	}
+	return true
+}
+
+// String describes the conditions (e.g. "Night, Autumn").
+func (c encounterConditions) String() string {
+	return i18n.T(conditionNames["time-"+c.timeOfDay]) + ", " + i18n.T(conditionNames["season-"+c.season])
+}
+
+// formatConditions lists the conditions a Pokémon needs to turn up (e.g.
+// "Morning, Day"), or returns "" if it can turn up whatever the conditions.
+// Conditions that are met by every value of their group, such as a Pokémon
+// found in the morning, the day, and at night, are left out.
+//
+// Parameters:
+//   - encounter: The Pokémon's encounter details
+//
+// Returns:
+//   - The display names of the conditions, or "" if there are none
+func formatConditions(encounter pokeapi.PokemonEncounter) string {
+	var values []string
+	for _, version := range encounter.VersionDetails {
+		for _, detail := range version.EncounterDetails {
+			if len(detail.ConditionValues) == 0 {
+				return ""
+			}
+			for _, value := range detail.ConditionValues {
+				if !slices.Contains(values, value.Name) {
+					values = append(values, value.Name)
+				}
+			}
+		}
+	}
+	for _, group := range conditionGroups {
+		if !slices.ContainsFunc(group, func(value string) bool { return !slices.Contains(values, value) }) {
+			values = slices.DeleteFunc(values, func(value string) bool { return slices.Contains(group, value) })
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		if name, ok := conditionNames[value]; ok {
+			names = append(names, i18n.T(name))
+		} else {
+			names = append(names, FormatItemName(value))
+		}
+	}
+	return strings.Join(names, ", ")
+}
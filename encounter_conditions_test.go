@@ -0,0 +1,103 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// conditionalEncounter builds an encounter on land with one detail per set of
+// condition values, each with the given chance.
+func conditionalEncounter(name string, chance int, conditions ...[]string) pokeapi.PokemonEncounter {
+	version := pokeapi.EncounterVersionDetails{}
+	for _, values := range conditions {
+		detail := pokeapi.EncounterDetail{Chance: chance, Method: pokeapi.NamedAPIResource{Name: "walk"}}
+		for _, value := range values {
+			detail.ConditionValues = append(detail.ConditionValues, pokeapi.NamedAPIResource{Name: value})
+		}
+		version.EncounterDetails = append(version.EncounterDetails, detail)
+		version.MaxChance += chance
+	}
+	return pokeapi.PokemonEncounter{
+		Pokemon:        pokeapi.NamedAPIResource{Name: name},
+		VersionDetails: []pokeapi.EncounterVersionDetails{version},
+	}
+}
+
+// TestCurrentConditions tests the time of day and season taken from the clock
+func TestCurrentConditions(t *testing.T) {
+	cases := []struct {
+		time     time.Time
+		expected encounterConditions
+	}{
+		{time.Date(2026, time.January, 1, 3, 59, 0, 0, time.UTC), encounterConditions{"night", "spring"}},
+		{time.Date(2026, time.June, 1, 4, 0, 0, 0, time.UTC), encounterConditions{"morning", "summer"}},
+		{time.Date(2026, time.November, 1, 10, 0, 0, 0, time.UTC), encounterConditions{"day", "autumn"}},
+		{time.Date(2026, time.December, 1, 20, 0, 0, 0, time.UTC), encounterConditions{"night", "winter"}},
+	}
+	for _, c := range cases {
+		if got := currentConditions(c.time); got != c.expected {
+			t.Errorf("currentConditions(%s) = %+v, want %+v", c.time, got, c.expected)
+		}
+	}
+}
+
+// TestEncounterConditionsHold tests which condition values hold
+func TestEncounterConditionsHold(t *testing.T) {
+	conditions := encounterConditions{timeOfDay: "night", season: "winter"}
+	for value, expected := range map[string]bool{
+		"time-night":    true,
+		"time-day":      false,
+		"season-winter": true,
+		"season-spring": false,
+		"swarm-yes":     false,
+		"swarm-no":      true,
+		"weather-rain":  true, // Not simulated, so taken to hold
+	} {
+		if got := conditions.holds(value); got != expected {
+			t.Errorf("holds(%q) = %v, want %v", value, got, expected)
+		}
+	}
+}
+
+// TestEncounterWeightsConditions tests that Pokémon only turn up when their
+// conditions hold, with the chances of the details that hold
+func TestEncounterWeightsConditions(t *testing.T) {
+	cfg := &config{}
+	encounters := []pokeapi.PokemonEncounter{
+		conditionalEncounter("rattata", 10, nil),
+		conditionalEncounter("hoothoot", 20, []string{"time-night"}),
+		conditionalEncounter("pidgey", 10, []string{"time-morning"}, []string{"time-day"}, []string{"time-night"}),
+		conditionalEncounter("deerling", 30, []string{"season-spring"}),
+		conditionalEncounter("dunsparce", 5, []string{"swarm-yes"}),
+	}
+	got, err := encounterWeights(cfg, encounters, poolLand, encounterConditions{"night", "winter"}, "", nil)
+	if err != nil {
+		t.Fatalf("encounterWeights returned an error: %v", err)
+	}
+	if want := []int{10, 20, 10, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("encounterWeights = %v, want %v", got, want)
+	}
+}
+
+// TestFormatConditions tests listing the conditions a Pokémon needs
+func TestFormatConditions(t *testing.T) {
+	cases := []struct {
+		encounter pokeapi.PokemonEncounter
+		expected  string
+	}{
+		{conditionalEncounter("rattata", 10, nil), ""},
+		{conditionalEncounter("rattata", 10, nil, []string{"time-night"}), ""},
+		{conditionalEncounter("hoothoot", 10, []string{"time-night"}), "Night"},
+		{conditionalEncounter("pidgey", 10, []string{"time-morning"}, []string{"time-day"}, []string{"time-night"}), ""},
+		{conditionalEncounter("sentret", 10, []string{"time-morning"}, []string{"time-day"}), "Morning, Day"},
+		{conditionalEncounter("dunsparce", 10, []string{"swarm-yes", "radar-off"}), "During a swarm, Radar Off"},
+	}
+	for _, c := range cases {
+		if got := formatConditions(c.encounter); got != c.expected {
+			t.Errorf("formatConditions(%s) = %q, want %q", c.encounter.Pokemon.Name, got, c.expected)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// This file contains the REPL's event loop, which lets background work such
+// as timed auto-saves and the web dashboard reach the user safely. Input is
+// read in the background, so the REPL can wait for the next line and for work
+// posted by other goroutines at once. Posted work runs on the REPL's goroutine
+// between commands, so it never races with one, and messages from the
+// background are held until the next prompt instead of being printed in the
+// middle of what the user is typing.
+//
+// The event loop also routes Ctrl+C: while a command runs, it cancels just that
+// command (see interruptMiddleware), and at the prompt it asks whether to exit.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// maxQueuedJobs is how much background work can wait for the REPL. Work posted
+// while the queue is full is dropped, so it must be safe to skip, like a timed
+// save that the next tick repeats.
+const maxQueuedJobs = 16
+
+// maxQueuedKeys is how many reads of keys can wait for a picker.
+const maxQueuedKeys = 16
+
+// errInterrupted is returned by waitForLine when the user presses Ctrl+C at the prompt.
+var errInterrupted = errors.New("interrupted")
+
+// inputLine is one line of input read in the background.
+type inputLine struct {
+	text string // The line, including its newline if it had one
+	err  error  // The error that ended the input, if any (io.EOF at the end)
+}
+
+// eventLoop connects the REPL with the goroutines that read input and do
+// background work.
+type eventLoop struct {
+	lines      chan inputLine  // Lines of input, closed once the input ends
+	keys       chan []byte     // Keys pressed while a picker reads them one at a time (see multiselect_utils.go)
+	keyMode    atomic.Bool     // Whether input goes to keys instead of being split into lines
+	jobs       chan func()     // Work to run on the REPL's goroutine between commands
+	interrupts chan os.Signal  // Ctrl+C presses, taken by the running command or else the prompt
+	mutex      sync.Mutex      // Protects messages
+	messages   []string        // Messages from the background waiting for the next prompt
+	saves      backgroundSaves // Auto-saves being written in the background (see background_save.go)
+}
+
+// startEventLoop starts reading input in the background, so that the REPL and
+// the prompts of the commands it runs read lines through the event loop. From
+// then on, Ctrl+C no longer ends the program right away.
+//
+// Parameters:
+//   - cfg: The application configuration containing the input reader
+func startEventLoop(cfg *config) {
+	loop := &eventLoop{
+		lines:      make(chan inputLine),
+		keys:       make(chan []byte, maxQueuedKeys),
+		jobs:       make(chan func(), maxQueuedJobs),
+		interrupts: make(chan os.Signal, 1),
+	}
+	signal.Notify(loop.interrupts, os.Interrupt)
+	reader := inputReader(cfg)
+	go loop.readInput(reader)
+	cfg.events = loop
+}
+
+// readInput reads input until it ends, splitting it into lines, or while a
+// picker is open, handing it over as it's typed. The terminal only hands over
+// keys one at a time while the picker has it in raw mode, so reading stays
+// line by line the rest of the time.
+func (loop *eventLoop) readInput(reader *bufio.Reader) {
+	defer close(loop.lines)
+	var line []byte
+	chunk := make([]byte, 4096)
+	for {
+		n, err := reader.Read(chunk)
+		data := chunk[:n]
+		if len(data) > 0 && loop.keyMode.Load() {
+			select {
+			case loop.keys <- slices.Clone(data):
+			default:
+				// Keys pressed faster than the picker keeps up with are dropped
+			}
+			data = nil
+		}
+		for len(data) > 0 {
+			end := bytes.IndexByte(data, '\n')
+			if end < 0 {
+				line = append(line, data...)
+				break
+			}
+			line = append(line, data[:end+1]...)
+			loop.lines <- inputLine{text: string(line)}
+			line, data = nil, data[end+1:]
+		}
+		if err != nil {
+			loop.lines <- inputLine{text: string(line), err: err}
+			return
+		}
+	}
+}
+
+// readLine reads the next line of input, through the event loop if it's
+// running. Commands that prompt for input read with readLine, so that they
+// get the lines the REPL's background reader has read, and stop waiting if
+// they're cancelled with Ctrl+C.
+//
+// Parameters:
+//   - cfg: The application configuration containing the input reader
+//
+// Returns:
+//   - The line, including its newline if it had one
+//   - The error that ended the input, if any (io.EOF at the end, or
+//     context.Canceled if the command was cancelled)
+func readLine(cfg *config) (string, error) {
+	if cfg.events == nil {
+		return inputReader(cfg).ReadString('\n')
+	}
+	select {
+	case line, ok := <-cfg.events.lines:
+		if !ok {
+			return "", io.EOF
+		}
+		return line.text, line.err
+	case <-commandContext(cfg).Done():
+		fmt.Println()
+		return "", commandContext(cfg).Err()
+	}
+}
+
+// waitForLine waits for the next line of input, running background work
+// posted in the meantime. It returns errInterrupted if the user presses
+// Ctrl+C first.
+func waitForLine(cfg *config) (string, error) {
+	for {
+		select {
+		case line, ok := <-cfg.events.lines:
+			if !ok {
+				return "", io.EOF
+			}
+			return line.text, line.err
+		case job := <-cfg.events.jobs:
+			job()
+		case <-cfg.events.interrupts:
+			return "", errInterrupted
+		}
+	}
+}
+
+// commandContext returns the context of the running command, which is
+// cancelled when the user presses Ctrl+C. Commands that take a while pass it
+// on (for example with pokeapi.Client.WithContext) or check it between steps.
+//
+// Parameters:
+//   - cfg: The application configuration
+//
+// Returns:
+//   - The running command's context, or a context that's never cancelled
+//     when no command is running
+func commandContext(cfg *config) context.Context {
+	if cfg.commandCtx == nil {
+		return context.Background()
+	}
+	return cfg.commandCtx
+}
+
+// interruptContext returns a context for a command that's cancelled when the
+// user presses Ctrl+C. In the REPL, the press is taken from the event loop so
+// that the prompt doesn't see it too; otherwise, Ctrl+C is caught until stop
+// is called.
+//
+// Parameters:
+//   - cfg: The application configuration
+//
+// Returns:
+//   - The command's context
+//   - A function to call once the command is finished
+func interruptContext(cfg *config) (context.Context, context.CancelFunc) {
+	if cfg.events == nil {
+		return signal.NotifyContext(context.Background(), os.Interrupt)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-cfg.events.interrupts:
+			cancel()
+		case <-done:
+		}
+	}()
+	return ctx, func() {
+		close(done)
+		cancel()
+	}
+}
+
+// postJob hands work to the REPL to run between commands. Without an event
+// loop (when a single command is run), the work runs right away.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - job: The work to run; it should report anything with notify
+func postJob(cfg *config, job func()) {
+	if cfg.events == nil {
+		job()
+		return
+	}
+	select {
+	case cfg.events.jobs <- job:
+	default:
+		// The REPL is busy with a long command; the work is skipped
+	}
+}
+
+// notify shows a message from background work before the next prompt. It's
+// safe to call from any goroutine. Without an event loop, the message is
+// printed right away.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - format: The message to translate and format, like i18n.Printf
+//   - args: The values to format
+func notify(cfg *config, format string, args ...any) {
+	message := i18n.Sprintf(format, args...)
+	if cfg.events == nil {
+		fmt.Print(message)
+		return
+	}
+	cfg.events.mutex.Lock()
+	cfg.events.messages = append(cfg.events.messages, message)
+	cfg.events.mutex.Unlock()
+}
+
+// flushNotifications prints the messages from background work that are
+// waiting, if any.
+func flushNotifications(cfg *config) {
+	if cfg.events == nil {
+		return
+	}
+	cfg.events.mutex.Lock()
+	messages := cfg.events.messages
+	cfg.events.messages = nil
+	cfg.events.mutex.Unlock()
+	for _, message := range messages {
+		fmt.Print(message)
+	}
+}
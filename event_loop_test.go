@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestEventLoopInput tests that work posted while waiting for input runs
+// first, that lines are read through the event loop, and io.EOF at the end
+func TestEventLoopInput(t *testing.T) {
+	input, typed := io.Pipe()
+	cfg := &config{input: bufio.NewReader(input)}
+	startEventLoop(cfg)
+
+	// The job types the line the REPL waits for, so it must run first
+	postJob(cfg, func() { io.WriteString(typed, "pokedex\n") })
+	if line, err := waitForLine(cfg); line != "pokedex\n" || err != nil {
+		t.Fatalf("Expected the line typed by the job, got %q, %v", line, err)
+	}
+
+	go func() {
+		io.WriteString(typed, "y\n")
+		typed.Close()
+	}()
+	if line, err := readLine(cfg); line != "y\n" || err != nil {
+		t.Errorf("Expected prompts to read the next line, got %q, %v", line, err)
+	}
+	if _, err := waitForLine(cfg); err != io.EOF {
+		t.Errorf("Expected io.EOF at the end of the input, got %v", err)
+	}
+	if _, err := readLine(cfg); err != io.EOF {
+		t.Errorf("Expected io.EOF once the input has ended, got %v", err)
+	}
+}
+
+// TestEventLoopKeys tests that input goes to a picker as it's typed while one
+// is open, and is split into lines again once it closes
+func TestEventLoopKeys(t *testing.T) {
+	input, typed := io.Pipe()
+	cfg := &config{input: bufio.NewReader(input)}
+	startEventLoop(cfg)
+
+	cfg.events.keyMode.Store(true)
+	go io.WriteString(typed, "\x1b[B ")
+	if keys, err := readKeys(cfg); string(keys) != "\x1b[B " || err != nil {
+		t.Fatalf("Expected the keys as they were typed, got %q, %v", keys, err)
+	}
+	cfg.events.keyMode.Store(false)
+
+	go func() {
+		io.WriteString(typed, "pok")
+		io.WriteString(typed, "edex\nhelp\n")
+	}()
+	for _, want := range []string{"pokedex\n", "help\n"} {
+		if line, err := waitForLine(cfg); line != want || err != nil {
+			t.Errorf("Expected the line %q, got %q, %v", want, line, err)
+		}
+	}
+}
+
+// TestPostJobQueueFull tests that work posted while the queue is full is skipped
+func TestPostJobQueueFull(t *testing.T) {
+	cfg := &config{events: &eventLoop{jobs: make(chan func(), maxQueuedJobs)}}
+	ran := 0
+	for range maxQueuedJobs + 3 {
+		postJob(cfg, func() { ran++ })
+	}
+	if len(cfg.events.jobs) != maxQueuedJobs {
+		t.Fatalf("Expected %d queued jobs, got %d", maxQueuedJobs, len(cfg.events.jobs))
+	}
+	if ran != 0 {
+		t.Errorf("Expected no job to run before the REPL picks it up, %d ran", ran)
+	}
+
+	// Without an event loop, the work runs right away
+	postJob(&config{}, func() { ran++ })
+	if ran != 1 {
+		t.Error("Expected the job to run right away without an event loop")
+	}
+}
+
+// TestNotifications tests that messages from the background are held until
+// the next prompt, in the order they were sent
+func TestNotifications(t *testing.T) {
+	cfg := &config{events: &eventLoop{}}
+	notify(cfg, "Warning: Could not auto-save: %v\n", io.ErrShortWrite)
+	notify(cfg, "The dashboard stopped: %v\n", io.ErrClosedPipe)
+
+	want := []string{
+		"Warning: Could not auto-save: short write\n",
+		"The dashboard stopped: io: read/write on closed pipe\n",
+	}
+	if !slices.Equal(cfg.events.messages, want) {
+		t.Fatalf("Expected %q to be held, got %q", want, cfg.events.messages)
+	}
+	flushNotifications(cfg)
+	if len(cfg.events.messages) != 0 {
+		t.Errorf("Expected the messages to be printed once, %d are left", len(cfg.events.messages))
+	}
+}
+
+// TestInterrupts tests that Ctrl+C cancels the running command, and stops a
+// prompt without using up the next line, while at the prompt it's reported
+// by waitForLine
+func TestInterrupts(t *testing.T) {
+	cfg := &config{events: &eventLoop{lines: make(chan inputLine, 1), interrupts: make(chan os.Signal, 1)}}
+
+	ctx, stop := interruptContext(cfg)
+	cfg.commandCtx = ctx
+	cfg.events.interrupts <- os.Interrupt
+	<-ctx.Done()
+	if _, err := readLine(cfg); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the prompt to be cancelled, got %v", err)
+	}
+	stop()
+	cfg.commandCtx = nil
+
+	cfg.events.lines <- inputLine{text: "pokedex\n"}
+	if line, err := readLine(cfg); line != "pokedex\n" || err != nil {
+		t.Errorf("Expected the line to be kept for the next prompt, got %q, %v", line, err)
+	}
+	cfg.events.interrupts <- os.Interrupt
+	if _, err := waitForLine(cfg); err != errInterrupted {
+		t.Errorf("Expected Ctrl+C at the prompt to be reported, got %v", err)
+	}
+}
+
+// blockingTransport holds every API request until it's cancelled, as if the
+// API never answered. Each request is announced on started when it arrives.
+type blockingTransport struct{ started chan struct{} }
+
+func (b blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.started <- struct{}{}
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+// TestInterruptCancelsFetch tests that Ctrl+C abandons a request a command
+// makes through the shared client, and that the client is put back afterwards
+func TestInterruptCancelsFetch(t *testing.T) {
+	transport := blockingTransport{started: make(chan struct{}, 1)}
+	client := pokeapi.NewClientWithOptions(pokeapi.ClientOptions{CacheInterval: time.Hour, Transport: transport})
+	cfg := &config{
+		events:        &eventLoop{interrupts: make(chan os.Signal, 1)},
+		pokeapiClient: client,
+		pokedex:       pokedex.New(),
+		settings:      defaultSettings(),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		executeCommand(cfg, getCommands()["moveinfo"], []string{"thunderbolt"})
+		close(done)
+	}()
+	<-transport.started
+	cfg.events.interrupts <- os.Interrupt
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Ctrl+C to stop the command while it waited for the API")
+	}
+	if cfg.pokeapiClient != client {
+		t.Error("Expected the shared client to be restored after the command")
+	}
+}
@@ -0,0 +1,313 @@
+// This file contains the experience points Pokémon earn in battle. Each
+// opposing Pokémon defeated is worth experience based on its species' base
+// experience, as in the games, which is shared among the Pokémon that fought
+// it. Pokémon level up as they earn experience and are told when they can
+// evolve by leveling up. Defeating a Pokémon also earns the Pokémon that
+// fought it effort values (EVs) in the stats the defeated species yields.
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// trainerExperienceBonus is the multiplier on the experience earned for
+// defeating a trainer's Pokémon rather than a wild one, as in the games.
+const trainerExperienceBonus = 1.5
+
+// defeatExperience returns the experience points a defeated Pokémon is worth,
+// using the games' formula for a Pokémon at battleLevel.
+//
+// Parameters:
+//   - baseExperience: The defeated Pokémon's base experience from the API
+//   - trainer: Whether the Pokémon belonged to a trainer rather than being wild
+//
+// Returns:
+//   - The experience points earned
+func defeatExperience(baseExperience int, trainer bool) int {
+	points := float64(baseExperience*battleLevel) / 7
+	if trainer {
+		points *= trainerExperienceBonus
+	}
+	return max(int(points), 1)
+}
+
+// battleExperience works out the experience each of the player's Pokémon earns
+// from a battle. Each opposing Pokémon that fainted is worth defeatExperience,
+// shared evenly among the player's Pokémon that were sent out during the
+// battle. Pokémon that fainted themselves earn nothing.
+//
+// Parameters:
+//   - battle: The recorded battle
+//   - teams: The player's team, followed by the opponent's, as they were at the end of the battle
+//   - opponents: The opposing Pokémon's data, including their base experience
+//
+// Returns:
+//   - The experience points earned, by the names of the player's Pokémon
+func battleExperience(battle replayBattle, teams [2]*battleTeam, opponents []pokeapi.PokemonDataResp) map[string]int {
+	baseExperience := make(map[string]int, len(opponents))
+	for _, data := range opponents {
+		baseExperience[data.Name] = data.BaseExperience
+	}
+
+	participants, defeated := battleParticipants(battle, teams)
+	gains := make(map[string]int)
+	for _, member := range teams[0].members {
+		if member.fainted() || !slices.Contains(participants, member.name) {
+			continue
+		}
+		for _, name := range defeated {
+			gains[member.name] += max(defeatExperience(baseExperience[name], teams[1].player != "")/len(participants), 1)
+		}
+	}
+	return gains
+}
+
+// battleParticipants works out which of the player's Pokémon took part in a
+// battle, and which opposing Pokémon fainted. Everyone who took part is found
+// first, since a Pokémon sent out late still shares in the rewards for
+// opponents that fainted before it came out.
+//
+// Parameters:
+//   - battle: The recorded battle
+//   - teams: The player's team, followed by the opponent's
+//
+// Returns:
+//   - The names of the player's Pokémon that were sent out, in the order they came out
+//   - The names of the opposing Pokémon that fainted, in the order they fainted
+func battleParticipants(battle replayBattle, teams [2]*battleTeam) (participants, defeated []string) {
+	for _, turn := range battle.Turns {
+		for _, e := range turn {
+			switch {
+			case e.Kind == eventKindNames[eventSwitch] && e.Player == teams[0].player &&
+				!slices.Contains(participants, e.Pokemon):
+				participants = append(participants, e.Pokemon)
+			case e.Kind == eventKindNames[eventFaint] && e.Player == teams[1].player:
+				defeated = append(defeated, e.Pokemon)
+			}
+		}
+	}
+	return participants, defeated
+}
+
+// evYield returns the effort values a Pokémon yields when it's defeated, by
+// API stat name, from the effort field of its stats.
+func evYield(data pokeapi.PokemonDataResp) map[string]int {
+	yield := make(map[string]int)
+	for _, stat := range data.Stats {
+		if stat.Effort > 0 {
+			yield[stat.Stat.Name] = stat.Effort
+		}
+	}
+	return yield
+}
+
+// battleEVs works out the effort values each of the player's Pokémon earns
+// from a battle. Unlike experience, EVs aren't shared: every Pokémon sent out
+// during the battle earns the full yield of each opposing Pokémon that
+// fainted, as in the games. Pokémon that fainted themselves earn nothing.
+//
+// Parameters:
+//   - battle: The recorded battle
+//   - teams: The player's team, followed by the opponent's, as they were at the end of the battle
+//   - opponents: The opposing Pokémon's data, including their EV yields
+//
+// Returns:
+//   - The EVs earned by API stat name, by the names of the player's Pokémon
+func battleEVs(battle replayBattle, teams [2]*battleTeam, opponents []pokeapi.PokemonDataResp) map[string]map[string]int {
+	yields := make(map[string]map[string]int, len(opponents))
+	for _, data := range opponents {
+		yields[data.Name] = evYield(data)
+	}
+
+	participants, defeated := battleParticipants(battle, teams)
+	gains := make(map[string]map[string]int)
+	for _, member := range teams[0].members {
+		if member.fainted() || !slices.Contains(participants, member.name) {
+			continue
+		}
+		for _, name := range defeated {
+			addEVs(gains, member.name, yields[name])
+		}
+	}
+	return gains
+}
+
+// roundsEVs works out the effort values each of the user's Pokémon earns from
+// a trainer battle, where each Pokémon earns the yields of the opponents it defeated.
+//
+// Parameters:
+//   - result: The outcome of the battle
+//   - opponents: The trainer's Pokémon, including their EV yields
+//
+// Returns:
+//   - The EVs earned by API stat name, by the names of the user's Pokémon
+func roundsEVs(result battleResult, opponents []pokeapi.PokemonDataResp) map[string]map[string]int {
+	yields := make(map[string]map[string]int, len(opponents))
+	for _, data := range opponents {
+		yields[data.Name] = evYield(data)
+	}
+	gains := make(map[string]map[string]int)
+	for _, round := range result.rounds {
+		if round.won {
+			addEVs(gains, round.player, yields[round.opponent])
+		}
+	}
+	return gains
+}
+
+// addEVs adds a defeated Pokémon's EV yield to the EVs a Pokémon has earned.
+func addEVs(gains map[string]map[string]int, name string, yield map[string]int) {
+	if len(yield) == 0 {
+		return
+	}
+	if gains[name] == nil {
+		gains[name] = make(map[string]int)
+	}
+	for stat, points := range yield {
+		gains[name][stat] += points
+	}
+}
+
+// roundsExperience works out the experience each of the user's Pokémon earns
+// from a trainer battle, where each Pokémon earns all the experience of the
+// opponents it defeated.
+//
+// Parameters:
+//   - result: The outcome of the battle
+//   - opponents: The trainer's Pokémon, including their base experience
+//
+// Returns:
+//   - The experience points earned, by the names of the user's Pokémon
+func roundsExperience(result battleResult, opponents []pokeapi.PokemonDataResp) map[string]int {
+	baseExperience := make(map[string]int, len(opponents))
+	for _, data := range opponents {
+		baseExperience[data.Name] = data.BaseExperience
+	}
+	gains := make(map[string]int)
+	for _, round := range result.rounds {
+		if round.won {
+			gains[round.player] += defeatExperience(baseExperience[round.opponent], true)
+		}
+	}
+	return gains
+}
+
+// awardExperience gives the user's Pokémon the experience they earned, and
+// announces any that leveled up and any evolutions they can now reach by
+// leveling up. The caller saves the Pokédex.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - gains: The experience points earned, by the names of the user's Pokémon
+func awardExperience(cfg *config, gains map[string]int) {
+	names := make([]string, 0, len(gains))
+	for name := range gains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var before, after int
+		err := cfg.pokedex.Update(name, func(entry *pokedex.Entry) error {
+			before = entry.CurrentLevel()
+			entry.GainExperience(gains[name])
+			after = entry.CurrentLevel()
+			return nil
+		})
+		if err != nil {
+			// Every fighter came from the Pokédex, so only a Pokémon removed since then is skipped
+			continue
+		}
+		i18n.Printf("%s gained %d experience points.\n", FormatPokemonName(name), gains[name])
+		if after == before {
+			continue
+		}
+		i18n.Printf("%s grew to level %d!\n", FormatPokemonName(name), after)
+
+		// The evolution chain is only a hint here, so a failed lookup isn't reported
+		chain, err := cfg.pokeapiClient.GetEvolutionChainBySpecies(name)
+		if err != nil {
+			continue
+		}
+		evolutions, err := findEvolutionsFor(name, chain.Chain)
+		if err != nil {
+			continue
+		}
+		for _, evolution := range levelEvolutions(evolutions, before, after) {
+			i18n.Printf("%s can now evolve into %s! Use 'evolve %s' to evolve it.\n",
+				FormatPokemonName(name), FormatPokemonName(evolution), name)
+		}
+	}
+}
+
+// awardEVs gives the user's Pokémon the effort values they earned, up to the
+// limits on EVs, and announces what each gained. The caller saves the Pokédex.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - gains: The EVs earned by API stat name, by the names of the user's Pokémon
+func awardEVs(cfg *config, gains map[string]map[string]int) {
+	for _, name := range slices.Sorted(maps.Keys(gains)) {
+		var gained map[string]int
+		var total int
+		err := cfg.pokedex.Update(name, func(entry *pokedex.Entry) error {
+			gained = entry.GainEVs(gains[name])
+			total = entry.TotalEVs()
+			return nil
+		})
+		if err != nil {
+			// Every fighter came from the Pokédex, so only a Pokémon removed since then is skipped
+			continue
+		}
+		if len(gained) == 0 {
+			continue
+		}
+		i18n.Printf("%s gained EVs: %s\n", FormatPokemonName(name), formatEVGains(gained))
+		if total == pokedex.MaxTotalEVs {
+			i18n.Printf("%s has reached the limit of %d EVs.\n", FormatPokemonName(name), pokedex.MaxTotalEVs)
+		}
+	}
+}
+
+// formatEVGains formats the EVs a Pokémon gained for display, in the order
+// the API lists stats (e.g. "+1 Attack, +1 Speed").
+func formatEVGains(gained map[string]int) string {
+	var parts []string
+	for _, stat := range statOrder {
+		if points, ok := gained[stat]; ok {
+			parts = append(parts, fmt.Sprintf("+%d %s", points, FormatStatName(stat)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// levelEvolutions returns the evolutions a Pokémon reaches by leveling up that
+// became available as it grew from one level to another.
+//
+// Parameters:
+//   - evolutions: The Pokémon's possible evolutions
+//   - from: The level it was
+//   - to: The level it is now
+//
+// Returns:
+//   - The names of the evolutions in API format
+func levelEvolutions(evolutions []pokeapi.ChainLink, from, to int) []string {
+	var names []string
+	for _, evolution := range evolutions {
+		for _, detail := range evolution.EvolutionDetails {
+			if detail.Trigger.Name == "level-up" && detail.MinLevel > from && detail.MinLevel <= to {
+				names = append(names, evolution.Species.Name)
+				break
+			}
+		}
+	}
+	return names
+}
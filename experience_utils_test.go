@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestDefeatExperience tests the experience a defeated Pokémon is worth, with
+// the bonus for a trainer's Pokémon
+func TestDefeatExperience(t *testing.T) {
+	tests := []struct {
+		base    int
+		trainer bool
+		want    int
+	}{
+		{112, false, 800},
+		{112, true, 1200},
+		{0, false, 1},
+	}
+	for _, tt := range tests {
+		if got := defeatExperience(tt.base, tt.trainer); got != tt.want {
+			t.Errorf("defeatExperience(%d, %v) = %d, want %d", tt.base, tt.trainer, got, tt.want)
+		}
+	}
+}
+
+// TestBattleExperience tests that each defeated opponent's experience is shared
+// among the Pokémon sent out during the battle, leaving out any that fainted
+func TestBattleExperience(t *testing.T) {
+	pikachu := testBattler("pikachu", 50, []string{"electric"})
+	diglett := testBattler("diglett", 50, []string{"ground"})
+	onix := testBattler("onix", 50, []string{"rock"})
+	onix.hp = 0
+	squirtle := testBattler("squirtle", 50, []string{"water"})
+	player := &battleTeam{player: "Player", members: []*battler{pikachu, diglett, onix, squirtle}}
+	wild := &battleTeam{members: []*battler{testBattler("geodude", 50, []string{"rock"})}}
+	wild.members[0].hp = 0
+
+	event := func(kind battleEventKind, player, pokemon string) replayEvent {
+		return replayEvent{Kind: eventKindNames[kind], Player: player, Pokemon: pokemon}
+	}
+	battle := replayBattle{Turns: [][]replayEvent{
+		{event(eventSwitch, "Player", "onix"), event(eventSwitch, "", "geodude")},
+		{event(eventFaint, "Player", "onix"), event(eventSwitch, "Player", "pikachu")},
+		{event(eventSwitch, "Player", "diglett")},
+		{event(eventFaint, "", "geodude")},
+	}}
+	opponents := []pokeapi.PokemonDataResp{{Name: "geodude", BaseExperience: 60}}
+
+	got := battleExperience(battle, [2]*battleTeam{player, wild}, opponents)
+	share := defeatExperience(60, false) / 3
+	want := map[string]int{"pikachu": share, "diglett": share}
+	if !maps.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	wild.player = "Rock Gym Leader"
+	for i := range battle.Turns {
+		for j := range battle.Turns[i] {
+			if battle.Turns[i][j].Player == "" {
+				battle.Turns[i][j].Player = wild.player
+			}
+		}
+	}
+	got = battleExperience(battle, [2]*battleTeam{player, wild}, opponents)
+	if share := defeatExperience(60, true) / 3; got["pikachu"] != share {
+		t.Errorf("Expected a trainer battle share of %d, got %d", share, got["pikachu"])
+	}
+}
+
+// TestRoundsExperience tests that each opponent's experience goes to the
+// Pokémon that defeated it
+func TestRoundsExperience(t *testing.T) {
+	result := battleResult{rounds: []battleRound{
+		{player: "pikachu", opponent: "geodude", won: true},
+		{player: "pikachu", opponent: "onix", won: true},
+		{player: "pikachu", opponent: "golem", won: false},
+		{player: "squirtle", opponent: "golem", won: true},
+	}}
+	opponents := []pokeapi.PokemonDataResp{
+		{Name: "geodude", BaseExperience: 60},
+		{Name: "onix", BaseExperience: 77},
+		{Name: "golem", BaseExperience: 223},
+	}
+	want := map[string]int{
+		"pikachu":  defeatExperience(60, true) + defeatExperience(77, true),
+		"squirtle": defeatExperience(223, true),
+	}
+	if got := roundsExperience(result, opponents); !maps.Equal(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// testEVYieldPokemon builds Pokémon data with an EV yield, by API stat name.
+func testEVYieldPokemon(t *testing.T, name string, yield map[string]int) pokeapi.PokemonDataResp {
+	t.Helper()
+	var stats []string
+	for _, stat := range statOrder {
+		stats = append(stats, `{"base_stat": 50, "effort": `+strconv.Itoa(yield[stat])+`, "stat": {"name": "`+stat+`"}}`)
+	}
+	var data pokeapi.PokemonDataResp
+	if err := json.Unmarshal([]byte(`{"name": "`+name+`", "stats": [`+strings.Join(stats, ",")+`]}`), &data); err != nil {
+		t.Fatalf("Failed to build test Pokémon: %v", err)
+	}
+	return data
+}
+
+// TestBattleEVs tests that every Pokémon sent out and still standing earns the
+// full EV yield of each opponent that fainted
+func TestBattleEVs(t *testing.T) {
+	pikachu := testBattler("pikachu", 50, []string{"electric"})
+	onix := testBattler("onix", 50, []string{"rock"})
+	onix.hp = 0
+	squirtle := testBattler("squirtle", 50, []string{"water"})
+	player := &battleTeam{player: "Player", members: []*battler{pikachu, onix, squirtle}}
+	wild := &battleTeam{members: []*battler{testBattler("geodude", 50, []string{"rock"}), testBattler("zubat", 50, []string{"poison"})}}
+
+	event := func(kind battleEventKind, player, pokemon string) replayEvent {
+		return replayEvent{Kind: eventKindNames[kind], Player: player, Pokemon: pokemon}
+	}
+	battle := replayBattle{Turns: [][]replayEvent{
+		{event(eventSwitch, "Player", "onix"), event(eventSwitch, "", "geodude")},
+		{event(eventFaint, "Player", "onix"), event(eventSwitch, "Player", "pikachu")},
+		{event(eventFaint, "", "geodude"), event(eventSwitch, "", "zubat")},
+		{event(eventFaint, "", "zubat")},
+	}}
+	opponents := []pokeapi.PokemonDataResp{
+		testEVYieldPokemon(t, "geodude", map[string]int{"defense": 1}),
+		testEVYieldPokemon(t, "zubat", map[string]int{"speed": 1}),
+	}
+
+	got := battleEVs(battle, [2]*battleTeam{player, wild}, opponents)
+	want := map[string]int{"defense": 1, "speed": 1}
+	if len(got) != 1 || !maps.Equal(got["pikachu"], want) {
+		t.Errorf("Expected only pikachu to earn %v, got %v", want, got)
+	}
+}
+
+// TestRoundsEVs tests that each opponent's EV yield goes to the Pokémon that
+// defeated it
+func TestRoundsEVs(t *testing.T) {
+	result := battleResult{rounds: []battleRound{
+		{player: "pikachu", opponent: "geodude", won: true},
+		{player: "pikachu", opponent: "graveler", won: true},
+		{player: "pikachu", opponent: "golem", won: false},
+		{player: "squirtle", opponent: "golem", won: true},
+	}}
+	opponents := []pokeapi.PokemonDataResp{
+		testEVYieldPokemon(t, "geodude", map[string]int{"defense": 1}),
+		testEVYieldPokemon(t, "graveler", map[string]int{"defense": 2}),
+		testEVYieldPokemon(t, "golem", map[string]int{"defense": 3}),
+	}
+
+	got := roundsEVs(result, opponents)
+	if !maps.Equal(got["pikachu"], map[string]int{"defense": 3}) || !maps.Equal(got["squirtle"], map[string]int{"defense": 3}) {
+		t.Errorf("Unexpected EVs earned: %v", got)
+	}
+}
+
+// TestLevelEvolutions tests that only level-up evolutions whose level was just
+// reached are reported
+func TestLevelEvolutions(t *testing.T) {
+	evolution := func(name, trigger string, level int) pokeapi.ChainLink {
+		return pokeapi.ChainLink{
+			Species:          pokeapi.NamedAPIResource{Name: name},
+			EvolutionDetails: []pokeapi.EvolutionDetail{{Trigger: pokeapi.NamedAPIResource{Name: trigger}, MinLevel: level}},
+		}
+	}
+	evolutions := []pokeapi.ChainLink{
+		evolution("hitmonlee", "level-up", 20),
+		evolution("hitmonchan", "level-up", 25),
+		evolution("hitmontop", "level-up", 16),
+		evolution("alakazam", "trade", 0),
+	}
+
+	tests := []struct {
+		from, to int
+		want     []string
+	}{
+		{18, 20, []string{"hitmonlee"}},
+		{19, 30, []string{"hitmonlee", "hitmonchan"}},
+		{20, 24, nil},
+	}
+	for _, tt := range tests {
+		if got := levelEvolutions(evolutions, tt.from, tt.to); !slices.Equal(got, tt.want) {
+			t.Errorf("levelEvolutions(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
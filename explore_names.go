@@ -0,0 +1,106 @@
+// This file lets the Pokémon just found by 'explore' stand in for typing their
+// names in full. After exploring, 'catch pika' completes to the one Pokémon
+// found there whose name starts that way, misspelled names are offered the
+// Pokémon found there first, and tab completion lists them first, both in the
+// REPL and in the shell (see command_completion.go).
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// completeExploredName completes a partly typed name to the Pokémon found in
+// the area explored last, if exactly one of them starts with it and it isn't
+// the full name of another Pokémon.
+//
+// Parameters:
+//   - cfg: The application configuration containing the explored area and name index
+//   - nameInfo: The name as typed
+//
+// Returns:
+//   - The completed name, or nameInfo unchanged
+//   - Whether the name was completed
+func completeExploredName(cfg *config, nameInfo PokemonNameInfo) (PokemonNameInfo, bool) {
+	typed := nameInfo.APIFormat
+	explored := cfg.ExploredPokemon()
+	if typed == "" || slices.Contains(explored, typed) {
+		return nameInfo, false
+	}
+	matches := exploredWithPrefix(cfg, typed)
+	if len(matches) != 1 || knownPokemonName(cfg, typed) {
+		return nameInfo, false
+	}
+	return FormatPokemonInput(matches[0]), true
+}
+
+// exploredWithPrefix returns the Pokémon found in the area explored last whose
+// names start with a prefix, in alphabetical order.
+func exploredWithPrefix(cfg *config, prefix string) []string {
+	var matches []string
+	for _, name := range cfg.ExploredPokemon() {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+// knownPokemonName reports whether a name is a Pokémon's full name, according
+// to the species dataset or, failing that, the name index. If the index can't
+// be loaded, only the dataset is asked.
+func knownPokemonName(cfg *config, name string) bool {
+	if _, ok := cfg.Dataset().Lookup(name); ok {
+		return true
+	}
+	idx, err := getNameIndex(cfg)
+	return err == nil && idx.Contains(name)
+}
+
+// exploredSuggestions returns the Pokémon found in the area explored last
+// whose names start with, or are close to, a misspelled name.
+//
+// Parameters:
+//   - cfg: The application configuration containing the explored area
+//   - name: The misspelled name in API format
+//
+// Returns:
+//   - The matching names, closest first
+func exploredSuggestions(cfg *config, name string) []string {
+	if name == "" {
+		return nil
+	}
+	maxDistance := max(len(name)/3, 2) // The same allowance as nameIndex.Suggest
+	var prefixed, close []string
+	for _, explored := range cfg.ExploredPokemon() {
+		switch {
+		case strings.HasPrefix(explored, name):
+			prefixed = append(prefixed, explored)
+		case editDistance(name, explored) <= maxDistance:
+			close = append(close, explored)
+		}
+	}
+	slices.SortStableFunc(close, func(a, b string) int {
+		return editDistance(name, a) - editDistance(name, b)
+	})
+	return append(prefixed, close...)
+}
+
+// mergeSuggestions puts the suggestions from the explored area before those
+// from the whole Pokédex, without repeating any, up to maxNameSuggestions.
+func mergeSuggestions(explored, others []string) []string {
+	merged := mergeNames(explored, others)
+	return merged[:min(len(merged), maxNameSuggestions)]
+}
+
+// mergeNames puts the names from the explored area before the others, without
+// repeating any.
+func mergeNames(explored, others []string) []string {
+	merged := slices.Clone(explored)
+	for _, name := range others {
+		if !slices.Contains(merged, name) {
+			merged = append(merged, name)
+		}
+	}
+	return merged
+}
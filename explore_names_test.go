@@ -0,0 +1,114 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/dataset"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// exploredConfig returns a configuration with a complete dataset, having
+// explored an area where a few Pokémon were found.
+func exploredConfig() *config {
+	cfg := &config{dataset: dataset.New([]dataset.Species{
+		{ID: 10, Name: "caterpie"},
+		{ID: 16, Name: "pidgey"},
+		{ID: 25, Name: "pikachu"},
+		{ID: 26, Name: "raichu"},
+		{ID: 29, Name: "nidoran-f"},
+		{ID: 32, Name: "nidoran-m"},
+		{ID: 172, Name: "pichu"},
+	}, true)}
+	cfg.SetExploredArea("viridian-forest-area", []string{"caterpie", "pidgey", "pikachu", "nidoran-f", "nidoran-m"})
+	return cfg
+}
+
+// TestCompleteExploredName tests completing names from the area explored last
+func TestCompleteExploredName(t *testing.T) {
+	cfg := exploredConfig()
+	cases := []struct {
+		typed     string
+		completed string
+		ok        bool
+	}{
+		{"pika", "pikachu", true},
+		{"Cater", "caterpie", true},
+		{"pi", "pi", false},           // Pidgey and Pikachu both start with it
+		{"nidoran", "nidoran", false}, // Both Nidoran do too
+		{"pikachu", "pikachu", false}, // Already a full name
+		{"pichu", "pichu", false},     // Not found there, but a Pokémon all the same
+		{"raic", "raic", false},       // Not found there
+		{"", "", false},
+	}
+	for _, c := range cases {
+		got, ok := completeExploredName(cfg, FormatPokemonInput(c.typed))
+		if got.APIFormat != c.completed || ok != c.ok {
+			t.Errorf("completeExploredName(%q) = %q, %v, want %q, %v", c.typed, got.APIFormat, ok, c.completed, c.ok)
+		}
+	}
+}
+
+// TestExploredSuggestions tests that the Pokémon found in the area explored
+// last are suggested first for a misspelled name
+func TestExploredSuggestions(t *testing.T) {
+	cfg := exploredConfig()
+	if got := exploredSuggestions(cfg, "pidgy"); !slices.Equal(got, []string{"pidgey"}) {
+		t.Errorf("exploredSuggestions(pidgy) = %v, want [pidgey]", got)
+	}
+	if got := exploredSuggestions(cfg, "nidoran"); !slices.Equal(got, []string{"nidoran-f", "nidoran-m"}) {
+		t.Errorf("exploredSuggestions(nidoran) = %v, want both Nidoran", got)
+	}
+
+	merged := mergeSuggestions([]string{"pikachu"}, []string{"pichu", "pikachu", "raichu"})
+	if !slices.Equal(merged, []string{"pikachu", "pichu", "raichu"}) {
+		t.Errorf("mergeSuggestions = %v, want the explored name first without repeats", merged)
+	}
+
+	err := ValidatePokemonName(cfg, FormatPokemonInput("catterpie"))
+	if err == nil {
+		t.Fatal("Expected a misspelled name to be rejected")
+	}
+	if !strings.Contains(err.Error(), "Caterpie") {
+		t.Errorf("Expected Caterpie to be suggested, got %q", err.Error())
+	}
+}
+
+// notFoundTransport answers every API request with 404 Not Found, as if the
+// API had none of the resources asked for.
+type notFoundTransport struct{}
+
+func (notFoundTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody, Request: req}, nil
+}
+
+// TestCompletionsExploredFirst tests that the Pokémon found in the area
+// explored last are completed first, and are still completed when the name
+// index can't be loaded
+func TestCompletionsExploredFirst(t *testing.T) {
+	commands := getCommands()
+	cfg := exploredConfig()
+	if got, err := completions(cfg, commands, []string{"catch", "pi"}); err != nil || !slices.Equal(got, []string{"pidgey", "pikachu", "pichu"}) {
+		t.Errorf("completions(catch pi) = %v, %v, want the explored Pokémon before Pichu", got, err)
+	}
+	if got, err := completions(cfg, commands, []string{"inspect", "pi"}); err != nil || !slices.Equal(got, []string{"pichu", "pidgey", "pikachu"}) {
+		t.Errorf("completions(inspect pi) = %v, %v, want the index's order", got, err)
+	}
+
+	// Without a complete dataset, the index comes from the API, which fails here
+	cfg = &config{
+		dataset:       dataset.New(nil, false),
+		pokeapiClient: pokeapi.NewClientWithOptions(pokeapi.ClientOptions{CacheInterval: time.Hour, Transport: notFoundTransport{}}),
+		settings:      defaultSettings(),
+	}
+	cfg.SetExploredArea("viridian-forest-area", []string{"caterpie", "pidgey", "pikachu"})
+	if got, err := completions(cfg, commands, []string{"catch", "pi"}); err != nil || !slices.Equal(got, []string{"pidgey", "pikachu"}) {
+		t.Errorf("completions(catch pi) = %v, %v, want the explored Pokémon without the index", got, err)
+	}
+	if _, err := completions(cfg, commands, []string{"inspect", "pi"}); err == nil {
+		t.Error("Expected an error when there's nothing to complete from")
+	}
+}
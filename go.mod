@@ -2,9 +2,8 @@ module github.com/bmlevitt/pokedexcli
 
 go 1.24.0
 
-require golang.org/x/text v0.23.0
-
 require (
-	github.com/gofrs/flock v0.12.1 // indirect
-	golang.org/x/sys v0.22.0 // indirect
+	github.com/gofrs/flock v0.12.1
+	golang.org/x/sys v0.22.0
+	golang.org/x/text v0.23.0
 )
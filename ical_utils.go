@@ -0,0 +1,91 @@
+// This file writes the user's catch history as an iCalendar (.ics) file
+// (RFC 5545) for the export command, with an event for every catch, so that
+// collecting history can be browsed in a calendar app.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// icalTimeFormat is the layout of a UTC date-time in iCalendar.
+const icalTimeFormat = "20060102T150405Z"
+
+// icalLineLimit is the longest a line may be, in bytes, before it's folded
+// onto the next line.
+const icalLineLimit = 75
+
+// icalEscaper escapes the characters that have a meaning in iCalendar text values.
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// writeICal writes an iCalendar file with an event at the time of each catch.
+// Pokémon caught before catch dates were recorded have no date and are left out.
+//
+// Parameters:
+//   - w: Where to write the calendar
+//   - entries: The Pokédex entries, by name
+//   - now: When the calendar was made, recorded as each event's time stamp
+//
+// Returns:
+//   - The number of events written
+//   - An error if writing fails
+func writeICal(w io.Writer, entries []pokedex.NamedEntry, now time.Time) (int, error) {
+	out := bufio.NewWriter(w)
+	writeICalLine(out, "BEGIN:VCALENDAR")
+	writeICalLine(out, "VERSION:2.0")
+	writeICalLine(out, "PRODID:-//pokedexcli//Catch history//EN")
+	writeICalLine(out, "CALSCALE:GREGORIAN")
+	writeICalLine(out, "X-WR-CALNAME:"+icalEscaper.Replace(i18n.T("Pokémon catches")))
+
+	events := 0
+	for _, named := range entries {
+		entry := named.Entry
+		if entry.CaughtOn.IsZero() {
+			continue
+		}
+		events++
+		caughtOn := entry.CaughtOn.UTC().Format(icalTimeFormat)
+		writeICalLine(out, "BEGIN:VEVENT")
+		// The name and catch time identify the event, so exporting again updates it
+		writeICalLine(out, fmt.Sprintf("UID:%s-%s@pokedexcli", named.Name, caughtOn))
+		writeICalLine(out, "DTSTAMP:"+now.UTC().Format(icalTimeFormat))
+		writeICalLine(out, "DTSTART:"+caughtOn)
+		writeICalLine(out, "SUMMARY:"+icalEscaper.Replace(i18n.Sprintf("Caught %s", FormatPokemonName(named.Name))))
+		if entry.CaughtAt != "" {
+			writeICalLine(out, "LOCATION:"+icalEscaper.Replace(FormatLocationName(entry.CaughtAt)))
+		}
+		if len(entry.Notes) > 0 {
+			writeICalLine(out, "DESCRIPTION:"+icalEscaper.Replace(strings.Join(entry.Notes, "\n")))
+		}
+		writeICalLine(out, "END:VEVENT")
+	}
+	writeICalLine(out, "END:VCALENDAR")
+	return events, out.Flush()
+}
+
+// writeICalLine writes a content line, folding it onto continuation lines
+// that start with a space if it's too long. Lines are only folded between
+// characters, so that multibyte characters are never split.
+func writeICalLine(out *bufio.Writer, line string) {
+	limit := icalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		out.WriteString(line[:cut])
+		out.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts toward the length of continuation lines
+		limit = icalLineLimit - 1
+	}
+	out.WriteString(line)
+	out.WriteString("\r\n")
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestWriteICal tests the events written for catches, and that Pokémon without
+// a catch date are left out
+func TestWriteICal(t *testing.T) {
+	pikachu := pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric"))
+	pikachu.CaughtOn = time.Date(2026, 3, 14, 9, 26, 53, 0, time.UTC)
+	pikachu.CaughtAt = "viridian-forest-area"
+	pikachu.Notes = []string{"First catch; very shy", "Likes ketchup"}
+	squirtle := pokedex.NewEntry(testMatchupPokemon(t, "squirtle", 314, "water"))
+	entries := []pokedex.NamedEntry{{Name: "pikachu", Entry: pikachu}, {Name: "squirtle", Entry: squirtle}}
+
+	var out strings.Builder
+	events, err := writeICal(&out, entries, time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	if err != nil || events != 1 {
+		t.Fatalf("writeICal() = %d, %v; expected 1 event", events, err)
+	}
+	calendar := out.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:pikachu-20260314T092653Z@pokedexcli\r\n",
+		"DTSTAMP:20261015T120000Z\r\n",
+		"DTSTART:20260314T092653Z\r\n",
+		"SUMMARY:Caught Pikachu\r\n",
+		"LOCATION:Viridian Forest Area\r\n",
+		`DESCRIPTION:First catch\; very shy\nLikes ketchup` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(calendar, want) {
+			t.Errorf("Expected the calendar to contain %q, got:\n%s", want, calendar)
+		}
+	}
+	if strings.Contains(calendar, "Squirtle") {
+		t.Error("Expected Squirtle, which has no catch date, to be left out")
+	}
+}
+
+// TestWriteICalLine tests that long lines are folded without splitting characters
+func TestWriteICalLine(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("é", 80)
+	var out strings.Builder
+	w := bufio.NewWriter(&out)
+	writeICalLine(w, line)
+	w.Flush()
+
+	folded := strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n")
+	if len(folded) != 3 {
+		t.Fatalf("Expected the line to be folded into 3 lines, got %q", folded)
+	}
+	unfolded := folded[0]
+	for _, l := range folded {
+		if len(l) > icalLineLimit || !utf8.ValidString(l) {
+			t.Errorf("Folded line %q is too long or splits a character", l)
+		}
+	}
+	for _, l := range folded[1:] {
+		unfolded += strings.TrimPrefix(l, " ")
+	}
+	if unfolded != line {
+		t.Errorf("Expected unfolding to restore the line, got %q", unfolded)
+	}
+}
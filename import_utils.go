@@ -0,0 +1,333 @@
+// This file reads Pokémon from other programs' formats for the import command:
+// team exports from Pokémon Showdown, and CSV files whose columns are matched
+// to Pokédex fields with a mapping.
+//
+// A mapping is a comma-separated list of field=source assignments, e.g.
+//
+//	name=Species,level=Lvl,caught_on=Date,box="imported",moves=Move 1|Move 2
+//
+// A source is a column header (matched regardless of case), #N for the Nth
+// column, or a "quoted" value used for every row. Several sources separated by
+// | are tried in turn for a single value, while the moves and note fields take
+// a value from each of them.
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// importedPokemon is a Pokémon read from another program's format, before its
+// data is looked up.
+type importedPokemon struct {
+	Line     int       // The line of the file it was read from, for messages
+	Name     string    // The species name as written in the file
+	Level    int       // The Pokémon's level, or zero if none was given
+	Moves    []string  // The moves it knows, as written in the file
+	Notes    []string  // Notes to add to its entry
+	Box      string    // The box to put it in, or "" for none
+	CaughtAt string    // Where it was caught, or "" if unknown
+	CaughtOn time.Time // When it was caught (zero if unknown)
+}
+
+// importFields are the fields a CSV mapping can set, in the order they're listed in messages.
+var importFields = []string{"name", "level", "moves", "note", "box", "caught_at", "caught_on"}
+
+// importDateLayouts are the date formats accepted for caught_on.
+var importDateLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02", "2006/01/02"}
+
+// parseShowdown reads a team exported from Pokémon Showdown. Each Pokémon is a
+// block of lines separated by blank lines, starting with a line like
+// "Sparky (Pikachu) (M) @ Light Ball". Its level and moves are kept, and its
+// nickname is added as a note; abilities, items, EVs, and the like are ignored.
+//
+// Parameters:
+//   - r: The exported team
+//
+// Returns:
+//   - The Pokémon in the team, in order
+//   - An error if the text can't be read or has no Pokémon
+func parseShowdown(r io.Reader) ([]importedPokemon, error) {
+	var team []importedPokemon
+	var current *importedPokemon
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "":
+			current = nil
+		case strings.HasPrefix(text, "==="):
+			// Team headers in Showdown's backups, like "=== [gen9ou] Rain ==="
+			current = nil
+		case current == nil:
+			team = append(team, parseShowdownHeader(text, line))
+			current = &team[len(team)-1]
+		case strings.HasPrefix(text, "-"):
+			current.Moves = append(current.Moves, showdownMove(text))
+		case strings.HasPrefix(text, "Level:"):
+			level, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(text, "Level:")))
+			if err != nil || level < 1 || level > pokedex.MaxLevel {
+				return nil, importLineError(line, i18n.Sprintf("Invalid level '%s'", text))
+			}
+			current.Level = level
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(team) == 0 {
+		return nil, errorhandling.NewInvalidInputError("No Pokémon found in the Showdown export", nil)
+	}
+	return team, nil
+}
+
+// parseShowdownHeader reads the first line of a Pokémon in a Showdown export:
+// an optional nickname with the species in parentheses, an optional gender,
+// and an optional held item.
+func parseShowdownHeader(text string, line int) importedPokemon {
+	text, _, _ = strings.Cut(text, " @ ")
+	text = strings.TrimSpace(text)
+	text = strings.TrimSuffix(strings.TrimSuffix(text, " (M)"), " (F)")
+
+	pokemon := importedPokemon{Line: line, Name: text}
+	if open := strings.LastIndex(text, " ("); open > 0 && strings.HasSuffix(text, ")") {
+		pokemon.Name = text[open+2 : len(text)-1]
+		nickname := strings.TrimSpace(text[:open])
+		pokemon.Notes = []string{i18n.Sprintf("Nickname: %s", nickname)}
+	}
+	return pokemon
+}
+
+// showdownMove reads a move line of a Showdown export, like "- Thunderbolt".
+// Of alternatives written as "Thunderbolt / Volt Switch", the first is used,
+// and a Hidden Power's type in brackets is dropped.
+func showdownMove(text string) string {
+	move := strings.TrimSpace(strings.TrimPrefix(text, "-"))
+	move, _, _ = strings.Cut(move, "/")
+	move, _, _ = strings.Cut(move, "[")
+	return strings.TrimSpace(move)
+}
+
+// importSource is where a mapped field's value comes from: a column, or a
+// literal value.
+type importSource struct {
+	column  int    // The column's index, or -1 for a literal
+	literal string // The value, for a literal
+}
+
+// importMapping maps CSV columns to Pokédex fields.
+type importMapping map[string][]importSource
+
+// parseImportMapping parses a mapping against the header row of a CSV file.
+//
+// Parameters:
+//   - spec: The mapping, e.g. "name=Species,level=Lvl"
+//   - header: The CSV file's header row
+//
+// Returns:
+//   - The mapping
+//   - An error if the mapping is malformed, names an unknown field or column,
+//     or doesn't map the name field
+func parseImportMapping(spec string, header []string) (importMapping, error) {
+	mapping := make(importMapping)
+	for _, assignment := range strings.Split(spec, ",") {
+		field, sources, ok := strings.Cut(assignment, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !ok || field == "" {
+			return nil, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Invalid mapping '%s'. Use field=column, e.g. name=Species", strings.TrimSpace(assignment)), nil)
+		}
+		if !slices.Contains(importFields, field) {
+			return nil, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Unknown field '%s'. Fields: %s", field, strings.Join(importFields, ", ")), nil)
+		}
+		for _, raw := range strings.Split(sources, "|") {
+			source, err := parseImportSource(strings.TrimSpace(raw), header)
+			if err != nil {
+				return nil, err
+			}
+			mapping[field] = append(mapping[field], source)
+		}
+	}
+	if _, ok := mapping["name"]; !ok {
+		return nil, errorhandling.NewInvalidInputError("The mapping must set the name field, e.g. name=Species", nil)
+	}
+	return mapping, nil
+}
+
+// parseImportSource parses one source of a mapped field.
+func parseImportSource(raw string, header []string) (importSource, error) {
+	if len(raw) >= 2 && strings.HasPrefix(raw, `"`) && strings.HasSuffix(raw, `"`) {
+		return importSource{column: -1, literal: raw[1 : len(raw)-1]}, nil
+	}
+	if number, ok := strings.CutPrefix(raw, "#"); ok {
+		n, err := strconv.Atoi(number)
+		if err != nil || n < 1 || n > len(header) {
+			return importSource{}, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Column %s is out of range: the file has %d columns", raw, len(header)), nil)
+		}
+		return importSource{column: n - 1}, nil
+	}
+	for i, name := range header {
+		if strings.EqualFold(strings.TrimSpace(name), raw) {
+			return importSource{column: i}, nil
+		}
+	}
+	return importSource{}, errorhandling.NewInvalidInputError(
+		i18n.Sprintf("No column named '%s'. Columns: %s", raw, strings.Join(header, ", ")), nil)
+}
+
+// values returns the non-empty values of a field in a row.
+func (m importMapping) values(field string, row []string) []string {
+	var values []string
+	for _, source := range m[field] {
+		value := source.literal
+		if source.column >= 0 && source.column < len(row) {
+			value = row[source.column]
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// value returns the first non-empty value of a field in a row, or "".
+func (m importMapping) value(field string, row []string) string {
+	if values := m.values(field, row); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// parseImportCSV reads Pokémon from a CSV file whose first row names its
+// columns, mapping the columns to Pokédex fields. Rows without a name are
+// skipped.
+//
+// Parameters:
+//   - r: The CSV file
+//   - spec: The mapping of fields to columns (see parseImportMapping)
+//
+// Returns:
+//   - The Pokémon in the file, in order
+//   - An error if the file isn't valid CSV, the mapping is invalid, or a
+//     level or date can't be read
+func parseImportCSV(r io.Reader, spec string) ([]importedPokemon, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errorhandling.NewInvalidInputError("The CSV file is empty", nil)
+	}
+	if err != nil {
+		return nil, errorhandling.NewInvalidInputError("Could not read the CSV file", err)
+	}
+	mapping, err := parseImportMapping(spec, header)
+	if err != nil {
+		return nil, err
+	}
+
+	var imported []importedPokemon
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, errorhandling.NewInvalidInputError("Could not read the CSV file", err)
+		}
+		line, _ := reader.FieldPos(0)
+		pokemon, err := mapping.pokemon(row, line)
+		if err != nil {
+			return nil, err
+		}
+		if pokemon.Name != "" {
+			imported = append(imported, pokemon)
+		}
+	}
+	return imported, nil
+}
+
+// pokemon reads the Pokémon in a CSV row.
+func (m importMapping) pokemon(row []string, line int) (importedPokemon, error) {
+	pokemon := importedPokemon{
+		Line:     line,
+		Name:     m.value("name", row),
+		Moves:    m.values("moves", row),
+		Notes:    m.values("note", row),
+		Box:      m.value("box", row),
+		CaughtAt: m.value("caught_at", row),
+	}
+	if level := m.value("level", row); level != "" {
+		n, err := strconv.Atoi(level)
+		if err != nil || n < 1 || n > pokedex.MaxLevel {
+			return importedPokemon{}, importLineError(line, i18n.Sprintf("Invalid level '%s'", level))
+		}
+		pokemon.Level = n
+	}
+	if date := m.value("caught_on", row); date != "" {
+		caughtOn, ok := parseImportDate(date)
+		if !ok {
+			return importedPokemon{}, importLineError(line, i18n.Sprintf("Invalid date '%s'. Use YYYY-MM-DD", date))
+		}
+		pokemon.CaughtOn = caughtOn
+	}
+	return pokemon, nil
+}
+
+// parseImportDate reads a date in one of importDateLayouts, in local time
+// unless it has a time zone.
+func parseImportDate(date string) (time.Time, bool) {
+	for _, layout := range importDateLayouts {
+		if t, err := time.ParseInLocation(layout, date, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// importLineError returns the error for a problem on a line of an imported file.
+func importLineError(line int, message string) error {
+	return errorhandling.NewInvalidInputError(i18n.Sprintf("Line %d: %s", line, message), nil)
+}
+
+// importedEntry builds the Pokédex entry for an imported Pokémon from its
+// data. Moves it can't learn, or beyond the moveset's limit, are left out.
+//
+// Parameters:
+//   - pokemon: The imported Pokémon
+//   - entry: A new entry with its Pokémon data from the API
+//
+// Returns:
+//   - The entry
+//   - The moves that were left out, in API format
+func importedEntry(pokemon importedPokemon, entry pokedex.Entry) (pokedex.Entry, []string) {
+	entry.Level = pokemon.Level
+	entry.Notes = slices.Clone(pokemon.Notes)
+	entry.Box = ConvertToAPIFormat(pokemon.Box)
+	entry.CaughtAt = ConvertToAPIFormat(pokemon.CaughtAt)
+	entry.CaughtOn = pokemon.CaughtOn
+
+	var leftOut []string
+	for _, name := range pokemon.Moves {
+		move := ConvertToAPIFormat(name)
+		switch {
+		case entry.KnowsMove(move):
+		case entry.CanLearn(move, "") && len(entry.Moveset) < pokedex.MaxMovesetSize:
+			entry.Moveset = append(entry.Moveset, move)
+		default:
+			leftOut = append(leftOut, move)
+		}
+	}
+	return entry, leftOut
+}
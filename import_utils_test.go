@@ -0,0 +1,138 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestParseShowdown tests that species, nicknames, levels, and moves are read
+// from a Showdown export, and that everything else is ignored
+func TestParseShowdown(t *testing.T) {
+	export := `=== [gen9ou] Storm ===
+
+Sparky (Pikachu) (M) @ Light Ball
+Ability: Static
+Level: 50
+EVs: 252 Atk / 4 SpD / 252 Spe
+Jolly Nature
+- Volt Tackle
+- Hidden Power [Ice]
+- Thunderbolt / Volt Switch
+
+Mr. Mime (F)
+- Psychic
+`
+	team, err := parseShowdown(strings.NewReader(export))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(team) != 2 {
+		t.Fatalf("Expected 2 Pokémon, got %d", len(team))
+	}
+	sparky := team[0]
+	if sparky.Name != "Pikachu" || sparky.Level != 50 || sparky.Line != 3 {
+		t.Errorf("Unexpected Pokémon: %+v", sparky)
+	}
+	if !slices.Equal(sparky.Moves, []string{"Volt Tackle", "Hidden Power", "Thunderbolt"}) {
+		t.Errorf("Unexpected moves: %v", sparky.Moves)
+	}
+	if !slices.Equal(sparky.Notes, []string{"Nickname: Sparky"}) {
+		t.Errorf("Expected the nickname as a note, got %v", sparky.Notes)
+	}
+	if team[1].Name != "Mr. Mime" || team[1].Level != 0 || len(team[1].Notes) != 0 {
+		t.Errorf("Unexpected Pokémon: %+v", team[1])
+	}
+
+	if _, err := parseShowdown(strings.NewReader("Pikachu\nLevel: 101\n")); err == nil {
+		t.Error("Expected an invalid level to be rejected")
+	}
+	if _, err := parseShowdown(strings.NewReader("\n\n")); err == nil {
+		t.Error("Expected an export without Pokémon to be rejected")
+	}
+}
+
+// TestParseImportCSV tests mapping columns by header, by number, and to literal
+// values, with fallbacks and fields that take several columns
+func TestParseImportCSV(t *testing.T) {
+	file := `Species,Nickname,Lvl,Move 1,Move 2,Caught,Where
+Pikachu,Sparky,25,Thunderbolt,Quick Attack,2024-05-01,Viridian Forest
+,,,,,,
+Bulbasaur,,,Vine Whip,,,
+`
+	spec := `name=Species,level=#3,moves=Move 1|move 2,note=Nickname|"imported",caught_on=Caught,caught_at=Where,box="From CSV"`
+	imported, err := parseImportCSV(strings.NewReader(file), spec)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("Expected the row without a name to be skipped, got %d Pokémon", len(imported))
+	}
+
+	pikachu := imported[0]
+	if pikachu.Name != "Pikachu" || pikachu.Level != 25 || pikachu.Line != 2 || pikachu.CaughtAt != "Viridian Forest" {
+		t.Errorf("Unexpected Pokémon: %+v", pikachu)
+	}
+	if !slices.Equal(pikachu.Moves, []string{"Thunderbolt", "Quick Attack"}) || !slices.Equal(pikachu.Notes, []string{"Sparky", "imported"}) {
+		t.Errorf("Expected values from each column, got %v and %v", pikachu.Moves, pikachu.Notes)
+	}
+	if want := time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local); !pikachu.CaughtOn.Equal(want) {
+		t.Errorf("Expected the catch date %v, got %v", want, pikachu.CaughtOn)
+	}
+	if bulbasaur := imported[1]; bulbasaur.Box != "From CSV" || bulbasaur.Level != 0 || len(bulbasaur.Moves) != 1 {
+		t.Errorf("Unexpected Pokémon: %+v", bulbasaur)
+	}
+
+	if _, err := parseImportCSV(strings.NewReader("Species,Lvl\nPikachu,high\n"), "name=Species,level=Lvl"); err == nil {
+		t.Error("Expected an invalid level to be rejected")
+	}
+}
+
+// TestParseImportMapping tests that malformed mappings are rejected
+func TestParseImportMapping(t *testing.T) {
+	header := []string{"Species", "Level"}
+	for _, spec := range []string{
+		"level=Level",           // No name
+		"name=Species,hp=Level", // Unknown field
+		"name=Species,level",    // No source
+		"name=Pokemon",          // Unknown column
+		"name=#3",               // Column out of range
+	} {
+		if _, err := parseImportMapping(spec, header); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+	if _, err := parseImportMapping("NAME=species", header); err != nil {
+		t.Errorf("Expected fields and columns to match regardless of case, got %v", err)
+	}
+}
+
+// TestImportedEntry tests that moves the Pokémon can't learn, and moves beyond
+// the moveset's limit, are left out
+func TestImportedEntry(t *testing.T) {
+	data := testMatchupPokemon(t, "pikachu", 320, "electric")
+	for _, move := range []string{"thunderbolt", "quick-attack", "thunder", "surf", "volt-tackle"} {
+		data.Moves = append(data.Moves, pokeapi.PokemonMove{Move: pokeapi.NamedAPIResource{Name: move}})
+	}
+	pokemon := importedPokemon{
+		Name:  "Pikachu",
+		Level: 30,
+		Moves: []string{"Thunderbolt", "Fly", "Quick Attack", "Thunderbolt", "Thunder", "Surf", "Volt Tackle"},
+		Box:   "From CSV",
+	}
+
+	entry, leftOut := importedEntry(pokemon, pokedex.NewEntry(data))
+	if !slices.Equal(entry.Moveset, []string{"thunderbolt", "quick-attack", "thunder", "surf"}) {
+		t.Errorf("Unexpected moveset: %v", entry.Moveset)
+	}
+	if !slices.Equal(leftOut, []string{"fly", "volt-tackle"}) {
+		t.Errorf("Expected Fly and Volt Tackle to be left out, got %v", leftOut)
+	}
+	if entry.Level != 30 || entry.Box != "from-csv" {
+		t.Errorf("Unexpected entry: level %d, box %q", entry.Level, entry.Box)
+	}
+}
@@ -0,0 +1,238 @@
+// Package challenge provides user-defined challenges: goals such as "catch 5
+// Ghost-type Pokémon in 7 days", written by the user as small JSON files, and
+// the engine that checks them and works out how far along each one is.
+//
+// A challenge file looks like this:
+//
+//	{
+//	  "id": "ghost-hunter",
+//	  "name": "Ghost Hunter",
+//	  "description": "Catch 5 Ghost-type Pokémon in a week",
+//	  "goal": {"action": "catch", "count": 5, "type": "ghost", "days": 7}
+//	}
+//
+// The action is "catch" (Pokémon caught) or "see" (Pokémon seen for the first
+// time). The count is how many are needed. The optional type and location
+// count only Pokémon of that type, or caught or first seen in that location
+// area. The optional days is how long the user has to finish, from when they
+// start the challenge; without it, there's no time limit.
+package challenge
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FileExtension is the extension of challenge files; other files in the
+// challenge directory are ignored.
+const FileExtension = ".json"
+
+// Actions a goal can count
+const (
+	ActionCatch = "catch" // Pokémon caught
+	ActionSee   = "see"   // Pokémon seen for the first time
+)
+
+// Goal is what a challenge asks the user to do.
+type Goal struct {
+	Action   string `json:"action"`             // ActionCatch or ActionSee
+	Count    int    `json:"count"`              // How many Pokémon are needed
+	Type     string `json:"type,omitempty"`     // Only count Pokémon of this type, if set (catch only)
+	Location string `json:"location,omitempty"` // Only count Pokémon caught or first seen in this location area, if set
+	Days     int    `json:"days,omitempty"`     // The number of days to finish in, or 0 for no time limit
+}
+
+// Challenge is a challenge defined by the user.
+type Challenge struct {
+	ID          string `json:"id"`                    // Identifier typed to start it (e.g. "ghost-hunter")
+	Name        string `json:"name"`                  // Display name
+	Description string `json:"description,omitempty"` // What it asks, in the user's words
+	Goal        Goal   `json:"goal"`                  // What it takes to complete it
+}
+
+// Parse reads a challenge from JSON and checks it. Unknown fields are
+// rejected, so that a misspelled condition isn't silently ignored.
+//
+// Parameters:
+//   - data: The challenge in JSON
+//   - types: The type names a goal can use
+//
+// Returns:
+//   - The challenge, with its type and location in API format
+//   - An error describing what's wrong with it
+func Parse(data []byte, types []string) (Challenge, error) {
+	var c Challenge
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&c); err != nil {
+		return Challenge{}, err
+	}
+	c.Goal.Type = apiName(c.Goal.Type)
+	c.Goal.Location = apiName(c.Goal.Location)
+
+	switch {
+	case c.ID == "" || strings.ContainsAny(c.ID, " \t"):
+		return Challenge{}, errors.New(`"id" must be set, without spaces`)
+	case c.Name == "":
+		return Challenge{}, errors.New(`"name" must be set`)
+	case c.Goal.Action != ActionCatch && c.Goal.Action != ActionSee:
+		return Challenge{}, fmt.Errorf(`"action" must be %q or %q, not %q`, ActionCatch, ActionSee, c.Goal.Action)
+	case c.Goal.Count < 1:
+		return Challenge{}, errors.New(`"count" must be at least 1`)
+	case c.Goal.Days < 0:
+		return Challenge{}, errors.New(`"days" can't be negative`)
+	case c.Goal.Type != "" && !slices.Contains(types, c.Goal.Type):
+		return Challenge{}, fmt.Errorf("unknown type %q", c.Goal.Type)
+	case c.Goal.Type != "" && c.Goal.Action != ActionCatch:
+		return Challenge{}, fmt.Errorf(`"type" can only be used with %q`, ActionCatch)
+	}
+	return c, nil
+}
+
+// apiName converts a name typed by the user to API format (e.g. "Viridian
+// Forest Area" to "viridian-forest-area").
+func apiName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
+
+// FileError is a challenge file that couldn't be read or isn't valid.
+type FileError struct {
+	Path string // The path of the file
+	Err  error  // What's wrong with it
+}
+
+// Error describes the problem, with the file's name.
+func (e FileError) Error() string {
+	return fmt.Sprintf("%s: %v", filepath.Base(e.Path), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e FileError) Unwrap() error {
+	return e.Err
+}
+
+// LoadDir reads every challenge file in a directory. A file that can't be
+// read or isn't valid is reported and skipped, as is a challenge with the
+// same ID as one in an earlier file, so the rest can still be used.
+//
+// Parameters:
+//   - dir: The directory to read (a missing directory has no challenges)
+//   - types: The type names a goal can use
+//
+// Returns:
+//   - The valid challenges, ordered by file name
+//   - The files that were skipped, and why
+//   - An error if the directory exists but can't be read
+func LoadDir(dir string, types []string) ([]Challenge, []FileError, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var challenges []Challenge
+	var problems []FileError
+	ids := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != FileExtension {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		var c Challenge
+		if err == nil {
+			c, err = Parse(data, types)
+		}
+		if err == nil && ids[c.ID] != "" {
+			err = fmt.Errorf("the ID %q is already used by %s", c.ID, ids[c.ID])
+		}
+		if err != nil {
+			problems = append(problems, FileError{Path: path, Err: err})
+			continue
+		}
+		ids[c.ID] = entry.Name()
+		challenges = append(challenges, c)
+	}
+	return challenges, problems, nil
+}
+
+// Event is something the user did that a goal can count: catching a Pokémon
+// or seeing one for the first time.
+type Event struct {
+	Action   string    // ActionCatch or ActionSee
+	At       time.Time // When it happened
+	Types    []string  // The Pokémon's types
+	Location string    // The location area it happened in, if known
+}
+
+// Progress is how far along a started challenge is.
+type Progress struct {
+	Count     int       // How many of the needed Pokémon have been counted (at most the goal's count)
+	Completed time.Time // When the challenge was completed (zero if it hasn't been)
+	Deadline  time.Time // When the time to finish runs out (zero if there's no time limit)
+	Failed    bool      // Whether time ran out before it was completed
+}
+
+// Deadline returns when the time to finish a challenge started at a time runs
+// out, or the zero time if there's no time limit.
+func (c Challenge) Deadline(started time.Time) time.Time {
+	if c.Goal.Days == 0 {
+		return time.Time{}
+	}
+	return started.AddDate(0, 0, c.Goal.Days)
+}
+
+// Progress works out how far along a challenge is from what the user has
+// done. Only events from when it was started until its deadline count.
+//
+// Parameters:
+//   - started: When the user started the challenge
+//   - events: What the user has done, in any order
+//   - now: The current time, to tell whether time has run out
+//
+// Returns:
+//   - The challenge's progress
+func (c Challenge) Progress(started time.Time, events []Event, now time.Time) Progress {
+	progress := Progress{Deadline: c.Deadline(started)}
+	var counted []time.Time
+	for _, e := range events {
+		if c.counts(e, started, progress.Deadline) {
+			counted = append(counted, e.At)
+		}
+	}
+	slices.SortFunc(counted, time.Time.Compare)
+
+	progress.Count = min(len(counted), c.Goal.Count)
+	if len(counted) >= c.Goal.Count {
+		progress.Completed = counted[c.Goal.Count-1]
+	} else if !progress.Deadline.IsZero() && now.After(progress.Deadline) {
+		progress.Failed = true
+	}
+	return progress
+}
+
+// counts reports whether an event counts toward a challenge started at a time.
+func (c Challenge) counts(e Event, started, deadline time.Time) bool {
+	switch {
+	case e.Action != c.Goal.Action:
+		return false
+	case e.At.Before(started) || (!deadline.IsZero() && e.At.After(deadline)):
+		return false
+	case c.Goal.Type != "" && !slices.Contains(e.Types, c.Goal.Type):
+		return false
+	case c.Goal.Location != "" && e.Location != c.Goal.Location:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package challenge
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// types is the list of type names the tests accept.
+var types = []string{"ghost", "fire", "water"}
+
+// TestParse tests that valid challenges are read and invalid ones rejected
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(`{"id": "ghost-hunter", "name": "Ghost Hunter",
+		"goal": {"action": "catch", "count": 5, "type": "Ghost", "location": "Old Chateau", "days": 7}}`), types)
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+	want := Goal{Action: ActionCatch, Count: 5, Type: "ghost", Location: "old-chateau", Days: 7}
+	if c.ID != "ghost-hunter" || c.Name != "Ghost Hunter" || c.Goal != want {
+		t.Errorf("Parse() = %+v, want the ID, name, and goal %+v", c, want)
+	}
+
+	invalid := map[string]string{
+		"missing id":     `{"name": "A", "goal": {"action": "catch", "count": 1}}`,
+		"id with spaces": `{"id": "a b", "name": "A", "goal": {"action": "catch", "count": 1}}`,
+		"missing name":   `{"id": "a", "goal": {"action": "catch", "count": 1}}`,
+		"unknown action": `{"id": "a", "name": "A", "goal": {"action": "battle", "count": 1}}`,
+		"zero count":     `{"id": "a", "name": "A", "goal": {"action": "catch", "count": 0}}`,
+		"negative days":  `{"id": "a", "name": "A", "goal": {"action": "catch", "count": 1, "days": -1}}`,
+		"unknown type":   `{"id": "a", "name": "A", "goal": {"action": "catch", "count": 1, "type": "shadow"}}`,
+		"type with see":  `{"id": "a", "name": "A", "goal": {"action": "see", "count": 1, "type": "fire"}}`,
+		"unknown field":  `{"id": "a", "name": "A", "goal": {"action": "catch", "count": 1, "colour": "red"}}`,
+		"invalid JSON":   `{"id": "a",`,
+	}
+	for name, data := range invalid {
+		if _, err := Parse([]byte(data), types); err == nil {
+			t.Errorf("Expected Parse to reject a challenge with %s", name)
+		}
+	}
+}
+
+// TestLoadDir tests that valid files are loaded in order and problem files
+// are reported without stopping the rest
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b.json":    `{"id": "second", "name": "Second", "goal": {"action": "see", "count": 3}}`,
+		"a.json":    `{"id": "first", "name": "First", "goal": {"action": "catch", "count": 1}}`,
+		"c.json":    `{"id": "first", "name": "Copy", "goal": {"action": "catch", "count": 2}}`,
+		"d.json":    `not json`,
+		"notes.txt": `not a challenge`,
+		"readme.md": `# Challenges`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	challenges, problems, err := LoadDir(dir, types)
+	if err != nil {
+		t.Fatalf("LoadDir returned an error: %v", err)
+	}
+	if len(challenges) != 2 || challenges[0].ID != "first" || challenges[1].ID != "second" {
+		t.Errorf("Expected the challenges first and second, got %+v", challenges)
+	}
+	if len(problems) != 2 || filepath.Base(problems[0].Path) != "c.json" || filepath.Base(problems[1].Path) != "d.json" {
+		t.Errorf("Expected problems with c.json and d.json, got %v", problems)
+	}
+
+	challenges, problems, err = LoadDir(filepath.Join(dir, "missing"), types)
+	if err != nil || challenges != nil || problems != nil {
+		t.Errorf("Expected a missing directory to have no challenges, got %v, %v, %v", challenges, problems, err)
+	}
+	if !errors.Is(FileError{Path: "x", Err: os.ErrNotExist}, os.ErrNotExist) {
+		t.Error("Expected FileError to unwrap to its error")
+	}
+}
+
+// TestProgress tests counting events toward a goal, with filters, completion,
+// and a deadline
+func TestProgress(t *testing.T) {
+	started := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	c := Challenge{ID: "ghosts", Name: "Ghosts", Goal: Goal{Action: ActionCatch, Count: 2, Type: "ghost", Location: "old-chateau", Days: 7}}
+	ghost := func(day int, location string) Event {
+		return Event{Action: ActionCatch, At: started.AddDate(0, 0, day), Types: []string{"ghost", "poison"}, Location: location}
+	}
+	events := []Event{
+		ghost(-1, "old-chateau"), // Before the challenge started
+		ghost(1, "old-chateau"),
+		ghost(2, "lake-verity"), // Wrong location
+		{Action: ActionCatch, At: started.AddDate(0, 0, 2), Types: []string{"fire"}, Location: "old-chateau"}, // Wrong type
+		{Action: ActionSee, At: started.AddDate(0, 0, 2), Location: "old-chateau"},                            // Wrong action
+	}
+
+	progress := c.Progress(started, events, started.AddDate(0, 0, 3))
+	if progress.Count != 1 || !progress.Completed.IsZero() || progress.Failed {
+		t.Errorf("Expected 1 of 2 counted and still under way, got %+v", progress)
+	}
+	if want := started.AddDate(0, 0, 7); !progress.Deadline.Equal(want) {
+		t.Errorf("Deadline = %v, want %v", progress.Deadline, want)
+	}
+
+	progress = c.Progress(started, events, started.AddDate(0, 0, 8))
+	if !progress.Failed {
+		t.Errorf("Expected the challenge to fail after its deadline, got %+v", progress)
+	}
+
+	events = append(events, ghost(5, "old-chateau"), ghost(6, "old-chateau"), ghost(9, "old-chateau"))
+	progress = c.Progress(started, events, started.AddDate(0, 0, 10))
+	if progress.Count != 2 || !progress.Completed.Equal(started.AddDate(0, 0, 5)) || progress.Failed {
+		t.Errorf("Expected completion on the second counted catch, got %+v", progress)
+	}
+
+	unlimited := Challenge{Goal: Goal{Action: ActionSee, Count: 1}}
+	progress = unlimited.Progress(started, nil, started.AddDate(1, 0, 0))
+	if !progress.Deadline.IsZero() || progress.Failed {
+		t.Errorf("Expected a challenge without days never to fail, got %+v", progress)
+	}
+}
@@ -0,0 +1,89 @@
+// Package clipboard copies text to the system clipboard. There's no portable
+// way to reach the clipboard from Go without cgo, so it runs the command-line
+// tool each platform provides, feeding it the text on standard input:
+//
+//	Platform               Tools, in the order tried
+//	macOS                  pbcopy
+//	Windows                powershell (Set-Clipboard)
+//	Linux and other Unix   wl-copy (on Wayland), xclip, xsel, clip.exe (under WSL)
+//
+// Usage Example:
+//
+//	if err := clipboard.Copy("Pikachu, level 12"); errors.Is(err, clipboard.ErrUnavailable) {
+//	    fmt.Println("No clipboard tool is installed")
+//	}
+package clipboard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ErrUnavailable is returned by Copy when none of the platform's clipboard
+// tools is installed.
+var ErrUnavailable = errors.New("no clipboard tool is available")
+
+// tool is a command that copies its standard input to the clipboard.
+type tool struct {
+	name string   // The program to run
+	args []string // Its arguments
+}
+
+// Copy places text on the system clipboard, replacing what was there.
+//
+// Parameters:
+//   - text: The text to copy
+//
+// Returns:
+//   - ErrUnavailable if no clipboard tool is installed, or an error if the tool fails
+func Copy(text string) error {
+	for _, t := range toolsFor(runtime.GOOS, os.Getenv) {
+		path, err := exec.LookPath(t.name)
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, t.args...)
+		cmd.Stdin = strings.NewReader(text)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if message := strings.TrimSpace(stderr.String()); message != "" {
+				return fmt.Errorf("%s: %w: %s", t.name, err, message)
+			}
+			return fmt.Errorf("%s: %w", t.name, err)
+		}
+		return nil
+	}
+	return ErrUnavailable
+}
+
+// toolsFor returns the clipboard tools to try on an operating system, in
+// order, given how to look up environment variables.
+func toolsFor(goos string, getenv func(string) string) []tool {
+	switch goos {
+	case "darwin":
+		return []tool{{name: "pbcopy"}}
+	case "windows":
+		// clip.exe mangles text that isn't ASCII, so PowerShell reads it as UTF-8
+		return []tool{{name: "powershell.exe", args: []string{"-NoProfile", "-NonInteractive", "-Command",
+			"[Console]::InputEncoding = [Text.Encoding]::UTF8; Set-Clipboard -Value ([Console]::In.ReadToEnd())"}}}
+	}
+
+	var tools []tool
+	if getenv("WAYLAND_DISPLAY") != "" {
+		tools = append(tools, tool{name: "wl-copy"})
+	}
+	tools = append(tools,
+		tool{name: "xclip", args: []string{"-selection", "clipboard"}},
+		tool{name: "xsel", args: []string{"--clipboard", "--input"}},
+	)
+	if getenv("WSL_DISTRO_NAME") != "" {
+		tools = append(tools, tool{name: "clip.exe"})
+	}
+	return tools
+}
@@ -0,0 +1,66 @@
+package clipboard
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+// TestToolsFor tests the clipboard tools tried on each platform
+func TestToolsFor(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(name string) string { return vars[name] }
+	}
+	tests := []struct {
+		goos string
+		vars map[string]string
+		want []string
+	}{
+		{"darwin", nil, []string{"pbcopy"}},
+		{"windows", nil, []string{"powershell.exe"}},
+		{"linux", nil, []string{"xclip", "xsel"}},
+		{"linux", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, []string{"wl-copy", "xclip", "xsel"}},
+		{"linux", map[string]string{"WSL_DISTRO_NAME": "Ubuntu"}, []string{"xclip", "xsel", "clip.exe"}},
+	}
+	for _, tt := range tests {
+		var names []string
+		for _, tool := range toolsFor(tt.goos, env(tt.vars)) {
+			names = append(names, tool.name)
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("toolsFor(%s, %v) = %v, want %v", tt.goos, tt.vars, names, tt.want)
+		}
+	}
+}
+
+// TestCopy tests feeding the text to a clipboard tool, and reporting when
+// there's none
+func TestCopy(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("The fake clipboard tool is a shell script")
+	}
+	dir := t.TempDir()
+	t.Setenv("PATH", dir)
+	t.Setenv("WAYLAND_DISPLAY", "")
+	t.Setenv("WSL_DISTRO_NAME", "")
+	if err := Copy("hello"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable without a clipboard tool, got %v", err)
+	}
+
+	// A fake xclip that saves what it's given, found before any real one
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+"/bin:/usr/bin")
+	out := filepath.Join(dir, "copied")
+	script := "#!/bin/sh\ncat > " + out + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "xclip"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := Copy("Pikachu ⚡"); err != nil {
+		t.Fatalf("Copy returned an error: %v", err)
+	}
+	if data, err := os.ReadFile(out); err != nil || string(data) != "Pikachu ⚡" {
+		t.Errorf("Expected the tool to get the text, got %q, %v", data, err)
+	}
+}
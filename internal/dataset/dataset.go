@@ -0,0 +1,190 @@
+// Package dataset provides a compact, static table of Pokémon species: their
+// names, National Pokédex numbers, types, and base stats. A copy is embedded in
+// the binary so that lookups work instantly without the network, and a fuller
+// copy can be downloaded from the PokeAPI and saved locally, where it takes the
+// place of the embedded one.
+package dataset
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// embeddedJSON is the dataset built into the binary. It covers every species
+// in its default form, so it's marked complete; "dataset update" replaces it
+// with every Pokémon in the PokeAPI, alternate forms included.
+//
+//go:embed species.json
+var embeddedJSON []byte
+
+// BaseStats holds a species' base stats, keyed by their PokeAPI names.
+type BaseStats struct {
+	HP             int `json:"hp"`
+	Attack         int `json:"attack"`
+	Defense        int `json:"defense"`
+	SpecialAttack  int `json:"special-attack"`
+	SpecialDefense int `json:"special-defense"`
+	Speed          int `json:"speed"`
+}
+
+// Total returns the sum of the base stats.
+func (s BaseStats) Total() int {
+	return s.HP + s.Attack + s.Defense + s.SpecialAttack + s.SpecialDefense + s.Speed
+}
+
+// Species is the static data for one Pokémon.
+type Species struct {
+	ID    int       `json:"id"`    // National Pokédex number (or PokeAPI ID for alternate forms)
+	Name  string    `json:"name"`  // Name in API format (lowercase with hyphens)
+	Types []string  `json:"types"` // Type names, in slot order
+	Stats BaseStats `json:"stats"` // Base stats
+}
+
+// Dataset is a table of species, indexed by name and number.
+type Dataset struct {
+	Updated  time.Time `json:"updated,omitzero"` // When the dataset was downloaded (zero for the embedded one)
+	Complete bool      `json:"complete"`         // Whether the dataset covers every species in the PokeAPI
+	Species  []Species `json:"species"`          // The species, ordered by ID
+
+	byName map[string]int // Index into Species by name
+	byID   map[int]int    // Index into Species by ID
+}
+
+// New builds a dataset from a list of species, ordering them by ID.
+//
+// Parameters:
+//   - species: The species to include, in any order
+//   - complete: Whether the list covers every Pokémon in the PokeAPI
+//
+// Returns:
+//   - The indexed dataset, stamped with the current time
+func New(species []Species, complete bool) *Dataset {
+	d := &Dataset{Updated: time.Now(), Complete: complete, Species: species}
+	d.index()
+	return d
+}
+
+// index sorts the species by ID and builds the lookup maps.
+func (d *Dataset) index() {
+	sort.SliceStable(d.Species, func(i, j int) bool {
+		return d.Species[i].ID < d.Species[j].ID
+	})
+	d.byName = make(map[string]int, len(d.Species))
+	d.byID = make(map[int]int, len(d.Species))
+	for i, species := range d.Species {
+		d.byName[species.Name] = i
+		d.byID[species.ID] = i
+	}
+}
+
+// parse decodes and indexes a dataset from JSON.
+func parse(encoded []byte) (*Dataset, error) {
+	var d Dataset
+	if err := json.Unmarshal(encoded, &d); err != nil {
+		return nil, err
+	}
+	d.index()
+	return &d, nil
+}
+
+// embedded decodes the embedded dataset once, on first use.
+var embedded = sync.OnceValue(func() *Dataset {
+	d, err := parse(embeddedJSON)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded dataset: %v", err))
+	}
+	return d
+})
+
+// Embedded returns the dataset built into the binary.
+func Embedded() *Dataset {
+	return embedded()
+}
+
+// Load reads the dataset saved at path, or returns the embedded dataset if
+// there isn't one.
+//
+// Parameters:
+//   - path: The location of the downloaded dataset
+//
+// Returns:
+//   - The dataset
+//   - Whether the dataset was read from path
+//   - An error if the file exists but can't be read or decoded
+func Load(path string) (*Dataset, bool, error) {
+	encoded, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return Embedded(), false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading dataset: %w", err)
+	}
+
+	d, err := parse(encoded)
+	if err != nil {
+		return nil, false, fmt.Errorf("error decoding dataset: %w", err)
+	}
+	return d, true, nil
+}
+
+// Write saves a dataset to path, replacing the file atomically.
+//
+// Parameters:
+//   - path: The location to save the dataset
+//   - d: The dataset to save
+//
+// Returns:
+//   - An error if the dataset can't be written
+func Write(path string, d *Dataset) error {
+	encoded, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("error serializing dataset: %w", err)
+	}
+
+	tempFilePath := path + ".tmp"
+	if err := os.WriteFile(tempFilePath, encoded, 0644); err != nil {
+		return fmt.Errorf("error writing temporary dataset file: %w", err)
+	}
+	if err := os.Rename(tempFilePath, path); err != nil {
+		os.Remove(tempFilePath)
+		return fmt.Errorf("error replacing dataset file: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of species in the dataset.
+func (d *Dataset) Len() int {
+	return len(d.Species)
+}
+
+// Lookup returns the species with the given API-format name.
+func (d *Dataset) Lookup(name string) (Species, bool) {
+	i, ok := d.byName[name]
+	if !ok {
+		return Species{}, false
+	}
+	return d.Species[i], true
+}
+
+// ByID returns the species with the given Pokédex number.
+func (d *Dataset) ByID(id int) (Species, bool) {
+	i, ok := d.byID[id]
+	if !ok {
+		return Species{}, false
+	}
+	return d.Species[i], true
+}
+
+// Names returns the names of every species, ordered by ID.
+func (d *Dataset) Names() []string {
+	names := make([]string, len(d.Species))
+	for i, species := range d.Species {
+		names[i] = species.Name
+	}
+	return names
+}
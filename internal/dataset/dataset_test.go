@@ -0,0 +1,99 @@
+package dataset
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestEmbedded tests that the embedded dataset decodes and can be looked up by
+// name and by number
+func TestEmbedded(t *testing.T) {
+	d := Embedded()
+	if d.Len() == 0 {
+		t.Fatal("Expected the embedded dataset to contain species")
+	}
+
+	pikachu, ok := d.Lookup("pikachu")
+	if !ok {
+		t.Fatal("Expected pikachu in the embedded dataset")
+	}
+	if pikachu.ID != 25 || len(pikachu.Types) != 1 || pikachu.Types[0] != "electric" || pikachu.Stats.Total() != 320 {
+		t.Errorf("Unexpected data for pikachu: %+v", pikachu)
+	}
+	if byID, ok := d.ByID(25); !ok || byID.Name != "pikachu" {
+		t.Errorf("Expected number 25 to be pikachu, got %+v", byID)
+	}
+	if _, ok := d.Lookup("missingno"); ok {
+		t.Error("Expected an unknown name not to be found")
+	}
+
+	// Every species needs a name, a number, and at least one type
+	for _, species := range d.Species {
+		if species.Name == "" || species.ID <= 0 || len(species.Types) == 0 {
+			t.Errorf("Incomplete species in the embedded dataset: %+v", species)
+		}
+	}
+}
+
+// TestEmbeddedCoversEverySpecies tests that the embedded dataset has every
+// species listed in the golden names, in National Pokédex order. Species the
+// API names after their default form (e.g. "deoxys-normal") keep that name.
+func TestEmbeddedCoversEverySpecies(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "pokemon_names.golden"))
+	if err != nil {
+		t.Fatalf("Failed to read the golden names: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+
+	d := Embedded()
+	if !d.Complete || d.Len() != len(lines) {
+		t.Fatalf("Expected a complete dataset of %d species, got %d (complete: %v)", len(lines), d.Len(), d.Complete)
+	}
+	for i, line := range lines {
+		fields := strings.Split(line, "\t")
+		id, _ := strconv.Atoi(fields[0])
+		species, ok := d.ByID(id)
+		if !ok || (species.Name != fields[1] && !strings.HasPrefix(species.Name, fields[1]+"-")) {
+			t.Errorf("Line %d: expected %s, got %+v", i+1, fields[1], species)
+		}
+		if species.Stats.Total() == 0 {
+			t.Errorf("Expected base stats for %s", species.Name)
+		}
+	}
+}
+
+// TestWriteAndLoad tests that a saved dataset takes the place of the embedded
+// one, in ID order and with its indexes rebuilt
+func TestWriteAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dataset.json")
+
+	d, found, err := Load(path)
+	if err != nil || found || d != Embedded() {
+		t.Fatalf("Expected the embedded dataset without a saved one, got found=%v, err=%v", found, err)
+	}
+
+	saved := New([]Species{
+		{ID: 133, Name: "eevee", Types: []string{"normal"}},
+		{ID: 1, Name: "bulbasaur", Types: []string{"grass", "poison"}},
+	}, true)
+	if err := Write(path, saved); err != nil {
+		t.Fatalf("Failed to write dataset: %v", err)
+	}
+
+	d, found, err = Load(path)
+	if err != nil || !found {
+		t.Fatalf("Failed to load dataset: found=%v, err=%v", found, err)
+	}
+	if !d.Complete || d.Updated.IsZero() {
+		t.Errorf("Expected a complete, dated dataset, got complete=%v, updated=%v", d.Complete, d.Updated)
+	}
+	if names := d.Names(); len(names) != 2 || names[0] != "bulbasaur" || names[1] != "eevee" {
+		t.Errorf("Expected the species in ID order, got %v", names)
+	}
+	if eevee, ok := d.ByID(133); !ok || eevee.Name != "eevee" {
+		t.Errorf("Expected number 133 to be eevee, got %+v", eevee)
+	}
+}
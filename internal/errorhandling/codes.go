@@ -0,0 +1,166 @@
+// This file defines the stable error codes attached to application errors.
+// Each code identifies a specific failure so that users can look up its likely
+// cause and how to fix it with the 'explain' command, and so that bug reports
+// can refer to errors precisely regardless of the wording of the message.
+package errorhandling
+
+import (
+	"sort"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// Error codes are grouped by category:
+//   - E1xxx: A requested resource was not found
+//   - E2xxx: The user's input was invalid
+//   - E3xxx: The Pokémon API could not be reached or returned an error
+//   - E4xxx: Problems inside the application or with the data it received
+const (
+	CodeNotFound          = "E1001"
+	CodePokemonNotFound   = "E1002"
+	CodeLocationNotFound  = "E1003"
+	CodeEvolutionNotFound = "E1004"
+	CodeNotInPokedex      = "E1005"
+
+	CodeInvalidInput       = "E2001"
+	CodeInvalidPokemonName = "E2002"
+
+	CodeNetwork        = "E3001"
+	CodeRateLimited    = "E3002"
+	CodeAPIUnavailable = "E3003"
+	CodeAPIBadRequest  = "E3004"
+	CodeAPIUnexpected  = "E3005"
+
+	CodeInternal        = "E4001"
+	CodeInvalidResponse = "E4002"
+)
+
+// CodeInfo describes an error code for users: what it means, why it
+// typically happens, and what they can do about it.
+type CodeInfo struct {
+	Code   string // The stable error code (e.g. "E1002")
+	Title  string // A short summary of the error
+	Cause  string // The most likely cause of the error
+	Remedy string // Suggested steps to resolve the error
+}
+
+// codeCatalog contains the explanation for every error code.
+var codeCatalog = map[string]CodeInfo{
+	CodeNotFound: {
+		Code:   CodeNotFound,
+		Title:  "Resource not found",
+		Cause:  "The Pokémon API has no resource with the requested name or ID.",
+		Remedy: "Check the spelling of the name you entered and try again.",
+	},
+	CodePokemonNotFound: {
+		Code:   CodePokemonNotFound,
+		Title:  "Pokémon not found",
+		Cause:  "The Pokémon API doesn't recognize the Pokémon name.",
+		Remedy: "Check the spelling, or end the line with a tab to list matching names (e.g. 'catch pika<TAB>').",
+	},
+	CodeLocationNotFound: {
+		Code:   CodeLocationNotFound,
+		Title:  "Location not found",
+		Cause:  "The location area no longer exists or the location list is out of date.",
+		Remedy: "Run 'map' to reload the list of locations, then explore by number.",
+	},
+	CodeEvolutionNotFound: {
+		Code:   CodeEvolutionNotFound,
+		Title:  "Evolution data not found",
+		Cause:  "The Pokémon has no evolution chain in the Pokémon API.",
+		Remedy: "This Pokémon can't be evolved. No action is needed.",
+	},
+	CodeNotInPokedex: {
+		Code:   CodeNotInPokedex,
+		Title:  "Pokémon not in your Pokédex",
+		Cause:  "The command only works with Pokémon you have caught.",
+		Remedy: "Run 'pokedex' to see what you've caught, or catch the Pokémon first.",
+	},
+	CodeInvalidInput: {
+		Code:   CodeInvalidInput,
+		Title:  "Invalid input",
+		Cause:  "A command was given missing or invalid parameters.",
+		Remedy: "Run 'help' to see each command and the parameters it expects.",
+	},
+	CodeInvalidPokemonName: {
+		Code:   CodeInvalidPokemonName,
+		Title:  "Invalid Pokémon name",
+		Cause:  "The name doesn't match any Pokémon.",
+		Remedy: "Use one of the suggested names, or end the line with a tab to list matching names.",
+	},
+	CodeNetwork: {
+		Code:   CodeNetwork,
+		Title:  "Network error",
+		Cause:  "The Pokémon API couldn't be reached, usually because of a connection problem.",
+		Remedy: "Check your internet connection and try again. Data you've already viewed is cached.",
+	},
+	CodeRateLimited: {
+		Code:   CodeRateLimited,
+		Title:  "Rate limit exceeded",
+		Cause:  "Too many requests were sent to the Pokémon API in a short time.",
+		Remedy: "Wait a minute before trying again.",
+	},
+	CodeAPIUnavailable: {
+		Code:   CodeAPIUnavailable,
+		Title:  "Pokémon API unavailable",
+		Cause:  "The Pokémon API is down or having temporary problems.",
+		Remedy: "Try again in a few minutes.",
+	},
+	CodeAPIBadRequest: {
+		Code:   CodeAPIBadRequest,
+		Title:  "Bad request",
+		Cause:  "The Pokémon API rejected the request, usually because of an unusual name.",
+		Remedy: "Check the name you entered for unusual characters and try again.",
+	},
+	CodeAPIUnexpected: {
+		Code:   CodeAPIUnexpected,
+		Title:  "Unexpected API error",
+		Cause:  "The Pokémon API responded with an unexpected status code.",
+		Remedy: "Try again. If the problem continues, enable 'debug' and report the details.",
+	},
+	CodeInternal: {
+		Code:   CodeInternal,
+		Title:  "Internal error",
+		Cause:  "Something went wrong inside the Pokédex application.",
+		Remedy: "Enable 'debug' to see the details and report the problem.",
+	},
+	CodeInvalidResponse: {
+		Code:   CodeInvalidResponse,
+		Title:  "Incomplete API data",
+		Cause:  "The Pokémon API returned data with missing fields, so it wasn't used.",
+		Remedy: "Try again later. If the problem continues, the API data may need correcting upstream.",
+	},
+}
+
+// LookupCode returns the explanation for an error code.
+//
+// Parameters:
+//   - code: The error code to look up (e.g. "E1002")
+//
+// Returns:
+//   - The CodeInfo describing the code
+//   - A boolean indicating whether the code exists
+func LookupCode(code string) (CodeInfo, bool) {
+	info, ok := codeCatalog[code]
+	return info.translated(), ok
+}
+
+// AllCodes returns the explanations for every error code, ordered by code.
+func AllCodes() []CodeInfo {
+	codes := make([]CodeInfo, 0, len(codeCatalog))
+	for _, info := range codeCatalog {
+		codes = append(codes, info.translated())
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].Code < codes[j].Code
+	})
+	return codes
+}
+
+// translated returns the explanation in the selected language.
+func (info CodeInfo) translated() CodeInfo {
+	info.Title = i18n.T(info.Title)
+	info.Cause = i18n.T(info.Cause)
+	info.Remedy = i18n.T(info.Remedy)
+	return info
+}
@@ -0,0 +1,44 @@
+package errorhandling
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestErrorCodesHaveExplanations verifies that every error constructor assigns
+// a code that the 'explain' command can describe.
+func TestErrorCodesHaveExplanations(t *testing.T) {
+	cause := errors.New("cause")
+	errs := []*AppError{
+		NewNotFoundError("thing", "name", cause),
+		NewInvalidInputError("bad input", cause),
+		NewNetworkError("offline", cause),
+		NewAPIError(400, "/x", cause),
+		NewAPIError(404, "/x", cause),
+		NewAPIError(429, "/x", cause),
+		NewAPIError(503, "/x", cause),
+		NewAPIError(418, "/x", cause),
+		NewInternalError("oops", cause),
+		NewInvalidResponseError("thing", "name", "missing field"),
+		PokemonNotFoundError("pikachu", cause),
+		LocationNotFoundError("route-1", cause),
+		EvolutionNotFoundError("pikachu", cause),
+		PokemonNotInPokedexError("pikachu"),
+		InvalidPokemonNameError("pikachoo"),
+	}
+
+	for _, err := range errs {
+		if err.Code == "" {
+			t.Errorf("Error %q has no code", err.Message)
+			continue
+		}
+		info, ok := LookupCode(err.Code)
+		if !ok {
+			t.Errorf("Code %s for %q has no explanation", err.Code, err.Message)
+			continue
+		}
+		if info.Cause == "" || info.Remedy == "" {
+			t.Errorf("Code %s is missing a cause or remedy", err.Code)
+		}
+	}
+}
@@ -3,8 +3,11 @@
 package errorhandling
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 )
 
 // ErrorType represents categories of errors that can occur in the application.
@@ -17,13 +20,15 @@ const (
 	NetworkError        ErrorType = "NETWORK_ERROR"
 	ResourceUnavailable ErrorType = "RESOURCE_UNAVAILABLE"
 	InternalError       ErrorType = "INTERNAL_ERROR"
+	InvalidResponse     ErrorType = "INVALID_RESPONSE"
 )
 
 // AppError is a custom error type that provides context about errors.
-// It includes error type classification, status code for API errors,
-// and a descriptive message with context about what went wrong.
+// It includes error type classification, a stable error code, status code for
+// API errors, and a descriptive message with context about what went wrong.
 type AppError struct {
 	Type       ErrorType   // The category of error
+	Code       string      // Stable error code that can be looked up with 'explain'
 	StatusCode int         // HTTP status code (for API errors)
 	Message    string      // Human-readable error message
 	Err        error       // The original error (optional)
@@ -47,8 +52,9 @@ func (e *AppError) Unwrap() error {
 func NewNotFoundError(resourceType, resourceName string, err error) *AppError {
 	return &AppError{
 		Type:       NotFound,
+		Code:       CodeNotFound,
 		StatusCode: http.StatusNotFound,
-		Message:    fmt.Sprintf("The %s '%s' was not found", resourceType, resourceName),
+		Message:    i18n.Sprintf("The %s '%s' was not found", i18n.T(resourceType), resourceName),
 		Err:        err,
 		Context: map[string]string{
 			"resourceType": resourceType,
@@ -61,6 +67,7 @@ func NewNotFoundError(resourceType, resourceName string, err error) *AppError {
 func NewInvalidInputError(message string, err error) *AppError {
 	return &AppError{
 		Type:       InvalidInput,
+		Code:       CodeInvalidInput,
 		StatusCode: http.StatusBadRequest,
 		Message:    message,
 		Err:        err,
@@ -71,6 +78,7 @@ func NewInvalidInputError(message string, err error) *AppError {
 func NewNetworkError(message string, err error) *AppError {
 	return &AppError{
 		Type:       NetworkError,
+		Code:       CodeNetwork,
 		StatusCode: http.StatusServiceUnavailable,
 		Message:    message,
 		Err:        err,
@@ -80,28 +88,35 @@ func NewNetworkError(message string, err error) *AppError {
 // NewAPIError creates a new error based on an HTTP response status code.
 func NewAPIError(statusCode int, endpoint string, err error) *AppError {
 	var errType ErrorType
+	var code string
 	var message string
 
 	switch statusCode {
 	case http.StatusNotFound:
 		errType = NotFound
-		message = fmt.Sprintf("The requested resource at '%s' was not found", endpoint)
+		code = CodeNotFound
+		message = i18n.Sprintf("The requested resource at '%s' was not found", endpoint)
 	case http.StatusBadRequest:
 		errType = InvalidInput
-		message = fmt.Sprintf("Bad request to '%s'", endpoint)
+		code = CodeAPIBadRequest
+		message = i18n.Sprintf("Bad request to '%s'", endpoint)
 	case http.StatusTooManyRequests:
 		errType = ResourceUnavailable
-		message = "Rate limit exceeded. Please try again later"
+		code = CodeRateLimited
+		message = i18n.T("Rate limit exceeded. Please try again later")
 	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable:
 		errType = ResourceUnavailable
-		message = "The Pokémon API service is temporarily unavailable"
+		code = CodeAPIUnavailable
+		message = i18n.T("The Pokémon API service is temporarily unavailable")
 	default:
 		errType = InternalError
-		message = fmt.Sprintf("Unexpected API error (status code: %d)", statusCode)
+		code = CodeAPIUnexpected
+		message = i18n.Sprintf("Unexpected API error (status code: %d)", statusCode)
 	}
 
 	return &AppError{
 		Type:       errType,
+		Code:       code,
 		StatusCode: statusCode,
 		Message:    message,
 		Err:        err,
@@ -115,33 +130,77 @@ func NewAPIError(statusCode int, endpoint string, err error) *AppError {
 func NewInternalError(message string, err error) *AppError {
 	return &AppError{
 		Type:       InternalError,
+		Code:       CodeInternal,
 		StatusCode: http.StatusInternalServerError,
 		Message:    message,
 		Err:        err,
 	}
 }
 
+// NewInvalidResponseError creates a new error for API responses that decoded
+// successfully but are missing data the application relies on.
+func NewInvalidResponseError(resourceType, resourceName, problem string) *AppError {
+	return &AppError{
+		Type:       InvalidResponse,
+		Code:       CodeInvalidResponse,
+		StatusCode: http.StatusBadGateway,
+		Message:    i18n.Sprintf("The Pokémon API returned incomplete data for %s '%s'", i18n.T(resourceType), resourceName),
+		Err:        errors.New(problem),
+		Context: map[string]string{
+			"resourceType": resourceType,
+			"resourceName": resourceName,
+		},
+	}
+}
+
 // IsNotFoundError checks if an error is a NotFound error.
+// The error chain is searched, so AppErrors wrapped with fmt.Errorf("...: %w", err)
+// are still classified correctly.
 func IsNotFoundError(err error) bool {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Type == NotFound
-	}
-	return false
+	return hasType(err, NotFound)
 }
 
 // IsInvalidInputError checks if an error is an InvalidInput error.
+// The error chain is searched, so wrapped AppErrors are still classified correctly.
 func IsInvalidInputError(err error) bool {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Type == InvalidInput
+	return hasType(err, InvalidInput)
+}
+
+// IsInvalidResponseError checks if an error is an InvalidResponse error, returned
+// when the API's data is missing fields it needs.
+// The error chain is searched, so wrapped AppErrors are still classified correctly.
+func IsInvalidResponseError(err error) bool {
+	return hasType(err, InvalidResponse)
+}
+
+// hasType reports whether the first AppError in err's chain has the given type.
+func hasType(err error, errType ErrorType) bool {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Type == errType
 	}
 	return false
 }
 
+// ErrorCode returns the stable error code of the first AppError in err's chain,
+// or an empty string if there is no AppError or it has no code.
+func ErrorCode(err error) string {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
+
 // FormatUserMessage formats an error for display to the user.
 // Removes technical details and provides a user-friendly message.
+// If an AppError is wrapped inside another error, its message is used.
+// Messages created before a language was selected, such as those of
+// package-level errors, are translated here.
 func FormatUserMessage(err error) string {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Message
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return i18n.T(appErr.Message)
 	}
 	return err.Error()
 }
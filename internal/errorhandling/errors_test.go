@@ -0,0 +1,47 @@
+package errorhandling
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestClassificationOfWrappedErrors verifies that AppErrors are still classified
+// correctly after being wrapped with additional context.
+func TestClassificationOfWrappedErrors(t *testing.T) {
+	notFound := PokemonNotFoundError("pikachoo", errors.New("HTTP 404"))
+	wrapped := fmt.Errorf("error fetching species data: %w", notFound)
+	doubleWrapped := fmt.Errorf("catch failed: %w", wrapped)
+
+	for _, err := range []error{notFound, wrapped, doubleWrapped} {
+		if !IsNotFoundError(err) {
+			t.Errorf("Expected %q to be a not found error", err)
+		}
+		if IsInvalidInputError(err) {
+			t.Errorf("Did not expect %q to be an invalid input error", err)
+		}
+		if code := ErrorCode(err); code != CodePokemonNotFound {
+			t.Errorf("Expected code %s for %q, got %q", CodePokemonNotFound, err, code)
+		}
+		if msg := FormatUserMessage(err); msg != notFound.Message {
+			t.Errorf("Expected user message %q, got %q", notFound.Message, msg)
+		}
+	}
+
+	invalid := fmt.Errorf("validating: %w", NewInvalidInputError("bad", nil))
+	if !IsInvalidInputError(invalid) {
+		t.Errorf("Expected wrapped invalid input error to be classified")
+	}
+	incomplete := fmt.Errorf("decoding: %w", NewInvalidResponseError(ResourcePokemonSpecies, "pikachu", "missing name"))
+	if !IsInvalidResponseError(incomplete) || IsNotFoundError(incomplete) {
+		t.Errorf("Expected wrapped invalid response error to be classified")
+	}
+
+	plain := errors.New("plain error")
+	if IsNotFoundError(plain) || IsInvalidInputError(plain) || IsInvalidResponseError(plain) || ErrorCode(plain) != "" {
+		t.Errorf("Did not expect a plain error to be classified")
+	}
+	if FormatUserMessage(plain) != "plain error" {
+		t.Errorf("Expected plain error message to be returned unchanged")
+	}
+}
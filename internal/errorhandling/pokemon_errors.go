@@ -4,8 +4,9 @@
 package errorhandling
 
 import (
-	"fmt"
 	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 )
 
 // Resource types for error messages
@@ -17,6 +18,12 @@ const (
 	ResourcePokemonMove      = "Pokémon move"
 	ResourcePokemonAbility   = "Pokémon ability"
 	ResourcePokemonEncounter = "Pokémon encounter"
+	ResourceGeneration       = "generation"
+	ResourceType             = "type"
+	ResourceEggGroup         = "egg group"
+	ResourceItem             = "item"
+	ResourceVersionGroup     = "version group"
+	ResourceNature           = "nature"
 )
 
 // PokemonNotFoundError creates a specific error for when a Pokémon is not found.
@@ -29,18 +36,19 @@ const (
 // Returns:
 //   - An AppError with detailed information about the not found condition
 func PokemonNotFoundError(pokemonName string, err error) *AppError {
-	message := fmt.Sprintf("The Pokémon '%s' was not found. Please check the spelling and try again.", pokemonName)
+	message := i18n.Sprintf("The Pokémon '%s' was not found. Please check the spelling and try again.", pokemonName)
 
 	// Add suggestions based on common issues
 	if strings.Contains(pokemonName, " ") {
-		message += " Note: Pokémon names should not contain spaces (use '-' instead)."
+		message += " " + i18n.T("Note: Pokémon names should not contain spaces (use '-' instead).")
 	}
 	if strings.Contains(pokemonName, ".") || strings.Contains(pokemonName, "'") {
-		message += " Note: Special characters like '.' and ''' are not used in API Pokémon names."
+		message += " " + i18n.T("Note: Special characters like '.' and ''' are not used in API Pokémon names.")
 	}
 
 	return &AppError{
 		Type:       NotFound,
+		Code:       CodePokemonNotFound,
 		StatusCode: 404,
 		Message:    message,
 		Err:        err,
@@ -61,10 +69,11 @@ func PokemonNotFoundError(pokemonName string, err error) *AppError {
 // Returns:
 //   - An AppError with detailed information about the not found condition
 func LocationNotFoundError(locationName string, err error) *AppError {
-	message := fmt.Sprintf("The location '%s' was not found. Please run the 'map' command to see available locations.", locationName)
+	message := i18n.Sprintf("The location '%s' was not found. Please run the 'map' command to see available locations.", locationName)
 
 	return &AppError{
 		Type:       NotFound,
+		Code:       CodeLocationNotFound,
 		StatusCode: 404,
 		Message:    message,
 		Err:        err,
@@ -85,10 +94,11 @@ func LocationNotFoundError(locationName string, err error) *AppError {
 // Returns:
 //   - An AppError with detailed information about the not found condition
 func EvolutionNotFoundError(pokemonName string, err error) *AppError {
-	message := fmt.Sprintf("No evolution information found for '%s'. This Pokémon might not have any evolutions.", pokemonName)
+	message := i18n.Sprintf("No evolution information found for '%s'. This Pokémon might not have any evolutions.", pokemonName)
 
 	return &AppError{
 		Type:       NotFound,
+		Code:       CodeEvolutionNotFound,
 		StatusCode: 404,
 		Message:    message,
 		Err:        err,
@@ -131,10 +141,11 @@ func FormatResourceNotFoundError(resourceType, resourceName string, err error) *
 // Returns:
 //   - An AppError with a user-friendly message about the Pokémon not being in the Pokédex
 func PokemonNotInPokedexError(pokemonName string) *AppError {
-	message := fmt.Sprintf("Pokémon '%s' is not in your Pokédex. Try catching it first!", pokemonName)
+	message := i18n.Sprintf("Pokémon '%s' is not in your Pokédex. Try catching it first!", pokemonName)
 
 	return &AppError{
 		Type:       NotFound,
+		Code:       CodeNotInPokedex,
 		StatusCode: 404,
 		Message:    message,
 		Context: map[string]string{
@@ -146,17 +157,23 @@ func PokemonNotInPokedexError(pokemonName string) *AppError {
 
 // InvalidPokemonNameError creates an error for when a user provides an invalid Pokémon name.
 // This is used when the name format is incorrect or when the Pokémon doesn't exist.
+// If suggestions are provided, they are offered as likely intended names.
 //
 // Parameters:
 //   - pokemonName: The invalid Pokémon name provided by the user
+//   - suggestions: Optional display names of similar, valid Pokémon
 //
 // Returns:
 //   - An AppError with a user-friendly message about the invalid Pokémon name
-func InvalidPokemonNameError(pokemonName string) *AppError {
-	message := fmt.Sprintf("'%s' is not a valid Pokémon name. Please check your spelling - this Pokémon doesn't exist.", pokemonName)
+func InvalidPokemonNameError(pokemonName string, suggestions ...string) *AppError {
+	message := i18n.Sprintf("'%s' is not a valid Pokémon name. Please check your spelling - this Pokémon doesn't exist.", pokemonName)
+	if len(suggestions) > 0 {
+		message = i18n.Sprintf("'%s' is not a valid Pokémon name. Did you mean: %s?", pokemonName, strings.Join(suggestions, ", "))
+	}
 
 	return &AppError{
 		Type:       InvalidInput,
+		Code:       CodeInvalidPokemonName,
 		StatusCode: 400,
 		Message:    message,
 		Context: map[string]string{
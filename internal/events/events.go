@@ -0,0 +1,232 @@
+// Package events provides the schedule of seasonal events: themed periods of
+// the year, such as Halloween, when some Pokémon turn up more often in the
+// wild and a special message greets the user. The schedule is data, embedded
+// in the binary as JSON, so events can be added or changed without touching
+// the code that applies them.
+//
+// Each event repeats every year between two dates, given as "MM-DD". An event
+// whose end comes before its start, such as "12-20" to "01-05", spans the new year.
+package events
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// scheduleJSON is the schedule built into the binary.
+//
+//go:embed schedule.json
+var scheduleJSON []byte
+
+// fallbackLanguage is the language used for an event's text when it isn't
+// written in the language asked for. Every event must have text in it.
+const fallbackLanguage = "en"
+
+// MonthDay is a day of the year that repeats every year, such as October 31.
+type MonthDay struct {
+	Month time.Month // The month
+	Day   int        // The day of the month
+}
+
+// Of returns the day of the year of a time.
+func Of(t time.Time) MonthDay {
+	return MonthDay{Month: t.Month(), Day: t.Day()}
+}
+
+// String formats the day as "MM-DD", the form used in the schedule.
+func (d MonthDay) String() string {
+	return fmt.Sprintf("%02d-%02d", int(d.Month), d.Day)
+}
+
+// UnmarshalJSON reads a day in the form "MM-DD".
+func (d *MonthDay) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	// 2024 is a leap year, so February 29 is accepted
+	parsed, err := time.Parse("2006-01-02", "2024-"+text)
+	if err != nil {
+		return fmt.Errorf("invalid day %q (use MM-DD)", text)
+	}
+	*d = Of(parsed)
+	return nil
+}
+
+// compare orders days within a year.
+func (d MonthDay) compare(other MonthDay) int {
+	if d.Month != other.Month {
+		return int(d.Month) - int(other.Month)
+	}
+	return d.Day - other.Day
+}
+
+// InRange reports whether a time falls between two days of the year,
+// inclusive. If to comes before from, the range spans the new year.
+//
+// Parameters:
+//   - t: The time to check
+//   - from: The first day of the range
+//   - to: The last day of the range
+func InRange(t time.Time, from, to MonthDay) bool {
+	day := Of(t)
+	after, before := day.compare(from) >= 0, day.compare(to) <= 0
+	if from.compare(to) > 0 {
+		return after || before
+	}
+	return after && before
+}
+
+// Boost makes some Pokémon turn up more often in the wild during an event.
+type Boost struct {
+	Target     string `json:"target"`     // The type (e.g. "ghost") or Pokémon (e.g. "pikachu") boosted, in API format
+	Multiplier int    `json:"multiplier"` // How many times more often they turn up
+}
+
+// Event is a seasonal event in the schedule.
+type Event struct {
+	ID       string            `json:"id"`               // Identifier of the event (e.g. "spooky-season")
+	Names    map[string]string `json:"name"`             // The event's name, by language code
+	Messages map[string]string `json:"message"`          // The message shown while it's on, by language code
+	From     MonthDay          `json:"from"`             // The first day of the event
+	To       MonthDay          `json:"to"`               // The last day of the event
+	Boosts   []Boost           `json:"boosts,omitempty"` // The Pokémon that turn up more often
+}
+
+// Name returns the event's name in a language, or in English if it hasn't
+// been translated into it.
+func (e Event) Name(language string) string {
+	return localized(e.Names, language)
+}
+
+// Message returns the event's message in a language, or in English if it
+// hasn't been translated into it.
+func (e Event) Message(language string) string {
+	return localized(e.Messages, language)
+}
+
+// localized picks the text for a language from an event's translations.
+func localized(text map[string]string, language string) string {
+	if translated, ok := text[language]; ok {
+		return translated
+	}
+	return text[fallbackLanguage]
+}
+
+// ActiveOn reports whether the event is on at a time.
+func (e Event) ActiveOn(t time.Time) bool {
+	return InRange(t, e.From, e.To)
+}
+
+// NextStart returns midnight on the next day the event starts, after the day
+// of a time.
+func (e Event) NextStart(t time.Time) time.Time {
+	year := t.Year()
+	if Of(t).compare(e.From) >= 0 {
+		year++
+	}
+	return time.Date(year, e.From.Month, e.From.Day, 0, 0, 0, 0, t.Location())
+}
+
+// End returns midnight at the start of the last day of the event that's on at
+// a time. The result is only meaningful while the event is on.
+func (e Event) End(t time.Time) time.Time {
+	year := t.Year()
+	if Of(t).compare(e.To) > 0 {
+		// The event spans the new year, and this is the part before it
+		year++
+	}
+	return time.Date(year, e.To.Month, e.To.Day, 0, 0, 0, 0, t.Location())
+}
+
+// Schedule is the list of seasonal events.
+type Schedule []Event
+
+// Parse reads and checks a schedule in JSON.
+//
+// Parameters:
+//   - data: The schedule in JSON, as an object with an "events" list
+//
+// Returns:
+//   - The schedule
+//   - An error if the JSON is invalid or an event is incomplete
+func Parse(data []byte) (Schedule, error) {
+	var file struct {
+		Events Schedule `json:"events"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool)
+	for _, e := range file.Events {
+		switch {
+		case e.ID == "" || ids[e.ID]:
+			return nil, fmt.Errorf("event %q: the ID is missing or used twice", e.ID)
+		case e.From == (MonthDay{}) || e.To == (MonthDay{}):
+			return nil, fmt.Errorf("event %q: the dates are missing", e.ID)
+		case e.Names[fallbackLanguage] == "" || e.Messages[fallbackLanguage] == "":
+			return nil, fmt.Errorf("event %q: the %s name or message is missing", e.ID, fallbackLanguage)
+		}
+		for _, boost := range e.Boosts {
+			if boost.Target == "" || boost.Multiplier < 1 {
+				return nil, fmt.Errorf("event %q: a boost needs a target and a multiplier of at least 1", e.ID)
+			}
+		}
+		ids[e.ID] = true
+	}
+	return file.Events, nil
+}
+
+// builtIn is the schedule embedded in the binary, parsed on first use.
+var builtIn = sync.OnceValue(func() Schedule {
+	schedule, err := Parse(scheduleJSON)
+	if err != nil {
+		panic(fmt.Sprintf("invalid embedded event schedule: %v", err))
+	}
+	return schedule
+})
+
+// Default returns the schedule built into the binary.
+func Default() Schedule {
+	return builtIn()
+}
+
+// Active returns the events that are on at a time, in schedule order.
+func (s Schedule) Active(t time.Time) []Event {
+	var active []Event
+	for _, e := range s {
+		if e.ActiveOn(t) {
+			active = append(active, e)
+		}
+	}
+	return active
+}
+
+// Boosts returns the boosts of every event that's on at a time.
+func (s Schedule) Boosts(t time.Time) []Boost {
+	var boosts []Boost
+	for _, e := range s.Active(t) {
+		boosts = append(boosts, e.Boosts...)
+	}
+	return boosts
+}
+
+// ErrNoEvents is returned by Next when the schedule is empty.
+var ErrNoEvents = errors.New("no events are scheduled")
+
+// Next returns the event that starts soonest after the day of a time, and
+// when it starts.
+func (s Schedule) Next(t time.Time) (Event, time.Time, error) {
+	if len(s) == 0 {
+		return Event{}, time.Time{}, ErrNoEvents
+	}
+	next := slices.MinFunc(s, func(a, b Event) int {
+		return a.NextStart(t).Compare(b.NextStart(t))
+	})
+	return next, next.NextStart(t), nil
+}
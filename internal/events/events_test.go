@@ -0,0 +1,102 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDefaultSchedule tests that the embedded schedule is valid and that
+// every event has a Spanish translation
+func TestDefaultSchedule(t *testing.T) {
+	schedule, err := Parse(scheduleJSON)
+	if err != nil {
+		t.Fatalf("The embedded schedule is invalid: %v", err)
+	}
+	for _, e := range schedule {
+		if e.Names["es"] == "" || e.Messages["es"] == "" {
+			t.Errorf("Expected event %q to have a Spanish name and message", e.ID)
+		}
+	}
+}
+
+// TestInRange tests date ranges within a year and across the new year
+func TestInRange(t *testing.T) {
+	october := MonthDay{Month: time.October, Day: 1}
+	halloween := MonthDay{Month: time.October, Day: 31}
+	newYearsEve := MonthDay{Month: time.December, Day: 31}
+	epiphany := MonthDay{Month: time.January, Day: 6}
+	tests := []struct {
+		date     time.Time
+		from, to MonthDay
+		want     bool
+	}{
+		{time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), october, halloween, true},
+		{time.Date(2026, 10, 31, 23, 0, 0, 0, time.UTC), october, halloween, true},
+		{time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), october, halloween, false},
+		{time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), newYearsEve, epiphany, true},
+		{time.Date(2027, 1, 6, 0, 0, 0, 0, time.UTC), newYearsEve, epiphany, true},
+		{time.Date(2027, 1, 7, 0, 0, 0, 0, time.UTC), newYearsEve, epiphany, false},
+		{time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), newYearsEve, epiphany, false},
+	}
+	for _, tt := range tests {
+		if got := InRange(tt.date, tt.from, tt.to); got != tt.want {
+			t.Errorf("InRange(%s, %s, %s) = %v, want %v", tt.date.Format("2006-01-02"), tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+// TestScheduleActiveAndNext tests which events are on, when they end, and
+// which starts next
+func TestScheduleActiveAndNext(t *testing.T) {
+	schedule, err := Parse([]byte(`{"events": [
+		{"id": "spooky", "name": {"en": "Spooky"}, "message": {"en": "Boo!"}, "from": "10-01", "to": "10-31",
+		 "boosts": [{"target": "ghost", "multiplier": 3}]},
+		{"id": "winter", "name": {"en": "Winter", "es": "Invierno"}, "message": {"en": "Brr!"}, "from": "12-20", "to": "01-05"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Parse returned an error: %v", err)
+	}
+
+	october := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	active := schedule.Active(october)
+	if len(active) != 1 || active[0].ID != "spooky" {
+		t.Fatalf("Expected only the spooky event on October 15, got %v", active)
+	}
+	if boosts := schedule.Boosts(october); len(boosts) != 1 || boosts[0] != (Boost{Target: "ghost", Multiplier: 3}) {
+		t.Errorf("Expected a ghost boost in October, got %v", boosts)
+	}
+	if end := active[0].End(october); end.Format("2006-01-02") != "2026-10-31" {
+		t.Errorf("Expected the spooky event to end on 2026-10-31, got %s", end.Format("2006-01-02"))
+	}
+
+	next, start, err := schedule.Next(october)
+	if err != nil || next.ID != "winter" || start.Format("2006-01-02") != "2026-12-20" {
+		t.Errorf("Expected the winter event to start next on 2026-12-20, got %q on %s (%v)", next.ID, start.Format("2006-01-02"), err)
+	}
+	christmas := time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC)
+	if end := schedule[1].End(christmas); end.Format("2006-01-02") != "2027-01-05" {
+		t.Errorf("Expected the winter event to end on 2027-01-05, got %s", end.Format("2006-01-02"))
+	}
+	if name := schedule[1].Name("es"); name != "Invierno" {
+		t.Errorf("Expected the Spanish name, got %q", name)
+	}
+	if name := schedule[0].Name("es"); name != "Spooky" {
+		t.Errorf("Expected the English name when there's no translation, got %q", name)
+	}
+}
+
+// TestParseRejectsInvalidEvents tests that incomplete events are rejected
+func TestParseRejectsInvalidEvents(t *testing.T) {
+	invalid := []string{
+		`{"events": [{"id": "a", "name": {"en": "A"}, "message": {"en": "A"}, "from": "13-01", "to": "12-31"}]}`,
+		`{"events": [{"id": "a", "name": {"es": "A"}, "message": {"en": "A"}, "from": "01-01", "to": "12-31"}]}`,
+		`{"events": [{"id": "a", "name": {"en": "A"}, "message": {"en": "A"}, "from": "01-01"}]}`,
+		`{"events": [{"id": "a", "name": {"en": "A"}, "message": {"en": "A"}, "from": "01-01", "to": "12-31",
+			"boosts": [{"target": "ghost", "multiplier": 0}]}]}`,
+	}
+	for _, data := range invalid {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("Expected Parse to reject %s", data)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSignature is the author of the commits made in tests.
+var testSignature = Signature{Name: "ash", Email: "ash@example.com", When: time.Date(2026, 10, 15, 12, 0, 0, 0, time.FixedZone("", 2*3600))}
+
+// TestCommitFile tests that commits are made only when the file changes, and
+// that they can be read back
+func TestCommitFile(t *testing.T) {
+	repo, err := Init(filepath.Join(t.TempDir(), "backup"))
+	if err != nil {
+		t.Fatalf("Init returned an error: %v", err)
+	}
+	first, changed, err := repo.CommitFile("pokedex.json", []byte(`{"pikachu":{}}`), "Save 1 Pokémon", testSignature)
+	if err != nil || !changed {
+		t.Fatalf("Expected a first commit, got %v, %v", changed, err)
+	}
+	if again, changed, _ := repo.CommitFile("pokedex.json", []byte(`{"pikachu":{}}`), "Save again", testSignature); changed || again != first {
+		t.Error("Expected no commit when the file is unchanged")
+	}
+	second, changed, err := repo.CommitFile("pokedex.json", []byte(`{"pikachu":{},"eevee":{}}`), "Save 2 Pokémon", testSignature)
+	if err != nil || !changed {
+		t.Fatalf("Expected a second commit, got %v, %v", changed, err)
+	}
+
+	commits, err := repo.Log(0)
+	if err != nil {
+		t.Fatalf("Log returned an error: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Hash != second || commits[0].Parent != first || commits[1].Parent != "" {
+		t.Fatalf("Unexpected history %+v", commits)
+	}
+	if commits[0].Message != "Save 2 Pokémon" || !commits[0].When.Equal(testSignature.When) {
+		t.Errorf("Unexpected commit %+v", commits[0])
+	}
+	if _, err := Init(repo.Dir()); err != nil {
+		t.Errorf("Expected an existing repository to be opened, got %v", err)
+	}
+}
+
+// TestObjectHash tests that objects are hashed as git hashes them
+func TestObjectHash(t *testing.T) {
+	// The hash git gives the file "hello\n", from 'git hash-object'
+	if hash, _ := hashObject(typeBlob, []byte("hello\n")); hash != "ce013625030ba8dba906f756967f9e9ca394464a" {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+}
+
+// TestPushLocal tests pushing to a repository on disk, including refusing a
+// push that would lose the remote's commits
+func TestPushLocal(t *testing.T) {
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	repo, _ := Init(filepath.Join(dir, "local"))
+	repo.CommitFile("pokedex.json", []byte("1"), "First", testSignature)
+	if err := repo.Push(context.Background(), remote); err != nil {
+		t.Fatalf("Push returned an error: %v", err)
+	}
+	repo.CommitFile("pokedex.json", []byte("2"), "Second", testSignature)
+	if unpushed, _ := repo.Unpushed(); unpushed != 1 {
+		t.Errorf("Expected 1 unpushed commit, got %d", unpushed)
+	}
+	if err := repo.Push(context.Background(), remote); err != nil {
+		t.Fatalf("Push returned an error: %v", err)
+	}
+	if unpushed, _ := repo.Unpushed(); unpushed != 0 {
+		t.Errorf("Expected no unpushed commits, got %d", unpushed)
+	}
+
+	pushed, _ := Init(remote)
+	commits, err := pushed.Log(0)
+	if err != nil || len(commits) != 2 || commits[0].Message != "Second" {
+		t.Fatalf("Expected both commits in the remote, got %+v, %v", commits, err)
+	}
+	if files, err := pushed.treeFiles(commits[0].Tree); err != nil || len(files) != 1 {
+		t.Errorf("Expected the file in the remote, got %v, %v", files, err)
+	}
+
+	other, _ := Init(filepath.Join(dir, "other"))
+	other.CommitFile("pokedex.json", []byte("3"), "Unrelated", testSignature)
+	if err := other.Push(context.Background(), remote); !errors.Is(err, ErrNotFastForward) {
+		t.Errorf("Expected ErrNotFastForward, got %v", err)
+	}
+}
+
+// TestPushHTTP tests the requests sent to push to an empty repository on a
+// git server
+func TestPushHTTP(t *testing.T) {
+	var command string
+	var pack []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, _ := r.BasicAuth(); username != "ash" || password != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/pokedex.git/info/refs":
+			io.WriteString(w, pktLine("# service=git-receive-pack\n")+"0000"+
+				pktLine(zeroHash+" capabilities^{}\x00report-status delete-refs\n")+"0000")
+		case r.Method == http.MethodPost && r.URL.Path == "/pokedex.git/git-receive-pack":
+			body, _ := io.ReadAll(r.Body)
+			lines, _ := readPktLines(body[:bytes.Index(body, []byte("0000PACK"))+4])
+			command, pack = lines[0], body[bytes.Index(body, []byte("PACK")):]
+			io.WriteString(w, pktLine("unpack ok\n")+pktLine("ok "+Branch+"\n")+"0000")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	repo, _ := Init(t.TempDir())
+	head, _, _ := repo.CommitFile("pokedex.json", []byte("1"), "First", testSignature)
+	remote := strings.Replace(server.URL, "://", "://ash:token@", 1) + "/pokedex.git"
+	if err := repo.Push(context.Background(), remote); err != nil {
+		t.Fatalf("Push returned an error: %v", err)
+	}
+	if want := zeroHash + " " + head + " " + Branch + "\x00report-status\n"; command != want {
+		t.Errorf("Unexpected command %q", command)
+	}
+	// A commit, its tree, and the file, followed by a checksum of the pack
+	if len(pack) < 32 || binary.BigEndian.Uint32(pack[8:12]) != 3 {
+		t.Fatalf("Expected a pack of 3 objects, got %q", pack)
+	}
+	if sum := sha1.Sum(pack[:len(pack)-sha1.Size]); !bytes.Equal(sum[:], pack[len(pack)-sha1.Size:]) {
+		t.Error("Expected the pack to end with its checksum")
+	}
+
+	if err := repo.Push(context.Background(), server.URL+"/pokedex.git"); err == nil || !strings.Contains(err.Error(), "credentials") {
+		t.Errorf("Expected the missing credentials to be reported, got %v", err)
+	}
+}
+
+// TestPackObjectHeader tests encoding object types and sizes in pack files
+func TestPackObjectHeader(t *testing.T) {
+	if got := packObjectHeader(3, 10); !bytes.Equal(got, []byte{0x3a}) {
+		t.Errorf("Unexpected header % x for a small blob", got)
+	}
+	if got := packObjectHeader(1, 300); !bytes.Equal(got, []byte{0x9c, 0x12}) {
+		t.Errorf("Unexpected header % x for a 300-byte commit", got)
+	}
+}
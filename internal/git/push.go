@@ -0,0 +1,371 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"context"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// zeroHash stands for a ref that doesn't exist in the push protocol.
+const zeroHash = "0000000000000000000000000000000000000000"
+
+// packTypes maps object types to their numbers in pack files.
+var packTypes = map[string]byte{typeCommit: 1, typeTree: 2, typeBlob: 3}
+
+// ErrNotFastForward is returned when the remote branch has commits that the
+// local branch doesn't, so pushing would lose them.
+var ErrNotFastForward = errors.New("the remote branch has commits that aren't in the local repository")
+
+// IsHTTP reports whether a remote is a URL pushed to over HTTP, rather than a
+// repository on disk.
+func IsHTTP(remote string) bool {
+	return strings.HasPrefix(remote, "http://") || strings.HasPrefix(remote, "https://")
+}
+
+// Push updates the branch of a remote repository to the latest commit. The
+// remote is either the directory of a bare repository, which is created if it
+// doesn't exist, or an http:// or https:// URL of a repository on a git
+// server. A user name and password (or token) in the URL are sent with Basic
+// authentication.
+//
+// Parameters:
+//   - ctx: Limits how long pushing over HTTP may take
+//   - remote: The remote repository's directory or URL
+//
+// Returns:
+//   - ErrNotFastForward if the remote branch has commits that aren't in this
+//     repository, or another error if the push fails
+func (r *Repo) Push(ctx context.Context, remote string) error {
+	head, err := r.Head()
+	if err != nil {
+		return err
+	}
+	if head == "" {
+		return errors.New("there are no commits to push")
+	}
+	if IsHTTP(remote) {
+		err = r.pushHTTP(ctx, remote, head)
+	} else {
+		err = r.pushLocal(remote, head)
+	}
+	if err != nil {
+		return err
+	}
+	return r.writeRef(pushedRef, head)
+}
+
+// pushLocal pushes to a bare repository on disk by copying the objects it's
+// missing and then updating its branch.
+func (r *Repo) pushLocal(dir, head string) error {
+	remote, err := Init(dir)
+	if err != nil {
+		return err
+	}
+	remoteHead, err := remote.Head()
+	if err != nil || remoteHead == head {
+		return err
+	}
+	hashes, err := r.objectsSince(head, remoteHead)
+	if err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		if _, err := os.Stat(objectPath(dir, hash)); err == nil {
+			continue
+		}
+		data, err := os.ReadFile(objectPath(r.dir, hash))
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomic(objectPath(dir, hash), data, 0o444); err != nil {
+			return err
+		}
+	}
+	return remote.writeRef(Branch, head)
+}
+
+// pushHTTP pushes over git's smart HTTP protocol: it asks the server where
+// its branch is, then sends the commands to update it with a pack of the
+// objects the server is missing.
+func (r *Repo) pushHTTP(ctx context.Context, remote, head string) error {
+	base, username, password, err := splitCredentials(remote)
+	if err != nil {
+		return err
+	}
+	get, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"/info/refs?service=git-receive-pack", nil)
+	if err != nil {
+		return err
+	}
+	refs, err := doRequest(get, username, password)
+	if err != nil {
+		return err
+	}
+	remoteHead, err := parseAdvertisement(refs)
+	if err != nil || remoteHead == head {
+		return err
+	}
+
+	hashes, err := r.objectsSince(head, remoteHead)
+	if err != nil {
+		return err
+	}
+	old := remoteHead
+	if old == "" {
+		old = zeroHash
+	}
+	var body bytes.Buffer
+	body.WriteString(pktLine(fmt.Sprintf("%s %s %s\x00report-status\n", old, head, Branch)))
+	body.WriteString("0000")
+	if err := r.writePack(&body, hashes); err != nil {
+		return err
+	}
+	post, err := http.NewRequestWithContext(ctx, http.MethodPost, base+"/git-receive-pack", &body)
+	if err != nil {
+		return err
+	}
+	post.Header.Set("Content-Type", "application/x-git-receive-pack-request")
+	post.Header.Set("Accept", "application/x-git-receive-pack-result")
+	result, err := doRequest(post, username, password)
+	if err != nil {
+		return err
+	}
+	return parseReportStatus(result)
+}
+
+// splitCredentials returns a remote URL without the user name and password
+// in it, and the user name and password.
+func splitCredentials(remote string) (string, string, string, error) {
+	u, err := url.Parse(remote)
+	if err != nil {
+		// The URL isn't repeated, since it may hold a token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return "", "", "", fmt.Errorf("invalid remote URL: %w", err)
+	}
+	var username, password string
+	if u.User != nil {
+		username = u.User.Username()
+		password, _ = u.User.Password()
+		u.User = nil
+	}
+	return strings.TrimSuffix(u.String(), "/"), username, password, nil
+}
+
+// doRequest sends a request to a git server and returns the response body.
+func doRequest(req *http.Request, username, password string) ([]byte, error) {
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+	req.Header.Set("User-Agent", "git/pokedexcli")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("the git server refused the credentials (%s)", resp.Status)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("the git server replied %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parseAdvertisement returns where the branch is in a server's list of refs,
+// or "" if the server doesn't have it.
+func parseAdvertisement(data []byte) (string, error) {
+	lines, err := readPktLines(data)
+	if err != nil {
+		return "", err
+	}
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "# service=git-receive-pack") {
+		return "", errors.New("the server is not a git server or doesn't allow pushing")
+	}
+	for _, line := range lines[1:] {
+		line, _, _ = strings.Cut(strings.TrimSuffix(line, "\n"), "\x00")
+		if hash, ref, ok := strings.Cut(line, " "); ok && ref == Branch {
+			return hash, nil
+		}
+	}
+	return "", nil
+}
+
+// parseReportStatus returns an error if the server reports that it couldn't
+// unpack the objects or update the branch.
+func parseReportStatus(data []byte) error {
+	lines, err := readPktLines(data)
+	if err != nil {
+		return err
+	}
+	unpacked, updated := false, false
+	for _, line := range lines {
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "unpack ok":
+			unpacked = true
+		case strings.HasPrefix(line, "unpack "):
+			return fmt.Errorf("the git server couldn't unpack the objects: %s", strings.TrimPrefix(line, "unpack "))
+		case line == "ok "+Branch:
+			updated = true
+		case strings.HasPrefix(line, "ng "+Branch+" "):
+			return fmt.Errorf("the git server refused the push: %s", strings.TrimPrefix(line, "ng "+Branch+" "))
+		}
+	}
+	if !unpacked || !updated {
+		return errors.New("the git server didn't confirm the push")
+	}
+	return nil
+}
+
+// pktLine returns a line in git's pkt-line format: its length, including the
+// four length digits, in hex, followed by the line.
+func pktLine(line string) string {
+	return fmt.Sprintf("%04x%s", len(line)+4, line)
+}
+
+// readPktLines returns the lines in data in pkt-line format, skipping the
+// flush packets ("0000") between sections.
+func readPktLines(data []byte) ([]string, error) {
+	var lines []string
+	reader := bufio.NewReader(bytes.NewReader(data))
+	for {
+		var length [4]byte
+		if _, err := io.ReadFull(reader, length[:]); err == io.EOF {
+			return lines, nil
+		} else if err != nil {
+			return nil, errors.New("the git server's reply is truncated")
+		}
+		raw, err := hex.DecodeString(string(length[:]))
+		if err != nil {
+			return nil, errors.New("the git server's reply is malformed")
+		}
+		n := int(binary.BigEndian.Uint16(raw))
+		if n == 0 {
+			continue
+		}
+		if n < 4 {
+			return nil, errors.New("the git server's reply is malformed")
+		}
+		line := make([]byte, n-4)
+		if _, err := io.ReadFull(reader, line); err != nil {
+			return nil, errors.New("the git server's reply is truncated")
+		}
+		lines = append(lines, string(line))
+	}
+}
+
+// objectsSince returns the hashes of the commits after a commit on the
+// branch, and of their trees and files.
+func (r *Repo) objectsSince(head, since string) ([]string, error) {
+	var hashes []string
+	seen := make(map[string]bool)
+	add := func(hash string) {
+		if !seen[hash] {
+			seen[hash] = true
+			hashes = append(hashes, hash)
+		}
+	}
+	for hash := head; hash != since; {
+		if hash == "" {
+			return nil, ErrNotFastForward
+		}
+		commit, err := r.ReadCommit(hash)
+		if err != nil {
+			return nil, err
+		}
+		add(commit.Hash)
+		add(commit.Tree)
+		files, err := r.treeFiles(commit.Tree)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			add(file)
+		}
+		hash = commit.Parent
+	}
+	return hashes, nil
+}
+
+// treeFiles returns the hashes of the files in a tree. The trees this package
+// writes have no subdirectories.
+func (r *Repo) treeFiles(hash string) ([]string, error) {
+	objectType, body, err := r.readObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	if objectType != typeTree {
+		return nil, fmt.Errorf("object %s is a %s, not a tree", hash, objectType)
+	}
+	var files []string
+	for len(body) > 0 {
+		// Each entry is "mode name\0" followed by the raw 20-byte hash
+		end := bytes.IndexByte(body, 0)
+		if end < 0 || len(body) < end+1+sha1.Size {
+			return nil, fmt.Errorf("tree %s is damaged", hash)
+		}
+		files = append(files, hex.EncodeToString(body[end+1:end+1+sha1.Size]))
+		body = body[end+1+sha1.Size:]
+	}
+	return files, nil
+}
+
+// writePack writes objects as a pack file: a header with the number of
+// objects, each object compressed with a header of its type and size, and a
+// checksum of everything before it.
+func (r *Repo) writePack(w io.Writer, hashes []string) error {
+	sum := sha1.New()
+	out := io.MultiWriter(w, sum)
+	header := []byte("PACK")
+	header = binary.BigEndian.AppendUint32(header, 2)
+	header = binary.BigEndian.AppendUint32(header, uint32(len(hashes)))
+	if _, err := out.Write(header); err != nil {
+		return err
+	}
+	for _, hash := range hashes {
+		objectType, body, err := r.readObject(hash)
+		if err != nil {
+			return err
+		}
+		if _, err := out.Write(packObjectHeader(packTypes[objectType], len(body))); err != nil {
+			return err
+		}
+		zw := zlib.NewWriter(out)
+		if _, err := zw.Write(body); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(sum.Sum(nil))
+	return err
+}
+
+// packObjectHeader returns the header of an object in a pack file: its type
+// and the low 4 bits of its size in the first byte, and the rest of the size
+// 7 bits at a time, with the high bit set on every byte but the last.
+func packObjectHeader(objectType byte, size int) []byte {
+	b := objectType<<4 | byte(size&0x0f)
+	size >>= 4
+	var header []byte
+	for size > 0 {
+		header = append(header, b|0x80)
+		b = byte(size & 0x7f)
+		size >>= 7
+	}
+	return append(header, b)
+}
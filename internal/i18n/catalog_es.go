@@ -0,0 +1,1610 @@
+package i18n
+
+// spanish contains the Spanish translations, keyed by the English message.
+// Command names and their parameters (like 'box create') are left in English
+// because they are what the user types.
+var spanish = map[string]string{
+	// Startup and the REPL
+	"Welcome to the Pokédex!":                            "¡Bienvenido a la Pokédex!",
+	"Welcome to the Pokedex!":                            "¡Bienvenido a la Pokédex!",
+	"Type 'help' for a list of commands.":                "Escribe 'help' para ver la lista de comandos.",
+	"Pokédex > ":                                         "Pokédex > ",
+	"Pokédex* > ":                                        "Pokédex* > ",
+	"Exiting Pokédex. Goodbye!":                          "Saliendo de la Pokédex. ¡Adiós!",
+	"Error reading input: %v\n":                          "Error al leer la entrada: %v\n",
+	"Unknown command: %s":                                "Comando desconocido: %s",
+	"Unknown command: %s\n":                              "Comando desconocido: %s\n",
+	"No completions found.":                              "No se encontraron coincidencias.",
+	"Loaded Pokédex with %d Pokémon\n":                   "Pokédex cargada con %d Pokémon\n",
+	"Warning: Could not load saved Pokédex data: %v\n":   "Aviso: no se pudieron cargar los datos guardados de la Pokédex: %v\n",
+	"Recording API responses to %s\n":                    "Grabando las respuestas de la API en %s\n",
+	"Using the save file %s\n":                           "Usando el archivo de guardado %s\n",
+	"the folder for the save file %s doesn't exist":      "la carpeta del archivo de guardado %s no existe",
+	"Using API fixtures from %s\n":                       "Usando las respuestas de la API guardadas en %s\n",
+	"Warning: --record has no effect without --fixtures": "Aviso: --record no tiene efecto sin --fixtures",
+	"Error marshaling to JSON: %v\n":                     "Error al convertir a JSON: %v\n",
+	"Warning: Could not load the species dataset, using the built-in one: %v\n": "Aviso: no se pudo cargar el conjunto de datos de especies, se usa el integrado: %v\n",
+
+	// Command descriptions shown by 'help'
+	"List available commands": "Muestra los comandos disponibles",
+	"List the usage of every command, or describe them all as JSON with --json":                                                                        "Muestra cómo se usa cada comando, o los describe todos en JSON con --json",
+	"Print a shell completion script for running commands from the command line":                                                                       "Muestra un script de autocompletado de la shell para ejecutar comandos desde la línea de comandos",
+	"List the pokemon found at the specified map location number, or a bookmarked location, or those not caught yet in every location on the map page": "Muestra los Pokémon que hay en la ubicación del mapa indicada, o en una ubicación de tus marcadores, o los que aún no has atrapado en todas las ubicaciones de la página del mapa",
+	"Attempt to catch the specified pokemon, or the one with that number in the last explore list":                                                     "Intenta atrapar al Pokémon indicado, o al que tiene ese número en la última lista de explore",
+	"Try to catch a random pokemon from the whole pokedex":                                                                                             "Intenta atrapar a un Pokémon al azar de toda la Pokédex",
+	"Look for a wild pokemon in the area you explored last":                                                                                            "Busca un Pokémon salvaje en la última zona que exploraste",
+	"Look for a wild pokemon by surfing":                                                                                                               "Busca un Pokémon salvaje haciendo surf",
+	"Look for a wild pokemon by fishing":                                                                                                               "Busca un Pokémon salvaje pescando",
+	"Use a lure to draw out a type or pokemon where you explored":                                                                                      "Usa un cebo para atraer a un tipo o Pokémon donde exploraste",
+	"Show the chance of catching a pokemon with each ball":                                                                                             "Muestra la probabilidad de atrapar a un Pokémon con cada Ball",
+	"List the stats of the specified pokemon":                                                                                                          "Muestra las estadísticas del Pokémon indicado",
+	"Show the stats and catch difficulty of any pokemon":                                                                                               "Muestra las estadísticas y la dificultad de captura de cualquier Pokémon",
+	"List every form of a pokemon's species and which ones you own":                                                                                    "Muestra todas las formas de la especie de un Pokémon y cuáles tienes",
+	"List all pokemon currently in your pokedex":                                                                                                       "Muestra todos los Pokémon de tu Pokédex",
+	"Release a caught pokemon from your pokedex, or choose several to release with --select":                                                           "Libera a un Pokémon de tu Pokédex, o elige varios para liberar con --select",
+	"Show off a caught pokemon using one of its moves":                                                                                                 "Luce a uno de tus Pokémon con uno de sus movimientos",
+	"Display information about a caught pokemon":                                                                                                       "Muestra información sobre un Pokémon atrapado",
+	"Evolve a pokemon that is in your pokedex":                                                                                                         "Hace evolucionar a un Pokémon de tu Pokédex",
+	"Undo the last evolution of a pokemon in your pokedex":                                                                                             "Deshace la última evolución de un Pokémon de tu Pokédex",
+	"Show which species of a generation you've caught (e.g. checklist gen1)":                                                                           "Muestra qué especies de una generación has atrapado (p. ej. checklist gen1)",
+	"List the pokemon you've seen and where you first spotted them (seen --at <location>)":                                                             "Muestra los Pokémon que has visto y dónde los viste por primera vez (seen --at <ubicación>)",
+	"Show a pokemon's egg groups and which of your pokemon it can breed with":                                                                          "Muestra los grupos huevo de un Pokémon y con cuáles de tus Pokémon puede criar",
+	"Buy Poké Balls and items with the money you've earned, or list your bag":                                                                          "Compra Poké Balls y objetos con el dinero que has ganado, o muestra tu bolsa",
+	"Redeem an event distribution code for a pokemon or items":                                                                                         "Canjea un código de evento por un Pokémon u objetos",
+	"Summarize the ribbons your pokemon have earned":                                                                                                   "Resume las cintas que han ganado tus Pokémon",
+	"Play a quick stat-based minigame with a caught pokemon to earn happiness":                                                                         "Juega un minijuego rápido basado en estadísticas con un Pokémon atrapado para ganar felicidad",
+	"Leave up to 2 pokemon at the day care to gain levels over time (deposit/withdraw)":                                                                "Deja hasta 2 Pokémon en la guardería para que suban de nivel con el tiempo (deposit/withdraw)",
+	"List the pokemon with you, show their condition, heal them, or show or change the party size":                                                     "Muestra los Pokémon que llevas contigo, su estado, cúralos, o muestra o cambia el tamaño del equipo",
+	"Battle an NPC trainer with your team to earn money (e.g. fight trainer swimmer)":                                                                  "Combate contra un entrenador con tu equipo para ganar dinero (p. ej. fight trainer swimmer)",
+	"Battle a friend at the same keyboard, or a wild pokemon or gym leader":                                                                            "Combate contra un amigo en el mismo teclado, o contra un Pokémon salvaje o un líder de gimnasio",
+	"Rank your best pokemon to use against the specified pokemon":                                                                                      "Clasifica tus mejores Pokémon contra el Pokémon indicado",
+	"Suggest a balanced team of 6 from your pokedex":                                                                                                   "Sugiere un equipo equilibrado de 6 Pokémon de tu Pokédex",
+	"Rank your pokemon by a stat or their stat total (e.g. top attack 10)":                                                                             "Clasifica tus Pokémon por una estadística o por su total (p. ej. top attack 10)",
+	"Chart the types, generations, and stat totals of your pokemon":                                                                                    "Muestra en gráficos los tipos, generaciones y totales de estadísticas de tus Pokémon",
+	"Teach a caught pokemon a move (up to 4), or list its moves":                                                                                       "Enseña un movimiento (hasta 4) a un Pokémon atrapado, o muestra sus movimientos",
+	"Make a caught pokemon forget a move":                                                                                                              "Hace que un Pokémon atrapado olvide un movimiento",
+	"Add, list, clear, or search notes on caught pokemon":                                                                                              "Añade, muestra, borra o busca notas de tus Pokémon",
+	"Organize caught pokemon into named boxes (create/move/remove/delete/list)":                                                                        "Organiza tus Pokémon en cajas con nombre (create/move/remove/delete/list)",
+	"Navigate to the first page of locations":                                                                                                          "Va a la primera página de ubicaciones",
+	"Navigate to the next page of locations":                                                                                                           "Va a la página siguiente de ubicaciones",
+	"Navigate to the previous page of locations":                                                                                                       "Va a la página anterior de ubicaciones",
+	"Save your current Pokédex to a file":                                                                                                              "Guarda tu Pokédex en un archivo",
+	"Clear your Pokédex and start fresh":                                                                                                               "Vacía tu Pokédex y empieza de cero",
+	"Create, load, or list named snapshots of your save":                                                                                               "Crea, carga o lista instantáneas con nombre de tu partida",
+	"Show or download the species dataset used offline":                                                                                                "Muestra o descarga el conjunto de datos de especies usado sin conexión",
+	"Enable or disable automatic saving (on/off)":                                                                                                      "Activa o desactiva el guardado automático (on/off)",
+	"Set how often to auto-save (number of changes, or a time like 5m)":                                                                                "Indica cada cuántos cambios, o cada cuánto tiempo (como 5m), se guarda automáticamente",
+	"Show heights and weights in metric or imperial units":                                                                                             "Muestra alturas y pesos en unidades métricas o imperiales",
+	"Turn plain, screen-reader-friendly output on or off":                                                                                              "Activa o desactiva la salida sencilla, apta para lectores de pantalla",
+	"Show or change the language of the interface (e.g. lang es)":                                                                                      "Muestra o cambia el idioma de la interfaz (p. ej. lang en)",
+	"Show the application version, or check for a newer one with --check":                                                                              "Muestra la versión de la aplicación, o busca una más reciente con --check",
+	"Explain an error code and how to fix it":                                                                                                          "Explica un código de error y cómo solucionarlo",
+	"Limit moves to those learnable in one version group (e.g. versiongroup red-blue), or 'all'":                                                       "Limita los movimientos a los que se aprenden en un grupo de versiones (p. ej. versiongroup red-blue), o 'all'",
+	"Toggle debug mode to show detailed error information":                                                                                             "Activa o desactiva el modo de depuración con información detallada de errores",
+	"Exit the Pokedex": "Sale de la Pokédex",
+
+	// Batch mode and confirmations
+	"Batch summary: %d %s run, %d succeeded, %d failed\n":                  "Resumen: %d %s ejecutados, %d correctos, %d con errores\n",
+	"A command crashed, so the changes made by this batch were not saved.": "Un comando ha fallado de forma inesperada, así que no se han guardado los cambios de este lote.",
+	"command":                     "comando",
+	"commands":                    "comandos",
+	"Failed commands:":            "Comandos con errores:",
+	" - line %d: %s (%s)\n":       " - línea %d: %s (%s)\n",
+	"y":                           "s",
+	"yes":                         "sí",
+	"%s (y/N): ":                  "%s (s/N): ",
+	"%s (y/N): yes (--yes)\n":     "%s (s/N): sí (--yes)\n",
+	"%s (y/N): yes (--dry-run)\n": "%s (s/N): sí (--dry-run)\n",
+	"%s (y/N): no (input is not interactive; start with --yes to answer yes)\n": "%s (s/N): no (la entrada no es interactiva; inicia con --yes para responder que sí)\n",
+
+	// Errors shown by every command
+	"Error [%s]: %s\n":            "Error [%s]: %s\n",
+	"Error: %s\n":                 "Error: %s\n",
+	"No Pokémon name provided":    "No has indicado el nombre de ningún Pokémon",
+	"No location number provided": "No has indicado ningún número de ubicación",
+	"Unexpected data type for %s": "Tipo de datos inesperado para %s",
+	"The '%s' command crashed unexpectedly, but your session is still running": "El comando '%s' ha fallado inesperadamente, pero tu sesión sigue activa",
+	"Warning: Could not save Pokédex data after the crash: %v\n":               "Aviso: no se pudo guardar la Pokédex tras el fallo: %v\n",
+	"Warning: Could not save Pokédex data after the crash: timed out":          "Aviso: no se pudo guardar la Pokédex tras el fallo: se agotó el tiempo de espera",
+	"Your Pokédex was saved as a precaution.":                                  "Tu Pokédex se ha guardado por precaución.",
+	"The new form of %s is already in your Pokédex. Release it first":          "La nueva forma de %s ya está en tu Pokédex. Libérala primero",
+	"You already have a %s in your Pokédex. Release it before %s":              "Ya tienes un %s en tu Pokédex. Libéralo antes de %s",
+	"error auto-saving: %w": "error al guardar automáticamente: %w",
+
+	// Saving and settings
+	"Pokédex saved successfully!":                                           "¡Pokédex guardada correctamente!",
+	"Are you sure you want to clear your Pokédex? This cannot be undone.":   "¿Seguro que quieres vaciar tu Pokédex? No se puede deshacer.",
+	"Pokédex cleared! All Pokémon have been released.":                      "¡Pokédex vaciada! Todos los Pokémon han sido liberados.",
+	"operation cancelled":                                                   "operación cancelada",
+	"error saving empty Pokédex: %w":                                        "error al guardar la Pokédex vacía: %w",
+	"The '%s' command doesn't support %s":                                   "El comando '%s' no admite %s",
+	"Dry run: nothing will be changed or saved.":                            "Simulación: no se cambiará ni se guardará nada.",
+	"Dry run: '%s' wouldn't change anything.\n":                             "Simulación: '%s' no cambiaría nada.\n",
+	"Dry run: '%s' would make these changes, but none were made:\n":         "Simulación: '%s' haría estos cambios, pero no se ha hecho ninguno:\n",
+	"Remove %s from your Pokédex":                                           "Quitar a %s de tu Pokédex",
+	"Update %s in your Pokédex":                                             "Actualizar a %s en tu Pokédex",
+	"Add %s to your Pokédex":                                                "Añadir a %s a tu Pokédex",
+	"Register %d Pokémon as seen":                                           "Registrar %d Pokémon como vistos",
+	"Remove %d Pokémon from the seen list":                                  "Quitar %d Pokémon de la lista de vistos",
+	"Create box '%s'":                                                       "Crear la caja '%s'",
+	"Delete box '%s'":                                                       "Borrar la caja '%s'",
+	"Change your money from %d to %d":                                       "Cambiar tu dinero de %d a %d",
+	"Change the number of %s in your bag from %d to %d":                     "Cambiar la cantidad de %s en tu mochila de %d a %d",
+	"Pokédex data saved!":                                                   "¡Datos de la Pokédex guardados!",
+	"Thanks for using the Pokédex! See you next time!":                      "¡Gracias por usar la Pokédex! ¡Hasta la próxima!",
+	"Warning: Could not save Pokédex data: %v\n":                            "Aviso: no se pudieron guardar los datos de la Pokédex: %v\n",
+	"Usage: snapshot create <name>, snapshot load <name>, or snapshot list": "Uso: snapshot create <nombre>, snapshot load <nombre> o snapshot list",
+	"Unknown snapshot command '%s'. %s":                                     "Comando de instantánea desconocido '%s'. %s",
+	"No snapshot name provided":                                             "No has indicado el nombre de ninguna instantánea",
+	"'%s' can't be used as a snapshot name":                                 "'%s' no se puede usar como nombre de instantánea",
+	"error accessing snapshot '%s': %w":                                     "error al acceder a la instantánea '%s': %w",
+	"Replace the snapshot '%s' taken %s?":                                   "¿Reemplazar la instantánea '%s' tomada el %s?",
+	"Saved snapshot '%s' with %d Pokémon.\n":                                "Instantánea '%s' guardada con %d Pokémon.\n",
+	"No snapshot named '%s'. Use 'snapshot list' to see your snapshots":     "No hay ninguna instantánea llamada '%s'. Usa 'snapshot list' para ver tus instantáneas",
+	"Load the snapshot '%s'? Your current progress will be replaced unless you save it as a snapshot first.": "¿Cargar la instantánea '%s'? Tu progreso actual se reemplazará salvo que lo guardes antes como instantánea.",
+	"Loaded snapshot '%s' with %d Pokémon.\n":                                                                "Instantánea '%s' cargada con %d Pokémon.\n",
+	"error listing snapshots: %w":                                                                            "error al listar las instantáneas: %w",
+	"You have no snapshots. Use 'snapshot create <name>' to save one.":                                       "No tienes instantáneas. Usa 'snapshot create <nombre>' para guardar una.",
+	"Taken":                            "Tomada",
+	"Money":                            "Dinero",
+	"Usage: dataset or dataset update": "Uso: dataset o dataset update",
+	"Using the built-in dataset of %d species.\n":                                                    "Usando el conjunto de datos integrado de %d especies.\n",
+	"Using a dataset of %d species downloaded %s.\n":                                                 "Usando un conjunto de datos de %d especies descargado el %s.\n",
+	"It doesn't cover every Pokémon, so other names are checked with the PokeAPI.":                   "No incluye todos los Pokémon, así que los demás nombres se comprueban con la PokeAPI.",
+	"Run 'dataset update' to download the complete dataset.":                                         "Ejecuta 'dataset update' para descargar el conjunto de datos completo.",
+	"Downloading data for %d Pokémon. This may take a few minutes...\n":                              "Descargando los datos de %d Pokémon. Esto puede tardar unos minutos...\n",
+	"Saved a dataset of %d species to %s\n":                                                          "Conjunto de datos de %d especies guardado en %s\n",
+	"enabled":                                                                                        "activado",
+	"disabled":                                                                                       "desactivado",
+	"Auto-save is currently %s\n":                                                                    "El guardado automático está %s\n",
+	"Auto-save enabled. Your Pokédex will be saved automatically after changes.":                     "Guardado automático activado. Tu Pokédex se guardará automáticamente tras los cambios.",
+	"Auto-save disabled. Use 'save' command to manually save your Pokédex.":                          "Guardado automático desactivado. Usa el comando 'save' para guardar tu Pokédex manualmente.",
+	"Auto-save occurs after every change to your Pokédex.":                                           "El guardado automático se hace tras cada cambio en tu Pokédex.",
+	"Auto-save occurs after every %d changes to your Pokédex.\n":                                     "El guardado automático se hace cada %d cambios en tu Pokédex.\n",
+	"Auto-save will occur after every change to your Pokédex.":                                       "El guardado automático se hará tras cada cambio en tu Pokédex.",
+	"Auto-save will occur after every %d changes to your Pokédex.\n":                                 "El guardado automático se hará cada %d cambios en tu Pokédex.\n",
+	"invalid parameter: %s (use 'on' or 'off')":                                                      "parámetro no válido: %s (usa 'on' u 'off')",
+	"invalid interval: %s (must be a positive number or a duration like 5m)":                         "intervalo no válido: %s (debe ser un número positivo o una duración como 5m)",
+	"invalid interval: %s (must be at least %s)":                                                     "intervalo no válido: %s (debe ser de al menos %s)",
+	"Unsaved changes are also saved every %s.\n":                                                     "Los cambios sin guardar también se guardan cada %s.\n",
+	"Auto-save will no longer run on a timer.":                                                       "El guardado automático ya no se hará por tiempo.",
+	"Unsaved changes will be saved every %s.\n":                                                      "Los cambios sin guardar se guardarán cada %s.\n",
+	"Warning: Could not auto-save: %v\n":                                                             "Advertencia: No se pudo guardar automáticamente: %v\n",
+	"Heights and weights are shown in %s units. Use 'units metric' or 'units imperial' to change.\n": "Las alturas y los pesos se muestran en unidades %s. Usa 'units metric' o 'units imperial' para cambiarlo.\n",
+	"Heights and weights will be shown in %s units.\n":                                               "Las alturas y los pesos se mostrarán en unidades %s.\n",
+	"metric":   "métricas",
+	"imperial": "imperiales",
+	"Unknown units '%s' (use 'metric' or 'imperial')":                                                            "Unidades desconocidas '%s' (usa 'metric' o 'imperial')",
+	"The interface is shown in %s. Available languages: %s\n":                                                    "La interfaz se muestra en %s. Idiomas disponibles: %s\n",
+	"Use 'lang <code>' to change it (e.g. 'lang es').":                                                           "Usa 'lang <código>' para cambiarlo (p. ej. 'lang en').",
+	"Unknown language '%s'. Available languages: %s":                                                             "Idioma desconocido '%s'. Idiomas disponibles: %s",
+	"The interface will be shown in %s.\n":                                                                       "La interfaz se mostrará en %s.\n",
+	"Accessible mode is currently %s. Use 'accessible on' or 'accessible off' to change it.\n":                   "El modo accesible está %s. Usa 'accessible on' o 'accessible off' para cambiarlo.\n",
+	"Accessible mode enabled. Output is plain text without tables or separators, and the same every time.":       "Modo accesible activado. La salida es texto sencillo, sin tablas ni separadores, y siempre igual.",
+	"Accessible mode disabled.":                                                                                  "Modo accesible desactivado.",
+	"Debug mode is now enabled. Detailed error information and command timings will be logged.":                  "El modo de depuración está activado. Se registrarán los detalles de los errores y la duración de los comandos.",
+	"Debug mode is now disabled. Only user-friendly error messages will be shown.":                               "El modo de depuración está desactivado. Solo se mostrarán mensajes de error sencillos.",
+	"Moves are limited to those learnable in %s. Use 'versiongroup all' to allow every move.\n":                  "Los movimientos se limitan a los que se aprenden en %s. Usa 'versiongroup all' para permitir todos.\n",
+	"Moves from every game are allowed. Use 'versiongroup <name>' (e.g. 'versiongroup red-blue') to limit them.": "Se permiten los movimientos de todos los juegos. Usa 'versiongroup <nombre>' (p. ej. 'versiongroup red-blue') para limitarlos.",
+	"Unknown version group '%s' (e.g. red-blue, gold-silver, or sword-shield)":                                   "Grupo de versiones desconocido '%s' (p. ej. red-blue, gold-silver o sword-shield)",
+	"Moves from every game are allowed.":                                                                         "Se permiten los movimientos de todos los juegos.",
+	"Moves are now limited to those learnable in %s (%s).\n":                                                     "Ahora los movimientos se limitan a los que se aprenden en %s (%s).\n",
+	"Moves are now limited to those learnable in %s.\n":                                                          "Ahora los movimientos se limitan a los que se aprenden en %s.\n",
+
+	// Version and updates
+	"Pokédex CLI %s\n":                    "Pokédex CLI %s\n",
+	"Built with %s for %s/%s\n":           "Compilado con %s para %s/%s\n",
+	"Usage: version [--check] [--json]":   "Uso: version [--check] [--json]",
+	"Could not check for a newer version": "No se pudo comprobar si hay una versión más reciente",
+	"This is a development build, so there are no releases to compare it with.":            "Esta es una versión de desarrollo, así que no hay publicaciones con las que compararla.",
+	"You're using the latest version.":                                                     "Estás usando la última versión.",
+	"A new version of the Pokédex CLI is available: %s (you have %s)\nDownload it from %s": "Hay una nueva versión de Pokédex CLI: %s (tienes la %s)\nDescárgala desde %s",
+
+	// Maps and exploring
+	"You need to use the 'map' command first to load locations":                           "Primero tienes que usar el comando 'map' para cargar las ubicaciones",
+	"You're on the first page":                                                            "Estás en la primera página",
+	"You're on the last page":                                                             "Estás en la última página",
+	"Usage: map [--sort name|region]":                                                     "Uso: map [--sort name|region]",
+	"Unknown region:":                                                                     "Región desconocida:",
+	"Exploring %s...\n":                                                                   "Explorando %s...\n",
+	"Found Pokémon:":                                                                      "Pokémon encontrados:",
+	"Use 'catch <number>' to try to catch one of them.":                                   "Usa 'catch <número>' para intentar atrapar a uno de ellos.",
+	"No Pokémon found at this location.":                                                  "No se encontraron Pokémon en esta ubicación.",
+	"Invalid location number: please provide a number between 1-%d":                       "Número de ubicación no válido: indica un número entre 1 y %d",
+	"Location number %d is out of range (valid range: 1-%d)":                              "El número de ubicación %d está fuera de rango (rango válido: 1-%d)",
+	"No location list available, please run the 'map' command first":                      "No hay ninguna lista de ubicaciones, ejecuta primero el comando 'map'",
+	"Explore an area with 'explore <number>' before looking for a wild Pokémon":           "Explora una zona con 'explore <número>' antes de buscar un Pokémon salvaje",
+	"Choose a location with '%s <location number>', or explore an area first":             "Elige una ubicación con '%s <número de ubicación>' o explora antes una zona",
+	"No wild Pokémon can be found in %s that way.\n":                                      "De esa forma no se puede encontrar ningún Pokémon salvaje en %s.\n",
+	"A wild %s appeared in %s!\n":                                                         "¡Un %s salvaje apareció en %s!\n",
+	"The %s will last for %d more encounters here.\n":                                     "El %s durará %d encuentros más aquí.\n",
+	"The %s has worn off.\n":                                                              "El efecto del %s se ha agotado.\n",
+	"Use 'catch %s' to try to catch it.\n":                                                "Usa 'catch %s' para intentar atraparlo.\n",
+	"No lure is in use. Use 'lure <type or pokemon>' after exploring an area.":            "No hay ningún cebo en uso. Usa 'lure <tipo o pokémon>' después de explorar una zona.",
+	"%s in %s, drawing out %s (%d encounters left)":                                       "%s en %s, atrayendo a %s (quedan %d encuentros)",
+	"%s-type Pokémon":                                                                     "Pokémon de tipo %s",
+	"Explore an area with 'explore <number>' before using a lure":                         "Explora una zona con 'explore <número>' antes de usar un cebo",
+	"You don't have a lure. Buy Honey with 'shop buy honey'.":                             "No tienes ningún cebo. Compra Miel con 'shop buy honey'.",
+	"Replace the %s?":                                                                     "¿Reemplazar %s?",
+	"You used the %s in %s. %s will turn up more often in the next %d encounters here.\n": "Has usado %s en %s. %s aparecerán más a menudo en los próximos %d encuentros aquí.\n",
+
+	// Encounter methods
+	"Found by": "Se encuentra",
+	"On land":  "En tierra",
+	"Surfing":  "Haciendo surf",
+	"Fishing":  "Pescando",
+
+	// Catching, releasing, and showing off
+	"Throwing a Pokéball at %s...\n":                           "Lanzando una Poké Ball a %s...\n",
+	"Throwing a %s at %s...\n":                                 "Lanzando una %s a %s...\n",
+	"You don't have any %s. Buy some with 'shop buy %s'.":      "No tienes ninguna %s. Compra alguna con 'shop buy %s'.",
+	"Unknown ball '%s'. Usage: %s <pokemon> [--ball %s]":       "Ball desconocida '%s'. Uso: %s <pokémon> [--ball %s]",
+	"Throwing a Masterball at %s...\n":                         "Lanzando una Master Ball a %s...\n",
+	"You found a Masterball lying nearby...!":                  "¡Has encontrado una Master Ball tirada por ahí...!",
+	"%s was caught!\n":                                         "¡Has atrapado a %s!\n",
+	"%s was caught in %s!\n":                                   "¡Has atrapado a %s en %s!\n",
+	"%s escaped!\n":                                            "¡%s se ha escapado!\n",
+	"Usage: random catch [--gen <generation>] [--type <type>]": "Uso: random catch [--gen <generación>] [--type <tipo>]",
+	"Unknown type '%s'":                                        "Tipo desconocido '%s'",
+	"No species match that filter":                             "Ninguna especie coincide con ese filtro",
+	"A wild %s appeared!\n":                                    "¡Un %s salvaje apareció!\n",
+	"Capture rate of %s: %d/255\n":                             "Ratio de captura de %s: %d/255\n",
+	"%s is rare, so its capture rate is raised to %d.\n":       "%s es raro, así que su ratio de captura sube a %d.\n",
+	"Ball":             "Ball",
+	"Chance per throw": "Probabilidad por lanzamiento",
+	"Expected throws":  "Lanzamientos esperados",
+	"A throw succeeds when a random number from 0 to %d is below the capture rate.\n": "Un lanzamiento tiene éxito cuando un número aleatorio del 0 al %d es menor que el ratio de captura.\n",
+	"%s was released. Bye, %s!": "Has liberado a %s. ¡Adiós, %s!",
+	"%s used %s!\n":             "¡%s usó %s!\n",
+
+	// Inspecting and listing
+	"Level: %d\n":                     "Nivel: %d\n",
+	"At the day care since %s\n":      "En la guardería desde %s\n",
+	"Name: %s\n":                      "Nombre: %s\n",
+	"Height: %s\n":                    "Altura: %s\n",
+	"Weight: %s\n":                    "Peso: %s\n",
+	"Stats:\n":                        "Estadísticas:\n",
+	"Types:\n":                        "Tipos:\n",
+	"Name: %s (#%d)\n":                "Nombre: %s (n.º %d)\n",
+	"Types: %s\n":                     "Tipos: %s\n",
+	" - Total: %d\n":                  " - Total: %d\n",
+	"%s is in your Pokédex.\n":        "%s está en tu Pokédex.\n",
+	"Forms of %s:\n":                  "Formas de %s:\n",
+	"Form":                            "Forma",
+	"Owned":                           "Lo tienes",
+	"%s (default)":                    "%s (predeterminada)",
+	"%s has no alternate forms.\n":    "%s no tiene formas alternativas.\n",
+	"You own every form of %s!\n":     "¡Tienes todas las formas de %s!\n",
+	"You own %d of %d forms of %s.\n": "Tienes %d de las %d formas de %s.\n",
+	"Ribbons: %s\n":                   "Cintas: %s\n",
+	"Minigame bests: %s\n":            "Mejores marcas en minijuegos: %s\n",
+	"Happiness gained from minigames and care: +%d\n": "Felicidad ganada con minijuegos y cuidados: +%d\n",
+	"Moves:\n":               "Movimientos:\n",
+	"Notes:\n":               "Notas:\n",
+	"Caught: %s\n":           "Atrapado: %s\n",
+	"in %s":                  "en %s",
+	"Day care":               "Guardería",
+	"Where":                  "Dónde",
+	"Box '%s'":               "Caja '%s'",
+	"With you (%d of %d):\n": "Contigo (%d de %d):\n",
+	"No Pokémon are with you. Take some out of storage with 'box remove <pokemon>'.": "No llevas ningún Pokémon contigo. Saca alguno del almacenamiento con 'box remove <pokémon>'.",
+	"In storage (%d):\n":     "Almacenados (%d):\n",
+	"Seen: %d  Caught: %d\n": "Vistos: %d  Atrapados: %d\n",
+	"%d new Pokémon registered as seen. Use 'seen' to browse them.\n": "%d Pokémon nuevos registrados como vistos. Usa 'seen' para consultarlos.\n",
+	"Usage: seen [--at <location>]":                                   "Uso: seen [--at <ubicación>]",
+	"First seen":                                                      "Visto por primera vez",
+	"Caught":                                                          "Atrapado",
+	"You haven't spotted any Pokémon in %s yet.\n":                    "Todavía no has visto ningún Pokémon en %s.\n",
+	"You haven't seen any Pokémon yet. Use 'explore' to look around.":                                    "Todavía no has visto ningún Pokémon. Usa 'explore' para echar un vistazo.",
+	"Pokémon first spotted in %s:\n":                                                                     "Pokémon vistos por primera vez en %s:\n",
+	"You have seen %d Pokémon:\n":                                                                        "Has visto %d Pokémon:\n",
+	"Usage: party, party size [number], party status, or party heal":                                     "Uso: party, party size [número], party status o party heal",
+	"Unknown party command '%s'. %s":                                                                     "Comando de equipo desconocido '%s'. %s",
+	"Your party is empty (up to %d Pokémon). Take Pokémon out of storage with 'box remove <pokemon>'.\n": "Tu equipo está vacío (hasta %d Pokémon). Saca Pokémon del almacenamiento con 'box remove <pokémon>'.\n",
+	"You can have up to %d Pokémon with you. Use 'party size <number>' to change this.\n":                "Puedes llevar hasta %d Pokémon contigo. Usa 'party size <número>' para cambiarlo.\n",
+	"The party size must be a number from 1 to %d":                                                       "El tamaño del equipo debe ser un número del 1 al %d",
+	"You have %d Pokémon with you. Move some to a box before lowering the party size to %d":              "Llevas %d Pokémon contigo. Mueve algunos a una caja antes de reducir el tamaño del equipo a %d",
+	"You can now have up to %d Pokémon with you.\n":                                                      "Ahora puedes llevar hasta %d Pokémon contigo.\n",
+	"Your party is full (%d Pokémon). Move a Pokémon to a box with 'box move <pokemon> <box>' first":     "Tu equipo está lleno (%d Pokémon). Mueve un Pokémon a una caja con 'box move <pokémon> <caja>' primero",
+	"Your party is full, so %s was sent to box '%s'.\n":                                                  "Tu equipo está lleno, así que %s se ha enviado a la caja '%s'.\n",
+	"Box '%s' has %d Pokémon, but your party only has room for %d. Move them to another box first":       "La caja '%s' tiene %d Pokémon, pero en tu equipo solo caben %d. Muévelos a otra caja primero",
+	"Your Pokédex (%s):\n":                                                "Tu Pokédex (%s):\n",
+	"You have not caught any Pokémon yet":                                 "Todavía no has atrapado ningún Pokémon",
+	"None of your Pokémon match (%s).\n":                                  "Ninguno de tus Pokémon coincide (%s).\n",
+	"Box '%s' is empty. Add Pokémon with 'box move <pokemon> %s'.\n":      "La caja '%s' está vacía. Añade Pokémon con 'box move <pokemon> %s'.\n",
+	"Usage: pokedex [--box <name>] [--caught-at <location>] [--families]": "Uso: pokedex [--box <nombre>] [--caught-at <ubicación>] [--families]",
+	"Your Pokédex by evolution family:":                                   "Tu Pokédex por familia evolutiva:",
+	"Your Pokédex by evolution family (%s):\n":                            "Tu Pokédex por familia evolutiva (%s):\n",
+	"[x] caught  [o] seen  [ ] not seen":                                  "[x] atrapado  [o] visto  [ ] no visto",
+	"box '%s'":                                                            "caja '%s'",
+	"caught at %s":                                                        "atrapado en %s",
+
+	// Describing
+	"%s, the %s\n":                      "%s, el %s\n",
+	" (From Pokémon %s)\n":              " (De Pokémon %s)\n",
+	"Your notes:":                       "Tus notas:",
+	"Biology:":                          "Biología:",
+	"Habitat: %s":                       "Hábitat: %s",
+	"Color: %s":                         "Color: %s",
+	"Shape: %s":                         "Forma: %s",
+	"Growth rate: %s":                   "Ritmo de crecimiento: %s",
+	"Base happiness: %d":                "Felicidad base: %d",
+	"Catching:":                         "Captura:",
+	" - Capture rate: %d/255\n":         " - Ratio de captura: %d/255\n",
+	" - Base experience: %d\n":          " - Experiencia base: %d\n",
+	" - Rarity: %s\n":                   " - Rareza: %s\n",
+	"Common":                            "Común",
+	"Rare":                              "Raro",
+	"Legendary":                         "Legendario",
+	"No Pokédex entries found for %s\n": "No se encontraron entradas de la Pokédex para %s\n",
+	"No species data is available for %s, so only what your Pokédex holds is shown.\n": "No hay datos de especie para %s, así que solo se muestra lo que guarda tu Pokédex.\n",
+	"Pokédex entries for %s are available from %d versions:\n":                         "Hay entradas de la Pokédex para %s en %d versiones:\n",
+	"Use 'describe %s --version <game>' to read one.\n":                                "Usa 'describe %s --version <juego>' para leer una.\n",
+	"No Pokédex entry for %s in Pokémon %s. Available versions: %s":                    "No hay entrada de la Pokédex para %s en Pokémon %s. Versiones disponibles: %s",
+	"Unknown option '%s'":              "Opción desconocida '%s'",
+	"Option '%s' doesn't take a value": "La opción '%s' no admite un valor",
+	"%s. Usage: describe <pokemon> [--version <game> | --versions | --all]": "%s. Uso: describe <pokemon> [--version <juego> | --versions | --all]",
+	"Generation %d": "Generación %d",
+	"Generation %s": "Generación %s",
+	"Other games":   "Otros juegos",
+
+	// Evolving and devolving
+	"%s can evolve into %s.\n":                                                 "%s puede evolucionar a %s.\n",
+	"%s can evolve into multiple forms. Choose one:\n":                         "%s puede evolucionar a varias formas. Elige una:\n",
+	"%s cannot evolve (not found in evolution chain)":                          "%s no puede evolucionar (no está en la cadena evolutiva)",
+	"%s cannot evolve any further":                                             "%s no puede evolucionar más",
+	"No evolution data is available for %s, so it can't be evolved for now.\n": "No hay datos de evolución para %s, así que por ahora no puede evolucionar.\n",
+	"Invalid evolution selection: '%s'":                                        "Selección de evolución no válida: '%s'",
+	"Please specify which evolution to use (e.g., 'evolve %s 1')":              "Indica qué evolución quieres (p. ej. 'evolve %s 1')",
+	"In the games, it evolves by:":                                             "En los juegos, evoluciona así:",
+	"Type: %s (unchanged)\n":                                                   "Tipo: %s (sin cambios)\n",
+	"Type: %s -> %s\n":                                                         "Tipo: %s -> %s\n",
+	"Type: changes from %s to %s\n":                                            "Tipo: cambia de %s a %s\n",
+	"Stat":                                                                     "Estadística",
+	"Change":                                                                   "Cambio",
+	"Evolve %s into %s?":                                                       "¿Hacer evolucionar a %s en %s?",
+	"Evolution cancelled. %s was not changed.\n":                               "Evolución cancelada. %s no ha cambiado.\n",
+	"Evolving %s into %s...\n":                                                 "%s está evolucionando a %s...\n",
+	"Congratulations! Your %s evolved into %s!\n":                              "¡Enhorabuena! ¡Tu %s ha evolucionado a %s!\n",
+	"Changed your mind? Use 'devolve %s' to undo the evolution.\n":             "¿Has cambiado de opinión? Usa 'devolve %s' para deshacer la evolución.\n",
+	"evolving %s":                                                              "hacer evolucionar a %s",
+	"devolving %s":                                                             "revertir la evolución de %s",
+	"%s has no earlier form to return to (only Pokémon evolved with 'evolve' can be devolved)": "%s no tiene una forma anterior a la que volver (solo se pueden revertir los Pokémon que evolucionaron con 'evolve')",
+	"%s returned to its previous form. Welcome back, %s!\n":                                    "%s ha vuelto a su forma anterior. ¡Bienvenido de nuevo, %s!\n",
+	"Unknown conditions": "Condiciones desconocidas",
+	"Level up":           "Subir de nivel",
+	"Reach level %d":     "Alcanzar el nivel %d",
+	"Trade for %s":       "Intercambiarlo por %s",
+	"Use a %s":           "Usar %s",
+	"Use an item":        "Usar un objeto",
+	"Level up with an empty party slot and a spare Poké Ball": "Subir de nivel con un hueco libre en el equipo y una Poké Ball de sobra",
+	"using a %s":                          "usando %s",
+	"while holding a %s":                  "llevando equipado %s",
+	"with high friendship (%d+)":          "con mucha amistad (%d+)",
+	"with high affection (%d+)":           "con mucho afecto (%d+)",
+	"with high beauty (%d+)":              "con mucha belleza (%d+)",
+	"knowing %s":                          "conociendo %s",
+	"knowing a %s-type move":              "conociendo un movimiento de tipo %s",
+	"with %s in the party":                "con %s en el equipo",
+	"with a %s-type Pokémon in the party": "con un Pokémon de tipo %s en el equipo",
+	"with Attack higher than Defense":     "con más Ataque que Defensa",
+	"with Attack equal to Defense":        "con el mismo Ataque que Defensa",
+	"with Attack lower than Defense":      "con menos Ataque que Defensa",
+	"at %s":                               "en %s",
+	"during the day":                      "durante el día",
+	"at night":                            "de noche",
+	"while it's raining":                  "mientras llueve",
+	"with the console held upside down":   "con la consola boca abajo",
+
+	// Moves, notes, and boxes
+	"%s can't learn %s": "%s no puede aprender %s",
+	"%s can't learn %s in %s. Use 'versiongroup all' to allow moves from every game": "%s no puede aprender %s en %s. Usa 'versiongroup all' para permitir los movimientos de todos los juegos",
+	"%s already knows %s": "%s ya conoce %s",
+	"%s already knows %d moves. Use 'forget %s <move>' to make room first": "%s ya conoce %d movimientos. Usa 'forget %s <movimiento>' para hacer sitio primero",
+	"%s doesn't know %s":         "%s no conoce %s",
+	"%s learned %s!\n":           "¡%s ha aprendido %s!\n",
+	"%s forgot %s.\n":            "%s ha olvidado %s.\n",
+	"%s knows %d of %d moves:\n": "%s conoce %d de %d movimientos:\n",
+	"%s hasn't been taught any moves. It can learn %d moves, e.g. 'teach %s %s'.\n":       "A %s no se le ha enseñado ningún movimiento. Puede aprender %d movimientos, p. ej. 'teach %s %s'.\n",
+	"%s hasn't been taught any moves. It can learn %d moves in %s, e.g. 'teach %s %s'.\n": "A %s no se le ha enseñado ningún movimiento. Puede aprender %d movimientos en %s, p. ej. 'teach %s %s'.\n",
+	"Usage: forget <pokemon> <move>":                                             "Uso: forget <pokemon> <movimiento>",
+	"Usage: commands [--json]":                                                   "Uso: commands [--json]",
+	"Usage: completion bash|zsh|fish|names":                                      "Uso: completion bash|zsh|fish|names",
+	"Added a note to %s.\n":                                                      "Nota añadida a %s.\n",
+	"Notes for %s:\n":                                                            "Notas de %s:\n",
+	"%s has no notes. Add one with 'note %s <text>'.\n":                          "%s no tiene notas. Añade una con 'note %s <texto>'.\n",
+	"Removed %d note(s) from %s.\n":                                              "Se han borrado %d nota(s) de %s.\n",
+	"No notes found matching '%s'.\n":                                            "No se encontraron notas que coincidan con '%s'.\n",
+	"No search text provided (e.g., 'note search shiny')":                        "No has indicado ningún texto de búsqueda (p. ej. 'note search shiny')",
+	"Usage: note <pokemon> <text>, note clear <pokemon>, or note search <query>": "Uso: note <pokemon> <texto>, note clear <pokemon> o note search <búsqueda>",
+	"Note": "Nota",
+	"Usage: box create <name>, box move <pokemon> <box>, box move --select <box>, box remove <pokemon>, box delete <name>, or box list": "Uso: box create <nombre>, box move <pokemon> <caja>, box move --select <caja>, box remove <pokemon>, box delete <nombre> o box list",
+	"Unknown box command '%s'. %s":                                       "Comando de caja desconocido '%s'. %s",
+	"No box name provided":                                               "No has indicado el nombre de ninguna caja",
+	"A box named '%s' already exists":                                    "Ya existe una caja llamada '%s'",
+	"Created box '%s'.\n":                                                "Caja '%s' creada.\n",
+	"Usage: box move <pokemon> <box>":                                    "Uso: box move <pokemon> <caja>",
+	"Moved %s to box '%s'.\n":                                            "%s se ha movido a la caja '%s'.\n",
+	"%s isn't in a box.\n":                                               "%s no está en ninguna caja.\n",
+	"Took %s out of box '%s'.\n":                                         "%s se ha sacado de la caja '%s'.\n",
+	"Deleted box '%s'. %d Pokémon were taken out of it.\n":               "Caja '%s' borrada. Se han sacado %d Pokémon de ella.\n",
+	"You don't have any boxes yet. Create one with 'box create <name>'.": "Todavía no tienes ninguna caja. Crea una con 'box create <nombre>'.",
+	"There is no box named '%s'. Create it with 'box create %s'":         "No hay ninguna caja llamada '%s'. Créala con 'box create %s'",
+	"Box":     "Caja",
+	"Count":   "Cantidad",
+	"Name":    "Nombre",
+	"Pokémon": "Pokémon",
+
+	// Checklists, counters, and teams
+	"Usage: checklist <generation> [--out <file>] (e.g., 'checklist gen1')": "Uso: checklist <generación> [--out <archivo>] (p. ej. 'checklist gen1')",
+	"Invalid generation '%s': use a number like 'gen1' or '3'":              "Generación no válida '%s': usa un número como 'gen1' o '3'",
+	"Could not create file '%s'":                                            "No se pudo crear el archivo '%s'",
+	"Checklist for %s written to %s\n":                                      "Lista de %s escrita en %s\n",
+	"%s checklist:\n":                                                       "Lista de %s:\n",
+	"caught":                                                                "atrapado",
+	"seen":                                                                  "visto",
+	"not seen":                                                              "sin ver",
+	"Seen %d, caught %d of %d (%d%%)\n":                                     "Vistos %d, atrapados %d de %d (%d%%)\n",
+	"You have not caught any Pokémon yet, so there's nothing to counter with.": "Todavía no has atrapado ningún Pokémon, así que no tienes con qué contrarrestarlo.",
+	"Best counters to %s (%s):\n": "Mejores opciones contra %s (%s):\n",
+	"%d. %s (%s) - score %.1f\n":  "%d. %s (%s) - puntuación %.1f\n",
+	"hits %gx with %s STAB":       "golpea x%g con su STAB de tipo %s",
+	"only hits %gx with its STAB": "solo golpea x%g con su STAB",
+	"resists its STAB":            "resiste su STAB",
+	"immune to its %s STAB":       "inmune a su STAB de tipo %s",
+	"weak to its %s STAB (%gx)":   "débil a su STAB de tipo %s (x%g)",
+	"base stats %d vs %d":         "estadísticas base %d contra %d",
+	"You have not caught any Pokémon yet, so there's no team to build.":                             "Todavía no has atrapado ningún Pokémon, así que no hay equipo que formar.",
+	"Usage: top <stat> [count] [--effective] (e.g. top attack 10)":                                  "Uso: top <estadística> [cantidad] [--effective] (p. ej. top attack 10)",
+	"Unknown stat '%s' (use hp, attack, defense, special-attack, special-defense, speed, or total)": "Estadística desconocida '%s' (usa hp, attack, defense, special-attack, special-defense, speed o total)",
+	"invalid count: %s (must be a positive number)":                                                 "cantidad no válida: %s (debe ser un número positivo)",
+	"Your top %d Pokémon by %s at their current level:\n":                                           "Tus %d mejores Pokémon por %s a su nivel actual:\n",
+	"Your top %d Pokémon by base %s:\n":                                                             "Tus %d mejores Pokémon por %s base:\n",
+	"Your Pokédex has %d Pokémon.\n":                                                                "Tu Pokédex tiene %d Pokémon.\n",
+	"Types:":                                                                                        "Tipos:",
+	"Generations:":                                                                                  "Generaciones:",
+	"Base stat totals:":                                                                             "Totales de estadísticas base:",
+	"Unknown":                                                                                       "Desconocida",
+	"You have %d Pokémon, so all of them are on the team.\n":                                        "Tienes %d Pokémon, así que todos forman parte del equipo.\n",
+	"Suggested team:":                                                                               "Equipo sugerido:",
+	"Role":                                                                                          "Función",
+	"Types":                                                                                         "Tipos",
+	"Stats":                                                                                         "Estadísticas",
+	"Super-effective against":                                                                       "Superefectivo contra",
+	"Coverage: hits %d of %d types super-effectively":                                               "Cobertura: golpea de forma superefectiva a %d de %d tipos",
+	" (not covered: %s)":                                                                            " (sin cubrir: %s)",
+	"No egg groups are recorded for %s.\n":                                                          "No hay grupos huevo registrados para %s.\n",
+	"Egg groups of %s: %s\n":                                                                        "Grupos huevo de %s: %s\n",
+	"%s is in the Undiscovered egg group, so it can't breed.\n":                                     "%s pertenece al grupo huevo Desconocido, así que no puede criar.\n",
+	"%s can breed with any Pokémon that isn't in the Undiscovered egg group, except another Ditto.\n": "%s puede criar con cualquier Pokémon que no pertenezca al grupo huevo Desconocido, salvo con otro Ditto.\n",
+	"%s is genderless, so it can only breed with Ditto.\n":                                            "%s no tiene género, así que solo puede criar con Ditto.\n",
+	"None of the Pokémon in your Pokédex can breed with %s.\n":                                        "Ninguno de los Pokémon de tu Pokédex puede criar con %s.\n",
+	"Compatible partners in your Pokédex (%d):\n":                                                     "Parejas compatibles en tu Pokédex (%d):\n",
+	"Shared egg groups":                             "Grupos huevo en común",
+	"Any (Ditto)":                                   "Cualquiera (Ditto)",
+	"...and %d more.\n":                             "...y %d más.\n",
+	"Usage: fight trainer [class]":                  "Uso: fight trainer [clase]",
+	"Unknown trainer class '%s'. Choose one of: %s": "Clase de entrenador desconocida '%s'. Elige una de: %s",
+	"You have no Pokémon to battle with. Catch some, or withdraw them from the day care.": "No tienes Pokémon con los que combatir. Atrapa alguno o recógelos de la guardería.",
+	"Could not find any Pokémon for the %s":                                               "No se encontró ningún Pokémon para el %s",
+	"A %s wants to battle!\n":                                                             "¡Un %s quiere combatir!\n",
+	"The %s's team: %s\n":                                                                 "Equipo del %s: %s\n",
+	"Round %d: Your %s defeated %s (%d%% chance)\n":                                       "Ronda %d: tu %s derrotó a %s (%d%% de probabilidad)\n",
+	"Round %d: Your %s was defeated by %s (%d%% chance)\n":                                "Ronda %d: tu %s fue derrotado por %s (%d%% de probabilidad)\n",
+	"You lost to the %s.\n":                                                               "Has perdido contra el %s.\n",
+	"%s earned the %s!\n":                                                                 "¡%s ha ganado la %s!\n",
+	"Usage: battle hotseat [--best-of <n>] [--p1 <save file>] [--p2 <save file>] [--record <file>], battle wild [--difficulty <level>] [--record <file>], or battle gym <type> [--difficulty <level>] [--record <file>]": "Uso: battle hotseat [--best-of <n>] [--p1 <archivo de partida>] [--p2 <archivo de partida>] [--record <archivo>], battle wild [--difficulty <nivel>] [--record <archivo>] o battle gym <tipo> [--difficulty <nivel>] [--record <archivo>]",
+	"The number of battles must be an odd number, like 3 or 5":               "El número de combates debe ser impar, como 3 o 5",
+	"Battles need an interactive terminal and can't be played in batch mode": "Los combates necesitan una terminal interactiva y no se pueden jugar en modo por lotes",
+	"Player %d": "Jugador %d",
+	"%s has no Pokémon to battle with. Catch some, or withdraw them from the day care.": "%s no tiene Pokémon con los que combatir. Atrapa alguno o recógelos de la guardería.",
+	"Could not read save file '%s'":                                       "No se pudo leer el archivo de partida '%s'",
+	"Battle %d (best of %d)\n":                                            "Combate %d (al mejor de %d)\n",
+	"%s wins the battle! Score: %s %d, %s %d\n":                           "¡%s gana el combate! Marcador: %s %d, %s %d\n",
+	"%s wins the series %d to %d!\n":                                      "¡%s gana la serie por %d a %d!\n",
+	"%s, choose up to %d Pokémon for your team, separated by commas:\n":   "%s, elige hasta %d Pokémon para tu equipo, separados por comas:\n",
+	"%s can't battle: it isn't in the Pokédex, or it's at the day care":   "%s no puede combatir: no está en la Pokédex o está en la guardería",
+	"Choose from 1 to %d Pokémon":                                         "Elige de 1 a %d Pokémon",
+	"%s's team: %s\n":                                                     "Equipo de %s: %s\n",
+	"%s, it's your turn. Make sure %s isn't looking, then press Enter.\n": "%s, es tu turno. Asegúrate de que %s no está mirando y pulsa Intro.\n",
+	"Your %s (%d/%d HP) is facing %s's %s (%d/%d HP).\n":                  "Tu %[1]s (%[2]d/%[3]d PS) se enfrenta al %[5]s de %[4]s (%[6]d/%[7]d PS).\n",
+	"no damage":                                 "sin daño",
+	"power %d":                                  "potencia %d",
+	"%s has chosen.\n":                          "%s ya ha elegido.\n",
+	"That Pokémon can't be switched in":         "Ese Pokémon no puede entrar en combate",
+	"Choose a move from 1 to %d":                "Elige un movimiento del 1 al %d",
+	"%s, choose your next Pokémon:\n":           "%s, elige tu siguiente Pokémon:\n",
+	"The battle ended because the input closed": "El combate terminó porque se cerró la entrada",
+	"%s attack":                                 "Ataque %s",
+	"%s sent out %s!":                           "¡%s sacó a %s!",
+	"%s's %s used %s, but it missed!":           "¡El %[2]s de %[1]s usó %[3]s, pero falló!",
+	"%s's %s used %s, but nothing happened.":    "El %[2]s de %[1]s usó %[3]s, pero no pasó nada.",
+	"%s's %s fainted!":                          "¡El %[2]s de %[1]s se debilitó!",
+	"%s's %s used %s!":                          "¡El %[2]s de %[1]s usó %[3]s!",
+	"It doesn't affect %s...":                   "No afecta a %s...",
+	"It's super effective!":                     "¡Es supereficaz!",
+	"It's not very effective...":                "No es muy eficaz...",
+	"%s took %d damage (%d/%d HP left).":        "%s recibió %d de daño (le quedan %d/%d PS).",
+
+	// Computer opponents
+	"Unknown difficulty '%s'. Choose one of: %s":   "Dificultad desconocida '%s'. Elige una de: %s",
+	"Choose the type of gym to battle, one of: %s": "Elige el tipo de gimnasio contra el que combatir, uno de: %s",
+	"%s Gym Leader":        "Líder de gimnasio de tipo %s",
+	"Player":               "Jugador",
+	"You won the battle!":  "¡Has ganado el combate!",
+	"You lost the battle.": "Has perdido el combate.",
+	"Your %s (%d/%d HP) is facing the wild %s (%d/%d HP).\n": "Tu %s (%d/%d PS) se enfrenta al %s salvaje (%d/%d PS).\n",
+	"A wild %s appeared!":                        "¡Un %s salvaje apareció!",
+	"The wild %s used %s, but it missed!":        "¡El %s salvaje usó %s, pero falló!",
+	"The wild %s used %s, but nothing happened.": "El %s salvaje usó %s, pero no pasó nada.",
+	"The wild %s fainted!":                       "¡El %s salvaje se debilitó!",
+	"The wild %s used %s!":                       "¡El %s salvaje usó %s!",
+	"Wild Pokémon":                               "Pokémon salvaje",
+
+	// Status conditions
+	"burned":                 "quemado",
+	"poisoned":               "envenenado",
+	"paralyzed":              "paralizado",
+	"asleep":                 "dormido",
+	"frozen":                 "congelado",
+	"%s's %s is now %s!":     "¡El %[2]s de %[1]s ahora está %[3]s!",
+	"The wild %s is now %s!": "¡El %s salvaje ahora está %s!",
+	"%s's %s is %s and took %d damage (%d/%d HP left).":     "El %[2]s de %[1]s está %[3]s y recibió %[4]d de daño (le quedan %[5]d/%[6]d PS).",
+	"The wild %s is %s and took %d damage (%d/%d HP left).": "El %s salvaje está %s y recibió %d de daño (le quedan %d/%d PS).",
+	"%s's %s is %s and can't move!":                         "¡El %[2]s de %[1]s está %[3]s y no se puede mover!",
+	"The wild %s is %s and can't move!":                     "¡El %s salvaje está %s y no se puede mover!",
+	"%s's %s is no longer %s!":                              "¡El %[2]s de %[1]s ya no está %[3]s!",
+	"The wild %s is no longer %s!":                          "¡El %s salvaje ya no está %s!",
+
+	// Weather and terrain
+	"It started to rain!":                                                      "¡Ha empezado a llover!",
+	"The sunlight turned harsh!":                                               "¡El sol pega fuerte!",
+	"A sandstorm kicked up!":                                                   "¡Se ha levantado una tormenta de arena!",
+	"An electric current ran across the battlefield!":                          "¡Se ha formado un campo de corriente eléctrica en el terreno de combate!",
+	"Grass grew to cover the battlefield!":                                     "¡El terreno de combate se ha cubierto de hierba!",
+	"The battlefield got weird!":                                               "¡El terreno de combate se ha vuelto muy extraño!",
+	"Mist swirled around the battlefield!":                                     "¡La niebla ha envuelto el terreno de combate!",
+	"The rain stopped.":                                                        "Ha dejado de llover.",
+	"The harsh sunlight faded.":                                                "El sol vuelve a brillar como siempre.",
+	"The sandstorm subsided.":                                                  "La tormenta de arena ha amainado.",
+	"The electricity disappeared from the battlefield.":                        "El campo de corriente eléctrica ha desaparecido.",
+	"The grass disappeared from the battlefield.":                              "La hierba ha desaparecido.",
+	"The weirdness disappeared from the battlefield.":                          "El terreno de combate ha vuelto a la normalidad.",
+	"The mist disappeared from the battlefield.":                               "La niebla ha desaparecido.",
+	"%s's %s's %s!":                                                            "¡%[3]s del %[2]s de %[1]s!",
+	"The wild %s's %s!":                                                        "¡%[2]s del %[1]s salvaje!",
+	"%s's %s is buffeted by the sandstorm and took %d damage (%d/%d HP left).": "La tormenta de arena zarandea al %[2]s de %[1]s, que recibió %[3]d de daño (le quedan %[4]d/%[5]d PS).",
+	"The wild %s is buffeted by the sandstorm and took %d damage (%d/%d HP left).": "La tormenta de arena zarandea al %s salvaje, que recibió %d de daño (le quedan %d/%d PS).",
+	"%s's %s restored %d HP on the grassy terrain (%d/%d HP left).":                "El %[2]s de %[1]s recuperó %[3]d PS gracias al campo de hierba (le quedan %[4]d/%[5]d PS).",
+	"The wild %s restored %d HP on the grassy terrain (%d/%d HP left).":            "El %s salvaje recuperó %d PS gracias al campo de hierba (le quedan %d/%d PS).",
+
+	// Battle commands
+	"Enter 'move <n>' to use a move, 'switch <n>' to send out another Pokémon, or 'run' to forfeit:": "Escribe 'move <n>' para usar un movimiento, 'switch <n>' para sacar otro Pokémon o 'run' para rendirte:",
+	"%s forfeited the battle.": "%s se ha rendido.",
+	"You got away safely!":     "¡Escapaste sin problemas!",
+
+	// Dashboard
+	"Serve a web page showing your collection while the app is open":                              "Sirve una página web con tu colección mientras la aplicación está abierta",
+	"Usage: dashboard [--port <n>], or dashboard stop":                                            "Uso: dashboard [--port <n>], o dashboard stop",
+	"The port must be a number from 1 to 65535":                                                   "El puerto debe ser un número del 1 al 65535",
+	"The dashboard is already running at http://%s/\n":                                            "El panel ya está funcionando en http://%s/\n",
+	"The dashboard stops when the app exits, so it can't be started in batch mode":                "El panel se detiene al salir de la aplicación, así que no se puede iniciar en modo por lotes",
+	"Could not serve the dashboard on port %d":                                                    "No se pudo servir el panel en el puerto %d",
+	"The dashboard stopped: %v\n":                                                                 "El panel se ha detenido: %v\n",
+	"The dashboard is running at http://%s/\n":                                                    "El panel está funcionando en http://%s/\n",
+	"It shows your Pokédex as it changes while the app is open. Use 'dashboard stop' to stop it.": "Muestra tu Pokédex según cambia mientras la aplicación está abierta. Usa 'dashboard stop' para detenerlo.",
+	"The dashboard isn't running.":                                                                "El panel no está funcionando.",
+	"Could not stop the dashboard cleanly":                                                        "No se pudo detener el panel correctamente",
+	"Stopped the dashboard.":                                                                      "Se ha detenido el panel.",
+	"Pokédex dashboard":                                                                           "Panel de la Pokédex",
+	"Collection":                                                                                  "Colección",
+	"Completion":                                                                                  "Progreso",
+	"Caught by type":                                                                              "Atrapados por tipo",
+	"Search by name":                                                                              "Buscar por nombre",
+	"All types":                                                                                   "Todos los tipos",
+	"Everywhere":                                                                                  "En cualquier lugar",
+	"Lv.":                                                                                         "Nv.",
+	"Base stat total":                                                                             "Total de estadísticas base",
+	"No Pokémon match these filters.":                                                             "Ningún Pokémon coincide con estos filtros.",
+	"Completion only counts the species in the built-in dataset. Run 'dataset update' to count every species.": "El progreso solo cuenta las especies del conjunto de datos integrado. Ejecuta 'dataset update' para contar todas las especies.",
+	"Party": "Equipo",
+
+	// Serve mode
+	"Let other programs control your Pokédex through a gRPC service until Ctrl+C": "Permite que otros programas controlen tu Pokédex a través de un servicio gRPC hasta pulsar Ctrl+C",
+	"Usage: serve [--port <n>]":                                            "Uso: serve [--port <n>]",
+	"Could not serve on port %d":                                           "No se pudo servir en el puerto %d",
+	"Serving the %s gRPC service at %s\n":                                  "Sirviendo el servicio gRPC %s en %s\n",
+	"Press Ctrl+C to stop.":                                                "Pulsa Ctrl+C para detenerlo.",
+	"The gRPC service stopped unexpectedly":                                "El servicio gRPC se detuvo inesperadamente",
+	"Could not stop the gRPC service cleanly":                              "No se pudo detener el servicio gRPC correctamente",
+	"Could not find the trainers' save folder":                             "No se pudo encontrar la carpeta de partidas de los entrenadores",
+	"Could not create the trainers' save folder":                           "No se pudo crear la carpeta de partidas de los entrenadores",
+	"Could not load the Pokédex of trainer '%s'":                           "No se pudo cargar la Pokédex del entrenador '%s'",
+	"Invalid trainer ID '%s'. Use up to 64 letters, digits, '-', and '_'.": "ID de entrenador '%s' no válido. Usa hasta 64 letras, dígitos, '-' y '_'.",
+	"\nStopped serving.":                                                   "\nSe ha dejado de servir.",
+	"Unknown ball '%s'. Choose one of: %s":                                 "Ball desconocida '%s'. Elige una de: %s",
+	"No location area provided":                                            "No se indicó ninguna zona",
+
+	// MQTT
+	"Publish catches, evolutions, and ribbons to an MQTT broker for home automation":          "Publica capturas, evoluciones y cintas en un bróker MQTT para la domótica",
+	"Usage: mqtt [<broker> [--topic <topic>] | off | test]":                                   "Uso: mqtt [<bróker> [--topic <tema>] | off | test]",
+	"Events will no longer be published to MQTT.":                                             "Los eventos ya no se publicarán en MQTT.",
+	"Invalid MQTT broker '%s': %v":                                                            "Bróker MQTT no válido '%s': %v",
+	"Events can't be published to a topic with wildcards (+ or #)":                            "Los eventos no se pueden publicar en un tema con comodines (+ o #)",
+	"Use 'mqtt test' to check that events arrive.":                                            "Usa 'mqtt test' para comprobar que los eventos llegan.",
+	"No MQTT broker is set. Use 'mqtt <broker>' to set one.":                                  "No hay ningún bróker MQTT configurado. Usa 'mqtt <bróker>' para configurar uno.",
+	"Could not publish to the MQTT broker: %v":                                                "No se pudo publicar en el bróker MQTT: %v",
+	"Published a test event to topic '%s'.\n":                                                 "Se publicó un evento de prueba en el tema '%s'.\n",
+	"Events aren't published to MQTT. Use 'mqtt <broker> [--topic <topic>]' to publish them.": "Los eventos no se publican en MQTT. Usa 'mqtt <bróker> [--topic <tema>]' para publicarlos.",
+	"The MQTT broker '%s' is invalid: %v\n":                                                   "El bróker MQTT '%s' no es válido: %v\n",
+	"Catches, evolutions, and ribbons are published to topic '%s' on %s.\n":                   "Las capturas, evoluciones y cintas se publican en el tema '%s' de %s.\n",
+	"Warning: Could not publish the '%s' event to MQTT: %v\n":                                 "Aviso: No se pudo publicar el evento '%s' en MQTT: %v\n",
+
+	// Export
+	"Export your collection to a file, like a calendar of your catches or a Showdown team":                      "Exporta tu colección a un archivo, como un calendario de tus capturas o un equipo de Showdown",
+	"Usage: export [--select] ical <file> | export [--select] showdown <file>":                                  "Uso: export [--select] ical <archivo> | export [--select] showdown <archivo>",
+	"Your party is empty. Take Pokémon out of storage with 'box remove <pokemon>' first":                        "Tu equipo está vacío. Saca Pokémon del almacenamiento con 'box remove <pokémon>' primero",
+	"Wrote a team of %d Pokémon to %s. Paste it into Pokémon Showdown's teambuilder with 'Import from text'.\n": "Se escribió un equipo de %d Pokémon en %s. Pégalo en el constructor de equipos de Pokémon Showdown con 'Import from text'.\n",
+	"Showdown teams have at most %d Pokémon, so %d from your party were left out.\n":                            "Los equipos de Showdown tienen como máximo %d Pokémon, así que se omitieron %d de tu equipo.\n",
+	"Unknown export format '%s'. %s": "Formato de exportación desconocido '%s'. %s",
+	"Could not write file '%s'":      "No se pudo escribir el archivo '%s'",
+	"Wrote %d catches to %s. Import it into a calendar app to browse your collecting history.\n": "Se escribieron %d capturas en %s. Impórtalo en una aplicación de calendario para repasar tu historial de capturas.\n",
+	"%d Pokémon caught before catch dates were recorded were left out.\n":                        "Se omitieron %d Pokémon atrapados antes de que se registraran las fechas de captura.\n",
+	"Pokémon catches": "Capturas Pokémon",
+	"Caught %s":       "%s atrapado",
+
+	// Import
+	"Import Pokémon from a Pokémon Showdown team or a CSV file":                                  "Importa Pokémon de un equipo de Pokémon Showdown o de un archivo CSV",
+	"Usage: import showdown <file> | import csv <file> --mapping <spec>":                         "Uso: import showdown <archivo> | import csv <archivo> --mapping <especificación>",
+	"Unknown import format '%s'. %s":                                                             "Formato de importación desconocido '%s'. %s",
+	"CSV imports need a mapping, e.g. import csv pokemon.csv --mapping name=Species,level=Level": "Las importaciones CSV necesitan una correspondencia, p. ej. import csv pokemon.csv --mapping name=Especie,level=Nivel",
+	"Could not open file '%s'":                                                                   "No se pudo abrir el archivo '%s'",
+	"No Pokémon found in the Showdown export":                                                    "No se encontraron Pokémon en la exportación de Showdown",
+	"Nickname: %s":       "Mote: %s",
+	"Invalid level '%s'": "Nivel no válido '%s'",
+	"Invalid mapping '%s'. Use field=column, e.g. name=Species":           "Correspondencia no válida '%s'. Usa campo=columna, p. ej. name=Especie",
+	"Unknown field '%s'. Fields: %s":                                      "Campo desconocido '%s'. Campos: %s",
+	"The mapping must set the name field, e.g. name=Species":              "La correspondencia debe indicar el campo name, p. ej. name=Especie",
+	"Column %s is out of range: the file has %d columns":                  "La columna %s está fuera de rango: el archivo tiene %d columnas",
+	"No column named '%s'. Columns: %s":                                   "No hay ninguna columna llamada '%s'. Columnas: %s",
+	"The CSV file is empty":                                               "El archivo CSV está vacío",
+	"Could not read the CSV file":                                         "No se pudo leer el archivo CSV",
+	"Invalid date '%s'. Use YYYY-MM-DD":                                   "Fecha no válida '%s'. Usa AAAA-MM-DD",
+	"Line %d: %s":                                                         "Línea %d: %s",
+	"Line %d: Skipped %s: %s\n":                                           "Línea %d: se omitió %s: %s\n",
+	"%s is already in your Pokédex":                                       "%s ya está en tu Pokédex",
+	"Imported %s (level %d).\n":                                           "%s importado (nivel %d).\n",
+	"  Left out moves it can't learn or that don't fit its moveset: %s\n": "  Se omitieron los movimientos que no puede aprender o que no caben en su conjunto de movimientos: %s\n",
+	"Imported %d of %d Pokémon from %s.\n":                                "Se importaron %d de %d Pokémon desde %s.\n",
+
+	// Reports
+	"Write a Markdown report of your collection, or a digest of the last week to show, save, or email":                   "Escribe un informe en Markdown de tu colección, o un resumen de la última semana para mostrarlo, guardarlo o enviarlo por correo",
+	"Usage: report md <file> | weekly [--out <file> | --email] | email [<server> --from <address> --to <address> | off]": "Uso: report md <archivo> | weekly [--out <archivo> | --email] | email [<servidor> --from <dirección> --to <dirección> | off]",
+	"Unknown report format '%s'. %s":       "Formato de informe desconocido '%s'. %s",
+	"Report of %d Pokémon written to %s\n": "Informe de %d Pokémon escrito en %s\n",
+	"My Pokédex":                           "Mi Pokédex",
+	"Generated by pokedexcli on %s":        "Generado por pokedexcli el %s",
+	"Summary":                              "Resumen",
+	"Pokémon caught":                       "Pokémon atrapados",
+	"Pokémon seen":                         "Pokémon vistos",
+	"Evolved since being caught":           "Evolucionados desde su captura",
+	"Most common type":                     "Tipo más común",
+	"Favorites":                            "Favoritos",
+	"Move Pokémon into a box named '%s' to list them here.": "Mueve Pokémon a una caja llamada '%s' para que aparezcan aquí.",
+	"Highlights":               "Destacados",
+	"Ribbons":                  "Cintas",
+	"Collection by generation": "Colección por generación",
+	"Caught in":                "Atrapado en",
+	"Caught on":                "Atrapado el",
+	"Highest level":            "Nivel más alto",
+	"level %d":                 "nivel %d",
+	"Highest base stat total":  "Mayor total de estadísticas base",
+	"Most battles won":         "Más combates ganados",
+	"%d rounds":                "%d rondas",
+	"Most recent catch":        "Captura más reciente",
+	"No ribbons earned yet.":   "Aún no se ha ganado ninguna cinta.",
+	"Earned by":                "Ganada por",
+
+	// Trainer cards
+	"Show a Pokémon's battle card, or export a trainer card with your favorites, ribbons, and completion as HTML or PNG": "Muestra la ficha de combate de un Pokémon, o exporta una tarjeta de entrenador con tus favoritos, cintas y progreso en HTML o PNG",
+	"Usage: card <pokemon> | card export <file.html|file.png> [--name <name>]":                                           "Uso: card <pokemon> | card export <archivo.html|archivo.png> [--name <nombre>]",
+	"Cards can be written as .html or .png files, not '%s'":                                                              "Las tarjetas se pueden escribir como archivos .html o .png, no '%s'",
+	"Downloading sprites...":              "Descargando sprites...",
+	"Trainer card for %s written to %s\n": "Tarjeta de entrenador de %s escrita en %s\n",
+	"Trainer":                             "Entrenador",
+	"Trainer card":                        "Tarjeta de entrenador",
+	"Pokédex completion":                  "Progreso de la Pokédex",
+	"Seen":                                "Vistos",
+	"Move Pokémon into a box named '%s' to show them here.": "Mueve Pokémon a una caja llamada '%s' para que aparezcan aquí.",
+
+	// Backups
+	"Keep a history of your saves in git and push it to a remote":                                    "Guarda un historial de tus partidas en git y envíalo a un remoto",
+	"Usage: backup [git <remote> [--every <n>] | push | off]":                                        "Uso: backup [git <remoto> [--every <n>] | push | off]",
+	"Unknown backup command '%s'. %s":                                                                "Comando de copia de seguridad desconocido '%s'. %s",
+	"Saves will no longer be backed up. The history so far is kept in %s.\n":                         "Ya no se harán copias de seguridad de las partidas. El historial hasta ahora se conserva en %s.\n",
+	"The number of saves between pushes must be a positive number":                                   "El número de guardados entre envíos debe ser un número positivo",
+	"Every save will be backed up to %s. Use 'backup push' to push the backups to %s.\n":             "Cada guardado se copiará en %s. Usa 'backup push' para enviar las copias a %s.\n",
+	"Every save will be backed up to %s and pushed to %s after every %d saves.\n":                    "Cada guardado se copiará en %s y se enviará a %s cada %d guardados.\n",
+	"Invalid git remote '%s'":                                                                        "Remoto de git no válido '%s'",
+	"Only http:// and https:// remotes and repositories on disk are supported":                       "Solo se admiten remotos http:// y https:// y repositorios en disco",
+	"No backup remote is set. Use 'backup git <remote>' to set one.":                                 "No hay ningún remoto de copia de seguridad configurado. Usa 'backup git <remoto>' para configurar uno.",
+	"Could not find the save file":                                                                   "No se pudo encontrar el archivo de guardado",
+	"Could not back up the save file: %v":                                                            "No se pudo hacer una copia de seguridad del archivo de guardado: %v",
+	"Warning: Could not back up the save file: %v\n":                                                 "Advertencia: No se pudo hacer una copia de seguridad del archivo de guardado: %v\n",
+	"The remote %s has history that isn't in the local backup. Push to an empty repository instead.": "El remoto %s tiene un historial que no está en la copia local. Envía a un repositorio vacío en su lugar.",
+	"Could not push the backups to %s: %v":                                                           "No se pudieron enviar las copias de seguridad a %s: %v",
+	"The backups at %s are up to date.\n":                                                            "Las copias de seguridad en %s están al día.\n",
+	"Backups pushed to %s (%d new).\n":                                                               "Copias de seguridad enviadas a %s (%d nuevas).\n",
+	"Saves aren't backed up. Use 'backup git <remote>' to keep a history of them in git.":            "No se hacen copias de seguridad de las partidas. Usa 'backup git <remoto>' para guardar su historial en git.",
+	"Nothing has been backed up yet. Saves are backed up once the Pokédex is saved.":                 "Aún no hay copias de seguridad. Las partidas se copian cuando se guarda la Pokédex.",
+	"Could not read the backups: %v":                                                                 "No se pudieron leer las copias de seguridad: %v",
+	"Saves are backed up to %s and pushed to %s.\n":                                                  "Las partidas se copian en %s y se envían a %s.\n",
+	"Backups are pushed only with 'backup push'.":                                                    "Las copias solo se envían con 'backup push'.",
+	"Backups are pushed after every %d saves.\n":                                                     "Las copias se envían cada %d guardados.\n",
+	"%d saves in the history, the latest on %s. %d not pushed yet.\n":                                "%d guardados en el historial, el último el %s. %d aún sin enviar.\n",
+
+	// Unsaved changes
+	"List the changes that haven't been saved yet":                     "Muestra los cambios que aún no se han guardado",
+	"All changes are saved.":                                           "Todos los cambios están guardados.",
+	"There are unsaved changes, but none that change your collection.": "Hay cambios sin guardar, pero ninguno cambia tu colección.",
+	"These changes haven't been saved yet:":                            "Estos cambios aún no se han guardado:",
+	"Auto-save is off. Use 'save' to save them.":                       "El guardado automático está desactivado. Usa 'save' para guardarlos.",
+	"Could not read the save file":                                     "No se pudo leer el archivo de guardado",
+	"Auto-save is off, and %d changes haven't been saved:\n":           "El guardado automático está desactivado y hay %d cambios sin guardar:\n",
+	"Auto-save is off, and there are unsaved changes.":                 "El guardado automático está desactivado y hay cambios sin guardar.",
+	"Save them before exiting?":                                        "¿Guardarlos antes de salir?",
+	"Your changes were not saved.":                                     "Tus cambios no se han guardado.",
+
+	// Cancelling commands
+	"Cancelled.":              "Cancelado.",
+	"Exit the Pokédex?":       "¿Salir de la Pokédex?",
+	"Press Ctrl+C to cancel.": "Pulsa Ctrl+C para cancelar.",
+
+	// Type dex
+	"List every type with what it's strong and weak against": "Muestra todos los tipos con sus fortalezas y debilidades",
+	"Show a type's strengths, weaknesses, and pokemon":       "Muestra las fortalezas, debilidades y Pokémon de un tipo",
+	"Type":           "Tipo",
+	"Strong against": "Fuerte contra",
+	"Weak to":        "Débil contra",
+	"Use 'type <name>' for a type's full damage relations and its Pokémon.": "Usa 'type <nombre>' para ver las relaciones de daño de un tipo y sus Pokémon.",
+	"No type provided (e.g., 'type fire'). Use 'types' to list them":        "No se ha indicado ningún tipo (p. ej., 'type fire'). Usa 'types' para verlos",
+	"Attacking:":                 "Al atacar:",
+	"Defending:":                 "Al defender:",
+	"Super effective against":    "Muy eficaz contra",
+	"Not very effective against": "Poco eficaz contra",
+	"No effect on":               "Sin efecto contra",
+	"Resists":                    "Resiste",
+	"Immune to":                  "Inmune a",
+	"Pokémon with this type (%d caught of %d):\n": "Pokémon de este tipo (%d atrapados de %d):\n",
+	" ...and %d more\n":                           " ...y %d más\n",
+	"none":                                        "ninguno",
+
+	// Natures
+	"List every nature and the stats it raises and lowers": "Muestra todas las naturalezas y las estadísticas que suben y bajan",
+	"Nature":   "Naturaleza",
+	"Raises":   "Sube",
+	"Lowers":   "Baja",
+	"Likes":    "Le gusta",
+	"Dislikes": "No le gusta",
+	"Raised stats are 10%% higher and lowered stats 10%% lower than usual.\n": "Las estadísticas que suben son un 10 %% más altas, y las que bajan, un 10 %% más bajas.\n",
+
+	// Move details
+	"Show the type, power, accuracy, and effect of a move": "Muestra el tipo, la potencia, la precisión y el efecto de un movimiento",
+	"No move name provided (e.g., 'moveinfo thunderbolt')": "No se ha indicado ningún movimiento (p. ej., 'moveinfo thunderbolt')",
+	"Type: %s\n":            "Tipo: %s\n",
+	"Category: %s\n":        "Categoría: %s\n",
+	"Power: %s\n":           "Potencia: %s\n",
+	"Accuracy: %s\n":        "Precisión: %s\n",
+	"PP: %d\n":              "PP: %d\n",
+	"Priority: %s\n":        "Prioridad: %s\n",
+	"Effect chance: %d%%\n": "Probabilidad del efecto: %d%%\n",
+	"Effect: %s\n":          "Efecto: %s\n",
+	"Description: %s\n":     "Descripción: %s\n",
+	"Physical":              "Físico",
+	"Special":               "Especial",
+	"Status":                "Estado",
+
+	// Page size
+	"Set how many locations a map page lists (1-100)":                        "Elige cuántas ubicaciones muestra cada página del mapa (1-100)",
+	"Map pages list %d locations. Use 'pagesize <number>' to change this.\n": "Las páginas del mapa muestran %d ubicaciones. Usa 'pagesize <número>' para cambiarlo.\n",
+	"The page size must be a number from 1 to %d":                            "El tamaño de página debe ser un número del 1 al %d",
+	"Map pages will list %d locations.\n":                                    "Las páginas del mapa mostrarán %d ubicaciones.\n",
+
+	// Refresh
+	"Update a caught pokemon's data from the API, keeping your notes and progress":        "Actualiza los datos de un Pokémon capturado desde la API, conservando tus notas y tu progreso",
+	"%s's data is already up to date.\n":                                                  "Los datos de %s ya están al día.\n",
+	"Updated %s's data from the API. Your notes, box, moveset, and progress were kept.\n": "Se actualizaron los datos de %s desde la API. Se conservaron tus notas, caja, movimientos y progreso.\n",
+	"Refreshing the data for %d Pokémon...\n":                                             "Actualizando los datos de %d Pokémon...\n",
+	"Refresh cancelled after %d of %d Pokémon. The ones already refreshed were kept.\n":   "Actualización cancelada tras %d de %d Pokémon. Se conservaron los que ya se habían actualizado.\n",
+	"No changes: %d Pokémon were already up to date.\n":                                   "Sin cambios: %d Pokémon ya estaban al día.\n",
+	"Updated %d of %d Pokémon. Their notes, boxes, movesets, and progress were kept.\n":   "Se actualizaron %d de %d Pokémon. Se conservaron sus notas, cajas, movimientos y progreso.\n",
+	"Couldn't refresh %d Pokémon; they were left as they were:\n":                         "No se pudieron actualizar %d Pokémon; se dejaron como estaban:\n",
+	"Field": "Campo",
+
+	// Doctor
+	"Check your pokedex for inconsistent or incomplete entries and offer to fix them": "Revisa tu Pokédex en busca de entradas incoherentes o incompletas y ofrece corregirlas",
+	"Usage: doctor": "Uso: doctor",
+	"No problems found in %d Pokédex entries.\n": "No se encontraron problemas en %d entradas de la Pokédex.\n",
+	"Problem":                                     "Problema",
+	"Fix":                                         "Corrección",
+	"Fix %d problems?":                            "¿Corregir %d problemas?",
+	"Nothing was changed.":                        "No se cambió nada.",
+	"Fixed %d of %d problems.\n":                  "Se corrigieron %d de %d problemas.\n",
+	"These problems couldn't be fixed:":           "Estos problemas no se pudieron corregir:",
+	"Download its data again":                     "Descargar sus datos de nuevo",
+	"Store it as %s":                              "Guardarlo como %s",
+	"Remove the entry":                            "Eliminar la entrada",
+	"Correct the entry":                           "Corregir la entrada",
+	"Stored without a name":                       "Guardado sin nombre",
+	"Its data is for %s":                          "Sus datos son de %s",
+	"Missing %s":                                  "Falta: %s",
+	"Its moveset has moves it can't learn: %s":    "Sus movimientos incluyen algunos que no puede aprender: %s",
+	"Its moveset has %d moves (at most %d)":       "Tiene %d movimientos activos (como máximo %d)",
+	"Level %d with %d experience is out of range": "El nivel %d con %d de experiencia está fuera de rango",
+	"Its earlier form has no name, so it can't be devolved": "Su forma anterior no tiene nombre, así que no puede volver a ella",
+	"(no name)": "(sin nombre)",
+
+	// Usage counts
+	"Count the commands you run (opt-in, kept on this computer) and show the counts": "Cuenta los comandos que usas (si lo activas, sin salir de este equipo) y muestra los recuentos",
+	"Usage: usage [on | off | clear | report | endpoint <url|off>]":                  "Uso: usage [on | off | clear | report | endpoint <url|off>]",
+	"Usage counting is off. Use 'usage on' to count the commands you run.":           "El recuento de uso está desactivado. Usa 'usage on' para contar los comandos que ejecutas.",
+	"Only command names are counted, and the counts stay on this computer.":          "Solo se cuentan los nombres de los comandos, y los recuentos no salen de este equipo.",
+	"No commands have been counted yet.":                                             "Todavía no se ha contado ningún comando.",
+	"Command":                                                                        "Comando",
+	"Times run":                                                                      "Veces",
+	"Counted since %s.\n":                                                            "Contando desde el %s.\n",
+	"The commands you run will be counted. Only their names are counted, and the counts stay on this computer.": "Se contarán los comandos que ejecutes. Solo se cuentan sus nombres, y los recuentos no salen de este equipo.",
+	"See them with 'usage'.": "Consúltalos con 'usage'.",
+	"Commands will no longer be counted. Use 'usage clear' to delete the counts so far.":   "Los comandos ya no se contarán. Usa 'usage clear' para borrar los recuentos hasta ahora.",
+	"Could not delete the usage counts: %v":                                                "No se pudieron borrar los recuentos de uso: %v",
+	"Deleted the usage counts.":                                                            "Se borraron los recuentos de uso.",
+	"Invalid reporting endpoint '%s' (use an http or https URL)":                           "Destino de informes no válido '%s' (usa una URL http o https)",
+	"The reporting endpoint was removed. Usage counts won't be sent anywhere.":             "Se quitó el destino de informes. Los recuentos de uso no se enviarán a ningún sitio.",
+	"'usage report' will send the usage counts to %s. Nothing is sent until you run it.\n": "'usage report' enviará los recuentos de uso a %s. No se envía nada hasta que lo ejecutes.\n",
+	"No reporting endpoint is set. Use 'usage endpoint <url>' to set one.":                 "No hay ningún destino de informes. Usa 'usage endpoint <url>' para indicar uno.",
+	"Could not send the usage counts to %s: %v":                                            "No se pudieron enviar los recuentos de uso a %s: %v",
+	"Sent the counts for %d commands to %s.\n":                                             "Se enviaron los recuentos de %d comandos a %s.\n",
+
+	// Data changes
+	"Base stats: %d in total (unchanged)\n": "Estadísticas base: %d en total (sin cambios)\n",
+	"Before":                                "Antes",
+	"After":                                 "Después",
+	"Height":                                "Altura",
+	"Weight":                                "Peso",
+	"Base experience":                       "Experiencia base",
+	"%s: changes from %s to %s\n":           "%s: cambia de %s a %s\n",
+	"Abilities gained: %s\n":                "Habilidades nuevas: %s\n",
+	"Abilities lost: %s\n":                  "Habilidades perdidas: %s\n",
+	"Moves it can now learn: %s\n":          "Movimientos que ahora puede aprender: %s\n",
+	"Moves it can no longer learn: %s\n":    "Movimientos que ya no puede aprender: %s\n",
+	"%s, and %d more":                       "%s y %d más",
+
+	// Stat calculator
+	"Work out a stat from its base stat, IVs, EVs, level, and nature (e.g. calcstat pikachu attack 50)":                                        "Calcula una estadística a partir de su valor base, IV, EV, nivel y naturaleza (p. ej. calcstat pikachu attack 50)",
+	"Usage: calcstat <base> <iv> <ev> <level> [+|-] or calcstat <pokemon> <stat> <level> [iv] [ev] [nature] (e.g. calcstat pikachu attack 50)": "Uso: calcstat <base> <iv> <ev> <nivel> [+|-] o calcstat <pokémon> <estadística> <nivel> [iv] [ev] [naturaleza] (p. ej. calcstat pikachu attack 50)",
+	"Neutral": "Neutra",
+	"Without a stat, the nature must be + (raises the stat) or - (lowers it), not '%s'": "Sin una estadística, la naturaleza debe ser + (la sube) o - (la baja), no '%s'",
+	"Base %d with %d IVs and %d EVs at level %d:\n":                                     "Base %d con %d IV y %d EV al nivel %d:\n",
+	"Value":       "Valor",
+	"Other stats": "Otras estadísticas",
+	"Unknown stat '%s' (use hp, attack, defense, special-attack, special-defense, or speed)": "Estadística desconocida '%s' (usa hp, attack, defense, special-attack, special-defense o speed)",
+	"%s's %s (base %d) at level %d:\n":                                           "%[2]s de %[1]s (base %[3]d) al nivel %[4]d:\n",
+	"%s's %s (base %d) at level %d with %d IVs and %d EVs: %d\n":                 "%[2]s de %[1]s (base %[3]d) al nivel %[4]d con %[5]d IV y %[6]d EV: %[7]d\n",
+	"%s's %s (base %d) at level %d with %d IVs and %d EVs, %s nature (%s): %d\n": "%[2]s de %[1]s (base %[3]d) al nivel %[4]d con %[5]d IV y %[6]d EV, naturaleza %[7]s (%[8]s): %[9]d\n",
+	"IVs":       "IV",
+	"EVs":       "EV",
+	"base stat": "la estadística base",
+	"level":     "nivel",
+	"The %s must be a number from %d to %d, not '%s'": "El valor de %s debe ser un número del %d al %d, no '%s'",
+
+	// EV training
+	"%s gained EVs: %s\n":                                   "%s ganó EV: %s\n",
+	"%s has reached the limit of %d EVs.\n":                 "%s alcanzó el límite de %d EV.\n",
+	"EVs: %s (%d/%d)\n":                                     "EV: %s (%d/%d)\n",
+	"Its EVs are over the limits (%d in a stat, %d in all)": "Sus EV superan los límites (%d en una estadística, %d en total)",
+
+	// Friendship
+	"Pet a caught pokemon to raise its happiness (once a day)":                     "Acaricia a un pokémon capturado para aumentar su felicidad (una vez al día)",
+	"Play with a caught pokemon to raise its happiness (once a day)":               "Juega con un pokémon capturado para aumentar su felicidad (una vez al día)",
+	"You've already petted %s today. Try again tomorrow":                           "Ya acariciaste a %s hoy. Vuelve a intentarlo mañana",
+	"You've already played with %s today. Try again tomorrow":                      "Ya jugaste con %s hoy. Vuelve a intentarlo mañana",
+	"%s squeaks and nuzzles your hand.":                                            "%s chilla y frota el hocico contra tu mano.",
+	"%s scurries around you in circles.":                                           "%s corretea a tu alrededor en círculos.",
+	"%s wags its tail and licks your hand.":                                        "%s mueve la cola y te lame la mano.",
+	"%s chases the stick you throw and brings it right back.":                      "%s persigue el palo que lanzas y te lo trae enseguida.",
+	"%s purrs and curls up in your lap.":                                           "%s ronronea y se acurruca en tu regazo.",
+	"%s pounces on the string you dangle.":                                         "%s salta sobre el cordel que le balanceas.",
+	"%s ruffles its feathers and coos.":                                            "%s eriza las plumas y arrulla.",
+	"%s flutters around your head.":                                                "%s revolotea alrededor de tu cabeza.",
+	"%s pokes its head out of its shell to be patted.":                             "%s asoma la cabeza del caparazón para que lo acaricies.",
+	"%s squirts water at you from its shell.":                                      "%s te lanza agua desde su caparazón.",
+	"%s's flame flickers warmly as you pet it.":                                    "La llama de %s parpadea cálidamente mientras lo acaricias.",
+	"%s puffs little embers into the air as it plays.":                             "%s lanza pequeñas ascuas al aire mientras juega.",
+	"%s splashes you happily.":                                                     "%s te salpica alegremente.",
+	"%s sprays a fountain of water into the air.":                                  "%s lanza un chorro de agua al aire.",
+	"%s's fur crackles with static as you pet it.":                                 "El pelaje de %s chisporrotea de estática mientras lo acaricias.",
+	"%s sends out happy little sparks as it runs around.":                          "%s suelta chispitas de alegría mientras corretea.",
+	"%s sways contentedly, smelling faintly of fresh leaves.":                      "%s se balancea contento, con un leve olor a hojas frescas.",
+	"%s rolls around in the grass.":                                                "%s rueda por la hierba.",
+	"Your hand passes right through %s, but it seems to like the thought.":         "Tu mano atraviesa a %s, pero parece agradecer el gesto.",
+	"%s plays hide-and-seek, vanishing and reappearing behind you.":                "%s juega al escondite, desapareciendo y reapareciendo a tu espalda.",
+	"%s closes its eyes and hums contentedly.":                                     "%s cierra los ojos y murmura contento.",
+	"%s floats your things around in the air to amuse you.":                        "%s hace flotar tus cosas en el aire para divertirte.",
+	"%s is hard to the touch, but it leans into your hand.":                        "%s es duro al tacto, pero se apoya en tu mano.",
+	"%s rolls around you, rumbling happily.":                                       "%s rueda a tu alrededor, retumbando feliz.",
+	"%s lowers its head so you can scratch it.":                                    "%s baja la cabeza para que se la rasques.",
+	"%s lets out a happy roar as it chases you.":                                   "%s suelta un rugido alegre mientras te persigue.",
+	"%s looks happy to be petted.":                                                 "%s parece feliz de que lo acaricies.",
+	"%s plays with you happily.":                                                   "%s juega contigo alegremente.",
+	"%s gained %d happiness.\n":                                                    "%s ganó %d de felicidad.\n",
+	"%s couldn't be any happier.\n":                                                "%s no podría estar más feliz.\n",
+	"Friendship: %d/%d\n":                                                          "Amistad: %d/%d\n",
+	"%s is now friendly enough to evolve into %s! Use 'evolve %s' to evolve it.\n": "¡%s ya tiene suficiente amistad para evolucionar a %s! Usa 'evolve %s' para que evolucione.\n",
+
+	// Party condition
+	"Healthy":                               "Sano",
+	"Fainted":                               "Debilitado",
+	"Your party is empty.":                  "Tu equipo está vacío.",
+	"Your party is already at full health.": "Tu equipo ya está en plena forma.",
+	"Your Pokémon are back to full health (%d healed). We hope to see you again!\n": "Tus Pokémon recuperaron toda su salud (%d curados). ¡Esperamos volver a verte!\n",
+	"[%d of %d can battle] ": "[%d de %d pueden combatir] ",
+	"All your Pokémon have fainted. Heal them with 'party heal' first.":                        "Todos tus Pokémon están debilitados. Cúralos primero con 'party heal'.",
+	"These Pokémon have fainted and can't battle until they're healed with 'party heal': %s\n": "Estos Pokémon están debilitados y no pueden combatir hasta que los cures con 'party heal': %s\n",
+
+	// Location heatmap
+	"Show the locations you've explored, encountered, and caught the most in (e.g. heatmap 5)": "Muestra los lugares donde más has explorado, encontrado y atrapado (p. ej. heatmap 5)",
+	"Usage: heatmap [count]": "Uso: heatmap [cantidad]",
+	"You haven't visited any locations yet. Use 'explore' or 'encounter' to look around.": "Aún no has visitado ningún lugar. Usa 'explore' o 'encounter' para echar un vistazo.",
+	"Your most active locations:": "Los lugares donde más actividad has tenido:",
+	"Location":                    "Lugar",
+	"Explored":                    "Exploraciones",
+	"Encounters":                  "Encuentros",
+	"Catches":                     "Capturas",
+	"Heat":                        "Actividad",
+
+	// Pokédex growth
+	"Chart the size of your Pokédex over time, with milestones marked": "Muestra en un gráfico el tamaño de tu Pokédex a lo largo del tiempo, con los hitos marcados",
+	"There's no growth to chart yet. Catch some Pokémon first!":        "Aún no hay crecimiento que mostrar. ¡Atrapa algunos Pokémon primero!",
+	"Pokédex size from %s to %s:\n":                                    "Tamaño de la Pokédex del %s al %s:\n",
+	"Started with %d, now %d. Peak: %d on %s.\n":                       "Empezó con %d, ahora %d. Máximo: %d el %s.\n",
+	"Milestones:":          "Hitos:",
+	"%d. %s: %d Pokémon\n": "%d. %s: %d Pokémon\n",
+
+	// Mystery gift
+	"Receive this week's mystery gift: an item or an uncommon pokemon":              "Recibe el regalo misterioso de esta semana: un objeto o un pokemon poco común",
+	"You've already received this week's mystery gift. The next one arrives on %s.": "Ya recibiste el regalo misterioso de esta semana. El próximo llega el %s.",
+	"Trainer ID %05d, mystery gift for week %d of %d:\n":                            "ID de entrenador %05d, regalo misterioso de la semana %d de %d:\n",
+	"A festive gift for %s!\n":                                                      "¡Un regalo festivo por %s!\n",
+	"The gift was %s, but you already have one, so here's something else.\n":        "El regalo era %s, pero ya tienes uno, así que aquí tienes otra cosa.\n",
+	"Valentine's Day":     "San Valentín",
+	"Halloween":           "Halloween",
+	"the winter holidays": "las fiestas de invierno",
+
+	// Seasonal events
+	"List the seasonal events on now and the pokemon that turn up more often during them": "Lista los eventos de temporada activos y los pokemon que aparecen con más frecuencia durante ellos",
+	"No events are on right now.":     "No hay eventos activos en este momento.",
+	"Events on now:":                  "Eventos activos:",
+	"%s (until %s)\n":                 "%s (hasta el %s)\n",
+	"  More common in the wild: %s\n": "  Más frecuentes en estado salvaje: %s\n",
+	"Next event: %s, from %s.\n":      "Próximo evento: %s, desde el %s.\n",
+	"%s-type Pokémon (x%d)":           "Pokémon de tipo %s (x%d)",
+
+	// Challenges
+	"Take on challenges you define in JSON files, and track your progress": "Acepta desafíos que defines en archivos JSON y sigue tu progreso",
+	"Challenge":       "Desafío",
+	"Goal":            "Objetivo",
+	"Progress":        "Progreso",
+	"Not started":     "Sin empezar",
+	"Completed on %s": "Completado el %s",
+	"Failed (%d/%d)":  "Fallido (%d/%d)",
+	"%d/%d, until %s": "%d/%d, hasta el %s",
+	"Usage: challenge [list | start <id> | abandon <id> | check]":                             "Uso: challenge [list | start <id> | abandon <id> | check]",
+	"Could not read the challenge directory %s: %v":                                           "No se pudo leer el directorio de desafíos %s: %v",
+	"You have no challenges yet. Add challenge files (%s) to %s.\n":                           "Aún no tienes desafíos. Añade archivos de desafío (%s) a %s.\n",
+	"See the README for the format, and use 'challenge check' to check them.":                 "Consulta el README para ver el formato, y usa 'challenge check' para revisarlos.",
+	"%d challenge files have problems and were skipped. Use 'challenge check' to see them.\n": "%d archivos de desafío tienen problemas y se omitieron. Usa 'challenge check' para verlos.\n",
+	"There's no challenge '%s'. Use 'challenge list' to see them":                             "No hay ningún desafío '%s'. Usa 'challenge list' para verlos",
+	"You've already completed %s":                                                             "Ya completaste %s",
+	"You've already started %s":                                                               "Ya empezaste %s",
+	"Challenge started: %s\n":                                                                 "Desafío empezado: %s\n",
+	"Goal: %s\n":                                                                              "Objetivo: %s\n",
+	"You have until %s.\n":                                                                    "Tienes hasta el %s.\n",
+	"You haven't started a challenge '%s'":                                                    "No has empezado ningún desafío '%s'",
+	"Abandoned the challenge '%s'.\n":                                                         "Abandonaste el desafío '%s'.\n",
+	"There are no challenge files (%s) in %s.\n":                                              "No hay archivos de desafío (%s) en %s.\n",
+	"%d challenges are valid.\n":                                                              "%d desafíos son válidos.\n",
+	"%d challenge files have problems:\n":                                                     "%d archivos de desafío tienen problemas:\n",
+	"Fix the problems in %s":                                                                  "Corrige los problemas en %s",
+	"See %d new Pokémon":                                                                      "Ve %d Pokémon nuevos",
+	"Catch %d %s-type Pokémon":                                                                "Atrapa %d Pokémon de tipo %s",
+	"Catch %d Pokémon":                                                                        "Atrapa %d Pokémon",
+	"%s in %s":                                                                                "%s en %s",
+	"%s within %d days":                                                                       "%s en %d días",
+	"Challenge complete: %s!\n":                                                               "¡Desafío completado: %s!\n",
+
+	// Exploring every location
+	"Exploring %d locations...\n":      "Explorando %d ubicaciones...\n",
+	"Not caught yet":                   "Sin atrapar",
+	"No Pokémon":                       "Ningún Pokémon",
+	"All caught":                       "Todos atrapados",
+	"Couldn't explore %d locations:\n": "No se pudieron explorar %d ubicaciones:\n",
+	"Exploring cancelled after %d of %d locations.\n":       "Exploración cancelada tras %d de %d ubicaciones.\n",
+	"Use 'explore <location number>' to go to one of them.": "Usa 'explore <número de ubicación>' para ir a una de ellas.",
+
+	// Numbered lists
+	"No numbered list available, please run a command that lists items first, such as 'explore' or 'pokedex'": "No hay ninguna lista numerada, ejecuta primero un comando que liste elementos, como 'explore' o 'pokedex'",
+	"The last list, from '%s', isn't a list of Pokémon":                                                       "La última lista, de '%s', no es una lista de Pokémon",
+	"The last list, from '%s', isn't a list of moves":                                                         "La última lista, de '%s', no es una lista de movimientos",
+	"Number %d isn't in the list from '%s' (valid range: 1-%d)":                                               "El número %d no está en la lista de '%s' (rango válido: 1-%d)",
+
+	// Copying to the clipboard
+	"Copy a summary of a caught pokemon to the clipboard, as text or JSON":                          "Copia un resumen de un pokemon atrapado al portapapeles, como texto o JSON",
+	"Could not encode the Pokémon as JSON":                                                          "No se pudo codificar el Pokémon como JSON",
+	"No clipboard tool was found (such as xclip, xsel, or wl-copy), so here it is to copy by hand:": "No se encontró ninguna herramienta de portapapeles (como xclip, xsel o wl-copy), así que aquí lo tienes para copiarlo a mano:",
+	"Could not copy %s to the clipboard":                                                            "No se pudo copiar %s al portapapeles",
+	"Copied %s to the clipboard.\n":                                                                 "Se copió %s al portapapeles.\n",
+	"Base stats: %s (total %d)\n":                                                                   "Estadísticas base: %s (total %d)\n",
+	"Moves: %s\n":                                                                                   "Movimientos: %s\n",
+	"Note: %s\n":                                                                                    "Nota: %s\n",
+
+	// Posters
+	"Write the whole national pokedex as a grid to print, with caught pokemon filled in (.html or text)": "Escribe toda la pokedex nacional como una cuadrícula para imprimir, con los pokemon atrapados rellenos (.html o texto)",
+	"Usage: poster <file> (e.g., 'poster pokedex.html' or 'poster pokedex.txt')":                         "Uso: poster <archivo> (p. ej., 'poster pokedex.html' o 'poster pokedex.txt')",
+	"Fetching the species of every generation...":                                                        "Obteniendo las especies de todas las generaciones...",
+	"Poster of %d species (%d caught) written to %s\n":                                                   "Póster de %d especies (%d atrapadas) escrito en %s\n",
+	"National Pokédex": "Pokédex Nacional",
+	"Not seen":         "Sin ver",
+	"Seen %d, caught %d of %d. [x] caught, [o] seen, [ ] not seen\n": "Vistos %d, atrapados %d de %d. [x] atrapado, [o] visto, [ ] sin ver\n",
+
+	// Weekly reports
+	"Pokédex weekly report, %s to %s": "Informe semanal de la Pokédex, del %s al %s",
+	"No Pokémon caught.":              "No se atrapó ningún Pokémon.",
+	"Caught (%d):":                    "Atrapados (%d):",
+	"%s, in %s":                       "%s, en %s",
+	"No Pokémon evolved.":             "Ningún Pokémon evolucionó.",
+	"Evolved (%d):":                   "Evolucionados (%d):",
+	"%s into %s, %s":                  "%s en %s, %s",
+	"No challenges completed.":        "No se completó ningún desafío.",
+	"Challenges completed (%d):":      "Desafíos completados (%d):",
+	"New Pokémon seen: %d":            "Pokémon nuevos vistos: %d",
+	"Weekly report written to %s\n":   "Informe semanal escrito en %s\n",
+	"No SMTP server is set. Use 'report email <server> --from <address> --to <address>' to set one.": "No hay ningún servidor SMTP configurado. Usa 'report email <servidor> --from <dirección> --to <dirección>' para configurar uno.",
+	"Could not email the weekly report: %v":                                                          "No se pudo enviar el informe semanal por correo: %v",
+	"Weekly report emailed to %s.\n":                                                                 "Informe semanal enviado por correo a %s.\n",
+	"Weekly reports will no longer be emailed.":                                                      "Los informes semanales ya no se enviarán por correo.",
+	"Invalid SMTP server '%s': %v":                                                                   "Servidor SMTP no válido '%s': %v",
+	"Invalid email address '%s'":                                                                     "Dirección de correo no válida '%s'",
+	"Use 'report weekly --email' to send one.":                                                       "Usa 'report weekly --email' para enviar uno.",
+	"Weekly reports aren't emailed. Use 'report email <server> --from <address> --to <address>' to set up email.": "Los informes semanales no se envían por correo. Usa 'report email <servidor> --from <dirección> --to <dirección>' para configurar el correo.",
+	"The SMTP server '%s' is invalid: %v\n":                  "El servidor SMTP '%s' no es válido: %v\n",
+	"Weekly reports are emailed from %s to %s through %s.\n": "Los informes semanales se envían por correo de %s a %s a través de %s.\n",
+
+	// Catch rate presets
+	"Choose how forgiving catching is with rare Pokémon":                                                      "Elige cuánto se facilita atrapar Pokémon raros",
+	"Usage: catchrate [casual | authentic | custom [--rare <0-255>] [--boost <0-100>] [--masterball on|off]]": "Uso: catchrate [casual | authentic | custom [--rare <0-255>] [--boost <0-100>] [--masterball on|off]]",
+	"The value of %s must be a whole number from 0 to %d":                                                     "El valor de %s debe ser un número entero de 0 a %d",
+	"Catch rate preset: %s\n":                                                "Ajuste de captura: %s\n",
+	" - Pokémon with a capture rate below %d are rare\n":                     " - Los Pokémon con un ratio de captura menor que %d son raros\n",
+	" - Rare Pokémon are caught at their own capture rate":                   " - Los Pokémon raros se atrapan con su propio ratio de captura",
+	" - Rare Pokémon have their capture rate raised %d%% of the way to %d\n": " - El ratio de captura de los Pokémon raros se acerca un %d%% a %d\n",
+	" - A Masterball turns up when you catch a rare Pokémon":                 " - Aparece una Master Ball cuando atrapas un Pokémon raro",
+	" - No Masterball turns up when you catch a rare Pokémon":                " - No aparece ninguna Master Ball cuando atrapas un Pokémon raro",
+
+	// Developer commands
+	"Add a pokemon to your Pokédex without catching it (debug mode only)":     "Añade un Pokémon a tu Pokédex sin atraparlo (solo en modo depuración)",
+	"give is a developer command. Turn on debug mode with 'debug' to use it.": "give es un comando para desarrolladores. Activa el modo depuración con 'debug' para usarlo.",
+	"Gave you %s.\n": "Te dieron a %s.\n",
+
+	// Sandbox
+	"Try out commands on a copy of your Pokédex, then discard or keep the changes":              "Prueba comandos en una copia de tu Pokédex y luego descarta o conserva los cambios",
+	"Usage: sandbox [on | off | commit]":                                                        "Uso: sandbox [on | off | commit]",
+	"The sandbox isn't on. Use 'sandbox on' to start one.":                                      "El entorno de pruebas no está activado. Usa 'sandbox on' para empezar uno.",
+	"The sandbox is already on. Use 'sandbox off' or 'sandbox commit' to leave it.":             "El entorno de pruebas ya está activado. Usa 'sandbox off' o 'sandbox commit' para salir de él.",
+	"Could not copy the current state: %v":                                                      "No se pudo copiar el estado actual: %v",
+	"Sandbox on: try anything you like. Nothing you do now will be saved.":                      "Entorno de pruebas activado: prueba lo que quieras. Nada de lo que hagas ahora se guardará.",
+	"Use 'sandbox off' to go back to how things were, or 'sandbox commit' to keep the changes.": "Usa 'sandbox off' para volver a como estaba todo, o 'sandbox commit' para conservar los cambios.",
+	"Sandbox off: discarded %d changes.\n":                                                      "Entorno de pruebas desactivado: se descartaron %d cambios.\n",
+	"Sandbox committed: kept %d changes.\n":                                                     "Entorno de pruebas confirmado: se conservaron %d cambios.\n",
+	"The sandbox is off. Use 'sandbox on' to try things out without saving them.":               "El entorno de pruebas está desactivado. Usa 'sandbox on' para probar cosas sin guardarlas.",
+	"The sandbox is on. Nothing has changed in it yet.":                                         "El entorno de pruebas está activado. Todavía no ha cambiado nada en él.",
+	"The sandbox is on. These changes have been made in it:":                                    "El entorno de pruebas está activado. Se han hecho estos cambios en él:",
+	"The changes made in the sandbox were discarded.":                                           "Se descartaron los cambios hechos en el entorno de pruebas.",
+	"[sandbox] ": "[pruebas] ",
+
+	// Network settings
+	"Invalid proxy '%s'. Use a URL such as http://proxy.example.com:3128":                              "Proxy '%s' no válido. Usa una URL como http://proxy.example.com:3128",
+	"Could not use the certificate bundle %s: %v":                                                      "No se pudo usar el paquete de certificados %s: %v",
+	"Warning: --insecure is on, so the API's certificate isn't verified. Only use it for development.": "Aviso: --insecure está activado, así que no se verifica el certificado de la API. Úsalo solo para desarrollo.",
+
+	// Search
+	"Usage: search [<name>] [--type <type>] [--gen <generation>]": "Uso: search [<nombre>] [--type <tipo>] [--gen <generación>]",
+	"No Pokémon match your search.":                               "Ningún Pokémon coincide con tu búsqueda.",
+	"Found %d Pokémon:\n":                                         "Se encontraron %d Pokémon:\n",
+	"No.":                                                         "N.º",
+	"Find pokemon by name, type, and generation":                  "Busca pokemon por nombre, tipo y generación",
+
+	// Where
+	"Show where you are and the state of the session: map page, location, filters, modes, and unsaved changes": "Muestra dónde estás y el estado de la sesión: página del mapa, ubicación, filtros, modos y cambios sin guardar",
+	"Same as 'where'": "Igual que 'where'",
+	"Map: no page viewed yet. Use 'map' to start.": "Mapa: aún no has visto ninguna página. Usa 'map' para empezar.",
+	"Map: page %d, with %d location areas":         "Mapa: página %d, con %d áreas",
+	"Map: %d location areas":                       "Mapa: %d áreas",
+	"Location: %s (%d Pokémon found)":              "Ubicación: %s (%d Pokémon encontrados)",
+	"Location: none explored yet":                  "Ubicación: aún no has explorado ninguna",
+	"Bookmarks: %d":                                "Marcadores: %d",
+	"catch rates %s":                               "tasas de captura %s",
+	"units %s":                                     "unidades %s",
+	"moves from %s":                                "movimientos de %s",
+	"map sorted by %s":                             "mapa ordenado por %s",
+	"Filters: %s":                                  "Filtros: %s",
+	"Last list: %d items from '%s' (refer to them as #1 to #%d)": "Última lista: %d elementos de '%s' (refiérete a ellos como #1 a #%d)",
+	"Lure: %s":                        "Cebo: %s",
+	"Rental team: %s":                 "Equipo de alquiler: %s",
+	"recording API responses to %s":   "grabando respuestas de la API en %s",
+	"offline, using fixtures from %s": "sin conexión, usando datos de prueba de %s",
+	"online":                          "en línea",
+	"complete dataset":                "conjunto de datos completo",
+	"sandbox":                         "sandbox",
+	"debug":                           "depuración",
+	"accessible output":               "salida accesible",
+	"batch mode":                      "modo por lotes",
+	"Modes: %s":                       "Modos: %s",
+	"auto-save is on":                 "el autoguardado está activado",
+	"auto-save is off":                "el autoguardado está desactivado",
+	"Unsaved changes: %d (%s). Use 'unsaved' to list them.": "Cambios sin guardar: %d (%s). Usa 'unsaved' para verlos.",
+	"Unsaved changes: none (%s)":                            "Cambios sin guardar: ninguno (%s)",
+
+	// Release notes
+	"Show what's new in this version of the Pokédex CLI": "Muestra las novedades de esta versión de la Pokédex CLI",
+	"What's new since the last release:":                 "Novedades desde la última versión:",
+	"What's new in %s:":                                  "Novedades de %s:",
+	"...and %d more. Use 'whatsnew' to see them all.":    "...y %d más. Usa 'whatsnew' para verlas todas.",
+	"The release notes are missing from this build":      "Las notas de la versión no están incluidas en esta compilación",
+
+	// Themes
+	"Show or change how the results of commands that accept --json are drawn":                                                       "Muestra o cambia cómo se dibujan los resultados de los comandos que admiten --json",
+	"Themes apply to commands that accept --json, such as catch, map, and pokedex. Battles and other commands keep their own look.": "Los temas se aplican a los comandos que admiten --json, como catch, map y pokedex. Los combates y los demás comandos mantienen su aspecto.",
+	"Results are drawn in the %s theme. Available themes: %s\n":                                                                     "Los resultados se dibujan con el tema %s. Temas disponibles: %s\n",
+	"Unknown theme '%s' (use %s)":              "Tema '%s' desconocido (usa %s)",
+	"Results will be drawn in the %s theme.\n": "Los resultados se dibujarán con el tema %s.\n",
+	"Accessible output is on, so results are drawn in the classic theme until it's turned off.": "La salida accesible está activada, así que los resultados se dibujan con el tema clásico hasta que se desactive.",
+
+	// Secret Santa
+	"Draw a Secret Santa trade between trainers' save files and write each a trade file":                                     "Sortea un intercambio de amigo invisible entre los archivos de guardado de varios entrenadores y escribe un archivo de intercambio para cada uno",
+	"Usage: santa <save file> <save file> <save file>... [--out <directory>]":                                                "Uso: santa <archivo de guardado> <archivo de guardado> <archivo de guardado>... [--out <directorio>]",
+	"Drew a Secret Santa trade between %d trainers and wrote a trade file for each to %s:\n":                                 "Se sorteó un intercambio de amigo invisible entre %d entrenadores y se escribió un archivo de intercambio para cada uno en %s:\n",
+	"Send each trainer only their own file: it names whom they give to, but not who gives to them.":                          "Envía a cada entrenador solo su propio archivo: indica a quién le hace el regalo, pero no quién se lo hace a él.",
+	"A Secret Santa needs at least %d trainers, so nobody can tell who their Santa is. %s":                                   "Un amigo invisible necesita al menos %d entrenadores, para que nadie pueda saber quién le hace el regalo. %s",
+	"Two save files are named '%s'. Rename one so each trainer gets their own trade file":                                    "Hay dos archivos de guardado llamados '%s'. Cambia el nombre de uno para que cada entrenador tenga su propio archivo de intercambio",
+	"Couldn't draw a trade where every trainer can give a Pokémon the next one doesn't have. Catch a few more and try again": "No se pudo sortear un intercambio en el que cada entrenador pueda regalar un Pokémon que el siguiente no tenga. Captura algunos más e inténtalo de nuevo",
+	"Could not create directory '%s'": "No se pudo crear el directorio '%s'",
+
+	// Encounter conditions
+	"When":           "Cuándo",
+	"Morning":        "Mañana",
+	"Day":            "Día",
+	"Night":          "Noche",
+	"Spring":         "Primavera",
+	"Summer":         "Verano",
+	"Autumn":         "Otoño",
+	"Winter":         "Invierno",
+	"During a swarm": "Durante una aparición masiva",
+	"Outside swarms": "Fuera de apariciones masivas",
+	"Some Pokémon only turn up at certain times. Right now it's: %s\n":                                                     "Algunos Pokémon solo aparecen en ciertos momentos. Ahora mismo es: %s\n",
+	"No wild Pokémon can be found in %s that way right now (%s). Try again at another time of day or in another season.\n": "Ahora mismo no se puede encontrar ningún Pokémon salvaje en %s de esa forma (%s). Inténtalo de nuevo a otra hora del día o en otra estación.\n",
+
+	// Trainer level
+	"Show your trainer level and what it unlocks": "Muestra tu nivel de entrenador y lo que desbloquea",
+	"Gym battles":       "Combates de gimnasio",
+	"Legendary Pokémon": "Pokémon legendarios",
+	"%s unlock at trainer level %d, and you're level %d. Catch more Pokémon to level up (see 'trainer').": "%s se desbloquean con el nivel de entrenador %d, y tienes el nivel %d. Captura más Pokémon para subir de nivel (consulta 'trainer').",
+	"Trainer level %d\n":               "Nivel de entrenador %d\n",
+	"Experience: %d from %d Pokémon\n": "Experiencia: %d de %d Pokémon\n",
+	"Next level: %d more experience\n": "Siguiente nivel: %d de experiencia más\n",
+	" - %s: unlocked at level %d\n":    " - %s: desbloqueado en el nivel %d\n",
+	" - %s: unlock at level %d\n":      " - %s: se desbloquea en el nivel %d\n",
+	"Trainer level":                    "Nivel de entrenador",
+
+	// Explore names
+	"Completed '%s' to %s, found in %s.\n": "Se completó '%s' como %s, encontrado en %s.\n",
+
+	// Crash reports
+	"Warning: Could not write a crash report: %v\n":                                "Advertencia: No se pudo escribir un informe de fallo: %v\n",
+	"A crash report was written to %s. Please attach it if you report this bug.\n": "Se escribió un informe de fallo en %s. Adjúntalo si informas de este error.\n",
+
+	// Chained commands
+	"Skipped '%s' because the command before it failed.\n": "Se omitió '%s' porque falló el comando anterior.\n",
+
+	// Reminders
+	"Set a reminder to show after a delay (e.g. remind 10m check berries), or list your reminders":                         "Programa un recordatorio que aparece tras un tiempo (p. ej. remind 10m check berries), o muestra tus recordatorios",
+	"Be reminded to run a command every hour, day, or week, or list the scheduled commands":                                "Recibe un recordatorio para ejecutar un comando cada hora, día o semana, o muestra los comandos programados",
+	"Usage: remind, remind <delay> <message> (e.g. remind 10m check berries), or remind cancel <number>":                   "Uso: remind, remind <tiempo> <mensaje> (p. ej. remind 10m check berries), o remind cancel <número>",
+	"Usage: schedule, schedule hourly|daily|weekly <command> (e.g. schedule daily challenge), or schedule cancel <number>": "Uso: schedule, schedule hourly|daily|weekly <comando> (p. ej. schedule daily challenge), o schedule cancel <número>",
+	"hourly": "cada hora",
+	"daily":  "cada día",
+	"weekly": "cada semana",
+	"[Scheduled] It's time for your %s '%s'.\n": "[Programado] Es hora de tu '%[2]s' (%[1]s).\n",
+	"[Reminder] %s\n": "[Recordatorio] %s\n",
+	"any moment now":  "en cualquier momento",
+	"in %s (%s)":      "en %s (%s)",
+	"Invalid number: %s (see the numbers in the list)":                "Número no válido: %s (consulta los números de la lista)",
+	"Cancelled the reminder: %s\n":                                    "Se canceló el recordatorio: %s\n",
+	"You have no reminders. Set one with 'remind 10m check berries'.": "No tienes recordatorios. Programa uno con 'remind 10m check berries'.",
+	"Reminders:":   "Recordatorios:",
+	"%d. %s, %s\n": "%d. %s, %s\n",
+	"Invalid delay: %s (use a duration like 10m or 1h30m)":                          "Tiempo no válido: %s (usa una duración como 10m o 1h30m)",
+	"I'll remind you %s: %s\n":                                                      "Te lo recordaré %s: %s\n",
+	"'%s' is no longer scheduled %s.\n":                                             "'%s' ya no está programado %s.\n",
+	"You have no scheduled commands. Schedule one with 'schedule daily challenge'.": "No tienes comandos programados. Programa uno con 'schedule daily challenge'.",
+	"Scheduled commands:":                                                           "Comandos programados:",
+	"%d. '%s' %s, next %s\n":                                                        "%d. '%s' %s, la próxima vez %s\n",
+	"Scheduled '%s' %s, starting %s.\n":                                             "Se programó '%s' %s, empezando %s.\n",
+
+	// Macros
+	"Define macros that run a line of commands with parameters filled in, or list your macros":                                         "Define macros que ejecutan una línea de comandos con sus parámetros completados, o muestra tus macros",
+	"Usage: macro, macro define <name> \"<commands>\" (e.g. macro define hunt \"map; explore $1; encounter\"), or macro remove <name>": "Uso: macro, macro define <nombre> \"<comandos>\" (p. ej. macro define hunt \"map; explore $1; encounter\"), o macro remove <nombre>",
+	"There's no macro named '%s'": "No hay ninguna macro llamada '%s'",
+	"Removed the macro %s.\n":     "Se eliminó la macro %s.\n",
+	"You have no macros. Define one with 'macro define hunt \"map; explore $1; encounter\"'.": "No tienes macros. Define una con 'macro define hunt \"map; explore $1; encounter\"'.",
+	"Macros:":     "Macros:",
+	" - %s: %s\n": " - %s: %s\n",
+	"Run one by typing its name and any parameters, like a command.":                   "Ejecuta una escribiendo su nombre y sus parámetros, como un comando.",
+	"Invalid macro name: %s (use letters, digits, and dashes, starting with a letter)": "Nombre de macro no válido: %s (usa letras, dígitos y guiones, empezando por una letra)",
+	"'%s' is already a command, so it can't be a macro's name":                         "'%s' ya es un comando, así que no puede ser el nombre de una macro",
+	"Defined the macro %s, which runs: %s\n":                                           "Se definió la macro %s, que ejecuta: %s\n",
+	"Run it with '%s' followed by %d parameters.\n":                                    "Ejecútala con '%s' seguido de %d parámetros.\n",
+	"Run it with '%s' followed by a parameter.\n":                                      "Ejecútala con '%s' seguido de un parámetro.\n",
+	"The macro '%s' needs %d parameters, but was given %d":                             "La macro '%s' necesita %d parámetros, pero recibió %d",
+	"The macro '%s' needs a parameter":                                                 "La macro '%s' necesita un parámetro",
+	"Macros can only run %d levels deep; check that '%s' doesn't run itself":           "Las macros solo pueden anidarse %d niveles; comprueba que '%s' no se ejecute a sí misma",
+	"Running %s: %s\n": "Ejecutando %s: %s\n",
+
+	// Release safeguards
+	"%s splashed back into the water. Bye, %s!":                   "%s volvió al agua de un salto. ¡Adiós, %s!",
+	"%s gave a last warm glow and headed for the hills. Bye, %s!": "%s dio un último brillo cálido y se fue hacia las colinas. ¡Adiós, %s!",
+	"%s disappeared into the tall grass. Bye, %s!":                "%s desapareció entre la hierba alta. ¡Adiós, %s!",
+	"%s scuttled off into the undergrowth. Bye, %s!":              "%s se escabulló entre la maleza. ¡Adiós, %s!",
+	"%s spread its wings and flew off. Bye, %s!":                  "%s extendió las alas y se fue volando. ¡Adiós, %s!",
+	"%s crackled once and darted away. Bye, %s!":                  "%s chisporroteó una vez y salió disparado. ¡Adiós, %s!",
+	"%s faded away into the shadows. Bye, %s!":                    "%s se desvaneció entre las sombras. ¡Adiós, %s!",
+	"%s vanished in a flash of light. Bye, %s!":                   "%s desapareció en un destello de luz. ¡Adiós, %s!",
+	"%s rumbled off back to the mountains. Bye, %s!":              "%s volvió retumbando a las montañas. ¡Adiós, %s!",
+	"%s burrowed back underground. Bye, %s!":                      "%s volvió a excavar bajo tierra. ¡Adiós, %s!",
+	"%s slid off towards the snowfields. Bye, %s!":                "%s se deslizó hacia los campos nevados. ¡Adiós, %s!",
+	"%s soared off beyond the clouds. Bye, %s!":                   "%s se elevó más allá de las nubes. ¡Adiós, %s!",
+	"%s is one of your favorites.":                                "%s es uno de tus favoritos.",
+	"%s is a legendary Pokémon, and may never turn up again.":     "%s es un Pokémon legendario, y puede que no vuelva a aparecer nunca.",
+	"%s is the last Pokémon you have from the %s family.":         "%s es el último Pokémon que tienes de la familia de %s.",
+	"Warning: %s\n":        "Advertencia: %s\n",
+	"Release %s anyway?":   "¿Liberar a %s de todos modos?",
+	"%s stays with you.\n": "%s se queda contigo.\n",
+
+	// Area progress
+	"Usage: area progress [location number or name] (the area explored last if none is given)": "Uso: area progress [número o nombre de la ubicación] (la última zona explorada si no se indica)",
+	"No area explored yet. Explore one first, or give its number on the map page.":             "Aún no has explorado ninguna zona. Explora una primero, o indica su número en la página del mapa.",
+	"%s: %d/%d caught there\n":   "%s: %d/%d capturados allí\n",
+	"No Pokémon are found here.": "Aquí no se encuentra ningún Pokémon.",
+	"not yet":                    "todavía no",
+	"★ Area complete! You've caught every Pokémon found in %s.\n":               "★ ¡Zona completada! Has capturado todos los Pokémon que se encuentran en %s.\n",
+	"Show how many of the Pokémon found in a location area you've caught there": "Muestra cuántos de los Pokémon de una zona has capturado allí",
+
+	// Save format
+	"Warning: Could not check the save file: %v\n":                                          "Aviso: no se pudo comprobar el archivo de guardado: %v\n",
+	"%d fields in the save file don't match the save format, so they couldn't be loaded:\n": "%d campos del archivo de guardado no coinciden con el formato de guardado, por lo que no se pudieron cargar:\n",
+	" - %s: expected %s, found %s\n":                                                        " - %s: se esperaba %s, se encontró %s\n",
+
+	// Multi-select picker
+	"Choose the Pokémon to release:":                             "Elige los Pokémon que quieres liberar:",
+	"Nothing was chosen, so nothing was released.":               "No elegiste nada, así que no se liberó nada.",
+	"Release %d Pokémon?":                                        "¿Liberar %d Pokémon?",
+	"Nothing was released.":                                      "No se liberó nada.",
+	"Usage: box move --select <box>":                             "Uso: box move --select <caja>",
+	"Choose the Pokémon to move to box '%s':":                    "Elige los Pokémon que quieres mover a la caja '%s':",
+	"Nothing was chosen, so nothing was moved.":                  "No elegiste nada, así que no se movió nada.",
+	"Choose the catches to export:":                              "Elige las capturas que quieres exportar:",
+	"Choose the team to export:":                                 "Elige el equipo que quieres exportar:",
+	"Nothing was chosen, so nothing was exported.":               "No elegiste nada, así que no se exportó nada.",
+	"A Showdown team has at most %d Pokémon, but %d were chosen": "Un equipo de Showdown tiene como máximo %d Pokémon, pero elegiste %d",
+	"%d of %d chosen":                                            "%d de %d elegidos",
+	"(showing %d-%d)":                                            "(mostrando %d-%d)",
+	"--select needs someone at the keyboard to choose, so it can't be used in batch mode":                 "--select necesita a alguien frente al teclado para elegir, así que no se puede usar en modo por lotes",
+	"Move with the arrow keys, choose with space (a for all), and press Enter when done, or q to cancel.": "Muévete con las flechas, elige con la barra espaciadora (a para todos) y pulsa Intro al terminar, o q para cancelar.",
+	"Type the numbers to choose, such as 1,3-5 or all (or nothing to cancel): ":                           "Escribe los números que quieres elegir, como 1,3-5 o todos (o nada para cancelar): ",
+	"all": "todos",
+	"Invalid choice '%s': use numbers from 1 to %d, such as 1,3-5": "Elección no válida '%s': usa números del 1 al %d, como 1,3-5",
+	"There are no Pokémon to choose from":                          "No hay Pokémon entre los que elegir",
+	"%s, level %d":                                                 "%s, nivel %d",
+	"(box '%s')":                                                   "(caja '%s')",
+
+	// Battle card
+	"%s (%gx)":                               "%s (x%g)",
+	"Looking up the moves %s can learn...\n": "Consultando los movimientos que puede aprender %s...\n",
+	"%s — level %d\n":                        "%s — nivel %d\n",
+	"Weak to: %s\n":                          "Débil contra: %s\n",
+	"Resists: %s\n":                          "Resiste: %s\n",
+	"Immune to: %s\n":                        "Inmune a: %s\n",
+	"Stats at level %d:\n":                   "Estadísticas a nivel %d:\n",
+	"Suggested moves:":                       "Movimientos sugeridos:",
+	" - none of the moves it can learn deal damage": " - ninguno de los movimientos que puede aprender hace daño",
+	"Move":     "Movimiento",
+	"Category": "Categoría",
+	"Power":    "Potencia",
+	"Accuracy": "Precisión",
+	"%d of the moves it can learn couldn't be looked up, so they weren't considered.\n": "No se pudieron consultar %d de los movimientos que puede aprender, así que no se tuvieron en cuenta.\n",
+	"Abilities:":  "Habilidades:",
+	"%s (hidden)": "%s (oculta)",
+
+	// Unreadable entries
+	"Warning: %v\n": "Aviso: %v\n",
+	"The entry is kept unchanged in your save file, but can't be used until it's fixed. Run 'doctor' for details.": "La entrada se conserva sin cambios en tu archivo de guardado, pero no se puede usar hasta que se corrija. Ejecuta 'doctor' para más detalles.",
+
+	// Held items
+	"%s isn't holding an item. Give it one from your bag with 'hold %s <item>'. Items that work in battle: %s\n": "%s no lleva ningún objeto. Dale uno de tu mochila con 'hold %s <objeto>'. Objetos que funcionan en combate: %s\n",
+	"%s is holding %s.\n": "%s lleva %s.\n",
+	"%s has no effect when held. Items that work in battle: %s": "%s no tiene efecto al llevarlo. Objetos que funcionan en combate: %s",
+	"%s is already holding %s":                                  "%s ya lleva %s",
+	"You don't have any %s. Buy one with 'shop buy %s'":         "No tienes ningún %s. Compra uno con 'shop buy %s'",
+	"%s is now holding %s.\n":                                   "Ahora %s lleva %s.\n",
+	"%s went back in your bag.\n":                               "%s ha vuelto a tu mochila.\n",
+	"%s isn't holding an item":                                  "%s no lleva ningún objeto",
+	"You took %s from %s. It went back in your bag.\n":          "Le quitaste %s a %s. Ha vuelto a tu mochila.\n",
+	"%s (holding %s)":                                           "%s (lleva %s)",
+	"Held item: %s\n":                                           "Objeto equipado: %s\n",
+	"%s's %s only lets it use %s until it's switched out":       "El %[2]s de %[1]s solo le deja usar %[3]s hasta que se retire",
+
+	"%s's %s restored %d HP with its %s (%d/%d HP left).":     "El %[2]s de %[1]s recuperó %[3]d PS con sus %[4]s (%[5]d/%[6]d PS restantes).",
+	"The wild %s restored %d HP with its %s (%d/%d HP left).": "El %s salvaje recuperó %d PS con sus %s (%d/%d PS restantes).",
+
+	// Bookmarks
+	"Bookmark locations to explore again later, or list your bookmarks":              "Guarda ubicaciones como marcadores para explorarlas más tarde, o lista tus marcadores",
+	"Usage: bookmark, bookmark add [location number], or bookmark remove <location>": "Uso: bookmark, bookmark add [número de ubicación], o bookmark remove <ubicación>",
+	"You haven't bookmarked any locations. Explore one, then use 'bookmark add'.":    "No tienes ubicaciones en marcadores. Explora una y usa 'bookmark add'.",
+	"Bookmarked locations:":                                   "Ubicaciones en marcadores:",
+	"Explore one with 'explore <location>'.":                  "Explora una con 'explore <ubicación>'.",
+	"Explore a location first, or give its number on the map": "Explora una ubicación primero, o indica su número en el mapa",
+	"%s is already bookmarked":                                "%s ya está en tus marcadores",
+	"Bookmarked %s.\n":                                        "%s se añadió a tus marcadores.\n",
+	"%s isn't bookmarked":                                     "%s no está en tus marcadores",
+	"Removed the bookmark for %s.\n":                          "Se quitó %s de tus marcadores.\n",
+
+	// Data directories
+	"Warning: Could not move %s to %s: %v\n": "Advertencia: No se pudo mover %s a %s: %v\n",
+	"Moved %s to %s\n":                       "Se movió %s a %s\n",
+
+	// Save file lock
+	"%w. Run 'save' to break the stale lock":                                             "%w. Ejecuta 'save' para romper el bloqueo obsoleto",
+	"The save file is locked by process %d, which is no longer running. Break the lock?": "El archivo de guardado está bloqueado por el proceso %d, que ya no se está ejecutando. ¿Romper el bloqueo?",
+	"Could not break the lock: %v\n":                                                     "No se pudo romper el bloqueo: %v\n",
+	"The stale lock was broken.":                                                         "Se rompió el bloqueo obsoleto.",
+
+	// Save log
+	"Show the latest writes to the save file and what triggered them": "Muestra las últimas escrituras del archivo de guardado y qué las provocó",
+	"Usage: savelog [number of writes to show]":                       "Uso: savelog [número de escrituras a mostrar]",
+	"Could not find the save log":                                     "No se pudo encontrar el registro de guardado",
+	"Could not read the save log":                                     "No se pudo leer el registro de guardado",
+	"The save file hasn't been written yet.":                          "El archivo de guardado aún no se ha escrito.",
+	"Time":                                                            "Hora",
+	"Trigger":                                                         "Origen",
+	"Size":                                                            "Tamaño",
+	"Result":                                                          "Resultado",
+	"OK":                                                              "Correcto",
+	"Failed: %s":                                                      "Falló: %s",
+	"(end of batch)":                                                  "(fin del lote)",
+	"(auto-save timer)":                                               "(temporizador de autoguardado)",
+	"The latest %d of %d writes to the save file, oldest first:\n": "Las últimas %d de %d escrituras del archivo de guardado, de la más antigua a la más reciente:\n",
+	"The full log is in %s.\n":                                     "El registro completo está en %s.\n",
+
+	// Rental teams
+	"Rent a preset team to battle with, without changing your Pokédex": "Alquila un equipo predefinido para combatir sin cambiar tu Pokédex",
+	"Usage: rental [team], or rental return":                           "Uso: rental [equipo], o rental return",
+	"Team":                                                             "Equipo",
+	"The fully evolved starters of Kanto":                              "Los iniciales de Kanto en su última evolución",
+	"The fully evolved starters of Johto":                              "Los iniciales de Johto en su última evolución",
+	"The fully evolved starters of Hoenn":                              "Los iniciales de Hoenn en su última evolución",
+	"Articuno, Zapdos, and Moltres":                                    "Articuno, Zapdos y Moltres",
+	"The legendary Pokémon of sea, land, and sky":                      "Los Pokémon legendarios del mar, la tierra y el cielo",
+	"Water types only":                                                 "Solo de tipo Agua",
+	"Fire types only":                                                  "Solo de tipo Fuego",
+	"Dragon types only":                                                "Solo de tipo Dragón",
+	"You're renting the '%s' team. Return it with 'rental return'.\n":  "Estás alquilando el equipo '%s'. Devuélvelo con 'rental return'.\n",
+	"Rent a team with 'rental <team>' to battle with it instead of your Pokémon.":      "Alquila un equipo con 'rental <equipo>' para combatir con él en lugar de con tus Pokémon.",
+	"Unknown rental team '%s'. Teams: %s":                                              "Equipo de alquiler desconocido '%s'. Equipos: %s",
+	"You rented the '%s' team: %s.\n":                                                  "Has alquilado el equipo '%s': %s.\n",
+	"Battles use it instead of your Pokémon until you return it with 'rental return'.": "Los combates lo usan en lugar de tus Pokémon hasta que lo devuelvas con 'rental return'.",
+	"You aren't renting a team":                                                        "No estás alquilando ningún equipo",
+	"You returned the '%s' team. Battles use your Pokémon again.\n":                    "Has devuelto el equipo '%s'. Los combates vuelven a usar tus Pokémon.\n",
+	"Battling with the rental team '%s'.\n":                                            "Combatirás con el equipo de alquiler '%s'.\n",
+	"Rental Pokémon don't gain experience.":                                            "Los Pokémon de alquiler no ganan experiencia.",
+
+	// Battle replays
+	"Replay saved to %s. Watch it with 'replay %s'.\n":              "Repetición guardada en %s. Mírala con 'replay %s'.\n",
+	"Play back a battle recorded with 'battle --record'":            "Reproduce un combate grabado con 'battle --record'",
+	"Usage: replay <file> [--speed <n>]":                            "Uso: replay <archivo> [--speed <n>]",
+	"The speed must be a positive number, like 2 for twice as fast": "La velocidad debe ser un número positivo, como 2 para el doble de rápido",
+	"Battle recorded %s: %s vs %s\n":                                "Combate grabado el %s: %s contra %s\n",
+	"Turn %d\n":                                                     "Turno %d\n",
+	"The replay file is damaged":                                    "El archivo de repetición está dañado",
+	"Could not write replay file '%s'":                              "No se pudo escribir el archivo de repetición '%s'",
+	"Could not read replay file '%s'":                               "No se pudo leer el archivo de repetición '%s'",
+	"The replay file '%s' was made by a newer version of the app":   "El archivo de repetición '%s' se creó con una versión más reciente de la aplicación",
+
+	// Experience
+	"%s gained %d experience points.\n":                          "%s ha ganado %d puntos de experiencia.\n",
+	"%s grew to level %d!\n":                                     "¡%s ha subido al nivel %d!\n",
+	"%s can now evolve into %s! Use 'evolve %s' to evolve it.\n": "¡%s ya puede evolucionar a %s! Usa 'evolve %s' para que evolucione.\n",
+	"Experience: %d/%d to the next level\n":                      "Experiencia: %d/%d para el siguiente nivel\n",
+
+	"Usage: redeem <code>": "Uso: redeem <código>",
+	"'%s' isn't a valid distribution code. Check that it was typed correctly.": "'%s' no es un código de evento válido. Comprueba que lo has escrito bien.",
+	"That code has already been redeemed.":                                     "Ese código ya se ha canjeado.",
+	"You already have %s. Release it first to receive this one.":               "Ya tienes a %s. Libéralo primero para recibir este.",
+	"You received %s! It has the %s.\n":                                        "¡Has recibido a %s! Tiene la %s.\n",
+	"You received %s x%d! It's in your bag.\n":                                 "¡Has recibido %s x%d! Está en tu mochila.\n",
+	"Ribbons earned: %d of %d\n":                                               "Cintas ganadas: %d de %d\n",
+	"Ribbon":                                                                   "Cinta",
+	"How to earn":                                                              "Cómo conseguirla",
+	"Held by":                                                                  "La tienen",
+	"Victory Ribbon":                                                           "Cinta Victoria",
+	"Veteran Ribbon":                                                           "Cinta Veterano",
+	"Champion Ribbon":                                                          "Cinta Campeón",
+	"Flawless Ribbon":                                                          "Cinta Impecable",
+	"Classic Ribbon":                                                           "Cinta Clásica",
+	"Won a battle round":                                                       "Ganó un asalto de combate",
+	"Won 10 battle rounds":                                                     "Ganó 10 asaltos de combate",
+	"Won 50 battle rounds":                                                     "Ganó 50 asaltos de combate",
+	"Beat a trainer without any of the team fainting":                          "Venció a un entrenador sin que nadie del equipo se debilitara",
+	"Received at a special event":                                              "Recibida en un evento especial",
+	"You defeated the %s and earned ₽%d! You now have ₽%d.\n":                                      "¡Has derrotado al %s y ganado ₽%d! Ahora tienes ₽%d.\n",
+	"Usage: shop, shop buy <item> [quantity], or shop bag":                                         "Uso: shop, shop buy <objeto> [cantidad] o shop bag",
+	"Unknown shop command '%s'. %s":                                                                "Comando de tienda desconocido '%s'. %s",
+	"Welcome to the Poké Mart! You have ₽%d.\n":                                                    "¡Bienvenido a la Tienda Pokémon! Tienes ₽%d.\n",
+	"Use 'shop buy <item> [quantity]' to buy an item.":                                             "Usa 'shop buy <objeto> [cantidad]' para comprar un objeto.",
+	"The quantity must be at least 1":                                                              "La cantidad debe ser al menos 1",
+	"The shop doesn't sell '%s'. Items for sale: %s":                                               "La tienda no vende '%s'. Objetos a la venta: %s",
+	"You need ₽%d for that, but you only have ₽%d. Win battles with 'fight trainer' to earn more.": "Necesitas ₽%d para eso, pero solo tienes ₽%d. Gana combates con 'fight trainer' para conseguir más.",
+	"You bought %s x%d for ₽%d. You have ₽%d left.\n":                                              "Has comprado %s x%d por ₽%d. Te quedan ₽%d.\n",
+	"You have ₽%d.\n":    "Tienes ₽%d.\n",
+	"Your bag is empty.": "Tu bolsa está vacía.",
+	"Lure in use: %s\n":  "Cebo en uso: %s\n",
+	"Item":               "Objeto",
+	"Price":              "Precio",
+	"In bag":             "En la bolsa",
+	"Effect":             "Efecto",
+	"Quantity":           "Cantidad",
+	"Usage: daycare, daycare deposit <pokemon>, or daycare withdraw <pokemon>":                             "Uso: daycare, daycare deposit <pokémon> o daycare withdraw <pokémon>",
+	"Unknown daycare command '%s'. %s":                                                                     "Comando de guardería desconocido '%s'. %s",
+	"The day care is empty. It can look after %d Pokémon; use 'daycare deposit <pokemon>' to leave one.\n": "La guardería está vacía. Puede cuidar de %d Pokémon; usa 'daycare deposit <pokémon>' para dejar uno.\n",
+	"Pokémon at the day care (%d of %d):\n":                                                                "Pokémon en la guardería (%d de %d):\n",
+	"Level":                                                                                                "Nivel",
+	"Left on":                                                                                              "Dejado el",
+	"Levels gained":                                                                                        "Niveles ganados",
+	"The day care can only look after %d Pokémon at a time. Withdraw one first":      "La guardería solo puede cuidar de %d Pokémon a la vez. Recoge uno primero",
+	"%s is already at the day care":                                                  "%s ya está en la guardería",
+	"%s is already level %d and can't gain any more levels":                          "%s ya tiene el nivel %d y no puede subir más",
+	"%s was left at the day care. It will gain a level every %d minutes.\n":          "%s se ha quedado en la guardería. Subirá un nivel cada %d minutos.\n",
+	"%s isn't at the day care":                                                       "%s no está en la guardería",
+	"%s is back from the day care, still at level %d.\n":                             "%s ha vuelto de la guardería, todavía con nivel %d.\n",
+	"%s is back from the day care and grew 1 level to level %d!\n":                   "¡%s ha vuelto de la guardería y ha subido 1 nivel hasta el nivel %d!\n",
+	"%s is back from the day care and grew %d levels to level %d!\n":                 "¡%s ha vuelto de la guardería y ha subido %d niveles hasta el nivel %d!\n",
+	"Usage: minigame, or minigame <game> <pokemon> (e.g. minigame reaction pikachu)": "Uso: minigame o minigame <juego> <pokémon> (p. ej. minigame reaction pikachu)",
+	"Game":          "Juego",
+	"Helped by":     "Ayuda",
+	"Reaction Dash": "Carrera de reflejos",
+	"Memory Match":  "Desafío de memoria",
+	"Play one with 'minigame <game> <pokemon>'.":                               "Juega a uno con 'minigame <juego> <pokémon>'.",
+	"Unknown minigame '%s'. %s":                                                "Minijuego desconocido '%s'. %s",
+	"%s is at the day care. Withdraw it first":                                 "%s está en la guardería. Recógelo primero",
+	"Minigames need an interactive terminal and can't be played in batch mode": "Los minijuegos necesitan un terminal interactivo y no se pueden jugar en modo por lotes",
+	"%s is playing %s! (%s %d)\n":                                              "¡%s está jugando a %s! (%s %d)\n",
+	"Score: %d/%d\n":                                                           "Puntuación: %d/%d\n",
+	"That's a new best for %s!\n":                                              "¡Es la mejor marca de %s!\n",
+	"%s's best is %d.\n":                                                       "La mejor marca de %s es %d.\n",
+	"%s had fun and gained %d happiness.\n":                                    "%s se ha divertido y ha ganado %d de felicidad.\n",
+	"Press Enter as soon as you see GO! You have %.1f seconds each round.\n":   "¡Pulsa Intro en cuanto veas YA! Tienes %.1f segundos en cada ronda.\n",
+	"Round %d: get ready...\n":                                                 "Ronda %d: prepárate...\n",
+	"GO!":                                                                      "¡YA!",
+	"False start!":                                                             "¡Salida en falso!",
+	"%d ms: %d points\n":                                                       "%d ms: %d puntos\n",
+	"Remember these %d digits: %s\n":                                           "Recuerda estas %d cifras: %s\n",
+	"Press Enter when you're ready.":                                           "Pulsa Intro cuando estés listo.",
+	"Type the digits:":                                                         "Escribe las cifras:",
+	"The digits were %s.\n":                                                    "Las cifras eran %s.\n",
+	"The minigame ended because the input closed":                              "El minijuego terminó porque se cerró la entrada",
+	"Bug Catcher":                                                              "Cazabichos",
+	"Swimmer":                                                                  "Nadador",
+	"Bird Keeper":                                                              "Ornitólogo",
+	"Hiker":                                                                    "Montañero",
+	"Kindler":                                                                  "Pirómano",
+	"Guitarist":                                                                "Guitarrista",
+	"Psychic":                                                                  "Médium",
+	"Shared weaknesses: %s\n":                                                  "Debilidades compartidas: %s\n",
+	"%s (%d members)":                                                          "%s (%d miembros)",
+	"Weaknesses: no type is super-effective against more than one member":      "Debilidades: ningún tipo es superefectivo contra más de un miembro",
+	"Balance: %d physical and %d special attackers, average base stats %.0f\n": "Equilibrio: %d atacantes físicos y %d especiales, media de estadísticas base %.0f\n",
+
+	// Error codes
+	"Error codes:": "Códigos de error:",
+	"Code":         "Código",
+	"Description":  "Descripción",
+	"Use 'explain <code>' for details about a specific error.":  "Usa 'explain <código>' para ver los detalles de un error.",
+	"Unknown error code '%s'. Use 'explain' to list all codes.": "Código de error desconocido '%s'. Usa 'explain' para ver todos los códigos.",
+	"Likely cause: %s\n":          "Causa probable: %s\n",
+	"How to fix it: %s\n":         "Cómo solucionarlo: %s\n",
+	"Resource not found":          "Recurso no encontrado",
+	"Pokémon not found":           "Pokémon no encontrado",
+	"Location not found":          "Ubicación no encontrada",
+	"Evolution data not found":    "Datos de evolución no encontrados",
+	"Pokémon not in your Pokédex": "El Pokémon no está en tu Pokédex",
+	"Invalid input":               "Entrada no válida",
+	"Invalid Pokémon name":        "Nombre de Pokémon no válido",
+	"Network error":               "Error de red",
+	"Rate limit exceeded":         "Límite de peticiones superado",
+	"Pokémon API unavailable":     "API de Pokémon no disponible",
+	"Bad request":                 "Petición incorrecta",
+	"Unexpected API error":        "Error inesperado de la API",
+	"Internal error":              "Error interno",
+	"Incomplete API data":         "Datos incompletos de la API",
+	"The Pokémon API has no resource with the requested name or ID.":                                  "La API de Pokémon no tiene ningún recurso con ese nombre o ID.",
+	"Check the spelling of the name you entered and try again.":                                       "Comprueba cómo has escrito el nombre e inténtalo de nuevo.",
+	"The Pokémon API doesn't recognize the Pokémon name.":                                             "La API de Pokémon no reconoce el nombre del Pokémon.",
+	"Check the spelling, or end the line with a tab to list matching names (e.g. 'catch pika<TAB>').": "Comprueba cómo lo has escrito, o termina la línea con un tabulador para ver los nombres que coinciden (p. ej. 'catch pika<TAB>').",
+	"The location area no longer exists or the location list is out of date.":                         "La zona ya no existe o la lista de ubicaciones está desactualizada.",
+	"Run 'map' to reload the list of locations, then explore by number.":                              "Ejecuta 'map' para recargar la lista de ubicaciones y explora por número.",
+	"The Pokémon has no evolution chain in the Pokémon API.":                                          "El Pokémon no tiene cadena evolutiva en la API de Pokémon.",
+	"This Pokémon can't be evolved. No action is needed.":                                             "Este Pokémon no puede evolucionar. No hace falta hacer nada.",
+	"The command only works with Pokémon you have caught.":                                            "El comando solo funciona con Pokémon que hayas atrapado.",
+	"Run 'pokedex' to see what you've caught, or catch the Pokémon first.":                            "Ejecuta 'pokedex' para ver lo que has atrapado, o atrapa primero al Pokémon.",
+	"A command was given missing or invalid parameters.":                                              "Un comando ha recibido parámetros que faltan o no son válidos.",
+	"Run 'help' to see each command and the parameters it expects.":                                   "Ejecuta 'help' para ver cada comando y los parámetros que espera.",
+	"The name doesn't match any Pokémon.":                                                             "El nombre no coincide con ningún Pokémon.",
+	"Use one of the suggested names, or end the line with a tab to list matching names.":              "Usa uno de los nombres sugeridos, o termina la línea con un tabulador para ver los nombres que coinciden.",
+	"The Pokémon API couldn't be reached, usually because of a connection problem.":                   "No se pudo contactar con la API de Pokémon, normalmente por un problema de conexión.",
+	"Check your internet connection and try again. Data you've already viewed is cached.":             "Comprueba tu conexión a internet e inténtalo de nuevo. Los datos que ya has consultado están en caché.",
+	"Too many requests were sent to the Pokémon API in a short time.":                                 "Se enviaron demasiadas peticiones a la API de Pokémon en poco tiempo.",
+	"Wait a minute before trying again.":                                                              "Espera un minuto antes de volver a intentarlo.",
+	"The Pokémon API is down or having temporary problems.":                                           "La API de Pokémon no funciona o tiene problemas temporales.",
+	"Try again in a few minutes.":                                                                     "Inténtalo de nuevo dentro de unos minutos.",
+	"The Pokémon API rejected the request, usually because of an unusual name.":                       "La API de Pokémon rechazó la petición, normalmente por un nombre poco habitual.",
+	"Check the name you entered for unusual characters and try again.":                                "Comprueba si el nombre tiene caracteres poco habituales e inténtalo de nuevo.",
+	"The Pokémon API responded with an unexpected status code.":                                       "La API de Pokémon respondió con un código de estado inesperado.",
+	"Try again. If the problem continues, enable 'debug' and report the details.":                     "Inténtalo de nuevo. Si el problema continúa, activa 'debug' e informa de los detalles.",
+	"Something went wrong inside the Pokédex application.":                                            "Algo ha fallado dentro de la aplicación Pokédex.",
+	"Enable 'debug' to see the details and report the problem.":                                       "Activa 'debug' para ver los detalles e informa del problema.",
+	"The Pokémon API returned data with missing fields, so it wasn't used.":                           "La API de Pokémon devolvió datos con campos que faltan, así que no se usaron.",
+	"Try again later. If the problem continues, the API data may need correcting upstream.":           "Inténtalo más tarde. Si el problema continúa, puede que haya que corregir los datos de la API.",
+
+	// Error messages
+	"The %s '%s' was not found":                                                                  "No se encontró %s '%s'",
+	"The requested resource at '%s' was not found":                                               "No se encontró el recurso solicitado en '%s'",
+	"Bad request to '%s'":                                                                        "Petición incorrecta a '%s'",
+	"Rate limit exceeded. Please try again later":                                                "Límite de peticiones superado. Inténtalo de nuevo más tarde",
+	"The Pokémon API service is temporarily unavailable":                                         "El servicio de la API de Pokémon no está disponible temporalmente",
+	"Unexpected API error (status code: %d)":                                                     "Error inesperado de la API (código de estado: %d)",
+	"The Pokémon API returned incomplete data for %s '%s'":                                       "La API de Pokémon devolvió datos incompletos de %s '%s'",
+	"The Pokémon '%s' was not found. Please check the spelling and try again.":                   "No se encontró el Pokémon '%s'. Comprueba cómo lo has escrito e inténtalo de nuevo.",
+	"Note: Pokémon names should not contain spaces (use '-' instead).":                           "Nota: los nombres de Pokémon no llevan espacios (usa '-' en su lugar).",
+	"Note: Special characters like '.' and ''' are not used in API Pokémon names.":               "Nota: los nombres de Pokémon de la API no usan caracteres especiales como '.' y '''.",
+	"The location '%s' was not found. Please run the 'map' command to see available locations.":  "No se encontró la ubicación '%s'. Ejecuta el comando 'map' para ver las ubicaciones disponibles.",
+	"No evolution information found for '%s'. This Pokémon might not have any evolutions.":       "No se encontró información de evolución para '%s'. Puede que este Pokémon no tenga evoluciones.",
+	"Pokémon '%s' is not in your Pokédex. Try catching it first!":                                "El Pokémon '%s' no está en tu Pokédex. ¡Intenta atraparlo primero!",
+	"'%s' is not a valid Pokémon name. Please check your spelling - this Pokémon doesn't exist.": "'%s' no es un nombre de Pokémon válido. Comprueba cómo lo has escrito: este Pokémon no existe.",
+	"'%s' is not a valid Pokémon name. Did you mean: %s?":                                        "'%s' no es un nombre de Pokémon válido. ¿Querías decir: %s?",
+	"Pokémon species":   "especie de Pokémon",
+	"Pokémon move":      "movimiento de Pokémon",
+	"Pokémon ability":   "habilidad de Pokémon",
+	"Pokémon encounter": "encuentro de Pokémon",
+	"evolution chain":   "cadena evolutiva",
+	"location":          "ubicación",
+	"generation":        "generación",
+	"type":              "tipo",
+	"egg group":         "grupo huevo",
+	"item":              "objeto",
+	"version group":     "grupo de versiones",
+	"nature":            "naturaleza",
+	"Request to the Pokémon API was cancelled":                "Se canceló la petición a la API de Pokémon",
+	"Failed to create HTTP request":                           "No se pudo crear la petición HTTP",
+	"Failed to connect to the Pokémon API":                    "No se pudo conectar con la API de Pokémon",
+	"Failed to read the Pokémon API response":                 "No se pudo leer la respuesta de la API de Pokémon",
+	"The Pokémon API response was larger than the size limit": "La respuesta de la API de Pokémon superó el límite de tamaño",
+	"Failed to parse the Pokémon API response":                "No se pudo interpretar la respuesta de la API de Pokémon",
+}
@@ -0,0 +1,123 @@
+// Package i18n translates the application's user-facing text.
+//
+// Messages are written in English in the code and looked up, by their English
+// text, in the catalog of the selected language. A message with no translation
+// is shown in English, so a catalog can be filled in gradually. Format strings
+// are translated before the arguments are substituted, which lets translations
+// reorder arguments with explicit indexes like %[2]s.
+//
+// Usage Example:
+//
+//	i18n.SetLanguage("es")
+//	i18n.Printf("%s was caught!\n", name) // "¡Has atrapado a Pikachu!"
+//	err := errors.New(i18n.T("No Pokémon name provided"))
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Default is the language the messages are written in.
+const Default = "en"
+
+// Language describes a language the interface can be shown in.
+type Language struct {
+	Code string // The code used to select the language (e.g. "es")
+	Name string // The name of the language, in that language
+}
+
+// ErrUnknownLanguage is returned when selecting a language with no catalog.
+var ErrUnknownLanguage = errors.New("unknown language")
+
+// languages lists the supported languages, with the default first.
+var languages = []Language{
+	{Code: "en", Name: "English"},
+	{Code: "es", Name: "Español"},
+}
+
+// catalogs maps each language code to its translations, keyed by the English message.
+var catalogs = map[string]map[string]string{
+	"es": spanish,
+}
+
+var (
+	mu      sync.RWMutex
+	current = Default
+)
+
+// Languages returns the supported languages, with the default first.
+func Languages() []Language {
+	return append([]Language(nil), languages...)
+}
+
+// Lookup returns the supported language with the given code.
+func Lookup(code string) (Language, bool) {
+	for _, lang := range languages {
+		if lang.Code == code {
+			return lang, true
+		}
+	}
+	return Language{}, false
+}
+
+// SetLanguage selects the language that messages are shown in.
+// An empty code selects the default language.
+//
+// Returns:
+//   - ErrUnknownLanguage if the language isn't supported
+func SetLanguage(code string) error {
+	if code == "" {
+		code = Default
+	}
+	if _, ok := Lookup(code); !ok {
+		return ErrUnknownLanguage
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	current = code
+	return nil
+}
+
+// Current returns the code of the selected language.
+func Current() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// T translates a message into the selected language, or returns it
+// unchanged if there's no translation.
+func T(message string) string {
+	if translated, ok := catalogs[Current()][message]; ok {
+		return translated
+	}
+	return message
+}
+
+// Sprintf translates a format string and formats it like fmt.Sprintf.
+func Sprintf(format string, args ...any) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Printf translates a format string and prints it like fmt.Printf.
+func Printf(format string, args ...any) {
+	fmt.Print(Sprintf(format, args...))
+}
+
+// Println translates a message and prints it on its own line.
+func Println(message string) {
+	fmt.Println(T(message))
+}
+
+// Fprintf translates a format string and writes it to w like fmt.Fprintf.
+func Fprintf(w io.Writer, format string, args ...any) (int, error) {
+	return fmt.Fprint(w, Sprintf(format, args...))
+}
+
+// Fprintln translates a message and writes it to w on its own line.
+func Fprintln(w io.Writer, message string) (int, error) {
+	return fmt.Fprintln(w, T(message))
+}
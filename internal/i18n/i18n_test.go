@@ -0,0 +1,117 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestTranslate tests looking up messages in the selected language
+func TestTranslate(t *testing.T) {
+	defer SetLanguage(Default)
+
+	if got := T("Level up"); got != "Level up" {
+		t.Errorf("Expected English by default, got %q", got)
+	}
+
+	if err := SetLanguage("es"); err != nil {
+		t.Fatalf("Expected Spanish to be supported, got %v", err)
+	}
+	if got := T("Level up"); got != "Subir de nivel" {
+		t.Errorf("Expected the Spanish translation, got %q", got)
+	}
+	if got := Sprintf("Reach level %d", 16); got != "Alcanzar el nivel 16" {
+		t.Errorf("Expected the format string to be translated, got %q", got)
+	}
+	if got := T("A message with no translation"); got != "A message with no translation" {
+		t.Errorf("Expected untranslated messages in English, got %q", got)
+	}
+
+	if err := SetLanguage("xx"); err != ErrUnknownLanguage {
+		t.Errorf("Expected ErrUnknownLanguage, got %v", err)
+	}
+	if Current() != "es" {
+		t.Errorf("Expected an unknown language to leave the selection unchanged, got %q", Current())
+	}
+
+	if err := SetLanguage(""); err != nil || Current() != Default {
+		t.Errorf("Expected an empty code to select the default, got %q, %v", Current(), err)
+	}
+}
+
+// formatVerb matches the verbs in a format string, such as %s, %.1f, %[2]d, and %%
+var formatVerb = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*(\.\d+)?[a-zA-Z%]`)
+
+// TestCatalogVerbs tests that every translation uses the same format verbs as
+// its English message, so that the arguments are still formatted correctly
+func TestCatalogVerbs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for message, translated := range catalog {
+			want := formatVerb.FindAllString(message, -1)
+			got := formatVerb.FindAllString(translated, -1)
+			// Translations that reorder arguments are checked by verb type only
+			if strings.Contains(translated, "%[") {
+				want, got = verbTypes(want), verbTypes(got)
+				slices.Sort(want)
+				slices.Sort(got)
+			}
+			if !slices.Equal(want, got) {
+				t.Errorf("%s: %q has verbs %v, but its translation %q has %v", lang, message, want, translated, got)
+			}
+		}
+	}
+}
+
+// verbTypes strips the argument indexes from format verbs.
+func verbTypes(verbs []string) []string {
+	types := make([]string, len(verbs))
+	for i, verb := range verbs {
+		types[i] = verb[len(verb)-1:]
+	}
+	return types
+}
+
+// TestCatalogKeysInSource tests that every translated message still appears
+// in the code, so that translations don't silently stop being used when a
+// message is reworded
+func TestCatalogKeysInSource(t *testing.T) {
+	literals := map[string]bool{}
+	root := filepath.Join("..", "..")
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") ||
+			strings.HasSuffix(path, "_test.go") || strings.HasPrefix(d.Name(), "catalog_") {
+			return err
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				if value, err := strconv.Unquote(lit.Value); err == nil {
+					literals[value] = true
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Could not read the source files: %v", err)
+	}
+
+	for lang, catalog := range catalogs {
+		for message := range catalog {
+			if !literals[message] {
+				t.Errorf("%s: %q is translated but isn't used in the code", lang, message)
+			}
+		}
+	}
+}
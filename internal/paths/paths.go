@@ -0,0 +1,173 @@
+// Package paths decides where the application keeps its files. It follows the
+// XDG Base Directory Specification on Linux and other Unix systems, and the
+// platform's conventions on macOS and Windows:
+//
+//	Kind   Linux and other Unix                   macOS                                       Windows
+//	Data   $XDG_DATA_HOME (~/.local/share)        ~/Library/Application Support/pokedexcli    %APPDATA%\pokedexcli
+//	Cache  $XDG_CACHE_HOME (~/.cache)             ~/Library/Caches/pokedexcli                 %LOCALAPPDATA%\pokedexcli\cache
+//	State  $XDG_STATE_HOME (~/.local/state)       ~/Library/Application Support/pokedexcli    %LOCALAPPDATA%\pokedexcli
+//
+// On Linux and other Unix systems, each directory is a pokedexcli directory
+// inside the one shown. Older versions of the application kept their files in
+// the home directory; Migrate moves them to their new place.
+//
+// Usage Example:
+//
+//	dir, err := paths.Dir(paths.Data)
+//	if err == nil {
+//	    savePath := filepath.Join(dir, "save.json")
+//	}
+package paths
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// appName is the name of the application's directory in each base directory.
+const appName = "pokedexcli"
+
+// Kind is a kind of file, which decides the directory it's kept in.
+type Kind int
+
+const (
+	Data  Kind = iota // The user's own data, like the save file, which can't be recreated
+	Cache             // Data that can be downloaded again, like the species dataset
+	State             // Data that is kept between sessions but isn't worth backing up, like logs
+)
+
+// Dir returns the application's directory for a kind of file, creating it if
+// it doesn't exist yet.
+//
+// Parameters:
+//   - kind: The kind of file
+//
+// Returns:
+//   - The path of the directory
+//   - An error if the directory can't be determined or created
+func Dir(kind Kind) (string, error) {
+	dir, err := dirFor(kind, runtime.GOOS, os.Getenv, os.UserHomeDir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// dirFor returns the application's directory for a kind of file on an operating
+// system, given how to look up environment variables and the home directory.
+func dirFor(kind Kind, goos string, getenv func(string) string, homeDir func() (string, error)) (string, error) {
+	switch goos {
+	case "windows":
+		variable, sub := "LOCALAPPDATA", ""
+		switch kind {
+		case Data:
+			variable = "APPDATA"
+		case Cache:
+			sub = "cache"
+		}
+		base := getenv(variable)
+		if base == "" {
+			return "", errors.New("%" + variable + "% is not defined")
+		}
+		return filepath.Join(base, appName, sub), nil
+
+	case "darwin", "ios":
+		home, err := homeDir()
+		if err != nil {
+			return "", err
+		}
+		if kind == Cache {
+			return filepath.Join(home, "Library", "Caches", appName), nil
+		}
+		return filepath.Join(home, "Library", "Application Support", appName), nil
+
+	default:
+		variable, fallback := "XDG_DATA_HOME", filepath.Join(".local", "share")
+		switch kind {
+		case Cache:
+			variable, fallback = "XDG_CACHE_HOME", ".cache"
+		case State:
+			variable, fallback = "XDG_STATE_HOME", filepath.Join(".local", "state")
+		}
+		// The specification says relative paths are invalid and must be ignored
+		if base := getenv(variable); filepath.IsAbs(base) {
+			return filepath.Join(base, appName), nil
+		}
+		home, err := homeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, fallback, appName), nil
+	}
+}
+
+// Migrate moves a file or directory from where an older version of the
+// application kept it to its new path. It only moves it once: nothing is moved
+// if there's nothing at the old path, or if there's already something at the
+// new one. Files are copied if they can't be renamed, as when the two paths are
+// on different file systems.
+//
+// Parameters:
+//   - oldPath: Where the file or directory used to be kept
+//   - newPath: Where it's kept now
+//
+// Returns:
+//   - Whether it was moved
+//   - An error if it couldn't be moved
+func Migrate(oldPath, newPath string) (bool, error) {
+	info, err := os.Lstat(oldPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Lstat(newPath); !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0o700); err != nil {
+		return false, err
+	}
+
+	err = os.Rename(oldPath, newPath)
+	if err != nil && info.Mode().IsRegular() {
+		err = copyFile(oldPath, newPath, info.Mode().Perm())
+		if err == nil {
+			err = os.Remove(oldPath)
+		}
+	}
+	return err == nil, err
+}
+
+// copyFile copies a file, writing it under a temporary name first so that an
+// interrupted copy never leaves a partial file at the new path.
+func copyFile(oldPath, newPath string, perm os.FileMode) error {
+	in, err := os.Open(oldPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tempPath := newPath + ".tmp"
+	out, err := os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempPath, newPath)
+	}
+	if err != nil {
+		os.Remove(tempPath)
+	}
+	return err
+}
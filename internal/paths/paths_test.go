@@ -0,0 +1,117 @@
+package paths
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestDirFor tests the directories chosen on each platform, with and without
+// the environment variables that override them
+func TestDirFor(t *testing.T) {
+	home := func() (string, error) { return "/home/ash", nil }
+	env := map[string]string{
+		"XDG_DATA_HOME":  "/data",
+		"XDG_CACHE_HOME": "relative/cache",
+		"APPDATA":        `C:\Users\ash\AppData\Roaming`,
+		"LOCALAPPDATA":   `C:\Users\ash\AppData\Local`,
+	}
+	getenv := func(name string) string { return env[name] }
+
+	tests := []struct {
+		kind Kind
+		goos string
+		want string
+	}{
+		{Data, "linux", filepath.Join("/data", appName)},
+		{Cache, "linux", filepath.Join("/home/ash", ".cache", appName)}, // Relative paths are ignored
+		{State, "freebsd", filepath.Join("/home/ash", ".local", "state", appName)},
+		{Data, "darwin", filepath.Join("/home/ash", "Library", "Application Support", appName)},
+		{Cache, "darwin", filepath.Join("/home/ash", "Library", "Caches", appName)},
+		{Data, "windows", filepath.Join(env["APPDATA"], appName)},
+		{Cache, "windows", filepath.Join(env["LOCALAPPDATA"], appName, "cache")},
+		{State, "windows", filepath.Join(env["LOCALAPPDATA"], appName)},
+	}
+	for _, test := range tests {
+		got, err := dirFor(test.kind, test.goos, getenv, home)
+		if err != nil || got != test.want {
+			t.Errorf("dirFor(%d, %s) = %q, %v; want %q", test.kind, test.goos, got, err, test.want)
+		}
+	}
+
+	noHome := func() (string, error) { return "", errors.New("no home") }
+	if _, err := dirFor(State, "linux", getenv, noHome); err == nil {
+		t.Error("Expected an error without a home directory")
+	}
+	if _, err := dirFor(Data, "windows", func(string) string { return "" }, home); err == nil {
+		t.Error("Expected an error without %APPDATA%")
+	}
+}
+
+// TestMigrate tests that files and directories are moved once, and never over
+// something already at the new path
+func TestMigrate(t *testing.T) {
+	dir := t.TempDir()
+	oldPath := filepath.Join(dir, ".pokedexcli_save.json")
+	newPath := filepath.Join(dir, "data", appName, "save.json")
+
+	if moved, err := Migrate(oldPath, newPath); moved || err != nil {
+		t.Fatalf("Expected nothing to move, got %v, %v", moved, err)
+	}
+
+	if err := os.WriteFile(oldPath, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if moved, err := Migrate(oldPath, newPath); !moved || err != nil {
+		t.Fatalf("Expected the file to move, got %v, %v", moved, err)
+	}
+	if data, err := os.ReadFile(newPath); err != nil || string(data) != "old" {
+		t.Errorf("Expected the file at the new path, got %q, %v", data, err)
+	}
+	if _, err := os.Stat(oldPath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected the old file to be gone, got %v", err)
+	}
+
+	// A file at the old path is left alone once there's one at the new path
+	if err := os.WriteFile(oldPath, []byte("older"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if moved, err := Migrate(oldPath, newPath); moved || err != nil {
+		t.Errorf("Expected nothing to move, got %v, %v", moved, err)
+	}
+	if data, _ := os.ReadFile(newPath); string(data) != "old" {
+		t.Errorf("Expected the new file to be kept, got %q", data)
+	}
+
+	oldDir := filepath.Join(dir, ".pokedexcli_snapshots")
+	if err := os.MkdirAll(oldDir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(oldDir, "gym.json"), []byte("{}"), 0o600)
+	newDir := filepath.Join(dir, "data", appName, "snapshots")
+	if moved, err := Migrate(oldDir, newDir); !moved || err != nil {
+		t.Fatalf("Expected the directory to move, got %v, %v", moved, err)
+	}
+	if _, err := os.Stat(filepath.Join(newDir, "gym.json")); err != nil {
+		t.Errorf("Expected the directory's files to move with it, got %v", err)
+	}
+}
+
+// TestCopyFile tests the copy used when a file can't be renamed
+func TestCopyFile(t *testing.T) {
+	dir := t.TempDir()
+	oldPath, newPath := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	if err := os.WriteFile(oldPath, []byte("pikachu"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := copyFile(oldPath, newPath, 0o600); err != nil {
+		t.Fatalf("Failed to copy: %v", err)
+	}
+	if data, err := os.ReadFile(newPath); err != nil || string(data) != "pikachu" {
+		t.Errorf("Unexpected copy: %q, %v", data, err)
+	}
+	if _, err := os.Stat(newPath + ".tmp"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected no temporary file, got %v", err)
+	}
+}
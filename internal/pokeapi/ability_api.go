@@ -0,0 +1,24 @@
+package pokeapi
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// GetAbility retrieves an ability's effect and descriptions.
+// Results are cached to improve performance and reduce API calls.
+//
+// Parameters:
+//   - name: The name of the ability (e.g. "static")
+//
+// Returns:
+//   - An AbilityResp containing the ability's data
+//   - An error if the API request fails or the ability doesn't exist
+func (c *Client) GetAbility(name string) (AbilityResp, error) {
+	fullURL := baseURL + "/ability/" + name
+
+	return doGet[AbilityResp](c.context(), c, fullURL,
+		withDecodeHook(validateAbility),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(errorhandling.ResourcePokemonAbility, name, err)
+		}))
+}
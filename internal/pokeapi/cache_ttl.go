@@ -0,0 +1,90 @@
+// This file implements cache durations for classes of resources. Some PokeAPI
+// data practically never changes, such as species and evolution chains, while
+// other data, such as the pages of the location list, is worth refreshing more
+// often, so each class of resource can be cached for a different duration.
+package pokeapi
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CacheClass groups the resources that change at a similar rate.
+type CacheClass string
+
+const (
+	// CacheSpecies covers Pokémon species and evolution chains, which are
+	// fixed once a game is released.
+	CacheSpecies CacheClass = "species"
+
+	// CacheGameData covers the other game data: Pokémon, types, moves,
+	// abilities, items, egg groups, generations, version groups, and natures.
+	CacheGameData CacheClass = "game-data"
+
+	// CacheLocations covers locations, location areas, and the pages of the
+	// location list.
+	CacheLocations CacheClass = "locations"
+)
+
+// resourceCacheClasses maps the first path segment of each endpoint to its class.
+var resourceCacheClasses = map[string]CacheClass{
+	"pokemon-species": CacheSpecies,
+	"evolution-chain": CacheSpecies,
+	"pokemon":         CacheGameData,
+	"type":            CacheGameData,
+	"move":            CacheGameData,
+	"ability":         CacheGameData,
+	"item":            CacheGameData,
+	"egg-group":       CacheGameData,
+	"generation":      CacheGameData,
+	"version-group":   CacheGameData,
+	"nature":          CacheGameData,
+	"location":        CacheLocations,
+	"location-area":   CacheLocations,
+}
+
+// DefaultCacheTTLs returns the cache durations the application uses for each
+// class of resource. The map is new on every call, so it can be changed freely.
+func DefaultCacheTTLs() map[CacheClass]time.Duration {
+	return map[CacheClass]time.Duration{
+		CacheSpecies:   7 * 24 * time.Hour,
+		CacheGameData:  24 * time.Hour,
+		CacheLocations: 6 * time.Hour,
+	}
+}
+
+// cacheClassOf returns the class of the resource at a URL, and false if the
+// URL isn't a known PokeAPI endpoint.
+//
+// Parameters:
+//   - fullURL: The complete URL of the resource
+//
+// Returns:
+//   - The class of the resource
+//   - Whether the resource has a class
+func cacheClassOf(fullURL string) (CacheClass, bool) {
+	parsed, err := url.Parse(fullURL)
+	if err != nil {
+		return "", false
+	}
+	path := strings.TrimPrefix(parsed.Path, "/api/v2/")
+	resource, _, _ := strings.Cut(path, "/")
+	class, ok := resourceCacheClasses[resource]
+	return class, ok
+}
+
+// cacheTTL returns how long the response from a URL is cached. Resources
+// whose class has no duration in the client options use the cache interval.
+//
+// Parameters:
+//   - fullURL: The complete URL of the resource
+//
+// Returns:
+//   - The cache duration, or zero for the cache interval
+func (c *Client) cacheTTL(fullURL string) time.Duration {
+	if class, ok := cacheClassOf(fullURL); ok {
+		return c.cacheTTLs[class]
+	}
+	return 0
+}
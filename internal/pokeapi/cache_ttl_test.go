@@ -0,0 +1,68 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestCacheClassOf tests that endpoints, including lists with query strings,
+// are sorted into their cache classes
+func TestCacheClassOf(t *testing.T) {
+	tests := []struct {
+		url   string
+		class CacheClass
+		ok    bool
+	}{
+		{baseURL + "/pokemon-species/pikachu", CacheSpecies, true},
+		{baseURL + "/evolution-chain/10/", CacheSpecies, true},
+		{baseURL + "/pokemon/pikachu", CacheGameData, true},
+		{baseURL + "/pokemon?limit=100000", CacheGameData, true},
+		{baseURL + "/move/flamethrower", CacheGameData, true},
+		{baseURL + "/location-area?offset=20&limit=20", CacheLocations, true},
+		{baseURL + "/location/canalave-city", CacheLocations, true},
+		{baseURL + "/berry/cheri", "", false},
+	}
+
+	for _, tt := range tests {
+		class, ok := cacheClassOf(tt.url)
+		if class != tt.class || ok != tt.ok {
+			t.Errorf("cacheClassOf(%q) = %q, %v; want %q, %v", tt.url, class, ok, tt.class, tt.ok)
+		}
+	}
+}
+
+// TestCacheTTLs tests that each class of resource is cached for its own
+// duration, and that other resources use the cache interval
+func TestCacheTTLs(t *testing.T) {
+	hits := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.URL.Path]++
+		w.Write([]byte(`{"name": "test"}`))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{
+		CacheInterval: time.Hour,
+		CacheTTLs:     map[CacheClass]time.Duration{CacheSpecies: time.Nanosecond},
+		Transport:     &testTransport{testServer: server},
+	})
+
+	for i := 0; i < 2; i++ {
+		for _, path := range []string{"/pokemon-species/pikachu", "/pokemon/pikachu"} {
+			if _, err := doGet[PokemonDataResp](context.Background(), client, baseURL+path); err != nil {
+				t.Fatalf("Expected no error for %s, got %v", path, err)
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if hits["/api/v2/pokemon-species/pikachu"] != 2 {
+		t.Errorf("Expected the species to expire after its own duration, got %d requests", hits["/api/v2/pokemon-species/pikachu"])
+	}
+	if hits["/api/v2/pokemon/pikachu"] != 1 {
+		t.Errorf("Expected the Pokémon to be cached for the cache interval, got %d requests", hits["/api/v2/pokemon/pikachu"])
+	}
+}
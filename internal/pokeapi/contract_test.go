@@ -0,0 +1,319 @@
+//go:build integration
+
+// This file contains contract tests that run each client method against the live
+// PokeAPI. They verify that the fields the application relies on still decode,
+// catching upstream schema changes that the mocked unit tests can't detect.
+//
+// The tests make real network requests, so they are excluded from normal runs.
+// Run them with:
+//
+//	go test -tags integration ./internal/pokeapi/
+package pokeapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newContractClient creates a client for contract tests
+func newContractClient(t *testing.T) *Client {
+	t.Helper()
+	return NewClient(time.Minute)
+}
+
+// TestContractListLocationAreas tests that location pages decode with navigation links
+func TestContractListLocationAreas(t *testing.T) {
+	client := newContractClient(t)
+
+	first, err := client.ListLocationAreas(nil, DefaultLocationPageSize)
+	if err != nil {
+		t.Fatalf("ListLocationAreas failed: %v", err)
+	}
+	if first.Count == 0 {
+		t.Error("Expected a non-zero location count")
+	}
+	if len(first.Results) != 20 {
+		t.Errorf("Expected 20 locations on the first page, got %d", len(first.Results))
+	}
+	if first.Next == nil {
+		t.Fatal("Expected a next page URL")
+	}
+	if first.Previous != nil {
+		t.Error("Expected no previous page URL on the first page")
+	}
+
+	second, err := client.ListLocationAreas(first.Next, DefaultLocationPageSize)
+	if err != nil {
+		t.Fatalf("ListLocationAreas (page 2) failed: %v", err)
+	}
+	if second.Previous == nil {
+		t.Error("Expected a previous page URL on the second page")
+	}
+}
+
+// TestContractExploreLocation tests that encounters decode for a canonical location area
+func TestContractExploreLocation(t *testing.T) {
+	client := newContractClient(t)
+
+	resp, err := client.ExploreLocation("canalave-city-area")
+	if err != nil {
+		t.Fatalf("ExploreLocation failed: %v", err)
+	}
+	if len(resp.PokemonEncounters) == 0 {
+		t.Fatal("Expected at least one encounter")
+	}
+	for _, encounter := range resp.PokemonEncounters {
+		if encounter.Pokemon.Name == "" || encounter.Pokemon.URL == "" {
+			t.Errorf("Expected encounter to name a Pokémon, got %+v", encounter.Pokemon)
+		}
+	}
+}
+
+// TestContractGetPokemonData tests that the Pokémon fields used by the commands decode
+func TestContractGetPokemonData(t *testing.T) {
+	client := newContractClient(t)
+
+	for _, name := range []string{"pikachu", "charizard"} {
+		t.Run(name, func(t *testing.T) {
+			pokemon, err := client.GetPokemonData(name)
+			if err != nil {
+				t.Fatalf("GetPokemonData failed: %v", err)
+			}
+			if pokemon.Name != name {
+				t.Errorf("Expected name %s, got %s", name, pokemon.Name)
+			}
+			if pokemon.Height == 0 || pokemon.Weight == 0 {
+				t.Errorf("Expected height and weight, got %d and %d", pokemon.Height, pokemon.Weight)
+			}
+			if len(pokemon.Stats) != expectedStatCount {
+				t.Errorf("Expected %d stats, got %d", expectedStatCount, len(pokemon.Stats))
+			}
+			for _, stat := range pokemon.Stats {
+				if stat.Stat.Name == "" || stat.BaseStat == 0 {
+					t.Errorf("Expected named stat with a base value, got %+v", stat)
+				}
+			}
+			if len(pokemon.Types) == 0 || pokemon.Types[0].Type.Name == "" {
+				t.Error("Expected at least one named type")
+			}
+			if len(pokemon.Moves) == 0 || pokemon.Moves[0].Move.Name == "" {
+				t.Error("Expected at least one named move")
+			}
+			if !strings.HasPrefix(pokemon.Sprites.FrontDefault, "https://") {
+				t.Errorf("Expected a front sprite URL, got %q", pokemon.Sprites.FrontDefault)
+			}
+			if len(pokemon.Abilities) == 0 || pokemon.Abilities[0].Ability.Name == "" {
+				t.Error("Expected at least one named ability")
+			}
+			if pokemon.Species.Name == "" || pokemon.Species.URL == "" {
+				t.Errorf("Expected species reference, got %+v", pokemon.Species)
+			}
+		})
+	}
+}
+
+// TestContractListAllPokemon tests that the full Pokémon list decodes in one page
+func TestContractListAllPokemon(t *testing.T) {
+	client := newContractClient(t)
+
+	resp, err := client.ListAllPokemon()
+	if err != nil {
+		t.Fatalf("ListAllPokemon failed: %v", err)
+	}
+	if resp.Count == 0 {
+		t.Fatal("Expected a non-zero Pokémon count")
+	}
+	if len(resp.Results) != resp.Count {
+		t.Errorf("Expected all %d Pokémon in one page, got %d", resp.Count, len(resp.Results))
+	}
+}
+
+// TestContractGetPokemonSpecies tests that the species fields used by describe and evolve decode
+func TestContractGetPokemonSpecies(t *testing.T) {
+	client := newContractClient(t)
+
+	species, err := client.GetPokemonSpecies("ivysaur")
+	if err != nil {
+		t.Fatalf("GetPokemonSpecies failed: %v", err)
+	}
+	if species.ID != 2 || species.Name != "ivysaur" {
+		t.Errorf("Expected ivysaur (#2), got %s (#%d)", species.Name, species.ID)
+	}
+	if species.CaptureRate == 0 {
+		t.Error("Expected a capture rate")
+	}
+	if len(species.FlavorTextEntries) == 0 || species.FlavorTextEntries[0].Language.Name == "" {
+		t.Error("Expected flavor text entries with languages")
+	}
+	if len(species.Genera) == 0 || species.Genera[0].Genus == "" {
+		t.Error("Expected genera")
+	}
+	if species.EvolutionChain.URL == "" {
+		t.Error("Expected an evolution chain URL")
+	}
+	if species.EvolvesFromSpecies == nil || species.EvolvesFromSpecies.Name != "bulbasaur" {
+		t.Errorf("Expected ivysaur to evolve from bulbasaur, got %+v", species.EvolvesFromSpecies)
+	}
+	if len(species.EggGroups) != 2 {
+		t.Errorf("Expected ivysaur to be in 2 egg groups, got %+v", species.EggGroups)
+	}
+	if species.GenderRate != 1 {
+		t.Errorf("Expected ivysaur's gender rate to be 1, got %d", species.GenderRate)
+	}
+	if len(species.Varieties) == 0 || !species.Varieties[0].IsDefault || species.Varieties[0].Pokemon.Name != "ivysaur" {
+		t.Errorf("Expected ivysaur's default variety to be ivysaur, got %+v", species.Varieties)
+	}
+}
+
+// TestContractGetEggGroup tests that egg groups decode with names and member species
+func TestContractGetEggGroup(t *testing.T) {
+	client := newContractClient(t)
+
+	group, err := client.GetEggGroup("monster")
+	if err != nil {
+		t.Fatalf("GetEggGroup failed: %v", err)
+	}
+	if group.Name != "monster" {
+		t.Errorf("Expected the monster egg group, got %s", group.Name)
+	}
+	if len(group.Names) == 0 || group.Names[0].Language.Name == "" {
+		t.Error("Expected localized names")
+	}
+	found := false
+	for _, species := range group.PokemonSpecies {
+		if species.Name == "bulbasaur" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected bulbasaur in the monster egg group")
+	}
+}
+
+// TestContractGetPokemonCaptureRate tests capture rates for species names and form names
+func TestContractGetPokemonCaptureRate(t *testing.T) {
+	client := newContractClient(t)
+
+	// "giratina-altered" is a form name with no species of the same name
+	for _, name := range []string{"pikachu", "giratina-altered"} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := client.GetPokemonCaptureRate(name)
+			if err != nil {
+				t.Fatalf("GetPokemonCaptureRate failed: %v", err)
+			}
+			if resp.CaptureRate <= 0 || resp.CaptureRate > 255 {
+				t.Errorf("Expected capture rate between 1 and 255, got %d", resp.CaptureRate)
+			}
+		})
+	}
+}
+
+// TestContractEvolutionChain tests that evolution chains decode with species and triggers
+func TestContractEvolutionChain(t *testing.T) {
+	client := newContractClient(t)
+
+	bySpecies, err := client.GetEvolutionChainBySpecies("bulbasaur")
+	if err != nil {
+		t.Fatalf("GetEvolutionChainBySpecies failed: %v", err)
+	}
+	if bySpecies.ID != 1 {
+		t.Errorf("Expected chain 1, got %d", bySpecies.ID)
+	}
+
+	chain, err := client.GetEvolutionChain(1)
+	if err != nil {
+		t.Fatalf("GetEvolutionChain failed: %v", err)
+	}
+	if chain.Chain.Species.Name != "bulbasaur" {
+		t.Errorf("Expected chain to start with bulbasaur, got %s", chain.Chain.Species.Name)
+	}
+	if len(chain.Chain.EvolvesTo) != 1 {
+		t.Fatalf("Expected 1 evolution from bulbasaur, got %d", len(chain.Chain.EvolvesTo))
+	}
+	next := chain.Chain.EvolvesTo[0]
+	if next.Species.Name != "ivysaur" {
+		t.Errorf("Expected ivysaur, got %s", next.Species.Name)
+	}
+	if len(next.EvolutionDetails) == 0 {
+		t.Fatal("Expected evolution details")
+	}
+	if next.EvolutionDetails[0].Trigger.Name != "level-up" || next.EvolutionDetails[0].MinLevel != 16 {
+		t.Errorf("Expected level-up at 16, got %+v", next.EvolutionDetails[0])
+	}
+}
+
+// TestContractGetItem tests that items decode with a cost and English effect text
+func TestContractGetItem(t *testing.T) {
+	client := newContractClient(t)
+
+	item, err := client.GetItem("leftovers")
+	if err != nil {
+		t.Fatalf("GetItem failed: %v", err)
+	}
+	if item.Name != "leftovers" {
+		t.Errorf("Expected leftovers, got %s", item.Name)
+	}
+	if item.Category.Name != "held-items" {
+		t.Errorf("Expected the held-items category, got %s", item.Category.Name)
+	}
+	if item.EnglishEffect() == "" {
+		t.Error("Expected English effect text")
+	}
+}
+
+// TestContractListTypes tests that the type list includes the standard types
+func TestContractListTypes(t *testing.T) {
+	client := newContractClient(t)
+
+	list, err := client.ListTypes()
+	if err != nil {
+		t.Fatalf("ListTypes failed: %v", err)
+	}
+	if len(list.Results) < 18 || list.Results[0].Name != "normal" {
+		t.Errorf("Expected the standard types starting with normal, got %d types", len(list.Results))
+	}
+}
+
+// TestContractGetMove tests that moves decode with the type and power used in battles
+func TestContractGetMove(t *testing.T) {
+	client := newContractClient(t)
+
+	move, err := client.GetMove("flamethrower")
+	if err != nil {
+		t.Fatalf("GetMove failed: %v", err)
+	}
+	if move.Type.Name != "fire" || move.DamageClass.Name != "special" {
+		t.Errorf("Expected a special Fire move, got %s (%s)", move.Type.Name, move.DamageClass.Name)
+	}
+	if move.Power == nil || *move.Power == 0 {
+		t.Error("Expected flamethrower to have power")
+	}
+	if move.Accuracy == nil || *move.Accuracy == 0 {
+		t.Error("Expected flamethrower to have accuracy")
+	}
+	if move.Meta == nil || move.Meta.Ailment.Name != "burn" || move.Meta.AilmentChance == 0 {
+		t.Errorf("Expected flamethrower to have a chance to burn, got %+v", move.Meta)
+	}
+}
+
+// TestContractListNatures tests that there are 25 natures and that they decode
+// with the stats they change
+func TestContractListNatures(t *testing.T) {
+	client := newContractClient(t)
+
+	list, err := client.ListNatures()
+	if err != nil {
+		t.Fatalf("ListNatures failed: %v", err)
+	}
+	if len(list.Results) != 25 {
+		t.Errorf("Expected 25 natures, got %d", len(list.Results))
+	}
+	nature, err := client.GetNature("modest")
+	if err != nil {
+		t.Fatalf("GetNature failed: %v", err)
+	}
+	if nature.Neutral() || nature.IncreasedStat.Name != "special-attack" || nature.DecreasedStat.Name != "attack" {
+		t.Errorf("Expected Modest to raise Sp. Atk and lower Attack, got %+v", nature)
+	}
+}
@@ -0,0 +1,25 @@
+package pokeapi
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// GetEggGroup retrieves an egg group and the species that belong to it.
+// This is used to work out which Pokémon can breed with each other.
+// Results are cached to improve performance and reduce API calls.
+//
+// Parameters:
+//   - groupName: The name of the egg group (e.g. "monster")
+//
+// Returns:
+//   - An EggGroupResp containing the group's names and member species
+//   - An error if the API request fails or the egg group doesn't exist
+func (c *Client) GetEggGroup(groupName string) (EggGroupResp, error) {
+	fullURL := baseURL + "/egg-group/" + groupName
+
+	return doGet[EggGroupResp](c.context(), c, fullURL,
+		withDecodeHook(validateEggGroup),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(errorhandling.ResourceEggGroup, groupName, err)
+		}))
+}
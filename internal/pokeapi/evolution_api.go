@@ -1,12 +1,8 @@
 package pokeapi
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
 )
@@ -36,20 +32,9 @@ func (c *Client) GetEvolutionChainBySpecies(pokemonName string) (EvolutionChainR
 	}
 
 	// Extract the evolution chain ID from the URL
-	parts := strings.Split(evolutionURL, "/")
-	if len(parts) < 2 {
-		return EvolutionChainResp{}, fmt.Errorf("invalid evolution chain URL format: %s", evolutionURL)
-	}
-
-	// The ID should be the last part of the URL (minus any trailing slash)
-	idStr := parts[len(parts)-1]
-	if idStr == "" && len(parts) >= 3 {
-		idStr = parts[len(parts)-2] // Handle trailing slash
-	}
-
-	id, err := strconv.Atoi(idStr)
+	id, err := NamedAPIResource{URL: evolutionURL}.ID()
 	if err != nil {
-		return EvolutionChainResp{}, fmt.Errorf("invalid evolution chain ID: %s", idStr)
+		return EvolutionChainResp{}, fmt.Errorf("invalid evolution chain URL: %w", err)
 	}
 
 	// Now get the evolution chain data
@@ -83,59 +68,14 @@ func getEvolutionChainURL(speciesData PokemonSpeciesResp) (string, error) {
 //   - An EvolutionChainResp containing the complete evolution chain data
 //   - An error if the API request fails or the evolution chain doesn't exist
 func (c *Client) GetEvolutionChain(id int) (EvolutionChainResp, error) {
-	endpoint := "/evolution-chain/"
-	fullURL := baseURL + endpoint + strconv.Itoa(id)
-
-	// Check cache
-	data, ok := c.cache.Get(fullURL)
-	if ok {
-		evolutionChainResp := EvolutionChainResp{}
-		err := json.Unmarshal(data, &evolutionChainResp)
-		if err != nil {
-			return EvolutionChainResp{}, fmt.Errorf("error unmarshaling cached evolution data: %w", err)
-		}
-		return evolutionChainResp, nil
-	}
+	fullURL := baseURL + "/evolution-chain/" + strconv.Itoa(id)
 
-	// Create a new HTTP request
-	req, err := http.NewRequest("GET", fullURL, nil)
-	if err != nil {
-		return EvolutionChainResp{}, errorhandling.NewNetworkError("Failed to create HTTP request", err)
-	}
-
-	// Send the request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return EvolutionChainResp{}, errorhandling.NewNetworkError("Failed to connect to the Pokémon API", err)
-	}
-	defer resp.Body.Close()
-
-	// Check if the response was successful
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		if resp.StatusCode == http.StatusNotFound {
-			return EvolutionChainResp{}, errorhandling.FormatResourceNotFoundError(
+	return doGet[EvolutionChainResp](c.context(), c, fullURL,
+		withDecodeHook(validateEvolutionChain),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(
 				errorhandling.ResourceEvolutionChain,
 				fmt.Sprintf("ID: %d", id),
-				fmt.Errorf("HTTP 404"))
-		}
-		return EvolutionChainResp{}, errorhandling.NewAPIError(resp.StatusCode, fmt.Sprintf("%s%d", endpoint, id), fmt.Errorf("HTTP error: %d", resp.StatusCode))
-	}
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return EvolutionChainResp{}, fmt.Errorf("error reading response body: %w", err)
-	}
-
-	// Store in cache
-	c.cache.Add(fullURL, body)
-
-	// Unmarshal the response into the appropriate struct
-	evolutionChainResp := EvolutionChainResp{}
-	err = json.Unmarshal(body, &evolutionChainResp)
-	if err != nil {
-		return EvolutionChainResp{}, fmt.Errorf("error unmarshaling response: %w", err)
-	}
-
-	return evolutionChainResp, nil
+				err)
+		}))
 }
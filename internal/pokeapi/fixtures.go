@@ -0,0 +1,139 @@
+// This file implements offline fixture support for the PokeAPI client.
+// In replay mode, responses are served from JSON files on disk instead of the
+// network, enabling development, demos, and end-to-end tests without the real
+// API. In record mode, real API responses are saved to disk as they are fetched
+// so that a fixture directory can be built simply by using the application.
+package pokeapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// fixtureQueryReplacer makes query strings safe to use in file names.
+var fixtureQueryReplacer = strings.NewReplacer("&", "_", "=", "-", "/", "_")
+
+// fixtureTransport is an http.RoundTripper that serves or records fixtures.
+type fixtureTransport struct {
+	dir    string            // Directory containing the fixture files
+	record bool              // Whether to fetch from the network and save responses
+	next   http.RoundTripper // Transport used to reach the real API when recording
+}
+
+// UseFixtures switches the client to serve responses from fixture files on disk.
+// Each API path maps to a JSON file under dir; for example, "/api/v2/pokemon/pikachu"
+// maps to "pokemon/pikachu.json". Requests without a fixture receive a 404 response.
+//
+// When record is true, requests are sent to the real API instead and every
+// successful response is written to its fixture file, creating directories as needed.
+//
+// Parameters:
+//   - dir: The directory containing (or receiving) the fixture files
+//   - record: Whether to record real responses rather than replay saved ones
+//
+// UseFixtures changes the client's transport, so call it before the client is
+// shared with other goroutines.
+func (c *Client) UseFixtures(dir string, record bool) {
+	next := c.httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	c.httpClient.Transport = &fixtureTransport{
+		dir:    dir,
+		record: record,
+		next:   next,
+	}
+}
+
+// FixturesDir returns the directory the client's responses are replayed from
+// or recorded to, or "" if it uses the real API without fixtures.
+//
+// Returns:
+//   - The fixture directory, and whether responses are being recorded to it
+func (c *Client) FixturesDir() (string, bool) {
+	if t, ok := c.httpClient.Transport.(*fixtureTransport); ok {
+		return t.dir, t.record
+	}
+	return "", false
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	path := t.fixturePath(req.URL)
+
+	if t.record {
+		return t.recordResponse(req, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return fixtureResponse(req, http.StatusNotFound, []byte(`{"detail": "No fixture recorded"}`)), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading fixture %s: %w", path, err)
+	}
+	return fixtureResponse(req, http.StatusOK, data), nil
+}
+
+// recordResponse sends the request to the real API and saves successful responses.
+func (t *fixtureTransport) recordResponse(req *http.Request, path string) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, nil
+	}
+
+	// Read the body so it can be both saved and returned to the caller
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("error creating fixture directory: %w", err)
+	}
+	if err := os.WriteFile(path, body, 0644); err != nil {
+		return nil, fmt.Errorf("error writing fixture %s: %w", path, err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// fixturePath returns the fixture file path for a request URL.
+// The API version prefix is dropped, and any query string is appended to the
+// file name in a file-system-safe form (e.g. "location-area__offset-0_limit-20.json").
+func (t *fixtureTransport) fixturePath(u *url.URL) string {
+	name := strings.Trim(strings.TrimPrefix(u.Path, "/api/v2"), "/")
+	if name == "" {
+		name = "index"
+	}
+	if u.RawQuery != "" {
+		name += "__" + fixtureQueryReplacer.Replace(u.RawQuery)
+	}
+	return filepath.Join(t.dir, filepath.FromSlash(name)+".json")
+}
+
+// fixtureResponse builds an HTTP response with a JSON body.
+func fixtureResponse(req *http.Request, statusCode int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,96 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// TestFixtures tests that responses recorded from the API can be replayed
+// from disk without a network connection
+func TestFixtures(t *testing.T) {
+	dir := t.TempDir()
+	pikachu, err := json.Marshal(testPokemonData("pikachu", "pikachu"))
+	if err != nil {
+		t.Fatalf("Failed to marshal test data: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/pokemon/pikachu":
+			w.Write(pikachu)
+		case "/api/v2/location-area":
+			w.Write([]byte(`{"count": 1, "results": [{"name": "canalave-city-area", "url": "https://pokeapi.co/api/v2/location-area/1/"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Run("Record mode saves responses", func(t *testing.T) {
+		client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+		if fixtures, _ := client.FixturesDir(); fixtures != "" {
+			t.Errorf("Expected no fixtures before UseFixtures, got %q", fixtures)
+		}
+		client.UseFixtures(dir, true)
+		if fixtures, record := client.FixturesDir(); fixtures != dir || !record {
+			t.Errorf("Expected to record to %q, got %q (record=%v)", dir, fixtures, record)
+		}
+
+		if _, err := client.GetPokemonData("pikachu"); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := client.ListLocationAreas(nil, DefaultLocationPageSize); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := client.GetPokemonData("missingno"); err == nil {
+			t.Fatal("Expected an error for a missing Pokémon")
+		}
+
+		for _, name := range []string{"pokemon/pikachu.json", "location-area__offset-0_limit-20.json"} {
+			if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+				t.Errorf("Expected fixture %s to be recorded: %v", name, err)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, "pokemon", "missingno.json")); !os.IsNotExist(err) {
+			t.Error("Expected failed responses not to be recorded")
+		}
+	})
+
+	t.Run("Replay mode serves recorded responses", func(t *testing.T) {
+		client := NewClient(time.Minute)
+		client.UseFixtures(dir, false)
+
+		pokemon, err := client.GetPokemonData("pikachu")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if pokemon.Name != "pikachu" {
+			t.Errorf("Expected pikachu, got %s", pokemon.Name)
+		}
+
+		locations, err := client.ListLocationAreas(nil, DefaultLocationPageSize)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(locations.Results) != 1 {
+			t.Errorf("Expected 1 location, got %d", len(locations.Results))
+		}
+	})
+
+	t.Run("Missing fixtures are not found", func(t *testing.T) {
+		client := NewClient(time.Minute)
+		client.UseFixtures(dir, false)
+
+		_, err := client.GetPokemonData("bulbasaur")
+		if !errorhandling.IsNotFoundError(err) {
+			t.Errorf("Expected a not found error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package pokeapi
+
+import (
+	"strconv"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// GetGeneration retrieves the list of Pokémon species introduced in a generation.
+// This is used by the "checklist" command to show which species the user has caught.
+// Results are cached to improve performance and reduce API calls.
+//
+// Parameters:
+//   - id: The generation number (1 for Red/Blue/Yellow, 2 for Gold/Silver/Crystal, etc.)
+//
+// Returns:
+//   - A GenerationResp containing the generation's species
+//   - An error if the API request fails or the generation doesn't exist
+func (c *Client) GetGeneration(id int) (GenerationResp, error) {
+	fullURL := baseURL + "/generation/" + strconv.Itoa(id)
+
+	return doGet[GenerationResp](c.context(), c, fullURL,
+		withDecodeHook(validateGeneration),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(errorhandling.ResourceGeneration, strconv.Itoa(id), err)
+		}))
+}
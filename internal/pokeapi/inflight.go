@@ -0,0 +1,91 @@
+// This file implements request coalescing. When several goroutines ask for the
+// same URL before the first response has been cached, such as prefetchers and
+// batch catches looking up the same Pokémon, only the first sends an HTTP request
+// and the others wait for its result instead of sending duplicates. Each
+// waiter stops waiting when its own context is cancelled, and a request that
+// was only cancelled for the caller who sent it is sent again for the others.
+package pokeapi
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// inflightGroup tracks the HTTP requests in progress, by URL.
+// The zero value is ready to use, and it is safe for concurrent use.
+type inflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+// inflightCall is an HTTP request in progress, whose result is shared by
+// everyone who asked for the same URL while it was running.
+type inflightCall struct {
+	done chan struct{} // Closed when the request has finished
+	body []byte        // The response body, once done
+	err  error         // The error the request failed with, once done
+
+	waiters   int  // How many other callers have joined it, guarded by the group's mutex
+	cancelled bool // Whether it failed because the context of the caller who sent it was cancelled
+}
+
+// do runs fetch for a URL, unless a request for the same URL is already in
+// progress, in which case it waits for that request and returns its result.
+// If that request fails because the caller who sent it cancelled it, the
+// request is made again, by whichever waiter gets there first.
+//
+// Parameters:
+//   - ctx: The caller's context; fetch must use it for the request, and
+//     waiting for another caller's request stops when it's cancelled
+//   - key: The URL being requested
+//   - fetch: Performs the request
+//
+// Returns:
+//   - The response body
+//   - Whether the result was shared from a request made by another caller
+//   - The error the request failed with, if any
+func (g *inflightGroup) do(ctx context.Context, key string, fetch func() ([]byte, error)) ([]byte, bool, error) {
+	for {
+		g.mu.Lock()
+		call, ok := g.calls[key]
+		if !ok {
+			if g.calls == nil {
+				g.calls = make(map[string]*inflightCall)
+			}
+			call = &inflightCall{done: make(chan struct{})}
+			g.calls[key] = call
+			g.mu.Unlock()
+			return g.run(ctx, key, call, fetch)
+		}
+		call.waiters++
+		g.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, false, errorhandling.NewNetworkError("Request to the Pokémon API was cancelled", ctx.Err())
+		}
+		if !call.cancelled {
+			return call.body, true, call.err
+		}
+	}
+}
+
+// run performs a request that other callers may be waiting for, and hands
+// its result to them.
+func (g *inflightGroup) run(ctx context.Context, key string, call *inflightCall, fetch func() ([]byte, error)) ([]byte, bool, error) {
+	// Remove the call before waking the waiters, so that later requests
+	// start afresh rather than reusing a finished (possibly failed) one
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.body, call.err = fetch()
+	call.cancelled = call.err != nil && ctx.Err() != nil
+	return call.body, false, call.err
+}
@@ -0,0 +1,126 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// startInflight starts a request with the given context whose fetch blocks
+// until the context is cancelled or release is closed, and returns once the
+// request is in progress.
+func startInflight(ctx context.Context, g *inflightGroup, release <-chan struct{}) <-chan error {
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(ctx, "pikachu", func() ([]byte, error) {
+			close(started)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-release:
+				return nil, errors.New("server error")
+			}
+		})
+		result <- err
+	}()
+	<-started
+	return result
+}
+
+// waitForWaiter waits until another caller has joined the request in progress
+// for pikachu, so that the request isn't finished before it starts waiting.
+func waitForWaiter(t *testing.T, g *inflightGroup) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		g.mu.Lock()
+		call := g.calls["pikachu"]
+		joined := call != nil && call.waiters > 0
+		g.mu.Unlock()
+		if joined {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected a caller to join the request in progress")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// TestInflightWaiterCancelled tests that a caller waiting for another's request
+// stops waiting when its own context is cancelled
+func TestInflightWaiterCancelled(t *testing.T) {
+	var g inflightGroup
+	release := make(chan struct{})
+	defer close(release)
+	startInflight(context.Background(), &g, release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := g.do(ctx, "pikachu", func() ([]byte, error) {
+			t.Error("Expected the waiter not to send its own request")
+			return nil, nil
+		})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected the waiter to be cancelled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the waiter to stop waiting once cancelled")
+	}
+}
+
+// TestInflightRetriesAfterSenderCancelled tests that a request cancelled by the
+// caller who sent it is sent again for those waiting for it, while other
+// failures are shared
+func TestInflightRetriesAfterSenderCancelled(t *testing.T) {
+	var g inflightGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	sender := startInflight(ctx, &g, nil)
+
+	type outcome struct {
+		body   []byte
+		shared bool
+		err    error
+	}
+	waiter := make(chan outcome, 1)
+	go func() {
+		body, shared, err := g.do(context.Background(), "pikachu", func() ([]byte, error) {
+			return []byte("pikachu"), nil
+		})
+		waiter <- outcome{body, shared, err}
+	}()
+	waitForWaiter(t, &g)
+	cancel()
+
+	if err := <-sender; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the sender's request to be cancelled, got %v", err)
+	}
+	if got := <-waiter; got.err != nil || string(got.body) != "pikachu" || got.shared {
+		t.Errorf("Expected the waiter to send the request again, got %+v", got)
+	}
+
+	// A request that fails for any other reason fails for its waiters too
+	release := make(chan struct{})
+	sender = startInflight(context.Background(), &g, release)
+	go func() {
+		body, shared, err := g.do(context.Background(), "pikachu", func() ([]byte, error) {
+			t.Error("Expected the failed request to be shared, not sent again")
+			return nil, nil
+		})
+		waiter <- outcome{body, shared, err}
+	}()
+	waitForWaiter(t, &g)
+	close(release)
+	<-sender
+	if got := <-waiter; got.err == nil || !got.shared {
+		t.Errorf("Expected the waiter to share the failure, got %+v", got)
+	}
+}
@@ -0,0 +1,24 @@
+package pokeapi
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// GetItem retrieves an item's price, category, and effect descriptions.
+// Results are cached to improve performance and reduce API calls.
+//
+// Parameters:
+//   - itemName: The name of the item (e.g. "leftovers")
+//
+// Returns:
+//   - An ItemResp containing the item's data
+//   - An error if the API request fails or the item doesn't exist
+func (c *Client) GetItem(itemName string) (ItemResp, error) {
+	fullURL := baseURL + "/item/" + itemName
+
+	return doGet[ItemResp](c.context(), c, fullURL,
+		withDecodeHook(validateItem),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(errorhandling.ResourceItem, itemName, err)
+		}))
+}
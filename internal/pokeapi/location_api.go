@@ -1,14 +1,20 @@
 package pokeapi
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"regexp"
+	"strings"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
 )
 
+// DefaultLocationPageSize is the number of location areas on a page of the
+// location list, unless another size is asked for.
+const DefaultLocationPageSize = 20
+
+// pageLimitPattern matches the limit parameter in the URL of a page.
+var pageLimitPattern = regexp.MustCompile(`([?&])limit=\d*`)
+
 // ListLocationAreas retrieves a paginated list of location areas from the PokeAPI.
 // Location areas are specific places within the Pokémon world where Pokémon can be encountered.
 // This function supports pagination, allowing navigation through all available locations.
@@ -16,66 +22,23 @@ import (
 //
 // Parameters:
 //   - pageURL: Optional URL for a specific page of results. If nil, retrieves the first page.
+//   - pageSize: The number of location areas on the page (DefaultLocationPageSize if zero).
+//     The page of pageURL starts where it did, with this many areas.
 //
 // Returns:
 //   - A LocationAreasResp containing the list of location areas and pagination URLs
 //   - An error if the API request fails
-func (c *Client) ListLocationAreas(pageURL *string) (LocationAreasResp, error) {
-	endpoint := "/location-area?offset=0&limit=20"
-	fullURL := baseURL + endpoint
-	if pageURL != nil {
-		fullURL = *pageURL
-	}
-
-	data, ok := c.cache.Get(fullURL)
-	if ok {
-		locationAreasResp := LocationAreasResp{}
-		err := json.Unmarshal(data, &locationAreasResp)
-		if err != nil {
-			return LocationAreasResp{}, fmt.Errorf("error unmarshaling cached location data: %w", err)
-		}
-		return locationAreasResp, nil
-	}
-
-	// Create a new HTTP request
-	req, err := http.NewRequest("GET", fullURL, nil)
-	if err != nil {
-		return LocationAreasResp{}, errorhandling.NewNetworkError("Failed to create HTTP request", err)
-	}
-
-	// Send the request
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return LocationAreasResp{}, errorhandling.NewNetworkError("Failed to connect to the Pokémon API", err)
-	}
-	defer resp.Body.Close()
-
-	// Check if the response was successful
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		reqEndpoint := endpoint
-		if pageURL != nil {
-			reqEndpoint = *pageURL
-		}
-		return LocationAreasResp{}, errorhandling.NewAPIError(resp.StatusCode, reqEndpoint, fmt.Errorf("HTTP error: %d", resp.StatusCode))
+func (c *Client) ListLocationAreas(pageURL *string, pageSize int) (LocationAreasResp, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultLocationPageSize
 	}
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return LocationAreasResp{}, fmt.Errorf("error reading response body: %w", err)
-	}
-
-	// Store in cache
-	c.cache.Add(fullURL, body)
-
-	// Unmarshal the response into the appropriate struct
-	locationAreasResp := LocationAreasResp{}
-	err = json.Unmarshal(body, &locationAreasResp)
-	if err != nil {
-		return LocationAreasResp{}, fmt.Errorf("error unmarshaling response: %w", err)
+	fullURL := fmt.Sprintf("%s/location-area?offset=0&limit=%d", baseURL, pageSize)
+	if pageURL != nil {
+		fullURL = withPageLimit(*pageURL, pageSize)
 	}
 
-	return locationAreasResp, nil
+	return doGet[LocationAreasResp](c.context(), c, fullURL,
+		withDecodeHook(validateLocationAreas))
 }
 
 // ExploreLocation retrieves a list of Pokémon that can be encountered at a specific location area.
@@ -90,55 +53,89 @@ func (c *Client) ListLocationAreas(pageURL *string) (LocationAreasResp, error) {
 //   - A LocationExploreResp containing the list of Pokémon encounters at the location
 //   - An error if the API request fails or the location doesn't exist
 func (c *Client) ExploreLocation(location string) (LocationExploreResp, error) {
-	endpoint := "/location-area/"
-	fullURL := baseURL + endpoint + location
-
-	data, ok := c.cache.Get(fullURL)
-	if ok {
-		locationExploreResp := LocationExploreResp{}
-		err := json.Unmarshal(data, &locationExploreResp)
-		if err != nil {
-			return LocationExploreResp{}, fmt.Errorf("error unmarshaling cached location data: %w", err)
-		}
-		return locationExploreResp, nil
-	}
+	fullURL := baseURL + "/location-area/" + location
 
-	// Create a new HTTP request
-	req, err := http.NewRequest("GET", fullURL, nil)
-	if err != nil {
-		return LocationExploreResp{}, errorhandling.NewNetworkError("Failed to create HTTP request", err)
-	}
+	return doGet[LocationExploreResp](c.context(), c, fullURL,
+		withDecodeHook(validateLocationExplore),
+		withNotFound(func(err error) error {
+			return errorhandling.LocationNotFoundError(location, err)
+		}))
+}
 
-	// Send the request
-	resp, err := c.httpClient.Do(req)
+// GetLocationArea retrieves the details of a single location area.
+// This uses the same endpoint as ExploreLocation, so exploring an area after
+// looking it up (or vice versa) is served from the cache.
+//
+// Parameters:
+//   - area: The name or ID of the location area (in lowercase with hyphens)
+//
+// Returns:
+//   - A LocationAreaResp containing the area's name and parent location
+//   - An error if the API request fails or the location area doesn't exist
+func (c *Client) GetLocationArea(area string) (LocationAreaResp, error) {
+	fullURL := baseURL + "/location-area/" + area
+
+	return doGet[LocationAreaResp](c.context(), c, fullURL,
+		withDecodeHook(validateLocationArea),
+		withNotFound(func(err error) error {
+			return errorhandling.LocationNotFoundError(area, err)
+		}))
+}
+
+// GetLocation retrieves the details of a location, including its region.
+//
+// Parameters:
+//   - location: The name or ID of the location (in lowercase with hyphens)
+//
+// Returns:
+//   - A LocationResp containing the location's name and region
+//   - An error if the API request fails or the location doesn't exist
+func (c *Client) GetLocation(location string) (LocationResp, error) {
+	fullURL := baseURL + "/location/" + location
+
+	return doGet[LocationResp](c.context(), c, fullURL,
+		withDecodeHook(validateLocation),
+		withNotFound(func(err error) error {
+			return errorhandling.LocationNotFoundError(location, err)
+		}))
+}
+
+// GetLocationAreaRegion looks up the name of the region a location area is in.
+// It fetches the area and then its parent location; both responses are cached.
+//
+// Parameters:
+//   - area: The name of the location area (in lowercase with hyphens)
+//
+// Returns:
+//   - The region name (e.g. "kanto"), or an empty string if the location has no region
+//   - An error if either API request fails
+func (c *Client) GetLocationAreaRegion(area string) (string, error) {
+	areaData, err := c.GetLocationArea(area)
 	if err != nil {
-		return LocationExploreResp{}, errorhandling.NewNetworkError("Failed to connect to the Pokémon API", err)
-	}
-	defer resp.Body.Close()
-
-	// Check if the response was successful
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		if resp.StatusCode == http.StatusNotFound {
-			return LocationExploreResp{}, errorhandling.LocationNotFoundError(location, fmt.Errorf("HTTP 404"))
-		}
-		return LocationExploreResp{}, errorhandling.NewAPIError(resp.StatusCode, endpoint+location, fmt.Errorf("HTTP error: %d", resp.StatusCode))
+		return "", err
 	}
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
+	location, err := c.GetLocation(areaData.Location.Name)
 	if err != nil {
-		return LocationExploreResp{}, fmt.Errorf("error reading response body: %w", err)
+		return "", err
 	}
 
-	// Store in cache
-	c.cache.Add(fullURL, body)
-
-	// Unmarshal the response into the appropriate struct
-	locationExploreResp := LocationExploreResp{}
-	err = json.Unmarshal(body, &locationExploreResp)
-	if err != nil {
-		return LocationExploreResp{}, fmt.Errorf("error unmarshaling response: %w", err)
+	if location.Region == nil {
+		return "", nil
 	}
+	return location.Region.Name, nil
+}
 
-	return locationExploreResp, nil
+// withPageLimit changes the number of results on the page of a URL. The limit
+// is replaced in place, so that URLs for the default size stay the same as the
+// ones the API returns, for the cache and for fixtures.
+func withPageLimit(pageURL string, limit int) string {
+	if pageLimitPattern.MatchString(pageURL) {
+		return pageLimitPattern.ReplaceAllString(pageURL, fmt.Sprintf("${1}limit=%d", limit))
+	}
+	separator := "?"
+	if strings.Contains(pageURL, "?") {
+		separator = "&"
+	}
+	return fmt.Sprintf("%s%slimit=%d", pageURL, separator, limit)
 }
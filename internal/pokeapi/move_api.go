@@ -0,0 +1,24 @@
+package pokeapi
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// GetMove retrieves a move's type, power, and accuracy.
+// Results are cached to improve performance and reduce API calls.
+//
+// Parameters:
+//   - moveName: The name of the move (e.g. "flamethrower")
+//
+// Returns:
+//   - A MoveResp containing the move's data
+//   - An error if the API request fails or the move doesn't exist
+func (c *Client) GetMove(moveName string) (MoveResp, error) {
+	fullURL := baseURL + "/move/" + moveName
+
+	return doGet[MoveResp](c.context(), c, fullURL,
+		withDecodeHook(validateMove),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(errorhandling.ResourcePokemonMove, moveName, err)
+		}))
+}
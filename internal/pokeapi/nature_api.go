@@ -0,0 +1,37 @@
+package pokeapi
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// ListNatures retrieves the names of every nature in the PokeAPI, in the order
+// of their IDs. Results are cached to improve performance and reduce API calls.
+//
+// Returns:
+//   - A NatureListResp containing a NamedAPIResource for every nature
+//   - An error if the API request fails
+func (c *Client) ListNatures() (NatureListResp, error) {
+	fullURL := baseURL + "/nature?limit=100"
+
+	return doGet[NatureListResp](c.context(), c, fullURL,
+		withDecodeHook(validateNatureList))
+}
+
+// GetNature retrieves the stats a nature raises and lowers and the flavors it
+// likes and hates. Results are cached to improve performance and reduce API calls.
+//
+// Parameters:
+//   - name: The name of the nature (e.g. "adamant")
+//
+// Returns:
+//   - A NatureResp containing the nature's data
+//   - An error if the API request fails or the nature doesn't exist
+func (c *Client) GetNature(name string) (NatureResp, error) {
+	fullURL := baseURL + "/nature/" + name
+
+	return doGet[NatureResp](c.context(), c, fullURL,
+		withDecodeHook(validateNature),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(errorhandling.ResourceNature, name, err)
+		}))
+}
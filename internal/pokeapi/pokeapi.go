@@ -12,7 +12,8 @@
 //
 // Usage Example:
 //
-//	// Create a new client with 1-hour cache duration
+//	// Create a new client with 1-hour cache duration. Clients are safe for
+//	// concurrent use, so create one and share it by pointer.
 //	client := pokeapi.NewClient(time.Hour)
 //
 //	// Get data for a specific Pokemon
@@ -26,7 +27,16 @@
 package pokeapi
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sync/atomic"
 	"time"
 
 	"github.com/bmlevitt/pokedexcli/internal/pokecache"
@@ -35,27 +45,253 @@ import (
 // baseURL is the root endpoint for the PokeAPI v2 service
 const baseURL = "https://pokeapi.co/api/v2"
 
+// defaultTimeout is the time limit for each request when ClientOptions doesn't set one.
+const defaultTimeout = time.Minute
+
+// projectURL identifies the application in the User-Agent header, so that the
+// PokeAPI maintainers can see where requests come from and get in touch.
+const projectURL = "https://github.com/bmlevitt/pokedexcli"
+
+// UserAgent returns the User-Agent header value for a version of the application.
+//
+// Parameters:
+//   - version: The application version (e.g. "v1.2.0")
+//
+// Returns:
+//   - A User-Agent such as "pokedexcli/v1.2.0 (+https://github.com/bmlevitt/pokedexcli)"
+func UserAgent(version string) string {
+	return fmt.Sprintf("pokedexcli/%s (+%s)", version, projectURL)
+}
+
+// sharedTransport is the HTTP transport used by every client that isn't given its own.
+// Sharing one transport lets clients reuse open connections to the API instead of
+// each keeping a separate pool. Since every request goes to the same host, the
+// per-host idle connection limit is raised from the standard library default of 2.
+var sharedTransport = newSharedTransport()
+
+// newSharedTransport creates the transport shared by clients, based on the
+// standard library's default transport so that proxy settings are honored.
+func newSharedTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 16
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
+
+// networkTransport returns the transport for a client's network settings:
+// the shared transport if they're the defaults, or a transport of its own.
+func networkTransport(opts ClientOptions) *http.Transport {
+	if opts.Proxy == nil && opts.RootCAs == nil && !opts.Insecure {
+		return sharedTransport
+	}
+	transport := newSharedTransport()
+	if opts.Proxy != nil {
+		transport.Proxy = http.ProxyURL(opts.Proxy)
+	}
+	if opts.RootCAs != nil || opts.Insecure {
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:            opts.RootCAs,
+			InsecureSkipVerify: opts.Insecure,
+		}
+	}
+	return transport
+}
+
+// LoadCABundle reads a PEM file of certificate authorities, such as a
+// company's own, and returns them with the system's for ClientOptions.RootCAs.
+//
+// Parameters:
+//   - path: The path of the PEM file
+//
+// Returns:
+//   - The system's certificate authorities and those in the file
+//   - An error if the file can't be read or holds no certificates
+func LoadCABundle(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
+
 // Client represents a PokeAPI client that handles API requests with caching.
 // It uses an internal cache to reduce the number of HTTP requests made to the API,
 // improving performance and reducing load on the API service.
+//
+// A Client is safe for concurrent use by multiple goroutines and should be
+// shared by pointer; create one per application rather than one per request.
 type Client struct {
-	cache      pokecache.Cache // Cache for storing API responses
-	httpClient http.Client     // HTTP client for making API requests
+	cache           *pokecache.Cache             // Cache for storing API responses
+	httpClient      *http.Client                 // HTTP client for making API requests
+	header          http.Header                  // Headers sent with every request
+	retryDelay      time.Duration                // Wait before the first retry of a failed request
+	stats           *requestStats                // Counters for HTTP requests and cache hits
+	inflight        *inflightGroup               // HTTP requests in progress, shared by concurrent callers
+	cacheTTLs       map[CacheClass]time.Duration // How long responses are cached for each class of resource
+	ctx             context.Context              // Cancels the client's requests (nil for never; see WithContext)
+	uncached        bool                         // Whether responses are decoded as they arrive instead of cached (see WithoutCaching)
+	maxResponseSize int64                        // The largest response body accepted, in bytes
+	onSpecies       func(PokemonSpeciesResp)     // Called with every species retrieved (nil for none)
+}
+
+// ClientOptions configures a new Client. Zero values select the defaults.
+type ClientOptions struct {
+	CacheInterval   time.Duration                // How long cached responses remain valid (required)
+	CacheTTLs       map[CacheClass]time.Duration // How long each class of resource is cached, overriding CacheInterval (see DefaultCacheTTLs)
+	Timeout         time.Duration                // Time limit for each HTTP request (default 1 minute)
+	Transport       http.RoundTripper            // Transport for HTTP requests (default: a pooled transport shared by all clients)
+	UserAgent       string                       // User-Agent header for every request (default: UserAgent("dev"))
+	Header          http.Header                  // Extra headers for every request, replacing defaults with the same name
+	MaxResponseSize int64                        // The largest response body accepted, in bytes (default 16 MB)
+	OnSpecies       func(PokemonSpeciesResp)     // Called with every species retrieved, such as to remember their names (optional; must be safe for concurrent use)
+
+	// Network settings, for users behind a corporate proxy. They're ignored
+	// if Transport is set.
+	Proxy    *url.URL       // Proxy for every request (default: HTTP_PROXY, HTTPS_PROXY, and NO_PROXY from the environment)
+	RootCAs  *x509.CertPool // Certificate authorities trusted for HTTPS (default: the system's; see LoadCABundle)
+	Insecure bool           // Skip verifying the API's certificate; only for development, never for real use
+}
+
+// requestStats counts the work done by a client. The counters are safe for concurrent use.
+type requestStats struct {
+	apiCalls  atomic.Int64 // Number of HTTP requests sent to the API
+	cacheHits atomic.Int64 // Number of requests served from the cache
+	coalesced atomic.Int64 // Number of requests that shared an identical request in progress
+}
+
+// RequestStats is a snapshot of a client's request counters.
+// Subtracting two snapshots gives the work done between them.
+type RequestStats struct {
+	APICalls  int64 // Number of HTTP requests sent to the API
+	CacheHits int64 // Number of requests served from the cache
+	Coalesced int64 // Number of requests that shared an identical request in progress
 }
 
-// NewClient creates a new PokeAPI client with the specified cache duration.
-// The cache helps avoid redundant API calls by storing responses for the specified duration.
+// Stats returns a snapshot of the number of API calls, cache hits, and
+// coalesced requests the client has made since it was created.
+func (c *Client) Stats() RequestStats {
+	return RequestStats{
+		APICalls:  c.stats.apiCalls.Load(),
+		CacheHits: c.stats.cacheHits.Load(),
+		Coalesced: c.stats.coalesced.Load(),
+	}
+}
+
+// Sub returns the difference between two snapshots.
+func (s RequestStats) Sub(earlier RequestStats) RequestStats {
+	return RequestStats{
+		APICalls:  s.APICalls - earlier.APICalls,
+		CacheHits: s.CacheHits - earlier.CacheHits,
+		Coalesced: s.Coalesced - earlier.Coalesced,
+	}
+}
+
+// WithContext returns a copy of the client whose requests are cancelled when
+// ctx is. The copy shares the original's cache, connections, and counters, so
+// it's cheap to make one for each long-running task that may be cancelled.
+// A request cancelled while other callers were waiting for the same URL
+// fails for them too.
+//
+// Parameters:
+//   - ctx: The context for the copy's requests
+//
+// Returns:
+//   - The copy of the client
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
+}
+
+// WithoutCaching returns a copy of the client that doesn't cache responses or
+// share requests in progress: each response is decoded as it arrives and then
+// dropped, so memory stays flat when downloading many resources that won't be
+// needed again. Responses the original has already cached are still used.
+//
+// Returns:
+//   - The copy of the client
+func (c *Client) WithoutCaching() *Client {
+	clone := *c
+	clone.uncached = true
+	return &clone
+}
+
+// context returns the context for the client's requests.
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// NewClient creates a new PokeAPI client with the specified cache duration and
+// default options. The cache helps avoid redundant API calls by storing responses
+// for the specified duration.
 //
 // Parameters:
 //   - cacheInterval: How long cached items should remain valid before expiring
 //
 // Returns:
 //   - A configured Client ready to make API requests with caching
-func NewClient(cacheInterval time.Duration) Client {
-	return Client{
-		cache: *pokecache.NewCache(cacheInterval),
-		httpClient: http.Client{
-			Timeout: time.Minute, // Set a 1-minute timeout for all requests
+func NewClient(cacheInterval time.Duration) *Client {
+	return NewClientWithOptions(ClientOptions{CacheInterval: cacheInterval})
+}
+
+// NewClientWithOptions creates a new PokeAPI client configured by opts.
+// Use this to set a custom request timeout, to go through a proxy or trust
+// another certificate authority, or to inject a transport, for example to
+// route requests through a test server.
+//
+// Parameters:
+//   - opts: The client options; zero fields use the defaults
+//
+// Returns:
+//   - A configured Client ready to make API requests with caching
+func NewClientWithOptions(opts ClientOptions) *Client {
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	transport := opts.Transport
+	if transport == nil {
+		transport = networkTransport(opts)
+	}
+
+	userAgent := opts.UserAgent
+	if userAgent == "" {
+		userAgent = UserAgent("dev")
+	}
+	maxResponseSize := opts.MaxResponseSize
+	if maxResponseSize <= 0 {
+		maxResponseSize = defaultMaxResponseSize
+	}
+	header := http.Header{}
+	header.Set("User-Agent", userAgent)
+	header.Set("Accept", "application/json")
+	for name, values := range opts.Header {
+		header[http.CanonicalHeaderKey(name)] = slices.Clone(values)
+	}
+
+	return &Client{
+		cache: pokecache.NewCache(opts.CacheInterval),
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
 		},
+		header:          header,
+		retryDelay:      defaultRetryDelay,
+		stats:           &requestStats{},
+		inflight:        &inflightGroup{},
+		cacheTTLs:       maps.Clone(opts.CacheTTLs),
+		maxResponseSize: maxResponseSize,
+		onSpecies:       opts.OnSpecies,
 	}
 }
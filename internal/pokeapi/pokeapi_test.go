@@ -2,10 +2,19 @@ package pokeapi
 
 import (
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
 )
 
 // TestNewClient tests the creation of a new PokeAPI client
@@ -16,6 +25,86 @@ func TestNewClient(t *testing.T) {
 	if client.httpClient.Timeout != time.Minute {
 		t.Errorf("Expected default timeout of 1 minute, got %v", client.httpClient.Timeout)
 	}
+
+	// Clients created with the defaults share one pooled transport
+	other := NewClient(time.Hour)
+	if client.httpClient.Transport != sharedTransport || other.httpClient.Transport != sharedTransport {
+		t.Error("Expected clients to share the default transport")
+	}
+	if sharedTransport.MaxIdleConnsPerHost < 2 {
+		t.Errorf("Expected the shared transport to keep several idle connections, got %d", sharedTransport.MaxIdleConnsPerHost)
+	}
+}
+
+// TestNewClientWithOptions tests that options override the defaults
+func TestNewClientWithOptions(t *testing.T) {
+	transport := &http.Transport{}
+	client := NewClientWithOptions(ClientOptions{
+		CacheInterval: time.Hour,
+		Timeout:       5 * time.Second,
+		Transport:     transport,
+	})
+
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Expected timeout of 5 seconds, got %v", client.httpClient.Timeout)
+	}
+	if client.httpClient.Transport != transport {
+		t.Error("Expected the injected transport to be used")
+	}
+}
+
+// TestNetworkSettings tests that clients behind a proxy or trusting another
+// certificate authority get a transport of their own
+func TestNetworkSettings(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // The refused handshake is expected
+	server.StartTLS()
+	defer server.Close()
+
+	// A bundle with the test server's certificate lets its certificate be verified
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certificate, 0o600); err != nil {
+		t.Fatalf("Could not write the bundle: %v", err)
+	}
+	roots, err := LoadCABundle(bundle)
+	if err != nil {
+		t.Fatalf("LoadCABundle returned an error: %v", err)
+	}
+	for name, opts := range map[string]ClientOptions{
+		"custom CA": {RootCAs: roots},
+		"insecure":  {Insecure: true},
+	} {
+		transport := networkTransport(opts)
+		if transport == sharedTransport {
+			t.Fatalf("%s: expected a transport of its own", name)
+		}
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		if err != nil {
+			t.Errorf("%s: expected the request to succeed, got %v", name, err)
+			continue
+		}
+		resp.Body.Close()
+	}
+	if _, err := (&http.Client{Transport: networkTransport(ClientOptions{})}).Get(server.URL); err == nil {
+		t.Error("Expected the test server's certificate to be refused without the bundle")
+	}
+
+	proxy, _ := url.Parse("http://proxy.example.com:3128")
+	transport := networkTransport(ClientOptions{Proxy: proxy})
+	request, _ := http.NewRequest(http.MethodGet, baseURL+"/pokemon/pikachu", nil)
+	if got, err := transport.Proxy(request); err != nil || got.String() != proxy.String() {
+		t.Errorf("Expected requests to go through %s, got %v, %v", proxy, got, err)
+	}
+
+	if err := os.WriteFile(bundle, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("Could not write the bundle: %v", err)
+	}
+	if _, err := LoadCABundle(bundle); err == nil {
+		t.Error("Expected a bundle without certificates to be refused")
+	}
 }
 
 // TestGetPokemonData tests the GetPokemonData method
@@ -25,23 +114,9 @@ func TestGetPokemonData(t *testing.T) {
 		// Check if the request is for a Pokémon
 		if r.URL.Path == "/api/v2/pokemon/pikachu" {
 			// Return a mock Pokémon response
-			pokemon := PokemonDataResp{
-				Name:   "pikachu",
-				Height: 4,
-				Weight: 60,
-				Types: []struct {
-					Slot int              `json:"slot"`
-					Type NamedAPIResource `json:"type"`
-				}{
-					{
-						Slot: 1,
-						Type: NamedAPIResource{
-							Name: "electric",
-							URL:  "https://pokeapi.co/api/v2/type/13/",
-						},
-					},
-				},
-			}
+			pokemon := testPokemonData("pikachu", "pikachu")
+			pokemon.Height = 4
+			pokemon.Weight = 60
 
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(pokemon)
@@ -59,23 +134,11 @@ func TestGetPokemonData(t *testing.T) {
 	}))
 	defer server.Close()
 
-	// Create a client with a short cache duration and custom base URL
-	client := NewClient(time.Millisecond * 10)
-
-	// Store the original HTTP client to restore later
-	originalClient := client.httpClient
-
-	// Create a custom HTTP client that redirects to our test server
-	client.httpClient = http.Client{
-		Transport: &testTransport{
-			testServer: server,
-		},
-	}
-
-	// Restore the original client when done
-	defer func() {
-		client.httpClient = originalClient
-	}()
+	// Create a client with a short cache duration that redirects to our test server
+	client := NewClientWithOptions(ClientOptions{
+		CacheInterval: time.Millisecond * 10,
+		Transport:     &testTransport{testServer: server},
+	})
 
 	// Test getting a valid Pokémon
 	t.Run("Valid Pokemon", func(t *testing.T) {
@@ -114,6 +177,27 @@ func TestGetPokemonData(t *testing.T) {
 	})
 }
 
+// testPokemonData builds Pokémon data that passes response validation,
+// with a full set of stats, a single Electric type, and a species reference
+func testPokemonData(name, species string) PokemonDataResp {
+	pokemon := PokemonDataResp{
+		Name:    name,
+		Species: NamedAPIResource{Name: species, URL: "https://pokeapi.co/api/v2/pokemon-species/" + species + "/"},
+	}
+	for _, stat := range []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"} {
+		pokemon.Stats = append(pokemon.Stats, struct {
+			BaseStat int              `json:"base_stat"`
+			Effort   int              `json:"effort"`
+			Stat     NamedAPIResource `json:"stat"`
+		}{BaseStat: 50, Stat: NamedAPIResource{Name: stat}})
+	}
+	pokemon.Types = append(pokemon.Types, struct {
+		Slot int              `json:"slot"`
+		Type NamedAPIResource `json:"type"`
+	}{Slot: 1, Type: NamedAPIResource{Name: "electric", URL: "https://pokeapi.co/api/v2/type/13/"}})
+	return pokemon
+}
+
 // testTransport is a custom http.RoundTripper that redirects requests to a test server
 type testTransport struct {
 	testServer *httptest.Server
@@ -127,8 +211,8 @@ func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		url += "?" + req.URL.RawQuery
 	}
 
-	// Create a new request with the test server URL
-	newReq, err := http.NewRequest(req.Method, url, req.Body)
+	// Create a new request with the test server URL, keeping its context
+	newReq, err := http.NewRequestWithContext(req.Context(), req.Method, url, req.Body)
 	if err != nil {
 		return nil, err
 	}
@@ -139,3 +223,311 @@ func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	// Send the request to the test server
 	return http.DefaultTransport.RoundTrip(newReq)
 }
+
+// TestGetPokemonCaptureRate tests that the capture rate is read from species data,
+// falling back to the Pokémon's species name for alternate forms
+func TestGetPokemonCaptureRate(t *testing.T) {
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		switch r.URL.Path {
+		case "/api/v2/pokemon-species/pikachu":
+			json.NewEncoder(w).Encode(PokemonSpeciesResp{Name: "pikachu", CaptureRate: 190})
+		case "/api/v2/pokemon-species/deoxys":
+			json.NewEncoder(w).Encode(PokemonSpeciesResp{Name: "deoxys", CaptureRate: 3, IsMythical: true})
+		case "/api/v2/pokemon/deoxys-attack":
+			json.NewEncoder(w).Encode(testPokemonData("deoxys-attack", "deoxys"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	resp, err := client.GetPokemonCaptureRate("pikachu")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.CaptureRate != 190 {
+		t.Errorf("Expected capture rate 190, got %d", resp.CaptureRate)
+	}
+	if requests["/api/v2/pokemon/pikachu"] != 0 {
+		t.Errorf("Expected no Pokémon data request for a species name")
+	}
+
+	resp, err = client.GetPokemonCaptureRate("deoxys-attack")
+	if err != nil {
+		t.Fatalf("Expected no error for alternate form, got %v", err)
+	}
+	if resp.CaptureRate != 3 || !resp.Legendary {
+		t.Errorf("Expected capture rate 3 of a legendary, got %+v", resp)
+	}
+}
+
+// TestOnSpecies tests that the OnSpecies option sees every species retrieved,
+// including those retrieved along the way, and that their names are indexed
+// by language
+func TestOnSpecies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		species := PokemonSpeciesResp{Name: "mr-mime", CaptureRate: 45, Names: []Name{
+			{Name: "Mr. Mime", Language: NamedAPIResource{Name: "en"}},
+			{Name: "Barrierd", Language: NamedAPIResource{Name: "ja-Hrkt"}},
+		}}
+		json.NewEncoder(w).Encode(species)
+	}))
+	defer server.Close()
+
+	var seen []map[string]string
+	client := NewClientWithOptions(ClientOptions{
+		CacheInterval: time.Minute,
+		Transport:     &testTransport{testServer: server},
+		OnSpecies:     func(species PokemonSpeciesResp) { seen = append(seen, species.NamesByLanguage()) },
+	})
+	if _, err := client.GetPokemonCaptureRate("mr-mime"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(seen) != 1 || seen[0]["en"] != "Mr. Mime" || seen[0]["ja-Hrkt"] != "Barrierd" {
+		t.Errorf("Expected the species' names to be seen once, got %v", seen)
+	}
+}
+
+// TestGetEggGroup tests that egg group members decode and that a missing
+// group is reported as a not found error
+func TestGetEggGroup(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/egg-group/ground":
+			fmt.Fprint(w, `{"id": 5, "name": "ground",
+				"names": [{"name": "Field", "language": {"name": "en", "url": ""}}],
+				"pokemon_species": [{"name": "eevee", "url": "https://pokeapi.co/api/v2/pokemon-species/133/"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	group, err := client.GetEggGroup("ground")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(group.Names) != 1 || group.Names[0].Name != "Field" {
+		t.Errorf("Expected the English name Field, got %+v", group.Names)
+	}
+	if len(group.PokemonSpecies) != 1 || group.PokemonSpecies[0].Name != "eevee" {
+		t.Errorf("Expected eevee as the only member, got %+v", group.PokemonSpecies)
+	}
+
+	_, err = client.GetEggGroup("unknown")
+	if !errorhandling.IsNotFoundError(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+// TestGetItem tests that item cost and effect text decode, and that the
+// English effect is picked out of the localized entries
+func TestGetItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/item/leftovers" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id": 234, "name": "leftovers", "cost": 4000,
+			"category": {"name": "held-items", "url": ""},
+			"effect_entries": [
+				{"effect": "Restaurations", "short_effect": "Restaure des PV", "language": {"name": "fr", "url": ""}},
+				{"effect": "Restores 1/16 max HP each turn.", "short_effect": "Restores HP each turn.", "language": {"name": "en", "url": ""}}
+			]}`)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	item, err := client.GetItem("leftovers")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if item.Cost != 4000 || item.Category.Name != "held-items" {
+		t.Errorf("Unexpected item data: %+v", item)
+	}
+	if effect := item.EnglishEffect(); effect != "Restores HP each turn." {
+		t.Errorf("Expected the English short effect, got %q", effect)
+	}
+
+	_, err = client.GetItem("unknown")
+	if !errorhandling.IsNotFoundError(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+// TestListTypes tests that the type list decodes and that an empty list is
+// rejected as an invalid response
+func TestListTypes(t *testing.T) {
+	empty := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if empty {
+			fmt.Fprint(w, `{"count": 0, "results": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"count": 2, "results": [
+			{"name": "normal", "url": "https://pokeapi.co/api/v2/type/1/"},
+			{"name": "fighting", "url": "https://pokeapi.co/api/v2/type/2/"}]}`)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+	list, err := client.ListTypes()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list.Results) != 2 || list.Results[1].Name != "fighting" {
+		t.Errorf("Unexpected type list: %+v", list)
+	}
+
+	empty = true
+	client = NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+	if _, err := client.ListTypes(); err == nil {
+		t.Error("Expected an empty type list to be rejected")
+	}
+}
+
+// TestGetMove tests that move details decode, that the effect chance is filled
+// into the effect text, and that descriptions are picked by version group
+func TestGetMove(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/move/thunderbolt" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, `{"id": 85, "name": "thunderbolt", "power": 90, "accuracy": 100, "pp": 15,
+			"priority": 0, "effect_chance": 10,
+			"type": {"name": "electric", "url": ""}, "damage_class": {"name": "special", "url": ""},
+			"effect_entries": [{"effect": "", "short_effect": "Has a $effect_chance% chance to paralyze the target.", "language": {"name": "en", "url": ""}}],
+			"flavor_text_entries": [
+				{"flavor_text": "A strong electric\nblast.", "language": {"name": "en", "url": ""}, "version_group": {"name": "gold-silver", "url": ""}},
+				{"flavor_text": "Une décharge.", "language": {"name": "fr", "url": ""}, "version_group": {"name": "sword-shield", "url": ""}},
+				{"flavor_text": "A strong electrical blast crashes down.", "language": {"name": "en", "url": ""}, "version_group": {"name": "sword-shield", "url": ""}}
+			]}`)
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	move, err := client.GetMove("thunderbolt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if move.PP != 15 || move.EffectChance == nil || *move.EffectChance != 10 || move.DamageClass.Name != "special" {
+		t.Errorf("Unexpected move data: %+v", move)
+	}
+	if effect := move.EnglishEffect(); effect != "Has a 10% chance to paralyze the target." {
+		t.Errorf("Expected the effect chance to be filled in, got %q", effect)
+	}
+	if text := move.EnglishFlavorText("gold-silver"); text != "A strong electric\nblast." {
+		t.Errorf("Expected the Gold/Silver description, got %q", text)
+	}
+	if text := move.EnglishFlavorText("x-y"); text != "A strong electrical blast crashes down." {
+		t.Errorf("Expected the latest English description, got %q", text)
+	}
+
+	_, err = client.GetMove("unknown")
+	if !errorhandling.IsNotFoundError(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+// TestGetNature tests that natures decode and that natures which change no
+// stats are neutral
+func TestGetNature(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/nature/adamant":
+			fmt.Fprint(w, `{"id": 3, "name": "adamant",
+				"increased_stat": {"name": "attack", "url": ""}, "decreased_stat": {"name": "special-attack", "url": ""},
+				"likes_flavor": {"name": "spicy", "url": ""}, "hates_flavor": {"name": "dry", "url": ""}}`)
+		case "/api/v2/nature/hardy":
+			fmt.Fprint(w, `{"id": 1, "name": "hardy", "increased_stat": null, "decreased_stat": null,
+				"likes_flavor": null, "hates_flavor": null}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	adamant, err := client.GetNature("adamant")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if adamant.Neutral() || adamant.IncreasedStat.Name != "attack" || adamant.HatesFlavor.Name != "dry" {
+		t.Errorf("Unexpected nature data: %+v", adamant)
+	}
+	hardy, err := client.GetNature("hardy")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !hardy.Neutral() {
+		t.Error("Expected Hardy to be neutral")
+	}
+
+	_, err = client.GetNature("unknown")
+	if !errorhandling.IsNotFoundError(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+// TestGetAbility tests that abilities decode and that the English effect is
+// picked from the descriptions
+func TestGetAbility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/ability/static":
+			fmt.Fprint(w, `{"id": 9, "name": "static", "effect_entries": [
+				{"effect": "", "short_effect": "Paralyse le contact.", "language": {"name": "fr", "url": ""}},
+				{"effect": "", "short_effect": "Has a 30% chance of paralyzing attacking Pokémon on contact.", "language": {"name": "en", "url": ""}}
+			]}`)
+		case "/api/v2/ability/nameless":
+			fmt.Fprint(w, `{"id": 1, "effect_entries": []}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	ability, err := client.GetAbility("static")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if effect := ability.EnglishEffect(); effect != "Has a 30% chance of paralyzing attacking Pokémon on contact." {
+		t.Errorf("Expected the English effect, got %q", effect)
+	}
+	if _, err := client.GetAbility("nameless"); err == nil {
+		t.Error("Expected an ability without a name to be rejected")
+	}
+
+	_, err = client.GetAbility("unknown")
+	if !errorhandling.IsNotFoundError(err) {
+		t.Errorf("Expected a not found error, got %v", err)
+	}
+}
+
+// TestWithPageLimit tests that the page size is changed in place in the URL of
+// a page, or added if the URL has none
+func TestWithPageLimit(t *testing.T) {
+	tests := map[string]string{
+		baseURL + "/location-area?offset=20&limit=20": baseURL + "/location-area?offset=20&limit=50",
+		baseURL + "/location-area?limit=20&offset=40": baseURL + "/location-area?limit=50&offset=40",
+		baseURL + "/location-area?offset=60":          baseURL + "/location-area?offset=60&limit=50",
+		baseURL + "/location-area":                    baseURL + "/location-area?limit=50",
+	}
+	for pageURL, want := range tests {
+		if got := withPageLimit(pageURL, 50); got != want {
+			t.Errorf("withPageLimit(%q, 50) = %q, want %q", pageURL, got, want)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// This file implements the shared request logic used by every PokeAPI endpoint.
+// It provides a single generic helper that checks the cache, performs the HTTP
+// request with retries, maps error status codes to application errors, and
+// decodes the JSON response, so that each endpoint method only needs to
+// describe its URL and any special handling.
+package pokeapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// maxRetries is the number of times a failed request is retried before giving up.
+// Only transient failures (connection errors, rate limiting, and server errors) are retried.
+const maxRetries = 2
+
+// defaultRetryDelay is the wait before the first retry; it doubles for each retry after that.
+const defaultRetryDelay = 500 * time.Millisecond
+
+// maxRetryAfter is the longest wait requested with a Retry-After header that is honored.
+// If the API asks for a longer wait, the request fails instead of leaving the user waiting.
+const maxRetryAfter = 30 * time.Second
+
+// defaultMaxResponseSize is the largest response body accepted when
+// ClientOptions doesn't set a limit. The largest PokeAPI resources, such as
+// Pokémon with hundreds of moves, are well under 1 MB.
+const defaultMaxResponseSize = 16 << 20
+
+// errResponseTooLarge is returned when reading a response body larger than the client's limit.
+var errResponseTooLarge = errors.New("response body too large")
+
+// retryAdvice describes whether and when a failed request should be retried.
+type retryAdvice struct {
+	retryable bool          // Whether the failure is worth retrying
+	after     time.Duration // Wait requested by the API with Retry-After (zero to use backoff)
+}
+
+// notFoundFunc builds the error returned when an endpoint responds with 404.
+// It receives the underlying HTTP error so that it can be wrapped.
+type notFoundFunc func(err error) error
+
+// requestConfig holds the per-request settings applied by requestOptions.
+type requestConfig struct {
+	notFound notFoundFunc      // Builds the error for 404 responses
+	hooks    []func(any) error // Run in order on the decoded response
+	fresh    bool              // Whether to skip the cache and request the resource again
+}
+
+// requestOption customizes how doGet handles a single request.
+type requestOption func(*requestConfig)
+
+// withNotFound sets the error returned when the API responds with 404.
+// Without this option, 404 responses are reported using NewAPIError.
+func withNotFound(notFound notFoundFunc) requestOption {
+	return func(rc *requestConfig) {
+		rc.notFound = notFound
+	}
+}
+
+// withDecodeHook adds a function that runs on the decoded response before it is
+// returned. Hooks can validate or normalize data; if a hook returns an error,
+// the request fails with that error and the response is not cached.
+//
+// Parameters:
+//   - hook: The function to run on a pointer to the decoded response
+//
+// Returns:
+//   - A requestOption that registers the hook
+func withDecodeHook[T any](hook func(*T) error) requestOption {
+	return func(rc *requestConfig) {
+		rc.hooks = append(rc.hooks, func(v any) error {
+			return hook(v.(*T))
+		})
+	}
+}
+
+// withFreshFetch makes the request skip the cache, so that the resource is
+// requested from the API again. The new response replaces the cached one.
+func withFreshFetch() requestOption {
+	return func(rc *requestConfig) {
+		rc.fresh = true
+	}
+}
+
+// doGet retrieves a resource from the PokeAPI and decodes it into T.
+// Responses are cached by URL so repeated requests for the same resource are
+// served from memory without making another HTTP request, and concurrent
+// requests for a URL that isn't cached yet share a single HTTP request.
+// Clients made with WithoutCaching decode responses as they arrive instead,
+// and keep nothing. Response bodies larger than the client's limit are refused.
+// Transient failures are retried with exponential backoff until the context
+// is cancelled.
+//
+// Errors are reported consistently for all endpoints:
+//   - NetworkError: If the request can't be created, sent, or read, or the
+//     response is too large
+//   - The error set by withNotFound: If the API responds with 404
+//   - API errors from NewAPIError: For any other unsuccessful status code
+//   - InternalError: If the response (cached or fresh) can't be decoded
+//   - Any error returned by a decode hook
+//
+// Parameters:
+//   - ctx: Context for cancelling the request and any retries
+//   - c: The client whose cache and HTTP client are used
+//   - fullURL: The complete URL to request, also used as the cache key
+//   - opts: Options for error mapping, decode hooks, and skipping the cache
+//
+// Returns:
+//   - The decoded response
+//   - An error if the request, decoding, or a hook fails
+func doGet[T any](ctx context.Context, c *Client, fullURL string, opts ...requestOption) (T, error) {
+	var result T
+	rc := requestConfig{}
+	for _, opt := range opts {
+		opt(&rc)
+	}
+
+	// Check cache, unless a fresh copy was asked for
+	if data, ok := c.cache.Get(fullURL); ok && !rc.fresh {
+		c.stats.cacheHits.Add(1)
+		if err := decodeResponse(bytes.NewReader(data), &result, rc.hooks); err != nil {
+			return result, err
+		}
+		return result, nil
+	}
+
+	// Without caching, decode the response as it arrives instead of keeping it
+	if c.uncached {
+		err := c.getWithRetries(ctx, fullURL, rc.notFound, func(body io.Reader) error {
+			result = *new(T)
+			return decodeResponse(body, &result, rc.hooks)
+		})
+		return result, err
+	}
+
+	// Share the request with any other caller already fetching the same URL
+	body, shared, err := c.inflight.do(ctx, fullURL, func() ([]byte, error) {
+		var body bytes.Buffer
+		err := c.getWithRetries(ctx, fullURL, rc.notFound, func(r io.Reader) error {
+			body.Reset()
+			_, err := body.ReadFrom(r)
+			return err
+		})
+		return body.Bytes(), err
+	})
+	if shared {
+		c.stats.coalesced.Add(1)
+	}
+	if err != nil {
+		return result, err
+	}
+
+	// Decode before caching so invalid data is never stored
+	if err := decodeResponse(bytes.NewReader(body), &result, rc.hooks); err != nil {
+		return result, err
+	}
+
+	// Store in cache, for as long as this class of resource is kept
+	c.cache.AddWithTTL(fullURL, body, c.cacheTTL(fullURL))
+
+	return result, nil
+}
+
+// getWithRetries performs the HTTP request for a URL, retrying transient failures.
+//
+// Parameters:
+//   - ctx: Context for cancelling the request and any retries
+//   - fullURL: The complete URL to request
+//   - notFound: Builds the error for 404 responses; if nil, NewAPIError is used
+//   - read: Reads the response body; it's called again if a retry succeeds
+//
+// Returns:
+//   - An error if every attempt fails or the context is cancelled, or the
+//     error read returned for a body that was read in full
+func (c *Client) getWithRetries(ctx context.Context, fullURL string, notFound notFoundFunc, read func(io.Reader) error) error {
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		advice, err := c.get(ctx, fullURL, notFound, read)
+		if err == nil || !advice.retryable || attempt >= maxRetries || advice.after > maxRetryAfter {
+			return err
+		}
+
+		// Wait as long as the API asked, or back off exponentially
+		wait := delay
+		if advice.after > 0 {
+			wait = advice.after
+		}
+
+		// Wait before retrying, unless the request is cancelled first
+		select {
+		case <-ctx.Done():
+			return errorhandling.NewNetworkError("Request to the Pokémon API was cancelled", ctx.Err())
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// get performs a single HTTP GET request and maps unsuccessful responses to errors.
+// Every request carries the client's headers, including its User-Agent.
+//
+// Returns:
+//   - Whether and when a failed request should be retried
+//   - An error if the request failed, the body couldn't be read or was too
+//     large, or read failed
+func (c *Client) get(ctx context.Context, fullURL string, notFound notFoundFunc, read func(io.Reader) error) (retryAdvice, error) {
+	endpoint := strings.TrimPrefix(fullURL, baseURL)
+
+	// Create a new HTTP request
+	req, err := http.NewRequestWithContext(ctx, "GET", fullURL, nil)
+	if err != nil {
+		return retryAdvice{}, errorhandling.NewNetworkError("Failed to create HTTP request", err)
+	}
+	req.Header = c.header.Clone()
+
+	// Send the request
+	c.stats.apiCalls.Add(1)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		retryable := ctx.Err() == nil
+		return retryAdvice{retryable: retryable}, errorhandling.NewNetworkError("Failed to connect to the Pokémon API", err)
+	}
+	defer resp.Body.Close()
+
+	// Check if the response was successful
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		httpErr := fmt.Errorf("HTTP error: %d", resp.StatusCode)
+		if resp.StatusCode == http.StatusNotFound && notFound != nil {
+			return retryAdvice{}, notFound(httpErr)
+		}
+		advice := retryAdvice{retryable: resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500}
+		if advice.retryable {
+			advice.after = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		return advice, errorhandling.NewAPIError(resp.StatusCode, endpoint, httpErr)
+	}
+
+	// Read the response body, up to the size limit
+	body := &limitedBody{r: resp.Body, remaining: c.maxResponseSize}
+	if resp.ContentLength > c.maxResponseSize {
+		body.err = errResponseTooLarge
+	} else {
+		err = read(body)
+	}
+	switch {
+	case errors.Is(body.err, errResponseTooLarge):
+		return retryAdvice{}, errorhandling.NewNetworkError("The Pokémon API response was larger than the size limit",
+			fmt.Errorf("%s is over %d bytes: %w", endpoint, c.maxResponseSize, body.err))
+	case body.err != nil:
+		return retryAdvice{retryable: true}, errorhandling.NewNetworkError("Failed to read the Pokémon API response", body.err)
+	}
+	return retryAdvice{}, err
+}
+
+// limitedBody reads a response body until more than a number of bytes have
+// been read, and records why reading stopped, so that a body that couldn't
+// be read in full can be told apart from one that held invalid data.
+type limitedBody struct {
+	r         io.Reader // The response body
+	remaining int64     // How many more bytes may be read
+	err       error     // The error reading stopped with, other than io.EOF
+}
+
+// Read reads from the body, failing with errResponseTooLarge once more than
+// the limit has been read.
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	// Read at most one byte more than is allowed, to detect bodies over the limit
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.r.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		err = errResponseTooLarge
+	}
+	if err != nil && err != io.EOF {
+		b.err = err
+	}
+	return n, err
+}
+
+// parseRetryAfter reads a Retry-After header, which gives either a number of
+// seconds to wait or the date after which to retry.
+//
+// Parameters:
+//   - value: The header value
+//   - now: The current time, used to turn a date into a wait
+//
+// Returns:
+//   - How long to wait, or zero if the header is missing, invalid, or in the past
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds <= 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// decodeResponse decodes a JSON response into result, reading it as it
+// arrives, and runs the decode hooks on it.
+func decodeResponse(r io.Reader, result any, hooks []func(any) error) error {
+	if err := json.NewDecoder(r).Decode(result); err != nil {
+		return errorhandling.NewInternalError("Failed to parse the Pokémon API response", err)
+	}
+	for _, hook := range hooks {
+		if err := hook(result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// TestDoGet tests that responses are decoded and served from the cache
+// on subsequent requests, and that errors are classified consistently
+func TestDoGet(t *testing.T) {
+	hits := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits[r.URL.Path]++
+		switch r.URL.Path {
+		case "/api/v2/pokemon/pikachu":
+			w.Write([]byte(`{"name": "pikachu", "height": 4}`))
+		case "/api/v2/pokemon/broken":
+			w.Write([]byte(`{"name": `))
+		case "/api/v2/pokemon/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+	client.retryDelay = time.Millisecond
+
+	t.Run("Cached after first request", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			pokemon, err := doGet[PokemonDataResp](context.Background(), client, baseURL+"/pokemon/pikachu")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if pokemon.Name != "pikachu" || pokemon.Height != 4 {
+				t.Errorf("Unexpected decoded data: %+v", pokemon)
+			}
+		}
+		if hits["/api/v2/pokemon/pikachu"] != 1 {
+			t.Errorf("Expected 1 HTTP request, got %d", hits["/api/v2/pokemon/pikachu"])
+		}
+	})
+
+	t.Run("Fresh fetches skip the cache", func(t *testing.T) {
+		if _, err := doGet[PokemonDataResp](context.Background(), client, baseURL+"/pokemon/pikachu", withFreshFetch()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if hits["/api/v2/pokemon/pikachu"] != 2 {
+			t.Errorf("Expected a second HTTP request, got %d", hits["/api/v2/pokemon/pikachu"])
+		}
+	})
+
+	t.Run("Not found uses custom error", func(t *testing.T) {
+		sentinel := errors.New("custom not found")
+		_, err := doGet[PokemonDataResp](context.Background(), client, baseURL+"/pokemon/missing", withNotFound(func(error) error { return sentinel }))
+		if err != sentinel {
+			t.Errorf("Expected custom not found error, got %v", err)
+		}
+	})
+
+	t.Run("Transient errors are retried", func(t *testing.T) {
+		_, err := doGet[PokemonDataResp](context.Background(), client, baseURL+"/pokemon/limited")
+		var appErr *errorhandling.AppError
+		if !errors.As(err, &appErr) || appErr.Type != errorhandling.ResourceUnavailable {
+			t.Errorf("Expected ResourceUnavailable error, got %v", err)
+		}
+		if hits["/api/v2/pokemon/limited"] != maxRetries+1 {
+			t.Errorf("Expected %d attempts, got %d", maxRetries+1, hits["/api/v2/pokemon/limited"])
+		}
+	})
+
+	t.Run("Not found errors are not retried", func(t *testing.T) {
+		doGet[PokemonDataResp](context.Background(), client, baseURL+"/pokemon/unknown")
+		if hits["/api/v2/pokemon/unknown"] != 1 {
+			t.Errorf("Expected 1 attempt, got %d", hits["/api/v2/pokemon/unknown"])
+		}
+	})
+
+	t.Run("Decode hooks can reject data", func(t *testing.T) {
+		hookErr := errors.New("rejected")
+		_, err := doGet[PokemonDataResp](context.Background(), client, baseURL+"/pokemon/pikachu",
+			withDecodeHook(func(p *PokemonDataResp) error {
+				if p.Name != "pikachu" {
+					t.Errorf("Hook received unexpected data: %+v", p)
+				}
+				return hookErr
+			}))
+		if err != hookErr {
+			t.Errorf("Expected hook error, got %v", err)
+		}
+	})
+
+	t.Run("Malformed JSON is an internal error and not cached", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			_, err := doGet[PokemonDataResp](context.Background(), client, baseURL+"/pokemon/broken")
+			var appErr *errorhandling.AppError
+			if !errors.As(err, &appErr) || appErr.Type != errorhandling.InternalError {
+				t.Errorf("Expected InternalError, got %v", err)
+			}
+		}
+		if hits["/api/v2/pokemon/broken"] != 2 {
+			t.Errorf("Expected malformed response to be refetched, got %d requests", hits["/api/v2/pokemon/broken"])
+		}
+	})
+}
+
+// TestClientConcurrentUse tests that one client can be shared by several goroutines.
+// Run with -race to check for unsynchronized access.
+func TestClientConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(testPokemonData("pikachu", "pikachu"))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := client.GetPokemonData("pikachu"); err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := client.Stats()
+	if stats.APICalls+stats.CacheHits+stats.Coalesced != 80 {
+		t.Errorf("Expected 80 requests to be counted, got %+v", stats)
+	}
+}
+
+// TestRequestHeaders tests that requests identify the application and ask for JSON
+func TestRequestHeaders(t *testing.T) {
+	var userAgent, accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent, accept = r.Header.Get("User-Agent"), r.Header.Get("Accept")
+		json.NewEncoder(w).Encode(testPokemonData("pikachu", "pikachu"))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if userAgent != UserAgent("dev") || accept != "application/json" {
+		t.Errorf("Unexpected default headers: User-Agent %q, Accept %q", userAgent, accept)
+	}
+
+	client = NewClientWithOptions(ClientOptions{
+		CacheInterval: time.Minute,
+		Transport:     &testTransport{testServer: server},
+		UserAgent:     UserAgent("v1.2.0"),
+		Header:        http.Header{"accept": {"application/json; charset=utf-8"}},
+	})
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if userAgent != "pokedexcli/v1.2.0 (+https://github.com/bmlevitt/pokedexcli)" {
+		t.Errorf("Expected the configured User-Agent, got %q", userAgent)
+	}
+	if accept != "application/json; charset=utf-8" {
+		t.Errorf("Expected the configured Accept header, got %q", accept)
+	}
+}
+
+// TestRetryAfter tests that rate-limited requests wait as long as the API asks
+func TestRetryAfter(t *testing.T) {
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts[r.URL.Path]++
+		switch {
+		case r.URL.Path == "/api/v2/pokemon/pikachu" && attempts[r.URL.Path] == 1:
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case r.URL.Path == "/api/v2/pokemon/pikachu":
+			json.NewEncoder(w).Encode(testPokemonData("pikachu", "pikachu"))
+		default:
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+	// The backoff delay is too long for the test to finish, so only Retry-After can be used
+	client.retryDelay = time.Hour
+
+	start := time.Now()
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("Expected the retry to succeed, got %v", err)
+	}
+	if waited := time.Since(start); waited < time.Second {
+		t.Errorf("Expected to wait for Retry-After, waited %v", waited)
+	}
+
+	// A wait longer than maxRetryAfter fails immediately instead
+	if _, err := client.GetPokemonData("eevee"); err == nil {
+		t.Error("Expected an error when the API asks for a long wait")
+	}
+	if attempts["/api/v2/pokemon/eevee"] != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts["/api/v2/pokemon/eevee"])
+	}
+}
+
+// TestParseRetryAfter tests reading both forms of the Retry-After header
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "", want: 0},
+		{value: "5", want: 5 * time.Second},
+		{value: " 2 ", want: 2 * time.Second},
+		{value: "-1", want: 0},
+		{value: "soon", want: 0},
+		{value: now.Add(90 * time.Second).Format(http.TimeFormat), want: 90 * time.Second},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+	}
+	for _, c := range cases {
+		if got := parseRetryAfter(c.value, now); got != c.want {
+			t.Errorf("parseRetryAfter(%q) = %v, expected %v", c.value, got, c.want)
+		}
+	}
+}
+
+// TestConcurrentRequestsAreCoalesced tests that concurrent requests for a URL
+// that isn't cached yet share a single HTTP request
+func TestConcurrentRequestsAreCoalesced(t *testing.T) {
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// Respond slowly, so that the other requests arrive while this one is in progress
+		time.Sleep(100 * time.Millisecond)
+		json.NewEncoder(w).Encode(testPokemonData("pikachu", "pikachu"))
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pokemon, err := client.GetPokemonData("pikachu")
+			if err != nil || pokemon.Name != "pikachu" {
+				t.Errorf("Expected pikachu, got %+v, err=%v", pokemon, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if hits.Load() != 1 {
+		t.Errorf("Expected 1 HTTP request, got %d", hits.Load())
+	}
+	if stats := client.Stats(); stats.APICalls != 1 || stats.Coalesced != 4 {
+		t.Errorf("Expected 1 API call and 4 coalesced requests, got %+v", stats)
+	}
+}
+
+// TestWithContext tests that a client copy's requests stop when its context
+// is cancelled, while it shares the original's cache
+func TestWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 5, "name": "ground", "names": [], "pokemon_species": []}`)
+	}))
+	defer server.Close()
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.WithContext(ctx).GetEggGroup("ground"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the cancelled request to fail, got %v", err)
+	}
+	if _, err := client.GetEggGroup("ground"); err != nil {
+		t.Fatalf("Expected the original client to be unaffected, got %v", err)
+	}
+	if _, err := client.WithContext(ctx).GetEggGroup("ground"); err != nil {
+		t.Errorf("Expected the cached response to be shared, got %v", err)
+	}
+}
+
+// TestResponseSizeLimit tests that response bodies larger than the client's
+// limit are refused without retrying, whether or not their size is announced
+func TestResponseSizeLimit(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if r.URL.Path == "/api/v2/pokemon/chunked" {
+			// Flushing before the body is written leaves its size unannounced
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprintf(w, `{"name": "%s"}`, strings.Repeat("a", 100))
+	}))
+	defer server.Close()
+	client := NewClientWithOptions(ClientOptions{
+		CacheInterval:   time.Minute,
+		Transport:       &testTransport{testServer: server},
+		MaxResponseSize: 64,
+	})
+	client.retryDelay = time.Millisecond
+
+	for _, name := range []string{"sized", "chunked"} {
+		for _, c := range []*Client{client, client.WithoutCaching()} {
+			hits.Store(0)
+			_, err := doGet[PokemonDataResp](context.Background(), c, baseURL+"/pokemon/"+name)
+			if !errors.Is(err, errResponseTooLarge) || errorhandling.FormatUserMessage(err) != "The Pokémon API response was larger than the size limit" {
+				t.Errorf("%s: expected the response to be refused as too large, got %v", name, err)
+			}
+			if hits.Load() != 1 {
+				t.Errorf("%s: expected no retries, got %d requests", name, hits.Load())
+			}
+		}
+	}
+}
+
+// TestWithoutCaching tests that a client copy made with WithoutCaching
+// decodes responses without caching them, while still using the cache
+func TestWithoutCaching(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		json.NewEncoder(w).Encode(testPokemonData("pikachu", "pikachu"))
+	}))
+	defer server.Close()
+	client := NewClientWithOptions(ClientOptions{CacheInterval: time.Minute, Transport: &testTransport{testServer: server}})
+	uncached := client.WithoutCaching()
+
+	for i := 0; i < 2; i++ {
+		pokemon, err := uncached.GetPokemonData("pikachu")
+		if err != nil || pokemon.Name != "pikachu" || len(pokemon.Stats) != 6 {
+			t.Fatalf("Unexpected result: %+v, %v", pokemon, err)
+		}
+	}
+	if hits.Load() != 2 {
+		t.Errorf("Expected every request to reach the API, got %d requests", hits.Load())
+	}
+
+	if _, err := client.GetPokemonData("pikachu"); err != nil {
+		t.Fatalf("GetPokemonData returned an error: %v", err)
+	}
+	if _, err := uncached.GetPokemonData("pikachu"); err != nil || hits.Load() != 3 {
+		t.Errorf("Expected the original's cached response to be used, got %d requests, %v", hits.Load(), err)
+	}
+}
@@ -0,0 +1,38 @@
+package pokeapi
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// GetType retrieves the damage relations of a Pokémon type.
+// This is used to work out how effective one Pokémon's attacks are against another.
+// Results are cached to improve performance and reduce API calls.
+//
+// Parameters:
+//   - typeName: The name of the type (e.g. "fire")
+//
+// Returns:
+//   - A TypeResp containing the type's damage relations
+//   - An error if the API request fails or the type doesn't exist
+func (c *Client) GetType(typeName string) (TypeResp, error) {
+	fullURL := baseURL + "/type/" + typeName
+
+	return doGet[TypeResp](c.context(), c, fullURL,
+		withDecodeHook(validateType),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(errorhandling.ResourceType, typeName, err)
+		}))
+}
+
+// ListTypes retrieves the names of every type in the PokeAPI, in the order
+// of their IDs. Results are cached to improve performance and reduce API calls.
+//
+// Returns:
+//   - A TypeListResp containing a NamedAPIResource for every type
+//   - An error if the API request fails
+func (c *Client) ListTypes() (TypeListResp, error) {
+	fullURL := baseURL + "/type?limit=100"
+
+	return doGet[TypeListResp](c.context(), c, fullURL,
+		withDecodeHook(validateTypeList))
+}
@@ -0,0 +1,30 @@
+// This file defines the data structures for working with ability data from the PokeAPI.
+// Every Pokémon has one of a few abilities, which have an effect in battle or
+// in the field, such as Static paralyzing attackers that make contact.
+package pokeapi
+
+// AbilityResp represents the response from the ability endpoint in the PokeAPI.
+// It includes the ability's effect in different languages.
+type AbilityResp struct {
+	ID            int             `json:"id"`             // The identifier for this ability
+	Name          string          `json:"name"`           // The name of this ability (e.g. "static")
+	EffectEntries []AbilityEffect `json:"effect_entries"` // Descriptions of the ability's effect in different languages
+}
+
+// AbilityEffect describes the effect of an ability in one language.
+type AbilityEffect struct {
+	Effect      string           `json:"effect"`       // The full description of the ability's effect
+	ShortEffect string           `json:"short_effect"` // A one-line summary of the ability's effect
+	Language    NamedAPIResource `json:"language"`     // The language this description is in
+}
+
+// EnglishEffect returns the short English description of the ability's
+// effect, or an empty string if there is none.
+func (a AbilityResp) EnglishEffect() string {
+	for _, entry := range a.EffectEntries {
+		if entry.Language.Name == "en" {
+			return entry.ShortEffect
+		}
+	}
+	return ""
+}
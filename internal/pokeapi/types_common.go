@@ -1,5 +1,11 @@
 package pokeapi
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // NamedAPIResource represents a resource with a name and URL in the PokeAPI.
 // This type is used extensively throughout the API to reference other objects
 // like Pokémon, moves, types, locations, etc. It serves as a pointer to another
@@ -8,3 +14,25 @@ type NamedAPIResource struct {
 	Name string `json:"name"` // The name of the referenced resource (in lowercase with hyphens)
 	URL  string `json:"url"`  // The URL to fetch the complete data for the referenced resource
 }
+
+// ID extracts the numeric identifier of the referenced resource from its URL.
+// PokeAPI resource URLs end with the resource ID, for example
+// "https://pokeapi.co/api/v2/pokemon-species/25/".
+//
+// Returns:
+//   - The resource ID
+//   - An error if the URL doesn't end with a numeric ID
+func (r NamedAPIResource) ID() (int, error) {
+	parts := strings.Split(strings.TrimSuffix(r.URL, "/"), "/")
+	id, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return 0, fmt.Errorf("no resource ID in URL: %s", r.URL)
+	}
+	return id, nil
+}
+
+// Name is the name of a resource in one language.
+type Name struct {
+	Name     string           `json:"name"`     // The localized name
+	Language NamedAPIResource `json:"language"` // The language the name is in
+}
@@ -0,0 +1,16 @@
+// This file defines the data structures for working with egg group data from the PokeAPI.
+// Egg groups determine which Pokémon species can breed with each other.
+package pokeapi
+
+// EggGroupResp represents the response from the egg-group endpoint in the PokeAPI.
+// It lists every species that belongs to the group. Two Pokémon can only breed
+// if they share at least one egg group.
+type EggGroupResp struct {
+	ID    int    `json:"id"`   // The identifier for this egg group
+	Name  string `json:"name"` // The name of this egg group (e.g. "water1")
+	Names []struct {
+		Name     string           `json:"name"`     // The localized name of this egg group
+		Language NamedAPIResource `json:"language"` // The language this name is in
+	} `json:"names"`
+	PokemonSpecies []NamedAPIResource `json:"pokemon_species"` // The species that belong to this egg group
+}
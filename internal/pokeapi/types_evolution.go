@@ -27,22 +27,22 @@ type ChainLink struct {
 // There are many different ways Pokémon can evolve in the games (level up, trading,
 // using specific items, etc.), and this structure captures all those possibilities.
 type EvolutionDetail struct {
-	Item                  interface{}      `json:"item"`                    // The item required to trigger evolution
-	Trigger               NamedAPIResource `json:"trigger"`                 // The evolution trigger (e.g., level-up, trade)
-	Gender                interface{}      `json:"gender"`                  // The gender the Pokémon must be
-	HeldItem              interface{}      `json:"held_item"`               // The item the Pokémon must be holding
-	KnownMove             interface{}      `json:"known_move"`              // The move that must be known
-	KnownMoveType         interface{}      `json:"known_move_type"`         // The type of move that must be known
-	Location              interface{}      `json:"location"`                // The location where evolution must occur
-	MinLevel              int              `json:"min_level"`               // The minimum level required
-	MinHappiness          interface{}      `json:"min_happiness"`           // The minimum happiness required
-	MinBeauty             interface{}      `json:"min_beauty"`              // The minimum beauty required
-	MinAffection          interface{}      `json:"min_affection"`           // The minimum affection required
-	NeedsOverworldRain    bool             `json:"needs_overworld_rain"`    // Whether it must be raining
-	PartySpecies          interface{}      `json:"party_species"`           // The species that must be in the party
-	PartyType             interface{}      `json:"party_type"`              // The type that must be in the party
-	RelativePhysicalStats interface{}      `json:"relative_physical_stats"` // The relative physical stats (attack vs defense)
-	TimeOfDay             string           `json:"time_of_day"`             // The time of day (day or night)
-	TradeSpecies          interface{}      `json:"trade_species"`           // The species that must be traded
-	TurnUpsideDown        bool             `json:"turn_upside_down"`        // Whether the 3DS must be turned upside-down
+	Item                  *NamedAPIResource `json:"item"`                    // The item required to trigger evolution
+	Trigger               NamedAPIResource  `json:"trigger"`                 // The evolution trigger (e.g., level-up, trade)
+	Gender                *int              `json:"gender"`                  // The gender the Pokémon must be (1 female, 2 male)
+	HeldItem              *NamedAPIResource `json:"held_item"`               // The item the Pokémon must be holding
+	KnownMove             *NamedAPIResource `json:"known_move"`              // The move that must be known
+	KnownMoveType         *NamedAPIResource `json:"known_move_type"`         // The type of move that must be known
+	Location              *NamedAPIResource `json:"location"`                // The location where evolution must occur
+	MinLevel              int               `json:"min_level"`               // The minimum level required
+	MinHappiness          *int              `json:"min_happiness"`           // The minimum happiness required
+	MinBeauty             *int              `json:"min_beauty"`              // The minimum beauty required
+	MinAffection          *int              `json:"min_affection"`           // The minimum affection required
+	NeedsOverworldRain    bool              `json:"needs_overworld_rain"`    // Whether it must be raining
+	PartySpecies          *NamedAPIResource `json:"party_species"`           // The species that must be in the party
+	PartyType             *NamedAPIResource `json:"party_type"`              // The type that must be in the party
+	RelativePhysicalStats *int              `json:"relative_physical_stats"` // Attack compared to Defense (1 higher, 0 equal, -1 lower)
+	TimeOfDay             string            `json:"time_of_day"`             // The time of day (day or night)
+	TradeSpecies          *NamedAPIResource `json:"trade_species"`           // The species that must be traded
+	TurnUpsideDown        bool              `json:"turn_upside_down"`        // Whether the 3DS must be turned upside-down
 }
@@ -0,0 +1,13 @@
+// This file defines the data structures for working with generation data from the PokeAPI.
+// A generation groups the Pokémon species introduced in a set of games.
+package pokeapi
+
+// GenerationResp represents the response from the generation endpoint in the PokeAPI.
+// It lists every species introduced in the generation. The species are not in
+// any particular order; their National Pokédex numbers can be read from their URLs.
+type GenerationResp struct {
+	ID             int                `json:"id"`              // The identifier for this generation
+	Name           string             `json:"name"`            // The name of this generation (e.g. "generation-i")
+	MainRegion     NamedAPIResource   `json:"main_region"`     // The region the generation's games take place in
+	PokemonSpecies []NamedAPIResource `json:"pokemon_species"` // The species introduced in this generation
+}
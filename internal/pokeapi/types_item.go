@@ -0,0 +1,32 @@
+// This file defines the data structures for working with item data from the PokeAPI.
+// Items include Poké Balls, evolution stones, and held items.
+package pokeapi
+
+// ItemResp represents the response from the item endpoint in the PokeAPI.
+// It includes the item's price and a description of what it does.
+type ItemResp struct {
+	ID            int                `json:"id"`             // The identifier for this item
+	Name          string             `json:"name"`           // The name of this item (e.g. "leftovers")
+	Cost          int                `json:"cost"`           // The price of the item in stores
+	Category      NamedAPIResource   `json:"category"`       // The category of the item (e.g. "held-items")
+	Attributes    []NamedAPIResource `json:"attributes"`     // Attributes such as "holdable" or "consumable"
+	EffectEntries []ItemEffect       `json:"effect_entries"` // Descriptions of the item's effect in different languages
+}
+
+// ItemEffect describes the effect of an item in one language.
+type ItemEffect struct {
+	Effect      string           `json:"effect"`       // The full description of the item's effect
+	ShortEffect string           `json:"short_effect"` // A one-line summary of the item's effect
+	Language    NamedAPIResource `json:"language"`     // The language this description is in
+}
+
+// EnglishEffect returns the short English description of the item's effect,
+// or an empty string if there is none.
+func (i ItemResp) EnglishEffect() string {
+	for _, entry := range i.EffectEntries {
+		if entry.Language.Name == "en" {
+			return entry.ShortEffect
+		}
+	}
+	return ""
+}
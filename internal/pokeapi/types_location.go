@@ -21,7 +21,105 @@ type LocationExploreResp struct {
 }
 
 // PokemonEncounter represents a Pokémon that can be encountered in a location area.
-// It contains a reference to the Pokémon species that can be found at that location.
+// It contains a reference to the Pokémon species that can be found at that location,
+// and how likely it is to turn up in each game.
 type PokemonEncounter struct {
-	Pokemon NamedAPIResource `json:"pokemon"` // Reference to the Pokémon that can be encountered
+	Pokemon        NamedAPIResource          `json:"pokemon"`         // Reference to the Pokémon that can be encountered
+	VersionDetails []EncounterVersionDetails `json:"version_details"` // How likely the encounter is in each game
+}
+
+// EncounterVersionDetails describes how likely an encounter is in one game.
+type EncounterVersionDetails struct {
+	MaxChance        int               `json:"max_chance"`        // The highest chance of the encounter, as a percentage
+	Version          NamedAPIResource  `json:"version"`           // The game the chance applies to
+	EncounterDetails []EncounterDetail `json:"encounter_details"` // The ways the Pokémon can be encountered in the game
+}
+
+// EncounterDetail describes one way a Pokémon can be encountered, such as
+// walking in tall grass or fishing with an Old Rod, and when it can be, such
+// as only at night or during a swarm.
+type EncounterDetail struct {
+	Chance          int                `json:"chance"`           // The chance of the encounter, as a percentage
+	MinLevel        int                `json:"min_level"`        // The lowest level the Pokémon can be
+	MaxLevel        int                `json:"max_level"`        // The highest level the Pokémon can be
+	Method          NamedAPIResource   `json:"method"`           // The encounter method (e.g. "walk", "surf", or "old-rod")
+	ConditionValues []NamedAPIResource `json:"condition_values"` // The conditions that must all hold for the encounter (e.g. "time-night" or "swarm-yes"), if any
+}
+
+// MaxChance returns the highest chance of the encounter in any game, as a percentage.
+func (e PokemonEncounter) MaxChance() int {
+	chance := 0
+	for _, details := range e.VersionDetails {
+		chance = max(chance, details.MaxChance)
+	}
+	return chance
+}
+
+// MethodChance returns the highest chance, in any game, of encountering the
+// Pokémon by the methods accepted by match, as a percentage.
+//
+// Parameters:
+//   - match: Reports whether an encounter method (e.g. "surf") is included
+//
+// Returns:
+//   - The sum of the chances of the matching methods in the game where it is highest
+func (e PokemonEncounter) MethodChance(match func(method string) bool) int {
+	return e.DetailChance(func(detail EncounterDetail) bool {
+		return match(detail.Method.Name)
+	})
+}
+
+// DetailChance returns the highest chance, in any game, of encountering the
+// Pokémon in the ways accepted by match, as a percentage.
+//
+// Parameters:
+//   - match: Reports whether a way of encountering the Pokémon is included
+//
+// Returns:
+//   - The sum of the chances of the matching details in the game where it is highest
+func (e PokemonEncounter) DetailChance(match func(detail EncounterDetail) bool) int {
+	chance := 0
+	for _, version := range e.VersionDetails {
+		sum := 0
+		for _, detail := range version.EncounterDetails {
+			if match(detail) {
+				sum += detail.Chance
+			}
+		}
+		chance = max(chance, sum)
+	}
+	return chance
+}
+
+// Methods returns the names of the methods the Pokémon can be encountered by,
+// in any game, in the order they first appear.
+func (e PokemonEncounter) Methods() []string {
+	var methods []string
+	seen := make(map[string]bool)
+	for _, version := range e.VersionDetails {
+		for _, detail := range version.EncounterDetails {
+			if !seen[detail.Method.Name] {
+				seen[detail.Method.Name] = true
+				methods = append(methods, detail.Method.Name)
+			}
+		}
+	}
+	return methods
+}
+
+// LocationAreaResp represents the details of a single location area in the PokeAPI.
+// It identifies the location the area belongs to, which is used to look up the
+// area's region when grouping map pages.
+type LocationAreaResp struct {
+	ID       int              `json:"id"`       // The identifier for this location area
+	Name     string           `json:"name"`     // The name of this location area
+	Location NamedAPIResource `json:"location"` // The location this area is part of
+}
+
+// LocationResp represents a location (such as a city or route) in the PokeAPI.
+// Locations contain one or more location areas and belong to a region.
+type LocationResp struct {
+	ID     int               `json:"id"`     // The identifier for this location
+	Name   string            `json:"name"`   // The name of this location
+	Region *NamedAPIResource `json:"region"` // The region this location is in, or null if it has none
 }
@@ -0,0 +1,86 @@
+// This file defines the data structures for working with move data from the PokeAPI.
+// Moves are used in battles, where their type and power decide how much damage they do,
+// and their meta data says which status conditions they can inflict.
+package pokeapi
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MoveResp represents the response from the move endpoint in the PokeAPI.
+// It includes the move's type, power, accuracy, and effects.
+type MoveResp struct {
+	ID                int                   `json:"id"`                  // The identifier for this move
+	Name              string                `json:"name"`                // The name of this move (e.g. "flamethrower")
+	Power             *int                  `json:"power"`               // The base power of the move, missing for moves that don't deal damage directly
+	Accuracy          *int                  `json:"accuracy"`            // The percent chance the move hits, missing for moves that never miss
+	PP                int                   `json:"pp"`                  // The number of times the move can be used
+	Priority          int                   `json:"priority"`            // When the move goes in a turn: higher goes first, and most moves have 0
+	EffectChance      *int                  `json:"effect_chance"`       // The percent chance of the move's secondary effect, missing if it has none or always happens
+	Type              NamedAPIResource      `json:"type"`                // The type of the move (e.g. "fire")
+	DamageClass       NamedAPIResource      `json:"damage_class"`        // Whether the move is "physical", "special", or "status"
+	Meta              *MoveMeta             `json:"meta"`                // The move's effects, missing for some newer moves
+	EffectEntries     []MoveEffect          `json:"effect_entries"`      // Descriptions of the move's effect in different languages
+	FlavorTextEntries []MoveFlavorTextEntry `json:"flavor_text_entries"` // The move's description in each game and language
+}
+
+// MoveEffect describes the effect of a move in one language. The text may
+// refer to the move's effect chance as "$effect_chance".
+type MoveEffect struct {
+	Effect      string           `json:"effect"`       // The full description of the move's effect
+	ShortEffect string           `json:"short_effect"` // A one-line summary of the move's effect
+	Language    NamedAPIResource `json:"language"`     // The language this description is in
+}
+
+// MoveFlavorTextEntry is a move's description from one version group, in one language.
+type MoveFlavorTextEntry struct {
+	FlavorText   string           `json:"flavor_text"`   // The description as shown in the games
+	Language     NamedAPIResource `json:"language"`      // The language this description is in
+	VersionGroup NamedAPIResource `json:"version_group"` // The version group this description is from
+}
+
+// EnglishEffect returns the short English description of the move's effect,
+// with its effect chance filled in, or an empty string if there is none.
+func (m MoveResp) EnglishEffect() string {
+	for _, entry := range m.EffectEntries {
+		if entry.Language.Name != "en" {
+			continue
+		}
+		if m.EffectChance == nil {
+			return entry.ShortEffect
+		}
+		return strings.ReplaceAll(entry.ShortEffect, "$effect_chance", strconv.Itoa(*m.EffectChance))
+	}
+	return ""
+}
+
+// EnglishFlavorText returns the move's English description from a version
+// group. If the version group is empty or has no description, the most recent
+// one is returned. The text is returned as the API gives it, line breaks included.
+//
+// Parameters:
+//   - versionGroup: The version group to prefer (e.g. "sword-shield"), or "" for any
+//
+// Returns:
+//   - The description, or an empty string if the move has no English description
+func (m MoveResp) EnglishFlavorText(versionGroup string) string {
+	var latest string
+	for _, entry := range m.FlavorTextEntries {
+		if entry.Language.Name != "en" {
+			continue
+		}
+		if versionGroup != "" && entry.VersionGroup.Name == versionGroup {
+			return entry.FlavorText
+		}
+		// The API lists the entries from the oldest games to the newest
+		latest = entry.FlavorText
+	}
+	return latest
+}
+
+// MoveMeta describes the effects of a move beyond the damage it deals.
+type MoveMeta struct {
+	Ailment       NamedAPIResource `json:"ailment"`        // The status condition the move can inflict (e.g. "paralysis"), or "none"
+	AilmentChance int              `json:"ailment_chance"` // The percent chance of inflicting it, or 0 if a status move always does
+}
@@ -0,0 +1,26 @@
+// This file defines the data structures for working with nature data from the PokeAPI.
+// A Pokémon's nature raises one of its stats by 10% and lowers another by 10%,
+// except for the neutral natures, which raise and lower the same stat.
+package pokeapi
+
+// NatureListResp represents the response from the nature list endpoint in the PokeAPI.
+type NatureListResp struct {
+	Count   int                `json:"count"`   // The total number of natures available in the API
+	Results []NamedAPIResource `json:"results"` // The natures on this page of results
+}
+
+// NatureResp represents the response from the nature endpoint in the PokeAPI.
+// It includes the stats the nature changes and the flavors it likes and hates.
+type NatureResp struct {
+	ID            int               `json:"id"`             // The identifier for this nature
+	Name          string            `json:"name"`           // The name of this nature (e.g. "adamant")
+	IncreasedStat *NamedAPIResource `json:"increased_stat"` // The stat raised by 10%, missing for neutral natures
+	DecreasedStat *NamedAPIResource `json:"decreased_stat"` // The stat lowered by 10%, missing for neutral natures
+	LikesFlavor   *NamedAPIResource `json:"likes_flavor"`   // The berry flavor the Pokémon likes, missing for neutral natures
+	HatesFlavor   *NamedAPIResource `json:"hates_flavor"`   // The berry flavor the Pokémon hates, missing for neutral natures
+}
+
+// Neutral reports whether the nature leaves every stat unchanged.
+func (n NatureResp) Neutral() bool {
+	return n.IncreasedStat == nil || n.DecreasedStat == nil || n.IncreasedStat.Name == n.DecreasedStat.Name
+}
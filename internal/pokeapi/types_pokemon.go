@@ -12,6 +12,8 @@ type PokemonDataResp struct {
 	Height int    `json:"height"` // The height of the Pokémon in decimeters
 	Weight int    `json:"weight"` // The weight of the Pokémon in hectograms
 
+	BaseExperience int `json:"base_experience"` // The experience gained for defeating the Pokémon
+
 	// Stats information
 	Stats []struct {
 		BaseStat int              `json:"base_stat"` // The base value for the stat
@@ -25,17 +27,73 @@ type PokemonDataResp struct {
 		Type NamedAPIResource `json:"type"` // The type the Pokémon has
 	} `json:"types"`
 
+	// Ability information
+	Abilities []PokemonAbility `json:"abilities"`
+
 	// Moves information
-	Moves []struct {
-		Move NamedAPIResource `json:"move"` // The move that can be learned
-	} `json:"moves"`
+	Moves []PokemonMove `json:"moves"`
 
 	// Species reference
 	Species NamedAPIResource `json:"species"` // The species this Pokémon belongs to
 
+	// Sprite information
+	Sprites PokemonSprites `json:"sprites"`
+
 	CaptureRate int `json:"capture_rate"` // The capture rate (not in the standard API response, added manually)
 }
 
+// PokemonSprites holds the URLs of a Pokémon's images. Only the default
+// front sprite is kept, since it's the only one the app shows.
+type PokemonSprites struct {
+	FrontDefault string `json:"front_default"` // The URL of the default front sprite, or "" if there isn't one
+}
+
+// PokemonAbility is an ability a Pokémon may have.
+type PokemonAbility struct {
+	Ability  NamedAPIResource `json:"ability"`   // The ability
+	IsHidden bool             `json:"is_hidden"` // Whether this is a hidden ability, which the Pokémon rarely has
+	Slot     int              `json:"slot"`      // The slot this ability occupies in this Pokémon
+}
+
+// PokemonMove is a move a Pokémon can learn, with the games it can learn it in.
+type PokemonMove struct {
+	Move                NamedAPIResource         `json:"move"`                            // The move that can be learned
+	VersionGroupDetails []MoveVersionGroupDetail `json:"version_group_details,omitempty"` // How the move is learned in each version group
+}
+
+// MoveVersionGroupDetail describes how a Pokémon learns a move in one version group.
+type MoveVersionGroupDetail struct {
+	LevelLearnedAt  int              `json:"level_learned_at"`  // The level the move is learned at (0 if not learned by level up)
+	MoveLearnMethod NamedAPIResource `json:"move_learn_method"` // How the move is learned (e.g. "level-up" or "machine")
+	VersionGroup    NamedAPIResource `json:"version_group"`     // The version group, such as "red-blue"
+}
+
+// LearnableIn reports whether the move can be learned in a version group.
+// Moves saved before version groups were recorded have no details, and are
+// treated as learnable in every version group.
+//
+// Parameters:
+//   - versionGroup: The version group name (e.g. "red-blue")
+func (m PokemonMove) LearnableIn(versionGroup string) bool {
+	if len(m.VersionGroupDetails) == 0 {
+		return true
+	}
+	for _, detail := range m.VersionGroupDetails {
+		if detail.VersionGroup.Name == versionGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// PokemonListResp represents the response from the pokemon list endpoint in the PokeAPI.
+// When requested with a large limit it contains every Pokémon the API knows about,
+// which is used to build the local name index for validation and suggestions.
+type PokemonListResp struct {
+	Count   int                `json:"count"`   // The total number of Pokémon available in the API
+	Results []NamedAPIResource `json:"results"` // The Pokémon on this page of results
+}
+
 // PokemonCaptureRateResp represents a specialized response containing just the capture rate.
 // This is used by the catch command to determine the probability of successfully
 // catching a Pokémon by comparing the capture rate against a random number.
@@ -44,7 +102,8 @@ type PokemonDataResp struct {
 // meaning the Pokémon is easier to catch. This struct is populated based on data
 // from the Pokémon species endpoint.
 type PokemonCaptureRateResp struct {
-	CaptureRate int `json:"capture_rate"` // The base capture rate between 0-255 (higher = easier to catch)
+	CaptureRate int  `json:"capture_rate"` // The base capture rate between 0-255 (higher = easier to catch)
+	Legendary   bool `json:"legendary"`    // Whether the species is legendary or mythical
 }
 
 // PokemonSpeciesResp represents the response from the pokemon-species endpoint.
@@ -56,12 +115,30 @@ type PokemonSpeciesResp struct {
 	ID   int    `json:"id"`   // The identifier for this Pokémon species
 	Name string `json:"name"` // The name of this Pokémon species (lowercase with hyphens)
 
+	// The official name of the species in each language (e.g. "Mr. Mime")
+	Names []Name `json:"names"`
+
+	// Catch information
+	CaptureRate int  `json:"capture_rate"` // The base capture rate between 0-255 (higher = easier to catch)
+	IsLegendary bool `json:"is_legendary"` // Whether the species is a legendary Pokémon
+	IsMythical  bool `json:"is_mythical"`  // Whether the species is a mythical Pokémon
+
+	// The generation the species was introduced in
+	Generation NamedAPIResource `json:"generation"`
+
+	// Biology
+	Habitat       *NamedAPIResource `json:"habitat"`        // The habitat the species lives in (missing for newer species)
+	Color         NamedAPIResource  `json:"color"`          // The species' main color in the Pokédex
+	Shape         *NamedAPIResource `json:"shape"`          // The species' body shape in the Pokédex
+	GrowthRate    NamedAPIResource  `json:"growth_rate"`    // How quickly the species gains levels
+	BaseHappiness *int              `json:"base_happiness"` // The happiness of a newly caught Pokémon (0-255)
+
+	// Breeding
+	EggGroups  []NamedAPIResource `json:"egg_groups"`  // The egg groups the species belongs to
+	GenderRate int                `json:"gender_rate"` // The chance of being female in eighths, or -1 if genderless
+
 	// Flavor text entries from different games
-	FlavorTextEntries []struct {
-		FlavorText string           `json:"flavor_text"` // The localized flavor text for this species in different games
-		Language   NamedAPIResource `json:"language"`    // The language this flavor text is in
-		Version    NamedAPIResource `json:"version"`     // The game version this flavor text is from
-	} `json:"flavor_text_entries"`
+	FlavorTextEntries []FlavorTextEntry `json:"flavor_text_entries"`
 
 	// Form descriptions
 	FormDescriptions []struct {
@@ -82,4 +159,32 @@ type PokemonSpeciesResp struct {
 
 	// Reference to the Pokémon species that evolves into this one
 	EvolvesFromSpecies *NamedAPIResource `json:"evolves_from_species"` // The species that evolves into this one, if any
+
+	// The Pokémon that belong to this species, such as regional and alternate forms
+	Varieties []PokemonSpeciesVariety `json:"varieties"`
+}
+
+// NamesByLanguage returns the species' official names, indexed by language
+// code (e.g. "en", "es").
+func (s PokemonSpeciesResp) NamesByLanguage() map[string]string {
+	names := make(map[string]string, len(s.Names))
+	for _, name := range s.Names {
+		if name.Name != "" {
+			names[name.Language.Name] = name.Name
+		}
+	}
+	return names
+}
+
+// PokemonSpeciesVariety is one of the Pokémon that belong to a species.
+type PokemonSpeciesVariety struct {
+	IsDefault bool             `json:"is_default"` // Whether this is the species' default Pokémon
+	Pokemon   NamedAPIResource `json:"pokemon"`    // The Pokémon
+}
+
+// FlavorTextEntry is a Pokédex entry for a species from one game, in one language.
+type FlavorTextEntry struct {
+	FlavorText string           `json:"flavor_text"` // The localized flavor text for this species in different games
+	Language   NamedAPIResource `json:"language"`    // The language this flavor text is in
+	Version    NamedAPIResource `json:"version"`     // The game version this flavor text is from
 }
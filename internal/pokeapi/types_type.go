@@ -0,0 +1,39 @@
+// This file defines the data structures for working with type data from the PokeAPI.
+// Types determine how effective attacks are, which is used to compare Pokémon
+// in matchups.
+package pokeapi
+
+// TypeResp represents the response from the type endpoint in the PokeAPI.
+// It describes how the type's attacks affect other types and how attacks
+// of other types affect it.
+type TypeResp struct {
+	ID              int             `json:"id"`               // The identifier for this type
+	Name            string          `json:"name"`             // The name of this type (e.g. "fire")
+	DamageRelations DamageRelations `json:"damage_relations"` // How this type interacts with other types
+	Pokemon         []TypePokemon   `json:"pokemon"`          // The Pokémon that have this type
+}
+
+// TypeListResp represents the response from the type list endpoint in the PokeAPI.
+// When requested with a large limit it contains every type, including the
+// types no Pokémon has (like "unknown" and "shadow").
+type TypeListResp struct {
+	Count   int                `json:"count"`   // The total number of types available in the API
+	Results []NamedAPIResource `json:"results"` // The types on this page of results
+}
+
+// TypePokemon is a Pokémon that has a type, with the slot the type is in.
+type TypePokemon struct {
+	Slot    int              `json:"slot"`    // Whether this is the Pokémon's first or second type
+	Pokemon NamedAPIResource `json:"pokemon"` // The Pokémon
+}
+
+// DamageRelations lists the types this type is strong or weak against.
+// The "to" lists describe this type's attacks; the "from" lists describe attacks against it.
+type DamageRelations struct {
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`       // Types this type's attacks don't affect
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`     // Types that resist this type's attacks
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`   // Types weak to this type's attacks
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`     // Types whose attacks don't affect this type
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`   // Types whose attacks this type resists
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"` // Types whose attacks this type is weak to
+}
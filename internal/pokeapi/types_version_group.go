@@ -0,0 +1,12 @@
+// This file defines the data structures for working with version group data from the PokeAPI.
+// A version group is a set of games that share their move data, such as Red and Blue.
+package pokeapi
+
+// VersionGroupResp represents the response from the version-group endpoint in the PokeAPI.
+type VersionGroupResp struct {
+	ID         int                `json:"id"`         // The identifier for this version group
+	Name       string             `json:"name"`       // The name of this version group (e.g. "red-blue")
+	Order      int                `json:"order"`      // The order the version groups were released in
+	Generation NamedAPIResource   `json:"generation"` // The generation the version group belongs to
+	Versions   []NamedAPIResource `json:"versions"`   // The games in the version group
+}
@@ -0,0 +1,194 @@
+// This file implements validation of decoded PokeAPI responses.
+// JSON decoding silently leaves missing fields at their zero values, so these
+// checks reject partial or malformed payloads before they can be cached or
+// stored in the user's Pokédex. They are registered with doGet as decode hooks.
+package pokeapi
+
+import (
+	"fmt"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// expectedStatCount is the number of base stats every Pokémon has:
+// HP, Attack, Defense, Special Attack, Special Defense, and Speed.
+const expectedStatCount = 6
+
+// validatePokemonData checks that Pokémon data has a name, a full set of
+// named stats, at least one type, and a species reference.
+func validatePokemonData(p *PokemonDataResp) error {
+	if p.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemon, "unknown", "missing name")
+	}
+	if len(p.Stats) != expectedStatCount {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemon, p.Name,
+			fmt.Sprintf("expected %d stats, got %d", expectedStatCount, len(p.Stats)))
+	}
+	for _, stat := range p.Stats {
+		if stat.Stat.Name == "" {
+			return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemon, p.Name, "stat with missing name")
+		}
+	}
+	if len(p.Types) == 0 {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemon, p.Name, "missing types")
+	}
+	if p.Species.Name == "" || p.Species.URL == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemon, p.Name, "missing species reference")
+	}
+	return nil
+}
+
+// validatePokemonSpecies checks that species data has a name.
+func validatePokemonSpecies(s *PokemonSpeciesResp) error {
+	if s.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemonSpecies, "unknown", "missing name")
+	}
+	return nil
+}
+
+// validatePokemonList checks that the Pokémon list is non-empty and every entry has a name.
+func validatePokemonList(l *PokemonListResp) error {
+	if len(l.Results) == 0 {
+		return errorhandling.NewInvalidResponseError("Pokémon list", "all", "no results")
+	}
+	return validateNamedResources("Pokémon list", l.Results)
+}
+
+// validateTypeList checks that the type list is non-empty and every entry has a name.
+func validateTypeList(l *TypeListResp) error {
+	if len(l.Results) == 0 {
+		return errorhandling.NewInvalidResponseError("type list", "all", "no results")
+	}
+	return validateNamedResources("type list", l.Results)
+}
+
+// validateNatureList checks that the nature list is non-empty and every entry has a name.
+func validateNatureList(l *NatureListResp) error {
+	if len(l.Results) == 0 {
+		return errorhandling.NewInvalidResponseError("nature list", "all", "no results")
+	}
+	return validateNamedResources("nature list", l.Results)
+}
+
+// validateLocationAreas checks that every location area on the page has a name.
+func validateLocationAreas(l *LocationAreasResp) error {
+	return validateNamedResources("location list", l.Results)
+}
+
+// validateLocationArea checks that a location area references its parent location.
+func validateLocationArea(l *LocationAreaResp) error {
+	if l.Location.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceLocation, l.Name, "missing parent location")
+	}
+	return nil
+}
+
+// validateLocation checks that location data has a name.
+func validateLocation(l *LocationResp) error {
+	if l.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceLocation, "unknown", "missing name")
+	}
+	return nil
+}
+
+// validateLocationExplore checks that every encounter references a named Pokémon.
+func validateLocationExplore(l *LocationExploreResp) error {
+	for _, encounter := range l.PokemonEncounters {
+		if encounter.Pokemon.Name == "" {
+			return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemonEncounter, "unknown", "encounter with missing Pokémon name")
+		}
+	}
+	return nil
+}
+
+// validateEvolutionChain checks that the chain starts with a named species.
+func validateEvolutionChain(e *EvolutionChainResp) error {
+	if e.Chain.Species.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceEvolutionChain,
+			fmt.Sprintf("ID: %d", e.ID), "missing base species")
+	}
+	return nil
+}
+
+// validateGeneration checks that a generation lists species that each have a name and ID.
+func validateGeneration(g *GenerationResp) error {
+	if len(g.PokemonSpecies) == 0 {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceGeneration, g.Name, "no species")
+	}
+	for _, species := range g.PokemonSpecies {
+		if _, err := species.ID(); err != nil || species.Name == "" {
+			return errorhandling.NewInvalidResponseError(errorhandling.ResourceGeneration, g.Name,
+				fmt.Sprintf("species with missing name or ID: %+v", species))
+		}
+	}
+	return nil
+}
+
+// validateType checks that type data has a name.
+func validateType(t *TypeResp) error {
+	if t.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceType, "unknown", "missing name")
+	}
+	return nil
+}
+
+// validateEggGroup checks that egg group data has a name and that every member species has a name.
+func validateEggGroup(g *EggGroupResp) error {
+	if g.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceEggGroup, "unknown", "missing name")
+	}
+	return validateNamedResources(errorhandling.ResourceEggGroup, g.PokemonSpecies)
+}
+
+// validateItem checks that item data has a name.
+func validateItem(i *ItemResp) error {
+	if i.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceItem, "unknown", "missing name")
+	}
+	return nil
+}
+
+// validateNature checks that nature data has a name.
+func validateNature(n *NatureResp) error {
+	if n.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceNature, "unknown", "missing name")
+	}
+	return nil
+}
+
+// validateMove checks that move data has a name and a type.
+func validateMove(m *MoveResp) error {
+	if m.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemonMove, "unknown", "missing name")
+	}
+	if m.Type.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemonMove, m.Name, "missing type")
+	}
+	return nil
+}
+
+// validateAbility checks that ability data has a name.
+func validateAbility(a *AbilityResp) error {
+	if a.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourcePokemonAbility, "unknown", "missing name")
+	}
+	return nil
+}
+
+// validateVersionGroup checks that version group data has a name.
+func validateVersionGroup(v *VersionGroupResp) error {
+	if v.Name == "" {
+		return errorhandling.NewInvalidResponseError(errorhandling.ResourceVersionGroup, "unknown", "missing name")
+	}
+	return nil
+}
+
+// validateNamedResources checks that every resource in a list has a name.
+func validateNamedResources(resourceType string, resources []NamedAPIResource) error {
+	for i, resource := range resources {
+		if resource.Name == "" {
+			return errorhandling.NewInvalidResponseError(resourceType, fmt.Sprintf("entry %d", i+1), "missing name")
+		}
+	}
+	return nil
+}
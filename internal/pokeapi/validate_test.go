@@ -0,0 +1,38 @@
+package pokeapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// TestValidatePokemonData tests that partial Pokémon data is rejected
+// with an InvalidResponse error
+func TestValidatePokemonData(t *testing.T) {
+	valid := testPokemonData("pikachu", "pikachu")
+	if err := validatePokemonData(&valid); err != nil {
+		t.Fatalf("Expected valid data to pass, got %v", err)
+	}
+
+	cases := map[string]func(p *PokemonDataResp){
+		"Missing name":    func(p *PokemonDataResp) { p.Name = "" },
+		"Missing stats":   func(p *PokemonDataResp) { p.Stats = p.Stats[:2] },
+		"Unnamed stat":    func(p *PokemonDataResp) { p.Stats[0].Stat.Name = "" },
+		"Missing types":   func(p *PokemonDataResp) { p.Types = nil },
+		"Missing species": func(p *PokemonDataResp) { p.Species = NamedAPIResource{} },
+	}
+
+	for name, corrupt := range cases {
+		t.Run(name, func(t *testing.T) {
+			pokemon := testPokemonData("pikachu", "pikachu")
+			corrupt(&pokemon)
+
+			err := validatePokemonData(&pokemon)
+			var appErr *errorhandling.AppError
+			if !errors.As(err, &appErr) || appErr.Type != errorhandling.InvalidResponse {
+				t.Errorf("Expected InvalidResponse error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package pokeapi
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// GetVersionGroup retrieves a version group and the games it contains.
+// This is used to check the version group chosen with the "versiongroup" command.
+// Results are cached to improve performance and reduce API calls.
+//
+// Parameters:
+//   - name: The name of the version group (e.g. "red-blue")
+//
+// Returns:
+//   - A VersionGroupResp containing the version group's data
+//   - An error if the API request fails or the version group doesn't exist
+func (c *Client) GetVersionGroup(name string) (VersionGroupResp, error) {
+	fullURL := baseURL + "/version-group/" + name
+
+	return doGet[VersionGroupResp](c.context(), c, fullURL,
+		withDecodeHook(validateVersionGroup),
+		withNotFound(func(err error) error {
+			return errorhandling.FormatResourceNotFoundError(errorhandling.ResourceVersionGroup, name, err)
+		}))
+}
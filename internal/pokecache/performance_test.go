@@ -0,0 +1,33 @@
+//go:build performance
+
+// This file contains the cache's performance budget, a test that fails if
+// adding or looking up an entry gets far slower than it is today, since every
+// API call goes through the cache. It benchmarks the cache for several
+// seconds, so it is excluded from normal runs. Run it with:
+//
+//	go test -tags performance ./internal/pokecache/
+package pokecache
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCachePerformanceBudget checks adding and looking up entries. The
+// budgets leave plenty of room for slow machines and the race detector.
+func TestCachePerformanceBudget(t *testing.T) {
+	budgets := []struct {
+		name      string
+		benchmark func(*testing.B)
+		budget    time.Duration
+	}{
+		{"Add", BenchmarkCacheAdd, 20 * time.Microsecond},
+		{"Get", BenchmarkCacheGet, 20 * time.Microsecond},
+	}
+	for _, b := range budgets {
+		result := testing.Benchmark(b.benchmark)
+		if perOp := time.Duration(result.NsPerOp()); perOp > b.budget {
+			t.Errorf("%s took %v per operation, over its budget of %v", b.name, perOp, b.budget)
+		}
+	}
+}
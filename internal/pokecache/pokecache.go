@@ -4,7 +4,8 @@
 // API calls and improve application performance.
 //
 // The package implements a key-value cache that automatically removes expired
-// entries in the background. Cache operations are thread-safe, making it suitable
+// entries in the background. Entries expire after the cache's interval unless
+// they are added with a duration of their own. Cache operations are thread-safe, making it suitable
 // for concurrent access in applications with multiple goroutines.
 //
 // Usage Example:
@@ -31,16 +32,23 @@ import (
 // It uses a mutex to ensure thread-safety for concurrent operations, making it
 // suitable for use in concurrent applications.
 type Cache struct {
-	cache map[string]cacheEntry // Internal map storing cached data
-	mu    sync.Mutex            // Mutex for thread-safe operations
+	cache    map[string]cacheEntry // Internal map storing cached data
+	interval time.Duration         // How long entries remain valid unless added with their own duration
+	mu       sync.Mutex            // Mutex for thread-safe operations
 }
 
 // cacheEntry represents a single item in the cache.
-// Each entry contains the cached value as a byte slice and a timestamp
-// indicating when it was created, used to determine expiration.
+// Each entry contains the cached value as a byte slice, a timestamp
+// indicating when it was created, and how long it remains valid.
 type cacheEntry struct {
-	val       []byte    // The cached data as a byte slice
-	createdAt time.Time // Timestamp when the entry was created
+	val       []byte        // The cached data as a byte slice
+	createdAt time.Time     // Timestamp when the entry was created
+	ttl       time.Duration // How long the entry remains valid after it was created
+}
+
+// expired reports whether the entry is no longer valid at the given time.
+func (e cacheEntry) expired(now time.Time) bool {
+	return e.createdAt.Before(now.Add(-e.ttl))
 }
 
 // NewCache creates and initializes a new Cache with automatic cleanup.
@@ -53,29 +61,47 @@ type cacheEntry struct {
 // Returns:
 //   - A pointer to the newly created Cache
 func NewCache(interval time.Duration) *Cache {
-	c := &Cache{cache: make(map[string]cacheEntry)}
+	c := &Cache{cache: make(map[string]cacheEntry), interval: interval}
 	go c.reapLoop(interval)
 	return c
 }
 
 // Add stores a value in the cache with the specified key.
 // If the key already exists, its value will be overwritten with the new value.
-// The entry is timestamped with the current UTC time for expiration tracking.
+// The entry is timestamped with the current UTC time for expiration tracking,
+// and expires after the cache's interval.
 //
 // Parameters:
 //   - key: The string key to associate with the value
 //   - val: The byte slice value to store in the cache
 func (c *Cache) Add(key string, val []byte) {
+	c.AddWithTTL(key, val, c.interval)
+}
+
+// AddWithTTL stores a value in the cache that expires after its own duration
+// rather than the cache's interval. This lets data that rarely changes be kept
+// longer, and data that changes often be dropped sooner.
+//
+// Parameters:
+//   - key: The string key to associate with the value
+//   - val: The byte slice value to store in the cache
+//   - ttl: How long the entry remains valid; zero or less uses the cache's interval
+func (c *Cache) AddWithTTL(key string, val []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = c.interval
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.cache[key] = cacheEntry{
 		val:       val,
 		createdAt: time.Now().UTC(),
+		ttl:       ttl,
 	}
 }
 
 // Get retrieves a value from the cache by its key.
 // It returns the value and a boolean indicating whether the key was found.
+// Entries that have expired but not yet been removed are not returned.
 //
 // Parameters:
 //   - key: The string key to look up
@@ -87,7 +113,10 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	value, ok := c.cache[key]
-	return value.val, ok
+	if !ok || value.expired(time.Now().UTC()) {
+		return nil, false
+	}
+	return value.val, true
 }
 
 // reapLoop runs in a separate goroutine and periodically triggers
@@ -98,21 +127,18 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 func (c *Cache) reapLoop(interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	for range ticker.C {
-		c.reap(interval)
+		c.reap()
 	}
 }
 
-// reap removes all cache entries that have expired based on the provided interval.
-// An entry is considered expired if its creation time is before (now - interval).
-//
-// Parameters:
-//   - interval: The time duration used to determine if an entry has expired
-func (c *Cache) reap(interval time.Duration) {
+// reap removes all cache entries that have expired, that is, whose creation
+// time is longer ago than the duration they remain valid for.
+func (c *Cache) reap() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	timeAgo := time.Now().UTC().Add(-interval)
+	now := time.Now().UTC()
 	for k, v := range c.cache {
-		if v.createdAt.Before(timeAgo) {
+		if v.expired(now) {
 			delete(c.cache, k)
 		}
 	}
@@ -1,9 +1,11 @@
 // This file contains tests for the pokecache package.
 // It verifies the functionality of the cache implementation, including
-// creation, adding/retrieving values, and automatic expiration of entries.
+// creation, adding/retrieving values, and automatic expiration of entries,
+// and measures how fast entries are added and looked up.
 package pokecache
 
 import (
+	"strconv"
 	"testing"
 	"time"
 )
@@ -68,3 +70,64 @@ func TestReap(t *testing.T) {
 		t.Errorf("%s should have been reaped", keyOne)
 	}
 }
+
+// TestAddWithTTL verifies that entries added with their own duration expire
+// after that duration rather than the cache's interval.
+func TestAddWithTTL(t *testing.T) {
+	interval := time.Millisecond * 20
+	cache := NewCache(interval)
+	cache.AddWithTTL("short", []byte("val1"), time.Millisecond)
+	cache.AddWithTTL("long", []byte("val2"), time.Hour)
+	cache.AddWithTTL("default", []byte("val3"), 0)
+
+	time.Sleep(time.Millisecond * 5)
+	if _, ok := cache.Get("short"); ok {
+		t.Error("short should have expired")
+	}
+	if _, ok := cache.Get("default"); !ok {
+		t.Error("default should still be cached")
+	}
+
+	time.Sleep(interval * 3)
+	if _, ok := cache.Get("long"); !ok {
+		t.Error("long should have outlived the cache's interval")
+	}
+	if _, ok := cache.Get("default"); ok {
+		t.Error("default should have expired after the cache's interval")
+	}
+}
+
+// benchmarkKeys are the keys the benchmarks use: as many as a long session
+// caches, shaped like the API URLs the client caches responses under.
+var benchmarkKeys = func() []string {
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = "https://pokeapi.co/api/v2/pokemon/" + strconv.Itoa(i+1)
+	}
+	return keys
+}()
+
+// BenchmarkCacheAdd measures adding a response to the cache.
+func BenchmarkCacheAdd(b *testing.B) {
+	cache := NewCache(time.Hour)
+	val := make([]byte, 4096)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.Add(benchmarkKeys[i%len(benchmarkKeys)], val)
+	}
+}
+
+// BenchmarkCacheGet measures looking a response up in a full cache.
+func BenchmarkCacheGet(b *testing.B) {
+	cache := NewCache(time.Hour)
+	val := make([]byte, 4096)
+	for _, key := range benchmarkKeys {
+		cache.Add(key, val)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := cache.Get(benchmarkKeys[i%len(benchmarkKeys)]); !ok {
+			b.Fatal("Expected the key to be cached")
+		}
+	}
+}
@@ -0,0 +1,347 @@
+// This file defines the entries stored in the user's Pokédex.
+// An entry holds the Pokémon data retrieved from the API when it was caught,
+// along with information the user adds themselves, such as notes, boxes, and
+// an active moveset.
+package pokedex
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// MaxMovesetSize is the number of moves a Pokémon can have in its active moveset.
+const MaxMovesetSize = 4
+
+// DefaultLevel is the level of a Pokémon whose level hasn't been recorded,
+// such as one caught before levels were tracked.
+const DefaultLevel = 5
+
+// MaxLevel is the highest level a Pokémon can reach.
+const MaxLevel = 100
+
+// MaxStatEVs is the most effort values (EVs) a Pokémon can have in one stat.
+const MaxStatEVs = 252
+
+// MaxTotalEVs is the most effort values a Pokémon can have across all its stats.
+const MaxTotalEVs = 510
+
+// Entry represents a single caught Pokémon in the user's Pokédex.
+// The API data is embedded so that its fields are saved at the top level of
+// each entry, keeping save files from earlier versions compatible.
+// Fields the user adds are declared alongside the embedded data rather than in
+// it, so that they carry over when the Pokémon evolves and only the data changes.
+type Entry struct {
+	pokeapi.PokemonDataResp                      // Pokémon data from the API at the time of capture
+	Notes                   []string             `json:"notes,omitempty"`           // Free-form notes added by the user
+	Box                     string               `json:"box,omitempty"`             // The box the Pokémon is stored in, if any
+	Moveset                 []string             `json:"moveset,omitempty"`         // Active moves chosen by the user (up to MaxMovesetSize)
+	PreEvolution            *EvolutionSnapshot   `json:"pre_evolution,omitempty"`   // The Pokémon before it last evolved, if it has evolved
+	CaughtAt                string               `json:"caught_at,omitempty"`       // The location area it was caught in, if known
+	CaughtOn                time.Time            `json:"caught_on,omitzero"`        // When it was caught (zero for entries from older saves)
+	Level                   int                  `json:"level,omitempty"`           // The Pokémon's level (zero means DefaultLevel)
+	Experience              int                  `json:"experience,omitempty"`      // Experience points earned toward the next level
+	DaycareSince            time.Time            `json:"daycare_since,omitzero"`    // When it was left at the day care (zero if it isn't there)
+	BattlesWon              int                  `json:"battles_won,omitempty"`     // The number of battle rounds it has won
+	Ribbons                 []string             `json:"ribbons,omitempty"`         // The ribbons it has earned, in the order they were earned
+	Happiness               int                  `json:"happiness,omitempty"`       // Happiness gained from minigames, petting, and playing
+	MinigameScores          map[string]int       `json:"minigame_scores,omitempty"` // Its best score in each minigame it has played
+	EVs                     map[string]int       `json:"evs,omitempty"`             // Effort values gained in battle, by API stat name
+	Interactions            map[string]time.Time `json:"interactions,omitempty"`    // When the user last interacted with it, by interaction (e.g. "pet")
+	Damage                  int                  `json:"damage,omitempty"`          // HP lost in battle and not yet healed
+	Status                  string               `json:"status,omitempty"`          // The status condition it was left with after a battle (e.g. "poison"), or "" for none
+	HeldItem                string               `json:"held_item,omitempty"`       // The API name of the item it holds in battle, or "" for none
+}
+
+// EvolutionSnapshot records a Pokémon as it was before it evolved, so that the
+// evolution can be undone.
+type EvolutionSnapshot struct {
+	Name      string    `json:"name"`                // The Pokédex name of the previous form
+	Entry     Entry     `json:"entry"`               // The previous entry, including its own earlier forms
+	EvolvedOn time.Time `json:"evolved_on,omitzero"` // When it evolved (zero for evolutions from older saves)
+}
+
+// NewEntry creates a Pokédex entry for newly caught Pokémon data.
+//
+// Parameters:
+//   - data: The Pokémon data retrieved from the API
+//
+// Returns:
+//   - An Entry with no user-added information
+func NewEntry(data pokeapi.PokemonDataResp) Entry {
+	return Entry{PokemonDataResp: data}
+}
+
+// withData returns a copy of the entry with its Pokémon data replaced, keeping
+// everything the user has added. This is used when a Pokémon evolves.
+//
+// Parameters:
+//   - data: The new Pokémon data
+//
+// Returns:
+//   - The updated Entry
+func (e Entry) withData(data pokeapi.PokemonDataResp) Entry {
+	e.PokemonDataResp = data
+	return e
+}
+
+// Clone returns a deep copy of the entry, sharing none of its lists, maps, or
+// earlier forms, so that changes to either copy don't show in the other. The
+// API data is shared, since it's only ever replaced, never changed in place.
+func (e Entry) Clone() Entry {
+	e.Notes = slices.Clone(e.Notes)
+	e.Moveset = slices.Clone(e.Moveset)
+	e.Ribbons = slices.Clone(e.Ribbons)
+	e.MinigameScores = maps.Clone(e.MinigameScores)
+	e.EVs = maps.Clone(e.EVs)
+	e.Interactions = maps.Clone(e.Interactions)
+	if e.PreEvolution != nil {
+		previous := *e.PreEvolution
+		previous.Entry = previous.Entry.Clone()
+		e.PreEvolution = &previous
+	}
+	return e
+}
+
+// EvolveInto returns the entry for the evolved form of this Pokémon. The evolved
+// entry keeps everything the user has added and remembers the current entry so
+// that the evolution can be undone.
+//
+// Parameters:
+//   - name: The Pokédex name of the current form
+//   - data: The Pokémon data of the evolved form
+//   - at: When the Pokémon evolved
+//
+// Returns:
+//   - The Entry for the evolved form
+func (e Entry) EvolveInto(name string, data pokeapi.PokemonDataResp, at time.Time) Entry {
+	// Copy the user's lists so later changes to the evolved form don't alter the snapshot
+	previous := e.Clone()
+
+	evolved := e.withData(data)
+	evolved.PreEvolution = &EvolutionSnapshot{Name: name, Entry: previous, EvolvedOn: at}
+	return evolved
+}
+
+// CanLearn reports whether the Pokémon can learn a move, based on its learnable moves.
+//
+// Parameters:
+//   - move: The move name in API format
+//   - versionGroup: The version group the move must be learnable in, or "" for any
+func (e Entry) CanLearn(move, versionGroup string) bool {
+	for _, m := range e.Moves {
+		if m.Move.Name == move {
+			return versionGroup == "" || m.LearnableIn(versionGroup)
+		}
+	}
+	return false
+}
+
+// LearnableMoves returns the moves the Pokémon can learn.
+//
+// Parameters:
+//   - versionGroup: The version group the moves must be learnable in, or "" for any
+//
+// Returns:
+//   - The names of the learnable moves in API format, in the API's order
+func (e Entry) LearnableMoves(versionGroup string) []string {
+	moves := make([]string, 0, len(e.Moves))
+	for _, m := range e.Moves {
+		if versionGroup == "" || m.LearnableIn(versionGroup) {
+			moves = append(moves, m.Move.Name)
+		}
+	}
+	return moves
+}
+
+// KnowsMove reports whether a move is in the Pokémon's active moveset.
+//
+// Parameters:
+//   - move: The move name in API format
+func (e Entry) KnowsMove(move string) bool {
+	return slices.Contains(e.Moveset, move)
+}
+
+// ActiveMoves returns the moves the Pokémon uses in showoffs and battles.
+// If the user hasn't taught it any moves, all of its learnable moves are used.
+//
+// Parameters:
+//   - versionGroup: The version group the learnable moves are limited to, or "" for any
+//
+// Returns:
+//   - The names of the active moves in API format
+func (e Entry) ActiveMoves(versionGroup string) []string {
+	if len(e.Moveset) > 0 {
+		return e.Moveset
+	}
+	return e.LearnableMoves(versionGroup)
+}
+
+// CurrentLevel returns the Pokémon's level, or DefaultLevel if it hasn't been recorded.
+func (e Entry) CurrentLevel() int {
+	if e.Level == 0 {
+		return DefaultLevel
+	}
+	return e.Level
+}
+
+// ExperienceToNextLevel returns the experience points a Pokémon needs to grow
+// from a level to the next one. Levels follow the games' medium fast growth
+// rate, where reaching level n takes n³ points in total.
+func ExperienceToNextLevel(level int) int {
+	next := level + 1
+	return next*next*next - level*level*level
+}
+
+// GainExperience adds experience points to the Pokémon, raising its level each
+// time it earns enough for the next one. Points earned at MaxLevel are lost.
+//
+// Parameters:
+//   - points: The experience points earned
+//
+// Returns:
+//   - The number of levels gained
+func (e *Entry) GainExperience(points int) int {
+	start := e.CurrentLevel()
+	level := start
+	e.Experience += points
+	for level < MaxLevel && e.Experience >= ExperienceToNextLevel(level) {
+		e.Experience -= ExperienceToNextLevel(level)
+		level++
+	}
+	if level >= MaxLevel {
+		e.Experience = 0
+	}
+	e.Level = level
+	return level - start
+}
+
+// TotalEVs returns the sum of the Pokémon's effort values across all its stats.
+func (e Entry) TotalEVs() int {
+	total := 0
+	for _, evs := range e.EVs {
+		total += evs
+	}
+	return total
+}
+
+// GainEVs adds effort values to the Pokémon's stats, as earned by defeating
+// another Pokémon. EVs stop growing at MaxStatEVs in a stat and MaxTotalEVs in
+// all, and EVs beyond those limits are lost. Stats are filled in order of
+// their API names, so the result is the same whatever order the yield is in.
+//
+// Parameters:
+//   - yield: The EVs earned, by API stat name
+//
+// Returns:
+//   - The EVs actually gained, by API stat name, leaving out stats that gained none
+func (e *Entry) GainEVs(yield map[string]int) map[string]int {
+	gained := make(map[string]int)
+	total := e.TotalEVs()
+	for _, stat := range slices.Sorted(maps.Keys(yield)) {
+		points := min(yield[stat], MaxStatEVs-e.EVs[stat], MaxTotalEVs-total)
+		if points <= 0 {
+			continue
+		}
+		if e.EVs == nil {
+			e.EVs = make(map[string]int)
+		}
+		e.EVs[stat] += points
+		total += points
+		gained[stat] = points
+	}
+	return gained
+}
+
+// InteractedOn reports whether the user had an interaction with the Pokémon on
+// the same calendar day as a time, in that time's location.
+//
+// Parameters:
+//   - interaction: The interaction's identifier (e.g. "pet")
+//   - day: Any time on the day to check
+func (e Entry) InteractedOn(interaction string, day time.Time) bool {
+	last, ok := e.Interactions[interaction]
+	if !ok {
+		return false
+	}
+	last = last.In(day.Location())
+	return last.Year() == day.Year() && last.YearDay() == day.YearDay()
+}
+
+// RecordInteraction records when the user had an interaction with the Pokémon.
+//
+// Parameters:
+//   - interaction: The interaction's identifier (e.g. "pet")
+//   - at: When the interaction happened
+func (e *Entry) RecordInteraction(interaction string, at time.Time) {
+	if e.Interactions == nil {
+		e.Interactions = make(map[string]time.Time)
+	}
+	e.Interactions[interaction] = at
+}
+
+// Hurt reports whether the Pokémon has lost HP or has a status condition from a battle.
+func (e Entry) Hurt() bool {
+	return e.Damage > 0 || e.Status != ""
+}
+
+// Heal restores the Pokémon to full health and cures its status condition.
+func (e *Entry) Heal() {
+	e.Damage = 0
+	e.Status = ""
+}
+
+// InDaycare reports whether the Pokémon has been left at the day care.
+func (e Entry) InDaycare() bool {
+	return !e.DaycareSince.IsZero()
+}
+
+// InParty reports whether the Pokémon is with the user, rather than stored in
+// a box or left at the day care.
+func (e Entry) InParty() bool {
+	return e.Box == "" && !e.InDaycare()
+}
+
+// HasRibbon reports whether the Pokémon has earned a ribbon.
+//
+// Parameters:
+//   - ribbon: The ribbon's identifier
+func (e Entry) HasRibbon(ribbon string) bool {
+	return slices.Contains(e.Ribbons, ribbon)
+}
+
+// AwardRibbon gives the Pokémon a ribbon, unless it already has it.
+//
+// Parameters:
+//   - ribbon: The ribbon's identifier
+//
+// Returns:
+//   - Whether the ribbon was newly awarded
+func (e *Entry) AwardRibbon(ribbon string) bool {
+	if e.HasRibbon(ribbon) {
+		return false
+	}
+	e.Ribbons = append(e.Ribbons, ribbon)
+	return true
+}
+
+// RecordMinigameScore keeps a minigame score if it's the Pokémon's best in that game.
+//
+// Parameters:
+//   - game: The minigame's identifier
+//   - score: The score achieved
+//
+// Returns:
+//   - Whether the score is a new best
+func (e *Entry) RecordMinigameScore(game string, score int) bool {
+	if best, played := e.MinigameScores[game]; played && score <= best {
+		return false
+	}
+	if e.MinigameScores == nil {
+		e.MinigameScores = make(map[string]int)
+	}
+	e.MinigameScores[game] = score
+	return true
+}
@@ -0,0 +1,222 @@
+package pokedex
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestEvolveIntoKeepsUserData tests that evolving replaces only the Pokémon data,
+// carrying every field the user has added over to the evolved entry. The check
+// covers all fields of Entry, so new user fields are included automatically.
+func TestEvolveIntoKeepsUserData(t *testing.T) {
+	original := NewEntry(pokeapi.PokemonDataResp{Name: "pichu"})
+	original.Notes = []string{"Hatched from an egg"}
+	original.Box = "favorites"
+	original.Moveset = []string{"thunder-shock", "charm"}
+	original.Ribbons = []string{"victory"}
+
+	evolvedOn := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	evolved := original.EvolveInto("pichu", pokeapi.PokemonDataResp{Name: "pikachu"}, evolvedOn)
+	if evolved.Name != "pikachu" {
+		t.Errorf("Expected the evolved data, got %q", evolved.Name)
+	}
+	if evolved.PreEvolution == nil || evolved.PreEvolution.Name != "pichu" || !evolved.PreEvolution.EvolvedOn.Equal(evolvedOn) {
+		t.Errorf("Expected the previous form to be recorded, got %+v", evolved.PreEvolution)
+	}
+
+	originalValue, evolvedValue := reflect.ValueOf(original), reflect.ValueOf(evolved)
+	for i := 0; i < originalValue.NumField(); i++ {
+		field := originalValue.Type().Field(i)
+		if field.Anonymous || field.Name == "PreEvolution" {
+			continue
+		}
+		if !reflect.DeepEqual(originalValue.Field(i).Interface(), evolvedValue.Field(i).Interface()) {
+			t.Errorf("Expected %s to carry over, got %v instead of %v", field.Name,
+				evolvedValue.Field(i).Interface(), originalValue.Field(i).Interface())
+		}
+	}
+}
+
+// TestClone tests that a cloned entry shares no lists or maps with the
+// original, including those of its earlier forms. Like the test above, the
+// check covers all fields of Entry, so new user fields are included automatically.
+func TestClone(t *testing.T) {
+	original := NewEntry(pokeapi.PokemonDataResp{Name: "pichu"})
+	original.Notes = []string{"Hatched from an egg"}
+	original.Moveset = []string{"thunder-shock"}
+	original.Ribbons = []string{"victory"}
+	original.MinigameScores = map[string]int{"quiz": 3}
+	original.EVs = map[string]int{"speed": 4}
+	original.Interactions = map[string]time.Time{"pet": time.Now()}
+	original = original.EvolveInto("pichu", pokeapi.PokemonDataResp{Name: "pikachu"}, time.Now())
+
+	clone := original.Clone()
+	if !reflect.DeepEqual(clone, original) {
+		t.Fatalf("Expected an equal copy, got %+v", clone)
+	}
+	for _, pair := range [][2]Entry{{original, clone}, {original.PreEvolution.Entry, clone.PreEvolution.Entry}} {
+		originalValue, cloneValue := reflect.ValueOf(pair[0]), reflect.ValueOf(pair[1])
+		for i := 0; i < originalValue.NumField(); i++ {
+			field := originalValue.Type().Field(i)
+			kind := field.Type.Kind()
+			if field.Anonymous || (kind != reflect.Slice && kind != reflect.Map && kind != reflect.Pointer) {
+				continue
+			}
+			if !originalValue.Field(i).IsNil() && originalValue.Field(i).Pointer() == cloneValue.Field(i).Pointer() {
+				t.Errorf("Expected %s to be copied, but the clone shares it", field.Name)
+			}
+		}
+	}
+
+	clone.EVs["speed"] = 8
+	clone.PreEvolution.Entry.Notes[0] = "Changed"
+	if original.EVs["speed"] != 4 || original.PreEvolution.Entry.Notes[0] != "Hatched from an egg" {
+		t.Error("Expected changes to the clone not to affect the original")
+	}
+}
+
+// TestCurrentLevel tests that entries without a recorded level use the default level
+func TestCurrentLevel(t *testing.T) {
+	if level := (Entry{}).CurrentLevel(); level != DefaultLevel {
+		t.Errorf("Expected the default level %d, got %d", DefaultLevel, level)
+	}
+	if level := (Entry{Level: 42}).CurrentLevel(); level != 42 {
+		t.Errorf("Expected level 42, got %d", level)
+	}
+}
+
+// TestGainExperience tests that experience raises levels as it's earned, with
+// leftover points carried toward the next level and none kept at the maximum level
+func TestGainExperience(t *testing.T) {
+	tests := []struct {
+		name           string
+		entry          Entry
+		points         int
+		wantLevels     int
+		wantLevel      int
+		wantExperience int
+	}{
+		{"not enough for a level", Entry{}, 90, 0, DefaultLevel, 90},
+		{"exactly one level", Entry{}, 91, 1, 6, 0},
+		{"several levels with leftover", Entry{Level: 10, Experience: 300}, 331 + 397 - 300 + 5, 2, 12, 5},
+		{"capped at the maximum level", Entry{Level: MaxLevel - 1}, 1000000, 1, MaxLevel, 0},
+		{"at the maximum level", Entry{Level: MaxLevel}, 500, 0, MaxLevel, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := tt.entry
+			if levels := entry.GainExperience(tt.points); levels != tt.wantLevels {
+				t.Errorf("Expected %d levels gained, got %d", tt.wantLevels, levels)
+			}
+			if entry.Level != tt.wantLevel || entry.Experience != tt.wantExperience {
+				t.Errorf("Expected level %d with %d experience, got level %d with %d",
+					tt.wantLevel, tt.wantExperience, entry.Level, entry.Experience)
+			}
+		})
+	}
+}
+
+// TestGainEVs tests that effort values stop growing at the limits for a stat
+// and in all, and that only the EVs actually gained are reported
+func TestGainEVs(t *testing.T) {
+	tests := []struct {
+		name       string
+		evs        map[string]int
+		yield      map[string]int
+		wantGained map[string]int
+		wantEVs    map[string]int
+	}{
+		{"first EVs", nil, map[string]int{"speed": 2}, map[string]int{"speed": 2}, map[string]int{"speed": 2}},
+		{"capped in a stat", map[string]int{"attack": 251}, map[string]int{"attack": 3},
+			map[string]int{"attack": 1}, map[string]int{"attack": MaxStatEVs}},
+		{"capped in all", map[string]int{"attack": 252, "speed": 252, "hp": 5}, map[string]int{"defense": 1, "hp": 2},
+			map[string]int{"defense": 1}, map[string]int{"attack": 252, "speed": 252, "hp": 5, "defense": 1}},
+		{"nothing left to gain", map[string]int{"attack": 252, "speed": 252, "hp": 6}, map[string]int{"defense": 1},
+			map[string]int{}, map[string]int{"attack": 252, "speed": 252, "hp": 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := Entry{EVs: tt.evs}
+			if gained := entry.GainEVs(tt.yield); !maps.Equal(gained, tt.wantGained) {
+				t.Errorf("Expected %v gained, got %v", tt.wantGained, gained)
+			}
+			if !maps.Equal(entry.EVs, tt.wantEVs) {
+				t.Errorf("Expected EVs %v, got %v", tt.wantEVs, entry.EVs)
+			}
+			if entry.TotalEVs() > MaxTotalEVs {
+				t.Errorf("Expected at most %d EVs in all, got %d", MaxTotalEVs, entry.TotalEVs())
+			}
+		})
+	}
+}
+
+// TestInteractedOn tests that interactions are limited to one per calendar
+// day, in the local time of the day being checked
+func TestInteractedOn(t *testing.T) {
+	zone := time.FixedZone("UTC-5", -5*60*60)
+	var entry Entry
+	if entry.InteractedOn("pet", time.Now()) {
+		t.Error("Expected no interactions yet")
+	}
+
+	entry.RecordInteraction("pet", time.Date(2024, 5, 1, 23, 0, 0, 0, zone))
+	tests := []struct {
+		interaction string
+		day         time.Time
+		want        bool
+	}{
+		{"pet", time.Date(2024, 5, 1, 8, 0, 0, 0, zone), true},
+		{"pet", time.Date(2024, 5, 2, 0, 30, 0, 0, zone), false},
+		{"pet", time.Date(2025, 5, 1, 23, 0, 0, 0, zone), false},
+		{"play", time.Date(2024, 5, 1, 23, 30, 0, 0, zone), false},
+	}
+	for _, tt := range tests {
+		if got := entry.InteractedOn(tt.interaction, tt.day); got != tt.want {
+			t.Errorf("InteractedOn(%q, %v) = %v, want %v", tt.interaction, tt.day, got, tt.want)
+		}
+	}
+}
+
+// TestAwardRibbon tests that a ribbon is only awarded once
+func TestAwardRibbon(t *testing.T) {
+	var entry Entry
+	if !entry.AwardRibbon("victory") {
+		t.Error("Expected the first ribbon to be awarded")
+	}
+	if entry.AwardRibbon("victory") {
+		t.Error("Expected the same ribbon not to be awarded twice")
+	}
+	if !entry.HasRibbon("victory") || len(entry.Ribbons) != 1 {
+		t.Errorf("Expected one victory ribbon, got %v", entry.Ribbons)
+	}
+}
+
+// TestLearnableMoves tests that moves are limited to a version group, and that
+// moves saved without version group details are learnable in every game
+func TestLearnableMoves(t *testing.T) {
+	detail := func(group string) pokeapi.MoveVersionGroupDetail {
+		return pokeapi.MoveVersionGroupDetail{VersionGroup: pokeapi.NamedAPIResource{Name: group}}
+	}
+	entry := NewEntry(pokeapi.PokemonDataResp{Moves: []pokeapi.PokemonMove{
+		{Move: pokeapi.NamedAPIResource{Name: "thunder-shock"}, VersionGroupDetails: []pokeapi.MoveVersionGroupDetail{detail("red-blue"), detail("sword-shield")}},
+		{Move: pokeapi.NamedAPIResource{Name: "nuzzle"}, VersionGroupDetails: []pokeapi.MoveVersionGroupDetail{detail("sword-shield")}},
+		{Move: pokeapi.NamedAPIResource{Name: "growl"}},
+	}})
+
+	if got := entry.LearnableMoves(""); !reflect.DeepEqual(got, []string{"thunder-shock", "nuzzle", "growl"}) {
+		t.Errorf("Expected every move without a version group, got %v", got)
+	}
+	if got := entry.LearnableMoves("red-blue"); !reflect.DeepEqual(got, []string{"thunder-shock", "growl"}) {
+		t.Errorf("Expected the red-blue moves, got %v", got)
+	}
+	if entry.CanLearn("nuzzle", "red-blue") || !entry.CanLearn("nuzzle", "") {
+		t.Error("Expected nuzzle to be learnable only outside red-blue")
+	}
+	if got := entry.ActiveMoves("red-blue"); len(got) != 2 {
+		t.Errorf("Expected the active moves to follow the version group, got %v", got)
+	}
+}
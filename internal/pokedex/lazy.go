@@ -0,0 +1,197 @@
+// This file implements loading a save without decoding its entries. Most of a
+// large save is the entries' API data, such as the moves each Pokémon can
+// learn, and decoding all of it before the prompt appears makes startup slow.
+// Saves include an index of where each Pokémon is kept, so ReadFileLazy can
+// keep each entry's JSON as it is, and the Pokédex decodes an entry the first
+// time it's used: one at a time when a Pokémon or the party is looked up, and
+// all of them at once when the whole Pokédex is listed, summarized, or saved.
+//
+// An entry that turns out not to match the save format when it's decoded, such
+// as after the save was edited by hand, can't be used, but isn't dropped
+// either: it's kept as it was saved and written back unchanged, and why it
+// couldn't be decoded is reported through DecodeProblems.
+package pokedex
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+)
+
+// IndexEntry is where a Pokémon is kept, as recorded in the index of a save.
+// Its fields have the same JSON names as the entry's own.
+type IndexEntry struct {
+	Box          string    `json:"box,omitempty"`          // The box the Pokémon is stored in, if any
+	DaycareSince time.Time `json:"daycare_since,omitzero"` // When it was left at the day care (zero if it isn't there)
+}
+
+// indexOf returns the index of some entries.
+func indexOf(entries map[string]Entry) map[string]IndexEntry {
+	index := make(map[string]IndexEntry, len(entries))
+	for name, entry := range entries {
+		index[name] = IndexEntry{Box: entry.Box, DaycareSince: entry.DaycareSince}
+	}
+	return index
+}
+
+// rawEntry is an entry read from a save and not decoded yet.
+type rawEntry struct {
+	data   json.RawMessage // The entry's JSON
+	header IndexEntry      // Where the Pokémon is kept
+}
+
+// inParty reports whether the Pokémon is in the party, like Entry.InParty.
+func (r rawEntry) inParty() bool {
+	return r.header.Box == "" && r.header.DaycareSince.IsZero()
+}
+
+// lazySaveData is the save data with its entries left undecoded. Its Pokedex
+// field takes the place of the one in SaveData when decoding.
+type lazySaveData struct {
+	SaveData
+	Pokedex map[string]json.RawMessage `json:"pokedex"`
+}
+
+// ReadFileLazy loads saved data from disk like ReadFile, without decoding the
+// entries. The entries are only available by passing the data to
+// Pokedex.ResetFrom; the Pokedex field of the data is nil.
+//
+// Parameters:
+//   - path: The path to the save file
+//
+// Returns:
+//   - The saved data, and whether a save file exists
+//   - An error if the load operation fails for any reason
+func ReadFileLazy(path string) (SaveData, bool, error) {
+	var lazy lazySaveData
+	found, err := readJSONFile(path, &lazy, saveSchemaDef)
+	if err != nil || !found {
+		return SaveData{}, found, err
+	}
+	data := lazy.SaveData
+	data.lazyEntries = make(map[string]rawEntry, len(lazy.Pokedex))
+	for name, raw := range lazy.Pokedex {
+		header, indexed := data.Index[name]
+		if !indexed {
+			// Saves from older versions have no index, so the header is
+			// decoded from the entry itself, which is slower
+			if err := json.Unmarshal(raw, &header); err != nil {
+				return SaveData{}, false, decodeError(raw, entrySchemaDef, joinPath("pokedex", name), err)
+			}
+		}
+		data.lazyEntries[name] = rawEntry{data: raw, header: header}
+	}
+	data.Index = nil
+	return data, true, nil
+}
+
+// ResetFrom replaces the entries and boxes with those in save data, like
+// Reset. Entries read by ReadFileLazy stay undecoded until they're used, and
+// the data's unreadable entries are kept as they are.
+//
+// Parameters:
+//   - data: The save data, from ReadFile, ReadFileLazy, or Export
+func (p *Pokedex) ResetFrom(data SaveData) {
+	p.Reset(data.Pokedex, data.Boxes)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.unreadable = maps.Clone(data.Unreadable)
+	for name := range p.unreadable {
+		delete(p.entries, name)
+	}
+	if len(data.lazyEntries) == 0 {
+		return
+	}
+	p.raw = maps.Clone(data.lazyEntries)
+	for name, raw := range p.raw {
+		delete(p.entries, name)
+		if raw.header.Box != "" {
+			p.boxes[raw.header.Box] = true
+		}
+	}
+}
+
+// Party returns the Pokémon in the party, sorted by name. Unlike List, it
+// only decodes the party's entries.
+func (p *Pokedex) Party() []NamedEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for name, raw := range p.raw {
+		if raw.inParty() {
+			p.entry(name)
+		}
+	}
+	var party []NamedEntry
+	for _, name := range slices.Sorted(maps.Keys(p.entries)) {
+		if entry := p.entries[name]; entry.InParty() {
+			party = append(party, NamedEntry{Name: name, Entry: entry})
+		}
+	}
+	return party
+}
+
+// entry returns an entry, decoding it first if it hasn't been. An entry that
+// can't be decoded is reported as missing. The caller must hold the write lock.
+func (p *Pokedex) entry(name string) (Entry, bool) {
+	if entry, exists := p.entries[name]; exists {
+		return entry, true
+	}
+	raw, exists := p.raw[name]
+	if !exists {
+		return Entry{}, false
+	}
+	delete(p.raw, name)
+	return p.decode(name, raw)
+}
+
+// decodeAll decodes every entry that hasn't been. The caller must hold the
+// write lock.
+func (p *Pokedex) decodeAll() {
+	for name, raw := range p.raw {
+		p.decode(name, raw)
+	}
+	p.raw = nil
+}
+
+// decode decodes an entry and adds it to the decoded ones. An entry that
+// doesn't match the save format is kept as it was saved instead, with the
+// fields that don't match recorded for DecodeProblems. The caller must hold
+// the write lock.
+//
+// Returns:
+//   - The entry, and whether it could be decoded
+func (p *Pokedex) decode(name string, raw rawEntry) (Entry, bool) {
+	var entry Entry
+	if err := json.Unmarshal(raw.data, &entry); err != nil {
+		if p.unreadable == nil {
+			p.unreadable = make(map[string]json.RawMessage)
+		}
+		p.unreadable[name] = raw.data
+		p.problems = append(p.problems, decodeError(raw.data, entrySchemaDef, joinPath("pokedex", name), err))
+		return Entry{}, false
+	}
+	p.entries[name] = entry
+	return entry, true
+}
+
+// DecodeProblems returns why entries loaded by ReadFileLazy couldn't be
+// decoded since it was last called, one error per entry, such as a
+// SchemaError listing the fields that don't match the save format. The
+// entries can't be used until the save is fixed, but are kept in it.
+func (p *Pokedex) DecodeProblems() []error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	problems := p.problems
+	p.problems = nil
+	return problems
+}
+
+// has reports whether a Pokémon is in the Pokédex, decoded or not. The caller
+// must hold the lock.
+func (p *Pokedex) has(name string) bool {
+	_, decoded := p.entries[name]
+	_, undecoded := p.raw[name]
+	_, unreadable := p.unreadable[name]
+	return decoded || undecoded || unreadable
+}
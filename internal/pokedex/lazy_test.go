@@ -0,0 +1,161 @@
+package pokedex
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestReadFileLazy tests that a save loaded lazily only decodes the entries
+// that are used, and otherwise behaves like one loaded with ReadFile
+func TestReadFileLazy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	dex := New()
+	for _, name := range []string{"pikachu", "eevee"} {
+		dex.Add(name, NewEntry(pokeapi.PokemonDataResp{Name: name, Height: 4}))
+	}
+	boxed := NewEntry(pokeapi.PokemonDataResp{Name: "onix"})
+	boxed.Box = "rocks"
+	dex.Add("onix", boxed)
+	resting := NewEntry(pokeapi.PokemonDataResp{Name: "snorlax"})
+	resting.DaycareSince = time.Now()
+	dex.Add("snorlax", resting)
+	saved := dex.Export()
+	saved.Money = 100
+	if err := WriteFile(path, saved); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	data, found, err := ReadFileLazy(path)
+	if err != nil || !found {
+		t.Fatalf("Failed to load: found=%v, err=%v", found, err)
+	}
+	if data.Pokedex != nil || data.Money != 100 {
+		t.Errorf("Expected the settings without the entries, got %+v", data)
+	}
+	loaded := New()
+	loaded.ResetFrom(data)
+	if loaded.Len() != 4 || len(loaded.raw) != 4 {
+		t.Fatalf("Expected 4 undecoded entries, got %d of %d", len(loaded.raw), loaded.Len())
+	}
+	if !loaded.HasBox("rocks") || loaded.PartyCount("") != 2 || !loaded.HasSeen("onix") {
+		t.Error("Expected boxes, the party count, and sightings without decoding the entries")
+	}
+
+	// Looking up the party decodes only its members
+	party := loaded.Party()
+	if len(party) != 2 || party[0].Name != "eevee" || party[1].Name != "pikachu" || party[1].Entry.Height != 4 {
+		t.Errorf("Unexpected party: %+v", party)
+	}
+	if len(loaded.raw) != 2 {
+		t.Errorf("Expected the boxed and day care entries to stay undecoded, got %d undecoded", len(loaded.raw))
+	}
+	if onix, exists := loaded.Get("onix"); !exists || onix.Box != "rocks" || len(loaded.raw) != 1 {
+		t.Errorf("Expected onix to be decoded on its own, got %+v with %d undecoded", onix, len(loaded.raw))
+	}
+
+	// Undecoded entries can be replaced and removed
+	if err := loaded.Replace("pikachu", "snorlax", func(entry Entry) (Entry, error) { return entry, nil }); err != ErrNameTaken {
+		t.Errorf("Expected an undecoded name to be taken, got %v", err)
+	}
+	if !loaded.Remove("snorlax") || loaded.Len() != 3 {
+		t.Errorf("Expected snorlax to be removed, leaving 3 Pokémon, got %d", loaded.Len())
+	}
+
+	// Saving decodes everything, and gives the same entries as an eager load
+	eager, _, _ := ReadFile(path)
+	delete(eager.Pokedex, "snorlax")
+	if exported := loaded.Export(); !reflect.DeepEqual(exported.Pokedex, eager.Pokedex) {
+		t.Errorf("Expected the lazily loaded entries to match, got %+v, expected %+v", exported.Pokedex, eager.Pokedex)
+	}
+}
+
+// TestReadFileLazyWithoutIndex tests that saves from versions that didn't
+// write an index still load lazily, with the index read from the entries
+func TestReadFileLazyWithoutIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	boxed := NewEntry(pokeapi.PokemonDataResp{Name: "onix"})
+	boxed.Box = "rocks"
+	old := SaveData{Pokedex: map[string]Entry{"pikachu": NewEntry(pokeapi.PokemonDataResp{Name: "pikachu"}), "onix": boxed}}
+	if err := writeJSONFile(path, old); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	data, _, err := ReadFileLazy(path)
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	loaded := New()
+	loaded.ResetFrom(data)
+	if loaded.Len() != 2 || !loaded.HasBox("rocks") || loaded.PartyCount("") != 1 {
+		t.Errorf("Expected the boxes and party from the entries, got %d Pokémon, boxes %v, party of %d",
+			loaded.Len(), loaded.Boxes(), loaded.PartyCount(""))
+	}
+}
+
+// TestReadFileLazyKeepsUnreadableEntries tests that an entry that doesn't match
+// the save format is reported when it's first used, and is written back as it
+// was saved rather than with the fields that didn't decode left empty
+func TestReadFileLazyKeepsUnreadableEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	broken := `{"name":"pikachu","height":"tall","level":12}`
+	save := `{"pokedex": {"pikachu": ` + broken + `, "eevee": {"name": "eevee"}},
+		"index": {"pikachu": {}, "eevee": {}}}`
+	if err := os.WriteFile(path, []byte(save), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _, err := ReadFileLazy(path)
+	if err != nil {
+		t.Fatalf("Expected the entries to be left for later, got %v", err)
+	}
+	loaded := New()
+	loaded.ResetFrom(data)
+	if _, exists := loaded.Get("pikachu"); exists {
+		t.Error("Expected the unreadable entry not to be usable")
+	}
+	problems := loaded.DecodeProblems()
+	var schemaErr *SchemaError
+	if len(problems) != 1 || !errors.As(problems[0], &schemaErr) ||
+		!reflect.DeepEqual(schemaErr.Problems, []SchemaProblem{{"pokedex.pikachu.height", "integer", `string "tall"`}}) {
+		t.Fatalf("Expected the height to be reported, got %v", problems)
+	}
+	if again := loaded.DecodeProblems(); len(again) != 0 {
+		t.Errorf("Expected the problem to be reported once, got %v", again)
+	}
+	if loaded.Len() != 2 || len(loaded.List()) != 1 {
+		t.Errorf("Expected the unreadable entry to be counted but not listed, got %d and %d", loaded.Len(), len(loaded.List()))
+	}
+
+	// Copies of the state, as taken by dry runs, keep the entry too
+	var copied SaveData
+	encoded, _ := json.Marshal(loaded.Export())
+	if err := json.Unmarshal(encoded, &copied); err != nil {
+		t.Fatal(err)
+	}
+	restored := New()
+	restored.ResetFrom(copied)
+	if err := WriteFile(path, restored.Export()); err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file struct {
+		Pokedex    map[string]json.RawMessage `json:"pokedex"`
+		Unreadable json.RawMessage            `json:"unreadable"`
+	}
+	if err := json.Unmarshal(written, &file); err != nil {
+		t.Fatal(err)
+	}
+	if string(file.Pokedex["pikachu"]) != broken || file.Unreadable != nil {
+		t.Errorf("Expected the entry to be written back unchanged, got %s", written)
+	}
+}
@@ -0,0 +1,137 @@
+// This file diagnoses the save file's lock when it can't be acquired. While a
+// process writes the save file, it records itself as the lock's owner in the
+// lock file, so that another process that times out waiting for the lock can
+// report who holds it. If the owner is no longer running, as can happen with
+// network file systems that keep the locks of crashed clients, the lock is
+// stale and can be broken.
+package pokedex
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// lockWait is how long to wait for the lock on a file. It is LockTimeout, but
+// tests shorten it.
+var lockWait = LockTimeout
+
+// ErrLockNotStale is returned by BreakLock when the lock's owner is running
+// again, or another process has taken the lock since it was found stale.
+var ErrLockNotStale = errors.New("the lock is no longer stale")
+
+// LockOwner describes the process writing a file.
+type LockOwner struct {
+	PID   int       `json:"pid"`   // The process ID of the owner
+	Host  string    `json:"host"`  // The name of the machine it runs on
+	Since time.Time `json:"since"` // When it acquired the lock
+}
+
+// LockTimeoutError is returned when the lock on a file can't be acquired in time.
+type LockTimeoutError struct {
+	Path   string        // The path of the locked file
+	Read   bool          // Whether the lock was wanted for reading
+	Owner  *LockOwner    // The process holding the lock, if it's known
+	Stale  bool          // Whether the owner is known to be no longer running
+	Waited time.Duration // How long the lock was waited for
+}
+
+// Error describes the timeout and, if it's known, who holds the lock.
+func (e *LockTimeoutError) Error() string {
+	kind := "lock"
+	if e.Read {
+		kind = "read lock"
+	}
+	msg := fmt.Sprintf("could not acquire %s on save file: timeout after %v", kind, e.Waited)
+	switch {
+	case e.Owner == nil:
+		return msg + " (held by another process, perhaps one reading the file)"
+	case e.Stale:
+		return msg + fmt.Sprintf(" (held by process %d, which is no longer running)", e.Owner.PID)
+	default:
+		return msg + fmt.Sprintf(" (held by process %d on %s since %s)",
+			e.Owner.PID, e.Owner.Host, e.Owner.Since.Local().Format(time.DateTime))
+	}
+}
+
+// newLockTimeoutError describes a lock on a file that couldn't be acquired.
+func newLockTimeoutError(path string, read bool) *LockTimeoutError {
+	err := &LockTimeoutError{Path: path, Read: read, Waited: lockWait}
+	if owner, ok := readLockOwner(path); ok {
+		err.Owner = &owner
+		err.Stale = isStale(owner)
+	}
+	return err
+}
+
+// BreakLock removes the lock file of a file whose lock is stale, so that the
+// next attempt to lock it creates a new one. The owner is checked again first,
+// so a lock is never broken if its owner has come back or someone else has
+// taken it over.
+//
+// Parameters:
+//   - err: The timeout that found the lock stale
+//
+// Returns:
+//   - ErrLockNotStale if the lock is no longer stale, or an error if the lock file can't be removed
+func BreakLock(err *LockTimeoutError) error {
+	owner, ok := readLockOwner(err.Path)
+	if !err.Stale || !ok || owner != *err.Owner || !isStale(owner) {
+		return ErrLockNotStale
+	}
+	if err := os.Remove(lockFilePath(err.Path)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing lock file: %w", err)
+	}
+	return nil
+}
+
+// recordLockOwner records this process as the owner of a file's lock. It is
+// only called while holding the exclusive lock. Shared locks aren't recorded,
+// since several readers can hold one at once.
+func recordLockOwner(path string) {
+	host, _ := os.Hostname()
+	encoded, err := json.Marshal(LockOwner{PID: os.Getpid(), Host: host, Since: time.Now()})
+	if err == nil {
+		// The owner is only a diagnostic, so failing to record it doesn't fail the write
+		os.WriteFile(lockFilePath(path), encoded, 0644)
+	}
+}
+
+// clearLockOwner removes the owner recorded by recordLockOwner, before the
+// lock is released.
+func clearLockOwner(path string) {
+	os.Truncate(lockFilePath(path), 0)
+}
+
+// readLockOwner reads the owner recorded in a file's lock file, if any.
+func readLockOwner(path string) (LockOwner, bool) {
+	encoded, err := os.ReadFile(lockFilePath(path))
+	if err != nil || len(encoded) == 0 {
+		return LockOwner{}, false
+	}
+	var owner LockOwner
+	if err := json.Unmarshal(encoded, &owner); err != nil || owner.PID <= 0 {
+		return LockOwner{}, false
+	}
+	return owner, true
+}
+
+// isStale reports whether a lock's owner is known to be no longer running.
+// Processes on other machines can't be checked, so their locks are never stale.
+func isStale(owner LockOwner) bool {
+	host, err := os.Hostname()
+	if err != nil || owner.Host != host || owner.PID == os.Getpid() {
+		return false
+	}
+	process, err := os.FindProcess(owner.PID)
+	if err != nil {
+		// On Windows, finding a process fails once it has exited
+		return true
+	}
+	// Signal 0 checks that the process exists without disturbing it. Where it
+	// isn't supported, the process is assumed to be running.
+	return errors.Is(process.Signal(syscall.Signal(0)), os.ErrProcessDone)
+}
@@ -0,0 +1,93 @@
+package pokedex
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// holdLock locks a save file as another process would, recording the given
+// owner, until the test ends
+func holdLock(t *testing.T, path string, owner LockOwner) {
+	t.Helper()
+	fileLock := flock.New(lockFilePath(path))
+	if locked, err := fileLock.TryLock(); err != nil || !locked {
+		t.Fatalf("Failed to lock: %v", err)
+	}
+	t.Cleanup(func() { fileLock.Unlock() })
+	encoded, _ := json.Marshal(owner)
+	if err := os.WriteFile(lockFilePath(path), encoded, 0644); err != nil {
+		t.Fatalf("Failed to record the owner: %v", err)
+	}
+}
+
+// shortenLockWait makes lock timeouts quick for the rest of the test
+func shortenLockWait(t *testing.T) {
+	wait := lockWait
+	lockWait = 200 * time.Millisecond
+	t.Cleanup(func() { lockWait = wait })
+}
+
+// TestStaleLock tests that a lock held for a process that has exited is
+// reported as stale, and that breaking it lets the file be written
+func TestStaleLock(t *testing.T) {
+	shortenLockWait(t)
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	exited := exec.Command("go", "version")
+	if err := exited.Run(); err != nil {
+		t.Skipf("Could not run a process to exit: %v", err)
+	}
+	host, _ := os.Hostname()
+	owner := LockOwner{PID: exited.Process.Pid, Host: host, Since: time.Now().Truncate(time.Second)}
+	holdLock(t, path, owner)
+
+	err := WriteFile(path, SaveData{})
+	var lockErr *LockTimeoutError
+	if !errors.As(err, &lockErr) {
+		t.Fatalf("Expected a lock timeout, got %v", err)
+	}
+	if !lockErr.Stale || lockErr.Owner == nil || lockErr.Owner.PID != owner.PID || !lockErr.Owner.Since.Equal(owner.Since) {
+		t.Fatalf("Expected the lock to be stale, got %+v", lockErr)
+	}
+
+	if err := BreakLock(lockErr); err != nil {
+		t.Fatalf("Failed to break the lock: %v", err)
+	}
+	if err := WriteFile(path, SaveData{Money: 100}); err != nil {
+		t.Fatalf("Expected the write to succeed once the lock was broken, got %v", err)
+	}
+	if _, ok := readLockOwner(path); ok {
+		t.Error("Expected the owner to be cleared once the write finished")
+	}
+}
+
+// TestLiveLock tests that a lock held by a running process is reported with its
+// owner, and isn't broken
+func TestLiveLock(t *testing.T) {
+	shortenLockWait(t)
+	path := filepath.Join(t.TempDir(), "save.json")
+	owner := LockOwner{PID: os.Getppid(), Host: "elsewhere", Since: time.Now().Truncate(time.Second)}
+	if err := WriteFile(path, SaveData{}); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	holdLock(t, path, owner)
+
+	_, _, err := ReadFile(path)
+	var lockErr *LockTimeoutError
+	if !errors.As(err, &lockErr) || !lockErr.Read || lockErr.Stale || lockErr.Owner == nil {
+		t.Fatalf("Expected a timeout on a lock that isn't stale, got %v", err)
+	}
+	if !errors.Is(BreakLock(lockErr), ErrLockNotStale) {
+		t.Error("Expected a lock that isn't stale not to be broken")
+	}
+	if _, err := os.Stat(lockFilePath(path)); err != nil {
+		t.Errorf("Expected the lock file to be kept, got %v", err)
+	}
+}
@@ -0,0 +1,342 @@
+// Package pokedex manages the user's collection of caught Pokémon: the entries
+// themselves, the boxes used to organize them, and saving them to disk.
+//
+// A Pokedex is safe for concurrent use. Every method takes the Pokédex's own
+// lock, and the maps and slices it returns are copies, so callers never share
+// state with the Pokédex after a method returns.
+//
+// Usage Example:
+//
+//	dex := pokedex.New()
+//	dex.Add("pikachu", pokedex.NewEntry(data))
+//
+//	// Change an entry in place
+//	err := dex.Update("pikachu", func(entry *pokedex.Entry) error {
+//	    entry.Notes = append(entry.Notes, "Caught on Route 1")
+//	    return nil
+//	})
+package pokedex
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ErrNotFound is returned when an operation names a Pokémon that is not in the Pokédex.
+var ErrNotFound = errors.New("pokémon is not in the pokédex")
+
+// ErrNameTaken is returned when an entry would replace a different Pokémon with the same name.
+var ErrNameTaken = errors.New("a pokémon with that name is already in the pokédex")
+
+// Pokedex holds the user's caught Pokémon, indexed by name, and their boxes.
+type Pokedex struct {
+	entries map[string]Entry    // Caught Pokémon indexed by name
+	raw     map[string]rawEntry // Caught Pokémon loaded from a save and not decoded yet, indexed by name (see lazy.go)
+
+	unreadable map[string]json.RawMessage // Caught Pokémon whose saved entries couldn't be decoded, kept as saved (see lazy.go)
+	problems   []error                    // Why entries couldn't be decoded, until they're taken by DecodeProblems
+	boxes      map[string]bool            // Names of the boxes used to organize the Pokédex
+	seen       map[string]Sighting        // Pokémon the user has seen, indexed by name (see seen.go)
+	visits     map[string]Visits          // Visits to each location area, indexed by name (see visits.go)
+	mu         sync.RWMutex               // Mutex for thread-safe operations
+}
+
+// NamedEntry pairs a Pokédex entry with the name it is stored under.
+type NamedEntry struct {
+	Name  string // The Pokémon's name in the Pokédex
+	Entry Entry  // The Pokédex entry
+}
+
+// Stats summarizes the contents of a Pokédex.
+type Stats struct {
+	Total   int            // Number of Pokémon in the Pokédex
+	Seen    int            // Number of different Pokémon seen, including every caught one
+	Boxes   int            // Number of boxes
+	Boxed   int            // Number of Pokémon stored in a box
+	Evolved int            // Number of Pokémon that have evolved since being caught
+	Types   map[string]int // Number of Pokémon of each type (dual-type Pokémon count toward both)
+}
+
+// New creates an empty Pokédex.
+func New() *Pokedex {
+	return &Pokedex{
+		entries: make(map[string]Entry),
+		boxes:   make(map[string]bool),
+		seen:    make(map[string]Sighting),
+		visits:  make(map[string]Visits),
+	}
+}
+
+// Add adds a Pokémon to the Pokédex, replacing any entry with the same name.
+// If the entry is stored in a box, the box is created if needed.
+//
+// Parameters:
+//   - name: The Pokémon's name in the Pokédex
+//   - entry: The Pokédex entry
+func (p *Pokedex) Add(name string, entry Entry) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.raw, name)
+	delete(p.unreadable, name)
+	p.entries[name] = entry
+	if entry.Box != "" {
+		p.boxes[entry.Box] = true
+	}
+}
+
+// Remove removes a Pokémon from the Pokédex.
+//
+// Parameters:
+//   - name: The Pokémon's name in the Pokédex
+//
+// Returns:
+//   - Whether the Pokémon was in the Pokédex
+func (p *Pokedex) Remove(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	exists := p.has(name)
+	delete(p.entries, name)
+	delete(p.raw, name)
+	delete(p.unreadable, name)
+	return exists
+}
+
+// Get returns the entry for a caught Pokémon.
+//
+// Parameters:
+//   - name: The Pokémon's name in the Pokédex
+//
+// Returns:
+//   - The entry, and whether the Pokémon is in the Pokédex
+func (p *Pokedex) Get(name string) (Entry, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.entry(name)
+}
+
+// List returns every entry in the Pokédex, sorted by name.
+func (p *Pokedex) List() []NamedEntry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.decodeAll()
+	list := make([]NamedEntry, 0, len(p.entries))
+	for _, name := range slices.Sorted(maps.Keys(p.entries)) {
+		list = append(list, NamedEntry{Name: name, Entry: p.entries[name]})
+	}
+	return list
+}
+
+// All returns a snapshot of the Pokédex indexed by name. The snapshot is a
+// separate map, so it can be read while the Pokédex changes.
+func (p *Pokedex) All() map[string]Entry {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.decodeAll()
+	return maps.Clone(p.entries)
+}
+
+// Len returns the number of Pokémon in the Pokédex.
+func (p *Pokedex) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.entries) + len(p.raw) + len(p.unreadable)
+}
+
+// PartyCount returns the number of Pokémon in the user's party.
+//
+// Parameters:
+//   - exclude: A name to leave out of the count, such as a Pokémon that is
+//     about to be replaced ("" to count every Pokémon)
+func (p *Pokedex) PartyCount(exclude string) int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	count := 0
+	for name, entry := range p.entries {
+		if name != exclude && entry.InParty() {
+			count++
+		}
+	}
+	for name, raw := range p.raw {
+		if name != exclude && raw.inParty() {
+			count++
+		}
+	}
+	return count
+}
+
+// Stats returns a summary of the Pokédex.
+func (p *Pokedex) Stats() Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.decodeAll()
+	stats := Stats{
+		Total: len(p.entries),
+		Seen:  len(p.seen),
+		Boxes: len(p.boxes),
+		Types: make(map[string]int),
+	}
+	for name, entry := range p.entries {
+		if _, seen := p.seen[name]; !seen {
+			stats.Seen++
+		}
+		if entry.Box != "" {
+			stats.Boxed++
+		}
+		if entry.PreEvolution != nil {
+			stats.Evolved++
+		}
+		for _, t := range entry.Types {
+			stats.Types[t.Type.Name]++
+		}
+	}
+	return stats
+}
+
+// Update changes an entry while holding the Pokédex lock, so that reading the
+// entry and storing the change can't be interleaved with other changes.
+// If update returns an error, the entry is left unchanged.
+//
+// Parameters:
+//   - name: The Pokémon's name in the Pokédex
+//   - update: A function that modifies the entry it's given
+//
+// Returns:
+//   - ErrNotFound if the Pokémon is not in the Pokédex, or the error from update
+func (p *Pokedex) Update(name string, update func(entry *Entry) error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, exists := p.entry(name)
+	if !exists {
+		return ErrNotFound
+	}
+	if err := update(&entry); err != nil {
+		return err
+	}
+	p.entries[name] = entry
+	return nil
+}
+
+// Replace swaps an entry for one under a new name, as when a Pokémon evolves
+// or devolves. If the new entry is stored in a box, the box is created if needed.
+//
+// Parameters:
+//   - oldName: The name of the entry to replace
+//   - newName: The name of the new entry
+//   - replace: A function that builds the new entry from the old one
+//
+// Returns:
+//   - ErrNotFound if the old Pokémon is not in the Pokédex, ErrNameTaken if a
+//     different Pokémon already has the new name, or the error from replace
+func (p *Pokedex) Replace(oldName, newName string, replace func(entry Entry) (Entry, error)) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	entry, exists := p.entry(oldName)
+	if !exists {
+		return ErrNotFound
+	}
+	if p.has(newName) && newName != oldName {
+		return ErrNameTaken
+	}
+
+	newEntry, err := replace(entry)
+	if err != nil {
+		return err
+	}
+	delete(p.entries, oldName)
+	p.entries[newName] = newEntry
+	if newEntry.Box != "" {
+		p.boxes[newEntry.Box] = true
+	}
+	return nil
+}
+
+// Reset replaces the entries and boxes, as when loading a save or starting over.
+// Every box that a Pokémon is stored in is included, even if it's not listed.
+// The recorded sightings and visits are cleared; use RestoreSeen and
+// RestoreVisits to load them.
+//
+// Parameters:
+//   - entries: The new entries (nil for an empty Pokédex)
+//   - boxes: The names of the new boxes
+func (p *Pokedex) Reset(entries map[string]Entry, boxes []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.entries = maps.Clone(entries)
+	if p.entries == nil {
+		p.entries = make(map[string]Entry)
+	}
+	p.raw = nil
+	p.unreadable = nil
+	p.boxes = make(map[string]bool, len(boxes))
+	p.seen = make(map[string]Sighting)
+	p.visits = make(map[string]Visits)
+	for _, box := range boxes {
+		p.boxes[box] = true
+	}
+	for _, entry := range p.entries {
+		if entry.Box != "" {
+			p.boxes[entry.Box] = true
+		}
+	}
+}
+
+// HasBox reports whether a box with the given name exists.
+func (p *Pokedex) HasBox(name string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.boxes[name]
+}
+
+// Boxes returns the names of all boxes in alphabetical order.
+func (p *Pokedex) Boxes() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return slices.Sorted(maps.Keys(p.boxes))
+}
+
+// AddBox creates a box.
+//
+// Parameters:
+//   - name: The box name
+//
+// Returns:
+//   - false if a box with that name already exists
+func (p *Pokedex) AddBox(name string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.boxes[name] {
+		return false
+	}
+	p.boxes[name] = true
+	return true
+}
+
+// DeleteBox deletes a box, taking every Pokémon in it out of the box.
+//
+// Parameters:
+//   - name: The box name
+//
+// Returns:
+//   - The number of Pokémon that were in the box
+func (p *Pokedex) DeleteBox(name string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.decodeAll()
+
+	delete(p.boxes, name)
+	released := 0
+	for key, entry := range p.entries {
+		if entry.Box == name {
+			entry.Box = ""
+			p.entries[key] = entry
+			released++
+		}
+	}
+	return released
+}
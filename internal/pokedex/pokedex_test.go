@@ -0,0 +1,194 @@
+package pokedex
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestAllReturnsSnapshot tests that changing the Pokédex doesn't change an earlier snapshot
+func TestAllReturnsSnapshot(t *testing.T) {
+	dex := New()
+	dex.Add("pikachu", Entry{Box: "team"})
+
+	snapshot := dex.All()
+	dex.Add("eevee", Entry{})
+	dex.Remove("pikachu")
+
+	if len(snapshot) != 1 || snapshot["pikachu"].Box != "team" {
+		t.Errorf("Snapshot changed with the Pokédex: %+v", snapshot)
+	}
+	if !dex.HasBox("team") {
+		t.Errorf("Expected Add to create the entry's box")
+	}
+}
+
+// TestListIsSortedByName tests that List returns entries in alphabetical order
+func TestListIsSortedByName(t *testing.T) {
+	dex := New()
+	for _, name := range []string{"pikachu", "bulbasaur", "eevee"} {
+		dex.Add(name, NewEntry(pokeapi.PokemonDataResp{Name: name}))
+	}
+
+	list := dex.List()
+	want := []string{"bulbasaur", "eevee", "pikachu"}
+	if len(list) != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), len(list))
+	}
+	for i, name := range want {
+		if list[i].Name != name || list[i].Entry.Name != name {
+			t.Errorf("Entry %d is %q, expected %q", i, list[i].Name, name)
+		}
+	}
+}
+
+// TestUpdate tests that updates are stored and that failed updates change nothing
+func TestUpdate(t *testing.T) {
+	dex := New()
+	dex.Add("pikachu", Entry{})
+
+	if err := dex.Update("eevee", func(*Entry) error { return nil }); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	failure := errors.New("invalid move")
+	err := dex.Update("pikachu", func(entry *Entry) error {
+		entry.Notes = append(entry.Notes, "discarded")
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the update's error, got %v", err)
+	}
+	if entry, _ := dex.Get("pikachu"); len(entry.Notes) != 0 {
+		t.Errorf("Failed update was stored: %+v", entry.Notes)
+	}
+
+	err = dex.Update("pikachu", func(entry *Entry) error {
+		entry.Box = "team"
+		return nil
+	})
+	if entry, _ := dex.Get("pikachu"); err != nil || entry.Box != "team" {
+		t.Errorf("Update was not stored: %v, %+v", err, entry)
+	}
+}
+
+// TestReplaceKeepsExistingEntry tests that replacing refuses to overwrite another Pokémon
+func TestReplaceKeepsExistingEntry(t *testing.T) {
+	dex := New()
+	dex.Reset(map[string]Entry{
+		"eevee":    {Notes: []string{"first"}},
+		"vaporeon": {Notes: []string{"second"}},
+	}, nil)
+
+	err := dex.Replace("eevee", "vaporeon", func(entry Entry) (Entry, error) {
+		return entry, nil
+	})
+	if !errors.Is(err, ErrNameTaken) {
+		t.Fatalf("Expected ErrNameTaken, got %v", err)
+	}
+	if entry, _ := dex.Get("vaporeon"); entry.Notes[0] != "second" {
+		t.Errorf("Existing entry was overwritten: %+v", entry)
+	}
+	if _, exists := dex.Get("eevee"); !exists {
+		t.Errorf("Original entry was removed")
+	}
+}
+
+// TestDeleteBox tests that deleting a box takes its Pokémon out of it
+func TestDeleteBox(t *testing.T) {
+	dex := New()
+	dex.Reset(map[string]Entry{
+		"eevee":   {Box: "team"},
+		"pikachu": {Box: "team"},
+		"onix":    {},
+	}, []string{"spare"})
+
+	if got := dex.Boxes(); len(got) != 2 || got[0] != "spare" || got[1] != "team" {
+		t.Errorf("Boxes() = %v, expected [spare team]", got)
+	}
+	if dex.AddBox("team") {
+		t.Error("Expected AddBox to refuse an existing box")
+	}
+	if released := dex.DeleteBox("team"); released != 2 {
+		t.Errorf("DeleteBox released %d Pokémon, expected 2", released)
+	}
+	if entry, _ := dex.Get("eevee"); entry.Box != "" || dex.HasBox("team") {
+		t.Errorf("Box was not deleted: %+v", entry)
+	}
+}
+
+// TestPartyCount tests that Pokémon in boxes or at the day care aren't counted in the party
+func TestPartyCount(t *testing.T) {
+	dex := New()
+	dex.Reset(map[string]Entry{
+		"eevee":   {},
+		"pikachu": {},
+		"onix":    {Box: "pc"},
+		"ditto":   {DaycareSince: time.Now()},
+	}, nil)
+
+	if count := dex.PartyCount(""); count != 2 {
+		t.Errorf("PartyCount() = %d, expected 2", count)
+	}
+	if count := dex.PartyCount("eevee"); count != 1 {
+		t.Errorf("PartyCount(eevee) = %d, expected 1", count)
+	}
+	if count := dex.PartyCount("onix"); count != 2 {
+		t.Errorf("PartyCount(onix) = %d, expected 2", count)
+	}
+}
+
+// TestStats tests the summary of the Pokédex contents
+func TestStats(t *testing.T) {
+	electric := NewEntry(pokeapi.PokemonDataResp{Name: "pichu"})
+	electric.Types = append(electric.Types, struct {
+		Slot int                      `json:"slot"`
+		Type pokeapi.NamedAPIResource `json:"type"`
+	}{Slot: 1, Type: pokeapi.NamedAPIResource{Name: "electric"}})
+	evolved := electric.EvolveInto("pichu", electric.PokemonDataResp, time.Now())
+	evolved.Box = "team"
+
+	dex := New()
+	dex.Add("pichu", electric)
+	dex.Add("pikachu", evolved)
+
+	stats := dex.Stats()
+	if stats.Total != 2 || stats.Boxes != 1 || stats.Boxed != 1 || stats.Evolved != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+	if stats.Types["electric"] != 2 {
+		t.Errorf("Expected 2 electric Pokémon, got %d", stats.Types["electric"])
+	}
+}
+
+// TestConcurrentUse tests that the Pokédex can be used from several goroutines.
+// Run with -race to check for unsynchronized access.
+func TestConcurrentUse(t *testing.T) {
+	dex := New()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				dex.Add("pikachu", Entry{})
+				_ = dex.Update("pikachu", func(entry *Entry) error {
+					entry.Notes = append(entry.Notes, "note")
+					return nil
+				})
+				_ = dex.All()
+				_ = dex.Export()
+				dex.Remove("pikachu")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if dex.Len() != 0 {
+		t.Errorf("Expected an empty Pokédex, got %d entries", dex.Len())
+	}
+}
@@ -0,0 +1,347 @@
+// This file checks saves against the save format, described by the JSON schema
+// in save_schema.json. When a save can't be decoded, such as after it was
+// edited by hand, the schema is used to report every field that's wrong, by
+// its path in the file and the type expected there, instead of only the first
+// error the JSON decoder happened to hit. Only the parts of JSON Schema the
+// save format needs are supported, and nothing about the data can make the
+// check panic.
+package pokedex
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed save_schema.json
+var saveSchemaJSON []byte
+
+// The schema definitions that files are checked against.
+const (
+	saveSchemaDef     = "#"                // A save file
+	entrySchemaDef    = "#/$defs/entry"    // A Pokédex entry
+	snapshotSchemaDef = "#/$defs/snapshot" // A snapshot file (see snapshot.go)
+)
+
+// maxSchemaProblems is the most problems a SchemaError lists.
+const maxSchemaProblems = 10
+
+// maxSchemaDepth is how deeply nested data is checked, so that a long chain of
+// earlier forms can't exhaust the stack. Anything deeper is left to the decoder.
+const maxSchemaDepth = 64
+
+// schema is a schema, or a part of one, from save_schema.json.
+type schema struct {
+	Ref                  string             `json:"$ref"`                 // The definition to check against instead, "#" or "#/$defs/<name>"
+	Type                 string             `json:"type"`                 // The JSON type expected, or "" for any
+	Format               string             `json:"format"`               // "date-time" for times, which must be in RFC 3339 format
+	Properties           map[string]*schema `json:"properties"`           // The schemas of an object's known fields
+	AdditionalProperties *schema            `json:"additionalProperties"` // The schema of an object's other fields, for objects used as maps
+	Items                *schema            `json:"items"`                // The schema of an array's elements
+	Defs                 map[string]*schema `json:"$defs"`                // The definitions that $ref refers to
+}
+
+// saveSchema returns the embedded save schema. It's nil if the schema can't be
+// parsed, in which case nothing is checked.
+var saveSchema = sync.OnceValue(func() *schema {
+	var s schema
+	if err := json.Unmarshal(saveSchemaJSON, &s); err != nil {
+		return nil
+	}
+	return &s
+})
+
+// SchemaProblem is a field in a save that doesn't match the save format.
+type SchemaProblem struct {
+	Path     string // Where the field is, such as "pokedex.pikachu.level"
+	Expected string // What the save format expects there, such as "integer"
+	Found    string // What was found there instead, such as `string "five"`
+}
+
+// String describes the problem.
+func (p SchemaProblem) String() string {
+	return fmt.Sprintf("%s: expected %s, found %s", p.Path, p.Expected, p.Found)
+}
+
+// SchemaError is returned when a save can't be decoded because some of its
+// fields don't match the save format.
+type SchemaError struct {
+	Problems []SchemaProblem // The fields that don't match, in the order they appear (at most maxSchemaProblems)
+	More     int             // How many more fields don't match
+	Err      error           // The error from the JSON decoder
+}
+
+// Error lists the fields that don't match, one per line.
+func (e *SchemaError) Error() string {
+	var b strings.Builder
+	b.WriteString("error deserializing Pokédex data: these fields don't match the save format:")
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - " + problem.String())
+	}
+	if e.More > 0 {
+		fmt.Fprintf(&b, "\n  ... and %d more", e.More)
+	}
+	return b.String()
+}
+
+// Unwrap returns the error from the JSON decoder.
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// CheckFile checks a save file against the save format, without loading it.
+// Unlike loading, this finds the problems in entries that ReadFileLazy leaves
+// undecoded, which would otherwise only show up as fields left empty.
+//
+// Parameters:
+//   - path: The path to the save file
+//
+// Returns:
+//   - The fields that don't match the save format, or none if it matches or doesn't exist
+//   - An error if the file can't be read or isn't valid JSON
+func CheckFile(path string) ([]SchemaProblem, error) {
+	encoded, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading save file: %w", err)
+	}
+	if err := describeSyntaxError(encoded, json.Unmarshal(encoded, new(json.RawMessage))); err != nil {
+		return nil, err
+	}
+	checker := schemaChecker{root: saveSchema(), limit: -1}
+	checker.check(checker.resolve(saveSchemaDef), encoded, "", 0)
+	return checker.problems, nil
+}
+
+// decodeError describes why JSON couldn't be decoded: where a syntax error is,
+// or which fields don't match the schema definition the JSON should follow.
+//
+// Parameters:
+//   - encoded: The JSON
+//   - def: The schema definition it should follow, or "" to check nothing
+//   - prefix: The path of the JSON in the file, or "" if it's the whole file
+//   - err: The error from the JSON decoder
+//
+// Returns:
+//   - The error to report
+func decodeError(encoded []byte, def, prefix string, err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) || def == "" {
+		return describeSyntaxError(encoded, err)
+	}
+	checker := schemaChecker{root: saveSchema(), limit: maxSchemaProblems}
+	checker.check(checker.resolve(def), encoded, prefix, 0)
+	if len(checker.problems) == 0 {
+		return fmt.Errorf("error deserializing Pokédex data: %w", err)
+	}
+	return &SchemaError{Problems: checker.problems, More: checker.more, Err: err}
+}
+
+// describeSyntaxError adds the line and column of a JSON syntax error to it.
+// Other errors are returned wrapped as they are, and nil as nil.
+func describeSyntaxError(encoded []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		if err == nil {
+			return nil
+		}
+		return fmt.Errorf("error deserializing Pokédex data: %w", err)
+	}
+	// The offset is just past the character that was wrong
+	offset := min(max(int(syntaxErr.Offset)-1, 0), len(encoded))
+	before := encoded[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := offset - bytes.LastIndexByte(before, '\n')
+	return fmt.Errorf("error deserializing Pokédex data: line %d, column %d: %w", line, column, err)
+}
+
+// schemaChecker collects the fields of some JSON that don't match a schema.
+type schemaChecker struct {
+	root     *schema         // The whole schema, which $ref refers into
+	limit    int             // The most problems to collect, or -1 for all of them
+	problems []SchemaProblem // The problems found
+	more     int             // How many problems were found beyond the limit
+}
+
+// resolve returns the schema a definition refers to, or nil if there's none.
+func (c *schemaChecker) resolve(ref string) *schema {
+	if c.root == nil {
+		return nil
+	}
+	if ref == "#" {
+		return c.root
+	}
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil
+	}
+	return c.root.Defs[name]
+}
+
+// report records a problem, unless the limit has been reached.
+func (c *schemaChecker) report(path, expected string, data []byte) {
+	if c.limit >= 0 && len(c.problems) >= c.limit {
+		c.more++
+		return
+	}
+	if path == "" {
+		path = "(the whole file)"
+	}
+	c.problems = append(c.problems, SchemaProblem{Path: path, Expected: expected, Found: describeJSON(data)})
+}
+
+// check checks JSON against a schema, recording the problems it finds.
+//
+// Parameters:
+//   - s: The schema, or nil to check nothing
+//   - data: The JSON
+//   - path: The path of the JSON in the file
+//   - depth: How deeply nested the JSON is
+func (c *schemaChecker) check(s *schema, data []byte, path string, depth int) {
+	if s == nil || depth > maxSchemaDepth {
+		return
+	}
+	if s.Ref != "" {
+		c.check(c.resolve(s.Ref), data, path, depth)
+		return
+	}
+	data = bytes.TrimSpace(data)
+	kind := jsonKind(data)
+	// null leaves a field unset, so it's accepted anywhere
+	if kind == "null" || s.Type == "" {
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		var fields map[string]json.RawMessage
+		if kind != "object" || json.Unmarshal(data, &fields) != nil {
+			c.report(path, "object", data)
+			return
+		}
+		for _, name := range objectKeys(data, fields) {
+			field := s.Properties[name]
+			if field == nil {
+				field = s.AdditionalProperties
+			}
+			c.check(field, fields[name], joinPath(path, name), depth+1)
+		}
+	case "array":
+		var items []json.RawMessage
+		if kind != "array" || json.Unmarshal(data, &items) != nil {
+			c.report(path, "array", data)
+			return
+		}
+		for i, item := range items {
+			c.check(s.Items, item, fmt.Sprintf("%s[%d]", path, i), depth+1)
+		}
+	case "string":
+		var text string
+		if kind != "string" || json.Unmarshal(data, &text) != nil {
+			c.report(path, "string", data)
+			return
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, text); err != nil {
+				c.report(path, "date-time string (e.g. \"2024-05-01T12:00:00Z\")", data)
+			}
+		}
+	case "integer":
+		if _, err := strconv.ParseInt(string(data), 10, 64); kind != "number" || err != nil {
+			c.report(path, "integer", data)
+		}
+	case "number":
+		if kind != "number" {
+			c.report(path, "number", data)
+		}
+	case "boolean":
+		if kind != "boolean" {
+			c.report(path, "boolean", data)
+		}
+	}
+}
+
+// jsonKind returns the JSON type of a value, from its first character.
+func jsonKind(data []byte) string {
+	if len(data) == 0 {
+		return "nothing"
+	}
+	switch data[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+// describeJSON describes a value found where it doesn't belong, such as
+// `string "five"`. Long values are shortened.
+func describeJSON(data []byte) string {
+	kind := jsonKind(data)
+	switch kind {
+	case "object", "array", "nothing":
+		return kind
+	}
+	text := string(data)
+	if runes := []rune(text); len(runes) > 30 {
+		text = string(runes[:27]) + "..."
+	}
+	return kind + " " + text
+}
+
+// objectKeys returns the keys of an object in the order they appear in it, so
+// that problems are reported in the order they're found in the file. The keys
+// are sorted instead if the order can't be worked out.
+func objectKeys(data []byte, fields map[string]json.RawMessage) []string {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var keys []string
+	if _, err := decoder.Token(); err != nil {
+		return slices.Sorted(maps.Keys(fields))
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		key, ok := token.(string)
+		var skip json.RawMessage
+		if err != nil || !ok || decoder.Decode(&skip) != nil {
+			return slices.Sorted(maps.Keys(fields))
+		}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// plainKey matches the keys that can be written in a path without quoting.
+var plainKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// joinPath adds a key to a path, such as "pokedex" and "pikachu" to
+// "pokedex.pikachu". Keys with other characters are quoted, as in pokedex["mr. mime"].
+func joinPath(path, key string) string {
+	if !plainKey.MatchString(key) {
+		return path + "[" + strconv.Quote(key) + "]"
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
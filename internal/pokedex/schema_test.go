@@ -0,0 +1,176 @@
+package pokedex
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSchemaCoversSaveFormat tests that the embedded schema has every field of
+// the save format, with the type it's saved as, so the two can't drift apart
+func TestSchemaCoversSaveFormat(t *testing.T) {
+	checker := schemaChecker{root: saveSchema()}
+	if checker.root == nil {
+		t.Fatal("The embedded save schema couldn't be parsed")
+	}
+	visited := make(map[reflect.Type]bool)
+	var compare func(typ reflect.Type, s *schema, path string)
+	compare = func(typ reflect.Type, s *schema, path string) {
+		for s != nil && s.Ref != "" {
+			s = checker.resolve(s.Ref)
+		}
+		if s == nil {
+			t.Errorf("%s: no schema", path)
+			return
+		}
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		want := map[reflect.Kind]string{
+			reflect.String: "string", reflect.Bool: "boolean", reflect.Int: "integer",
+			reflect.Struct: "object", reflect.Map: "object", reflect.Slice: "array",
+		}[typ.Kind()]
+		if typ == reflect.TypeFor[json.RawMessage]() {
+			// Raw JSON is kept as it is, whatever it holds
+			if s.Type != "" {
+				t.Errorf("%s: expected any type in the schema, got %q", path, s.Type)
+			}
+			return
+		}
+		if typ == reflect.TypeFor[time.Time]() {
+			if s.Type != "string" || s.Format != "date-time" {
+				t.Errorf("%s: expected a date-time string in the schema, got %q (%q)", path, s.Type, s.Format)
+			}
+			return
+		}
+		if s.Type != want {
+			t.Errorf("%s: expected type %q in the schema, got %q", path, want, s.Type)
+			return
+		}
+		switch typ.Kind() {
+		case reflect.Map:
+			compare(typ.Elem(), s.AdditionalProperties, path+".*")
+		case reflect.Slice:
+			compare(typ.Elem(), s.Items, path+"[]")
+		case reflect.Struct:
+			if visited[typ] {
+				return
+			}
+			visited[typ] = true
+			defer delete(visited, typ)
+			for _, field := range reflect.VisibleFields(typ) {
+				name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+				if !field.IsExported() || field.Anonymous || name == "-" {
+					continue
+				}
+				if name == "" {
+					name = field.Name
+				}
+				compare(field.Type, s.Properties[name], joinPath(path, name))
+			}
+		}
+	}
+	compare(reflect.TypeFor[SaveData](), checker.resolve(saveSchemaDef), "")
+	compare(reflect.TypeFor[snapshotFile](), checker.resolve(snapshotSchemaDef), "(snapshot)")
+}
+
+// hand-edited save with mistakes in a setting, an entry, and a sighting
+const mistakenSave = `{
+  "pokedex": {
+    "pikachu": {"name": "pikachu", "level": "five", "types": [{"slot": 1.5, "type": {"name": "electric"}}]}
+  },
+  "seen": {"pikachu": {"seen_on": "yesterday"}},
+  "money": "lots",
+  "lastSaved": "2024-05-01T12:00:00Z"
+}`
+
+// TestReadFileReportsSchemaProblems tests that a save that can't be loaded is
+// reported with every field that doesn't match, in the order they appear
+func TestReadFileReportsSchemaProblems(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(mistakenSave), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := ReadFile(path)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Expected a SchemaError, got %v", err)
+	}
+	want := []SchemaProblem{
+		{"pokedex.pikachu.level", "integer", `string "five"`},
+		{"pokedex.pikachu.types[0].slot", "integer", "number 1.5"},
+		{"seen.pikachu.seen_on", `date-time string (e.g. "2024-05-01T12:00:00Z")`, `string "yesterday"`},
+		{"money", "integer", `string "lots"`},
+	}
+	if !reflect.DeepEqual(schemaErr.Problems, want) {
+		t.Errorf("Unexpected problems:\n got %v\nwant %v", schemaErr.Problems, want)
+	}
+	if !strings.Contains(err.Error(), "\n  - money: expected integer, found string \"lots\"") {
+		t.Errorf("Expected one problem per line, got:\n%s", err)
+	}
+
+	// Loading lazily fails on the money, and reports the entries' problems too
+	_, _, err = ReadFileLazy(path)
+	if !errors.As(err, &schemaErr) || !reflect.DeepEqual(schemaErr.Problems, want) {
+		t.Errorf("Expected every problem to be reported, got %v", err)
+	}
+
+	// Checking the file finds them all without loading it
+	problems, err := CheckFile(path)
+	if err != nil || !reflect.DeepEqual(problems, want) {
+		t.Errorf("Unexpected check of the file: %v, %v", problems, err)
+	}
+}
+
+// TestSchemaProblemsAreLimited tests that a badly broken save lists only the first problems
+func TestSchemaProblemsAreLimited(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte(`{"boxes": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err := ReadFile(path)
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) || len(schemaErr.Problems) != maxSchemaProblems || schemaErr.More != 2 {
+		t.Fatalf("Expected %d problems and 2 more, got %v", maxSchemaProblems, err)
+	}
+	if !strings.HasSuffix(err.Error(), "... and 2 more") {
+		t.Errorf("Expected the rest to be counted, got:\n%s", err)
+	}
+}
+
+// TestReadFileReportsSyntaxErrorPosition tests that invalid JSON is reported with its line and column
+func TestReadFileReportsSyntaxErrorPosition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	if err := os.WriteFile(path, []byte("{\n  \"money\": 5,\n  \"units\": metric\n}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, _, err := ReadFile(path)
+	if err == nil || !strings.Contains(err.Error(), "line 3, column 12") {
+		t.Errorf("Expected the position of the syntax error, got %v", err)
+	}
+	if _, err := CheckFile(path); err == nil || !strings.Contains(err.Error(), "line 3, column 12") {
+		t.Errorf("Expected checking the file to report the syntax error, got %v", err)
+	}
+}
+
+// TestSchemaCheckDoesNotPanic tests that the check copes with anything a file could hold
+func TestSchemaCheckDoesNotPanic(t *testing.T) {
+	deep := strings.Repeat(`{"pre_evolution": {"entry": `, 200) + `{"level": "x"}` + strings.Repeat("}}", 200)
+	inputs := []string{
+		``, `null`, `[]`, `"save"`, `{"pokedex": null}`, `{"pokedex": []}`, `{"pokedex": {"": {}}}`,
+		`{"pokedex": {"a": ` + deep + `}}`, `{"items": {"poke-ball": 1e400}}`, `{"lastSaved": "` + strings.Repeat("9", 100) + `"}`,
+		`{"map": {"found": [null, 1, {}]}}`, `{"reminders": [{"due": 5}]}`, `{"money": -}`,
+	}
+	for _, input := range inputs {
+		checker := schemaChecker{root: saveSchema(), limit: -1}
+		checker.check(checker.resolve(saveSchemaDef), []byte(input), "", 0)
+		_ = decodeError([]byte(input), saveSchemaDef, "", errors.New("decoding failed"))
+	}
+	(&schemaChecker{}).check(&schema{Ref: "#"}, []byte(`{}`), "", 0)
+}
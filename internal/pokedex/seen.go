@@ -0,0 +1,68 @@
+// This file tracks the Pokémon the user has seen, like the "seen" count of the
+// Pokédex in the games. A Pokémon is seen when it turns up while exploring, is
+// looked up, or is caught, and it stays seen after it's released.
+package pokedex
+
+import (
+	"maps"
+	"time"
+)
+
+// Sighting records when and where a Pokémon was first seen.
+type Sighting struct {
+	SeenOn   time.Time `json:"seen_on,omitzero"`   // When it was first seen (zero if unknown)
+	Location string    `json:"location,omitempty"` // The location area it was first spotted in, if any
+}
+
+// MarkSeen records that a Pokémon has been seen. Only the first sighting is
+// kept, so seeing a Pokémon again doesn't change when it was first seen.
+//
+// Parameters:
+//   - name: The Pokémon's name in API format
+//   - sighting: The details of the sighting
+//
+// Returns:
+//   - Whether this is the first time the Pokémon has been seen
+func (p *Pokedex) MarkSeen(name string, sighting Sighting) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, seen := p.seen[name]; seen {
+		return false
+	}
+	p.seen[name] = sighting
+	return true
+}
+
+// HasSeen reports whether a Pokémon has been seen. Caught Pokémon always count
+// as seen, including those caught before sightings were recorded.
+//
+// Parameters:
+//   - name: The Pokémon's name in API format
+func (p *Pokedex) HasSeen(name string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if _, seen := p.seen[name]; seen {
+		return true
+	}
+	return p.has(name)
+}
+
+// Seen returns a copy of the recorded sightings, indexed by Pokémon name.
+func (p *Pokedex) Seen() map[string]Sighting {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return maps.Clone(p.seen)
+}
+
+// RestoreSeen replaces the recorded sightings, as when loading a save.
+//
+// Parameters:
+//   - seen: The sightings indexed by Pokémon name (nil for none)
+func (p *Pokedex) RestoreSeen(seen map[string]Sighting) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.seen = maps.Clone(seen)
+	if p.seen == nil {
+		p.seen = make(map[string]Sighting)
+	}
+}
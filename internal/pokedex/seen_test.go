@@ -0,0 +1,42 @@
+package pokedex
+
+import (
+	"testing"
+	"time"
+)
+
+// TestMarkSeen tests that only the first sighting is kept, that released
+// Pokémon stay seen, and that caught Pokémon count as seen without a sighting
+func TestMarkSeen(t *testing.T) {
+	dex := New()
+	first := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	if !dex.MarkSeen("pidgey", Sighting{SeenOn: first}) {
+		t.Error("Expected the first sighting to be new")
+	}
+	if dex.MarkSeen("pidgey", Sighting{SeenOn: first.Add(time.Hour)}) {
+		t.Error("Expected a second sighting not to be new")
+	}
+	if got := dex.Seen()["pidgey"].SeenOn; !got.Equal(first) {
+		t.Errorf("Expected the first sighting to be kept, got %v", got)
+	}
+
+	dex.Add("rattata", Entry{})
+	if !dex.HasSeen("rattata") || dex.HasSeen("spearow") {
+		t.Error("Expected caught Pokémon, and only those, to count as seen")
+	}
+	dex.MarkSeen("rattata", Sighting{})
+	dex.Remove("rattata")
+	if !dex.HasSeen("rattata") {
+		t.Error("Expected a released Pokémon to stay seen")
+	}
+
+	dex.Add("eevee", Entry{})
+	if stats := dex.Stats(); stats.Seen != 3 || stats.Total != 1 {
+		t.Errorf("Expected 3 seen and 1 caught, got %d and %d", stats.Seen, stats.Total)
+	}
+
+	dex.Reset(nil, nil)
+	if len(dex.Seen()) != 0 {
+		t.Error("Expected Reset to clear the sightings")
+	}
+}
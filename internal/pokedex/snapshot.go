@@ -0,0 +1,131 @@
+// This file implements named snapshots of the save data. Like the save slots of
+// a game, each snapshot is a complete copy of the user's progress, stored in its
+// own file together with metadata describing it, so that it can be listed and
+// restored later without touching the main save file.
+package pokedex
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// snapshotExtension is the file extension of snapshot files
+const snapshotExtension = ".json"
+
+// ErrInvalidSnapshotName is returned when a snapshot name can't be used as a file name
+var ErrInvalidSnapshotName = errors.New("invalid snapshot name")
+
+// SnapshotInfo describes a snapshot without the save data it contains.
+type SnapshotInfo struct {
+	Name    string    `json:"name"`            // Name of the snapshot
+	Created time.Time `json:"created"`         // When the snapshot was taken
+	Pokemon int       `json:"pokemon"`         // Number of Pokémon caught at the time
+	Money   int       `json:"money,omitempty"` // Money the user had at the time
+}
+
+// snapshotFile is the structure of a snapshot file on disk.
+type snapshotFile struct {
+	SnapshotInfo
+	Data SaveData `json:"data"` // The complete save data
+}
+
+// snapshotPath returns the path of the file for the named snapshot.
+// Names that could refer to a file outside the snapshot directory are rejected.
+func snapshotPath(dir, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", ErrInvalidSnapshotName
+	}
+	return filepath.Join(dir, name+snapshotExtension), nil
+}
+
+// WriteSnapshot saves a named snapshot of the save data in the given directory,
+// creating the directory if needed and replacing any snapshot with the same name.
+//
+// Parameters:
+//   - dir: The directory snapshots are stored in
+//   - name: The name of the snapshot
+//   - data: The save data to capture
+//
+// Returns:
+//   - The metadata written with the snapshot
+//   - An error if the name is invalid or the snapshot can't be written
+func WriteSnapshot(dir, name string, data SaveData) (SnapshotInfo, error) {
+	path, err := snapshotPath(dir, name)
+	if err != nil {
+		return SnapshotInfo{}, err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return SnapshotInfo{}, err
+	}
+
+	info := SnapshotInfo{
+		Name:    name,
+		Created: time.Now(),
+		Pokemon: len(data.Pokedex),
+		Money:   data.Money,
+	}
+	return info, writeJSONFile(path, snapshotFile{SnapshotInfo: info, Data: data})
+}
+
+// ReadSnapshot loads the named snapshot from the given directory.
+//
+// Parameters:
+//   - dir: The directory snapshots are stored in
+//   - name: The name of the snapshot
+//
+// Returns:
+//   - The save data in the snapshot, and whether the snapshot exists
+//   - An error if the name is invalid or the snapshot can't be read
+func ReadSnapshot(dir, name string) (SaveData, bool, error) {
+	path, err := snapshotPath(dir, name)
+	if err != nil {
+		return SaveData{}, false, err
+	}
+
+	var snapshot snapshotFile
+	found, err := readJSONFile(path, &snapshot, snapshotSchemaDef)
+	if err != nil || !found {
+		return SaveData{}, found, err
+	}
+	return snapshot.Data, true, nil
+}
+
+// ListSnapshots returns the metadata of the snapshots in the given directory,
+// newest first. A directory that doesn't exist yet has no snapshots.
+//
+// Parameters:
+//   - dir: The directory snapshots are stored in
+//
+// Returns:
+//   - The metadata of each snapshot
+//   - An error if the directory or one of the snapshots can't be read
+func ListSnapshots(dir string) ([]SnapshotInfo, error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []SnapshotInfo
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), snapshotExtension) {
+			continue
+		}
+		var snapshot snapshotFile
+		if _, err := readJSONFile(filepath.Join(dir, file.Name()), &snapshot, snapshotSchemaDef); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot.SnapshotInfo)
+	}
+
+	slices.SortFunc(snapshots, func(a, b SnapshotInfo) int {
+		return b.Created.Compare(a.Created)
+	})
+	return snapshots, nil
+}
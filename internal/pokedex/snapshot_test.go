@@ -0,0 +1,78 @@
+package pokedex
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestSnapshots tests that snapshots are written with their metadata, read back
+// unchanged, listed, and replaced when written again under the same name
+func TestSnapshots(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+
+	if snapshots, err := ListSnapshots(dir); err != nil || len(snapshots) != 0 {
+		t.Fatalf("Expected no snapshots before the directory exists, got %v, err=%v", snapshots, err)
+	}
+
+	dex := New()
+	dex.Add("pikachu", NewEntry(pokeapi.PokemonDataResp{Name: "pikachu"}))
+	data := dex.Export()
+	data.Money = 300
+
+	info, err := WriteSnapshot(dir, "before-gym", data)
+	if err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+	if info.Name != "before-gym" || info.Pokemon != 1 || info.Money != 300 || info.Created.IsZero() {
+		t.Errorf("Unexpected snapshot metadata: %+v", info)
+	}
+
+	dex.Add("eevee", NewEntry(pokeapi.PokemonDataResp{Name: "eevee"}))
+	if _, err := WriteSnapshot(dir, "after-gym", dex.Export()); err != nil {
+		t.Fatalf("Failed to write snapshot: %v", err)
+	}
+
+	loaded, found, err := ReadSnapshot(dir, "before-gym")
+	if err != nil || !found {
+		t.Fatalf("Failed to read snapshot: found=%v, err=%v", found, err)
+	}
+	if len(loaded.Pokedex) != 1 || loaded.Money != 300 {
+		t.Errorf("Expected the snapshot to hold 1 Pokémon and 300 money, got %d and %d", len(loaded.Pokedex), loaded.Money)
+	}
+
+	if _, found, err := ReadSnapshot(dir, "missing"); err != nil || found {
+		t.Errorf("Expected a missing snapshot not to be found, got found=%v, err=%v", found, err)
+	}
+
+	snapshots, err := ListSnapshots(dir)
+	if err != nil {
+		t.Fatalf("Failed to list snapshots: %v", err)
+	}
+	if len(snapshots) != 2 || snapshots[0].Name != "after-gym" || snapshots[1].Name != "before-gym" {
+		t.Errorf("Expected both snapshots, newest first, got %+v", snapshots)
+	}
+
+	// Writing a snapshot again replaces it
+	if _, err := WriteSnapshot(dir, "before-gym", dex.Export()); err != nil {
+		t.Fatalf("Failed to replace snapshot: %v", err)
+	}
+	if snapshots, _ := ListSnapshots(dir); len(snapshots) != 2 || snapshots[0].Name != "before-gym" || snapshots[0].Pokemon != 2 {
+		t.Errorf("Expected the replaced snapshot to be listed first with 2 Pokémon, got %+v", snapshots)
+	}
+}
+
+// TestSnapshotNames tests that names that could escape the snapshot directory are rejected
+func TestSnapshotNames(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"", "../save", "a/b", `a\b`, ".hidden"} {
+		if _, err := WriteSnapshot(dir, name, SaveData{}); !errors.Is(err, ErrInvalidSnapshotName) {
+			t.Errorf("WriteSnapshot(%q): expected ErrInvalidSnapshotName, got %v", name, err)
+		}
+		if _, _, err := ReadSnapshot(dir, name); !errors.Is(err, ErrInvalidSnapshotName) {
+			t.Errorf("ReadSnapshot(%q): expected ErrInvalidSnapshotName, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+// This file implements saving and loading the Pokédex to and from disk, including
+// file locking to ensure data integrity and prevent corruption during concurrent access.
+package pokedex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+// LockTimeout is the maximum time to wait for acquiring a lock on the save file
+const LockTimeout = 5 * time.Second
+
+// lockRetryInterval is the interval between lock attempts
+const lockRetryInterval = 100 * time.Millisecond
+
+// SaveData represents the structure of data saved to disk.
+// It includes the Pokédex data and other persistent state.
+type SaveData struct {
+	Pokedex       map[string]Entry          `json:"pokedex"`                  // User's caught Pokémon
+	Boxes         []string                  `json:"boxes,omitempty"`          // Names of the user's boxes
+	Seen          map[string]Sighting       `json:"seen,omitempty"`           // Pokémon the user has seen, indexed by name
+	Visits        map[string]Visits         `json:"visits,omitempty"`         // Visits to each location area, indexed by name
+	Units         string                    `json:"units,omitempty"`          // Units for heights and weights
+	Language      string                    `json:"language,omitempty"`       // Language of the interface
+	Accessible    bool                      `json:"accessible,omitempty"`     // Whether accessible output is enabled
+	Theme         string                    `json:"theme,omitempty"`          // How command results are drawn ("" for the classic theme)
+	Money         int                       `json:"money,omitempty"`          // Money earned from battles
+	Items         map[string]int            `json:"items,omitempty"`          // Items in the user's bag, by API name, with their quantities
+	PartySize     int                       `json:"party_size,omitempty"`     // Maximum number of Pokémon in the party (zero for the default)
+	PageSize      int                       `json:"page_size,omitempty"`      // Number of location areas on a map page (zero for the default)
+	Redeemed      []string                  `json:"redeemed,omitempty"`       // Distribution codes and weekly mystery gifts that have been redeemed
+	TrainerID     int                       `json:"trainer_id,omitempty"`     // The user's trainer ID (zero if none has been assigned)
+	Challenges    map[string]ChallengeState `json:"challenges,omitempty"`     // The user-defined challenges the user has started, by ID
+	VersionGroup  string                    `json:"version_group,omitempty"`  // The version group moves are limited to, if any
+	Lure          *Lure                     `json:"lure,omitempty"`           // The lure in use, if any
+	MQTTBroker    string                    `json:"mqtt_broker,omitempty"`    // The URL of the MQTT broker events are published to, if any
+	MQTTTopic     string                    `json:"mqtt_topic,omitempty"`     // The MQTT topic events are published to
+	BackupRemote  string                    `json:"backup_remote,omitempty"`  // The git remote the save file is backed up to, if any
+	BackupEvery   int                       `json:"backup_every,omitempty"`   // How many backups to make between pushes, or 0 to push only on demand
+	Map           *MapState                 `json:"map,omitempty"`            // Where the user was exploring the map, if anywhere
+	Usage         bool                      `json:"usage,omitempty"`          // Whether the commands the user runs are counted
+	UsageEndpoint string                    `json:"usage_endpoint,omitempty"` // The URL usage counts are sent to on request, if any
+	ReportSMTP    string                    `json:"report_smtp,omitempty"`    // The URL of the SMTP server weekly reports are emailed through, if any
+	ReportFrom    string                    `json:"report_from,omitempty"`    // The address weekly reports are emailed from
+	ReportTo      string                    `json:"report_to,omitempty"`      // The address weekly reports are emailed to
+	CatchPreset   string                    `json:"catch_preset,omitempty"`   // The catch rate preset, if not the default
+	CatchCustom   *CatchTuning              `json:"catch_custom,omitempty"`   // The values of the custom catch rate preset, if they've been set
+	Reminders     []Reminder                `json:"reminders,omitempty"`      // Reminders and scheduled commands that haven't come up yet
+	Macros        map[string]string         `json:"macros,omitempty"`         // The commands each of the user's macros runs, by the macro's name
+	LastSaved     time.Time                 `json:"lastSaved"`                // Timestamp of the last save
+	Index         map[string]IndexEntry     `json:"index,omitempty"`          // Where each Pokémon is kept, written by WriteFile for ReadFileLazy
+
+	// Entries that couldn't be decoded after ReadFileLazy, kept as they were
+	// saved (see lazy.go). WriteFile puts them back in the Pokédex unchanged.
+	Unreadable map[string]json.RawMessage `json:"unreadable,omitempty"`
+
+	lazyEntries map[string]rawEntry // The undecoded entries, in data from ReadFileLazy (see lazy.go)
+}
+
+// Lure is a lure item in use in a location area, which makes the Pokémon it
+// targets turn up more often there for a number of encounters.
+type Lure struct {
+	Item      string `json:"item"`      // The API name of the lure item
+	Location  string `json:"location"`  // The location area it was used in
+	Target    string `json:"target"`    // The type or Pokémon it draws out, in API format
+	Remaining int    `json:"remaining"` // The number of encounters it lasts for
+}
+
+// Reminder is a reminder set with 'remind', or a command scheduled with
+// 'schedule' to be suggested again and again.
+type Reminder struct {
+	Message string    `json:"message,omitempty"` // What to remind the user of (for a reminder)
+	Command string    `json:"command,omitempty"` // The command to suggest (for a scheduled command)
+	Every   string    `json:"every,omitempty"`   // How often a scheduled command comes up ("hourly", "daily", or "weekly")
+	Due     time.Time `json:"due"`               // When it next comes up
+}
+
+// MapState is where the user was exploring the map, saved so that they can
+// carry on where they left off in the next session.
+type MapState struct {
+	Next      string   `json:"next,omitempty"`      // The URL of the page of location areas after the one viewed last
+	Previous  string   `json:"previous,omitempty"`  // The URL of the page before it
+	Locations []string `json:"locations,omitempty"` // The location areas on the page viewed last, in the order shown
+	Explored  string   `json:"explored,omitempty"`  // The location area explored last
+	Found     []string `json:"found,omitempty"`     // The Pokémon that can be found there
+	Bookmarks []string `json:"bookmarks,omitempty"` // The location areas the user bookmarked, in the order added
+}
+
+// CatchTuning is how catching treats rare Pokémon: the values a catch rate
+// preset sets.
+type CatchTuning struct {
+	RareThreshold int  `json:"rare_threshold"` // The capture rate below which a Pokémon is rare
+	RareBoost     int  `json:"rare_boost"`     // The percentage of the gap between a rare Pokémon's capture rate and the threshold made up
+	Masterball    bool `json:"masterball"`     // Whether a Masterball is found nearby when a rare Pokémon is caught
+}
+
+// ChallengeState is the user's progress on a user-defined challenge they've
+// started. How far along it is is worked out from the Pokédex; only when it
+// started and when it was completed are kept.
+type ChallengeState struct {
+	Started   time.Time `json:"started"`            // When the user started the challenge
+	Completed time.Time `json:"completed,omitzero"` // When it was completed (zero if it hasn't been)
+}
+
+// Export returns the entries, boxes, sightings, and visits of the Pokédex as save data,
+// taken together so that they are consistent with each other. The entries are
+// deep copies, so the save data can be written while the Pokédex keeps changing.
+func (p *Pokedex) Export() SaveData {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.decodeAll()
+	entries := make(map[string]Entry, len(p.entries))
+	for name, entry := range p.entries {
+		entries[name] = entry.Clone()
+	}
+	return SaveData{
+		Pokedex:    entries,
+		Boxes:      slices.Sorted(maps.Keys(p.boxes)),
+		Seen:       maps.Clone(p.seen),
+		Visits:     maps.Clone(p.visits),
+		Unreadable: maps.Clone(p.unreadable),
+	}
+}
+
+// lockFilePath returns the path to the lock file based on the save file path.
+// The lock file is used to prevent concurrent access to the save file.
+//
+// Parameters:
+//   - saveFilePath: The path to the save file
+//
+// Returns:
+//   - The path to the corresponding lock file
+func lockFilePath(saveFilePath string) string {
+	return saveFilePath + ".lock"
+}
+
+// WriteFile saves data to disk. It uses file locking to ensure data integrity
+// when multiple instances of the application might be running simultaneously,
+// and replaces the file atomically so that a failed write leaves the old save intact.
+//
+// Parameters:
+//   - path: The path to the save file
+//   - data: The data to save
+//
+// Returns:
+//   - An error if the save operation fails for any reason
+func WriteFile(path string, data SaveData) error {
+	data.Index = indexOf(data.Pokedex)
+	if len(data.Unreadable) == 0 {
+		return writeJSONFile(path, data)
+	}
+	// Unreadable entries go back where they were, without an index entry, so
+	// that they're checked again the next time they're loaded
+	entries := make(map[string]json.RawMessage, len(data.Pokedex)+len(data.Unreadable))
+	for name, entry := range data.Pokedex {
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("error serializing Pokédex data: %w", err)
+		}
+		entries[name] = encoded
+	}
+	maps.Copy(entries, data.Unreadable)
+	data.Unreadable = nil
+	return writeJSONFile(path, lazySaveData{SaveData: data, Pokedex: entries})
+}
+
+// ReadFile loads saved data from disk. It uses file locking to ensure data
+// integrity when multiple instances of the application might be running simultaneously.
+//
+// Parameters:
+//   - path: The path to the save file
+//
+// Returns:
+//   - The saved data, and whether a save file exists
+//   - An error if the load operation fails for any reason
+func ReadFile(path string) (SaveData, bool, error) {
+	var data SaveData
+	found, err := readJSONFile(path, &data, saveSchemaDef)
+	if err != nil || !found {
+		return SaveData{}, found, err
+	}
+	data.Index = nil
+	return data, true, nil
+}
+
+// writeJSONFile serializes a value to JSON and writes it to disk while holding
+// an exclusive lock on the file, replacing the file atomically.
+func writeJSONFile(path string, value any) error {
+	// Create a file lock
+	fileLock := flock.New(lockFilePath(path))
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), lockWait)
+	defer cancel()
+
+	// Acquire an exclusive lock with a timeout
+	locked, err := fileLock.TryLockContext(ctx, lockRetryInterval)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("error acquiring file lock: %w", err)
+	}
+	if !locked {
+		return newLockTimeoutError(path, false)
+	}
+
+	// Release the lock when we're done, and until then let anyone waiting
+	// for it know who holds it
+	defer fileLock.Unlock()
+	recordLockOwner(path)
+	defer clearLockOwner(path)
+
+	// Serialize data to JSON
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error serializing Pokédex data: %w", err)
+	}
+
+	// Create a temporary file in the same directory
+	tempFilePath := path + ".tmp"
+
+	// Write data to the temporary file
+	err = os.WriteFile(tempFilePath, encoded, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing temporary save file: %w", err)
+	}
+
+	// Atomically replace the old file with the new one
+	err = os.Rename(tempFilePath, path)
+	if err != nil {
+		// Try to clean up the temporary file if rename fails
+		os.Remove(tempFilePath)
+		return fmt.Errorf("error replacing save file: %w", err)
+	}
+
+	return nil
+}
+
+// readJSONFile reads a file from disk while holding a shared lock on it, and
+// deserializes its JSON into value. It reports false if the file doesn't exist.
+// If the JSON can't be deserialized, the fields that don't match the schema
+// definition def are reported (see schema.go).
+func readJSONFile(path string, value any, def string) (bool, error) {
+	// Check if the file exists
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		// No save file exists, nothing to load
+		return false, nil
+	}
+
+	// Create a file lock
+	fileLock := flock.New(lockFilePath(path))
+
+	// Create a context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), lockWait)
+	defer cancel()
+
+	// Acquire a shared lock with a timeout
+	locked, err := fileLock.TryRLockContext(ctx, lockRetryInterval)
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return false, fmt.Errorf("error acquiring file lock for reading: %w", err)
+	}
+	if !locked {
+		return false, newLockTimeoutError(path, true)
+	}
+
+	// Release the lock when we're done
+	defer fileLock.Unlock()
+
+	// Read data from file
+	encoded, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("error reading save file: %w", err)
+	}
+
+	// Deserialize JSON data
+	err = json.Unmarshal(encoded, value)
+	if err != nil {
+		return false, decodeError(encoded, def, "", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,92 @@
+package pokedex
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestWriteAndReadFile tests that saved data is loaded back unchanged
+func TestWriteAndReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test_save.json")
+
+	if _, found, err := ReadFile(path); err != nil || found {
+		t.Fatalf("Expected no save file, got found=%v, err=%v", found, err)
+	}
+
+	dex := New()
+	entry := NewEntry(pokeapi.PokemonDataResp{Name: "pikachu", Height: 4, Weight: 60})
+	entry.Box = "team"
+	dex.Add("pikachu", entry)
+	dex.AddBox("spare")
+
+	data := dex.Export()
+	data.Units = "imperial"
+	data.Money = 480
+	data.Items = map[string]int{"great-ball": 3}
+	if err := WriteFile(path, data); err != nil {
+		t.Fatalf("Failed to save Pokédex data: %v", err)
+	}
+
+	loaded, found, err := ReadFile(path)
+	if err != nil || !found {
+		t.Fatalf("Failed to load Pokédex data: found=%v, err=%v", found, err)
+	}
+	if loaded.Units != "imperial" {
+		t.Errorf("Expected units to be saved, got %q", loaded.Units)
+	}
+	if loaded.Money != 480 {
+		t.Errorf("Expected money to be saved, got %d", loaded.Money)
+	}
+	if loaded.Items["great-ball"] != 3 {
+		t.Errorf("Expected items to be saved, got %v", loaded.Items)
+	}
+
+	reloaded := New()
+	reloaded.Reset(loaded.Pokedex, loaded.Boxes)
+	pikachu, exists := reloaded.Get("pikachu")
+	if !exists {
+		t.Fatalf("Pikachu was not loaded into the Pokédex")
+	}
+	if pikachu.Name != "pikachu" || pikachu.Height != 4 || pikachu.Weight != 60 || pikachu.Box != "team" {
+		t.Errorf("Unexpected entry loaded: %+v", pikachu)
+	}
+	if boxes := reloaded.Boxes(); len(boxes) != 2 {
+		t.Errorf("Expected 2 boxes, got %v", boxes)
+	}
+}
+
+// TestEntryCompatibility tests that entries saved before notes existed
+// still load, and that notes survive a save and load
+func TestEntryCompatibility(t *testing.T) {
+	// A save file entry from before Entry had any extra fields
+	oldSave := `{"pokedex": {"pikachu": {"name": "pikachu", "height": 4, "weight": 60}}, "lastSaved": "2024-01-01T00:00:00Z"}`
+
+	var saveData SaveData
+	if err := json.Unmarshal([]byte(oldSave), &saveData); err != nil {
+		t.Fatalf("Failed to load old save data: %v", err)
+	}
+	entry := saveData.Pokedex["pikachu"]
+	if entry.Name != "pikachu" || entry.Height != 4 || len(entry.Notes) != 0 {
+		t.Fatalf("Unexpected entry loaded from old save: %+v", entry)
+	}
+
+	// Add a note and check that it round-trips
+	entry.Notes = append(entry.Notes, "Caught on Route 1")
+	saveData.Pokedex["pikachu"] = entry
+	jsonData, err := json.Marshal(saveData)
+	if err != nil {
+		t.Fatalf("Failed to marshal save data: %v", err)
+	}
+
+	var reloaded SaveData
+	if err := json.Unmarshal(jsonData, &reloaded); err != nil {
+		t.Fatalf("Failed to reload save data: %v", err)
+	}
+	notes := reloaded.Pokedex["pikachu"].Notes
+	if len(notes) != 1 || notes[0] != "Caught on Route 1" {
+		t.Errorf("Expected note to be saved, got %v", notes)
+	}
+}
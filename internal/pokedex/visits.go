@@ -0,0 +1,61 @@
+// This file counts how often the user has visited each location area, by
+// exploring it or running into wild Pokémon there, for the heatmap of the
+// locations they spend the most time in.
+package pokedex
+
+import "maps"
+
+// Visits counts the user's visits to a location area.
+type Visits struct {
+	Explored   int `json:"explored,omitempty"`   // How many times the area was explored
+	Encounters int `json:"encounters,omitempty"` // How many wild Pokémon were encountered there
+}
+
+// Total returns the number of visits of either kind.
+func (v Visits) Total() int {
+	return v.Explored + v.Encounters
+}
+
+// RecordExplored counts a visit to explore a location area.
+//
+// Parameters:
+//   - location: The location area's name in API format
+func (p *Pokedex) RecordExplored(location string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	v := p.visits[location]
+	v.Explored++
+	p.visits[location] = v
+}
+
+// RecordEncounter counts a wild Pokémon encountered in a location area.
+//
+// Parameters:
+//   - location: The location area's name in API format
+func (p *Pokedex) RecordEncounter(location string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	v := p.visits[location]
+	v.Encounters++
+	p.visits[location] = v
+}
+
+// Visits returns a copy of the visit counts, indexed by location area.
+func (p *Pokedex) Visits() map[string]Visits {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return maps.Clone(p.visits)
+}
+
+// RestoreVisits replaces the visit counts, as when loading a save.
+//
+// Parameters:
+//   - visits: The visit counts indexed by location area (nil for none)
+func (p *Pokedex) RestoreVisits(visits map[string]Visits) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.visits = maps.Clone(visits)
+	if p.visits == nil {
+		p.visits = make(map[string]Visits)
+	}
+}
@@ -0,0 +1,29 @@
+package pokedex
+
+import "testing"
+
+// TestRecordVisits tests that explores and encounters are counted separately
+// for each location area, and that Reset clears the counts
+func TestRecordVisits(t *testing.T) {
+	dex := New()
+	dex.RecordExplored("viridian-forest-area")
+	dex.RecordExplored("viridian-forest-area")
+	dex.RecordEncounter("viridian-forest-area")
+	dex.RecordEncounter("mt-moon-1f")
+
+	visits := dex.Visits()
+	if got := visits["viridian-forest-area"]; got != (Visits{Explored: 2, Encounters: 1}) || got.Total() != 3 {
+		t.Errorf("Expected 2 explores and 1 encounter in Viridian Forest, got %+v", got)
+	}
+	if got := visits["mt-moon-1f"]; got != (Visits{Encounters: 1}) {
+		t.Errorf("Expected 1 encounter in Mt. Moon, got %+v", got)
+	}
+
+	if data := dex.Export(); len(data.Visits) != 2 {
+		t.Errorf("Expected the visits to be exported, got %v", data.Visits)
+	}
+	dex.Reset(nil, nil)
+	if len(dex.Visits()) != 0 {
+		t.Error("Expected Reset to clear the visits")
+	}
+}
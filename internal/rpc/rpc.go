@@ -0,0 +1,294 @@
+// Package rpc serves and calls unary gRPC methods over HTTP/2 without TLS,
+// using only the standard library.
+//
+// Messages are passed to and from handlers as encoded protocol buffers, which
+// callers build with the Append functions and read with Fields. Only what the
+// application's service needs is supported: unary calls, uncompressed
+// messages, and status codes with messages. Any gRPC client can call a
+// Server, as long as it connects without TLS.
+//
+// Usage Example:
+//
+//	server := rpc.NewServer("pokedexcli.v1.Pokedex")
+//	server.Handle("Greet", func(ctx context.Context, request []byte) ([]byte, error) {
+//	    return rpc.AppendString(nil, 1, "Hello!"), nil
+//	})
+//	http.Serve(listener, server)
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Code is a gRPC status code.
+type Code int
+
+// The gRPC status codes used by the application.
+const (
+	OK                Code = 0
+	Unknown           Code = 2
+	InvalidArgument   Code = 3
+	NotFound          Code = 5
+	ResourceExhausted Code = 8
+	Unimplemented     Code = 12
+	Internal          Code = 13
+	Unavailable       Code = 14
+)
+
+// maxMessageSize is the largest request or response message accepted, the
+// same limit gRPC uses by default.
+const maxMessageSize = 4 << 20
+
+// Error is a failed call's status: a code other than OK and a message for the caller.
+type Error struct {
+	Code    Code
+	Message string
+}
+
+// Error returns a string representation of the status.
+func (e *Error) Error() string {
+	return fmt.Sprintf("rpc error: code = %d desc = %s", e.Code, e.Message)
+}
+
+// Errorf returns an error with the given status code and formatted message.
+func Errorf(code Code, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// Handler handles a unary call. It's given the encoded request message and
+// returns the encoded response message, or an error. Errors other than *Error
+// are reported to the caller with the Unknown code.
+type Handler func(ctx context.Context, request []byte) ([]byte, error)
+
+// Server serves the methods of one gRPC service. It's an http.Handler, and
+// must be served over HTTP/2 (see Protocols).
+type Server struct {
+	service string
+	methods map[string]Handler
+}
+
+// NewServer returns a server for the service with the given fully-qualified
+// name, e.g. "pokedexcli.v1.Pokedex".
+func NewServer(service string) *Server {
+	return &Server{service: service, methods: make(map[string]Handler)}
+}
+
+// Handle registers the handler for a method of the service, e.g. "Catch".
+func (s *Server) Handle(method string, handler Handler) {
+	s.methods["/"+s.service+"/"+method] = handler
+}
+
+// Protocols returns the protocols a gRPC server or client uses without TLS:
+// HTTP/2 only, with prior knowledge.
+func Protocols() *http.Protocols {
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	return protocols
+}
+
+// ServeHTTP handles a gRPC call.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ProtoMajor != 2 {
+		http.Error(w, "gRPC requires POST over HTTP/2", http.StatusBadRequest)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "unsupported content type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc")
+	response, err := s.call(r)
+	if err == nil {
+		if _, err = w.Write(frame(response)); err != nil {
+			return
+		}
+	}
+	writeStatus(w, err)
+}
+
+// call reads the request message and passes it to the method's handler.
+func (s *Server) call(r *http.Request) ([]byte, error) {
+	handler, ok := s.methods[r.URL.Path]
+	if !ok {
+		return nil, Errorf(Unimplemented, "unknown method %s", r.URL.Path)
+	}
+	request, err := readMessage(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.WithValue(r.Context(), incomingKey{}, r.Header)
+	return handler(ctx, request)
+}
+
+// incomingKey is the context key for a call's request headers.
+type incomingKey struct{}
+
+// outgoingKey is the context key for the metadata a client sends with a call.
+type outgoingKey struct{}
+
+// Metadata returns the value of a metadata key sent with the call a handler
+// is handling, or "" if it wasn't sent. gRPC metadata is sent as HTTP
+// headers, so keys aren't case-sensitive.
+func Metadata(ctx context.Context, key string) string {
+	header, _ := ctx.Value(incomingKey{}).(http.Header)
+	return header.Get(key)
+}
+
+// WithMetadata returns a copy of ctx that sends a metadata key and value with
+// the calls a Client makes with it. Earlier values for the same key are replaced.
+func WithMetadata(ctx context.Context, key, value string) context.Context {
+	metadata := http.Header{}
+	if previous, ok := ctx.Value(outgoingKey{}).(http.Header); ok {
+		metadata = previous.Clone()
+	}
+	metadata.Set(key, value)
+	return context.WithValue(ctx, outgoingKey{}, metadata)
+}
+
+// writeStatus sends a call's status in the response trailers.
+func writeStatus(w http.ResponseWriter, err error) {
+	code, message := OK, ""
+	if err != nil {
+		var status *Error
+		if !errors.As(err, &status) {
+			status = &Error{Code: Unknown, Message: err.Error()}
+		}
+		code, message = status.Code, status.Message
+	}
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(int(code)))
+	if message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", encodeMessage(message))
+	}
+}
+
+// frame prefixes a message with its gRPC header: an uncompressed flag and its length.
+func frame(msg []byte) []byte {
+	framed := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(framed[1:], uint32(len(msg)))
+	return append(framed, msg...)
+}
+
+// readMessage reads one framed message from a request or response body.
+func readMessage(body io.Reader) ([]byte, error) {
+	var header [5]byte
+	if _, err := io.ReadFull(body, header[:]); err != nil {
+		return nil, Errorf(Internal, "reading message header: %v", err)
+	}
+	if header[0] != 0 {
+		return nil, Errorf(Unimplemented, "compressed messages are not supported")
+	}
+	size := binary.BigEndian.Uint32(header[1:])
+	if size > maxMessageSize {
+		return nil, Errorf(ResourceExhausted, "message of %d bytes is larger than the limit of %d", size, maxMessageSize)
+	}
+	msg := make([]byte, size)
+	if _, err := io.ReadFull(body, msg); err != nil {
+		return nil, Errorf(Internal, "reading message: %v", err)
+	}
+	return msg, nil
+}
+
+// encodeMessage percent-encodes a status message, as gRPC requires for the
+// grpc-message trailer.
+func encodeMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		if c := message[i]; c < ' ' || c > '~' || c == '%' {
+			fmt.Fprintf(&b, "%%%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// decodeMessage reverses encodeMessage. Invalid escapes are kept as they are.
+func decodeMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		if message[i] == '%' && i+2 < len(message) {
+			if c, err := strconv.ParseUint(message[i+1:i+3], 16, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 2
+				continue
+			}
+		}
+		b.WriteByte(message[i])
+	}
+	return b.String()
+}
+
+// Client calls the methods of a gRPC service on a server without TLS.
+type Client struct {
+	baseURL string
+	service string
+	http    *http.Client
+}
+
+// NewClient returns a client for the service with the given fully-qualified
+// name, served at addr (host:port).
+func NewClient(addr, service string) *Client {
+	return &Client{
+		baseURL: "http://" + addr,
+		service: service,
+		http:    &http.Client{Transport: &http.Transport{Protocols: Protocols()}},
+	}
+}
+
+// Invoke calls a method of the service.
+//
+// Parameters:
+//   - ctx: The context of the call, with any metadata to send (see WithMetadata)
+//   - method: The method's name, e.g. "Catch"
+//   - request: The encoded request message
+//
+// Returns:
+//   - The encoded response message
+//   - An *Error with the call's status if it failed, or another error if the
+//     server couldn't be reached
+func (c *Client) Invoke(ctx context.Context, method string, request []byte) ([]byte, error) {
+	url := c.baseURL + "/" + c.service + "/" + method
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(frame(request)))
+	if err != nil {
+		return nil, err
+	}
+	if metadata, ok := ctx.Value(outgoingKey{}).(http.Header); ok {
+		for key, values := range metadata {
+			req.Header[key] = values
+		}
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("Te", "trailers")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, Errorf(Unavailable, "unexpected HTTP status %s", resp.Status)
+	}
+
+	// The status is in the trailers, which are only read with the whole body
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	code, err := strconv.Atoi(resp.Trailer.Get("Grpc-Status"))
+	if err != nil {
+		return nil, Errorf(Internal, "missing or invalid grpc-status trailer")
+	}
+	if Code(code) != OK {
+		return nil, &Error{Code: Code(code), Message: decodeMessage(resp.Trailer.Get("Grpc-Message"))}
+	}
+	return readMessage(bytes.NewReader(body))
+}
@@ -0,0 +1,128 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+)
+
+// TestFieldsRoundTrip tests that encoded fields decode to the same values
+func TestFieldsRoundTrip(t *testing.T) {
+	inner := AppendString(nil, 1, "pikachu")
+	msg := AppendString(nil, 1, "Pokédex")
+	msg = AppendInt(msg, 2, 300)
+	msg = AppendBool(msg, 3, true)
+	msg = AppendStrings(msg, 4, []string{"electric", ""})
+	msg = AppendMessage(msg, 5, inner)
+	msg = AppendString(msg, 6, "")
+	msg = AppendInt(msg, 7, 0)
+
+	fields, err := Fields(msg)
+	if err != nil {
+		t.Fatalf("Fields returned an error: %v", err)
+	}
+	if len(fields) != 6 {
+		t.Fatalf("Expected 6 fields without the default values, got %d", len(fields))
+	}
+	if fields[0].String() != "Pokédex" || fields[1].Int() != 300 || !fields[2].Bool() {
+		t.Errorf("Unexpected scalar fields: %+v", fields[:3])
+	}
+	if fields[3].String() != "electric" || fields[4].Number != 4 || fields[4].String() != "" {
+		t.Errorf("Expected both repeated strings to be kept, got %+v", fields[3:5])
+	}
+	nested, err := Fields(fields[5].Bytes())
+	if err != nil || len(nested) != 1 || nested[0].String() != "pikachu" {
+		t.Errorf("Expected the embedded message to decode, got %+v, %v", nested, err)
+	}
+}
+
+// TestFieldsMalformed tests that truncated and invalid messages are rejected
+func TestFieldsMalformed(t *testing.T) {
+	cases := map[string][]byte{
+		"truncated string": {0x0a, 0x05, 'a', 'b'},
+		"truncated varint": {0x10, 0x80},
+		"field zero":       {0x00, 0x01},
+		"group":            {0x0b},
+	}
+	for name, msg := range cases {
+		if _, err := Fields(msg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	// Fixed-width fields are skipped rather than rejected
+	fields, err := Fields([]byte{0x0d, 1, 2, 3, 4, 0x10, 0x07})
+	if err != nil || len(fields) != 1 || fields[0].Int() != 7 {
+		t.Errorf("Expected the fixed32 field to be skipped, got %+v, %v", fields, err)
+	}
+}
+
+// TestStatusMessageEncoding tests percent-encoding of status messages
+func TestStatusMessageEncoding(t *testing.T) {
+	message := "100% sure: Pokémon\nnot found"
+	encoded := encodeMessage(message)
+	if encoded != "100%25 sure: Pok%C3%A9mon%0Anot found" {
+		t.Errorf("Unexpected encoding %q", encoded)
+	}
+	if decoded := decodeMessage(encoded); decoded != message {
+		t.Errorf("Expected %q back, got %q", message, decoded)
+	}
+}
+
+// TestServerAndClient tests calls over HTTP/2, including failed and unknown methods
+func TestServerAndClient(t *testing.T) {
+	server := NewServer("test.v1.Echo")
+	server.Handle("Echo", func(ctx context.Context, request []byte) ([]byte, error) {
+		return request, nil
+	})
+	server.Handle("Fail", func(ctx context.Context, request []byte) ([]byte, error) {
+		return nil, Errorf(NotFound, "Pokémon %s was not found", "missingno")
+	})
+	server.Handle("Crash", func(ctx context.Context, request []byte) ([]byte, error) {
+		return nil, errors.New("something broke")
+	})
+	server.Handle("Whoami", func(ctx context.Context, request []byte) ([]byte, error) {
+		return AppendString(nil, 1, Metadata(ctx, "x-trainer-id")), nil
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Could not listen: %v", err)
+	}
+	httpServer := &http.Server{Handler: server, Protocols: Protocols()}
+	go httpServer.Serve(listener)
+	defer httpServer.Close()
+
+	client := NewClient(listener.Addr().String(), "test.v1.Echo")
+	ctx := context.Background()
+
+	request := AppendString(nil, 1, "pikachu")
+	response, err := client.Invoke(ctx, "Echo", request)
+	if err != nil || string(response) != string(request) {
+		t.Errorf("Echo returned %q, %v", response, err)
+	}
+
+	response, err = client.Invoke(WithMetadata(ctx, "x-trainer-id", "ash"), "Whoami", nil)
+	if fields, _ := Fields(response); err != nil || len(fields) != 1 || fields[0].String() != "ash" {
+		t.Errorf("Expected the metadata to reach the handler, got %q, %v", response, err)
+	}
+
+	cases := []struct {
+		method  string
+		code    Code
+		message string
+	}{
+		{"Fail", NotFound, "Pokémon missingno was not found"},
+		{"Crash", Unknown, "something broke"},
+		{"Missing", Unimplemented, "unknown method /test.v1.Echo/Missing"},
+	}
+	for _, c := range cases {
+		_, err := client.Invoke(ctx, c.method, nil)
+		var status *Error
+		if !errors.As(err, &status) || status.Code != c.code || status.Message != c.message {
+			t.Errorf("%s: expected code %d %q, got %v", c.method, c.code, c.message, err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package rpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Protocol buffer wire types.
+const (
+	wireVarint  = 0 // int32, int64, uint32, uint64, bool, enum
+	wireFixed64 = 1 // fixed64, sfixed64, double
+	wireBytes   = 2 // string, bytes, embedded messages, packed repeated fields
+	wireFixed32 = 5 // fixed32, sfixed32, float
+)
+
+// errMalformed is returned when a message can't be decoded.
+var errMalformed = errors.New("malformed protocol buffer message")
+
+// Field is one field of a decoded protocol buffer message.
+type Field struct {
+	Number int    // The field number from the .proto file
+	varint uint64 // The value of a varint field
+	bytes  []byte // The value of a length-delimited field
+}
+
+// String returns the value of a string field.
+func (f Field) String() string {
+	return string(f.bytes)
+}
+
+// Bytes returns the value of a bytes or embedded message field.
+func (f Field) Bytes() []byte {
+	return f.bytes
+}
+
+// Int returns the value of an int32 or int64 field.
+func (f Field) Int() int64 {
+	return int64(f.varint)
+}
+
+// Bool returns the value of a bool field.
+func (f Field) Bool() bool {
+	return f.varint != 0
+}
+
+// Fields decodes the fields of a protocol buffer message in the order they
+// were encoded. Fixed-width fields are skipped, since the messages this
+// package is used for don't have any.
+//
+// Parameters:
+//   - msg: The encoded message
+//
+// Returns:
+//   - The message's varint and length-delimited fields
+//   - An error if the message is malformed or uses groups
+func Fields(msg []byte) ([]Field, error) {
+	var fields []Field
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 || key>>3 == 0 || key>>3 > math.MaxInt32 {
+			return nil, errMalformed
+		}
+		msg = msg[n:]
+
+		field := Field{Number: int(key >> 3)}
+		switch key & 7 {
+		case wireVarint:
+			if field.varint, n = binary.Uvarint(msg); n <= 0 {
+				return nil, errMalformed
+			}
+			msg = msg[n:]
+		case wireBytes:
+			length, n := binary.Uvarint(msg)
+			if n <= 0 || length > uint64(len(msg)-n) {
+				return nil, errMalformed
+			}
+			field.bytes = msg[n : n+int(length)]
+			msg = msg[n+int(length):]
+		case wireFixed64, wireFixed32:
+			size := 8
+			if key&7 == wireFixed32 {
+				size = 4
+			}
+			if len(msg) < size {
+				return nil, errMalformed
+			}
+			msg = msg[size:]
+			continue
+		default:
+			return nil, errMalformed
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// AppendString appends a string field to a message. Empty strings are left
+// out, since they're the default value.
+func AppendString(msg []byte, number int, value string) []byte {
+	if value == "" {
+		return msg
+	}
+	return appendBytes(msg, number, []byte(value))
+}
+
+// AppendStrings appends a repeated string field to a message.
+func AppendStrings(msg []byte, number int, values []string) []byte {
+	for _, value := range values {
+		msg = appendBytes(msg, number, []byte(value))
+	}
+	return msg
+}
+
+// AppendMessage appends an embedded message field to a message. Messages are
+// always written, so that each element of a repeated field is kept.
+func AppendMessage(msg []byte, number int, value []byte) []byte {
+	return appendBytes(msg, number, value)
+}
+
+// AppendInt appends an int32 or int64 field to a message. Zero is left out,
+// since it's the default value.
+func AppendInt(msg []byte, number int, value int64) []byte {
+	if value == 0 {
+		return msg
+	}
+	msg = appendKey(msg, number, wireVarint)
+	return binary.AppendUvarint(msg, uint64(value))
+}
+
+// AppendBool appends a bool field to a message. False is left out, since
+// it's the default value.
+func AppendBool(msg []byte, number int, value bool) []byte {
+	if !value {
+		return msg
+	}
+	msg = appendKey(msg, number, wireVarint)
+	return binary.AppendUvarint(msg, 1)
+}
+
+// appendBytes appends a length-delimited field to a message.
+func appendBytes(msg []byte, number int, value []byte) []byte {
+	msg = appendKey(msg, number, wireBytes)
+	msg = binary.AppendUvarint(msg, uint64(len(value)))
+	return append(msg, value...)
+}
+
+// appendKey appends the key that starts a field: its number and wire type.
+func appendKey(msg []byte, number int, wireType uint64) []byte {
+	return binary.AppendUvarint(msg, uint64(number)<<3|wireType)
+}
@@ -0,0 +1,242 @@
+// Package search provides a precomputed index of Pokémon for finding them by
+// name, type, and generation. It's an inverted index: each word of a name,
+// each type, and each generation lists the Pokémon it belongs to, so a query
+// only looks at the Pokémon that match it. The index is built once from the
+// species data and saved to disk, so that searches and name suggestions are
+// answered without the network, even on the first command of a session.
+//
+// Usage Example:
+//
+//	idx := search.New("dataset:2026-10-15", []search.Entry{
+//	    {Name: "pikachu", ID: 25, Types: []string{"electric"}},
+//	}, true)
+//	names := idx.Search(search.Query{Prefix: "pika", Type: "electric", Generation: 1})
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// generationEnds is the last National Pokédex number of each generation, in order.
+var generationEnds = []int{151, 251, 386, 493, 649, 721, 809, 905, 1025}
+
+// Generation returns the generation a Pokémon was introduced in, from its
+// National Pokédex number, or 0 if it's unknown, as for alternate forms,
+// whose IDs are above 10000.
+func Generation(id int) int {
+	if id < 1 {
+		return 0
+	}
+	for i, end := range generationEnds {
+		if id <= end {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// Entry is a Pokémon to index.
+type Entry struct {
+	Name  string   // Name in API format (lowercase with hyphens)
+	ID    int      // The Pokémon's ID, which is its National Pokédex number for default forms
+	Types []string // Type names, if known
+}
+
+// Query describes the Pokémon to find. Empty fields match every Pokémon.
+type Query struct {
+	Prefix     string `json:"prefix,omitempty"`     // The start of the name or of a word in it, in API format (e.g. "mime" finds Mr. Mime)
+	Type       string `json:"type,omitempty"`       // A type the Pokémon has
+	Generation int    `json:"generation,omitempty"` // The generation the Pokémon was introduced in
+}
+
+// Index is a searchable index of Pokémon names, types, and generations.
+type Index struct {
+	Source      string              `json:"source"`      // What the index was built from, to tell when it's out of date
+	Built       time.Time           `json:"built"`       // When the index was built
+	HasTypes    bool                `json:"has_types"`   // Whether the types of every Pokémon are indexed
+	Names       []string            `json:"names"`       // Every Pokémon name, sorted
+	Words       map[string][]string `json:"words"`       // The Pokémon whose name is or has each word, sorted
+	Types       map[string][]string `json:"types"`       // The Pokémon with each type, sorted
+	Generations map[int][]string    `json:"generations"` // The Pokémon introduced in each generation, sorted
+
+	words []string // The keys of Words, sorted, for prefix searches
+}
+
+// New builds an index of Pokémon.
+//
+// Parameters:
+//   - source: Identifies the data the index is built from
+//   - entries: The Pokémon to index, in any order
+//   - hasTypes: Whether the entries have the types of every Pokémon
+//
+// Returns:
+//   - The index, stamped with the current time
+func New(source string, entries []Entry, hasTypes bool) *Index {
+	idx := &Index{
+		Source:      source,
+		Built:       time.Now(),
+		HasTypes:    hasTypes,
+		Words:       make(map[string][]string),
+		Types:       make(map[string][]string),
+		Generations: make(map[int][]string),
+	}
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		if entry.Name == "" || seen[entry.Name] {
+			continue
+		}
+		seen[entry.Name] = true
+		idx.Names = append(idx.Names, entry.Name)
+
+		// The whole name is a word too, so that prefixes with hyphens match
+		for _, word := range append(strings.Split(entry.Name, "-"), entry.Name) {
+			if word != "" && !slices.Contains(idx.Words[word], entry.Name) {
+				idx.Words[word] = append(idx.Words[word], entry.Name)
+			}
+		}
+		for _, typeName := range entry.Types {
+			idx.Types[typeName] = append(idx.Types[typeName], entry.Name)
+		}
+		if generation := Generation(entry.ID); generation > 0 {
+			idx.Generations[generation] = append(idx.Generations[generation], entry.Name)
+		}
+	}
+
+	sort.Strings(idx.Names)
+	for _, postings := range []map[string][]string{idx.Words, idx.Types} {
+		for _, names := range postings {
+			sort.Strings(names)
+		}
+	}
+	for _, names := range idx.Generations {
+		sort.Strings(names)
+	}
+	idx.prepare()
+	return idx
+}
+
+// prepare sorts the words for prefix searches.
+func (idx *Index) prepare() {
+	idx.words = make([]string, 0, len(idx.Words))
+	for word := range idx.Words {
+		idx.words = append(idx.words, word)
+	}
+	sort.Strings(idx.words)
+}
+
+// Search returns the Pokémon matching a query.
+//
+// Parameters:
+//   - q: The query; empty fields match every Pokémon
+//
+// Returns:
+//   - The names of the matching Pokémon, sorted
+func (idx *Index) Search(q Query) []string {
+	var sets [][]string
+	if q.Prefix != "" {
+		sets = append(sets, idx.withWordPrefix(q.Prefix))
+	}
+	if q.Type != "" {
+		sets = append(sets, idx.Types[q.Type])
+	}
+	if q.Generation > 0 {
+		sets = append(sets, idx.Generations[q.Generation])
+	}
+	if len(sets) == 0 {
+		return slices.Clone(idx.Names)
+	}
+
+	// Start from the smallest set, keeping the names that are in all the others
+	sort.Slice(sets, func(i, j int) bool { return len(sets[i]) < len(sets[j]) })
+	matches := []string{}
+	for _, name := range sets[0] {
+		inAll := true
+		for _, set := range sets[1:] {
+			if _, found := slices.BinarySearch(set, name); !found {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			matches = append(matches, name)
+		}
+	}
+	return matches
+}
+
+// withWordPrefix returns the Pokémon with a word in their name that starts
+// with a prefix, sorted.
+func (idx *Index) withWordPrefix(prefix string) []string {
+	found := make(map[string]bool)
+	start := sort.SearchStrings(idx.words, prefix)
+	for _, word := range idx.words[start:] {
+		if !strings.HasPrefix(word, prefix) {
+			break
+		}
+		for _, name := range idx.Words[word] {
+			found[name] = true
+		}
+	}
+	names := make([]string, 0, len(found))
+	for name := range found {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load reads the index saved at path.
+//
+// Parameters:
+//   - path: The location of the saved index
+//
+// Returns:
+//   - The index, or nil if there isn't one
+//   - An error if the file exists but can't be read or decoded
+func Load(path string) (*Index, error) {
+	encoded, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading search index: %w", err)
+	}
+
+	var idx Index
+	if err := json.Unmarshal(encoded, &idx); err != nil {
+		return nil, fmt.Errorf("error decoding search index: %w", err)
+	}
+	idx.prepare()
+	return &idx, nil
+}
+
+// Write saves an index to path, replacing the file atomically.
+//
+// Parameters:
+//   - path: The location to save the index
+//   - idx: The index to save
+//
+// Returns:
+//   - An error if the index can't be written
+func Write(path string, idx *Index) error {
+	encoded, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("error serializing search index: %w", err)
+	}
+
+	tempFilePath := path + ".tmp"
+	if err := os.WriteFile(tempFilePath, encoded, 0644); err != nil {
+		return fmt.Errorf("error writing temporary search index file: %w", err)
+	}
+	if err := os.Rename(tempFilePath, path); err != nil {
+		os.Remove(tempFilePath)
+		return fmt.Errorf("error replacing search index file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// testEntries are the Pokémon indexed by the tests.
+var testEntries = []Entry{
+	{Name: "pikachu", ID: 25, Types: []string{"electric"}},
+	{Name: "mr-mime", ID: 122, Types: []string{"psychic", "fairy"}},
+	{Name: "mime-jr", ID: 439, Types: []string{"psychic", "fairy"}},
+	{Name: "mr-rime", ID: 866, Types: []string{"ice", "psychic"}},
+	{Name: "pichu", ID: 172, Types: []string{"electric"}},
+	{Name: "pikachu-rock-star", ID: 10080, Types: []string{"electric"}},
+}
+
+// TestGeneration tests that National Pokédex numbers map to the generation
+// they were introduced in, and forms to none
+func TestGeneration(t *testing.T) {
+	cases := map[int]int{1: 1, 151: 1, 152: 2, 386: 3, 387: 4, 905: 8, 1025: 9, 1026: 0, 10080: 0, 0: 0}
+	for id, expected := range cases {
+		if got := Generation(id); got != expected {
+			t.Errorf("Generation(%d) == %d, expected %d", id, got, expected)
+		}
+	}
+}
+
+// TestSearch tests that queries match names by the start of any word, and
+// combine with types and generations
+func TestSearch(t *testing.T) {
+	idx := New("test", testEntries, true)
+	cases := []struct {
+		query    Query
+		expected []string
+	}{
+		{Query{Prefix: "pi"}, []string{"pichu", "pikachu", "pikachu-rock-star"}},
+		{Query{Prefix: "mime"}, []string{"mime-jr", "mr-mime"}},
+		{Query{Prefix: "mr-m"}, []string{"mr-mime"}},
+		{Query{Prefix: "rock"}, []string{"pikachu-rock-star"}},
+		{Query{Type: "psychic"}, []string{"mime-jr", "mr-mime", "mr-rime"}},
+		{Query{Type: "psychic", Generation: 4}, []string{"mime-jr"}},
+		{Query{Prefix: "mr", Type: "ice"}, []string{"mr-rime"}},
+		{Query{Prefix: "pi", Generation: 1}, []string{"pikachu"}},
+		{Query{Prefix: "zzz"}, []string{}},
+		{Query{Type: "dragon"}, []string{}},
+	}
+	for _, tc := range cases {
+		if got := idx.Search(tc.query); !slices.Equal(got, tc.expected) {
+			t.Errorf("Search(%+v) == %v, expected %v", tc.query, got, tc.expected)
+		}
+	}
+
+	if got := idx.Search(Query{}); len(got) != len(testEntries) {
+		t.Errorf("Expected an empty query to match all %d Pokémon, got %v", len(testEntries), got)
+	}
+}
+
+// TestWriteAndLoad tests that a saved index answers the same queries once
+// loaded, and that a missing index isn't an error
+func TestWriteAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search-index.json")
+	if idx, err := Load(path); idx != nil || err != nil {
+		t.Fatalf("Expected no index and no error for a missing file, got %v, %v", idx, err)
+	}
+
+	saved := New("dataset:1:6", testEntries, true)
+	if err := Write(path, saved); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil || loaded == nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Source != saved.Source || !loaded.HasTypes || !slices.Equal(loaded.Names, saved.Names) {
+		t.Errorf("Loaded index %+v doesn't match the saved one", loaded)
+	}
+	query := Query{Prefix: "mi", Type: "fairy", Generation: 4}
+	if got := loaded.Search(query); !slices.Equal(got, []string{"mime-jr"}) {
+		t.Errorf("Search(%+v) on the loaded index == %v, expected [mime-jr]", query, got)
+	}
+}
@@ -0,0 +1,116 @@
+// Package update checks GitHub for newer releases of the application.
+//
+// Release versions are tags of the form vMAJOR.MINOR.PATCH. Builds that
+// don't carry such a version, like development builds, are never considered
+// out of date.
+//
+// Usage Example:
+//
+//	release, err := update.LatestRelease(ctx, http.DefaultClient, "pokedexcli/v1.2.0")
+//	if err == nil && update.Newer(release.Version, "v1.2.0") {
+//	    fmt.Printf("Version %s is available: %s\n", release.Version, release.URL)
+//	}
+package update
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// releasesURL is the GitHub API endpoint for the latest release of the application.
+// It's a variable so that tests can point it at a local server.
+var releasesURL = "https://api.github.com/repos/bmlevitt/pokedexcli/releases/latest"
+
+// Release describes a published release of the application.
+type Release struct {
+	Version string `json:"tag_name"` // The release tag, e.g. "v1.2.0"
+	URL     string `json:"html_url"` // The release page on GitHub
+}
+
+// LatestRelease asks GitHub for the most recent release of the application.
+//
+// Parameters:
+//   - ctx: Context for cancelling the request
+//   - client: The HTTP client to send the request with
+//   - userAgent: The User-Agent header, which GitHub requires
+//
+// Returns:
+//   - The latest release
+//   - An error if the request fails or the response can't be read
+func LatestRelease(ctx context.Context, client *http.Client, userAgent string) (Release, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, releasesURL, nil)
+	if err != nil {
+		return Release{}, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return Release{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Release{}, fmt.Errorf("unexpected status checking for releases: %d", resp.StatusCode)
+	}
+
+	var release Release
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return Release{}, fmt.Errorf("error reading release information: %w", err)
+	}
+	if !IsRelease(release.Version) {
+		return Release{}, fmt.Errorf("unexpected release version %q", release.Version)
+	}
+	return release, nil
+}
+
+// IsRelease reports whether a version is a release version (vMAJOR.MINOR.PATCH).
+func IsRelease(version string) bool {
+	_, ok := parseVersion(version)
+	return ok
+}
+
+// Newer reports whether latest is a later release than current. It's false
+// if either version is not a release version.
+//
+// Parameters:
+//   - latest: The version of the latest release
+//   - current: The version that is running
+func Newer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+// parseVersion splits a release version into its major, minor, and patch numbers.
+func parseVersion(version string) ([3]int, bool) {
+	var parts [3]int
+	fields := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if !strings.HasPrefix(version, "v") || len(fields) != len(parts) {
+		return parts, false
+	}
+	for i, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 || field != strconv.Itoa(n) {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
@@ -0,0 +1,66 @@
+package update
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestNewer tests comparing release versions
+func TestNewer(t *testing.T) {
+	cases := []struct {
+		latest, current string
+		want            bool
+	}{
+		{latest: "v1.2.0", current: "v1.1.9", want: true},
+		{latest: "v1.10.0", current: "v1.9.0", want: true},
+		{latest: "v2.0.0", current: "v1.99.99", want: true},
+		{latest: "v1.2.0", current: "v1.2.0", want: false},
+		{latest: "v1.2.0", current: "v1.3.0", want: false},
+		{latest: "v1.2.0", current: "dev", want: false},
+		{latest: "v1.2.0", current: "v0.0.0-20240101000000-abcdef123456", want: false},
+		{latest: "v1.2", current: "v1.0.0", want: false},
+		{latest: "1.2.0", current: "v1.0.0", want: false},
+		{latest: "v1.02.0", current: "v1.0.0", want: false},
+	}
+	for _, c := range cases {
+		if got := Newer(c.latest, c.current); got != c.want {
+			t.Errorf("Newer(%q, %q) = %v, expected %v", c.latest, c.current, got, c.want)
+		}
+	}
+}
+
+// TestLatestRelease tests reading the latest release from GitHub
+func TestLatestRelease(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		if r.URL.Path == "/broken" {
+			w.Write([]byte(`{"tag_name": "nightly"}`))
+			return
+		}
+		w.Write([]byte(`{"tag_name": "v1.3.0", "html_url": "https://github.com/bmlevitt/pokedexcli/releases/tag/v1.3.0"}`))
+	}))
+	defer server.Close()
+
+	original := releasesURL
+	defer func() { releasesURL = original }()
+
+	releasesURL = server.URL + "/latest"
+	release, err := LatestRelease(context.Background(), server.Client(), "pokedexcli/v1.2.0")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if release.Version != "v1.3.0" || release.URL == "" {
+		t.Errorf("Unexpected release: %+v", release)
+	}
+	if userAgent != "pokedexcli/v1.2.0" {
+		t.Errorf("Expected the User-Agent to be sent, got %q", userAgent)
+	}
+
+	releasesURL = server.URL + "/broken"
+	if _, err := LatestRelease(context.Background(), server.Client(), "pokedexcli/v1.2.0"); err == nil {
+		t.Error("Expected an error for a release without a version")
+	}
+}
@@ -0,0 +1,48 @@
+// This file contains the items sold in the shop, the effect of Poké Balls
+// on catching, and the lures that draw out wild Pokémon. The effects of held
+// items are in battle_items.go.
+package main
+
+// shopStock lists the items sold in the shop, in the order they are shown.
+// Prices come from the PokeAPI item data.
+var shopStock = []string{
+	"poke-ball", "great-ball", "ultra-ball", "honey",
+	"fire-stone", "water-stone", "thunder-stone", "leaf-stone", "moon-stone",
+	"leftovers", "choice-band", "choice-specs", "choice-scarf",
+}
+
+// ballModifiers lists the Poké Balls that can be thrown and how much each
+// multiplies a Pokémon's capture rate, as in the main series games.
+var ballModifiers = map[string]float64{
+	"poke-ball":  1,
+	"great-ball": 1.5,
+	"ultra-ball": 2,
+}
+
+// maxCaptureRate is the highest possible capture rate.
+const maxCaptureRate = 255
+
+// applyBall adjusts a capture rate for the ball being thrown.
+//
+// Parameters:
+//   - captureRate: The capture rate before the ball is taken into account
+//   - ball: The API name of the ball, or "" for a standard Poké Ball
+//
+// Returns:
+//   - The adjusted capture rate, capped at maxCaptureRate
+func applyBall(captureRate int, ball string) int {
+	modifier, ok := ballModifiers[ball]
+	if !ok {
+		modifier = 1
+	}
+	return min(maxCaptureRate, int(float64(captureRate)*modifier))
+}
+
+// lureEncounters lists the lure items and the number of encounters each one
+// lasts for once it is used in a location area.
+var lureEncounters = map[string]int{
+	"honey": 10,
+}
+
+// lureBias is how many times more often a lure's target turns up in encounters.
+const lureBias = 5
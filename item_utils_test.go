@@ -0,0 +1,42 @@
+package main
+
+import "testing"
+
+// TestApplyBall tests that balls multiply the capture rate up to the maximum
+func TestApplyBall(t *testing.T) {
+	cases := []struct {
+		rate     int
+		ball     string
+		expected int
+	}{
+		{45, "", 45},
+		{45, "poke-ball", 45},
+		{45, "great-ball", 67},
+		{45, "ultra-ball", 90},
+		{200, "ultra-ball", maxCaptureRate},
+	}
+	for _, c := range cases {
+		if got := applyBall(c.rate, c.ball); got != c.expected {
+			t.Errorf("applyBall(%d, %q) = %d, expected %d", c.rate, c.ball, got, c.expected)
+		}
+	}
+}
+
+// TestShopStockIncludesBalls tests that every ball that can be thrown, and
+// every lure, is sold in the shop
+func TestShopStockIncludesBalls(t *testing.T) {
+	stocked := make(map[string]bool)
+	for _, item := range shopStock {
+		stocked[item] = true
+	}
+	for ball := range ballModifiers {
+		if !stocked[ball] {
+			t.Errorf("Expected the shop to sell %s", ball)
+		}
+	}
+	for lure := range lureEncounters {
+		if !stocked[lure] {
+			t.Errorf("Expected the shop to sell %s", lure)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// This file contains macros: named lines of commands with placeholders, which
+// the user defines with 'macro define' and runs by typing the macro's name like
+// a command. With "map; explore $1; encounter" defined as hunt, "hunt 5" runs
+// "map; explore 5; encounter". The line is run just as if it had been typed,
+// so a macro can chain commands with ";" and "&&" and run other macros.
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// maxMacroDepth is how many macros can run inside one another, which stops a
+// macro that runs itself.
+const maxMacroDepth = 8
+
+// macroPlaceholder matches the placeholders in a macro: $1 to $9 for its
+// parameters, and $* for all of them.
+var macroPlaceholder = regexp.MustCompile(`\$(\*|[1-9])`)
+
+// macroNamePattern matches the names macros can have.
+var macroNamePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// macroParamCount returns how many parameters a macro needs: the number of its
+// highest numbered placeholder.
+func macroParamCount(body string) int {
+	count := 0
+	for _, match := range macroPlaceholder.FindAllStringSubmatch(body, -1) {
+		if n, err := strconv.Atoi(match[1]); err == nil {
+			count = max(count, n)
+		}
+	}
+	return count
+}
+
+// expandMacro fills in a macro's placeholders with the parameters it was given.
+// Parameters beyond those it has placeholders for are only used by $*.
+//
+// Parameters:
+//   - name: The macro's name, for the error message
+//   - body: The macro's line of commands
+//   - params: The parameters it was given
+//
+// Returns:
+//   - The line of commands to run
+//   - An error if it was given too few parameters
+func expandMacro(name, body string, params []string) (string, error) {
+	if need := macroParamCount(body); len(params) < need {
+		if need == 1 {
+			return "", errorhandling.NewInvalidInputError(i18n.Sprintf("The macro '%s' needs a parameter", name), nil)
+		}
+		return "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The macro '%s' needs %d parameters, but was given %d", name, need, len(params)), nil)
+	}
+	return macroPlaceholder.ReplaceAllStringFunc(body, func(placeholder string) string {
+		if placeholder == "$*" {
+			return strings.Join(params, " ")
+		}
+		n, _ := strconv.Atoi(placeholder[1:])
+		return params[n-1]
+	}), nil
+}
+
+// runMacro runs a macro typed at the prompt: its line of commands, expanded
+// with the parameters it was given.
+//
+// Parameters:
+//   - cfg: The application configuration to be shared with the commands
+//   - commands: The registry of available commands
+//   - name: The macro's name
+//   - body: The macro's line of commands
+//   - params: The parameters it was given, as typed
+//   - lineNumber: The line of input the macro is on, for the batch summary
+//
+// Returns:
+//   - Whether every command in the macro succeeded
+func runMacro(cfg *config, commands map[string]cliCommand, name, body string, params []string, lineNumber int) bool {
+	line, err := expandMacro(name, body, params)
+	if err == nil && cfg.macroDepth >= maxMacroDepth {
+		err = errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Macros can only run %d levels deep; check that '%s' doesn't run itself", maxMacroDepth, name), nil)
+	}
+	if err != nil {
+		PrintUserError(err)
+		if cfg.batch != nil {
+			cfg.batch.record(lineNumber, strings.TrimSpace(name+" "+strings.Join(params, " ")), err)
+		}
+		return false
+	}
+
+	i18n.Printf("Running %s: %s\n", name, line)
+	cfg.macroDepth++
+	defer func() { cfg.macroDepth-- }()
+	return runREPLLine(cfg, commands, line, lineNumber) == 0
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestExpandMacro tests filling in a macro's placeholders
+func TestExpandMacro(t *testing.T) {
+	body := "map; explore $1 && catch $2; note $2 $*"
+	if count := macroParamCount(body); count != 2 {
+		t.Errorf("macroParamCount() = %d, want 2", count)
+	}
+	line, err := expandMacro("hunt", body, []string{"5", "pidgey", "extra"})
+	if err != nil || line != "map; explore 5 && catch pidgey; note pidgey 5 pidgey extra" {
+		t.Errorf("expandMacro() = %q, %v", line, err)
+	}
+	if _, err := expandMacro("hunt", body, []string{"5"}); err == nil || !strings.Contains(err.Error(), "needs 2 parameters, but was given 1") {
+		t.Errorf("Expected too few parameters to be rejected, got %v", err)
+	}
+	if _, err := expandMacro("go", "explore $1", nil); err == nil || !strings.HasSuffix(err.Error(), "needs a parameter") {
+		t.Errorf("Expected a missing parameter to be rejected in the singular, got %v", err)
+	}
+}
+
+// TestRunMacro tests that a macro runs its commands like a typed line, and
+// that a macro that runs itself is stopped
+func TestRunMacro(t *testing.T) {
+	var ran []string
+	commands := map[string]cliCommand{
+		"pass": {name: "pass", callback: func(cfg *config, params []string) error {
+			ran = append(ran, params...)
+			return nil
+		}},
+	}
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	cfg.SetMacro("twice", "pass $1; pass $2")
+	cfg.SetMacro("nested", "twice a b && pass $1")
+	cfg.SetMacro("forever", "forever")
+
+	if failed := runREPLLine(cfg, commands, "nested c", 1); failed != 0 || !slices.Equal(ran, []string{"a", "b", "c"}) {
+		t.Errorf("Expected the nested macro to run a, b, and c, got %v with %d failed", ran, failed)
+	}
+	if failed := runREPLLine(cfg, commands, "forever", 2); failed == 0 {
+		t.Error("Expected a macro that runs itself to fail")
+	}
+	if cfg.macroDepth != 0 {
+		t.Errorf("Expected the macro depth to be restored, got %d", cfg.macroDepth)
+	}
+}
+
+// TestDefineMacro tests the names and lines macros can have
+func TestDefineMacro(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+
+	if err := defineMacro(cfg, "hunt", `"map; explore $1; encounter"`); err != nil {
+		t.Fatalf("defineMacro returned an error: %v", err)
+	}
+	if body, ok := cfg.Macro("hunt"); !ok || body != "map; explore $1; encounter" {
+		t.Errorf("Expected the quotes to be removed, got %q", body)
+	}
+	for _, name := range []string{"catch", "2fast", "hunt!"} {
+		if err := defineMacro(cfg, name, "map"); err == nil {
+			t.Errorf("Expected %q to be rejected as a macro name", name)
+		}
+	}
+	if err := defineMacro(cfg, "empty", `" ; "`); err == nil {
+		t.Error("Expected a macro without commands to be rejected")
+	}
+}
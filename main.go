@@ -1,36 +1,110 @@
 package main
 
 import (
-	"fmt"
+	"bufio"
+	"context"
+	"flag"
+	"net/http"
+	"os"
+	"runtime/debug"
 	"sync"
 	"time"
 
+	"github.com/bmlevitt/pokedexcli/internal/dataset"
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+	"github.com/bmlevitt/pokedexcli/internal/search"
 )
 
+// version is the application version, sent to the PokeAPI in the User-Agent header.
+// Release builds set it with -ldflags "-X main.version=v1.2.0"; otherwise the
+// module version recorded by the Go toolchain is used.
+var version string
+
+// appVersion returns the application version, or "dev" if it is unknown.
+func appVersion() string {
+	if version != "" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
 // config holds the application's global configuration and state.
 // It includes API clients, navigation state, and the user's Pokédex data.
+// The Pokédex locks itself; the settings and explored area are shared state
+// that commands access through the methods in config_access.go.
 type config struct {
-	pokeapiClient        pokeapi.Client                     // Client for making Pokemon API requests
-	nextLocationURL      *string                            // URL for the next page of map locations
-	prevLocationURL      *string                            // URL for the previous page of map locations
-	pokedex              map[string]pokeapi.PokemonDataResp // Map of caught Pokemon indexed by name
-	autoSaveEnabled      bool                               // Whether to automatically save after changes
-	autoSaveInterval     int                                // How many changes before auto-saving (if enabled)
-	changesSinceSync     int                                // Counter for changes since last save
-	recentLocations      []pokeapi.NamedAPIResource         // Most recent list of map locations displayed
-	mapViewedThisSession bool                               // Whether the map command has been used in this session
-	debugMode            bool                               // Whether to show detailed error messages
-	mutex                sync.RWMutex                       // Mutex to protect access to shared data
+	pokeapiClient        *pokeapi.Client                   // Client for making Pokemon API requests (shared, safe for concurrent use)
+	nextLocationURL      *string                           // URL for the next page of map locations
+	prevLocationURL      *string                           // URL for the previous page of map locations
+	pokedex              *pokedex.Pokedex                  // The user's caught Pokémon and boxes
+	settings             settings                          // The user's preferences
+	changesSinceSync     int                               // Counter for changes since last save
+	recentLocations      []pokeapi.NamedAPIResource        // Most recent list of map locations displayed
+	exploredLocation     string                            // The location area explored most recently
+	exploredPokemon      map[string]bool                   // The Pokémon found in exploredLocation
+	selection            selection                         // The numbered list shown by the last command that lists items, for #N references (not saved)
+	mapViewedThisSession bool                              // Whether a map page has been viewed, in this session or the last one (see RestoreMapState)
+	bookmarks            []string                          // The location areas the user bookmarked, in the order added
+	nameIndex            *nameIndex                        // Index of all Pokémon names, loaded on first use
+	dataset              *dataset.Dataset                  // Static species data, downloaded by 'dataset update' (nil for the embedded data)
+	searchIndex          *search.Index                     // Index of Pokémon by name, type, and generation, loaded on first use
+	searchIndexPath      string                            // Where the search index is saved between sessions ("" to only keep it in memory)
+	input                *bufio.Reader                     // Reader for user input, shared by the REPL and confirmation prompts
+	batch                *batchResults                     // Results of the commands run so far in batch mode (nil when interactive)
+	assumeYes            bool                              // Whether confirmation prompts are answered yes automatically
+	dryRun               bool                              // Whether the running command's changes are only being previewed (--dry-run)
+	commandErr           error                             // An error the running command reported without returning it
+	money                int                               // Money earned from battles
+	items                map[string]int                    // Items in the user's bag, by API name, with their quantities
+	lure                 *pokedex.Lure                     // The lure in use, if any
+	rental               *rentalParty                      // The rental team battles use instead of the Pokédex, if one is rented
+	sandbox              *sandboxState                     // The state from before the sandbox was turned on, while it's on
+	redeemedCodes        map[string]bool                   // Distribution codes the user has redeemed, in canonical form
+	trainerID            int                               // The user's trainer ID, assigned the first time it's needed (zero until then)
+	challenges           map[string]pokedex.ChallengeState // The user-defined challenges the user has started, by ID
+	reminders            []pokedex.Reminder                // Reminders and scheduled commands that haven't come up yet, soonest first
+	macros               map[string]string                 // The commands each of the user's macros runs, by the macro's name
+	macroDepth           int                               // How many macros are running inside one another (see runMacro)
+	dashboard            *http.Server                      // The web dashboard's server, if it's running (only the dashboard command uses it)
+	autoSaveStop         chan struct{}                     // Closed to stop the timed auto-save, if it's running
+	events               *eventLoop                        // The REPL's event loop, for background work and messages (nil when no REPL is running)
+	commandCtx           context.Context                   // Cancelled when the user presses Ctrl+C during the running command (nil between commands)
+	saveTrigger          string                            // What's saving, recorded in the save log: the running command or a saveTrigger constant
+	saveFilePath         string                            // The save file given with --save-file or POKEDEXCLI_SAVE ("" for the default one)
+	mutex                sync.RWMutex                      // Mutex to protect access to shared data
 	// Only one mutex -- risk is low in this simple app
 }
 
 // main is the entry point for the Pokédex CLI application.
-// It creates a new API client with a 1-hour cache duration to reduce API calls,
+// It creates a new API client that caches responses to reduce API calls (species
+// for a week, other game data for a day, locations for 6 hours, and anything else for an hour),
 // initializes an empty Pokédex to store caught Pokémon, and loads any saved data.
 // After initialization, it starts the interactive REPL (Read-Eval-Print Loop)
 // that accepts user commands and processes them.
 //
+// Command-line flags:
+//   - --fixtures <dir>: Serve API responses from JSON fixture files in dir instead of the network
+//   - --record: With --fixtures, fetch from the real API and save each response to dir
+//   - --yes: Answer yes to every confirmation prompt, including in batch mode
+//   - --save-file <path>: Keep the Pokédex in this file instead of the default one
+//     (setting the POKEDEXCLI_SAVE environment variable does the same)
+//   - --no-update-check: Don't check GitHub for a newer release at startup
+//     (setting the POKEDEXCLI_NO_UPDATE_CHECK environment variable does the same)
+//   - --proxy <url>: Send API requests through this proxy instead of the one
+//     in the HTTP_PROXY and HTTPS_PROXY environment variables
+//   - --ca-bundle <file>: Also trust the certificate authorities in this PEM
+//     file (setting the POKEDEXCLI_CA_BUNDLE environment variable does the same)
+//   - --insecure: Don't verify the API's certificate (for development only)
+//
+// Any arguments after the flags are run as a single command instead of starting
+// the REPL (e.g. "pokedexcli commands --json").
+//
 // The function handles startup errors gracefully, particularly for loading saved data,
 // by displaying friendly error messages to the user instead of crashing.
 //
@@ -40,26 +114,114 @@ type config struct {
 //   - Prints startup messages to stdout
 //   - Starts the interactive command loop that runs until program exit
 func main() {
+	fixturesDir := flag.String("fixtures", "", "serve API responses from JSON fixtures in this directory")
+	record := flag.Bool("record", false, "with --fixtures, record real API responses into the fixture directory")
+	assumeYes := flag.Bool("yes", false, "answer yes to every confirmation prompt")
+	noUpdateCheck := flag.Bool("no-update-check", false, "don't check for a newer release at startup")
+	saveFilePath := flag.String("save-file", "", "keep the Pokédex in this file instead of the default one (or set "+saveFileEnv+")")
+	proxy := flag.String("proxy", "", "send API requests through this proxy (default: HTTP_PROXY and HTTPS_PROXY)")
+	caBundle := flag.String("ca-bundle", "", "also trust the certificate authorities in this PEM file (or set "+caBundleEnv+")")
+	insecure := flag.Bool("insecure", false, "don't verify the API's certificate (for development only)")
+	flag.Parse()
+
+	// Go through a proxy, or trust its certificate authority, if asked
+	clientOptions := pokeapi.ClientOptions{
+		CacheInterval: time.Hour,
+		CacheTTLs:     pokeapi.DefaultCacheTTLs(),
+		UserAgent:     pokeapi.UserAgent(appVersion()),
+		OnSpecies:     speciesNames.record,
+	}
+	if err := applyNetworkFlags(&clientOptions, *proxy, *caBundle, *insecure); err != nil {
+		i18n.Printf("Error: %s\n", errorhandling.FormatUserMessage(err))
+		os.Exit(1)
+	}
+	if *insecure {
+		i18n.Println("Warning: --insecure is on, so the API's certificate isn't verified. Only use it for development.")
+	}
+
 	// Initialize the configuration with a new Pokemon API client and default settings
 	cfg := config{
-		pokeapiClient:        pokeapi.NewClient(time.Hour),
-		pokedex:              make(map[string]pokeapi.PokemonDataResp),
-		autoSaveEnabled:      true,  // Auto-save is enabled by default
-		autoSaveInterval:     1,     // Save after every change by default
+		pokeapiClient:        pokeapi.NewClientWithOptions(clientOptions),
+		pokedex:              pokedex.New(),
+		settings:             defaultSettings(),
 		changesSinceSync:     0,     // No changes yet
 		mapViewedThisSession: false, // Map hasn't been viewed in this session yet
-		debugMode:            false, // Debug mode is disabled by default
+		input:                bufio.NewReader(os.Stdin),
+		assumeYes:            *assumeYes,
+	}
+
+	// Write a crash report if the program panics outside a command
+	defer reportFatalCrash(&cfg)
+
+	// Serve (or record) API responses from fixtures if requested
+	if *fixturesDir != "" {
+		cfg.pokeapiClient.UseFixtures(*fixturesDir, *record)
+		if *record {
+			i18n.Printf("Recording API responses to %s\n", *fixturesDir)
+		} else {
+			i18n.Printf("Using API fixtures from %s\n", *fixturesDir)
+		}
+	} else if *record {
+		i18n.Println("Warning: --record has no effect without --fixtures")
+	}
+
+	// Keep the Pokédex in another file if asked, for example in a synced folder
+	customSaveFile, err := resolveSaveFilePath(*saveFilePath)
+	if err != nil {
+		i18n.Printf("Error: %s\n", err)
+		os.Exit(1)
+	}
+	if customSaveFile != "" {
+		cfg.saveFilePath = customSaveFile
+		i18n.Printf("Using the save file %s\n", customSaveFile)
+	}
+
+	// Piped input has nobody to answer prompts (including the offer to break a stale
+	// lock on the save file while loading it), so run in batch mode
+	if !stdinIsTerminal() {
+		cfg.batch = &batchResults{}
 	}
 
-	// Try to load saved data
-	err := loadPokedexData(&cfg)
+	// Move the files older versions kept in the home directory, then try to load saved data
+	migrateLegacyFiles()
+	err = loadPokedexData(&cfg)
 	if err != nil {
-		fmt.Printf("Warning: Could not load saved Pokédex data: %v\n", err)
-	} else if len(cfg.pokedex) > 0 {
-		fmt.Printf("Loaded Pokédex with %d Pokémon\n", len(cfg.pokedex))
+		i18n.Printf("Warning: Could not load saved Pokédex data: %v\n", err)
+	}
+	configureOutput(cfg.Settings().accessible)
+
+	// Use the downloaded species dataset, if there is one
+	if err := loadDataset(&cfg); err != nil {
+		i18n.Printf("Warning: Could not load the species dataset, using the built-in one: %v\n", err)
+	}
+	// Keep the search index and species names between sessions, unless they
+	// would come from fixtures
+	if *fixturesDir == "" {
+		cfg.searchIndexPath = getSearchIndexPath()
+		speciesNames.load(getSpeciesNamePath(), cfg.Settings().debugMode)
+	}
+
+	// A command given after the flags is run on its own, without the REPL
+	if flag.NArg() > 0 {
+		configureDebugLogging(cfg.Settings().debugMode)
+		os.Exit(runCommandLine(&cfg, flag.Args()))
+	}
+
+	if size := cfg.pokedex.Len(); err == nil && size > 0 {
+		i18n.Printf("Loaded Pokédex with %d Pokémon\n", size)
+	}
+	printSeparator()
+
+	// After an upgrade, show what's new in this release once
+	if cfg.batch == nil && *fixturesDir == "" {
+		notifyReleaseNotes()
+	}
+
+	// Let interactive users know about newer releases (at most one check a day)
+	if !updateCheckDisabled(&cfg, *noUpdateCheck, *fixturesDir != "") {
+		notifyUpdate()
 	}
-	fmt.Println("-----")
 
 	// Start the REPL (Read-Eval-Print Loop) with our config
-	startREPL(&cfg)
+	os.Exit(startREPL(&cfg))
 }
@@ -3,7 +3,12 @@
 package main
 
 import (
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
 // TestCleanInput verifies that the cleanInput function correctly processes user input.
@@ -47,3 +52,107 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+// TestExecuteCommandRecoversFromPanic verifies that a panicking command is
+// converted into an internal error instead of crashing the REPL.
+func TestExecuteCommandRecoversFromPanic(t *testing.T) {
+	useTempHome(t)
+
+	cfg := &config{pokedex: pokedex.New()}
+	command := cliCommand{
+		name: "crash",
+		callback: func(*config, []string) error {
+			var pokedex map[string]int
+			pokedex["pikachu"] = 1 // Writing to a nil map panics
+			return nil
+		},
+	}
+
+	err := executeCommand(cfg, command, nil)
+	if err == nil {
+		t.Fatal("Expected an error from a panicking command")
+	}
+	if code := errorhandling.ErrorCode(err); code != errorhandling.CodeInternal {
+		t.Errorf("Expected internal error code, got %q (%v)", code, err)
+	}
+}
+
+// TestSplitChainedCommands verifies that a line is split into the commands
+// separated by ";" and "&&".
+func TestSplitChainedCommands(t *testing.T) {
+	chain := splitChainedCommands("map; explore 3 && catch pidgey;; ")
+	expected := []chainedCommand{
+		{text: "map"},
+		{text: "explore 3"},
+		{text: "catch pidgey", afterSuccess: true},
+	}
+	if !slices.Equal(chain, expected) {
+		t.Errorf("splitChainedCommands() = %+v, expected %+v", chain, expected)
+	}
+	if chain := splitChainedCommands("help"); len(chain) != 1 || chain[0].text != "help" {
+		t.Errorf("Expected a single command, got %+v", chain)
+	}
+	if chain := splitChainedCommands(`macro define hunt "map; explore $1 && encounter"; help`); len(chain) != 2 || chain[0].text != `macro define hunt "map; explore $1 && encounter"` {
+		t.Errorf("Expected separators in quotes to be kept, got %+v", chain)
+	}
+	if last := lastChainedCommand("map && catch pi\t"); last != " catch pi\t" {
+		t.Errorf("lastChainedCommand() = %q", last)
+	}
+}
+
+// TestChainedFreeText verifies that free text in double quotes, such as a
+// note, reaches its command whole and without the quotes, and that tab
+// completion doesn't treat separators in quotes as the start of a command.
+func TestChainedFreeText(t *testing.T) {
+	var notes []string
+	commands := map[string]cliCommand{
+		"note": {name: "note", freeText: true, callback: func(cfg *config, params []string) error {
+			notes = append(notes, unquoteText(strings.Join(params[1:], " ")))
+			return nil
+		}},
+	}
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+
+	failed := runREPLLine(cfg, commands, `note pikachu "Likes berries; hates && rain" && note eevee Calm`, 1)
+	if !slices.Equal(notes, []string{"Likes berries; hates && rain", "Calm"}) || failed != 0 {
+		t.Errorf("Expected two notes with their separators kept, got %q and %d failed", notes, failed)
+	}
+
+	for input, expected := range map[string]string{
+		`note pikachu "likes; berr` + "\t":           `note pikachu "likes; berr` + "\t",
+		`note pikachu "a && b"; catch pi` + "\t":     " catch pi\t",
+		`macro define hunt "map; explore $1"` + "\t": `macro define hunt "map; explore $1"` + "\t",
+	} {
+		if last := lastChainedCommand(input); last != expected {
+			t.Errorf("lastChainedCommand(%q) = %q, expected %q", input, last, expected)
+		}
+	}
+	if text := unquoteText(`"a; b"`); text != "a; b" {
+		t.Errorf("unquoteText() = %q", text)
+	}
+	if text := unquoteText(`say "hi"`); text != `say "hi"` {
+		t.Errorf("Expected text that isn't all quoted to be kept, got %q", text)
+	}
+}
+
+// TestRunREPLLineStopsAfterFailure verifies that "&&" skips the commands after
+// a failed one, while ";" runs the next command whatever happened.
+func TestRunREPLLineStopsAfterFailure(t *testing.T) {
+	var ran []string
+	record := func(result error) func(*config, []string) error {
+		return func(cfg *config, params []string) error {
+			ran = append(ran, params...)
+			return result
+		}
+	}
+	commands := map[string]cliCommand{
+		"pass": {name: "pass", callback: record(nil)},
+		"fail": {name: "fail", callback: record(errorhandling.NewInvalidInputError("failed", nil))},
+	}
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+
+	failed := runREPLLine(cfg, commands, "pass 1 && fail 2 && pass 3 && pass 4; pass 5", 1)
+	if !slices.Equal(ran, []string{"1", "2", "5"}) || failed != 3 {
+		t.Errorf("Expected commands 1, 2, and 5 to run with 3 failed or skipped, got %v and %d", ran, failed)
+	}
+}
@@ -0,0 +1,185 @@
+// This file contains utilities for comparing Pokémon by type matchups and stats.
+// It builds a type effectiveness chart from the PokeAPI type data and scores how
+// well one Pokémon fares against another, assuming each attacks with moves of
+// its own types (same-type attack bonus, or STAB).
+package main
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// typeChart holds the damage multipliers of attacking types against defending types.
+// Only the attacking types that were loaded are present; any defending type not
+// listed for an attacking type takes normal (1x) damage.
+type typeChart map[string]map[string]float64
+
+// loadTypeChart fetches the damage relations of the given attacking types.
+// Type responses are cached by the API client, so repeated calls are cheap.
+//
+// Parameters:
+//   - cfg: The application configuration containing the API client
+//   - typeNames: The attacking types to load; duplicates are fetched once
+//
+// Returns:
+//   - A typeChart covering the requested types
+//   - An error if any type can't be retrieved
+func loadTypeChart(cfg *config, typeNames []string) (typeChart, error) {
+	chart := make(typeChart)
+	for _, name := range typeNames {
+		if _, loaded := chart[name]; loaded {
+			continue
+		}
+		typeData, err := cfg.pokeapiClient.GetType(name)
+		if err != nil {
+			return nil, err
+		}
+		chart.add(typeData)
+	}
+	return chart, nil
+}
+
+// add records the multipliers of a type's attacks in the chart.
+func (tc typeChart) add(typeData pokeapi.TypeResp) {
+	multipliers := make(map[string]float64)
+	relations := typeData.DamageRelations
+	for _, t := range relations.DoubleDamageTo {
+		multipliers[t.Name] = 2
+	}
+	for _, t := range relations.HalfDamageTo {
+		multipliers[t.Name] = 0.5
+	}
+	for _, t := range relations.NoDamageTo {
+		multipliers[t.Name] = 0
+	}
+	tc[typeData.Name] = multipliers
+}
+
+// effectiveness returns the damage multiplier of an attacking type against a
+// Pokémon with the given defending types (e.g. 4 for Ground against Electric/Steel).
+//
+// Parameters:
+//   - attacking: The type of the attack
+//   - defending: The types of the defending Pokémon
+//
+// Returns:
+//   - The combined damage multiplier (0, 0.25, 0.5, 1, 2, or 4)
+func (tc typeChart) effectiveness(attacking string, defending []string) float64 {
+	multiplier := 1.0
+	for _, d := range defending {
+		if m, ok := tc[attacking][d]; ok {
+			multiplier *= m
+		}
+	}
+	return multiplier
+}
+
+// bestAttack finds the attacking type that does the most damage to the defending types.
+//
+// Parameters:
+//   - attacking: The types the attacker can use
+//   - defending: The types of the defending Pokémon
+//
+// Returns:
+//   - The most effective attacking type, or an empty string if there are none
+//   - Its damage multiplier
+func (tc typeChart) bestAttack(attacking []string, defending []string) (string, float64) {
+	bestType, best := "", 0.0
+	for _, a := range attacking {
+		if m := tc.effectiveness(a, defending); bestType == "" || m > best {
+			bestType, best = a, m
+		}
+	}
+	return bestType, best
+}
+
+// pokemonTypes returns the names of a Pokémon's types in slot order.
+func pokemonTypes(data pokeapi.PokemonDataResp) []string {
+	types := make([]string, 0, len(data.Types))
+	for _, t := range data.Types {
+		types = append(types, t.Type.Name)
+	}
+	return types
+}
+
+// baseStatTotal returns the sum of a Pokémon's base stats.
+func baseStatTotal(data pokeapi.PokemonDataResp) int {
+	total := 0
+	for _, s := range data.Stats {
+		total += s.BaseStat
+	}
+	return total
+}
+
+// matchup describes how well one Pokémon fares against a target.
+type matchup struct {
+	offenseType string  // The attacker's most effective STAB type
+	offense     float64 // Damage multiplier of the attacker's best STAB against the target
+	defenseType string  // The target's most effective STAB type against the attacker
+	defense     float64 // Damage multiplier of the target's best STAB against the attacker
+	statTotal   int     // The attacker's base stat total
+	targetTotal int     // The target's base stat total
+	score       float64 // Overall rating; higher is better
+}
+
+// evaluateMatchup rates an attacker against a target.
+// The score rewards hitting the target hard and resisting its attacks, and is
+// adjusted by the ratio of base stat totals so that stronger Pokémon win ties.
+//
+// Parameters:
+//   - chart: A type chart covering both Pokémon's types
+//   - attacker: The Pokémon being rated
+//   - target: The Pokémon it would face
+//
+// Returns:
+//   - The matchup details and score
+func evaluateMatchup(chart typeChart, attacker, target pokeapi.PokemonDataResp) matchup {
+	attackerTypes, targetTypes := pokemonTypes(attacker), pokemonTypes(target)
+	m := matchup{
+		statTotal:   baseStatTotal(attacker),
+		targetTotal: baseStatTotal(target),
+	}
+	m.offenseType, m.offense = chart.bestAttack(attackerTypes, targetTypes)
+	m.defenseType, m.defense = chart.bestAttack(targetTypes, attackerTypes)
+
+	// Treat immunity like a strong resistance so the score stays finite
+	m.score = m.offense / max(m.defense, 0.25)
+
+	// Scale by relative strength, limited so types matter more than stats
+	if m.targetTotal > 0 {
+		m.score *= min(max(float64(m.statTotal)/float64(m.targetTotal), 0.5), 1.5)
+	}
+	return m
+}
+
+// reasons explains a matchup in short phrases (e.g. "hits 4x with Ground STAB").
+func (m matchup) reasons() []string {
+	var reasons []string
+
+	switch {
+	case m.offenseType == "":
+		// No type data; nothing to say about offense
+	case m.offense == 0:
+		reasons = append(reasons, "can't damage it with STAB moves")
+	case m.offense > 1:
+		reasons = append(reasons, i18n.Sprintf("hits %gx with %s STAB", m.offense, FormatTypeName(m.offenseType)))
+	case m.offense < 1:
+		reasons = append(reasons, i18n.Sprintf("only hits %gx with its STAB", m.offense))
+	}
+
+	switch {
+	case m.defenseType == "":
+		// No type data; nothing to say about defense
+	case m.defense == 0:
+		reasons = append(reasons, i18n.Sprintf("immune to its %s STAB", FormatTypeName(m.defenseType)))
+	case m.defense < 1:
+		reasons = append(reasons, i18n.T("resists its STAB"))
+	case m.defense > 1:
+		reasons = append(reasons, i18n.Sprintf("weak to its %s STAB (%gx)", FormatTypeName(m.defenseType), m.defense))
+	}
+
+	if m.statTotal > 0 && m.targetTotal > 0 {
+		reasons = append(reasons, i18n.Sprintf("base stats %d vs %d", m.statTotal, m.targetTotal))
+	}
+	return reasons
+}
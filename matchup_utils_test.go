@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// testTypeChart builds a small type chart covering ground, electric, water, and steel
+func testTypeChart() typeChart {
+	named := func(names ...string) []pokeapi.NamedAPIResource {
+		resources := make([]pokeapi.NamedAPIResource, len(names))
+		for i, name := range names {
+			resources[i] = pokeapi.NamedAPIResource{Name: name}
+		}
+		return resources
+	}
+
+	chart := make(typeChart)
+	chart.add(pokeapi.TypeResp{Name: "ground", DamageRelations: pokeapi.DamageRelations{
+		DoubleDamageTo: named("electric", "steel"),
+	}})
+	chart.add(pokeapi.TypeResp{Name: "electric", DamageRelations: pokeapi.DamageRelations{
+		DoubleDamageTo: named("water"),
+		HalfDamageTo:   named("electric"),
+		NoDamageTo:     named("ground"),
+	}})
+	chart.add(pokeapi.TypeResp{Name: "water", DamageRelations: pokeapi.DamageRelations{
+		DoubleDamageTo: named("ground"),
+		HalfDamageTo:   named("water"),
+	}})
+	chart.add(pokeapi.TypeResp{Name: "steel", DamageRelations: pokeapi.DamageRelations{
+		HalfDamageTo: named("electric", "water", "steel"),
+	}})
+	return chart
+}
+
+// testMatchupPokemon creates Pokémon data with the given types and base stat total
+func testMatchupPokemon(t *testing.T, name string, statTotal int, types ...string) pokeapi.PokemonDataResp {
+	t.Helper()
+	typeJSON := make([]string, len(types))
+	for i, typ := range types {
+		typeJSON[i] = `{"slot": 1, "type": {"name": "` + typ + `"}}`
+	}
+	data := `{"name": "` + name + `", "stats": [{"base_stat": ` + strconv.Itoa(statTotal) +
+		`}], "types": [` + strings.Join(typeJSON, ",") + `]}`
+
+	var pokemon pokeapi.PokemonDataResp
+	if err := json.Unmarshal([]byte(data), &pokemon); err != nil {
+		t.Fatalf("Failed to build test Pokémon: %v", err)
+	}
+	return pokemon
+}
+
+// TestTypeEffectiveness tests that multipliers combine across dual types
+func TestTypeEffectiveness(t *testing.T) {
+	chart := testTypeChart()
+
+	cases := []struct {
+		attacking string
+		defending []string
+		expected  float64
+	}{
+		{"ground", []string{"electric", "steel"}, 4},
+		{"electric", []string{"water"}, 2},
+		{"electric", []string{"ground"}, 0},
+		{"steel", []string{"water", "steel"}, 0.25},
+		{"steel", []string{"ground"}, 1},
+		{"fire", []string{"ground"}, 1}, // Unloaded types deal normal damage
+	}
+	for _, c := range cases {
+		if got := chart.effectiveness(c.attacking, c.defending); got != c.expected {
+			t.Errorf("effectiveness(%s -> %v) = %g, want %g", c.attacking, c.defending, got, c.expected)
+		}
+	}
+}
+
+// TestEvaluateMatchup tests that strong type matchups outrank weak ones
+// and that the reasons describe the matchup
+func TestEvaluateMatchup(t *testing.T) {
+	chart := testTypeChart()
+	target := testMatchupPokemon(t, "magnezone", 535, "electric", "steel")
+	dugtrio := testMatchupPokemon(t, "dugtrio", 425, "ground")
+	vaporeon := testMatchupPokemon(t, "vaporeon", 525, "water")
+
+	good := evaluateMatchup(chart, dugtrio, target)
+	bad := evaluateMatchup(chart, vaporeon, target)
+	if good.score <= bad.score {
+		t.Errorf("Expected Dugtrio (%g) to outrank Vaporeon (%g)", good.score, bad.score)
+	}
+
+	reasons := strings.Join(good.reasons(), ", ")
+	if reasons != "hits 4x with Ground STAB, base stats 425 vs 535" {
+		t.Errorf("Unexpected reasons for Dugtrio: %q", reasons)
+	}
+
+	reasons = strings.Join(bad.reasons(), ", ")
+	if !strings.Contains(reasons, "weak to its Electric STAB (2x)") {
+		t.Errorf("Expected Vaporeon to be weak to Electric, got %q", reasons)
+	}
+
+	// A single-type Electric target can't touch a Ground type at all
+	pikachu := testMatchupPokemon(t, "pikachu", 320, "electric")
+	reasons = strings.Join(evaluateMatchup(chart, dugtrio, pikachu).reasons(), ", ")
+	if !strings.Contains(reasons, "immune to its Electric STAB") {
+		t.Errorf("Expected Dugtrio to be immune to Electric, got %q", reasons)
+	}
+}
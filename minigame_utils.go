@@ -0,0 +1,141 @@
+// This file contains the rules of the Pokéathlon-style minigames: how a
+// Pokémon's stats shape each game, how a round is scored, and how much
+// happiness a score earns. The command in command_minigame.go handles the
+// timing and input.
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// minigame describes one of the minigames a Pokémon can play.
+type minigame struct {
+	id   string // Identifier typed by the user and stored with the scores (e.g. "reaction")
+	name string // Display name
+	stat string // The base stat that helps the Pokémon in this game, in API format
+}
+
+// Minigame identifiers
+const (
+	minigameReaction = "reaction"
+	minigameMemory   = "memory"
+)
+
+// minigames lists every minigame, in the order they are shown.
+var minigames = []minigame{
+	{id: minigameReaction, name: "Reaction Dash", stat: "speed"},
+	{id: minigameMemory, name: "Memory Match", stat: "special-attack"},
+}
+
+// Scoring limits shared by the minigames
+const (
+	maxMinigameScore    = 100                    // The best possible score in a game
+	falseStartThreshold = 100 * time.Millisecond // Presses faster than this started before the signal
+	maxHappiness        = 255                    // The highest happiness a Pokémon can have, as in the games
+)
+
+// findMinigame returns the minigame with the given identifier.
+func findMinigame(id string) (minigame, bool) {
+	for _, game := range minigames {
+		if game.id == id {
+			return game, true
+		}
+	}
+	return minigame{}, false
+}
+
+// reactionWindow returns how long a Pokémon has to react before a round of
+// Reaction Dash scores nothing. Faster Pokémon are given more time.
+//
+// Parameters:
+//   - speed: The Pokémon's base Speed
+func reactionWindow(speed int) time.Duration {
+	return time.Second + time.Duration(speed)*5*time.Millisecond
+}
+
+// reactionScore scores a round of Reaction Dash. Pressing Enter before the
+// signal is a false start and scores nothing; otherwise the score falls from
+// the maximum toward zero as the reaction time approaches the Pokémon's window.
+//
+// Parameters:
+//   - reaction: The time between the signal and the key press
+//   - speed: The Pokémon's base Speed
+//
+// Returns:
+//   - The score, from 0 to maxMinigameScore
+func reactionScore(reaction time.Duration, speed int) int {
+	window := reactionWindow(speed)
+	if reaction < falseStartThreshold || reaction >= window {
+		return 0
+	}
+	return int(int64(maxMinigameScore) * int64(window-reaction) / int64(window-falseStartThreshold))
+}
+
+// memorySequenceLength returns how many digits a Pokémon must remember in
+// Memory Match. Pokémon with a higher Special Attack get shorter sequences.
+//
+// Parameters:
+//   - specialAttack: The Pokémon's base Special Attack
+func memorySequenceLength(specialAttack int) int {
+	return max(4, 9-specialAttack/40)
+}
+
+// newMemorySequence returns a random sequence of digits to remember.
+func newMemorySequence(rng *rand.Rand, length int) string {
+	var sb strings.Builder
+	for range length {
+		sb.WriteByte(byte('0' + rng.Intn(10)))
+	}
+	return sb.String()
+}
+
+// memoryScore scores a round of Memory Match by the share of digits recalled
+// in the right position. Spaces in the answer are ignored.
+//
+// Parameters:
+//   - sequence: The digits that were shown
+//   - answer: The digits the user typed
+//
+// Returns:
+//   - The score, from 0 to maxMinigameScore
+func memoryScore(sequence, answer string) int {
+	answer = strings.Join(strings.Fields(answer), "")
+	correct := 0
+	for i := 0; i < len(sequence) && i < len(answer); i++ {
+		if sequence[i] == answer[i] {
+			correct++
+		}
+	}
+	return maxMinigameScore * correct / len(sequence)
+}
+
+// happinessEarned returns the happiness a Pokémon earns for a minigame score:
+// one point for every 20 points scored.
+func happinessEarned(score int) int {
+	return score / 20
+}
+
+// formatMinigameScores lists a Pokémon's best minigame scores
+// (e.g. "Reaction Dash 85, Memory Match 60"), or returns an empty string if
+// it hasn't played any.
+func formatMinigameScores(entry pokedex.Entry) string {
+	var parts []string
+	for _, game := range minigames {
+		if best, played := entry.MinigameScores[game.id]; played {
+			parts = append(parts, fmt.Sprintf("%s %d", i18n.T(game.name), best))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// minigameStat returns the value of the stat that helps a Pokémon in a minigame.
+func minigameStat(game minigame, data pokeapi.PokemonDataResp) int {
+	return baseStat(data, game.stat)
+}
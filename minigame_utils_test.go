@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestReactionScore tests that false starts and slow reactions score nothing,
+// and that faster Pokémon are given more time to react
+func TestReactionScore(t *testing.T) {
+	cases := []struct {
+		reaction time.Duration
+		speed    int
+		expected int
+	}{
+		{reaction: 50 * time.Millisecond, speed: 100, expected: 0},
+		{reaction: 100 * time.Millisecond, speed: 0, expected: 100},
+		{reaction: 550 * time.Millisecond, speed: 0, expected: 50},
+		{reaction: 1200 * time.Millisecond, speed: 0, expected: 0},
+		{reaction: 1200 * time.Millisecond, speed: 100, expected: 21},
+	}
+
+	for _, c := range cases {
+		if got := reactionScore(c.reaction, c.speed); got != c.expected {
+			t.Errorf("reactionScore(%v, %d) = %d, expected %d", c.reaction, c.speed, got, c.expected)
+		}
+	}
+}
+
+// TestMemoryScore tests that digits count only in the right position and spaces are ignored
+func TestMemoryScore(t *testing.T) {
+	cases := []struct {
+		sequence, answer string
+		expected         int
+	}{
+		{sequence: "12345", answer: "12345", expected: 100},
+		{sequence: "12345", answer: "1 2 3 4 5", expected: 100},
+		{sequence: "12345", answer: "12354", expected: 60},
+		{sequence: "12345", answer: "", expected: 0},
+		{sequence: "1234", answer: "123456", expected: 100},
+	}
+
+	for _, c := range cases {
+		if got := memoryScore(c.sequence, c.answer); got != c.expected {
+			t.Errorf("memoryScore(%q, %q) = %d, expected %d", c.sequence, c.answer, got, c.expected)
+		}
+	}
+	if length := memorySequenceLength(200); length != 4 {
+		t.Errorf("Expected the shortest sequence for a high Special Attack, got %d", length)
+	}
+}
+
+// TestRecordMinigameScore tests that only a Pokémon's best score in each game is kept
+func TestRecordMinigameScore(t *testing.T) {
+	var entry pokedex.Entry
+	if !entry.RecordMinigameScore(minigameReaction, 0) {
+		t.Error("Expected the first score to be a new best, even if it's zero")
+	}
+	entry.RecordMinigameScore(minigameReaction, 70)
+	if entry.RecordMinigameScore(minigameReaction, 60) {
+		t.Error("Expected a lower score not to replace the best")
+	}
+	if got := formatMinigameScores(entry); got != "Reaction Dash 70" {
+		t.Errorf("Expected the best score to be listed, got %q", got)
+	}
+}
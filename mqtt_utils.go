@@ -0,0 +1,85 @@
+// This file publishes events to an MQTT broker, so that home automation can
+// react to what happens in the app (for example, flashing the lights when a
+// rare Pokémon is caught). Publishing is off until a broker is set with the
+// mqtt command, and the broker and topic are saved with the other settings.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/mqtt"
+)
+
+// Events published to MQTT
+const (
+	mqttEventCaught  = "caught"        // A Pokémon was caught
+	mqttEventEvolved = "evolved"       // A Pokémon evolved
+	mqttEventRibbon  = "ribbon_earned" // A Pokémon earned a ribbon
+	mqttEventTest    = "test"          // Sent by 'mqtt test' to check the setup
+)
+
+// defaultMQTTTopic is the topic events are published to unless another is chosen.
+const defaultMQTTTopic = "pokedexcli/events"
+
+// mqttClientID identifies the app to the broker.
+const mqttClientID = "pokedexcli"
+
+// mqttPublishTimeout is how long publishing an event may take before it's
+// given up on, so that an unreachable broker doesn't hold up commands for long.
+const mqttPublishTimeout = 3 * time.Second
+
+// mqttEvent is the JSON message published for an event.
+type mqttEvent struct {
+	Event    string    `json:"event"`              // What happened: one of the mqttEvent constants
+	Pokemon  string    `json:"pokemon,omitempty"`  // The Pokémon involved, in API format
+	Evolved  string    `json:"evolved,omitempty"`  // What the Pokémon evolved into, for evolutions
+	Ribbon   string    `json:"ribbon,omitempty"`   // The ribbon's identifier, for ribbons earned
+	Location string    `json:"location,omitempty"` // Where the Pokémon was caught, if known
+	Rare     bool      `json:"rare,omitempty"`     // Whether a caught Pokémon is rare
+	Time     time.Time `json:"time"`               // When it happened
+}
+
+// publishEvent publishes an event to the MQTT broker, if one is set. A broker
+// that can't be reached is reported as a warning, since the event itself
+// already happened. Nothing is published while changes are only previewed, or
+// while the sandbox is on, since they'll be thrown away.
+//
+// Parameters:
+//   - cfg: The application configuration containing the MQTT settings
+//   - event: The event to publish; its time is set to now
+func publishEvent(cfg *config, event mqttEvent) {
+	current := cfg.Settings()
+	if current.mqttBroker == "" || cfg.dryRun || cfg.sandbox != nil {
+		return
+	}
+	event.Time = time.Now()
+	if err := publishMQTT(current, event); err != nil {
+		i18n.Printf("Warning: Could not publish the '%s' event to MQTT: %v\n", event.Event, err)
+	}
+}
+
+// publishMQTT sends an event to the broker and topic in the settings.
+func publishMQTT(current settings, event mqttEvent) error {
+	broker, err := mqtt.ParseBroker(current.mqttBroker)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), mqttPublishTimeout)
+	defer cancel()
+	return broker.Publish(ctx, mqttClientID, mqttTopic(current), payload)
+}
+
+// mqttTopic returns the topic events are published to.
+func mqttTopic(current settings) string {
+	if current.mqttTopic == "" {
+		return defaultMQTTTopic
+	}
+	return current.mqttTopic
+}
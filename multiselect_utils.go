@@ -0,0 +1,359 @@
+// This file implements the multi-select picker used by bulk operations, such
+// as 'release --select', 'export --select', and 'box move --select', so that
+// the user can choose several Pokémon without typing their names. The picker
+// lists the choices; the arrow keys (or j and k) move through them, space
+// chooses one, a chooses all of them, and Enter confirms, while q, Esc, or
+// Ctrl+C cancels. Where the terminal can't be put into raw mode (see
+// terminal_raw.go), and in accessible mode, where redrawing the list would
+// confuse screen readers, the choices are numbered and typed in instead.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// selectFlag is the flag that has a bulk operation open the picker.
+const selectFlag = "--select"
+
+// pickerVisibleRows is how many choices the picker shows at once. Longer
+// lists scroll as the cursor moves.
+const pickerVisibleRows = 10
+
+// pickerKey is a key pressed in the picker.
+type pickerKey int
+
+const (
+	keyOther     pickerKey = iota // A key the picker doesn't use
+	keyUp                         // Up arrow or k: move to the choice above
+	keyDown                       // Down arrow or j: move to the choice below
+	keyToggle                     // Space: choose the choice under the cursor, or unchoose it
+	keyToggleAll                  // a: choose every choice, or none if they all are
+	keyConfirm                    // Enter: finish with the choices made
+	keyCancel                     // q, Esc, Ctrl+C, or Ctrl+D: finish without choosing anything
+)
+
+// parsePickerKeys turns what the terminal sent into keys. One read can hold
+// several keys, such as when an arrow key is held down.
+func parsePickerKeys(data []byte) []pickerKey {
+	var keys []pickerKey
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case 0x1b: // Esc, or the start of an escape sequence such as an arrow key
+			if i+1 == len(data) || (data[i+1] != '[' && data[i+1] != 'O') {
+				keys = append(keys, keyCancel)
+				continue
+			}
+			// Skip the parameters, up to the sequence's final byte
+			i += 2
+			for i < len(data) && data[i] >= 0x30 && data[i] <= 0x3f {
+				i++
+			}
+			switch {
+			case i < len(data) && data[i] == 'A':
+				keys = append(keys, keyUp)
+			case i < len(data) && data[i] == 'B':
+				keys = append(keys, keyDown)
+			default:
+				keys = append(keys, keyOther)
+			}
+		case 'k':
+			keys = append(keys, keyUp)
+		case 'j':
+			keys = append(keys, keyDown)
+		case ' ':
+			keys = append(keys, keyToggle)
+		case 'a':
+			keys = append(keys, keyToggleAll)
+		case '\r', '\n':
+			keys = append(keys, keyConfirm)
+		case 'q', 0x03, 0x04: // q, Ctrl+C, Ctrl+D
+			keys = append(keys, keyCancel)
+		default:
+			keys = append(keys, keyOther)
+		}
+	}
+	return keys
+}
+
+// multiSelect is the state of the picker.
+type multiSelect struct {
+	options  []string // The choices, for display
+	selected []bool   // Whether each choice is chosen
+	cursor   int      // The index of the choice under the cursor
+	top      int      // The index of the first choice shown
+}
+
+// newMultiSelect creates a picker with nothing chosen.
+func newMultiSelect(options []string) *multiSelect {
+	return &multiSelect{options: options, selected: make([]bool, len(options))}
+}
+
+// press handles a key. Moving past either end of the list wraps around to
+// the other.
+//
+// Returns:
+//   - Whether the picker is finished
+//   - Whether it finished with the choices confirmed, rather than cancelled
+func (m *multiSelect) press(key pickerKey) (bool, bool) {
+	if len(m.options) == 0 {
+		return true, key == keyConfirm
+	}
+	switch key {
+	case keyUp:
+		m.cursor = (m.cursor + len(m.options) - 1) % len(m.options)
+	case keyDown:
+		m.cursor = (m.cursor + 1) % len(m.options)
+	case keyToggle:
+		m.selected[m.cursor] = !m.selected[m.cursor]
+	case keyToggleAll:
+		all := !slices.Contains(m.selected, false)
+		for i := range m.selected {
+			m.selected[i] = !all
+		}
+	case keyConfirm:
+		return true, true
+	case keyCancel:
+		return true, false
+	}
+	// Scroll to keep the cursor in view
+	m.top = min(m.top, m.cursor)
+	m.top = max(m.top, m.cursor-pickerVisibleRows+1)
+	return false, false
+}
+
+// chosen returns the indexes of the chosen choices, in the order listed.
+func (m *multiSelect) chosen() []int {
+	var chosen []int
+	for i, selected := range m.selected {
+		if selected {
+			chosen = append(chosen, i)
+		}
+	}
+	return chosen
+}
+
+// lines returns the lines the picker shows: the choices in view, and how many
+// are chosen. There are always as many lines, so each redraw covers the last.
+func (m *multiSelect) lines() []string {
+	rows := min(len(m.options), pickerVisibleRows)
+	lines := make([]string, 0, rows+1)
+	for i := m.top; i < m.top+rows; i++ {
+		cursor, box := "  ", "[ ]"
+		if i == m.cursor {
+			cursor = "> "
+		}
+		if m.selected[i] {
+			box = "[x]"
+		}
+		lines = append(lines, cursor+box+" "+m.options[i])
+	}
+	status := i18n.Sprintf("%d of %d chosen", len(m.chosen()), len(m.options))
+	if rows < len(m.options) {
+		status += " " + i18n.Sprintf("(showing %d-%d)", m.top+1, m.top+rows)
+	}
+	return append(lines, status)
+}
+
+// draw shows the picker, over the last drawing of it if redraw is set.
+func (m *multiSelect) draw(w io.Writer, redraw bool) {
+	lines := m.lines()
+	if redraw {
+		fmt.Fprintf(w, "\x1b[%dA", len(lines))
+	}
+	for _, line := range lines {
+		fmt.Fprintf(w, "\r\x1b[2K%s\n", line)
+	}
+}
+
+// selectMany asks the user to choose any number of options, with the picker if
+// the terminal allows, and otherwise by typing their numbers.
+//
+// Parameters:
+//   - cfg: The application configuration containing the input reader and settings
+//   - title: What to choose, shown above the options
+//   - options: The options, for display
+//
+// Returns:
+//   - The indexes of the options chosen, in the order listed, or none if the user cancelled
+//   - An error in batch mode, where there's nobody to choose, or if the typed numbers are invalid
+func selectMany(cfg *config, title string, options []string) ([]int, error) {
+	if cfg.batch != nil {
+		return nil, errorhandling.NewInvalidInputError(
+			"--select needs someone at the keyboard to choose, so it can't be used in batch mode", nil)
+	}
+	fmt.Println(title)
+	if !cfg.Settings().accessible {
+		if restore, err := makeRaw(int(os.Stdin.Fd())); err == nil {
+			defer restore()
+			return pickWithKeys(cfg, options), nil
+		}
+	}
+	return pickByNumbers(cfg, options)
+}
+
+// pickWithKeys runs the picker, with the terminal in raw mode.
+//
+// Returns:
+//   - The indexes of the options chosen, or none if the user cancelled or the input ended
+func pickWithKeys(cfg *config, options []string) []int {
+	if cfg.events != nil {
+		// Keys pressed before the picker opened aren't meant for it
+		for len(cfg.events.keys) > 0 {
+			<-cfg.events.keys
+		}
+		cfg.events.keyMode.Store(true)
+		defer cfg.events.keyMode.Store(false)
+	}
+
+	i18n.Println("Move with the arrow keys, choose with space (a for all), and press Enter when done, or q to cancel.")
+	fmt.Print("\x1b[?25l") // Hide the cursor while the picker is drawn
+	defer fmt.Print("\x1b[?25h")
+
+	picker := newMultiSelect(options)
+	picker.draw(os.Stdout, false)
+	for {
+		data, err := readKeys(cfg)
+		if err != nil {
+			return nil
+		}
+		for _, key := range parsePickerKeys(data) {
+			if done, confirmed := picker.press(key); done {
+				picker.draw(os.Stdout, true)
+				if !confirmed {
+					return nil
+				}
+				return picker.chosen()
+			}
+		}
+		picker.draw(os.Stdout, true)
+	}
+}
+
+// readKeys waits for the next keys pressed, through the event loop if it's
+// running. A line the event loop read before the picker opened is taken as
+// keys, so that it isn't left to hold up the keys after it.
+func readKeys(cfg *config) ([]byte, error) {
+	if cfg.events == nil {
+		data := make([]byte, 64)
+		n, err := inputReader(cfg).Read(data)
+		if n == 0 {
+			return nil, err
+		}
+		return data[:n], nil
+	}
+	select {
+	case data := <-cfg.events.keys:
+		return data, nil
+	case line, ok := <-cfg.events.lines:
+		if !ok || line.text == "" {
+			return nil, io.EOF
+		}
+		return []byte(line.text), nil
+	}
+}
+
+// pickByNumbers lists the options numbered, and reads the numbers of the ones
+// chosen, such as "1,3-5".
+//
+// Returns:
+//   - The indexes of the options chosen, or none if nothing was typed
+//   - An error if the numbers are invalid
+func pickByNumbers(cfg *config, options []string) ([]int, error) {
+	for i, option := range options {
+		fmt.Printf("%d. %s\n", i+1, option)
+	}
+	i18n.Printf("Type the numbers to choose, such as 1,3-5 or all (or nothing to cancel): ")
+	line, err := readLine(cfg)
+	if err != nil && line == "" {
+		fmt.Println()
+		return nil, nil
+	}
+	return parseNumberList(line, len(options))
+}
+
+// parseNumberList parses the numbers of the options chosen, such as "1,3-5",
+// or "all". Numbers can be separated by commas or spaces.
+//
+// Parameters:
+//   - text: The numbers as typed
+//   - count: The number of options
+//
+// Returns:
+//   - The indexes of the options chosen, in the order listed, without repeats
+//   - An error if a number is out of range or isn't a number
+func parseNumberList(text string, count int) ([]int, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "all" || text == i18n.T("all") {
+		indexes := make([]int, count)
+		for i := range indexes {
+			indexes[i] = i
+		}
+		return indexes, nil
+	}
+
+	var indexes []int
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ' ' }) {
+		first, last, isRange := strings.Cut(part, "-")
+		from, err := strconv.Atoi(first)
+		to := from
+		if err == nil && isRange {
+			to, err = strconv.Atoi(last)
+		}
+		if err != nil || from < 1 || to > count || from > to {
+			return nil, errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Invalid choice '%s': use numbers from 1 to %d, such as 1,3-5", part, count), nil)
+		}
+		for n := from; n <= to; n++ {
+			indexes = append(indexes, n-1)
+		}
+	}
+	slices.Sort(indexes)
+	return slices.Compact(indexes), nil
+}
+
+// pickPokemon asks the user to choose any number of Pokémon.
+//
+// Parameters:
+//   - cfg: The application configuration containing the input reader and settings
+//   - title: What the Pokémon are chosen for, shown above them
+//   - entries: The Pokémon to choose from
+//
+// Returns:
+//   - The Pokémon chosen, or none if the user cancelled
+//   - An error if there are no Pokémon to choose from, or as for selectMany
+func pickPokemon(cfg *config, title string, entries []pokedex.NamedEntry) ([]pokedex.NamedEntry, error) {
+	if len(entries) == 0 {
+		return nil, errorhandling.NewInvalidInputError("There are no Pokémon to choose from", nil)
+	}
+	options := make([]string, len(entries))
+	for i, named := range entries {
+		options[i] = pokemonChoice(named)
+	}
+	chosen, err := selectMany(cfg, title, options)
+	if err != nil {
+		return nil, err
+	}
+	picked := make([]pokedex.NamedEntry, len(chosen))
+	for i, index := range chosen {
+		picked[i] = entries[index]
+	}
+	return picked, nil
+}
+
+// pokemonChoice describes a Pokémon in the picker: its name, level, and box.
+func pokemonChoice(named pokedex.NamedEntry) string {
+	choice := i18n.Sprintf("%s, level %d", FormatPokemonName(named.Name), named.Entry.CurrentLevel())
+	if named.Entry.Box != "" {
+		choice += " " + i18n.Sprintf("(box '%s')", named.Entry.Box)
+	}
+	return choice
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestParsePickerKeys tests reading keys, including several in one read and escape sequences
+func TestParsePickerKeys(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []pickerKey
+	}{
+		{"\x1b[A\x1b[A\x1b[B", []pickerKey{keyUp, keyUp, keyDown}},
+		{"\x1bOB", []pickerKey{keyDown}},
+		{"jk a\r", []pickerKey{keyDown, keyUp, keyToggle, keyToggleAll, keyConfirm}},
+		{"\x1b", []pickerKey{keyCancel}},
+		{"\x03", []pickerKey{keyCancel}},
+		{"\x1b[5~x", []pickerKey{keyOther, keyOther}},
+		{"\x1b[", []pickerKey{keyOther}},
+	}
+	for _, c := range cases {
+		if got := parsePickerKeys([]byte(c.input)); !slices.Equal(got, c.want) {
+			t.Errorf("parsePickerKeys(%q) = %v, want %v", c.input, got, c.want)
+		}
+	}
+}
+
+// TestMultiSelect tests moving through, choosing, and scrolling the picker
+func TestMultiSelect(t *testing.T) {
+	options := make([]string, pickerVisibleRows+2)
+	for i := range options {
+		options[i] = string(rune('a' + i))
+	}
+	picker := newMultiSelect(options)
+
+	// Up from the first choice wraps around to the last, scrolling it into view
+	picker.press(keyUp)
+	picker.press(keyToggle)
+	if picker.cursor != len(options)-1 || picker.top != 2 {
+		t.Errorf("Expected the last choice in view under the cursor, got cursor %d, top %d", picker.cursor, picker.top)
+	}
+	lines := picker.lines()
+	if len(lines) != pickerVisibleRows+1 || lines[pickerVisibleRows-1] != "> [x] l" || !strings.Contains(lines[pickerVisibleRows], "1 of 12 chosen") {
+		t.Errorf("Unexpected picker lines %q", lines)
+	}
+
+	picker.press(keyDown)
+	picker.press(keyToggle)
+	if picker.top != 0 || !slices.Equal(picker.chosen(), []int{0, 11}) {
+		t.Errorf("Expected the first and last choices, got %v (top %d)", picker.chosen(), picker.top)
+	}
+
+	// Choosing all chooses the rest, and again chooses none
+	picker.press(keyToggleAll)
+	if len(picker.chosen()) != len(options) {
+		t.Errorf("Expected every choice, got %v", picker.chosen())
+	}
+	picker.press(keyToggleAll)
+	if done, confirmed := picker.press(keyConfirm); !done || !confirmed || len(picker.chosen()) != 0 {
+		t.Errorf("Expected nothing to be chosen and the picker confirmed, got %v (%v, %v)", picker.chosen(), done, confirmed)
+	}
+	if done, confirmed := newMultiSelect(options).press(keyCancel); !done || confirmed {
+		t.Error("Expected cancelling to finish the picker unconfirmed")
+	}
+}
+
+// TestParseNumberList tests choosing options by typing their numbers
+func TestParseNumberList(t *testing.T) {
+	cases := []struct {
+		input string
+		want  []int
+	}{
+		{"", nil},
+		{"3", []int{2}},
+		{"1,3-5", []int{0, 2, 3, 4}},
+		{" 5 2, 2-3 ", []int{1, 2, 4}},
+		{"all", []int{0, 1, 2, 3, 4, 5}},
+	}
+	for _, c := range cases {
+		got, err := parseNumberList(c.input, 6)
+		if err != nil || !slices.Equal(got, c.want) {
+			t.Errorf("parseNumberList(%q) = %v, %v, want %v", c.input, got, err, c.want)
+		}
+	}
+	for _, input := range []string{"0", "7", "2-9", "4-2", "pikachu", "1-"} {
+		if _, err := parseNumberList(input, 6); err == nil {
+			t.Errorf("Expected parseNumberList(%q) to fail", input)
+		}
+	}
+}
+
+// TestSelectManyInBatchMode tests that the picker isn't opened when there's nobody to use it
+func TestSelectManyInBatchMode(t *testing.T) {
+	cfg := &config{settings: defaultSettings(), batch: &batchResults{}}
+	if _, err := selectMany(cfg, "Choose:", []string{"pikachu"}); err == nil {
+		t.Error("Expected the picker to be refused in batch mode")
+	}
+}
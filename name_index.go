@@ -0,0 +1,186 @@
+// This file implements the local Pokémon name index for the Pokédex CLI application.
+// The index holds every Pokémon name known to the PokeAPI so that commands can
+// validate names without probing the API, offer "did you mean" suggestions for
+// typos, and complete partially typed names.
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+// maxNameSuggestions is the maximum number of suggestions offered for a misspelled name.
+const maxNameSuggestions = 5
+
+// nameIndex is a sorted, searchable set of Pokémon names in API format.
+// It is built once per session from the full Pokémon list and shared by all commands.
+type nameIndex struct {
+	names []string        // All Pokémon names, sorted alphabetically
+	set   map[string]bool // The same names, for constant-time lookups
+}
+
+// newNameIndex builds a name index from a list of Pokémon names.
+//
+// Parameters:
+//   - names: Pokémon names in API format, in any order
+//
+// Returns:
+//   - A pointer to the populated nameIndex
+func newNameIndex(names []string) *nameIndex {
+	idx := &nameIndex{
+		names: make([]string, 0, len(names)),
+		set:   make(map[string]bool, len(names)),
+	}
+	for _, name := range names {
+		if name == "" || idx.set[name] {
+			continue
+		}
+		idx.set[name] = true
+		idx.names = append(idx.names, name)
+	}
+	sort.Strings(idx.names)
+	return idx
+}
+
+// Contains reports whether the given API-format name is a known Pokémon.
+func (idx *nameIndex) Contains(name string) bool {
+	return idx.set[name]
+}
+
+// WithPrefix returns all names beginning with the given prefix, in alphabetical order.
+//
+// Parameters:
+//   - prefix: The start of a Pokémon name in API format
+//
+// Returns:
+//   - The matching names, or an empty slice if none match
+func (idx *nameIndex) WithPrefix(prefix string) []string {
+	// Binary search for the first name that could match, then scan forward
+	start := sort.SearchStrings(idx.names, prefix)
+	matches := []string{}
+	for _, name := range idx.names[start:] {
+		if !strings.HasPrefix(name, prefix) {
+			break
+		}
+		matches = append(matches, name)
+	}
+	return matches
+}
+
+// Suggest returns the known names most likely intended by a misspelled name.
+// Names that start with the input are preferred, followed by names within a
+// small edit distance of it, closest first.
+//
+// Parameters:
+//   - name: The misspelled name in API format
+//
+// Returns:
+//   - Up to maxNameSuggestions names, best match first
+func (idx *nameIndex) Suggest(name string) []string {
+	if name == "" {
+		return nil
+	}
+
+	suggestions := idx.WithPrefix(name)
+	if len(suggestions) >= maxNameSuggestions {
+		return suggestions[:maxNameSuggestions]
+	}
+
+	// Allow roughly one typo for every three characters typed
+	maxDistance := len(name) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	type candidate struct {
+		name     string
+		distance int
+	}
+	var candidates []candidate
+	for _, known := range idx.names {
+		if strings.HasPrefix(known, name) {
+			continue // Already included as a prefix match
+		}
+		if d := editDistance(name, known); d <= maxDistance {
+			candidates = append(candidates, candidate{known, d})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	for _, c := range candidates {
+		if len(suggestions) >= maxNameSuggestions {
+			break
+		}
+		suggestions = append(suggestions, c.name)
+	}
+	return suggestions
+}
+
+// editDistance calculates the Levenshtein distance between two strings:
+// the number of single-character insertions, deletions, or substitutions
+// required to turn one into the other.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// getNameIndex returns the session's Pokémon name index, building it on first use
+// from the names in the search index (see search_index.go). The search index is
+// saved between sessions, so usually no request is made at all; otherwise it's
+// built from the complete species dataset, or from the full Pokémon list fetched
+// from the API.
+//
+// Parameters:
+//   - cfg: The application configuration holding the API client and index
+//
+// Returns:
+//   - The name index
+//   - An error if the Pokémon list could not be retrieved
+func getNameIndex(cfg *config) (*nameIndex, error) {
+	cfg.mutex.RLock()
+	idx := cfg.nameIndex
+	cfg.mutex.RUnlock()
+	if idx != nil {
+		return idx, nil
+	}
+
+	searchIdx, err := getSearchIndex(cfg)
+	if err != nil {
+		return nil, err
+	}
+	idx = newNameIndex(searchIdx.Names)
+
+	cfg.mutex.Lock()
+	cfg.nameIndex = idx
+	cfg.mutex.Unlock()
+
+	return idx, nil
+}
+
+// formatSuggestions converts suggested API names into display names.
+func formatSuggestions(names []string) []string {
+	formatted := make([]string, len(names))
+	for i, name := range names {
+		formatted[i] = FormatPokemonName(name)
+	}
+	return formatted
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/dataset"
+)
+
+// TestNameIndexLookup verifies membership checks and prefix completion.
+func TestNameIndexLookup(t *testing.T) {
+	idx := newNameIndex([]string{"charizard", "pikachu", "charmander", "charmeleon", "pikachu"})
+
+	if !idx.Contains("pikachu") {
+		t.Error("Expected index to contain pikachu")
+	}
+	if idx.Contains("pika") {
+		t.Error("Did not expect index to contain a partial name")
+	}
+
+	expected := []string{"charizard", "charmander", "charmeleon"}
+	if actual := idx.WithPrefix("char"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("WithPrefix(char) == %v, expected %v", actual, expected)
+	}
+	if actual := idx.WithPrefix("zz"); len(actual) != 0 {
+		t.Errorf("Expected no matches for zz, got %v", actual)
+	}
+}
+
+// TestNameIndexSuggest verifies that misspelled names produce close suggestions.
+func TestNameIndexSuggest(t *testing.T) {
+	idx := newNameIndex([]string{"bulbasaur", "charmander", "pikachu", "squirtle"})
+
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "pikachoo", expected: "pikachu"},
+		{input: "charmandr", expected: "charmander"},
+		{input: "squirt", expected: "squirtle"},
+	}
+
+	for _, tc := range cases {
+		suggestions := idx.Suggest(tc.input)
+		if len(suggestions) == 0 || suggestions[0] != tc.expected {
+			t.Errorf("Suggest(%q) == %v, expected %q first", tc.input, suggestions, tc.expected)
+		}
+	}
+
+	if suggestions := idx.Suggest("xyz123"); len(suggestions) != 0 {
+		t.Errorf("Expected no suggestions for xyz123, got %v", suggestions)
+	}
+}
+
+// TestNameIndexFromDataset verifies that a complete dataset provides the index
+// and validates names without an API client.
+func TestNameIndexFromDataset(t *testing.T) {
+	cfg := &config{dataset: dataset.New([]dataset.Species{
+		{ID: 25, Name: "pikachu", Types: []string{"electric"}},
+		{ID: 26, Name: "raichu", Types: []string{"electric"}},
+	}, true)}
+
+	idx, err := getNameIndex(cfg)
+	if err != nil {
+		t.Fatalf("Expected the index to be built from the dataset, got %v", err)
+	}
+	if !idx.Contains("raichu") {
+		t.Error("Expected index to contain raichu")
+	}
+
+	if err := ValidatePokemonName(cfg, FormatPokemonInput("Pikachu")); err != nil {
+		t.Errorf("Expected pikachu to be valid, got %v", err)
+	}
+	if err := ValidatePokemonName(cfg, FormatPokemonInput("pikachoo")); err == nil {
+		t.Error("Expected an unknown name to be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+// This file turns the network flags into the API client's settings, for users
+// behind a corporate proxy: --proxy to choose the proxy (the HTTP_PROXY,
+// HTTPS_PROXY, and NO_PROXY environment variables are used otherwise),
+// --ca-bundle to trust the proxy's certificate authority, and --insecure to
+// skip certificate checks while developing.
+package main
+
+import (
+	"net/url"
+	"os"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// caBundleEnv is the environment variable that sets the certificate authority
+// bundle, like the --ca-bundle flag.
+const caBundleEnv = "POKEDEXCLI_CA_BUNDLE"
+
+// applyNetworkFlags sets the API client's network settings from the flags.
+// The --ca-bundle flag takes precedence over the POKEDEXCLI_CA_BUNDLE
+// environment variable.
+//
+// Parameters:
+//   - opts: The API client's options to update
+//   - proxy: The value of the --proxy flag, if any
+//   - caBundle: The value of the --ca-bundle flag, if any
+//   - insecure: Whether --insecure was given
+//
+// Returns:
+//   - An error if the proxy isn't a valid URL or the bundle can't be used
+func applyNetworkFlags(opts *pokeapi.ClientOptions, proxy, caBundle string, insecure bool) error {
+	if proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Invalid proxy '%s'. Use a URL such as http://proxy.example.com:3128", proxy), err)
+		}
+		opts.Proxy = proxyURL
+	}
+
+	if caBundle == "" {
+		caBundle = os.Getenv(caBundleEnv)
+	}
+	if caBundle != "" {
+		roots, err := pokeapi.LoadCABundle(caBundle)
+		if err != nil {
+			return errorhandling.NewInvalidInputError(
+				i18n.Sprintf("Could not use the certificate bundle %s: %v", caBundle, err), err)
+		}
+		opts.RootCAs = roots
+	}
+
+	opts.Insecure = insecure
+	return nil
+}
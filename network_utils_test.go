@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestApplyNetworkFlags tests that the proxy and certificate bundle flags are
+// checked and set on the API client's options
+func TestApplyNetworkFlags(t *testing.T) {
+	t.Setenv(caBundleEnv, "")
+	var opts pokeapi.ClientOptions
+	if err := applyNetworkFlags(&opts, "http://proxy.example.com:3128", "", true); err != nil {
+		t.Fatalf("applyNetworkFlags returned an error: %v", err)
+	}
+	if opts.Proxy == nil || opts.Proxy.Host != "proxy.example.com:3128" || !opts.Insecure || opts.RootCAs != nil {
+		t.Errorf("Unexpected options: %+v", opts)
+	}
+
+	for _, proxy := range []string{"proxy.example.com", "http://"} {
+		if err := applyNetworkFlags(&pokeapi.ClientOptions{}, proxy, "", false); err == nil {
+			t.Errorf("Expected proxy %q to be refused", proxy)
+		}
+	}
+
+	// The environment variable is used without the flag
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(bundle, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("Could not write the bundle: %v", err)
+	}
+	t.Setenv(caBundleEnv, bundle)
+	if err := applyNetworkFlags(&pokeapi.ClientOptions{}, "", "", false); err == nil {
+		t.Error("Expected a bundle without certificates to be refused")
+	}
+}
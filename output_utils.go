@@ -0,0 +1,39 @@
+// This file contains the output settings shared by everything that prints.
+// Accessible mode makes the output friendlier to screen readers: tables are
+// read out as labelled lines instead of aligned columns, separators and other
+// line-drawing characters are left out, symbols are replaced by words, and
+// commands that normally pick something at random make the same choice every time.
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// accessibleOutput records whether accessible mode is on. Rendering helpers
+// such as printSeparator and Table read it, so it's set with configureOutput
+// whenever the setting changes instead of being passed to every caller.
+var accessibleOutput atomic.Bool
+
+// configureOutput switches the output renderer into or out of accessible mode.
+//
+// Parameters:
+//   - accessible: Whether accessible mode is enabled
+func configureOutput(accessible bool) {
+	accessibleOutput.Store(accessible)
+}
+
+// isAccessibleOutput reports whether output is being rendered in accessible mode.
+func isAccessibleOutput() bool {
+	return accessibleOutput.Load()
+}
+
+// printSeparator prints the line that ends the output of a command. In
+// accessible mode it's a blank line, so screen readers don't read out a row of dashes.
+func printSeparator() {
+	if isAccessibleOutput() {
+		fmt.Println()
+		return
+	}
+	fmt.Println("-----")
+}
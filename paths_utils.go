@@ -0,0 +1,77 @@
+// This file lists the files the application keeps between sessions, and where
+// each is kept (see internal/paths). Older versions kept them all in the home
+// directory, under names starting with ".pokedexcli_"; migrateLegacyFiles
+// moves them to their new places the first time this version runs.
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/paths"
+)
+
+// appFile is a file or directory the application keeps between sessions.
+type appFile struct {
+	kind   paths.Kind // The kind of directory it's kept in
+	name   string     // Its name in that directory
+	legacy string     // Its name in the home directory, where older versions kept it
+}
+
+// The files and directories the application keeps between sessions
+var (
+	saveFile        = appFile{paths.Data, "save.json", ".pokedexcli_save.json"}
+	snapshotDir     = appFile{paths.Data, "snapshots", ".pokedexcli_snapshots"}
+	backupDir       = appFile{paths.Data, "backup", ".pokedexcli_backup"}
+	datasetFile     = appFile{paths.Cache, "dataset.json", ".pokedexcli_dataset.json"}
+	searchIndexFile = appFile{paths.Cache, "search-index.json", ".pokedexcli_search_index.json"}
+	speciesNameFile = appFile{paths.Cache, "species-names.json", ".pokedexcli_species_names.json"}
+	saveLogFile     = appFile{paths.State, "save.log", ".pokedexcli_save.log"}
+	updateStateFile = appFile{paths.State, "update.json", ".pokedexcli_update.json"}
+	usageFile       = appFile{paths.State, "usage.json", ".pokedexcli_usage.json"}
+	challengesDir   = appFile{paths.Data, "challenges", ".pokedexcli_challenges"}
+	crashReportDir  = appFile{paths.State, "crashes", ".pokedexcli_crashes"}
+)
+
+// appFiles lists every file the application keeps, for migrateLegacyFiles.
+var appFiles = []appFile{saveFile, snapshotDir, backupDir, datasetFile, searchIndexFile, speciesNameFile, saveLogFile, updateStateFile, usageFile, challengesDir, crashReportDir}
+
+// path returns the path of a file. If its directory can't be determined or
+// created, as when there's no home directory, the file is kept in the current
+// directory under its legacy name instead.
+func (f appFile) path() string {
+	dir, err := paths.Dir(f.kind)
+	if err != nil {
+		return f.legacy
+	}
+	return filepath.Join(dir, f.name)
+}
+
+// migrateLegacyFiles moves the files older versions kept in the home directory
+// to their new places. Each is only moved if nothing is at its new place yet,
+// so this does nothing once they've been moved. A file that can't be moved is
+// reported as a warning, and left where it is.
+func migrateLegacyFiles() {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return
+	}
+	for _, file := range appFiles {
+		legacyPath, newPath := filepath.Join(homeDir, file.legacy), file.path()
+		if newPath == file.legacy {
+			continue
+		}
+		moved, err := paths.Migrate(legacyPath, newPath)
+		switch {
+		case err != nil:
+			i18n.Printf("Warning: Could not move %s to %s: %v\n", legacyPath, newPath, err)
+		case moved:
+			i18n.Printf("Moved %s to %s\n", legacyPath, newPath)
+			if file == saveFile {
+				// The save file's lock file is created again next to it when needed
+				os.Remove(legacyPath + ".lock")
+			}
+		}
+	}
+}
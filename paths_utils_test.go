@@ -0,0 +1,60 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useTempHome points the home directory, and the base directories that would
+// override it, at a temporary directory for the rest of the test, so that
+// nothing the test saves touches the user's files
+func useTempHome(t testing.TB) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, variable := range []string{"XDG_DATA_HOME", "XDG_CACHE_HOME", "XDG_STATE_HOME"} {
+		t.Setenv(variable, "")
+	}
+	t.Setenv("APPDATA", filepath.Join(home, "AppData", "Roaming"))
+	t.Setenv("LOCALAPPDATA", filepath.Join(home, "AppData", "Local"))
+	return home
+}
+
+// TestMigrateLegacyFiles tests that the save file and snapshots kept in the
+// home directory by older versions are moved to the data directory
+func TestMigrateLegacyFiles(t *testing.T) {
+	home := useTempHome(t)
+	legacySave := filepath.Join(home, saveFile.legacy)
+	if err := os.WriteFile(legacySave, []byte(`{"pokedex":{}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(home, snapshotDir.legacy), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	migrateLegacyFiles()
+	savePath := saveFile.path()
+	if !strings.HasPrefix(savePath, home) || filepath.Base(savePath) != "save.json" {
+		t.Errorf("Expected the save file in the home directory's data directory, got %s", savePath)
+	}
+	if data, err := os.ReadFile(savePath); err != nil || string(data) != `{"pokedex":{}}` {
+		t.Errorf("Expected the save file to be moved, got %q, %v", data, err)
+	}
+	if _, err := os.Stat(legacySave); !os.IsNotExist(err) {
+		t.Errorf("Expected the legacy save file to be gone, got %v", err)
+	}
+	if info, err := os.Stat(snapshotDir.path()); err != nil || !info.IsDir() {
+		t.Errorf("Expected the snapshots to be moved, got %v", err)
+	}
+
+	// Once moved, a legacy file that turns up again is left alone
+	if err := os.WriteFile(legacySave, []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	migrateLegacyFiles()
+	if data, _ := os.ReadFile(savePath); string(data) != `{"pokedex":{}}` {
+		t.Errorf("Expected the save file not to be replaced, got %q", data)
+	}
+}
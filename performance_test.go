@@ -0,0 +1,43 @@
+//go:build performance
+
+// This file contains the performance budgets: tests that fail if the
+// operations users wait on get far slower than they are today. Each run
+// benchmarks the operations for several seconds, so they are excluded from
+// normal runs. Run them with:
+//
+//	go test -tags performance ./...
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPerformanceBudgets checks formatting names, which every command does;
+// saving and loading a large Pokédex, which happen after every change and at
+// startup; and catching, which batch scripts do thousands of times. Each
+// budget is several times the measured cost, leaving room for slow machines
+// and the race detector, so a failure means a real regression.
+func TestPerformanceBudgets(t *testing.T) {
+	budgets := []struct {
+		name      string
+		benchmark func(*testing.B)
+		budget    time.Duration
+	}{
+		{"FormatPokemonInput", BenchmarkFormatPokemonInput, 50 * time.Microsecond},
+		{"FormatPokemonName", BenchmarkFormatPokemonName, 50 * time.Microsecond},
+		{"SaveLargePokedex", BenchmarkSaveLargePokedex, 500 * time.Millisecond},
+		{"LoadLargePokedex", BenchmarkLoadLargePokedex, time.Second},
+		{"CatchBatch", BenchmarkCatchBatch, 5 * time.Millisecond},
+	}
+	for _, b := range budgets {
+		result := testing.Benchmark(b.benchmark)
+		if result.N == 0 {
+			t.Errorf("%s failed to run", b.name)
+			continue
+		}
+		if perOp := time.Duration(result.NsPerOp()); perOp > b.budget {
+			t.Errorf("%s took %v per operation, over its budget of %v", b.name, perOp, b.budget)
+		}
+	}
+}
@@ -1,69 +1,73 @@
-// This file implements the persistence layer for the Pokédex CLI application.
-// It handles saving and loading the user's Pokédex data to/from disk, including
-// file locking to ensure data integrity and prevent corruption during concurrent access.
+// This file connects the Pokédex CLI application to the persistence layer in
+// internal/pokedex. It decides where the save file lives and which settings are
+// saved along with the Pokédex, and implements the save and reset commands.
 package main
 
 import (
-	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
-	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
-	"github.com/gofrs/flock"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
-// defaultSaveFile is the default location for storing Pokédex data.
-// The file is stored in the user's home directory.
-const defaultSaveFile = ".pokedexcli_save.json"
+// saveFileEnv is the environment variable that sets where the save file is
+// kept, like the --save-file flag.
+const saveFileEnv = "POKEDEXCLI_SAVE"
 
-// lockTimeout is the maximum time to wait for acquiring a file lock
-const lockTimeout = 5 * time.Second
-
-// lockRetryInterval is the interval between lock attempts
-const lockRetryInterval = 100 * time.Millisecond
-
-// SaveData represents the structure of data saved to disk.
-// It includes the Pokédex data and other persistent state.
-type SaveData struct {
-	Pokedex   map[string]pokeapi.PokemonDataResp `json:"pokedex"`   // User's caught Pokémon
-	LastSaved time.Time                          `json:"lastSaved"` // Timestamp of the last save
-}
-
-// getSaveFilePath returns the full path to the save file.
-// It tries to use the user's home directory, falling back to the current directory.
+// getSaveFilePath returns the full path to the save file: the one given with
+// --save-file or POKEDEXCLI_SAVE, or else the one in the data directory (see
+// paths_utils.go).
+//
+// Parameters:
+//   - cfg: The application configuration
 //
 // Returns:
 //   - The full path to the save file
 //   - An error if there was a problem determining the path
-func getSaveFilePath() (string, error) {
-	// Try to get user's home directory
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		// Fall back to current directory if home can't be determined
-		return defaultSaveFile, nil
+func getSaveFilePath(cfg *config) (string, error) {
+	if cfg.saveFilePath != "" {
+		return cfg.saveFilePath, nil
 	}
-	return filepath.Join(homeDir, defaultSaveFile), nil
+	return saveFile.path(), nil
 }
 
-// getLockFilePath returns the path to the lock file based on the save file path.
-// The lock file is used to prevent concurrent access to the save file.
+// resolveSaveFilePath decides which save file to use at startup. The
+// --save-file flag takes precedence over the POKEDEXCLI_SAVE environment
+// variable, and without either the default save file is used. A custom save
+// file doesn't have to exist yet, but its folder does, so that a mistyped
+// path is caught before anything is saved.
 //
 // Parameters:
-//   - saveFilePath: The path to the save file
+//   - flagValue: The value of the --save-file flag, if any
 //
 // Returns:
-//   - The path to the corresponding lock file
-func getLockFilePath(saveFilePath string) string {
-	return saveFilePath + ".lock"
+//   - The absolute path of the custom save file, or "" for the default one
+//   - An error if the custom save file's folder doesn't exist
+func resolveSaveFilePath(flagValue string) (string, error) {
+	path := flagValue
+	if path == "" {
+		path = os.Getenv(saveFileEnv)
+	}
+	if path == "" {
+		return "", nil
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || !info.IsDir() {
+		return "", fmt.Errorf(i18n.T("the folder for the save file %s doesn't exist"), path)
+	}
+	return path, nil
 }
 
-// savePokedexData saves the current Pokédex and navigation state to disk.
-// It uses file locking to ensure data integrity when multiple instances
-// of the application might be running simultaneously.
+// savePokedexData saves the current Pokédex and settings to disk. In batch mode
+// the save is deferred until the batch ends (see commitBatch), so that a batch
+// of commands rewrites the save file once rather than after every change.
 //
 // Parameters:
 //   - cfg: The application configuration containing the Pokédex to save
@@ -71,68 +75,158 @@ func getLockFilePath(saveFilePath string) string {
 // Returns:
 //   - An error if the save operation fails for any reason
 func savePokedexData(cfg *config) error {
-	// Get save file path
-	saveFilePath, err := getSaveFilePath()
-	if err != nil {
-		return fmt.Errorf("error determining save file path: %w", err)
+	// Nothing is written while a command's changes are only being previewed
+	if cfg.dryRun {
+		return nil
+	}
+
+	// In batch mode, the whole batch is saved at once when it ends
+	if cfg.batch != nil {
+		cfg.batch.pendingSave = true
+		return nil
 	}
 
-	// Create a file lock
-	fileLock := flock.New(getLockFilePath(saveFilePath))
+	return writeSaveFile(cfg)
+}
 
-	// Create a context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
-	defer cancel()
+// writeSaveFile writes the current Pokédex and settings to the save file, and
+// backs it up if a backup remote is set. Every write, and every failed write,
+// is recorded in the save log. While the sandbox is on, the state from before
+// it was turned on is written instead, so nothing tried in it is saved.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex to save
+//
+// Returns:
+//   - An error if the save operation fails for any reason
+func writeSaveFile(cfg *config) error {
+	// An auto-save still being written would replace this one if it finished later
+	waitForBackgroundSaves(cfg)
 
-	// Acquire an exclusive lock with a timeout
-	locked, err := fileLock.TryLockContext(ctx, lockRetryInterval)
+	save, err := takeSave(cfg)
 	if err != nil {
-		return fmt.Errorf("error acquiring file lock: %w", err)
+		return err
 	}
-	if !locked {
-		return fmt.Errorf("could not acquire lock on save file: timeout after %v", lockTimeout)
+	if err := writeSave(cfg, save); err != nil {
+		return err
 	}
 
-	// Release the lock when we're done
-	defer fileLock.Unlock()
-
-	// Acquire read lock on the config to get a consistent snapshot
-	cfg.mutex.RLock()
-	saveData := SaveData{
-		Pokedex:   cfg.pokedex,
-		LastSaved: time.Now(),
+	cfg.mutex.Lock()
+	cfg.changesSinceSync = 0
+	cfg.mutex.Unlock()
+	if cfg.sandbox != nil {
+		cfg.sandbox.changesSinceSync = 0
 	}
-	cfg.mutex.RUnlock()
+	return nil
+}
 
-	// Serialize data to JSON
-	data, err := json.Marshal(saveData)
+// pendingSave is the save data taken from the Pokédex and settings at one
+// moment, ready to be written to the save file.
+type pendingSave struct {
+	path    string           // The save file to write
+	data    pokedex.SaveData // The data to write, sharing nothing with the Pokédex
+	trigger string           // What's saving, recorded in the save log
+	changes int              // The changes it saves, counted as unsaved again if it can't be written
+}
+
+// takeSave takes the data to save: the current Pokédex and settings, or
+// while the sandbox is on, the state from before it was turned on.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex to save
+//
+// Returns:
+//   - The save, without its changes counted
+//   - An error if the save file's path can't be determined
+func takeSave(cfg *config) (pendingSave, error) {
+	saveFilePath, err := getSaveFilePath(cfg)
 	if err != nil {
-		return fmt.Errorf("error serializing Pokédex data: %w", err)
+		return pendingSave{}, fmt.Errorf("error determining save file path: %w", err)
 	}
 
-	// Create a temporary file in the same directory
-	tempFilePath := saveFilePath + ".tmp"
-
-	// Write data to the temporary file
-	err = os.WriteFile(tempFilePath, data, 0644)
-	if err != nil {
-		return fmt.Errorf("error writing temporary save file: %w", err)
+	saveData := currentSaveData(cfg)
+	if cfg.sandbox != nil {
+		lastSaved := saveData.LastSaved
+		saveData = cfg.sandbox.before
+		saveData.LastSaved = lastSaved
 	}
+	return pendingSave{path: saveFilePath, data: saveData, trigger: cfg.saveTrigger}, nil
+}
 
-	// Atomically replace the old file with the new one
-	err = os.Rename(tempFilePath, saveFilePath)
+// writeSave writes a save to the save file, records it in the save log, and
+// backs it up. It only reads the configuration through its locks, so it can
+// run on any goroutine.
+//
+// Parameters:
+//   - cfg: The application configuration, for the save log and backups
+//   - save: The save to write
+//
+// Returns:
+//   - An error if the save file can't be written
+func writeSave(cfg *config, save pendingSave) error {
+	err := pokedex.WriteFile(save.path, save.data)
+	record := saveLogRecord{Time: save.data.LastSaved, Trigger: save.trigger, Entries: len(save.data.Pokedex)}
 	if err != nil {
-		// Try to clean up the temporary file if rename fails
-		os.Remove(tempFilePath)
-		return fmt.Errorf("error replacing save file: %w", err)
+		record.Error = err.Error()
+		logSave(cfg, record)
+		if isStaleLock(err) {
+			return fmt.Errorf(i18n.T("%w. Run 'save' to break the stale lock"), err)
+		}
+		return err
 	}
-
+	if info, err := os.Stat(save.path); err == nil {
+		record.Bytes = info.Size()
+	}
+	logSave(cfg, record)
+	backupSaveFile(cfg, save.path)
 	return nil
 }
 
-// loadPokedexData loads the Pokédex data from disk into the application config.
-// It uses file locking to ensure data integrity when multiple instances
-// of the application might be running simultaneously.
+// currentSaveData collects the Pokédex and the settings saved with it.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex to save
+//
+// Returns:
+//   - The save data describing the current state
+func currentSaveData(cfg *config) pokedex.SaveData {
+	saveData := cfg.pokedex.Export()
+	current := cfg.Settings()
+	saveData.Units = current.units
+	saveData.Accessible = current.accessible
+	saveData.Theme = current.theme
+	saveData.PartySize = current.partySize
+	saveData.PageSize = current.pageSize
+	saveData.VersionGroup = current.versionGroup
+	saveData.MQTTBroker = current.mqttBroker
+	saveData.MQTTTopic = current.mqttTopic
+	saveData.BackupRemote = current.backupRemote
+	saveData.BackupEvery = current.backupEvery
+	saveData.Usage = current.usageTracking
+	saveData.UsageEndpoint = current.usageEndpoint
+	saveData.ReportSMTP = current.reportSMTP
+	saveData.ReportFrom = current.reportFrom
+	saveData.ReportTo = current.reportTo
+	saveData.CatchPreset = current.catchPreset
+	saveData.CatchCustom = current.catchCustom
+	saveData.Language = i18n.Current()
+	saveData.Money = cfg.Money()
+	saveData.Items = cfg.Items()
+	saveData.Redeemed = cfg.RedeemedCodes()
+	saveData.TrainerID = cfg.trainerIDIfAssigned()
+	saveData.Challenges = cfg.Challenges()
+	saveData.Reminders = cfg.Reminders()
+	saveData.Macros = cfg.Macros()
+	if lure, ok := cfg.ActiveLure(); ok {
+		saveData.Lure = &lure
+	}
+	saveData.Map = cfg.MapState()
+	saveData.LastSaved = time.Now()
+	return saveData
+}
+
+// loadPokedexData loads the Pokédex data from disk into the application config,
+// and switches the interface to the language it was saved in.
 //
 // Parameters:
 //   - cfg: The application configuration to load the Pokédex data into
@@ -140,60 +234,141 @@ func savePokedexData(cfg *config) error {
 // Returns:
 //   - An error if the load operation fails for any reason
 func loadPokedexData(cfg *config) error {
-	// Get save file path
-	saveFilePath, err := getSaveFilePath()
-	if err != nil {
-		return fmt.Errorf("error determining save file path: %w", err)
-	}
-
-	// Check if the file exists
-	if _, err := os.Stat(saveFilePath); os.IsNotExist(err) {
-		// No save file exists, nothing to load
-		return nil
+	saveData, found, err := readPokedexData(cfg)
+	if err != nil || !found {
+		return err
 	}
+	applySaveData(cfg, saveData)
+	return nil
+}
 
-	// Create a file lock
-	fileLock := flock.New(getLockFilePath(saveFilePath))
-
-	// Create a context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
-	defer cancel()
-
-	// Acquire a shared lock with a timeout
-	locked, err := fileLock.TryRLockContext(ctx, lockRetryInterval)
+// readPokedexData reads the save file of the application config without
+// applying it.
+//
+// Parameters:
+//   - cfg: The application configuration whose save file to read
+//
+// Returns:
+//   - The save data
+//   - Whether there was a save file
+//   - An error if the save file can't be read
+func readPokedexData(cfg *config) (pokedex.SaveData, bool, error) {
+	// Get save file path
+	saveFilePath, err := getSaveFilePath(cfg)
 	if err != nil {
-		return fmt.Errorf("error acquiring file lock for reading: %w", err)
-	}
-	if !locked {
-		return fmt.Errorf("could not acquire read lock on save file: timeout after %v", lockTimeout)
+		return pokedex.SaveData{}, false, fmt.Errorf("error determining save file path: %w", err)
 	}
 
-	// Release the lock when we're done
-	defer fileLock.Unlock()
+	// The save file must be complete before it's read
+	waitForBackgroundSaves(cfg)
 
-	// Read data from file
-	data, err := os.ReadFile(saveFilePath)
-	if err != nil {
-		return fmt.Errorf("error reading save file: %w", err)
+	// The entries are decoded as they're used, so a large Pokédex doesn't hold up startup
+	saveData, found, err := pokedex.ReadFileLazy(saveFilePath)
+	if breakStaleLock(cfg, err) {
+		saveData, found, err = pokedex.ReadFileLazy(saveFilePath)
 	}
+	return saveData, found, err
+}
 
-	// Deserialize JSON data
-	var saveData SaveData
-	err = json.Unmarshal(data, &saveData)
-	if err != nil {
-		return fmt.Errorf("error deserializing Pokédex data: %w", err)
+// applySaveData replaces the Pokédex and settings in the application config
+// with those in the save data, and switches the interface to the language
+// they were saved in.
+//
+// Parameters:
+//   - cfg: The application configuration to update
+//   - saveData: The save data to apply
+func applySaveData(cfg *config, saveData pokedex.SaveData) {
+	applySavedState(cfg, saveData)
+
+	// A language that's no longer supported falls back to the default
+	if err := i18n.SetLanguage(saveData.Language); err != nil {
+		i18n.SetLanguage(i18n.Default)
 	}
+}
 
-	// Update configuration with loaded data - acquire a write lock
+// applySavedState replaces the Pokédex and settings in the application config
+// with those in the save data, leaving the interface language alone. The
+// language is shared by the whole process, so it's only changed for the
+// user's own save (see applySaveData), never for another trainer's.
+//
+// Parameters:
+//   - cfg: The application configuration to update
+//   - saveData: The save data to apply
+func applySavedState(cfg *config, saveData pokedex.SaveData) {
+	// Update configuration with loaded data
+	cfg.pokedex.ResetFrom(saveData)
+	cfg.pokedex.RestoreSeen(saveData.Seen)
+	cfg.pokedex.RestoreVisits(saveData.Visits)
 	cfg.mutex.Lock()
-	cfg.pokedex = saveData.Pokedex
-	// Don't load map navigation URLs - user must run 'map' command first
-	cfg.nextLocationURL = nil
-	cfg.prevLocationURL = nil
-	cfg.mapViewedThisSession = false
+	cfg.settings.units = saveData.Units
+	cfg.settings.accessible = saveData.Accessible
+	cfg.settings.theme = saveData.Theme
+	if saveData.PartySize > 0 {
+		cfg.settings.partySize = saveData.PartySize
+	}
+	if saveData.PageSize > 0 {
+		cfg.settings.pageSize = saveData.PageSize
+	}
+	cfg.settings.versionGroup = saveData.VersionGroup
+	cfg.settings.mqttBroker = saveData.MQTTBroker
+	cfg.settings.mqttTopic = saveData.MQTTTopic
+	cfg.settings.backupRemote = saveData.BackupRemote
+	cfg.settings.backupEvery = saveData.BackupEvery
+	cfg.settings.usageTracking = saveData.Usage
+	cfg.settings.usageEndpoint = saveData.UsageEndpoint
+	cfg.settings.reportSMTP = saveData.ReportSMTP
+	cfg.settings.reportFrom = saveData.ReportFrom
+	cfg.settings.reportTo = saveData.ReportTo
+	cfg.settings.catchPreset = saveData.CatchPreset
+	cfg.settings.catchCustom = saveData.CatchCustom
+	cfg.money = saveData.Money
+	cfg.items = saveData.Items
+	cfg.lure = saveData.Lure
+	cfg.redeemedCodes = make(map[string]bool, len(saveData.Redeemed))
+	for _, code := range saveData.Redeemed {
+		cfg.redeemedCodes[code] = true
+	}
+	cfg.trainerID = saveData.TrainerID
+	cfg.challenges = saveData.Challenges
+	cfg.reminders = saveData.Reminders
+	cfg.macros = saveData.Macros
 	cfg.mutex.Unlock()
+	cfg.RestoreMapState(saveData.Map)
+}
 
-	return nil
+// isStaleLock reports whether an error is a timeout waiting for the save
+// file's lock, held by a process that is no longer running.
+func isStaleLock(err error) bool {
+	var lockErr *pokedex.LockTimeoutError
+	return errors.As(err, &lockErr) && lockErr.Stale
+}
+
+// breakStaleLock offers to break the save file's lock when a save or load
+// timed out waiting for a lock whose owner is no longer running. It is only
+// called where the user can be asked: when the Pokédex is loaded at startup,
+// and by the save command. Other saves suggest running save instead.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - err: The error the save or load failed with, if any
+//
+// Returns:
+//   - Whether the lock was broken, so the save or load should be tried again
+func breakStaleLock(cfg *config, err error) bool {
+	var lockErr *pokedex.LockTimeoutError
+	if !errors.As(err, &lockErr) || !lockErr.Stale {
+		return false
+	}
+	question := i18n.Sprintf("The save file is locked by process %d, which is no longer running. Break the lock?", lockErr.Owner.PID)
+	if !confirm(cfg, question) {
+		return false
+	}
+	if err := pokedex.BreakLock(lockErr); err != nil {
+		i18n.Printf("Could not break the lock: %v\n", err)
+		return false
+	}
+	i18n.Println("The stale lock was broken.")
+	return true
 }
 
 // commandSave implements the "save" command, which manually saves the Pokédex to disk.
@@ -208,10 +383,13 @@ func loadPokedexData(cfg *config) error {
 //   - An error if the save operation fails
 func commandSave(cfg *config, params []string) error {
 	err := savePokedexData(cfg)
+	if breakStaleLock(cfg, err) {
+		err = savePokedexData(cfg)
+	}
 	if err != nil {
 		return err
 	}
-	fmt.Println("Pokédex saved successfully!")
+	i18n.Println("Pokédex saved successfully!")
 	return nil
 }
 
@@ -226,22 +404,24 @@ func commandSave(cfg *config, params []string) error {
 //   - An error if the reset operation fails
 func commandReset(cfg *config, params []string) error {
 	// Confirm with the user before clearing data
-	fmt.Print("Are you sure you want to clear your Pokédex? This cannot be undone. (y/N): ")
-	var response string
-	fmt.Scanln(&response)
-
-	if response != "y" && response != "Y" {
-		return errors.New("operation cancelled")
+	if !confirm(cfg, i18n.T("Are you sure you want to clear your Pokédex? This cannot be undone.")) {
+		return errors.New(i18n.T("operation cancelled"))
 	}
 
-	// Clear the Pokédex
-	cfg.pokedex = make(map[string]pokeapi.PokemonDataResp)
-	fmt.Println("Pokédex cleared! All Pokémon have been released.")
+	// Clear the Pokédex, its boxes, and the money and items collected.
+	// Redeemed codes are kept so that event gifts can't be claimed again.
+	cfg.pokedex.Reset(nil, nil)
+	cfg.mutex.Lock()
+	cfg.money = 0
+	cfg.items = nil
+	cfg.lure = nil
+	cfg.mutex.Unlock()
+	i18n.Println("Pokédex cleared! All Pokémon have been released.")
 
 	// Save the empty state
 	err := savePokedexData(cfg)
 	if err != nil {
-		return fmt.Errorf("error saving empty Pokédex: %w", err)
+		return fmt.Errorf(i18n.T("error saving empty Pokédex: %w"), err)
 	}
 
 	return nil
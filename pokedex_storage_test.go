@@ -2,137 +2,17 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
-// testSaveAndLoadPokedex is a helper function that saves and loads Pokédex data
-// using a specified save file path for testing purposes
-func testSaveAndLoadPokedex(saveFilePath string, cfg *config) error {
-	// Create a SaveData struct
-	saveData := SaveData{
-		Pokedex:   cfg.pokedex,
-		LastSaved: time.Now(),
-	}
-
-	// Marshal the data to JSON
-	jsonData, err := json.MarshalIndent(saveData, "", "  ")
-	if err != nil {
-		return err
-	}
-
-	// Write to the file
-	err = os.WriteFile(saveFilePath, jsonData, 0644)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
-// testLoadPokedexData is a helper function that loads Pokédex data
-// from a specified save file path for testing purposes
-func testLoadPokedexData(saveFilePath string, cfg *config) error {
-	// Check if the file exists
-	_, err := os.Stat(saveFilePath)
-	if os.IsNotExist(err) {
-		return nil // No save file, nothing to load
-	}
-
-	// Read the file
-	jsonData, err := os.ReadFile(saveFilePath)
-	if err != nil {
-		return err
-	}
-
-	// Unmarshal the data
-	var saveData SaveData
-	err = json.Unmarshal(jsonData, &saveData)
-	if err != nil {
-		return err
-	}
-
-	// Update the config
-	cfg.pokedex = saveData.Pokedex
-
-	return nil
-}
-
-// TestSaveAndLoadPokedex tests the saving and loading of Pokédex data
-func TestSaveAndLoadPokedex(t *testing.T) {
-	// Create a temporary directory for test files
-	tempDir, err := os.MkdirTemp("", "pokedex_test")
-	if err != nil {
-		t.Fatalf("Failed to create temp directory: %v", err)
-	}
-	defer os.RemoveAll(tempDir)
-
-	// Create a temporary save file path
-	tempSaveFile := filepath.Join(tempDir, "test_save.json")
-
-	// Create test data
-	testPokemon := pokeapi.PokemonDataResp{
-		Name:   "pikachu",
-		Height: 4,
-		Weight: 60,
-	}
-
-	// Create a config with test data
-	cfg := &config{
-		pokedex: map[string]pokeapi.PokemonDataResp{
-			"pikachu": testPokemon,
-		},
-		autoSaveEnabled:  true,
-		autoSaveInterval: 1,
-	}
-
-	// Test saving
-	err = testSaveAndLoadPokedex(tempSaveFile, cfg)
-	if err != nil {
-		t.Fatalf("Failed to save Pokédex data: %v", err)
-	}
-
-	// Verify the file exists
-	if _, err := os.Stat(tempSaveFile); os.IsNotExist(err) {
-		t.Fatalf("Save file was not created")
-	}
-
-	// Create a new empty config
-	newCfg := &config{
-		pokedex:          make(map[string]pokeapi.PokemonDataResp),
-		autoSaveEnabled:  true,
-		autoSaveInterval: 1,
-	}
-
-	// Test loading
-	err = testLoadPokedexData(tempSaveFile, newCfg)
-	if err != nil {
-		t.Fatalf("Failed to load Pokédex data: %v", err)
-	}
-
-	// Verify the data was loaded correctly
-	loadedPokemon, exists := newCfg.pokedex["pikachu"]
-	if !exists {
-		t.Fatalf("Pikachu was not loaded into the Pokédex")
-	}
-
-	if loadedPokemon.Name != testPokemon.Name {
-		t.Errorf("Expected Pokémon name %s, got %s", testPokemon.Name, loadedPokemon.Name)
-	}
-
-	if loadedPokemon.Height != testPokemon.Height {
-		t.Errorf("Expected Pokémon height %d, got %d", testPokemon.Height, loadedPokemon.Height)
-	}
-
-	if loadedPokemon.Weight != testPokemon.Weight {
-		t.Errorf("Expected Pokémon weight %d, got %d", testPokemon.Weight, loadedPokemon.Weight)
-	}
-}
-
 // TestAutoSaveLogic tests the auto-save logic without actually saving files
 func TestAutoSaveLogic(t *testing.T) {
 	// Test cases
@@ -182,13 +62,12 @@ func TestAutoSaveLogic(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			// Create config for this test
 			cfg := &config{
-				autoSaveEnabled:  tc.autoSaveEnabled,
-				autoSaveInterval: tc.autoSaveInterval,
+				settings:         settings{autoSaveEnabled: tc.autoSaveEnabled, autoSaveInterval: tc.autoSaveInterval},
 				changesSinceSync: tc.changesSinceSync,
 			}
 
 			// Check if we should save based on the auto-save logic
-			shouldSave := cfg.autoSaveEnabled && cfg.changesSinceSync >= cfg.autoSaveInterval
+			shouldSave := cfg.settings.autoSaveEnabled && cfg.changesSinceSync >= cfg.settings.autoSaveInterval
 
 			// Simulate the update logic
 			cfg.changesSinceSync++
@@ -207,3 +86,153 @@ func TestAutoSaveLogic(t *testing.T) {
 		})
 	}
 }
+
+// TestEvolutionSnapshotRoundTrip tests that an evolved Pokémon's previous form
+// is saved and can be restored by devolving
+func TestEvolutionSnapshotRoundTrip(t *testing.T) {
+	charmander := pokedex.NewEntry(pokeapi.PokemonDataResp{Name: "charmander", Height: 6})
+	charmander.Notes = []string{"My starter"}
+	charmeleon := charmander.EvolveInto("charmander", pokeapi.PokemonDataResp{Name: "charmeleon", Height: 11}, time.Now())
+	charmeleon.Notes = append(charmeleon.Notes, "Evolved at level 16")
+
+	jsonData, err := json.Marshal(pokedex.SaveData{Pokedex: map[string]pokedex.Entry{"charmeleon": charmeleon}})
+	if err != nil {
+		t.Fatalf("Failed to marshal save data: %v", err)
+	}
+	var reloaded pokedex.SaveData
+	if err := json.Unmarshal(jsonData, &reloaded); err != nil {
+		t.Fatalf("Failed to reload save data: %v", err)
+	}
+
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Reset(reloaded.Pokedex, nil)
+	if err := devolvePokemon(cfg, "charmeleon", FormatPokemonInput("charmeleon")); err != nil {
+		t.Fatalf("Failed to devolve: %v", err)
+	}
+	if _, exists := cfg.pokedex.Get("charmeleon"); exists {
+		t.Error("Expected the evolved form to be removed")
+	}
+	restored, exists := cfg.pokedex.Get("charmander")
+	if !exists || restored.Height != 6 || restored.PreEvolution != nil {
+		t.Fatalf("Unexpected restored entry: %+v", restored)
+	}
+	if len(restored.Notes) != 1 || restored.Notes[0] != "My starter" {
+		t.Errorf("Expected the notes from before evolving, got %v", restored.Notes)
+	}
+
+	// A Pokémon that was caught rather than evolved can't be devolved
+	if err := devolvePokemon(cfg, "charmander", FormatPokemonInput("charmander")); err == nil {
+		t.Error("Expected an error when devolving a Pokémon with no previous form")
+	}
+}
+
+// TestCustomSaveFile tests that --save-file takes precedence over
+// POKEDEXCLI_SAVE, and that the Pokédex is saved to the chosen file
+func TestCustomSaveFile(t *testing.T) {
+	useTempHome(t)
+	dir := t.TempDir()
+	t.Setenv(saveFileEnv, filepath.Join(dir, "from-env.json"))
+
+	path, err := resolveSaveFilePath("")
+	if err != nil || path != filepath.Join(dir, "from-env.json") {
+		t.Errorf("Expected the save file from the environment, got %q, %v", path, err)
+	}
+	path, err = resolveSaveFilePath(filepath.Join(dir, "from-flag.json"))
+	if err != nil || path != filepath.Join(dir, "from-flag.json") {
+		t.Fatalf("Expected the save file from the flag, got %q, %v", path, err)
+	}
+	if _, err := resolveSaveFilePath(filepath.Join(dir, "missing", "save.json")); err == nil {
+		t.Error("Expected a save file in a missing folder to be rejected")
+	}
+	t.Setenv(saveFileEnv, "")
+	if path, err := resolveSaveFilePath(""); err != nil || path != "" {
+		t.Errorf("Expected the default save file, got %q, %v", path, err)
+	}
+
+	cfg := &config{pokedex: pokedex.New(), saveFilePath: filepath.Join(dir, "from-flag.json")}
+	cfg.pokedex.Add("pikachu", pokedex.Entry{})
+	if err := writeSaveFile(cfg); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	if saved, found, err := pokedex.ReadFile(cfg.saveFilePath); err != nil || !found || len(saved.Pokedex) != 1 {
+		t.Errorf("Expected the Pokédex in the custom save file, got found=%v, err=%v", found, err)
+	}
+	if _, err := os.Stat(saveFile.path()); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing in the default save file, got %v", err)
+	}
+}
+
+// benchmarkPokemonJSON returns API data for a Pokémon shaped like a real
+// response: a full set of stats, two types, abilities, and 40 moves.
+func benchmarkPokemonJSON(name string) []byte {
+	stats := make([]string, 0, 6)
+	for _, stat := range []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"} {
+		stats = append(stats, `{"base_stat": 80, "effort": 0, "stat": {"name": "`+stat+`", "url": "https://pokeapi.co/api/v2/stat/`+stat+`/"}}`)
+	}
+	moves := make([]string, 0, 40)
+	for i := range 40 {
+		move := fmt.Sprintf("move-%d", i)
+		moves = append(moves, `{"move": {"name": "`+move+`", "url": "https://pokeapi.co/api/v2/move/`+move+`/"}, `+
+			`"version_group_details": [{"level_learned_at": 10, "move_learn_method": {"name": "level-up", "url": ""}, "version_group": {"name": "scarlet-violet", "url": ""}}]}`)
+	}
+	return []byte(`{"name": "` + name + `", "height": 4, "weight": 60, "base_experience": 112,
+		"stats": [` + strings.Join(stats, ",") + `],
+		"types": [{"slot": 1, "type": {"name": "electric", "url": ""}}, {"slot": 2, "type": {"name": "steel", "url": ""}}],
+		"abilities": [{"ability": {"name": "static", "url": ""}, "is_hidden": false, "slot": 1}],
+		"moves": [` + strings.Join(moves, ",") + `],
+		"species": {"name": "` + name + `", "url": "https://pokeapi.co/api/v2/pokemon-species/` + name + `/"},
+		"sprites": {"front_default": "https://example.com/` + name + `.png"}}`)
+}
+
+// largePokedex returns a configuration whose Pokédex holds 1000 Pokémon with
+// notes, movesets, and battle records, kept in a save file in a temporary
+// directory.
+func largePokedex(b *testing.B) *config {
+	b.Helper()
+	useTempHome(b)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings(), saveFilePath: filepath.Join(b.TempDir(), "save.json")}
+	for i := range 1000 {
+		name := fmt.Sprintf("pokemon-%d", i)
+		var data pokeapi.PokemonDataResp
+		if err := json.Unmarshal(benchmarkPokemonJSON(name), &data); err != nil {
+			b.Fatalf("Failed to build test Pokémon: %v", err)
+		}
+		entry := pokedex.NewEntry(data)
+		entry.CaughtOn = time.Now()
+		entry.Notes = []string{"Caught on the first try"}
+		entry.Moveset = []string{"move-1", "move-2", "move-3", "move-4"}
+		entry.BattlesWon = i % 50
+		cfg.pokedex.Add(name, entry)
+	}
+	return cfg
+}
+
+// BenchmarkSaveLargePokedex measures saving a Pokédex of 1000 Pokémon, which
+// happens after every change that auto-saves.
+func BenchmarkSaveLargePokedex(b *testing.B) {
+	cfg := largePokedex(b)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := writeSaveFile(cfg); err != nil {
+			b.Fatalf("Failed to save: %v", err)
+		}
+	}
+}
+
+// BenchmarkLoadLargePokedex measures loading a Pokédex of 1000 Pokémon, which
+// happens at startup.
+func BenchmarkLoadLargePokedex(b *testing.B) {
+	cfg := largePokedex(b)
+	if err := writeSaveFile(cfg); err != nil {
+		b.Fatalf("Failed to save: %v", err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := loadPokedexData(cfg); err != nil {
+			b.Fatalf("Failed to load: %v", err)
+		}
+	}
+	if cfg.pokedex.Len() != 1000 {
+		b.Errorf("Expected 1000 Pokémon after loading, got %d", cfg.pokedex.Len())
+	}
+}
@@ -4,9 +4,14 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
 )
 
 // PokemonNameInfo encapsulates information about a Pokémon's name in different formats.
@@ -50,18 +55,14 @@ func FormatPokemonInput(input string) PokemonNameInfo {
 func CheckPokemonExists(cfg *config, pokemonName string) (string, bool, interface{}) {
 	nameInfo := FormatPokemonInput(pokemonName)
 
-	// Acquire a read lock before accessing the pokedex
-	cfg.mutex.RLock()
-	defer cfg.mutex.RUnlock()
-
 	// Check if the pokemon exists directly
-	pokemonData, exists := cfg.pokedex[nameInfo.APIFormat]
+	pokemonData, exists := cfg.pokedex.Get(nameInfo.APIFormat)
 	if exists {
 		return nameInfo.APIFormat, true, pokemonData
 	}
 
 	// Check if it's a capitalization issue by trying all keys
-	for key, data := range cfg.pokedex {
+	for key, data := range cfg.pokedex.All() {
 		if ConvertToAPIFormat(key) == nameInfo.APIFormat {
 			return key, true, data
 		}
@@ -70,6 +71,36 @@ func CheckPokemonExists(cfg *config, pokemonName string) (string, bool, interfac
 	return nameInfo.APIFormat, false, nil
 }
 
+// splitPokemonParams separates a Pokémon name from the text that follows it,
+// such as a note or a move name. Because Pokémon names can contain spaces, the
+// longest run of leading words that names a Pokémon in the Pokédex is used as the name.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - params: The command parameters, starting with the Pokémon name
+//
+// Returns:
+//   - The API-formatted name of the Pokémon
+//   - Structured information about the Pokémon name
+//   - The remaining text, or an empty string if none was given
+//   - An error if no caught Pokémon matches the leading words
+func splitPokemonParams(cfg *config, params []string) (string, PokemonNameInfo, string, error) {
+	if len(params) == 0 {
+		return "", PokemonNameInfo{}, "", ErrNoPokemonName
+	}
+
+	for i := len(params); i > 0; i-- {
+		nameInfo := FormatPokemonInput(strings.Join(params[:i], " "))
+		if apiName, exists, _ := CheckPokemonExists(cfg, nameInfo.APIFormat); exists {
+			return apiName, nameInfo, strings.Join(params[i:], " "), nil
+		}
+	}
+
+	// No caught Pokémon matched, so report the error for the first word
+	apiName, nameInfo, _, _, err := GetPokemonIfExists(cfg, params[:1])
+	return apiName, nameInfo, "", err
+}
+
 // HandlePokemonNotInPokedex returns a standardized error when a Pokémon is not found in the Pokédex.
 // This ensures consistent error messaging for this common error condition.
 //
@@ -82,8 +113,46 @@ func HandlePokemonNotInPokedex(pokemonName string) error {
 	return errorhandling.PokemonNotInPokedexError(pokemonName)
 }
 
+// pokedexError converts an error from the Pokédex into a user-friendly error.
+// Errors from the Pokédex itself are replaced, while other errors, such as those
+// returned by an update function, are passed through unchanged.
+//
+// Parameters:
+//   - err: The error returned by the Pokédex
+//   - name: The name of the Pokémon the operation was for
+//
+// Returns:
+//   - The error to report to the user
+func pokedexError(err error, name string) error {
+	switch {
+	case errors.Is(err, pokedex.ErrNotFound):
+		return HandlePokemonNotInPokedex(FormatPokemonName(name))
+	case errors.Is(err, pokedex.ErrNameTaken):
+		return errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The new form of %s is already in your Pokédex. Release it first", FormatPokemonName(name)), err)
+	}
+	return err
+}
+
+// alreadyInPokedexError returns the error for a change that would replace a
+// Pokémon with one that is already in the Pokédex, as when evolving or devolving.
+//
+// Parameters:
+//   - existing: The API name of the Pokémon already in the Pokédex
+//   - action: What the user tried to do (e.g. "evolving Eevee")
+//
+// Returns:
+//   - An invalid input error explaining how to continue
+func alreadyInPokedexError(existing, action string) error {
+	return errorhandling.NewInvalidInputError(
+		i18n.Sprintf("You already have a %s in your Pokédex. Release it before %s",
+			FormatPokemonName(existing), action), pokedex.ErrNameTaken)
+}
+
 // UpdatePokedexAndSave handles all the auto-save logic after a change to the Pokédex.
 // It increments the change counter and triggers an auto-save if the threshold is reached.
+// The counter is reset when the save file is written, so it also tells whether
+// there are unsaved changes.
 //
 // Parameters:
 //   - cfg: The application configuration containing auto-save settings
@@ -94,16 +163,63 @@ func UpdatePokedexAndSave(cfg *config) error {
 	// Lock the config before modifying the counter
 	cfg.mutex.Lock()
 	cfg.changesSinceSync++
-	shouldSave := cfg.changesSinceSync >= cfg.autoSaveInterval
-	if shouldSave {
-		cfg.changesSinceSync = 0
-	}
+	shouldSave := cfg.changesSinceSync >= cfg.settings.autoSaveInterval
 	cfg.mutex.Unlock()
 
 	if shouldSave {
 		if err := autoSaveIfEnabled(cfg); err != nil {
-			return fmt.Errorf("error auto-saving: %w", err)
+			return fmt.Errorf(i18n.T("error auto-saving: %w"), err)
 		}
 	}
 	return nil
 }
+
+// recordSeen marks Pokémon as seen in the Pokédex and counts it as a change
+// for auto-saving if any of them hadn't been seen before. A failed auto-save
+// is reported but doesn't stop the command, since the Pokémon were still seen.
+// Seeing new Pokémon can also complete a challenge the user has started.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - commandName: The command that saw the Pokémon, for error reporting
+//   - location: The location area the Pokémon were spotted in ("" if they were looked up)
+//   - names: The API names of the Pokémon that were seen
+//
+// Returns:
+//   - The number of Pokémon seen for the first time
+func recordSeen(cfg *config, commandName, location string, names ...string) int {
+	sighting := pokedex.Sighting{SeenOn: time.Now(), Location: location}
+	newlySeen := 0
+	for _, name := range names {
+		if cfg.pokedex.MarkSeen(name, sighting) {
+			newlySeen++
+		}
+	}
+	if newlySeen > 0 {
+		if err := UpdatePokedexAndSave(cfg); err != nil {
+			HandleCommandError(cfg, commandName, err)
+		}
+		updateChallenges(cfg)
+	}
+	return newlySeen
+}
+
+// recordVisit counts a visit to a location area for the heatmap command, and
+// counts it as a change for auto-saving. A failed auto-save is reported but
+// doesn't stop the command.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - commandName: The command that visited the area, for error reporting
+//   - location: The location area's name in API format
+//   - encounter: Whether a wild Pokémon was encountered, rather than the area explored
+func recordVisit(cfg *config, commandName, location string, encounter bool) {
+	if encounter {
+		cfg.pokedex.RecordEncounter(location)
+	} else {
+		cfg.pokedex.RecordExplored(location)
+	}
+	if err := UpdatePokedexAndSave(cfg); err != nil {
+		HandleCommandError(cfg, commandName, err)
+	}
+}
@@ -0,0 +1,88 @@
+// This file provides a progress bar for long-running commands that work through
+// many items, such as 'refresh --all'. On a terminal the bar is redrawn in place
+// as items finish. Elsewhere, and in accessible mode, where a redrawn line would
+// be read out over and over, a line is printed at each quarter instead.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// progressBarWidth is the length of a progress bar when every item is done.
+const progressBarWidth = 30
+
+// ProgressBar reports how many of a known number of items are done. It's safe
+// for concurrent use, so workers can each call Advance as they finish an item.
+type ProgressBar struct {
+	w      io.Writer  // Where the progress is written
+	total  int        // The number of items
+	done   int        // The number of items done so far
+	redraw bool       // Whether the bar is redrawn in place rather than printed at each quarter
+	mu     sync.Mutex // Guards done and the writer
+}
+
+// NewProgressBar creates a progress bar for a number of items and draws it
+// with none of them done.
+//
+// Parameters:
+//   - w: The writer to report progress to; the bar is only redrawn in place if it's a terminal
+//   - total: The number of items
+//
+// Returns:
+//   - The progress bar
+func NewProgressBar(w io.Writer, total int) *ProgressBar {
+	p := &ProgressBar{w: w, total: total, redraw: isTerminal(w) && !isAccessibleOutput()}
+	if p.redraw {
+		p.draw()
+	}
+	return p
+}
+
+// Advance records that one more item is done.
+func (p *ProgressBar) Advance() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done++
+	if p.redraw {
+		p.draw()
+		return
+	}
+	// Print a line each time another quarter of the items is done
+	if p.total > 0 && p.done*4/p.total != (p.done-1)*4/p.total {
+		fmt.Fprintf(p.w, "%d/%d (%d%%)\n", p.done, p.total, p.done*100/p.total)
+	}
+}
+
+// Finish ends the line the bar is drawn on, so that output after it starts on a
+// new line. It should be called once every item is done, or the work is stopped.
+func (p *ProgressBar) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.redraw {
+		fmt.Fprintln(p.w)
+	}
+}
+
+// draw redraws the bar in place. The caller must hold p.mu.
+func (p *ProgressBar) draw() {
+	filled := progressBarWidth
+	if p.total > 0 {
+		filled = p.done * progressBarWidth / p.total
+	}
+	fmt.Fprintf(p.w, "\r[%s%s] %d/%d", strings.Repeat("#", filled),
+		strings.Repeat("-", progressBarWidth-filled), p.done, p.total)
+}
+
+// isTerminal reports whether a writer is an interactive terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	stat, err := file.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestProgressBarQuarters tests that progress written to something other than a
+// terminal is printed as a line at each quarter
+func TestProgressBarQuarters(t *testing.T) {
+	var out bytes.Buffer
+	bar := NewProgressBar(&out, 8)
+	for range 8 {
+		bar.Advance()
+	}
+	bar.Finish()
+
+	expected := "2/8 (25%)\n4/8 (50%)\n6/8 (75%)\n8/8 (100%)\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
+
+// TestProgressBarRedraw tests the bar drawn in place on a terminal
+func TestProgressBarRedraw(t *testing.T) {
+	var out bytes.Buffer
+	bar := &ProgressBar{w: &out, total: 3, redraw: true}
+	bar.Advance()
+	bar.Finish()
+
+	expected := "\r[" + strings.Repeat("#", 10) + strings.Repeat("-", 20) + "] 1/3\n"
+	if out.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, out.String())
+	}
+}
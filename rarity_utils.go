@@ -0,0 +1,71 @@
+// This file implements the rarity tiers of Pokémon species, which give players
+// an idea of how hard a Pokémon is to catch before they throw a ball.
+package main
+
+import (
+	"io"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// rareCaptureRate is the capture rate below which a Pokémon is rare. It's the
+// rare threshold of the built-in catch rate presets (see command_catchrate.go),
+// the casual one of which scales up the capture rate of rare Pokémon so they
+// can still be caught.
+const rareCaptureRate = 50
+
+// rarityTier is how rare a species is: common, rare, or legendary.
+type rarityTier string
+
+const (
+	rarityCommon    rarityTier = "Common"    // Easy to catch
+	rarityRare      rarityTier = "Rare"      // A capture rate below rareCaptureRate
+	rarityLegendary rarityTier = "Legendary" // A legendary or mythical species
+)
+
+// speciesRarity returns the rarity tier of a species. Legendary and mythical
+// species are legendary whatever their capture rate; others are rare when
+// their capture rate is below rareCaptureRate.
+func speciesRarity(species pokeapi.PokemonSpeciesResp) rarityTier {
+	switch {
+	case species.IsLegendary || species.IsMythical:
+		return rarityLegendary
+	case species.CaptureRate < rareCaptureRate:
+		return rarityRare
+	default:
+		return rarityCommon
+	}
+}
+
+// catchInfo is how hard a Pokémon is to catch.
+type catchInfo struct {
+	CaptureRate    int        `json:"capture_rate"`              // The species' capture rate from the API (0-255)
+	BaseExperience int        `json:"base_experience,omitempty"` // The Pokémon's base experience, if known
+	Rarity         rarityTier `json:"rarity"`                    // The species' rarity tier
+}
+
+// newCatchInfo returns a species' capture rate and rarity tier, and the base
+// experience of the Pokémon if it is known (Pokémon caught before it was
+// recorded have none).
+//
+// Parameters:
+//   - species: The species data from the API
+//   - baseExperience: The Pokémon's base experience, or 0 if unknown
+func newCatchInfo(species pokeapi.PokemonSpeciesResp, baseExperience int) catchInfo {
+	return catchInfo{
+		CaptureRate:    species.CaptureRate,
+		BaseExperience: baseExperience,
+		Rarity:         speciesRarity(species),
+	}
+}
+
+// writeCatchInfo writes how hard a Pokémon is to catch.
+func writeCatchInfo(w io.Writer, info catchInfo) {
+	i18n.Fprintln(w, "Catching:")
+	i18n.Fprintf(w, " - Capture rate: %d/255\n", info.CaptureRate)
+	if info.BaseExperience > 0 {
+		i18n.Fprintf(w, " - Base experience: %d\n", info.BaseExperience)
+	}
+	i18n.Fprintf(w, " - Rarity: %s\n", i18n.T(string(info.Rarity)))
+}
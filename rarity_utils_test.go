@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestSpeciesRarity tests that species are sorted into rarity tiers by their
+// capture rate, with legendary and mythical species always legendary
+func TestSpeciesRarity(t *testing.T) {
+	tests := []struct {
+		name    string
+		species pokeapi.PokemonSpeciesResp
+		want    rarityTier
+	}{
+		{"common", pokeapi.PokemonSpeciesResp{CaptureRate: 190}, rarityCommon},
+		{"at the threshold", pokeapi.PokemonSpeciesResp{CaptureRate: rareCaptureRate}, rarityCommon},
+		{"rare", pokeapi.PokemonSpeciesResp{CaptureRate: 45}, rarityRare},
+		{"legendary", pokeapi.PokemonSpeciesResp{CaptureRate: 3, IsLegendary: true}, rarityLegendary},
+		{"mythical", pokeapi.PokemonSpeciesResp{CaptureRate: 45, IsMythical: true}, rarityLegendary},
+	}
+
+	for _, tt := range tests {
+		if got := speciesRarity(tt.species); got != tt.want {
+			t.Errorf("%s: speciesRarity() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
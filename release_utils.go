@@ -0,0 +1,120 @@
+// This file contains what the release command says and checks before letting a
+// Pokémon go. The farewell is themed on the Pokémon's type, and releasing a
+// Pokémon that would be hard to get back (a legendary, a favorite, or the last
+// one the user has from its evolution family) needs confirming first.
+package main
+
+import (
+	"log"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// releaseFarewells are the messages shown when a Pokémon of each type is
+// released, formatted with the Pokémon's name. Pokémon are matched by their
+// first type, and those of other types get the plain farewell.
+var releaseFarewells = map[string]string{
+	"water":    "%s splashed back into the water. Bye, %s!",
+	"fire":     "%s gave a last warm glow and headed for the hills. Bye, %s!",
+	"grass":    "%s disappeared into the tall grass. Bye, %s!",
+	"bug":      "%s scuttled off into the undergrowth. Bye, %s!",
+	"flying":   "%s spread its wings and flew off. Bye, %s!",
+	"electric": "%s crackled once and darted away. Bye, %s!",
+	"ghost":    "%s faded away into the shadows. Bye, %s!",
+	"psychic":  "%s vanished in a flash of light. Bye, %s!",
+	"rock":     "%s rumbled off back to the mountains. Bye, %s!",
+	"ground":   "%s burrowed back underground. Bye, %s!",
+	"ice":      "%s slid off towards the snowfields. Bye, %s!",
+	"dragon":   "%s soared off beyond the clouds. Bye, %s!",
+}
+
+// releaseFarewell returns the message shown when a Pokémon is released.
+//
+// Parameters:
+//   - name: The Pokémon's name for display
+//   - data: The Pokémon's data, for its types
+//
+// Returns:
+//   - The farewell, translated
+func releaseFarewell(name string, data pokeapi.PokemonDataResp) string {
+	format := "%s was released. Bye, %s!"
+	if len(data.Types) > 0 {
+		if themed, ok := releaseFarewells[data.Types[0].Type.Name]; ok {
+			format = themed
+		}
+	}
+	return i18n.Sprintf(format, name, name)
+}
+
+// releaseWarnings returns the reasons to think twice before releasing a
+// Pokémon. Whether it's legendary and the rest of its evolution family are
+// looked up in the API; if they can't be, those warnings are left out rather
+// than keep the user from releasing it.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//   - apiName: The Pokémon's name in the Pokédex
+//   - entry: The Pokémon's Pokédex entry
+//
+// Returns:
+//   - The warnings, translated, or none if it can be released without asking
+func releaseWarnings(cfg *config, apiName string, entry pokedex.Entry) []string {
+	name := FormatPokemonName(apiName)
+	species := speciesName(apiName, entry.PokemonDataResp)
+	var warnings []string
+
+	if entry.Box == favoritesBox {
+		warnings = append(warnings, i18n.Sprintf("%s is one of your favorites.", name))
+	}
+
+	speciesData, err := cfg.pokeapiClient.GetPokemonSpecies(species)
+	if err == nil && speciesRarity(speciesData) == rarityLegendary {
+		warnings = append(warnings, i18n.Sprintf("%s is a legendary Pokémon, and may never turn up again.", name))
+	} else if err != nil && cfg.Settings().debugMode {
+		log.Printf("Could not look up whether %s is legendary: %v", species, err)
+	}
+
+	chain, err := cfg.pokeapiClient.GetEvolutionChainBySpecies(species)
+	if err != nil {
+		if cfg.Settings().debugMode {
+			log.Printf("Could not look up the evolution family of %s: %v", species, err)
+		}
+		return warnings
+	}
+	if lastOfFamily(cfg, apiName, chain.Chain) {
+		warnings = append(warnings, i18n.Sprintf("%s is the last Pokémon you have from the %s family.",
+			name, FormatPokemonName(chain.Chain.Species.Name)))
+	}
+	return warnings
+}
+
+// lastOfFamily reports whether a Pokémon is the only one in the Pokédex from
+// its evolution family.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - apiName: The Pokémon's name in the Pokédex
+//   - chain: The first link of its species' evolution chain
+//
+// Returns:
+//   - true if no other Pokémon in the Pokédex is of a species in the chain
+func lastOfFamily(cfg *config, apiName string, chain pokeapi.ChainLink) bool {
+	family := make(map[string]bool)
+	var collect func(link pokeapi.ChainLink)
+	collect = func(link pokeapi.ChainLink) {
+		family[link.Species.Name] = true
+		for _, next := range link.EvolvesTo {
+			collect(next)
+		}
+	}
+	collect(chain)
+
+	for _, owned := range cfg.pokedex.List() {
+		if owned.Name != apiName && family[speciesName(owned.Name, owned.Entry.PokemonDataResp)] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// releaseTestConfig returns a configuration whose API responses come from
+// fixtures for Pikachu's family and Mewtwo.
+func releaseTestConfig(t *testing.T) *config {
+	t.Helper()
+	dir := t.TempDir()
+	fixtures := map[string]string{
+		"pokemon-species/pikachu.json": `{"name": "pikachu", "capture_rate": 190, "evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/10/"}}`,
+		"pokemon-species/mewtwo.json":  `{"name": "mewtwo", "capture_rate": 3, "is_legendary": true, "evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/77/"}}`,
+		"evolution-chain/10.json": `{"id": 10, "chain": {"species": {"name": "pichu"}, "evolves_to": [
+			{"species": {"name": "pikachu"}, "evolves_to": [{"species": {"name": "raichu"}, "evolves_to": []}]}]}}`,
+		"evolution-chain/77.json": `{"id": 77, "chain": {"species": {"name": "mewtwo"}, "evolves_to": []}}`,
+	}
+	for path, data := range fixtures {
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := pokeapi.NewClient(time.Hour)
+	client.UseFixtures(dir, false)
+	return &config{pokeapiClient: client, pokedex: pokedex.New(), settings: defaultSettings()}
+}
+
+// releaseTestEntry returns an entry for a Pokémon of a species and type.
+func releaseTestEntry(t *testing.T, species, typeName string) pokedex.Entry {
+	t.Helper()
+	var data pokeapi.PokemonDataResp
+	raw := `{"name": "` + species + `", "species": {"name": "` + species + `"}, "types": [{"slot": 1, "type": {"name": "` + typeName + `"}}]}`
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		t.Fatal(err)
+	}
+	return pokedex.NewEntry(data)
+}
+
+// TestReleaseWarnings tests the warnings given before releasing a legendary,
+// a favorite, or the last Pokémon of an evolution family
+func TestReleaseWarnings(t *testing.T) {
+	cfg := releaseTestConfig(t)
+	pikachu := releaseTestEntry(t, "pikachu", "electric")
+	cfg.pokedex.Add("pikachu", pikachu)
+
+	warnings := releaseWarnings(cfg, "pikachu", pikachu)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Pichu family") {
+		t.Errorf("Expected only the last of its family to be warned about, got %v", warnings)
+	}
+
+	// With a Raichu too, Pikachu isn't the last of the family
+	cfg.pokedex.Add("raichu", releaseTestEntry(t, "raichu", "electric"))
+	if warnings := releaseWarnings(cfg, "pikachu", pikachu); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+
+	mewtwo := releaseTestEntry(t, "mewtwo", "psychic")
+	mewtwo.Box = favoritesBox
+	cfg.pokedex.Add("mewtwo", mewtwo)
+	warnings = releaseWarnings(cfg, "mewtwo", mewtwo)
+	if len(warnings) != 3 || !strings.Contains(warnings[0], "favorites") || !strings.Contains(warnings[1], "legendary") {
+		t.Errorf("Expected favorite, legendary, and last of family warnings, got %v", warnings)
+	}
+
+	// Without species data, only what's known locally is warned about
+	unknown := releaseTestEntry(t, "missingno", "normal")
+	if warnings := releaseWarnings(cfg, "missingno", unknown); len(warnings) != 0 {
+		t.Errorf("Expected no warnings without species data, got %v", warnings)
+	}
+}
+
+// TestReleaseFarewell tests that farewells are themed on the Pokémon's first type
+func TestReleaseFarewell(t *testing.T) {
+	cases := map[string]string{
+		"water":  "Squirtle splashed back into the water. Bye, Squirtle!",
+		"normal": "Squirtle was released. Bye, Squirtle!",
+	}
+	for typeName, want := range cases {
+		if got := releaseFarewell("Squirtle", releaseTestEntry(t, "squirtle", typeName).PokemonDataResp); got != want {
+			t.Errorf("releaseFarewell(%s) = %q, want %q", typeName, got, want)
+		}
+	}
+	if got := releaseFarewell("Missingno", pokeapi.PokemonDataResp{}); got != "Missingno was released. Bye, Missingno!" {
+		t.Errorf("Expected the plain farewell for a Pokémon without types, got %q", got)
+	}
+}
+
+// TestReleaseResult tests that the released Pokémon is the result's data and
+// is gone from the Pokédex
+func TestReleaseResult(t *testing.T) {
+	useTempHome(t)
+	cfg := releaseTestConfig(t)
+	cfg.pokedex.Add("pikachu", releaseTestEntry(t, "pikachu", "electric"))
+	cfg.pokedex.Add("raichu", releaseTestEntry(t, "raichu", "electric"))
+
+	result, err := releaseResult(cfg, []string{"pikachu"})
+	if err != nil {
+		t.Fatalf("releaseResult returned an error: %v", err)
+	}
+	if released, ok := result.Data.(releasedPokemon); !ok || len(released.Released) != 1 || released.Released[0] != "pikachu" {
+		t.Errorf("Expected Pikachu to be released, got %+v", result.Data)
+	}
+	if !strings.Contains(result.Message, "Pikachu") {
+		t.Errorf("Expected a farewell to Pikachu, got %q", result.Message)
+	}
+	if _, ok := cfg.pokedex.Get("pikachu"); ok {
+		t.Error("Expected Pikachu to be gone from the Pokédex")
+	}
+}
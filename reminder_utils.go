@@ -0,0 +1,148 @@
+// This file contains the reminders set with 'remind' and the commands
+// scheduled with 'schedule'. Both are saved with the Pokédex, and while the
+// REPL runs, a timer in the background checks for those that have come up and
+// shows them before the next prompt (see event_loop.go). Those that came up
+// while the program wasn't running are shown when it starts.
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// reminderCheckInterval is how often the REPL checks for reminders that have come up.
+const reminderCheckInterval = 15 * time.Second
+
+// reminderPeriods is how often a scheduled command comes up, by the name given
+// to 'schedule'.
+var reminderPeriods = map[string]time.Duration{
+	"hourly": time.Hour,
+	"daily":  24 * time.Hour,
+	"weekly": 7 * 24 * time.Hour,
+}
+
+// compareReminders orders reminders soonest first, for slices.SortFunc.
+func compareReminders(a, b pokedex.Reminder) int {
+	return a.Due.Compare(b.Due)
+}
+
+// nextReminderDue returns when a scheduled command next comes up after now.
+// Times it came up while the program wasn't running are skipped, so that it's
+// only shown once however long the program was closed.
+//
+// Parameters:
+//   - reminder: The scheduled command
+//   - now: The current time
+//
+// Returns:
+//   - The first time after now it comes up
+func nextReminderDue(reminder pokedex.Reminder, now time.Time) time.Time {
+	period := reminderPeriods[reminder.Every]
+	if period <= 0 {
+		period = reminderPeriods["daily"]
+	}
+	due := reminder.Due
+	for !due.After(now) {
+		due = due.Add(period)
+	}
+	return due
+}
+
+// startReminderTimer shows the reminders that came up while the program wasn't
+// running, and starts checking for the rest in the background. No timer runs
+// in batch mode, where nobody is there to be reminded.
+//
+// Parameters:
+//   - cfg: The application configuration containing the reminders
+func startReminderTimer(cfg *config) {
+	if cfg.batch != nil {
+		return
+	}
+	showDueReminders(cfg, time.Now())
+	go func() {
+		ticker := time.NewTicker(reminderCheckInterval)
+		defer ticker.Stop()
+		for now := range ticker.C {
+			showDueReminders(cfg, now)
+		}
+	}()
+}
+
+// showDueReminders shows the reminders and scheduled commands that have come
+// up before the next prompt (see notify). It's safe to call from any goroutine.
+//
+// Parameters:
+//   - cfg: The application configuration containing the reminders
+//   - now: The current time
+func showDueReminders(cfg *config, now time.Time) {
+	for _, reminder := range cfg.TakeDueReminders(now) {
+		if reminder.Command != "" {
+			notify(cfg, "[Scheduled] It's time for your %s '%s'.\n", i18n.T(reminder.Every), reminder.Command)
+		} else {
+			notify(cfg, "[Reminder] %s\n", reminder.Message)
+		}
+	}
+}
+
+// formatReminderDue describes when a reminder comes up, relative to now.
+func formatReminderDue(due, now time.Time) string {
+	wait := due.Sub(now).Round(time.Minute)
+	if wait < time.Minute {
+		return i18n.T("any moment now")
+	}
+	return i18n.Sprintf("in %s (%s)", formatWait(wait), due.Local().Format("Mon 15:04"))
+}
+
+// formatWait shortens a duration to hours and minutes, such as "1h5m"
+// instead of "1h5m0s".
+func formatWait(wait time.Duration) string {
+	text := strings.TrimSuffix(wait.String(), "0s")
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
+// remindersOfKind returns the reminders, or the scheduled commands, soonest first.
+//
+// Parameters:
+//   - cfg: The application configuration containing the reminders
+//   - scheduled: Whether to return the scheduled commands instead of the reminders
+//
+// Returns:
+//   - The reminders or scheduled commands
+func remindersOfKind(cfg *config, scheduled bool) []pokedex.Reminder {
+	var matching []pokedex.Reminder
+	for _, reminder := range cfg.Reminders() {
+		if (reminder.Command != "") == scheduled {
+			matching = append(matching, reminder)
+		}
+	}
+	return matching
+}
+
+// cancelReminder cancels a reminder or scheduled command, given by its number
+// in the list shown by 'remind' or 'schedule', and saves the change.
+//
+// Parameters:
+//   - cfg: The application configuration containing the reminders
+//   - scheduled: Whether the number is of a scheduled command instead of a reminder
+//   - param: The number
+//
+// Returns:
+//   - The reminder or scheduled command that was cancelled
+//   - An error if the number is invalid or the change can't be saved
+func cancelReminder(cfg *config, scheduled bool, param string) (pokedex.Reminder, error) {
+	reminders := remindersOfKind(cfg, scheduled)
+	n, err := strconv.Atoi(param)
+	if err != nil || n < 1 || n > len(reminders) || !cfg.RemoveReminder(reminders[n-1]) {
+		return pokedex.Reminder{}, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Invalid number: %s (see the numbers in the list)", param), nil)
+	}
+	return reminders[n-1], savePokedexData(cfg)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestTakeDueReminders tests that reminders are removed once they come up,
+// and that scheduled commands move on to the next time they come up
+func TestTakeDueReminders(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	cfg.AddReminder(pokedex.Reminder{Message: "check berries", Due: now.Add(10 * time.Minute)})
+	cfg.AddReminder(pokedex.Reminder{Message: "feed the daycare", Due: now.Add(-time.Minute)})
+	cfg.AddReminder(pokedex.Reminder{Command: "challenge", Every: "daily", Due: now.Add(-50 * time.Hour)})
+
+	due := cfg.TakeDueReminders(now)
+	if len(due) != 2 || due[0].Command != "challenge" || due[1].Message != "feed the daycare" {
+		t.Fatalf("Expected the scheduled command and the overdue reminder, soonest first, got %+v", due)
+	}
+
+	waiting := cfg.Reminders()
+	if len(waiting) != 2 || waiting[0].Message != "check berries" {
+		t.Fatalf("Expected the reminder still to come and the scheduled command, got %+v", waiting)
+	}
+	// The two days missed are skipped, and it comes up once more tomorrow
+	if want := now.Add(22 * time.Hour); !waiting[1].Due.Equal(want) {
+		t.Errorf("Expected the scheduled command next at %v, got %v", want, waiting[1].Due)
+	}
+	if due := cfg.TakeDueReminders(now); len(due) != 0 {
+		t.Errorf("Expected nothing more to come up, got %+v", due)
+	}
+}
+
+// TestRemindAndSchedule tests setting, listing, and cancelling reminders and
+// scheduled commands
+func TestRemindAndSchedule(t *testing.T) {
+	useTempHome(t)
+	now := time.Now()
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+
+	if err := addReminder(cfg, "10M", "Check berries", now); err != nil {
+		t.Fatalf("addReminder returned an error: %v", err)
+	}
+	if err := addReminder(cfg, "soon", "Check berries", now); err == nil {
+		t.Error("Expected an invalid delay to be rejected")
+	}
+	if err := scheduleCommand(cfg, "weekly", "challenge list", now); err != nil {
+		t.Fatalf("scheduleCommand returned an error: %v", err)
+	}
+	if err := scheduleCommand(cfg, "daily", "fly", now); err == nil {
+		t.Error("Expected an unknown command to be rejected")
+	}
+
+	reminders, scheduled := remindersOfKind(cfg, false), remindersOfKind(cfg, true)
+	if len(reminders) != 1 || reminders[0].Message != "Check berries" || !reminders[0].Due.Equal(now.Add(10*time.Minute)) {
+		t.Errorf("Unexpected reminders %+v", reminders)
+	}
+	if len(scheduled) != 1 || scheduled[0].Command != "challenge list" || scheduled[0].Every != "weekly" {
+		t.Errorf("Unexpected scheduled commands %+v", scheduled)
+	}
+
+	// Cancelling is by the number in each list
+	if _, err := cancelReminder(cfg, true, "2"); err == nil {
+		t.Error("Expected an invalid number to be rejected")
+	}
+	if cancelled, err := cancelReminder(cfg, true, "1"); err != nil || cancelled.Command != "challenge list" {
+		t.Errorf("Expected the scheduled command to be cancelled, got %+v, %v", cancelled, err)
+	}
+	if remaining := cfg.Reminders(); len(remaining) != 1 || remaining[0].Message != "Check berries" {
+		t.Errorf("Expected only the reminder to be left, got %+v", remaining)
+	}
+}
+
+// TestFormatWait tests that waits are shortened to hours and minutes
+func TestFormatWait(t *testing.T) {
+	cases := map[time.Duration]string{
+		10 * time.Minute:              "10m",
+		time.Hour + 5*time.Minute:     "1h5m",
+		2 * time.Hour:                 "2h",
+		24*time.Hour + 30*time.Minute: "24h30m",
+	}
+	for wait, want := range cases {
+		if got := formatWait(wait); got != want {
+			t.Errorf("formatWait(%v) = %q, want %q", wait, got, want)
+		}
+	}
+}
@@ -1,21 +1,29 @@
 package main
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 )
 
 // cliCommand represents a command that can be executed in the CLI.
 // Each command has a name, description, and callback function to execute.
 type cliCommand struct {
 	name        string                        // Name of the command
+	args        string                        // Arguments the command accepts, in usage notation (e.g. "<pokemon> [--yes]")
 	description string                        // Description shown in help
 	callback    func(*config, []string) error // Function to execute when command is called
+	result      resultFunc                    // Function returning the command's result, used instead of callback if set (see result_utils.go)
+	dryRun      bool                          // Whether the command can preview its changes with --dry-run
+	freeText    bool                          // Whether the command takes free text, so #N parameters aren't replaced by listed items
 }
 
 // getCommands returns a map of all available CLI commands.
@@ -28,45 +36,397 @@ func getCommands() map[string]cliCommand {
 			description: "List available commands",
 			callback:    commandHelp,
 		},
+		"commands": {
+			name:        "commands",
+			args:        "[--json]",
+			description: "List the usage of every command, or describe them all as JSON with --json",
+			callback:    commandCommands,
+		},
+		"completion": {
+			name:        "completion",
+			args:        "bash | zsh | fish",
+			description: "Print a shell completion script for running commands from the command line",
+			callback:    commandCompletion,
+		},
 		"explore": {
 			name:        "explore",
-			description: "List the pokemon found at the specified map location number (1-20)",
-			callback:    commandExplore,
+			args:        "<location number | bookmark | all> [--json]",
+			description: "List the pokemon found at the specified map location number, or a bookmarked location, or those not caught yet in every location on the map page",
+			result:      exploreResult,
+		},
+		"bookmark": {
+			name:        "bookmark",
+			args:        "[add [location number] | remove <location>]",
+			description: "Bookmark locations to explore again later, or list your bookmarks",
+			callback:    commandBookmark,
+		},
+		"area": {
+			name:        "area",
+			args:        "progress [location]",
+			description: "Show how many of the Pokémon found in a location area you've caught there",
+			callback:    commandArea,
+		},
+		"encounter": {
+			name:        "encounter",
+			description: "Look for a wild pokemon in the area you explored last",
+			callback:    commandEncounter,
+		},
+		"surf": {
+			name:        "surf",
+			args:        "[location number]",
+			description: "Look for a wild pokemon by surfing",
+			callback:    commandSurf,
+		},
+		"fish": {
+			name:        "fish",
+			args:        "[location number]",
+			description: "Look for a wild pokemon by fishing",
+			callback:    commandFish,
+		},
+		"lure": {
+			name:        "lure",
+			args:        "[type|pokemon]",
+			description: "Use a lure to draw out a type or pokemon where you explored",
+			callback:    commandLure,
 		},
 		"catch": {
 			name:        "catch",
-			description: "Attempt to catch the specified pokemon",
-			callback:    commandCatch,
+			args:        "<pokemon | number> [--ball <ball>] [--json]",
+			description: "Attempt to catch the specified pokemon, or the one with that number in the last explore list",
+			result:      catchResult,
+		},
+		"random": {
+			name:        "random",
+			args:        "catch [--gen <generation>] [--type <type>] [--json]",
+			description: "Try to catch a random pokemon from the whole pokedex",
+			result:      randomResult,
+		},
+		"search": {
+			name:        "search",
+			args:        "[<name>] [--type <type>] [--gen <generation>] [--json]",
+			description: "Find pokemon by name, type, and generation",
+			result:      searchResult,
+		},
+		"odds": {
+			name:        "odds",
+			args:        "<pokemon> [--ball <ball>] [--json]",
+			description: "Show the chance of catching a pokemon with each ball",
+			result:      oddsResult,
+		},
+		"catchrate": {
+			name:        "catchrate",
+			args:        "[casual | authentic | custom [--rare <0-255>] [--boost <0-100>] [--masterball on|off]]",
+			description: "Choose how forgiving catching is with rare Pokémon",
+			callback:    commandCatchRate,
 		},
 		"inspect": {
 			name:        "inspect",
+			args:        "<pokemon> [--json]",
 			description: "List the stats of the specified pokemon",
-			callback:    commandInspect,
+			result:      inspectResult,
+		},
+		"lookup": {
+			name:        "lookup",
+			args:        "<pokemon>",
+			description: "Show the stats and catch difficulty of any pokemon",
+			callback:    commandLookup,
+		},
+		"variants": {
+			name:        "variants",
+			args:        "<pokemon>",
+			description: "List every form of a pokemon's species and which ones you own",
+			callback:    commandVariants,
 		},
 		"pokedex": {
 			name:        "pokedex",
+			args:        "[--box <name>] [--caught-at <location>] [--families] [--json]",
 			description: "List all pokemon currently in your pokedex",
-			callback:    commandPokedex,
+			result:      pokedexResult,
 		},
 		"release": {
 			name:        "release",
-			description: "Release a caught pokemon from your pokedex",
-			callback:    commandRelease,
+			args:        "<pokemon> | --select [--dry-run] [--json]",
+			description: "Release a caught pokemon from your pokedex, or choose several to release with --select",
+			result:      releaseResult,
+			dryRun:      true,
 		},
 		"showoff": {
 			name:        "showoff",
+			args:        "<pokemon>",
 			description: "Show off a caught pokemon using one of its moves",
 			callback:    commandShowOff,
 		},
 		"describe": {
 			name:        "describe",
+			args:        "[pokemon] [--version <game> | --versions | --all] [--json]",
 			description: "Display information about a caught pokemon",
-			callback:    commandDescribe,
+			result:      describeResult,
 		},
 		"evolve": {
 			name:        "evolve",
+			args:        "<pokemon> [choice] [--yes] [--dry-run] [--json]",
 			description: "Evolve a pokemon that is in your pokedex",
-			callback:    commandEvolve,
+			result:      evolveResult,
+			dryRun:      true,
+		},
+		"devolve": {
+			name:        "devolve",
+			args:        "<pokemon>",
+			description: "Undo the last evolution of a pokemon in your pokedex",
+			callback:    commandDevolve,
+		},
+		"refresh": {
+			name:        "refresh",
+			args:        "<pokemon|--all>",
+			description: "Update a caught pokemon's data from the API, keeping your notes and progress",
+			callback:    commandRefresh,
+		},
+		"checklist": {
+			name:        "checklist",
+			args:        "<generation> [--out <file>]",
+			description: "Show which species of a generation you've caught (e.g. checklist gen1)",
+			callback:    commandChecklist,
+		},
+		"seen": {
+			name:        "seen",
+			args:        "[--at <location>]",
+			description: "List the pokemon you've seen and where you first spotted them (seen --at <location>)",
+			callback:    commandSeen,
+		},
+		"heatmap": {
+			name:        "heatmap",
+			args:        "[count]",
+			description: "Show the locations you've explored, encountered, and caught the most in (e.g. heatmap 5)",
+			callback:    commandHeatmap,
+		},
+		"growth": {
+			name:        "growth",
+			description: "Chart the size of your Pokédex over time, with milestones marked",
+			callback:    commandGrowth,
+		},
+		"box": {
+			name:        "box",
+			args:        "create <name> | move <pokemon> <box> | move --select <box> | remove <pokemon> | delete <name> | list",
+			description: "Organize caught pokemon into named boxes (create/move/remove/delete/list)",
+			callback:    commandBox,
+		},
+		"counter": {
+			name:        "counter",
+			args:        "<pokemon>",
+			description: "Rank your best pokemon to use against the specified pokemon",
+			callback:    commandCounter,
+		},
+		"egggroups": {
+			name:        "egggroups",
+			args:        "<pokemon>",
+			description: "Show a pokemon's egg groups and which of your pokemon it can breed with",
+			callback:    commandEggGroups,
+		},
+		"fight": {
+			name:        "fight",
+			args:        "trainer [class]",
+			description: "Battle an NPC trainer with your team to earn money (e.g. fight trainer swimmer)",
+			callback:    commandFight,
+		},
+		"battle": {
+			name:        "battle",
+			args:        "hotseat [--best-of <n>] [--p1 <save file>] [--p2 <save file>] | wild | gym <type> [--difficulty <level>] [--record <file>]",
+			description: "Battle a friend at the same keyboard, or a wild pokemon or gym leader",
+			callback:    commandBattle,
+		},
+		"rental": {
+			name:        "rental",
+			args:        "[team] | return",
+			description: "Rent a preset team to battle with, without changing your Pokédex",
+			callback:    commandRental,
+		},
+		"replay": {
+			name:        "replay",
+			args:        "<file> [--speed <n>]",
+			description: "Play back a battle recorded with 'battle --record'",
+			callback:    commandReplay,
+		},
+		"shop": {
+			name:        "shop",
+			args:        "[buy <item> [quantity] | bag]",
+			description: "Buy Poké Balls and items with the money you've earned, or list your bag",
+			callback:    commandShop,
+		},
+		"daycare": {
+			name:        "daycare",
+			args:        "[deposit <pokemon> | withdraw <pokemon>]",
+			description: "Leave up to 2 pokemon at the day care to gain levels over time (deposit/withdraw)",
+			callback:    commandDaycare,
+		},
+		"party": {
+			name:        "party",
+			args:        "[size <number> | status | heal] [--json]",
+			description: "List the pokemon with you, show their condition, heal them, or show or change the party size",
+			result:      partyResult,
+		},
+		"redeem": {
+			name:        "redeem",
+			args:        "<code>",
+			description: "Redeem an event distribution code for a pokemon or items",
+			callback:    commandRedeem,
+		},
+		"mysterygift": {
+			name:        "mysterygift",
+			description: "Receive this week's mystery gift: an item or an uncommon pokemon",
+			callback:    commandMysteryGift,
+		},
+		"events": {
+			name:        "events",
+			description: "List the seasonal events on now and the pokemon that turn up more often during them",
+			callback:    commandEvents,
+		},
+		"challenge": {
+			name:        "challenge",
+			args:        "[list | start <id> | abandon <id> | check]",
+			description: "Take on challenges you define in JSON files, and track your progress",
+			callback:    commandChallenge,
+		},
+		"ribbons": {
+			name:        "ribbons",
+			description: "Summarize the ribbons your pokemon have earned",
+			callback:    commandRibbons,
+		},
+		"minigame": {
+			name:        "minigame",
+			args:        "[game] [pokemon]",
+			description: "Play a quick stat-based minigame with a caught pokemon to earn happiness",
+			callback:    commandMinigame,
+		},
+		"pet": {
+			name:        "pet",
+			args:        "<pokemon>",
+			description: "Pet a caught pokemon to raise its happiness (once a day)",
+			callback:    commandPet,
+		},
+		"play": {
+			name:        "play",
+			args:        "<pokemon>",
+			description: "Play with a caught pokemon to raise its happiness (once a day)",
+			callback:    commandPlay,
+		},
+		"top": {
+			name:        "top",
+			args:        "<stat> [count] [--effective]",
+			description: "Rank your pokemon by a stat or their stat total (e.g. top attack 10)",
+			callback:    commandTop,
+		},
+		"calcstat": {
+			name:        "calcstat",
+			args:        "<base> <iv> <ev> <level> [+|-] | <pokemon> <stat> <level> [iv] [ev] [nature]",
+			description: "Work out a stat from its base stat, IVs, EVs, level, and nature (e.g. calcstat pikachu attack 50)",
+			callback:    commandCalcStat,
+		},
+		"analytics": {
+			name:        "analytics",
+			description: "Chart the types, generations, and stat totals of your pokemon",
+			callback:    commandAnalytics,
+		},
+		"dashboard": {
+			name:        "dashboard",
+			args:        "[--port <n>] | stop",
+			description: "Serve a web page showing your collection while the app is open",
+			callback:    commandDashboard,
+		},
+		"serve": {
+			name:        "serve",
+			args:        "[--port <n>]",
+			description: "Let other programs control your Pokédex through a gRPC service until Ctrl+C",
+			callback:    commandServe,
+		},
+		"mqtt": {
+			name:        "mqtt",
+			args:        "[<broker> [--topic <topic>] | off | test]",
+			description: "Publish catches, evolutions, and ribbons to an MQTT broker for home automation",
+			callback:    commandMQTT,
+		},
+		"teambuild": {
+			name:        "teambuild",
+			description: "Suggest a balanced team of 6 from your pokedex",
+			callback:    commandTeamBuild,
+		},
+		"teach": {
+			name:        "teach",
+			args:        "<pokemon> [move]",
+			description: "Teach a caught pokemon a move (up to 4), or list its moves",
+			callback:    commandTeach,
+		},
+		"forget": {
+			name:        "forget",
+			args:        "<pokemon> <move>",
+			description: "Make a caught pokemon forget a move",
+			callback:    commandForget,
+		},
+		"hold": {
+			name:        "hold",
+			args:        "<pokemon> [item | none] [--json]",
+			description: "Give a caught pokemon an item from your bag to hold in battle, or take it back",
+			result:      holdResult,
+		},
+		"types": {
+			name:        "types",
+			description: "List every type with what it's strong and weak against",
+			callback:    commandTypes,
+		},
+		"type": {
+			name:        "type",
+			args:        "<type>",
+			description: "Show a type's strengths, weaknesses, and pokemon",
+			callback:    commandType,
+		},
+		"natures": {
+			name:        "natures",
+			description: "List every nature and the stats it raises and lowers",
+			callback:    commandNatures,
+		},
+		"moveinfo": {
+			name:        "moveinfo",
+			args:        "<move>",
+			description: "Show the type, power, accuracy, and effect of a move",
+			callback:    commandMoveInfo,
+		},
+		"poster": {
+			name:        "poster",
+			args:        "<file>",
+			description: "Write the whole national pokedex as a grid to print, with caught pokemon filled in (.html or text)",
+			callback:    commandPoster,
+		},
+		"copy": {
+			name:        "copy",
+			args:        "<pokemon> [--json]",
+			description: "Copy a summary of a caught pokemon to the clipboard, as text or JSON",
+			callback:    commandCopy,
+		},
+		"note": {
+			name:        "note",
+			args:        "<pokemon> <text> | clear <pokemon> | search <query>",
+			description: "Add, list, clear, or search notes on caught pokemon",
+			callback:    commandNote,
+			freeText:    true,
+		},
+		"macro": {
+			name:        "macro",
+			args:        "[define <name> \"<commands>\" | remove <name>]",
+			description: "Define macros that run a line of commands with parameters filled in, or list your macros",
+			callback:    commandMacro,
+			freeText:    true,
+		},
+		"remind": {
+			name:        "remind",
+			args:        "[<delay> <message> | cancel <number>]",
+			description: "Set a reminder to show after a delay (e.g. remind 10m check berries), or list your reminders",
+			callback:    commandRemind,
+			freeText:    true,
+		},
+		"schedule": {
+			name:        "schedule",
+			args:        "[hourly | daily | weekly <command> | cancel <number>]",
+			description: "Be reminded to run a command every hour, day, or week, or list the scheduled commands",
+			callback:    commandSchedule,
 		},
 		"save": {
 			name:        "save",
@@ -75,47 +435,252 @@ func getCommands() map[string]cliCommand {
 		},
 		"reset": {
 			name:        "reset",
+			args:        "[--dry-run]",
 			description: "Clear your Pokédex and start fresh",
 			callback:    commandReset,
+			dryRun:      true,
+		},
+		"sandbox": {
+			name:        "sandbox",
+			args:        "[on | off | commit]",
+			description: "Try out commands on a copy of your Pokédex, then discard or keep the changes",
+			callback:    commandSandbox,
+		},
+		"export": {
+			name:        "export",
+			args:        "[--select] ical <file> | [--select] showdown <file>",
+			description: "Export your collection to a file, like a calendar of your catches or a Showdown team",
+			callback:    commandExport,
+		},
+		"import": {
+			name:        "import",
+			args:        "showdown <file> | csv <file> --mapping <spec>",
+			description: "Import Pokémon from a Pokémon Showdown team or a CSV file",
+			callback:    commandImport,
+			dryRun:      true,
+		},
+		"report": {
+			name:        "report",
+			args:        "md <file> | weekly [--out <file> | --email] | email [<server> --from <address> --to <address> | off]",
+			description: "Write a Markdown report of your collection, or a digest of the last week to show, save, or email",
+			callback:    commandReport,
+		},
+		"card": {
+			name:        "card",
+			args:        "<pokemon> | export <file> [--name <name>]",
+			description: "Show a Pokémon's battle card, or export a trainer card with your favorites, ribbons, and completion as HTML or PNG",
+			callback:    commandCard,
+		},
+		"backup": {
+			name:        "backup",
+			args:        "[git <remote> [--every <n>] | push | off]",
+			description: "Keep a history of your saves in git and push it to a remote",
+			callback:    commandBackup,
+		},
+		"snapshot": {
+			name:        "snapshot",
+			args:        "create <name> | load <name> | list",
+			description: "Create, load, or list named snapshots of your save",
+			callback:    commandSnapshot,
+		},
+		"dataset": {
+			name:        "dataset",
+			args:        "[update]",
+			description: "Show or download the species dataset used offline",
+			callback:    commandDataset,
 		},
 		"autosave": {
 			name:        "autosave",
+			args:        "[on/off]",
 			description: "Enable or disable automatic saving (on/off)",
 			callback:    commandAutoSave,
 		},
+		"pagesize": {
+			name:        "pagesize",
+			args:        "[number]",
+			description: "Set how many locations a map page lists (1-100)",
+			callback:    commandPageSize,
+		},
+		"units": {
+			name:        "units",
+			args:        "[metric/imperial]",
+			description: "Show heights and weights in metric or imperial units",
+			callback:    commandUnits,
+		},
+		"versiongroup": {
+			name:        "versiongroup",
+			args:        "[name/all]",
+			description: "Limit moves to those learnable in one version group (e.g. versiongroup red-blue), or 'all'",
+			callback:    commandVersionGroup,
+		},
+		"accessible": {
+			name:        "accessible",
+			args:        "[on/off]",
+			description: "Turn plain, screen-reader-friendly output on or off",
+			callback:    commandAccessible,
+		},
+		"lang": {
+			name:        "lang",
+			args:        "[code]",
+			description: "Show or change the language of the interface (e.g. lang es)",
+			callback:    commandLang,
+		},
+		"unsaved": {
+			name:        "unsaved",
+			description: "List the changes that haven't been saved yet",
+			callback:    commandUnsaved,
+		},
+		"where": {
+			name:        "where",
+			args:        "[--json]",
+			description: "Show where you are and the state of the session: map page, location, filters, modes, and unsaved changes",
+			result:      whereResult,
+		},
+		"state": {
+			name:        "state",
+			args:        "[--json]",
+			description: "Same as 'where'",
+			result:      whereResult,
+		},
 		"saveinterval": {
 			name:        "saveinterval",
-			description: "Set how often to auto-save (number of changes)",
+			args:        "[number | duration | off]",
+			description: "Set how often to auto-save (number of changes, or a time like 5m)",
 			callback:    commandSaveInterval,
 		},
+		"savelog": {
+			name:        "savelog",
+			args:        "[count]",
+			description: "Show the latest writes to the save file and what triggered them",
+			callback:    commandSaveLog,
+		},
+		"usage": {
+			name:        "usage",
+			args:        "[on|off|clear|report|endpoint <url|off>]",
+			description: "Count the commands you run (opt-in, kept on this computer) and show the counts",
+			callback:    commandUsage,
+		},
+		"doctor": {
+			name:        "doctor",
+			description: "Check your pokedex for inconsistent or incomplete entries and offer to fix them",
+			callback:    commandDoctor,
+			dryRun:      true,
+		},
 		"map": {
 			name:        "map",
+			args:        "[--sort name/region] [--json]",
 			description: "Navigate to the first page of locations",
-			callback:    commandMap,
+			result:      mapResult,
 		},
 		"next": {
 			name:        "next",
+			args:        "[--json]",
 			description: "Navigate to the next page of locations",
-			callback:    commandNext,
+			result:      nextResult,
 		},
 		"prev": {
 			name:        "prev",
+			args:        "[--json]",
 			description: "Navigate to the previous page of locations",
-			callback:    commandPrev,
+			result:      prevResult,
 		},
 		"exit": {
 			name:        "exit",
 			description: "Exit the Pokedex",
 			callback:    commandExit,
 		},
+		"explain": {
+			name:        "explain",
+			args:        "<code>",
+			description: "Explain an error code and how to fix it",
+			callback:    commandExplain,
+		},
+		"version": {
+			name:        "version",
+			args:        "[--check] [--json]",
+			description: "Show the application version, or check for a newer one with --check",
+			result:      versionResult,
+		},
+		"trainer": {
+			name:        "trainer",
+			args:        "[--json]",
+			description: "Show your trainer level and what it unlocks",
+			result:      trainerResult,
+		},
+		"santa": {
+			name:        "santa",
+			args:        "<save file> <save file> <save file>... [--out <directory>]",
+			description: "Draw a Secret Santa trade between trainers' save files and write each a trade file",
+			callback:    commandSanta,
+		},
+		"theme": {
+			name:        "theme",
+			args:        "[classic | minimal | retro]",
+			description: "Show or change how the results of commands that accept --json are drawn",
+			callback:    commandTheme,
+		},
+		"whatsnew": {
+			name:        "whatsnew",
+			args:        "[--json]",
+			description: "Show what's new in this version of the Pokédex CLI",
+			result:      whatsnewResult,
+		},
 		"debug": {
 			name:        "debug",
 			description: "Toggle debug mode to show detailed error information",
 			callback:    commandToggleDebug,
 		},
+		"give": {
+			name:        "give",
+			args:        "<pokemon>",
+			description: "Add a pokemon to your Pokédex without catching it (debug mode only)",
+			callback:    commandGive,
+		},
 	}
 }
 
+// pokemonNameCommands lists the commands whose parameters are a single Pokémon name.
+// For these commands all words after the command are joined into one name, and
+// tab completion suggests Pokémon names.
+var pokemonNameCommands = map[string]bool{
+	"catch":     true,
+	"odds":      true,
+	"inspect":   true,
+	"lookup":    true,
+	"variants":  true,
+	"release":   true,
+	"showoff":   true,
+	"describe":  true,
+	"evolve":    true,
+	"devolve":   true,
+	"counter":   true,
+	"egggroups": true,
+	"pet":       true,
+	"play":      true,
+	"give":      true,
+}
+
+// preserveCaseCommands lists the commands whose parameters keep the capitalization
+// they were typed with, such as free-form note text and file paths. Command names
+// are still matched case-insensitively.
+var preserveCaseCommands = map[string]bool{
+	"note":      true,
+	"remind":    true,
+	"macro":     true,
+	"checklist": true,
+	"battle":    true,
+	"replay":    true,
+	"mqtt":      true,
+	"export":    true,
+	"import":    true,
+	"report":    true,
+	"card":      true,
+	"backup":    true,
+	"poster":    true,
+	"santa":     true,
+	"usage":     true,
+}
+
 // cleanInput normalizes and splits user input into words.
 // It handles whitespace and converts all text to lowercase for case-insensitive command matching.
 // This function is crucial for robust command processing, allowing users to input
@@ -147,105 +712,422 @@ func cleanInput(text string) []string {
 	return slice
 }
 
+// splitCommandLine splits a line of input into the command name and its parameters.
+// The parameters of commands that take a Pokémon name are joined into one name,
+// and those of commands in preserveCaseCommands keep their capitalization.
+//
+// Parameters:
+//   - input: The line of input, which must contain at least one word
+//
+// Returns:
+//   - The lowercase command name
+//   - The parameters for the command
+func splitCommandLine(input string) (string, []string) {
+	cleaned := cleanInput(input)
+	commandName := cleaned[0]
+	parameters := []string{}
+	if len(cleaned) > 1 {
+		// For Pokemon-related commands that take a Pokemon name,
+		// combine all parameters after the command into a single Pokemon name
+		if pokemonNameCommands[commandName] {
+			// Join all parameters as a single Pokemon name parameter
+			pokemonName := strings.Join(cleaned[1:], " ")
+			parameters = []string{pokemonName}
+		} else if preserveCaseCommands[commandName] {
+			// Keep the original capitalization of the parameters
+			parameters = strings.Fields(input)[1:]
+		} else {
+			// For other commands, use normal parameter handling
+			parameters = cleaned[1:]
+		}
+	}
+	return commandName, parameters
+}
+
+// runCommandLine runs a single command given on the program's command line
+// (e.g. "pokedexcli commands --json") instead of starting the REPL, so that
+// scripts and other tools can use the output directly. Changes the command
+// makes are saved before returning.
+//
+// Parameters:
+//   - cfg: The application configuration to be shared with the command
+//   - args: The command name followed by its parameters
+//
+// Returns:
+//   - The exit status for the program (non-zero if the command failed)
+func runCommandLine(cfg *config, args []string) int {
+	commandName, parameters := splitCommandLine(strings.Join(args, " "))
+	command, exists := getCommands()[commandName]
+	if !exists {
+		PrintUserError(errorhandling.NewInvalidInputError(i18n.Sprintf("Unknown command: %s", commandName), nil))
+		return 1
+	}
+	// The changes saved once the command has run are the command's
+	defer setSaveTrigger(cfg, commandName)()
+
+	status := 0
+	if err := executeCommand(cfg, command, parameters); err != nil {
+		PrintUserError(err)
+		status = 1
+	} else if cfg.commandErr != nil {
+		status = 1
+	}
+
+	cfg.mutex.RLock()
+	unsaved := cfg.changesSinceSync > 0
+	cfg.mutex.RUnlock()
+	if unsaved {
+		if err := savePokedexData(cfg); err != nil {
+			i18n.Printf("Warning: Could not save Pokédex data: %v\n", err)
+			status = 1
+		}
+	}
+
+	// With piped input the command runs in batch mode, which defers its saves
+	if err := commitBatch(cfg); err != nil {
+		i18n.Printf("Warning: Could not save Pokédex data: %v\n", err)
+		status = 1
+	}
+	return status
+}
+
 // startREPL begins the read-eval-print loop for the CLI application.
 // It continuously reads user input, processes commands, and displays the results
 // until the user chooses to exit the application with the 'exit' command or
 // an EOF signal is received (e.g., when piping commands).
 //
 // Each command is validated against the registered commands map, and if found,
-// is executed with any provided parameters. The REPL handles command errors
+// is executed with any provided parameters. A line can hold several commands,
+// separated by ";" or "&&" (see splitChainedCommands). The REPL handles command errors
 // by displaying appropriate error messages to the user, with more detailed
 // error information shown when debug mode is enabled.
 //
 // Parameters:
 //   - cfg: The application configuration to be shared with all commands
 //
+// When running in batch mode, the outcome of every command is recorded and a
+// summary is printed when the input ends.
+//
+// Returns:
+//   - The exit status for the program (non-zero if a command failed in batch mode)
+//
 // Side Effects:
 //   - Continuously reads from stdin and writes to stdout
 //   - Modifies application state through command execution
 //   - May read/write files through save/load commands
-func startREPL(cfg *config) {
-	reader := bufio.NewReader(os.Stdin)
+func startREPL(cfg *config) int {
+	startEventLoop(cfg)
+	startReminderTimer(cfg)
 	commands := getCommands()
 
 	// Display initial welcome and instructions
-	fmt.Println("Welcome to the Pokédex!")
-	fmt.Println("Type 'help' for a list of commands.")
+	i18n.Println("Welcome to the Pokédex!")
+	i18n.Println("Type 'help' for a list of commands.")
+	printEventGreetings()
 
 	// Set up debug logging if enabled
-	if cfg.debugMode {
-		log.SetOutput(os.Stderr)
-	} else {
-		// Discard logs when not in debug mode
-		log.SetOutput(nil)
-	}
+	configureDebugLogging(cfg.Settings().debugMode)
 
 	// Loop until exit
+	lineNumber := 0
 	for {
-		fmt.Print("Pokédex > ")
-		input, err := reader.ReadString('\n')
+		// Messages from background work are shown between commands, before the prompt
+		flushNotifications(cfg)
+
+		// The party's condition is shown before the prompt while any member is hurt,
+		// as is whether the sandbox is on, and an asterisk in the prompt shows
+		// there are unsaved changes
+		fmt.Print(partyPrompt(cfg))
+		if cfg.sandbox != nil {
+			fmt.Print(i18n.T("[sandbox] "))
+		}
+		if hasUnsavedChanges(cfg) {
+			fmt.Print(i18n.T("Pokédex* > "))
+		} else {
+			fmt.Print(i18n.T("Pokédex > "))
+		}
+		input, err := waitForLine(cfg)
+		if errors.Is(err, errInterrupted) {
+			if confirmExit(cfg) {
+				executeCommand(cfg, commands["exit"], nil)
+			}
+			continue
+		}
 		if err != nil {
 			// Check if it's an EOF error, which happens when piping commands
 			if err.Error() == "EOF" {
-				// Exit gracefully on EOF
-				fmt.Println("Exiting Pokédex. Goodbye!")
-				return
+				// Exit gracefully on EOF, once any auto-save is written
+				waitForBackgroundSaves(cfg)
+				i18n.Println("Exiting Pokédex. Goodbye!")
+				return finishBatch(cfg)
 			}
 
 			// For other errors, log and continue
-			fmt.Println("Error reading input:", err)
+			i18n.Printf("Error reading input: %v\n", err)
 			continue
 		}
 
+		lineNumber++
+
 		// Clean input and split into command and parameters
 		cleaned := cleanInput(input)
 		if len(cleaned) == 0 {
 			continue
 		}
-		commandName := cleaned[0]
-		parameters := []string{}
-		if len(cleaned) > 1 {
-			// For Pokemon-related commands that take a Pokemon name,
-			// combine all parameters after the command into a single Pokemon name
-			pokemonCommands := map[string]bool{
-				"catch":    true,
-				"inspect":  true,
-				"release":  true,
-				"showoff":  true,
-				"describe": true,
-				"evolve":   true,
-			}
-			
-			if pokemonCommands[commandName] && len(cleaned) > 1 {
-				// Join all parameters as a single Pokemon name parameter
-				pokemonName := strings.Join(cleaned[1:], " ")
-				parameters = []string{pokemonName}
-			} else {
-				// For other commands, use normal parameter handling
-				parameters = cleaned[1:]
+
+		// A line ending in a tab is a request to complete the last word of the
+		// last command on it
+		if strings.HasSuffix(strings.TrimRight(input, "\r\n"), "\t") {
+			if words := cleanInput(lastChainedCommand(input)); len(words) > 0 {
+				printCompletions(cfg, commands, words)
 			}
+			continue
 		}
 
-		// Find the command in our available commands
-		command, exists := commands[commandName]
-		if !exists {
-			fmt.Printf("Unknown command: %s\n", commandName)
-			fmt.Println("Type 'help' for a list of commands.")
-			fmt.Println("-----")
+		runREPLLine(cfg, commands, input, lineNumber)
+	}
+}
+
+// runREPLLine runs the commands on a line typed at the prompt in turn,
+// skipping those chained with "&&" to a command that failed or was skipped.
+//
+// Parameters:
+//   - cfg: The application configuration to be shared with the commands
+//   - commands: The registry of available commands
+//   - input: The line of input
+//   - lineNumber: The number of the line, for the batch summary
+//
+// Returns:
+//   - The number of commands that ran and failed, or were skipped
+func runREPLLine(cfg *config, commands map[string]cliCommand, input string, lineNumber int) int {
+	failed := 0
+	succeeded := true
+	for _, chained := range splitChainedCommands(input) {
+		if chained.afterSuccess && !succeeded {
+			i18n.Printf("Skipped '%s' because the command before it failed.\n", chained.text)
+			failed++
 			continue
 		}
+		succeeded = runREPLCommand(cfg, commands, chained.text, lineNumber)
+		if !succeeded {
+			failed++
+		}
+	}
+	return failed
+}
 
-		// Execute the command
-		err = command.callback(cfg, parameters)
-		if err != nil {
-			// Log the full error for debugging
-			if cfg.debugMode {
-				log.Printf("ERROR: [%s] %v", commandName, err)
-			}
+// runREPLCommand runs one command typed at the prompt, or one of the user's
+// macros (see macro_utils.go), showing any error, and records its outcome in
+// batch mode.
+//
+// Parameters:
+//   - cfg: The application configuration to be shared with the command
+//   - commands: The registry of available commands
+//   - text: The command and its parameters
+//   - lineNumber: The line of input the command is on, for the batch summary
+//
+// Returns:
+//   - Whether the command succeeded
+func runREPLCommand(cfg *config, commands map[string]cliCommand, text string, lineNumber int) bool {
+	commandName, parameters := splitCommandLine(text)
+
+	// Find the command in our available commands
+	command, exists := commands[commandName]
+	if !exists {
+		if body, ok := cfg.Macro(commandName); ok {
+			return runMacro(cfg, commands, commandName, body, strings.Fields(text)[1:], lineNumber)
+		}
+		i18n.Printf("Unknown command: %s\n", commandName)
+		i18n.Println("Type 'help' for a list of commands.")
+		printSeparator()
+		if cfg.batch != nil {
+			cfg.batch.record(lineNumber, text,
+				errorhandling.NewInvalidInputError(i18n.Sprintf("Unknown command: %s", commandName), nil))
+		}
+		return false
+	}
+
+	// Execute the command through the middleware pipeline
+	cfg.commandErr = nil
+	err := executeCommand(cfg, command, parameters)
+	failure := err
+	if failure == nil {
+		failure = cfg.commandErr
+	}
+	if cfg.batch != nil {
+		cfg.batch.record(lineNumber, text, failure)
+	}
+	if err != nil {
+		// Log the full error for debugging
+		if cfg.Settings().debugMode {
+			log.Printf("ERROR: [%s] %v", commandName, err)
+		}
 
-			// Format error message for display to user
-			fmt.Printf("Error: %s\n", errorhandling.FormatUserMessage(err))
-			fmt.Println("-----")
+		// Format error message for display to user
+		PrintUserError(err)
+	}
+	return failure == nil
+}
+
+// chainedCommand is one of the commands on a line of input. A line can hold
+// several, separated by ";" to run each in turn, or by "&&" to run the next
+// only if the one before it succeeded (e.g. "map; explore 3 && catch pidgey").
+type chainedCommand struct {
+	text         string // The command and its parameters
+	afterSuccess bool   // Whether it only runs if the command before it succeeded
+}
+
+// splitChainedCommands splits a line of input into the commands on it. Empty
+// commands, as in "map;;", are left out, and separators between double quotes
+// are part of the command they're in (as in a macro's definition or a note).
+//
+// Parameters:
+//   - input: The line of input
+//
+// Returns:
+//   - The commands on the line, in order
+func splitChainedCommands(input string) []chainedCommand {
+	var chain []chainedCommand
+	afterSuccess, start := false, 0
+	add := func(end int) {
+		if text := strings.TrimSpace(input[start:end]); text != "" {
+			chain = append(chain, chainedCommand{text: text, afterSuccess: afterSuccess})
+		}
+	}
+	scanChainSeparators(input, func(at, next int, beforeNext bool) {
+		add(at)
+		afterSuccess, start = beforeNext, next
+	})
+	add(len(input))
+	return chain
+}
+
+// lastChainedCommand returns the text of the last command on a line of input,
+// which is the one tab completion completes. As in splitChainedCommands,
+// separators between double quotes don't start a command.
+func lastChainedCommand(input string) string {
+	start := 0
+	scanChainSeparators(input, func(_, next int, _ bool) {
+		start = next
+	})
+	return input[start:]
+}
+
+// scanChainSeparators finds the separators between the commands on a line of
+// input, leaving out those between double quotes.
+//
+// Parameters:
+//   - input: The line of input
+//   - found: Called for each separator, in order, with where it starts, where
+//     the next command starts, and whether the next command only runs if the
+//     one before it succeeded ("&&" rather than ";")
+func scanChainSeparators(input string, found func(at, next int, afterSuccess bool)) {
+	quoted := false
+	for i := 0; i < len(input); i++ {
+		switch {
+		case input[i] == '"':
+			quoted = !quoted
+		case quoted:
+		case input[i] == ';':
+			found(i, i+1, false)
+		case strings.HasPrefix(input[i:], "&&"):
+			found(i, i+2, true)
+			i++
+		}
+	}
+}
+
+// confirmExit asks whether to exit after Ctrl+C is pressed at the prompt, since
+// it may have been meant for a command that had already finished. Pressing
+// Ctrl+C again also exits. In batch mode, there's nobody to ask.
+//
+// Parameters:
+//   - cfg: The application configuration
+//
+// Returns:
+//   - true if the program should exit
+func confirmExit(cfg *config) bool {
+	fmt.Println()
+	if cfg.batch != nil {
+		return true
+	}
+	ctx, stop := interruptContext(cfg)
+	defer stop()
+	cfg.commandCtx = ctx
+	defer func() { cfg.commandCtx = nil }()
+	return confirm(cfg, i18n.T("Exit the Pokédex?")) || ctx.Err() != nil
+}
+
+// configureDebugLogging directs log output to stderr when debug mode is enabled
+// and discards it otherwise.
+//
+// Parameters:
+//   - enabled: Whether debug mode is enabled
+func configureDebugLogging(enabled bool) {
+	if enabled {
+		log.SetOutput(os.Stderr)
+	} else {
+		log.SetOutput(io.Discard)
+	}
+}
+
+// printCompletions displays possible completions for a partially typed line.
+// Users request completions by ending a line with a tab (e.g. "catch char<TAB>").
+//
+// Parameters:
+//   - cfg: The application configuration containing the name index
+//   - commands: The registry of available commands
+//   - words: The cleaned words of the input line
+func printCompletions(cfg *config, commands map[string]cliCommand, words []string) {
+	matches, err := completions(cfg, commands, words)
+	if err != nil {
+		PrintUserError(err)
+		return
+	}
+	if len(matches) == 0 {
+		i18n.Println("No completions found.")
+	} else {
+		fmt.Println(strings.Join(matches, "  "))
+	}
+	printSeparator()
+}
+
+// completions finds the completions for a partially typed line. A lone word is
+// completed against the command names, while the parameter of a Pokémon
+// command is completed against the Pokémon name index. For the commands in
+// exploredNameCommands, the Pokémon found in the area explored last come
+// first, and are still offered if the index can't be loaded.
+//
+// Parameters:
+//   - cfg: The application configuration containing the name index
+//   - commands: The registry of available commands
+//   - words: The cleaned words of the input line
+//
+// Returns:
+//   - The completions, in the order they should be shown
+//   - An error if the name index is needed but can't be loaded, and there are
+//     no explored Pokémon to offer instead
+func completions(cfg *config, commands map[string]cliCommand, words []string) ([]string, error) {
+	var matches []string
+	if len(words) == 1 {
+		for name := range commands {
+			if strings.HasPrefix(name, words[0]) {
+				matches = append(matches, name)
+			}
+		}
+		sort.Strings(matches)
+	} else if pokemonNameCommands[words[0]] {
+		prefix := ConvertToAPIFormat(strings.Join(words[1:], " "))
+		if slices.Contains(exploredNameCommands, words[0]) {
+			matches = exploredWithPrefix(cfg, prefix)
+		}
+		idx, err := getNameIndex(cfg)
+		if err != nil && len(matches) == 0 {
+			return nil, err
+		}
+		if err == nil {
+			matches = mergeNames(matches, idx.WithPrefix(prefix))
 		}
 	}
+	return matches, nil
 }
@@ -0,0 +1,187 @@
+// This file contains the replay file format. A replay records what happened in
+// a battle, or a series of battles, turn by turn, so that it can be played back
+// later with the replay command and shared with others. Replays store names in
+// API format and are described in the viewer's language when played back.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// replayVersion is the version of the replay file format. Replays from a newer
+// version of the app can't be played back.
+const replayVersion = 4
+
+// battleReplay is a recorded battle series, as saved in a replay file.
+type battleReplay struct {
+	Version  int            `json:"version"`
+	Recorded time.Time      `json:"recorded"`
+	BestOf   int            `json:"best_of"`
+	Teams    [2]replayTeam  `json:"teams"`
+	Battles  []replayBattle `json:"battles"`
+}
+
+// replayTeam is one side of a recorded battle.
+type replayTeam struct {
+	Player  string   `json:"player"`
+	Pokemon []string `json:"pokemon"`
+}
+
+// replayBattle is one recorded battle. The first turn holds the Pokémon sent
+// out at the start, and each turn after it holds what happened that turn,
+// including the Pokémon sent out to replace any that fainted.
+type replayBattle struct {
+	Turns  [][]replayEvent `json:"turns"`
+	Winner int             `json:"winner"`
+}
+
+// forfeited reports whether the battle ended with a side forfeiting.
+func (b replayBattle) forfeited() bool {
+	if len(b.Turns) == 0 {
+		return false
+	}
+	last := b.Turns[len(b.Turns)-1]
+	return len(last) > 0 && last[len(last)-1].Kind == eventKindNames[eventForfeit]
+}
+
+// replayEvent is a battle event, as saved in a replay file.
+type replayEvent struct {
+	Kind          string  `json:"kind"`
+	Player        string  `json:"player"`
+	Pokemon       string  `json:"pokemon"`
+	Move          string  `json:"move,omitempty"`
+	MoveType      string  `json:"move_type,omitempty"`
+	Target        string  `json:"target,omitempty"`
+	Damage        int     `json:"damage,omitempty"`
+	Effectiveness float64 `json:"effectiveness,omitempty"`
+	TargetHP      int     `json:"target_hp,omitempty"`
+	TargetMaxHP   int     `json:"target_max_hp,omitempty"`
+	Status        string  `json:"status,omitempty"`
+	Field         string  `json:"field,omitempty"`
+	Ability       string  `json:"ability,omitempty"`
+	Item          string  `json:"item,omitempty"`
+}
+
+// eventKindNames are the names battle event kinds are saved under.
+var eventKindNames = map[battleEventKind]string{
+	eventSwitch:        "switch",
+	eventHit:           "hit",
+	eventMiss:          "miss",
+	eventNoDamage:      "no-damage",
+	eventFaint:         "faint",
+	eventUse:           "use",
+	eventStatus:        "status",
+	eventStatusDamage:  "status-damage",
+	eventImmobile:      "immobile",
+	eventRecover:       "recover",
+	eventFieldStart:    "field-start",
+	eventFieldEnd:      "field-end",
+	eventWeatherDamage: "weather-damage",
+	eventFieldHeal:     "field-heal",
+	eventForfeit:       "forfeit",
+	eventItemHeal:      "item-heal",
+}
+
+// newBattleReplay starts a replay of a battle series between two teams.
+func newBattleReplay(teams [2]*battleTeam, bestOf int, now time.Time) battleReplay {
+	replay := battleReplay{Version: replayVersion, Recorded: now, BestOf: bestOf}
+	for i, team := range teams {
+		replay.Teams[i].Player = team.player
+		for _, member := range team.members {
+			replay.Teams[i].Pokemon = append(replay.Teams[i].Pokemon, member.name)
+		}
+	}
+	return replay
+}
+
+// recordEvents converts battle events into the form they're saved in.
+func recordEvents(events []battleEvent) []replayEvent {
+	recorded := make([]replayEvent, 0, len(events))
+	for _, e := range events {
+		recorded = append(recorded, replayEvent{
+			Kind:          eventKindNames[e.kind],
+			Player:        e.player,
+			Pokemon:       e.pokemon,
+			Move:          e.move.name,
+			MoveType:      e.move.typeName,
+			Target:        e.target,
+			Damage:        e.damage,
+			Effectiveness: e.effectiveness,
+			TargetHP:      e.targetHP,
+			TargetMaxHP:   e.targetMaxHP,
+			Status:        string(e.status),
+			Field:         string(e.field),
+			Ability:       e.ability,
+			Item:          e.item,
+		})
+	}
+	return recorded
+}
+
+// event converts a saved event back into a battle event, so that it can be described.
+//
+// Returns:
+//   - The battle event
+//   - An error if the event's kind is unknown
+func (e replayEvent) event() (battleEvent, error) {
+	for kind, name := range eventKindNames {
+		if name == e.Kind {
+			return battleEvent{
+				kind:          kind,
+				player:        e.Player,
+				pokemon:       e.Pokemon,
+				move:          battleMove{name: e.Move, typeName: e.MoveType},
+				target:        e.Target,
+				damage:        e.Damage,
+				effectiveness: e.Effectiveness,
+				targetHP:      e.TargetHP,
+				targetMaxHP:   e.TargetMaxHP,
+				status:        battleStatus(e.Status),
+				item:          e.Item,
+			}, nil
+		}
+	}
+	return battleEvent{}, fmt.Errorf("unknown event kind %q", e.Kind)
+}
+
+// writeReplay saves a replay to a file, replacing the file if it exists.
+func writeReplay(path string, replay battleReplay) error {
+	encoded, err := json.MarshalIndent(replay, "", "  ")
+	if err == nil {
+		err = os.WriteFile(path, encoded, 0644)
+	}
+	if err != nil {
+		return errorhandling.NewInvalidInputError(i18n.Sprintf("Could not write replay file '%s'", path), err)
+	}
+	return nil
+}
+
+// readReplay loads a replay from a file.
+//
+// Returns:
+//   - The replay
+//   - An error if the file can't be read, isn't a replay, or is from a newer version of the app
+func readReplay(path string) (battleReplay, error) {
+	var replay battleReplay
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, &replay)
+	}
+	if err == nil && (replay.Version < 1 || len(replay.Battles) == 0) {
+		err = fmt.Errorf("not a replay file")
+	}
+	if err != nil {
+		return replay, errorhandling.NewInvalidInputError(i18n.Sprintf("Could not read replay file '%s'", path), err)
+	}
+	if replay.Version > replayVersion {
+		return replay, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The replay file '%s' was made by a newer version of the app", path), nil)
+	}
+	return replay, nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestReplayRoundTrip tests saving a replay and loading it back
+func TestReplayRoundTrip(t *testing.T) {
+	strike := battleMove{name: "earthquake", typeName: "ground", power: 100}
+	// Replays keep only what's needed to describe a move
+	described := battleMove{name: strike.name, typeName: strike.typeName}
+	events := []battleEvent{
+		{kind: eventHit, player: "Player 2", pokemon: "dugtrio", move: described, target: "pikachu",
+			damage: 80, effectiveness: 2, targetHP: 0, targetMaxHP: 80},
+		{kind: eventFaint, player: "Player 1", pokemon: "pikachu"},
+	}
+	teams := [2]*battleTeam{
+		{player: "Player 1", members: []*battler{testBattler("pikachu", 80, []string{"electric"}, strike)}},
+		{player: "Player 2", members: []*battler{testBattler("dugtrio", 80, []string{"ground"}, strike)}},
+	}
+	replay := newBattleReplay(teams, 1, time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC))
+	replay.Battles = append(replay.Battles, replayBattle{Turns: [][]replayEvent{recordEvents(events)}, Winner: 1})
+
+	path := filepath.Join(t.TempDir(), "final.json")
+	if err := writeReplay(path, replay); err != nil {
+		t.Fatalf("Unexpected error writing: %v", err)
+	}
+	loaded, err := readReplay(path)
+	if err != nil {
+		t.Fatalf("Unexpected error reading: %v", err)
+	}
+	if loaded.Teams[1].Pokemon[0] != "dugtrio" || loaded.Battles[0].Winner != 1 || !loaded.Recorded.Equal(replay.Recorded) {
+		t.Errorf("Unexpected replay: %+v", loaded)
+	}
+	for i, recorded := range loaded.Battles[0].Turns[0] {
+		event, err := recorded.event()
+		if err != nil || event != events[i] {
+			t.Errorf("Event %d: expected %+v, got %+v (%v)", i, events[i], event, err)
+		}
+	}
+}
+
+// TestReadReplayErrors tests loading files that can't be played back
+func TestReadReplayErrors(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"empty.json":   `{"version": 1, "battles": []}`,
+		"save.json":    `{"pokedex": {}}`,
+		"newer.json":   `{"version": 99, "battles": [{"turns": [], "winner": 0}]}`,
+		"invalid.json": `not json`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readReplay(path); err == nil {
+			t.Errorf("readReplay(%s): expected an error", name)
+		}
+	}
+	if _, err := readReplay(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,206 @@
+// This file builds the Markdown report written by the report command: a
+// summary of the user's collection with favorites, highlights, ribbons, and
+// tables of every Pokémon by generation, suitable for posting on GitHub or a blog.
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// favoritesBox is the box whose Pokémon are the user's favorites. Reports
+// list them in their own section.
+const favoritesBox = "favorites"
+
+// reportGeneration is the Pokémon of one generation in a report.
+type reportGeneration struct {
+	Name    string               // The generation's name for display, e.g. "Generation I"
+	Pokemon []pokedex.NamedEntry // The Pokémon introduced in the generation, sorted by name
+}
+
+// collectionReport is everything a report shows.
+type collectionReport struct {
+	Stats       pokedex.Stats        // Counts of the Pokémon in the Pokédex
+	Entries     []pokedex.NamedEntry // Every Pokémon, sorted by name
+	Favorites   []pokedex.NamedEntry // The Pokémon in the favorites box, sorted by name
+	Generations []reportGeneration   // The Pokémon by generation, in generation order, with unknown generations last
+}
+
+// buildReport gathers what a report shows from the Pokédex, looking up the
+// generation of each Pokémon's species.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex and API client
+//
+// Returns:
+//   - The report
+func buildReport(cfg *config) collectionReport {
+	report := collectionReport{Stats: cfg.pokedex.Stats(), Entries: cfg.pokedex.List()}
+
+	byGeneration := make(map[int]*reportGeneration)
+	var unknown reportGeneration
+	for _, named := range report.Entries {
+		if named.Entry.Box == favoritesBox {
+			report.Favorites = append(report.Favorites, named)
+		}
+		id, name, ok := pokemonGeneration(cfg, named.Name, named.Entry)
+		if !ok {
+			unknown.Pokemon = append(unknown.Pokemon, named)
+			continue
+		}
+		if byGeneration[id] == nil {
+			byGeneration[id] = &reportGeneration{Name: formatGenerationName(name)}
+		}
+		byGeneration[id].Pokemon = append(byGeneration[id].Pokemon, named)
+	}
+	for _, id := range slices.Sorted(maps.Keys(byGeneration)) {
+		report.Generations = append(report.Generations, *byGeneration[id])
+	}
+	if len(unknown.Pokemon) > 0 {
+		unknown.Name = i18n.T("Unknown")
+		report.Generations = append(report.Generations, unknown)
+	}
+	return report
+}
+
+// writeMarkdownReport writes a report as Markdown.
+//
+// Parameters:
+//   - w: Where to write the report
+//   - report: The report to write
+//   - now: When the report was made, shown under its title
+//
+// Returns:
+//   - An error if writing fails
+func writeMarkdownReport(w io.Writer, report collectionReport, now time.Time) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", i18n.T("My Pokédex"))
+	fmt.Fprintf(&b, "_%s_\n\n", i18n.Sprintf("Generated by pokedexcli on %s", now.Format("2006-01-02")))
+
+	fmt.Fprintf(&b, "## %s\n\n", i18n.T("Summary"))
+	fmt.Fprintf(&b, "- **%s:** %d\n", i18n.T("Pokémon caught"), report.Stats.Total)
+	fmt.Fprintf(&b, "- **%s:** %d\n", i18n.T("Pokémon seen"), report.Stats.Seen)
+	fmt.Fprintf(&b, "- **%s:** %d\n", i18n.T("Evolved since being caught"), report.Stats.Evolved)
+	if len(report.Stats.Types) > 0 {
+		types := slices.SortedFunc(maps.Keys(report.Stats.Types), func(a, b string) int {
+			return cmp.Or(cmp.Compare(report.Stats.Types[b], report.Stats.Types[a]), cmp.Compare(a, b))
+		})
+		fmt.Fprintf(&b, "- **%s:** %s (%d)\n", i18n.T("Most common type"), FormatTypeName(types[0]), report.Stats.Types[types[0]])
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "## %s\n\n", i18n.T("Favorites"))
+	if len(report.Favorites) == 0 {
+		fmt.Fprintf(&b, "%s\n\n", i18n.Sprintf("Move Pokémon into a box named '%s' to list them here.", favoritesBox))
+	} else {
+		writeReportTable(&b, report.Favorites)
+	}
+
+	if len(report.Entries) > 0 {
+		fmt.Fprintf(&b, "## %s\n\n", i18n.T("Highlights"))
+		writeReportHighlights(&b, report.Entries)
+	}
+
+	fmt.Fprintf(&b, "## %s\n\n", i18n.T("Ribbons"))
+	writeReportRibbons(&b, report.Entries)
+
+	fmt.Fprintf(&b, "## %s\n\n", i18n.T("Collection by generation"))
+	if len(report.Generations) == 0 {
+		fmt.Fprintf(&b, "%s\n\n", i18n.T("You have not caught any Pokémon yet"))
+	}
+	for _, generation := range report.Generations {
+		fmt.Fprintf(&b, "### %s (%d)\n\n", generation.Name, len(generation.Pokemon))
+		writeReportTable(&b, generation.Pokemon)
+	}
+
+	_, err := io.WriteString(w, strings.TrimSuffix(b.String(), "\n"))
+	return err
+}
+
+// writeReportTable writes a table of Pokémon with their types, levels, and
+// where and when they were caught.
+func writeReportTable(b *strings.Builder, entries []pokedex.NamedEntry) {
+	fmt.Fprintf(b, "| %s | %s | %s | %s | %s |\n",
+		i18n.T("Pokémon"), i18n.T("Types"), i18n.T("Level"), i18n.T("Caught in"), i18n.T("Caught on"))
+	b.WriteString("|---|---|--:|---|---|\n")
+	for _, named := range entries {
+		entry := named.Entry
+		caughtAt, caughtOn := "", ""
+		if entry.CaughtAt != "" {
+			caughtAt = FormatLocationName(entry.CaughtAt)
+		}
+		if !entry.CaughtOn.IsZero() {
+			caughtOn = entry.CaughtOn.Local().Format("2006-01-02")
+		}
+		fmt.Fprintf(b, "| %s | %s | %d | %s | %s |\n",
+			markdownCell(FormatPokemonName(named.Name)), FormatTypeList(pokemonTypes(entry.PokemonDataResp)),
+			entry.CurrentLevel(), markdownCell(caughtAt), caughtOn)
+	}
+	b.WriteString("\n")
+}
+
+// writeReportHighlights writes the standout Pokémon of the collection: the
+// highest level, the highest base stat total, the most battles won, and the
+// most recent catch.
+func writeReportHighlights(b *strings.Builder, entries []pokedex.NamedEntry) {
+	best := func(value func(pokedex.Entry) int) pokedex.NamedEntry {
+		// Ties go to the first Pokémon by name, since the entries are sorted
+		return slices.MaxFunc(entries, func(a, b pokedex.NamedEntry) int {
+			return cmp.Compare(value(a.Entry), value(b.Entry))
+		})
+	}
+	highlight := func(label string, named pokedex.NamedEntry, detail string) {
+		fmt.Fprintf(b, "- **%s:** %s (%s)\n", label, markdownCell(FormatPokemonName(named.Name)), detail)
+	}
+
+	highest := best(pokedex.Entry.CurrentLevel)
+	highlight(i18n.T("Highest level"), highest, i18n.Sprintf("level %d", highest.Entry.CurrentLevel()))
+	strongest := best(func(e pokedex.Entry) int { return baseStatTotal(e.PokemonDataResp) })
+	highlight(i18n.T("Highest base stat total"), strongest, fmt.Sprint(baseStatTotal(strongest.Entry.PokemonDataResp)))
+	if veteran := best(func(e pokedex.Entry) int { return e.BattlesWon }); veteran.Entry.BattlesWon > 0 {
+		highlight(i18n.T("Most battles won"), veteran, i18n.Sprintf("%d rounds", veteran.Entry.BattlesWon))
+	}
+	if latest := best(func(e pokedex.Entry) int { return int(e.CaughtOn.Unix()) }); !latest.Entry.CaughtOn.IsZero() {
+		highlight(i18n.T("Most recent catch"), latest, latest.Entry.CaughtOn.Local().Format("2006-01-02"))
+	}
+	b.WriteString("\n")
+}
+
+// writeReportRibbons writes each ribbon earned with the Pokémon that earned it,
+// in the order ribbons are listed.
+func writeReportRibbons(b *strings.Builder, entries []pokedex.NamedEntry) {
+	earned := make(map[string][]string)
+	for _, named := range entries {
+		for _, id := range named.Entry.Ribbons {
+			earned[id] = append(earned[id], markdownCell(FormatPokemonName(named.Name)))
+		}
+	}
+	if len(earned) == 0 {
+		fmt.Fprintf(b, "%s\n\n", i18n.T("No ribbons earned yet."))
+		return
+	}
+	fmt.Fprintf(b, "| %s | %s |\n|---|---|\n", i18n.T("Ribbon"), i18n.T("Earned by"))
+	for _, r := range ribbons {
+		if names, ok := earned[r.id]; ok {
+			fmt.Fprintf(b, "| %s | %s |\n", ribbonName(r.id), strings.Join(names, ", "))
+		}
+	}
+	b.WriteString("\n")
+}
+
+// markdownCell escapes text for a Markdown table cell, so that characters
+// like | and * in names and notes are shown as they are.
+func markdownCell(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// markdownEscaper escapes the characters that have a meaning in Markdown text.
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", "&lt;")
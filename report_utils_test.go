@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestWriteMarkdownReport tests the sections of a report
+func TestWriteMarkdownReport(t *testing.T) {
+	pikachu := pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric"))
+	pikachu.Box = favoritesBox
+	pikachu.BattlesWon = 12
+	pikachu.Ribbons = []string{ribbonVictory, ribbonVeteran}
+	pikachu.CaughtOn = time.Date(2026, 3, 14, 12, 0, 0, 0, time.Local)
+	pikachu.CaughtAt = "viridian-forest-area"
+	squirtle := pokedex.NewEntry(testMatchupPokemon(t, "squirtle", 314, "water"))
+	squirtle.Ribbons = []string{ribbonVictory}
+	entries := []pokedex.NamedEntry{{Name: "pikachu", Entry: pikachu}, {Name: "squirtle", Entry: squirtle}}
+
+	report := collectionReport{
+		Stats:       pokedex.Stats{Total: 2, Seen: 5, Types: map[string]int{"electric": 1, "water": 1}},
+		Entries:     entries,
+		Favorites:   entries[:1],
+		Generations: []reportGeneration{{Name: "Generation I", Pokemon: entries}},
+	}
+	var out strings.Builder
+	if err := writeMarkdownReport(&out, report, time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("writeMarkdownReport returned an error: %v", err)
+	}
+	markdown := out.String()
+	for _, want := range []string{
+		"_Generated by pokedexcli on 2026-10-15_",
+		"- **Pokémon seen:** 5",
+		"- **Most common type:** Electric (1)",
+		"## Favorites\n\n| Pokémon | Types | Level | Caught in | Caught on |\n|---|---|--:|---|---|\n| Pikachu | Electric |",
+		"| Viridian Forest Area | 2026-03-14 |",
+		"- **Highest base stat total:** Pikachu (320)",
+		"- **Most battles won:** Pikachu (12 rounds)",
+		"| Victory Ribbon | Pikachu, Squirtle |",
+		"| Veteran Ribbon | Pikachu |",
+		"### Generation I (2)",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected the report to contain %q, got:\n%s", want, markdown)
+		}
+	}
+}
+
+// TestMarkdownCell tests escaping text for table cells
+func TestMarkdownCell(t *testing.T) {
+	if got := markdownCell("a|b *c* _d_ <e>"); got != `a\|b \*c\* \_d\_ &lt;e>` {
+		t.Errorf("Unexpected escaping %q", got)
+	}
+}
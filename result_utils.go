@@ -0,0 +1,80 @@
+// This file contains structured command results. Instead of printing, a
+// command can return a commandResult: the message people see in the REPL and
+// the data behind it. Running the command prints the message as usual, or,
+// with --json, the whole result as JSON, so scripts, tests, and other front
+// ends all consume the same result. Commands opt in by setting the result
+// field of their cliCommand instead of callback; the others still print
+// directly, and refuse --json unless they handle it themselves (see
+// jsonMiddleware).
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// jsonFlag is the flag that prints a command's result as JSON.
+const jsonFlag = "--json"
+
+// commandResult is what a command produced.
+type commandResult struct {
+	Command string `json:"command"`        // The command that produced it
+	Message string `json:"message"`        // What the command reports, as shown in the REPL, in the user's language
+	Data    any    `json:"data,omitempty"` // The data behind the message, with stable field names for programs
+}
+
+// resultFunc is a command that returns its result instead of printing it.
+type resultFunc func(*config, []string) (commandResult, error)
+
+// resultCallback returns the callback that runs a command returning a result
+// and prints it: drawn in the user's theme (see theme_utils.go), or as JSON
+// if --json is given. Errors are handled like those of other commands.
+//
+// Parameters:
+//   - command: The command, whose result field is set
+//
+// Returns:
+//   - The callback to run through the middleware pipeline
+func resultCallback(command cliCommand) commandFunc {
+	return func(cfg *config, params []string) error {
+		params, asJSON := takeFlag(params, jsonFlag)
+		result, err := command.result(cfg, params)
+		if err == nil {
+			result.Command = command.name
+			if asJSON {
+				err = writeResultJSON(os.Stdout, result)
+			} else {
+				themeRenderer(cfg.Settings().theme).render(os.Stdout, result)
+			}
+		}
+		if err != nil {
+			// Use standardized error handling
+			if HandleCommandError(cfg, command.name, err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// writeResultJSON writes a command's result as indented JSON on its own, so
+// that it can be piped straight into a parser.
+//
+// Parameters:
+//   - w: Where to write the result
+//   - result: The result to write
+//
+// Returns:
+//   - An error if the result can't be encoded or written
+func writeResultJSON(w io.Writer, result commandResult) error {
+	encoded, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return errorhandling.NewInternalError(fmt.Sprintf("error encoding the result of '%s'", result.Command), err)
+	}
+	_, err = fmt.Fprintln(w, string(encoded))
+	return err
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+)
+
+// TestTakeFlag tests removing a flag given on its own or within a joined Pokémon name
+func TestTakeFlag(t *testing.T) {
+	params, found := takeFlag([]string{"pikachu --json", "--ball", "great-ball"}, jsonFlag)
+	if !found || !reflect.DeepEqual(params, []string{"pikachu", "--ball", "great-ball"}) {
+		t.Errorf("Expected the flag to be removed from the name, got %v, %v", params, found)
+	}
+	params, found = takeFlag([]string{"--check"}, jsonFlag)
+	if found || !reflect.DeepEqual(params, []string{"--check"}) {
+		t.Errorf("Expected the parameters to be left alone, got %v, %v", params, found)
+	}
+}
+
+// TestWriteResultJSON tests that a result is written as JSON with its data
+func TestWriteResultJSON(t *testing.T) {
+	result := commandResult{Command: "odds", Message: "Capture rate of Pikachu: 190/255\n", Data: catchOdds{Pokemon: "pikachu", CaptureRate: 190}}
+	var out strings.Builder
+	if err := writeResultJSON(&out, result); err != nil {
+		t.Fatalf("writeResultJSON returned an error: %v", err)
+	}
+	var decoded struct {
+		Command string
+		Message string
+		Data    map[string]any
+	}
+	if err := json.Unmarshal([]byte(out.String()), &decoded); err != nil {
+		t.Fatalf("Expected valid JSON, got %v:\n%s", err, out.String())
+	}
+	if decoded.Command != "odds" || decoded.Message != result.Message || decoded.Data["pokemon"] != "pikachu" || decoded.Data["capture_rate"] != 190.0 {
+		t.Errorf("Unexpected result decoded from:\n%s", out.String())
+	}
+}
+
+// TestVersionResult tests the version information and message
+func TestVersionResult(t *testing.T) {
+	result, err := versionResult(nil, nil)
+	if err != nil {
+		t.Fatalf("versionResult returned an error: %v", err)
+	}
+	info, ok := result.Data.(versionInfo)
+	if !ok || info.Version != appVersion() || info.GoVersion != runtime.Version() || info.Platform != runtime.GOOS+"/"+runtime.GOARCH {
+		t.Errorf("Unexpected version information: %+v", result.Data)
+	}
+	if !strings.HasPrefix(result.Message, "Pokédex CLI "+appVersion()+"\n") {
+		t.Errorf("Unexpected message: %q", result.Message)
+	}
+	if _, err := versionResult(nil, []string{"--latest"}); err == nil {
+		t.Error("Expected an error for an unknown parameter")
+	}
+}
+
+// TestJSONMiddleware tests that --json is refused by commands that can't
+// print their output as JSON, and passed on to those that can
+func TestJSONMiddleware(t *testing.T) {
+	commands := getCommands()
+	ran := false
+	next := func(*config, []string) error {
+		ran = true
+		return nil
+	}
+
+	err := jsonMiddleware(commands["bookmark"], next)(&config{}, []string{"--json"})
+	if !errorhandling.IsInvalidInputError(err) || ran {
+		t.Errorf("Expected bookmark to refuse --json, got %v", err)
+	}
+	for _, name := range []string{"catch", "copy", "commands"} {
+		if err := jsonMiddleware(commands[name], next)(&config{}, []string{"pikachu --json"}); err != nil {
+			t.Errorf("Expected %s to accept --json, got %v", name, err)
+		}
+	}
+	if !ran {
+		t.Error("Expected the commands that accept --json to run")
+	}
+}
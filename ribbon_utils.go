@@ -0,0 +1,121 @@
+// This file contains the ribbons Pokémon can earn and the battle milestones
+// that award them. Ribbons are stored on each Pokédex entry by identifier, so
+// renaming a ribbon's display name doesn't affect saved data.
+package main
+
+import "github.com/bmlevitt/pokedexcli/internal/i18n"
+
+// ribbon describes an award a Pokémon can earn.
+type ribbon struct {
+	id          string // Identifier stored on the entry (e.g. "victory")
+	name        string // Display name (e.g. "Victory Ribbon")
+	description string // How the ribbon is earned
+}
+
+// Ribbon identifiers
+const (
+	ribbonVictory  = "victory"
+	ribbonVeteran  = "veteran"
+	ribbonChampion = "champion"
+	ribbonFlawless = "flawless"
+	ribbonClassic  = "classic"
+)
+
+// ribbons lists every ribbon, in the order they are shown.
+var ribbons = []ribbon{
+	{id: ribbonVictory, name: "Victory Ribbon", description: "Won a battle round"},
+	{id: ribbonVeteran, name: "Veteran Ribbon", description: "Won 10 battle rounds"},
+	{id: ribbonChampion, name: "Champion Ribbon", description: "Won 50 battle rounds"},
+	{id: ribbonFlawless, name: "Flawless Ribbon", description: "Beat a trainer without any of the team fainting"},
+	{id: ribbonClassic, name: "Classic Ribbon", description: "Received at a special event"},
+}
+
+// battleMilestones maps the number of battle rounds won to the ribbon it earns.
+var battleMilestones = []struct {
+	wins   int
+	ribbon string
+}{
+	{wins: 1, ribbon: ribbonVictory},
+	{wins: 10, ribbon: ribbonVeteran},
+	{wins: 50, ribbon: ribbonChampion},
+}
+
+// findRibbon looks up a ribbon by its identifier.
+//
+// Parameters:
+//   - id: The ribbon identifier (e.g. "veteran")
+//
+// Returns:
+//   - The ribbon
+//   - Whether a ribbon with that identifier exists
+func findRibbon(id string) (ribbon, bool) {
+	for _, r := range ribbons {
+		if r.id == id {
+			return r, true
+		}
+	}
+	return ribbon{}, false
+}
+
+// ribbonName returns the translated display name of a ribbon.
+// Unknown identifiers, such as ribbons from a newer version, are shown as is.
+func ribbonName(id string) string {
+	if r, ok := findRibbon(id); ok {
+		return i18n.T(r.name)
+	}
+	return id
+}
+
+// battleRibbons returns the ribbons a Pokémon qualifies for after a battle.
+//
+// Parameters:
+//   - battlesWon: The total number of battle rounds the Pokémon has won
+//   - flawless: Whether it was on a team that won without anyone fainting
+//
+// Returns:
+//   - The identifiers of the ribbons it qualifies for, whether or not it already has them
+func battleRibbons(battlesWon int, flawless bool) []string {
+	var earned []string
+	for _, milestone := range battleMilestones {
+		if battlesWon >= milestone.wins {
+			earned = append(earned, milestone.ribbon)
+		}
+	}
+	if flawless {
+		earned = append(earned, ribbonFlawless)
+	}
+	return earned
+}
+
+// roundsWon counts the rounds each of the user's Pokémon won in a battle.
+// Every Pokémon that was sent out is included, even if it won no rounds.
+//
+// Parameters:
+//   - result: The outcome of the battle
+//
+// Returns:
+//   - The number of rounds won, keyed by name in the Pokédex
+func roundsWon(result battleResult) map[string]int {
+	wins := make(map[string]int)
+	for _, round := range result.rounds {
+		won := 0
+		if round.won {
+			won = 1
+		}
+		wins[round.player] += won
+	}
+	return wins
+}
+
+// flawlessVictory reports whether a battle was won without losing a round.
+func flawlessVictory(result battleResult) bool {
+	if !result.won {
+		return false
+	}
+	for _, round := range result.rounds {
+		if !round.won {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestBattleRibbons tests that battle milestones award the right ribbons
+func TestBattleRibbons(t *testing.T) {
+	tests := []struct {
+		battlesWon int
+		flawless   bool
+		expected   []string
+	}{
+		{battlesWon: 0, expected: nil},
+		{battlesWon: 1, expected: []string{ribbonVictory}},
+		{battlesWon: 12, expected: []string{ribbonVictory, ribbonVeteran}},
+		{battlesWon: 50, flawless: true, expected: []string{ribbonVictory, ribbonVeteran, ribbonChampion, ribbonFlawless}},
+	}
+
+	for _, tc := range tests {
+		if got := battleRibbons(tc.battlesWon, tc.flawless); !slices.Equal(got, tc.expected) {
+			t.Errorf("battleRibbons(%d, %v) = %v, expected %v", tc.battlesWon, tc.flawless, got, tc.expected)
+		}
+	}
+}
+
+// TestRoundsWon tests that every fighter is counted, including those that won no rounds
+func TestRoundsWon(t *testing.T) {
+	result := battleResult{rounds: []battleRound{
+		{player: "pikachu", won: true},
+		{player: "pikachu", won: false},
+		{player: "squirtle", won: true},
+		{player: "squirtle", won: true},
+	}, won: true}
+
+	wins := roundsWon(result)
+	if len(wins) != 2 || wins["pikachu"] != 1 || wins["squirtle"] != 2 {
+		t.Errorf("Expected pikachu=1 and squirtle=2, got %v", wins)
+	}
+	if flawlessVictory(result) {
+		t.Error("Expected a battle where pikachu fainted not to be flawless")
+	}
+
+	result.rounds = result.rounds[2:]
+	if !flawlessVictory(result) {
+		t.Error("Expected a battle won without losing a round to be flawless")
+	}
+	result.won = false
+	if flawlessVictory(result) {
+		t.Error("Expected a lost battle not to be flawless")
+	}
+}
+
+// TestRibbonName tests that unknown ribbons are shown by identifier
+func TestRibbonName(t *testing.T) {
+	if got := ribbonName(ribbonVictory); got != "Victory Ribbon" {
+		t.Errorf("Expected 'Victory Ribbon', got %q", got)
+	}
+	if got := ribbonName("mystery"); got != "mystery" {
+		t.Errorf("Expected 'mystery', got %q", got)
+	}
+}
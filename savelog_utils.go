@@ -0,0 +1,133 @@
+// This file keeps the save log: an append-only record of every write to the
+// save file, with when it happened, what triggered it, and how much was
+// written. The savelog command shows it, to help track down how often
+// auto-save runs and what happened before data went missing.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Triggers recorded for saves made between commands
+const (
+	saveTriggerBatch = "(end of batch)"    // The changes of a batch of commands, saved when it ends
+	saveTriggerTimer = "(auto-save timer)" // A timed auto-save (see autosave every)
+)
+
+// saveLogRecord is one write to the save file, stored as a line of JSON.
+type saveLogRecord struct {
+	Time    time.Time `json:"time"`            // When the save file was written
+	Trigger string    `json:"trigger"`         // The command that saved, or one of the saveTrigger constants
+	Entries int       `json:"entries"`         // The number of Pokémon saved
+	Bytes   int64     `json:"bytes"`           // The size of the save file written (zero if the write failed)
+	Error   string    `json:"error,omitempty"` // Why the write failed, if it did
+}
+
+// getSaveLogPath returns the path of the save log, in the state directory
+// (see paths_utils.go).
+//
+// Returns:
+//   - The path to the save log
+//   - An error if there was a problem determining the path
+func getSaveLogPath() (string, error) {
+	return saveLogFile.path(), nil
+}
+
+// setSaveTrigger records what's causing the saves that follow, for the save
+// log, until the returned function is called to restore the previous trigger.
+// Like the command context, the trigger is only changed on the REPL's
+// goroutine, and isn't locked, so that restoring it can't hang after a
+// command crashed while holding the config lock.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - trigger: The running command's name, or one of the saveTrigger constants
+//
+// Returns:
+//   - A function that restores the previous trigger
+func setSaveTrigger(cfg *config, trigger string) func() {
+	previous := cfg.saveTrigger
+	cfg.saveTrigger = trigger
+	return func() { cfg.saveTrigger = previous }
+}
+
+// logSave appends a write to the save log. The save log is only an aid for
+// debugging, so a failure to write it is only reported in debug mode.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - record: The write to record
+func logSave(cfg *config, record saveLogRecord) {
+	path, err := getSaveLogPath()
+	if err == nil {
+		err = appendSaveLog(path, record)
+	}
+	if err != nil && cfg.Settings().debugMode {
+		log.Printf("Could not write to the save log: %v", err)
+	}
+}
+
+// appendSaveLog appends a record to a save log, creating it if needed.
+func appendSaveLog(path string, record saveLogRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// readSaveLog reads a save log. Lines that can't be read, such as one cut
+// short by a crash, are skipped.
+//
+// Parameters:
+//   - path: The path to the save log
+//
+// Returns:
+//   - The records, oldest first (none if there's no log yet)
+//   - An error if the file exists but can't be read
+func readSaveLog(path string) ([]saveLogRecord, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var records []saveLogRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record saveLogRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err == nil {
+			records = append(records, record)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// formatByteSize formats a number of bytes for display, e.g. "12.3 KB".
+func formatByteSize(bytes int64) string {
+	switch {
+	case bytes < 1024:
+		return fmt.Sprintf("%d B", bytes)
+	case bytes < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
+	}
+}
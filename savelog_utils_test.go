@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestSaveLogRecordsWrites tests that writing the save file appends a record
+// with the trigger, the number of Pokémon, and the size of the file
+func TestSaveLogRecordsWrites(t *testing.T) {
+	useTempHome(t)
+
+	cfg := &config{pokedex: pokedex.New()}
+	cfg.pokedex.Add("pikachu", pokedex.Entry{})
+	cfg.pokedex.Add("eevee", pokedex.Entry{})
+
+	restore := setSaveTrigger(cfg, "catch")
+	if err := writeSaveFile(cfg); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+	restore()
+	if cfg.saveTrigger != "" {
+		t.Errorf("Expected the trigger to be restored, got %q", cfg.saveTrigger)
+	}
+	defer setSaveTrigger(cfg, saveTriggerTimer)()
+	if err := writeSaveFile(cfg); err != nil {
+		t.Fatalf("Failed to save: %v", err)
+	}
+
+	records, err := readSaveLog(saveLogFile.path())
+	if err != nil {
+		t.Fatalf("Failed to read the save log: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+	info, err := os.Stat(saveFile.path())
+	if err != nil {
+		t.Fatalf("Failed to stat the save file: %v", err)
+	}
+	first := records[0]
+	if first.Trigger != "catch" || first.Entries != 2 || first.Bytes == 0 || first.Error != "" {
+		t.Errorf("Unexpected record: %+v", first)
+	}
+	// The size of the last write is the size of the file
+	if last := records[1]; last.Trigger != saveTriggerTimer || last.Bytes != info.Size() {
+		t.Errorf("Expected the timer as the trigger and a size of %d, got %+v", info.Size(), last)
+	}
+}
+
+// TestReadSaveLog tests that a missing log has no records, and that lines that
+// can't be read are skipped
+func TestReadSaveLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), saveLogFile.name)
+	if records, err := readSaveLog(path); err != nil || records != nil {
+		t.Errorf("Expected no records for a missing log, got %v, %v", records, err)
+	}
+
+	if err := appendSaveLog(path, saveLogRecord{Trigger: "save", Entries: 1}); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatalf("Failed to open the log: %v", err)
+	}
+	file.WriteString("{\"time\":\"2024-\n")
+	file.Close()
+	if err := appendSaveLog(path, saveLogRecord{Trigger: saveTriggerBatch, Error: "disk full"}); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+
+	records, err := readSaveLog(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(records) != 2 || records[0].Trigger != "save" || records[1].Error != "disk full" {
+		t.Errorf("Expected the cut-off line to be skipped, got %+v", records)
+	}
+}
+
+// TestFormatByteSize tests sizes in bytes, kilobytes, and megabytes
+func TestFormatByteSize(t *testing.T) {
+	for bytes, want := range map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KB",
+		5 * 1024 * 1024: "5.0 MB",
+	} {
+		if got := formatByteSize(bytes); got != want {
+			t.Errorf("formatByteSize(%d) = %q, want %q", bytes, got, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// This file connects the search index (see internal/search) to the
+// application. The index is built from the complete species dataset when
+// there is one, and from the PokeAPI's list of Pokémon otherwise, then saved
+// in the cache directory, so later sessions search and suggest names without
+// waiting for the network. A saved index is rebuilt when the dataset it was
+// built from changes, or, if it was built from the API list, once it's a week
+// old, like the cached species data.
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/search"
+)
+
+// apiSearchSource is the source of a search index built from the API's list of Pokémon.
+const apiSearchSource = "api"
+
+// searchIndexMaxAge is how long a search index built from the API's list of
+// Pokémon is used before it's rebuilt, to pick up newly added Pokémon.
+const searchIndexMaxAge = 7 * 24 * time.Hour
+
+// getSearchIndexPath returns the path of the saved search index, in the cache
+// directory (see paths_utils.go), since it can always be rebuilt.
+func getSearchIndexPath() string {
+	return searchIndexFile.path()
+}
+
+// searchSource returns what the search index should be built from: the
+// dataset in use if it's complete, stamped with when it was downloaded, and
+// the API's list of Pokémon otherwise.
+func searchSource(cfg *config) string {
+	if d := cfg.Dataset(); d.Complete {
+		return fmt.Sprintf("dataset:%d:%d", d.Updated.UnixNano(), d.Len())
+	}
+	return apiSearchSource
+}
+
+// searchIndexCurrent reports whether an index was built from the given
+// source and is still recent enough to use.
+func searchIndexCurrent(idx *search.Index, source string) bool {
+	if idx == nil || idx.Source != source {
+		return false
+	}
+	return source != apiSearchSource || time.Since(idx.Built) < searchIndexMaxAge
+}
+
+// getSearchIndex returns the search index, loading the saved copy on first
+// use, or building and saving it if there isn't a current one.
+//
+// Parameters:
+//   - cfg: The application configuration holding the dataset, API client, and index
+//
+// Returns:
+//   - The search index
+//   - An error if the index had to be built and the Pokémon list could not be retrieved
+func getSearchIndex(cfg *config) (*search.Index, error) {
+	source := searchSource(cfg)
+	cfg.mutex.RLock()
+	idx, path := cfg.searchIndex, cfg.searchIndexPath
+	cfg.mutex.RUnlock()
+	if searchIndexCurrent(idx, source) {
+		return idx, nil
+	}
+
+	if path != "" {
+		saved, err := search.Load(path)
+		if err != nil && cfg.Settings().debugMode {
+			log.Printf("Could not load the search index, rebuilding it: %v", err)
+		}
+		if searchIndexCurrent(saved, source) {
+			cfg.setSearchIndex(saved)
+			return saved, nil
+		}
+	}
+
+	idx, err := buildSearchIndex(cfg, source)
+	if err != nil {
+		return nil, err
+	}
+	if path != "" {
+		// The index still works for this session if it can't be saved
+		if err := search.Write(path, idx); err != nil && cfg.Settings().debugMode {
+			log.Printf("Could not save the search index: %v", err)
+		}
+	}
+	cfg.setSearchIndex(idx)
+	return idx, nil
+}
+
+// buildSearchIndex builds the search index from the complete dataset, with
+// the types of every Pokémon, or from the API's list of Pokémon, with their
+// names and generations only.
+//
+// Parameters:
+//   - cfg: The application configuration holding the dataset and API client
+//   - source: What the index is built from, as returned by searchSource
+//
+// Returns:
+//   - The search index
+//   - An error if the Pokémon list could not be retrieved
+func buildSearchIndex(cfg *config, source string) (*search.Index, error) {
+	if d := cfg.Dataset(); d.Complete {
+		entries := make([]search.Entry, len(d.Species))
+		for i, species := range d.Species {
+			entries[i] = search.Entry{Name: species.Name, ID: species.ID, Types: species.Types}
+		}
+		return search.New(source, entries, true), nil
+	}
+
+	listResp, err := cfg.pokeapiClient.ListAllPokemon()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]search.Entry, len(listResp.Results))
+	for i, result := range listResp.Results {
+		id, _ := result.ID() // A Pokémon without an ID is still indexed by name
+		entries[i] = search.Entry{Name: result.Name, ID: id}
+	}
+	return search.New(source, entries, false), nil
+}
+
+// setSearchIndex replaces the search index in use, and drops the name index
+// so that it's rebuilt from the new one.
+func (cfg *config) setSearchIndex(idx *search.Index) {
+	cfg.mutex.Lock()
+	defer cfg.mutex.Unlock()
+	cfg.searchIndex = idx
+	cfg.nameIndex = nil
+}
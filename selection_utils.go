@@ -0,0 +1,109 @@
+// This file provides the selection registry: the items listed by the last
+// command that numbers what it lists, such as the Pokémon from 'explore',
+// 'pokedex', or 'seen', or the moves from 'teach <pokemon>'. Any later command
+// can then refer to one of them as #N instead of typing its name, e.g.
+// 'lookup #3' after 'pokedex' or 'forget pikachu #2' after 'teach pikachu'.
+package main
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// Kinds of items a numbered list can hold
+const (
+	selectPokemon = "pokemon" // Pokémon, by API name
+	selectMove    = "move"    // Moves, by API name
+)
+
+// selection is the numbered list shown by the last command that lists items.
+// It lasts for the session and isn't saved.
+type selection struct {
+	kind    string   // What the items are (selectPokemon or selectMove)
+	command string   // The command that listed them, for messages
+	items   []string // The items' API names, in the order they were numbered from 1
+}
+
+// selectionRef matches a reference to an item of the last list, such as "#3".
+var selectionRef = regexp.MustCompile(`^#([0-9]+)$`)
+
+// selectionMiddleware replaces each #N parameter with the item numbered N in
+// the last list shown, before the command runs. Commands that take free text,
+// such as notes, get their parameters as typed.
+func selectionMiddleware(command cliCommand, next commandFunc) commandFunc {
+	return func(cfg *config, params []string) error {
+		if command.freeText {
+			return next(cfg, params)
+		}
+		resolved, err := resolveSelections(cfg, params)
+		if err != nil {
+			return err
+		}
+		return next(cfg, resolved)
+	}
+}
+
+// resolveSelections replaces each #N parameter with the item numbered N in the
+// last list shown.
+//
+// Parameters:
+//   - cfg: The application configuration containing the last list
+//   - params: The command's parameters
+//
+// Returns:
+//   - The parameters, with references replaced by API names
+//   - An error if there's no list, or a number isn't in it
+func resolveSelections(cfg *config, params []string) ([]string, error) {
+	var resolved []string
+	for i, param := range params {
+		match := selectionRef.FindStringSubmatch(param)
+		if match == nil {
+			continue
+		}
+		number, _ := strconv.Atoi(match[1])
+		item, err := selectedItem(cfg, "", number)
+		if err != nil {
+			return nil, err
+		}
+		if resolved == nil {
+			resolved = append([]string(nil), params...)
+		}
+		resolved[i] = item
+	}
+	if resolved == nil {
+		return params, nil
+	}
+	return resolved, nil
+}
+
+// selectedItem looks up an item by its number in the last list shown.
+//
+// Parameters:
+//   - cfg: The application configuration containing the last list
+//   - kind: The kind of item wanted (selectPokemon or selectMove), or "" for any
+//   - number: The item's number in the list, from 1
+//
+// Returns:
+//   - The item's API name
+//   - An error if there's no list, it holds another kind of item, or the number isn't in it
+func selectedItem(cfg *config, kind string, number int) (string, error) {
+	last := cfg.Selection()
+	switch {
+	case len(last.items) == 0:
+		return "", errorhandling.NewInvalidInputError(
+			"No numbered list available, please run a command that lists items first, such as 'explore' or 'pokedex'", nil)
+	case kind == selectPokemon && last.kind != kind:
+		return "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The last list, from '%s', isn't a list of Pokémon", last.command), nil)
+	case kind == selectMove && last.kind != kind:
+		return "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("The last list, from '%s', isn't a list of moves", last.command), nil)
+	case number < 1 || number > len(last.items):
+		return "", errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Number %d isn't in the list from '%s' (valid range: 1-%d)", number, last.command, len(last.items)), nil)
+	}
+	return last.items[number-1], nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestResolveSelections tests replacing #N parameters with listed items
+func TestResolveSelections(t *testing.T) {
+	cfg := &config{}
+	if _, err := resolveSelections(cfg, []string{"#1"}); err == nil {
+		t.Error("Expected an error before anything has been listed")
+	}
+	params := []string{"pikachu", "thunderbolt"}
+	if got, err := resolveSelections(cfg, params); err != nil || !slices.Equal(got, params) {
+		t.Errorf("Expected parameters without references to be kept, got %v, %v", got, err)
+	}
+
+	cfg.SetSelection(selectPokemon, "pokedex", []string{"bulbasaur", "mr-mime"})
+	got, err := resolveSelections(cfg, []string{"#2", "--ball", "great-ball"})
+	if want := []string{"mr-mime", "--ball", "great-ball"}; err != nil || !slices.Equal(got, want) {
+		t.Errorf("resolveSelections() = %v, %v, want %v", got, err, want)
+	}
+	for _, param := range []string{"#0", "#3"} {
+		if _, err := resolveSelections(cfg, []string{param}); err == nil {
+			t.Errorf("Expected an error for %s, outside the list", param)
+		}
+	}
+}
+
+// TestSelectedItem tests that a kind of item can be required
+func TestSelectedItem(t *testing.T) {
+	cfg := &config{}
+	cfg.SetSelection(selectMove, "teach", []string{"thunderbolt", "quick-attack"})
+	if move, err := selectedItem(cfg, selectMove, 2); err != nil || move != "quick-attack" {
+		t.Errorf("selectedItem(move, 2) = %q, %v, want quick-attack", move, err)
+	}
+	if _, err := selectedItem(cfg, selectPokemon, 1); err == nil {
+		t.Error("Expected an error choosing a Pokémon from a list of moves")
+	}
+}
+
+// TestSelectionMiddlewareFreeText tests that commands taking free text get #N as typed
+func TestSelectionMiddlewareFreeText(t *testing.T) {
+	cfg := &config{}
+	cfg.SetSelection(selectPokemon, "seen", []string{"pikachu"})
+	var got []string
+	next := func(cfg *config, params []string) error {
+		got = params
+		return nil
+	}
+
+	if err := selectionMiddleware(cliCommand{name: "lookup"}, next)(cfg, []string{"#1"}); err != nil || !slices.Equal(got, []string{"pikachu"}) {
+		t.Errorf("Expected lookup to get pikachu, got %v, %v", got, err)
+	}
+	if err := selectionMiddleware(cliCommand{name: "note", freeText: true}, next)(cfg, []string{"pikachu", "#1"}); err != nil || !slices.Equal(got, []string{"pikachu", "#1"}) {
+		t.Errorf("Expected note to get #1 as typed, got %v, %v", got, err)
+	}
+}
@@ -0,0 +1,186 @@
+// This file contains the gRPC service served by the serve command, defined in
+// proto/pokedexcli/v1/pokedex.proto. Its methods decode their request messages
+// and call the same functions as the catch, release, pokedex, and explore
+// commands, so that programs automating the Pokédex get the same behavior as
+// the command line. Each call is for a trainer (see trainer_stores.go); a
+// trainer's calls are handled one at a time, like commands in the REPL, and
+// different trainers' calls are handled at the same time.
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+	"github.com/bmlevitt/pokedexcli/internal/rpc"
+)
+
+// pokedexService is the fully-qualified name of the gRPC service.
+const pokedexService = "pokedexcli.v1.Pokedex"
+
+// newPokedexServer returns the gRPC server for the Pokédex service.
+//
+// Parameters:
+//   - stores: The Pokédexes of the trainers the calls are for
+//
+// Returns:
+//   - A server handling the Catch, Release, List, and Explore methods
+func newPokedexServer(stores *trainerStores) *rpc.Server {
+	handle := func(method func(*config, []rpc.Field) ([]byte, error)) rpc.Handler {
+		return func(ctx context.Context, request []byte) ([]byte, error) {
+			fields, err := rpc.Fields(request)
+			if err != nil {
+				return nil, rpc.Errorf(rpc.InvalidArgument, "%v", err)
+			}
+			store, err := stores.get(rpc.Metadata(ctx, trainerMetadata))
+			if err != nil {
+				return nil, rpcStatus(err)
+			}
+			store.mutex.Lock()
+			defer store.mutex.Unlock()
+			response, err := method(store.cfg, fields)
+			return response, rpcStatus(err)
+		}
+	}
+
+	server := rpc.NewServer(pokedexService)
+	server.Handle("Catch", handle(serveCatch))
+	server.Handle("Release", handle(serveRelease))
+	server.Handle("List", handle(serveList))
+	server.Handle("Explore", handle(serveExplore))
+	return server
+}
+
+// serveCatch handles the Catch method: CatchRequest{pokemon = 1, ball = 2}
+// returns CatchResponse{caught = 1, pokemon = 2, masterball = 3}.
+func serveCatch(cfg *config, request []rpc.Field) ([]byte, error) {
+	var name, ball string
+	for _, field := range request {
+		switch field.Number {
+		case 1:
+			name = field.String()
+		case 2:
+			ball = ConvertToAPIFormat(field.String())
+		}
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrNoPokemonName
+	}
+	if _, ok := ballModifiers[ball]; ball != "" && !ok {
+		balls := slices.Sorted(maps.Keys(ballModifiers))
+		return nil, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Unknown ball '%s'. Choose one of: %s", ball, strings.Join(balls, ", ")), nil)
+	}
+	nameInfo := FormatPokemonInput(name)
+	if err := ValidatePokemonName(cfg, nameInfo); err != nil {
+		return nil, err
+	}
+
+	result, err := catchPokemon(cfg, nameInfo.APIFormat, ball)
+	if err != nil {
+		return nil, err
+	}
+	response := rpc.AppendBool(nil, 1, result.caught)
+	if result.caught {
+		response = rpc.AppendMessage(response, 2, encodePokemon(nameInfo.APIFormat, result.entry))
+	}
+	return rpc.AppendBool(response, 3, result.masterball), nil
+}
+
+// serveRelease handles the Release method: ReleaseRequest{pokemon = 1}
+// returns an empty ReleaseResponse.
+func serveRelease(cfg *config, request []rpc.Field) ([]byte, error) {
+	var name string
+	for _, field := range request {
+		if field.Number == 1 {
+			name = field.String()
+		}
+	}
+
+	var params []string
+	if name = strings.TrimSpace(name); name != "" {
+		params = []string{name}
+	}
+	apiName, _, _, _, err := GetPokemonIfExists(cfg, params)
+	if err != nil {
+		return nil, err
+	}
+	return nil, releasePokemon(cfg, apiName)
+}
+
+// serveList handles the List method: an empty ListRequest returns
+// ListResponse{repeated pokemon = 1}, sorted by name.
+func serveList(cfg *config, _ []rpc.Field) ([]byte, error) {
+	var response []byte
+	for _, named := range cfg.pokedex.List() {
+		response = rpc.AppendMessage(response, 1, encodePokemon(named.Name, named.Entry))
+	}
+	return response, nil
+}
+
+// serveExplore handles the Explore method: ExploreRequest{location_area = 1}
+// returns ExploreResponse{repeated pokemon = 1, newly_seen = 2}.
+func serveExplore(cfg *config, request []rpc.Field) ([]byte, error) {
+	var location string
+	for _, field := range request {
+		if field.Number == 1 {
+			location = ConvertToAPIFormat(field.String())
+		}
+	}
+	if location == "" {
+		return nil, errorhandling.NewInvalidInputError("No location area provided", nil)
+	}
+
+	resp, newlySeen, err := exploreArea(cfg, location)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]string, 0, len(resp.PokemonEncounters))
+	for _, encounter := range resp.PokemonEncounters {
+		found = append(found, encounter.Pokemon.Name)
+	}
+	response := rpc.AppendStrings(nil, 1, found)
+	return rpc.AppendInt(response, 2, int64(newlySeen)), nil
+}
+
+// encodePokemon encodes a Pokédex entry as a Pokemon message: name = 1,
+// repeated types = 2, level = 3, box = 4, caught_at = 5, caught_on = 6.
+func encodePokemon(name string, entry pokedex.Entry) []byte {
+	msg := rpc.AppendString(nil, 1, name)
+	msg = rpc.AppendStrings(msg, 2, pokemonTypes(entry.PokemonDataResp))
+	msg = rpc.AppendInt(msg, 3, int64(entry.CurrentLevel()))
+	msg = rpc.AppendString(msg, 4, entry.Box)
+	msg = rpc.AppendString(msg, 5, entry.CaughtAt)
+	if !entry.CaughtOn.IsZero() {
+		msg = rpc.AppendString(msg, 6, entry.CaughtOn.Format(time.RFC3339))
+	}
+	return msg
+}
+
+// rpcStatus converts an error from the shared command functions to a gRPC
+// status, with the message the command line would show.
+func rpcStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	code := rpc.Internal
+	var appErr *errorhandling.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Type {
+		case errorhandling.NotFound:
+			code = rpc.NotFound
+		case errorhandling.InvalidInput:
+			code = rpc.InvalidArgument
+		case errorhandling.NetworkError, errorhandling.ResourceUnavailable:
+			code = rpc.Unavailable
+		}
+	}
+	return &rpc.Error{Code: code, Message: errorhandling.FormatUserMessage(err)}
+}
@@ -0,0 +1,83 @@
+// This file writes the user's party as a team in Pokémon Showdown's text
+// format for the export command, so that teams built here can be pasted into
+// Showdown's teambuilder or other battle simulators. The format is the one read
+// by parseShowdown for the import command.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// showdownTeamSize is the most Pokémon a Showdown team can have.
+const showdownTeamSize = 6
+
+// showdownDefaultLevel is the level Showdown assumes when a set has none.
+const showdownDefaultLevel = 100
+
+// showdownStatNames maps API stat names to the abbreviations Showdown uses in EV spreads.
+var showdownStatNames = map[string]string{
+	"hp":              "HP",
+	"attack":          "Atk",
+	"defense":         "Def",
+	"special-attack":  "SpA",
+	"special-defense": "SpD",
+	"speed":           "Spe",
+}
+
+// writeShowdownTeam writes Pokémon as a Showdown team: a block for each with
+// its species, its level, the effort values it has gained, and the moves in its moveset. Showdown matches names
+// regardless of punctuation, so species are written as the API names them,
+// with each part capitalized (e.g. "Rotom-Wash").
+//
+// Parameters:
+//   - w: Where to write the team
+//   - team: The Pokémon to write, in order
+//
+// Returns:
+//   - An error if writing fails
+func writeShowdownTeam(w io.Writer, team []pokedex.NamedEntry) error {
+	out := bufio.NewWriter(w)
+	for i, named := range team {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintln(out, showdownName(named.Name))
+		if level := named.Entry.CurrentLevel(); level != showdownDefaultLevel {
+			fmt.Fprintf(out, "Level: %d\n", level)
+		}
+		if evs := showdownEVs(named.Entry.EVs); evs != "" {
+			fmt.Fprintf(out, "EVs: %s\n", evs)
+		}
+		for _, move := range named.Entry.Moveset {
+			fmt.Fprintf(out, "- %s\n", FormatMoveName(move))
+		}
+	}
+	return out.Flush()
+}
+
+// showdownName formats an API name the way Showdown writes it, with each
+// hyphenated part capitalized (e.g. "nidoran-f" becomes "Nidoran-F").
+func showdownName(name string) string {
+	parts := strings.Split(name, "-")
+	for i, part := range parts {
+		parts[i] = CapitalizeFirstLetter(part)
+	}
+	return strings.Join(parts, "-")
+}
+
+// showdownEVs formats effort values the way Showdown writes them
+// (e.g. "252 Atk / 4 SpD / 252 Spe"), or returns "" if there are none.
+func showdownEVs(evs map[string]int) string {
+	var parts []string
+	for _, stat := range statOrder {
+		if evs[stat] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", evs[stat], showdownStatNames[stat]))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestWriteShowdownTeam tests the team text, and that the import command reads
+// it back
+func TestWriteShowdownTeam(t *testing.T) {
+	pikachu := pokedex.NewEntry(testMatchupPokemon(t, "pikachu", 320, "electric"))
+	pikachu.Level = 50
+	pikachu.Moveset = []string{"thunderbolt", "volt-tackle"}
+	pikachu.EVs = map[string]int{"speed": 252, "attack": 4}
+	rotom := pokedex.NewEntry(testMatchupPokemon(t, "rotom-wash", 520, "electric", "water"))
+	rotom.Level = 100
+
+	var b strings.Builder
+	team := []pokedex.NamedEntry{{Name: "pikachu", Entry: pikachu}, {Name: "rotom-wash", Entry: rotom}}
+	if err := writeShowdownTeam(&b, team); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := "Pikachu\nLevel: 50\nEVs: 4 Atk / 252 Spe\n- Thunderbolt\n- Volt Tackle\n\nRotom-Wash\n"
+	if b.String() != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, b.String())
+	}
+
+	imported, err := parseShowdown(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("Expected the team to be read back, got %v", err)
+	}
+	if len(imported) != 2 || imported[0].Level != 50 || !slices.Equal(imported[0].Moves, []string{"Thunderbolt", "Volt Tackle"}) ||
+		ConvertToAPIFormat(imported[1].Name) != "rotom-wash" {
+		t.Errorf("Unexpected team read back: %+v", imported)
+	}
+}
@@ -0,0 +1,108 @@
+// This file keeps the official names of species, as the PokeAPI gives them in
+// each language (the species' `names`). Every species the API client
+// retrieves, such as when a Pokémon is caught or described, has its names
+// recorded, and the names are saved in the cache directory, so later sessions
+// display them without asking the API again. FormatPokemonName uses a
+// species' official name in the selected language once it's known, and works
+// the name out from the API name until then.
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"maps"
+	"os"
+	"sync"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// speciesNameStore holds the official names of the species seen so far. It's
+// safe for concurrent use, since the API client records names from any goroutine.
+type speciesNameStore struct {
+	mutex sync.RWMutex
+	names map[string]map[string]string // Names by species API name, then by language code
+	path  string                       // Where the names are saved ("" to only keep them in memory)
+	debug bool                         // Whether failures to save the names are logged
+}
+
+// speciesNames is the store FormatPokemonName looks names up in. It's
+// package-level because names are formatted everywhere, without the config.
+var speciesNames = &speciesNameStore{}
+
+// getSpeciesNamePath returns the path of the saved species names, in the cache
+// directory (see paths_utils.go), since they can always be fetched again.
+func getSpeciesNamePath() string {
+	return speciesNameFile.path()
+}
+
+// load replaces the names with those saved at a path, and saves new names
+// there from then on. A missing file leaves no names, and so does one that
+// can't be read, so that it's replaced by the next save.
+//
+// Parameters:
+//   - path: The file the names are saved in
+//   - debug: Whether failures to read or save the names are logged
+func (s *speciesNameStore) load(path string, debug bool) {
+	names := map[string]map[string]string{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &names); err != nil && debug {
+			log.Printf("Could not read the species names, starting over: %v", err)
+		}
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.names, s.path, s.debug = names, path, debug
+}
+
+// record remembers the official names of a species retrieved from the API,
+// saving them if they're new. It's the API client's OnSpecies option.
+//
+// Parameters:
+//   - species: The species data
+func (s *speciesNameStore) record(species pokeapi.PokemonSpeciesResp) {
+	names := species.NamesByLanguage()
+	if species.Name == "" || len(names) == 0 {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if maps.Equal(s.names[species.Name], names) {
+		return
+	}
+	if s.names == nil {
+		s.names = map[string]map[string]string{}
+	}
+	s.names[species.Name] = names
+	if s.path == "" {
+		return
+	}
+	// The names still work for this session if they can't be saved
+	data, err := json.Marshal(s.names)
+	if err == nil {
+		err = os.WriteFile(s.path, data, 0644)
+	}
+	if err != nil && s.debug {
+		log.Printf("Could not save the species names: %v", err)
+	}
+}
+
+// lookup returns the official name of a species in the selected language, or
+// in English if it has none in that language.
+//
+// Parameters:
+//   - species: The species' API name
+//
+// Returns:
+//   - The official name, and whether one is known
+func (s *speciesNameStore) lookup(species string) (string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	names := s.names[species]
+	if name, ok := names[i18n.Current()]; ok {
+		return name, true
+	}
+	name, ok := names[i18n.Default]
+	return name, ok
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// useSpeciesNames replaces the species names for the rest of a test.
+func useSpeciesNames(t *testing.T, store *speciesNameStore) {
+	t.Helper()
+	saved := speciesNames
+	speciesNames = store
+	t.Cleanup(func() { speciesNames = saved })
+}
+
+// testSpecies returns species data with official names, given as pairs of a
+// language code and a name.
+func testSpecies(name string, names ...string) pokeapi.PokemonSpeciesResp {
+	species := pokeapi.PokemonSpeciesResp{Name: name}
+	for i := 0; i+1 < len(names); i += 2 {
+		species.Names = append(species.Names, pokeapi.Name{Name: names[i+1], Language: pokeapi.NamedAPIResource{Name: names[i]}})
+	}
+	return species
+}
+
+// TestSpeciesNames tests that recorded official names are displayed in the
+// selected language, falling back to English, including for alternate forms
+func TestSpeciesNames(t *testing.T) {
+	useSpeciesNames(t, &speciesNameStore{})
+	defer i18n.SetLanguage(i18n.Default)
+
+	if got := FormatPokemonName("mr-mime"); got != "Mr. Mime" {
+		t.Errorf("Expected the built-in name before the official one is known, got %q", got)
+	}
+	speciesNames.record(testSpecies("mr-mime", "en", "Mr. Mime", "fr", "M. Mime"))
+	speciesNames.record(testSpecies("pikachu", "en", "Pikachu"))
+	speciesNames.record(testSpecies("wooper")) // No names, so nothing is recorded
+
+	cases := map[string]string{"mr-mime": "Mr. Mime", "mr-mime-galar": "Mr. Mime-Galar", "pikachu": "Pikachu", "wooper": "Wooper"}
+	for name, expected := range cases {
+		if got := FormatPokemonName(name); got != expected {
+			t.Errorf("FormatPokemonName(%q) == %q, expected %q", name, got, expected)
+		}
+	}
+
+	i18n.SetLanguage("es")
+	if got := FormatPokemonName("mr-mime"); got != "Mr. Mime" {
+		t.Errorf("Expected the English name without a Spanish one, got %q", got)
+	}
+	speciesNames.record(testSpecies("mr-mime", "en", "Mr. Mime", "es", "Sr. Mime"))
+	if got := FormatPokemonName("mr-mime-galar"); got != "Sr. Mime-Galar" {
+		t.Errorf("Expected the Spanish name, got %q", got)
+	}
+}
+
+// TestSpeciesNamesSaved tests that recorded names are saved and loaded in a
+// later session
+func TestSpeciesNamesSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "species-names.json")
+	first := &speciesNameStore{}
+	first.load(path, false)
+	first.record(testSpecies("farfetchd", "en", "Farfetch’d"))
+
+	second := &speciesNameStore{}
+	second.load(path, false)
+	useSpeciesNames(t, second)
+	if got := FormatPokemonName("farfetchd-galar"); got != "Farfetch’d-Galar" {
+		t.Errorf("Expected the saved official name, got %q", got)
+	}
+}
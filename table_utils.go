@@ -0,0 +1,231 @@
+// This file provides a lightweight table renderer for the PokédexCLI application.
+// It lays out rows of text as aligned columns with a header row, truncating
+// cells when the table would be wider than the user's terminal.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// defaultTerminalWidth is the width assumed when the terminal size is unknown.
+const defaultTerminalWidth = 80
+
+// minColumnWidth is the narrowest a column will be truncated to.
+const minColumnWidth = 4
+
+// columnGap is the spacing placed between adjacent columns.
+const columnGap = "  "
+
+// Table holds the headers and rows of a table to be rendered as aligned columns.
+// Build a table with NewTable, add rows with AddRow, and then call Print or Render.
+type Table struct {
+	headers  []string   // Column headings shown above the rows
+	rows     [][]string // Cell values, one slice per row
+	maxWidth int        // Maximum total width of a rendered line
+}
+
+// NewTable creates a table with the given column headers.
+// The maximum width defaults to the width of the user's terminal.
+//
+// Parameters:
+//   - headers: The column headings, in display order
+//
+// Returns:
+//   - A pointer to an empty Table ready for rows to be added
+func NewTable(headers ...string) *Table {
+	return &Table{
+		headers:  translateHeaders(headers),
+		maxWidth: terminalWidth(),
+	}
+}
+
+// translateHeaders returns the column headers in the selected language.
+func translateHeaders(headers []string) []string {
+	translated := make([]string, len(headers))
+	for i, header := range headers {
+		translated[i] = i18n.T(header)
+	}
+	return translated
+}
+
+// AddRow appends a row of cells to the table.
+// Rows with fewer cells than there are headers are padded with empty cells,
+// and any extra cells beyond the number of headers are ignored.
+//
+// Parameters:
+//   - cells: The cell values for the row, in column order
+func (t *Table) AddRow(cells ...string) {
+	row := make([]string, len(t.headers))
+	copy(row, cells)
+	t.rows = append(t.rows, row)
+}
+
+// Len returns the number of rows that have been added to the table.
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// Print renders the table to standard output.
+func (t *Table) Print() {
+	t.Render(os.Stdout)
+}
+
+// Render writes the table to the provided writer.
+// Each column is padded to the width of its widest cell, a dashed rule is drawn
+// under the headers, and cells are truncated with an ellipsis if the table
+// would otherwise be wider than the maximum width. In accessible mode, the
+// table is written as labelled lines instead (see renderAccessible).
+//
+// Parameters:
+//   - w: The writer to render the table to
+func (t *Table) Render(w io.Writer) {
+	if isAccessibleOutput() {
+		t.renderAccessible(w)
+		return
+	}
+
+	widths := t.columnWidths()
+
+	// Header row followed by a rule under each heading
+	fmt.Fprintln(w, formatRow(t.headers, widths))
+	rules := make([]string, len(widths))
+	for i, width := range widths {
+		rules[i] = strings.Repeat("-", width)
+	}
+	fmt.Fprintln(w, formatRow(rules, widths))
+
+	for _, row := range t.rows {
+		fmt.Fprintln(w, formatRow(row, widths))
+	}
+}
+
+// renderAccessible writes each row on its own line with every cell labelled
+// by its header, like "Name: Pikachu; Types: Electric", so that screen readers
+// don't have to make sense of columns. Empty cells are left out and nothing
+// is truncated.
+//
+// Parameters:
+//   - w: The writer to render the table to
+func (t *Table) renderAccessible(w io.Writer) {
+	for _, row := range t.rows {
+		var cells []string
+		for i, cell := range row {
+			if cell != "" {
+				cells = append(cells, t.headers[i]+": "+cell)
+			}
+		}
+		fmt.Fprintln(w, strings.Join(cells, "; "))
+	}
+}
+
+// columnWidths calculates the display width of each column.
+// Columns start at the width of their widest cell; if the total exceeds the
+// table's maximum width, the widest column is narrowed repeatedly until the
+// table fits or every column has reached the minimum width.
+//
+// Returns:
+//   - A slice containing the width of each column
+func (t *Table) columnWidths() []int {
+	widths := make([]int, len(t.headers))
+	for i, header := range t.headers {
+		widths[i] = displayWidth(header)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if w := displayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	if t.maxWidth <= 0 || len(widths) == 0 {
+		return widths
+	}
+
+	for totalWidth(widths) > t.maxWidth {
+		// Find the widest column that can still be narrowed
+		widest := -1
+		for i, w := range widths {
+			if w > minColumnWidth && (widest == -1 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			break
+		}
+		widths[widest]--
+	}
+
+	return widths
+}
+
+// totalWidth returns the rendered width of a line with the given column widths.
+func totalWidth(widths []int) int {
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+	return total + len(columnGap)*(len(widths)-1)
+}
+
+// formatRow pads and truncates each cell to its column width and joins them.
+// Trailing whitespace is trimmed so lines don't end with padding.
+func formatRow(cells []string, widths []int) string {
+	parts := make([]string, len(widths))
+	for i, width := range widths {
+		cell := truncateText(cells[i], width)
+		parts[i] = cell + strings.Repeat(" ", width-displayWidth(cell))
+	}
+	return strings.TrimRight(strings.Join(parts, columnGap), " ")
+}
+
+// truncateText shortens text to fit within the given display width,
+// replacing the last visible character with an ellipsis when it is cut.
+//
+// Parameters:
+//   - text: The text to shorten
+//   - width: The maximum display width of the result
+//
+// Returns:
+//   - The original text if it fits, otherwise a truncated copy ending in "…"
+func truncateText(text string, width int) string {
+	if displayWidth(text) <= width {
+		return text
+	}
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(text)
+	return string(runes[:width-1]) + "…"
+}
+
+// displayWidth returns the number of terminal columns needed to show text.
+// Each rune is counted as one column, which holds for the names and labels
+// displayed by this application, except for combining marks (like the accent
+// of a decomposed "é"), which share the column of the letter before them.
+func displayWidth(text string) int {
+	width := 0
+	for _, r := range text {
+		if !unicode.Is(unicode.Mn, r) {
+			width++
+		}
+	}
+	return width
+}
+
+// terminalWidth returns the width of the user's terminal in columns.
+// It reads the COLUMNS environment variable set by most shells and falls
+// back to a standard 80-column width when it is missing or invalid.
+func terminalWidth() int {
+	if cols, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && cols > 0 {
+		return cols
+	}
+	return defaultTerminalWidth
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestTableRender verifies that columns are aligned to the widest cell
+// and that a rule is drawn under the headers.
+func TestTableRender(t *testing.T) {
+	table := NewTable("#", "Name")
+	table.maxWidth = 80
+	table.AddRow("1", "Pikachu")
+	table.AddRow("10", "Flabébé")
+
+	var buf bytes.Buffer
+	table.Render(&buf)
+
+	expected := strings.Join([]string{
+		"#   Name",
+		"--  -------",
+		"1   Pikachu",
+		"10  Flabébé",
+		"",
+	}, "\n")
+	if buf.String() != expected {
+		t.Errorf("Unexpected table output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+// TestTableRenderAccessible verifies that in accessible mode each row is
+// written as one line of labelled cells, without a rule or padding.
+func TestTableRenderAccessible(t *testing.T) {
+	configureOutput(true)
+	defer configureOutput(false)
+
+	table := NewTable("#", "Name", "Box")
+	table.maxWidth = 10
+	table.AddRow("1", "Pikachu", "favorites")
+	table.AddRow("10", "Flabébé")
+
+	var buf bytes.Buffer
+	table.Render(&buf)
+
+	expected := "#: 1; Name: Pikachu; Box: favorites\n#: 10; Name: Flabébé\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected table output:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+// TestTableTruncation verifies that wide cells are truncated with an ellipsis
+// so the rendered table fits within the maximum width.
+func TestTableTruncation(t *testing.T) {
+	table := NewTable("Name", "Description")
+	table.maxWidth = 20
+	table.AddRow("Bulbasaur", "A strange seed was planted on its back at birth")
+
+	var buf bytes.Buffer
+	table.Render(&buf)
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if displayWidth(line) > 20 {
+			t.Errorf("Line %q is wider than 20 columns", line)
+		}
+	}
+	if !strings.Contains(buf.String(), "…") {
+		t.Errorf("Expected truncated cell to end with an ellipsis, got:\n%s", buf.String())
+	}
+}
+
+// TestDisplayWidth tests that combining marks don't take up a column of their own
+func TestDisplayWidth(t *testing.T) {
+	cases := map[string]int{
+		"Flabébé":             7,
+		"Flabe\u0301be\u0301": 7,
+		"Nidoran♀":            8,
+	}
+	for text, expected := range cases {
+		if w := displayWidth(text); w != expected {
+			t.Errorf("displayWidth(%q) == %d, expected %d", text, w, expected)
+		}
+	}
+}
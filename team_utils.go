@@ -0,0 +1,206 @@
+// This file contains the team builder used by the teambuild command.
+// It searches the user's Pokédex for a team that covers as many types as
+// possible with super-effective STAB attacks, avoids stacking weaknesses to the
+// same type, and balances strong physical and special attackers.
+package main
+
+import (
+	"sort"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// maxTeamSize is the number of Pokémon in a full team.
+const maxTeamSize = 6
+
+// standardTypes lists the eighteen Pokémon types considered when scoring coverage.
+var standardTypes = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// Weights used when scoring a team. Coverage matters most, shared weaknesses
+// are the biggest risk, and stats break ties between similar teams.
+const (
+	coverageWeight       = 10.0 // Per type the team hits super-effectively
+	sharedWeaknessWeight = 6.0  // Per extra member weak to the same attacking type
+	statWeight           = 0.02 // Per point of average base stat total
+	imbalanceWeight      = 3.0  // Per extra physical or special attacker beyond an even split
+)
+
+// teamMember is a candidate for the team with its precomputed properties.
+type teamMember struct {
+	name      string   // Pokémon name in API format
+	types     []string // The Pokémon's types
+	statTotal int      // Base stat total
+	physical  bool     // Whether its Attack is higher than its Special Attack
+}
+
+// newTeamMember precomputes the properties of a Pokémon used in team scoring.
+func newTeamMember(name string, data pokeapi.PokemonDataResp) teamMember {
+	return teamMember{
+		name:      name,
+		types:     pokemonTypes(data),
+		statTotal: baseStatTotal(data),
+		physical:  baseStat(data, "attack") >= baseStat(data, "special-attack"),
+	}
+}
+
+// teamAnalysis summarizes the strengths and weaknesses of a team.
+type teamAnalysis struct {
+	covered          []string       // Types the team hits super-effectively, in standard order
+	uncovered        []string       // Types no member hits super-effectively
+	sharedWeaknesses map[string]int // Attacking types that two or more members are weak to
+	physical         int            // Number of physical attackers
+	special          int            // Number of special attackers
+	averageStats     float64        // Average base stat total
+	score            float64        // Overall rating; higher is better
+}
+
+// analyzeTeam scores a team's type coverage, shared weaknesses, and stats.
+//
+// Parameters:
+//   - chart: A type chart covering all standard types
+//   - team: The team members
+//
+// Returns:
+//   - The analysis of the team, including its score
+func analyzeTeam(chart typeChart, team []teamMember) teamAnalysis {
+	analysis := teamAnalysis{sharedWeaknesses: make(map[string]int)}
+	if len(team) == 0 {
+		analysis.uncovered = standardTypes
+		return analysis
+	}
+
+	// Offensive coverage: which types does some member's STAB hit for 2x or more?
+	for _, defending := range standardTypes {
+		covered := false
+		for _, member := range team {
+			if _, m := chart.bestAttack(member.types, []string{defending}); m > 1 {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			analysis.covered = append(analysis.covered, defending)
+		} else {
+			analysis.uncovered = append(analysis.uncovered, defending)
+		}
+	}
+
+	// Defensive balance: how many members share a weakness to each attacking type?
+	stacked := 0
+	for _, attacking := range standardTypes {
+		weak := 0
+		for _, member := range team {
+			if chart.effectiveness(attacking, member.types) > 1 {
+				weak++
+			}
+		}
+		if weak >= 2 {
+			analysis.sharedWeaknesses[attacking] = weak
+			stacked += weak - 1
+		}
+	}
+
+	// Stat distribution: total strength and a mix of physical and special attackers
+	totalStats := 0
+	for _, member := range team {
+		totalStats += member.statTotal
+		if member.physical {
+			analysis.physical++
+		} else {
+			analysis.special++
+		}
+	}
+	analysis.averageStats = float64(totalStats) / float64(len(team))
+	imbalance := max(analysis.physical-analysis.special, analysis.special-analysis.physical) / 2
+
+	analysis.score = coverageWeight*float64(len(analysis.covered)) -
+		sharedWeaknessWeight*float64(stacked) +
+		statWeight*analysis.averageStats -
+		imbalanceWeight*float64(imbalance)
+	return analysis
+}
+
+// buildTeam chooses the best team of up to maxTeamSize members from the candidates.
+// It builds a team greedily, adding whichever candidate improves the score most,
+// and then repeatedly swaps members for unused candidates while that improves the
+// score. This finds a strong team quickly without trying every combination.
+//
+// Parameters:
+//   - chart: A type chart covering all standard types
+//   - candidates: The Pokémon available for the team
+//
+// Returns:
+//   - The chosen team, ordered by name
+func buildTeam(chart typeChart, candidates []teamMember) []teamMember {
+	// Sort candidates so that ties are broken consistently
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].name < candidates[j].name
+	})
+	if len(candidates) <= maxTeamSize {
+		return candidates
+	}
+
+	used := make([]bool, len(candidates))
+	var team []teamMember
+	var indexes []int
+
+	// Greedy construction
+	for len(team) < maxTeamSize {
+		best, bestScore := -1, 0.0
+		for i, candidate := range candidates {
+			if used[i] {
+				continue
+			}
+			score := analyzeTeam(chart, append(team[:len(team):len(team)], candidate)).score
+			if best == -1 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		used[best] = true
+		team = append(team, candidates[best])
+		indexes = append(indexes, best)
+	}
+
+	// Local search: swap in unused candidates while the score improves
+	current := analyzeTeam(chart, team).score
+	for improved := true; improved; {
+		improved = false
+		for slot := range team {
+			for i, candidate := range candidates {
+				if used[i] {
+					continue
+				}
+				trial := append([]teamMember(nil), team...)
+				trial[slot] = candidate
+				if score := analyzeTeam(chart, trial).score; score > current {
+					used[indexes[slot]], used[i] = false, true
+					team, indexes[slot], current = trial, i, score
+					improved = true
+				}
+			}
+		}
+	}
+
+	sort.Slice(team, func(i, j int) bool {
+		return team[i].name < team[j].name
+	})
+	return team
+}
+
+// baseStat returns the value of one of a Pokémon's base stats, or 0 if it's missing.
+//
+// Parameters:
+//   - data: The Pokémon data
+//   - name: The stat name in API format (e.g. "special-attack")
+func baseStat(data pokeapi.PokemonDataResp, name string) int {
+	for _, s := range data.Stats {
+		if s.Stat.Name == name {
+			return s.BaseStat
+		}
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestBuildTeam tests that the team builder prefers type coverage and avoids
+// shared weaknesses over raw stats
+func TestBuildTeam(t *testing.T) {
+	chart := testTypeChart()
+
+	candidates := []teamMember{
+		{name: "dugtrio", types: []string{"ground"}, statTotal: 405, physical: true},
+		{name: "jolteon", types: []string{"electric"}, statTotal: 525},
+	}
+	for i := 1; i <= 6; i++ {
+		candidates = append(candidates, teamMember{
+			name:      fmt.Sprintf("water-%d", i),
+			types:     []string{"water"},
+			statTotal: 600,
+		})
+	}
+
+	team := buildTeam(chart, candidates)
+	if len(team) != maxTeamSize {
+		t.Fatalf("Expected a team of %d, got %d", maxTeamSize, len(team))
+	}
+
+	names := map[string]bool{}
+	for _, member := range team {
+		names[member.name] = true
+	}
+	if !names["dugtrio"] || !names["jolteon"] {
+		t.Errorf("Expected Dugtrio and Jolteon for coverage, got %v", names)
+	}
+
+	analysis := analyzeTeam(chart, team)
+	if len(analysis.covered) != 4 {
+		t.Errorf("Expected 4 covered types, got %v", analysis.covered)
+	}
+	if analysis.physical != 1 || analysis.special != 5 {
+		t.Errorf("Expected 1 physical and 5 special attackers, got %d and %d", analysis.physical, analysis.special)
+	}
+}
+
+// TestBuildTeamSmallPokedex tests that every candidate is used when there are too few to choose from
+func TestBuildTeamSmallPokedex(t *testing.T) {
+	candidates := []teamMember{
+		{name: "pikachu", types: []string{"electric"}},
+		{name: "bulbasaur", types: []string{"grass", "poison"}},
+	}
+	team := buildTeam(testTypeChart(), candidates)
+	if len(team) != 2 || team[0].name != "bulbasaur" || team[1].name != "pikachu" {
+		t.Errorf("Expected both candidates sorted by name, got %+v", team)
+	}
+}
@@ -0,0 +1,36 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+// This file puts the terminal into raw mode for the multi-select picker (see
+// multiselect_utils.go), so that it gets each key as it's pressed instead of
+// a line at a time, and doesn't echo them. Ctrl+C then reaches the picker as a
+// key, which cancels it, rather than interrupting the command.
+package main
+
+import "golang.org/x/sys/unix"
+
+// makeRaw puts a terminal into raw mode.
+//
+// Parameters:
+//   - fd: The terminal's file descriptor
+//
+// Returns:
+//   - A function that restores the terminal's previous mode
+//   - An error if the file isn't a terminal or its mode can't be changed
+func makeRaw(fd int) (func(), error) {
+	previous, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+	raw := *previous
+	// Output is left as it is, so that newlines still start a new line
+	raw.Lflag &^= unix.ICANON | unix.ECHO | unix.ISIG | unix.IEXTEN
+	raw.Iflag &^= unix.ICRNL | unix.IXON
+	raw.Cc[unix.VMIN] = 1
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+	return func() {
+		unix.IoctlSetTermios(fd, ioctlSetTermios, previous)
+	}, nil
+}
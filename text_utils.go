@@ -7,9 +7,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"unicode"
 
 	"golang.org/x/text/cases"
+	"golang.org/x/text/collate"
 	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
 )
 
 // PrettyPrint formats and displays any data structure as indented JSON.
@@ -28,7 +33,7 @@ import (
 func PrettyPrint(data interface{}) {
 	jsonData, err := json.MarshalIndent(data, "", "    ")
 	if err != nil {
-		fmt.Println("Error marshaling to JSON:", err)
+		i18n.Printf("Error marshaling to JSON: %v\n", err)
 		return
 	}
 	fmt.Println(string(jsonData))
@@ -92,6 +97,50 @@ func FormatLocationName(name string) string {
 	return strings.Join(words, " ")
 }
 
+// FormatItemName converts API item names (like "thunder-stone") to a user-friendly format (like "Thunder Stone").
+//
+// Parameters:
+//   - name: The raw item name with hyphens
+//
+// Returns:
+//   - A formatted item name with spaces and proper capitalization
+func FormatItemName(name string) string {
+	// Replace hyphens with spaces
+	name = strings.ReplaceAll(name, "-", " ")
+
+	// Split the name into words
+	words := strings.Fields(name)
+	for i, word := range words {
+		// Capitalize each word
+		words[i] = CapitalizeFirstLetter(word)
+	}
+
+	// Join the words back together
+	return strings.Join(words, " ")
+}
+
+// FormatAbilityName converts API ability names (like "lightning-rod") to a user-friendly format (like "Lightning Rod").
+//
+// Parameters:
+//   - name: The raw ability name with hyphens
+//
+// Returns:
+//   - A formatted ability name with spaces and proper capitalization
+func FormatAbilityName(name string) string {
+	// Replace hyphens with spaces
+	name = strings.ReplaceAll(name, "-", " ")
+
+	// Split the name into words
+	words := strings.Fields(name)
+	for i, word := range words {
+		// Capitalize each word
+		words[i] = CapitalizeFirstLetter(word)
+	}
+
+	// Join the words back together
+	return strings.Join(words, " ")
+}
+
 // FormatTypeName converts API type names (like "fire") to a capitalized format (like "Fire").
 //
 // Parameters:
@@ -103,6 +152,26 @@ func FormatTypeName(name string) string {
 	return CapitalizeFirstLetter(name)
 }
 
+// FormatTypeList formats a list of type names for display, joined by slashes.
+//
+// Parameters:
+//   - types: The raw type names (like "electric", "steel")
+//
+// Returns:
+//   - The formatted types (like "Electric/Steel")
+func FormatTypeList(types []string) string {
+	return strings.Join(formatTypeNames(types), "/")
+}
+
+// formatTypeNames formats each type name in a list for display.
+func formatTypeNames(types []string) []string {
+	formatted := make([]string, len(types))
+	for i, t := range types {
+		formatted[i] = FormatTypeName(t)
+	}
+	return formatted
+}
+
 // FormatStatName converts API stat names (like "special-attack") to a user-friendly format (like "Special Attack").
 //
 // Parameters:
@@ -125,86 +194,186 @@ func FormatStatName(name string) string {
 	return strings.Join(words, " ")
 }
 
-// FormatPokemonName converts API Pokémon names (like "pikachu") to a properly capitalized format (like "Pikachu").
+// pokemonDisplayNames holds the display names of the species that can't be
+// worked out from the API name by capitalizing it: those the API spells
+// without their accents, symbols, or punctuation, and those whose names are
+// several words. Each still converts back to its API name with
+// ConvertToAPIFormat. testdata/pokemon_names.golden lists every species with
+// its display name, and the tests check each one against this table.
+var pokemonDisplayNames = map[string]string{
+	"nidoran-f":    "Nidoran♀",
+	"nidoran-m":    "Nidoran♂",
+	"farfetchd":    "Farfetch'd",
+	"mr-mime":      "Mr. Mime",
+	"ho-oh":        "Ho-Oh",
+	"mime-jr":      "Mime Jr.",
+	"porygon-z":    "Porygon-Z",
+	"flabebe":      "Flabébé",
+	"type-null":    "Type: Null",
+	"jangmo-o":     "Jangmo-o",
+	"hakamo-o":     "Hakamo-o",
+	"kommo-o":      "Kommo-o",
+	"tapu-koko":    "Tapu Koko",
+	"tapu-lele":    "Tapu Lele",
+	"tapu-bulu":    "Tapu Bulu",
+	"tapu-fini":    "Tapu Fini",
+	"sirfetchd":    "Sirfetch'd",
+	"mr-rime":      "Mr. Rime",
+	"great-tusk":   "Great Tusk",
+	"scream-tail":  "Scream Tail",
+	"brute-bonnet": "Brute Bonnet",
+	"flutter-mane": "Flutter Mane",
+	"slither-wing": "Slither Wing",
+	"sandy-shocks": "Sandy Shocks",
+	"iron-treads":  "Iron Treads",
+	"iron-bundle":  "Iron Bundle",
+	"iron-hands":   "Iron Hands",
+	"iron-jugulis": "Iron Jugulis",
+	"iron-moth":    "Iron Moth",
+	"iron-thorns":  "Iron Thorns",
+	"wo-chien":     "Wo-Chien",
+	"chien-pao":    "Chien-Pao",
+	"ting-lu":      "Ting-Lu",
+	"chi-yu":       "Chi-Yu",
+	"roaring-moon": "Roaring Moon",
+	"iron-valiant": "Iron Valiant",
+	"walking-wake": "Walking Wake",
+	"iron-leaves":  "Iron Leaves",
+	"gouging-fire": "Gouging Fire",
+	"raging-bolt":  "Raging Bolt",
+	"iron-boulder": "Iron Boulder",
+	"iron-crown":   "Iron Crown",
+}
+
+// FormatPokemonName converts API Pokémon names (like "pikachu") to their display names (like "Pikachu").
+// Species use their official name from the API once it's known (see
+// species_names.go). Until then, species whose names can't be worked out by
+// capitalizing get theirs from pokemonDisplayNames ("mr-mime" -> "Mr. Mime",
+// "flabebe" -> "Flabébé"). The
+// names of alternate forms are the species' display name followed by the form,
+// each word capitalized ("charizard-mega-x" -> "Charizard-Mega-X",
+// "mr-mime-galar" -> "Mr. Mime-Galar").
 //
 // Parameters:
 //   - name: The raw Pokémon name
 //
 // Returns:
-//   - A formatted Pokémon name with proper capitalization
+//   - The Pokémon's display name
 func FormatPokemonName(name string) string {
 	if len(name) == 0 {
 		return name
 	}
+	parts := strings.Split(strings.ToLower(name), "-")
 
-	// Split the name by hyphens
-	parts := strings.Split(name, "-")
-
-	// Capitalize first letter of first part
-	parts[0] = cases.Title(language.English).String(parts[0])
-
-	// Keep the rest of the parts lowercase
-	for i := 1; i < len(parts); i++ {
-		parts[i] = strings.ToLower(parts[i])
+	// The species is the longest run of leading parts with a display name,
+	// or else the first part
+	species, form := CapitalizeFirstLetter(parts[0]), parts[1:]
+	for i := len(parts); i > 0; i-- {
+		prefix := strings.Join(parts[:i], "-")
+		if display, ok := speciesNames.lookup(prefix); ok {
+			species, form = display, parts[i:]
+			break
+		}
+		if display, ok := pokemonDisplayNames[prefix]; ok {
+			species, form = display, parts[i:]
+			break
+		}
 	}
-
-	// Join the parts back with hyphens
-	return strings.Join(parts, "-")
+	for _, part := range form {
+		species += "-" + CapitalizeFirstLetter(part)
+	}
+	return species
 }
 
+// genderSymbolReplacer spells out the gender symbols used in names like
+// "Nidoran♀" as the suffix letters the API uses ("nidoran-f").
+var genderSymbolReplacer = strings.NewReplacer(
+	"♀", " f",
+	"♂", " m",
+)
+
 // ConvertToAPIFormat converts a user-friendly formatted string (like "Mt Coronet 5F")
 // back to the API format (like "mt-coronet-5f").
 //
+// The conversion is Unicode-aware so display names round-trip without special cases:
+//   - Accented letters are decomposed and their marks dropped ("Flabébé" -> "flabebe")
+//   - Gender symbols become suffixes ("Nidoran♀" -> "nidoran-f")
+//   - Punctuation such as apostrophes, periods, and colons is removed
+//     ("Farfetch'd" -> "farfetchd", "Mr. Mime" -> "mr-mime", "Type: Null" -> "type-null")
+//
 // Parameters:
 //   - formattedName: The formatted name with spaces and proper capitalization
 //
 // Returns:
 //   - The name in API format with lowercase and hyphens instead of spaces
 func ConvertToAPIFormat(formattedName string) string {
-	// Handle special case Pokemon names
-	specialCasePokemon := map[string]string{
-		"mr mime":   "mr-mime",
-		"mr. mime":  "mr-mime",
-		"mime jr":   "mime-jr",
-		"mime jr.":  "mime-jr",
-		"type null": "type-null",
-		"type: null": "type-null",
-		"tapu koko": "tapu-koko",
-		"tapu lele": "tapu-lele",
-		"tapu bulu": "tapu-bulu",
-		"tapu fini": "tapu-fini",
-		"ho oh":    "ho-oh",
-		"porygon z": "porygon-z",
-		"jangmo o":  "jangmo-o",
-		"hakamo o":  "hakamo-o",
-		"kommo o":   "kommo-o",
-	}
-
-	// Check if this is a special case Pokemon name (case insensitive)
-	lowerName := strings.ToLower(formattedName)
-	if apiName, isSpecial := specialCasePokemon[lowerName]; isSpecial {
-		return apiName
-	}
+	// Keep letters and digits, treat spaces and hyphens as word separators,
+	// and drop punctuation entirely
+	cleaned := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return r
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			return ' '
+		default:
+			return -1
+		}
+	}, foldText(genderSymbolReplacer.Replace(formattedName)))
 
-	// Handle special cases for "é" character in "Pokémon"
-	formattedName = strings.ReplaceAll(formattedName, "é", "e")
+	// Collapse repeated separators and join the words with hyphens
+	return strings.Join(strings.Fields(cleaned), "-")
+}
 
-	// Remove special characters that aren't letters, numbers, or spaces
-	// This handles things like parentheses, quotation marks, etc.
-	var result strings.Builder
-	for _, r := range formattedName {
-		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == ' ' || r == '-' {
-			result.WriteRune(r)
+// foldText folds text for matching regardless of accents and case: accented
+// letters lose their marks and the case is folded, so "Flabébé", "FLABEBE",
+// and "flabebe" all fold to "flabebe".
+//
+// Parameters:
+//   - text: The text to fold
+//
+// Returns:
+//   - The folded text
+func foldText(text string) string {
+	// Decompose accented characters so the base letter and its mark are
+	// separate runes, and drop the marks
+	unmarked := strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
 		}
-	}
-	formattedName = result.String()
+		return r
+	}, norm.NFD.String(text))
+	return cases.Fold().String(unmarked)
+}
 
-	// Convert to lowercase
-	formattedName = strings.ToLower(formattedName)
+// containsFolded reports whether text contains query, ignoring accents and
+// case (see foldText), so a search for "pokemon" finds "Pokémon".
+func containsFolded(text, query string) bool {
+	return strings.Contains(foldText(text), foldText(query))
+}
 
-	// Replace multiple spaces with a single space
-	formattedName = strings.Join(strings.Fields(formattedName), " ")
+// sortText sorts display text in the order of the selected language, rather
+// than by code point: accented letters sort with their base letters and case
+// doesn't separate words, so "Équipo" sorts with the E's instead of after "z".
+//
+// Parameters:
+//   - texts: The text to sort, sorted in place
+func sortText(texts []string) {
+	collate.New(language.Make(i18n.Current())).SortStrings(texts)
+}
 
-	// Replace spaces with hyphens for the final API format
-	return strings.ReplaceAll(formattedName, " ", "-")
+// unquoteText removes the double quotes around the free text given to a
+// command, such as a note or a reminder's message. Text is quoted when it
+// contains ";" or "&&", which would otherwise separate commands (see
+// splitChainedCommands), and the quotes aren't part of the text.
+//
+// Parameters:
+//   - text: The text as typed
+//
+// Returns:
+//   - The text without the quotes around it, or unchanged if it isn't quoted
+func unquoteText(text string) string {
+	if len(text) >= 2 && strings.HasPrefix(text, `"`) && strings.HasSuffix(text, `"`) {
+		return text[1 : len(text)-1]
+	}
+	return text
 }
@@ -0,0 +1,147 @@
+// This file contains the output themes, which decide how command results
+// (see result_utils.go) are drawn. Each theme is a renderer that's given the
+// structured result rather than printed text, so it can lay out the message
+// however it likes, using the command's name or data. A new theme only needs
+// a type that implements renderer and an entry in themes. Commands that still
+// print directly keep their own look in every theme.
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Theme names, as typed with the theme command and saved in the save file.
+const (
+	themeClassic = "classic"
+	themeMinimal = "minimal"
+	themeRetro   = "retro"
+)
+
+// renderer draws command results in one theme.
+type renderer interface {
+	// render writes a command's result.
+	render(w io.Writer, result commandResult)
+}
+
+// themes are the available renderers, by theme name.
+var themes = map[string]renderer{
+	themeClassic: classicRenderer{},
+	themeMinimal: minimalRenderer{},
+	themeRetro:   retroRenderer{},
+}
+
+// themeNames lists the themes in the order they're offered to the user.
+var themeNames = []string{themeClassic, themeMinimal, themeRetro}
+
+// themeRenderer returns the renderer of a theme. Unknown themes, such as one
+// saved by a newer version, are drawn in the classic theme, and so is every
+// theme in accessible mode, which leaves out frames and other line drawing.
+//
+// Parameters:
+//   - theme: The theme name, or "" for the classic theme
+//
+// Returns:
+//   - The renderer to draw results with
+func themeRenderer(theme string) renderer {
+	if r, ok := themes[theme]; ok && !isAccessibleOutput() {
+		return r
+	}
+	return classicRenderer{}
+}
+
+// classicRenderer draws the message followed by a separator line.
+type classicRenderer struct{}
+
+func (classicRenderer) render(w io.Writer, result commandResult) {
+	fmt.Fprint(w, result.Message)
+	if isAccessibleOutput() {
+		fmt.Fprintln(w)
+		return
+	}
+	fmt.Fprintln(w, "-----")
+}
+
+// minimalRenderer draws only the message, separated from the next prompt by a
+// blank line.
+type minimalRenderer struct{}
+
+func (minimalRenderer) render(w io.Writer, result commandResult) {
+	fmt.Fprintln(w, strings.TrimRight(result.Message, "\n"))
+	fmt.Fprintln(w)
+}
+
+// retroRenderer draws the message in a double-lined frame, like the text
+// boxes of the handheld games, with the command's name in the top border.
+// Lines too long for the terminal are wrapped inside the frame.
+type retroRenderer struct{}
+
+// retroMaxWidth is the widest a retro frame gets, borders included, so that
+// short messages aren't stretched across a wide terminal.
+const retroMaxWidth = 72
+
+func (retroRenderer) render(w io.Writer, result commandResult) {
+	inner := min(terminalWidth(), retroMaxWidth) - 4 // Two columns of border and padding on each side
+	title := strings.ToUpper(result.Command)
+	var lines []string
+	width := displayWidth(title) + 2
+	for _, line := range strings.Split(strings.TrimRight(result.Message, "\n"), "\n") {
+		for _, wrapped := range wrapLine(line, inner) {
+			lines = append(lines, wrapped)
+			width = max(width, displayWidth(wrapped))
+		}
+	}
+
+	top := "═"
+	if title != "" {
+		top += " " + title + " "
+	}
+	fmt.Fprintln(w, "╔"+top+strings.Repeat("═", width+2-displayWidth(top))+"╗")
+	for _, line := range lines {
+		fmt.Fprintln(w, "║ "+line+strings.Repeat(" ", width-displayWidth(line))+" ║")
+	}
+	fmt.Fprintln(w, "╚"+strings.Repeat("═", width+2)+"╝")
+}
+
+// wrapLine splits a line into lines no wider than width, breaking between
+// words. A word wider than the line is cut.
+//
+// Parameters:
+//   - line: The line to wrap
+//   - width: The widest a line may be, in columns
+//
+// Returns:
+//   - The wrapped lines; a blank line stays one blank line
+func wrapLine(line string, width int) []string {
+	if width <= 0 || displayWidth(line) <= width {
+		return []string{line}
+	}
+	indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(line) {
+		for displayWidth(word) > width {
+			runes := []rune(word)
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			lines = append(lines, string(runes[:width]))
+			word = string(runes[width:])
+		}
+		switch {
+		case current == "":
+			current = indent + word
+		case displayWidth(current)+1+displayWidth(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = indent + word
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestThemeRenderers tests how each theme draws the same result
+func TestThemeRenderers(t *testing.T) {
+	t.Setenv("COLUMNS", "80")
+	result := commandResult{Command: "odds", Message: "Pikachu\n  Poké Ball: 30%\n"}
+	cases := []struct {
+		theme    string
+		expected string
+	}{
+		{themeClassic, "Pikachu\n  Poké Ball: 30%\n-----\n"},
+		{themeMinimal, "Pikachu\n  Poké Ball: 30%\n\n"},
+		{themeRetro, strings.Join([]string{
+			"╔═ ODDS ═══════════╗",
+			"║ Pikachu          ║",
+			"║   Poké Ball: 30% ║",
+			"╚══════════════════╝",
+			"",
+		}, "\n")},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		themeRenderer(c.theme).render(&buf, result)
+		if buf.String() != c.expected {
+			t.Errorf("%s: unexpected output:\n%s\nexpected:\n%s", c.theme, buf.String(), c.expected)
+		}
+	}
+}
+
+// TestThemeRendererFallback tests that unknown themes, and every theme in
+// accessible mode, are drawn in the classic theme
+func TestThemeRendererFallback(t *testing.T) {
+	if _, ok := themeRenderer("neon").(classicRenderer); !ok {
+		t.Error("Expected an unknown theme to be drawn in the classic theme")
+	}
+
+	configureOutput(true)
+	defer configureOutput(false)
+	if _, ok := themeRenderer(themeRetro).(classicRenderer); !ok {
+		t.Error("Expected the classic theme in accessible mode")
+	}
+}
+
+// TestWrapLine tests wrapping long lines between words, keeping their indent
+func TestWrapLine(t *testing.T) {
+	cases := []struct {
+		line     string
+		width    int
+		expected []string
+	}{
+		{"short", 10, []string{"short"}},
+		{"", 10, []string{""}},
+		{"one two three four", 9, []string{"one two", "three", "four"}},
+		{"  indented words here", 10, []string{"  indented", "  words", "  here"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+	}
+	for _, c := range cases {
+		if got := wrapLine(c.line, c.width); !slices.Equal(got, c.expected) {
+			t.Errorf("wrapLine(%q, %d): expected %q, got %q", c.line, c.width, c.expected, got)
+		}
+	}
+}
+
+// TestThemeSaved tests that the theme is checked, saved, and restored
+func TestThemeSaved(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := commandTheme(cfg, []string{"neon"}); err == nil {
+		t.Error("Expected an unknown theme to be refused")
+	}
+	if err := commandTheme(cfg, []string{"Retro"}); err != nil {
+		t.Fatalf("commandTheme returned an error: %v", err)
+	}
+
+	restored := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := loadPokedexData(restored); err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if got := restored.Settings().theme; got != themeRetro {
+		t.Errorf("Expected the retro theme to be restored, got %q", got)
+	}
+}
@@ -0,0 +1,92 @@
+// This file contains the trainer level, which grows with the total base
+// experience of the Pokémon in the Pokédex and unlocks features as it goes:
+// gym battles, and then legendary Pokémon. The level is worked out from the
+// Pokédex each time it's needed rather than saved, so it always matches the
+// collection; releasing Pokémon lowers it again.
+package main
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// Trainer level curve: reaching level n takes trainerLevelStep * (n-1)²
+// experience, so a first Pikachu is enough for level 2 and each level takes
+// a little more than the last.
+const (
+	trainerLevelStep = 100
+	maxTrainerLevel  = 100
+)
+
+// trainerGate is a feature that unlocks at a trainer level.
+type trainerGate struct {
+	feature string // The feature's display name, in the plural (e.g. "Gym battles")
+	level   int    // The trainer level it unlocks at
+}
+
+// The features that are locked until the trainer reaches their level, in the
+// order they unlock.
+var (
+	gymGate       = trainerGate{feature: "Gym battles", level: 5}
+	legendaryGate = trainerGate{feature: "Legendary Pokémon", level: 10}
+	trainerGates  = []trainerGate{gymGate, legendaryGate}
+)
+
+// trainerProgress is how far the trainer has come.
+type trainerProgress struct {
+	Level      int `json:"level"`      // The trainer level, from 1 to maxTrainerLevel
+	Experience int `json:"experience"` // The total base experience of the Pokémon in the Pokédex
+	NextLevel  int `json:"next_level"` // The experience the next level takes (zero at maxTrainerLevel)
+}
+
+// trainerLevelExperience returns the experience a trainer level takes.
+func trainerLevelExperience(level int) int {
+	return trainerLevelStep * (level - 1) * (level - 1)
+}
+
+// trainerLevelFor returns the trainer level reached with an amount of experience.
+//
+// Parameters:
+//   - experience: The total base experience of the Pokémon in the Pokédex
+//
+// Returns:
+//   - The progress at that experience
+func trainerLevelFor(experience int) trainerProgress {
+	progress := trainerProgress{Level: 1, Experience: experience}
+	for progress.Level < maxTrainerLevel && experience >= trainerLevelExperience(progress.Level+1) {
+		progress.Level++
+	}
+	if progress.Level < maxTrainerLevel {
+		progress.NextLevel = trainerLevelExperience(progress.Level + 1)
+	}
+	return progress
+}
+
+// currentTrainerProgress works out the trainer level from the Pokédex.
+// Pokémon caught before base experience was recorded count for nothing.
+func currentTrainerProgress(cfg *config) trainerProgress {
+	experience := 0
+	for _, caught := range cfg.pokedex.List() {
+		experience += caught.Entry.BaseExperience
+	}
+	return trainerLevelFor(experience)
+}
+
+// requireTrainerLevel checks that the trainer has reached the level that
+// unlocks a feature.
+//
+// Parameters:
+//   - cfg: The application configuration containing the Pokédex
+//   - gate: The feature and the level it unlocks at
+//
+// Returns:
+//   - An error saying what level is needed if it hasn't been reached
+func requireTrainerLevel(cfg *config, gate trainerGate) error {
+	progress := currentTrainerProgress(cfg)
+	if progress.Level >= gate.level {
+		return nil
+	}
+	return errorhandling.NewInvalidInputError(
+		i18n.Sprintf("%s unlock at trainer level %d, and you're level %d. Catch more Pokémon to level up (see 'trainer').",
+			i18n.T(gate.feature), gate.level, progress.Level), nil)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestTrainerLevelFor tests the experience each trainer level takes
+func TestTrainerLevelFor(t *testing.T) {
+	cases := []struct {
+		experience int
+		level      int
+		next       int
+	}{
+		{0, 1, 100},
+		{99, 1, 100},
+		{112, 2, 400},
+		{1600, 5, 2500},
+		{8099, 9, 8100},
+		{8100, 10, 10000},
+		{10_000_000, maxTrainerLevel, 0},
+	}
+	for _, c := range cases {
+		got := trainerLevelFor(c.experience)
+		if got.Level != c.level || got.NextLevel != c.next {
+			t.Errorf("trainerLevelFor(%d) = %+v, want level %d with the next at %d", c.experience, got, c.level, c.next)
+		}
+	}
+}
+
+// TestRequireTrainerLevel tests that features unlock as the Pokédex grows
+func TestRequireTrainerLevel(t *testing.T) {
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	if err := requireTrainerLevel(cfg, gymGate); err == nil {
+		t.Error("Expected gym battles to be locked for a new trainer")
+	}
+
+	// 20 Pokémon worth 100 each is 2000 experience: level 5
+	for i := range 20 {
+		name := "pokemon-" + string(rune('a'+i))
+		cfg.pokedex.Add(name, pokedex.NewEntry(pokeapi.PokemonDataResp{Name: name, BaseExperience: 100}))
+	}
+	if err := requireTrainerLevel(cfg, gymGate); err != nil {
+		t.Errorf("Expected gym battles to be unlocked at level 5, got %v", err)
+	}
+	err := requireTrainerLevel(cfg, legendaryGate)
+	if err == nil || !strings.Contains(err.Error(), "level 10") {
+		t.Errorf("Expected legendary Pokémon to be locked until level 10, got %v", err)
+	}
+
+	result, err := trainerResult(cfg, nil)
+	if err != nil {
+		t.Fatalf("trainerResult returned an error: %v", err)
+	}
+	profile := result.Data.(trainerProfile)
+	if profile.Level != 5 || profile.Caught != 20 || len(profile.Unlocked) != 1 || len(profile.Locked) != 1 {
+		t.Errorf("Unexpected profile %+v", profile)
+	}
+	if !strings.Contains(result.Message, "Trainer level 5") || !strings.Contains(result.Message, "Next level: 500 more experience") {
+		t.Errorf("Unexpected profile message:\n%s", result.Message)
+	}
+}
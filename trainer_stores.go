@@ -0,0 +1,131 @@
+// This file keeps the Pokédexes of the trainers using serve mode. A call
+// names its trainer with the x-trainer-id metadata key; each trainer has a
+// Pokédex, settings, and save file of their own (<id>.json in a trainers
+// folder next to the user's save file), loaded the first time they make a
+// call. Calls that don't name a trainer use the user's own Pokédex. The API
+// client, with its cache, and the species data are shared by everyone.
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/bmlevitt/pokedexcli/internal/errorhandling"
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// trainerMetadata is the gRPC metadata key naming the trainer a call is for.
+const trainerMetadata = "x-trainer-id"
+
+// validTrainerID matches the IDs trainers can be named by, which are also
+// the names of their save files: letters, digits, '-', and '_', such as a
+// chat platform's user ID.
+var validTrainerID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// trainerStore is a trainer's Pokédex and settings. Its mutex is held for
+// the whole of each call, so a trainer's calls are handled one at a time
+// while other trainers' calls run alongside them.
+type trainerStore struct {
+	cfg   *config
+	mutex sync.Mutex
+}
+
+// trainerStores holds the stores of the trainers that have made calls.
+type trainerStores struct {
+	local  *trainerStore            // The user's own store, for calls that don't name a trainer
+	dir    string                   // The directory the trainers' save files are kept in
+	stores map[string]*trainerStore // The other trainers' stores, by ID
+	mutex  sync.Mutex               // Protects stores
+}
+
+// newTrainerStores returns the trainer stores for serve mode.
+//
+// Parameters:
+//   - cfg: The application configuration, with the user's own Pokédex and
+//     the API client the trainers share
+//   - dir: The directory to keep the trainers' save files in
+//
+// Returns:
+//   - The trainer stores, with only the user's own loaded
+func newTrainerStores(cfg *config, dir string) *trainerStores {
+	return &trainerStores{
+		local:  &trainerStore{cfg: cfg},
+		dir:    dir,
+		stores: make(map[string]*trainerStore),
+	}
+}
+
+// get returns a trainer's store, loading their save file the first time. A
+// trainer without a save file starts with an empty Pokédex and the default
+// settings.
+//
+// Parameters:
+//   - id: The trainer's ID, or "" for the user's own store
+//
+// Returns:
+//   - The trainer's store
+//   - An error if the ID is invalid or the trainer's save file can't be read
+func (s *trainerStores) get(id string) (*trainerStore, error) {
+	if id == "" {
+		return s.local, nil
+	}
+	if !validTrainerID.MatchString(id) {
+		return nil, errorhandling.NewInvalidInputError(
+			i18n.Sprintf("Invalid trainer ID '%s'. Use up to 64 letters, digits, '-', and '_'.", id), nil)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if store, ok := s.stores[id]; ok {
+		return store, nil
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return nil, errorhandling.NewInternalError("Could not create the trainers' save folder", err)
+	}
+	cfg := newTrainerConfig(s.local.cfg, filepath.Join(s.dir, id+".json"))
+	// The interface language stays the user's, so only the trainer's state is applied
+	saveData, found, err := readPokedexData(cfg)
+	if err != nil {
+		return nil, errorhandling.NewInternalError(i18n.Sprintf("Could not load the Pokédex of trainer '%s'", id), err)
+	}
+	if found {
+		applySavedState(cfg, saveData)
+	}
+	store := &trainerStore{cfg: cfg}
+	s.stores[id] = store
+	return store, nil
+}
+
+// newTrainerConfig returns the configuration of a trainer other than the
+// user. It has its own Pokédex, settings, and save file, and shares the API
+// client, species data, and search and name indexes with the user's
+// configuration.
+func newTrainerConfig(local *config, saveFilePath string) *config {
+	local.mutex.RLock()
+	defer local.mutex.RUnlock()
+	return &config{
+		pokeapiClient:   local.pokeapiClient,
+		pokedex:         pokedex.New(),
+		settings:        defaultSettings(),
+		nameIndex:       local.nameIndex,
+		searchIndex:     local.searchIndex,
+		searchIndexPath: local.searchIndexPath,
+		dataset:         local.dataset,
+		saveFilePath:    saveFilePath,
+		saveTrigger:     local.saveTrigger,
+	}
+}
+
+// trainerSaveDir returns the directory the trainers' save files are kept in,
+// next to the user's own save file.
+func trainerSaveDir(cfg *config) (string, error) {
+	saveFilePath, err := getSaveFilePath(cfg)
+	if err != nil {
+		return "", fmt.Errorf("error determining save file path: %w", err)
+	}
+	return filepath.Join(filepath.Dir(saveFilePath), "trainers"), nil
+}
@@ -0,0 +1,172 @@
+// This file contains the NPC trainers and the battle simulation used by the
+// fight command. Trainers belong to a themed class whose team is drawn from
+// the Pokémon of the class's types, and battles are decided round by round
+// using the same type matchups as the counter command.
+package main
+
+import (
+	"sort"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// trainerClass describes a kind of NPC trainer, such as a Bug Catcher.
+type trainerClass struct {
+	id       string   // Identifier typed by the user (e.g. "bug-catcher")
+	name     string   // Display name (e.g. "Bug Catcher")
+	types    []string // The types the trainer's Pokémon are drawn from
+	teamSize int      // The number of Pokémon on the trainer's team
+	prize    int      // Money awarded per defeated Pokémon
+}
+
+// trainerClasses lists the NPC trainers the user can fight.
+var trainerClasses = []trainerClass{
+	{id: "bug-catcher", name: "Bug Catcher", types: []string{"bug"}, teamSize: 2, prize: 200},
+	{id: "swimmer", name: "Swimmer", types: []string{"water"}, teamSize: 2, prize: 240},
+	{id: "bird-keeper", name: "Bird Keeper", types: []string{"flying"}, teamSize: 3, prize: 280},
+	{id: "hiker", name: "Hiker", types: []string{"rock", "ground"}, teamSize: 3, prize: 320},
+	{id: "kindler", name: "Kindler", types: []string{"fire"}, teamSize: 3, prize: 320},
+	{id: "guitarist", name: "Guitarist", types: []string{"electric"}, teamSize: 3, prize: 360},
+	{id: "psychic", name: "Psychic", types: []string{"psychic"}, teamSize: 4, prize: 400},
+}
+
+// findTrainerClass looks up a trainer class by its identifier.
+//
+// Parameters:
+//   - id: The class identifier (e.g. "swimmer")
+//
+// Returns:
+//   - The trainer class
+//   - Whether a class with that identifier exists
+func findTrainerClass(id string) (trainerClass, bool) {
+	for _, class := range trainerClasses {
+		if class.id == id {
+			return class, true
+		}
+	}
+	return trainerClass{}, false
+}
+
+// maxDefaultPokemonID is the highest ID of a Pokémon's default form.
+// Alternate forms such as Mega Evolutions have IDs above 10000.
+const maxDefaultPokemonID = 10000
+
+// trainerPool returns the default-form Pokémon that have any of the given types.
+// Each Pokémon is listed once, even if it has two of the types.
+//
+// Parameters:
+//   - types: The type data, including the Pokémon of each type
+//
+// Returns:
+//   - The API names of the Pokémon, in the order they were found
+func trainerPool(types []pokeapi.TypeResp) []string {
+	seen := make(map[string]bool)
+	var pool []string
+	for _, t := range types {
+		for _, p := range t.Pokemon {
+			id, err := p.Pokemon.ID()
+			if err != nil || id > maxDefaultPokemonID || seen[p.Pokemon.Name] {
+				continue
+			}
+			seen[p.Pokemon.Name] = true
+			pool = append(pool, p.Pokemon.Name)
+		}
+	}
+	return pool
+}
+
+// selectTrainerTeam picks the Pokémon closest in strength to the user's team,
+// so that trainers are a fair match whatever the user has caught.
+//
+// Parameters:
+//   - candidates: The Pokémon the trainer could use
+//   - targetTotal: The base stat total to aim for
+//   - size: The number of Pokémon to pick
+//
+// Returns:
+//   - Up to size Pokémon, closest to the target first
+func selectTrainerTeam(candidates []pokeapi.PokemonDataResp, targetTotal, size int) []pokeapi.PokemonDataResp {
+	sorted := make([]pokeapi.PokemonDataResp, len(candidates))
+	copy(sorted, candidates)
+	distance := func(data pokeapi.PokemonDataResp) int {
+		return max(baseStatTotal(data)-targetTotal, targetTotal-baseStatTotal(data))
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return distance(sorted[i]) < distance(sorted[j])
+	})
+	return sorted[:min(len(sorted), size)]
+}
+
+// battleRound records the outcome of one round of a battle.
+type battleRound struct {
+	player   string  // The user's Pokémon in API format
+	opponent string  // The trainer's Pokémon in API format
+	chance   float64 // The chance the user's Pokémon had of winning (0-1)
+	won      bool    // Whether the user's Pokémon won the round
+}
+
+// battleResult records how a battle went.
+type battleResult struct {
+	rounds []battleRound // The rounds in the order they were fought
+	won    bool          // Whether the user won the battle
+}
+
+// winChance converts a matchup score into the chance of winning a round.
+// An even matchup (score 1) is a coin flip, and the chance approaches
+// certainty as one side's advantage grows.
+func winChance(score float64) float64 {
+	return score / (score + 1)
+}
+
+// simulateBattle fights the user's team against a trainer's team.
+// In each round the trainer's next Pokémon faces whichever of the user's
+// remaining Pokémon has the best matchup against it. The loser of a round
+// faints and the winner stays in. The battle ends when one side has no
+// Pokémon left.
+//
+// Parameters:
+//   - chart: A type chart covering every type on both teams
+//   - player: The user's team, keyed by name in the Pokédex
+//   - opponent: The trainer's team, in the order it is sent out
+//   - roll: Returns a random number in [0, 1) for each round
+//
+// Returns:
+//   - The rounds fought and the outcome
+func simulateBattle(chart typeChart, player map[string]pokeapi.PokemonDataResp,
+	opponent []pokeapi.PokemonDataResp, roll func() float64) battleResult {
+	remaining := make([]string, 0, len(player))
+	for name := range player {
+		remaining = append(remaining, name)
+	}
+	sort.Strings(remaining)
+
+	var result battleResult
+	next := 0
+	for next < len(opponent) && len(remaining) > 0 {
+		target := opponent[next]
+
+		// Send out the best remaining counter
+		best, bestScore := 0, -1.0
+		for i, name := range remaining {
+			if score := evaluateMatchup(chart, player[name], target).score; score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		round := battleRound{
+			player:   remaining[best],
+			opponent: target.Name,
+			chance:   winChance(bestScore),
+		}
+		round.won = roll() < round.chance
+		if round.won {
+			next++
+		} else {
+			remaining = append(remaining[:best], remaining[best+1:]...)
+		}
+		result.rounds = append(result.rounds, round)
+	}
+
+	result.won = next == len(opponent)
+	return result
+}
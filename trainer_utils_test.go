@@ -0,0 +1,110 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// TestFindTrainerClass tests that trainer classes are found by identifier
+func TestFindTrainerClass(t *testing.T) {
+	class, ok := findTrainerClass("swimmer")
+	if !ok || class.name != "Swimmer" || class.types[0] != "water" {
+		t.Errorf("Expected the Swimmer class, got %+v (found=%v)", class, ok)
+	}
+	if _, ok := findTrainerClass("champion"); ok {
+		t.Error("Expected no class named champion")
+	}
+}
+
+// TestTrainerPool tests that alternate forms and duplicates are left out of a trainer's pool
+func TestTrainerPool(t *testing.T) {
+	pokemon := func(name, id string) pokeapi.TypePokemon {
+		return pokeapi.TypePokemon{Pokemon: pokeapi.NamedAPIResource{
+			Name: name, URL: "https://pokeapi.co/api/v2/pokemon/" + id + "/"}}
+	}
+	rock := pokeapi.TypeResp{Name: "rock", Pokemon: []pokeapi.TypePokemon{
+		pokemon("geodude", "74"), pokemon("onix", "95"), pokemon("tyranitar-mega", "10049"),
+	}}
+	ground := pokeapi.TypeResp{Name: "ground", Pokemon: []pokeapi.TypePokemon{
+		pokemon("geodude", "74"), pokemon("diglett", "50"),
+	}}
+
+	pool := trainerPool([]pokeapi.TypeResp{rock, ground})
+	expected := []string{"geodude", "onix", "diglett"}
+	if len(pool) != len(expected) {
+		t.Fatalf("Expected pool %v, got %v", expected, pool)
+	}
+	for i := range expected {
+		if pool[i] != expected[i] {
+			t.Errorf("Expected pool %v, got %v", expected, pool)
+			break
+		}
+	}
+}
+
+// TestSelectTrainerTeam tests that the Pokémon closest in strength are chosen
+func TestSelectTrainerTeam(t *testing.T) {
+	candidates := []pokeapi.PokemonDataResp{
+		testMatchupPokemon(t, "caterpie", 195, "bug"),
+		testMatchupPokemon(t, "scyther", 500, "bug"),
+		testMatchupPokemon(t, "beedrill", 395, "bug"),
+		testMatchupPokemon(t, "butterfree", 395, "bug"),
+	}
+
+	team := selectTrainerTeam(candidates, 420, 2)
+	if len(team) != 2 || team[0].Name != "beedrill" || team[1].Name != "butterfree" {
+		t.Errorf("Expected beedrill and butterfree, got %v", team)
+	}
+
+	if team := selectTrainerTeam(candidates[:1], 420, 3); len(team) != 1 {
+		t.Errorf("Expected the team to be limited to the candidates, got %d members", len(team))
+	}
+}
+
+// TestSimulateBattle tests that the best counter is sent out each round and
+// that the battle ends when one side has no Pokémon left
+func TestSimulateBattle(t *testing.T) {
+	chart := testTypeChart()
+	player := map[string]pokeapi.PokemonDataResp{
+		"pikachu":   testMatchupPokemon(t, "pikachu", 320, "electric"),
+		"sandslash": testMatchupPokemon(t, "sandslash", 450, "ground"),
+	}
+	opponent := []pokeapi.PokemonDataResp{
+		testMatchupPokemon(t, "squirtle", 314, "water"),
+		testMatchupPokemon(t, "magnemite", 325, "electric", "steel"),
+	}
+
+	// Every round is won: Pikachu counters Squirtle, then Sandslash counters Magnemite
+	result := simulateBattle(chart, player, opponent, func() float64 { return 0 })
+	if !result.won || len(result.rounds) != 2 {
+		t.Fatalf("Expected a win in 2 rounds, got %+v", result)
+	}
+	if result.rounds[0].player != "pikachu" || result.rounds[1].player != "sandslash" {
+		t.Errorf("Expected Pikachu then Sandslash to be sent out, got %+v", result.rounds)
+	}
+	if result.rounds[0].chance <= 0.5 {
+		t.Errorf("Expected Pikachu to be favored against Squirtle, got %.2f", result.rounds[0].chance)
+	}
+
+	// Every round is lost: both of the user's Pokémon faint against Squirtle
+	result = simulateBattle(chart, player, opponent, func() float64 { return 1 })
+	if result.won || len(result.rounds) != 2 {
+		t.Fatalf("Expected a loss in 2 rounds, got %+v", result)
+	}
+	for _, round := range result.rounds {
+		if round.opponent != "squirtle" || round.won {
+			t.Errorf("Expected every round to be lost to Squirtle, got %+v", round)
+		}
+	}
+}
+
+// TestWinChance tests that an even matchup is a coin flip
+func TestWinChance(t *testing.T) {
+	if chance := winChance(1); chance != 0.5 {
+		t.Errorf("Expected an even matchup to have a 50%% chance, got %v", chance)
+	}
+	if winChance(4) <= winChance(2) {
+		t.Error("Expected a better matchup to have a higher chance")
+	}
+}
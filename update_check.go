@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+	"github.com/bmlevitt/pokedexcli/internal/update"
+)
+
+// Constants for the startup update check
+const (
+	updateCheckInterval = 24 * time.Hour  // How often the startup check asks GitHub
+	updateCheckTimeout  = 2 * time.Second // How long the startup check may delay startup
+	updateCheckEnv      = "POKEDEXCLI_NO_UPDATE_CHECK"
+)
+
+// updateState is the data stored in the update state file between sessions.
+type updateState struct {
+	LastChecked time.Time      `json:"last_checked"`
+	Latest      update.Release `json:"latest"`
+	NotesShown  string         `json:"notes_shown,omitempty"` // The newest version whose release notes were shown (see command_whatsnew.go)
+}
+
+// releaseFetcher looks up the latest release. It's a parameter so tests don't need GitHub.
+type releaseFetcher func(ctx context.Context) (update.Release, error)
+
+// getUpdateStatePath returns the path of the file that records when releases
+// were last checked, in the state directory (see paths_utils.go).
+func getUpdateStatePath() string {
+	return updateStateFile.path()
+}
+
+// fetchLatestRelease asks GitHub for the latest release, identifying the application
+// the same way as requests to the PokeAPI.
+func fetchLatestRelease(ctx context.Context) (update.Release, error) {
+	return update.LatestRelease(ctx, http.DefaultClient, pokeapi.UserAgent(appVersion()))
+}
+
+// updateCheckDisabled reports whether the startup update check should be skipped,
+// either because the user turned it off or because nobody would see the hint.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - noUpdateCheck: Whether the --no-update-check flag was given
+//   - usingFixtures: Whether API responses are served from fixtures
+func updateCheckDisabled(cfg *config, noUpdateCheck, usingFixtures bool) bool {
+	return noUpdateCheck || os.Getenv(updateCheckEnv) != "" || cfg.batch != nil || usingFixtures
+}
+
+// checkForUpdate returns the latest release, asking GitHub at most once per
+// updateCheckInterval unless forced. Between checks, the release found by the
+// last check is returned. Failed checks are recorded too, so an offline user
+// isn't delayed at every startup.
+//
+// Parameters:
+//   - ctx: Context for cancelling the request
+//   - statePath: The path of the update state file
+//   - now: The current time
+//   - force: Whether to ask GitHub even if the last check was recent
+//   - fetch: The function that looks up the latest release
+//
+// Returns:
+//   - The latest known release (empty if none is known)
+//   - An error if GitHub was asked and the check failed
+func checkForUpdate(ctx context.Context, statePath string, now time.Time, force bool, fetch releaseFetcher) (update.Release, error) {
+	state, _ := readUpdateState(statePath)
+	if !force && now.Sub(state.LastChecked) < updateCheckInterval && !state.LastChecked.After(now) {
+		return state.Latest, nil
+	}
+
+	release, fetchErr := fetch(ctx)
+	state.LastChecked = now
+	if fetchErr == nil {
+		state.Latest = release
+	}
+
+	writeUpdateState(statePath, state)
+	return state.Latest, fetchErr
+}
+
+// readUpdateState reads the update state file. A corrupt file is treated as
+// never having checked.
+//
+// Returns:
+//   - The state, empty if there's no file or it can't be read
+//   - Whether the file exists
+func readUpdateState(statePath string) (updateState, bool) {
+	var state updateState
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return state, false
+	}
+	if json.Unmarshal(data, &state) != nil {
+		state = updateState{}
+	}
+	return state, true
+}
+
+// writeUpdateState writes the update state file. The state only rate-limits
+// checks and avoids repeating release notes, so failing to write it isn't an error.
+func writeUpdateState(statePath string, state updateState) {
+	if data, err := json.MarshalIndent(state, "", "  "); err == nil {
+		_ = os.WriteFile(statePath, data, 0644)
+	}
+}
+
+// updateHint returns the message telling the user about a newer release,
+// or "" if current is up to date or isn't a release version.
+func updateHint(current string, latest update.Release) string {
+	if !update.Newer(latest.Version, current) {
+		return ""
+	}
+	return i18n.Sprintf("A new version of the Pokédex CLI is available: %s (you have %s)\nDownload it from %s",
+		latest.Version, current, latest.URL)
+}
+
+// notifyUpdate prints an upgrade hint at startup if a newer release is available.
+// Development builds are never checked, and any failure is ignored so the
+// check can't get in the way of starting the application.
+func notifyUpdate() {
+	current := appVersion()
+	if !update.IsRelease(current) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+	defer cancel()
+
+	// On failure, the release found by an earlier check can still be reported
+	latest, _ := checkForUpdate(ctx, getUpdateStatePath(), time.Now(), false, fetchLatestRelease)
+	if hint := updateHint(current, latest); hint != "" {
+		fmt.Println(hint)
+		printSeparator()
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/update"
+)
+
+// TestCheckForUpdate tests that GitHub is asked at most once per interval
+// and that the last known release is kept between checks
+func TestCheckForUpdate(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), updateStateFile.name)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	calls := 0
+	release := update.Release{Version: "v1.3.0", URL: "https://github.com/bmlevitt/pokedexcli/releases/tag/v1.3.0"}
+	var fetchErr error
+	fetch := func(ctx context.Context) (update.Release, error) {
+		calls++
+		return release, fetchErr
+	}
+
+	latest, err := checkForUpdate(context.Background(), statePath, now, false, fetch)
+	if err != nil || latest != release || calls != 1 {
+		t.Fatalf("First check: got %+v, %v after %d calls", latest, err, calls)
+	}
+
+	// A recent check is reused without asking again
+	latest, err = checkForUpdate(context.Background(), statePath, now.Add(time.Hour), false, fetch)
+	if err != nil || latest != release || calls != 1 {
+		t.Errorf("Recent check: got %+v, %v after %d calls", latest, err, calls)
+	}
+
+	// Forcing a check always asks
+	if _, err := checkForUpdate(context.Background(), statePath, now.Add(time.Hour), true, fetch); err != nil || calls != 2 {
+		t.Errorf("Forced check: got %v after %d calls", err, calls)
+	}
+
+	// A failed check keeps the last known release and is still rate-limited
+	fetchErr = errors.New("offline")
+	later := now.Add(2 * updateCheckInterval)
+	latest, err = checkForUpdate(context.Background(), statePath, later, false, fetch)
+	if err == nil || latest != release || calls != 3 {
+		t.Errorf("Failed check: got %+v, %v after %d calls", latest, err, calls)
+	}
+	if _, err := checkForUpdate(context.Background(), statePath, later.Add(time.Minute), false, fetch); err != nil || calls != 3 {
+		t.Errorf("Expected no check right after a failure, got %v after %d calls", err, calls)
+	}
+}
+
+// TestUpdateHint tests that the hint is only shown for newer releases
+func TestUpdateHint(t *testing.T) {
+	latest := update.Release{Version: "v1.3.0", URL: "https://github.com/bmlevitt/pokedexcli/releases/tag/v1.3.0"}
+
+	hint := updateHint("v1.2.0", latest)
+	if !strings.Contains(hint, "v1.3.0") || !strings.Contains(hint, latest.URL) {
+		t.Errorf("Expected a hint naming the release, got %q", hint)
+	}
+	for _, current := range []string{"v1.3.0", "v1.4.0", "dev"} {
+		if hint := updateHint(current, latest); hint != "" {
+			t.Errorf("Expected no hint for %s, got %q", current, hint)
+		}
+	}
+	if hint := updateHint("v1.2.0", update.Release{}); hint != "" {
+		t.Errorf("Expected no hint without a known release, got %q", hint)
+	}
+}
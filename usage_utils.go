@@ -0,0 +1,131 @@
+// This file keeps the usage counts: how many times each command has been run,
+// for users who turn counting on with 'usage on'. Only command names are
+// counted, never their parameters, so no Pokémon, locations, or notes are
+// recorded. The counts are kept in the state directory and only leave this
+// computer when the user sends them to a reporting endpoint they've set, with
+// 'usage report'.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokeapi"
+)
+
+// usageReportTimeout is how long sending the usage counts may take.
+const usageReportTimeout = 10 * time.Second
+
+// usageCounts is how many times each command has been run since counting began.
+type usageCounts struct {
+	Since    time.Time      `json:"since"`    // When counting began, or the counts were last cleared
+	Commands map[string]int `json:"commands"` // The number of times each command was run, by name
+}
+
+// usageReport is the body sent to a reporting endpoint by 'usage report'.
+type usageReport struct {
+	Version  string         `json:"version"`  // The application version
+	Since    time.Time      `json:"since"`    // When counting began
+	Commands map[string]int `json:"commands"` // The number of times each command was run, by name
+}
+
+// readUsage reads the usage counts. Missing counts are empty, starting now.
+//
+// Parameters:
+//   - path: The path of the usage file
+//   - now: The time counting begins, if it hasn't yet
+//
+// Returns:
+//   - The usage counts
+//   - An error if the file exists but can't be read
+func readUsage(path string, now time.Time) (usageCounts, error) {
+	counts := usageCounts{Since: now, Commands: map[string]int{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return counts, nil
+	}
+	if err != nil {
+		return counts, err
+	}
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return counts, fmt.Errorf("error reading usage counts: %w", err)
+	}
+	if counts.Commands == nil {
+		counts.Commands = map[string]int{}
+	}
+	return counts, nil
+}
+
+// writeUsage writes the usage counts, replacing any already written.
+func writeUsage(path string, counts usageCounts) error {
+	data, err := json.MarshalIndent(counts, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// recordUsage counts a run of a command, if counting is on. The counts are
+// only for the user's interest, so a failure to write them is only reported
+// in debug mode.
+//
+// Parameters:
+//   - cfg: The application configuration
+//   - command: The name of the command that was run
+func recordUsage(cfg *config, command string) {
+	current := cfg.Settings()
+	if !current.usageTracking {
+		return
+	}
+	path := usageFile.path()
+	counts, err := readUsage(path, time.Now())
+	if err == nil {
+		counts.Commands[command]++
+		err = writeUsage(path, counts)
+	}
+	if err != nil && current.debugMode {
+		log.Printf("Could not record command usage: %v", err)
+	}
+}
+
+// sendUsageReport posts the usage counts to a reporting endpoint as JSON.
+//
+// Parameters:
+//   - ctx: Context for cancelling the request
+//   - endpoint: The URL to post the counts to
+//   - counts: The usage counts
+//
+// Returns:
+//   - An error if the request fails or the endpoint doesn't accept the counts
+func sendUsageReport(ctx context.Context, endpoint string, counts usageCounts) error {
+	body, err := json.Marshal(usageReport{Version: appVersion(), Since: counts.Since, Commands: counts.Commands})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, usageReportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", pokeapi.UserAgent(appVersion()))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+	return nil
+}
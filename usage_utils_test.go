@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bmlevitt/pokedexcli/internal/pokedex"
+)
+
+// TestRecordUsage tests that commands are only counted once counting is turned
+// on, and that only their names are recorded
+func TestRecordUsage(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+	command := cliCommand{name: "catch", callback: func(*config, []string) error { return nil }}
+
+	if err := executeCommand(cfg, command, nil); err != nil {
+		t.Fatalf("Failed to run the command: %v", err)
+	}
+	if _, err := os.Stat(usageFile.path()); !os.IsNotExist(err) {
+		t.Fatalf("Expected nothing to be counted while counting is off, got %v", err)
+	}
+
+	cfg.UpdateSettings(func(s *settings) { s.usageTracking = true })
+	for range 2 {
+		if err := executeCommand(cfg, command, []string{"pikachu"}); err != nil {
+			t.Fatalf("Failed to run the command: %v", err)
+		}
+	}
+	data, err := os.ReadFile(usageFile.path())
+	if err != nil {
+		t.Fatalf("Failed to read the usage counts: %v", err)
+	}
+	var counts usageCounts
+	if err := json.Unmarshal(data, &counts); err != nil {
+		t.Fatalf("Failed to decode the usage counts: %v", err)
+	}
+	if len(counts.Commands) != 1 || counts.Commands["catch"] != 2 {
+		t.Errorf("Expected catch to be counted twice, got %v", counts.Commands)
+	}
+}
+
+// TestSendUsageReport tests the counts sent to a reporting endpoint
+func TestSendUsageReport(t *testing.T) {
+	var received usageReport
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	counts := usageCounts{Since: since, Commands: map[string]int{"catch": 3}}
+	if err := sendUsageReport(context.Background(), server.URL, counts); err != nil {
+		t.Fatalf("Failed to send the report: %v", err)
+	}
+	if received.Commands["catch"] != 3 || !received.Since.Equal(since) || received.Version == "" {
+		t.Errorf("Unexpected report: %+v", received)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	if err := sendUsageReport(context.Background(), server.URL, counts); err == nil {
+		t.Error("Expected an error when the endpoint refuses the report")
+	}
+}
+
+// TestUsageEndpointCase tests that the endpoint typed at the prompt is saved
+// with its capitalization, while the subcommand matches in any case
+func TestUsageEndpointCase(t *testing.T) {
+	useTempHome(t)
+	cfg := &config{pokedex: pokedex.New(), settings: defaultSettings()}
+
+	name, params := splitCommandLine("usage Endpoint https://stats.example.com/Collect?token=AbC")
+	if err := getCommands()[name].callback(cfg, params); err != nil {
+		t.Fatalf("usage returned an error: %v", err)
+	}
+	if got, want := cfg.Settings().usageEndpoint, "https://stats.example.com/Collect?token=AbC"; got != want {
+		t.Errorf("Expected the endpoint %q, got %q", want, got)
+	}
+}
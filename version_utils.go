@@ -0,0 +1,50 @@
+// This file contains information about the main-series Pokémon games.
+// The PokeAPI only links game versions to generations through version groups,
+// so the generation of each version is listed here to avoid fetching a version
+// group for every Pokédex entry.
+package main
+
+import (
+	"github.com/bmlevitt/pokedexcli/internal/i18n"
+)
+
+// versionGenerations maps game version names, in API format, to the generation they belong to.
+var versionGenerations = map[string]int{
+	"red": 1, "blue": 1, "yellow": 1,
+	"gold": 2, "silver": 2, "crystal": 2,
+	"ruby": 3, "sapphire": 3, "emerald": 3, "firered": 3, "leafgreen": 3, "colosseum": 3, "xd": 3,
+	"diamond": 4, "pearl": 4, "platinum": 4, "heartgold": 4, "soulsilver": 4,
+	"black": 5, "white": 5, "black-2": 5, "white-2": 5,
+	"x": 6, "y": 6, "omega-ruby": 6, "alpha-sapphire": 6,
+	"sun": 7, "moon": 7, "ultra-sun": 7, "ultra-moon": 7, "lets-go-pikachu": 7, "lets-go-eevee": 7,
+	"sword": 8, "shield": 8, "the-isle-of-armor": 8, "the-crown-tundra": 8,
+	"brilliant-diamond": 8, "shining-pearl": 8, "legends-arceus": 8,
+	"scarlet": 9, "violet": 9, "the-teal-mask": 9, "the-indigo-disk": 9,
+}
+
+// generationNumerals holds the Roman numerals used in generation names, indexed by generation.
+var generationNumerals = []string{"", "I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX"}
+
+// versionGeneration returns the generation a game version belongs to.
+//
+// Parameters:
+//   - version: The version name in API format (e.g. "firered")
+//
+// Returns:
+//   - The generation number, or 0 if the version is not known
+func versionGeneration(version string) int {
+	return versionGenerations[version]
+}
+
+// generationDisplayName formats a generation number for display (e.g. "Generation III").
+// Unknown generations (0) are shown as "Other games".
+func generationDisplayName(generation int) string {
+	switch {
+	case generation <= 0:
+		return i18n.T("Other games")
+	case generation < len(generationNumerals):
+		return i18n.Sprintf("Generation %s", generationNumerals[generation])
+	default:
+		return i18n.Sprintf("Generation %d", generation)
+	}
+}